@@ -0,0 +1,125 @@
+package builders
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArtifactsDir is the build-context directory holding release downloads
+// prefetched on the host (see DockerfileGeneratorOptions.PrefetchArtifacts).
+// Each tool has a subdirectory, and each file is named by its SHA256 digest
+// so a builder stage picks the one for its own architecture.
+const ArtifactsDir = "artifacts"
+
+// PinnedArtifact is a release download a builder stage verifies against a
+// checksum pinned in checksums.go.
+type PinnedArtifact struct {
+	Tool   string // Subdirectory of ArtifactsDir
+	URL    string
+	SHA256 string
+}
+
+// PinnedArtifacts lists the builder-stage downloads for a Go architecture
+// (amd64 or arm64). Other architectures have no pinned downloads.
+func PinnedArtifacts(goarch string) []PinnedArtifact {
+	if goarch != "arm64" && goarch != "amd64" {
+		return nil
+	}
+	pick := func(arm64, amd64 string) string {
+		if goarch == "arm64" {
+			return arm64
+		}
+		return amd64
+	}
+
+	return []PinnedArtifact{
+		{
+			Tool: "neovim",
+			URL: fmt.Sprintf("https://github.com/neovim/neovim/releases/download/v%s/%s.tar.gz",
+				neovimVersion, pick("nvim-linux-arm64", "nvim-linux-x86_64")),
+			SHA256: pick(neovimTarballChecksumArm64, neovimTarballChecksumX86_64),
+		},
+		{
+			Tool: "lazygit",
+			URL: fmt.Sprintf("https://github.com/jesseduffield/lazygit/releases/download/v%s/lazygit_%s_Linux_%s.tar.gz",
+				lazygitVersion, lazygitVersion, pick("arm64", "x86_64")),
+			SHA256: pick(lazygitChecksumArm64, lazygitChecksumX86_64),
+		},
+		{
+			Tool: "starship",
+			URL: fmt.Sprintf("https://github.com/starship/starship/releases/download/v%s/starship-%s.tar.gz",
+				starshipVersion, pick("aarch64-unknown-linux-musl", "x86_64-unknown-linux-musl")),
+			SHA256: pick(starshipChecksumArm64, starshipChecksumX86_64),
+		},
+		{
+			Tool: "tree-sitter",
+			URL: fmt.Sprintf("https://github.com/tree-sitter/tree-sitter/releases/download/v%s/tree-sitter-%s.gz",
+				treeSitterVersion, pick("linux-arm64", "linux-x64")),
+			SHA256: pick(treeSitterChecksumArm64, treeSitterChecksumX86_64),
+		},
+		{
+			Tool: "opencode",
+			URL: fmt.Sprintf("https://github.com/anomalyco/opencode/releases/download/v%s/opencode-linux-%s-musl.tar.gz",
+				opencodeVersion, pick("arm64", "x64")),
+			SHA256: pick(opencodeChecksumArm64, opencodeChecksumAmd64),
+		},
+		{
+			Tool:   "kubectl",
+			URL:    fmt.Sprintf("https://dl.k8s.io/release/v%s/bin/linux/%s/kubectl", kubectlVersion, goarch),
+			SHA256: pick(kubectlChecksumArm64, kubectlChecksumAmd64),
+		},
+		{
+			Tool:   "helm",
+			URL:    fmt.Sprintf("https://get.helm.sh/helm-v%s-linux-%s.tar.gz", helmVersion, goarch),
+			SHA256: pick(helmChecksumArm64, helmChecksumAmd64),
+		},
+		{
+			Tool: "kustomize",
+			URL: fmt.Sprintf("https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/v%s/kustomize_v%s_linux_%s.tar.gz",
+				kustomizeVersion, kustomizeVersion, goarch),
+			SHA256: pick(kustomizeChecksumArm64, kustomizeChecksumAmd64),
+		},
+		{
+			Tool:   "argocd",
+			URL:    fmt.Sprintf("https://github.com/argoproj/argo-cd/releases/download/v%s/argocd-linux-%s", argocdVersion, goarch),
+			SHA256: pick(argocdChecksumArm64, argocdChecksumAmd64),
+		},
+	}
+}
+
+// ReferencedArtifacts returns the pinned downloads a Dockerfile generated
+// with PrefetchArtifacts copies into its builder stages.
+func ReferencedArtifacts(dockerfile, goarch string) []PinnedArtifact {
+	var referenced []PinnedArtifact
+	for _, a := range PinnedArtifacts(goarch) {
+		if strings.Contains(dockerfile, artifactCopyLine(a.Tool)) {
+			referenced = append(referenced, a)
+		}
+	}
+	return referenced
+}
+
+func artifactCopyLine(tool string) string {
+	return fmt.Sprintf("COPY %s/%s/ /tmp/%s/\n", ArtifactsDir, tool, ArtifactsDir)
+}
+
+// artifactCopy returns the COPY instruction that brings a tool's prefetched
+// downloads into its builder stage, or "" when nothing is prefetched.
+func (g *DefaultDockerfileGenerator) artifactCopy(tool string) string {
+	if !g.prefetchArtifacts {
+		return ""
+	}
+	return artifactCopyLine(tool)
+}
+
+// download returns the shell command that saves url to out. With prefetched
+// artifacts the file whose name is the digest in shaVar is used when present,
+// falling back to curl; the caller's sha256sum check runs either way.
+func (g *DefaultDockerfileGenerator) download(out, url, shaVar string) string {
+	curl := fmt.Sprintf("curl %s -o %s \"%s\"", curlFlags, out, url)
+	if !g.prefetchArtifacts {
+		return curl
+	}
+	cached := fmt.Sprintf("\"/tmp/%s/${%s}\"", ArtifactsDir, shaVar)
+	return fmt.Sprintf("if [ -f %s ]; then cp %s %s; else %s; fi", cached, cached, out, curl)
+}
@@ -0,0 +1,88 @@
+//go:build !integration
+
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/utils/appkind"
+	"github.com/rmkohlman/MaestroSDK/paths"
+)
+
+func TestPinnedArtifacts(t *testing.T) {
+	for _, goarch := range []string{"amd64", "arm64"} {
+		artifacts := PinnedArtifacts(goarch)
+		if len(artifacts) == 0 {
+			t.Fatalf("PinnedArtifacts(%q) is empty", goarch)
+		}
+		for _, a := range artifacts {
+			if len(a.SHA256) != 64 {
+				t.Errorf("%s/%s: SHA256 %q is not a hex digest", goarch, a.Tool, a.SHA256)
+			}
+			if strings.Contains(a.URL, "${") {
+				t.Errorf("%s/%s: URL %q has an unexpanded shell variable", goarch, a.Tool, a.URL)
+			}
+		}
+	}
+
+	arm := PinnedArtifacts("arm64")
+	if arm[0].Tool != "neovim" || arm[0].SHA256 != neovimTarballChecksumArm64 ||
+		!strings.HasSuffix(arm[0].URL, "/nvim-linux-arm64.tar.gz") {
+		t.Errorf("arm64 neovim artifact = %+v", arm[0])
+	}
+	if got := PinnedArtifacts("riscv64"); got != nil {
+		t.Errorf("PinnedArtifacts(riscv64) = %v, want nil", got)
+	}
+}
+
+func TestDockerfileGenerator_PrefetchArtifacts(t *testing.T) {
+	generate := func(prefetch bool) string {
+		t.Helper()
+		gen := NewDockerfileGenerator(DockerfileGeneratorOptions{
+			Workspace:         &models.Workspace{ID: 1, Name: "cicd-ws", ImageName: "cicd:latest"},
+			AppKind:           string(appkind.KindCICD),
+			AppPath:           "/tmp/cicd-test",
+			PathConfig:        paths.New(t.TempDir()),
+			PrefetchArtifacts: prefetch,
+		})
+		dockerfile, err := gen.Generate()
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		return dockerfile
+	}
+
+	plain := generate(false)
+	if strings.Contains(plain, "COPY artifacts/") || strings.Contains(plain, "/tmp/artifacts/") {
+		t.Error("Dockerfile without prefetching must not reference prefetched artifacts")
+	}
+	if refs := ReferencedArtifacts(plain, "amd64"); len(refs) != 0 {
+		t.Errorf("ReferencedArtifacts(plain) = %v, want none", refs)
+	}
+
+	prefetched := generate(true)
+	for _, want := range []string{
+		"FROM " + pinnedImage("alpine:3.20") + " AS kubectl-builder\nCOPY artifacts/kubectl/ /tmp/artifacts/\n",
+		`if [ -f "/tmp/artifacts/${K_SHA256}" ]; then cp "/tmp/artifacts/${K_SHA256}" /tmp/kubectl; else curl ` + curlFlags + ` -o /tmp/kubectl "https://dl.k8s.io/`,
+		`echo "${K_SHA256}  /tmp/kubectl" | sha256sum -c -`,
+	} {
+		if !strings.Contains(prefetched, want) {
+			t.Errorf("prefetching Dockerfile is missing %q", want)
+		}
+	}
+
+	tools := map[string]bool{}
+	for _, a := range ReferencedArtifacts(prefetched, "amd64") {
+		tools[a.Tool] = true
+	}
+	for _, tool := range []string{"kubectl", "helm", "kustomize", "starship"} {
+		if !tools[tool] {
+			t.Errorf("ReferencedArtifacts is missing %s (got %v)", tool, tools)
+		}
+	}
+	if tools["argocd"] {
+		t.Error("argocd is only built when .argocd/ is detected")
+	}
+}
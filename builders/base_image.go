@@ -0,0 +1,135 @@
+package builders
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/utils"
+)
+
+// BaseImageLanguages lists the languages with shared base images, by the
+// language keys the detector uses.
+var BaseImageLanguages = []string{"golang", "nodejs", "python"}
+
+// BaseImageLanguage returns the language key for name, which may be an alias
+// such as "go" or "node", and whether the language has shared base images.
+func BaseImageLanguage(name string) (string, bool) {
+	language := strings.ToLower(name)
+	if canonical, ok := languageAliases[language]; ok {
+		language = canonical
+	}
+	return language, slices.Contains(BaseImageLanguages, language)
+}
+
+// BaseImageVersion returns the language version a base image is built for:
+// version, or the version workspaces of the language default to.
+func BaseImageVersion(language, version string) string {
+	g := &DefaultDockerfileGenerator{language: language, version: version}
+	return g.effectiveVersion()
+}
+
+// BaseImageName returns the local tag of the base image for a language
+// version, e.g. "dvm-base-golang:1.22".
+func BaseImageName(language, version string) string {
+	return fmt.Sprintf("dvm-base-%s:%s", language, version)
+}
+
+// BaseImageRepository returns the path of the base image for a language
+// version in the local registry, e.g. "dvm-base/golang:1.22".
+func BaseImageRepository(language, version string) string {
+	return fmt.Sprintf("dvm-base/%s:%s", language, version)
+}
+
+// GenerateBaseImageDockerfile returns the Dockerfile of the shared base image
+// for a language version: the language image plus the dev tooling every
+// workspace of the language gets with the default spec (Neovim and its
+// dependencies, lazygit, starship, tree-sitter, the default packages, and the
+// default language tools). A workspace built on it (see
+// DockerfileGeneratorOptions.BaseImage) skips installing them again.
+func GenerateBaseImageDockerfile(language, version string) (string, error) {
+	language, ok := BaseImageLanguage(language)
+	if !ok {
+		return "", fmt.Errorf("no base image for language %q (supported: %s)", language, strings.Join(BaseImageLanguages, ", "))
+	}
+	g := &DefaultDockerfileGenerator{
+		workspace: &models.Workspace{Name: "base", Slug: "dvm-base-" + language},
+		language:  language,
+		version:   version,
+	}
+
+	var dockerfile strings.Builder
+	dockerfile.WriteString("# syntax=docker/dockerfile:1\n")
+	dockerfile.WriteString("# Generated by DevOpsMaestro\n")
+	dockerfile.WriteString(fmt.Sprintf("# Shared %s %s base image for workspace builds (dvm base-image build)\n\n", language, g.effectiveVersion()))
+
+	g.generateBaseStage(&dockerfile, &utils.PrivateRepoInfo{})
+	stages := g.activeBuilderStages()
+	g.emitBuilderStages(&dockerfile, stages)
+
+	dockerfile.WriteString("# Dev tooling shared by every workspace of the language\n")
+	dockerfile.WriteString("FROM base AS tools\n\n")
+	dockerfile.WriteString("USER root\n\n")
+	g.emitAPTTimeoutConfig(&dockerfile)
+	g.emitProxyHealthCheck(&dockerfile)
+	g.emitCopyFromBuilders(&dockerfile, stages)
+	g.generateDevStage(&dockerfile)
+
+	return dockerfile.String(), nil
+}
+
+// usesBaseImage reports whether the workspace builds on a shared base image.
+func (g *DefaultDockerfileGenerator) usesBaseImage() bool {
+	if g.baseImage == "" || g.appKind == "cicd" {
+		return false
+	}
+	_, ok := BaseImageLanguage(g.language)
+	return ok
+}
+
+// baseImageGenerator returns a generator for what the shared base image of
+// the workspace's language version contains, to tell what to skip.
+func (g *DefaultDockerfileGenerator) baseImageGenerator() *DefaultDockerfileGenerator {
+	return &DefaultDockerfileGenerator{
+		workspace: &models.Workspace{Name: "base", Slug: "dvm-base-" + g.language},
+		language:  g.language,
+		version:   g.effectiveVersion(),
+		isAlpine:  g.isAlpine,
+	}
+}
+
+// withoutBaseImageStages drops the builder stages whose binaries the shared
+// base image already contains. The Go tools builder is kept when the
+// workspace installs other tools than the defaults.
+func (g *DefaultDockerfileGenerator) withoutBaseImageStages(stages []builderStage) []builderStage {
+	base := g.baseImageGenerator()
+	provided := make(map[string]bool)
+	for _, stage := range base.activeBuilderStages() {
+		provided[stage.name] = true
+	}
+	if !slices.Equal(g.getGoToolsList(), base.getGoToolsList()) {
+		delete(provided, "go-tools-builder")
+	}
+
+	kept := stages[:0:0]
+	for _, stage := range stages {
+		if !provided[stage.name] {
+			kept = append(kept, stage)
+		}
+	}
+	return kept
+}
+
+// withoutBaseImagePackages drops the packages the shared base image already
+// installs.
+func (g *DefaultDockerfileGenerator) withoutBaseImagePackages(packages []string) []string {
+	installed := g.baseImageGenerator().devStagePackages()
+	var missing []string
+	for _, pkg := range packages {
+		if !slices.Contains(installed, pkg) {
+			missing = append(missing, pkg)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,132 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/paths"
+)
+
+func generateOnBaseImage(t *testing.T, language string, spec models.WorkspaceSpec, baseImage string) string {
+	t.Helper()
+	gen := NewDockerfileGenerator(DockerfileGeneratorOptions{
+		Workspace:     &models.Workspace{ID: 1, Name: "dev", Slug: "eco-dom-app-dev"},
+		WorkspaceSpec: spec,
+		Language:      language,
+		AppPath:       t.TempDir(),
+		PathConfig:    paths.New(t.TempDir()),
+		BaseImage:     baseImage,
+	})
+	dockerfile, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	return dockerfile
+}
+
+func TestBaseImageLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		wantBase bool
+	}{
+		{"golang", "golang", true},
+		{"go", "golang", true},
+		{"Node", "nodejs", true},
+		{"py", "python", true},
+		{"rust", "rust", false},
+	}
+	for _, tt := range tests {
+		got, ok := BaseImageLanguage(tt.name)
+		if got != tt.want || ok != tt.wantBase {
+			t.Errorf("BaseImageLanguage(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantBase)
+		}
+	}
+
+	if got := BaseImageVersion("golang", ""); got != "1.22" {
+		t.Errorf("BaseImageVersion(golang, \"\") = %q, want the default 1.22", got)
+	}
+	if got := BaseImageName("python", "3.12"); got != "dvm-base-python:3.12" {
+		t.Errorf("BaseImageName() = %q", got)
+	}
+}
+
+func TestGenerateBaseImageDockerfile(t *testing.T) {
+	dockerfile, err := GenerateBaseImageDockerfile("go", "1.23")
+	if err != nil {
+		t.Fatalf("GenerateBaseImageDockerfile() error = %v", err)
+	}
+	assertInOrder(t, dockerfile,
+		"golang:1.23-alpine",
+		"AS lazygit-builder",
+		"AS go-tools-builder",
+		"FROM base AS tools",
+		"COPY --from=lazygit-builder",
+		"apk add",
+		"neovim",
+	)
+	for _, workspaceOnly := range []string{"useradd", "adduser", "CMD [", "FROM base AS dev"} {
+		if strings.Contains(dockerfile, workspaceOnly) {
+			t.Errorf("base image Dockerfile contains workspace-only %q", workspaceOnly)
+		}
+	}
+
+	if _, err := GenerateBaseImageDockerfile("rust", ""); err == nil {
+		t.Error("GenerateBaseImageDockerfile(rust) error = nil, want unsupported language")
+	}
+}
+
+func TestDockerfileGenerator_BaseImage(t *testing.T) {
+	const ref = "localhost:5001/dvm-base/python:3.11"
+	dockerfile := generateOnBaseImage(t, "python", models.WorkspaceSpec{}, ref)
+
+	if !strings.Contains(dockerfile, "FROM "+ref+" AS base\n") {
+		t.Errorf("base stage doesn't start from the base image:\n%s", dockerfile)
+	}
+	for _, provided := range []string{
+		"AS neovim-builder",
+		"AS lazygit-builder",
+		"AS starship-builder",
+		"AS treesitter-builder",
+		"deb.nodesource.com",
+		"npm install -g neovim",
+		"python-lsp-server",
+		"fd-find",
+	} {
+		if strings.Contains(dockerfile, provided) {
+			t.Errorf("Dockerfile on a base image still installs %q", provided)
+		}
+	}
+	// The workspace's own parts remain
+	assertInOrder(t, dockerfile, "FROM base AS dev", "useradd", "USER dev", "CMD [")
+}
+
+func TestDockerfileGenerator_BaseImage_WorkspaceExtras(t *testing.T) {
+	spec := models.WorkspaceSpec{}
+	spec.Build.DevStage.Packages = []string{"git", "jq"}
+	spec.Build.DevStage.DevTools = []string{"gopls", "staticcheck"}
+	dockerfile := generateOnBaseImage(t, "golang", spec, "dvm-base-golang:1.22")
+
+	// Only what the base image lacks is installed
+	if !strings.Contains(dockerfile, "    jq\n") {
+		t.Errorf("extra package jq not installed:\n%s", dockerfile)
+	}
+	if strings.Contains(dockerfile, "    git \\\n") {
+		t.Error("package git from the base image installed again")
+	}
+	// Non-default tools keep the Go tools builder
+	if !strings.Contains(dockerfile, "AS go-tools-builder") || !strings.Contains(dockerfile, "staticcheck") {
+		t.Error("custom Go tools not built")
+	}
+	if strings.Contains(dockerfile, "AS lazygit-builder") {
+		t.Error("lazygit builder emitted on a base image")
+	}
+}
+
+func TestDockerfileGenerator_BaseImage_UnsupportedLanguage(t *testing.T) {
+	dockerfile := generateOnBaseImage(t, "rust", models.WorkspaceSpec{}, "dvm-base-rust:1")
+	if strings.Contains(dockerfile, "dvm-base-rust") {
+		t.Error("base image used for a language without base images")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"devopsmaestro/operators"
+
 	"github.com/rmkohlman/MaestroSDK/render"
 
 	"github.com/containerd/containerd/v2/client"
@@ -148,6 +150,13 @@ func (b *BuildKitBuilder) Build(ctx context.Context, opts BuildOptions) error {
 		solveOpts.FrontendAttrs["build-arg:"+key] = value
 	}
 
+	// Add image labels
+	solveOpts.FrontendAttrs["label:"+operators.LabelNamespace] = b.namespace
+	solveOpts.FrontendAttrs["label:"+operators.LabelManaged] = "true"
+	for key, value := range opts.Labels {
+		solveOpts.FrontendAttrs["label:"+key] = value
+	}
+
 	// Add cache import if specified (e.g., "type=registry,ref=localhost:5001/dvm-cache/img")
 	// Multiple sources can be separated by newlines (#383).
 	// Pre-check registry connectivity to avoid build failures when cache
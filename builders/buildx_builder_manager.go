@@ -1,6 +1,7 @@
 package builders
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"log/slog"
@@ -16,8 +17,10 @@ const dvmBuilderName = "dvm-builder"
 // config, it is recreated. If no config path is given, returns empty string
 // (use default builder).
 //
+// The docker commands it runs are killed if ctx is cancelled.
+//
 // Returns the builder name to use with --builder, or empty string for default.
-func EnsureDVMBuilder(configPath string, dockerHost string) string {
+func EnsureDVMBuilder(ctx context.Context, configPath string, dockerHost string) string {
 	if configPath == "" {
 		return ""
 	}
@@ -29,16 +32,16 @@ func EnsureDVMBuilder(configPath string, dockerHost string) string {
 	}
 
 	// Check if builder already exists with correct config
-	if builderHasConfig(configPath, dockerHost) {
+	if builderHasConfig(ctx, configPath, dockerHost) {
 		slog.Debug("dvm-builder already exists with correct config")
 		return dvmBuilderName
 	}
 
 	// Remove existing builder if it exists (config mismatch)
-	removeDVMBuilder(dockerHost)
+	removeDVMBuilder(ctx, dockerHost)
 
 	// Create new builder with config
-	if err := createDVMBuilder(configPath, dockerHost); err != nil {
+	if err := createDVMBuilder(ctx, configPath, dockerHost); err != nil {
 		slog.Warn("failed to create dvm-builder, using default builder", "error", err)
 		return ""
 	}
@@ -48,10 +51,10 @@ func EnsureDVMBuilder(configPath string, dockerHost string) string {
 }
 
 // createDVMBuilder creates a new buildx builder with the given config.
-func createDVMBuilder(configPath string, dockerHost string) error {
+func createDVMBuilder(ctx context.Context, configPath string, dockerHost string) error {
 	args := []string{"buildx", "create", "--name", dvmBuilderName,
 		"--driver", "docker-container", "--config", configPath}
-	cmd := exec.Command("docker", args...)
+	cmd := exec.CommandContext(ctx, "docker", args...)
 	if dockerHost != "" {
 		cmd.Env = append(os.Environ(), "DOCKER_HOST="+dockerHost)
 	}
@@ -63,8 +66,8 @@ func createDVMBuilder(configPath string, dockerHost string) error {
 }
 
 // removeDVMBuilder removes the dvm-builder if it exists.
-func removeDVMBuilder(dockerHost string) {
-	cmd := exec.Command("docker", "buildx", "rm", dvmBuilderName)
+func removeDVMBuilder(ctx context.Context, dockerHost string) {
+	cmd := exec.CommandContext(ctx, "docker", "buildx", "rm", dvmBuilderName)
 	if dockerHost != "" {
 		cmd.Env = append(os.Environ(), "DOCKER_HOST="+dockerHost)
 	}
@@ -73,9 +76,9 @@ func removeDVMBuilder(dockerHost string) {
 
 // builderHasConfig checks if the dvm-builder exists and its config matches.
 // It compares the hash of the current config file with a stored hash marker.
-func builderHasConfig(configPath string, dockerHost string) bool {
+func builderHasConfig(ctx context.Context, configPath string, dockerHost string) bool {
 	// Check if builder exists
-	cmd := exec.Command("docker", "buildx", "inspect", dvmBuilderName)
+	cmd := exec.CommandContext(ctx, "docker", "buildx", "inspect", dvmBuilderName)
 	if dockerHost != "" {
 		cmd.Env = append(os.Environ(), "DOCKER_HOST="+dockerHost)
 	}
@@ -3,9 +3,11 @@ package builders
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"devopsmaestro/operators"
@@ -40,13 +42,13 @@ type DockerBuilder struct {
 // NewDockerBuilder creates a new Docker CLI-based image builder.
 func NewDockerBuilder(cfg BuilderConfig) (*DockerBuilder, error) {
 	// Verify we can connect to Docker
-	dockerHost := "unix://" + cfg.Platform.SocketPath
+	dockerHost := cfg.Platform.DockerHost()
 
 	cmd := exec.Command("docker", "info")
 	cmd.Env = append(os.Environ(), "DOCKER_HOST="+dockerHost)
 	if err := cmd.Run(); err != nil {
 		return nil, fmt.Errorf("failed to connect to Docker at %s: %w\n%s",
-			cfg.Platform.SocketPath, err, cfg.Platform.GetStartHint())
+			dockerHost, err, cfg.Platform.GetStartHint())
 	}
 
 	return &DockerBuilder{
@@ -65,15 +67,17 @@ func (b *DockerBuilder) Build(ctx context.Context, opts BuildOptions) error {
 
 	render.MsgTo(out, "", render.Message{Level: render.LevelProgress, Content: fmt.Sprintf("Building image: %s", b.imageName)})
 	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: fmt.Sprintf("Using Docker CLI (%s)", b.platform.Name)})
-	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: fmt.Sprintf("Socket: %s", b.platform.SocketPath)})
+	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: fmt.Sprintf("Host: %s", b.platform.DockerHost())})
 	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: ""})
 
 	// Build docker buildx build command (buildx supports --cache-from/--cache-to)
 	args := []string{"buildx", "build"}
 
-	// Use dvm-builder with registry mirror config if available
-	dockerHost := "unix://" + b.platform.SocketPath
-	if builderName := EnsureDVMBuilder(opts.BuildKitConfigPath, dockerHost); builderName != "" {
+	// Use dvm-builder with registry mirror config if available. The mirrors
+	// listen on localhost, so remote hosts build with their default builder.
+	if b.platform.IsRemote() {
+		render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: "Registry mirrors are not used on remote hosts"})
+	} else if builderName := EnsureDVMBuilder(ctx, opts.BuildKitConfigPath, b.platform.DockerHost()); builderName != "" {
 		args = append(args, "--builder", builderName)
 		render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: fmt.Sprintf("Builder: %s (registry mirrors enabled)", builderName)})
 		WriteConfigHash(opts.BuildKitConfigPath)
@@ -129,8 +133,11 @@ func (b *DockerBuilder) Build(ctx context.Context, opts BuildOptions) error {
 	}
 
 	// Add labels for namespace tracking
-	args = append(args, "--label", "io.devopsmaestro.namespace="+b.namespace)
-	args = append(args, "--label", "io.devopsmaestro.managed=true")
+	args = append(args, "--label", operators.LabelNamespace+"="+b.namespace)
+	args = append(args, "--label", operators.LabelManaged+"=true")
+	for _, key := range slices.Sorted(maps.Keys(opts.Labels)) {
+		args = append(args, "--label", key+"="+opts.Labels[key])
+	}
 
 	// Add image tag
 	args = append(args, "-t", b.imageName)
@@ -153,7 +160,7 @@ func (b *DockerBuilder) Build(ctx context.Context, opts BuildOptions) error {
 	// Prepare docker build command
 	cmd := exec.Command("docker", args...)
 	cmd.Dir = b.appPath
-	cmd.Env = append(os.Environ(), "DOCKER_HOST=unix://"+b.platform.SocketPath)
+	cmd.Env = append(os.Environ(), "DOCKER_HOST="+b.platform.DockerHost())
 	stdoutWriter := NewRedactingWriter(out, opts.BuildArgs)
 	stderrWriter := NewRedactingWriter(opts.StderrOrDiscard(), opts.BuildArgs)
 	cmd.Stdout = stdoutWriter
@@ -223,7 +230,7 @@ func (b *DockerBuilder) Build(ctx context.Context, opts BuildOptions) error {
 // ImageExists checks if an image already exists using docker CLI.
 func (b *DockerBuilder) ImageExists(ctx context.Context) (bool, error) {
 	cmd := exec.CommandContext(ctx, "docker", "images", "-q", b.imageName)
-	cmd.Env = append(os.Environ(), "DOCKER_HOST=unix://"+b.platform.SocketPath)
+	cmd.Env = append(os.Environ(), "DOCKER_HOST="+b.platform.DockerHost())
 
 	output, err := cmd.Output()
 	if err != nil {
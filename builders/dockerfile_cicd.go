@@ -37,7 +37,7 @@ func (g *DefaultDockerfileGenerator) generateCICDDockerfile() (string, error) {
 	// Base stage
 	baseImage := "alpine:3.20"
 	df.WriteString(pinnedImageComment(baseImage))
-	df.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+	g.emitBaseFrom(&df, baseImage)
 
 	// Required build args (proxy etc.) must be re-declared in this stage.
 	if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -80,6 +80,10 @@ func (g *DefaultDockerfileGenerator) generateCICDDockerfile() (string, error) {
 	df.WriteString("# Ensure workspace directory exists with correct ownership\n")
 	df.WriteString(fmt.Sprintf("RUN mkdir -p %s && chown %s:%s %s\n\n", workdir, user, user, workdir))
 
+	// CICD images have no Neovim, so postNvim runs right after postTools.
+	g.emitHook(&df, "postTools", g.hooks.PostTools)
+	g.emitHook(&df, "postNvim", g.hooks.PostNvim)
+
 	// Switch to non-root user — final USER directive in image
 	df.WriteString(fmt.Sprintf("USER %s\n\n", user))
 	df.WriteString(fmt.Sprintf("WORKDIR %s\n\n", workdir))
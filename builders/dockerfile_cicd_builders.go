@@ -20,6 +20,7 @@ import (
 func (g *DefaultDockerfileGenerator) generateKubectlBuilder(df *strings.Builder) {
 	df.WriteString("# --- Parallel builder: kubectl (pinned upstream + SHA256) ---\n")
 	df.WriteString(fmt.Sprintf("FROM %s AS kubectl-builder\n", pinnedImage("alpine:3.20")))
+	df.WriteString(g.artifactCopy("kubectl"))
 	df.WriteString(g.apkCacheMountsLocked())
 	df.WriteString("    set -e && \\\n")
 	df.WriteString("    apk add --no-cache curl ca-certificates && \\\n")
@@ -31,7 +32,7 @@ func (g *DefaultDockerfileGenerator) generateKubectlBuilder(df *strings.Builder)
 	df.WriteString("    else \\\n")
 	df.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	df.WriteString("    fi && \\\n")
-	df.WriteString(fmt.Sprintf("    curl %s -o /tmp/kubectl \"https://dl.k8s.io/release/v%s/bin/linux/${K_ARCH}/kubectl\" && \\\n", curlFlags, kubectlVersion))
+	df.WriteString("    " + g.download("/tmp/kubectl", fmt.Sprintf("https://dl.k8s.io/release/v%s/bin/linux/${K_ARCH}/kubectl", kubectlVersion), "K_SHA256") + " && \\\n")
 	df.WriteString("    echo \"${K_SHA256}  /tmp/kubectl\" | sha256sum -c - && \\\n")
 	df.WriteString("    install -m 0755 /tmp/kubectl /usr/local/bin/kubectl && \\\n")
 	df.WriteString("    rm /tmp/kubectl && \\\n")
@@ -43,6 +44,7 @@ func (g *DefaultDockerfileGenerator) generateKubectlBuilder(df *strings.Builder)
 func (g *DefaultDockerfileGenerator) generateHelmBuilder(df *strings.Builder) {
 	df.WriteString("# --- Parallel builder: helm (pinned upstream + SHA256) ---\n")
 	df.WriteString(fmt.Sprintf("FROM %s AS helm-builder\n", pinnedImage("alpine:3.20")))
+	df.WriteString(g.artifactCopy("helm"))
 	df.WriteString(g.apkCacheMountsLocked())
 	df.WriteString("    set -e && \\\n")
 	df.WriteString("    apk add --no-cache curl ca-certificates && \\\n")
@@ -54,7 +56,7 @@ func (g *DefaultDockerfileGenerator) generateHelmBuilder(df *strings.Builder) {
 	df.WriteString("    else \\\n")
 	df.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	df.WriteString("    fi && \\\n")
-	df.WriteString(fmt.Sprintf("    curl %s -o /tmp/helm.tgz \"https://get.helm.sh/helm-v%s-linux-${H_ARCH}.tar.gz\" && \\\n", curlFlags, helmVersion))
+	df.WriteString("    " + g.download("/tmp/helm.tgz", fmt.Sprintf("https://get.helm.sh/helm-v%s-linux-${H_ARCH}.tar.gz", helmVersion), "H_SHA256") + " && \\\n")
 	df.WriteString("    echo \"${H_SHA256}  /tmp/helm.tgz\" | sha256sum -c - && \\\n")
 	df.WriteString("    tar -C /tmp -xzf /tmp/helm.tgz && \\\n")
 	df.WriteString("    install -m 0755 /tmp/linux-${H_ARCH}/helm /usr/local/bin/helm && \\\n")
@@ -67,6 +69,7 @@ func (g *DefaultDockerfileGenerator) generateHelmBuilder(df *strings.Builder) {
 func (g *DefaultDockerfileGenerator) generateKustomizeBuilder(df *strings.Builder) {
 	df.WriteString("# --- Parallel builder: kustomize (pinned upstream + SHA256) ---\n")
 	df.WriteString(fmt.Sprintf("FROM %s AS kustomize-builder\n", pinnedImage("alpine:3.20")))
+	df.WriteString(g.artifactCopy("kustomize"))
 	df.WriteString(g.apkCacheMountsLocked())
 	df.WriteString("    set -e && \\\n")
 	df.WriteString("    apk add --no-cache curl ca-certificates && \\\n")
@@ -78,7 +81,7 @@ func (g *DefaultDockerfileGenerator) generateKustomizeBuilder(df *strings.Builde
 	df.WriteString("    else \\\n")
 	df.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	df.WriteString("    fi && \\\n")
-	df.WriteString(fmt.Sprintf("    curl %s -o /tmp/kustomize.tgz \"https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/v%s/kustomize_v%s_linux_${KU_ARCH}.tar.gz\" && \\\n", curlFlags, kustomizeVersion, kustomizeVersion))
+	df.WriteString("    " + g.download("/tmp/kustomize.tgz", fmt.Sprintf("https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize/v%s/kustomize_v%s_linux_${KU_ARCH}.tar.gz", kustomizeVersion, kustomizeVersion), "KU_SHA256") + " && \\\n")
 	df.WriteString("    echo \"${KU_SHA256}  /tmp/kustomize.tgz\" | sha256sum -c - && \\\n")
 	df.WriteString("    tar -C /tmp -xzf /tmp/kustomize.tgz kustomize && \\\n")
 	df.WriteString("    install -m 0755 /tmp/kustomize /usr/local/bin/kustomize && \\\n")
@@ -92,6 +95,7 @@ func (g *DefaultDockerfileGenerator) generateKustomizeBuilder(df *strings.Builde
 func (g *DefaultDockerfileGenerator) generateArgoCDBuilder(df *strings.Builder) {
 	df.WriteString("# --- Parallel builder: argocd CLI (pinned upstream + SHA256, conditional) ---\n")
 	df.WriteString(fmt.Sprintf("FROM %s AS argocd-builder\n", pinnedImage("alpine:3.20")))
+	df.WriteString(g.artifactCopy("argocd"))
 	df.WriteString(g.apkCacheMountsLocked())
 	df.WriteString("    set -e && \\\n")
 	df.WriteString("    apk add --no-cache curl ca-certificates && \\\n")
@@ -103,7 +107,7 @@ func (g *DefaultDockerfileGenerator) generateArgoCDBuilder(df *strings.Builder)
 	df.WriteString("    else \\\n")
 	df.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	df.WriteString("    fi && \\\n")
-	df.WriteString(fmt.Sprintf("    curl %s -o /tmp/argocd \"https://github.com/argoproj/argo-cd/releases/download/v%s/argocd-linux-${A_ARCH}\" && \\\n", curlFlags, argocdVersion))
+	df.WriteString("    " + g.download("/tmp/argocd", fmt.Sprintf("https://github.com/argoproj/argo-cd/releases/download/v%s/argocd-linux-${A_ARCH}", argocdVersion), "A_SHA256") + " && \\\n")
 	df.WriteString("    echo \"${A_SHA256}  /tmp/argocd\" | sha256sum -c - && \\\n")
 	df.WriteString("    install -m 0755 /tmp/argocd /usr/local/bin/argocd && \\\n")
 	df.WriteString("    rm /tmp/argocd && \\\n")
@@ -118,6 +122,7 @@ func (g *DefaultDockerfileGenerator) generateArgoCDBuilder(df *strings.Builder)
 func (g *DefaultDockerfileGenerator) generateStarshipBuilderAlpine(df *strings.Builder) {
 	df.WriteString("# --- Parallel builder: Starship prompt (Alpine) ---\n")
 	df.WriteString(fmt.Sprintf("FROM %s AS starship-builder\n", pinnedImage("alpine:3.20")))
+	df.WriteString(g.artifactCopy("starship"))
 	df.WriteString(g.apkCacheMountsLocked())
 	df.WriteString("    set -e && \\\n")
 	df.WriteString("    apk add --no-cache curl ca-certificates && \\\n")
@@ -129,7 +134,7 @@ func (g *DefaultDockerfileGenerator) generateStarshipBuilderAlpine(df *strings.B
 	df.WriteString("    else \\\n")
 	df.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	df.WriteString("    fi && \\\n")
-	df.WriteString(fmt.Sprintf("    curl %s -o /tmp/starship.tar.gz \"https://github.com/starship/starship/releases/download/v%s/starship-${STARSHIP_ARCH}.tar.gz\" && \\\n", curlFlags, starshipVersion))
+	df.WriteString("    " + g.download("/tmp/starship.tar.gz", fmt.Sprintf("https://github.com/starship/starship/releases/download/v%s/starship-${STARSHIP_ARCH}.tar.gz", starshipVersion), "STARSHIP_SHA256") + " && \\\n")
 	df.WriteString("    echo \"${STARSHIP_SHA256}  /tmp/starship.tar.gz\" | sha256sum -c - && \\\n")
 	df.WriteString("    tar -C /usr/local/bin -xzf /tmp/starship.tar.gz starship && \\\n")
 	df.WriteString("    rm /tmp/starship.tar.gz && \\\n")
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/terminalbridge/fonts"
 	"devopsmaestro/utils"
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroSDK/paths"
@@ -101,6 +102,7 @@ type DefaultDockerfileGenerator struct {
 	stagingDir          string // Explicit staging directory path (used for file existence checks)
 	baseDockerfile      string
 	pluginManifest      *plugin.PluginManifest
+	pluginFiletypes     []string // Filetypes the workspace's plugins lazy-load on (Treesitter provisioning)
 	pathConfig          *paths.PathConfig
 	isAlpine            bool // computed in generateBaseStage() based on the actual image chosen
 	privateRepoInfo     *utils.PrivateRepoInfo
@@ -108,6 +110,15 @@ type DefaultDockerfileGenerator struct {
 	// appKind drives top-level dispatch; see #404.
 	appKind        string
 	argoCDDetected bool
+	// prefetchArtifacts makes builder stages prefer release downloads the
+	// caller placed under ArtifactsDir in the build context.
+	prefetchArtifacts bool
+	// appHooks are the app's Dockerfile hooks; the workspace's own hooks
+	// (workspaceYAML.Build.Hooks) are spliced after them.
+	appHooks *models.DockerfileHooks
+	hooks    models.DockerfileHooks
+	// baseImage is the shared base image the base stage starts from.
+	baseImage string
 }
 
 // DockerfileGeneratorOptions contains all configuration for creating a DockerfileGenerator.
@@ -122,6 +133,7 @@ type DockerfileGeneratorOptions struct {
 	PathConfig          *paths.PathConfig      // Injected for filesystem operations (nil = fallback to os.UserHomeDir)
 	PrivateRepoInfo     *utils.PrivateRepoInfo // Injected for system dep detection (nil = auto-detect at build time)
 	AdditionalBuildArgs []string               // Extra ARG names to declare in the Dockerfile (names only, not values)
+	PluginFiletypes     []string               // Filetypes the workspace's plugins lazy-load on; their Treesitter parsers are installed at build time
 	// AppKind drives the top-level dispatch: KindCICD routes to generateCICDStage(),
 	// KindLanguage routes to the existing language switch, KindUnknown falls back to ubuntu.
 	// Populated by the caller after running utils/appkind.Detect(). See #404.
@@ -129,6 +141,19 @@ type DockerfileGeneratorOptions struct {
 	// ArgoCDDetected is true when .argocd/ directory is present in the source tree.
 	// When true, the KindCICD path includes the argocd CLI builder stage. See #404.
 	ArgoCDDetected bool
+	// PrefetchArtifacts makes builder stages COPY ArtifactsDir/<tool>/ from the
+	// build context and use a file there named by the pinned digest instead of
+	// downloading it. The caller must create the directory of every tool
+	// ReferencedArtifacts lists; missing files fall back to curl.
+	PrefetchArtifacts bool
+	// AppHooks are the Dockerfile hooks from the app's spec.build (with its
+	// build template applied). They are spliced before WorkspaceSpec.Build.Hooks.
+	AppHooks *models.DockerfileHooks
+	// BaseImage is the shared base image of the workspace's language version
+	// (see GenerateBaseImageDockerfile) to start the base stage from instead
+	// of the language image. Builder stages, packages, and tools it already
+	// contains are skipped. Ignored for languages without base images.
+	BaseImage string
 }
 
 // NewDockerfileGenerator creates a new Dockerfile generator.
@@ -145,8 +170,13 @@ func NewDockerfileGenerator(opts DockerfileGeneratorOptions) DockerfileGenerator
 		pathConfig:          opts.PathConfig,
 		privateRepoInfo:     opts.PrivateRepoInfo,
 		additionalBuildArgs: opts.AdditionalBuildArgs,
+		pluginFiletypes:     opts.PluginFiletypes,
 		appKind:             opts.AppKind,
 		argoCDDetected:      opts.ArgoCDDetected,
+		prefetchArtifacts:   opts.PrefetchArtifacts,
+		appHooks:            opts.AppHooks,
+		hooks:               models.MergeDockerfileHooks(opts.AppHooks, opts.WorkspaceSpec.Build.Hooks),
+		baseImage:           opts.BaseImage,
 	}
 }
 
@@ -162,6 +192,17 @@ func (g *DefaultDockerfileGenerator) Generate() (string, error) {
 	if g.workspace == nil {
 		return "", fmt.Errorf("workspace must not be nil")
 	}
+	if err := g.appHooks.Validate(); err != nil {
+		return "", fmt.Errorf("app build %w", err)
+	}
+	if err := g.workspaceYAML.Build.Hooks.Validate(); err != nil {
+		return "", fmt.Errorf("workspace build %w", err)
+	}
+	if font := g.workspaceYAML.Tools.NerdFont; font != "" {
+		if _, err := fonts.Get(font); err != nil {
+			return "", fmt.Errorf("workspace tools.nerdFont: %w", err)
+		}
+	}
 
 	// CICD apps (YAML/Helm/Kustomize/Argo/Flux) get a small Alpine image with
 	// pinned kubectl/helm/kustomize (and optional argocd) instead of the
@@ -232,11 +273,15 @@ func (g *DefaultDockerfileGenerator) Generate() (string, error) {
 	dockerfile.WriteString("# Ensure workspace directory exists with correct ownership\n")
 	dockerfile.WriteString(fmt.Sprintf("RUN mkdir -p %s && chown %s:%s %s\n\n", workdir, user, user, workdir))
 
+	g.emitHook(&dockerfile, "postTools", g.hooks.PostTools)
+
 	// Add Neovim configuration after user is created
 	if err := g.generateNvimSection(&dockerfile); err != nil {
 		return "", fmt.Errorf("nvim section: %w", err)
 	}
 
+	g.emitHook(&dockerfile, "postNvim", g.hooks.PostNvim)
+
 	// Switch to dev user
 	dockerfile.WriteString(fmt.Sprintf("USER %s\n\n", user))
 
@@ -255,6 +300,36 @@ func (g *DefaultDockerfileGenerator) Generate() (string, error) {
 	return dockerfile.String(), nil
 }
 
+// emitBaseFrom starts the base stage from the pinned baseImage, or the shared
+// base image built on it, followed by the preBase hook.
+func (g *DefaultDockerfileGenerator) emitBaseFrom(dockerfile *strings.Builder, baseImage string) {
+	if g.usesBaseImage() {
+		dockerfile.WriteString(fmt.Sprintf("# Shared base image: %s with dev tooling (dvm base-image build)\n", baseImage))
+		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", g.baseImage))
+	} else {
+		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+	}
+	g.emitHook(dockerfile, "preBase", g.hooks.PreBase)
+}
+
+// emitHook splices a spec.build.hooks snippet into the Dockerfile. Snippets
+// are validated by Generate; one that switches USER is followed by USER root.
+func (g *DefaultDockerfileGenerator) emitHook(dockerfile *strings.Builder, name, snippet string) {
+	if strings.TrimSpace(snippet) == "" {
+		return
+	}
+	dockerfile.WriteString(fmt.Sprintf("# Hook: %s (spec.build.hooks)\n", name))
+	dockerfile.WriteString(strings.TrimRight(snippet, "\n") + "\n")
+	instructions, _ := models.ParseDockerfileSnippet(snippet)
+	for _, instruction := range instructions {
+		if instruction == "USER" {
+			dockerfile.WriteString("USER root\n")
+			break
+		}
+	}
+	dockerfile.WriteString("\n")
+}
+
 func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Builder, privateRepoInfo *utils.PrivateRepoInfo) {
 	dockerfile.WriteString("# Base stage (auto-generated)\n")
 
@@ -264,7 +339,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("python:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Apply APT timeout + EOL sources fix before any apt-get (#393)
 		g.emitAPTTimeoutConfig(dockerfile)
@@ -365,7 +440,8 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 			reqCheckDir = sd
 		}
 		requirementsPath := filepath.Join(reqCheckDir, "requirements.txt")
-		if _, err := os.Stat(requirementsPath); err == nil {
+		// A generator without source (a shared base image) has no requirements
+		if _, err := os.Stat(requirementsPath); err == nil && reqCheckDir != "" {
 			switch privateRepoInfo.GitURLType {
 			case "https":
 				dockerfile.WriteString("# Install dependencies (pip expands ${VAR} from build args)\n")
@@ -418,7 +494,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = true
 		baseImage := fmt.Sprintf("golang:%s-alpine", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Emit additional build args (de-duplicated with RequiredBuildArgs)
 		g.emitAdditionalBuildArgs(dockerfile, privateRepoInfo.RequiredBuildArgs)
@@ -450,7 +526,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = true
 		baseImage := fmt.Sprintf("node:%s-alpine", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Emit additional build args (de-duplicated with RequiredBuildArgs)
 		g.emitAdditionalBuildArgs(dockerfile, privateRepoInfo.RequiredBuildArgs)
@@ -488,7 +564,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = true
 		baseImage := fmt.Sprintf("mcr.microsoft.com/dotnet/sdk:%s-alpine", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -530,7 +606,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = true
 		baseImage := fmt.Sprintf("php:%s-cli-alpine", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -598,7 +674,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("eclipse-temurin:%s-jdk-noble", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -644,7 +720,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("eclipse-temurin:%s-jdk-noble", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -703,7 +779,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("elixir:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -762,7 +838,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("swift:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -816,7 +892,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := "ubuntu:22.04"
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -877,7 +953,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("dart:%s", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -931,7 +1007,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := "ubuntu:22.04"
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -992,7 +1068,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("r-base:%s", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -1046,7 +1122,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("haskell:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -1108,7 +1184,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("perl:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -1165,7 +1241,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		g.isAlpine = false
 		baseImage := fmt.Sprintf("ruby:%s-slim", version)
 		dockerfile.WriteString(pinnedImageComment(baseImage))
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Declare build args after FROM so they're available in RUN commands
 		if len(privateRepoInfo.RequiredBuildArgs) > 0 {
@@ -1221,7 +1297,7 @@ func (g *DefaultDockerfileGenerator) generateBaseStage(dockerfile *strings.Build
 		// Generic Ubuntu base
 		g.isAlpine = false
 		baseImage := "ubuntu:22.04"
-		dockerfile.WriteString(fmt.Sprintf("FROM %s AS base\n\n", pinnedImage(baseImage)))
+		g.emitBaseFrom(dockerfile, baseImage)
 
 		// Emit additional build args (de-duplicated with RequiredBuildArgs)
 		g.emitAdditionalBuildArgs(dockerfile, privateRepoInfo.RequiredBuildArgs)
@@ -1337,6 +1413,23 @@ func (g *DefaultDockerfileGenerator) activeBuilderStages() []builderStage {
 		})
 	}
 
+	// Nerd Font builder (opt-in via workspace tools config)
+	// (Generate has already rejected names outside the catalog.)
+	if font, err := fonts.Get(g.workspaceYAML.Tools.NerdFont); err == nil {
+		stages = append(stages, builderStage{
+			name: "nerdfont-builder",
+			emitFunc: func(df *strings.Builder) {
+				g.generateNerdFontBuilder(df, font)
+			},
+			copyLines: []string{
+				"COPY --from=nerdfont-builder " + nerdFontDir + "/ " + nerdFontDir + "/",
+			},
+		})
+	}
+
+	if g.usesBaseImage() {
+		stages = g.withoutBaseImageStages(stages)
+	}
 	return stages
 }
 
@@ -1374,6 +1467,7 @@ func (g *DefaultDockerfileGenerator) emitBuilderStages(dockerfile *strings.Build
 func (g *DefaultDockerfileGenerator) generateNeovimBuilder(dockerfile *strings.Builder) {
 	dockerfile.WriteString("# --- Parallel builder: Neovim ---\n")
 	dockerfile.WriteString(fmt.Sprintf("FROM %s AS neovim-builder\n", pinnedImage("debian:bookworm-slim")))
+	dockerfile.WriteString(g.artifactCopy("neovim"))
 	dockerfile.WriteString(g.aptCacheMountsLocked())
 	dockerfile.WriteString("    set -e && \\\n")
 	dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \\\n")
@@ -1385,7 +1479,7 @@ func (g *DefaultDockerfileGenerator) generateNeovimBuilder(dockerfile *strings.B
 	dockerfile.WriteString("    else \\\n")
 	dockerfile.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	dockerfile.WriteString("    fi && \\\n")
-	dockerfile.WriteString(fmt.Sprintf("    curl %s -o \"${NVIM_ARCH}.tar.gz\" \"https://github.com/neovim/neovim/releases/download/v%s/${NVIM_ARCH}.tar.gz\" && \\\n", curlFlags, neovimVersion))
+	dockerfile.WriteString("    " + g.download("\"${NVIM_ARCH}.tar.gz\"", fmt.Sprintf("https://github.com/neovim/neovim/releases/download/v%s/${NVIM_ARCH}.tar.gz", neovimVersion), "NVIM_SHA256") + " && \\\n")
 	dockerfile.WriteString("    echo \"${NVIM_SHA256}  ${NVIM_ARCH}.tar.gz\" | sha256sum -c - && \\\n")
 	dockerfile.WriteString("    mkdir -p /opt/nvim && \\\n")
 	dockerfile.WriteString("    tar xzf \"${NVIM_ARCH}.tar.gz\" --strip-components=1 -C /opt/nvim && \\\n")
@@ -1423,6 +1517,7 @@ func (g *DefaultDockerfileGenerator) generateLazygitBuilder(dockerfile *strings.
 	dockerfile.WriteString("# --- Parallel builder: lazygit ---\n")
 	if isAlpine {
 		dockerfile.WriteString(fmt.Sprintf("FROM %s AS lazygit-builder\n", pinnedImage("alpine:3.20")))
+		dockerfile.WriteString(g.artifactCopy("lazygit"))
 		dockerfile.WriteString(g.apkCacheMountsLocked())
 		dockerfile.WriteString("    set -e && \\\n")
 		dockerfile.WriteString("    apk add --no-cache curl && \\\n")
@@ -1436,6 +1531,7 @@ func (g *DefaultDockerfileGenerator) generateLazygitBuilder(dockerfile *strings.
 		dockerfile.WriteString("    fi && \\\n")
 	} else {
 		dockerfile.WriteString(fmt.Sprintf("FROM %s AS lazygit-builder\n", pinnedImage("debian:bookworm-slim")))
+		dockerfile.WriteString(g.artifactCopy("lazygit"))
 		dockerfile.WriteString(g.aptCacheMountsLocked())
 		dockerfile.WriteString("    set -e && \\\n")
 		dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \\\n")
@@ -1449,7 +1545,7 @@ func (g *DefaultDockerfileGenerator) generateLazygitBuilder(dockerfile *strings.
 		dockerfile.WriteString("    fi && \\\n")
 	}
 	// Shared download + verify logic (identical for Alpine and Debian)
-	dockerfile.WriteString(fmt.Sprintf("    curl %s -o lazygit.tar.gz \"https://github.com/jesseduffield/lazygit/releases/download/v%s/lazygit_%s_Linux_${LG_ARCH}.tar.gz\" && \\\n", curlFlags, lazygitVersion, lazygitVersion))
+	dockerfile.WriteString("    " + g.download("lazygit.tar.gz", fmt.Sprintf("https://github.com/jesseduffield/lazygit/releases/download/v%s/lazygit_%s_Linux_${LG_ARCH}.tar.gz", lazygitVersion, lazygitVersion), "LG_SHA256") + " && \\\n")
 	dockerfile.WriteString("    echo \"${LG_SHA256}  lazygit.tar.gz\" | sha256sum -c - && \\\n")
 	dockerfile.WriteString("    tar xf lazygit.tar.gz lazygit && \\\n")
 	dockerfile.WriteString("    install lazygit /usr/local/bin && \\\n")
@@ -1463,6 +1559,7 @@ func (g *DefaultDockerfileGenerator) generateLazygitBuilder(dockerfile *strings.
 func (g *DefaultDockerfileGenerator) generateStarshipBuilder(dockerfile *strings.Builder) {
 	dockerfile.WriteString("# --- Parallel builder: Starship prompt ---\n")
 	dockerfile.WriteString(fmt.Sprintf("FROM %s AS starship-builder\n", pinnedImage("debian:bookworm-slim")))
+	dockerfile.WriteString(g.artifactCopy("starship"))
 	dockerfile.WriteString(g.aptCacheMountsLocked())
 	dockerfile.WriteString("    set -e && \\\n")
 	dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \\\n")
@@ -1474,7 +1571,7 @@ func (g *DefaultDockerfileGenerator) generateStarshipBuilder(dockerfile *strings
 	dockerfile.WriteString("    else \\\n")
 	dockerfile.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 	dockerfile.WriteString("    fi && \\\n")
-	dockerfile.WriteString(fmt.Sprintf("    curl %s -o /tmp/starship.tar.gz \"https://github.com/starship/starship/releases/download/v%s/starship-${STARSHIP_ARCH}.tar.gz\" && \\\n", curlFlags, starshipVersion))
+	dockerfile.WriteString("    " + g.download("/tmp/starship.tar.gz", fmt.Sprintf("https://github.com/starship/starship/releases/download/v%s/starship-${STARSHIP_ARCH}.tar.gz", starshipVersion), "STARSHIP_SHA256") + " && \\\n")
 	dockerfile.WriteString("    echo \"${STARSHIP_SHA256}  /tmp/starship.tar.gz\" | sha256sum -c - && \\\n")
 	dockerfile.WriteString("    tar -C /usr/local/bin -xzf /tmp/starship.tar.gz starship && \\\n")
 	dockerfile.WriteString("    rm /tmp/starship.tar.gz && \\\n")
@@ -1500,6 +1597,7 @@ func (g *DefaultDockerfileGenerator) generateTreeSitterBuilder(dockerfile *strin
 	} else {
 		// Debian: download pre-built binary from GitHub releases (see #348)
 		dockerfile.WriteString(fmt.Sprintf("FROM %s AS treesitter-builder\n", pinnedImage("debian:bookworm-slim")))
+		dockerfile.WriteString(g.artifactCopy("tree-sitter"))
 		dockerfile.WriteString(g.aptCacheMountsLocked())
 		dockerfile.WriteString("    set -e && \\\n")
 		dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \\\n")
@@ -1511,7 +1609,7 @@ func (g *DefaultDockerfileGenerator) generateTreeSitterBuilder(dockerfile *strin
 		dockerfile.WriteString("    else \\\n")
 		dockerfile.WriteString("        echo \"ERROR: Unsupported architecture: $ARCH\"; exit 1; \\\n")
 		dockerfile.WriteString("    fi && \\\n")
-		dockerfile.WriteString(fmt.Sprintf("    curl %s -o /tmp/tree-sitter.gz \"https://github.com/tree-sitter/tree-sitter/releases/download/v%s/tree-sitter-${TS_ARCH}.gz\" && \\\n", curlFlags, treeSitterVersion))
+		dockerfile.WriteString("    " + g.download("/tmp/tree-sitter.gz", fmt.Sprintf("https://github.com/tree-sitter/tree-sitter/releases/download/v%s/tree-sitter-${TS_ARCH}.gz", treeSitterVersion), "TS_SHA256") + " && \\\n")
 		dockerfile.WriteString("    echo \"${TS_SHA256}  /tmp/tree-sitter.gz\" | sha256sum -c - && \\\n")
 		dockerfile.WriteString("    gunzip /tmp/tree-sitter.gz && \\\n")
 		dockerfile.WriteString("    mv /tmp/tree-sitter /usr/local/bin/tree-sitter && \\\n")
@@ -1530,6 +1628,7 @@ func (g *DefaultDockerfileGenerator) generateOpencodeBuilder(dockerfile *strings
 	dockerfile.WriteString("# --- Parallel builder: opencode ---\n")
 	if isAlpine {
 		dockerfile.WriteString(fmt.Sprintf("FROM %s AS opencode-builder\n", pinnedImage("alpine:3.20")))
+		dockerfile.WriteString(g.artifactCopy("opencode"))
 		dockerfile.WriteString(g.apkCacheMountsLocked())
 		dockerfile.WriteString("    set -e && \\\n")
 		dockerfile.WriteString("    apk add --no-cache curl && \\\n")
@@ -1543,6 +1642,7 @@ func (g *DefaultDockerfileGenerator) generateOpencodeBuilder(dockerfile *strings
 		dockerfile.WriteString("    fi && \\\n")
 	} else {
 		dockerfile.WriteString(fmt.Sprintf("FROM %s AS opencode-builder\n", pinnedImage("debian:bookworm-slim")))
+		dockerfile.WriteString(g.artifactCopy("opencode"))
 		dockerfile.WriteString(g.aptCacheMountsLocked())
 		dockerfile.WriteString("    set -e && \\\n")
 		dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \\\n")
@@ -1556,7 +1656,7 @@ func (g *DefaultDockerfileGenerator) generateOpencodeBuilder(dockerfile *strings
 		dockerfile.WriteString("    fi && \\\n")
 	}
 	// Shared download + verify logic (identical for Alpine and Debian)
-	dockerfile.WriteString(fmt.Sprintf("    curl %s -o /tmp/opencode.tar.gz \"https://github.com/anomalyco/opencode/releases/download/v%s/opencode-linux-${OC_ARCH}-musl.tar.gz\" && \\\n", curlFlags, opencodeVersion))
+	dockerfile.WriteString("    " + g.download("/tmp/opencode.tar.gz", fmt.Sprintf("https://github.com/anomalyco/opencode/releases/download/v%s/opencode-linux-${OC_ARCH}-musl.tar.gz", opencodeVersion), "OC_SHA256") + " && \\\n")
 	dockerfile.WriteString("    printf '%s  /tmp/opencode.tar.gz\\n' \"${OC_SHA256}\" > /tmp/opencode.sha256 && \\\n")
 	dockerfile.WriteString("    sha256sum -c - < /tmp/opencode.sha256 && \\\n")
 	dockerfile.WriteString("    tar -C /tmp -xzf /tmp/opencode.tar.gz opencode && \\\n")
@@ -1565,6 +1665,30 @@ func (g *DefaultDockerfileGenerator) generateOpencodeBuilder(dockerfile *strings
 	dockerfile.WriteString("    test -x /usr/local/bin/opencode\n\n")
 }
 
+// nerdFontDir is where the nerdfont-builder stage puts font files; fontconfig
+// scans /usr/share/fonts recursively.
+const nerdFontDir = "/usr/share/fonts/nerd-fonts"
+
+// generateNerdFontBuilder creates a parallel stage to download a Nerd Font
+// for GUI Neovim clients. Font files are architecture-independent, so one
+// Alpine stage serves both base images. The archive is verified against the
+// pinned release's SHA-256 manifest, the same check 'dvt font install' makes.
+func (g *DefaultDockerfileGenerator) generateNerdFontBuilder(dockerfile *strings.Builder, font fonts.Font) {
+	dest := nerdFontDir + "/" + font.Name
+	dockerfile.WriteString("# --- Parallel builder: Nerd Font ---\n")
+	dockerfile.WriteString(fmt.Sprintf("FROM %s AS nerdfont-builder\n", pinnedImage("alpine:3.20")))
+	dockerfile.WriteString(g.apkCacheMountsLocked())
+	dockerfile.WriteString("    set -e && \\\n")
+	dockerfile.WriteString("    apk add --no-cache curl unzip && \\\n")
+	dockerfile.WriteString(fmt.Sprintf("    curl %s -o /tmp/SHA-256.txt \"%s\" && \\\n", curlFlags, fonts.ChecksumManifestURL))
+	dockerfile.WriteString(fmt.Sprintf("    curl %s -o /tmp/%s \"%s\" && \\\n", curlFlags, font.Archive(), font.URL()))
+	dockerfile.WriteString(fmt.Sprintf("    cd /tmp && grep \" %s$\" SHA-256.txt | sha256sum -c - && \\\n", font.Archive()))
+	dockerfile.WriteString(fmt.Sprintf("    mkdir -p %s && \\\n", dest))
+	dockerfile.WriteString(fmt.Sprintf("    unzip -j -o /tmp/%s '*.ttf' -d %s && \\\n", font.Archive(), dest))
+	dockerfile.WriteString(fmt.Sprintf("    rm /tmp/%s /tmp/SHA-256.txt && \\\n", font.Archive()))
+	dockerfile.WriteString(fmt.Sprintf("    ls %s/*.ttf > /dev/null\n\n", dest))
+}
+
 // getGoToolsList returns the resolved Go tools list (config or defaults)
 func (g *DefaultDockerfileGenerator) getGoToolsList() []string {
 	tools := g.workspaceYAML.Build.DevStage.DevTools
@@ -1755,15 +1879,15 @@ func compareGoVersions(a, b string) int {
 	return 0
 }
 
-func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builder) {
+// devStagePackages returns the packages the dev stage installs: the dev
+// packages (from config or defaults), nvim dependencies, and Mason toolchains.
+func (g *DefaultDockerfileGenerator) devStagePackages() []string {
 	// Get packages from config or use defaults
 	packages := g.workspaceYAML.Build.DevStage.Packages
 	if len(packages) == 0 {
 		packages = g.getDefaultPackages()
 	}
 
-	isAlpine := g.isAlpineImage()
-
 	// Merge all packages into a single install: dev packages + nvim deps + Mason toolchains
 	// This eliminates redundant apt-get update/apk update calls
 	allPackages := make([]string, 0, len(packages)+10)
@@ -1771,7 +1895,7 @@ func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builde
 
 	// Add nvim dependency packages (previously in installNvimDependencies)
 	if g.workspaceYAML.Nvim.Structure != "none" {
-		if isAlpine {
+		if g.isAlpineImage() {
 			allPackages = appendUnique(allPackages, "unzip", "build-base", "ripgrep", "fd")
 			// Mason toolchains for Alpine
 			allPackages = appendUnique(allPackages, "nodejs", "npm", "py3-pip", "cargo")
@@ -1783,11 +1907,26 @@ func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builde
 			allPackages = appendUnique(allPackages, "python3-pip", "cargo")
 		}
 	}
+	return allPackages
+}
+
+func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builder) {
+	isAlpine := g.isAlpineImage()
+	allPackages := g.devStagePackages()
+
+	// A shared base image has the default tooling installed already
+	usesBaseImage := g.usesBaseImage()
+	if usesBaseImage {
+		allPackages = g.withoutBaseImagePackages(allPackages)
+		if len(allPackages) == 0 {
+			dockerfile.WriteString("# Dev tools and nvim dependencies come from the shared base image\n\n")
+		}
+	}
 
 	// Add Debian backports repo for git >= 2.32.0 (required by lazygit v0.60+, #382).
 	// Skip backports for EOL Debian releases whose backports repos return 404 (#390).
 	// Gate on ID=debian so Ubuntu (jammy/focal/noble) doesn't 404 on deb.debian.org (#417).
-	if !isAlpine {
+	if !isAlpine && len(allPackages) > 0 {
 		dockerfile.WriteString("# Enable backports for git >= 2.32.0 (required by lazygit v0.60+)\n")
 		dockerfile.WriteString("# Only for Debian (Ubuntu codenames like jammy don't exist on deb.debian.org) (#417)\n")
 		dockerfile.WriteString("# Skip EOL Debian releases (jessie/stretch/buster/bullseye) whose backports are gone (#390)\n")
@@ -1799,32 +1938,34 @@ func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builde
 	}
 
 	// Install all packages in one shot with cache mounts
-	dockerfile.WriteString("# Install all dev tools, nvim dependencies, and Mason toolchains (merged)\n")
-	if isAlpine {
-		dockerfile.WriteString(g.apkCacheMounts())
-		dockerfile.WriteString("    apk add \\\n")
-	} else {
-		dockerfile.WriteString(g.aptCacheMounts())
-		dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && \\\n")
-		dockerfile.WriteString("    if [ -f /etc/apt/sources.list.d/backports.list ]; then \\\n")
-		dockerfile.WriteString("      apt-get install -y --no-install-recommends -t $(. /etc/os-release && echo \"$VERSION_CODENAME\")-backports git; \\\n")
-		dockerfile.WriteString("    fi && \\\n")
-		dockerfile.WriteString("    apt-get install -y --no-install-recommends --fix-broken \\\n")
-	}
-
-	for i, pkg := range allPackages {
-		if i < len(allPackages)-1 {
-			dockerfile.WriteString(fmt.Sprintf("    %s \\\n", pkg))
+	if len(allPackages) > 0 {
+		dockerfile.WriteString("# Install all dev tools, nvim dependencies, and Mason toolchains (merged)\n")
+		if isAlpine {
+			dockerfile.WriteString(g.apkCacheMounts())
+			dockerfile.WriteString("    apk add \\\n")
 		} else {
-			dockerfile.WriteString(fmt.Sprintf("    %s\n", pkg))
+			dockerfile.WriteString(g.aptCacheMounts())
+			dockerfile.WriteString("    rm -rf /var/lib/apt/lists/* && apt-get update && \\\n")
+			dockerfile.WriteString("    if [ -f /etc/apt/sources.list.d/backports.list ]; then \\\n")
+			dockerfile.WriteString("      apt-get install -y --no-install-recommends -t $(. /etc/os-release && echo \"$VERSION_CODENAME\")-backports git; \\\n")
+			dockerfile.WriteString("    fi && \\\n")
+			dockerfile.WriteString("    apt-get install -y --no-install-recommends --fix-broken \\\n")
+		}
+
+		for i, pkg := range allPackages {
+			if i < len(allPackages)-1 {
+				dockerfile.WriteString(fmt.Sprintf("    %s \\\n", pkg))
+			} else {
+				dockerfile.WriteString(fmt.Sprintf("    %s\n", pkg))
+			}
 		}
+		dockerfile.WriteString("\n")
 	}
-	dockerfile.WriteString("\n")
 
 	// Install Node.js 22 from NodeSource for Debian when nvim is enabled.
 	// Runs AFTER the merged apt-get install so that curl is available.
 	// Falls back to Debian's default nodejs+npm if NodeSource is unreachable.
-	if g.workspaceYAML.Nvim.Structure != "none" && !g.isAlpineImage() {
+	if g.workspaceYAML.Nvim.Structure != "none" && !g.isAlpineImage() && !usesBaseImage {
 		dockerfile.WriteString("# Install Node.js 22 from NodeSource (Mason toolchains require Node 22+)\n")
 		dockerfile.WriteString("# Falls back to Debian default nodejs+npm if NodeSource is unreachable\n")
 		dockerfile.WriteString(fmt.Sprintf("RUN (curl %s https://deb.nodesource.com/setup_22.x | bash - \\\n", curlFlags))
@@ -1834,7 +1975,7 @@ func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builde
 
 	// npm install neovim (needed by Mason) - with cache mount
 	// Falls back to direct npmjs.org access if HTTP proxy/registry is unreachable
-	if g.workspaceYAML.Nvim.Structure != "none" {
+	if g.workspaceYAML.Nvim.Structure != "none" && !usesBaseImage {
 		dockerfile.WriteString("# Install neovim npm package for Mason\n")
 		dockerfile.WriteString("# Falls back to direct npmjs.org access if HTTP proxy/registry is unreachable\n")
 		dockerfile.WriteString("RUN --mount=type=cache,target=/root/.npm \\\n")
@@ -1844,8 +1985,9 @@ func (g *DefaultDockerfileGenerator) generateDevStage(dockerfile *strings.Builde
 	}
 
 	// Install language-specific tools (non-Go, since Go tools come from parallel builder)
+	// (a shared base image has the default ones installed already)
 	languageTools := g.workspaceYAML.Build.DevStage.DevTools
-	if len(languageTools) == 0 {
+	if len(languageTools) == 0 && !usesBaseImage {
 		languageTools = g.getDefaultLanguageTools()
 	}
 	if len(languageTools) > 0 && g.language != "golang" {
@@ -2421,10 +2563,15 @@ func (g *DefaultDockerfileGenerator) generateNvimSection(dockerfile *strings.Bui
 }
 
 // getMasonToolsForLanguage returns Mason packages (LSPs, linters, formatters) for the detected language.
+func (g *DefaultDockerfileGenerator) getMasonToolsForLanguage() []string {
+	return masonToolsForLanguage(g.language)
+}
+
+// masonToolsForLanguage returns Mason packages (LSPs, linters, formatters) for a language.
 // This is the SINGLE AUTHORITY for language-specific Mason tool installation.
 // The plugin YAML (06-mason.yaml) provides only framework setup, not tool lists.
-func (g *DefaultDockerfileGenerator) getMasonToolsForLanguage() []string {
-	switch g.language {
+func masonToolsForLanguage(language string) []string {
+	switch language {
 	case "python":
 		return []string{"pyright", "ruff", "black", "isort", "pylint"}
 	case "golang":
@@ -2488,15 +2635,7 @@ func (g *DefaultDockerfileGenerator) installMasonTools(dockerfile *strings.Build
 		return
 	}
 
-	// Merge base tools (always installed) with language-specific tools
-	tools := g.getBaseMasonTools()
-	tools = append(tools, g.getMasonToolsForLanguage()...)
-
-	// Append user-configured extra tools
-	if g.workspaceYAML.Nvim.ExtraMasonTools != nil {
-		tools = append(tools, g.workspaceYAML.Nvim.ExtraMasonTools...)
-	}
-
+	tools := g.masonTools()
 	if len(tools) == 0 {
 		return
 	}
@@ -2515,6 +2654,11 @@ func (g *DefaultDockerfileGenerator) installMasonTools(dockerfile *strings.Build
 	user := g.effectiveUser()
 	g.writeMasonLuaScript(dockerfile, user, luaTools)
 
+	// Packages installed by an earlier build with the same tool list are restored
+	// first; the Lua script skips tools that are already installed.
+	masonDir := fmt.Sprintf("/home/%s/.local/share/nvim/mason", user)
+	g.emitProvisionCacheRestore(dockerfile, "mason", tools, masonDir)
+
 	// Execute nvim with the Lua script.
 	// Force-load mason.nvim via Lazy! so Mason is available in headless mode
 	// (same pattern as treesitter fix — see issues #232, #234).
@@ -2532,6 +2676,8 @@ func (g *DefaultDockerfileGenerator) installMasonTools(dockerfile *strings.Build
 
 	// Verification: ensure Mason packages directory was populated
 	g.writeMasonVerification(dockerfile, user, len(tools))
+
+	g.emitProvisionCacheSave(dockerfile, "mason", tools, masonDir)
 }
 
 // writeMasonLuaScript writes the COPY heredoc for the Mason install Lua script.
@@ -2639,12 +2785,19 @@ func (g *DefaultDockerfileGenerator) writeMasonVerification(dockerfile *strings.
 	dockerfile.WriteString(fmt.Sprintf("    echo \"Mason: $pkg_count package(s) installed (expected %d)\"\n\n", toolCount))
 }
 
+// baseTreesitterParsers are always installed for a good editing experience.
+var baseTreesitterParsers = []string{"lua", "vim", "vimdoc", "query", "markdown", "markdown_inline", "bash", "json", "yaml"}
+
 // getTreesitterParsersForLanguage returns Treesitter parsers for the detected language
 func (g *DefaultDockerfileGenerator) getTreesitterParsersForLanguage() []string {
-	// Base parsers always included for a good editing experience
-	base := []string{"lua", "vim", "vimdoc", "query", "markdown", "markdown_inline", "bash", "json", "yaml"}
+	return treesitterParsersForLanguage(g.language)
+}
 
-	switch g.language {
+// treesitterParsersForLanguage returns the base Treesitter parsers plus those for a language.
+func treesitterParsersForLanguage(language string) []string {
+	base := append([]string{}, baseTreesitterParsers...)
+
+	switch language {
 	case "python":
 		return append(base, "python", "toml", "dockerfile", "gitignore")
 	case "golang":
@@ -2696,13 +2849,7 @@ func (g *DefaultDockerfileGenerator) installTreesitterParsers(dockerfile *string
 		return
 	}
 
-	parsers := g.getTreesitterParsersForLanguage()
-
-	// Append user-configured extra parsers
-	if g.workspaceYAML.Nvim.ExtraTreesitterParsers != nil {
-		parsers = append(parsers, g.workspaceYAML.Nvim.ExtraTreesitterParsers...)
-	}
-
+	parsers := g.treesitterParsers()
 	if len(parsers) == 0 {
 		return
 	}
@@ -2720,6 +2867,11 @@ func (g *DefaultDockerfileGenerator) installTreesitterParsers(dockerfile *string
 	//   require('nvim-treesitter.configs').setup({ ensure_installed, sync_install })
 	g.writeTreesitterLuaScript(dockerfile, user, parsers)
 
+	// Parsers compiled by an earlier build with the same parser list are restored
+	// into nvim-treesitter (installed by Lazy sync above); ensure_installed skips them.
+	parserDir := fmt.Sprintf("/home/%s/.local/share/nvim/lazy/nvim-treesitter", user)
+	g.emitProvisionCacheRestore(dockerfile, "treesitter", parsers, parserDir+"/parser", parserDir+"/parser-info")
+
 	// Execute nvim with the Lua script using -c (NOT + prefix).
 	// CRITICAL: The + prefix runs during Neovim STARTUP, before init.lua finishes.
 	// Since init.lua bootstraps Lazy.nvim, using +luafile means Lazy sync hasn't
@@ -2745,6 +2897,8 @@ func (g *DefaultDockerfileGenerator) installTreesitterParsers(dockerfile *string
 	dockerfile.WriteString("      exit 1; \\\n")
 	dockerfile.WriteString("    fi && \\\n")
 	dockerfile.WriteString(fmt.Sprintf("    echo \"Treesitter: $parser_count parser(s) installed (expected %d)\"\n\n", len(parsers)))
+
+	g.emitProvisionCacheSave(dockerfile, "treesitter", parsers, parserDir+"/parser", parserDir+"/parser-info")
 }
 
 // writeTreesitterLuaScript writes the COPY heredoc for the Treesitter install Lua script.
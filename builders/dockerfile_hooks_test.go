@@ -0,0 +1,98 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/utils/appkind"
+	"github.com/rmkohlman/MaestroSDK/paths"
+)
+
+func generateWithHooks(t *testing.T, kind string, app, ws *models.DockerfileHooks) (string, error) {
+	t.Helper()
+	spec := models.WorkspaceSpec{}
+	spec.Build.Hooks = ws
+	gen := NewDockerfileGenerator(DockerfileGeneratorOptions{
+		Workspace:     &models.Workspace{ID: 1, Name: "hooks-ws", ImageName: "hooks:latest"},
+		WorkspaceSpec: spec,
+		Language:      "golang",
+		AppPath:       "/tmp/hooks-test",
+		PathConfig:    paths.New(t.TempDir()),
+		AppKind:       kind,
+		AppHooks:      app,
+	})
+	return gen.Generate()
+}
+
+// assertInOrder fails unless each of parts appears in s after the previous one.
+func assertInOrder(t *testing.T, s string, parts ...string) {
+	t.Helper()
+	pos := 0
+	for _, part := range parts {
+		i := strings.Index(s[pos:], part)
+		if i < 0 {
+			t.Fatalf("%q not found after offset %d in:\n%s", part, pos, s)
+		}
+		pos += i + len(part)
+	}
+}
+
+func TestDockerfileGenerator_Hooks_Placement(t *testing.T) {
+	app := &models.DockerfileHooks{
+		PreBase:   "RUN echo app-pre-base",
+		PostTools: "RUN echo app-post-tools",
+	}
+	ws := &models.DockerfileHooks{
+		PreBase:  "RUN echo ws-pre-base",
+		PostNvim: "USER dev\nRUN echo ws-post-nvim",
+	}
+
+	dockerfile, err := generateWithHooks(t, "", app, ws)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	assertInOrder(t, dockerfile,
+		"AS base\n\n# Hook: preBase (spec.build.hooks)\nRUN echo app-pre-base\nRUN echo ws-pre-base\n",
+		"FROM base AS dev",
+		"RUN mkdir -p /workspace",
+		"# Hook: postTools (spec.build.hooks)\nRUN echo app-post-tools\n",
+		"Neovim",
+		"# Hook: postNvim (spec.build.hooks)\nUSER dev\nRUN echo ws-post-nvim\nUSER root\n",
+		"CMD [",
+	)
+}
+
+func TestDockerfileGenerator_Hooks_CICD(t *testing.T) {
+	hooks := &models.DockerfileHooks{PreBase: "RUN echo pre", PostTools: "RUN echo tools", PostNvim: "RUN echo nvim"}
+
+	dockerfile, err := generateWithHooks(t, string(appkind.KindCICD), nil, hooks)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	assertInOrder(t, dockerfile, "AS base\n\n# Hook: preBase", "RUN echo pre", "FROM base AS dev", "RUN echo tools", "RUN echo nvim", "CMD [")
+}
+
+func TestDockerfileGenerator_Hooks_NoneEmitted(t *testing.T) {
+	dockerfile, err := generateWithHooks(t, "", nil, &models.DockerfileHooks{})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(dockerfile, "# Hook:") {
+		t.Error("Generate() emitted a hook comment with no hooks configured")
+	}
+}
+
+func TestDockerfileGenerator_Hooks_Invalid(t *testing.T) {
+	_, err := generateWithHooks(t, "", nil, &models.DockerfileHooks{PostTools: "FROM alpine"})
+	if err == nil || !strings.Contains(err.Error(), "workspace build hooks.postTools: line 1: FROM is not allowed") {
+		t.Fatalf("Generate() error = %v, want invalid workspace hook error", err)
+	}
+
+	_, err = generateWithHooks(t, "", &models.DockerfileHooks{PreBase: "CMD [\"sh\"]"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "app build hooks.preBase") {
+		t.Fatalf("Generate() error = %v, want invalid app hook error", err)
+	}
+}
@@ -45,6 +45,7 @@ func (c BuilderConfig) Validate() error {
 // Platform selection:
 //   - Docker-compatible (OrbStack, Docker Desktop, Podman): DockerBuilder
 //   - Containerd (Colima with containerd): BuildKitBuilder
+//   - Remote nerdctl host (runtime endpoint): NerdctlSSHBuilder
 func NewImageBuilder(cfg BuilderConfig) (ImageBuilder, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -59,5 +60,9 @@ func NewImageBuilder(cfg BuilderConfig) (ImageBuilder, error) {
 		return NewBuildKitBuilder(cfg)
 	}
 
+	if cfg.Platform.IsRemote() {
+		return NewNerdctlSSHBuilder(cfg)
+	}
+
 	return nil, fmt.Errorf("unsupported platform type: %s", cfg.Platform.Type)
 }
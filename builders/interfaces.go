@@ -8,6 +8,7 @@
 // Implementations:
 //   - DockerBuilder: Uses Docker API (OrbStack, Docker Desktop, Podman)
 //   - BuildKitBuilder: Uses BuildKit gRPC API (Colima with containerd)
+//   - NerdctlSSHBuilder: Uses nerdctl over ssh (remote nerdctl hosts)
 //
 // Example usage:
 //
@@ -51,6 +52,10 @@ type BuildOptions struct {
 	// BuildArgs are build-time variables passed to the Dockerfile
 	BuildArgs map[string]string
 
+	// Labels are added to the built image, next to the managed and
+	// namespace labels every dvm image carries (see operators.ResourceLabels)
+	Labels map[string]string
+
 	// Target specifies the target stage for multi-stage builds
 	Target string
 
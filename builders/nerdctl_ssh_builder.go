@@ -0,0 +1,80 @@
+package builders
+
+import (
+	"context"
+	"fmt"
+
+	"devopsmaestro/operators"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// NerdctlSSHBuilder builds container images on a remote nerdctl host: the
+// build context is synced to the host with rsync and built there with
+// nerdctl build over ssh.
+type NerdctlSSHBuilder struct {
+	runtime    *operators.NerdctlSSHRuntime
+	platform   *operators.Platform
+	appPath    string
+	imageName  string
+	dockerfile string
+}
+
+// NewNerdctlSSHBuilder creates a builder for a remote nerdctl platform.
+func NewNerdctlSSHBuilder(cfg BuilderConfig) (*NerdctlSSHBuilder, error) {
+	runtime, err := operators.NewNerdctlSSHRuntime(cfg.Platform)
+	if err != nil {
+		return nil, err
+	}
+	return &NerdctlSSHBuilder{
+		runtime:    runtime,
+		platform:   cfg.Platform,
+		appPath:    cfg.AppPath,
+		imageName:  cfg.ImageName,
+		dockerfile: cfg.Dockerfile,
+	}, nil
+}
+
+// Build builds the image on the remote host. Build caches and registry
+// mirrors are local to this machine, so they are not used.
+func (b *NerdctlSSHBuilder) Build(ctx context.Context, opts BuildOptions) error {
+	out := opts.OutputOrStdout()
+
+	render.MsgTo(out, "", render.Message{Level: render.LevelProgress, Content: fmt.Sprintf("Building image: %s", b.imageName)})
+	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: fmt.Sprintf("Using nerdctl (%s)", b.platform.Name)})
+	render.MsgTo(out, "", render.Message{Level: render.LevelInfo, Content: ""})
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	err := b.runtime.Build(ctx, operators.NerdctlBuildOptions{
+		ContextDir: b.appPath,
+		Dockerfile: b.dockerfile,
+		ImageName:  b.imageName,
+		BuildArgs:  opts.BuildArgs,
+		Labels:     opts.Labels,
+		Target:     opts.Target,
+		NoCache:    opts.NoCache,
+		Pull:       opts.Pull,
+		Stdout:     NewRedactingWriter(out, opts.BuildArgs),
+		Stderr:     NewRedactingWriter(opts.StderrOrDiscard(), opts.BuildArgs),
+	})
+	if err != nil {
+		return err
+	}
+
+	render.MsgTo(out, "", render.Message{Level: render.LevelSuccess, Content: fmt.Sprintf("Image built successfully: %s", b.imageName)})
+	return nil
+}
+
+// ImageExists checks if the image exists on the remote host.
+func (b *NerdctlSSHBuilder) ImageExists(ctx context.Context) (bool, error) {
+	return b.runtime.ImageExists(ctx, b.imageName)
+}
+
+// Close releases builder resources. NerdctlSSHBuilder holds none.
+func (b *NerdctlSSHBuilder) Close() error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package builders
+
+import (
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/paths"
+)
+
+func generateWithNerdFont(t *testing.T, font string) (string, error) {
+	t.Helper()
+	gen := NewDockerfileGenerator(DockerfileGeneratorOptions{
+		Workspace:     &models.Workspace{ID: 1, Name: "test-ws", ImageName: "test:latest"},
+		WorkspaceSpec: models.WorkspaceSpec{Tools: models.ToolsConfig{NerdFont: font}},
+		Language:      "python",
+		Version:       "3.11",
+		AppPath:       "/tmp/test",
+		PathConfig:    paths.New(t.TempDir()),
+	})
+	return gen.Generate()
+}
+
+func TestNerdFontBuilder(t *testing.T) {
+	dockerfile, err := generateWithNerdFont(t, "JetBrainsMono Nerd Font")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		"# --- Parallel builder: Nerd Font ---",
+		"AS nerdfont-builder",
+		"/JetBrainsMono.zip",
+		"/SHA-256.txt",
+		`grep " JetBrainsMono.zip$" SHA-256.txt | sha256sum -c -`,
+		"unzip -j -o /tmp/JetBrainsMono.zip '*.ttf' -d /usr/share/fonts/nerd-fonts/JetBrainsMono",
+		"COPY --from=nerdfont-builder /usr/share/fonts/nerd-fonts/ /usr/share/fonts/nerd-fonts/",
+	} {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Generate() missing %q", want)
+		}
+	}
+}
+
+func TestNerdFontBuilder_NotEmittedByDefault(t *testing.T) {
+	dockerfile, err := generateWithNerdFont(t, "")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(dockerfile, "nerdfont-builder") {
+		t.Error("Generate() emitted the Nerd Font builder without tools.nerdFont")
+	}
+}
+
+func TestNerdFontBuilder_UnknownFont(t *testing.T) {
+	if _, err := generateWithNerdFont(t, "Comic Sans"); err == nil || !strings.Contains(err.Error(), "tools.nerdFont") {
+		t.Errorf("Generate() error = %v, want tools.nerdFont error", err)
+	}
+}
@@ -0,0 +1,184 @@
+package builders
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// languageAliases maps the language names users write in nvim.languages to the
+// language keys used by the detector and the per-language tool tables.
+var languageAliases = map[string]string{
+	"go":         "golang",
+	"py":         "python",
+	"node":       "nodejs",
+	"javascript": "nodejs",
+	"typescript": "nodejs",
+	"js":         "nodejs",
+	"ts":         "nodejs",
+	"csharp":     "dotnet",
+	"c#":         "dotnet",
+}
+
+// filetypeParsers maps the filetypes plugins lazy-load on (the ft field) to
+// the Treesitter parser that highlights them. Filetypes without an entry
+// (e.g., "oil" or "NvimTree") have no parser and are skipped.
+var filetypeParsers = map[string]string{
+	"bash":            "bash",
+	"c":               "c",
+	"cmake":           "cmake",
+	"cpp":             "cpp",
+	"cs":              "c_sharp",
+	"css":             "css",
+	"dart":            "dart",
+	"diff":            "diff",
+	"dockerfile":      "dockerfile",
+	"eex":             "eex",
+	"elixir":          "elixir",
+	"erlang":          "erlang",
+	"gitcommit":       "gitcommit",
+	"gitignore":       "gitignore",
+	"gleam":           "gleam",
+	"go":              "go",
+	"gomod":           "gomod",
+	"gosum":           "gosum",
+	"gowork":          "gowork",
+	"graphql":         "graphql",
+	"haskell":         "haskell",
+	"hcl":             "hcl",
+	"heex":            "heex",
+	"help":            "vimdoc",
+	"html":            "html",
+	"java":            "java",
+	"javascript":      "javascript",
+	"javascriptreact": "javascript",
+	"json":            "json",
+	"jsonc":           "jsonc",
+	"kotlin":          "kotlin",
+	"lua":             "lua",
+	"make":            "make",
+	"markdown":        "markdown",
+	"nix":             "nix",
+	"perl":            "perl",
+	"php":             "php",
+	"proto":           "proto",
+	"python":          "python",
+	"query":           "query",
+	"r":               "r",
+	"rmd":             "rmd",
+	"ruby":            "ruby",
+	"rust":            "rust",
+	"scala":           "scala",
+	"scss":            "scss",
+	"sh":              "bash",
+	"sql":             "sql",
+	"svelte":          "svelte",
+	"swift":           "swift",
+	"terraform":       "terraform",
+	"tex":             "latex",
+	"toml":            "toml",
+	"typescript":      "typescript",
+	"typescriptreact": "tsx",
+	"vim":             "vim",
+	"vue":             "vue",
+	"xml":             "xml",
+	"yaml":            "yaml",
+	"zig":             "zig",
+	"zsh":             "bash",
+}
+
+// provisionLanguages returns the languages to provision Neovim tooling for: the
+// detected app language followed by the languages declared in nvim.languages.
+func (g *DefaultDockerfileGenerator) provisionLanguages() []string {
+	var languages []string
+	if g.language != "" {
+		languages = append(languages, g.language)
+	}
+	for _, lang := range g.workspaceYAML.Nvim.Languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if alias, ok := languageAliases[lang]; ok {
+			lang = alias
+		}
+		if lang != "" {
+			languages = appendUnique(languages, lang)
+		}
+	}
+	return languages
+}
+
+// treesitterParsers returns the parsers to install at build time: the base
+// parsers, those of every provisioned language, those for the filetypes the
+// workspace's plugins lazy-load on, and nvim.extraTreesitterParsers.
+func (g *DefaultDockerfileGenerator) treesitterParsers() []string {
+	parsers := append([]string{}, baseTreesitterParsers...)
+	for _, lang := range g.provisionLanguages() {
+		parsers = appendUnique(parsers, treesitterParsersForLanguage(lang)...)
+	}
+	for _, ft := range g.pluginFiletypes {
+		if parser, ok := filetypeParsers[ft]; ok {
+			parsers = appendUnique(parsers, parser)
+		}
+	}
+	return appendUnique(parsers, g.workspaceYAML.Nvim.ExtraTreesitterParsers...)
+}
+
+// masonTools returns the Mason packages to install at build time: the base
+// tools, those of every provisioned language, and nvim.extraMasonTools.
+//
+// Plugin filetypes do not add Mason tools: most language servers need their
+// language's toolchain to install, which only declared languages provide.
+func (g *DefaultDockerfileGenerator) masonTools() []string {
+	tools := g.getBaseMasonTools()
+	for _, lang := range g.provisionLanguages() {
+		tools = appendUnique(tools, masonToolsForLanguage(lang)...)
+	}
+	return appendUnique(tools, g.workspaceYAML.Nvim.ExtraMasonTools...)
+}
+
+// provisionCacheMount returns the cache mount holding the artifacts a
+// provisioning step (kind "mason" or "treesitter") installed. The cache ID
+// includes a hash of the item list, so a cache only ever holds the items of
+// one list and never restores tools or parsers the workspace dropped.
+func (g *DefaultDockerfileGenerator) provisionCacheMount(kind string, items []string) (mount, dir string) {
+	sum := sha256.Sum256([]byte(strings.Join(items, "\n")))
+	dir = fmt.Sprintf("/home/%s/.cache/dvm-provision/%s", g.effectiveUser(), kind)
+	mount = fmt.Sprintf("RUN --mount=type=cache,target=%s,id=nvim-%s-%s-%s,uid=1000,sharing=locked \\\n",
+		dir, kind, g.cacheID(), hex.EncodeToString(sum[:])[:12])
+	return mount, dir
+}
+
+// emitProvisionCacheRestore copies cached artifacts into targets before a
+// provisioning step, so rebuilds after a config change do not download and
+// compile them again. Each target is cached under its base name and is only
+// restored when its parent directory exists.
+func (g *DefaultDockerfileGenerator) emitProvisionCacheRestore(dockerfile *strings.Builder, kind string, items []string, targets ...string) {
+	mount, cacheDir := g.provisionCacheMount(kind, items)
+	dockerfile.WriteString(fmt.Sprintf("# Restore %s artifacts cached by an earlier build\n", kind))
+	dockerfile.WriteString(mount)
+	for i, target := range targets {
+		cached := cacheDir + "/" + path.Base(target)
+		dockerfile.WriteString(fmt.Sprintf("    if [ -d %s ] && [ -d %s ]; then mkdir -p %s && cp -a %s/. %s/; fi", cached, path.Dir(target), target, cached, target))
+		if i < len(targets)-1 {
+			dockerfile.WriteString(" && \\\n")
+		}
+	}
+	dockerfile.WriteString("\n\n")
+}
+
+// emitProvisionCacheSave replaces the cached artifacts with targets after a
+// provisioning step succeeded.
+func (g *DefaultDockerfileGenerator) emitProvisionCacheSave(dockerfile *strings.Builder, kind string, items []string, targets ...string) {
+	mount, cacheDir := g.provisionCacheMount(kind, items)
+	dockerfile.WriteString(fmt.Sprintf("# Save %s artifacts to the provisioning cache for the next build\n", kind))
+	dockerfile.WriteString(mount)
+	for i, target := range targets {
+		cached := cacheDir + "/" + path.Base(target)
+		dockerfile.WriteString(fmt.Sprintf("    if [ -d %s ]; then rm -rf %s && mkdir -p %s && cp -a %s/. %s/; fi", target, cached, cached, target, cached))
+		if i < len(targets)-1 {
+			dockerfile.WriteString(" && \\\n")
+		}
+	}
+	dockerfile.WriteString("\n\n")
+}
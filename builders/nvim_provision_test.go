@@ -0,0 +1,117 @@
+package builders
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// newProvisionGenerator returns a generator for a workspace with a staged
+// nvim config and both Mason and Treesitter installed.
+func newProvisionGenerator(t *testing.T, language string, nvim models.NvimConfig, filetypes []string) *DefaultDockerfileGenerator {
+	t.Helper()
+	stagingDir := t.TempDir()
+	nvimDir := filepath.Join(stagingDir, ".config", "nvim")
+	if err := os.MkdirAll(nvimDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(nvimDir, "init.lua"), []byte("-- test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	nvim.Structure = "custom"
+	gen := NewDockerfileGenerator(DockerfileGeneratorOptions{
+		Workspace:       &models.Workspace{ID: 1, Name: "myws", ImageName: "test:latest"},
+		WorkspaceSpec:   models.WorkspaceSpec{Nvim: nvim},
+		Language:        language,
+		AppPath:         "/tmp/provision-test",
+		StagingDir:      stagingDir,
+		PluginFiletypes: filetypes,
+	})
+	gen.SetPluginManifest(&plugin.PluginManifest{
+		Features: plugin.PluginFeatures{HasMason: true, HasTreesitter: true},
+	})
+	return gen.(*DefaultDockerfileGenerator)
+}
+
+func TestTreesitterParsers_DeclaredLanguagesAndFiletypes(t *testing.T) {
+	g := newProvisionGenerator(t, "golang", models.NvimConfig{
+		Languages:              []string{"TypeScript", "golang"},
+		ExtraTreesitterParsers: []string{"hcl"},
+	}, []string{"typescriptreact", "sh", "oil", "go"})
+
+	parsers := g.treesitterParsers()
+	for _, want := range []string{"lua", "go", "gomod", "typescript", "tsx", "html", "bash", "hcl"} {
+		if !slices.Contains(parsers, want) {
+			t.Errorf("treesitterParsers() missing %q; got %v", want, parsers)
+		}
+	}
+	if slices.Contains(parsers, "oil") {
+		t.Errorf("treesitterParsers() includes %q, which has no parser; got %v", "oil", parsers)
+	}
+	seen := map[string]bool{}
+	for _, p := range parsers {
+		if seen[p] {
+			t.Errorf("treesitterParsers() lists %q twice; got %v", p, parsers)
+		}
+		seen[p] = true
+	}
+}
+
+func TestMasonTools_DeclaredLanguages(t *testing.T) {
+	g := newProvisionGenerator(t, "golang", models.NvimConfig{
+		Languages:       []string{"python", "go"},
+		ExtraMasonTools: []string{"shellcheck"},
+	}, []string{"rust"})
+
+	tools := g.masonTools()
+	want := []string{"lua-language-server", "stylua", "gopls", "golangci-lint-langserver", "goimports",
+		"pyright", "ruff", "black", "isort", "pylint", "shellcheck"}
+	if !slices.Equal(tools, want) {
+		t.Errorf("masonTools() = %v, want %v", tools, want)
+	}
+}
+
+func TestProvisionCache_RestoreAndSave(t *testing.T) {
+	g := newProvisionGenerator(t, "python", models.NvimConfig{}, nil)
+	dockerfile, err := g.Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, kind := range []string{"mason", "treesitter"} {
+		restore := strings.Index(dockerfile, "# Restore "+kind+" artifacts")
+		save := strings.Index(dockerfile, "# Save "+kind+" artifacts")
+		if restore < 0 || save < 0 {
+			t.Fatalf("Generate() missing %s cache restore or save step", kind)
+		}
+		install := strings.Index(dockerfile, "luafile /tmp/"+kind+"-install.lua")
+		if !(restore < install && install < save) {
+			t.Errorf("%s cache steps must wrap the install: restore=%d install=%d save=%d", kind, restore, install, save)
+		}
+		if !strings.Contains(dockerfile, "target=/home/dev/.cache/dvm-provision/"+kind+",id=nvim-"+kind+"-myws-") {
+			t.Errorf("Generate() missing workspace-scoped %s provisioning cache mount", kind)
+		}
+	}
+	if !strings.Contains(dockerfile, "cp -a /home/dev/.cache/dvm-provision/treesitter/parser/. /home/dev/.local/share/nvim/lazy/nvim-treesitter/parser/") {
+		t.Error("treesitter restore step does not copy cached parsers into nvim-treesitter")
+	}
+}
+
+func TestProvisionCacheMount_KeyedByItemList(t *testing.T) {
+	g := newProvisionGenerator(t, "python", models.NvimConfig{}, nil)
+	a, _ := g.provisionCacheMount("mason", []string{"pyright", "ruff"})
+	b, _ := g.provisionCacheMount("mason", []string{"pyright", "ruff"})
+	c, _ := g.provisionCacheMount("mason", []string{"pyright"})
+	if a != b {
+		t.Errorf("same item list produced different cache mounts:\n%s\n%s", a, b)
+	}
+	if a == c {
+		t.Errorf("different item lists share a cache mount: %s", a)
+	}
+}
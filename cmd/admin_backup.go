@@ -0,0 +1,394 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/backup"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	adminBackupTo        string
+	adminBackupEncrypt   bool
+	adminBackupScheduled bool
+)
+
+// adminBackupCmd takes a backup and groups the other backup commands.
+var adminBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the dvm database",
+	Long: `Back up the dvm database according to the 'backup' section of config.yaml.
+
+The database is copied with the SQLite online backup API, so the snapshot is
+consistent even while other dvm commands are running. Each snapshot must pass
+an integrity check and carry a schema version this build knows before it is
+archived.
+
+Without a subcommand, a backup is taken now: written to the configured
+destination, after which only the newest 'retention' archives are kept. With
+--to, a single archive is written to the given file (or into the given
+directory) instead, and nothing is pruned.
+
+When backup.enabled is true, dvm takes a backup automatically whenever one is
+due: the check happens at the start of each command, and the backup itself
+runs in a background process (output in ~/.devopsmaestro/logs/backup.log). Archives are gzip-compressed,
+optionally encrypted, and written to a local directory, an S3-compatible
+bucket, or a git repository. Restore with 'dvm admin restore'.
+
+Examples:
+  dvm admin backup
+  dvm admin backup --to ~/dvm.db.gz
+  dvm admin backup --to ~/dvm.db.gz.enc --encrypt
+  dvm admin backup status
+  dvm admin backup status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAdminBackup,
+}
+
+// adminBackupRunCmd takes a backup immediately.
+var adminBackupRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Take a backup now",
+	Long: `Take a backup now, regardless of the schedule, and prune archives
+beyond the configured retention. Works even when scheduled backups are disabled.
+
+Examples:
+  dvm admin backup run`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if adminBackupScheduled {
+			return runScheduledBackup(cmd)
+		}
+		return backupNow(cmd, "")
+	},
+}
+
+// adminBackupStatusCmd shows the last backup run and when the next is due.
+var adminBackupStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the last backup run and when the next is due",
+	Long: `Show the backup schedule, destination, and retention, the outcome of the
+last run, and when the next scheduled backup is due.
+
+Examples:
+  dvm admin backup status
+  dvm admin backup status -o yaml`,
+	RunE: runAdminBackupStatus,
+}
+
+func init() {
+	adminBackupCmd.Flags().StringVar(&adminBackupTo, "to", "", "Write one archive to this file or directory instead of the configured destination")
+	for _, c := range []*cobra.Command{adminBackupCmd, adminBackupRunCmd} {
+		c.Flags().BoolVar(&adminBackupEncrypt, "encrypt", false, "Encrypt the archive even if backup.encryption is disabled (passphrase from $DVM_BACKUP_PASSPHRASE)")
+	}
+	// --scheduled marks the background run started by maybeRunScheduledBackup
+	adminBackupRunCmd.Flags().BoolVar(&adminBackupScheduled, "scheduled", false, "")
+	_ = adminBackupRunCmd.Flags().MarkHidden("scheduled")
+	AddOutputFlag(adminBackupStatusCmd, "")
+	adminBackupCmd.AddCommand(adminBackupRunCmd)
+	adminBackupCmd.AddCommand(adminBackupStatusCmd)
+	adminCmd.AddCommand(adminBackupCmd)
+}
+
+func runAdminBackup(cmd *cobra.Command, args []string) error {
+	return backupNow(cmd, adminBackupTo)
+}
+
+// backupNow takes a backup immediately: to the file or directory at to when
+// set, otherwise to the configured destination with retention applied.
+func backupNow(cmd *cobra.Command, to string) error {
+	cfg := config.GetConfig().Backup
+	if adminBackupEncrypt {
+		cfg.Encryption.Enabled = true
+	}
+	runner, err := newBackupRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	migrationsFS, _ := getMigrationsFSFromContext(cmd.Context())
+	snapshot := snapshotDatabase(cmd.Context(), ds.Driver(), migrationsFS)
+
+	if to != "" {
+		render.Progress(fmt.Sprintf("Backing up database to %s...", to))
+		path, err := runner.Export(snapshot, to)
+		if err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+		render.Successf("Backup written to %s", path)
+		return nil
+	}
+
+	render.Progress(fmt.Sprintf("Backing up database to %s...", runner.Destination().Name()))
+	state, err := runner.Run(cmd.Context(), snapshot)
+	if err != nil {
+		return fmt.Errorf("backup failed: %w", err)
+	}
+	render.Successf("Backup %s written to %s", state.LastArchive, runner.Destination().Name())
+	return nil
+}
+
+// backupStatusOutput is the structured form of 'dvm admin backup status'.
+type backupStatusOutput struct {
+	Enabled        bool `json:"enabled" yaml:"enabled"`
+	*backup.Status `yaml:",inline"`
+}
+
+func runAdminBackupStatus(cmd *cobra.Command, args []string) error {
+	outputFmt, _ := cmd.Flags().GetString("output")
+
+	cfg := config.GetConfig().Backup
+	runner, err := newBackupRunner(cfg)
+	if err != nil {
+		return err
+	}
+	status, err := runner.Status()
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(outputFmt) {
+		return render.OutputWith(outputFmt, backupStatusOutput{Enabled: cfg.Enabled, Status: status}, render.Options{})
+	}
+
+	render.Blank()
+	render.Info("Backup Configuration")
+	if cfg.Enabled {
+		render.Success("  Scheduled:    enabled")
+	} else {
+		render.Warning("  Scheduled:    disabled (set backup.enabled in config.yaml)")
+	}
+	render.Info(fmt.Sprintf("  Schedule:     %s", status.Schedule))
+	render.Info(fmt.Sprintf("  Destination:  %s", status.Destination))
+	render.Info(fmt.Sprintf("  Retention:    %d", status.Retention))
+	render.Info(fmt.Sprintf("  Encrypted:    %t", status.Encrypted))
+
+	render.Blank()
+	render.Info("Last Run")
+	if status.LastRun.IsZero() {
+		render.Info("  Never")
+	} else {
+		render.Info(fmt.Sprintf("  Time:         %s", formatBackupTime(status.LastRun)))
+		if status.LastStatus == backup.StatusSucceeded {
+			render.Success(fmt.Sprintf("  Status:       %s", status.LastStatus))
+			render.Info(fmt.Sprintf("  Archive:      %s", status.LastArchive))
+		} else {
+			render.Error(fmt.Sprintf("  Status:       %s", status.LastStatus))
+			render.Error(fmt.Sprintf("  Error:        %s", status.LastError))
+			if !status.LastSuccess.IsZero() {
+				render.Info(fmt.Sprintf("  Last success: %s (%s)", formatBackupTime(status.LastSuccess), status.LastArchive))
+			}
+		}
+	}
+
+	render.Blank()
+	if !cfg.Enabled {
+		render.Info("Next due:       - (scheduled backups disabled)")
+	} else if !time.Now().Before(status.NextDue) {
+		render.Warning("Next due:       now (runs with the next dvm command)")
+	} else {
+		render.Info(fmt.Sprintf("Next due:       %s", formatBackupTime(status.NextDue)))
+	}
+	return nil
+}
+
+// newBackupRunner converts the config section into a backup.Runner, filling
+// path defaults from the standard ~/.devopsmaestro layout.
+//
+// Retention is read from backup.retention in config.yaml (default 7, registered in
+// config.LoadConfig) rather than the database defaults table: the policy
+// must be known before the database is opened, and a restore replaces the
+// defaults table, which would silently change the policy along with it.
+func newBackupRunner(cfg config.BackupConfig) (*backup.Runner, error) {
+	pc, err := paths.Default()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	dest := backup.DestinationOptions{
+		Type:         cfg.Destination.Type,
+		Path:         cfg.Destination.Path,
+		Endpoint:     cfg.Destination.Endpoint,
+		Bucket:       cfg.Destination.Bucket,
+		Prefix:       cfg.Destination.Prefix,
+		Region:       cfg.Destination.Region,
+		AccessKeyEnv: cfg.Destination.AccessKeyEnv,
+		SecretKeyEnv: cfg.Destination.SecretKeyEnv,
+		Repo:         cfg.Destination.Repo,
+		Branch:       cfg.Destination.Branch,
+	}
+	if dest.Path == "" && (dest.Type == "" || dest.Type == backup.DestinationLocal) {
+		dest.Path = pc.BackupsDir()
+	}
+	return backup.New(backup.Options{
+		Schedule:    cfg.Schedule,
+		Retention:   cfg.Retention,
+		Destination: dest,
+		Encryption: backup.EncryptionOptions{
+			Enabled:       cfg.Encryption.Enabled,
+			PassphraseEnv: cfg.Encryption.PassphraseEnv,
+		},
+		StateFile: filepath.Join(pc.Root(), "backup-state.json"),
+	})
+}
+
+// snapshotDatabase returns a SnapshotFunc that copies the live database with
+// the SQLite online backup API, which produces a consistent file even while
+// other connections are open, and then verifies the copy with
+// db.VerifySnapshot. migrationsFS may be nil to check integrity only.
+func snapshotDatabase(ctx context.Context, driver db.Driver, migrationsFS fs.FS) backup.SnapshotFunc {
+	return func(path string) error {
+		if driver == nil {
+			return fmt.Errorf("database driver not available")
+		}
+		backuper, ok := driver.(db.Backuper)
+		if !ok {
+			return fmt.Errorf("backups are not supported for %s databases", driver.Type())
+		}
+		if err := backuper.BackupTo(ctx, path); err != nil {
+			return err
+		}
+		if _, err := db.VerifySnapshot(path, migrationsFS); err != nil {
+			return fmt.Errorf("snapshot failed verification: %w", err)
+		}
+		return nil
+	}
+}
+
+// scheduledBackupLockTTL is how long a scheduled backup lock is honored. A
+// lock older than this belongs to a run that died without releasing it.
+const scheduledBackupLockTTL = time.Hour
+
+// skipScheduledBackup reports whether cmd must not start a scheduled backup:
+// the backup commands themselves, and the context commands that shell
+// prompts run on every render.
+func skipScheduledBackup(cmd *cobra.Command) bool {
+	cmdPath := cmd.CommandPath()
+	if strings.HasPrefix(cmdPath, "dvm admin backup") || strings.HasPrefix(cmdPath, "dvm admin restore") {
+		return true
+	}
+	switch cmdPath {
+	case "dvm context current", "dvm context env", "dvm context starship":
+		return true
+	}
+	return false
+}
+
+// maybeRunScheduledBackup starts 'dvm admin backup run --scheduled' in a
+// detached background process when scheduled backups are enabled and one is
+// due. Only the state file is read here; the snapshot and upload happen in
+// the background process, so a slow S3 upload or git push never delays the
+// command. Failures are logged, never returned.
+func maybeRunScheduledBackup(cmd *cobra.Command) {
+	cfg := config.GetConfig().Backup
+	if !cfg.Enabled || skipScheduledBackup(cmd) {
+		return
+	}
+	runner, err := newBackupRunner(cfg)
+	if err != nil {
+		slog.Warn("scheduled backup skipped: invalid backup config", "error", err)
+		return
+	}
+	if due, err := runner.Due(); err != nil || !due {
+		return
+	}
+	pc, err := paths.Default()
+	if err != nil {
+		return
+	}
+	lock := scheduledBackupLockPath(pc)
+	if !acquireScheduledBackupLock(lock) {
+		return // a scheduled backup is already running
+	}
+	if err := startScheduledBackup(pc); err != nil {
+		os.Remove(lock)
+		slog.Warn("scheduled backup could not be started", "error", err)
+	}
+}
+
+func scheduledBackupLockPath(pc *paths.PathConfig) string {
+	return filepath.Join(pc.Root(), "backup.lock")
+}
+
+// acquireScheduledBackupLock creates the lock file, replacing a stale one.
+// It returns false when another scheduled backup holds the lock.
+func acquireScheduledBackupLock(path string) bool {
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return true
+		}
+		info, statErr := os.Stat(path)
+		if statErr != nil || time.Since(info.ModTime()) < scheduledBackupLockTTL {
+			return false
+		}
+		os.Remove(path)
+	}
+	return false
+}
+
+// startScheduledBackup re-executes dvm as 'admin backup run --scheduled' in
+// its own session, with output appended to logs/backup.log, and does not
+// wait for it.
+func startScheduledBackup(pc *paths.PathConfig) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(pc.LogsDir(), 0700); err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(filepath.Join(pc.LogsDir(), "backup.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	child := exec.Command(exe, "admin", "backup", "run", "--scheduled")
+	child.Stdout = logFile
+	child.Stderr = logFile
+	child.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := child.Start(); err != nil {
+		return err
+	}
+	return child.Process.Release()
+}
+
+// runScheduledBackup is the background half of maybeRunScheduledBackup. It
+// releases the lock when done and re-checks the schedule, since another run
+// may have finished in the meantime.
+func runScheduledBackup(cmd *cobra.Command) error {
+	if pc, err := paths.Default(); err == nil {
+		defer os.Remove(scheduledBackupLockPath(pc))
+	}
+	runner, err := newBackupRunner(config.GetConfig().Backup)
+	if err != nil {
+		return err
+	}
+	if due, err := runner.Due(); err != nil || !due {
+		return err
+	}
+	return backupNow(cmd, "")
+}
+
+func formatBackupTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05 MST")
+}
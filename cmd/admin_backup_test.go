@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSnapshotDatabase(t *testing.T) {
+	driver, err := db.NewDriver(db.DriverConfig{Type: db.DriverMemory})
+	if err != nil {
+		t.Fatalf("NewDriver() error = %v", err)
+	}
+	if err := driver.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer driver.Close()
+	if _, err := driver.Execute("CREATE TABLE t (v TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "snap.db")
+	if err := snapshotDatabase(context.Background(), driver, nil)(path); err != nil {
+		t.Fatalf("snapshot error = %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		t.Errorf("snapshot not written: %v", err)
+	}
+
+	if err := snapshotDatabase(context.Background(), nil, nil)(path); err == nil {
+		t.Error("expected error for nil driver")
+	}
+}
+
+func TestNewBackupRunner_Defaults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	runner, err := newBackupRunner(config.BackupConfig{})
+	if err != nil {
+		t.Fatalf("newBackupRunner() error = %v", err)
+	}
+	status, err := runner.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Schedule != "daily" || status.Retention != 7 {
+		t.Errorf("defaults = %s/%d, want daily/7", status.Schedule, status.Retention)
+	}
+	if want := filepath.Join(os.Getenv("HOME"), ".devopsmaestro", "backups"); status.Destination != want {
+		t.Errorf("Destination = %q, want %q", status.Destination, want)
+	}
+
+	if _, err := newBackupRunner(config.BackupConfig{Schedule: "fortnightly"}); err == nil {
+		t.Error("expected error for invalid schedule")
+	}
+}
+
+func TestAdminBackupToFileAndRestore(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	driver, err := db.NewDriver(db.DriverConfig{Type: db.DriverSQLite, Path: filepath.Join(home, "live.db")})
+	if err != nil {
+		t.Fatalf("NewDriver() error = %v", err)
+	}
+	if err := driver.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer driver.Close()
+	for _, q := range []string{"CREATE TABLE items (name TEXT)", "INSERT INTO items VALUES ('original')"} {
+		if _, err := driver.Execute(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{}
+	AddForceConfirmFlag(cmd)
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(db.NewSQLDataStore(driver, nil))))
+
+	archive := filepath.Join(home, "dvm.db.gz")
+	if err := backupNow(cmd, archive); err != nil {
+		t.Fatalf("backupNow() error = %v", err)
+	}
+
+	if _, err := driver.Execute("UPDATE items SET name = 'changed'"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Flags().Set("force", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAdminRestore(cmd, []string{archive}); err != nil {
+		t.Fatalf("runAdminRestore() error = %v", err)
+	}
+
+	var name string
+	if err := driver.QueryRow("SELECT name FROM items").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "original" {
+		t.Errorf("after restore name = %q, want original", name)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".devopsmaestro", "backups", "pre-restore.db.gz")); err != nil {
+		t.Errorf("pre-restore copy not written: %v", err)
+	}
+
+	garbage := filepath.Join(home, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runAdminRestore(cmd, []string{garbage}); err == nil {
+		t.Error("runAdminRestore(garbage) expected error")
+	}
+}
+
+func TestReadRestoreSource_LatestKeyword(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Chdir(t.TempDir())
+
+	// A local file named "latest" must not shadow the keyword
+	if err := os.WriteFile("latest", []byte("SQLite format 3\x00"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	runner, err := newBackupRunner(config.BackupConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	if _, _, err := readRestoreSource(cmd, runner, "latest"); err == nil {
+		t.Error("readRestoreSource(latest) with no archives succeeded; read the local file instead of the destination")
+	}
+	source, _, err := readRestoreSource(cmd, runner, "./latest")
+	if err != nil {
+		t.Fatalf("readRestoreSource(./latest) error = %v", err)
+	}
+	if filepath.Base(source) != "latest" {
+		t.Errorf("readRestoreSource(./latest) source = %q", source)
+	}
+}
+
+func TestSkipScheduledBackup(t *testing.T) {
+	root := &cobra.Command{Use: "dvm"}
+	add := func(parent *cobra.Command, use string) *cobra.Command {
+		c := &cobra.Command{Use: use}
+		parent.AddCommand(c)
+		return c
+	}
+	ctxCmd := add(root, "context")
+	admin := add(root, "admin")
+	backupCmd := add(admin, "backup")
+
+	tests := []struct {
+		cmd  *cobra.Command
+		want bool
+	}{
+		{add(ctxCmd, "current"), true},
+		{add(ctxCmd, "starship"), true},
+		{add(backupCmd, "run"), true},
+		{add(admin, "restore"), true},
+		{add(root, "build"), false},
+	}
+	for _, tt := range tests {
+		if got := skipScheduledBackup(tt.cmd); got != tt.want {
+			t.Errorf("skipScheduledBackup(%q) = %v, want %v", tt.cmd.CommandPath(), got, tt.want)
+		}
+	}
+}
+
+func TestAcquireScheduledBackupLock(t *testing.T) {
+	lock := filepath.Join(t.TempDir(), "backup.lock")
+	if !acquireScheduledBackupLock(lock) {
+		t.Fatal("first acquire failed")
+	}
+	if acquireScheduledBackupLock(lock) {
+		t.Error("second acquire succeeded while the lock is held")
+	}
+
+	// A lock left behind by a dead run is replaced once it is stale
+	old := time.Now().Add(-2 * scheduledBackupLockTTL)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if !acquireScheduledBackupLock(lock) {
+		t.Error("acquire over a stale lock failed")
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+var migrateProjectsDryRun bool
+
+// migrateProjectsCmd converts the projects of an old database into apps.
+var migrateProjectsCmd = &cobra.Command{
+	Use:   "migrate-projects --domain <name>",
+	Short: "Convert legacy projects into apps",
+	Long: `Convert the projects of a database created before apps replaced them into
+apps under a domain, then apply the migrations that retire the legacy projects
+table and context.active_project_id.
+
+Each project becomes an app with the same name, path, and description; an
+app of that name already in the domain is used instead. Workspaces and the
+active context that reference a project move to its app. Until every project
+is converted, dvm stops applying migrations before the one that drops them.
+
+Examples:
+  dvm admin migrate-projects --domain legacy
+  dvm admin migrate-projects --domain legacy -e platform
+  dvm admin migrate-projects --domain legacy --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateProjects,
+}
+
+func init() {
+	migrateProjectsCmd.Flags().StringP("domain", "d", "", "Domain to create the apps in (required)")
+	migrateProjectsCmd.Flags().StringP("ecosystem", "e", "", "Ecosystem of the domain (default: the active ecosystem)")
+	_ = migrateProjectsCmd.MarkFlagRequired("domain")
+	AddDryRunFlag(migrateProjectsCmd, &migrateProjectsDryRun)
+
+	adminCmd.AddCommand(migrateProjectsCmd)
+}
+
+func runMigrateProjects(cmd *cobra.Command, args []string) error {
+	domainName, _ := cmd.Flags().GetString("domain")
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := migrationDriver(cmd)
+	if err != nil {
+		return err
+	}
+	migrationsFS, err := getMigrationsFSFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	// Bring the schema up to the retiring migration, which waits for us
+	var legacy *db.LegacyProjectsError
+	if err := db.RunMigrations(driver, migrationsFS); err != nil && !errors.As(err, &legacy) {
+		return err
+	}
+	projects, err := ds.ListLegacyProjects()
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		render.Success("No legacy projects to convert; the schema is up to date.")
+		return nil
+	}
+
+	ecosystem, err := resolveEcosystemForDomain(ds, cmd)
+	if err != nil {
+		return err
+	}
+	domain, err := ds.GetDomainByName(sql.NullInt64{Int64: int64(ecosystem.ID), Valid: true}, domainName)
+	if err != nil {
+		return fmt.Errorf("domain '%s' not found in ecosystem '%s': %w", domainName, ecosystem.Name, err)
+	}
+	domainID := sql.NullInt64{Int64: int64(domain.ID), Valid: true}
+
+	tableData := render.TableData{
+		Headers: []string{"PROJECT", "PATH", "APP", "WORKSPACES"},
+		Rows:    make([][]string, len(projects)),
+	}
+	existing := make(map[int]*models.App, len(projects))
+	for i, p := range projects {
+		target := "new"
+		if app, err := ds.GetAppByName(domainID, p.Name); err == nil {
+			existing[p.ID] = app
+			target = "existing"
+		}
+		tableData.Rows[i] = []string{p.Name, p.Path, fmt.Sprintf("%s/%s (%s)", domain.Name, p.Name, target), fmt.Sprintf("%d", p.Workspaces)}
+	}
+	if err := render.OutputWith("", tableData, render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	if migrateProjectsDryRun {
+		render.Info(fmt.Sprintf("Dry run: %d project(s) would be converted into apps in domain '%s'", len(projects), domain.Name))
+		return nil
+	}
+
+	for _, p := range projects {
+		app := existing[p.ID]
+		if app == nil {
+			app = &models.App{
+				Name:        p.Name,
+				Path:        p.Path,
+				DomainID:    domainID,
+				Description: sql.NullString{String: p.Description, Valid: p.Description != ""},
+			}
+			if err := ds.CreateApp(app); err != nil {
+				return fmt.Errorf("failed to create app for project '%s': %w", p.Name, err)
+			}
+		} else if app.Path != p.Path && p.Path != "" {
+			render.Warningf("App '%s' already exists with path %s; project path %s is not kept", app.Name, app.Path, p.Path)
+		}
+		if err := ds.RetireLegacyProject(p.ID, app.ID); err != nil {
+			return fmt.Errorf("failed to convert project '%s': %w", p.Name, err)
+		}
+		render.Success(fmt.Sprintf("  Project '%s' converted to app '%s'", p.Name, app.Name))
+	}
+
+	if err := db.RunMigrations(driver, migrationsFS); err != nil {
+		return err
+	}
+	render.Successf("Converted %d project(s) into apps in domain '%s' and retired the projects table", len(projects), domain.Name)
+	return nil
+}
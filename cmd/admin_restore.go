@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/backup"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// adminRestoreCmd replaces the database with a backup.
+var adminRestoreCmd = &cobra.Command{
+	Use:   "restore <file|archive|latest>",
+	Short: "Restore the dvm database from a backup",
+	Long: `Restore the dvm database from a backup.
+
+The argument is a file on disk (an archive written by 'dvm admin backup', or
+a plain SQLite database), the name of an archive at the configured backup
+destination, or 'latest' for the newest archive there ('latest' always means
+the destination; use ./latest for a local file of that name). Encrypted archives are
+decrypted with the passphrase in $DVM_BACKUP_PASSPHRASE (or the env var named
+by backup.encryption.passphraseEnv).
+
+Before anything is replaced, the backup must pass an integrity check and its
+schema version must not be newer than this build supports. Backups from an
+older dvm are migrated forward after the restore. The current database is
+first saved to pre-restore.db.gz in the backups directory. The backup is then
+copied in with the SQLite online backup API, so no file is swapped underneath
+running commands.
+
+Examples:
+  dvm admin restore latest
+  dvm admin restore devopsmaestro-20260101T000000Z.db.gz
+  dvm admin restore ~/dvm.db.gz --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdminRestore,
+}
+
+func init() {
+	AddForceConfirmFlag(adminRestoreCmd)
+	adminCmd.AddCommand(adminRestoreCmd)
+}
+
+func runAdminRestore(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+
+	cfg := config.GetConfig().Backup
+	runner, err := newBackupRunner(cfg)
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	driver := ds.Driver()
+	backuper, ok := driver.(db.Backuper)
+	if !ok {
+		return fmt.Errorf("restore is not supported for this database")
+	}
+	migrationsFS, _ := getMigrationsFSFromContext(cmd.Context())
+
+	source, data, err := readRestoreSource(cmd, runner, args[0])
+	if err != nil {
+		return err
+	}
+	var passphrase string
+	if backup.IsEncrypted(data) {
+		if passphrase, err = runner.Passphrase(); err != nil {
+			return fmt.Errorf("%s is encrypted: %w", source, err)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "dvm-restore-")
+	if err != nil {
+		return fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	restorePath := filepath.Join(tmpDir, "devopsmaestro.db")
+	if err := backup.Extract(data, passphrase, restorePath); err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+	info, err := db.VerifySnapshot(restorePath, migrationsFS)
+	if err != nil {
+		return fmt.Errorf("%s failed verification: %w", source, err)
+	}
+	render.Success(fmt.Sprintf("%s passed integrity check (schema version %d)", source, info.SchemaVersion))
+
+	// The pre-restore copy is encrypted like any other archive, so a missing
+	// passphrase must stop the restore before anything is replaced.
+	if runner.Encrypted() {
+		if _, err := runner.Passphrase(); err != nil {
+			return fmt.Errorf("cannot save the current database before restoring: %w", err)
+		}
+	}
+
+	confirmed, err := confirmDelete(fmt.Sprintf("Replace the current database with %s?", source), force)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	safetyPath, err := savePreRestoreCopy(cmd, runner, driver)
+	if err != nil {
+		return fmt.Errorf("failed to save the current database before restoring: %w", err)
+	}
+	render.Info(fmt.Sprintf("Current database saved to %s", safetyPath))
+
+	if err := backuper.RestoreFrom(cmd.Context(), restorePath); err != nil {
+		return fmt.Errorf("restore failed: %w (the previous database is in %s)", err, safetyPath)
+	}
+	if info.NeedsMigration() {
+		render.Progress(fmt.Sprintf("Migrating schema from version %d to %d...", info.SchemaVersion, info.LatestVersion))
+		if err := db.RunMigrations(driver, migrationsFS); err != nil {
+			return fmt.Errorf("restored, but migration failed: %w", err)
+		}
+	}
+	render.Successf("Database restored from %s", source)
+	return nil
+}
+
+// readRestoreSource loads a restore argument: a file on disk, or an archive
+// (or "latest") at the configured destination. It returns a label for
+// messages and the raw contents.
+func readRestoreSource(cmd *cobra.Command, runner *backup.Runner, arg string) (string, []byte, error) {
+	if arg == backup.LatestArchive {
+		return runner.Fetch(cmd.Context(), arg)
+	}
+	path, err := db.ExpandPath(arg)
+	if err != nil {
+		path = arg
+	}
+	if info, err := os.Stat(path); err == nil && !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return path, data, nil
+	}
+	name, data, err := runner.Fetch(cmd.Context(), arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, data, nil
+}
+
+// savePreRestoreCopy archives the current database to pre-restore.db.gz in
+// the backups directory. The name does not match backup.ArchivePrefix, so
+// retention never prunes it, and each restore replaces the previous copy.
+// The .enc suffix follows the same setting that decides whether Export
+// encrypts.
+func savePreRestoreCopy(cmd *cobra.Command, runner *backup.Runner, driver db.Driver) (string, error) {
+	pc, err := paths.Default()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(pc.BackupsDir(), 0700); err != nil {
+		return "", err
+	}
+	name := "pre-restore" + backup.ArchiveExt
+	if runner.Encrypted() {
+		name += backup.EncryptedExt
+	}
+	return runner.Export(snapshotDatabase(cmd.Context(), driver, nil), filepath.Join(pc.BackupsDir(), name))
+}
@@ -10,6 +10,7 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/appdetect"
 	themeresolver "devopsmaestro/pkg/colors/resolver"
 	"devopsmaestro/pkg/mirror"
 	"devopsmaestro/pkg/resource/handlers"
@@ -27,6 +28,8 @@ var (
 	appPath        string
 	appFromCwd     bool
 	appRepo        string
+	appDetect      bool
+	appWorkspace   string
 )
 
 // Dry-run flags for app commands
@@ -49,6 +52,13 @@ Source Location (choose one):
   --path <path>       Use local filesystem path
   --repo <url|name>   Use git repository (URL or GitRepo name)
 
+With --detect, the source (--path or --from-cwd) is inspected: a root
+manifest (go.mod, Cargo.toml, package.json, pyproject.toml, ...) sets the
+app's language and version, the app's Dockerfile or the language becomes its
+build config, and the language's nvim package (LSP set) is selected. dvm then
+offers to create a 'dev' workspace ready for 'dvm build'; --workspace <name>
+creates it without asking.
+
 Examples:
   # Create an app from the current directory
   dvm create app my-api --from-cwd
@@ -71,6 +81,9 @@ Examples:
   # Create with description
   dvm create app my-api --from-cwd --description "REST API service"
 
+  # Detect language, build config, and nvim package, and create a workspace
+  dvm create app my-api --from-cwd --detect --workspace dev
+
 Next Steps:
   1. Create a workspace for this app:
      dvm create workspace main
@@ -109,6 +122,17 @@ Next Steps:
 		if flagsSet > 1 {
 			return fmt.Errorf("flags --from-cwd, --path, and --repo are mutually exclusive")
 		}
+		if appDetect && appRepo != "" {
+			return fmt.Errorf("--detect inspects a local checkout; use --path or --from-cwd")
+		}
+		if appWorkspace != "" {
+			if !appDetect {
+				return fmt.Errorf("--workspace requires --detect")
+			}
+			if err := ValidateResourceName(appWorkspace, "workspace"); err != nil {
+				return err
+			}
+		}
 
 		// Variables to track GitRepo if using --repo
 		var gitRepoID *int
@@ -196,6 +220,24 @@ Next Steps:
 
 		render.Progress(fmt.Sprintf("Creating app '%s' in domain '%s'...", appName, domain.Name))
 
+		var detection *appdetect.Result
+		if appDetect {
+			detection, err = appdetect.Detect(path)
+			if err != nil {
+				return fmt.Errorf("failed to inspect %s: %w", path, err)
+			}
+			// A package declared on the domain or ecosystem wins over the
+			// language default, so the app keeps inheriting it
+			inheritedPkg, source := inheritedNvimPackage(ds, domain.ID)
+			if inheritedPkg != "" {
+				detection.NvimPackage = ""
+			}
+			renderDetection(detection)
+			if inheritedPkg != "" {
+				render.Info(fmt.Sprintf("Nvim package: %s (inherited from %s)", inheritedPkg, source))
+			}
+		}
+
 		// Dry-run: preview what would be created
 		if createAppDryRun {
 			render.Plain(fmt.Sprintf("Would create app %q in domain %q (ecosystem %q)", appName, domain.Name, ecosystemName))
@@ -203,6 +245,9 @@ Next Steps:
 			if gitRepoName != "" {
 				render.Plain(fmt.Sprintf("  gitrepo: %s", gitRepoName))
 			}
+			if appWorkspace != "" {
+				render.Plain(fmt.Sprintf("  workspace: %s", appWorkspace))
+			}
 			return nil
 		}
 
@@ -225,6 +270,12 @@ Next Steps:
 			app.GitRepoID = sql.NullInt64{Int64: int64(*gitRepoID), Valid: true}
 		}
 
+		if detection != nil {
+			if err := detection.Apply(app); err != nil {
+				return fmt.Errorf("failed to apply detected settings: %w", err)
+			}
+		}
+
 		if err := ds.CreateApp(app); err != nil {
 			return fmt.Errorf("failed to create app: %w", err)
 		}
@@ -254,6 +305,27 @@ Next Steps:
 			render.Success(fmt.Sprintf("Set '%s' as active app", appName))
 		}
 
+		if detection != nil {
+			workspaceName := appWorkspace
+			if workspaceName == "" {
+				workspaceName = offerScaffoldWorkspace()
+			}
+			if workspaceName != "" {
+				workspace, err := scaffoldWorkspace(ds, createdApp, workspaceName)
+				if err != nil {
+					return err
+				}
+				render.Success(fmt.Sprintf("Workspace '%s' created", workspaceName))
+				if err := ds.SetActiveWorkspace(&workspace.ID); err != nil {
+					render.Warning(fmt.Sprintf("Failed to set active workspace: %v", err))
+				}
+				render.Blank()
+				render.Info("Next step:")
+				render.Info("  dvm build && dvm attach")
+				return nil
+			}
+		}
+
 		render.Blank()
 		render.Info("Next steps:")
 		render.Info("  1. Create a workspace for this app:")
@@ -352,6 +424,14 @@ func getApps(cmd *cobra.Command) error {
 		}
 	}
 
+	archived, err := loadArchivedIDs(ds)
+	if err != nil {
+		return err
+	}
+	if archived != nil {
+		apps = withoutArchived(apps, archived.Apps, func(a *models.App) int { return a.ID })
+	}
+
 	// Filter by system if --system flag is provided
 	if systemFlag != "" {
 		// Global system lookup: find system across all domains (#287)
@@ -384,10 +464,10 @@ func getApps(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		handlers.RegisterAll()
 		if len(apps) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
 		// Convert app models to Resource objects for BuildList
 		appResources := make([]resource.Resource, len(apps))
@@ -427,7 +507,7 @@ func getApps(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("failed to build resource list: %w", err)
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	if len(apps) == 0 {
@@ -435,7 +515,7 @@ func getApps(cmd *cobra.Command) error {
 		if allFlag {
 			msg = "No apps found"
 		}
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: msg,
 			EmptyHints:   []string{"dvm create app <name> --path <path>"},
@@ -536,7 +616,7 @@ func getApps(cmd *cobra.Command) error {
 		renderFormat = "table"
 	}
 
-	return render.OutputWith(renderFormat, tableData, render.Options{
+	return outputList(renderFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -623,7 +703,7 @@ func getApp(cmd *cobra.Command, name string) error {
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		workspaces, _ := ds.ListWorkspacesByApp(app.ID)
 		wsNames := make([]string, len(workspaces))
 		for j, w := range workspaces {
@@ -638,7 +718,7 @@ func getApp(cmd *cobra.Command, name string) error {
 		}
 		yamlDoc := app.ToYAML(domain.Name, wsNames, gitRepoName, systemName)
 		yamlDoc.Metadata.Ecosystem = ecosystemName
-		return render.OutputWith(getOutputFormat, yamlDoc, render.Options{})
+		return outputList(getOutputFormat, yamlDoc, render.Options{})
 	}
 
 	// For human output, show detail view
@@ -669,7 +749,7 @@ func getApp(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Created", Value: app.CreatedAt.Format("2006-01-02 15:04:05")},
 	)
 
-	err = render.OutputWith(getOutputFormat, kvData, render.Options{
+	err = outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "App Details",
 	})
@@ -823,6 +903,8 @@ func init() {
 	createAppCmd.Flags().StringVar(&appPath, "path", "", "Path to the app source code")
 	createAppCmd.Flags().BoolVar(&appFromCwd, "from-cwd", false, "Use current working directory as app path")
 	createAppCmd.Flags().StringVar(&appRepo, "repo", "", "Git repository (URL or existing GitRepo name)")
+	createAppCmd.Flags().BoolVar(&appDetect, "detect", false, "Detect language, build config, and nvim package from the source")
+	createAppCmd.Flags().StringVar(&appWorkspace, "workspace", "", "With --detect, create this workspace without asking")
 	AddDryRunFlag(createAppCmd, &createAppDryRun)
 
 	// App get/delete flags
@@ -847,7 +929,7 @@ func getActiveApp(ds db.DataStore) (*models.App, error) {
 	}
 
 	if ctx.ActiveAppID == nil {
-		return nil, fmt.Errorf("no active app set")
+		return nil, db.NewErrNoActiveContext("app")
 	}
 
 	app, err := ds.GetAppByID(*ctx.ActiveAppID)
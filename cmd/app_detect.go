@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/appdetect"
+	"devopsmaestro/pkg/interactive"
+	ws "devopsmaestro/pkg/workspace"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// defaultScaffoldWorkspace is the workspace 'dvm create app --detect' offers
+// to create.
+const defaultScaffoldWorkspace = "dev"
+
+// renderDetection prints what app detection found.
+func renderDetection(result *appdetect.Result) {
+	if !result.Detected() {
+		render.Warning("No language detected; set it later with 'dvm apply' (spec.language)")
+	} else {
+		language := result.Language.Name
+		if result.Language.Version != "" {
+			language += " " + result.Language.Version
+		}
+		if result.Manifest != "" {
+			language += fmt.Sprintf(" (from %s)", result.Manifest)
+		}
+		render.Info(fmt.Sprintf("Detected language: %s", language))
+	}
+	if result.Build.Dockerfile != "" {
+		render.Info(fmt.Sprintf("Build: Dockerfile %s", result.Build.Dockerfile))
+	} else if result.Build.Buildpack != "" {
+		render.Info(fmt.Sprintf("Build: %s buildpack", result.Build.Buildpack))
+	}
+	if result.NvimPackage != "" {
+		render.Info(fmt.Sprintf("Nvim package: %s", result.NvimPackage))
+	}
+}
+
+// offerScaffoldWorkspace asks whether to create a workspace for a detected
+// app. It returns the workspace name, or "" when the user declines or
+// prompting is disabled. --yes accepts the offer.
+func offerScaffoldWorkspace() string {
+	if !interactive.Offer(fmt.Sprintf("Create workspace '%s' for this app?", defaultScaffoldWorkspace)) {
+		return ""
+	}
+	return defaultScaffoldWorkspace
+}
+
+// scaffoldWorkspace creates a workspace for app with the defaults 'dvm create
+// workspace' uses. The workspace inherits the app's detected language and
+// nvim package, so it is ready for 'dvm build'.
+func scaffoldWorkspace(ds db.DataStore, app *models.App, name string) (*models.Workspace, error) {
+	if err := ValidateResourceName(name, "workspace"); err != nil {
+		return nil, err
+	}
+	workspace := &models.Workspace{
+		AppID:     app.ID,
+		Name:      name,
+		ImageName: fmt.Sprintf("dvm-%s-%s:pending", name, app.Name),
+		Status:    "stopped",
+	}
+	if err := ws.PrepareDefaults(workspace, ds); err != nil {
+		return nil, fmt.Errorf("failed to prepare workspace defaults: %w", err)
+	}
+	if err := ds.CreateWorkspace(workspace); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return workspace, nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"database/sql"
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAppCmd_HasDetectFlags(t *testing.T) {
+	detect := createAppCmd.Flags().Lookup("detect")
+	require.NotNil(t, detect)
+	assert.Equal(t, "false", detect.DefValue)
+
+	workspace := createAppCmd.Flags().Lookup("workspace")
+	require.NotNil(t, workspace)
+	assert.Equal(t, "", workspace.DefValue)
+}
+
+func TestScaffoldWorkspace(t *testing.T) {
+	mockStore, domain := setupAppTestContext()
+	app := &models.App{
+		Name:        "api",
+		DomainID:    sql.NullInt64{Int64: int64(domain.ID), Valid: true},
+		Path:        t.TempDir(),
+		NvimPackage: sql.NullString{String: "maestro-go", Valid: true},
+	}
+	require.NoError(t, mockStore.CreateApp(app))
+
+	workspace, err := scaffoldWorkspace(mockStore, app, "dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev", workspace.Name)
+	assert.Equal(t, app.ID, workspace.AppID)
+	assert.Equal(t, "dvm-dev-api:pending", workspace.ImageName)
+	assert.NotEmpty(t, workspace.Slug)
+	assert.True(t, workspace.NvimStructure.Valid, "workspace gets the default nvim structure")
+
+	stored, err := mockStore.ListWorkspacesByApp(app.ID)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+
+	_, err = scaffoldWorkspace(mockStore, app, "")
+	assert.Error(t, err, "workspace names are validated")
+}
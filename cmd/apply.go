@@ -1,8 +1,16 @@
 package cmd
 
 import (
-	"devopsmaestro/pkg/source"
 	"fmt"
+	"os"
+	"path/filepath"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/manifestvars"
+	"devopsmaestro/pkg/overlay"
+	"devopsmaestro/pkg/resource/handlers"
+	"devopsmaestro/pkg/source"
+
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/rmkohlman/MaestroSDK/resource"
 
@@ -39,6 +47,20 @@ Secrets in YAML can be resolved from various providers:
   
 Use inline syntax: ${secret:name} or ${secret:name:provider}
 
+Variables let one manifest serve several ecosystems. ${name} is replaced at
+apply time with the first value found in:
+  1. --set name=value, then --values files (later files win)
+  2. the environment variable DVM_VAR_<NAME>
+  3. the manifest's valuesFrom list (file: or env: entries)
+  4. the default var.<name> (dvm set default var.<name> <value>)
+Use ${name:-fallback} for a fallback and $${name} for a literal ${name}.
+Placeholders without a value are left as they are.
+
+dvm records which manager last set each field of a resource: a manifest
+(dvm apply) or the CLI (dvm set, edit, and patch). If the manifest would
+change or remove a field set with the CLI, the apply fails and lists those
+fields; add them to the manifest, or use --force to overwrite them.
+
 Examples:
   # Apply single file
   dvm apply -f plugin.yaml
@@ -57,14 +79,27 @@ Examples:
   
   # Apply from stdin
   cat plugin.yaml | dvm apply -f -
+
+  # Skip unknown-field checks (e.g. a manifest from a newer dvm)
+  dvm apply -f workspace.yaml --validate=false
+
+  # Overwrite fields changed since with dvm set, edit, or patch
+  dvm apply -f workspace.yaml --force
+
+  # Build and apply an overlay (base + patches for this machine)
+  dvm apply -k overlays/work-laptop
+
+  # Fill in ${...} variables
+  dvm apply -f app.yaml --set language=go --values prod.yaml
   
   # Using secrets (token from keychain for private repos)
   dvm apply -f github:user/private-repo/config.yaml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		files, _ := cmd.Flags().GetStringSlice("filename")
+		overlays, _ := cmd.Flags().GetStringSlice("overlay")
 
-		if len(files) == 0 {
-			// No -f flag provided, show help
+		if len(files) == 0 && len(overlays) == 0 {
+			// No -f or -k flag provided, show help
 			return cmd.Help()
 		}
 
@@ -74,21 +109,48 @@ Examples:
 
 // applyResources applies resources from the given sources using the unified pipeline.
 func applyResources(cmd *cobra.Command, sources []string) error {
+	// Reject unknown fields unless --validate=false
+	validate, _ := cmd.Flags().GetBool("validate")
+	handlers.SetStrict(validate)
+
+	// Fields set with the CLI block the apply unless --force
+	force, _ := cmd.Flags().GetBool("force")
+	handlers.SetFieldManager(handlers.ManagerManifest)
+	handlers.SetForceConflicts(force)
+	defer func() {
+		handlers.SetFieldManager(handlers.ManagerCLI)
+		handlers.SetForceConflicts(false)
+	}()
+
 	// Build resource context
 	ctx, err := buildResourceContext(cmd)
 	if err != nil {
 		return err
 	}
 
+	// ${...} variables from --set, --values, the environment, and defaults
+	vars, err := manifestVarSources(cmd, ctx)
+	if err != nil {
+		return err
+	}
+
+	// Overlays (-k) are built locally into a List
+	overlays, _ := cmd.Flags().GetStringSlice("overlay")
+	for _, dir := range overlays {
+		if err := applyOverlay(ctx, dir, vars); err != nil {
+			return err
+		}
+	}
+
 	for _, src := range sources {
 		// Check if this is a directory source
 		if source.IsDirectory(src) && source.IsURL(src) {
-			if err := applyDirectorySource(ctx, src); err != nil {
+			if err := applyDirectorySource(ctx, src, vars); err != nil {
 				return err
 			}
 		} else {
 			// Single file apply (existing behavior)
-			if err := applyResource(ctx, src); err != nil {
+			if err := applyResource(ctx, src, vars); err != nil {
 				return err
 			}
 		}
@@ -97,8 +159,91 @@ func applyResources(cmd *cobra.Command, sources []string) error {
 	return nil
 }
 
+// manifestVarSources collects the variables --set and --values give and
+// the lookups of the environment and the defaults table.
+func manifestVarSources(cmd *cobra.Command, ctx resource.Context) (manifestvars.Sources, error) {
+	vars := manifestvars.Sources{Values: map[string]string{}, Env: os.LookupEnv}
+	if ds, ok := ctx.DataStore.(db.DataStore); ok {
+		vars.Defaults = func(key string) (string, bool) {
+			value, err := ds.GetDefault(key)
+			return value, err == nil && value != ""
+		}
+	}
+
+	files, _ := cmd.Flags().GetStringArray("values")
+	for _, file := range files {
+		values, err := manifestvars.LoadFile(file)
+		if err != nil {
+			return vars, fmt.Errorf("--values %s: %w", file, err)
+		}
+		for name, value := range values {
+			vars.Values[name] = value
+		}
+	}
+	sets, _ := cmd.Flags().GetStringArray("set")
+	for _, set := range sets {
+		name, value, err := manifestvars.ParseAssignment(set)
+		if err != nil {
+			return vars, fmt.Errorf("--set: %w", err)
+		}
+		vars.Values[name] = value
+	}
+	return vars, nil
+}
+
+// renderManifestVars substitutes variables in data read from src.
+func renderManifestVars(data []byte, src source.Source, vars manifestvars.Sources, displayName string) ([]byte, error) {
+	switch s := src.(type) {
+	case *source.FileSource:
+		vars.Local = true
+		vars.BaseDir = filepath.Dir(s.Path)
+	case *source.StdinSource:
+		vars.Local = true
+	}
+	rendered, err := manifestvars.Render(data, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute variables in %s: %w", displayName, err)
+	}
+	return rendered, nil
+}
+
+// buildOverlay builds the overlay at path, substituting variables in every
+// file it reads.
+func buildOverlay(path string, vars manifestvars.Sources) ([]byte, error) {
+	return overlay.Build(path, overlay.Options{
+		Transform: func(data []byte, file string) ([]byte, error) {
+			v := vars
+			v.Local = true
+			v.BaseDir = filepath.Dir(file)
+			rendered, err := manifestvars.Render(data, v)
+			if err != nil {
+				return nil, fmt.Errorf("failed to substitute variables in %s: %w", file, err)
+			}
+			return rendered, nil
+		},
+	})
+}
+
+// applyOverlay builds the overlay at path and applies its resources.
+func applyOverlay(ctx resource.Context, path string, vars manifestvars.Sources) error {
+	list, err := buildOverlay(path, vars)
+	if err != nil {
+		return err
+	}
+	applied, err := resource.ApplyList(ctx, list)
+	for _, res := range applied {
+		render.Success(fmt.Sprintf("  %s '%s' applied", res.GetKind(), res.GetName()))
+		reportRebuilds(res)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply overlay %s: %w", path, err)
+	}
+	render.Success(fmt.Sprintf("Applied %d resources from overlay %s", len(applied), path))
+	return nil
+}
+
 // applyDirectorySource handles applying all YAML files from a directory source.
-func applyDirectorySource(ctx resource.Context, src string) error {
+func applyDirectorySource(ctx resource.Context, src string, vars manifestvars.Sources) error {
 	// Create the directory source (currently only GitHub directories are supported)
 	dirSource := source.NewGitHubDirectorySource(src)
 
@@ -124,7 +269,7 @@ func applyDirectorySource(ctx resource.Context, src string) error {
 		sourceName := source.GetSourceName(file)
 		render.Info(fmt.Sprintf("Applying %d/%d: %s...", i+1, len(files), sourceName))
 
-		if err := applySourceFile(ctx, file, sourceName); err != nil {
+		if err := applySourceFile(ctx, file, sourceName, vars); err != nil {
 			errors = append(errors, fmt.Errorf("%s: %w", sourceName, err))
 			render.Warning(fmt.Sprintf("  Failed: %v", err))
 		} else {
@@ -143,12 +288,15 @@ func applyDirectorySource(ctx resource.Context, src string) error {
 }
 
 // applySourceFile applies a single resource from a Source interface.
-func applySourceFile(ctx resource.Context, src source.Source, sourceName string) error {
-	// 1. Read data
+func applySourceFile(ctx resource.Context, src source.Source, sourceName string, vars manifestvars.Sources) error {
+	// 1. Read data and substitute variables
 	data, displayName, err := src.Read()
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", sourceName, err)
 	}
+	if data, err = renderManifestVars(data, src, vars, displayName); err != nil {
+		return err
+	}
 
 	// 2. Detect kind from YAML
 	kind, err := resource.DetectKind(data)
@@ -182,17 +330,21 @@ func applySourceFile(ctx resource.Context, src source.Source, sourceName string)
 	}
 
 	render.Success(fmt.Sprintf("  %s '%s' applied", kind, res.GetName()))
+	reportRebuilds(res)
 	return nil
 }
 
 // applyResource applies a single resource from the given source.
-func applyResource(ctx resource.Context, src string) error {
-	// 1. Resolve source and read data
+func applyResource(ctx resource.Context, src string, vars manifestvars.Sources) error {
+	// 1. Resolve source, read data, and substitute variables
 	s := source.Resolve(src)
 	data, displayName, err := s.Read()
 	if err != nil {
 		return fmt.Errorf("failed to read %s: %w", src, err)
 	}
+	if data, err = renderManifestVars(data, s, vars, displayName); err != nil {
+		return err
+	}
 
 	// 2. Detect kind from YAML
 	kind, err := resource.DetectKind(data)
@@ -226,6 +378,7 @@ func applyResource(ctx resource.Context, src string) error {
 	}
 
 	render.Success(fmt.Sprintf("%s '%s' applied (from %s)", kind, res.GetName(), displayName))
+	reportRebuilds(res)
 	return nil
 }
 
@@ -315,6 +468,11 @@ func init() {
 
 	// Add -f flag to root apply command
 	applyCmd.Flags().StringSliceP("filename", "f", []string{}, "Resource YAML file(s) or URL(s) to apply (use '-' for stdin)")
+	applyCmd.Flags().StringSliceP("overlay", "k", []string{}, "Overlay directory(s) to build and apply (see 'dvm overlay')")
+	applyCmd.PersistentFlags().Bool("validate", true, "Reject fields the resource kind doesn't define (--validate=false to skip)")
+	applyCmd.PersistentFlags().Bool("force", false, "Overwrite fields that were last set with a CLI command instead of failing")
+	applyCmd.PersistentFlags().StringArray("set", nil, "Set a ${name} variable in the manifests (name=value, repeatable)")
+	applyCmd.PersistentFlags().StringArray("values", nil, "YAML file of ${name} variables (repeatable; later files win, --set wins over all)")
 
 	// Add nvim subcommand to apply
 	applyCmd.AddCommand(applyNvimCmd)
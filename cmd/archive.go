@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/interactive"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// includeArchived is the --include-archived flag of the get list commands.
+var includeArchived bool
+
+// archiveTarget is an ecosystem, domain, or app named on the command line.
+type archiveTarget struct {
+	Kind string
+	ID   int
+	Path string
+}
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Archive an ecosystem, domain, or app",
+	Long: `Set an ecosystem, domain, or app aside without deleting it.
+
+An archived resource and everything below it are hidden from dvm get
+listings (unless --include-archived is given) and cannot be made active
+with dvm use. Archiving the active context clears it. Nothing is deleted:
+dvm restore brings the resource back, and dvm gc deletes resources that
+have been archived longer than its --archive-retention.
+
+Resources are named like workspaces: a bare name, or a path such as
+eco/domain for a domain and eco/domain/app or domain/app for an app.
+
+Examples:
+  dvm archive ecosystem legacy
+  dvm archive domain legacy/billing
+  dvm archive app payments/old-api
+  dvm get archived                  # List archived resources`,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore an archived ecosystem, domain, or app",
+	Long: `Restore a resource archived with dvm archive, making it and everything
+below it visible again.
+
+Examples:
+  dvm restore ecosystem legacy
+  dvm restore app payments/old-api`,
+}
+
+var getArchivedCmd = &cobra.Command{
+	Use:   "archived",
+	Short: "List archived ecosystems, domains, and apps",
+	Long: `List the resources archived with dvm archive, oldest first, with how long
+ago they were archived.
+
+Examples:
+  dvm get archived
+  dvm get archived -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ds, err := getDataStore(cmd)
+		if err != nil {
+			return err
+		}
+		archived, err := ds.ListArchivedResources()
+		if err != nil {
+			return err
+		}
+		return renderArchived(archived, time.Now())
+	},
+}
+
+// newArchiveKindCmd builds the ecosystem, domain, or app subcommand of
+// dvm archive (restore false) or dvm restore (restore true).
+func newArchiveKindCmd(kind, article string, aliases []string, restore bool) *cobra.Command {
+	verb := "Archive"
+	if restore {
+		verb = "Restore"
+	}
+	return &cobra.Command{
+		Use:     kind + " <name>",
+		Aliases: aliases,
+		Short:   fmt.Sprintf("%s %s %s", verb, article, kind),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ds, err := getDataStore(cmd)
+			if err != nil {
+				return err
+			}
+			target, err := resolveArchiveTarget(ds, kind, args[0])
+			if err != nil {
+				return err
+			}
+			if restore {
+				return restoreResource(ds, target)
+			}
+			return archiveResource(ds, target)
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(restoreCmd)
+	getCmd.AddCommand(getArchivedCmd)
+
+	for _, k := range []struct {
+		kind, article string
+		aliases       []string
+	}{
+		{models.ArchiveKindEcosystem, "an", []string{"eco"}},
+		{models.ArchiveKindDomain, "a", []string{"dom"}},
+		{models.ArchiveKindApp, "an", []string{"application", "a"}},
+	} {
+		archiveCmd.AddCommand(newArchiveKindCmd(k.kind, k.article, k.aliases, false))
+		restoreCmd.AddCommand(newArchiveKindCmd(k.kind, k.article, k.aliases, true))
+	}
+
+	for _, cmd := range []*cobra.Command{getEcosystemsCmd, getDomainsCmd, getAppsCmd, getWorkspacesCmd, getAllCmd} {
+		cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include archived resources and their children")
+	}
+}
+
+// archiveResource archives target and clears the parts of the active
+// context it hides.
+func archiveResource(ds db.DataStore, target archiveTarget) error {
+	if err := ds.ArchiveResource(target.Kind, target.ID); err != nil {
+		return err
+	}
+	render.Success(fmt.Sprintf("Archived %s '%s'", target.Kind, target.Path))
+
+	cleared, err := clearArchivedContext(ds)
+	if err != nil {
+		return err
+	}
+	if cleared != "" {
+		render.Info(fmt.Sprintf("Cleared the active %s context", cleared))
+	}
+	render.Info(fmt.Sprintf("Restore it with: dvm restore %s %s", target.Kind, target.Path))
+	return nil
+}
+
+// restoreResource restores target, warning when an archived parent still
+// hides it.
+func restoreResource(ds db.DataStore, target archiveTarget) error {
+	if err := ds.RestoreResource(target.Kind, target.ID); err != nil {
+		return err
+	}
+	render.Success(fmt.Sprintf("Restored %s '%s'", target.Kind, target.Path))
+
+	ids, err := ds.GetArchivedIDs()
+	if err != nil {
+		return err
+	}
+	hidden := false
+	switch target.Kind {
+	case models.ArchiveKindDomain:
+		hidden = ids.Domains[target.ID]
+	case models.ArchiveKindApp:
+		hidden = ids.Apps[target.ID]
+	}
+	if hidden {
+		render.Warning("A parent of it is still archived; restore the parent to see it in listings")
+	}
+	return nil
+}
+
+// clearArchivedContext clears the active ecosystem, domain, app, and
+// workspace from the first level that archiving hides, and returns that
+// level ("" when nothing was cleared).
+func clearArchivedContext(ds db.DataStore) (string, error) {
+	dbCtx, err := ds.GetContext()
+	if err != nil || dbCtx == nil {
+		return "", nil
+	}
+	ids, err := ds.GetArchivedIDs()
+	if err != nil {
+		return "", err
+	}
+	hidden := func(id *int, set map[int]bool) bool { return id != nil && set[*id] }
+
+	type level struct {
+		name  string
+		clear func(*int) error
+	}
+	levels := []level{
+		{"ecosystem", ds.SetActiveEcosystem},
+		{"domain", ds.SetActiveDomain},
+		{"app", ds.SetActiveApp},
+		{"workspace", ds.SetActiveWorkspace},
+	}
+	start := -1
+	switch {
+	case hidden(dbCtx.ActiveEcosystemID, ids.Ecosystems):
+		start = 0
+	case hidden(dbCtx.ActiveDomainID, ids.Domains):
+		start = 1
+	case hidden(dbCtx.ActiveAppID, ids.Apps):
+		start = 2
+	case hidden(dbCtx.ActiveWorkspaceID, ids.Workspaces):
+		start = 3
+	}
+	if start < 0 {
+		return "", nil
+	}
+	for _, l := range levels[start:] {
+		if err := l.clear(nil); err != nil {
+			return "", fmt.Errorf("failed to clear active %s: %w", l.name, err)
+		}
+	}
+	return levels[start].name, nil
+}
+
+// resolveArchiveTarget finds the ecosystem, domain, or app named by ref: a
+// bare name or a path of its parents and name (eco/domain, eco/domain/app,
+// or domain/app). Several matches are offered as a choice, or listed in the
+// error when prompting is disabled.
+func resolveArchiveTarget(ds db.DataStore, kind, ref string) (archiveTarget, error) {
+	parts := strings.Split(strings.Trim(ref, "/"), "/")
+	name := parts[len(parts)-1]
+	parents := parts[:len(parts)-1]
+
+	var matches []archiveTarget
+	switch kind {
+	case models.ArchiveKindEcosystem:
+		if len(parents) > 0 {
+			return archiveTarget{}, fmt.Errorf("invalid ecosystem name %q", ref)
+		}
+		eco, err := ds.GetEcosystemByName(name)
+		if err != nil {
+			return archiveTarget{}, ErrorWithSuggestion(fmt.Sprintf("ecosystem %q not found", name), SuggestEcosystemNotFound(name)...)
+		}
+		return archiveTarget{Kind: kind, ID: eco.ID, Path: eco.Name}, nil
+
+	case models.ArchiveKindDomain:
+		if len(parents) > 1 {
+			return archiveTarget{}, fmt.Errorf("invalid domain path %q: expected [ecosystem/]domain", ref)
+		}
+		found, err := ds.FindDomainsByName(name)
+		if err != nil {
+			return archiveTarget{}, err
+		}
+		for _, d := range found {
+			ecoName := ""
+			if d.Ecosystem != nil {
+				ecoName = d.Ecosystem.Name
+			}
+			if len(parents) == 1 && parents[0] != ecoName {
+				continue
+			}
+			matches = append(matches, archiveTarget{Kind: kind, ID: d.Domain.ID, Path: joinNonEmpty(ecoName, d.Domain.Name)})
+		}
+
+	case models.ArchiveKindApp:
+		if len(parents) > 2 {
+			return archiveTarget{}, fmt.Errorf("invalid app path %q: expected [[ecosystem/]domain/]app", ref)
+		}
+		found, err := ds.FindAppsByName(name)
+		if err != nil {
+			return archiveTarget{}, err
+		}
+		for _, a := range found {
+			ecoName, domName := "", ""
+			if a.Ecosystem != nil {
+				ecoName = a.Ecosystem.Name
+			}
+			if a.Domain != nil {
+				domName = a.Domain.Name
+			}
+			if len(parents) == 2 && (parents[0] != ecoName || parents[1] != domName) ||
+				len(parents) == 1 && parents[0] != domName {
+				continue
+			}
+			matches = append(matches, archiveTarget{Kind: kind, ID: a.App.ID, Path: joinNonEmpty(ecoName, domName, a.App.Name)})
+		}
+
+	default:
+		return archiveTarget{}, fmt.Errorf("cannot archive %q: only ecosystems, domains, and apps can be archived", kind)
+	}
+
+	switch len(matches) {
+	case 0:
+		return archiveTarget{}, ErrorWithSuggestion(fmt.Sprintf("%s %q not found", kind, ref),
+			SuggestResourceNotFound(kind, ref, fmt.Sprintf("dvm get %ss -A --include-archived", kind))...)
+	case 1:
+		return matches[0], nil
+	}
+
+	paths := make([]string, len(matches))
+	for i, m := range matches {
+		paths[i] = m.Path
+	}
+	i, err := interactive.Choose(fmt.Sprintf("Multiple %ss are named %q:", kind, name), paths)
+	if err == nil {
+		return matches[i], nil
+	}
+	if !errors.Is(err, interactive.ErrNonInteractive) {
+		return archiveTarget{}, err
+	}
+	return archiveTarget{}, ErrorWithSuggestion(
+		fmt.Sprintf("%d %ss are named %q:\n\n  %s", len(matches), kind, name, strings.Join(paths, "\n  ")),
+		"Name it by its path, e.g. "+paths[0])
+}
+
+// joinNonEmpty joins the non-empty parts of a hierarchy path.
+func joinNonEmpty(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// renderArchived renders the output of dvm get archived.
+func renderArchived(archived []*models.ArchivedResource, now time.Time) error {
+	if isStructuredOutput(getOutputFormat) {
+		if archived == nil {
+			archived = []*models.ArchivedResource{}
+		}
+		return outputList(getOutputFormat, archived, render.Options{})
+	}
+	if len(archived) == 0 {
+		return outputList(getOutputFormat, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: "No archived resources",
+		})
+	}
+
+	td := render.TableData{Headers: []string{"KIND", "NAME", "PARENT", "ARCHIVED"}}
+	for _, r := range archived {
+		parent := r.Parent
+		if parent == "" {
+			parent = "-"
+		}
+		td.Rows = append(td.Rows, []string{r.Kind, r.Name, parent, formatDuration(now.Sub(r.ArchivedAt)) + " ago"})
+	}
+	return outputList(getOutputFormat, td, render.Options{Type: render.TypeTable})
+}
+
+// loadArchivedIDs returns the IDs hidden by archiving, or nil when
+// --include-archived is set.
+func loadArchivedIDs(ds db.DataStore) (*models.ArchivedIDs, error) {
+	if includeArchived || ds == nil {
+		return nil, nil
+	}
+	return ds.GetArchivedIDs()
+}
+
+// withoutArchived returns items without those whose ID is in hidden. A nil
+// hidden set keeps every item.
+func withoutArchived[T any](items []T, hidden map[int]bool, id func(T) int) []T {
+	if len(hidden) == 0 {
+		return items
+	}
+	kept := make([]T, 0, len(items))
+	for _, item := range items {
+		if !hidden[id(item)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// checkNotArchived fails with a restore hint when a resource about to be
+// made active is hidden by archiving.
+func checkNotArchived(ds db.DataStore, kind string, id int, name string) error {
+	ids, err := ds.GetArchivedIDs()
+	if err != nil {
+		return err
+	}
+	var hidden bool
+	switch kind {
+	case models.ArchiveKindEcosystem:
+		hidden = ids.Ecosystems[id]
+	case models.ArchiveKindDomain:
+		hidden = ids.Domains[id]
+	case models.ArchiveKindApp:
+		hidden = ids.Apps[id]
+	case "workspace":
+		hidden = ids.Workspaces[id]
+	}
+	if !hidden {
+		return nil
+	}
+	return ErrorWithSuggestion(fmt.Sprintf("%s %q is archived", kind, name),
+		"List archived resources: dvm get archived",
+		"Restore it (or its archived parent) with: dvm restore <kind> <name>")
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/tableview"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveArchiveTarget(t *testing.T) {
+	mock := newSessionMock(t)
+	mock.Domains[2] = &models.Domain{ID: 2, Name: "ops", EcosystemID: sql.NullInt64{Int64: 1, Valid: true}}
+	mock.Apps[3] = &models.App{ID: 3, Name: "api", DomainID: sql.NullInt64{Int64: 2, Valid: true}}
+
+	t.Run("ecosystem", func(t *testing.T) {
+		target, err := resolveArchiveTarget(mock, models.ArchiveKindEcosystem, "eco")
+		require.NoError(t, err)
+		assert.Equal(t, archiveTarget{Kind: "ecosystem", ID: 1, Path: "eco"}, target)
+	})
+
+	t.Run("unique app by bare name", func(t *testing.T) {
+		target, err := resolveArchiveTarget(mock, models.ArchiveKindApp, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "eco/dom/web", target.Path)
+	})
+
+	t.Run("app by path", func(t *testing.T) {
+		target, err := resolveArchiveTarget(mock, models.ArchiveKindApp, "ops/api")
+		require.NoError(t, err)
+		assert.Equal(t, 3, target.ID)
+	})
+
+	t.Run("ambiguous app lists the paths", func(t *testing.T) {
+		_, err := resolveArchiveTarget(mock, models.ArchiveKindApp, "api")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "eco/dom/api")
+		assert.Contains(t, err.Error(), "eco/ops/api")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := resolveArchiveTarget(mock, models.ArchiveKindDomain, "eco/missing")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
+
+func TestArchiveResource_ClearsHiddenContext(t *testing.T) {
+	mock := newSessionMock(t)
+	mock.Context = &models.Context{ID: 1, ActiveEcosystemID: intPtr(1), ActiveDomainID: intPtr(1), ActiveAppID: intPtr(1), ActiveWorkspaceID: intPtr(2)}
+
+	require.NoError(t, archiveResource(mock, archiveTarget{Kind: models.ArchiveKindApp, ID: 1, Path: "eco/dom/api"}))
+
+	assert.Equal(t, 1, *mock.Context.ActiveDomainID, "the domain stays active")
+	assert.Nil(t, mock.Context.ActiveAppID)
+	assert.Nil(t, mock.Context.ActiveWorkspaceID)
+
+	require.NoError(t, restoreResource(mock, archiveTarget{Kind: models.ArchiveKindApp, ID: 1, Path: "eco/dom/api"}))
+	ids, err := mock.GetArchivedIDs()
+	require.NoError(t, err)
+	assert.Empty(t, ids.Apps)
+}
+
+func TestCheckNotArchived(t *testing.T) {
+	mock := newSessionMock(t)
+	require.NoError(t, mock.ArchiveResource(models.ArchiveKindDomain, 1))
+
+	err := checkNotArchived(mock, "workspace", 3, "dev")
+	require.Error(t, err, "workspaces under an archived domain cannot be made active")
+	assert.Contains(t, err.Error(), `workspace "dev" is archived`)
+	assert.NoError(t, checkNotArchived(mock, models.ArchiveKindEcosystem, 1, "eco"))
+}
+
+func TestGetApps_HidesArchived(t *testing.T) {
+	ds := createFullTestDataStore(t)
+	defer ds.Close()
+
+	eco := &models.Ecosystem{Name: "arch-eco"}
+	require.NoError(t, ds.CreateEcosystem(eco))
+	dom := &models.Domain{Name: "arch-dom", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, ds.CreateDomain(dom))
+	for _, name := range []string{"kept", "old"} {
+		app := &models.App{Name: name, Path: "/tmp/" + name, DomainID: sql.NullInt64{Int64: int64(dom.ID), Valid: true}}
+		require.NoError(t, ds.CreateApp(app))
+		if name == "old" {
+			require.NoError(t, ds.ArchiveResource(models.ArchiveKindApp, app.ID))
+		}
+	}
+
+	out := withListView(t, outputName, tableview.Options{})
+	cmd := newPluralGetTestCmd(t, ds)
+	require.NoError(t, cmd.Flags().Set("all", "true"))
+	require.NoError(t, getApps(cmd))
+	assert.Equal(t, "kept", strings.TrimSpace(out.String()))
+
+	includeArchived = true
+	t.Cleanup(func() { includeArchived = false })
+	out.Reset()
+	require.NoError(t, getApps(cmd))
+	assert.Equal(t, []string{"kept", "old"}, strings.Fields(out.String()))
+}
+
+func TestGCArchiveItems(t *testing.T) {
+	mock := newSessionMock(t)
+	now := time.Now()
+	require.NoError(t, mock.ArchiveResource(models.ArchiveKindEcosystem, 1))
+	require.NoError(t, mock.ArchiveResource(models.ArchiveKindApp, 2))
+	mock.Archived[models.ArchiveKindEcosystem][1] = now.Add(-40 * 24 * time.Hour)
+	mock.Archived[models.ArchiveKindApp][2] = now.Add(-31 * 24 * time.Hour)
+
+	items, err := gcArchiveItems(mock, 30*24*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "app", items[0].Kind, "children are removed before their parents")
+	assert.Equal(t, "eco/dom/web", items[0].Name)
+	assert.Equal(t, "ecosystem", items[1].Kind)
+
+	for _, item := range items {
+		_, err := item.remove(context.Background())
+		require.NoError(t, err)
+	}
+	assert.NotContains(t, mock.Apps, 2)
+	assert.NotContains(t, mock.Ecosystems, "eco")
+
+	t.Run("zero retention keeps archived resources", func(t *testing.T) {
+		items, err := gcArchiveItems(newSessionMock(t), 0, now)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("recent archives are kept", func(t *testing.T) {
+		mock := newSessionMock(t)
+		require.NoError(t, mock.ArchiveResource(models.ArchiveKindDomain, 1))
+		items, err := gcArchiveItems(mock, 30*24*time.Hour, now)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
@@ -9,7 +9,6 @@ import (
 	"devopsmaestro/pkg/envvalidation"
 	"devopsmaestro/pkg/mirror"
 	"devopsmaestro/pkg/registry/envinjector"
-	"devopsmaestro/pkg/resolver"
 	ws "devopsmaestro/pkg/workspace"
 	"fmt"
 	"github.com/rmkohlman/MaestroSDK/paths"
@@ -43,6 +42,12 @@ var attachCPUs float64
 // attachMemory holds the memory limit for the container
 var attachMemory string
 
+// attachMux and attachNoMux control the tmux/zellij session on attach
+var attachMux, attachNoMux bool
+
+// attachPorts holds the container ports published on localhost
+var attachPorts []int
+
 // attachCmd attaches to the active workspace
 var attachCmd = &cobra.Command{
 	Use:   "attach",
@@ -59,17 +64,42 @@ The workspace provides your complete dev environment with:
 If the workspace is associated with a GitRepo, the mirror is synced
 automatically before attach unless --no-sync is specified.
 
+If the workspace declares services (spec.services), they are started first
+and the container joins their network, with connection variables such as
+DATABASE_URL in its environment. 'dvm detach' stops them.
+
+Workspaces with spec.runtime: kubernetes run as a pod on a cluster; the
+image is pushed to spec.kubernetes.imageRegistry first when it is set.
+
+With spec.sync.mode one-way or two-way, the app's files are copied into the
+container instead of bind mounted, and synced every few seconds until the
+session ends ('dvm workspace sync status' shows conflicts).
+
+When the ecosystem has a runtime endpoint ('dvm set runtime-endpoint'), the
+workspace runs on that host: the app's files are synced there first, and
+--port ports are forwarded to localhost over ssh until the session ends.
+
+If the app has a session layout ('dvm set layout'), attach enters a named
+tmux/zellij session with its windows, re-attaching if it already exists.
+
+Lifecycle hooks (spec.hooks) run around the start: preStart on the host
+before the container starts, postSync after the first file sync, and
+postStart once the container is ready.
+
 Press Ctrl+D to detach from the workspace.
 
 Flags:
   -e, --ecosystem   Filter by ecosystem name
   -d, --domain      Filter by domain name  
   -a, --app         Filter by app name
-  -w, --workspace   Filter by workspace name
+  -w, --workspace   Workspace name or path (e.g. eco/domain/app/ws)
       --no-sync     Skip syncing git mirror before attach
       --network     Network mode: bridge (default), none, host, or custom name
       --cpus        CPU limit (e.g., 1.5 for 1.5 cores)
       --memory      Memory limit (e.g., 512m, 2g)
+      --port        Publish a container port on localhost (repeatable)
+      --mux         Use a tmux session even if the app has no layout
+      --no-mux      Skip the app's tmux/zellij session layout
 
 Examples:
   dvm attach                           # Use current context, sync mirror
@@ -78,20 +108,22 @@ Examples:
   dvm attach -e healthcare -a portal   # Specify ecosystem and app
   dvm attach -a portal -w staging      # Specify app and workspace name
   dvm attach --network=none            # Isolate container from network
-  dvm attach --cpus=2 --memory=4g      # Limit to 2 CPUs and 4GB RAM`,
+  dvm attach --cpus=2 --memory=4g      # Limit to 2 CPUs and 4GB RAM
+  dvm attach --port 3000 --port 5173   # Reach the dev servers on localhost
+  dvm attach --no-mux                  # Plain shell, skip the session layout`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Emergency mode short-circuits the normal attach flow entirely:
 		// it doesn't require a built workspace image and is meant to work
 		// even when the workspace database / build pipeline is broken.
 		if attachEmergency {
 			if err := runAttachEmergency(cmd); err != nil {
-				render.Error(err.Error())
+				renderError(err)
 				return errSilent
 			}
 			return nil
 		}
 		if err := runAttach(cmd); err != nil {
-			render.Error(err.Error())
+			renderError(err)
 			return errSilent
 		}
 		return nil
@@ -118,7 +150,7 @@ func runAttach(cmd *cobra.Command) error {
 	}
 
 	// Create timeout context from flag
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	if attachTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, attachTimeout)
@@ -142,22 +174,9 @@ func runAttach(cmd *cobra.Command) error {
 		slog.Debug("using hierarchy flags", "ecosystem", attachFlags.Ecosystem,
 			"domain", attachFlags.Domain, "system", attachFlags.System, "app", attachFlags.App, "workspace", attachFlags.Workspace)
 
-		wsResolver := resolver.NewWorkspaceResolver(ds)
-		result, err := wsResolver.Resolve(attachFlags.ToFilter())
+		result, err := resolveWorkspace(ds, attachFlags.ToFilter())
 		if err != nil {
-			// Check if ambiguous and provide helpful output
-			if ambiguousErr, ok := resolver.IsAmbiguousError(err); ok {
-				render.Warning("Multiple workspaces match your criteria")
-				render.Plain(ambiguousErr.FormatDisambiguation())
-				render.Plain(FormatSuggestions(SuggestAmbiguousWorkspace()...))
-				return fmt.Errorf("ambiguous workspace selection")
-			}
-			if resolver.IsNoWorkspaceFoundError(err) {
-				render.Warning("No workspace found matching your criteria")
-				render.Plain(FormatSuggestions(SuggestWorkspaceNotFound("")...))
-				return err
-			}
-			return fmt.Errorf("failed to resolve workspace: %w", err)
+			return err
 		}
 
 		// Use resolved workspace and app
@@ -177,13 +196,11 @@ func runAttach(cmd *cobra.Command) error {
 		var err error
 		appName, err = getActiveAppFromContext(ds)
 		if err != nil {
-			render.Plain(FormatSuggestions(SuggestNoActiveApp()...))
 			return err
 		}
 
 		workspaceName, err = getActiveWorkspaceFromContext(ds)
 		if err != nil {
-			render.Plain(FormatSuggestions(SuggestNoActiveWorkspace()...))
 			return err
 		}
 
@@ -219,7 +236,7 @@ func runAttach(cmd *cobra.Command) error {
 			render.Progress(fmt.Sprintf("Syncing mirror '%s'...", gitRepo.Name))
 			baseDir := getGitRepoBaseDir()
 			mirrorMgr := mirror.NewGitMirrorManager(baseDir)
-			if err := mirrorMgr.Sync(gitRepo.Slug); err != nil {
+			if err := mirrorMgr.Sync(commandContext(cmd), gitRepo.Slug); err != nil {
 				slog.Warn("failed to sync mirror", "repo", gitRepo.Name, "error", err)
 				render.Warning(fmt.Sprintf("Mirror sync failed: %v", err))
 				// Continue with attach - don't fail
@@ -231,137 +248,57 @@ func runAttach(cmd *cobra.Command) error {
 		render.Info("Skipping mirror sync (--no-sync)")
 	}
 
-	// Create container runtime using factory
-	runtime, err := operators.NewContainerRuntime()
-	if err != nil {
-		render.Plain(FormatSuggestions(SuggestNoContainerRuntime()...))
-		return fmt.Errorf("failed to create container runtime: %w", err)
-	}
-
-	slog.Info("using runtime", "type", runtime.GetRuntimeType(), "platform", runtime.GetPlatformName())
-	render.Info(fmt.Sprintf("Platform: %s", runtime.GetPlatformName()))
-
-	// Use image name from workspace
-	imageName := workspace.ImageName
-
-	// Check if workspace has been built (pending tag means not yet built)
-	if strings.HasSuffix(imageName, ":pending") || !strings.HasPrefix(imageName, "dvm-") {
-		slog.Warn("workspace image may not be built", "image", imageName)
-		render.Warning(fmt.Sprintf("Workspace image '%s' has not been built yet.", imageName))
-		render.Plain(FormatSuggestions(SuggestWorkspaceNotBuilt()...))
-		render.Blank()
-		return fmt.Errorf("workspace not built: run 'dvm build' first")
-	}
-
-	// Compute container name using hierarchical naming strategy
-	namingStrategy := operators.NewHierarchicalNamingStrategy()
-	containerName := namingStrategy.GenerateName(ecosystemName, domainName, systemName, appName, workspaceName)
-	slog.Debug("container details", "name", containerName, "image", imageName)
-
-	// Start workspace (handles existing containers automatically)
-	render.Progress("Starting workspace container...")
-
-	// Get correct mount path (workspace repo path if GitRepoID set, else app.Path)
-	mountPath, err := getMountPath(ds, workspace, app.Path)
+	started, err := startWorkspaceContainer(ctx, ds, app, workspace, workspaceStartOptions{
+		EcosystemName: ecosystemName,
+		DomainName:    domainName,
+		SystemName:    systemName,
+		NetworkMode:   attachNetworkMode,
+		CPUs:          attachCPUs,
+		Memory:        attachMemory,
+		Ports:         attachPorts,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get mount path: %w", err)
+		return err
 	}
+	runtime, containerName := started.runtime, started.containerName
 
-	// Mount bare git repos into container and rewrite git remote to local path (#379)
-	var extraMounts []operators.MountConfig
-	if workspace.GitRepoID.Valid {
-		gitRepo, err := ds.GetGitRepoByID(workspace.GitRepoID.Int64)
-		if err == nil && gitRepo != nil {
-			mounts, rewriteErr := setupGitMirrorMounts(gitRepo.Slug, mountPath)
-			if rewriteErr != nil {
-				slog.Warn("failed to setup git mirror mounts", "error", rewriteErr)
-			} else {
-				extraMounts = mounts
-			}
-		}
+	// Keep the copied files in sync until the session ends
+	if started.sync != nil {
+		defer startSyncWatch(commandContext(cmd), started.sync)()
 	}
 
-	// Get workspace container config for UID/GID
-	workspaceYAML := workspace.ToYAML(appName, "")
-	containerUID := workspaceYAML.Spec.Container.UID
-	containerGID := workspaceYAML.Spec.Container.GID
-
-	// Validate container options (network mode and resource limits)
-	if err := operators.ValidateNetworkMode(attachNetworkMode); err != nil {
-		return err
-	}
-	if err := operators.ValidateCPUs(attachCPUs); err != nil {
-		return err
-	}
-	if attachMemory != "" {
-		if _, err := operators.ParseMemoryString(attachMemory); err != nil {
-			return err
+	// Forward the published ports from the remote host for the session
+	if remote := started.remote; remote != nil && len(started.ports) > 0 {
+		stopForwarding, err := operators.ForwardPorts(ctx, remote.target, started.ports)
+		if err != nil {
+			render.Warning(fmt.Sprintf("Port forwarding failed: %v", err))
+		} else {
+			defer stopForwarding()
+			render.Info(fmt.Sprintf("Forwarding ports %s from %s", joinPorts(started.ports), remote.target.Host))
 		}
 	}
 
-	containerID, err := runtime.StartWorkspace(ctx, operators.StartOptions{
-		ImageName:             imageName,
-		WorkspaceName:         workspaceName,
-		ContainerName:         containerName,
-		AppName:               appName,
-		EcosystemName:         ecosystemName,
-		DomainName:            domainName,
-		SystemName:            systemName,
-		AppPath:               mountPath,
-		UID:                   containerUID,
-		GID:                   containerGID,
-		SSHAgentForwarding:    workspace.SSHAgentForwarding,
-		GitCredentialMounting: workspace.GitCredentialMounting,
-		NetworkMode:           attachNetworkMode,
-		CPUs:                  attachCPUs,
-		Memory:                attachMemory,
-		Mounts:                extraMounts,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to start workspace: %w", err)
-	}
-
-	slog.Info("workspace started", "container_id", containerID)
-
 	// Attach to workspace
 	render.Progress("Attaching to workspace...")
 	slog.Info("attaching to container", "name", containerName)
 
-	// Load workspace env
-	wsEnv := workspace.GetEnv()
-
-	// Load theme env
-	themeEnv := map[string]string{}
-	themeName := getThemeName(workspace)
-	if themeName != "" {
-		if te, err := loadThemeEnvVars(themeName); err == nil {
-			themeEnv = te
-			slog.Info("loaded theme colors", "theme", themeName, "colors", len(themeEnv))
-		} else {
-			slog.Warn("failed to load theme colors", "theme", themeName, "error", err)
-		}
-	}
-
-	// Load registry env (WI-3)
-	registryEnv, _ := loadRegistryEnv(ds)
-
-	// Load credential env (WI-2)
-	credentialEnv, credWarnings := loadBuildCredentials(ds, app, workspace)
-	for _, w := range credWarnings {
-		render.Warning(w)
-	}
-
-	// Build the merged env
-	envVars := buildRuntimeEnv(appName, workspaceName, ecosystemName, domainName, systemName, themeEnv, registryEnv, credentialEnv, wsEnv)
-
 	// Build AttachOptions with environment variables for proper terminal and workspace context
 	attachOpts := operators.AttachOptions{
 		WorkspaceID: containerName,
-		Env:         envVars,
+		Env:         started.env,
 		Shell:       "/bin/zsh",
 		LoginShell:  true,
-		UID:         containerUID,
-		GID:         containerGID,
+		UID:         started.uid,
+		GID:         started.gid,
+	}
+
+	// Enter the app's tmux/zellij session instead of a bare shell when it has a layout
+	muxCommand, err := sessionLayoutCommand(ds, app, workspaceName, attachOpts.Shell, attachMux, attachNoMux)
+	if err != nil {
+		render.Warning(fmt.Sprintf("Ignoring session layout: %v", err))
+	} else if muxCommand != nil {
+		slog.Info("attaching to multiplexer session", "app", appName, "workspace", workspaceName)
+		attachOpts.Command = muxCommand
 	}
 
 	// Set terminal tab title via OSC 0 escape sequence (standard xterm protocol).
@@ -369,6 +306,8 @@ func runAttach(cmd *cobra.Command) error {
 	// the tab/window title automatically — no terminal-specific configuration needed.
 	fmt.Fprintf(os.Stderr, "\x1b]0;[dvm] %s/%s\x07", appName, workspaceName)
 
+	recordWorkspaceAttach(ds, workspace.ID)
+
 	if err := runtime.AttachToWorkspace(ctx, attachOpts); err != nil {
 		fmt.Fprintf(os.Stderr, "\x1b]0;\x07") // reset title on error
 		return fmt.Errorf("failed to attach: %w", err)
@@ -507,6 +446,36 @@ func setupGitMirrorMounts(mirrorSlug, workspaceRepoPath string) ([]operators.Mou
 	return mounts, nil
 }
 
+// workspaceSpecMounts converts spec.mounts to bind mounts. Sources may start
+// with ~/ and reference ${APP_PATH} or host environment variables. Mounts
+// that are not bind mounts or whose source is unsafe or missing are skipped
+// with a warning rather than failing the attach.
+func workspaceSpecMounts(mounts []models.MountConfig, appPath string) []operators.MountConfig {
+	home, _ := os.UserHomeDir()
+	var out []operators.MountConfig
+	for _, m := range mounts {
+		if m.Type != "" && m.Type != "bind" {
+			render.Warning(fmt.Sprintf("Skipping mount %s: only bind mounts are supported (got %q)", m.Destination, m.Type))
+			continue
+		}
+		src := os.Expand(m.Source, func(name string) string {
+			if name == "APP_PATH" {
+				return appPath
+			}
+			return os.Getenv(name)
+		})
+		if home != "" && (src == "~" || strings.HasPrefix(src, "~/")) {
+			src = filepath.Join(home, strings.TrimPrefix(src, "~"))
+		}
+		if err := operators.ValidateMountSource(src); err != nil {
+			render.Warning(fmt.Sprintf("Skipping mount %s: %v", m.Destination, err))
+			continue
+		}
+		out = append(out, operators.MountConfig{Type: "bind", Source: src, Destination: m.Destination, ReadOnly: m.ReadOnly})
+	}
+	return out
+}
+
 // rewriteGitRemote sets the origin remote URL in a git repository.
 func rewriteGitRemote(repoPath, newURL string) error {
 	cmd := exec.Command("git", "-C", repoPath, "remote", "set-url", "origin", "--", newURL)
@@ -612,9 +581,13 @@ func init() {
 	AddHierarchyFlags(attachCmd, &attachFlags)
 	attachCmd.Flags().Bool("no-sync", false, "Skip syncing git mirror before attach")
 	attachCmd.Flags().DurationVar(&attachTimeout, "timeout", 10*time.Minute, "Timeout for the attach operation (e.g., 10m, 30s)")
-	attachCmd.Flags().StringVar(&attachNetworkMode, "network", "", "Network mode: bridge (default), none, host, or custom network name")
+	attachCmd.Flags().StringVar(&attachNetworkMode, "network", "", "Network mode: bridge, none, host, or custom network name (default: the app network)")
 	attachCmd.Flags().Float64Var(&attachCPUs, "cpus", 0, "CPU limit (e.g., 1.5 for 1.5 cores; 0 = no limit)")
 	attachCmd.Flags().StringVar(&attachMemory, "memory", "", "Memory limit (e.g., 512m, 2g; empty = no limit)")
+	attachCmd.Flags().IntSliceVar(&attachPorts, "port", nil, "Publish a container port on localhost (repeatable); applies when the container is created")
+	attachCmd.Flags().BoolVar(&attachMux, "mux", false, "Start a tmux session with the default layout when the app has none")
+	attachCmd.Flags().BoolVar(&attachNoMux, "no-mux", false, "Open a plain shell even if the app has a session layout")
+	attachCmd.MarkFlagsMutuallyExclusive("mux", "no-mux")
 	attachCmd.Flags().BoolVar(&attachEmergency, "emergency", false,
 		"Attach to a lightweight Alpine fallback container (no short flag — '-e' is reserved for --ecosystem). "+
 			"Use this when the normal workspace build is broken and you need to make emergency edits. "+
@@ -55,7 +55,6 @@ func runAttachEmergency(cmd *cobra.Command) error {
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
-		render.Plain(FormatSuggestions(SuggestNoContainerRuntime()...))
 		return fmt.Errorf("failed to create container runtime: %w", err)
 	}
 	render.Infof("Platform: %s", runtime.GetPlatformName())
@@ -158,8 +157,11 @@ func resolveEmergencyMountPath(cmd *cobra.Command) (string, error) {
 // into a host path suitable for mounting. Returns ok=false on any error so
 // the caller can fall back to a safer default.
 func tryResolveWorkspacePath(ds db.DataStore) (string, bool) {
-	wsResolver := resolver.NewWorkspaceResolver(ds)
-	result, err := wsResolver.Resolve(attachFlags.ToFilter())
+	filter, err := resolver.ExpandWorkspaceRef(attachFlags.ToFilter())
+	if err != nil {
+		return "", false
+	}
+	result, err := resolver.NewWorkspaceResolver(ds).Resolve(filter)
 	if err != nil || result == nil || result.Workspace == nil || result.App == nil {
 		return "", false
 	}
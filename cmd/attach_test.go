@@ -111,7 +111,7 @@ func TestAttach_WithGitRepoID_DefaultBehavior_SyncsBeforeAttach(t *testing.T) {
 
 	// Sync should be called when AutoSync is true and --no-sync not set
 	if repo.AutoSync {
-		err = mockMirror.Sync(repo.Slug)
+		err = mockMirror.Sync(context.Background(), repo.Slug)
 		assert.NoError(t, err)
 	}
 
@@ -151,7 +151,7 @@ func TestAttach_WithGitRepoID_NoSyncFlag_SkipsSync(t *testing.T) {
 	// Sync should be skipped when --no-sync is set
 	if workspace.GitRepoID.Valid && !noSync {
 		if repo.AutoSync {
-			mockMirror.Sync(repo.Slug)
+			mockMirror.Sync(context.Background(), repo.Slug)
 		}
 	}
 
@@ -186,7 +186,7 @@ func TestAttach_WithoutGitRepoID_NoSyncAttempted(t *testing.T) {
 	if workspace.GitRepoID.Valid {
 		repo, err := mockStore.GetGitRepoByID(workspace.GitRepoID.Int64)
 		if err == nil && repo.AutoSync {
-			mockMirror.Sync(repo.Slug)
+			mockMirror.Sync(context.Background(), repo.Slug)
 		}
 	}
 
@@ -212,7 +212,7 @@ func TestAttach_SyncFailure_IsWarningNotFatal(t *testing.T) {
 
 	// Attempt sync - should fail but not panic
 	if repo.AutoSync {
-		err = mockMirror.Sync(repo.Slug)
+		err = mockMirror.Sync(context.Background(), repo.Slug)
 		// In actual implementation, this error is logged as warning and attach continues
 		assert.Error(t, err, "sync should fail")
 	}
@@ -250,7 +250,7 @@ func TestAttach_AutoSyncFalse_SkipsSync(t *testing.T) {
 
 	// Sync should be skipped when AutoSync is false
 	if repo.AutoSync {
-		mockMirror.Sync(repo.Slug)
+		mockMirror.Sync(context.Background(), repo.Slug)
 	}
 
 	// Verify sync was NOT called
@@ -353,7 +353,7 @@ func TestAttach_SyncBehavior_TableDriven(t *testing.T) {
 			if workspace.GitRepoID.Valid && !noSync {
 				repo, err := mockStore.GetGitRepoByID(workspace.GitRepoID.Int64)
 				if err == nil && repo.AutoSync {
-					mockMirror.Sync(repo.Slug)
+					mockMirror.Sync(context.Background(), repo.Slug)
 				}
 			}
 
@@ -394,7 +394,7 @@ func TestAttach_FullWorkflow_WithSync(t *testing.T) {
 
 	// 5. Perform sync
 	if shouldSync {
-		err = mockMirror.Sync(repo.Slug)
+		err = mockMirror.Sync(context.Background(), repo.Slug)
 		assert.NoError(t, err)
 	}
 
@@ -430,7 +430,7 @@ func TestAttach_FullWorkflow_WithNoSync(t *testing.T) {
 
 	// 5. Perform sync (should be skipped)
 	if shouldSync {
-		mockMirror.Sync(repo.Slug)
+		mockMirror.Sync(context.Background(), repo.Slug)
 	}
 
 	// 6. Verify sync was NOT performed
@@ -487,7 +487,7 @@ func TestAttach_MultipleWorkspaces_DifferentRepos(t *testing.T) {
 		if ws.GitRepoID.Valid {
 			repo, err := mockStore.GetGitRepoByID(ws.GitRepoID.Int64)
 			if err == nil && repo.AutoSync {
-				mockMirror.Sync(repo.Slug)
+				mockMirror.Sync(context.Background(), repo.Slug)
 			}
 		}
 
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/builders"
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/registry"
+	"devopsmaestro/pkg/registry/envinjector"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// baseImageCmd is the root 'base-image' command
+var baseImageCmd = &cobra.Command{
+	Use:     "base-image",
+	Aliases: []string{"base-images", "bi"},
+	Short:   "Manage shared per-language base images",
+	Long: `Manage shared base images: per-language images with the dev tooling every
+workspace of the language gets (Neovim and its dependencies, lazygit,
+starship, tree-sitter, the default packages, and the default language tools).
+
+Once a base image is built for a language version, 'dvm build' starts the
+workspaces of that language version from it and only adds their own layers,
+which makes workspace builds much faster and lets their images share disk.
+Base images are pushed to the local registry when it is enabled.
+
+Supported languages: ` + strings.Join(builders.BaseImageLanguages, ", "),
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// baseImageBuildCmd builds base images
+var baseImageBuildCmd = &cobra.Command{
+	Use:   "build <language>...",
+	Short: "Build shared base images",
+	Long: `Build the shared base image of each language, for --version or the version
+workspaces default to, and push it to the local registry when it is enabled.
+Building again replaces the image, picking up newer tooling; rebuild base
+images after upgrading dvm when 'dvm base-image list' shows them outdated.
+
+Examples:
+  dvm base-image build go
+  dvm base-image build python --version 3.12
+  dvm base-image build go python node       # Several languages`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runBaseImageBuild,
+}
+
+// baseImageListCmd lists the recorded base images
+var baseImageListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List shared base images",
+	Long: `List the built base images. STATUS is "outdated" when this version of dvm
+would build the image differently; workspace builds don't use outdated
+images until they are rebuilt with 'dvm base-image build'.
+
+Examples:
+  dvm base-image list
+  dvm base-image list -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: runBaseImageList,
+}
+
+// baseImageDeleteCmd stops workspace builds from using a base image
+var baseImageDeleteCmd = &cobra.Command{
+	Use:   "delete <language>",
+	Short: "Delete a shared base image",
+	Long: `Delete the base image of a language version, for --version or the version
+workspaces default to. Workspace builds of that version go back to building
+their tooling themselves. The image itself is left in the container runtime
+and registry.
+
+Examples:
+  dvm base-image delete go
+  dvm base-image delete python --version 3.12 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBaseImageDelete,
+}
+
+var (
+	baseImageVersion string
+	baseImageNoCache bool
+	baseImageNoPush  bool
+)
+
+func init() {
+	baseImageBuildCmd.Flags().StringVar(&baseImageVersion, "version", "", "Language version (default: the version workspaces default to)")
+	baseImageBuildCmd.Flags().BoolVar(&baseImageNoCache, "no-cache", false, "Build without using the build cache")
+	baseImageBuildCmd.Flags().BoolVar(&baseImageNoPush, "no-push", false, "Don't push the image to the local registry")
+	baseImageDeleteCmd.Flags().StringVar(&baseImageVersion, "version", "", "Language version (default: the version workspaces default to)")
+	AddForceConfirmFlag(baseImageDeleteCmd)
+
+	baseImageCmd.AddCommand(baseImageBuildCmd)
+	baseImageCmd.AddCommand(baseImageListCmd)
+	baseImageCmd.AddCommand(baseImageDeleteCmd)
+	rootCmd.AddCommand(baseImageCmd)
+}
+
+// baseImageTarget resolves a language argument and --version to the language
+// key and version of a base image.
+func baseImageTarget(name, version string) (string, string, error) {
+	language, ok := builders.BaseImageLanguage(name)
+	if !ok {
+		return "", "", fmt.Errorf("no base image for language %q (supported: %s)", name, strings.Join(builders.BaseImageLanguages, ", "))
+	}
+	return language, builders.BaseImageVersion(language, version), nil
+}
+
+// baseImageDockerfileHash identifies a base image Dockerfile, to tell when a
+// recorded image was built from a different one.
+func baseImageDockerfileHash(dockerfile string) string {
+	sum := sha256.Sum256([]byte(dockerfile))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// baseImageOutdated reports whether img was built from a different
+// Dockerfile than this version of dvm generates for it.
+func baseImageOutdated(img *models.BaseImage) bool {
+	dockerfile, err := builders.GenerateBaseImageDockerfile(img.Language, img.Version)
+	return err != nil || baseImageDockerfileHash(dockerfile) != img.DockerfileHash
+}
+
+func runBaseImageBuild(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	type target struct{ language, version string }
+	var targets []target
+	for _, arg := range args {
+		language, version, err := baseImageTarget(arg, baseImageVersion)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target{language, version})
+	}
+
+	platform, err := detectPlatform()
+	if err != nil {
+		return err
+	}
+	render.Infof("Platform: %s", platform.Name)
+
+	var reg *registry.BuildRegistryResult
+	if !baseImageNoPush {
+		reg = prepareBaseImageRegistry(ctx, ds, platform)
+	}
+
+	for _, t := range targets {
+		img, err := buildBaseImage(ctx, platform, reg, t.language, t.version)
+		if err != nil {
+			return fmt.Errorf("failed to build %s %s base image: %w", t.language, t.version, err)
+		}
+		if err := ds.SetBaseImage(img); err != nil {
+			return err
+		}
+		render.Successf("Base image built: %s", img.Image)
+		if img.RegistryRef != "" {
+			render.Infof("Registry: %s", img.RegistryRef)
+		}
+	}
+	render.Blank()
+	render.Info("Workspace builds of these language versions now start from the base images")
+	return nil
+}
+
+// prepareBaseImageRegistry starts the local registry for pushing base images,
+// returning nil when it is disabled or unavailable.
+func prepareBaseImageRegistry(ctx context.Context, ds db.DataStore, platform *operators.Platform) *registry.BuildRegistryResult {
+	if !config.IsRegistryEnabled() || platform.IsRemote() {
+		render.Info("Registry is not enabled; base images stay local to this container runtime")
+		return nil
+	}
+	coordinator := registry.NewBuildRegistryCoordinator(ds, registry.NewServiceFactory(), envinjector.NewEnvironmentInjector())
+	result, err := coordinator.Prepare(ctx)
+	if err != nil {
+		render.Warningf("Registry unavailable, base images won't be pushed: %v", err)
+		slog.Warn("registry preparation failed", "error", err)
+		return nil
+	}
+	if result.OCIEndpoint == "" {
+		render.Warning("No OCI registry running; base images won't be pushed")
+		return nil
+	}
+	return result
+}
+
+// buildBaseImage builds the base image of a language version from a build
+// context under ~/.devopsmaestro/base-images and pushes it to reg when set.
+func buildBaseImage(ctx context.Context, platform *operators.Platform, reg *registry.BuildRegistryResult, language, version string) (*models.BaseImage, error) {
+	dockerfile, err := builders.GenerateBaseImageDockerfile(language, version)
+	if err != nil {
+		return nil, err
+	}
+	pc, err := paths.Default()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	contextDir := filepath.Join(pc.Root(), "base-images", language+"-"+version)
+	if err := os.MkdirAll(contextDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create build context: %w", err)
+	}
+	dfPath := filepath.Join(contextDir, "Dockerfile")
+	if err := os.WriteFile(dfPath, []byte(dockerfile), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write Dockerfile: %w", err)
+	}
+
+	img := &models.BaseImage{
+		Language:       language,
+		Version:        version,
+		Image:          builders.BaseImageName(language, version),
+		DockerfileHash: baseImageDockerfileHash(dockerfile),
+	}
+	builder, err := builders.NewImageBuilder(builders.BuilderConfig{
+		Platform:   platform,
+		Namespace:  "devopsmaestro",
+		AppPath:    contextDir,
+		ImageName:  img.Image,
+		Dockerfile: dfPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image builder: %w", err)
+	}
+	defer builder.Close()
+
+	opts := builders.BuildOptions{
+		NoCache: baseImageNoCache,
+		Labels:  operators.ResourceLabels(operators.LabelScope{}),
+		Output:  os.Stdout,
+	}
+	if reg != nil {
+		opts.BuildKitConfigPath = reg.BuildKitConfigPath
+		opts.RegistryMirrorsDir = reg.ContainerdCertsDir
+	}
+	render.Progressf("Building base image %s...", img.Image)
+	if err := builder.Build(ctx, opts); err != nil {
+		return nil, err
+	}
+	if platform.IsContainerd() {
+		if err := copyImageToNamespace(platform, img.Image, os.Stdout); err != nil {
+			return nil, fmt.Errorf("failed to copy image to namespace: %w", err)
+		}
+	}
+
+	if reg != nil {
+		ref := registry.EndpointFromURL(reg.OCIEndpoint) + "/" + builders.BaseImageRepository(language, version)
+		render.Progressf("Pushing %s...", ref)
+		if err := tagImageForRegistry(platform, img.Image, ref, os.Stdout); err != nil {
+			render.Warningf("Failed to tag base image for registry: %v", err)
+		} else if err := pushImageToRegistry(platform, ref, os.Stdout); err != nil {
+			render.Warningf("Failed to push base image to registry: %v", err)
+		} else {
+			img.RegistryRef = ref
+		}
+	}
+	return img, nil
+}
+
+func runBaseImageList(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	images, err := ds.ListBaseImages()
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, images, render.Options{})
+	}
+	if len(images) == 0 {
+		return outputList(getOutputFormat, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: "No base images built",
+			EmptyHints:   []string{"dvm base-image build go"},
+		})
+	}
+
+	tableData := render.TableData{Headers: []string{"LANGUAGE", "VERSION", "IMAGE", "REGISTRY", "STATUS", "UPDATED"}}
+	for _, img := range images {
+		status := "current"
+		if baseImageOutdated(img) {
+			status = "outdated"
+		}
+		tableData.Rows = append(tableData.Rows, []string{
+			img.Language,
+			img.Version,
+			img.Image,
+			orDash(img.RegistryRef),
+			status,
+			img.UpdatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+	return outputList(getOutputFormat, tableData, render.Options{Type: render.TypeTable})
+}
+
+func runBaseImageDelete(cmd *cobra.Command, args []string) error {
+	language, version, err := baseImageTarget(args[0], baseImageVersion)
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	if _, err := ds.GetBaseImage(language, version); err != nil {
+		if db.IsNotFound(err) {
+			return ErrorWithSuggestion(fmt.Sprintf("no %s %s base image", language, version),
+				"List base images with: dvm base-image list")
+		}
+		return err
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	confirmed, err := confirmDelete(fmt.Sprintf("Delete the %s %s base image?", language, version), force)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		render.Info("Aborted")
+		return nil
+	}
+	if err := ds.DeleteBaseImage(language, version); err != nil {
+		return err
+	}
+	render.Successf("Base image %s %s deleted", language, version)
+	return nil
+}
@@ -19,6 +19,8 @@ var (
 	buildDetach      bool
 	buildConcurrency int
 	buildCleanCache  bool
+	buildStaleOnly   bool
+	buildNoBaseImage bool
 )
 
 // buildCmd represents the build command
@@ -60,11 +62,25 @@ Flags:
   -e, --ecosystem   Filter by ecosystem — builds ALL workspaces in ecosystem
   -d, --domain      Filter by domain — builds ALL workspaces in domain
   -a, --app         Filter by app — builds ALL workspaces for app
-  -w, --workspace   Filter by workspace name
+  -w, --workspace   Workspace name or path (e.g. eco/domain/app/ws)
   -A, --all         Build all workspaces (combine with scope flags to narrow)
   --no-cache        Build without using registry cache (pull fresh from upstream)
   --push            Push built image to local registry after build
   --registry        Override registry endpoint (default: from config)
+  --stale-only      Skip workspaces whose image is current
+  --no-base-image   Don't start from the shared base image of the language
+
+Stale Images:
+  After each build dvm records a content hash of the build inputs: the app
+  source staged as the Docker build context, the app's build config (with its
+  build template), and the workspace's build config. 'dvm get workspaces'
+  marks a workspace "rebuild recommended" when they change or a rebuild is
+  pending, and --stale-only builds only those workspaces, plus any without a
+  recorded build.
+
+Base Images:
+  Workspaces of a language version with a base image built by
+  'dvm base-image build' start from it and only add their own layers.
 
 Examples:
   dvm build                               # Build active workspace
@@ -76,6 +92,8 @@ Examples:
   dvm build -e healthcare -d payments     # Build all workspaces in domain
   dvm build -A                            # Build every workspace
   dvm build -A -e healthcare              # Build all in ecosystem (same as -e alone)
+  dvm build -A --stale-only               # Rebuild only out-of-date workspaces
+  dvm build --no-base-image               # Build tooling into the image
   DVM_PLATFORM=colima dvm build
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -112,6 +130,8 @@ func init() {
 	AddAllFlag(buildCmd, "Build all matching workspaces (use with -e/-d/-a to scope)")
 	buildCmd.Flags().BoolVar(&buildDetach, "detach", false, "Run in background; monitor with 'dvm build status'")
 	buildCmd.Flags().IntVar(&buildConcurrency, "concurrency", 8, "Max parallel builds (capped at 2x CPU cores)")
+	buildCmd.Flags().BoolVar(&buildStaleOnly, "stale-only", false, "Build only workspaces whose app source or build config changed since their last build (or that were never built)")
+	buildCmd.Flags().BoolVar(&buildNoBaseImage, "no-base-image", false, "Build dev tooling into the image instead of starting from the shared base image of the language")
 	buildCmd.Flags().BoolVar(&buildCleanCache, "clean-cache", false, "Aggressively clean before/after build: prune BuildKit cache, remove old workspace images, use registry cache, minimize disk footprint")
 	buildCmd.AddCommand(buildStatusCmd)
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"devopsmaestro/builders"
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/fetch"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+)
+
+// newArtifactFetcher returns the fetcher configured by the artifacts section
+// of the config file. The cache defaults to ~/.devopsmaestro/cache/artifacts.
+func newArtifactFetcher(cfg config.ArtifactsConfig) (*fetch.Fetcher, error) {
+	cacheDir := cfg.CacheDir
+	if cacheDir == "" {
+		pc, err := paths.Default()
+		if err != nil {
+			return nil, err
+		}
+		cacheDir = filepath.Join(pc.Root(), "cache", "artifacts")
+	}
+	cacheDir, err := db.ExpandPath(cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifacts.cacheDir: %w", err)
+	}
+	return &fetch.Fetcher{CacheDir: cacheDir, Offline: cfg.Offline}, nil
+}
+
+// artifactSignature returns the signature the config requires for a tool's
+// download at url, or nil when it requires none.
+func artifactSignature(cfg config.ArtifactsConfig, tool, url string) (*fetch.Signature, error) {
+	sig, ok := cfg.Signatures[tool]
+	if !ok {
+		return nil, nil
+	}
+	key, err := db.ExpandPath(sig.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifacts.signatures.%s.key: %w", tool, err)
+	}
+	return &fetch.Signature{
+		Method: sig.Method,
+		URL:    strings.ReplaceAll(sig.URL, "{url}", url),
+		Key:    key,
+	}, nil
+}
+
+// prefetchArtifacts downloads the pinned releases the generated Dockerfile's
+// builder stages use into the staging directory, through the verified
+// artifact cache. A download that fails is left to the builder stage's curl
+// fallback, unless the config is offline or requires a signature; a digest or
+// signature mismatch always fails the build.
+func (bc *buildContext) prefetchArtifacts(dockerfile string, cfg config.ArtifactsConfig) error {
+	refs := builders.ReferencedArtifacts(dockerfile, runtime.GOARCH)
+	if len(refs) == 0 {
+		return nil
+	}
+	fetcher, err := newArtifactFetcher(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Every stage COPYs its directory, so all must exist before any fetch
+	for _, a := range refs {
+		if err := os.MkdirAll(filepath.Join(bc.stagingDir, builders.ArtifactsDir, a.Tool), 0755); err != nil {
+			return fmt.Errorf("failed to create artifact directory: %w", err)
+		}
+	}
+
+	bc.renderProgressf("Prefetching %d release artifact(s)...", len(refs))
+	for _, a := range refs {
+		sig, err := artifactSignature(cfg, a.Tool, a.URL)
+		if err != nil {
+			return err
+		}
+
+		err = fetcher.Fetch(bc.ctx, fetch.Artifact{
+			Name:      a.Tool,
+			URL:       a.URL,
+			SHA256:    a.SHA256,
+			Signature: sig,
+		}, filepath.Join(bc.stagingDir, builders.ArtifactsDir, a.Tool, a.SHA256))
+		switch {
+		case err == nil:
+			slog.Debug("prefetched artifact", "tool", a.Tool, "url", a.URL)
+		case errors.Is(err, fetch.ErrNotCached):
+			return ErrorWithSuggestion(err.Error(),
+				"Build once online to fill the cache, or set artifacts.offline: false in ~/.devopsmaestro/config.yaml")
+		case errors.Is(err, fetch.ErrChecksumMismatch), errors.Is(err, fetch.ErrSignature), sig != nil:
+			return fmt.Errorf("artifact verification failed: %w", err)
+		default:
+			bc.renderWarningf("Could not prefetch %s, the build will download it: %v", a.Tool, err)
+			slog.Warn("artifact prefetch failed", "tool", a.Tool, "url", a.URL, "error", err)
+		}
+	}
+	return nil
+}
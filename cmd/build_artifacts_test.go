@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"devopsmaestro/builders"
+	"devopsmaestro/config"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/fetch"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactSignature(t *testing.T) {
+	cfg := config.ArtifactsConfig{Signatures: map[string]config.ArtifactSignatureConfig{
+		"neovim": {Method: "cosign", URL: "{url}.sig", Key: "/keys/neovim.pub"},
+	}}
+
+	sig, err := artifactSignature(cfg, "neovim", "https://example.com/nvim.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, &fetch.Signature{Method: "cosign", URL: "https://example.com/nvim.tar.gz.sig", Key: "/keys/neovim.pub"}, sig)
+
+	sig, err = artifactSignature(cfg, "kubectl", "https://example.com/kubectl")
+	require.NoError(t, err)
+	assert.Nil(t, sig)
+}
+
+func TestNewArtifactFetcher_DefaultCacheDir(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	f, err := newArtifactFetcher(config.ArtifactsConfig{})
+	require.NoError(t, err)
+	pc, err := paths.Default()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(pc.Root(), "cache", "artifacts"), f.CacheDir)
+}
+
+func TestPrefetchArtifacts(t *testing.T) {
+	generate := func(prefetch bool) string {
+		gen := builders.NewDockerfileGenerator(builders.DockerfileGeneratorOptions{
+			Workspace:         &models.Workspace{ID: 1, Name: "ci", ImageName: "ci:latest"},
+			AppKind:           "cicd",
+			AppPath:           t.TempDir(),
+			PathConfig:        paths.New(t.TempDir()),
+			PrefetchArtifacts: prefetch,
+		})
+		dockerfile, err := gen.Generate()
+		require.NoError(t, err)
+		return dockerfile
+	}
+	dockerfile := generate(true)
+	if len(builders.ReferencedArtifacts(dockerfile, runtime.GOARCH)) == 0 {
+		t.Skipf("no pinned artifacts for %s", runtime.GOARCH)
+	}
+
+	t.Run("offline cache miss fails the build", func(t *testing.T) {
+		var out bytes.Buffer
+		bc := &buildContext{ctx: context.Background(), stagingDir: t.TempDir(), output: &out}
+		err := bc.prefetchArtifacts(dockerfile, config.ArtifactsConfig{CacheDir: t.TempDir(), Offline: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not in offline cache")
+		assert.DirExists(t, filepath.Join(bc.stagingDir, builders.ArtifactsDir, "kubectl"))
+	})
+
+	t.Run("nothing referenced without prefetching", func(t *testing.T) {
+		bc := &buildContext{ctx: context.Background(), stagingDir: t.TempDir()}
+		err := bc.prefetchArtifacts(generate(false), config.ArtifactsConfig{CacheDir: t.TempDir(), Offline: true})
+		assert.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(bc.stagingDir, builders.ArtifactsDir))
+	})
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"devopsmaestro/builders"
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/registry"
+)
+
+// resolveBaseImage returns the shared base image the workspace builds on, or
+// "" to build its tooling itself: with --no-base-image, for languages without
+// base images, when none was built for the language version, or when the
+// recorded one is outdated or out of reach of the platform's builder.
+func (bc *buildContext) resolveBaseImage() string {
+	if buildNoBaseImage || bc.ds == nil || bc.appKind == "cicd" {
+		return ""
+	}
+	language, ok := builders.BaseImageLanguage(bc.languageName)
+	if !ok {
+		return ""
+	}
+	version := builders.BaseImageVersion(language, bc.version)
+	img, err := bc.ds.GetBaseImage(language, version)
+	if err != nil {
+		if !db.IsNotFound(err) {
+			slog.Warn("failed to look up base image", "language", language, "version", version, "error", err)
+		}
+		return ""
+	}
+	if baseImageOutdated(img) {
+		bc.renderWarningf("Base image %s is outdated; rebuild it with: dvm base-image build %s --version %s", img.Image, language, version)
+		return ""
+	}
+
+	// Builders behind the local registry (buildx's container builder and
+	// BuildKit on containerd) only see images pulled from a registry.
+	if bc.registryEndpoint != "" && img.RegistryRef != "" {
+		return registry.EndpointFromURL(bc.registryEndpoint) + "/" + builders.BaseImageRepository(language, version)
+	}
+	if bc.registryEndpoint == "" && bc.platform != nil && !bc.platform.IsContainerd() {
+		return img.Image
+	}
+	bc.renderInfof("Base image %s is not in the local registry; building tooling in the workspace image", img.Image)
+	return ""
+}
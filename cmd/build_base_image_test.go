@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"devopsmaestro/builders"
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordBaseImage records a current base image for a language version.
+func recordBaseImage(t *testing.T, ds db.DataStore, language, version, registryRef string) *models.BaseImage {
+	t.Helper()
+	dockerfile, err := builders.GenerateBaseImageDockerfile(language, version)
+	require.NoError(t, err)
+	img := &models.BaseImage{
+		Language:       language,
+		Version:        version,
+		Image:          builders.BaseImageName(language, version),
+		RegistryRef:    registryRef,
+		DockerfileHash: baseImageDockerfileHash(dockerfile),
+	}
+	require.NoError(t, ds.SetBaseImage(img))
+	return img
+}
+
+func TestResolveBaseImage(t *testing.T) {
+	docker := &operators.Platform{Type: operators.PlatformOrbStack}
+	containerd := &operators.Platform{Type: operators.PlatformColima, SocketPath: "/tmp/containerd.sock"}
+	const ref = "localhost:5001/dvm-base/golang:1.22"
+
+	tests := []struct {
+		name        string
+		language    string
+		registryRef string
+		endpoint    string
+		platform    *operators.Platform
+		want        string
+	}{
+		{"local image without registry", "go", "", "", docker, "dvm-base-golang:1.22"},
+		{"registry image", "golang", ref, "http://localhost:5001", docker, ref},
+		{"not pushed, registry builder", "golang", "", "http://localhost:5001", docker, ""},
+		{"containerd without registry", "golang", "", "", containerd, ""},
+		{"language without base images", "rust", "", "", docker, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := db.NewMockDataStore()
+			recordBaseImage(t, ds, "golang", "1.22", tt.registryRef)
+			bc := &buildContext{
+				ds:               ds,
+				languageName:     tt.language,
+				platform:         tt.platform,
+				registryEndpoint: tt.endpoint,
+				output:           &bytes.Buffer{},
+			}
+			assert.Equal(t, tt.want, bc.resolveBaseImage())
+		})
+	}
+}
+
+func TestResolveBaseImage_Skipped(t *testing.T) {
+	ds := db.NewMockDataStore()
+	img := recordBaseImage(t, ds, "python", "3.11", "")
+	var out bytes.Buffer
+	bc := &buildContext{
+		ds:           ds,
+		languageName: "python",
+		platform:     &operators.Platform{Type: operators.PlatformOrbStack},
+		output:       &out,
+	}
+
+	// Another version has no base image
+	bc.version = "3.12"
+	assert.Empty(t, bc.resolveBaseImage())
+
+	// --no-base-image
+	bc.version = ""
+	buildNoBaseImage = true
+	assert.Empty(t, bc.resolveBaseImage())
+	buildNoBaseImage = false
+
+	// Built from a Dockerfile this dvm no longer generates
+	img.DockerfileHash = "sha256:old"
+	require.NoError(t, ds.SetBaseImage(img))
+	assert.Empty(t, bc.resolveBaseImage())
+	assert.Contains(t, out.String(), "dvm base-image build python --version 3.11")
+}
@@ -1,12 +1,12 @@
 package cmd
 
 import (
-	"fmt"
 	"log/slog"
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
-	"github.com/docker/docker/client"
+
+	"devopsmaestro/operators"
 )
 
 // preCleanCacheCleanup performs aggressive cleanup before building when
@@ -38,13 +38,10 @@ func (bc *buildContext) deleteExistingWorkspaceImages() {
 		return
 	}
 
-	repo := fmt.Sprintf("dvm-%s-%s", bc.workspaceName, bc.appName)
+	repo := bc.workspaceImageRepo()
 	bc.renderProgressf("Removing old images for %s...", repo)
 
-	cli, err := client.NewClientWithOpts(
-		client.WithHost(fmt.Sprintf("unix://%s", bc.platform.SocketPath)),
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := operators.NewDockerClient(bc.platform)
 	if err != nil {
 		slog.Warn("failed to create Docker client for pre-build cleanup", "error", err)
 		return
@@ -91,10 +88,7 @@ func (bc *buildContext) pruneDanglingImages() {
 		return
 	}
 
-	cli, err := client.NewClientWithOpts(
-		client.WithHost(fmt.Sprintf("unix://%s", bc.platform.SocketPath)),
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := operators.NewDockerClient(bc.platform)
 	if err != nil {
 		slog.Warn("failed to create Docker client for dangling prune", "error", err)
 		return
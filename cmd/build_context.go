@@ -38,6 +38,11 @@ type buildContext struct {
 	buildKitConfigPath string
 	containerdCertsDir string
 
+	// App build config with its build template applied, and why the
+	// workspace needs a rebuild (empty when none is pending)
+	buildConfig   models.AppBuildConfig
+	rebuildReason string
+
 	// Dockerfile detection
 	hasDockerfile  bool
 	dockerfilePath string
@@ -60,7 +65,9 @@ type buildContext struct {
 	argoCDDetected bool
 
 	// Nvim
-	pluginManifest *plugin.PluginManifest
+	pluginManifest  *plugin.PluginManifest
+	pluginFiletypes []string
+	nvimPlugins     []*plugin.Plugin
 
 	// Build args cascade (resolved once, used twice: Dockerfile gen + build args)
 	cascadeResolution *resolver.BuildArgsResolution
@@ -69,6 +76,10 @@ type buildContext struct {
 	imageName     string
 	dvmDockerfile string
 
+	// buildInputs is the hash of the source and build config this build
+	// uses, recorded on the workspace after it succeeds
+	buildInputs string
+
 	// Image builder (set during buildImage, closed by caller)
 	builder builders.ImageBuilder
 
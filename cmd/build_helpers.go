@@ -329,6 +329,13 @@ func getRelativePath(base, target string) string {
 // Used for both build-time (--build-arg) and runtime (container env) injection.
 // Environment variables always take highest priority.
 func loadBuildCredentials(ds db.DataStore, app *models.App, workspace *models.Workspace) (map[string]string, []string) {
+	return resolveCredentialScopes(loadCredentialScopes(ds, app, workspace))
+}
+
+// loadCredentialScopes loads the credentials visible to a workspace (or,
+// with a nil workspace, an app), least specific scope first, without
+// resolving their values.
+func loadCredentialScopes(ds db.DataStore, app *models.App, workspace *models.Workspace) []config.CredentialScope {
 	var scopes []config.CredentialScope
 
 	// Layer 1: Global credentials from config file
@@ -400,7 +407,12 @@ func loadBuildCredentials(ds db.DataStore, app *models.App, workspace *models.Wo
 			slog.Debug("loaded workspace credentials", "workspace", workspace.Name, "count", len(wsCreds))
 		}
 	}
+	return scopes
+}
 
+// resolveCredentialScopes resolves the credentials of scopes, returning
+// their values and a warning per credential that failed to resolve.
+func resolveCredentialScopes(scopes []config.CredentialScope) (map[string]string, []string) {
 	// Initialize vault backend via auto-token resolution chain
 	var backend config.SecretBackend
 	token, tokenErr := config.ResolveVaultToken()
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/imagename"
+)
+
+// imageNameFields returns the hierarchy placeholders of the image name
+// template for a workspace's app. Ecosystem and domain are empty when the
+// app isn't in one.
+func imageNameFields(ds db.DataStore, app *models.App, workspaceName string) imagename.Fields {
+	f := imagename.Fields{App: app.Name, Workspace: workspaceName}
+	if ds == nil || !app.DomainID.Valid {
+		return f
+	}
+	domain, err := ds.GetDomainByID(int(app.DomainID.Int64))
+	if err != nil {
+		return f
+	}
+	f.Domain = domain.Name
+	if domain.EcosystemID.Valid {
+		if ecosystem, err := ds.GetEcosystemByID(int(domain.EcosystemID.Int64)); err == nil {
+			f.Ecosystem = ecosystem.Name
+		}
+	}
+	return f
+}
+
+// imageNameFields returns the hierarchy placeholders for this build.
+func (bc *buildContext) imageNameFields() imagename.Fields {
+	if bc.app == nil {
+		return imagename.Fields{App: bc.appName, Workspace: bc.workspaceName}
+	}
+	return imageNameFields(bc.ds, bc.app, bc.workspaceName)
+}
+
+// imageContentHash hashes what a build produces its image from: the build
+// inputs (source and build config) and the generated Dockerfile, which also
+// changes with dvm's own tooling.
+func imageContentHash(buildInputs string, dockerfile []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "I %s\n", buildInputs)
+	h.Write(dockerfile)
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// renderImageName names the image of this build with the configured
+// template (images.name).
+func (bc *buildContext) renderImageName() (string, error) {
+	f := bc.imageNameFields()
+	f.Time = time.Now()
+	dockerfile, err := os.ReadFile(bc.dvmDockerfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read generated Dockerfile: %w", err)
+	}
+	f.Hash = imageContentHash(bc.buildInputs, dockerfile)
+
+	name, err := imagename.Render(config.GetConfig().Images.Name, f)
+	if err != nil {
+		return "", fmt.Errorf("invalid images.name in config: %w", err)
+	}
+	return name, nil
+}
+
+// workspaceImageRepo returns the repository every image of this workspace
+// is tagged in, falling back to the default naming when the configured
+// template is invalid.
+func (bc *buildContext) workspaceImageRepo() string {
+	f := bc.imageNameFields()
+	repo, err := imagename.Repository(config.GetConfig().Images.Name, f)
+	if err != nil {
+		repo, _ = imagename.Repository(imagename.DefaultTemplate, f)
+	}
+	return repo
+}
+
+// recordImageDigest stores the digest of the image just built on the
+// workspace, so 'dvm get workspaces' can tell when its container runs an
+// older image. Runtimes that can't report digests record nothing.
+func (bc *buildContext) recordImageDigest() {
+	runtime, err := operators.NewContainerRuntime()
+	if err != nil {
+		slog.Debug("image digest: failed to create runtime", "error", err)
+		return
+	}
+	inspector, ok := runtime.(operators.ImageInspector)
+	if !ok {
+		slog.Debug("image digest: runtime can't inspect images", "runtime", runtime.GetRuntimeType())
+		return
+	}
+	digest := bc.storeImageDigest(inspector)
+	if digest != "" {
+		bc.renderInfof("Image digest: %s", digest)
+	}
+}
+
+// storeImageDigest looks up the digest of bc.imageName and records it on the
+// workspace, returning it, or "" when either fails.
+func (bc *buildContext) storeImageDigest(inspector operators.ImageInspector) string {
+	digest, err := inspector.ImageDigest(bc.ctx, bc.imageName)
+	if err != nil {
+		slog.Warn("failed to look up image digest", "image", bc.imageName, "error", err)
+		return ""
+	}
+	if err := bc.ds.SetWorkspaceImageDigest(bc.workspace.ID, digest); err != nil {
+		slog.Warn("failed to record image digest", "workspace_id", bc.workspace.ID, "error", err)
+		return ""
+	}
+	return digest
+}
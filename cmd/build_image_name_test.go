@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupImageNameBuild returns a build context for workspace dev of app api
+// in domain payments of ecosystem acme, with a generated Dockerfile.
+func setupImageNameBuild(t *testing.T) *buildContext {
+	t.Helper()
+	ds := db.NewMockDataStore()
+	eco := &models.Ecosystem{Name: "acme"}
+	require.NoError(t, ds.CreateEcosystem(eco))
+	dom := &models.Domain{Name: "payments", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, ds.CreateDomain(dom))
+	app := &models.App{Name: "api", DomainID: sql.NullInt64{Int64: int64(dom.ID), Valid: true}}
+	require.NoError(t, ds.CreateApp(app))
+	ws := &models.Workspace{AppID: app.ID, Name: "dev"}
+	require.NoError(t, ds.CreateWorkspace(ws))
+
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile.dvm")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM alpine\n"), 0o644))
+	return &buildContext{
+		ds:            ds,
+		ctx:           context.Background(),
+		app:           app,
+		workspace:     ws,
+		appName:       "api",
+		workspaceName: "dev",
+		dvmDockerfile: dockerfile,
+		buildInputs:   "sha256:inputs",
+	}
+}
+
+func setImageNameTemplate(t *testing.T, template string) {
+	t.Helper()
+	viper.Set("images.name", template)
+	t.Cleanup(func() { viper.Set("images.name", "") })
+}
+
+func TestRenderImageName_Default(t *testing.T) {
+	bc := setupImageNameBuild(t)
+	name, err := bc.renderImageName()
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^dvm-dev-api:\d{8}-\d{6}$`), name)
+	assert.Equal(t, "dvm-dev-api", bc.workspaceImageRepo())
+}
+
+func TestRenderImageName_Template(t *testing.T) {
+	setImageNameTemplate(t, "dvm-{ecosystem}-{domain}-{app}-{workspace}:{hash}")
+	bc := setupImageNameBuild(t)
+
+	name, err := bc.renderImageName()
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^dvm-acme-payments-api-dev:[0-9a-f]{12}$`), name)
+	assert.Equal(t, "dvm-acme-payments-api-dev", bc.workspaceImageRepo())
+
+	// The same inputs name the same image
+	again, err := bc.renderImageName()
+	require.NoError(t, err)
+	assert.Equal(t, name, again)
+
+	// Changed inputs or Dockerfile name a new one
+	bc.buildInputs = "sha256:changed"
+	changed, err := bc.renderImageName()
+	require.NoError(t, err)
+	assert.NotEqual(t, name, changed)
+}
+
+func TestRenderImageName_InvalidTemplate(t *testing.T) {
+	setImageNameTemplate(t, "dvm-{app}:latest")
+	bc := setupImageNameBuild(t)
+
+	_, err := bc.renderImageName()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "images.name")
+	// Cleanup still finds the workspace's images
+	assert.Equal(t, "dvm-dev-api", bc.workspaceImageRepo())
+}
+
+func TestStoreImageDigest(t *testing.T) {
+	bc := setupImageNameBuild(t)
+	bc.imageName = "dvm-dev-api:20260415-234218"
+	runtime := operators.NewMockContainerRuntime()
+
+	// Unknown image: nothing recorded
+	assert.Empty(t, bc.storeImageDigest(runtime))
+
+	runtime.ImageDigests[bc.imageName] = "sha256:built"
+	assert.Equal(t, "sha256:built", bc.storeImageDigest(runtime))
+	digests, err := bc.ds.ListWorkspaceImageDigests()
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:built", digests[bc.workspace.ID])
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/buildinputs"
+	"devopsmaestro/pkg/buildtemplate"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// reasonBuildInputsChanged is the rebuild reason of a workspace whose build
+// inputs changed since its image was built.
+const reasonBuildInputsChanged = "build inputs changed"
+
+// reasonNoRecordedBuild is the rebuild reason, for --stale-only, of a
+// workspace with no recorded build inputs: never built, or built before they
+// were tracked.
+const reasonNoRecordedBuild = "no recorded build"
+
+// hashWorkspaceBuildInputs returns the hash of what building ws uses: the
+// source staged as the build context, the app's build config with its build
+// template applied, and the workspace's build config. sourceHash caches the
+// build context hash by source path; it may be nil.
+func hashWorkspaceBuildInputs(ds db.DataStore, app *models.App, ws *models.Workspace, sourceHash map[string]string) (string, error) {
+	source, err := getBuildSourcePath(ds, ws, app.Path)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := buildtemplate.Resolve(ds, app)
+	if err != nil {
+		return "", err
+	}
+	return hashBuildInputs(source, cfg, ws.BuildConfig.String, sourceHash)
+}
+
+// hashBuildInputs hashes a build context directory and build configs; see
+// hashWorkspaceBuildInputs.
+func hashBuildInputs(source string, cfg models.AppBuildConfig, workspaceConfig string, sourceHash map[string]string) (string, error) {
+	dir, ok := sourceHash[source]
+	if !ok {
+		var err error
+		if dir, err = buildinputs.Dir(source, shouldSkipPath); err != nil {
+			return "", err
+		}
+		if sourceHash != nil {
+			sourceHash[source] = dir
+		}
+	}
+	return buildinputs.Hash(dir, cfg, workspaceConfig)
+}
+
+// rebuildChecker tells why workspaces need a rebuild: a pending rebuild
+// reason, such as a changed build template, or build inputs that changed
+// since the image was built. It loads the recorded state once and hashes
+// each build context once.
+type rebuildChecker struct {
+	ds         db.DataStore
+	pending    map[int]string
+	built      map[int]string
+	apps       map[int]*models.App
+	sourceHash map[string]string
+}
+
+// newRebuildChecker loads the pending rebuilds and recorded build inputs.
+func newRebuildChecker(ds db.DataStore) (*rebuildChecker, error) {
+	pending, err := ds.ListWorkspaceRebuildReasons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending rebuilds: %w", err)
+	}
+	built, err := ds.ListWorkspaceBuildInputs()
+	if err != nil {
+		return nil, err
+	}
+	return &rebuildChecker{
+		ds:         ds,
+		pending:    pending,
+		built:      built,
+		apps:       map[int]*models.App{},
+		sourceHash: map[string]string{},
+	}, nil
+}
+
+// loadRebuildChecker returns a rebuildChecker for get output, or nil when the
+// recorded state can't be loaded, in which case nothing is marked.
+func loadRebuildChecker(ds db.DataStore) *rebuildChecker {
+	c, err := newRebuildChecker(ds)
+	if err != nil {
+		slog.Debug("rebuild check unavailable", "error", err)
+		return nil
+	}
+	return c
+}
+
+// reason returns why ws needs a rebuild, or "" when its image is current. A
+// workspace without recorded build inputs has nothing to compare and returns
+// "", unless stale is set, when it returns reasonNoRecordedBuild. app may be
+// nil; it is then looked up.
+func (c *rebuildChecker) reason(app *models.App, ws *models.Workspace, stale bool) string {
+	if c == nil {
+		return ""
+	}
+	if r := c.pending[ws.ID]; r != "" {
+		return r
+	}
+	recorded, ok := c.built[ws.ID]
+	if !ok {
+		if stale {
+			return reasonNoRecordedBuild
+		}
+		return ""
+	}
+	if app == nil {
+		if app = c.apps[ws.AppID]; app == nil {
+			found, err := c.ds.GetAppByID(ws.AppID)
+			if err != nil {
+				return ""
+			}
+			c.apps[ws.AppID], app = found, found
+		}
+	}
+	current, err := hashWorkspaceBuildInputs(c.ds, app, ws, c.sourceHash)
+	if err != nil {
+		slog.Debug("failed to hash build inputs", "workspace", ws.Name, "error", err)
+		return ""
+	}
+	if current != recorded {
+		return reasonBuildInputsChanged
+	}
+	return ""
+}
+
+// status returns status for table output, marked when ws needs a rebuild.
+func (c *rebuildChecker) status(status string, app *models.App, ws *models.Workspace) string {
+	if c.reason(app, ws, false) != "" {
+		return status + " (rebuild recommended)"
+	}
+	return status
+}
+
+// renderRebuildHint points at 'dvm build --stale-only' when any workspace
+// listed needs a rebuild.
+func renderRebuildHint(c *rebuildChecker, app *models.App, workspaces []*models.Workspace) {
+	n := 0
+	for _, ws := range workspaces {
+		if c.reason(app, ws, false) != "" {
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+	render.Blank()
+	render.Info(fmt.Sprintf("%d workspace(s) need a rebuild; rebuild them with: dvm build -A --stale-only", n))
+}
+
+// staleWorkspaces returns the workspaces that need a rebuild, for
+// 'dvm build --stale-only': those with a pending rebuild, changed build
+// inputs, or no recorded build.
+func staleWorkspaces(ds db.DataStore, workspaces []*models.WorkspaceWithHierarchy) ([]*models.WorkspaceWithHierarchy, error) {
+	c, err := newRebuildChecker(ds)
+	if err != nil {
+		return nil, err
+	}
+	var stale []*models.WorkspaceWithHierarchy
+	for _, ws := range workspaces {
+		if c.reason(ws.App, ws.Workspace, true) != "" {
+			stale = append(stale, ws)
+		}
+	}
+	return stale, nil
+}
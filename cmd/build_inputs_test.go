@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRebuildTest creates an app whose source is a temp directory holding a
+// Dockerfile, and one workspace in it.
+func setupRebuildTest(t *testing.T) (*db.MockDataStore, *models.App, *models.Workspace) {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\n"), 0o644))
+
+	ds := db.NewMockDataStore()
+	app := &models.App{Name: "api", Path: dir}
+	require.NoError(t, ds.CreateApp(app))
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "api-dev"}
+	require.NoError(t, ds.CreateWorkspace(ws))
+	return ds, app, ws
+}
+
+func TestRebuildChecker_BuildInputs(t *testing.T) {
+	ds, app, ws := setupRebuildTest(t)
+
+	checker, err := newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Empty(t, checker.reason(app, ws, false), "nothing to compare without a recorded build")
+	assert.Equal(t, reasonNoRecordedBuild, checker.reason(app, ws, true))
+
+	hash, err := hashWorkspaceBuildInputs(ds, app, ws, nil)
+	require.NoError(t, err)
+	require.NoError(t, ds.SetWorkspaceBuildInputs(ws.ID, hash))
+
+	checker, err = newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Empty(t, checker.reason(app, ws, true))
+	assert.Equal(t, "Running", checker.status("Running", app, ws))
+
+	// Files the build skips don't make the image stale
+	require.NoError(t, os.WriteFile(filepath.Join(app.Path, "debug.log"), []byte("noise"), 0o644))
+	checker, err = newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Empty(t, checker.reason(nil, ws, true))
+
+	// Source changes do
+	require.NoError(t, os.WriteFile(filepath.Join(app.Path, "Dockerfile"), []byte("FROM alpine:3.20\n"), 0o644))
+	checker, err = newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Equal(t, reasonBuildInputsChanged, checker.reason(nil, ws, false))
+	assert.Equal(t, "Running (rebuild recommended)", checker.status("Running", app, ws))
+}
+
+func TestRebuildChecker_WorkspaceBuildConfig(t *testing.T) {
+	ds, app, ws := setupRebuildTest(t)
+	hash, err := hashWorkspaceBuildInputs(ds, app, ws, nil)
+	require.NoError(t, err)
+	require.NoError(t, ds.SetWorkspaceBuildInputs(ws.ID, hash))
+
+	ws.BuildConfig = sql.NullString{String: `{"devStage":{"packages":["jq"]}}`, Valid: true}
+	checker, err := newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Equal(t, reasonBuildInputsChanged, checker.reason(app, ws, false))
+}
+
+func TestRebuildChecker_PendingReason(t *testing.T) {
+	ds, app, ws := setupRebuildTest(t)
+	require.NoError(t, ds.SetWorkspaceRebuildReason(ws.ID, "build template go-service changed"))
+
+	checker, err := newRebuildChecker(ds)
+	require.NoError(t, err)
+	assert.Equal(t, "build template go-service changed", checker.reason(app, ws, false))
+}
+
+func TestRebuildChecker_Nil(t *testing.T) {
+	var checker *rebuildChecker
+	ws := &models.Workspace{ID: 1}
+	assert.Empty(t, checker.reason(nil, ws, true))
+	assert.Equal(t, "Stopped", checker.status("Stopped", nil, ws))
+}
+
+func TestStaleWorkspaces(t *testing.T) {
+	ds, app, current := setupRebuildTest(t)
+	hash, err := hashWorkspaceBuildInputs(ds, app, current, nil)
+	require.NoError(t, err)
+	require.NoError(t, ds.SetWorkspaceBuildInputs(current.ID, hash))
+
+	neverBuilt := &models.Workspace{AppID: app.ID, Name: "test", Slug: "api-test"}
+	require.NoError(t, ds.CreateWorkspace(neverBuilt))
+
+	stale, err := staleWorkspaces(ds, []*models.WorkspaceWithHierarchy{
+		{Workspace: current, App: app},
+		{Workspace: neverBuilt, App: app},
+	})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "test", stale[0].Workspace.Name)
+}
@@ -25,13 +25,14 @@ import (
 // generateNvimConfig generates nvim configuration and copies to staging directory.
 // It filters plugins based on the workspace's configured plugin list.
 // Reads plugin data from the database (source of truth).
-// Returns a PluginManifest for use by Dockerfile generator.
-func generateNvimConfig(workspacePlugins []string, stagingDir, homeDir string, ds db.DataStore, app *models.App, workspace *models.Workspace, appName, workspaceName, language string, out io.Writer) (*plugin.PluginManifest, error) {
+// Returns a PluginManifest for use by the Dockerfile generator and the plugins
+// the configuration loads.
+func generateNvimConfig(workspacePlugins []string, stagingDir, homeDir string, ds db.DataStore, app *models.App, workspace *models.Workspace, appName, workspaceName, language string, out io.Writer) (*plugin.PluginManifest, []*plugin.Plugin, error) {
 	render.MsgTo(out, "", render.Message{Level: render.LevelProgress, Content: "Generating Neovim configuration..."})
 
 	nvimConfigPath := filepath.Join(stagingDir, ".config", "nvim")
 	if err := os.MkdirAll(nvimConfigPath, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create nvim config directory: %w", err)
+		return nil, nil, fmt.Errorf("failed to create nvim config directory: %w", err)
 	}
 
 	// Load core config from ~/.nvp/core.yaml or use defaults
@@ -192,7 +193,7 @@ func generateNvimConfig(workspacePlugins []string, stagingDir, homeDir string, d
 	// Generate the full nvim config structure
 	gen := nvimconfig.NewGenerator()
 	if err := gen.WriteToDirectory(cfg, enabledPlugins, nvimConfigPath); err != nil {
-		return nil, fmt.Errorf("failed to generate nvim config: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate nvim config: %w", err)
 	}
 
 	// Create plugin manifest for Dockerfile generator
@@ -247,7 +248,26 @@ func generateNvimConfig(workspacePlugins []string, stagingDir, homeDir string, d
 
 	render.MsgTo(out, "", render.Message{Level: render.LevelSuccess, Content: fmt.Sprintf("Neovim configuration generated (%d plugins)", len(enabledPlugins))})
 
-	return manifest, nil
+	return manifest, enabledPlugins, nil
+}
+
+// pluginFiletypes returns the filetypes enabled plugins lazy-load on, in
+// plugin order and without duplicates.
+func pluginFiletypes(plugins []*plugin.Plugin) []string {
+	var filetypes []string
+	seen := map[string]bool{}
+	for _, p := range plugins {
+		if !p.Enabled {
+			continue
+		}
+		for _, ft := range p.Ft {
+			if ft != "" && !seen[ft] {
+				seen[ft] = true
+				filetypes = append(filetypes, ft)
+			}
+		}
+	}
+	return filetypes
 }
 
 // appendPluginLoading appends terminal plugin loading configuration to the .zshrc file.
@@ -8,10 +8,11 @@ import (
 	"io"
 	"log/slog"
 	"os"
-	"sync"
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/progress"
 
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/spf13/cobra"
@@ -72,6 +73,16 @@ func runParallelBuild(cmd *cobra.Command) error {
 	if err != nil {
 		return err
 	}
+	if buildStaleOnly {
+		matched := len(workspaces)
+		if workspaces, err = staleWorkspaces(ds, workspaces); err != nil {
+			return err
+		}
+		if len(workspaces) == 0 {
+			render.Info(fmt.Sprintf("All %d matching workspace(s) are up to date; nothing to build", matched))
+			return nil
+		}
+	}
 
 	// Dry-run: preview what would be built
 	if buildDryRun {
@@ -82,33 +93,47 @@ func runParallelBuild(cmd *cobra.Command) error {
 		return nil
 	}
 
-	// Determine scope label for the progress header
-	scopeLabel, scopeValue := parallelBuildScopeLabel(buildFlags)
-	render.Plain(FormatParallelBuildHeader(len(workspaces), scopeLabel, scopeValue, buildConcurrency))
-	if buildTimeout > 0 {
-		render.Plain(fmt.Sprintf("Per-workspace timeout: %s", buildTimeout))
+	// Each workspace is a progress task. In json mode stdout carries only
+	// the event stream, so the header, summary, and build output (still in
+	// the build log) are left out.
+	reporter := newProgressReporter(cmd)
+	defer reporter.Close()
+	jsonProgress := reporter.Mode() == progress.ModeJSON
+
+	// Start the Colima VM once for all workspaces; json mode keeps stdout for events
+	vmOut := io.Writer(os.Stdout)
+	if jsonProgress {
+		vmOut = os.Stderr
+	}
+	if err := ensureColimaVM(commandContext(cmd), vmOut, operators.VMRequirements{}); err != nil {
+		return err
 	}
 
-	// Shared mutex for serializing buffer flushes to stdout
-	var outputMu sync.Mutex
+	// Determine scope label for the progress header
+	if !jsonProgress {
+		scopeLabel, scopeValue := parallelBuildScopeLabel(buildFlags)
+		render.Plain(FormatParallelBuildHeader(len(workspaces), scopeLabel, scopeValue, buildConcurrency))
+		if buildTimeout > 0 {
+			render.Plain(fmt.Sprintf("Per-workspace timeout: %s", buildTimeout))
+		}
+	}
 
 	// Build function wraps the single-workspace build phases for each workspace.
 	// Each workspace gets its own output buffer; when the build completes the
-	// buffer is flushed atomically to stdout under a mutex so output from
-	// concurrent builds never interleaves.
+	// buffer is flushed atomically through the progress reporter so output
+	// from concurrent builds never interleaves.
 	//
 	// The cobra command context (cmd.Context()) is wired to SIGINT/SIGTERM by
 	// main.go via signal.NotifyContext (#399). Workers check ctx.Err() before
 	// starting work so that, after the first Ctrl-C, queued workspaces fast-fail
 	// with context.Canceled, which the orchestration engine maps to the
 	// "interrupted"/"cancelled" workspace statuses and an "interrupted" session.
-	ctx := cmd.Context()
+	ctx := commandContext(cmd)
 	buildFn := func(ws *models.WorkspaceWithHierarchy, logWriter io.Writer) error {
-		if ctx != nil {
-			if err := ctx.Err(); err != nil {
-				return err
-			}
+		if err := ctx.Err(); err != nil {
+			return err
 		}
+		task := reporter.Start(ws.ShortPath())
 		var buf bytes.Buffer
 		buf.WriteString(fmt.Sprintf("\n─── Building: %s/%s ───\n", ws.App.Name, ws.Workspace.Name))
 
@@ -122,16 +147,21 @@ func runParallelBuild(cmd *cobra.Command) error {
 		if logWriter != nil {
 			sink = io.MultiWriter(&buf, logWriter)
 		}
-		err := buildSingleWorkspaceForParallel(ds, ws, sink)
+		err := buildSingleWorkspaceForParallel(ctx, ds, ws, sink, task)
 
 		// Flush the entire workspace output atomically
-		outputMu.Lock()
-		_, _ = io.Copy(os.Stdout, &buf)
-		outputMu.Unlock()
+		if !jsonProgress {
+			reporter.Log(buf.String())
+		}
+		if err != nil {
+			task.Fail(err)
+		} else {
+			task.Done()
+		}
 
 		// If the build failed AND the context was cancelled, surface the
 		// cancellation so the engine marks this workspace "interrupted".
-		if err != nil && ctx != nil && ctx.Err() != nil {
+		if err != nil && ctx.Err() != nil {
 			return ctx.Err()
 		}
 		return err
@@ -154,8 +184,11 @@ func runParallelBuild(cmd *cobra.Command) error {
 	// Extract accurate succeeded/failed counts directly from the BuildError
 	// returned by the engine. This avoids a DB round-trip that could return
 	// stale data from a concurrent or previous session.
-	succeeded, failed := getBuildCounts(len(workspaces), buildErr)
-	render.Plain(FormatBuildSummaryLine(succeeded, failed, len(workspaces)))
+	reporter.Close()
+	if !jsonProgress {
+		succeeded, failed := getBuildCounts(len(workspaces), buildErr)
+		render.Plain(FormatBuildSummaryLine(succeeded, failed, len(workspaces)))
+	}
 
 	return buildErr
 }
@@ -197,8 +230,8 @@ func getBuildCounts(total int, buildErr error) (succeeded, failed int) {
 //
 // On success, ws.Workspace.ImageName is updated to the built image tag
 // (e.g., "dvm-dev-myapp:20260410-123456") so the engine can persist it.
-func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHierarchy, out io.Writer) error {
-	ctx := context.Background()
+// Each phase is reported on task, which may be nil.
+func buildSingleWorkspaceForParallel(ctx context.Context, ds db.DataStore, ws *models.WorkspaceWithHierarchy, out io.Writer, task *progress.Task) error {
 	if buildTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, buildTimeout)
@@ -216,11 +249,13 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 	}
 
 	// Phase 1: Validate app path
+	task.Update("validating app path")
 	if err := bc.validateAppPath(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
 
 	// Phase 2: Platform & registry
+	task.Update("detecting platform")
 	if err := bc.detectBuildPlatform(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
@@ -228,13 +263,23 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
 
-	// Phase 3: Dockerfile detection & workspace spec
+	// Phase 3: Build config, Dockerfile detection & workspace spec
+	task.Update("resolving build config")
+	if err := bc.resolveAppBuildConfig(); err != nil {
+		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
+	}
 	bc.checkDockerfile()
 	if err := bc.prepareWorkspaceSpec(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
 
+	// Phase 3b: preBuild hooks, before the source is staged
+	if err := bc.runBuildHooks(models.HookPreBuild); err != nil {
+		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
+	}
+
 	// Phase 4: Source, staging, language detection
+	task.Update("staging source")
 	if err := bc.prepareSourceAndStaging(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
@@ -246,6 +291,7 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 	}()
 
 	// Phase 5: CA certs & nvim config
+	task.Update("generating configuration")
 	if err := bc.resolveCACerts(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
@@ -254,6 +300,7 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 	}
 
 	// Phase 6: Dockerfile generation & build
+	task.Update("generating Dockerfile")
 	if err := bc.generateDockerfileAndResolveArgs(); err != nil {
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
@@ -263,6 +310,7 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
 	}
 
+	task.Update("building image")
 	skipped, err := bc.buildImage()
 	if bc.builder != nil {
 		defer bc.builder.Close()
@@ -282,8 +330,12 @@ func buildSingleWorkspaceForParallel(ds db.DataStore, ws *models.WorkspaceWithHi
 		return nil
 	}
 
-	// Phase 7: Post-build (DB update, registry push, summary)
+	// Phase 7: Post-build (DB update, registry push, summary) and postBuild hooks
+	task.Update("finishing")
 	bc.postBuild()
+	if err := bc.runBuildHooks(models.HookPostBuild); err != nil {
+		return fmt.Errorf("%s/%s: %w", ws.App.Name, ws.Workspace.Name, err)
+	}
 
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"devopsmaestro/config"
 	"devopsmaestro/models"
+	"devopsmaestro/operators"
 	"devopsmaestro/pkg/buildlog"
 	"fmt"
 	"io"
@@ -27,7 +28,7 @@ func buildWorkspace(cmd *cobra.Command) error {
 	}
 
 	// Create timeout context from flag
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	if buildTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, buildTimeout)
@@ -44,6 +45,18 @@ func buildWorkspace(cmd *cobra.Command) error {
 	if err := bc.resolveWorkspaceTarget(); err != nil {
 		return err
 	}
+	if buildStaleOnly {
+		checker, err := newRebuildChecker(sqlDS)
+		if err != nil {
+			return err
+		}
+		reason := checker.reason(bc.app, bc.workspace, true)
+		if reason == "" {
+			bc.renderInfof("Workspace %s/%s is up to date; nothing to build", bc.appName, bc.workspaceName)
+			return nil
+		}
+		bc.renderInfof("Workspace %s/%s is stale: %s", bc.appName, bc.workspaceName, reason)
+	}
 	// Dry-run: preview what would be built
 	if buildDryRun {
 		bc.renderPlain(fmt.Sprintf("Would build image for workspace %q in app %q", bc.workspaceName, bc.appName))
@@ -196,6 +209,12 @@ func buildWorkspace(cmd *cobra.Command) error {
 	}
 
 	// Phase 2: Platform & registry
+	if endpoint, err := workspaceEndpoint(sqlDS, bc.workspace); err == nil && endpoint == nil {
+		if err := ensureColimaVM(ctx, bc.out(), operators.VMRequirements{}); err != nil {
+			buildErr = err
+			return buildErr
+		}
+	}
 	if err := bc.detectBuildPlatform(); err != nil {
 		buildErr = err
 		return buildErr
@@ -205,13 +224,23 @@ func buildWorkspace(cmd *cobra.Command) error {
 		return buildErr
 	}
 
-	// Phase 3: Dockerfile detection & workspace spec
+	// Phase 3: Build config, Dockerfile detection & workspace spec
+	if err := bc.resolveAppBuildConfig(); err != nil {
+		buildErr = err
+		return buildErr
+	}
 	bc.checkDockerfile()
 	if err := bc.prepareWorkspaceSpec(); err != nil {
 		buildErr = err
 		return buildErr
 	}
 
+	// Phase 3b: preBuild hooks, before the source is staged
+	if err := bc.runBuildHooks(models.HookPreBuild); err != nil {
+		buildErr = err
+		return buildErr
+	}
+
 	// Phase 4: Source, staging, language detection
 	if err := bc.prepareSourceAndStaging(); err != nil {
 		buildErr = err
@@ -259,8 +288,12 @@ func buildWorkspace(cmd *cobra.Command) error {
 		return nil
 	}
 
-	// Phase 7: Post-build (DB update, registry push, summary)
+	// Phase 7: Post-build (DB update, registry push, summary) and postBuild hooks
 	bc.postBuild()
+	if err := bc.runBuildHooks(models.HookPostBuild); err != nil {
+		buildErr = err
+		return buildErr
+	}
 
 	return nil
 }
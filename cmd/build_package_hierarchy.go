@@ -5,7 +5,10 @@ import (
 	"devopsmaestro/db"
 	"devopsmaestro/models"
 	"devopsmaestro/pkg/resolver"
+	"fmt"
 	"log/slog"
+
+	"github.com/rmkohlman/MaestroSDK/render"
 )
 
 // resolveNvimPackageFromHierarchy resolves the nvim package for a workspace
@@ -79,3 +82,27 @@ func resolveTerminalPackageFromHierarchy(ds db.DataStore, workspace *models.Work
 
 	return ""
 }
+
+// inheritedNvimPackage returns the nvim package a new app in the domain
+// inherits from the domain or its ecosystem, and the level that sets it.
+// The global default is not included: it applies only when nothing else is
+// set, so it never outranks a detected package.
+func inheritedNvimPackage(ds db.DataStore, domainID int) (pkg string, source string) {
+	adapter := resolver.NewDataStorePackageAdapter(ds)
+	resolution, err := resolver.NewHierarchyPackageResolver(adapter).ResolveNvimPackage(context.Background(), resolver.PackageLevelDomain, domainID)
+	if err != nil || resolution.PackageName == "" || resolution.Source == resolver.PackageLevelGlobal {
+		return "", ""
+	}
+	return resolution.PackageName, fmt.Sprintf("%s '%s'", resolution.Source, resolution.SourceName)
+}
+
+// renderDryRunPackages prints the default packages a dry-run create would
+// declare.
+func renderDryRunPackages(nvimPkg, terminalPkg string) {
+	if nvimPkg != "" {
+		render.Plain(fmt.Sprintf("  nvim-package: %s", nvimPkg))
+	}
+	if terminalPkg != "" {
+		render.Plain(fmt.Sprintf("  terminal-package: %s", terminalPkg))
+	}
+}
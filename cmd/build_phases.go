@@ -14,16 +14,20 @@ import (
 	"devopsmaestro/models"
 	"devopsmaestro/operators"
 	"devopsmaestro/pkg/buildargs/resolver"
+	"devopsmaestro/pkg/buildtemplate"
 	cacertsresolver "devopsmaestro/pkg/cacerts/resolver"
 	"devopsmaestro/pkg/envvalidation"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/hookrunner"
 	"devopsmaestro/pkg/registry"
 	"devopsmaestro/pkg/registry/envinjector"
-	wsresolver "devopsmaestro/pkg/resolver"
+	"devopsmaestro/pkg/telemetry"
 	"devopsmaestro/utils"
 	"devopsmaestro/utils/appkind"
 
 	"github.com/google/uuid"
 	"github.com/rmkohlman/MaestroSDK/paths"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // resolveWorkspaceTarget resolves the workspace from hierarchy flags or active context.
@@ -40,21 +44,9 @@ func (bc *buildContext) resolveFromHierarchyFlags() error {
 	slog.Debug("using hierarchy flags", "ecosystem", buildFlags.Ecosystem,
 		"domain", buildFlags.Domain, "system", buildFlags.System, "app", buildFlags.App, "workspace", buildFlags.Workspace)
 
-	wsResolver := wsresolver.NewWorkspaceResolver(bc.ds)
-	result, err := wsResolver.Resolve(buildFlags.ToFilter())
+	result, err := resolveWorkspace(bc.ds, buildFlags.ToFilter())
 	if err != nil {
-		if ambiguousErr, ok := wsresolver.IsAmbiguousError(err); ok {
-			bc.renderWarning("Multiple workspaces match your criteria")
-			bc.renderPlain(ambiguousErr.FormatDisambiguation())
-			bc.renderPlain(FormatSuggestions(SuggestAmbiguousWorkspace()...))
-			return fmt.Errorf("ambiguous workspace selection")
-		}
-		if wsresolver.IsNoWorkspaceFoundError(err) {
-			bc.renderWarning("No workspace found matching your criteria")
-			bc.renderPlain(FormatSuggestions(SuggestWorkspaceNotFound("")...))
-			return err
-		}
-		return fmt.Errorf("failed to resolve workspace: %w", err)
+		return err
 	}
 
 	bc.workspace = result.Workspace
@@ -72,15 +64,13 @@ func (bc *buildContext) resolveFromActiveContext() error {
 	bc.appName, err = getActiveAppFromContext(bc.ds)
 	if err != nil {
 		slog.Debug("no active app set")
-		bc.renderPlain(FormatSuggestions(SuggestNoActiveApp()...))
-		return fmt.Errorf("no active app set. Use 'dvm use app <name>' first")
+		return err
 	}
 
 	bc.workspaceName, err = getActiveWorkspaceFromContext(bc.ds)
 	if err != nil {
 		slog.Debug("no active workspace set")
-		bc.renderPlain(FormatSuggestions(SuggestNoActiveWorkspace()...))
-		return fmt.Errorf("no active workspace set. Use 'dvm use workspace <name>' first")
+		return err
 	}
 
 	slog.Debug("build context", "app", bc.appName, "workspace", bc.workspaceName)
@@ -121,11 +111,12 @@ func (bc *buildContext) validateAppPath() error {
 	return nil
 }
 
-// detectBuildPlatform detects the container platform (Docker/Colima/etc.).
+// detectBuildPlatform detects the container platform (Docker/Colima/etc.),
+// or uses the remote host of the ecosystem's runtime endpoint.
 // Sets bc.platform.
 func (bc *buildContext) detectBuildPlatform() error {
 	bc.renderProgress("Detecting container platform...")
-	platform, err := detectPlatform()
+	platform, err := workspacePlatform(bc.ds, bc.workspace)
 	if err != nil {
 		return err
 	}
@@ -141,6 +132,11 @@ func (bc *buildContext) prepareRegistry() error {
 	if !config.IsRegistryEnabled() {
 		return nil
 	}
+	if bc.platform != nil && bc.platform.IsRemote() {
+		// The caches listen on this machine; the remote host pulls directly
+		bc.renderInfo("Registry caches are not used on remote hosts")
+		return nil
+	}
 
 	coordinator := registry.NewBuildRegistryCoordinator(
 		bc.ds,
@@ -166,6 +162,9 @@ func (bc *buildContext) prepareRegistry() error {
 		// build performance. (Still non-fatal, but the user should see them.)
 		bc.renderError(w)
 	}
+	for _, name := range regResult.CacheReadiness.Unhealthy {
+		events.Emit(events.RegistryUnhealthy, map[string]any{"registry": name, "workspace": bc.workspaceName})
+	}
 	if len(regResult.Managers) > 0 {
 		bc.renderInfof("Started %d registry cache(s)", len(regResult.Managers))
 	}
@@ -176,12 +175,47 @@ func (bc *buildContext) prepareRegistry() error {
 	return nil
 }
 
-// checkDockerfile looks for an existing Dockerfile in the app directory.
+// resolveAppBuildConfig applies the app's build template, if any, to its
+// build config and loads the workspace's pending rebuild reason.
+// Sets bc.buildConfig and bc.rebuildReason.
+func (bc *buildContext) resolveAppBuildConfig() error {
+	cfg, err := buildtemplate.Resolve(bc.ds, bc.app)
+	if err != nil {
+		return err
+	}
+	bc.buildConfig = cfg
+	if cfg.Template != "" {
+		bc.renderInfof("Build template: %s", cfg.Template)
+	}
+
+	reasons, err := bc.ds.ListWorkspaceRebuildReasons()
+	if err != nil {
+		slog.Warn("failed to load pending workspace rebuilds", "error", err)
+	}
+	bc.rebuildReason = reasons[bc.workspace.ID]
+	return nil
+}
+
+// checkDockerfile looks for the Dockerfile named by the build config, or an
+// existing Dockerfile in the app directory.
 // Sets bc.hasDockerfile and bc.dockerfilePath.
 func (bc *buildContext) checkDockerfile() {
 	bc.renderBlank()
 	bc.renderProgress("Checking for Dockerfile...")
-	bc.hasDockerfile, bc.dockerfilePath = utils.HasDockerfile(bc.app.Path)
+	if name := bc.buildConfig.Dockerfile; name != "" {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(bc.app.Path, name)
+		}
+		if _, err := os.Stat(path); err == nil {
+			bc.hasDockerfile, bc.dockerfilePath = true, path
+		} else {
+			bc.renderWarningf("Dockerfile %s not found, looking for one in the app directory", name)
+			bc.hasDockerfile, bc.dockerfilePath = utils.HasDockerfile(bc.app.Path)
+		}
+	} else {
+		bc.hasDockerfile, bc.dockerfilePath = utils.HasDockerfile(bc.app.Path)
+	}
 	if bc.hasDockerfile {
 		bc.renderInfof("Found: %s", bc.dockerfilePath)
 		slog.Debug("found existing Dockerfile", "path", bc.dockerfilePath)
@@ -223,9 +257,10 @@ func (bc *buildContext) prepareWorkspaceSpec() error {
 	return nil
 }
 
-// prepareSourceAndStaging determines the build source path, detects the language,
-// and creates the staging directory with shell configuration.
-// Sets bc.sourcePath, bc.languageName, bc.version, bc.stagingDir.
+// prepareSourceAndStaging determines the build source path, hashes the build
+// inputs, detects the language, and creates the staging directory with shell
+// configuration.
+// Sets bc.sourcePath, bc.buildInputs, bc.languageName, bc.version, bc.stagingDir.
 func (bc *buildContext) prepareSourceAndStaging() error {
 	var err error
 	bc.sourcePath, err = getBuildSourcePath(bc.ds, bc.workspace, bc.app.Path)
@@ -233,9 +268,20 @@ func (bc *buildContext) prepareSourceAndStaging() error {
 		return fmt.Errorf("failed to determine build source path: %w", err)
 	}
 
+	// Hash the build inputs before staging, so edits made during the build
+	// leave the image marked for a rebuild
+	bc.buildInputs, err = hashBuildInputs(bc.sourcePath, bc.buildConfig, bc.workspace.BuildConfig.String, nil)
+	if err != nil {
+		slog.Warn("failed to hash build inputs", "workspace", bc.workspaceName, "error", err)
+	}
+
 	// Detect AppKind first (#404). For CICD apps we skip language detection
 	// entirely and use the alpine + kubectl/helm/kustomize build path.
-	kind, evidence, _ := appkind.Detect(bc.sourcePath, bc.app, bc.app.GetKind())
+	kindOverride := bc.buildConfig.Kind
+	if kindOverride == "" {
+		kindOverride = "auto"
+	}
+	kind, evidence, _ := appkind.Detect(bc.sourcePath, bc.app, kindOverride)
 	bc.appKind = string(kind)
 	for _, s := range evidence.Signals {
 		if strings.HasPrefix(s, "signal3:") {
@@ -297,6 +343,23 @@ func (bc *buildContext) detectLanguageAndReport() (string, string, bool) {
 // resolveCACerts resolves hierarchical CA certificates and prepares them for the build.
 // Modifies bc.workspaceYAML.Spec.Build.CACerts if cascade resolution succeeds.
 func (bc *buildContext) resolveCACerts() error {
+	bc.cascadeCACerts()
+
+	// Prepare CA certificates if configured
+	if len(bc.workspaceYAML.Spec.Build.CACerts) > 0 {
+		bc.renderProgress("Resolving CA certificates from vault...")
+		if err := prepareCACerts(bc.stagingDir, bc.workspaceYAML.Spec.Build.CACerts); err != nil {
+			return err
+		}
+		bc.renderInfof("Injecting %d CA certificate(s) into build context", len(bc.workspaceYAML.Spec.Build.CACerts))
+	}
+	return nil
+}
+
+// cascadeCACerts replaces bc.workspaceYAML.Spec.Build.CACerts with the
+// hierarchical CA certs, without fetching them from vault. Resolution errors
+// are logged and leave the workspace's own certs in place.
+func (bc *buildContext) cascadeCACerts() {
 	caCertsResolver := cacertsresolver.NewHierarchyCACertsResolver(bc.ds)
 	caCertsResolution, caCertsErr := caCertsResolver.Resolve(context.Background(), bc.workspace.ID)
 	if caCertsErr != nil {
@@ -314,20 +377,10 @@ func (bc *buildContext) resolveCACerts() error {
 		}
 		bc.workspaceYAML.Spec.Build.CACerts = mergedCerts
 	}
-
-	// Prepare CA certificates if configured
-	if len(bc.workspaceYAML.Spec.Build.CACerts) > 0 {
-		bc.renderProgress("Resolving CA certificates from vault...")
-		if err := prepareCACerts(bc.stagingDir, bc.workspaceYAML.Spec.Build.CACerts); err != nil {
-			return err
-		}
-		bc.renderInfof("Injecting %d CA certificate(s) into build context", len(bc.workspaceYAML.Spec.Build.CACerts))
-	}
-	return nil
 }
 
 // generateNvimConfiguration generates nvim config if a structure is configured.
-// Sets bc.pluginManifest.
+// Sets bc.pluginManifest, bc.pluginFiletypes, and bc.nvimPlugins.
 // Before generating config, it auto-syncs embedded libraries to the DB
 // if the library fingerprint has changed (issue #255).
 func (bc *buildContext) generateNvimConfiguration() error {
@@ -337,11 +390,14 @@ func (bc *buildContext) generateNvimConfiguration() error {
 
 	// Auto-sync embedded libraries to DB if fingerprint changed (#255).
 	// This ensures builds always use the latest embedded plugin definitions.
-	if err := EnsureLibrarySynced(bc.ds); err != nil {
+	_, span := telemetry.Start(bc.ctx, "dvm.library.sync")
+	err := EnsureLibrarySynced(bc.ds)
+	telemetry.End(span, err)
+	if err != nil {
 		slog.Warn("library auto-sync failed, continuing with existing DB data", "error", err)
 	}
 
-	manifest, err := generateNvimConfig(
+	manifest, plugins, err := generateNvimConfig(
 		bc.workspaceYAML.Spec.Nvim.Plugins, bc.stagingDir, bc.homeDir, bc.ds,
 		bc.app, bc.workspace, bc.appName, bc.workspaceName, bc.languageName, bc.out(),
 	)
@@ -349,6 +405,8 @@ func (bc *buildContext) generateNvimConfiguration() error {
 		return err
 	}
 	bc.pluginManifest = manifest
+	bc.pluginFiletypes = pluginFiletypes(plugins)
+	bc.nvimPlugins = plugins
 	return nil
 }
 
@@ -358,6 +416,30 @@ func (bc *buildContext) generateNvimConfiguration() error {
 func (bc *buildContext) generateDockerfileAndResolveArgs() error {
 	bc.renderBlank()
 	bc.renderProgress("Generating Dockerfile.dvm...")
+
+	dockerfileContent, err := bc.generateDockerfileContent()
+	if err != nil {
+		return err
+	}
+
+	bc.dvmDockerfile, err = builders.SaveDockerfile(dockerfileContent, bc.stagingDir)
+	if err != nil {
+		slog.Error("failed to save Dockerfile", "error", err)
+		return err
+	}
+	slog.Debug("saved Dockerfile", "path", bc.dvmDockerfile)
+
+	artifactsCfg := config.GetConfig().Artifacts
+	if artifactsCfg.Prefetch || artifactsCfg.Offline {
+		return bc.prefetchArtifacts(dockerfileContent, artifactsCfg)
+	}
+	return nil
+}
+
+// generateDockerfileContent resolves cascade build arg names and returns the
+// generated Dockerfile.dvm content without writing it.
+// Sets bc.cascadeResolution.
+func (bc *buildContext) generateDockerfileContent() (string, error) {
 	slog.Debug("generating Dockerfile", "language", bc.languageName, "version", bc.version)
 
 	// Detect private repos and system dependencies
@@ -371,6 +453,12 @@ func (bc *buildContext) generateDockerfileAndResolveArgs() error {
 
 	// Pre-compute additional build arg names for Dockerfile ARG declarations
 	additionalBuildArgNames := bc.resolveBuildArgNames()
+	artifactsCfg := config.GetConfig().Artifacts
+
+	baseImage := bc.resolveBaseImage()
+	if baseImage != "" {
+		bc.renderInfof("Base image: %s", baseImage)
+	}
 
 	generator := builders.NewDockerfileGenerator(builders.DockerfileGeneratorOptions{
 		Workspace:           bc.workspace,
@@ -383,8 +471,12 @@ func (bc *buildContext) generateDockerfileAndResolveArgs() error {
 		PathConfig:          paths.New(bc.homeDir),
 		PrivateRepoInfo:     privateRepoInfo,
 		AdditionalBuildArgs: additionalBuildArgNames,
+		PluginFiletypes:     bc.pluginFiletypes,
 		AppKind:             bc.appKind,
 		ArgoCDDetected:      bc.argoCDDetected,
+		PrefetchArtifacts:   artifactsCfg.Prefetch || artifactsCfg.Offline,
+		AppHooks:            bc.buildConfig.Hooks,
+		BaseImage:           baseImage,
 	})
 
 	if bc.pluginManifest != nil {
@@ -394,16 +486,9 @@ func (bc *buildContext) generateDockerfileAndResolveArgs() error {
 	dockerfileContent, err := generator.Generate()
 	if err != nil {
 		slog.Error("failed to generate Dockerfile", "error", err)
-		return fmt.Errorf("failed to generate Dockerfile: %w", err)
+		return "", fmt.Errorf("failed to generate Dockerfile: %w", err)
 	}
-
-	bc.dvmDockerfile, err = builders.SaveDockerfile(dockerfileContent, bc.stagingDir)
-	if err != nil {
-		slog.Error("failed to save Dockerfile", "error", err)
-		return err
-	}
-	slog.Debug("saved Dockerfile", "path", bc.dvmDockerfile)
-	return nil
+	return dockerfileContent, nil
 }
 
 // resolveBuildArgNames resolves hierarchical build args and collects all
@@ -508,9 +593,23 @@ func (bc *buildContext) validateStagingDirectory() error {
 // build args, and executes the container image build.
 // Sets bc.imageName, bc.builder. Returns true if build was skipped (image exists).
 func (bc *buildContext) buildImage() (skipped bool, err error) {
-	// Generate image name with timestamp tag
-	timestamp := time.Now().Format("20060102-150405")
-	bc.imageName = fmt.Sprintf("dvm-%s-%s:%s", bc.workspaceName, bc.appName, timestamp)
+	// Name the image with the configured template (a timestamp tag by default)
+	bc.imageName, err = bc.renderImageName()
+	if err != nil {
+		return false, err
+	}
+
+	_, span := telemetry.Start(bc.ctx, "dvm.build.image",
+		attribute.String("dvm.app", bc.appName),
+		attribute.String("dvm.workspace", bc.workspaceName),
+		attribute.String("dvm.image", bc.imageName),
+	)
+	started := time.Now()
+	defer func() {
+		span.SetAttributes(attribute.Bool("dvm.build.skipped", skipped))
+		telemetry.End(span, err)
+		bc.emitBuildFinished(started, skipped, err)
+	}()
 	bc.renderBlank()
 	bc.renderProgressf("Building image: %s", bc.imageName)
 	slog.Info("building image", "image", bc.imageName, "dockerfile", bc.dvmDockerfile)
@@ -519,8 +618,10 @@ func (bc *buildContext) buildImage() (skipped bool, err error) {
 		return false, err
 	}
 
-	// Check if image exists (skip if --force)
-	if !buildForce {
+	// Check if image exists (skip if --force or a rebuild is pending)
+	if bc.rebuildReason != "" {
+		bc.renderInfof("Rebuilding: %s", bc.rebuildReason)
+	} else if !buildForce {
 		exists, existsErr := bc.builder.ImageExists(bc.ctx)
 		if existsErr == nil && exists {
 			slog.Debug("image already exists, skipping build", "image", bc.imageName)
@@ -540,6 +641,7 @@ func (bc *buildContext) buildImage() (skipped bool, err error) {
 	// mismatch that blocked registry-based caching via Zot (see #225).
 	buildOpts := builders.BuildOptions{
 		BuildArgs:          buildArgs,
+		Labels:             operators.ResourceLabels(bc.labelScope()),
 		Target:             buildTarget,
 		NoCache:            buildNocache,
 		Timeout:            buildTimeout,
@@ -686,6 +788,25 @@ buildSuccess:
 	return false, nil
 }
 
+// emitBuildFinished sends the build.finished event for buildImage.
+func (bc *buildContext) emitBuildFinished(started time.Time, skipped bool, err error) {
+	data := map[string]any{
+		"app":             bc.appName,
+		"workspace":       bc.workspaceName,
+		"image":           bc.imageName,
+		"status":          "succeeded",
+		"durationSeconds": int(time.Since(started).Seconds()),
+	}
+	switch {
+	case err != nil:
+		data["status"] = "failed"
+		data["error"] = err.Error()
+	case skipped:
+		data["status"] = "skipped"
+	}
+	events.Emit(events.BuildFinished, data)
+}
+
 // createBuilder creates the image builder, using staging dir as build context
 // with a fallback to app path.
 // Sets bc.builder.
@@ -717,6 +838,9 @@ func (bc *buildContext) pruneBuildKitCache() error {
 		return bkBuilder.PruneBuildKitCache(bc.ctx)
 	}
 	// Fallback: use system cleaner for Docker-based platforms
+	if bc.platform != nil && bc.platform.IsRemote() && !bc.platform.IsDockerCompatible() {
+		return fmt.Errorf("cache prune is not supported on remote nerdctl hosts")
+	}
 	if bc.platform != nil {
 		cleaner := operators.NewSystemCleaner(bc.platform)
 		_, err := cleaner.PruneBuildKit(bc.ctx, false)
@@ -796,6 +920,18 @@ func (bc *buildContext) postBuild() {
 			"workspace_id", bc.workspace.ID, "image", bc.imageName, "error", err)
 	}
 
+	if bc.rebuildReason != "" {
+		if err := bc.ds.SetWorkspaceRebuildReason(bc.workspace.ID, ""); err != nil {
+			slog.Warn("failed to clear pending rebuild", "workspace_id", bc.workspace.ID, "error", err)
+		}
+	}
+	if bc.buildInputs != "" {
+		if err := bc.ds.SetWorkspaceBuildInputs(bc.workspace.ID, bc.buildInputs); err != nil {
+			slog.Warn("failed to record build inputs", "workspace_id", bc.workspace.ID, "error", err)
+		}
+	}
+	bc.recordImageDigest()
+
 	// Push to registry if --push flag is set and registry is available
 	if buildPush && bc.registryEndpoint != "" {
 		bc.pushToRegistry()
@@ -804,6 +940,9 @@ func (bc *buildContext) postBuild() {
 		bc.renderInfo("Start the registry with: dvm registry start")
 	}
 
+	// Record what went into the image with this build's session entry.
+	bc.recordSBOM()
+
 	// Prune old images for this workspace (auto-cleanup after successful build).
 	bc.pruneOldImages()
 
@@ -840,6 +979,21 @@ func (bc *buildContext) postBuild() {
 	bc.renderInfo("Next: Attach to your workspace with: dvm attach")
 }
 
+// runBuildHooks runs the workspace's preBuild or postBuild hooks on the
+// host, in the app's directory.
+func (bc *buildContext) runBuildHooks(point string) error {
+	hooks := workspaceLifecycleHooks(bc.app, bc.workspace)
+	if len(hooks.At(point)) == 0 {
+		return nil
+	}
+	runner := &hookrunner.Runner{
+		HostDir: bc.app.Path,
+		Env:     hookEnv(bc.ds, bc.app, bc.workspace, "", "", "", bc.out()),
+		Output:  bc.out(),
+	}
+	return runLifecycleHooks(bc.ctx, bc.out(), runner, hooks, point)
+}
+
 // pushToRegistry tags and pushes the built image to the registry.
 func (bc *buildContext) pushToRegistry() {
 	bc.renderBlank()
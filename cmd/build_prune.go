@@ -8,7 +8,6 @@ import (
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
-	"github.com/docker/docker/client"
 
 	"devopsmaestro/operators"
 )
@@ -66,10 +65,7 @@ func pruneImagesForRepo(
 		return nil, nil
 	}
 
-	cli, err := client.NewClientWithOpts(
-		client.WithHost(fmt.Sprintf("unix://%s", platform.SocketPath)),
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := operators.NewDockerClient(platform)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client for pruning: %w", err)
 	}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// buildRenderFlags holds the hierarchy flags for 'build render'
+var buildRenderFlags HierarchyFlags
+
+// buildRenderCmd prints the Dockerfile 'dvm build' would generate.
+var buildRenderCmd = &cobra.Command{
+	Use:   "render [workspace]",
+	Short: "Print the Dockerfile a build would generate",
+	Long: `Generate the workspace's Dockerfile.dvm exactly as 'dvm build' would,
+including the app and workspace spec.build.hooks, and print it without
+building an image.
+
+CA certificates are not fetched from MaestroVault, so rendering works without
+vault access; the COPY of certs/ still appears when certs are configured.
+Progress messages go to stderr, so the Dockerfile can be redirected.
+
+The workspace is the active one unless a name or hierarchy flags are given.
+
+Examples:
+  dvm build render
+  dvm build render dev -a api
+  dvm build render dev --out Dockerfile.preview`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBuildRender,
+}
+
+func init() {
+	AddHierarchyFlags(buildRenderCmd, &buildRenderFlags)
+	buildRenderCmd.Flags().String("out", "", "Output file (default: stdout)")
+	buildRenderCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+	buildCmd.AddCommand(buildRenderCmd)
+}
+
+func runBuildRender(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	wh, err := resolveSessionWorkspace(ds, buildRenderFlags, firstArg(args))
+	if err != nil {
+		return err
+	}
+
+	bc := &buildContext{
+		ds:            ds,
+		ctx:           commandContext(cmd),
+		output:        cmd.ErrOrStderr(),
+		app:           wh.App,
+		workspace:     wh.Workspace,
+		appName:       wh.App.Name,
+		workspaceName: wh.Workspace.Name,
+	}
+	if err := bc.validateAppPath(); err != nil {
+		return err
+	}
+	if err := bc.resolveAppBuildConfig(); err != nil {
+		return err
+	}
+	bc.checkDockerfile()
+	if err := bc.prepareWorkspaceSpec(); err != nil {
+		return err
+	}
+	if err := bc.prepareSourceAndStaging(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(bc.stagingDir); err != nil {
+			slog.Warn("failed to clean up staging directory", "path", bc.stagingDir, "error", err)
+		}
+	}()
+	bc.cascadeCACerts()
+	if err := bc.generateNvimConfiguration(); err != nil {
+		return err
+	}
+
+	dockerfile, err := bc.generateDockerfileContent()
+	if err != nil {
+		return err
+	}
+	if err := writeCommandOutput(cmd, out, []byte(dockerfile), force); err != nil {
+		return err
+	}
+	if out != "" {
+		render.Successf("Wrote Dockerfile for %s/%s to %s", bc.appName, bc.workspaceName, out)
+	}
+	return nil
+}
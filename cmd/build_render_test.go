@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRender_SplicesHooks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	appPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(appPath, "go.mod"), []byte("module example.com/api\n\ngo 1.22\n"), 0644))
+
+	store := db.NewMockDataStore()
+	eco := &models.Ecosystem{Name: "acme"}
+	require.NoError(t, store.CreateEcosystem(eco))
+	domain := &models.Domain{Name: "core", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, store.CreateDomain(domain))
+	app := &models.App{
+		Name:        "api",
+		Path:        appPath,
+		DomainID:    sql.NullInt64{Int64: int64(domain.ID), Valid: true},
+		BuildConfig: sql.NullString{String: `{"hooks":{"preBase":"RUN echo app-hook"}}`, Valid: true},
+	}
+	require.NoError(t, store.CreateApp(app))
+	workspace := &models.Workspace{
+		AppID:         app.ID,
+		Name:          "dev",
+		Slug:          "acme-core-api-dev",
+		NvimStructure: sql.NullString{String: "none", Valid: true},
+		BuildConfig:   sql.NullString{String: `{"hooks":{"postTools":"RUN echo workspace-hook"}}`, Valid: true},
+	}
+	require.NoError(t, store.CreateWorkspace(workspace))
+
+	out := filepath.Join(t.TempDir(), "Dockerfile.preview")
+	require.NoError(t, buildRenderCmd.Flags().Set("out", out))
+	buildRenderFlags = HierarchyFlags{App: "api"}
+	t.Cleanup(func() {
+		_ = buildRenderCmd.Flags().Set("out", "")
+		buildRenderFlags = HierarchyFlags{}
+	})
+	buildRenderCmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(store)))
+	buildRenderCmd.SetErr(&strings.Builder{})
+
+	require.NoError(t, runBuildRender(buildRenderCmd, []string{"dev"}))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	dockerfile := string(data)
+	assert.Contains(t, dockerfile, "# Hook: preBase (spec.build.hooks)\nRUN echo app-hook\n")
+	assert.Contains(t, dockerfile, "# Hook: postTools (spec.build.hooks)\nRUN echo workspace-hook\n")
+}
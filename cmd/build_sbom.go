@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/sbom"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// buildSBOMFlags holds the hierarchy flags for 'build sbom'
+var buildSBOMFlags HierarchyFlags
+
+// buildSBOMCmd exports the SBOM recorded by a workspace's last image build.
+var buildSBOMCmd = &cobra.Command{
+	Use:   "sbom [workspace]",
+	Short: "Export the SBOM of a workspace's image",
+	Long: `Export the software bill of materials recorded by the workspace's most
+recent successful image build.
+
+Every build records the base image digests, the apt/apk, pip, npm, go, and
+cargo packages the Dockerfile installs, the Neovim release, and the Neovim
+plugins with the commits pinned in ~/.nvp/lazy-lock.json (see 'nvp lock').
+Plugins without a pin are listed with the tag or branch they track.
+
+Packages installed only in builder stages are kept in the document but marked
+as build-time tools (SPDX BUILD_TOOL_OF, CycloneDX scope "excluded").
+
+The workspace is the active one unless a name or hierarchy flags are given.
+Without --out, the document is written to stdout.
+
+Examples:
+  dvm build sbom
+  dvm build sbom dev -a api --format cyclonedx
+  dvm build sbom --out api.spdx.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBuildSBOM,
+}
+
+func init() {
+	AddHierarchyFlags(buildSBOMCmd, &buildSBOMFlags)
+	buildSBOMCmd.Flags().String("format", sbom.FormatSPDX, "Document format: "+strings.Join(sbom.Formats, ", "))
+	buildSBOMCmd.Flags().String("out", "", "Output file (default: stdout)")
+	buildSBOMCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+	buildCmd.AddCommand(buildSBOMCmd)
+}
+
+func runBuildSBOM(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	out, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	wh, err := resolveSessionWorkspace(ds, buildSBOMFlags, firstArg(args))
+	if err != nil {
+		return err
+	}
+
+	entry, err := ds.GetLatestBuildSBOM(wh.Workspace.ID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrorWithSuggestion(
+			fmt.Sprintf("no SBOM recorded for workspace '%s'", wh.Workspace.Name),
+			"Build the workspace image with: dvm build --force")
+	}
+	inv, err := sbom.Parse(entry.SBOM.String)
+	if err != nil {
+		return err
+	}
+	data, err := inv.Encode(format)
+	if err != nil {
+		return err
+	}
+
+	if err := writeCommandOutput(cmd, out, data, force); err != nil {
+		return err
+	}
+	if out == "" {
+		return nil
+	}
+	render.Successf("Wrote %s SBOM for image %s to %s", format, inv.Image, out)
+	return nil
+}
+
+// recordSBOM stores the inventory of the image just built with the
+// workspace's entry in the running build session. A missing SBOM never fails
+// the build, so errors are only logged.
+func (bc *buildContext) recordSBOM() {
+	entry, err := bc.ds.GetLatestBuildSessionWorkspace(bc.workspace.ID)
+	if err != nil || entry == nil || entry.Status != "building" {
+		slog.Warn("no build session entry to record the SBOM with",
+			"workspace_id", bc.workspace.ID, "error", err)
+		return
+	}
+	dockerfile, err := os.ReadFile(bc.dvmDockerfile)
+	if err != nil {
+		slog.Warn("failed to read Dockerfile for SBOM", "path", bc.dvmDockerfile, "error", err)
+		return
+	}
+
+	inv := sbom.New(bc.workspaceName, bc.imageName, time.Now(), string(dockerfile),
+		bc.nvimPlugins, loadPluginLock(bc.homeDir))
+	data, err := inv.Marshal()
+	if err == nil {
+		err = bc.ds.SetBuildSessionWorkspaceSBOM(entry.ID, data)
+	}
+	if err != nil {
+		slog.Warn("failed to record build SBOM", "workspace_id", bc.workspace.ID, "error", err)
+		return
+	}
+	slog.Debug("recorded build SBOM", "image", bc.imageName,
+		"base_images", len(inv.BaseImages), "components", len(inv.Components))
+}
+
+// loadPluginLock reads the plugin commit pins 'nvp lock' maintains, or nil
+// when there are none.
+func loadPluginLock(homeDir string) *plugin.LockFile {
+	lockPath := filepath.Join(paths.New(homeDir).NVPRoot(), "lazy-lock.json")
+	lf, err := plugin.ParseLockFile(lockPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("ignoring unreadable plugin lock file", "path", lockPath, "error", err)
+		}
+		return nil
+	}
+	return lf
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSBOMTestStore returns a store holding the workspace acme/core/api/dev.
+func newSBOMTestStore(t *testing.T) (*db.MockDataStore, *models.Workspace) {
+	t.Helper()
+	store := db.NewMockDataStore()
+	eco := &models.Ecosystem{Name: "acme"}
+	require.NoError(t, store.CreateEcosystem(eco))
+	domain := &models.Domain{Name: "core", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, store.CreateDomain(domain))
+	app := &models.App{Name: "api", Path: "/src/api", DomainID: sql.NullInt64{Int64: int64(domain.ID), Valid: true}}
+	require.NoError(t, store.CreateApp(app))
+	workspace := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "acme-core-api-dev"}
+	require.NoError(t, store.CreateWorkspace(workspace))
+	return store, workspace
+}
+
+func TestBuildSBOM_RecordAndExport(t *testing.T) {
+	store, workspace := newSBOMTestStore(t)
+
+	session := &models.BuildSession{ID: "sbom-session", StartedAt: time.Now(), Status: "running", TotalWorkspaces: 1}
+	require.NoError(t, store.CreateBuildSession(session))
+	bsw := &models.BuildSessionWorkspace{SessionID: session.ID, WorkspaceID: workspace.ID, Status: "building"}
+	require.NoError(t, store.CreateBuildSessionWorkspace(bsw))
+
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile.dvm")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM golang:1.22-alpine@sha256:1699 AS base\nRUN apk add git\nFROM base AS dev\n"), 0644))
+	home := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".nvp"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".nvp", "lazy-lock.json"),
+		[]byte(`{"telescope.nvim": {"branch": "master", "commit": "a0bbec2"}}`), 0644))
+
+	bc := &buildContext{
+		ds:            store,
+		workspace:     workspace,
+		workspaceName: workspace.Name,
+		imageName:     "dvm-dev-api:20261017-093000",
+		dvmDockerfile: dockerfile,
+		homeDir:       home,
+		nvimPlugins:   []*plugin.Plugin{{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Branch: "master"}},
+	}
+	bc.recordSBOM()
+
+	// Finishing the session entry keeps the SBOM
+	bsw.Status = "succeeded"
+	require.NoError(t, store.UpdateBuildSessionWorkspace(bsw))
+
+	out := filepath.Join(t.TempDir(), "api.cdx.json")
+	flags := buildSBOMCmd.Flags()
+	require.NoError(t, flags.Set("format", "cyclonedx"))
+	require.NoError(t, flags.Set("out", out))
+	buildSBOMFlags = HierarchyFlags{App: "api"}
+	t.Cleanup(func() {
+		_ = flags.Set("format", "spdx")
+		_ = flags.Set("out", "")
+		buildSBOMFlags = HierarchyFlags{}
+	})
+	buildSBOMCmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(store)))
+
+	require.NoError(t, runBuildSBOM(buildSBOMCmd, []string{"dev"}))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var doc struct {
+		BOMFormat string `json:"bomFormat"`
+		Metadata  struct {
+			Component struct{ Name string } `json:"component"`
+		} `json:"metadata"`
+		Components []struct{ Name, Version, PURL string } `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "dvm-dev-api:20261017-093000", doc.Metadata.Component.Name)
+	require.Len(t, doc.Components, 3)
+	assert.Equal(t, "golang:1.22-alpine", doc.Components[0].Name)
+	assert.Equal(t, "pkg:apk/alpine/git", doc.Components[1].PURL)
+	assert.Equal(t, "a0bbec2", doc.Components[2].Version, "plugins carry their pinned commit")
+}
+
+func TestBuildSBOM_NoneRecorded(t *testing.T) {
+	store, _ := newSBOMTestStore(t)
+
+	buildSBOMFlags = HierarchyFlags{App: "api"}
+	t.Cleanup(func() { buildSBOMFlags = HierarchyFlags{} })
+	buildSBOMCmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(store)))
+
+	err := runBuildSBOM(buildSBOMCmd, []string{"dev"})
+	assert.ErrorContains(t, err, "no SBOM recorded for workspace 'dev'")
+}
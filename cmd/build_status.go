@@ -28,8 +28,15 @@ Examples:
   dvm build status --session-id <uuid>
   dvm build status --history
   dvm build status -o json
-  dvm build status -o yaml`,
+  dvm build status -o yaml
+  dvm build status --watch        # Follow a running build`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if buildStatusWatch {
+			format, _ := cmd.Flags().GetString("output")
+			return runWatch(cmd, format, buildStatusInterval, func() error {
+				return runBuildStatus(cmd)
+			})
+		}
 		return runBuildStatus(cmd)
 	},
 }
@@ -37,6 +44,8 @@ Examples:
 var (
 	buildStatusSessionID string
 	buildStatusHistory   bool
+	buildStatusWatch     bool
+	buildStatusInterval  time.Duration
 )
 
 func init() {
@@ -45,6 +54,7 @@ func init() {
 		"Show status for a specific build session ID")
 	buildStatusCmd.Flags().BoolVar(&buildStatusHistory, "history", false,
 		"Show recent build session history")
+	AddWatchFlags(buildStatusCmd, &buildStatusWatch, &buildStatusInterval)
 }
 
 // BuildStatusTableHeaders returns the ALL-CAPS column headers for the build
@@ -81,21 +91,45 @@ func runBuildStatus(cmd *cobra.Command) error {
 		return err
 	}
 
+	format, _ := cmd.Flags().GetString("output")
+
 	// History mode: show recent sessions
 	if buildStatusHistory {
-		return showBuildHistory(ds)
+		return showBuildHistory(ds, format)
 	}
 
 	// Specific session or latest
 	if buildStatusSessionID != "" {
-		return showBuildSession(ds, buildStatusSessionID)
+		return showBuildSession(ds, buildStatusSessionID, format)
 	}
 
-	return showLatestBuildSession(ds)
+	return showLatestBuildSession(ds, format)
+}
+
+// BuildSessionOutput is the -o json/yaml form of a build session.
+type BuildSessionOutput struct {
+	ID          string                        `json:"id" yaml:"id"`
+	Status      string                        `json:"status" yaml:"status"`
+	StartedAt   time.Time                     `json:"startedAt" yaml:"startedAt"`
+	CompletedAt *time.Time                    `json:"completedAt,omitempty" yaml:"completedAt,omitempty"`
+	Duration    string                        `json:"duration" yaml:"duration"`
+	Total       int                           `json:"total" yaml:"total"`
+	Succeeded   int                           `json:"succeeded" yaml:"succeeded"`
+	Failed      int                           `json:"failed" yaml:"failed"`
+	Workspaces  []BuildSessionWorkspaceOutput `json:"workspaces,omitempty" yaml:"workspaces,omitempty"`
+}
+
+// BuildSessionWorkspaceOutput is one workspace of a BuildSessionOutput.
+type BuildSessionWorkspaceOutput struct {
+	Workspace       string `json:"workspace" yaml:"workspace"` // app/workspace
+	Status          string `json:"status" yaml:"status"`
+	ImageTag        string `json:"imageTag,omitempty" yaml:"imageTag,omitempty"`
+	DurationSeconds *int64 `json:"durationSeconds,omitempty" yaml:"durationSeconds,omitempty"`
+	Error           string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 // showLatestBuildSession displays the most recent build session.
-func showLatestBuildSession(ds db.DataStore) error {
+func showLatestBuildSession(ds db.DataStore, format string) error {
 	session, err := ds.GetLatestBuildSession()
 	if err != nil {
 		return fmt.Errorf("failed to query build sessions: %w", err)
@@ -103,20 +137,20 @@ func showLatestBuildSession(ds db.DataStore) error {
 	if session == nil {
 		return fmt.Errorf("%s", FormatNoActiveBuildSessionMessage())
 	}
-	return renderBuildSession(ds, session)
+	return renderBuildSessionAs(ds, session, format)
 }
 
 // showBuildSession displays a specific build session by ID.
-func showBuildSession(ds db.DataStore, sessionID string) error {
+func showBuildSession(ds db.DataStore, sessionID, format string) error {
 	session, err := ds.GetBuildSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to get build session: %w", err)
 	}
-	return renderBuildSession(ds, session)
+	return renderBuildSessionAs(ds, session, format)
 }
 
 // showBuildHistory displays recent build sessions.
-func showBuildHistory(ds db.DataStore) error {
+func showBuildHistory(ds db.DataStore, format string) error {
 	sessions, err := ds.GetBuildSessions(10)
 	if err != nil {
 		return fmt.Errorf("failed to query build sessions: %w", err)
@@ -125,22 +159,62 @@ func showBuildHistory(ds db.DataStore) error {
 		return fmt.Errorf("%s", FormatNoActiveBuildSessionMessage())
 	}
 
-	render.Plain("Recent build sessions:")
-	render.Plain("")
+	out := make([]BuildSessionOutput, 0, len(sessions))
 	for _, s := range sessions {
 		duration := "in progress"
 		if s.CompletedAt.Valid {
 			duration = s.CompletedAt.Time.Sub(s.StartedAt).Round(time.Second).String()
 		}
+		out = append(out, newBuildSessionOutput(s, s.Status, duration, s.Succeeded, s.Failed))
+	}
+
+	switch {
+	case isStructuredOutput(format):
+		return render.OutputWith(format, out, render.Options{})
+	case format == outputName:
+		for _, s := range out {
+			render.Plain(s.ID)
+		}
+		return nil
+	}
+
+	render.Plain("Recent build sessions:")
+	render.Plain("")
+	for _, s := range out {
 		render.Plain(fmt.Sprintf("  %s  %s  %s  %d/%d succeeded  %s",
 			s.ID[:8], s.Status, s.StartedAt.Format("2006-01-02 15:04:05"),
-			s.Succeeded, s.TotalWorkspaces, duration))
+			s.Succeeded, s.Total, s.Duration))
 	}
 	return nil
 }
 
+// newBuildSessionOutput builds the output form of a session with the given
+// (possibly reconciled) status and counters.
+func newBuildSessionOutput(s *models.BuildSession, status, duration string, succeeded, failed int) BuildSessionOutput {
+	out := BuildSessionOutput{
+		ID:        s.ID,
+		Status:    status,
+		StartedAt: s.StartedAt,
+		Duration:  duration,
+		Total:     s.TotalWorkspaces,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+	if s.CompletedAt.Valid {
+		completed := s.CompletedAt.Time
+		out.CompletedAt = &completed
+	}
+	return out
+}
+
 // renderBuildSession displays a single build session with workspace details.
 func renderBuildSession(ds db.DataStore, session *models.BuildSession) error {
+	return renderBuildSessionAs(ds, session, "")
+}
+
+// renderBuildSessionAs displays a build session in the given output format,
+// healing stale session state first.
+func renderBuildSessionAs(ds db.DataStore, session *models.BuildSession, format string) error {
 	// Show workspace details (fetched first so we can derive accurate status/counters)
 	entries, err := ds.GetBuildSessionWorkspaces(session.ID)
 	if err != nil {
@@ -188,33 +262,59 @@ func renderBuildSession(ds db.DataStore, session *models.BuildSession) error {
 		duration = estimateDurationFromEntries(session.StartedAt, entries)
 	}
 
-	render.Plain(fmt.Sprintf("Build Session: %s", session.ID))
-	render.Plain(fmt.Sprintf("  Status:    %s", status))
-	render.Plain(fmt.Sprintf("  Started:   %s", session.StartedAt.Format("2006-01-02 15:04:05")))
-	render.Plain(fmt.Sprintf("  Duration:  %s", duration))
+	out := newBuildSessionOutput(session, status, duration, succeeded, failed)
+	for _, e := range entries {
+		ws := BuildSessionWorkspaceOutput{
+			// Resolve workspace and app names from DB instead of showing raw ID
+			Workspace: resolveWorkspaceLabel(ds, e.WorkspaceID),
+			Status:    e.Status,
+		}
+		if e.ImageTag.Valid {
+			ws.ImageTag = e.ImageTag.String
+		}
+		if e.DurationSeconds.Valid {
+			seconds := e.DurationSeconds.Int64
+			ws.DurationSeconds = &seconds
+		}
+		if e.ErrorMessage.Valid {
+			ws.Error = e.ErrorMessage.String
+		}
+		out.Workspaces = append(out.Workspaces, ws)
+	}
+
+	switch {
+	case isStructuredOutput(format):
+		return render.OutputWith(format, out, render.Options{})
+	case format == outputName:
+		render.Plain(out.ID)
+		return nil
+	}
+
+	render.Plain(fmt.Sprintf("Build Session: %s", out.ID))
+	render.Plain(fmt.Sprintf("  Status:    %s", out.Status))
+	render.Plain(fmt.Sprintf("  Started:   %s", out.StartedAt.Format("2006-01-02 15:04:05")))
+	render.Plain(fmt.Sprintf("  Duration:  %s", out.Duration))
 	render.Plain(fmt.Sprintf("  Result:    %d succeeded, %d failed (%d total)",
-		succeeded, failed, session.TotalWorkspaces))
+		out.Succeeded, out.Failed, out.Total))
 
-	if len(entries) > 0 {
+	if len(out.Workspaces) > 0 {
 		render.Plain("")
 		render.Plain("  Workspaces:")
-		for _, e := range entries {
+		for _, ws := range out.Workspaces {
 			wsDuration := "-"
-			if e.DurationSeconds.Valid {
-				wsDuration = fmt.Sprintf("%ds", e.DurationSeconds.Int64)
+			if ws.DurationSeconds != nil {
+				wsDuration = fmt.Sprintf("%ds", *ws.DurationSeconds)
 			}
 			imageTag := "-"
-			if e.ImageTag.Valid && e.ImageTag.String != "" {
-				imageTag = e.ImageTag.String
+			if ws.ImageTag != "" {
+				imageTag = ws.ImageTag
 			}
 			errMsg := ""
-			if e.ErrorMessage.Valid && e.ErrorMessage.String != "" {
-				errMsg = fmt.Sprintf("  error: %s", e.ErrorMessage.String)
+			if ws.Error != "" {
+				errMsg = fmt.Sprintf("  error: %s", ws.Error)
 			}
-			// Resolve workspace and app names from DB instead of showing raw ID
-			wsLabel := resolveWorkspaceLabel(ds, e.WorkspaceID)
 			render.Plain(fmt.Sprintf("    [%s] %s  %s  %s%s",
-				e.Status, wsLabel, imageTag, wsDuration, errMsg))
+				ws.Status, ws.Workspace, imageTag, wsDuration, errMsg))
 		}
 	}
 
@@ -0,0 +1,288 @@
+// Package cmd provides the 'dvm get/delete buildtemplate' commands. Build
+// templates are created and updated with 'dvm apply'; apps use one with
+// spec.build.template.
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/buildtemplate"
+	"devopsmaestro/pkg/resource/handlers"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+
+	"github.com/spf13/cobra"
+)
+
+// getBuildTemplatesCmd lists all build templates
+var getBuildTemplatesCmd = &cobra.Command{
+	Use:     "buildtemplates",
+	Aliases: []string{"bt", "build-templates"},
+	Short:   "List all build templates",
+	Long: `List the build templates apps can share with spec.build.template.
+
+Examples:
+  dvm get buildtemplates
+  dvm get bt                        # Short form
+  dvm get buildtemplates -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getBuildTemplates(cmd)
+	},
+}
+
+// getBuildTemplateCmd shows one build template with the apps using it
+var getBuildTemplateCmd = &cobra.Command{
+	Use:     "buildtemplate <name>",
+	Aliases: []string{"build-template"},
+	Short:   "Get a specific build template",
+	Long: `Show a build template, the apps using it, and their workspaces that
+still need a rebuild since the template last changed.
+
+Examples:
+  dvm get buildtemplate go-service
+  dvm get buildtemplate go-service -o yaml   # Re-applyable YAML`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBuildTemplateNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getBuildTemplate(cmd, args[0])
+	},
+}
+
+// deleteBuildTemplateCmd deletes an unused build template
+var deleteBuildTemplateCmd = &cobra.Command{
+	Use:     "buildtemplate <name>",
+	Aliases: []string{"build-template", "bt"},
+	Short:   "Delete a build template",
+	Long: `Delete a build template. Templates still used by an app cannot be
+deleted; remove spec.build.template from those apps first.
+
+Examples:
+  dvm delete buildtemplate go-service
+  dvm delete buildtemplate go-service --force   # Skip confirmation`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBuildTemplateNames,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deleteBuildTemplate(cmd, args[0])
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getBuildTemplatesCmd)
+	getCmd.AddCommand(getBuildTemplateCmd)
+	deleteCmd.AddCommand(deleteBuildTemplateCmd)
+	AddForceConfirmFlag(deleteBuildTemplateCmd)
+}
+
+func getBuildTemplates(cmd *cobra.Command) error {
+	ctx, err := buildResourceContext(cmd)
+	if err != nil {
+		return err
+	}
+	resources, err := resource.List(ctx, handlers.KindBuildTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to list build templates: %w", err)
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		list := resource.NewResourceList()
+		for _, res := range resources {
+			list.Items = append(list.Items, res.(*handlers.BuildTemplateResource).BuildTemplate().ToYAML())
+		}
+		return outputList(getOutputFormat, list, render.Options{})
+	}
+
+	if len(resources) == 0 {
+		return outputList(getOutputFormat, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: "No build templates found",
+			EmptyHints:   []string{"dvm apply -f buildtemplate.yaml"},
+		})
+	}
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	apps, err := ds.ListAllApps()
+	if err != nil {
+		return fmt.Errorf("failed to list apps: %w", err)
+	}
+	users := map[string]int{}
+	for _, app := range apps {
+		if cfg := app.GetBuildConfig(); cfg != nil && cfg.Template != "" {
+			users[cfg.Template]++
+		}
+	}
+
+	tableData := render.TableData{Headers: []string{"NAME", "DOCKERFILE", "TARGET", "ARGS", "APPS", "UPDATED"}}
+	for _, res := range resources {
+		tmpl := res.(*handlers.BuildTemplateResource).BuildTemplate()
+		cfg := tmpl.GetBuildConfig()
+		tableData.Rows = append(tableData.Rows, []string{
+			tmpl.Name,
+			orDash(cfg.Dockerfile),
+			orDash(cfg.Target),
+			fmt.Sprintf("%d", len(cfg.Args)),
+			fmt.Sprintf("%d", users[tmpl.Name]),
+			tmpl.UpdatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+	return outputList(getOutputFormat, tableData, render.Options{Type: render.TypeTable})
+}
+
+func getBuildTemplate(cmd *cobra.Command, name string) error {
+	ctx, err := buildResourceContext(cmd)
+	if err != nil {
+		return err
+	}
+	res, err := resource.Get(ctx, handlers.KindBuildTemplate, name)
+	if err != nil {
+		return ErrorWithSuggestion(fmt.Sprintf("build template '%s' not found", name),
+			"List build templates with: dvm get buildtemplates")
+	}
+	tmpl := res.(*handlers.BuildTemplateResource).BuildTemplate()
+
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, tmpl.ToYAML(), render.Options{})
+	}
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	appNames, pending, err := buildTemplateUsage(ds, name)
+	if err != nil {
+		return err
+	}
+
+	cfg := tmpl.GetBuildConfig()
+	certs := make([]string, len(cfg.CACerts))
+	for i, cert := range cfg.CACerts {
+		certs[i] = cert.Name
+	}
+	kvData := render.NewOrderedKeyValueData(
+		render.KeyValue{Key: "Name", Value: tmpl.Name},
+		render.KeyValue{Key: "Description", Value: tmpl.Description.String},
+		render.KeyValue{Key: "Dockerfile", Value: cfg.Dockerfile},
+		render.KeyValue{Key: "Target", Value: cfg.Target},
+		render.KeyValue{Key: "Kind", Value: cfg.Kind},
+		render.KeyValue{Key: "Args", Value: formatBuildArgs(cfg.Args)},
+		render.KeyValue{Key: "CA Certs", Value: strings.Join(certs, ", ")},
+		render.KeyValue{Key: "Apps", Value: strings.Join(appNames, ", ")},
+		render.KeyValue{Key: "Pending Rebuilds", Value: strings.Join(pending, ", ")},
+		render.KeyValue{Key: "Updated", Value: tmpl.UpdatedAt.Format("2006-01-02 15:04:05")},
+	)
+	return outputList(getOutputFormat, kvData, render.Options{
+		Type:  render.TypeKeyValue,
+		Title: "Build Template Details",
+	})
+}
+
+// buildTemplateUsage returns the names of the apps using the template name
+// and, as app/workspace, their workspaces with a pending rebuild.
+func buildTemplateUsage(ds db.DataStore, name string) (apps, pending []string, err error) {
+	dependents, err := buildtemplate.Dependents(ds, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	reasons, err := ds.ListWorkspaceRebuildReasons()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pending rebuilds: %w", err)
+	}
+	for _, app := range dependents {
+		apps = append(apps, app.Name)
+		workspaces, err := ds.ListWorkspacesByApp(app.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workspaces of app %s: %w", app.Name, err)
+		}
+		for _, ws := range workspaces {
+			if reasons[ws.ID] != "" {
+				pending = append(pending, app.Name+"/"+ws.Name)
+			}
+		}
+	}
+	sort.Strings(apps)
+	sort.Strings(pending)
+	return apps, pending, nil
+}
+
+func deleteBuildTemplate(cmd *cobra.Command, name string) error {
+	ctx, err := buildResourceContext(cmd)
+	if err != nil {
+		return err
+	}
+	if _, err := resource.Get(ctx, handlers.KindBuildTemplate, name); err != nil {
+		return ErrorWithSuggestion(fmt.Sprintf("build template '%s' not found", name),
+			"List build templates with: dvm get buildtemplates")
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	confirmed, err := confirmDelete(fmt.Sprintf("Delete build template '%s'?", name), force)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	if err := resource.Delete(ctx, handlers.KindBuildTemplate, name); err != nil {
+		return err
+	}
+	render.Successf("Build template '%s' deleted", name)
+	return nil
+}
+
+// reportRebuilds tells the user which workspaces applying res marked as
+// needing a rebuild.
+func reportRebuilds(res resource.Resource) {
+	tr, ok := res.(*handlers.BuildTemplateResource)
+	if !ok || len(tr.MarkedForRebuild()) == 0 {
+		return
+	}
+	names := make([]string, 0, len(tr.MarkedForRebuild()))
+	for _, ws := range tr.MarkedForRebuild() {
+		names = append(names, ws.Name)
+	}
+	render.Infof("  %d workspace(s) need a rebuild: %s", len(names), strings.Join(names, ", "))
+	render.Info("  Rebuild them with: dvm build")
+}
+
+// formatBuildArgs renders args as sorted KEY=VALUE pairs.
+func formatBuildArgs(args map[string]string) string {
+	pairs := make([]string, 0, len(args))
+	for k, v := range args {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// completeBuildTemplateNames completes build template names.
+func completeBuildTemplateNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	templates, err := ds.ListBuildTemplates()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, tmpl := range templates {
+		if strings.HasPrefix(tmpl.Name, toComplete) {
+			names = append(names, tmpl.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
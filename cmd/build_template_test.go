@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"database/sql"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/buildtemplate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildTemplateUsage(t *testing.T) {
+	ds := db.NewMockDataStore()
+	require.NoError(t, ds.CreateBuildTemplate(&models.BuildTemplate{Name: "go-service"}))
+
+	api := &models.App{Name: "api", BuildConfig: sql.NullString{String: `{"template":"go-service"}`, Valid: true}}
+	require.NoError(t, ds.CreateApp(api))
+	web := &models.App{Name: "web", BuildConfig: sql.NullString{String: `{"dockerfile":"Dockerfile"}`, Valid: true}}
+	require.NoError(t, ds.CreateApp(web))
+
+	dev := &models.Workspace{Name: "dev", AppID: api.ID}
+	require.NoError(t, ds.CreateWorkspace(dev))
+	ci := &models.Workspace{Name: "ci", AppID: api.ID}
+	require.NoError(t, ds.CreateWorkspace(ci))
+	require.NoError(t, ds.SetWorkspaceRebuildReason(dev.ID, buildtemplate.RebuildReason("go-service")))
+
+	apps, pending, err := buildTemplateUsage(ds, "go-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api"}, apps)
+	assert.Equal(t, []string{"api/dev"}, pending)
+}
+
+func TestFormatBuildArgs(t *testing.T) {
+	assert.Equal(t, "A=1, B=2", formatBuildArgs(map[string]string{"B": "2", "A": "1"}))
+	assert.Equal(t, "", formatBuildArgs(nil))
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	ws "devopsmaestro/pkg/workspace"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// cloneCmd is the parent for `dvm clone <kind> <source> <name>`.
+var cloneCmd = &cobra.Command{
+	Use:   "clone",
+	Short: "Copy a resource under a new name",
+	Long: `Copy a resource under a new name, to experiment without touching the original.
+
+Examples:
+  dvm clone workspace dev experiment
+  dvm clone workspace api/dev dev --app worker --with-data`,
+}
+
+// cloneWorkspaceCmd forks a workspace
+var cloneWorkspaceCmd = &cobra.Command{
+	Use:     "workspace <source> <name>",
+	Aliases: []string{"ws"},
+	Short:   "Copy a workspace's spec, plugins, and optionally its data",
+	Long: `Create workspace <name> as a copy of workspace <source>.
+
+The copy gets the source's spec (image build, shell, nvim and terminal
+settings, env, hooks, mounts, services), its theme and package bindings,
+its nvim plugins, its GitRepo, and its workspace-scoped credentials. It
+starts stopped with its own image, built by the next dvm build.
+
+The copy goes in the source's app unless --app names another. <source>
+may be a path such as app/ws or ecosystem/domain/app/ws.
+
+--with-data also copies the source's repo/ checkout and persistent volume
+(nvim plugins and state, caches). Stop the source first for a consistent
+copy. Without it, the copy starts with an empty repo/.
+
+Examples:
+  dvm clone workspace dev experiment
+  dvm clone workspace dev dev --app worker
+  dvm clone workspace api/dev experiment --with-data
+  dvm clone workspace dev experiment --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runCloneWorkspace,
+}
+
+var cloneWorkspaceDryRun bool
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+	cloneCmd.AddCommand(cloneWorkspaceCmd)
+
+	cloneWorkspaceCmd.Flags().StringP("app", "a", "", "App to create the copy in (default: the source's app)")
+	cloneWorkspaceCmd.Flags().Bool("with-data", false, "Also copy the repo checkout and persistent volume")
+	AddDryRunFlag(cloneWorkspaceCmd, &cloneWorkspaceDryRun)
+	AddOutputFlag(cloneWorkspaceCmd, "")
+	_ = cloneWorkspaceCmd.RegisterFlagCompletionFunc("app", completeApps)
+}
+
+// CloneWorkspaceOutput describes the workspace created by dvm clone workspace.
+type CloneWorkspaceOutput struct {
+	Source      string `json:"source" yaml:"source"`
+	Workspace   string `json:"workspace" yaml:"workspace"`
+	App         string `json:"app" yaml:"app"`
+	Plugins     int    `json:"plugins" yaml:"plugins"`
+	Credentials int    `json:"credentials" yaml:"credentials"`
+	DataCopied  bool   `json:"dataCopied" yaml:"dataCopied"`
+}
+
+func runCloneWorkspace(cmd *cobra.Command, args []string) error {
+	srcRef, name := args[0], args[1]
+	if err := ValidateResourceName(name, "workspace"); err != nil {
+		return err
+	}
+	appName, _ := cmd.Flags().GetString("app")
+	withData, _ := cmd.Flags().GetBool("with-data")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, _ := cmd.Flags().GetString("output")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("DataStore not initialized: %w", err)
+	}
+
+	src, err := resolveWorkspace(ds, models.WorkspaceFilter{WorkspaceName: srcRef})
+	if err != nil {
+		return err
+	}
+	app := src.App
+	if appName != "" {
+		if app, err = resolveAppByNameScoped(ds, appName); err != nil {
+			return ErrorWithSuggestion(fmt.Sprintf("app '%s' not found: %v", appName, err), SuggestAppNotFound(appName)...)
+		}
+	}
+	if existing, err := ds.GetWorkspaceByName(app.ID, name); err == nil && existing != nil {
+		return fmt.Errorf("workspace '%s' already exists in app '%s'", name, app.Name)
+	}
+
+	plugins, err := ds.GetWorkspacePlugins(src.Workspace.ID)
+	if err != nil {
+		return err
+	}
+	credentials, err := ds.ListCredentialsByScope(models.CredentialScopeWorkspace, int64(src.Workspace.ID))
+	if err != nil {
+		return err
+	}
+
+	out := CloneWorkspaceOutput{
+		Source:      src.FullPath(),
+		Workspace:   name,
+		App:         app.Name,
+		Plugins:     len(plugins),
+		Credentials: len(credentials),
+		DataCopied:  withData,
+	}
+	if dryRun {
+		render.Plain(fmt.Sprintf("Would clone workspace %s to %q in app %q (%d plugins, %d credentials, data: %t)",
+			out.Source, name, app.Name, out.Plugins, out.Credentials, withData))
+		return nil
+	}
+
+	// The struct copy carries every spec column; only identity and runtime
+	// state are reset
+	dst := *src.Workspace
+	dst.ID = 0
+	dst.AppID = app.ID
+	dst.Name = name
+	dst.Slug = ""
+	dst.ImageName = fmt.Sprintf("dvm-%s-%s:pending", name, app.Name)
+	dst.ContainerID = sql.NullString{}
+	dst.Status = "stopped"
+	dst.CreatedAt, dst.UpdatedAt = time.Time{}, time.Time{}
+
+	if err := ws.PrepareDefaults(&dst, ds); err != nil {
+		return fmt.Errorf("failed to prepare workspace defaults: %w", err)
+	}
+	if err := ds.CreateWorkspace(&dst); err != nil {
+		return fmt.Errorf("failed to create workspace: %w", err)
+	}
+	if err := copyWorkspaceBindings(ds, &dst, plugins, credentials); err != nil {
+		_ = ds.DeleteWorkspace(dst.ID)
+		return fmt.Errorf("failed to clone workspace: %w", err)
+	}
+
+	if withData {
+		if src.Workspace.Status == "running" {
+			render.Warning(fmt.Sprintf("Workspace '%s' is running; files written during the copy may be inconsistent", src.Workspace.Name))
+		}
+		if err := copyWorkspaceData(src.Workspace.Slug, dst.Slug); err != nil {
+			render.Warning(fmt.Sprintf("Workspace '%s' created, but its data was not fully copied: %v", name, err))
+			out.DataCopied = false
+		}
+	}
+
+	if isStructuredOutput(format) {
+		return render.OutputWith(format, out, render.Options{})
+	}
+	render.Success(fmt.Sprintf("Workspace '%s' cloned to '%s' in app '%s'", out.Source, name, app.Name))
+	render.Info(fmt.Sprintf("Copied %d plugins and %d credentials", out.Plugins, out.Credentials))
+	if out.DataCopied {
+		render.Info("Copied the repo checkout and persistent volume")
+	}
+	render.Info(fmt.Sprintf("Next: dvm use workspace %s && dvm build && dvm attach", name))
+	return nil
+}
+
+// copyWorkspaceBindings gives dst the plugin associations and
+// workspace-scoped credentials of its source.
+func copyWorkspaceBindings(ds db.DataStore, dst *models.Workspace, plugins []*models.NvimPluginDB, credentials []*models.CredentialDB) error {
+	for _, p := range plugins {
+		if err := ds.AddPluginToWorkspace(dst.ID, p.ID); err != nil {
+			return fmt.Errorf("plugin '%s': %w", p.Name, err)
+		}
+	}
+	for _, c := range credentials {
+		cred := *c
+		cred.ID = 0
+		cred.ScopeID = int64(dst.ID)
+		if err := ds.CreateCredential(&cred); err != nil {
+			return fmt.Errorf("credential '%s': %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyWorkspaceData copies the repo checkout and persistent volume of the
+// workspace with slug srcSlug into a fresh directory tree for dstSlug.
+func copyWorkspaceData(srcSlug, dstSlug string) error {
+	srcPath, err := ws.GetWorkspacePath(srcSlug)
+	if err != nil {
+		return err
+	}
+	dstPath, err := ws.GetWorkspacePath(dstSlug)
+	if err != nil {
+		return err
+	}
+	return ws.CopyWorkspaceData(srcPath, dstPath)
+}
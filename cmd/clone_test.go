@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	ws "devopsmaestro/pkg/workspace"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCloneTest creates app "test-app" with workspace "dev", which has one
+// plugin and one credential, plus a second app "other-app".
+func setupCloneTest(t *testing.T) (*db.MockDataStore, *models.Workspace, *models.App) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	store, app := setupTestContext()
+
+	other := &models.App{Name: "other-app", DomainID: app.DomainID, Path: "/other/path"}
+	require.NoError(t, store.CreateApp(other))
+
+	src := &models.Workspace{
+		Name:            "dev",
+		AppID:           app.ID,
+		ImageName:       "dvm-dev-test-app:abc123",
+		Status:          "running",
+		ContainerID:     sql.NullString{String: "c0ffee", Valid: true},
+		Theme:           sql.NullString{String: "tokyonight-night", Valid: true},
+		NvimPackage:     sql.NullString{String: "go-dev", Valid: true},
+		TerminalPackage: sql.NullString{String: "zsh-starship", Valid: true},
+	}
+	require.NoError(t, ws.PrepareDefaults(src, store))
+	require.NoError(t, store.CreateWorkspace(src))
+
+	plugin := &models.NvimPluginDB{Name: "telescope", Repo: "nvim-telescope/telescope.nvim"}
+	require.NoError(t, store.CreatePlugin(plugin))
+	require.NoError(t, store.AddPluginToWorkspace(src.ID, plugin.ID))
+
+	env := "GITHUB_TOKEN"
+	require.NoError(t, store.CreateCredential(&models.CredentialDB{
+		Name:      "github",
+		ScopeType: models.CredentialScopeWorkspace,
+		ScopeID:   int64(src.ID),
+		Source:    "env",
+		EnvVar:    &env,
+	}))
+	return store, src, other
+}
+
+func newCloneWorkspaceTestCmd(store db.DataStore) *cobra.Command {
+	cmd := &cobra.Command{RunE: runCloneWorkspace}
+	cmd.Flags().StringP("app", "a", "", "")
+	cmd.Flags().Bool("with-data", false, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().StringP("output", "o", "", "")
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, store))
+	return cmd
+}
+
+func TestCloneWorkspace_CopiesSpecAndBindings(t *testing.T) {
+	store, src, other := setupCloneTest(t)
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	cmd := newCloneWorkspaceTestCmd(store)
+	require.NoError(t, cmd.Flags().Set("app", "other-app"))
+	require.NoError(t, runCloneWorkspace(cmd, []string{"dev", "experiment"}))
+
+	dst, err := store.GetWorkspaceByName(other.ID, "experiment")
+	require.NoError(t, err)
+	assert.NotEqual(t, src.ID, dst.ID)
+	assert.NotEqual(t, src.Slug, dst.Slug)
+	assert.Equal(t, src.Theme, dst.Theme)
+	assert.Equal(t, src.NvimPackage, dst.NvimPackage)
+	assert.Equal(t, src.TerminalPackage, dst.TerminalPackage)
+	assert.Equal(t, "dvm-experiment-other-app:pending", dst.ImageName)
+	assert.Equal(t, "stopped", dst.Status)
+	assert.False(t, dst.ContainerID.Valid)
+
+	plugins, err := store.GetWorkspacePlugins(dst.ID)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "telescope", plugins[0].Name)
+
+	creds, err := store.ListCredentialsByScope(models.CredentialScopeWorkspace, int64(dst.ID))
+	require.NoError(t, err)
+	require.Len(t, creds, 1)
+	assert.Equal(t, "github", creds[0].Name)
+
+	// The source keeps its own bindings
+	srcCreds, err := store.ListCredentialsByScope(models.CredentialScopeWorkspace, int64(src.ID))
+	require.NoError(t, err)
+	assert.Len(t, srcCreds, 1)
+	assert.Contains(t, buf.String(), "cloned to 'experiment'")
+}
+
+func TestCloneWorkspace_WithData(t *testing.T) {
+	store, src, _ := setupCloneTest(t)
+	srcPath, err := ws.GetWorkspacePath(src.Slug)
+	require.NoError(t, err)
+	require.NoError(t, ws.CreateWorkspaceDirectories(srcPath))
+	require.NoError(t, os.WriteFile(filepath.Join(srcPath, "repo", "main.go"), []byte("package main\n"), 0644))
+
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	cmd := newCloneWorkspaceTestCmd(store)
+	require.NoError(t, cmd.Flags().Set("with-data", "true"))
+	require.NoError(t, runCloneWorkspace(cmd, []string{"dev", "experiment"}))
+
+	dst, err := store.GetWorkspaceByName(src.AppID, "experiment")
+	require.NoError(t, err)
+	dstPath, err := ws.GetWorkspacePath(dst.Slug)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dstPath, "repo", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+	assert.Contains(t, buf.String(), "is running")
+}
+
+func TestCloneWorkspace_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     string
+		args    []string
+		wantErr string
+	}{
+		{name: "existing name", args: []string{"dev", "dev"}, wantErr: "already exists in app 'test-app'"},
+		{name: "unknown app", app: "missing", args: []string{"dev", "experiment"}, wantErr: "app 'missing' not found"},
+		{name: "empty name", args: []string{"dev", " "}, wantErr: "name cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _, _ := setupCloneTest(t)
+			cmd := newCloneWorkspaceTestCmd(store)
+			if tt.app != "" {
+				require.NoError(t, cmd.Flags().Set("app", tt.app))
+			}
+			err := runCloneWorkspace(cmd, tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCloneWorkspace_DryRunCreatesNothing(t *testing.T) {
+	store, src, _ := setupCloneTest(t)
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	cmd := newCloneWorkspaceTestCmd(store)
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	require.NoError(t, runCloneWorkspace(cmd, []string{"dev", "experiment"}))
+
+	_, err := store.GetWorkspaceByName(src.AppID, "experiment")
+	assert.Error(t, err)
+	assert.Contains(t, buf.String(), "Would clone workspace")
+}
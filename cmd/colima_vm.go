@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/viper"
+)
+
+// colimaProfile returns the Colima profile dvm manages: runtime.colima.profile,
+// else the profile of the detected platform when it is Colima. It returns ""
+// when dvm does not run on Colima.
+func colimaProfile() string {
+	if profile := viper.GetString("runtime.colima.profile"); profile != "" {
+		return profile
+	}
+	detector, err := operators.NewPlatformDetector()
+	if err != nil {
+		return ""
+	}
+	if platform, err := detector.Detect(); err == nil && platform.Type == operators.PlatformColima {
+		return platform.Profile
+	}
+	return ""
+}
+
+// colimaRequirements returns the VM resources set with runtime.colima.cpus,
+// runtime.colima.memory, and runtime.colima.disk.
+func colimaRequirements() (operators.VMRequirements, error) {
+	var req operators.VMRequirements
+	if cpus := strings.TrimSpace(viper.GetString("runtime.colima.cpus")); cpus != "" {
+		n, err := strconv.ParseFloat(cpus, 64)
+		if err != nil || n <= 0 {
+			return req, fmt.Errorf("invalid runtime.colima.cpus %q: must be a positive number", cpus)
+		}
+		req.CPUs = n
+	}
+	var err error
+	if req.MemoryBytes, err = operators.ParseMemoryString(viper.GetString("runtime.colima.memory")); err != nil {
+		return req, fmt.Errorf("invalid runtime.colima.memory: %w", err)
+	}
+	if req.DiskBytes, err = operators.ParseMemoryString(viper.GetString("runtime.colima.disk")); err != nil {
+		return req, fmt.Errorf("invalid runtime.colima.disk: %w", err)
+	}
+	return req, nil
+}
+
+// maxRequirements returns the larger value of each field.
+func maxRequirements(a, b operators.VMRequirements) operators.VMRequirements {
+	if b.CPUs > a.CPUs {
+		a.CPUs = b.CPUs
+	}
+	if b.MemoryBytes > a.MemoryBytes {
+		a.MemoryBytes = b.MemoryBytes
+	}
+	if b.DiskBytes > a.DiskBytes {
+		a.DiskBytes = b.DiskBytes
+	}
+	return a
+}
+
+// ensureColimaVM prepares the Colima VM set with runtime.colima.profile for
+// a local workspace: it starts a stopped VM when runtime.colima.autoStart is
+// set, and warns when the VM is smaller than the configured resources or
+// the container limits in extra. Messages go to out. Without a configured
+// profile it does nothing.
+func ensureColimaVM(ctx context.Context, out io.Writer, extra operators.VMRequirements) error {
+	profile := viper.GetString("runtime.colima.profile")
+	if profile == "" {
+		return nil
+	}
+	vm, err := operators.NewColimaVM(profile)
+	if err != nil {
+		return err
+	}
+	configured, err := colimaRequirements()
+	if err != nil {
+		return err
+	}
+
+	status, err := vm.Status(ctx)
+	if err != nil {
+		slog.Warn("failed to get Colima VM status", "profile", profile, "error", err)
+		return nil
+	}
+	if !status.Running {
+		if !viper.GetBool("runtime.colima.autoStart") {
+			return nil
+		}
+		render.MsgTo(out, "", render.Message{Level: render.LevelProgress, Content: fmt.Sprintf("Starting Colima VM (profile: %s)...", profile)})
+		if err := vm.Start(ctx, configured); err != nil {
+			return fmt.Errorf("failed to start Colima VM: %w", err)
+		}
+		if status, err = vm.Status(ctx); err != nil {
+			return fmt.Errorf("failed to get Colima VM status: %w", err)
+		}
+	}
+
+	if shortfalls := status.Shortfalls(maxRequirements(configured, extra)); len(shortfalls) > 0 {
+		render.MsgTo(out, "", render.Message{Level: render.LevelWarning,
+			Content: fmt.Sprintf("Colima VM (profile: %s) is smaller than required: %s", profile, strings.Join(shortfalls, ", "))})
+	}
+	return nil
+}
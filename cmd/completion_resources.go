@@ -405,14 +405,14 @@ func registerAllResourceCompletions() {
 	// === Core hierarchy: get/use/delete ===
 
 	// Ecosystem commands
-	for _, cmd := range []*cobra.Command{getEcosystemCmd, useEcosystemCmd, deleteEcosystemCmd} {
+	for _, cmd := range []*cobra.Command{getEcosystemCmd, useEcosystemCmd, deleteEcosystemCmd, describeEcosystemCmd} {
 		if cmd != nil {
 			cmd.ValidArgsFunction = completeEcosystems
 		}
 	}
 
 	// Domain commands
-	for _, cmd := range []*cobra.Command{getDomainCmd, useDomainCmd, deleteDomainCmd} {
+	for _, cmd := range []*cobra.Command{getDomainCmd, useDomainCmd, deleteDomainCmd, describeDomainCmd} {
 		if cmd != nil {
 			cmd.ValidArgsFunction = completeDomains
 		}
@@ -426,14 +426,14 @@ func registerAllResourceCompletions() {
 	}
 
 	// App commands
-	for _, cmd := range []*cobra.Command{getAppCmd, useAppCmd, deleteAppCmd} {
+	for _, cmd := range []*cobra.Command{getAppCmd, useAppCmd, deleteAppCmd, describeAppCmd} {
 		if cmd != nil {
 			cmd.ValidArgsFunction = completeApps
 		}
 	}
 
 	// Workspace commands
-	for _, cmd := range []*cobra.Command{getWorkspaceCmd, useWorkspaceCmd, deleteWorkspaceCmd, attachCmd, buildCmd, detachCmd} {
+	for _, cmd := range []*cobra.Command{getWorkspaceCmd, useWorkspaceCmd, deleteWorkspaceCmd, attachCmd, buildCmd, detachCmd, describeWorkspaceCmd} {
 		if cmd != nil {
 			cmd.ValidArgsFunction = completeWorkspaces
 		}
@@ -459,7 +459,7 @@ func registerAllResourceCompletions() {
 	}
 
 	// === GitRepo commands ===
-	for _, cmd := range []*cobra.Command{getGitRepoCmd, deleteGitRepoCmd, syncGitRepoCmd} {
+	for _, cmd := range []*cobra.Command{getGitRepoCmd, deleteGitRepoCmd, syncGitRepoCmd, describeGitRepoCmd} {
 		if cmd != nil {
 			cmd.ValidArgsFunction = completeGitRepos
 		}
@@ -526,8 +526,11 @@ func registerAllFlagCompletions() {
 		attachCmd,
 		buildCmd,
 		detachCmd,
+		upCmd,
+		downCmd,
 		getWorkspacesCmd,
 		getWorkspaceCmd,
+		describeWorkspaceCmd,
 	} {
 		if cmd != nil {
 			registerHierarchyFlagCompletions(cmd)
@@ -535,7 +538,7 @@ func registerAllFlagCompletions() {
 	}
 
 	// === Domain commands with --ecosystem flag ===
-	for _, cmd := range []*cobra.Command{getDomainsCmd, getDomainCmd, deleteDomainCmd, createDomainCmd} {
+	for _, cmd := range []*cobra.Command{getDomainsCmd, getDomainCmd, deleteDomainCmd, createDomainCmd, describeDomainCmd} {
 		if cmd != nil {
 			cmd.RegisterFlagCompletionFunc("ecosystem", completeEcosystems)
 		}
@@ -561,6 +564,12 @@ func registerAllFlagCompletions() {
 		}
 	}
 
+	// === Describe app with --ecosystem and --domain flags ===
+	if describeAppCmd != nil {
+		describeAppCmd.RegisterFlagCompletionFunc("ecosystem", completeEcosystems)
+		describeAppCmd.RegisterFlagCompletionFunc("domain", completeDomains)
+	}
+
 	// === Workspace commands with --app flag ===
 	for _, cmd := range []*cobra.Command{deleteWorkspaceCmd, createWorkspaceCmd} {
 		if cmd != nil {
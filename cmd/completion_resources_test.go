@@ -45,8 +45,10 @@ func createTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME
 		)`,
 		`CREATE TABLE IF NOT EXISTS domains (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -58,8 +60,10 @@ func createTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME,
 			UNIQUE(ecosystem_id, name),
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id)
 		)`,
@@ -93,8 +97,10 @@ func createTestSchema(driver db.Driver) error {
 			language TEXT,
 			build_config TEXT,
 			git_repo_id INTEGER,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME,
 			FOREIGN KEY (domain_id) REFERENCES domains(id),
 			FOREIGN KEY (system_id) REFERENCES systems(id),
 			UNIQUE(domain_id, name)
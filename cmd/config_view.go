@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/config"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// configCmd is the root 'config' command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect dvm configuration",
+	Long: `Inspect the configuration dvm runs with.
+
+Configuration is read in layers, each overriding the ones before it:
+  1. system     /etc/devopsmaestro/config.yaml
+  2. user       ~/.devopsmaestro/config.yaml
+  3. ecosystem  ~/.devopsmaestro/ecosystems/<active ecosystem>.yaml
+  4. env        DVM_<KEY> variables, e.g. DVM_THEME, DVM_RUNTIME_TYPE, DVM_OUTPUT`,
+}
+
+// configViewCmd prints the effective configuration
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Show the effective configuration",
+	Long: `Show the configuration after merging all layers. Secrets such as tokens
+and passwords are masked.
+
+With --resolved, show each key set by a layer with the layer and the file
+or environment variable it came from.
+
+Examples:
+  dvm config view
+  dvm config view --resolved
+  dvm config view --resolved -o json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigView,
+}
+
+var configViewResolved bool
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configViewCmd)
+	configViewCmd.Flags().BoolVar(&configViewResolved, "resolved", false, "Show where each value came from")
+}
+
+func runConfigView(cmd *cobra.Command, args []string) error {
+	if !configViewResolved {
+		format := outputFormat
+		if !isStructuredOutput(format) {
+			format = outputYAML
+		}
+		return render.OutputWith(format, maskSettings("", viper.AllSettings()), render.Options{})
+	}
+
+	resolved := config.Resolve()
+	for i := range resolved {
+		if isSecretConfigKey(resolved[i].Key) {
+			resolved[i].Value = maskedValue
+		}
+	}
+	if isStructuredOutput(outputFormat) {
+		return render.OutputWith(outputFormat, resolved, render.Options{})
+	}
+	if len(resolved) == 0 {
+		render.Info("No configuration set; dvm is using its defaults")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(resolved))
+	for _, r := range resolved {
+		rows = append(rows, []string{r.Key, fmt.Sprint(r.Value), r.Layer, r.Origin})
+	}
+	return render.OutputWith(outputFormat, render.TableData{
+		Headers: []string{"KEY", "VALUE", "LAYER", "ORIGIN"},
+		Rows:    rows,
+	}, render.Options{Type: render.TypeTable})
+}
+
+const maskedValue = "********"
+
+// isSecretConfigKey reports whether key holds a secret value. Keys naming
+// the environment variable that holds a secret (secretEnv) are not secret.
+func isSecretConfigKey(key string) bool {
+	last := strings.ToLower(key[strings.LastIndex(key, ".")+1:])
+	for _, word := range []string{"token", "password", "secret", "passphrase"} {
+		if strings.HasSuffix(last, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSettings returns a copy of viper settings with secret values masked.
+func maskSettings(prefix string, settings map[string]any) map[string]any {
+	masked := make(map[string]any, len(settings))
+	for k, v := range settings {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch {
+		case isSecretConfigKey(key):
+			masked[k] = maskedValue
+		default:
+			if nested, ok := v.(map[string]any); ok {
+				v = maskSettings(key, nested)
+			}
+			masked[k] = v
+		}
+	}
+	return masked
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSecretConfigKey(t *testing.T) {
+	for _, key := range []string{"vault.token", "database.password", "events.secret", "github.accessToken"} {
+		assert.True(t, isSecretConfigKey(key), key)
+	}
+	for _, key := range []string{"theme", "events.webhooks.secretEnv", "backup.encryption.passphraseEnv", "tokens.dir"} {
+		assert.False(t, isSecretConfigKey(key), key)
+	}
+}
+
+func TestMaskSettings(t *testing.T) {
+	settings := map[string]any{
+		"theme":    "nord",
+		"vault":    map[string]any{"token": "abc"},
+		"database": map[string]any{"path": "~/db", "password": "pw"},
+	}
+	assert.Equal(t, map[string]any{
+		"theme":    "nord",
+		"vault":    map[string]any{"token": maskedValue},
+		"database": map[string]any{"path": "~/db", "password": maskedValue},
+	}, maskSettings("", settings))
+	assert.Equal(t, "abc", settings["vault"].(map[string]any)["token"], "the input is not modified")
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/pkg/terminalbridge/promptgen"
+
+	"github.com/rmkohlman/MaestroSDK/colors"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// contextCmd groups commands that expose the active context to the shell.
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Expose the active context to shells and prompts",
+	Long: `Print the active context (ecosystem, domain, system, app, workspace) in
+forms shells and prompts can consume. Use 'dvm get context' for the detailed
+view and 'dvm use ...' to change it.
+
+Examples:
+  dvm context current                     # eco/domain/system/app/workspace
+  dvm context current --format starship   # Compact segment for a prompt
+  eval "$(dvm context env)"               # Export DVM_* variables
+  dvm context starship >> ~/.config/starship.toml`,
+}
+
+// contextCurrentCmd prints the active context on one line.
+var contextCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the active context on one line",
+	Long: `Print the active context on one line. DVM_* environment variables override
+the stored context, as everywhere else.
+
+Formats:
+  path      eco/domain/system/app/workspace, skipping unset levels (default)
+  starship  app/workspace (or the path when no app is active); prints
+            nothing and exits 0 on any error so it is safe in a prompt
+  json      the same structure as 'dvm get context -o json'
+
+Examples:
+  dvm context current
+  dvm context current --format starship`,
+	Args: cobra.NoArgs,
+	RunE: runContextCurrent,
+}
+
+// contextEnvCmd prints shell exports for the active context.
+var contextEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Print DVM_* exports for the active context",
+	Long: `Print DVM_ECOSYSTEM, DVM_DOMAIN, DVM_SYSTEM, DVM_APP, and DVM_WORKSPACE
+exports for the active context. Unset levels are unset, so evaluating the
+output makes the shell match the context exactly.
+
+Examples:
+  eval "$(dvm context env)"
+  dvm context env --shell fish | source`,
+	Args: cobra.NoArgs,
+	RunE: runContextEnv,
+}
+
+// contextStarshipCmd prints a starship custom module for the context.
+var contextStarshipCmd = &cobra.Command{
+	Use:   "starship",
+	Short: "Print a starship custom module that shows the active context",
+	Long: `Print a [custom.dvm] starship module that runs
+'dvm context current --format starship'. Append it to an existing
+starship.toml; its color comes from --theme or the active theme.
+
+Prompts generated by dvm can include the module directly with
+'dvm prompt generate <name> --dvm-context'.
+
+Examples:
+  dvm context starship >> ~/.config/starship.toml
+  dvm context starship --theme tokyonight-night`,
+	Args: cobra.NoArgs,
+	RunE: runContextStarship,
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+	contextCmd.AddCommand(contextCurrentCmd, contextEnvCmd, contextStarshipCmd)
+
+	contextCurrentCmd.Flags().String("format", "path", "Output format: path, starship, json")
+	contextEnvCmd.Flags().String("shell", "sh", "Shell syntax: sh (bash, zsh) or fish")
+	contextStarshipCmd.Flags().String("theme", "", "Theme to take the color from (default: active theme)")
+
+	_ = contextCurrentCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"path", "starship", "json"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = contextEnvCmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"sh", "fish"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// contextPath joins the set levels of the context with '/'.
+func contextPath(c ContextOutput) string {
+	var parts []string
+	for _, name := range []string{c.CurrentEcosystem, c.CurrentDomain, c.CurrentSystem, c.CurrentApp, c.CurrentWorkspace} {
+		if name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// contextPromptSegment returns the compact prompt form: app/workspace when
+// an app is active, otherwise the path.
+func contextPromptSegment(c ContextOutput) string {
+	if c.CurrentApp == "" {
+		return contextPath(c)
+	}
+	if c.CurrentWorkspace == "" {
+		return c.CurrentApp
+	}
+	return c.CurrentApp + "/" + c.CurrentWorkspace
+}
+
+func runContextCurrent(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	switch format {
+	case "path", "starship", "json":
+	default:
+		return fmt.Errorf("unsupported format %q (supported: path, starship, json)", format)
+	}
+
+	data, err := loadCurrentContext(cmd)
+	if err != nil {
+		// A prompt segment must never print errors into the prompt
+		if format == "starship" {
+			return nil
+		}
+		return err
+	}
+
+	line := contextPath(data)
+	switch format {
+	case "json":
+		return render.OutputWith("json", data, render.Options{})
+	case "starship":
+		line = contextPromptSegment(data)
+	}
+	if line != "" {
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+	return nil
+}
+
+// loadCurrentContext resolves the active context for the context commands.
+func loadCurrentContext(cmd *cobra.Command) (ContextOutput, error) {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return ContextOutput{}, err
+	}
+	data, _, err := resolveCurrentContext(ds)
+	return data, err
+}
+
+// contextEnvLines returns shell statements that set the DVM_* variables to
+// the context, unsetting empty levels.
+func contextEnvLines(c ContextOutput, shell string) []string {
+	vars := []struct{ name, value string }{
+		{"DVM_ECOSYSTEM", c.CurrentEcosystem},
+		{"DVM_DOMAIN", c.CurrentDomain},
+		{"DVM_SYSTEM", c.CurrentSystem},
+		{"DVM_APP", c.CurrentApp},
+		{"DVM_WORKSPACE", c.CurrentWorkspace},
+	}
+	lines := make([]string, 0, len(vars))
+	for _, v := range vars {
+		switch {
+		case shell == "fish" && v.value == "":
+			lines = append(lines, "set -e "+v.name)
+		case shell == "fish":
+			quoted := strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(v.value)
+			lines = append(lines, fmt.Sprintf("set -gx %s '%s'", v.name, quoted))
+		case v.value == "":
+			lines = append(lines, "unset "+v.name)
+		default:
+			quoted := strings.ReplaceAll(v.value, "'", `'\''`)
+			lines = append(lines, fmt.Sprintf("export %s='%s'", v.name, quoted))
+		}
+	}
+	return lines
+}
+
+func runContextEnv(cmd *cobra.Command, args []string) error {
+	shell, _ := cmd.Flags().GetString("shell")
+	switch shell {
+	case "sh", "bash", "zsh":
+		shell = "sh"
+	case "fish":
+	default:
+		return fmt.Errorf("unsupported shell %q (supported: sh, bash, zsh, fish)", shell)
+	}
+
+	data, err := loadCurrentContext(cmd)
+	if err != nil {
+		return err
+	}
+	for _, line := range contextEnvLines(data, shell) {
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+	return nil
+}
+
+func runContextStarship(cmd *cobra.Command, args []string) error {
+	themeName, _ := cmd.Flags().GetString("theme")
+	themeStore, err := getThemeStore(cmd)
+	if err != nil {
+		return err
+	}
+	t, err := resolveTerminalTheme(themeStore, themeName)
+	if err != nil {
+		return err
+	}
+	pal := colors.ToPalette(colors.FromContextOrDefault(cmd.Context()))
+	if t != nil {
+		pal = t.ToPalette()
+	}
+	fmt.Fprint(cmd.OutOrStdout(), promptgen.ContextModuleSnippet(pal))
+	return nil
+}
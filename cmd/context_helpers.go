@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -23,14 +24,16 @@ func getDataStore(cmd *cobra.Command) (db.DataStore, error) {
 		return nil, fmt.Errorf("dataStore not found in context")
 	}
 
+	// Bind the store to the command's context so Ctrl-C and --timeout
+	// cancel in-flight queries.
 	switch ds := val.(type) {
 	case *db.DataStore:
 		if ds == nil || *ds == nil {
 			return nil, fmt.Errorf("dataStore not initialized")
 		}
-		return *ds, nil
+		return (*ds).WithContext(ctx), nil
 	case db.DataStore:
-		return ds, nil
+		return ds.WithContext(ctx), nil
 	case *db.MockDataStore:
 		return ds, nil
 	default:
@@ -38,6 +41,16 @@ func getDataStore(cmd *cobra.Command) (db.DataStore, error) {
 	}
 }
 
+// commandContext returns the command's context, which carries Ctrl-C and
+// --timeout cancellation, or a background context for commands run directly
+// (as tests do) without one.
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
 // getActiveAppFromContext returns the active app name from DB context, with env var override.
 // Precedence: DVM_APP env var > DB context (active_app_id) > error
 func getActiveAppFromContext(ds db.DataStore) (string, error) {
@@ -49,16 +62,16 @@ func getActiveAppFromContext(ds db.DataStore) (string, error) {
 	// Read from database context
 	dbCtx, err := ds.GetContext()
 	if err != nil {
-		return "", fmt.Errorf("no active app context (use 'dvm use app <name>' or set DVM_APP)")
+		return "", db.NewErrNoActiveContext("app")
 	}
 
 	if dbCtx == nil || dbCtx.ActiveAppID == nil {
-		return "", fmt.Errorf("no active app context (use 'dvm use app <name>' or set DVM_APP)")
+		return "", db.NewErrNoActiveContext("app")
 	}
 
 	app, err := ds.GetAppByID(*dbCtx.ActiveAppID)
 	if err != nil {
-		return "", fmt.Errorf("no active app context (use 'dvm use app <name>' or set DVM_APP)")
+		return "", db.NewErrNoActiveContext("app")
 	}
 
 	return app.Name, nil
@@ -75,16 +88,16 @@ func getActiveWorkspaceFromContext(ds db.DataStore) (string, error) {
 	// Read from database context
 	dbCtx, err := ds.GetContext()
 	if err != nil {
-		return "", fmt.Errorf("no active workspace context (use 'dvm use workspace <name>' or set DVM_WORKSPACE)")
+		return "", db.NewErrNoActiveContext("workspace")
 	}
 
 	if dbCtx == nil || dbCtx.ActiveWorkspaceID == nil {
-		return "", fmt.Errorf("no active workspace context (use 'dvm use workspace <name>' or set DVM_WORKSPACE)")
+		return "", db.NewErrNoActiveContext("workspace")
 	}
 
 	ws, err := ds.GetWorkspaceByID(*dbCtx.ActiveWorkspaceID)
 	if err != nil {
-		return "", fmt.Errorf("no active workspace context (use 'dvm use workspace <name>' or set DVM_WORKSPACE)")
+		return "", db.NewErrNoActiveContext("workspace")
 	}
 
 	return ws.Name, nil
@@ -101,16 +114,16 @@ func getActiveEcosystemFromContext(ds db.DataStore) (string, error) {
 	// Read from database context
 	dbCtx, err := ds.GetContext()
 	if err != nil {
-		return "", fmt.Errorf("no active ecosystem context (use 'dvm use ecosystem <name>' or set DVM_ECOSYSTEM)")
+		return "", db.NewErrNoActiveContext("ecosystem")
 	}
 
 	if dbCtx == nil || dbCtx.ActiveEcosystemID == nil {
-		return "", fmt.Errorf("no active ecosystem context (use 'dvm use ecosystem <name>' or set DVM_ECOSYSTEM)")
+		return "", db.NewErrNoActiveContext("ecosystem")
 	}
 
 	eco, err := ds.GetEcosystemByID(*dbCtx.ActiveEcosystemID)
 	if err != nil {
-		return "", fmt.Errorf("no active ecosystem context (use 'dvm use ecosystem <name>' or set DVM_ECOSYSTEM)")
+		return "", db.NewErrNoActiveContext("ecosystem")
 	}
 
 	return eco.Name, nil
@@ -127,16 +140,16 @@ func getActiveDomainFromContext(ds db.DataStore) (string, error) {
 	// Read from database context
 	dbCtx, err := ds.GetContext()
 	if err != nil {
-		return "", fmt.Errorf("no active domain context (use 'dvm use domain <name>' or set DVM_DOMAIN)")
+		return "", db.NewErrNoActiveContext("domain")
 	}
 
 	if dbCtx == nil || dbCtx.ActiveDomainID == nil {
-		return "", fmt.Errorf("no active domain context (use 'dvm use domain <name>' or set DVM_DOMAIN)")
+		return "", db.NewErrNoActiveContext("domain")
 	}
 
 	dom, err := ds.GetDomainByID(*dbCtx.ActiveDomainID)
 	if err != nil {
-		return "", fmt.Errorf("no active domain context (use 'dvm use domain <name>' or set DVM_DOMAIN)")
+		return "", db.NewErrNoActiveContext("domain")
 	}
 
 	return dom.Name, nil
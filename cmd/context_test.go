@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextPathAndPromptSegment(t *testing.T) {
+	full := ContextOutput{CurrentEcosystem: "eco", CurrentDomain: "dom", CurrentApp: "api", CurrentWorkspace: "dev"}
+	assert.Equal(t, "eco/dom/api/dev", contextPath(full))
+	assert.Equal(t, "api/dev", contextPromptSegment(full))
+
+	assert.Equal(t, "api", contextPromptSegment(ContextOutput{CurrentEcosystem: "eco", CurrentApp: "api"}))
+	assert.Equal(t, "eco/dom", contextPromptSegment(ContextOutput{CurrentEcosystem: "eco", CurrentDomain: "dom"}))
+	assert.Empty(t, contextPromptSegment(ContextOutput{}))
+}
+
+func TestResolveCurrentContext_EnvOverride(t *testing.T) {
+	t.Setenv("DVM_APP", "web")
+	t.Setenv("DVM_WORKSPACE", "")
+	mock := newSessionMock(t)
+	mock.Context = &models.Context{}
+	appID, wsID := 1, 1
+	require.NoError(t, mock.SetActiveApp(&appID))
+	require.NoError(t, mock.SetActiveWorkspace(&wsID))
+
+	data, src, err := resolveCurrentContext(mock)
+	require.NoError(t, err)
+	assert.Equal(t, "web", data.CurrentApp)
+	assert.Equal(t, "env: DVM_APP", src.App)
+	assert.Equal(t, "dev", data.CurrentWorkspace)
+	assert.Equal(t, "global", src.Workspace)
+}
+
+func TestContextEnvLines(t *testing.T) {
+	c := ContextOutput{CurrentEcosystem: "eco", CurrentApp: "it's", CurrentWorkspace: `back\slash`}
+
+	sh := contextEnvLines(c, "sh")
+	assert.Equal(t, []string{
+		"export DVM_ECOSYSTEM='eco'",
+		"unset DVM_DOMAIN",
+		"unset DVM_SYSTEM",
+		`export DVM_APP='it'\''s'`,
+		`export DVM_WORKSPACE='back\slash'`,
+	}, sh)
+
+	// The exports round-trip through a real shell
+	script := strings.Join(sh, "\n") + "\nprintf '%s|%s|%s' \"$DVM_ECOSYSTEM\" \"$DVM_APP\" \"$DVM_WORKSPACE\""
+	out, err := exec.Command("sh", "-c", script).Output()
+	require.NoError(t, err)
+	assert.Equal(t, `eco|it's|back\slash`, string(out))
+
+	fish := contextEnvLines(c, "fish")
+	assert.Equal(t, "set -gx DVM_ECOSYSTEM 'eco'", fish[0])
+	assert.Equal(t, "set -e DVM_DOMAIN", fish[1])
+	assert.Equal(t, `set -gx DVM_APP 'it\'s'`, fish[3])
+	assert.Equal(t, `set -gx DVM_WORKSPACE 'back\\slash'`, fish[4])
+}
@@ -8,6 +8,7 @@ import (
 	"devopsmaestro/pkg/mirror"
 	registrypkg "devopsmaestro/pkg/registry"
 	ws "devopsmaestro/pkg/workspace"
+	"devopsmaestro/pkg/wstemplate"
 	"fmt"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"os/exec"
@@ -41,6 +42,7 @@ var (
 	workspaceRepo         string
 	workspaceBranch       string
 	workspaceCreateBranch string
+	workspaceTemplate     string
 )
 
 // Dry-run flags for create commands
@@ -81,7 +83,12 @@ Examples:
 
   # Create with environment variables
   dvm create workspace dev --env API_URL=https://api.example.com
-  dvm create workspace dev --env DB_HOST=localhost --env DB_PORT=5432`,
+  dvm create workspace dev --env DB_HOST=localhost --env DB_PORT=5432
+
+  # Create from a workspace template (see: dvm templates list)
+  dvm create workspace api --template go-grpc
+  dvm create workspace api --template go-grpc --set theme=catppuccin-mocha
+  dvm create workspace api --template github:org/dev-templates/go-grpc.yaml`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		workspaceName := args[0]
@@ -126,6 +133,21 @@ Examples:
 			}
 		}
 
+		// Load the workspace template and resolve its parameters up front
+		templateSets, _ := cmd.Flags().GetStringArray("set")
+		if len(templateSets) > 0 && workspaceTemplate == "" {
+			return fmt.Errorf("--set requires --template")
+		}
+		var tmpl *wstemplate.Template
+		var templateValues map[string]string
+		if workspaceTemplate != "" {
+			var err error
+			tmpl, templateValues, err = loadWorkspaceTemplate(cmd, workspaceTemplate, templateSets)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Get datastore from context
 		ds, err := getDataStore(cmd)
 		if err != nil {
@@ -204,6 +226,12 @@ Examples:
 			if workspaceDescription != "" {
 				render.Plain(fmt.Sprintf("  description: %s", workspaceDescription))
 			}
+			if tmpl != nil {
+				if _, err := tmpl.Instantiate(models.WorkspaceSpec{}, templateValues); err != nil {
+					return err
+				}
+				render.Plain(fmt.Sprintf("  template: %s", tmpl.Metadata.Name))
+			}
 			return nil
 		}
 
@@ -223,8 +251,17 @@ Examples:
 		if err := ws.PrepareDefaults(workspace, ds); err != nil {
 			return fmt.Errorf("failed to prepare workspace defaults: %w", err)
 		}
+		if tmpl != nil {
+			if err := applyWorkspaceTemplate(workspace, tmpl, templateValues); err != nil {
+				return err
+			}
+		}
 		if len(envMap) > 0 {
-			workspace.SetEnv(envMap)
+			env := workspace.GetEnv()
+			for k, v := range envMap {
+				env[k] = v
+			}
+			workspace.SetEnv(env)
 		}
 		if err := ds.CreateWorkspace(workspace); err != nil {
 			return fmt.Errorf("failed to create workspace: %w", err)
@@ -275,6 +312,9 @@ Examples:
 			render.Info(fmt.Sprintf("GitRepo: %s (cloned)", repoFlag))
 		}
 		render.Info(fmt.Sprintf("Image:   %s", imageName))
+		if tmpl != nil {
+			render.Info(fmt.Sprintf("Template: %s", tmpl.Metadata.Name))
+		}
 
 		render.Blank()
 		render.Info("Next steps:")
@@ -592,6 +632,8 @@ func init() {
 	createWorkspaceCmd.Flags().StringVar(&workspaceBranch, "branch", "", "Git branch to checkout (default: repo's DefaultRef)")
 	createWorkspaceCmd.Flags().StringVar(&workspaceCreateBranch, "create-branch", "", "Create a new local branch in the workspace repo")
 	createWorkspaceCmd.Flags().StringArrayP("env", "e", []string{}, "Set environment variable (KEY=VALUE, repeatable)")
+	createWorkspaceCmd.Flags().StringVar(&workspaceTemplate, "template", "", "Workspace template name, file, URL, or github: reference (see: dvm templates list)")
+	createWorkspaceCmd.Flags().StringArray("set", nil, "Set a workspace template parameter (name=value, repeatable)")
 	AddDryRunFlag(createWorkspaceCmd, &createWorkspaceDryRun)
 
 	// --branch and --create-branch are mutually exclusive
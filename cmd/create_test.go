@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"database/sql"
 	"devopsmaestro/db"
 	"devopsmaestro/models"
@@ -34,7 +35,7 @@ func (m *MockMirrorManager) Clone(url string, slug string) (string, error) {
 	return "/mock/path", nil
 }
 
-func (m *MockMirrorManager) Sync(slug string) error {
+func (m *MockMirrorManager) Sync(ctx context.Context, slug string) error {
 	if m.SyncFunc != nil {
 		return m.SyncFunc(slug)
 	}
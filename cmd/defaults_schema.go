@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/manifestvars"
+	"devopsmaestro/pkg/registry"
+)
+
+// defaultValueType is the type a defaults table value must parse as.
+type defaultValueType string
+
+const (
+	defaultTypeString   defaultValueType = "string"
+	defaultTypeBool     defaultValueType = "bool"
+	defaultTypeInt      defaultValueType = "int"
+	defaultTypeDuration defaultValueType = "duration"
+	defaultTypeJSON     defaultValueType = "json"
+)
+
+// defaultKeySpec describes a key of the defaults table.
+type defaultKeySpec struct {
+	Key         string
+	Type        defaultValueType
+	Description string
+	Allowed     []string // permitted values; empty allows any value of Type
+
+	// ManagedBy names the command that edits the key. 'dvm set default'
+	// refuses such keys, 'dvm unset default' may still clear them.
+	ManagedBy string
+	// Internal keys are dvm bookkeeping and are never edited by hand.
+	Internal bool
+
+	// Check validates a well-typed value against the store, e.g. that a
+	// named resource exists.
+	Check func(ds db.DataStore, value string) error
+}
+
+// defaultKeys is the schema of known defaults table keys. It is built in a
+// variable initializer, not init, because command help is generated from it.
+var defaultKeys = indexDefaultKeys(
+	defaultKeySpec{
+		Key:         "theme",
+		Type:        defaultTypeString,
+		Description: "Global default theme",
+		Check:       func(_ db.DataStore, value string) error { return validateThemeExists(value) },
+	},
+	defaultKeySpec{
+		Key:         "nvim-package",
+		Type:        defaultTypeString,
+		Description: "Global default Neovim plugin package",
+	},
+	defaultKeySpec{
+		Key:         "terminal-package",
+		Type:        defaultTypeString,
+		Description: "Global default terminal package",
+	},
+	defaultKeySpec{
+		Key:         "terminal-emulator",
+		Type:        defaultTypeString,
+		Description: "Terminal emulator used when a build does not name one",
+		Check: func(ds db.DataStore, value string) error {
+			if _, err := ds.GetTerminalEmulator(value); err != nil {
+				return fmt.Errorf("terminal emulator '%s' not found: %w", value, err)
+			}
+			return nil
+		},
+	},
+	registryDefaultKey(registry.AliasOCI),
+	registryDefaultKey(registry.AliasPyPI),
+	registryDefaultKey(registry.AliasNPM),
+	registryDefaultKey(registry.AliasGo),
+	registryDefaultKey(registry.AliasHTTP),
+	defaultKeySpec{
+		Key:         registry.DefaultKeyIdleTimeout,
+		Type:        defaultTypeDuration,
+		Description: "Idle timeout for registries without their own (default " + registry.DefaultIdleTimeoutValue + ")",
+	},
+
+	defaultKeySpec{
+		Key:         defaultsBuildArgsKey,
+		Type:        defaultTypeJSON,
+		Description: "Global build args",
+		ManagedBy:   "dvm set build-arg --global",
+	},
+	defaultKeySpec{
+		Key:         defaultsCACertsKey,
+		Type:        defaultTypeJSON,
+		Description: "Global CA certificates",
+		ManagedBy:   "dvm set ca-cert --global",
+	},
+	defaultKeySpec{
+		Key:         "plugins",
+		Type:        defaultTypeJSON,
+		Description: "Global default Neovim plugins",
+		ManagedBy:   "dvm set nvim plugin --global",
+	},
+
+	defaultKeySpec{
+		Key:         "context.previous",
+		Type:        defaultTypeJSON,
+		Description: "Context restored by 'dvm use -'",
+		Internal:    true,
+	},
+	defaultKeySpec{
+		Key:         defaultKeyLibraryFingerprint,
+		Type:        defaultTypeString,
+		Description: "Fingerprint of the last synced plugin library",
+		Internal:    true,
+	},
+)
+
+// indexDefaultKeys keys specs by name, rejecting duplicates.
+func indexDefaultKeys(specs ...defaultKeySpec) map[string]defaultKeySpec {
+	m := make(map[string]defaultKeySpec, len(specs))
+	for _, spec := range specs {
+		if _, dup := m[spec.Key]; dup {
+			panic("duplicate default key " + spec.Key)
+		}
+		m[spec.Key] = spec
+	}
+	return m
+}
+
+// registryDefaultKey is the spec of the default registry for a type alias.
+func registryDefaultKey(alias string) defaultKeySpec {
+	return defaultKeySpec{
+		Key:         "registry-" + alias,
+		Type:        defaultTypeString,
+		Description: fmt.Sprintf("Default %s registry", alias),
+		Check: func(ds db.DataStore, value string) error {
+			return checkDefaultRegistry(ds, alias, value)
+		},
+	}
+}
+
+// lookupDefaultKey returns the schema entry for key. Keys under the
+// var. prefix are manifest variables and any name is known.
+func lookupDefaultKey(key string) (defaultKeySpec, bool) {
+	if name, ok := strings.CutPrefix(key, manifestvars.DefaultsPrefix); ok && name != "" {
+		return defaultKeySpec{Key: key, Type: defaultTypeString, Description: "Variable ${" + name + "} in applied manifests"}, true
+	}
+	spec, ok := defaultKeys[key]
+	return spec, ok
+}
+
+// editableDefaultKeys returns the keys 'dvm set default' accepts, sorted.
+func editableDefaultKeys() []string {
+	keys := make([]string, 0, len(defaultKeys))
+	for key, spec := range defaultKeys {
+		if !spec.Internal && spec.ManagedBy == "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateValue checks value against the key's type and allowed values.
+func (s defaultKeySpec) validateValue(value string) error {
+	var err error
+	switch s.Type {
+	case defaultTypeBool:
+		_, err = strconv.ParseBool(value)
+	case defaultTypeInt:
+		_, err = strconv.Atoi(value)
+	case defaultTypeDuration:
+		var d time.Duration
+		if d, err = time.ParseDuration(value); err == nil && d <= 0 {
+			err = fmt.Errorf("must be positive")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: expected a %s (%v)", value, s.Key, s.Type, err)
+	}
+	if len(s.Allowed) > 0 {
+		for _, allowed := range s.Allowed {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid value %q for %s: must be one of %s", value, s.Key, strings.Join(s.Allowed, ", "))
+	}
+	return nil
+}
+
+// checkDefaultRegistry verifies that registryName exists and has the
+// registry type the alias (oci, pypi, ...) expects.
+func checkDefaultRegistry(ds db.DataStore, alias, registryName string) error {
+	expectedType, ok := registry.GetAllAliases()[alias]
+	if !ok {
+		return fmt.Errorf("unknown type '%s'. Valid types: oci, pypi, npm, go, http", alias)
+	}
+	reg, err := ds.GetRegistryByName(registryName)
+	if err != nil {
+		return fmt.Errorf("registry '%s' not found: %w", registryName, err)
+	}
+	if reg.Type != expectedType {
+		return fmt.Errorf("registry '%s' is type '%s' but alias '%s' expects type '%s'",
+			registryName, reg.Type, alias, expectedType)
+	}
+	return nil
+}
@@ -1,13 +1,11 @@
 package cmd
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os"
-	"strings"
 
 	"devopsmaestro/db"
+	"devopsmaestro/models"
 	"devopsmaestro/pkg/registry"
 	"devopsmaestro/pkg/resource/handlers"
 	"github.com/rmkohlman/MaestroSDK/render"
@@ -104,14 +102,8 @@ func runDeleteGlobalPlugin(cmd *cobra.Command, name string) error {
 
 	// Confirm deletion
 	force, _ := cmd.Flags().GetBool("force")
-	if !force {
-		fmt.Printf("Delete plugin definition '%s' from global library? (y/N): ", name)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			render.Info("Aborted")
-			return nil
-		}
+	if _, err := confirmDelete(fmt.Sprintf("Delete plugin definition '%s' from global library?", name), force); err != nil {
+		return err
 	}
 
 	// Delete plugin
@@ -217,6 +209,7 @@ Examples:
   dvm delete workspace dev                    # Delete from active app
   dvm delete ws dev                           # Short form
   dvm delete workspace dev --app myapp        # Delete from specific app
+  dvm delete workspace prod/payments/api/dev  # Delete by path
   dvm delete workspace dev --force            # Skip confirmation`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -232,27 +225,29 @@ Examples:
 		appFlag, _ := cmd.Flags().GetString("app")
 
 		var appName string
+		var workspace *models.Workspace
 		if appFlag != "" {
 			appName = appFlag
-		} else {
-			// Fall back to active app context (DB-backed)
-			var err error
-			appName, err = getActiveAppFromContext(ds)
+
+			// Get app to get its ID (search globally across all domains)
+			app, err := ds.GetAppByNameGlobal(appName)
 			if err != nil {
-				return fmt.Errorf("no app specified. Use --app <name> or 'dvm use app <name>' first")
+				return fmt.Errorf("app '%s' not found: %v", appName, err)
 			}
-		}
 
-		// Get app to get its ID (search globally across all domains)
-		app, err := ds.GetAppByNameGlobal(appName)
-		if err != nil {
-			return fmt.Errorf("app '%s' not found: %v", appName, err)
-		}
-
-		// Check if workspace exists
-		workspace, err := ds.GetWorkspaceByName(app.ID, workspaceName)
-		if err != nil {
-			return fmt.Errorf("workspace '%s' not found in app '%s'", workspaceName, appName)
+			// Check if workspace exists
+			workspace, err = ds.GetWorkspaceByName(app.ID, workspaceName)
+			if err != nil {
+				return fmt.Errorf("workspace '%s' not found in app '%s'", workspaceName, appName)
+			}
+		} else {
+			// Resolve the name or path, preferring the active app
+			wh, err := resolveWorkspace(ds, models.WorkspaceFilter{WorkspaceName: workspaceName})
+			if err != nil {
+				return err
+			}
+			workspace = wh.Workspace
+			appName, workspaceName = wh.App.Name, workspace.Name
 		}
 
 		// Dry-run: preview what would be deleted
@@ -263,14 +258,8 @@ Examples:
 
 		// Confirm deletion
 		force, _ := cmd.Flags().GetBool("force")
-		if !force {
-			fmt.Printf("Delete workspace '%s' from app '%s'? (y/N): ", workspaceName, appName)
-			var response string
-			fmt.Scanln(&response)
-			if response != "y" && response != "Y" {
-				render.Info("Aborted")
-				return nil
-			}
+		if _, err := confirmDelete(fmt.Sprintf("Delete workspace '%s' from app '%s'?", workspaceName, appName), force); err != nil {
+			return err
 		}
 
 		// Check if this is the active workspace before deleting
@@ -345,15 +334,8 @@ Examples:
 
 		// Confirm deletion
 		force, _ := cmd.Flags().GetBool("force")
-		if !force {
-			fmt.Printf("Delete credential '%s' (scope: %s, source: %s)? (y/N): ", cred.Name, cred.ScopeType, cred.Source)
-			reader := bufio.NewReader(os.Stdin)
-			response, _ := reader.ReadString('\n')
-			response = strings.TrimSpace(response)
-			if response != "y" && response != "Y" {
-				render.Info("Aborted")
-				return nil
-			}
+		if _, err := confirmDelete(fmt.Sprintf("Delete credential '%s' (scope: %s, source: %s)?", cred.Name, cred.ScopeType, cred.Source), force); err != nil {
+			return err
 		}
 
 		// Delete the credential
@@ -406,14 +388,8 @@ func deleteRegistry(cmd *cobra.Command, name string) error {
 
 	// Confirm deletion
 	force, _ := cmd.Flags().GetBool("force")
-	if !force {
-		fmt.Printf("Delete registry '%s' (type: %s)? (y/N): ", name, reg.Type)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			render.Info("Aborted")
-			return nil
-		}
+	if _, err := confirmDelete(fmt.Sprintf("Delete registry '%s' (type: %s)?", name, reg.Type), force); err != nil {
+		return err
 	}
 
 	// Use the core function with auto-stop logic
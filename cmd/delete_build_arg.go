@@ -4,12 +4,9 @@
 package cmd
 
 import (
-	"bufio"
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"devopsmaestro/db"
 	"devopsmaestro/pkg/resource/handlers"
@@ -91,16 +88,8 @@ func runDeleteBuildArg(cmd *cobra.Command, args []string) error {
 	}
 
 	// Confirm deletion
-	if !deleteBuildArgForce {
-		levelDesc := resolveBuildArgLevelDesc()
-		fmt.Printf("Delete build arg %q at %s? (y/N): ", key, levelDesc)
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(response)
-		if response != "y" && response != "Y" {
-			render.Info("Aborted")
-			return nil
-		}
+	if _, err := confirmDelete(fmt.Sprintf("Delete build arg %q at %s?", key, resolveBuildArgLevelDesc()), deleteBuildArgForce); err != nil {
+		return err
 	}
 
 	switch {
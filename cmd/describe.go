@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+)
+
+var (
+	describeWorkspaceFlags HierarchyFlags
+	describeAppFlags       HierarchyFlags
+	describeDomainFlags    HierarchyFlags
+)
+
+// describeCmd is the root 'describe' command
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show a detailed view of a resource and everything around it",
+	Long: `Show a detailed, human-oriented view of a single resource.
+
+Where 'dvm get' lists resources, 'dvm describe' gathers everything related to
+one resource in a single view: its parents, children, attached plugins, theme
+resolution, container state, last build, and recent events.
+
+Examples:
+  dvm describe workspace dev
+  dvm describe workspace dev -a api
+  dvm describe app api
+  dvm describe domain backend -e prod
+  dvm describe ecosystem prod
+  dvm describe gitrepo my-repo`,
+}
+
+// describeWorkspaceCmd describes a workspace
+var describeWorkspaceCmd = &cobra.Command{
+	Use:     "workspace [name]",
+	Aliases: []string{"ws"},
+	Short:   "Describe a workspace",
+	Long: `Describe a workspace: its parents, container state, plugins, theme
+resolution, last build, and recent events.
+
+Without a name, the active workspace is described. A bare name is looked up
+in the active app first, then everywhere.
+
+Examples:
+  dvm describe workspace
+  dvm describe workspace dev
+  dvm describe workspace dev -a api -e prod
+  dvm describe workspace dev -o yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDescribeWorkspace,
+}
+
+// describeAppCmd describes an app
+var describeAppCmd = &cobra.Command{
+	Use:   "app [name]",
+	Short: "Describe an app",
+	Long: `Describe an app: its parents, workspaces, theme resolution, and the
+last build of each workspace.
+
+Without a name, the active app is described. Use --ecosystem and --domain
+when the name exists in more than one place.
+
+Examples:
+  dvm describe app
+  dvm describe app api
+  dvm describe app api -d backend`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDescribeApp,
+}
+
+// describeDomainCmd describes a domain
+var describeDomainCmd = &cobra.Command{
+	Use:     "domain [name]",
+	Aliases: []string{"dom"},
+	Short:   "Describe a domain",
+	Long: `Describe a domain: its ecosystem, systems, apps, and theme resolution.
+
+Without a name, the active domain is described.
+
+Examples:
+  dvm describe domain
+  dvm describe domain backend -e prod`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDescribeDomain,
+}
+
+// describeEcosystemCmd describes an ecosystem
+var describeEcosystemCmd = &cobra.Command{
+	Use:     "ecosystem [name]",
+	Aliases: []string{"eco"},
+	Short:   "Describe an ecosystem",
+	Long: `Describe an ecosystem: its domains with their system and app counts,
+and its theme resolution.
+
+Without a name, the active ecosystem is described.
+
+Examples:
+  dvm describe ecosystem
+  dvm describe ecosystem prod`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDescribeEcosystem,
+}
+
+// describeGitRepoCmd describes a git repository
+var describeGitRepoCmd = &cobra.Command{
+	Use:     "gitrepo <name>",
+	Aliases: []string{"repo", "gr"},
+	Short:   "Describe a git repository",
+	Long: `Describe a git repository: sync status, mirror health, disk usage, ref
+counts, and the resources that use it.
+
+Examples:
+  dvm describe gitrepo my-repo
+  dvm describe gitrepo my-repo -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribeGitRepo,
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.AddCommand(describeWorkspaceCmd)
+	describeCmd.AddCommand(describeAppCmd)
+	describeCmd.AddCommand(describeDomainCmd)
+	describeCmd.AddCommand(describeEcosystemCmd)
+	describeCmd.AddCommand(describeGitRepoCmd)
+
+	AddHierarchyFlags(describeWorkspaceCmd, &describeWorkspaceFlags)
+	describeAppCmd.Flags().StringVarP(&describeAppFlags.Ecosystem, "ecosystem", "e", "", "Ecosystem of the app")
+	describeAppCmd.Flags().StringVarP(&describeAppFlags.Domain, "domain", "d", "", "Domain of the app")
+	describeDomainCmd.Flags().StringVarP(&describeDomainFlags.Ecosystem, "ecosystem", "e", "", "Ecosystem of the domain")
+
+	for _, c := range describeCmd.Commands() {
+		AddOutputFlag(c, "")
+	}
+}
+
+func runDescribeWorkspace(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	wh, err := resolveSessionWorkspace(ds, describeWorkspaceFlags, firstArg(args))
+	if err != nil {
+		return err
+	}
+	// Report the live container state rather than the last recorded one
+	reconcileWorkspaceStatuses(ds, []*models.Workspace{wh.Workspace})
+
+	ecoName := ""
+	if wh.Ecosystem != nil {
+		ecoName = wh.Ecosystem.Name
+	}
+	domName := ""
+	if wh.Domain != nil {
+		domName = wh.Domain.Name
+	}
+	res := handlers.NewWorkspaceResource(wh.Workspace, wh.App.Name, domName, "", ecoName)
+	return describeResource(cmd, ds, res)
+}
+
+func runDescribeApp(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	var app *models.App
+	if name := firstArg(args); name != "" {
+		app, err = findDescribeApp(ds, name, describeAppFlags)
+	} else {
+		app, err = getActiveApp(ds)
+		if err != nil {
+			return ErrorWithSuggestion("no app specified and no active app", SuggestNoActiveApp()...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return describeResource(cmd, ds, handlers.NewAppResource(app, "", ""))
+}
+
+// findDescribeApp finds an app by name, narrowed by --ecosystem and --domain.
+func findDescribeApp(ds db.DataStore, name string, flags HierarchyFlags) (*models.App, error) {
+	matches, err := ds.FindAppsByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find app '%s': %w", name, err)
+	}
+	var found []*models.AppWithHierarchy
+	var places []string
+	for _, m := range matches {
+		if flags.Ecosystem != "" && (m.Ecosystem == nil || m.Ecosystem.Name != flags.Ecosystem) {
+			continue
+		}
+		if flags.Domain != "" && (m.Domain == nil || m.Domain.Name != flags.Domain) {
+			continue
+		}
+		found = append(found, m)
+		places = append(places, appPlace(m))
+	}
+	switch len(found) {
+	case 0:
+		return nil, ErrorWithSuggestion(fmt.Sprintf("app %q not found", name), SuggestAppNotFound(name)...)
+	case 1:
+		return found[0].App, nil
+	}
+	return nil, ErrorWithSuggestion(
+		fmt.Sprintf("app %q exists in several places: %s", name, strings.Join(places, ", ")),
+		fmt.Sprintf("dvm describe app %s -e <ecosystem> -d <domain>", name))
+}
+
+// appPlace formats where an app lives, as ecosystem/domain.
+func appPlace(m *models.AppWithHierarchy) string {
+	var parts []string
+	if m.Ecosystem != nil {
+		parts = append(parts, m.Ecosystem.Name)
+	}
+	if m.Domain != nil {
+		parts = append(parts, m.Domain.Name)
+	}
+	return strings.Join(parts, "/")
+}
+
+func runDescribeDomain(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	var dom *models.Domain
+	if name := firstArg(args); name != "" {
+		dom, err = findDescribeDomain(ds, name, describeDomainFlags)
+	} else {
+		dom, err = getActiveDomain(ds)
+		if err != nil {
+			return ErrorWithSuggestion("no domain specified and no active domain", SuggestNoActiveDomain()...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return describeResource(cmd, ds, handlers.NewDomainResource(dom, ""))
+}
+
+// findDescribeDomain finds a domain by name, narrowed by --ecosystem.
+func findDescribeDomain(ds db.DataStore, name string, flags HierarchyFlags) (*models.Domain, error) {
+	matches, err := ds.FindDomainsByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find domain '%s': %w", name, err)
+	}
+	var found []*models.DomainWithHierarchy
+	var ecosystems []string
+	for _, m := range matches {
+		if flags.Ecosystem != "" && (m.Ecosystem == nil || m.Ecosystem.Name != flags.Ecosystem) {
+			continue
+		}
+		found = append(found, m)
+		if m.Ecosystem != nil {
+			ecosystems = append(ecosystems, m.Ecosystem.Name)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, ErrorWithSuggestion(fmt.Sprintf("domain %q not found", name), SuggestDomainNotFound(name)...)
+	case 1:
+		return found[0].Domain, nil
+	}
+	return nil, ErrorWithSuggestion(
+		fmt.Sprintf("domain %q exists in several ecosystems: %s", name, strings.Join(ecosystems, ", ")),
+		fmt.Sprintf("dvm describe domain %s -e <ecosystem>", name))
+}
+
+func runDescribeEcosystem(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	var eco *models.Ecosystem
+	if name := firstArg(args); name != "" {
+		eco, err = ds.GetEcosystemByName(name)
+		if err != nil {
+			return ErrorWithSuggestion(fmt.Sprintf("ecosystem %q not found", name), SuggestEcosystemNotFound(name)...)
+		}
+	} else {
+		eco, err = getActiveEcosystem(ds)
+		if err != nil {
+			return ErrorWithSuggestion("no ecosystem specified and no active ecosystem", SuggestNoActiveEcosystem()...)
+		}
+	}
+	return describeResource(cmd, ds, handlers.NewEcosystemResource(eco))
+}
+
+// describeResource builds the description of res and renders it.
+func describeResource(cmd *cobra.Command, ds db.DataStore, res resource.Resource) error {
+	handlers.RegisterAll()
+	desc, err := handlers.Describe(resource.Context{DataStore: ds}, res)
+	if err != nil {
+		return err
+	}
+	format, _ := cmd.Flags().GetString("output")
+	return renderDescription(cmd.OutOrStdout(), format, desc)
+}
+
+// renderDescription writes a description. Structured formats get the whole
+// description; human formats get one titled block per section.
+func renderDescription(w io.Writer, format string, desc *handlers.Description) error {
+	if isStructuredOutput(format) {
+		return render.OutputTo(w, format, desc, render.Options{Type: render.TypeAuto})
+	}
+	for _, sec := range desc.Sections {
+		opts := render.Options{Title: sec.Title}
+		var err error
+		switch {
+		case len(sec.Rows) > 0:
+			opts.Type = render.TypeTable
+			err = render.OutputTo(w, format, render.TableData{Headers: sec.Headers, Rows: sec.Rows}, opts)
+		case len(sec.Fields) > 0:
+			pairs := make([]render.KeyValue, len(sec.Fields))
+			for i, f := range sec.Fields {
+				pairs[i] = render.KeyValue{Key: f.Key, Value: f.Value}
+			}
+			opts.Type = render.TypeKeyValue
+			err = render.OutputTo(w, format, render.NewOrderedKeyValueData(pairs...), opts)
+		default:
+			opts.Type = render.TypeKeyValue
+			if err = render.OutputTo(w, format, render.NewOrderedKeyValueData(), opts); err == nil {
+				_, err = fmt.Fprintln(w, sec.None)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstArg returns args[0], or "" when there are no arguments.
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
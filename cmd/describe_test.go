@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindDescribeApp(t *testing.T) {
+	mock := newSessionMock(t)
+	mock.Ecosystems["other"] = &models.Ecosystem{ID: 2, Name: "other"}
+	mock.Domains[2] = &models.Domain{ID: 2, Name: "dom", EcosystemID: sql.NullInt64{Int64: 2, Valid: true}}
+	mock.Apps[3] = &models.App{ID: 3, Name: "api", DomainID: sql.NullInt64{Int64: 2, Valid: true}}
+
+	app, err := findDescribeApp(mock, "web", HierarchyFlags{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, app.ID)
+
+	_, err = findDescribeApp(mock, "api", HierarchyFlags{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exists in several places")
+
+	app, err = findDescribeApp(mock, "api", HierarchyFlags{Ecosystem: "other"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, app.ID)
+
+	_, err = findDescribeApp(mock, "missing", HierarchyFlags{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `app "missing" not found`)
+}
+
+func TestDescribeApp_JSON(t *testing.T) {
+	mock := newSessionMock(t)
+
+	cmd := &cobra.Command{}
+	AddOutputFlag(cmd, "")
+	require.NoError(t, cmd.Flags().Set("output", "json"))
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, mock))
+
+	require.NoError(t, runDescribeApp(cmd, []string{"api"}))
+
+	var desc handlers.Description
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &desc))
+	assert.Equal(t, "App", desc.Kind)
+	assert.Equal(t, "api", desc.Name)
+	workspaces := desc.Section("Workspaces")
+	require.NotNil(t, workspaces)
+	assert.Len(t, workspaces.Rows, 2)
+	assert.Equal(t, "dom", desc.Section("Parents").Field("Domain"))
+}
+
+func TestRenderDescription_Plain(t *testing.T) {
+	desc := &handlers.Description{Kind: "App", Name: "api", Sections: []handlers.DescriptionSection{
+		{Title: "App", Fields: []handlers.DescriptionField{{Key: "Name", Value: "api"}}},
+		{Title: "Workspaces", Headers: []string{"NAME"}, Rows: [][]string{{"dev"}}},
+		{Title: "Last Builds", Headers: []string{"WORKSPACE"}, None: "<never built>"},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, renderDescription(&buf, "plain", desc))
+	out := buf.String()
+	for _, want := range []string{"== App ==", "api", "== Workspaces ==", "dev", "== Last Builds ==", "<never built>"} {
+		assert.Contains(t, out, want)
+	}
+}
@@ -2,9 +2,9 @@ package cmd
 
 import (
 	"context"
+	"devopsmaestro/db"
 	"devopsmaestro/models"
 	"devopsmaestro/operators"
-	"devopsmaestro/pkg/resolver"
 	"fmt"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"log/slog"
@@ -31,11 +31,14 @@ but not removed, so you can quickly re-attach later with 'dvm attach'.
 
 Use -A (--all) to stop all DVM workspace containers.
 
+Services started for the workspace (spec.services) are stopped and removed.
+Workspaces with spec.sync get a last sync pass before the container stops.
+
 Flags:
   -e, --ecosystem   Filter by ecosystem name
   -d, --domain      Filter by domain name  
   -a, --app         Filter by app name
-  -w, --workspace   Filter by workspace name
+  -w, --workspace   Workspace name or path (e.g. eco/domain/app/ws)
   -A, --all         Stop all DVM workspace containers
 
 Examples:
@@ -68,29 +71,27 @@ func runDetach(cmd *cobra.Command) error {
 	}
 
 	// Create timeout context from flag
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 	if detachTimeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, detachTimeout)
 		defer cancel()
 	}
 
-	// Create container runtime using factory
-	runtime, err := operators.NewContainerRuntime()
-	if err != nil {
-		return fmt.Errorf("failed to create container runtime: %w", err)
-	}
-
-	slog.Debug("using runtime", "type", runtime.GetRuntimeType(), "platform", runtime.GetPlatformName())
-
 	if detachAll {
-		return detachAllWorkspaces(ctx, runtime)
+		// Create container runtime using factory
+		runtime, err := operators.NewContainerRuntime()
+		if err != nil {
+			return fmt.Errorf("failed to create container runtime: %w", err)
+		}
+		slog.Debug("using runtime", "type", runtime.GetRuntimeType(), "platform", runtime.GetPlatformName())
+		return detachAllWorkspaces(cmd, ctx, runtime)
 	}
 
-	return detachActiveWorkspace(cmd, ctx, runtime)
+	return detachActiveWorkspace(cmd, ctx)
 }
 
-func detachActiveWorkspace(cmd *cobra.Command, ctx context.Context, runtime operators.ContainerRuntime) error {
+func detachActiveWorkspace(cmd *cobra.Command, ctx context.Context) error {
 	// Get datastore from context
 	ds, err := getDataStore(cmd)
 	if err != nil {
@@ -108,22 +109,9 @@ func detachActiveWorkspace(cmd *cobra.Command, ctx context.Context, runtime oper
 		slog.Debug("using hierarchy flags", "ecosystem", detachFlags.Ecosystem,
 			"domain", detachFlags.Domain, "system", detachFlags.System, "app", detachFlags.App, "workspace", detachFlags.Workspace)
 
-		wsResolver := resolver.NewWorkspaceResolver(ds)
-		result, err := wsResolver.Resolve(detachFlags.ToFilter())
+		result, err := resolveWorkspace(ds, detachFlags.ToFilter())
 		if err != nil {
-			// Check if ambiguous and provide helpful output
-			if ambiguousErr, ok := resolver.IsAmbiguousError(err); ok {
-				render.Warning("Multiple workspaces match your criteria")
-				render.Plain(ambiguousErr.FormatDisambiguation())
-				render.Plain(FormatSuggestions(SuggestAmbiguousWorkspace()...))
-				return fmt.Errorf("ambiguous workspace selection")
-			}
-			if resolver.IsNoWorkspaceFoundError(err) {
-				render.Warning("No workspace found matching your criteria")
-				render.Plain(FormatSuggestions(SuggestWorkspaceNotFound("")...))
-				return err
-			}
-			return fmt.Errorf("failed to resolve workspace: %w", err)
+			return err
 		}
 
 		// Use resolved workspace and app
@@ -169,16 +157,40 @@ func detachActiveWorkspace(cmd *cobra.Command, ctx context.Context, runtime oper
 		}
 	}
 
-	// Use workspace and app to ensure they're referenced (avoid unused variable warnings)
-	_ = workspace
-
 	// Stop the container using hierarchical naming strategy
 	namingStrategy := operators.NewHierarchicalNamingStrategy()
 	containerName := namingStrategy.GenerateName(ecosystemName, domainName, systemName, appName, workspaceName)
-	return stopWorkspace(ctx, runtime, containerName)
+	stopped, err := stopWorkspaceContainer(ctx, ds, app, workspace, containerName)
+	if stopped {
+		render.Blank()
+		render.Info("Re-attach with: dvm attach")
+	}
+	return err
 }
 
-func detachAllWorkspaces(ctx context.Context, runtime operators.ContainerRuntime) error {
+// stopWorkspaceContainer stops a workspace's container, syncing copied files
+// back first, and then its services. It reports whether the container was
+// running. dvm detach and dvm down share it.
+func stopWorkspaceContainer(ctx context.Context, ds db.DataStore, app *models.App, workspace *models.Workspace, containerName string) (bool, error) {
+	// Create the workspace's container runtime (local, remote endpoint, or Kubernetes)
+	runtime, err := workspaceRuntime(ds, workspace)
+	if err != nil {
+		return false, fmt.Errorf("failed to create container runtime: %w", err)
+	}
+	slog.Debug("using runtime", "type", runtime.GetRuntimeType(), "platform", runtime.GetPlatformName())
+
+	syncBeforeDetach(ctx, ds, runtime, workspace, app, containerName)
+	stopped, err := stopWorkspace(ctx, runtime, containerName)
+	if err != nil {
+		return false, err
+	}
+	if err := pullRemoteWorkspace(ctx, ds, workspace, app.Path); err != nil {
+		return stopped, fmt.Errorf("failed to sync files back: %w", err)
+	}
+	return stopped, stopWorkspaceServices(ctx, ds, workspace, app.Path)
+}
+
+func detachAllWorkspaces(cmd *cobra.Command, ctx context.Context, runtime operators.ContainerRuntime) error {
 	render.Progress("Finding all DVM workspace containers...")
 
 	stopped, err := runtime.StopAllWorkspaces(ctx)
@@ -186,6 +198,11 @@ func detachAllWorkspaces(ctx context.Context, runtime operators.ContainerRuntime
 		return fmt.Errorf("failed to stop workspaces: %w", err)
 	}
 
+	// Tear down workspace services (best effort)
+	if ds, err := getDataStore(cmd); err == nil {
+		stopAllWorkspaceServices(ctx, ds)
+	}
+
 	if stopped == 0 {
 		render.Info("No running DVM workspace containers found")
 		return nil
@@ -196,37 +213,58 @@ func detachAllWorkspaces(ctx context.Context, runtime operators.ContainerRuntime
 	return nil
 }
 
-func stopWorkspace(ctx context.Context, runtime operators.ContainerRuntime, containerName string) error {
+// stopAllWorkspaceServices tears down the services of every workspace that
+// has them, warning about failures.
+func stopAllWorkspaceServices(ctx context.Context, ds db.DataStore) {
+	workspaces, err := ds.ListAllWorkspaces()
+	if err != nil {
+		slog.Warn("failed to list workspaces", "error", err)
+		return
+	}
+	for _, workspace := range workspaces {
+		if workspace.ToYAML("", "").Spec.Services.IsZero() {
+			continue
+		}
+		app, err := ds.GetAppByID(workspace.AppID)
+		if err != nil {
+			slog.Warn("failed to get app", "workspace", workspace.Name, "error", err)
+			continue
+		}
+		if err := stopWorkspaceServices(ctx, ds, workspace, app.Path); err != nil {
+			render.Warning(fmt.Sprintf("Services of workspace '%s': %v", workspace.Name, err))
+		}
+	}
+}
+
+// stopWorkspace stops a workspace container and reports whether it was running.
+func stopWorkspace(ctx context.Context, runtime operators.ContainerRuntime, containerName string) (bool, error) {
 	render.Progress(fmt.Sprintf("Stopping workspace '%s'...", containerName))
 
 	// Check if workspace exists and is running
 	workspace, err := runtime.FindWorkspace(ctx, containerName)
 	if err != nil {
-		return fmt.Errorf("failed to find workspace: %w", err)
+		return false, fmt.Errorf("failed to find workspace: %w", err)
 	}
 
 	if workspace == nil {
 		render.Info(fmt.Sprintf("Workspace '%s' not found", containerName))
-		return nil
+		return false, nil
 	}
 
 	// Check if already stopped
 	if workspace.Status != "running" && workspace.Status != "Up" && !containsRunning(workspace.Status) {
 		render.Info(fmt.Sprintf("Workspace '%s' is not running (status: %s)", containerName, workspace.Status))
-		return nil
+		return false, nil
 	}
 
 	// Stop the workspace
 	if err := runtime.StopWorkspace(ctx, containerName); err != nil {
-		return fmt.Errorf("failed to stop container: %w", err)
+		return false, fmt.Errorf("failed to stop container: %w", err)
 	}
 
 	slog.Info("workspace stopped", "name", containerName)
 	render.Success(fmt.Sprintf("Workspace '%s' stopped", containerName))
-	render.Blank()
-	render.Info("Re-attach with: dvm attach")
-
-	return nil
+	return true, nil
 }
 
 // containsRunning checks if the status string indicates a running container
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/devcontainer"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// devcontainerExportFlags holds the hierarchy flags for 'devcontainer export'
+var devcontainerExportFlags HierarchyFlags
+
+// devcontainerCmd groups the devcontainer.json converters.
+// Usage: dvm devcontainer import [path]
+//
+//	dvm devcontainer export [workspace]
+var devcontainerCmd = &cobra.Command{
+	Use:   "devcontainer",
+	Short: "Convert between devcontainer.json and dvm resources",
+	Long: `Convert between VS Code devcontainer.json files and dvm resources, so
+colleagues using VS Code Dev Containers and dvm share one environment.
+
+Only settings with a dvm equivalent are converted: the image or Dockerfile,
+language features, forwarded ports, mounts, container environment, the
+workspace folder, and postCreateCommand. Anything else is reported as a
+warning.`,
+}
+
+// devcontainerImportCmd converts a devcontainer.json into App and Workspace YAML.
+var devcontainerImportCmd = &cobra.Command{
+	Use:   "import [path]",
+	Short: "Convert a devcontainer.json into App and Workspace YAML",
+	Long: `Convert a devcontainer.json into an App and a Workspace, written as a
+List document for 'dvm apply -f'.
+
+The path is a devcontainer.json file or an app directory holding
+.devcontainer/devcontainer.json or .devcontainer.json (default: the current
+directory). The app's path is that directory.
+
+Language images (golang:1.22, mcr.microsoft.com/devcontainers/python) and
+language features set the app language; other images become the workspace's
+base image. postCreateCommand becomes a dev stage custom command, which runs
+while the image is built rather than after the container starts.
+
+Without --out, the YAML is written to stdout.
+
+Examples:
+  dvm devcontainer import
+  dvm devcontainer import ~/src/api --app api --workspace dev
+  dvm devcontainer import --out api.yaml && dvm apply -f api.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDevcontainerImport,
+}
+
+// devcontainerExportCmd writes a devcontainer.json for a workspace.
+var devcontainerExportCmd = &cobra.Command{
+	Use:   "export [workspace]",
+	Short: "Write a devcontainer.json for a workspace",
+	Long: `Write a devcontainer.json for a workspace, so the app can be opened in
+VS Code Dev Containers.
+
+The workspace is the active one unless a name or hierarchy flags are given.
+The file is meant for the app's .devcontainer directory: the app's
+Dockerfile is referenced relative to it. Apps without a Dockerfile use the
+devcontainers base image with features for the app's languages.
+
+Without --out, the JSON is written to stdout.
+
+Examples:
+  dvm devcontainer export
+  dvm devcontainer export dev -a api --out ~/src/api/.devcontainer/devcontainer.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDevcontainerExport,
+}
+
+func init() {
+	devcontainerImportCmd.Flags().StringP("app", "a", "", "App name (default: the app directory name)")
+	devcontainerImportCmd.Flags().StringP("workspace", "w", defaultScaffoldWorkspace, "Workspace name")
+	devcontainerImportCmd.Flags().StringP("domain", "d", "", "Domain for the app (default: active domain)")
+	devcontainerImportCmd.Flags().StringP("ecosystem", "e", "", "Ecosystem of the domain (default: active ecosystem)")
+	devcontainerImportCmd.Flags().String("out", "", "Output file (default: stdout)")
+	devcontainerImportCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+
+	AddHierarchyFlags(devcontainerExportCmd, &devcontainerExportFlags)
+	devcontainerExportCmd.Flags().String("out", "", "Output file (default: stdout)")
+	devcontainerExportCmd.Flags().Bool("force", false, "Overwrite an existing output file")
+
+	devcontainerCmd.AddCommand(devcontainerImportCmd)
+	devcontainerCmd.AddCommand(devcontainerExportCmd)
+	rootCmd.AddCommand(devcontainerCmd)
+}
+
+func runDevcontainerImport(cmd *cobra.Command, args []string) error {
+	appName, _ := cmd.Flags().GetString("app")
+	workspaceName, _ := cmd.Flags().GetString("workspace")
+	domainName, _ := cmd.Flags().GetString("domain")
+	ecosystemName, _ := cmd.Flags().GetString("ecosystem")
+	out, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	path := "."
+	if len(args) == 1 {
+		path = args[0]
+	}
+	configPath, err := devcontainer.Find(path)
+	if err != nil {
+		return err
+	}
+	if configPath, err = filepath.Abs(configPath); err != nil {
+		return err
+	}
+	configDir := filepath.Dir(configPath)
+	appPath := configDir
+	if filepath.Base(configDir) == ".devcontainer" {
+		appPath = filepath.Dir(configDir)
+	}
+
+	if appName == "" {
+		appName = filepath.Base(appPath)
+	}
+	if err := ValidateResourceName(appName, "app"); err != nil {
+		return err
+	}
+	if err := ValidateResourceName(workspaceName, "workspace"); err != nil {
+		return err
+	}
+
+	if domainName == "" || ecosystemName == "" {
+		ds, err := getDataStore(cmd)
+		if err != nil {
+			return err
+		}
+		if domainName == "" {
+			if domainName, err = getActiveDomainFromContext(ds); err != nil {
+				return fmt.Errorf("no domain given: use --domain or 'dvm use domain <name>'")
+			}
+		}
+		if ecosystemName == "" {
+			// The ecosystem only disambiguates the domain, so it is optional.
+			ecosystemName, _ = getActiveEcosystemFromContext(ds)
+		}
+	}
+
+	cfg, err := devcontainer.Load(configPath)
+	if err != nil {
+		return err
+	}
+	imported := devcontainer.Import(cfg, devcontainer.ImportOptions{
+		AppName:       appName,
+		WorkspaceName: workspaceName,
+		Domain:        domainName,
+		Ecosystem:     ecosystemName,
+		AppPath:       appPath,
+		ConfigDir:     configDir,
+	})
+	for _, w := range imported.Warnings {
+		render.WarningToStderr(w)
+	}
+
+	list := resource.NewResourceList()
+	list.Items = []any{imported.App, imported.Workspace}
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to marshal resources: %w", err)
+	}
+	if err := writeCommandOutput(cmd, out, data, force); err != nil {
+		return err
+	}
+	if out == "" {
+		return nil
+	}
+	render.Successf("Wrote app '%s' and workspace '%s' to %s", appName, workspaceName, out)
+	render.Info(fmt.Sprintf("Apply with: dvm apply -f %s", out))
+	return nil
+}
+
+func runDevcontainerExport(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	wh, err := resolveSessionWorkspace(ds, devcontainerExportFlags, firstArg(args))
+	if err != nil {
+		return err
+	}
+
+	appYAML := wh.App.ToYAML(wh.Domain.Name, nil, "", "")
+	workspaceYAML := wh.Workspace.ToYAML(wh.App.Name, "")
+	cfg, warnings := devcontainer.Export(appYAML, workspaceYAML)
+	for _, w := range warnings {
+		render.WarningToStderr(w)
+	}
+
+	data, err := cfg.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal devcontainer.json: %w", err)
+	}
+	if err := writeCommandOutput(cmd, out, data, force); err != nil {
+		return err
+	}
+	if out == "" {
+		return nil
+	}
+	render.Successf("Wrote devcontainer.json for workspace '%s' to %s", wh.Workspace.Name, out)
+	return nil
+}
+
+// writeCommandOutput writes data to out, or to stdout when out is empty.
+func writeCommandOutput(cmd *cobra.Command, out string, data []byte, force bool) error {
+	if out == "" {
+		_, err := cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if strings.HasPrefix(out, "~") {
+		home, _ := os.UserHomeDir()
+		out = filepath.Join(home, out[1:])
+	}
+	if _, err := os.Stat(out); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", out)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(out, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	return nil
+}
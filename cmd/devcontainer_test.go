@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDevcontainerCmd_Subcommands(t *testing.T) {
+	names := map[string]bool{}
+	for _, sub := range devcontainerCmd.Commands() {
+		names[sub.Name()] = true
+	}
+	assert.True(t, names["import"])
+	assert.True(t, names["export"])
+
+	assert.Equal(t, defaultScaffoldWorkspace, devcontainerImportCmd.Flags().Lookup("workspace").DefValue)
+	for _, flag := range []string{"app", "domain", "ecosystem", "out", "force"} {
+		assert.NotNil(t, devcontainerImportCmd.Flags().Lookup(flag), flag)
+	}
+	for _, flag := range []string{"app", "workspace", "out", "force"} {
+		assert.NotNil(t, devcontainerExportCmd.Flags().Lookup(flag), flag)
+	}
+}
+
+func TestRunDevcontainerImport_WritesList(t *testing.T) {
+	appDir := filepath.Join(t.TempDir(), "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(appDir, ".devcontainer"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(appDir, ".devcontainer", "devcontainer.json"),
+		[]byte(`{"image": "golang:1.22", "forwardPorts": [8080]}`), 0644))
+	out := filepath.Join(t.TempDir(), "api.yaml")
+
+	flags := devcontainerImportCmd.Flags()
+	for name, value := range map[string]string{"domain": "backend", "ecosystem": "acme", "out": out} {
+		require.NoError(t, flags.Set(name, value))
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"domain", "ecosystem", "out"} {
+			_ = flags.Set(name, "")
+		}
+	})
+
+	require.NoError(t, runDevcontainerImport(devcontainerImportCmd, []string{appDir}))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	var list resource.ResourceList
+	require.NoError(t, yaml.Unmarshal(data, &list))
+	assert.Equal(t, "List", list.Kind)
+	require.Len(t, list.Items, 2)
+
+	app := list.Items[0].(map[string]any)
+	assert.Equal(t, "App", app["kind"])
+	assert.Equal(t, map[string]any{"name": "api", "domain": "backend", "ecosystem": "acme"}, app["metadata"])
+	workspace := list.Items[1].(map[string]any)
+	assert.Equal(t, "Workspace", workspace["kind"])
+	assert.Equal(t, "dev", workspace["metadata"].(map[string]any)["name"])
+
+	err = runDevcontainerImport(devcontainerImportCmd, []string{appDir})
+	assert.ErrorContains(t, err, "already exists")
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/preflight"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd checks that dvm's environment is ready to run workspaces
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the container runtime is ready",
+	Long: `Check that dvm's environment can run workspaces:
+
+- Container Platform: a container platform is detected and running
+- Colima VM: on Colima, the VM is running and has at least the CPUs,
+  memory, and disk set in runtime.colima.cpus, runtime.colima.memory, and
+  runtime.colima.disk
+
+Each failed check comes with a recommendation. dvm doctor exits non-zero
+when a check fails; warnings do not fail it.
+
+Examples:
+  dvm doctor
+  dvm doctor -o json`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	AddOutputFlag(doctorCmd, "")
+}
+
+// DoctorCheckOutput is one check of dvm doctor.
+type DoctorCheckOutput struct {
+	Check          string `json:"check" yaml:"check"`
+	Status         string `json:"status" yaml:"status"` // ok, warning, error, or skipped
+	Message        string `json:"message" yaml:"message"`
+	Recommendation string `json:"recommendation,omitempty" yaml:"recommendation,omitempty"`
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("output")
+
+	runner := preflight.NewPreflightRunner()
+	detector, err := operators.NewPlatformDetector()
+	if err != nil {
+		return fmt.Errorf("failed to create platform detector: %w", err)
+	}
+	runner.AddCheck(preflight.NewPlatformCheck(detector))
+	if profile := colimaProfile(); profile != "" {
+		req, err := colimaRequirements()
+		if err != nil {
+			return err
+		}
+		vm, err := operators.NewColimaVM(profile)
+		if err != nil {
+			return err
+		}
+		runner.AddCheck(preflight.NewColimaVMCheck(vm, req))
+	}
+
+	results := runner.Run(commandContext(cmd))
+	checks := make([]DoctorCheckOutput, len(results))
+	for i, result := range results {
+		checks[i] = DoctorCheckOutput{
+			Check:   runner.GetChecks()[i].Name(),
+			Status:  checkStatusLabel(result.Status),
+			Message: result.Message,
+		}
+		if rec, ok := result.Details["recommendation"].(string); ok {
+			checks[i].Recommendation = rec
+		}
+	}
+
+	if isStructuredOutput(format) {
+		if err := render.OutputWith(format, checks, render.Options{}); err != nil {
+			return err
+		}
+	} else {
+		td := render.TableData{Headers: []string{"CHECK", "STATUS", "MESSAGE"}}
+		for _, c := range checks {
+			td.Rows = append(td.Rows, []string{c.Check, c.Status, c.Message})
+		}
+		if err := render.OutputWith(format, td, render.Options{Type: render.TypeTable}); err != nil {
+			return err
+		}
+		for _, c := range checks {
+			if c.Recommendation != "" {
+				render.Info(fmt.Sprintf("%s: %s", c.Check, c.Recommendation))
+			}
+		}
+	}
+
+	if runner.HasErrors(results) {
+		return fmt.Errorf("dvm doctor found problems")
+	}
+	return nil
+}
+
+// checkStatusLabel returns the lowercase name of a check status.
+func checkStatusLabel(status preflight.CheckStatus) string {
+	switch status {
+	case preflight.StatusOK:
+		return "ok"
+	case preflight.StatusWarning:
+		return "warning"
+	case preflight.StatusError:
+		return "error"
+	default:
+		return "skipped"
+	}
+}
@@ -15,8 +15,10 @@ import (
 )
 
 var (
-	domainDescription string
-	domainEcosystem   string
+	domainDescription     string
+	domainEcosystem       string
+	domainNvimPackage     string
+	domainTerminalPackage string
 )
 
 // Dry-run flags for domain commands
@@ -45,7 +47,10 @@ Examples:
   dvm create domain backend --ecosystem my-platform
   
   # Create with description
-  dvm create domain backend --description "Backend services"`,
+  dvm create domain backend --description "Backend services"
+
+  # Declare default packages inherited by the domain's apps and workspaces
+  dvm create domain backend --nvim-package go-dev --terminal-package minimal`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		domainName := args[0]
@@ -84,6 +89,7 @@ Examples:
 			if domainDescription != "" {
 				render.Plain(fmt.Sprintf("  description: %s", domainDescription))
 			}
+			renderDryRunPackages(domainNvimPackage, domainTerminalPackage)
 			return nil
 		}
 
@@ -95,6 +101,8 @@ Examples:
 
 		// Create domain using handler helper
 		domain := handlers.NewDomainFromModel(domainName, ecosystem.ID, domainDescription)
+		domain.NvimPackage = nullString(domainNvimPackage)
+		domain.TerminalPackage = nullString(domainTerminalPackage)
 
 		if err := ds.CreateDomain(domain); err != nil {
 			return fmt.Errorf("failed to create domain: %w", err)
@@ -207,6 +215,10 @@ Examples:
 			return errSilent
 		}
 
+		if err := checkNotArchived(ds, models.ArchiveKindDomain, domain.ID, domainName); err != nil {
+			return err
+		}
+
 		// Handle --export flag: print export statement and return
 		exportFlag, _ := cmd.Flags().GetBool("export")
 		if exportFlag {
@@ -284,6 +296,14 @@ func getDomains(cmd *cobra.Command) error {
 		}
 	}
 
+	archived, err := loadArchivedIDs(ds)
+	if err != nil {
+		return err
+	}
+	if archived != nil {
+		domains = withoutArchived(domains, archived.Domains, func(d *models.Domain) int { return d.ID })
+	}
+
 	// Get active domain for highlighting
 	ctx, _ := ds.GetContext()
 	var activeDomainID *int
@@ -292,10 +312,10 @@ func getDomains(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		handlers.RegisterAll()
 		if len(domains) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
 		// Convert domain models to Resource objects for BuildList
 		domainResources := make([]resource.Resource, len(domains))
@@ -314,7 +334,7 @@ func getDomains(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("failed to build resource list: %w", err)
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	if len(domains) == 0 {
@@ -322,7 +342,7 @@ func getDomains(cmd *cobra.Command) error {
 		if allFlag {
 			msg = "No domains found"
 		}
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: msg,
 			EmptyHints:   []string{"dvm create domain <name>"},
@@ -413,7 +433,7 @@ func getDomains(cmd *cobra.Command) error {
 		renderFormat = "table"
 	}
 
-	return render.OutputWith(renderFormat, tableData, render.Options{
+	return outputList(renderFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -458,13 +478,13 @@ func getDomain(cmd *cobra.Command, name string) error {
 	domain := res.(*handlers.DomainResource).Domain()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		apps, _ := ds.ListAppsByDomain(domain.ID)
 		appNames := make([]string, len(apps))
 		for j, a := range apps {
 			appNames[j] = a.Name
 		}
-		return render.OutputWith(getOutputFormat, domain.ToYAML(ecosystem.Name, appNames), render.Options{})
+		return outputList(getOutputFormat, domain.ToYAML(ecosystem.Name, appNames), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -492,7 +512,7 @@ func getDomain(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Created", Value: domain.CreatedAt.Format("2006-01-02 15:04:05")},
 	)
 
-	err = render.OutputWith(getOutputFormat, kvData, render.Options{
+	err = outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Domain Details",
 	})
@@ -623,6 +643,8 @@ func init() {
 	// Domain creation flags
 	createDomainCmd.Flags().StringVar(&domainDescription, "description", "", "Domain description")
 	createDomainCmd.Flags().StringVar(&domainEcosystem, "ecosystem", "", "Ecosystem name (defaults to active ecosystem)")
+	createDomainCmd.Flags().StringVar(&domainNvimPackage, "nvim-package", "", "Default nvim package for the domain's apps and workspaces")
+	createDomainCmd.Flags().StringVar(&domainTerminalPackage, "terminal-package", "", "Default terminal package for the domain's apps and workspaces")
 	AddDryRunFlag(createDomainCmd, &createDomainDryRun)
 
 	// Use domain dry-run
@@ -647,7 +669,7 @@ func getActiveDomain(ds db.DataStore) (*models.Domain, error) {
 	}
 
 	if ctx.ActiveDomainID == nil {
-		return nil, fmt.Errorf("no active domain set")
+		return nil, db.NewErrNoActiveContext("domain")
 	}
 
 	domain, err := ds.GetDomainByID(*ctx.ActiveDomainID)
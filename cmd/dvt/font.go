@@ -0,0 +1,339 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/fetch"
+	"devopsmaestro/pkg/terminalbridge/emulatorgen"
+	"devopsmaestro/pkg/terminalbridge/fonts"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/emulator"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// fontInstallTimeout bounds a font download and extraction.
+const fontInstallTimeout = 5 * time.Minute
+
+// fontCmd is the main font command
+var fontCmd = &cobra.Command{
+	Use:   "font",
+	Short: "Manage Nerd Fonts",
+	Long: `Nerd Font management for terminal configurations.
+
+Prompts, statuslines, and nvim plugins use Nerd Font glyphs; without a Nerd
+Font they render as boxes. dvt installs fonts from a catalog pinned to Nerd
+Fonts v` + fonts.NerdFontsVersion + ` and checks that your terminal emulators use one.
+
+Examples:
+  dvt font get                   # List catalog fonts and whether they are installed
+  dvt font install JetBrainsMono # Install a Nerd Font for the current user
+  dvt font verify                # Check the configured emulator's font`,
+}
+
+// fontGetCmd lists the font catalog
+var fontGetCmd = &cobra.Command{
+	Use:     "get",
+	Aliases: []string{"list"},
+	Short:   "List catalog fonts and whether they are installed",
+	Long: `List the Nerd Fonts dvt can install, with the family name to configure in
+your terminal emulator and whether the font is installed.
+
+Examples:
+  dvt font get
+  dvt font get -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		platform, err := currentFontPlatform()
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("output")
+		return outputFonts(fonts.Catalog(), platform, format)
+	},
+}
+
+// fontInstallCmd installs a catalog font for the current user
+var fontInstallCmd = &cobra.Command{
+	Use:   "install <name>",
+	Short: "Install a Nerd Font for the current user",
+	Long: `Download a Nerd Font from the pinned release, verify it against the
+release's SHA-256 manifest, and install it for the current user:
+
+  macOS  ~/Library/Fonts/<name>
+  Linux  ~/.local/share/fonts/<name> (then fc-cache, when available)
+
+The name is a catalog name (JetBrainsMono) or family (JetBrainsMono Nerd Font).
+
+Examples:
+  dvt font install JetBrainsMono
+  dvt font install "FiraCode Nerd Font"
+  dvt font install Meslo --dry-run`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFonts,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		font, err := fonts.Get(args[0])
+		if err != nil {
+			return err
+		}
+		platform, err := currentFontPlatform()
+		if err != nil {
+			return err
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		if dryRun {
+			render.Infof("Would install %s from %s", font.Family, font.URL())
+			render.Plainf("  into %s/%s", platform.UserDir, font.Name)
+			return nil
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if installed := platform.Installed(font.Family); len(installed) > 0 && !force {
+			render.Infof("%s is already installed (%d files); use --force to reinstall", font.Family, len(installed))
+			return nil
+		}
+
+		render.Progressf("Installing %s (Nerd Fonts v%s)...", font.Family, fonts.NerdFontsVersion)
+		ctx, cancel := context.WithTimeout(cmd.Context(), fontInstallTimeout)
+		defer cancel()
+		files, err := platform.Install(ctx, &fetch.Fetcher{}, font)
+		if err != nil {
+			return fmt.Errorf("failed to install %s: %w", font.Name, err)
+		}
+		render.Successf("Installed %d font files to %s/%s", len(files), platform.UserDir, font.Name)
+		render.Info("Set your terminal emulator's font family to:")
+		render.Plainf("  %s Mono", font.Family)
+		return nil
+	},
+}
+
+// fontVerifyCmd checks that configured emulators use an installed Nerd Font
+var fontVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that terminal emulators use an installed Nerd Font",
+	Long: `Check the font family of your terminal emulator configuration: it must be
+a Nerd Font, and that font must be installed on this machine.
+
+Without --emulator, the 'terminal-emulator' default is checked, otherwise
+every enabled emulator. With none stored, the default font that
+'dvm terminal generate' writes is checked. --family checks a family directly.
+
+Examples:
+  dvt font verify
+  dvt font verify --emulator wezterm-default
+  dvt font verify --family "JetBrainsMono Nerd Font"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		platform, err := currentFontPlatform()
+		if err != nil {
+			return err
+		}
+
+		family, _ := cmd.Flags().GetString("family")
+		var checks []fontCheck
+		if family != "" {
+			checks = []fontCheck{{Source: "--family", Verification: fonts.Verification{Family: family}}}
+		} else {
+			name, _ := cmd.Flags().GetString("emulator")
+			if checks, err = emulatorFontChecks(cmd, name); err != nil {
+				return err
+			}
+		}
+
+		failed := 0
+		for i := range checks {
+			checks[i].Verification = platform.Verify(checks[i].Family)
+			if !checks[i].OK() {
+				failed++
+			}
+		}
+
+		format, _ := cmd.Flags().GetString("output")
+		if err := outputFontChecks(checks, format); err != nil {
+			return err
+		}
+		if failed > 0 {
+			for _, c := range checks {
+				if c.OK() {
+					continue
+				}
+				switch {
+				case !c.NerdFont:
+					render.Warningf("%s: %q is not a Nerd Font; pick one from 'dvt font get'", c.Source, c.Family)
+				case c.Catalog != "":
+					render.Warningf("%s: %q is not installed; run 'dvt font install %s'", c.Source, c.Family, c.Catalog)
+				default:
+					render.Warningf("%s: %q is not installed", c.Source, c.Family)
+				}
+			}
+			return errSilent
+		}
+		return nil
+	},
+}
+
+func init() {
+	fontCmd.AddCommand(fontGetCmd)
+	fontCmd.AddCommand(fontInstallCmd)
+	fontCmd.AddCommand(fontVerifyCmd)
+
+	fontGetCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	fontInstallCmd.Flags().Bool("dry-run", false, "Show what would be installed without installing")
+	fontInstallCmd.Flags().Bool("force", false, "Reinstall a font that is already installed")
+	fontVerifyCmd.Flags().String("emulator", "", "Stored emulator config to check")
+	fontVerifyCmd.Flags().String("family", "", "Font family to check instead of an emulator's")
+	fontVerifyCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+}
+
+// fontCheck is one font family to verify and where it is configured.
+type fontCheck struct {
+	Source             string `yaml:"source" json:"source"`
+	fonts.Verification `yaml:",inline"`
+}
+
+// currentFontPlatform returns the font locations of this machine.
+func currentFontPlatform() (fonts.Platform, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fonts.Platform{}, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return fonts.PlatformFor(runtime.GOOS, home, os.Getenv("XDG_DATA_HOME")), nil
+}
+
+// emulatorFontChecks returns the font families of the emulators to verify:
+// the named one, else the 'terminal-emulator' default, else every enabled
+// emulator. Without stored emulators the generator's default font is used.
+func emulatorFontChecks(cmd *cobra.Command, name string) ([]fontCheck, error) {
+	defaultCheck := []fontCheck{{Source: "default font", Verification: fonts.Verification{Family: emulatorgen.DefaultFontFamily}}}
+
+	dataStore, _ := cmd.Context().Value("dataStore").(*db.DataStore)
+	if dataStore == nil {
+		if name != "" {
+			return nil, fmt.Errorf("database not initialized - run 'dvt init' or check configuration")
+		}
+		return defaultCheck, nil
+	}
+	store, err := getEmulatorStore(cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	if name == "" {
+		if def, err := (*dataStore).GetDefault("terminal-emulator"); err == nil {
+			name = def
+		}
+	}
+	var emulators []*emulator.Emulator
+	if name != "" {
+		emu, err := store.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("emulator not found: %s", name)
+		}
+		emulators = []*emulator.Emulator{emu}
+	} else {
+		all, err := store.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list emulators: %w", err)
+		}
+		for _, emu := range all {
+			if emu.Enabled {
+				emulators = append(emulators, emu)
+			}
+		}
+	}
+	if len(emulators) == 0 {
+		return defaultCheck, nil
+	}
+
+	sort.Slice(emulators, func(i, j int) bool { return emulators[i].Name < emulators[j].Name })
+	checks := make([]fontCheck, 0, len(emulators))
+	for _, emu := range emulators {
+		font := emulatorgen.ResolveFont(emu.Config, emulatorgen.Font{})
+		checks = append(checks, fontCheck{Source: "emulator " + emu.Name, Verification: fonts.Verification{Family: font.Family}})
+	}
+	return checks, nil
+}
+
+// completeFonts completes catalog font names.
+func completeFonts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, f := range fonts.Catalog() {
+		names = append(names, f.Name+"\t"+f.Family)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// outputFonts outputs the catalog in the specified format
+func outputFonts(catalog []fonts.Font, platform fonts.Platform, format string) error {
+	switch format {
+	case "yaml", "json":
+		type fontStatus struct {
+			fonts.Font `yaml:",inline"`
+			Installed  bool `yaml:"installed" json:"installed"`
+		}
+		out := make([]fontStatus, 0, len(catalog))
+		for _, f := range catalog {
+			out = append(out, fontStatus{Font: f, Installed: len(platform.Installed(f.Family)) > 0})
+		}
+		return marshalFontOutput(out, format)
+	case "table", "":
+		tb := render.NewTableBuilder("NAME", "FAMILY", "INSTALLED", "DESCRIPTION")
+		for _, f := range catalog {
+			installed := "false"
+			if len(platform.Installed(f.Family)) > 0 {
+				installed = "true"
+			}
+			tb.AddRow(f.Name, f.Family, installed, f.Summary)
+		}
+		return render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable})
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// outputFontChecks outputs verification results in the specified format
+func outputFontChecks(checks []fontCheck, format string) error {
+	switch format {
+	case "yaml", "json":
+		return marshalFontOutput(checks, format)
+	case "table", "":
+		tb := render.NewTableBuilder("SOURCE", "FAMILY", "NERD FONT", "INSTALLED")
+		for _, c := range checks {
+			installed := "false"
+			if n := len(c.Installed); n > 0 {
+				installed = fmt.Sprintf("true (%d files)", n)
+			}
+			tb.AddRow(c.Source, c.Family, fmt.Sprint(c.NerdFont), installed)
+		}
+		return render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable})
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+func marshalFontOutput(v any, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fmt.Print(strings.TrimLeft(string(data), "\n"))
+	return nil
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"os"
 
+	"devopsmaestro/pkg/interactive"
+
 	"github.com/rmkohlman/MaestroSDK/render"
 )
 
@@ -15,10 +18,11 @@ var (
 
 func main() {
 	if err := Execute(); err != nil {
-		// errSilent means the command already displayed the error via render
-		if err.Error() != "" {
+		// errSilent means the command already displayed the error via render,
+		// and a declined prompt already printed "Aborted"
+		if err.Error() != "" && !errors.Is(err, interactive.ErrAborted) {
 			render.ErrorToStderr(err.Error())
 		}
-		os.Exit(1)
+		os.Exit(interactive.ExitCode(err))
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"devopsmaestro/db"
+	"devopsmaestro/pkg/interactive"
 	"devopsmaestro/pkg/resource/handlers"
 	"devopsmaestro/pkg/source"
 	"devopsmaestro/pkg/terminalbridge/promptgen"
@@ -218,14 +219,8 @@ func promptResourceDelete(cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 
 	// Confirm deletion unless --force is used
-	if !force {
-		fmt.Printf("Delete prompt '%s'? (y/N): ", name)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			render.Info("Aborted")
-			return nil
-		}
+	if _, err := interactive.Confirm(fmt.Sprintf("Delete prompt '%s'?", name), force); err != nil {
+		return err
 	}
 
 	// Get handler and delete
@@ -414,14 +409,8 @@ func promptResourceSet(cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 
 	// Confirm setting unless --force is used
-	if !force {
-		fmt.Printf("Set '%s' as the active prompt? (y/N): ", name)
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" {
-			render.Info("Aborted")
-			return nil
-		}
+	if _, err := interactive.Confirm(fmt.Sprintf("Set '%s' as the active prompt?", name), force); err != nil {
+		return err
 	}
 
 	// Validate config generation for the prompt (delegates to promptgen)
@@ -3,6 +3,8 @@ package main
 import (
 	"context"
 	"devopsmaestro/db"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/tablestyle"
 	"fmt"
 	"io"
 	"io/fs"
@@ -117,6 +119,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable debug logging")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to file (JSON format)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	interactive.AddFlags(rootCmd.PersistentFlags())
 
 	// Initialize logging, color provider, and database before any command runs
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
@@ -131,6 +134,7 @@ func init() {
 		if err != nil {
 			slog.Warn("using default colors", "error", err)
 		}
+		tablestyle.Register(colors.FromContextOrDefault(ctx), noColor)
 		cmd.SetContext(ctx)
 
 		// Check if this is a command that doesn't need database
@@ -205,6 +209,7 @@ func init() {
 	rootCmd.AddCommand(shellCmd)
 	rootCmd.AddCommand(profileCmd)
 	rootCmd.AddCommand(emulatorCmd)
+	rootCmd.AddCommand(fontCmd)
 	rootCmd.AddCommand(weztermCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(toolConfigCmd)
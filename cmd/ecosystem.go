@@ -13,7 +13,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var ecosystemDescription string
+var (
+	ecosystemDescription     string
+	ecosystemNvimPackage     string
+	ecosystemTerminalPackage string
+)
 
 // Dry-run flags for ecosystem commands
 var (
@@ -38,7 +42,10 @@ Examples:
   dvm create eco my-platform           # Short form
   
   # Create with description
-  dvm create ecosystem my-platform --description "Main development platform"`,
+  dvm create ecosystem my-platform --description "Main development platform"
+
+  # Declare default packages inherited by every domain, app, and workspace
+  dvm create ecosystem my-platform --nvim-package full-stack --terminal-package starship`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ecosystemName := args[0]
@@ -54,6 +61,7 @@ Examples:
 			if ecosystemDescription != "" {
 				render.Plain(fmt.Sprintf("  description: %s", ecosystemDescription))
 			}
+			renderDryRunPackages(ecosystemNvimPackage, ecosystemTerminalPackage)
 			return nil
 		}
 
@@ -78,6 +86,8 @@ Examples:
 
 		// Create ecosystem using handler
 		ecosystem := handlers.NewEcosystemFromModel(ecosystemName, ecosystemDescription)
+		ecosystem.NvimPackage = nullString(ecosystemNvimPackage)
+		ecosystem.TerminalPackage = nullString(ecosystemTerminalPackage)
 		if err := ds.CreateEcosystem(ecosystem); err != nil {
 			return fmt.Errorf("failed to create ecosystem: %w", err)
 		}
@@ -181,6 +191,10 @@ Examples:
 			return errSilent
 		}
 
+		if err := checkNotArchived(ds, models.ArchiveKindEcosystem, ecosystem.ID, ecosystemName); err != nil {
+			return err
+		}
+
 		// Handle --export flag: print export statement and return
 		exportFlag, _ := cmd.Flags().GetBool("export")
 		if exportFlag {
@@ -230,6 +244,15 @@ func getEcosystems(cmd *cobra.Command) error {
 
 	// Get active ecosystem for highlighting
 	ds, _ := getDataStore(cmd)
+	archived, err := loadArchivedIDs(ds)
+	if err != nil {
+		return err
+	}
+	if archived != nil {
+		resources = withoutArchived(resources, archived.Ecosystems, func(r resource.Resource) int {
+			return r.(*handlers.EcosystemResource).Ecosystem().ID
+		})
+	}
 	var activeEcosystemID *int
 	if ds != nil {
 		dbCtx, _ := ds.GetContext()
@@ -239,20 +262,20 @@ func getEcosystems(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		handlers.RegisterAll()
 		if len(resources) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
 		list, err := resource.BuildList(ctx, resources)
 		if err != nil {
 			return fmt.Errorf("failed to build resource list: %w", err)
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	if len(resources) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No ecosystems found",
 			EmptyHints:   []string{"dvm create ecosystem <name>"},
@@ -343,7 +366,7 @@ func getEcosystems(cmd *cobra.Command) error {
 		renderFormat = "table"
 	}
 
-	return render.OutputWith(renderFormat, tableData, render.Options{
+	return outputList(renderFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -363,7 +386,7 @@ func getEcosystem(cmd *cobra.Command, name string) error {
 	ecosystem := res.(*handlers.EcosystemResource).Ecosystem()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		ds, _ := getDataStore(cmd)
 		var domainNames []string
 		if ds != nil {
@@ -373,7 +396,7 @@ func getEcosystem(cmd *cobra.Command, name string) error {
 				domainNames[j] = d.Name
 			}
 		}
-		return render.OutputWith(getOutputFormat, ecosystem.ToYAML(domainNames), render.Options{})
+		return outputList(getOutputFormat, ecosystem.ToYAML(domainNames), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -403,7 +426,7 @@ func getEcosystem(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Created", Value: ecosystem.CreatedAt.Format("2006-01-02 15:04:05")},
 	)
 
-	err = render.OutputWith(getOutputFormat, kvData, render.Options{
+	err = outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Ecosystem Details",
 	})
@@ -520,6 +543,8 @@ func init() {
 
 	// Ecosystem creation flags
 	createEcosystemCmd.Flags().StringVar(&ecosystemDescription, "description", "", "Ecosystem description")
+	createEcosystemCmd.Flags().StringVar(&ecosystemNvimPackage, "nvim-package", "", "Default nvim package for everything in the ecosystem")
+	createEcosystemCmd.Flags().StringVar(&ecosystemTerminalPackage, "terminal-package", "", "Default terminal package for everything in the ecosystem")
 	AddDryRunFlag(createEcosystemCmd, &createEcosystemDryRun)
 
 	// Use ecosystem dry-run
@@ -538,7 +563,7 @@ func getActiveEcosystem(ds db.DataStore) (*models.Ecosystem, error) {
 	}
 
 	if ctx.ActiveEcosystemID == nil {
-		return nil, fmt.Errorf("no active ecosystem set")
+		return nil, db.NewErrNoActiveContext("ecosystem")
 	}
 
 	ecosystem, err := ds.GetEcosystemByID(*ctx.ActiveEcosystemID)
@@ -11,16 +11,32 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// editCmd is the root 'edit' command for kubectl-style resource editing
-// Usage: dvm edit nvim plugin <name>
+// editCmd edits any resource kind, kubectl-style, and is the parent of the
+// 'nvim' subcommands
+// Usage: dvm edit <kind> <name>
 var editCmd = &cobra.Command{
-	Use:   "edit",
+	Use:   "edit <kind> <name>",
 	Short: "Edit a resource in your default editor",
 	Long: `Edit a resource definition in your default editor ($EDITOR).
-After saving and closing the editor, changes are automatically applied.
+
+The resource is opened as YAML. When you save and close the editor, the
+change is checked the way 'dvm apply' checks it (unknown fields, admission
+checks) and applied. If the check or the apply fails, the editor reopens with
+the errors at the top of the file; exit without saving, or save the file
+unchanged, to give up; line numbers in the errors count from the document's
+first line, below the comments. Saving an empty file cancels the edit. The
+kind and name cannot be changed.
 
 Examples:
+  dvm edit workspace dev -a api
+  dvm edit app api -d backend
+  dvm edit ecosystem prod
+  dvm edit nvim-plugin telescope
+  EDITOR="code --wait" dvm edit registry zot
   dvm edit nvim plugin telescope    # Edit nvim plugin in $EDITOR`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeResourceKinds,
+	RunE:              runEditResource,
 }
 
 // editNvimCmd is the 'nvim' subcommand under 'edit'
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var editFlags HierarchyFlags
+
+func init() {
+	AddHierarchyFlags(editCmd, &editFlags)
+	editCmd.Flags().Bool("validate", true, "Reject fields the resource kind doesn't define (--validate=false to skip)")
+}
+
+// resourceKindAliases are the short kind names dvm get and describe accept.
+var resourceKindAliases = map[string]string{
+	"ws":    handlers.KindWorkspace,
+	"eco":   handlers.KindEcosystem,
+	"dom":   handlers.KindDomain,
+	"sys":   handlers.KindSystem,
+	"repo":  handlers.KindGitRepo,
+	"gr":    handlers.KindGitRepo,
+	"crd":   handlers.KindCRD,
+	"reg":   handlers.KindRegistry,
+	"cred":  handlers.KindCredential,
+	"theme": handlers.KindNvimTheme,
+}
+
+// resolveResourceKind resolves a kind given as PascalCase (NvimPlugin),
+// kebab-case (nvim-plugin), lowercase (gitrepo), or an alias (ws).
+func resolveResourceKind(kind string) (string, error) {
+	if k, ok := resourceKindAliases[kind]; ok {
+		return k, nil
+	}
+	want := strings.ToLower(strings.ReplaceAll(kind, "-", ""))
+	for _, k := range resource.RegisteredKinds() {
+		if strings.ToLower(k) == want {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown resource kind %q", kind)
+}
+
+func runEditResource(cmd *cobra.Command, args []string) error {
+	validate, _ := cmd.Flags().GetBool("validate")
+	handlers.SetStrict(validate)
+
+	kind, err := resolveResourceKind(args[0])
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := resource.Context{DataStore: ds}
+
+	res, err := getStoredResource(ctx, ds, kind, args[1], editFlags)
+	if err != nil {
+		return err
+	}
+	original, err := resource.ToYAML(res)
+	if err != nil {
+		return fmt.Errorf("failed to render %s '%s' as YAML: %w", kind, res.GetName(), err)
+	}
+
+	applied, err := editResource(ctx, kind, res.GetName(), original, openEditor)
+	if err != nil {
+		return err
+	}
+	if applied == nil {
+		render.Info("Edit cancelled, no changes made.")
+		return nil
+	}
+	render.Success(fmt.Sprintf("%s '%s' edited", kind, applied.GetName()))
+	reportRebuilds(applied)
+	return nil
+}
+
+// getStoredResource loads a resource to edit or patch. Hierarchy kinds are
+// found the way dvm describe finds them, so --ecosystem, --domain, and --app
+// narrow a name that exists in several places; other kinds go through their
+// handler.
+func getStoredResource(ctx resource.Context, ds db.DataStore, kind, name string, flags HierarchyFlags) (resource.Resource, error) {
+	switch kind {
+	case handlers.KindWorkspace:
+		wh, err := resolveSessionWorkspace(ds, flags, name)
+		if err != nil {
+			return nil, err
+		}
+		domName, ecoName := "", ""
+		if wh.Domain != nil {
+			domName = wh.Domain.Name
+		}
+		if wh.Ecosystem != nil {
+			ecoName = wh.Ecosystem.Name
+		}
+		return handlers.NewWorkspaceResource(wh.Workspace, wh.App.Name, domName, gitRepoNameOf(ds, wh.Workspace.GitRepoID.Valid, wh.Workspace.GitRepoID.Int64), ecoName), nil
+
+	case handlers.KindApp:
+		app, err := findDescribeApp(ds, name, flags)
+		if err != nil {
+			return nil, err
+		}
+		domName, ecoName, sysName := "", "", ""
+		if app.DomainID.Valid {
+			if dom, err := ds.GetDomainByID(int(app.DomainID.Int64)); err == nil && dom != nil {
+				domName = dom.Name
+				ecoName = ecosystemNameOf(ds, dom)
+			}
+		}
+		if app.SystemID.Valid {
+			if sys, err := ds.GetSystemByID(int(app.SystemID.Int64)); err == nil && sys != nil {
+				sysName = sys.Name
+			}
+		}
+		return handlers.NewAppResource(app, domName, ecoName, gitRepoNameOf(ds, app.GitRepoID.Valid, app.GitRepoID.Int64), sysName), nil
+
+	case handlers.KindDomain:
+		dom, err := findDescribeDomain(ds, name, flags)
+		if err != nil {
+			return nil, err
+		}
+		return handlers.NewDomainResource(dom, ecosystemNameOf(ds, dom)), nil
+
+	case handlers.KindEcosystem:
+		eco, err := ds.GetEcosystemByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("ecosystem '%s' not found: %w", name, err)
+		}
+		return handlers.NewEcosystemResource(eco), nil
+	}
+
+	res, err := resource.Get(ctx, kind, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s' not found: %w", kind, name, err)
+	}
+	return res, nil
+}
+
+func ecosystemNameOf(ds db.DataStore, dom *models.Domain) string {
+	if !dom.EcosystemID.Valid {
+		return ""
+	}
+	eco, err := ds.GetEcosystemByID(int(dom.EcosystemID.Int64))
+	if err != nil || eco == nil {
+		return ""
+	}
+	return eco.Name
+}
+
+func gitRepoNameOf(ds db.DataStore, valid bool, id int64) string {
+	if !valid {
+		return ""
+	}
+	repo, err := ds.GetGitRepoByID(id)
+	if err != nil || repo == nil {
+		return ""
+	}
+	return repo.Name
+}
+
+const editHeader = `# Please edit the object below. Lines beginning with a '#' will be ignored,
+# and an empty file will abort the edit. If the change can't be applied,
+# this file will be reopened with the errors.
+#
+`
+
+// openEditor opens path in $EDITOR (vi when unset), which may include
+// arguments, such as "code --wait". Tests replace it.
+var openEditor = func(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	c := exec.Command(editor[0], append(editor[1:], path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	return nil
+}
+
+// editResource opens original in an editor until the saved document passes
+// validation and applies, returning the applied resource. It returns nil
+// when the user saves the document unchanged or empty. When the user gives
+// up after an error, the edited file is kept and its path reported.
+func editResource(ctx resource.Context, kind, name string, original []byte, edit func(path string) error) (resource.Resource, error) {
+	f, err := os.CreateTemp("", fmt.Sprintf("dvm-edit-%s-*.yaml", strings.ToLower(kind)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := f.Name()
+	f.Close()
+
+	content := append([]byte(editHeader), original...)
+	var lastBody []byte
+	var lastErr error
+	for {
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write temp file: %w", err)
+		}
+		if err := edit(path); err != nil {
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w; your changes are saved in %s", err, path)
+			}
+			os.Remove(path)
+			return nil, err
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read edited file: %w", err)
+		}
+
+		body := stripEditComments(edited)
+		if len(bytes.TrimSpace(body)) == 0 {
+			os.Remove(path)
+			return nil, nil
+		}
+		if bytes.Equal(bytes.TrimSpace(body), bytes.TrimSpace(original)) {
+			os.Remove(path)
+			return nil, nil
+		}
+		if lastErr != nil && bytes.Equal(body, lastBody) {
+			return nil, fmt.Errorf("edit cancelled, no valid changes were saved; your changes are in %s:\n%w", path, lastErr)
+		}
+
+		err = checkEdit(ctx, kind, name, body)
+		if err == nil {
+			var res resource.Resource
+			if res, err = resource.Apply(ctx, body, "edit"); err == nil {
+				os.Remove(path)
+				return res, nil
+			}
+		}
+		lastBody, lastErr = body, err
+		content = append(append(commentLines(err), editHeader...), body...)
+	}
+}
+
+// checkEdit rejects documents that rename the resource or change its kind,
+// then runs the checks dvm apply makes.
+func checkEdit(ctx resource.Context, kind, name string, data []byte) error {
+	if err := checkIdentity(kind, name, data); err != nil {
+		return err
+	}
+	return handlers.Validate(ctx, data)
+}
+
+// checkIdentity rejects an edited or patched document whose kind or name
+// differs from the stored resource's.
+func checkIdentity(kind, name string, data []byte) error {
+	var head struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal(data, &head); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	if head.Kind != kind {
+		return fmt.Errorf("kind cannot be changed from %s to %q", kind, head.Kind)
+	}
+	if head.Metadata.Name != name {
+		return fmt.Errorf("metadata.name cannot be changed from %q to %q; use 'dvm apply' to create a new %s", name, head.Metadata.Name, kind)
+	}
+	return nil
+}
+
+// stripEditComments drops the lines that start with '#'. Comments inside
+// the document are indented, so only the header and error lines go.
+func stripEditComments(data []byte) []byte {
+	var out []byte
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte("#")) {
+			out = append(out, line...)
+		}
+	}
+	return out
+}
+
+// commentLines formats err as the comment block shown above a reopened
+// document.
+func commentLines(err error) []byte {
+	var sb strings.Builder
+	sb.WriteString("# The edit could not be applied:\n")
+	for _, line := range strings.Split(err.Error(), "\n") {
+		sb.WriteString("#   " + line + "\n")
+	}
+	sb.WriteString("#\n")
+	return []byte(sb.String())
+}
+
+// completeResourceKinds provides shell completion for the kind argument.
+func completeResourceKinds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return resource.RegisteredKinds(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedEditor returns an editor that replaces the document on each open
+// with the next of edits, recording what it was shown.
+func scriptedEditor(t *testing.T, edits ...func(shown string) string) (func(string) error, *[]string) {
+	t.Helper()
+	var shown []string
+	return func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		shown = append(shown, string(data))
+		require.Less(t, len(shown)-1, len(edits), "editor opened too many times")
+		return os.WriteFile(path, []byte(edits[len(shown)-1](string(data))), 0o600)
+	}, &shown
+}
+
+func setupEditTest(t *testing.T) (resource.Context, *db.MockDataStore, []byte) {
+	t.Helper()
+	t.Setenv("TMPDIR", t.TempDir())
+	handlers.RegisterAll()
+	handlers.SetStrict(true)
+	t.Cleanup(func() { handlers.SetStrict(false) })
+
+	store := db.NewMockDataStore()
+	require.NoError(t, store.CreateEcosystem(&models.Ecosystem{Name: "prod"}))
+	eco, err := store.GetEcosystemByName("prod")
+	require.NoError(t, err)
+	original, err := resource.ToYAML(handlers.NewEcosystemResource(eco))
+	require.NoError(t, err)
+	return resource.Context{DataStore: store}, store, original
+}
+
+func TestEditResource_AppliesChange(t *testing.T) {
+	ctx, store, original := setupEditTest(t)
+	editor, shown := scriptedEditor(t, func(doc string) string {
+		return strings.Replace(doc, "spec: {}", "spec:\n  description: Production", 1)
+	})
+
+	res, err := editResource(ctx, handlers.KindEcosystem, "prod", original, editor)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.True(t, strings.HasPrefix((*shown)[0], editHeader), "document should open with the edit header")
+
+	eco, err := store.GetEcosystemByName("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "Production", eco.Description.String)
+}
+
+func TestEditResource_ReopensWithErrors(t *testing.T) {
+	ctx, store, original := setupEditTest(t)
+	editor, shown := scriptedEditor(t,
+		func(doc string) string { return strings.Replace(doc, "spec: {}", "spec:\n  descripton: Production", 1) },
+		func(doc string) string { return strings.Replace(doc, "  descripton:", "  description:", 1) },
+	)
+
+	res, err := editResource(ctx, handlers.KindEcosystem, "prod", original, editor)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Len(t, *shown, 2)
+	assert.Contains(t, (*shown)[1], "# The edit could not be applied:")
+	assert.Contains(t, (*shown)[1], `#     - spec.descripton (line 6, column 3)`)
+
+	eco, err := store.GetEcosystemByName("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "Production", eco.Description.String)
+}
+
+func TestEditResource_GivesUpOnUnchangedRetry(t *testing.T) {
+	ctx, _, original := setupEditTest(t)
+	keep := func(doc string) string { return doc }
+	editor, _ := scriptedEditor(t,
+		func(doc string) string { return strings.Replace(doc, "name: prod", "name: staging", 1) },
+		keep,
+	)
+
+	res, err := editResource(ctx, handlers.KindEcosystem, "prod", original, editor)
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "no valid changes were saved")
+	assert.Contains(t, err.Error(), `metadata.name cannot be changed from "prod" to "staging"`)
+}
+
+func TestEditResource_NoChanges(t *testing.T) {
+	ctx, _, original := setupEditTest(t)
+
+	for name, edit := range map[string]func(string) string{
+		"unchanged": func(doc string) string { return doc },
+		"emptied":   func(string) string { return "# nothing\n" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			editor, _ := scriptedEditor(t, edit)
+			res, err := editResource(ctx, handlers.KindEcosystem, "prod", original, editor)
+			require.NoError(t, err)
+			assert.Nil(t, res)
+		})
+	}
+}
+
+func TestResolveEditKind(t *testing.T) {
+	handlers.RegisterAll()
+	for in, want := range map[string]string{
+		"workspace":   handlers.KindWorkspace,
+		"ws":          handlers.KindWorkspace,
+		"NvimPlugin":  handlers.KindNvimPlugin,
+		"nvim-plugin": handlers.KindNvimPlugin,
+		"gitrepo":     handlers.KindGitRepo,
+	} {
+		got, err := resolveResourceKind(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := resolveResourceKind("widget")
+	assert.Error(t, err)
+}
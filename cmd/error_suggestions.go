@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
 )
 
 // ErrorWithSuggestion wraps an error message with one or more actionable suggestions.
@@ -129,7 +136,88 @@ func SuggestWorkspaceNotBuilt() []string {
 // SuggestAmbiguousWorkspace returns suggestions for ambiguous workspace matches.
 func SuggestAmbiguousWorkspace() []string {
 	return []string{
-		"Narrow your selection with additional flags: -e, -d, -a, -w",
+		"Narrow your selection with additional flags: -e, -d, -a, -w, or a path like ecosystem/domain/app/workspace",
 		"List all workspaces: dvm get workspaces --all",
 	}
 }
+
+// --- Suggestions derived from typed errors ---
+
+// resourceListCommands maps db resource names to the command that lists them,
+// for ErrNotFound suggestions.
+var resourceListCommands = map[string]string{
+	"ecosystem":  "dvm get ecosystems",
+	"domain":     "dvm get domains",
+	"system":     "dvm get systems",
+	"app":        "dvm get apps",
+	"workspace":  "dvm get workspaces",
+	"credential": "dvm get credentials",
+	"git repo":   "dvm get gitrepos",
+	"registry":   "dvm get registries",
+	"plugin":     "dvm get nvim plugins",
+	"package":    "dvm get nvim packages",
+	"theme":      "dvm get nvim themes",
+}
+
+// suggestionsForError returns remediation hints for the typed errors that
+// the db and operators layers return, or nil for other errors.
+func suggestionsForError(err error) []string {
+	var notFound *db.ErrNotFound
+	var conflict *db.ErrConflict
+	var noContext *db.ErrNoActiveContext
+	var noRuntime *operators.ErrRuntimeUnavailable
+
+	switch {
+	case errors.As(err, &noContext):
+		return suggestNoActiveContext(noContext.Resource)
+	case errors.As(err, &notFound):
+		listCmd, ok := resourceListCommands[notFound.Resource]
+		if !ok {
+			return []string{fmt.Sprintf("Check the spelling of %q", fmt.Sprint(notFound.Key))}
+		}
+		return SuggestResourceNotFound(notFound.Resource, fmt.Sprint(notFound.Key), listCmd)
+	case errors.As(err, &conflict):
+		return []string{
+			fmt.Sprintf("Choose a different name for the %s", conflict.Resource),
+			fmt.Sprintf("Or change the existing %s: dvm apply -f <file>", conflict.Resource),
+		}
+	case db.IsUniqueViolation(err):
+		return []string{"A resource with the same unique value already exists; choose a different one"}
+	case errors.As(err, &noRuntime):
+		if noRuntime.Platform == "" {
+			return append([]string{noRuntime.Hint}, SuggestNoContainerRuntime()...)
+		}
+		return []string{noRuntime.Hint, "Check which runtimes are reachable: dvm system info"}
+	case errors.Is(err, context.DeadlineExceeded):
+		return []string{"Retry with a longer limit, e.g. --timeout 10m, or --timeout 0 for none"}
+	}
+	return nil
+}
+
+// suggestNoActiveContext returns the suggestions for a missing active
+// resource, including the DVM_* variable that can stand in for it.
+func suggestNoActiveContext(resource string) []string {
+	var suggestions []string
+	switch resource {
+	case "app":
+		suggestions = SuggestNoActiveApp()
+	case "workspace":
+		suggestions = SuggestNoActiveWorkspace()
+	case "ecosystem":
+		suggestions = SuggestNoActiveEcosystem()
+	case "domain":
+		suggestions = SuggestNoActiveDomain()
+	default:
+		return []string{fmt.Sprintf("Set active %s: dvm use %s <name>", resource, resource)}
+	}
+	return append(suggestions, fmt.Sprintf("Or set DVM_%s", strings.ToUpper(resource)))
+}
+
+// renderError displays a command error followed by the remediation hints
+// for its type, if any.
+func renderError(err error) {
+	render.Errorf("%s", err)
+	if suggestions := suggestionsForError(err); len(suggestions) > 0 {
+		render.Info(FormatSuggestions(suggestions...))
+	}
+}
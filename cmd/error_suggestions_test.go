@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
+	"devopsmaestro/db"
+	"devopsmaestro/operators"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -210,3 +216,58 @@ func TestFormatSuggestions_UsableWithRender(t *testing.T) {
 		assert.True(t, strings.HasPrefix(line, "  → "), "suggestion line should be prefixed with arrow: %q", line)
 	}
 }
+
+func TestSuggestionsForError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantContain []string
+	}{
+		{
+			name:        "no active app",
+			err:         fmt.Errorf("resolve: %w", db.NewErrNoActiveContext("app")),
+			wantContain: []string{"dvm use app <name>", "dvm get apps", "DVM_APP"},
+		},
+		{
+			name:        "no active system",
+			err:         db.NewErrNoActiveContext("system"),
+			wantContain: []string{"dvm use system <name>"},
+		},
+		{
+			name:        "workspace not found",
+			err:         fmt.Errorf("failed to get workspace: %w", db.NewErrNotFound("workspace", "dev")),
+			wantContain: []string{`"dev"`, "dvm get workspaces"},
+		},
+		{
+			name:        "conflict",
+			err:         fmt.Errorf("failed to create ecosystem: %w", db.NewErrConflict("ecosystem", "prod", nil)),
+			wantContain: []string{"different name for the ecosystem", "dvm apply"},
+		},
+		{
+			name:        "no runtime detected",
+			err:         &operators.ErrRuntimeUnavailable{Hint: "Install one of: OrbStack, Colima"},
+			wantContain: []string{"Install one of: OrbStack, Colima", "Ensure the runtime daemon is running"},
+		},
+		{
+			name:        "runtime not running",
+			err:         &operators.ErrRuntimeUnavailable{Platform: "Colima", Hint: "Start Colima with: colima start", Err: errors.New("dial failed")},
+			wantContain: []string{"colima start", "dvm system info"},
+		},
+		{
+			name:        "timeout",
+			err:         fmt.Errorf("failed to list workspaces: %w", context.DeadlineExceeded),
+			wantContain: []string{"--timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(suggestionsForError(tt.err), "\n")
+			for _, want := range tt.wantContain {
+				assert.Contains(t, got, want)
+			}
+		})
+	}
+
+	assert.Nil(t, suggestionsForError(errors.New("plain failure")), "untyped errors have no suggestions")
+}
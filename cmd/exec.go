@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+// execFlags holds the hierarchy flags for the exec command
+var execFlags HierarchyFlags
+
+// execCmd runs a command inside a running workspace container
+var execCmd = &cobra.Command{
+	Use:   "exec [workspace] -- <command> [args...]",
+	Short: "Run a command in a workspace container",
+	Long: `Run a command inside a running workspace container, with the same
+environment as 'dvm attach'.
+
+The workspace is the active one unless a name or hierarchy flags are given.
+A bare name is looked up in the active app first, then across all apps
+(ecosystem/app are inferred when unambiguous).
+
+A TTY is allocated when stdin is a terminal, so interactive tools work and
+output can still be piped. The command's exit status becomes dvm's.
+
+Examples:
+  dvm exec -- go test ./...               # Active workspace
+  dvm exec staging -- make lint           # Workspace by name
+  dvm exec -a portal -w dev -- ls -la     # Explicit hierarchy
+  dvm exec --workdir /workspace/api -- go build ./...
+  dvm exec dev -- cat go.mod | grep module`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		if dash < 0 {
+			return fmt.Errorf("missing command: use 'dvm exec [workspace] -- <command>'")
+		}
+		if dash > 1 {
+			return fmt.Errorf("expected at most one workspace before --, got %d", dash)
+		}
+		if len(args) == dash {
+			return fmt.Errorf("missing command after --")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dash := cmd.ArgsLenAtDash()
+		name := ""
+		if dash == 1 {
+			name = args[0]
+		}
+		workDir, _ := cmd.Flags().GetString("workdir")
+		return runExec(cmd, name, args[dash:], workDir)
+	},
+}
+
+func runExec(cmd *cobra.Command, name string, command []string, workDir string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+
+	wh, err := resolveSessionWorkspace(ds, execFlags, name)
+	if err != nil {
+		renderError(err)
+		return errSilent
+	}
+	slog.Debug("exec target resolved", "workspace", wh.FullPath(), "command", command)
+
+	// Exit errors pass through untouched so Execute can use the exit status
+	return runWorkspaceSession(cmd.Context(), ds, wh, command, workDir)
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	AddHierarchyFlags(execCmd, &execFlags)
+	execCmd.Flags().String("workdir", "", "Working directory inside the container")
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSessionMock builds one ecosystem/domain with two apps: "api" (workspaces
+// dev, staging) and "web" (workspace dev).
+func newSessionMock(t *testing.T) *db.MockDataStore {
+	t.Helper()
+	mock := db.NewMockDataStore()
+	mock.Ecosystems["eco"] = &models.Ecosystem{ID: 1, Name: "eco"}
+	mock.Domains[1] = &models.Domain{ID: 1, Name: "dom", EcosystemID: sql.NullInt64{Int64: 1, Valid: true}}
+	mock.Apps[1] = &models.App{ID: 1, Name: "api", DomainID: sql.NullInt64{Int64: 1, Valid: true}}
+	mock.Apps[2] = &models.App{ID: 2, Name: "web", DomainID: sql.NullInt64{Int64: 1, Valid: true}}
+	mock.Workspaces[1] = &models.Workspace{ID: 1, AppID: 1, Name: "dev"}
+	mock.Workspaces[2] = &models.Workspace{ID: 2, AppID: 1, Name: "staging"}
+	mock.Workspaces[3] = &models.Workspace{ID: 3, AppID: 2, Name: "dev"}
+	mock.Context = nil
+	return mock
+}
+
+func TestResolveSessionWorkspace(t *testing.T) {
+	t.Setenv("DVM_APP", "")
+	t.Setenv("DVM_WORKSPACE", "")
+
+	t.Run("unique bare name resolves anywhere", func(t *testing.T) {
+		wh, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{}, "staging")
+		require.NoError(t, err)
+		assert.Equal(t, 2, wh.Workspace.ID)
+	})
+
+	t.Run("ambiguous bare name without active app", func(t *testing.T) {
+		_, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{}, "dev")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ambiguous")
+	})
+
+	t.Run("bare name prefers the active app", func(t *testing.T) {
+		mock := newSessionMock(t)
+		mock.Context = &models.Context{ID: 1, ActiveAppID: intPtr(2)}
+		wh, err := resolveSessionWorkspace(mock, HierarchyFlags{}, "dev")
+		require.NoError(t, err)
+		assert.Equal(t, 3, wh.Workspace.ID)
+	})
+
+	t.Run("hierarchy flags", func(t *testing.T) {
+		wh, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{App: "api"}, "dev")
+		require.NoError(t, err)
+		assert.Equal(t, 1, wh.Workspace.ID)
+	})
+
+	t.Run("active context", func(t *testing.T) {
+		mock := newSessionMock(t)
+		mock.Context = &models.Context{ID: 1, ActiveAppID: intPtr(1), ActiveWorkspaceID: intPtr(2)}
+		wh, err := resolveSessionWorkspace(mock, HierarchyFlags{}, "")
+		require.NoError(t, err)
+		assert.Equal(t, "staging", wh.Workspace.Name)
+	})
+
+	t.Run("conflicting name and flag", func(t *testing.T) {
+		_, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{Workspace: "dev"}, "staging")
+		assert.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{}, "prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "workspace=prod")
+	})
+}
+
+func TestExecCmd_Args(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "command only", args: []string{"--", "ls"}},
+		{name: "workspace and command", args: []string{"dev", "--", "ls", "-la"}},
+		{name: "no dash", args: []string{"ls"}, wantErr: "missing command"},
+		{name: "nothing after dash", args: []string{"dev", "--"}, wantErr: "missing command after --"},
+		{name: "two workspaces", args: []string{"a", "b", "--", "ls"}, wantErr: "at most one workspace"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &cobra.Command{Use: "exec", Args: execCmd.Args, RunE: func(*cobra.Command, []string) error { return nil }}
+			c.SetArgs(tt.args)
+			c.SilenceErrors = true
+			c.SilenceUsage = true
+			err := c.Execute()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatLastAttached(t *testing.T) {
+	lastAttached := map[int]time.Time{1: time.Now().Add(-90 * time.Minute)}
+	assert.Equal(t, "1h ago", formatLastAttached(lastAttached, 1))
+	assert.Equal(t, "never", formatLastAttached(lastAttached, 2))
+	assert.Equal(t, "never", formatLastAttached(nil, 1))
+}
@@ -1,12 +1,18 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
 
 // AddOutputFlag registers the standard -o/--output flag on a command.
 // Use this for commands that support table, yaml, json, etc. output formats.
 // The default value is the format used when --output is not specified.
+// Unknown formats are rejected when flags are parsed.
 func AddOutputFlag(cmd *cobra.Command, defaultVal string) {
-	cmd.Flags().StringP("output", "o", defaultVal, "Output format (table|yaml|json)")
+	cmd.Flags().VarP(newOutputFormatValue(new(string), defaultVal), "output", "o", outputFormatUsage)
+	_ = cmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 }
 
 // AddForceConfirmFlag registers the --force flag for skipping confirmation prompts.
@@ -26,3 +32,10 @@ func AddDryRunFlag(cmd *cobra.Command, dest *bool) {
 func AddAllFlag(cmd *cobra.Command, description string) {
 	cmd.Flags().BoolP("all", "A", false, description)
 }
+
+// AddWatchFlags registers --watch and --interval for commands that can redraw
+// their output until interrupted. See runWatch.
+func AddWatchFlags(cmd *cobra.Command, watch *bool, interval *time.Duration) {
+	cmd.Flags().BoolVar(watch, "watch", false, "Redraw until interrupted, highlighting rows that changed")
+	cmd.Flags().DurationVar(interval, "interval", 5*time.Second, "Refresh interval for --watch")
+}
@@ -23,13 +23,14 @@ type HierarchyFlags struct {
 //   - `-d, --domain`    - Filter by domain name
 //   - `-s, --system`    - Filter by system name
 //   - `-a, --app`       - Filter by app name
-//   - `-w, --workspace` - Filter by workspace name
+//   - `-w, --workspace` - Filter by workspace name, or give its path
+//     (app/ws, domain/app/ws, ecosystem/domain/app/ws)
 func AddHierarchyFlags(cmd *cobra.Command, flags *HierarchyFlags) {
 	cmd.Flags().StringVarP(&flags.Ecosystem, "ecosystem", "e", "", "Filter by ecosystem name")
 	cmd.Flags().StringVarP(&flags.Domain, "domain", "d", "", "Filter by domain name")
 	cmd.Flags().StringVarP(&flags.System, "system", "s", "", "Filter by system name")
 	cmd.Flags().StringVarP(&flags.App, "app", "a", "", "Filter by app name")
-	cmd.Flags().StringVarP(&flags.Workspace, "workspace", "w", "", "Filter by workspace name")
+	cmd.Flags().StringVarP(&flags.Workspace, "workspace", "w", "", "Filter by workspace name or path (e.g. eco/domain/app/ws)")
 }
 
 // ToFilter converts HierarchyFlags to a WorkspaceFilter for use with the resolver.
@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/registry"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gcConfirm          bool
+	gcCacheTTL         time.Duration
+	gcArchiveRetention time.Duration
+)
+
+// gcItem is one reclaimable resource found by dvm gc.
+type gcItem struct {
+	Kind   string
+	Name   string
+	Size   int64
+	Reason string
+	remove func(ctx context.Context) (int64, error) // returns bytes freed
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Find and remove orphaned dvm resources",
+	Long: `Find resources left behind by deleted workspaces, apps, and plugins:
+
+  image      dvm workspace images whose workspace no longer exists
+  container  exited dvm containers whose workspace no longer exists
+  network    dvm app and service networks no container is on
+  db         workspace plugin associations pointing at deleted plugins or workspaces
+  cache      Go module cache entries (athens) not written within --cache-ttl
+  archived   ecosystems, domains, and apps archived longer than --archive-retention
+
+By default only a sized preview is shown. Pass --confirm to remove them.
+Images used by running containers and stopped containers of existing
+workspaces are never touched. Removing an archived resource deletes it
+and everything under it for good.
+
+Examples:
+  dvm gc                         # Preview
+  dvm gc --confirm               # Reclaim space
+  dvm gc --cache-ttl 168h        # Treat cache entries older than a week as stale
+  dvm gc --archive-retention 0   # Keep archived resources`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().BoolVar(&gcConfirm, "confirm", false, "Remove the listed resources (default: preview only)")
+	gcCmd.Flags().DurationVar(&gcCacheTTL, "cache-ttl", 30*24*time.Hour, "Age after which registry cache entries are stale")
+	gcCmd.Flags().DurationVar(&gcArchiveRetention, "archive-retention", 30*24*time.Hour, "Age after which archived resources are deleted (0 keeps them)")
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	ctx := commandContext(cmd)
+
+	render.Progress("Scanning for orphaned resources...")
+	var items []gcItem
+	runtimeItems, err := gcRuntimeItems(ctx, ds)
+	if err != nil {
+		render.Warning(fmt.Sprintf("Skipping images, containers, and networks: %v", err))
+	}
+	items = append(items, runtimeItems...)
+
+	dbItems, err := gcDBItems(ds)
+	if err != nil {
+		return err
+	}
+	items = append(items, dbItems...)
+
+	cacheItems, err := gcCacheItems(ds, gcCacheTTL, time.Now())
+	if err != nil {
+		render.Warning(fmt.Sprintf("Skipping registry cache: %v", err))
+	}
+	items = append(items, cacheItems...)
+
+	archiveItems, err := gcArchiveItems(ds, gcArchiveRetention, time.Now())
+	if err != nil {
+		return err
+	}
+	items = append(items, archiveItems...)
+
+	render.Blank()
+	if len(items) == 0 {
+		render.Success("Nothing to clean up")
+		return nil
+	}
+
+	var total int64
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		total += item.Size
+		size := "-"
+		if item.Size > 0 {
+			size = formatBytes(item.Size)
+		}
+		rows = append(rows, []string{item.Kind, item.Name, size, item.Reason})
+	}
+	if err := outputList(getOutputFormat, render.TableData{
+		Headers: []string{"KIND", "NAME", "SIZE", "REASON"},
+		Rows:    rows,
+	}, render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	render.Blank()
+	render.Info(fmt.Sprintf("%d item(s), %s reclaimable", len(items), formatBytes(total)))
+
+	if !gcConfirm {
+		render.Info("Run 'dvm gc --confirm' to remove them")
+		return nil
+	}
+
+	var removed, failed int
+	var freed int64
+	for _, item := range items {
+		n, err := item.remove(ctx)
+		if err != nil {
+			failed++
+			render.Warning(fmt.Sprintf("Failed to remove %s %s: %v", item.Kind, item.Name, err))
+			continue
+		}
+		removed++
+		freed += n
+	}
+	render.Success(fmt.Sprintf("Removed %d item(s), freed %s", removed, formatBytes(freed)))
+	if failed > 0 {
+		return fmt.Errorf("%d item(s) could not be removed", failed)
+	}
+	return nil
+}
+
+// gcKnownWorkspaces returns the "app/workspace" keys of every workspace in
+// the database.
+func gcKnownWorkspaces(ds db.DataStore) (map[string]bool, error) {
+	apps, err := ds.ListAllApps()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+	appNames := make(map[int]string, len(apps))
+	for _, a := range apps {
+		appNames[a.ID] = a.Name
+	}
+	workspaces, err := ds.ListAllWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	known := make(map[string]bool, len(workspaces))
+	for _, ws := range workspaces {
+		if appName, ok := appNames[ws.AppID]; ok {
+			known[appName+"/"+ws.Name] = true
+		}
+	}
+	return known, nil
+}
+
+// gcWorkspaceImageRepos returns the repositories of the images workspaces
+// were last built as, which images.name may have named differently from the
+// default dvm-<workspace>-<app>.
+func gcWorkspaceImageRepos(ds db.DataStore) (map[string]bool, error) {
+	workspaces, err := ds.ListAllWorkspaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	repos := make(map[string]bool, len(workspaces))
+	for _, ws := range workspaces {
+		if workspaceImageBuilt(ws) {
+			repos[imageRepo(ws.ImageName)] = true
+		}
+	}
+	return repos, nil
+}
+
+// orphanedImages returns dvm images whose repository (dvm-<workspace>-<app>,
+// or one of imageRepos) belongs to no known workspace and that no running
+// container uses.
+func orphanedImages(images []operators.ImageInfo, known, imageRepos map[string]bool, containers []operators.ContainerInfo) []operators.ImageInfo {
+	repos := make(map[string]bool, len(known)+len(imageRepos))
+	for key := range known {
+		appName, wsName, _ := strings.Cut(key, "/")
+		repos[fmt.Sprintf("dvm-%s-%s", wsName, appName)] = true
+	}
+	for repo := range imageRepos {
+		repos[repo] = true
+	}
+	inUse := make(map[string]bool)
+	for _, c := range containers {
+		if isRunning(c.Status) {
+			inUse[c.Image] = true
+		}
+	}
+
+	var orphans []operators.ImageInfo
+	for _, img := range images {
+		ref := img.Repository + ":" + img.Tag
+		if repos[img.Repository] || inUse[ref] || inUse[img.Repository] || inUse[img.ID] {
+			continue
+		}
+		orphans = append(orphans, img)
+	}
+	return orphans
+}
+
+// orphanedContainers returns non-running dvm containers whose app/workspace
+// labels belong to no known workspace. Stopped containers of existing
+// workspaces are kept because dvm attach reuses them.
+func orphanedContainers(containers []operators.ContainerInfo, known map[string]bool) []operators.ContainerInfo {
+	var orphans []operators.ContainerInfo
+	for _, c := range containers {
+		if isRunning(c.Status) {
+			continue
+		}
+		scope := operators.ScopeFromLabels(c.Labels)
+		if scope.App == "" || scope.Workspace == "" || known[scope.App+"/"+scope.Workspace] {
+			continue
+		}
+		orphans = append(orphans, c)
+	}
+	return orphans
+}
+
+// gcRuntimeItems finds orphaned containers, images, and networks. It returns
+// an error when the container runtime is unavailable.
+func gcRuntimeItems(ctx context.Context, ds db.DataStore) ([]gcItem, error) {
+	detector, err := operators.NewPlatformDetector()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize platform detector: %w", err)
+	}
+	platform, err := detector.Detect()
+	if err != nil {
+		return nil, err
+	}
+	if !platform.IsReachable() {
+		return nil, fmt.Errorf("container runtime is not running")
+	}
+	runtime, err := operators.NewContainerRuntimeWith(&staticPlatformDetector{platform: platform})
+	if err != nil {
+		return nil, err
+	}
+
+	known, err := gcKnownWorkspaces(ds)
+	if err != nil {
+		return nil, err
+	}
+	imageRepos, err := gcWorkspaceImageRepos(ds)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := runtime.ListContainers(ctx, operators.ManagedSelector(operators.LabelScope{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	images, err := operators.NewSystemCleaner(platform).ListDVMImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	var items []gcItem
+	for _, c := range orphanedContainers(containers, known) {
+		c := c
+		scope := operators.ScopeFromLabels(c.Labels)
+		items = append(items, gcItem{
+			Kind:   "container",
+			Name:   c.Name,
+			Reason: fmt.Sprintf("workspace %s/%s deleted", scope.App, scope.Workspace),
+			remove: func(ctx context.Context) (int64, error) {
+				return 0, runtime.RemoveContainer(ctx, c.ID, false)
+			},
+		})
+	}
+	for _, img := range orphanedImages(images, known, imageRepos, containers) {
+		img := img
+		items = append(items, gcItem{
+			Kind:   "image",
+			Name:   img.Repository + ":" + img.Tag,
+			Size:   img.Size,
+			Reason: "workspace deleted",
+			remove: func(ctx context.Context) (int64, error) {
+				if err := runtime.RemoveImage(ctx, img.ID); err != nil {
+					return 0, err
+				}
+				return img.Size, nil
+			},
+		})
+	}
+	// Networks come last: removing the orphaned containers may leave more
+	// unused, which the next run finds
+	if manager, ok := runtime.(operators.NetworkManager); ok {
+		networkItems, err := gcNetworkItems(ctx, manager)
+		if err != nil {
+			render.Warning(fmt.Sprintf("Skipping networks: %v", err))
+		}
+		items = append(items, networkItems...)
+	}
+	return items, nil
+}
+
+// gcNetworkItems finds dvm networks no container is on. Runtimes that can't
+// list networks have none to report.
+func gcNetworkItems(ctx context.Context, manager operators.NetworkManager) ([]gcItem, error) {
+	networks, err := manager.ListNetworks(ctx, operators.ManagedSelector(operators.LabelScope{}))
+	if errors.Is(err, operators.ErrNotImplemented) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	var items []gcItem
+	for _, n := range networks {
+		if n.Containers > 0 {
+			continue
+		}
+		n := n
+		items = append(items, gcItem{
+			Kind:   "network",
+			Name:   n.Name,
+			Reason: "no containers",
+			remove: func(ctx context.Context) (int64, error) {
+				return 0, manager.RemoveNetwork(ctx, n.Name)
+			},
+		})
+	}
+	return items, nil
+}
+
+// gcDBItems reports workspace plugin associations left by deleted plugins or
+// workspaces as a single item.
+func gcDBItems(ds db.DataStore) ([]gcItem, error) {
+	count, err := ds.CountOrphanedWorkspacePlugins()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return []gcItem{{
+		Kind:   "db",
+		Name:   fmt.Sprintf("workspace_plugins (%d rows)", count),
+		Reason: "plugin or workspace deleted",
+		remove: func(ctx context.Context) (int64, error) {
+			_, err := ds.DeleteOrphanedWorkspacePlugins()
+			return 0, err
+		},
+	}}, nil
+}
+
+// gcCacheItems reports stale entries of each athens registry's cache as one
+// item per registry. Other registry types may hold locally published
+// packages and are not collected.
+func gcCacheItems(ds db.DataStore, ttl time.Duration, now time.Time) ([]gcItem, error) {
+	registries, err := ds.ListRegistriesByType("athens")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list registries: %w", err)
+	}
+
+	var items []gcItem
+	for _, r := range registries {
+		storage := athensStorage(r)
+		entries, err := registry.StaleAthensEntries(storage, ttl, now)
+		if err != nil {
+			return items, err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		var size int64
+		for _, e := range entries {
+			size += e.Size
+		}
+		items = append(items, gcItem{
+			Kind:   "cache",
+			Name:   fmt.Sprintf("%s (%d modules)", r.Name, len(entries)),
+			Size:   size,
+			Reason: fmt.Sprintf("not used in %s", ttl),
+			remove: func(ctx context.Context) (int64, error) {
+				_, freed, err := registry.RemoveCacheEntries(storage, entries)
+				return freed, err
+			},
+		})
+	}
+	return items, nil
+}
+
+// gcArchiveKindOrder removes apps before their domains and domains before
+// their ecosystems, so deleting a parent never removes a listed item first.
+var gcArchiveKindOrder = map[string]int{
+	models.ArchiveKindApp:       0,
+	models.ArchiveKindDomain:    1,
+	models.ArchiveKindEcosystem: 2,
+}
+
+// gcArchiveItems reports resources archived at least retention ago. A zero
+// retention keeps archived resources.
+func gcArchiveItems(ds db.DataStore, retention time.Duration, now time.Time) ([]gcItem, error) {
+	if retention <= 0 {
+		return nil, nil
+	}
+	archived, err := ds.ListArchivedResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived resources: %w", err)
+	}
+	var expired []*models.ArchivedResource
+	for _, a := range archived {
+		if now.Sub(a.ArchivedAt) >= retention {
+			expired = append(expired, a)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return gcArchiveKindOrder[expired[i].Kind] < gcArchiveKindOrder[expired[j].Kind]
+	})
+
+	items := make([]gcItem, 0, len(expired))
+	for _, a := range expired {
+		a := a
+		items = append(items, gcItem{
+			Kind:   a.Kind,
+			Name:   a.Path(),
+			Reason: fmt.Sprintf("archived %s ago", formatDuration(now.Sub(a.ArchivedAt))),
+			remove: func(ctx context.Context) (int64, error) {
+				switch a.Kind {
+				case models.ArchiveKindEcosystem:
+					return 0, ds.DeleteEcosystem(a.Name)
+				case models.ArchiveKindDomain:
+					return 0, ds.DeleteDomain(a.ID)
+				default:
+					return 0, ds.DeleteApp(a.ID)
+				}
+			},
+		})
+	}
+	return items, nil
+}
+
+// athensStorage returns the registry's storage path, or the default.
+func athensStorage(r *models.Registry) string {
+	if r.Storage != "" {
+		return r.Storage
+	}
+	return registry.DefaultGoModuleConfig().Storage
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dvmContainer(name, status, app, ws string) operators.ContainerInfo {
+	return operators.ContainerInfo{
+		ID:     name + "-id",
+		Name:   name,
+		Status: status,
+		Image:  "dvm-" + ws + "-" + app + ":1",
+		Labels: map[string]string{
+			"io.devopsmaestro.managed":   "true",
+			"io.devopsmaestro.app":       app,
+			"io.devopsmaestro.workspace": ws,
+		},
+	}
+}
+
+func TestGCKnownWorkspaces(t *testing.T) {
+	known, err := gcKnownWorkspaces(newSessionMock(t))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"api/dev": true, "api/staging": true, "web/dev": true}, known)
+}
+
+func TestOrphanedContainers(t *testing.T) {
+	known := map[string]bool{"api/dev": true}
+	containers := []operators.ContainerInfo{
+		dvmContainer("live-stopped", "Exited (0) 2 hours ago", "api", "dev"),
+		dvmContainer("gone-exited", "Exited (137) 1 day ago", "api", "old"),
+		dvmContainer("gone-running", "Up 3 minutes", "api", "scratch"),
+		{Name: "unlabeled", Status: "Exited (0)", Labels: map[string]string{"io.devopsmaestro.managed": "true"}},
+	}
+
+	orphans := orphanedContainers(containers, known)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "gone-exited", orphans[0].Name)
+}
+
+func TestOrphanedImages(t *testing.T) {
+	known := map[string]bool{"api/dev": true}
+	images := []operators.ImageInfo{
+		{ID: "a", Repository: "dvm-dev-api", Tag: "1"},
+		{ID: "b", Repository: "dvm-old-api", Tag: "1", Size: 100},
+		{ID: "c", Repository: "dvm-scratch-api", Tag: "1"},
+		{ID: "d", Repository: "dvm-acme-api-dev", Tag: "0123456789ab"},
+	}
+	containers := []operators.ContainerInfo{
+		dvmContainer("gone-running", "Up 3 minutes", "api", "scratch"),
+	}
+	// Named by an images.name template
+	imageRepos := map[string]bool{"dvm-acme-api-dev": true}
+
+	orphans := orphanedImages(images, known, imageRepos, containers)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "b", orphans[0].ID)
+}
+
+func TestGCDBItems(t *testing.T) {
+	mock := newSessionMock(t)
+	mock.Plugins["kept"] = &models.NvimPluginDB{ID: 1, Name: "kept"}
+	mock.WorkspacePlugins[1] = map[int]bool{1: true, 42: true}
+	mock.WorkspacePlugins[99] = map[int]bool{1: true}
+
+	items, err := gcDBItems(mock)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Contains(t, items[0].Name, "2 rows")
+
+	_, err = items[0].remove(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int]map[int]bool{1: {1: true}}, mock.WorkspacePlugins)
+
+	items, err = gcDBItems(mock)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestGCCacheItems(t *testing.T) {
+	storage := t.TempDir()
+	now := time.Now()
+	for dir, age := range map[string]time.Duration{
+		"github.com/old/mod/@v/v1.0.0": 60 * 24 * time.Hour,
+		"github.com/new/mod/@v/v1.0.0": time.Hour,
+	} {
+		path := filepath.Join(storage, dir)
+		require.NoError(t, os.MkdirAll(path, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(path, "go.mod"), []byte("module x\n"), 0644))
+		require.NoError(t, os.Chtimes(filepath.Join(path, "go.mod"), now.Add(-age), now.Add(-age)))
+	}
+
+	mock := newSessionMock(t)
+	mock.Registries["go"] = &models.Registry{ID: 1, Name: "go", Type: "athens", Storage: storage}
+
+	items, err := gcCacheItems(mock, 30*24*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "go (1 modules)", items[0].Name)
+	assert.Equal(t, int64(len("module x\n")), items[0].Size)
+
+	freed, err := items[0].remove(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, items[0].Size, freed)
+	assert.NoDirExists(t, filepath.Join(storage, "github.com/old"))
+	assert.DirExists(t, filepath.Join(storage, "github.com/new/mod/@v/v1.0.0"))
+}
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
 var (
-	getOutputFormat    string
-	getWorkspacesFlags HierarchyFlags
-	getWorkspaceFlags  HierarchyFlags
-	showTheme          bool // Flag to show theme resolution information
+	getOutputFormat       string
+	getWorkspacesFlags    HierarchyFlags
+	getWorkspaceFlags     HierarchyFlags
+	showTheme             bool          // Flag to show theme resolution information
+	showPackages          bool          // Flag to show nvim and terminal package resolution
+	showEnv               bool          // Flag to show the resolved workspace environment
+	getWorkspacesWatch    bool          // Redraw get workspaces until interrupted
+	getWorkspacesInterval time.Duration // Refresh interval for --watch
 )
 
 // getCmd represents the get command
@@ -61,15 +67,27 @@ Flags:
   -e, --ecosystem   Filter by ecosystem name
   -d, --domain      Filter by domain name  
   -a, --app         Filter by app name
-  -w, --workspace   Filter by workspace name
+  -w, --workspace   Workspace name or path (e.g. eco/domain/app/ws)
+      --watch       Re-check the container runtime and redraw until Ctrl+C,
+                    highlighting rows that changed
+      --interval    Refresh interval for --watch (default 5s)
+
+STATUS comes from the container runtime. When it disagrees with the status
+recorded in the database (container started or stopped outside dvm), the
+database is corrected, the row is marked "(drifted)", and the command to
+bring the workspace back in line is printed below the table.
 
 Examples:
   dvm get workspaces              # List workspaces in active app
   dvm get ws                      # Short form
   dvm get workspaces -A           # List ALL workspaces across everything
   dvm get workspaces -a myapp     # List workspaces in specific app
-  dvm get workspaces -e healthcare -a portal`,
+  dvm get workspaces -e healthcare -a portal
+  dvm get workspaces -A --watch   # Keep statuses in sync in the background`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if getWorkspacesWatch {
+			return watchWorkspaces(cmd, getWorkspacesInterval)
+		}
 		return getWorkspaces(cmd)
 	},
 }
@@ -92,7 +110,9 @@ Examples:
   dvm get ws main                     # Short form
   dvm get workspace main -a myapp     # Get workspace from specific app
   dvm get workspace -a portal         # Get workspace if only one exists
-  dvm get workspace main -o yaml      # Output as YAML`,
+  dvm get workspace main -o yaml      # Output as YAML
+  dvm get workspace main --show-packages  # Show where its nvim and terminal packages come from
+  dvm get workspace main --show-env   # Show its resolved environment, secrets masked`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := ""
@@ -244,6 +264,9 @@ var getDefaultsCmd = &cobra.Command{
 Shows the default values used when creating new workspaces if no explicit 
 configuration is provided.
 
+Change a global default with 'dvm set default <key> <value>' and remove it
+with 'dvm unset default <key>'.
+
 Examples:
   dvm get defaults
   dvm get defaults -o yaml
@@ -277,7 +300,11 @@ func init() {
 	// Output format flag for get subcommands — shadows the root persistent flag
 	// so getCmd children read from getOutputFormat. When not explicitly set by
 	// user (empty string), render.OutputWith("") falls back to the global default.
-	getCmd.PersistentFlags().StringVarP(&getOutputFormat, "output", "o", "", "Output format (json, yaml, plain, table, colored)")
+	getCmd.PersistentFlags().VarP(newOutputFormatValue(&getOutputFormat, ""), "output", "o", outputFormatUsage)
+	_ = getCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
+
+	// Paging, sorting, and column selection for list output
+	addListViewFlags(getCmd)
 
 	// Add hierarchy flags for workspace commands
 	AddHierarchyFlags(getWorkspacesCmd, &getWorkspacesFlags)
@@ -285,6 +312,7 @@ func init() {
 
 	// Add --all flag to get workspaces (with -A shorthand for consistency)
 	AddAllFlag(getWorkspacesCmd, "List all workspaces across all apps/domains/ecosystems")
+	AddWatchFlags(getWorkspacesCmd, &getWorkspacesWatch, &getWorkspacesInterval)
 
 	// Add scoping flags to get all command
 	getAllCmd.Flags().StringP("ecosystem", "e", "", "Filter by ecosystem name")
@@ -295,4 +323,6 @@ func init() {
 	// Add --show-theme flag to hierarchy commands
 	getWorkspacesCmd.Flags().BoolVar(&showTheme, "show-theme", false, "Show theme resolution information")
 	getWorkspaceCmd.Flags().BoolVar(&showTheme, "show-theme", false, "Show theme resolution information")
+	getWorkspaceCmd.Flags().BoolVar(&showPackages, "show-packages", false, "Show nvim and terminal package resolution information")
+	getWorkspaceCmd.Flags().BoolVar(&showEnv, "show-env", false, "Show the resolved environment (ecosystem, domain, app, and workspace env) with secrets masked")
 }
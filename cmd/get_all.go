@@ -98,6 +98,18 @@ func getAll(cmd *cobra.Command) error {
 		workspaces = nil
 	}
 
+	archived, err := loadArchivedIDs(ds)
+	if err != nil {
+		render.Warning(fmt.Sprintf("failed to load archived resources: %v", err))
+		archived = nil
+	}
+	if archived != nil {
+		ecosystems = withoutArchived(ecosystems, archived.Ecosystems, func(e *models.Ecosystem) int { return e.ID })
+		domains = withoutArchived(domains, archived.Domains, func(d *models.Domain) int { return d.ID })
+		apps = withoutArchived(apps, archived.Apps, func(a *models.App) int { return a.ID })
+		workspaces = withoutArchived(workspaces, archived.Workspaces, func(w *models.Workspace) int { return w.ID })
+	}
+
 	credentials, err := ds.ListAllCredentials()
 	if err != nil {
 		render.Warning(fmt.Sprintf("failed to list credentials: %v", err))
@@ -172,7 +184,7 @@ func getAll(cmd *cobra.Command) error {
 	}
 
 	// JSON/YAML: build a kubectl-style kind: List document via resource.BuildList
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		// Warn when exporting YAML/JSON in a scoped context (global resources excluded)
 		if !scope.ShowAll {
 			render.Warning("Warning: Scoped export excludes global resources (GitRepos, Registries, NvimPlugins, NvimThemes, NvimPackages, TerminalPrompts, TerminalPackages, TerminalPlugins, CRDs, GlobalDefaults). Use -A for a complete backup.")
@@ -384,7 +396,7 @@ func getAll(cmd *cobra.Command) error {
 			}
 		}
 
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	// Human-readable output: render each section using shared table builders
@@ -408,175 +420,115 @@ func getAll(cmd *cobra.Command) error {
 	wide := getOutputFormat == "wide"
 
 	// === Ecosystems ===
-	render.Info(fmt.Sprintf("=== Ecosystems (%d) ===", len(ecosystems)))
-	if len(ecosystems) > 0 {
-		b := &ecosystemTableBuilder{ActiveID: activeEcoID}
-		td := BuildTable(b, ecosystems, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Ecosystems", len(ecosystems), func() render.TableData {
+		return BuildTable(&ecosystemTableBuilder{ActiveID: activeEcoID}, ecosystems, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Domains ===
-	render.Info(fmt.Sprintf("=== Domains (%d) ===", len(domains)))
-	if len(domains) > 0 {
-		b := &domainTableBuilder{DataStore: ds, ActiveID: activeDomID}
-		td := BuildTable(b, domains, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Domains", len(domains), func() render.TableData {
+		return BuildTable(&domainTableBuilder{DataStore: ds, ActiveID: activeDomID}, domains, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Systems ===
-	render.Info(fmt.Sprintf("=== Systems (%d) ===", len(systems)))
-	if len(systems) > 0 {
-		b := &systemTableBuilder{DataStore: ds, ActiveID: activeSystemID}
-		td := BuildTable(b, systems, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Systems", len(systems), func() render.TableData {
+		return BuildTable(&systemTableBuilder{DataStore: ds, ActiveID: activeSystemID}, systems, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Apps ===
-	render.Info(fmt.Sprintf("=== Apps (%d) ===", len(apps)))
-	if len(apps) > 0 {
-		b := &appTableBuilder{DataStore: ds, ActiveID: activeAppID}
-		td := BuildTable(b, apps, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Apps", len(apps), func() render.TableData {
+		return BuildTable(&appTableBuilder{DataStore: ds, ActiveID: activeAppID}, apps, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Workspaces ===
-	render.Info(fmt.Sprintf("=== Workspaces (%d) ===", len(workspaces)))
-	if len(workspaces) > 0 {
-		b := &workspaceTableBuilder{DataStore: ds, ActiveWorkspaceName: activeWorkspaceName}
-		td := BuildTable(b, workspaces, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Workspaces", len(workspaces), func() render.TableData {
+		return BuildTable(&workspaceTableBuilder{DataStore: ds, ActiveWorkspaceName: activeWorkspaceName}, workspaces, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Credentials ===
-	render.Info(fmt.Sprintf("=== Credentials (%d) ===", len(credentials)))
-	if len(credentials) > 0 {
-		b := &credentialTableBuilder{DataStore: ds}
-		td := BuildTable(b, credentials, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Credentials", len(credentials), func() render.TableData {
+		return BuildTable(&credentialTableBuilder{DataStore: ds}, credentials, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Registries ===
-	render.Info(fmt.Sprintf("=== Registries (%d) ===", len(registries)))
-	if len(registries) > 0 {
-		b := &registryTableBuilder{StatusMap: nil}
-		td := BuildTable(b, registries, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Registries", len(registries), func() render.TableData {
+		return BuildTable(&registryTableBuilder{StatusMap: nil}, registries, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Git Repos ===
-	render.Info(fmt.Sprintf("=== Git Repos (%d) ===", len(gitRepos)))
-	if len(gitRepos) > 0 {
+	if err := renderAllSection("Git Repos", len(gitRepos), func() render.TableData {
 		// ListGitRepos returns []models.GitRepoDB (value type), but
 		// gitRepoTableBuilder.Row expects *models.GitRepoDB, so convert.
 		gitRepoPtrs := make([]*models.GitRepoDB, len(gitRepos))
 		for i := range gitRepos {
 			gitRepoPtrs[i] = &gitRepos[i]
 		}
-		b := &gitRepoTableBuilder{}
-		td := BuildTable(b, gitRepoPtrs, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+		return BuildTable(&gitRepoTableBuilder{}, gitRepoPtrs, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Nvim Plugins ===
-	render.Info(fmt.Sprintf("=== Nvim Plugins (%d) ===", len(plugins)))
-	if len(plugins) > 0 {
-		b := &nvimPluginTableBuilder{}
-		td := BuildTable(b, plugins, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Nvim Plugins", len(plugins), func() render.TableData {
+		return BuildTable(&nvimPluginTableBuilder{}, plugins, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Nvim Themes ===
-	render.Info(fmt.Sprintf("=== Nvim Themes (%d) ===", len(themes)))
-	if len(themes) > 0 {
-		b := &nvimThemeTableBuilder{}
-		td := BuildTable(b, themes, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Nvim Themes", len(themes), func() render.TableData {
+		return BuildTable(&nvimThemeTableBuilder{}, themes, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Nvim Packages ===
-	render.Info(fmt.Sprintf("=== Nvim Packages (%d) ===", len(nvimPackages)))
-	if len(nvimPackages) > 0 {
-		b := &nvimPackageTableBuilder{}
-		td := BuildTable(b, nvimPackages, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Nvim Packages", len(nvimPackages), func() render.TableData {
+		return BuildTable(&nvimPackageTableBuilder{}, nvimPackages, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Terminal Prompts ===
-	render.Info(fmt.Sprintf("=== Terminal Prompts (%d) ===", len(terminalPrompts)))
-	if len(terminalPrompts) > 0 {
-		b := &terminalPromptTableBuilder{}
-		td := BuildTable(b, terminalPrompts, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Terminal Prompts", len(terminalPrompts), func() render.TableData {
+		return BuildTable(&terminalPromptTableBuilder{}, terminalPrompts, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Terminal Packages ===
-	render.Info(fmt.Sprintf("=== Terminal Packages (%d) ===", len(terminalPackages)))
-	if len(terminalPackages) > 0 {
-		b := &terminalPackageTableBuilder{}
-		td := BuildTable(b, terminalPackages, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Terminal Packages", len(terminalPackages), func() render.TableData {
+		return BuildTable(&terminalPackageTableBuilder{}, terminalPackages, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Terminal Plugins ===
-	render.Info(fmt.Sprintf("=== Terminal Plugins (%d) ===", len(terminalPlugins)))
-	if len(terminalPlugins) > 0 {
-		b := &terminalPluginTableBuilder{}
-		td := BuildTable(b, terminalPlugins, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Terminal Plugins", len(terminalPlugins), func() render.TableData {
+		return BuildTable(&terminalPluginTableBuilder{}, terminalPlugins, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === CRDs ===
-	render.Info(fmt.Sprintf("=== CRDs (%d) ===", len(crds)))
-	if len(crds) > 0 {
-		b := &crdTableBuilder{}
-		td := BuildTable(b, crds, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("CRDs", len(crds), func() render.TableData {
+		return BuildTable(&crdTableBuilder{}, crds, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === CA Certs ===
 	// Gather CA certs across all scopes for the summary table
@@ -614,15 +566,11 @@ func getAll(cmd *cobra.Command) error {
 			allCACerts = append(allCACerts, scopedCACert{Name: c.Name, Scope: fmt.Sprintf("app: %s", app.Name)})
 		}
 	}
-	render.Info(fmt.Sprintf("=== CA Certs (%d) ===", len(allCACerts)))
-	if len(allCACerts) > 0 {
-		b := &caCertTableBuilder{}
-		td := BuildTable(b, allCACerts, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("CA Certs", len(allCACerts), func() render.TableData {
+		return BuildTable(&caCertTableBuilder{}, allCACerts, wide)
+	}); err != nil {
+		return err
 	}
-	render.Blank()
 
 	// === Build Args ===
 	// Gather build args across all scopes for the summary table
@@ -660,18 +608,36 @@ func getAll(cmd *cobra.Command) error {
 			allBuildArgs = append(allBuildArgs, scopedBuildArg{Key: k, Scope: fmt.Sprintf("app: %s", app.Name)})
 		}
 	}
-	render.Info(fmt.Sprintf("=== Build Args (%d) ===", len(allBuildArgs)))
-	if len(allBuildArgs) > 0 {
-		b := &buildArgTableBuilder{}
-		td := BuildTable(b, allBuildArgs, wide)
-		renderTable(td)
-	} else {
-		render.Plainf("  (none)")
+	if err := renderAllSection("Build Args", len(allBuildArgs), func() render.TableData {
+		return BuildTable(&buildArgTableBuilder{}, allBuildArgs, wide)
+	}); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// renderAllSection renders one titled section of the human-readable 'get all'
+// output, followed by a blank line. build is only called for a non-empty
+// section. With -o name only the names are printed, so the output can be
+// piped.
+func renderAllSection(title string, count int, build func() render.TableData) error {
+	if getOutputFormat == outputName {
+		if count == 0 {
+			return nil
+		}
+		return renderTable(build())
+	}
+	render.Info(fmt.Sprintf("=== %s (%d) ===", title, count))
+	if count == 0 {
+		render.Plainf("  (none)")
+	} else if err := renderTable(build()); err != nil {
+		return err
+	}
+	render.Blank()
+	return nil
+}
+
 // scopeContext holds the resolved scope for a get all operation.
 type scopeContext struct {
 	EcosystemID *int
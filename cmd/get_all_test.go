@@ -913,6 +913,30 @@ func TestGetAll_WideOutput(t *testing.T) {
 	assert.Contains(t, output, "SLUG", "wide mode should add SLUG column to git repos")
 }
 
+// TestGetAll_NameOutput verifies that -o name prints only resource names,
+// without section headings or "(none)" placeholders.
+func TestGetAll_NameOutput(t *testing.T) {
+	dataStore := createFullTestDataStore(t)
+	eco := &models.Ecosystem{Name: "name-eco"}
+	require.NoError(t, dataStore.CreateEcosystem(eco))
+	dom := &models.Domain{Name: "name-dom", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, dataStore.CreateDomain(dom))
+
+	cmd := newGetAllTestCmd(t, dataStore)
+
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	origFormat := getOutputFormat
+	defer func() { getOutputFormat = origFormat }()
+	getOutputFormat = outputName
+
+	require.NoError(t, getAll(cmd))
+	assert.Equal(t, "name-eco\nname-dom\n", buf.String())
+}
+
 // ---------------------------------------------------------------------------
 // TestGetAll_RichColumns
 // ---------------------------------------------------------------------------
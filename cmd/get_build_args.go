@@ -165,7 +165,7 @@ func runGetBuildArgsEffective(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	if len(resolution.Args) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No build args set anywhere in the hierarchy",
 		})
@@ -179,7 +179,7 @@ func runGetBuildArgsEffective(cmd *cobra.Command, ctx resource.Context) error {
 	sort.Strings(keys)
 
 	// For JSON/YAML, build structured output
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		type argOutput struct {
 			Key    string `json:"key" yaml:"key"`
 			Value  string `json:"value" yaml:"value"`
@@ -194,7 +194,7 @@ func runGetBuildArgsEffective(cmd *cobra.Command, ctx resource.Context) error {
 				Source: source.String(),
 			})
 		}
-		return render.OutputWith(getOutputFormat, out, render.Options{})
+		return outputList(getOutputFormat, out, render.Options{})
 	}
 
 	// Table/human output
@@ -203,7 +203,7 @@ func runGetBuildArgsEffective(cmd *cobra.Command, ctx resource.Context) error {
 		source := resolution.Sources[k]
 		rows = append(rows, []string{k, resolution.Args[k], source.String()})
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"KEY", "VALUE", "SOURCE"},
 		Rows:    rows,
 	}, render.Options{
@@ -295,7 +295,7 @@ func runGetAllBuildArgs(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	if len(allArgs) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No build args found across any scope",
 			EmptyHints:   []string{"dvm set build-arg --global <KEY> <VALUE>"},
@@ -303,8 +303,8 @@ func runGetAllBuildArgs(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	// For JSON/YAML, output structured data
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, allArgs, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, allArgs, render.Options{})
 	}
 
 	// Table output
@@ -312,7 +312,7 @@ func runGetAllBuildArgs(cmd *cobra.Command, ctx resource.Context) error {
 	for i, a := range allArgs {
 		rows[i] = []string{a.Key, a.Value, a.Scope}
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"KEY", "VALUE", "SCOPE"},
 		Rows:    rows,
 	}, render.Options{Type: render.TypeTable})
@@ -437,15 +437,15 @@ func getBuildArgsGlobalLevel(cmd *cobra.Command, ctx resource.Context) error {
 // Uses render.OutputWith to support JSON/YAML/table output via the parent -o flag.
 func displayBuildArgs(level, objectName string, argMap map[string]string) error {
 	if len(argMap) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: fmt.Sprintf("No build args set at %s level (%s)", level, objectName),
 		})
 	}
 
 	// For JSON/YAML, output the map directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, argMap, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, argMap, render.Options{})
 	}
 
 	// Sort keys for deterministic output
@@ -460,7 +460,7 @@ func displayBuildArgs(level, objectName string, argMap map[string]string) error
 	for i, k := range keys {
 		rows[i] = []string{k, argMap[k]}
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"KEY", "VALUE"},
 		Rows:    rows,
 	}, render.Options{
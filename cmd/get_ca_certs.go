@@ -164,14 +164,14 @@ func runGetCACertsEffective(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	if len(resolution.Certs) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No CA certs set anywhere in the hierarchy",
 		})
 	}
 
 	// For JSON/YAML, build structured output
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		type certOutput struct {
 			Name             string `json:"name" yaml:"name"`
 			VaultSecret      string `json:"vaultSecret" yaml:"vaultSecret"`
@@ -190,7 +190,7 @@ func runGetCACertsEffective(cmd *cobra.Command, ctx resource.Context) error {
 				Source:           source.String(),
 			})
 		}
-		return render.OutputWith(getOutputFormat, out, render.Options{})
+		return outputList(getOutputFormat, out, render.Options{})
 	}
 
 	// Table/human output
@@ -206,7 +206,7 @@ func runGetCACertsEffective(cmd *cobra.Command, ctx resource.Context) error {
 		}
 		rows = append(rows, []string{cert.Name, vaultInfo, source.String()})
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"NAME", "VAULT-SECRET", "SOURCE"},
 		Rows:    rows,
 	}, render.Options{
@@ -318,7 +318,7 @@ func runGetAllCACerts(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	if len(allCerts) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No CA certs found across any scope",
 			EmptyHints:   []string{"dvm set ca-cert --global <name> --vault-secret <secret>"},
@@ -326,8 +326,8 @@ func runGetAllCACerts(cmd *cobra.Command, ctx resource.Context) error {
 	}
 
 	// For JSON/YAML, output structured data
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, allCerts, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, allCerts, render.Options{})
 	}
 
 	// Table output
@@ -335,7 +335,7 @@ func runGetAllCACerts(cmd *cobra.Command, ctx resource.Context) error {
 	for i, c := range allCerts {
 		rows[i] = []string{c.Name, c.VaultSecret, c.Scope}
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"NAME", "VAULT-SECRET", "SCOPE"},
 		Rows:    rows,
 	}, render.Options{Type: render.TypeTable})
@@ -460,15 +460,15 @@ func getCACertsGlobalLevel(cmd *cobra.Command, ctx resource.Context) error {
 // Uses render.OutputWith to support JSON/YAML/table output via the parent -o flag.
 func displayCACerts(level, objectName string, certs []models.CACertConfig) error {
 	if len(certs) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: fmt.Sprintf("No CA certs set at %s level (%s)", level, objectName),
 		})
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, certs, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, certs, render.Options{})
 	}
 
 	// Sort by name for deterministic output
@@ -481,7 +481,7 @@ func displayCACerts(level, objectName string, certs []models.CACertConfig) error
 	for i, c := range sorted {
 		rows[i] = []string{c.Name, formatCACertVaultInfo(c)}
 	}
-	return render.OutputWith(getOutputFormat, render.TableData{
+	return outputList(getOutputFormat, render.TableData{
 		Headers: []string{"NAME", "VAULT-SECRET"},
 		Rows:    rows,
 	}, render.Options{
@@ -7,7 +7,9 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/resource/handlers"
 	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
 
 	"github.com/spf13/cobra"
 )
@@ -43,27 +45,7 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("failed to list credentials: %w", err)
 			}
-
-			if len(creds) == 0 {
-				render.Info("No credentials found")
-				return nil
-			}
-
-			rows := make([][]string, 0, len(creds))
-			for _, c := range creds {
-				scope := resolveScopeName(ds, c.ScopeType, c.ScopeID)
-				target := formatTargetVars(c)
-				desc := ""
-				if c.Description != nil {
-					desc = *c.Description
-				}
-				rows = append(rows, []string{c.Name, scope, c.Source, target, desc, formatExpirationStatus(c)})
-			}
-			render.OutputWith(getOutputFormat, render.TableData{
-				Headers: []string{"NAME", "SCOPE", "SOURCE", "TARGET", "DESCRIPTION", "EXPIRES"},
-				Rows:    rows,
-			}, render.Options{Type: render.TypeTable})
-			return nil
+			return renderCredentialList(ds, creds, "No credentials found")
 		}
 
 		// Filter by scope
@@ -76,28 +58,51 @@ Examples:
 		if err != nil {
 			return fmt.Errorf("failed to list credentials: %w", err)
 		}
+		return renderCredentialList(ds, creds, fmt.Sprintf("No credentials found for %s scope", scopeType))
+	},
+}
 
+// renderCredentialList renders credentials as a table, or as a kind: List of
+// Credential documents for structured output.
+func renderCredentialList(ds db.DataStore, creds []*models.CredentialDB, emptyMessage string) error {
+	if isStructuredOutput(getOutputFormat) {
+		handlers.RegisterAll()
 		if len(creds) == 0 {
-			render.Info(fmt.Sprintf("No credentials found for %s scope", scopeType))
-			return nil
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
+		resources := make([]resource.Resource, len(creds))
+		for i, c := range creds {
+			resources[i] = handlers.NewCredentialResource(c, resolveCredentialScopeTargetName(ds, c.ScopeType, c.ScopeID))
+		}
+		list, err := resource.BuildList(resource.Context{DataStore: ds}, resources)
+		if err != nil {
+			return fmt.Errorf("failed to build resource list: %w", err)
+		}
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+	}
 
-		rows := make([][]string, 0, len(creds))
-		for _, c := range creds {
-			scope := resolveScopeName(ds, c.ScopeType, c.ScopeID)
-			target := formatTargetVars(c)
-			desc := ""
-			if c.Description != nil {
-				desc = *c.Description
-			}
-			rows = append(rows, []string{c.Name, scope, c.Source, target, desc, formatExpirationStatus(c)})
+	if len(creds) == 0 {
+		return outputList(getOutputFormat, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: emptyMessage,
+			EmptyHints:   []string{"dvm create credential <name>"},
+		})
+	}
+
+	rows := make([][]string, 0, len(creds))
+	for _, c := range creds {
+		scope := resolveScopeName(ds, c.ScopeType, c.ScopeID)
+		target := formatTargetVars(c)
+		desc := ""
+		if c.Description != nil {
+			desc = *c.Description
 		}
-		render.OutputWith(getOutputFormat, render.TableData{
-			Headers: []string{"NAME", "SCOPE", "SOURCE", "TARGET", "DESCRIPTION", "EXPIRES"},
-			Rows:    rows,
-		}, render.Options{Type: render.TypeTable})
-		return nil
-	},
+		rows = append(rows, []string{c.Name, scope, c.Source, target, desc, formatExpirationStatus(c)})
+	}
+	return outputList(getOutputFormat, render.TableData{
+		Headers: []string{"NAME", "SCOPE", "SOURCE", "TARGET", "DESCRIPTION", "EXPIRES"},
+		Rows:    rows,
+	}, render.Options{Type: render.TypeTable})
 }
 
 // getCredentialCmd gets a single credential by name (singular form)
@@ -135,71 +140,65 @@ Examples:
 		}
 
 		// For JSON/YAML, output the model data via ToYAML (issue #183)
-		if getOutputFormat == "json" || getOutputFormat == "yaml" {
+		if isStructuredOutput(getOutputFormat) {
 			scopeName := resolveCredentialScopeTargetName(ds, cred.ScopeType, cred.ScopeID)
 			yamlDoc := cred.ToYAML(scopeName)
-			return render.OutputWith(getOutputFormat, yamlDoc, render.Options{})
+			return outputList(getOutputFormat, yamlDoc, render.Options{})
 		}
 
-		// Display credential details (plain text)
-		render.Plainf("Name:      %s", cred.Name)
-		render.Plainf("Scope:     %s", resolveScopeName(ds, cred.ScopeType, cred.ScopeID))
-		render.Plainf("Source:    %s", cred.Source)
-		if cred.VaultSecret != nil {
-			render.Plainf("Secret:    %s", *cred.VaultSecret)
-		}
-		if cred.VaultEnv != nil {
-			render.Plainf("Vault Env: %s", *cred.VaultEnv)
-		}
-		if cred.VaultUsernameSecret != nil {
-			render.Plainf("Username Secret: %s", *cred.VaultUsernameSecret)
-		}
-		if cred.EnvVar != nil {
-			render.Plainf("EnvVar:    %s", *cred.EnvVar)
-		}
-		if cred.Description != nil {
-			render.Plainf("Desc:      %s", *cred.Description)
-		}
-		if cred.UsernameVar != nil {
-			render.Plainf("Username:  %s", *cred.UsernameVar)
-		}
-		if cred.PasswordVar != nil {
-			render.Plainf("Password:  %s", *cred.PasswordVar)
+		// Human output — detail view
+		kvData := render.NewOrderedKeyValueData(
+			render.KeyValue{Key: "Name", Value: cred.Name},
+			render.KeyValue{Key: "Scope", Value: resolveScopeName(ds, cred.ScopeType, cred.ScopeID)},
+			render.KeyValue{Key: "Source", Value: cred.Source},
+		)
+		add := func(key string, value *string) {
+			if value != nil {
+				kvData.Pairs = append(kvData.Pairs, render.KeyValue{Key: key, Value: *value})
+			}
 		}
-		// Show expiration status
+		add("Secret", cred.VaultSecret)
+		add("Vault Env", cred.VaultEnv)
+		add("Username Secret", cred.VaultUsernameSecret)
+		add("EnvVar", cred.EnvVar)
+		add("Desc", cred.Description)
+		add("Username", cred.UsernameVar)
+		add("Password", cred.PasswordVar)
 		if cred.ExpiresAt != nil {
-			render.Plainf("Expires:   %s", cred.ExpiresAt.Format("2006-01-02 15:04:05"))
-			status := cred.ExpirationStatus()
-			switch status {
-			case "expired":
-				render.Warning(fmt.Sprintf("Status:    %s", status))
-			case "expiring soon":
-				render.Warning(fmt.Sprintf("Status:    %s", status))
-			default:
-				render.Plainf("Status:    %s", status)
-			}
+			kvData.Pairs = append(kvData.Pairs,
+				render.KeyValue{Key: "Expires", Value: cred.ExpiresAt.Format("2006-01-02 15:04:05")},
+				render.KeyValue{Key: "Status", Value: cred.ExpirationStatus()},
+			)
 		}
 		if cred.HasVaultFields() {
-			fields, err := cred.GetVaultFieldsMap()
-			if err == nil && len(fields) > 0 {
-				render.Plainf("Fields:")
+			if fields, err := cred.GetVaultFieldsMap(); err == nil && len(fields) > 0 {
 				// Sort keys for deterministic output
 				keys := make([]string, 0, len(fields))
 				for k := range fields {
 					keys = append(keys, k)
 				}
 				sort.Strings(keys)
+				mappings := make([]string, 0, len(keys))
 				for _, envVar := range keys {
-					fieldName := fields[envVar]
-					if envVar == fieldName {
-						render.Plainf("  %s", envVar)
+					if fieldName := fields[envVar]; fieldName != envVar {
+						mappings = append(mappings, envVar+" <- "+fieldName)
 					} else {
-						render.Plainf("  %s <- %s", envVar, fieldName)
+						mappings = append(mappings, envVar)
 					}
 				}
+				kvData.Pairs = append(kvData.Pairs, render.KeyValue{Key: "Fields", Value: strings.Join(mappings, ", ")})
 			}
 		}
 
+		if err := outputList(getOutputFormat, kvData, render.Options{
+			Type:  render.TypeKeyValue,
+			Title: "Credential Details",
+		}); err != nil {
+			return err
+		}
+		if status := cred.ExpirationStatus(); status == "expired" || status == "expiring soon" {
+			render.Warning(fmt.Sprintf("Credential %s is %s", cred.Name, status))
+		}
 		return nil
 	},
 }
@@ -6,6 +6,7 @@ package cmd
 // =============================================================================
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"devopsmaestro/db"
 	"devopsmaestro/models"
 
+	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -470,3 +472,44 @@ func TestFormatTargetVars_NilVaultFields(t *testing.T) {
 
 	assert.Equal(t, "MY_TOKEN", result)
 }
+
+// =============================================================================
+// Tests for renderCredentialList()
+// =============================================================================
+
+// TestRenderCredentialList_Structured verifies that -o json lists credentials
+// as Credential documents rather than table rows.
+func TestRenderCredentialList_Structured(t *testing.T) {
+	store := db.NewMockDataStore()
+	eco := &models.Ecosystem{Name: "prod"}
+	require.NoError(t, store.CreateEcosystem(eco))
+	cred := &models.CredentialDB{Name: "gh", ScopeType: models.CredentialScopeEcosystem, ScopeID: int64(eco.ID), Source: "env", EnvVar: ptr("GH_TOKEN")}
+	require.NoError(t, store.CreateCredential(cred))
+
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+	origFormat := getOutputFormat
+	defer func() { getOutputFormat = origFormat }()
+	getOutputFormat = outputJSON
+
+	require.NoError(t, renderCredentialList(store, []*models.CredentialDB{cred}, "none"))
+
+	var list struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name      string `json:"name"`
+				Ecosystem string `json:"ecosystem"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &list), buf.String())
+	assert.Equal(t, "List", list.Kind)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "Credential", list.Items[0].Kind)
+	assert.Equal(t, "gh", list.Items[0].Metadata.Name)
+	assert.Equal(t, "prod", list.Items[0].Metadata.Ecosystem)
+}
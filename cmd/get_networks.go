@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// getNetworksCmd lists the container networks dvm created
+var getNetworksCmd = &cobra.Command{
+	Use:     "networks",
+	Aliases: []string{"network", "net"},
+	Short:   "List dvm container networks",
+	Long: `List the container networks dvm created on the local runtime.
+
+SCOPE is "app" or "domain" for the networks an app's (or a domain's)
+workspaces and services share, created on first start as containerNetworks.scope
+configures, and "workspace" for the network Compose creates for a workspace's
+services. CONTAINERS counts the containers on the network, running or not;
+'dvm gc' removes dvm networks without any.
+
+Examples:
+  dvm get networks
+  dvm get networks -o json`,
+	Args: cobra.NoArgs,
+	RunE: runGetNetworks,
+}
+
+func init() {
+	getCmd.AddCommand(getNetworksCmd)
+}
+
+func runGetNetworks(cmd *cobra.Command, args []string) error {
+	runtime, err := operators.NewContainerRuntime()
+	if err != nil {
+		return fmt.Errorf("failed to create container runtime: %w", err)
+	}
+	manager, ok := runtime.(operators.NetworkManager)
+	if !ok {
+		return fmt.Errorf("listing networks is not supported on %s", runtime.GetPlatformName())
+	}
+	networks, err := manager.ListNetworks(commandContext(cmd), operators.ManagedSelector(operators.LabelScope{}))
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, networks, render.Options{})
+	}
+	if len(networks) == 0 {
+		return outputList(getOutputFormat, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: "No dvm networks found",
+			EmptyHints:   []string{"dvm attach creates the network of the workspace's app"},
+		})
+	}
+	return outputList(getOutputFormat, networkTable(networks), render.Options{Type: render.TypeTable})
+}
+
+// networkTable renders networks as the dvm get networks table.
+func networkTable(networks []operators.NetworkInfo) render.TableData {
+	tableData := render.TableData{Headers: []string{"NAME", "SCOPE", "ECOSYSTEM", "DOMAIN", "APP", "CONTAINERS"}}
+	for _, n := range networks {
+		scope := operators.ScopeFromLabels(n.Labels)
+		app := scope.App
+		if scope.Workspace != "" {
+			app += "/" + scope.Workspace
+		}
+		tableData.Rows = append(tableData.Rows, []string{
+			n.Name,
+			orDash(n.Scope()),
+			orDash(scope.Ecosystem),
+			orDash(scope.Domain),
+			orDash(app),
+			strconv.Itoa(n.Containers),
+		})
+	}
+	return tableData
+}
@@ -4,8 +4,15 @@
 package cmd
 
 import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
 	"testing"
 
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/resolver"
+
+	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,3 +82,82 @@ func TestGetTerminalPackage_HasShowCascadeFlag(t *testing.T) {
 	assert.NotNil(t, getTerminalPackageCmd.Flags().Lookup("show-cascade"),
 		"--show-cascade flag should be present on get terminal-package")
 }
+
+// TestGetTerminalPackage_HasResolvedFlag verifies --resolved flag is present.
+func TestGetTerminalPackage_HasResolvedFlag(t *testing.T) {
+	require.NotNil(t, getTerminalPackageCmd)
+	assert.NotNil(t, getTerminalPackageCmd.Flags().Lookup("resolved"),
+		"--resolved flag should be present on get terminal-package")
+}
+
+// TestRenderPackageResolution_Structured verifies that -o json emits the
+// resolution with stable field names, including the cascade path.
+func TestRenderPackageResolution_Structured(t *testing.T) {
+	res := &resolver.PackageResolution{
+		PackageName: "maestro-go",
+		PackageType: "nvim",
+		Source:      resolver.PackageLevelApp,
+		SourceName:  "api",
+		Path: []resolver.PackageStep{
+			{Level: resolver.PackageLevelWorkspace, Name: "dev"},
+			{Level: resolver.PackageLevelApp, Name: "api", PackageName: "maestro-go", Found: true},
+		},
+	}
+
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	require.NoError(t, renderPackageResolution(res, "dev", true, outputJSON))
+	var out PackageResolutionOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out), buf.String())
+	assert.Equal(t, "maestro-go", out.Package)
+	assert.Equal(t, "nvim", out.PackageType)
+	require.Len(t, out.Path, 2)
+	assert.True(t, out.Path[1].Found)
+	assert.Contains(t, buf.String(), `"packageType"`)
+
+	buf.Reset()
+	require.NoError(t, renderPackageResolution(res, "dev", false, outputName))
+	assert.Equal(t, "maestro-go\n", buf.String())
+}
+
+// newPackageDefaultsMock returns the session mock with an nvim package on
+// domain dom and a terminal package on ecosystem eco.
+func newPackageDefaultsMock(t *testing.T) *db.MockDataStore {
+	mock := newSessionMock(t)
+	mock.Domains[1].NvimPackage = sql.NullString{String: "go-dev", Valid: true}
+	mock.Ecosystems["eco"].TerminalPackage = sql.NullString{String: "starship", Valid: true}
+	return mock
+}
+
+// TestShowPackageResolution verifies --show-packages prints where each
+// package of a workspace comes from.
+func TestShowPackageResolution(t *testing.T) {
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	require.NoError(t, showPackageResolution(newPackageDefaultsMock(t), resolver.PackageLevelWorkspace, 1))
+	out := buf.String()
+	assert.Contains(t, out, "Source: domain 'dom'")
+	assert.Contains(t, out, "● domain 'dom' → go-dev")
+	assert.Contains(t, out, "Source: ecosystem 'eco'")
+	assert.Contains(t, out, "● ecosystem 'eco' → starship")
+	assert.Contains(t, out, "○ workspace 'dev'")
+}
+
+// TestInheritedNvimPackage verifies a domain or ecosystem package outranks
+// language detection for new apps, and the global default does not.
+func TestInheritedNvimPackage(t *testing.T) {
+	pkg, source := inheritedNvimPackage(newPackageDefaultsMock(t), 1)
+	assert.Equal(t, "go-dev", pkg)
+	assert.Equal(t, "domain 'dom'", source)
+
+	mock := newSessionMock(t)
+	require.NoError(t, mock.SetDefault("nvim-package", "full-stack"))
+	pkg, _ = inheritedNvimPackage(mock, 1)
+	assert.Empty(t, pkg)
+}
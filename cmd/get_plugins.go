@@ -24,7 +24,7 @@ func getPlugins(cmd *cobra.Command) error {
 	}
 
 	if len(resources) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No plugins found",
 			EmptyHints:   []string{"dvm apply -f plugin.yaml"},
@@ -39,12 +39,12 @@ func getPlugins(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		pluginsYAML := make([]*plugin.PluginYAML, len(plugins))
 		for i, p := range plugins {
 			pluginsYAML[i] = p.ToYAML()
 		}
-		return render.OutputWith(getOutputFormat, pluginsYAML, render.Options{})
+		return outputList(getOutputFormat, pluginsYAML, render.Options{})
 	}
 
 	// For human output, build table data
@@ -74,7 +74,7 @@ func getPlugins(cmd *cobra.Command) error {
 		}
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -94,8 +94,8 @@ func getPlugin(cmd *cobra.Command, name string) error {
 	p := res.(*handlers.NvimPluginResource).Plugin()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, p.ToYAML(), render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, p.ToYAML(), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -119,7 +119,7 @@ func getPlugin(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Enabled", Value: enabledStr},
 	)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Plugin Details",
 	})
@@ -52,12 +52,27 @@ Examples:
   dvm get reg                     # Short form
   dvm get registries -o yaml
   dvm get registries -o json
-  dvm get registries -o wide      # Show additional columns`,
+  dvm get registries -o wide      # Show additional columns
+  dvm get registries --watch      # Redraw as registries start and stop`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if getRegistriesWatch {
+			return runWatch(cmd, getOutputFormat, getRegistriesInterval, func() error {
+				return getRegistries(cmd)
+			})
+		}
 		return getRegistries(cmd)
 	},
 }
 
+var (
+	getRegistriesWatch    bool          // Redraw get registries until interrupted
+	getRegistriesInterval time.Duration // Refresh interval for --watch
+)
+
+func init() {
+	AddWatchFlags(getRegistriesCmd, &getRegistriesWatch, &getRegistriesInterval)
+}
+
 // getRegistryCmd gets a specific registry by name
 var getRegistryCmd = &cobra.Command{
 	Use:     "registry <name>",
@@ -89,9 +104,9 @@ func getRegistries(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, produce a kind: List envelope (issue #154)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		if len(resources) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{})
 		}
 		list := resource.NewResourceList()
 		regs := make([]*models.Registry, len(resources))
@@ -119,11 +134,11 @@ func getRegistries(cmd *cobra.Command) error {
 			}
 			list.Items = append(list.Items, yamls[i])
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{})
+		return outputList(getOutputFormat, list, render.Options{})
 	}
 
 	if len(resources) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No registries found",
 			EmptyHints:   []string{"dvm create registry <name> --type <type>"},
@@ -201,7 +216,7 @@ func getRegistries(cmd *cobra.Command) error {
 		renderFormat = "table"
 	}
 
-	return render.OutputWith(renderFormat, tableData, render.Options{
+	return outputList(renderFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -221,14 +236,14 @@ func getRegistry(cmd *cobra.Command, name string) error {
 	registry := res.(*handlers.RegistryResource).Registry()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		ry := registry.ToYAML()
 		status := registryLiveStatus(cmd.Context(), registry)
 		ry.Status = &models.RegistryStatusYAML{
 			State:    status,
 			Endpoint: fmt.Sprintf("http://localhost:%d", registry.Port),
 		}
-		return render.OutputWith(getOutputFormat, ry, render.Options{})
+		return outputList(getOutputFormat, ry, render.Options{})
 	}
 
 	// For human output, show detail view
@@ -239,18 +254,24 @@ func getRegistry(cmd *cobra.Command, name string) error {
 
 	status := registryLiveStatus(cmd.Context(), registry)
 
-	kvData := render.NewOrderedKeyValueData(
-		render.KeyValue{Key: "Name", Value: registry.Name},
-		render.KeyValue{Key: "Type", Value: registry.Type},
-		render.KeyValue{Key: "Version", Value: registry.Version},
-		render.KeyValue{Key: "Port", Value: fmt.Sprintf("%d", registry.Port)},
-		render.KeyValue{Key: "Lifecycle", Value: registry.Lifecycle},
-		render.KeyValue{Key: "Status", Value: status},
+	pairs := []render.KeyValue{
+		{Key: "Name", Value: registry.Name},
+		{Key: "Type", Value: registry.Type},
+		{Key: "Version", Value: registry.Version},
+		{Key: "Port", Value: fmt.Sprintf("%d", registry.Port)},
+		{Key: "Lifecycle", Value: registry.Lifecycle},
+		{Key: "Status", Value: status},
+	}
+	if cache := registryCacheSummary(cmd.Context(), registry); cache != "" {
+		pairs = append(pairs, render.KeyValue{Key: "Cache", Value: cache})
+	}
+	pairs = append(pairs,
 		render.KeyValue{Key: "Description", Value: desc},
 		render.KeyValue{Key: "Created", Value: registry.CreatedAt},
 	)
+	kvData := render.NewOrderedKeyValueData(pairs...)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Registry Details",
 	})
@@ -290,6 +311,24 @@ func registryLiveStatus(ctx context.Context, reg *models.Registry) string {
 	return "stopped"
 }
 
+// registryCacheSummary describes what a registry has cached, e.g.
+// "12 modules, 40 versions, 3.4 MB", or "" when it can't be measured.
+func registryCacheSummary(ctx context.Context, reg *models.Registry) string {
+	mgr, err := newRegistryStatsManager(reg)
+	if err != nil {
+		return ""
+	}
+	reporter, ok := mgr.(registry.StorageReporter)
+	if !ok {
+		return ""
+	}
+	stats := reporter.StorageStats(ctx)
+	if stats.Versions == 0 {
+		return fmt.Sprintf("%d %s, %s", stats.Items, stats.Kind, formatBytes(stats.DiskUsage))
+	}
+	return fmt.Sprintf("%d %s, %d versions, %s", stats.Items, stats.Kind, stats.Versions, formatBytes(stats.DiskUsage))
+}
+
 // formatDuration formats a time.Duration into a human-readable string.
 // Examples: "5s", "3m", "2h", "1d", "3d 2h".
 func formatDuration(d time.Duration) string {
@@ -3,8 +3,11 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"devopsmaestro/builders"
+	"devopsmaestro/db"
 	"devopsmaestro/operators"
 	themeresolver "devopsmaestro/pkg/colors/resolver"
 	"devopsmaestro/pkg/nvimbridge"
@@ -23,93 +26,90 @@ type ContextOutput struct {
 	CurrentWorkspace string `yaml:"currentWorkspace" json:"currentWorkspace"`
 }
 
-func getContext(cmd *cobra.Command) error {
-	// Read from database context (authoritative source for all 4 hierarchy levels)
-	ds, err := getDataStore(cmd)
-	if err != nil {
-		return fmt.Errorf("failed to get data store: %w", err)
-	}
+// contextSources records where each ContextOutput level came from:
+// "global" for the database context or "env: DVM_X" for an override.
+type contextSources struct {
+	Ecosystem, Domain, System, App, Workspace string
+}
+
+// resolveCurrentContext returns the active context names from the database,
+// overridden by the DVM_ECOSYSTEM/DOMAIN/SYSTEM/APP/WORKSPACE env vars.
+func resolveCurrentContext(ds db.DataStore) (ContextOutput, contextSources, error) {
+	var data ContextOutput
+	var src contextSources
 
 	dbCtx, err := ds.GetContext()
 	if err != nil {
-		return fmt.Errorf("failed to load context: %w", err)
+		return data, src, fmt.Errorf("failed to load context: %w", err)
 	}
 
 	// Resolve IDs to names, tracking source
-	var ecosystemName, domainName, systemName, appName, workspaceName string
-	var ecosystemSource, domainSource, systemSource, appSource, workspaceSource string
-
 	if dbCtx != nil {
 		if dbCtx.ActiveEcosystemID != nil {
 			if eco, err := ds.GetEcosystemByID(*dbCtx.ActiveEcosystemID); err == nil {
-				ecosystemName = eco.Name
-				ecosystemSource = "global"
+				data.CurrentEcosystem, src.Ecosystem = eco.Name, "global"
 			}
 		}
 		if dbCtx.ActiveDomainID != nil {
 			if dom, err := ds.GetDomainByID(*dbCtx.ActiveDomainID); err == nil {
-				domainName = dom.Name
-				domainSource = "global"
+				data.CurrentDomain, src.Domain = dom.Name, "global"
 			}
 		}
 		if dbCtx.ActiveSystemID != nil {
 			if sys, err := ds.GetSystemByID(*dbCtx.ActiveSystemID); err == nil {
-				systemName = sys.Name
-				systemSource = "global"
+				data.CurrentSystem, src.System = sys.Name, "global"
 			}
 		}
 		if dbCtx.ActiveAppID != nil {
 			if app, err := ds.GetAppByID(*dbCtx.ActiveAppID); err == nil {
-				appName = app.Name
-				appSource = "global"
+				data.CurrentApp, src.App = app.Name, "global"
 			}
 		}
 		if dbCtx.ActiveWorkspaceID != nil {
 			if ws, err := ds.GetWorkspaceByID(*dbCtx.ActiveWorkspaceID); err == nil {
-				workspaceName = ws.Name
-				workspaceSource = "global"
+				data.CurrentWorkspace, src.Workspace = ws.Name, "global"
 			}
 		}
 	}
 
 	// Check env var overrides (DVM_ECOSYSTEM, DVM_DOMAIN, DVM_SYSTEM, DVM_APP, DVM_WORKSPACE)
 	if envEco := os.Getenv("DVM_ECOSYSTEM"); envEco != "" {
-		ecosystemName = envEco
-		ecosystemSource = "env: DVM_ECOSYSTEM"
+		data.CurrentEcosystem, src.Ecosystem = envEco, "env: DVM_ECOSYSTEM"
 	}
 	if envDom := os.Getenv("DVM_DOMAIN"); envDom != "" {
-		domainName = envDom
-		domainSource = "env: DVM_DOMAIN"
+		data.CurrentDomain, src.Domain = envDom, "env: DVM_DOMAIN"
 	}
 	if envSys := os.Getenv("DVM_SYSTEM"); envSys != "" {
-		systemName = envSys
-		systemSource = "env: DVM_SYSTEM"
+		data.CurrentSystem, src.System = envSys, "env: DVM_SYSTEM"
 	}
 	if envApp := os.Getenv("DVM_APP"); envApp != "" {
-		appName = envApp
-		appSource = "env: DVM_APP"
+		data.CurrentApp, src.App = envApp, "env: DVM_APP"
 	}
 	if envWorkspace := os.Getenv("DVM_WORKSPACE"); envWorkspace != "" {
-		workspaceName = envWorkspace
-		workspaceSource = "env: DVM_WORKSPACE"
+		data.CurrentWorkspace, src.Workspace = envWorkspace, "env: DVM_WORKSPACE"
 	}
+	return data, src, nil
+}
 
-	// Build structured data
-	data := ContextOutput{
-		CurrentEcosystem: ecosystemName,
-		CurrentDomain:    domainName,
-		CurrentSystem:    systemName,
-		CurrentApp:       appName,
-		CurrentWorkspace: workspaceName,
+func getContext(cmd *cobra.Command) error {
+	// Read from database context (authoritative source for all 4 hierarchy levels)
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to get data store: %w", err)
+	}
+
+	data, src, err := resolveCurrentContext(ds)
+	if err != nil {
+		return err
 	}
 
 	// Check if empty
-	isEmpty := ecosystemName == "" && domainName == "" && systemName == "" && appName == "" && workspaceName == ""
+	isEmpty := data.CurrentEcosystem == "" && data.CurrentDomain == "" && data.CurrentSystem == "" && data.CurrentApp == "" && data.CurrentWorkspace == ""
 
 	// For structured output (JSON/YAML), always output the data structure
 	// For human output, show nice key-value display
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, data, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, data, render.Options{})
 	}
 
 	// Human-readable output
@@ -139,14 +139,14 @@ func getContext(cmd *cobra.Command) error {
 	}
 
 	kvData := render.NewOrderedKeyValueData(
-		render.KeyValue{Key: "Ecosystem", Value: displayWithSource(ecosystemName, ecosystemSource)},
-		render.KeyValue{Key: "Domain", Value: displayWithSource(domainName, domainSource)},
-		render.KeyValue{Key: "System", Value: displayWithSource(systemName, systemSource)},
-		render.KeyValue{Key: "App", Value: displayWithSource(appName, appSource)},
-		render.KeyValue{Key: "Workspace", Value: displayWithSource(workspaceName, workspaceSource)},
+		render.KeyValue{Key: "Ecosystem", Value: displayWithSource(data.CurrentEcosystem, src.Ecosystem)},
+		render.KeyValue{Key: "Domain", Value: displayWithSource(data.CurrentDomain, src.Domain)},
+		render.KeyValue{Key: "System", Value: displayWithSource(data.CurrentSystem, src.System)},
+		render.KeyValue{Key: "App", Value: displayWithSource(data.CurrentApp, src.App)},
+		render.KeyValue{Key: "Workspace", Value: displayWithSource(data.CurrentWorkspace, src.Workspace)},
 	)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Current Context",
 	})
@@ -172,7 +172,7 @@ func getPlatforms(cmd *cobra.Command) error {
 	platforms := detector.DetectAll()
 
 	if len(platforms) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No container platforms detected",
 			EmptyHints:   []string{"Install OrbStack, Colima, Docker Desktop, or Podman"},
@@ -201,8 +201,8 @@ func getPlatforms(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, platformsOutput, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, platformsOutput, render.Options{})
 	}
 
 	// For human output, build table data
@@ -237,7 +237,7 @@ func getPlatforms(cmd *cobra.Command) error {
 		}
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -258,8 +258,10 @@ func getDefaults(cmd *cobra.Command) error {
 	containerDefaults := builders.GetContainerDefaults()
 
 	// Override with user-set defaults from database
+	var unknownKeys []string
 	ds, err := getDataStore(cmd)
 	if err == nil {
+		unknownKeys = unknownDefaultKeys(ds)
 		// Check for user-set nvim package
 		if userPkg, err := ds.GetDefault("nvim-package"); err == nil && userPkg != "" {
 			nvimDefaults["pluginPackage"] = userPkg
@@ -283,8 +285,8 @@ func getDefaults(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output the data structure directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, data, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, data, render.Options{})
 	}
 
 	// For human-readable output, show organized key-value display
@@ -312,5 +314,28 @@ func getDefaults(cmd *cobra.Command) error {
 		render.Plainf("  %s: %v", key, value)
 	}
 
+	if len(unknownKeys) > 0 {
+		render.Blank()
+		render.Warningf("Unknown default keys, not read by dvm: %s (remove with 'dvm unset default <key>')",
+			strings.Join(unknownKeys, ", "))
+	}
+
 	return nil
 }
+
+// unknownDefaultKeys returns the stored default keys missing from the
+// schema, sorted.
+func unknownDefaultKeys(ds db.DataStore) []string {
+	stored, err := ds.ListDefaults()
+	if err != nil {
+		return nil
+	}
+	var unknown []string
+	for key := range stored {
+		if _, ok := lookupDefaultKey(key); !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
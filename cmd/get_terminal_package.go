@@ -1,6 +1,7 @@
 // Package cmd implements the 'dvm get terminal-package' command.
 // It displays the resolved terminal package for the current workspace context,
-// walking the hierarchy: workspace → app → domain → ecosystem → global.
+// walking the hierarchy: workspace → app → domain → ecosystem → global, or a
+// named package with its extends chain flattened (--resolved).
 package cmd
 
 import (
@@ -9,7 +10,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var getTermPkgShowCascade bool
+var (
+	getTermPkgShowCascade bool
+	getTermPkgResolved    bool
+)
 
 // getTerminalPackageCmd displays the resolved terminal package
 var getTerminalPackageCmd = &cobra.Command{
@@ -22,19 +26,38 @@ The package is resolved by walking the hierarchy:
 
 Use --show-cascade to see where each level's package is set.
 
+With a package name, show that package instead. Add --resolved to flatten
+its extends chain: plugin, prompt and profile lists are merged parent-first
+(an entry prefixed with "-" drops an inherited item), and scalar settings
+such as theme and prompt style come from the nearest package that sets them.
+
 Examples:
   dvm get terminal-package
   dvm get terminal-package --show-cascade
-  dvm get terminal-package -o yaml`,
+  dvm get terminal-package -o yaml
+  dvm get terminal-package dev-essentials --resolved
+  dvm get terminal-package dev-essentials --resolved -o yaml`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runGetTerminalPackage,
 }
 
 func init() {
 	getCmd.AddCommand(getTerminalPackageCmd)
 	getTerminalPackageCmd.Flags().BoolVar(&getTermPkgShowCascade, "show-cascade", false, "Show full hierarchy walk")
+	getTerminalPackageCmd.Flags().BoolVar(&getTermPkgResolved, "resolved", false, "Flatten the named package's extends chain")
 }
 
 func runGetTerminalPackage(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 {
+		if !getTermPkgResolved {
+			return getTerminalPackage(cmd, args[0])
+		}
+		return getResolvedTerminalPackage(cmd, args[0])
+	}
+	if getTermPkgResolved {
+		return fmt.Errorf("--resolved requires a package name")
+	}
+
 	ctx, err := buildResourceContext(cmd)
 	if err != nil {
 		return err
@@ -52,3 +75,23 @@ func runGetTerminalPackage(cmd *cobra.Command, args []string) error {
 
 	return renderPackageResolution(resolution, ws, getTermPkgShowCascade, getOutputFormat)
 }
+
+// getResolvedTerminalPackage shows a terminal package with its extends chain
+// flattened. Packages are looked up in the database, then the library.
+func getResolvedTerminalPackage(cmd *cobra.Command, name string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	res, err := resolveTerminalPackage(ds, name)
+	if err != nil {
+		return err
+	}
+
+	inherits := res.Inherited()
+	if inherits == nil {
+		inherits = []string{}
+	}
+	return renderTerminalPackageDetail(res.Package, inherits)
+}
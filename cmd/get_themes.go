@@ -36,7 +36,7 @@ func getThemes(cmd *cobra.Command) error {
 	}
 
 	if len(entries) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No themes found",
 			EmptyHints:   []string{"dvm apply -f theme.yaml"},
@@ -44,7 +44,7 @@ func getThemes(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output the model data with source annotation
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		return getThemesStructured(entries)
 	}
 
@@ -52,7 +52,7 @@ func getThemes(cmd *cobra.Command) error {
 	colorEnabled := !colors.IsNoColorRequested(noColor)
 	tableData := buildThemeTableData(entries, colorEnabled)
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -144,7 +144,7 @@ func getThemesStructured(entries []themeEntry) error {
 			PreviewColors: themePreviewColors(e.theme.Colors),
 		}
 	}
-	return render.OutputWith(getOutputFormat, output, render.Options{})
+	return outputList(getOutputFormat, output, render.Options{})
 }
 
 func getTheme(cmd *cobra.Command, name string) error {
@@ -162,7 +162,7 @@ func getTheme(cmd *cobra.Command, name string) error {
 	t := res.(*handlers.NvimThemeResource).Theme()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		themeYAML := &theme.ThemeYAML{
 			APIVersion: "devopsmaestro.io/v1",
 			Kind:       "NvimTheme",
@@ -180,7 +180,7 @@ func getTheme(cmd *cobra.Command, name string) error {
 				Options:     t.Options,
 			},
 		}
-		return render.OutputWith(getOutputFormat, themeYAML, render.Options{})
+		return outputList(getOutputFormat, themeYAML, render.Options{})
 	}
 
 	// For human output, show detail view
@@ -219,7 +219,7 @@ func getTheme(cmd *cobra.Command, name string) error {
 
 	kvData := render.NewOrderedKeyValueData(pairs...)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Theme Details",
 	})
@@ -250,7 +250,7 @@ func getEffectiveThemeDisplay(cmd *cobra.Command) error {
 	sourceDesc := resolution.GetSourceDescription()
 
 	// For structured output (JSON/YAML)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		type resolutionStep struct {
 			Level    string `json:"level" yaml:"level"`
 			Name     string `json:"name" yaml:"name"`
@@ -279,7 +279,7 @@ func getEffectiveThemeDisplay(cmd *cobra.Command) error {
 			SourceLevel:    resolution.Source.String(),
 			ResolutionPath: steps,
 		}
-		return render.OutputWith(getOutputFormat, data, render.Options{})
+		return outputList(getOutputFormat, data, render.Options{})
 	}
 
 	// Build human-readable key-value display
@@ -301,7 +301,7 @@ func getEffectiveThemeDisplay(cmd *cobra.Command) error {
 	}
 
 	kvData := render.NewOrderedKeyValueData(pairs...)
-	if err := render.OutputWith(getOutputFormat, kvData, render.Options{
+	if err := outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Effective Theme",
 	}); err != nil {
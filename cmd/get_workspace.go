@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"devopsmaestro/db"
 	"devopsmaestro/models"
 	themeresolver "devopsmaestro/pkg/colors/resolver"
 	"devopsmaestro/pkg/resolver"
@@ -23,20 +24,41 @@ func getWorkspaces(cmd *cobra.Command) error {
 
 	// If --all/-A flag is set, list all workspaces across everything
 	if allFlag {
-		workspaces, err := sqlDS.ListAllWorkspaces()
+		// Page in the query, so only the rows shown are reconciled and
+		// resolved, unless they must be sorted by a display column first
+		if err := listView.Validate(); err != nil {
+			return err
+		}
+		archived, err := loadArchivedIDs(sqlDS)
+		if err != nil {
+			return err
+		}
+		view := listView
+		var workspaces []*models.Workspace
+		// Archived workspaces are dropped after loading, so the query can
+		// only page when none are hidden
+		if view.Paged() && view.SortBy == "" && (archived == nil || len(archived.Workspaces) == 0) {
+			workspaces, err = sqlDS.ListAllWorkspacesPage(db.ListOptions{Limit: view.Limit, Offset: view.Offset})
+			view = view.WithoutPaging()
+		} else {
+			workspaces, err = sqlDS.ListAllWorkspaces()
+		}
 		if err != nil {
 			return fmt.Errorf("failed to list all workspaces: %w", err)
 		}
+		if archived != nil {
+			workspaces = withoutArchived(workspaces, archived.Workspaces, func(ws *models.Workspace) int { return ws.ID })
+		}
 
 		// Reconcile cached DB status against live container runtime so this
 		// command agrees with `dvm status` (issue #405).
-		reconcileWorkspaceStatuses(workspaces)
+		drifts := reconcileWorkspaceStatuses(sqlDS, workspaces)
 
 		// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-		if getOutputFormat == "json" || getOutputFormat == "yaml" {
+		if isStructuredOutput(getOutputFormat) {
 			handlers.RegisterAll()
 			if len(workspaces) == 0 {
-				return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+				return outputListWith(view, getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 			}
 			wsResources := make([]resource.Resource, len(workspaces))
 			for i, ws := range workspaces {
@@ -73,11 +95,11 @@ func getWorkspaces(cmd *cobra.Command) error {
 			if listErr != nil {
 				return fmt.Errorf("failed to build resource list: %w", listErr)
 			}
-			return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+			return outputListWith(view, getOutputFormat, list, render.Options{Type: render.TypeAuto})
 		}
 
 		if len(workspaces) == 0 {
-			return render.OutputWith(getOutputFormat, nil, render.Options{
+			return outputListWith(view, getOutputFormat, nil, render.Options{
 				Empty:        true,
 				EmptyMessage: "No workspaces found",
 				EmptyHints:   []string{"dvm create workspace <name>"},
@@ -86,12 +108,14 @@ func getWorkspaces(cmd *cobra.Command) error {
 
 		// Determine if wide format
 		isWide := getOutputFormat == "wide"
+		lastAttached := loadLastAttached(sqlDS, isWide)
+		rebuilds := loadRebuildChecker(sqlDS)
 
 		// For human output, build table data
 		// We need to look up app names for display
 		var headers []string
 		if isWide {
-			headers = []string{"NAME", "APP", "SYSTEM", "IMAGE", "STATUS", "CREATED", "CONTAINER-ID"}
+			headers = []string{"NAME", "APP", "SYSTEM", "IMAGE", "STATUS", "CREATED", "LAST-ATTACHED", "CONTAINER-ID"}
 		} else {
 			headers = []string{"NAME", "APP", "SYSTEM", "IMAGE", "STATUS"}
 		}
@@ -128,12 +152,12 @@ func getWorkspaces(cmd *cobra.Command) error {
 				appName,
 				sysName,
 				ws.ImageName,
-				ws.Status,
+				rebuilds.status(drifts.status(ws), app, ws),
 			}
 
 			if isWide {
-				// Add CREATED timestamp
-				row = append(row, ws.CreatedAt.Format("2006-01-02 15:04"))
+				// Add CREATED timestamp and time since last attach
+				row = append(row, ws.CreatedAt.Format("2006-01-02 15:04"), formatLastAttached(lastAttached, ws.ID))
 				// Add CONTAINER-ID (truncated to 12 chars like Docker)
 				containerID := "<none>"
 				if ws.ContainerID.Valid && ws.ContainerID.String != "" {
@@ -169,9 +193,14 @@ func getWorkspaces(cmd *cobra.Command) error {
 			renderFormat = "table"
 		}
 
-		return render.OutputWith(renderFormat, tableData, render.Options{
+		if err := outputListWith(view, renderFormat, tableData, render.Options{
 			Type: render.TypeTable,
-		})
+		}); err != nil {
+			return err
+		}
+		renderWorkspaceDrifts(sqlDS, drifts)
+		renderRebuildHint(rebuilds, nil, workspaces)
+		return nil
 	}
 
 	// Check if hierarchy flags were provided
@@ -181,7 +210,7 @@ func getWorkspaces(cmd *cobra.Command) error {
 		results, err := wsResolver.ResolveAll(getWorkspacesFlags.ToFilter())
 		if err != nil {
 			if resolver.IsNoWorkspaceFoundError(err) {
-				return render.OutputWith(getOutputFormat, nil, render.Options{
+				return outputList(getOutputFormat, nil, render.Options{
 					Empty:        true,
 					EmptyMessage: "No workspaces found matching criteria",
 					EmptyHints:   []string{"dvm create workspace <name>"},
@@ -189,9 +218,16 @@ func getWorkspaces(cmd *cobra.Command) error {
 			}
 			return fmt.Errorf("failed to resolve workspaces: %w", err)
 		}
+		archived, err := loadArchivedIDs(sqlDS)
+		if err != nil {
+			return err
+		}
+		if archived != nil {
+			results = withoutArchived(results, archived.Workspaces, func(wh *models.WorkspaceWithHierarchy) int { return wh.Workspace.ID })
+		}
 
 		if len(results) == 0 {
-			return render.OutputWith(getOutputFormat, nil, render.Options{
+			return outputList(getOutputFormat, nil, render.Options{
 				Empty:        true,
 				EmptyMessage: "No workspaces found matching criteria",
 				EmptyHints:   []string{"dvm create workspace <name>"},
@@ -199,10 +235,10 @@ func getWorkspaces(cmd *cobra.Command) error {
 		}
 
 		// Reconcile cached DB status against live container runtime (#405).
-		reconcileWorkspaceHierarchyStatuses(results)
+		drifts := reconcileWorkspaceHierarchyStatuses(sqlDS, results)
 
 		// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-		if getOutputFormat == "json" || getOutputFormat == "yaml" {
+		if isStructuredOutput(getOutputFormat) {
 			handlers.RegisterAll()
 			wsResources := make([]resource.Resource, len(results))
 			for i, wh := range results {
@@ -228,16 +264,18 @@ func getWorkspaces(cmd *cobra.Command) error {
 			if listErr != nil {
 				return fmt.Errorf("failed to build resource list: %w", listErr)
 			}
-			return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 		}
 
 		// Determine if wide format
 		isWide := getOutputFormat == "wide"
+		lastAttached := loadLastAttached(sqlDS, isWide)
+		rebuilds := loadRebuildChecker(sqlDS)
 
 		// For human output, build table data with full path
 		var headers []string
 		if isWide {
-			headers = []string{"NAME", "PATH", "IMAGE", "STATUS", "CREATED", "CONTAINER-ID"}
+			headers = []string{"NAME", "PATH", "IMAGE", "STATUS", "CREATED", "LAST-ATTACHED", "CONTAINER-ID"}
 		} else {
 			headers = []string{"NAME", "PATH", "IMAGE", "STATUS"}
 		}
@@ -261,12 +299,12 @@ func getWorkspaces(cmd *cobra.Command) error {
 				wh.Workspace.Name,
 				wh.FullPath(),
 				wh.Workspace.ImageName,
-				wh.Workspace.Status,
+				rebuilds.status(drifts.status(wh.Workspace), wh.App, wh.Workspace),
 			}
 
 			if isWide {
-				// Add CREATED timestamp
-				row = append(row, wh.Workspace.CreatedAt.Format("2006-01-02 15:04"))
+				// Add CREATED timestamp and time since last attach
+				row = append(row, wh.Workspace.CreatedAt.Format("2006-01-02 15:04"), formatLastAttached(lastAttached, wh.Workspace.ID))
 				// Add CONTAINER-ID (truncated to 12 chars like Docker)
 				containerID := "<none>"
 				if wh.Workspace.ContainerID.Valid && wh.Workspace.ContainerID.String != "" {
@@ -302,9 +340,18 @@ func getWorkspaces(cmd *cobra.Command) error {
 			renderFormat = "table"
 		}
 
-		return render.OutputWith(renderFormat, tableData, render.Options{
+		if err := outputList(renderFormat, tableData, render.Options{
 			Type: render.TypeTable,
-		})
+		}); err != nil {
+			return err
+		}
+		renderWorkspaceDrifts(sqlDS, drifts)
+		matched := make([]*models.Workspace, len(results))
+		for i, wh := range results {
+			matched[i] = wh.Workspace
+		}
+		renderRebuildHint(rebuilds, nil, matched)
+		return nil
 	}
 
 	// Fall back to existing context-based behavior (DB-backed)
@@ -329,10 +376,10 @@ func getWorkspaces(cmd *cobra.Command) error {
 	}
 
 	// Reconcile cached DB status against live container runtime (#405).
-	reconcileWorkspaceStatuses(workspaces)
+	drifts := reconcileWorkspaceStatuses(sqlDS, workspaces)
 
 	if len(workspaces) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: fmt.Sprintf("No workspaces found in app '%s'", appName),
 			EmptyHints:   []string{"dvm create workspace <name>"},
@@ -340,10 +387,10 @@ func getWorkspaces(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, wrap in kind: List envelope for round-trip compatibility (issue #154)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		handlers.RegisterAll()
 		if len(workspaces) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
 		// Resolve domain/ecosystem names for context-free output
 		domName := ""
@@ -376,16 +423,18 @@ func getWorkspaces(cmd *cobra.Command) error {
 		if listErr != nil {
 			return fmt.Errorf("failed to build resource list: %w", listErr)
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	// Determine if wide format
 	isWide := getOutputFormat == "wide"
+	lastAttached := loadLastAttached(sqlDS, isWide)
+	rebuilds := loadRebuildChecker(sqlDS)
 
 	// For human output, build table data
 	var headers []string
 	if isWide {
-		headers = []string{"NAME", "APP", "IMAGE", "STATUS", "CREATED", "CONTAINER-ID"}
+		headers = []string{"NAME", "APP", "IMAGE", "STATUS", "CREATED", "LAST-ATTACHED", "CONTAINER-ID"}
 	} else {
 		headers = []string{"NAME", "APP", "IMAGE", "STATUS"}
 	}
@@ -414,12 +463,12 @@ func getWorkspaces(cmd *cobra.Command) error {
 			name,
 			appName,
 			ws.ImageName,
-			ws.Status,
+			rebuilds.status(drifts.status(ws), app, ws),
 		}
 
 		if isWide {
-			// Add CREATED timestamp
-			row = append(row, ws.CreatedAt.Format("2006-01-02 15:04"))
+			// Add CREATED timestamp and time since last attach
+			row = append(row, ws.CreatedAt.Format("2006-01-02 15:04"), formatLastAttached(lastAttached, ws.ID))
 			// Add CONTAINER-ID (truncated to 12 chars like Docker)
 			containerID := "<none>"
 			if ws.ContainerID.Valid && ws.ContainerID.String != "" {
@@ -455,9 +504,14 @@ func getWorkspaces(cmd *cobra.Command) error {
 		renderFormat = "table"
 	}
 
-	return render.OutputWith(renderFormat, tableData, render.Options{
+	if err := outputList(renderFormat, tableData, render.Options{
 		Type: render.TypeTable,
-	})
+	}); err != nil {
+		return err
+	}
+	renderWorkspaceDrifts(sqlDS, drifts)
+	renderRebuildHint(rebuilds, app, workspaces)
+	return nil
 }
 
 func getWorkspace(cmd *cobra.Command, name string) error {
@@ -469,6 +523,7 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 	var workspace *models.Workspace
 	var app *models.App
 	var appName string
+	var drifts workspaceDrifts
 
 	// If name is provided via positional arg, add it to the filter
 	filter := getWorkspaceFlags.ToFilter()
@@ -478,23 +533,9 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 
 	// Check if any criteria were provided (flags or positional arg)
 	if filter.EcosystemName != "" || filter.DomainName != "" || filter.AppName != "" || filter.WorkspaceName != "" {
-		// Use resolver to find workspace
-		wsResolver := resolver.NewWorkspaceResolver(sqlDS)
-		result, err := wsResolver.Resolve(filter)
+		result, err := resolveWorkspace(sqlDS, filter)
 		if err != nil {
-			// Check if ambiguous and provide helpful output
-			if ambiguousErr, ok := resolver.IsAmbiguousError(err); ok {
-				render.Warning("Multiple workspaces match your criteria")
-				render.Plain(ambiguousErr.FormatDisambiguation())
-				render.Plain(FormatSuggestions(SuggestAmbiguousWorkspace()...))
-				return fmt.Errorf("ambiguous workspace selection")
-			}
-			if resolver.IsNoWorkspaceFoundError(err) {
-				render.Warning("No workspace found matching your criteria")
-				render.Plain(FormatSuggestions(SuggestWorkspaceNotFound(filter.WorkspaceName)...))
-				return err
-			}
-			return fmt.Errorf("failed to resolve workspace: %w", err)
+			return err
 		}
 
 		workspace = result.Workspace
@@ -502,7 +543,7 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 		appName = app.Name
 
 		// Reconcile cached DB status against live container runtime (#405).
-		reconcileWorkspaceStatuses([]*models.Workspace{workspace})
+		drifts = reconcileWorkspaceStatuses(sqlDS, []*models.Workspace{workspace})
 
 	} else {
 		// Fall back to existing context-based behavior (DB-backed)
@@ -533,7 +574,7 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		// Resolve GitRepo name if GitRepoID is set
 		gitRepoName := ""
 		if workspace.GitRepoID.Valid {
@@ -542,7 +583,7 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 				gitRepoName = gitRepo.Name
 			}
 		}
-		return render.OutputWith(getOutputFormat, workspace.ToYAML(appName, gitRepoName), render.Options{})
+		return outputList(getOutputFormat, workspace.ToYAML(appName, gitRepoName), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -577,6 +618,17 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 		}
 	}
 
+	rebuildReason := loadRebuildChecker(sqlDS).reason(app, workspace, false)
+	status := drifts.status(workspace)
+	if rebuildReason != "" {
+		status += " (rebuild recommended)"
+	}
+
+	imageDigest := ""
+	if digests, dErr := sqlDS.ListWorkspaceImageDigests(); dErr == nil {
+		imageDigest = digests[workspace.ID]
+	}
+
 	kvData := render.NewOrderedKeyValueData(
 		render.KeyValue{Key: "Name", Value: nameDisplay},
 		render.KeyValue{Key: "App", Value: appName},
@@ -584,21 +636,41 @@ func getWorkspace(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Domain", Value: domainName},
 		render.KeyValue{Key: "Ecosystem", Value: ecosystemName},
 		render.KeyValue{Key: "Image", Value: workspace.ImageName},
-		render.KeyValue{Key: "Status", Value: workspace.Status},
+		render.KeyValue{Key: "Image Digest", Value: orDash(imageDigest)},
+		render.KeyValue{Key: "Status", Value: status},
 		render.KeyValue{Key: "Created", Value: workspace.CreatedAt.Format("2006-01-02 15:04:05")},
 	)
 
-	err = render.OutputWith(getOutputFormat, kvData, render.Options{
+	err = outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Workspace Details",
 	})
 	if err != nil {
 		return err
 	}
+	renderWorkspaceDrifts(sqlDS, drifts)
+	if rebuildReason != "" {
+		render.Blank()
+		render.Info(fmt.Sprintf("Rebuild recommended (%s): dvm build -a %s -w %s", rebuildReason, appName, workspace.Name))
+	}
 
 	// Show theme information if requested
 	if showTheme {
-		return showThemeResolution(cmd, sqlDS, themeresolver.LevelWorkspace, workspace.ID, workspace.Name)
+		if err := showThemeResolution(cmd, sqlDS, themeresolver.LevelWorkspace, workspace.ID, workspace.Name); err != nil {
+			return err
+		}
+	}
+
+	// Show package information if requested
+	if showPackages {
+		if err := showPackageResolution(sqlDS, resolver.PackageLevelWorkspace, workspace.ID); err != nil {
+			return err
+		}
+	}
+
+	// Show the resolved environment if requested
+	if showEnv {
+		return showEnvResolution(sqlDS, app, workspace)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package cmd
 import (
 	"database/sql"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/events"
 	"devopsmaestro/pkg/mirror"
 	"devopsmaestro/utils"
 	"fmt"
@@ -386,7 +387,7 @@ func runGetGitRepos(cmd *cobra.Command, args []string) error {
 	}
 
 	// Handle YAML/JSON output
-	if format == "yaml" || format == "json" {
+	if isStructuredOutput(format) {
 		return render.OutputWith(format, gitReposToYAML(repos), render.Options{})
 	}
 
@@ -402,7 +403,7 @@ func runGetGitRepos(cmd *cobra.Command, args []string) error {
 	// Build table using shared builder (with constraints)
 	tableData := BuildTable(&gitRepoTableBuilder{}, repoPtrs, isWide)
 
-	return render.OutputWith(format, tableData, render.Options{
+	return render.OutputWith(tableFormat(format), tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -427,7 +428,7 @@ func runGetGitRepo(cmd *cobra.Command, args []string) error {
 	format, _ := cmd.Flags().GetString("output")
 
 	// JSON/YAML: use ToYAML for apiVersion/kind/metadata/spec format (issue #183)
-	if format == "json" || format == "yaml" {
+	if isStructuredOutput(format) {
 		// Resolve credential name if associated
 		credentialName := ""
 		if repo.CredentialID.Valid {
@@ -548,7 +549,7 @@ func runSyncGitRepo(cmd *cobra.Command, args []string) error {
 		}
 	} else {
 		// Sync the mirror
-		if err := mirrorMgr.Sync(repo.Slug); err != nil {
+		if err := mirrorMgr.Sync(commandContext(cmd), repo.Slug); err != nil {
 			repo.SyncStatus = "failed"
 			repo.SyncError = sql.NullString{String: err.Error(), Valid: true}
 			dataStore.UpdateGitRepo(repo)
@@ -565,6 +566,7 @@ func runSyncGitRepo(cmd *cobra.Command, args []string) error {
 	}
 
 	render.Success(fmt.Sprintf("Synced gitrepo '%s'", name))
+	events.Emit(events.SyncCompleted, map[string]any{"kind": "gitrepo", "gitrepo": name})
 	return nil
 }
 
@@ -609,7 +611,7 @@ func runSyncGitRepos(cmd *cobra.Command, args []string) error {
 			}
 		} else {
 			// Sync the mirror
-			if err := mirrorMgr.Sync(repo.Slug); err != nil {
+			if err := mirrorMgr.Sync(commandContext(cmd), repo.Slug); err != nil {
 				repoPtr.SyncStatus = "failed"
 				repoPtr.SyncError = sql.NullString{String: err.Error(), Valid: true}
 				dataStore.UpdateGitRepo(repoPtr)
@@ -631,6 +633,7 @@ func runSyncGitRepos(cmd *cobra.Command, args []string) error {
 	} else {
 		render.Success(fmt.Sprintf("Synced %d repos", synced))
 	}
+	events.Emit(events.SyncCompleted, map[string]any{"kind": "gitrepos", "synced": synced, "failed": failed})
 
 	return nil
 }
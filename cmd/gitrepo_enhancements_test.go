@@ -53,7 +53,7 @@ type mockMirrorInspector struct {
 }
 
 func (m *mockMirrorInspector) Clone(url, slug string) (string, error) { return "", nil }
-func (m *mockMirrorInspector) Sync(slug string) error                 { return nil }
+func (m *mockMirrorInspector) Sync(ctx context.Context, slug string) error { return nil }
 func (m *mockMirrorInspector) Delete(slug string) error               { return nil }
 func (m *mockMirrorInspector) Exists(slug string) bool {
 	if m.existsFunc != nil {
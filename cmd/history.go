@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/linediff"
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyFlags  HierarchyFlags
+	diffFlags     HierarchyFlags
+	rollbackFlags HierarchyFlags
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <kind> <name>",
+	Short: "List the revisions of a resource",
+	Long: fmt.Sprintf(`List the recorded revisions of a resource.
+
+Every apply that changes a resource (dvm apply, edit, patch, set, rollback)
+records its YAML as a new revision; the newest %d are kept. Compare one with
+the resource as it is with 'dvm diff', and restore one with 'dvm rollback'.
+
+Examples:
+  dvm history app my-app
+  dvm history workspace dev -a api
+  dvm history ecosystem prod -o yaml`, handlers.RevisionHistoryLimit),
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeResourceKinds,
+	RunE:              runHistory,
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <kind> <name>",
+	Short: "Show how a resource changed since a revision",
+	Long: `Show the differences between a recorded revision of a resource and the
+resource as it is now, as a line diff of their YAML. Without --revision, the
+revision before the newest is compared.
+
+Examples:
+  dvm diff app my-app
+  dvm diff app my-app --revision 3
+  dvm diff workspace dev -a api --revision 2`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeResourceKinds,
+	RunE:              runDiff,
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <kind> <name>",
+	Short: "Restore a resource to a recorded revision",
+	Long: `Restore a resource to a recorded revision by applying that revision's YAML
+through the kind's handler, as 'dvm apply' would. Without --to, the revision
+before the newest is restored. The rollback is recorded as a new revision, so
+it can be rolled back in turn.
+
+Examples:
+  dvm rollback app my-app
+  dvm rollback app my-app --to 3
+  dvm rollback workspace dev -a api --to 2`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeResourceKinds,
+	RunE:              runRollback,
+}
+
+func init() {
+	AddHierarchyFlags(historyCmd, &historyFlags)
+	AddOutputFlag(historyCmd, "")
+	AddHierarchyFlags(diffCmd, &diffFlags)
+	diffCmd.Flags().Int("revision", 0, "Revision to compare (default: the one before the newest)")
+	AddHierarchyFlags(rollbackCmd, &rollbackFlags)
+	rollbackCmd.Flags().Int("to", 0, "Revision to restore (default: the one before the newest)")
+
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+// revisionsOf loads the resource args names and its revisions.
+func revisionsOf(cmd *cobra.Command, args []string, flags HierarchyFlags) (resource.Context, resource.Resource, []*models.ResourceRevision, error) {
+	kind, err := resolveResourceKind(args[0])
+	if err != nil {
+		return resource.Context{}, nil, nil, err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return resource.Context{}, nil, nil, err
+	}
+	ctx := resource.Context{DataStore: ds}
+	res, err := getStoredResource(ctx, ds, kind, args[1], flags)
+	if err != nil {
+		return resource.Context{}, nil, nil, err
+	}
+	revisions, err := handlers.Revisions(ctx, res)
+	if err != nil {
+		return resource.Context{}, nil, nil, fmt.Errorf("failed to list revisions of %s '%s': %w", kind, res.GetName(), err)
+	}
+	return ctx, res, revisions, nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("output")
+	_, res, revisions, err := revisionsOf(cmd, args, historyFlags)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		render.Info(fmt.Sprintf("No revisions recorded for %s '%s'; they are recorded from its next apply", res.GetKind(), res.GetName()))
+		return nil
+	}
+
+	if isStructuredOutput(format) {
+		out := make([]models.ResourceRevisionYAML, len(revisions))
+		for i, r := range revisions {
+			out[i] = r.ToYAML()
+		}
+		return outputData(cmd.Context(), format, out)
+	}
+	tableData := render.TableData{
+		Headers: []string{"REVISION", "MANAGER", "CREATED"},
+		Rows:    make([][]string, len(revisions)),
+	}
+	for i, r := range revisions {
+		manager := r.Manager
+		if manager == "" {
+			manager = "-"
+		}
+		tableData.Rows[i] = []string{fmt.Sprintf("%d", r.Revision), manager, r.CreatedAt.Format(time.RFC3339)}
+	}
+	return render.OutputWith("", tableData, render.Options{Type: render.TypeTable})
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	n, _ := cmd.Flags().GetInt("revision")
+	_, res, revisions, err := revisionsOf(cmd, args, diffFlags)
+	if err != nil {
+		return err
+	}
+	rev, err := handlers.FindRevision(revisions, n)
+	if err != nil {
+		return fmt.Errorf("%s '%s': %w", res.GetKind(), res.GetName(), err)
+	}
+	current, err := resource.ToYAML(res)
+	if err != nil {
+		return fmt.Errorf("failed to render %s '%s' as YAML: %w", res.GetKind(), res.GetName(), err)
+	}
+
+	diff, added, removed := linediff.Unified(rev.Content, string(current))
+	if added+removed == 0 {
+		render.Info(fmt.Sprintf("%s '%s' is unchanged since revision %d", res.GetKind(), res.GetName(), rev.Revision))
+		return nil
+	}
+	cmd.Printf("--- %s/%s revision %d\n+++ %s/%s current\n%s", res.GetKind(), res.GetName(), rev.Revision, res.GetKind(), res.GetName(), diff)
+	return nil
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	n, _ := cmd.Flags().GetInt("to")
+	kind, err := resolveResourceKind(args[0])
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := resource.Context{DataStore: ds}
+	res, err := getStoredResource(ctx, ds, kind, args[1], rollbackFlags)
+	if err != nil {
+		return err
+	}
+
+	applied, rev, err := handlers.Rollback(ctx, res, n)
+	if err != nil {
+		return err
+	}
+	render.Success(fmt.Sprintf("%s '%s' rolled back to revision %d", kind, applied.GetName(), rev.Revision))
+	reportRebuilds(applied)
+	return nil
+}
@@ -0,0 +1,272 @@
+// Package cmd provides the 'dvm set/get/delete layout' commands for per-app
+// terminal multiplexer layouts. When an app has a layout, 'dvm attach'
+// creates (or re-attaches) a named tmux/zellij session with its windows.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/terminalbridge/muxlayout"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags for the layout commands
+var (
+	layoutApp         string
+	layoutFile        string
+	layoutMultiplexer string
+	layoutWindows     []string
+	layoutDryRun      bool
+)
+
+// setLayoutCmd stores an app's multiplexer layout
+var setLayoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Set an app's terminal multiplexer layout",
+	Long: `Set the tmux or zellij layout used when attaching to the app's workspaces.
+
+On 'dvm attach', a session named <app>-<workspace> is created with these
+windows, or re-attached if it already exists, so detaching and attaching
+again restores the working layout.
+
+The layout comes from a YAML file (-f), from --window flags, or defaults to
+editor/shell/logs windows. Windows are NAME or NAME=COMMAND.
+
+Layout file:
+  multiplexer: tmux        # or zellij
+  windows:
+    - name: editor
+      command: nvim .
+    - name: shell
+    - name: logs
+      command: tail -F /tmp/app.log
+      dir: /workspace
+
+Examples:
+  dvm set layout --app api                                  # Default layout
+  dvm set layout --app api -f layout.yaml
+  dvm set layout --app api --window "editor=nvim ." --window shell --window "logs=make logs"
+  dvm set layout --app api --multiplexer zellij`,
+	Args: cobra.NoArgs,
+	RunE: runSetLayout,
+}
+
+// getLayoutCmd shows an app's multiplexer layout
+var getLayoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Get an app's terminal multiplexer layout",
+	Long: `Show the tmux/zellij layout used when attaching to the app's workspaces.
+Apps without a stored layout show the default (used with 'dvm attach --mux').
+
+Examples:
+  dvm get layout               # Active app
+  dvm get layout --app api
+  dvm get layout --app api -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: runGetLayout,
+}
+
+// deleteLayoutCmd removes an app's multiplexer layout
+var deleteLayoutCmd = &cobra.Command{
+	Use:   "layout",
+	Short: "Delete an app's terminal multiplexer layout",
+	Long: `Delete an app's tmux/zellij layout. 'dvm attach' then opens a plain shell
+again. Running sessions inside containers are not affected.
+
+Examples:
+  dvm delete layout --app api`,
+	Args: cobra.NoArgs,
+	RunE: runDeleteLayout,
+}
+
+func init() {
+	setCmd.AddCommand(setLayoutCmd)
+	getCmd.AddCommand(getLayoutCmd)
+	deleteCmd.AddCommand(deleteLayoutCmd)
+
+	for _, c := range []*cobra.Command{setLayoutCmd, getLayoutCmd, deleteLayoutCmd} {
+		c.Flags().StringVarP(&layoutApp, "app", "a", "", "App name (defaults to active app)")
+	}
+	setLayoutCmd.Flags().StringVarP(&layoutFile, "filename", "f", "", "Layout YAML file")
+	setLayoutCmd.Flags().StringVar(&layoutMultiplexer, "multiplexer", "", "Multiplexer: tmux (default) or zellij")
+	setLayoutCmd.Flags().StringArrayVar(&layoutWindows, "window", nil, "Window as NAME or NAME=COMMAND (repeatable)")
+	setLayoutCmd.MarkFlagsMutuallyExclusive("filename", "window")
+	AddDryRunFlag(setLayoutCmd, &layoutDryRun)
+}
+
+// resolveLayoutApp returns the app named by --app, or the active app.
+func resolveLayoutApp(ds db.DataStore) (*models.App, error) {
+	if layoutApp == "" {
+		app, err := getActiveApp(ds)
+		if err != nil {
+			return nil, ErrorWithSuggestion("no app specified", "Use --app <name> or 'dvm use app <name>'")
+		}
+		return app, nil
+	}
+	app, err := resolveAppByNameScoped(ds, layoutApp)
+	if err != nil {
+		return nil, fmt.Errorf("app %q not found: %w", layoutApp, err)
+	}
+	return app, nil
+}
+
+// buildLayoutFromFlags builds a layout from -f, --window, and --multiplexer.
+func buildLayoutFromFlags() (*muxlayout.Layout, error) {
+	var layout *muxlayout.Layout
+	switch {
+	case layoutFile != "":
+		data, err := os.ReadFile(layoutFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layout file: %w", err)
+		}
+		if layout, err = muxlayout.Parse(data); err != nil {
+			return nil, err
+		}
+	case len(layoutWindows) > 0:
+		layout = &muxlayout.Layout{}
+		for _, w := range layoutWindows {
+			name, command, _ := strings.Cut(w, "=")
+			layout.Windows = append(layout.Windows, muxlayout.Window{Name: strings.TrimSpace(name), Command: command})
+		}
+	default:
+		layout = muxlayout.DefaultLayout()
+	}
+	if layoutMultiplexer != "" {
+		layout.Multiplexer = layoutMultiplexer
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+func runSetLayout(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	app, err := resolveLayoutApp(ds)
+	if err != nil {
+		return err
+	}
+	layout, err := buildLayoutFromFlags()
+	if err != nil {
+		return err
+	}
+	spec, err := layout.ToYAML()
+	if err != nil {
+		return fmt.Errorf("failed to marshal layout: %w", err)
+	}
+
+	if layoutDryRun {
+		render.Info(fmt.Sprintf("Would set %s layout for app %q:", layout.GetMultiplexer(), app.Name))
+		render.Plain(string(spec))
+		return nil
+	}
+	if err := ds.SetAppSessionLayout(&models.AppSessionLayout{AppID: app.ID, Spec: string(spec)}); err != nil {
+		return err
+	}
+
+	render.Success(fmt.Sprintf("Layout set for app %q (%s, %d windows)", app.Name, layout.GetMultiplexer(), len(layout.Windows)))
+	return nil
+}
+
+func runGetLayout(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	app, err := resolveLayoutApp(ds)
+	if err != nil {
+		return err
+	}
+	layout, stored, err := loadAppLayout(ds, app.ID)
+	if err != nil {
+		return err
+	}
+	if layout == nil {
+		layout = muxlayout.DefaultLayout()
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, layout, render.Options{})
+	}
+
+	source := "app"
+	if !stored {
+		source = "default, not set"
+	}
+	render.Info(fmt.Sprintf("App: %s  Multiplexer: %s  (%s)", app.Name, layout.GetMultiplexer(), source))
+	rows := make([][]string, 0, len(layout.Windows))
+	for _, w := range layout.Windows {
+		rows = append(rows, []string{w.Name, w.Command, w.Dir})
+	}
+	return outputList(getOutputFormat, render.TableData{
+		Headers: []string{"WINDOW", "COMMAND", "DIR"},
+		Rows:    rows,
+	}, render.Options{Type: render.TypeTable})
+}
+
+func runDeleteLayout(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	app, err := resolveLayoutApp(ds)
+	if err != nil {
+		return err
+	}
+	if err := ds.DeleteAppSessionLayout(app.ID); err != nil {
+		if db.IsNotFound(err) {
+			render.Info(fmt.Sprintf("App %q has no layout", app.Name))
+			return nil
+		}
+		return err
+	}
+	render.Success(fmt.Sprintf("Layout deleted for app %q", app.Name))
+	return nil
+}
+
+// loadAppLayout returns the app's stored layout, or nil (and stored=false)
+// when it has none.
+func loadAppLayout(ds db.DataStore, appID int) (layout *muxlayout.Layout, stored bool, err error) {
+	record, err := ds.GetAppSessionLayout(appID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to load session layout: %w", err)
+	}
+	layout, err = muxlayout.Parse([]byte(record.Spec))
+	if err != nil {
+		return nil, false, fmt.Errorf("stored layout for app %d is invalid: %w", appID, err)
+	}
+	return layout, true, nil
+}
+
+// sessionLayoutCommand returns the command dvm attach runs to enter the
+// workspace's multiplexer session, or nil for a plain shell. The app's
+// stored layout is used unless disabled; force falls back to the default
+// layout when none is stored.
+func sessionLayoutCommand(ds db.DataStore, app *models.App, workspaceName, shell string, force, disabled bool) ([]string, error) {
+	if disabled {
+		return nil, nil
+	}
+	layout, _, err := loadAppLayout(ds, app.ID)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		if !force {
+			return nil, nil
+		}
+		layout = muxlayout.DefaultLayout()
+	}
+	return layout.Command(muxlayout.SessionName(app.Name, workspaceName), shell), nil
+}
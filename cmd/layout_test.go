@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/terminalbridge/muxlayout"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetLayoutFlags clears the package-level layout flags after a test.
+func resetLayoutFlags(t *testing.T) {
+	t.Cleanup(func() {
+		layoutApp, layoutFile, layoutMultiplexer, layoutWindows = "", "", "", nil
+	})
+}
+
+func TestBuildLayoutFromFlags(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		resetLayoutFlags(t)
+		layout, err := buildLayoutFromFlags()
+		require.NoError(t, err)
+		assert.Equal(t, muxlayout.DefaultLayout(), layout)
+	})
+
+	t.Run("windows and multiplexer", func(t *testing.T) {
+		resetLayoutFlags(t)
+		layoutWindows = []string{"editor=nvim .", "shell", "logs=tail -F a=b.log"}
+		layoutMultiplexer = "zellij"
+		layout, err := buildLayoutFromFlags()
+		require.NoError(t, err)
+		assert.Equal(t, "zellij", layout.Multiplexer)
+		assert.Equal(t, []muxlayout.Window{
+			{Name: "editor", Command: "nvim ."},
+			{Name: "shell"},
+			{Name: "logs", Command: "tail -F a=b.log"},
+		}, layout.Windows)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		resetLayoutFlags(t)
+		layoutFile = filepath.Join(t.TempDir(), "layout.yaml")
+		require.NoError(t, os.WriteFile(layoutFile, []byte("windows:\n  - name: editor\n"), 0644))
+		layout, err := buildLayoutFromFlags()
+		require.NoError(t, err)
+		assert.Equal(t, "tmux", layout.GetMultiplexer())
+		assert.Len(t, layout.Windows, 1)
+	})
+
+	t.Run("invalid multiplexer", func(t *testing.T) {
+		resetLayoutFlags(t)
+		layoutMultiplexer = "screen"
+		_, err := buildLayoutFromFlags()
+		assert.Error(t, err)
+	})
+}
+
+func TestSessionLayoutCommand(t *testing.T) {
+	mock := newSessionMock(t)
+	app := mock.Apps[1]
+
+	cmd, err := sessionLayoutCommand(mock, app, "dev", "/bin/zsh", false, false)
+	require.NoError(t, err)
+	assert.Nil(t, cmd, "no stored layout means a plain shell")
+
+	cmd, err = sessionLayoutCommand(mock, app, "dev", "/bin/zsh", true, false)
+	require.NoError(t, err)
+	require.Len(t, cmd, 3)
+	assert.Contains(t, cmd[2], "tmux has-session -t '=api-dev'")
+
+	require.NoError(t, mock.SetAppSessionLayout(&models.AppSessionLayout{
+		AppID: app.ID,
+		Spec:  "multiplexer: zellij\nwindows:\n  - name: editor\n",
+	}))
+	cmd, err = sessionLayoutCommand(mock, app, "dev", "/bin/zsh", false, false)
+	require.NoError(t, err)
+	require.Len(t, cmd, 3)
+	assert.Contains(t, cmd[2], "zellij --session 'api-dev'")
+
+	cmd, err = sessionLayoutCommand(mock, app, "dev", "/bin/zsh", false, true)
+	require.NoError(t, err)
+	assert.Nil(t, cmd)
+
+	mock.AppSessionLayouts[app.ID].Spec = "windows: []"
+	_, err = sessionLayoutCommand(mock, app, "dev", "/bin/zsh", false, false)
+	assert.Error(t, err)
+}
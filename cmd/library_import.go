@@ -32,56 +32,38 @@ func runLibraryImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Determine which types to import
-	var types []string
-	if allFlag {
-		types = []string{
-			"nvim-plugins",
-			"nvim-themes",
-			"nvim-packages",
-			"terminal-prompts",
-			"terminal-plugins",
-			"terminal-packages",
-			"terminal-emulators",
-		}
-	} else {
-		types = args
+	all := libraryImporters(ds)
+	byName := make(map[string]libraryImporter, len(all))
+	for _, imp := range all {
+		byName[imp.name] = imp
 	}
-
-	for _, t := range types {
-		switch t {
-		case "nvim-plugins":
-			if err := importNvimPlugins(ds); err != nil {
-				return fmt.Errorf("failed to import nvim-plugins: %w", err)
-			}
-		case "nvim-themes":
-			if err := importNvimThemes(ds); err != nil {
-				return fmt.Errorf("failed to import nvim-themes: %w", err)
-			}
-		case "nvim-packages":
-			if err := importNvimPackages(ds); err != nil {
-				return fmt.Errorf("failed to import nvim-packages: %w", err)
-			}
-		case "terminal-prompts":
-			if err := importTerminalPrompts(ds); err != nil {
-				return fmt.Errorf("failed to import terminal-prompts: %w", err)
-			}
-		case "terminal-plugins":
-			if err := importTerminalPlugins(ds); err != nil {
-				return fmt.Errorf("failed to import terminal-plugins: %w", err)
-			}
-		case "terminal-packages":
-			if err := importTerminalPackages(ds); err != nil {
-				return fmt.Errorf("failed to import terminal-packages: %w", err)
-			}
-		case "terminal-emulators":
-			if err := importTerminalEmulators(ds); err != nil {
-				return fmt.Errorf("failed to import terminal-emulators: %w", err)
+	importers := all
+	if !allFlag {
+		importers = nil
+		for _, t := range args {
+			imp, ok := byName[t]
+			if !ok {
+				return fmt.Errorf("unknown resource type: %s (valid: nvim-plugins, nvim-themes, nvim-packages, terminal-prompts, terminal-plugins, terminal-packages, terminal-emulators)", t)
 			}
-		default:
-			return fmt.Errorf("unknown resource type: %s (valid: nvim-plugins, nvim-themes, nvim-packages, terminal-prompts, terminal-plugins, terminal-packages, terminal-emulators)", t)
+			importers = append(importers, imp)
 		}
 	}
 
+	// One task with a bar across the resource types
+	reporter := newProgressReporter(cmd)
+	defer reporter.Close()
+	task := reporter.Start("library import")
+	task.SetTotal(len(importers))
+	for _, imp := range importers {
+		if err := imp.fn(); err != nil {
+			err = fmt.Errorf("failed to import %s: %w", imp.name, err)
+			task.Fail(err)
+			return err
+		}
+		task.Advance(1, imp.name)
+	}
+	task.Done()
+
 	return nil
 }
 
@@ -143,6 +125,7 @@ func importNvimPlugins(ds db.PluginStore) error {
 		return fmt.Errorf("failed to load plugin library: %w", err)
 	}
 
+	var plugins []*models.NvimPluginDB
 	for _, p := range lib.List() {
 		pluginDB := &models.NvimPluginDB{
 			Name:    p.Name,
@@ -216,12 +199,10 @@ func importNvimPlugins(ds db.PluginStore) error {
 			}
 		}
 
-		if err := ds.UpsertPlugin(pluginDB); err != nil {
-			return fmt.Errorf("failed to upsert plugin %s: %w", p.Name, err)
-		}
+		plugins = append(plugins, pluginDB)
 	}
 
-	return nil
+	return ds.UpsertPlugins(plugins)
 }
 
 // importNvimThemes loads themes from the library and creates them in the DB.
@@ -288,6 +269,7 @@ func importNvimPackages(ds db.NvimPackageStore) error {
 		return fmt.Errorf("failed to load package library: %w", err)
 	}
 
+	var pkgs []*models.NvimPackageDB
 	for _, p := range lib.List() {
 		pkgDB := &models.NvimPackageDB{
 			Name: p.Name,
@@ -306,12 +288,10 @@ func importNvimPackages(ds db.NvimPackageStore) error {
 			return fmt.Errorf("failed to set plugins for package %s: %w", p.Name, err)
 		}
 
-		if err := ds.UpsertPackage(pkgDB); err != nil {
-			return fmt.Errorf("failed to upsert package %s: %w", p.Name, err)
-		}
+		pkgs = append(pkgs, pkgDB)
 	}
 
-	return nil
+	return ds.UpsertPackages(pkgs)
 }
 
 // importTerminalPrompts loads prompts from the library and upserts them into the DB.
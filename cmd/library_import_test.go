@@ -14,9 +14,12 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"devopsmaestro/db"
+	"devopsmaestro/pkg/progress"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -158,6 +161,38 @@ func TestLibraryImportCmd_UnknownType(t *testing.T) {
 		"error should mention 'unknown' resource type")
 }
 
+// TestLibraryImportCmd_JSONProgress tests that --progress=json reports the
+// import as a JSON Lines event stream with one step per resource type.
+func TestLibraryImportCmd_JSONProgress(t *testing.T) {
+	mockStore := db.NewMockDataStore()
+	var ds db.DataStore = mockStore
+
+	oldMode := progressMode
+	progressMode = progress.ModeJSON
+	t.Cleanup(func() { progressMode = oldMode })
+
+	testRoot := newLibraryImportTestRoot(t, &ds)
+	testRoot.SetArgs([]string{"library", "import", "nvim-themes", "terminal-prompts"})
+
+	buf := new(bytes.Buffer)
+	testRoot.SetOut(buf)
+	testRoot.SetErr(buf)
+	require.NoError(t, testRoot.Execute())
+
+	var events []progress.Event
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var e progress.Event
+		require.NoError(t, json.Unmarshal([]byte(line), &e), "line %q", line)
+		events = append(events, e)
+	}
+	require.Len(t, events, 4)
+	assert.Equal(t, progress.EventStart, events[0].Event)
+	assert.Equal(t, "nvim-themes", events[1].Message)
+	assert.Equal(t, 2, events[2].Current)
+	assert.Equal(t, 2, events[2].Total)
+	assert.Equal(t, progress.EventDone, events[3].Event)
+}
+
 // =============================================================================
 // Test 6: No args and no --all flag shows an error or help
 // =============================================================================
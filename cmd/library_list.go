@@ -144,7 +144,7 @@ func listNvimPlugins(cmd *cobra.Command, outputFormat string) error {
 	plugins := lib.List()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, plugins, render.Options{})
 	}
 
@@ -169,7 +169,7 @@ func listNvimThemes(cmd *cobra.Command, outputFormat string) error {
 	}
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, themes, render.Options{})
 	}
 
@@ -196,7 +196,7 @@ func listNvimPackages(cmd *cobra.Command, outputFormat string) error {
 	packages := lib.List()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, packages, render.Options{})
 	}
 
@@ -223,7 +223,7 @@ func listTerminalPrompts(cmd *cobra.Command, outputFormat string) error {
 	prompts := lib.List()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, prompts, render.Options{})
 	}
 
@@ -250,7 +250,7 @@ func listTerminalPlugins(cmd *cobra.Command, outputFormat string) error {
 	plugins := lib.List()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, plugins, render.Options{})
 	}
 
@@ -277,7 +277,7 @@ func listTerminalPackages(cmd *cobra.Command, outputFormat string) error {
 	packages := lib.List()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, packages, render.Options{})
 	}
 
@@ -307,7 +307,7 @@ func showNvimPlugin(cmd *cobra.Command, name string, outputFormat string) error
 	}
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, plugin, render.Options{})
 	}
 
@@ -332,7 +332,7 @@ func showNvimTheme(cmd *cobra.Command, name string, outputFormat string) error {
 	}
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, theme, render.Options{})
 	}
 
@@ -362,7 +362,7 @@ func showTerminalPrompt(cmd *cobra.Command, name string, outputFormat string) er
 	}
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, prompt, render.Options{})
 	}
 
@@ -390,7 +390,7 @@ func showTerminalPlugin(cmd *cobra.Command, name string, outputFormat string) er
 	}
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, plugin, render.Options{})
 	}
 
@@ -420,7 +420,7 @@ func showNvimPackage(cmd *cobra.Command, name string, outputFormat string) error
 	w := cmd.OutOrStdout()
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputTo(w, outputFormat, p, render.Options{})
 	}
 
@@ -453,7 +453,7 @@ func showTerminalPackage(cmd *cobra.Command, name string, outputFormat string) e
 	w := cmd.OutOrStdout()
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputTo(w, outputFormat, p, render.Options{})
 	}
 
@@ -483,7 +483,7 @@ func listTerminalEmulators(cmd *cobra.Command, outputFormat string) error {
 	w := cmd.OutOrStdout()
 
 	// Convert to output format
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputTo(w, outputFormat, emulators, render.Options{})
 	}
 
@@ -515,7 +515,7 @@ func showTerminalEmulator(cmd *cobra.Command, name string, outputFormat string)
 	w := cmd.OutOrStdout()
 
 	// For structured formats (yaml/json), pass the raw struct
-	if outputFormat == "yaml" || outputFormat == "json" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputTo(w, outputFormat, emu, render.Options{})
 	}
 
@@ -54,13 +54,16 @@ func EnsureLibrarySynced(ds db.DataStore) error {
 	return nil
 }
 
-// importAllLibraries imports all embedded library resource types to the DB.
-// This is the same set of imports as `dvm library import --all`.
-func importAllLibraries(ds db.DataStore) error {
-	importers := []struct {
-		name string
-		fn   func() error
-	}{
+// libraryImporter imports one embedded library resource type.
+type libraryImporter struct {
+	name string
+	fn   func() error
+}
+
+// libraryImporters returns the importer for every library resource type, in
+// import order.
+func libraryImporters(ds db.DataStore) []libraryImporter {
+	return []libraryImporter{
 		{"nvim-plugins", func() error { return importNvimPlugins(ds) }},
 		{"nvim-themes", func() error { return importNvimThemes(ds) }},
 		{"nvim-packages", func() error { return importNvimPackages(ds) }},
@@ -69,8 +72,12 @@ func importAllLibraries(ds db.DataStore) error {
 		{"terminal-packages", func() error { return importTerminalPackages(ds) }},
 		{"terminal-emulators", func() error { return importTerminalEmulators(ds) }},
 	}
+}
 
-	for _, imp := range importers {
+// importAllLibraries imports all embedded library resource types to the DB.
+// This is the same set of imports as `dvm library import --all`.
+func importAllLibraries(ds db.DataStore) error {
+	for _, imp := range libraryImporters(ds) {
 		if err := imp.fn(); err != nil {
 			return fmt.Errorf("failed to import %s: %w", imp.name, err)
 		}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/hookrunner"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// workspaceLifecycleHooks returns the spec.hooks of a workspace: the app's
+// hooks, then the workspace's own, at each hook point.
+func workspaceLifecycleHooks(app *models.App, workspace *models.Workspace) models.LifecycleHooks {
+	return models.MergeLifecycleHooks(app.GetLifecycleHooks(), workspace.ToYAML(app.Name, "").Spec.Hooks)
+}
+
+// hookEnv returns the environment of hooks run outside an attach session:
+// the session env of dvm exec without the theme colors. Warnings about
+// credentials and spec.env go to out.
+func hookEnv(ds db.DataStore, app *models.App, workspace *models.Workspace, ecosystemName, domainName, systemName string, out io.Writer) map[string]string {
+	registryEnv, _ := loadRegistryEnv(ds)
+	credentialEnv, credWarnings := loadBuildCredentials(ds, app, workspace)
+	resolvedEnv := resolveWorkspaceEnv(ds, workspace, credentialEnv)
+	for _, w := range append(credWarnings, resolvedEnv.Warnings...) {
+		render.MsgTo(out, "", render.Message{Level: render.LevelWarning, Content: w})
+	}
+	return buildRuntimeEnv(app.Name, workspace.Name, ecosystemName, domainName, systemName, nil, registryEnv, credentialEnv, resolvedEnv.Env())
+}
+
+// containerHookExec runs container hooks through the runtime as the
+// workspace user. Runtimes without exec support fail the hook.
+func containerHookExec(runtime operators.ContainerRuntime, containerName string, uid, gid int) hookrunner.ContainerExec {
+	execer, ok := runtime.(operators.WorkspaceExecer)
+	return func(ctx context.Context, command []string, stdout io.Writer) error {
+		if !ok {
+			return fmt.Errorf("container hooks are not supported on %s", runtime.GetPlatformName())
+		}
+		return execer.ExecInWorkspace(ctx, operators.ExecOptions{
+			WorkspaceID: containerName,
+			Command:     command,
+			UID:         uid,
+			GID:         gid,
+			Stdout:      stdout,
+		})
+	}
+}
+
+// runLifecycleHooks runs the hooks of one hook point, reporting progress
+// and warnings to out. It returns the error of a hook that aborts.
+func runLifecycleHooks(ctx context.Context, out io.Writer, runner *hookrunner.Runner, hooks models.LifecycleHooks, point string) error {
+	list := hooks.At(point)
+	if len(list) == 0 {
+		return nil
+	}
+	render.MsgTo(out, "", render.Message{Level: render.LevelProgress, Content: fmt.Sprintf("Running %s hooks (%d)...", point, len(list))})
+	warnings, err := runner.Run(ctx, point, list)
+	for _, w := range warnings {
+		render.MsgTo(out, "", render.Message{Level: render.LevelWarning, Content: w})
+	}
+	return err
+}
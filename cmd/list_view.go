@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"devopsmaestro/pkg/tableview"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// listView holds the --limit, --offset, --sort-by, and --columns flags of
+// the get commands.
+var listView tableview.Options
+
+// addListViewFlags registers the list view flags as persistent flags of cmd.
+func addListViewFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.IntVar(&listView.Limit, "limit", 0, "Show at most this many rows (0 for all)")
+	flags.IntVar(&listView.Offset, "offset", 0, "Skip this many rows before the first one shown")
+	flags.StringVar(&listView.SortBy, "sort-by", "", "Sort rows by a column, e.g. name or -created for descending")
+	flags.StringSliceVar(&listView.Columns, "columns", nil, "Show only these columns, in this order (e.g. name,status)")
+}
+
+// outputList renders the output of a get command with the list view
+// applied.
+func outputList(format string, data any, opts render.Options) error {
+	return outputListWith(listView, format, data, opts)
+}
+
+// outputListWith renders data after applying view: tables are sorted, paged,
+// and narrowed to the selected columns; other lists are paged. Commands that
+// paged their query already pass view.WithoutPaging().
+func outputListWith(view tableview.Options, format string, data any, opts render.Options) error {
+	if !opts.Empty && !view.IsZero() {
+		shaped, err := applyListView(view, data)
+		if err != nil {
+			return err
+		}
+		data = shaped
+	}
+	return render.OutputWith(format, data, opts)
+}
+
+// applyListView shapes one command's output. --sort-by and --columns name
+// table columns, so they are rejected for output without a table.
+func applyListView(view tableview.Options, data any) (any, error) {
+	if td, ok := data.(render.TableData); ok {
+		return view.Apply(td)
+	}
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+	if view.SortBy != "" || len(view.Columns) > 0 {
+		return nil, ErrorWithSuggestion("--sort-by and --columns select table columns, and this output has none",
+			fmt.Sprintf("Use -o %s or -o %s, or -o jsonpath to pick fields", outputTable, outputWide))
+	}
+	return view.PageSlice(data), nil
+}
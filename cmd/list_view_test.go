@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/tableview"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withListView sets the get output format and list view flags for one test.
+func withListView(t *testing.T, format string, view tableview.Options) *bytes.Buffer {
+	t.Helper()
+	origFormat, origView, origWriter := getOutputFormat, listView, render.GetWriter()
+	t.Cleanup(func() {
+		getOutputFormat, listView = origFormat, origView
+		render.SetWriter(origWriter)
+	})
+	getOutputFormat, listView = format, view
+	var buf bytes.Buffer
+	render.SetWriter(&buf)
+	return &buf
+}
+
+func TestOutputList_ShapesTableRecords(t *testing.T) {
+	out := withListView(t, outputJSON, tableview.Options{SortBy: "-name", Limit: 2, Columns: []string{"status", "name"}})
+	td := render.TableData{
+		Headers: []string{"NAME", "APP", "STATUS"},
+		Rows: [][]string{
+			{"a", "api", "running"},
+			{"c", "web", "stopped"},
+			{"b", "api", "failed"},
+		},
+	}
+
+	require.NoError(t, outputList(getOutputFormat, td, render.Options{Type: render.TypeTable}))
+
+	var records []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &records), out.String())
+	assert.Equal(t, []map[string]string{
+		{"status": "stopped", "name": "c"},
+		{"status": "failed", "name": "b"},
+	}, records)
+}
+
+func TestOutputList_PagesSlices(t *testing.T) {
+	out := withListView(t, outputJSON, tableview.Options{Offset: 1})
+
+	require.NoError(t, outputList(getOutputFormat, []string{"a", "b", "c"}, render.Options{}))
+	assert.JSONEq(t, `["b", "c"]`, out.String())
+}
+
+func TestOutputList_RejectsColumnsWithoutTable(t *testing.T) {
+	withListView(t, outputJSON, tableview.Options{Columns: []string{"name"}})
+
+	err := outputList(getOutputFormat, []string{"a"}, render.Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--columns")
+}
+
+func TestOutputList_UnknownColumn(t *testing.T) {
+	withListView(t, outputTable, tableview.Options{SortBy: "size"})
+
+	err := outputList(getOutputFormat, render.TableData{Headers: []string{"NAME"}, Rows: [][]string{{"a"}}}, render.Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown column "size"`)
+}
+
+func TestGetWorkspacesAll_PagesInQuery(t *testing.T) {
+	ds := createFullTestDataStore(t)
+	defer ds.Close()
+
+	eco := &models.Ecosystem{Name: "page-eco"}
+	require.NoError(t, ds.CreateEcosystem(eco))
+	dom := &models.Domain{Name: "page-dom", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, ds.CreateDomain(dom))
+	app := &models.App{Name: "page-app", Path: "/tmp/page-app", DomainID: sql.NullInt64{Int64: int64(dom.ID), Valid: true}}
+	require.NoError(t, ds.CreateApp(app))
+	for _, name := range []string{"ws-a", "ws-b", "ws-c"} {
+		require.NoError(t, ds.CreateWorkspace(&models.Workspace{
+			Name: name, Slug: "page-eco/page-dom/page-app/" + name,
+			AppID: app.ID, ImageName: "golang:1.22", Status: "stopped",
+		}))
+	}
+
+	out := withListView(t, outputName, tableview.Options{Limit: 1, Offset: 1})
+	cmd := newPluralGetTestCmd(t, ds)
+	require.NoError(t, cmd.Flags().Set("all", "true"))
+
+	require.NoError(t, getWorkspaces(cmd))
+	assert.Equal(t, "ws-b", strings.TrimSpace(out.String()))
+}
@@ -2,16 +2,24 @@ package cmd
 
 import (
 	"devopsmaestro/db"
+	"devopsmaestro/pkg/telemetry"
+	"fmt"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Apply database migrations",
-	Long:  `This command applies the necessary database migrations to ensure your schema is up-to-date.`,
+	Long: `This command applies the necessary database migrations to ensure your schema is up-to-date.
+
+Use 'dvm admin migrate status' to see the current schema version and pending
+migrations, 'dvm admin migrate plan' to preview their SQL, and
+'dvm admin migrate down --to <version>' to roll back.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ds, dsErr := getDataStore(cmd)
 		if dsErr != nil {
@@ -34,7 +42,10 @@ var migrateCmd = &cobra.Command{
 		}
 
 		// Run the necessary migrations to set up the database schema
-		if err := db.RunMigrations(driver, migrationsFS); err != nil {
+		_, span := telemetry.Start(ctx, "dvm.db.migrate")
+		err := db.RunMigrations(driver, migrationsFS)
+		telemetry.End(span, err)
+		if err != nil {
 			render.Errorf("Failed to apply migrations: %v", err)
 			os.Exit(1)
 		}
@@ -43,6 +54,228 @@ var migrateCmd = &cobra.Command{
 	},
 }
 
+// migrateStatusCmd shows the schema version and pending migrations.
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations",
+	Long: `Show the database's current schema version, the newest migration shipped
+with this build, and the migrations 'dvm admin migrate' would apply.
+
+Examples:
+  dvm admin migrate status
+  dvm admin migrate status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateStatus,
+}
+
+// migratePlanCmd previews the SQL of pending (or rollback) migrations.
+var migratePlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Preview the SQL pending migrations would run",
+	Long: `Print the SQL of every migration 'dvm admin migrate' would apply, oldest
+first, without changing the database. With --to, print the down migrations
+'dvm admin migrate down --to <version>' would run instead.
+
+Examples:
+  dvm admin migrate plan
+  dvm admin migrate plan --to 25`,
+	Args: cobra.NoArgs,
+	RunE: runMigratePlan,
+}
+
+// migrateDownCmd rolls the schema back to an earlier version.
+var migrateDownCmd = &cobra.Command{
+	Use:   "down --to <version>",
+	Short: "Roll the schema back to an earlier version",
+	Long: `Roll the database schema back to an earlier version by running down
+migrations, newest first.
+
+Down migrations can drop tables and columns along with their data, so the
+steps are listed and confirmation is required (--force skips it). Take a
+backup with 'dvm admin backup' first. Running 'dvm admin migrate', or the
+automatic migration after upgrading dvm, applies the migrations again.
+
+Examples:
+  dvm admin migrate down --to 25
+  dvm admin migrate down --to 25 --force`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateDown,
+}
+
 func init() {
+	AddOutputFlag(migrateStatusCmd, "")
+	migratePlanCmd.Flags().String("to", "", "Preview rolling back to this version instead")
+	migrateDownCmd.Flags().String("to", "", "Version to roll back to (required)")
+	_ = migrateDownCmd.MarkFlagRequired("to")
+	AddForceConfirmFlag(migrateDownCmd)
+
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migratePlanCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
 	adminCmd.AddCommand(migrateCmd)
 }
+
+// migrationDriver returns the database driver of the command's DataStore.
+func migrationDriver(cmd *cobra.Command) (db.Driver, error) {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return nil, err
+	}
+	driver := ds.Driver()
+	if driver == nil {
+		return nil, fmt.Errorf("database driver not available")
+	}
+	return driver, nil
+}
+
+// parseMigrationVersion parses the --to flag.
+func parseMigrationVersion(s string) (uint, error) {
+	v, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid version %q: must be a migration number", s)
+	}
+	return uint(v), nil
+}
+
+// migrationOutput is one migration in structured output.
+type migrationOutput struct {
+	Version uint   `json:"version" yaml:"version"`
+	Name    string `json:"name" yaml:"name"`
+}
+
+// migrateStatusOutput is the structured form of 'dvm admin migrate status'.
+type migrateStatusOutput struct {
+	Current uint              `json:"current" yaml:"current"`
+	Latest  uint              `json:"latest" yaml:"latest"`
+	Dirty   bool              `json:"dirty" yaml:"dirty"`
+	Pending []migrationOutput `json:"pending" yaml:"pending"`
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	outputFmt, _ := cmd.Flags().GetString("output")
+	driver, err := migrationDriver(cmd)
+	if err != nil {
+		return err
+	}
+	migrationsFS, err := getMigrationsFSFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	status, err := db.GetMigrationStatus(driver, migrationsFS)
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(outputFmt) {
+		out := migrateStatusOutput{Current: status.Current, Latest: status.Latest, Dirty: status.Dirty, Pending: []migrationOutput{}}
+		for _, m := range status.Pending {
+			out.Pending = append(out.Pending, migrationOutput{Version: m.Version, Name: m.Name})
+		}
+		return render.OutputWith(outputFmt, out, render.Options{})
+	}
+
+	render.Info(fmt.Sprintf("Current version: %d", status.Current))
+	render.Info(fmt.Sprintf("Latest version:  %d", status.Latest))
+	if status.Dirty {
+		render.Warning(fmt.Sprintf("Migration %d failed part-way; the schema is in a dirty state", status.Current))
+	}
+	if len(status.Pending) == 0 {
+		render.Success("Database is up to date")
+		return nil
+	}
+
+	render.Blank()
+	render.Info(fmt.Sprintf("%d pending migration(s):", len(status.Pending)))
+	table := render.TableData{Headers: []string{"VERSION", "NAME"}}
+	for _, m := range status.Pending {
+		table.Rows = append(table.Rows, []string{strconv.FormatUint(uint64(m.Version), 10), m.Name})
+	}
+	if err := render.OutputWith(outputFmt, table, render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	render.Blank()
+	render.Info("Run 'dvm admin migrate' to apply them ('dvm admin migrate plan' shows the SQL)")
+	return nil
+}
+
+func runMigratePlan(cmd *cobra.Command, args []string) error {
+	to, _ := cmd.Flags().GetString("to")
+	driver, err := migrationDriver(cmd)
+	if err != nil {
+		return err
+	}
+	migrationsFS, err := getMigrationsFSFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	var steps []db.MigrationStep
+	if to != "" {
+		version, err := parseMigrationVersion(to)
+		if err != nil {
+			return err
+		}
+		steps, err = db.PlanMigrateDown(driver, migrationsFS, version)
+		if err != nil {
+			return err
+		}
+	} else {
+		steps, err = db.PlanMigrations(driver, migrationsFS)
+		if err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			render.Success("Database is up to date; no migrations to apply")
+			return nil
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	for _, step := range steps {
+		fmt.Fprintf(out, "-- %03d %s (%s: %s)\n", step.Version, step.Name, step.Direction, step.File)
+		fmt.Fprintln(out, step.SQL)
+	}
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	to, _ := cmd.Flags().GetString("to")
+	force, _ := cmd.Flags().GetBool("force")
+	version, err := parseMigrationVersion(to)
+	if err != nil {
+		return err
+	}
+	driver, err := migrationDriver(cmd)
+	if err != nil {
+		return err
+	}
+	migrationsFS, err := getMigrationsFSFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	steps, err := db.PlanMigrateDown(driver, migrationsFS, version)
+	if err != nil {
+		return err
+	}
+	render.Warning("Down migrations can drop tables and columns along with their data.")
+	render.Info("The following migrations will be rolled back:")
+	for _, step := range steps {
+		render.Info(fmt.Sprintf("  %03d %s", step.Version, step.Name))
+	}
+	render.Info("Take a backup first with 'dvm admin backup'; 'dvm admin migrate plan --to " + to + "' shows the SQL.")
+
+	confirmed, err := confirmDelete(fmt.Sprintf("Roll back %d migration(s) to version %d?", len(steps), version), force)
+	if err != nil || !confirmed {
+		return err
+	}
+
+	_, span := telemetry.Start(cmd.Context(), "dvm.db.migrate_down", attribute.Int("dvm.db.target_version", int(version)))
+	err = db.MigrateDown(driver, migrationsFS, version)
+	telemetry.End(span, err)
+	if err != nil {
+		return err
+	}
+	render.Successf("Database rolled back to version %d", version)
+	return nil
+}
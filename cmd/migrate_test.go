@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSkipAutoMigration_MigrateSubcommands(t *testing.T) {
+	for _, c := range []*cobra.Command{migrateStatusCmd, migratePlanCmd, migrateDownCmd} {
+		assert.True(t, shouldSkipAutoMigration(c), "%s must not auto-migrate", c.CommandPath())
+	}
+}
+
+func TestParseMigrationVersion(t *testing.T) {
+	v, err := parseMigrationVersion("25")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(25), v)
+
+	for _, bad := range []string{"", "-1", "v25", "latest"} {
+		_, err := parseMigrationVersion(bad)
+		assert.Error(t, err, bad)
+	}
+}
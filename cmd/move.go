@@ -131,8 +131,8 @@ Examples:
 // when set.
 func renderMoveResult(cmd *cobra.Command, result *handlers.MoveResult) error {
 	output, _ := cmd.Flags().GetString("output")
-	switch output {
-	case "yaml", "json":
+	switch {
+	case isStructuredOutput(output):
 		// Structured output: emit a small summary object via render.OutputWith.
 		payload := map[string]any{
 			"kind":         result.Kind,
@@ -293,7 +293,7 @@ func filterPluginsByNames(plugins []*plugin.Plugin, names []string) []*plugin.Pl
 
 // renderEmptyWorkspacePlugins renders the empty state for workspace plugins
 func renderEmptyWorkspacePlugins(workspaceName, appName string) error {
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		data := struct {
 			Workspace string   `json:"workspace" yaml:"workspace"`
 			App       string   `json:"app" yaml:"app"`
@@ -305,7 +305,7 @@ func renderEmptyWorkspacePlugins(workspaceName, appName string) error {
 			Plugins:   []string{},
 			Message:   "No plugins configured. Build will use all global plugins.",
 		}
-		return render.OutputWith(getOutputFormat, data, render.Options{})
+		return outputList(getOutputFormat, data, render.Options{})
 	}
 
 	render.Info(fmt.Sprintf("Workspace '%s' has no plugins configured", workspaceName))
@@ -320,7 +320,7 @@ func renderEmptyWorkspacePlugins(workspaceName, appName string) error {
 // renderWorkspacePlugins renders the workspace plugin list
 func renderWorkspacePlugins(workspaceName, appName string, plugins []*plugin.Plugin, configuredNames []string) error {
 	// For JSON/YAML output
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		pluginsYAML := make([]*plugin.PluginYAML, len(plugins))
 		for i, p := range plugins {
 			pluginsYAML[i] = p.ToYAML()
@@ -335,7 +335,7 @@ func renderWorkspacePlugins(workspaceName, appName string, plugins []*plugin.Plu
 			App:       appName,
 			Plugins:   pluginsYAML,
 		}
-		return render.OutputWith(getOutputFormat, data, render.Options{})
+		return outputList(getOutputFormat, data, render.Options{})
 	}
 
 	// Human-readable output
@@ -379,7 +379,7 @@ func renderWorkspacePlugins(workspaceName, appName string, plugins []*plugin.Plu
 		}
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -412,7 +412,7 @@ func getPackages(cmd *cobra.Command) error {
 	}
 
 	if len(resources) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No packages found",
 			EmptyHints:   []string{"dvm apply -f package.yaml"},
@@ -427,12 +427,12 @@ func getPackages(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		packagesYAML := make([]*nvimpkg.PackageYAML, len(packages))
 		for i, p := range packages {
 			packagesYAML[i] = p.ToYAML()
 		}
-		return render.OutputWith(getOutputFormat, packagesYAML, render.Options{})
+		return outputList(getOutputFormat, packagesYAML, render.Options{})
 	}
 
 	// For human output, build table data
@@ -462,7 +462,7 @@ func getPackages(cmd *cobra.Command) error {
 		}
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -482,8 +482,8 @@ func getPackage(cmd *cobra.Command, name string) error {
 	p := res.(*handlers.NvimPackageResource).Package()
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, p.ToYAML(), render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, p.ToYAML(), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -510,7 +510,7 @@ func getPackage(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Enabled", Value: enabledStr},
 	)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "Package Details",
 	})
@@ -558,8 +558,8 @@ func getNvimDefaults(cmd *cobra.Command) error {
 	}
 
 	// For structured output (JSON/YAML)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, nvimDefaults, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, nvimDefaults, render.Options{})
 	}
 
 	// For table output
@@ -592,7 +592,7 @@ func getNvimDefaults(cmd *cobra.Command) error {
 		tableData.Rows = append(tableData.Rows, []string{key, displayValue})
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"devopsmaestro/pkg/resource/handlers"
 	"devopsmaestro/pkg/source"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/rmkohlman/MaestroSDK/resource"
@@ -25,13 +26,16 @@ The -f flag accepts local files, URLs, or stdin (use '-' for stdin).
 URLs starting with http://, https://, or github: are fetched automatically.
 
 GitHub shorthand: github:user/repo/path/file.yaml
-   
+A GitHub directory (github:user/repo/plugins/) applies every YAML file in it.
+
 Examples:
   nvp apply -f telescope.yaml
   nvp apply -f plugin1.yaml -f plugin2.yaml
   nvp apply -f https://raw.githubusercontent.com/user/repo/main/plugin.yaml
   nvp apply -f github:rmkohlman/nvim-yaml-plugins/plugins/telescope.yaml
-  cat plugin.yaml | nvp apply -f -`,
+  nvp apply -f github:alice/nvim-plugins/plugins/
+  cat plugin.yaml | nvp apply -f -
+  nvp apply -f plugin.yaml --validate=false   # Skip unknown-field checks`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		files, _ := cmd.Flags().GetStringSlice("filename")
 
@@ -39,6 +43,10 @@ Examples:
 			return fmt.Errorf("must specify at least one file or URL with -f flag")
 		}
 
+		// Reject unknown fields unless --validate=false
+		validate, _ := cmd.Flags().GetBool("validate")
+		handlers.SetStrict(validate)
+
 		// Create resource context for file-based storage
 		ctx := resource.Context{
 			ConfigDir: getConfigDir(),
@@ -46,28 +54,66 @@ Examples:
 
 		// Process files and URLs using unified source resolution
 		for _, src := range files {
-			srcObj := source.Resolve(src)
-			data, displayName, err := srcObj.Read()
-			if err != nil {
-				return fmt.Errorf("failed to read %s: %w", src, err)
+			if source.IsDirectory(src) && source.IsURL(src) {
+				if err := applyDirectory(ctx, src); err != nil {
+					return err
+				}
+				continue
 			}
-
-			// Use unified resource pipeline
-			res, err := resource.Apply(ctx, data, displayName)
-			if err != nil {
-				return fmt.Errorf("failed to apply from %s: %w", displayName, err)
+			if err := applySource(ctx, source.Resolve(src), src); err != nil {
+				return err
 			}
-
-			// Determine if this was a create or update based on the resource type
-			// For now, just report success
-			slog.Info("resource applied", "kind", res.GetKind(), "name", res.GetName(), "source", displayName)
-			render.Successf("%s '%s' applied (from %s)", res.GetKind(), res.GetName(), displayName)
 		}
 
 		return nil
 	},
 }
 
+// applySource reads a single source and applies it through the unified
+// resource pipeline.
+func applySource(ctx resource.Context, srcObj source.Source, src string) error {
+	data, displayName, err := srcObj.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", src, err)
+	}
+
+	res, err := resource.Apply(ctx, data, displayName)
+	if err != nil {
+		return fmt.Errorf("failed to apply from %s: %w", displayName, err)
+	}
+
+	slog.Info("resource applied", "kind", res.GetKind(), "name", res.GetName(), "source", displayName)
+	render.Successf("%s '%s' applied (from %s)", res.GetKind(), res.GetName(), displayName)
+	return nil
+}
+
+// applyDirectory applies every YAML file in a GitHub directory, such as the
+// plugins/ directory written by "nvp export --to-git".
+func applyDirectory(ctx resource.Context, src string) error {
+	files, err := source.NewGitHubDirectorySource(src).ListFiles()
+	if err != nil {
+		return fmt.Errorf("failed to list files from %s: %w", src, err)
+	}
+	if len(files) == 0 {
+		render.Warningf("No YAML files found in %s", src)
+		return nil
+	}
+
+	failed := 0
+	for _, f := range files {
+		name := source.GetSourceName(f)
+		if err := applySource(ctx, f, name); err != nil {
+			render.WarningfToStderr("%v", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files from %s failed to apply", failed, len(files), src)
+	}
+	return nil
+}
+
 func init() {
 	applyCmd.Flags().StringSliceP("filename", "f", nil, "Plugin YAML file(s) or URL(s) to apply (use '-' for stdin)")
+	applyCmd.Flags().Bool("validate", true, "Reject fields the resource kind doesn't define (--validate=false to skip)")
 }
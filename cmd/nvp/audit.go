@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"devopsmaestro/pkg/nvimbridge/audit"
+	"devopsmaestro/pkg/source"
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// AUDIT COMMAND
+// =============================================================================
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [plugin-name]",
+	Short: "Audit stored plugins for dead, archived, or renamed repos",
+	Long: `Audit the plugins in the local store for upkeep problems.
+
+Each plugin's repository is checked via the GitHub API for:
+  - existence (deleted or private repositories)
+  - archival status
+  - last commit age (no commits in over a year)
+  - renames and transfers
+
+Plugins are also checked offline for known renames and successors
+(e.g. null-ls → none-ls) and deprecated lazy-load event names. Where
+possible, replacements are suggested from the plugin library.
+
+Use --offline to skip the GitHub checks. Set GITHUB_TOKEN for higher API
+limits. Exits non-zero when any plugin has an error-level finding.
+
+Examples:
+  nvp audit                    # Audit all stored plugins
+  nvp audit telescope          # Audit one plugin
+  nvp audit --offline          # Only the offline checks
+  nvp audit -o json            # Output as JSON`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	auditCmd.Flags().Bool("offline", false, "Skip repository checks against the GitHub API")
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	mgr, err := getManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	var plugins []*plugin.Plugin
+	if len(args) > 0 {
+		p, err := mgr.Get(args[0])
+		if err != nil {
+			return fmt.Errorf("plugin not found: %s", args[0])
+		}
+		plugins = []*plugin.Plugin{p}
+	} else {
+		plugins, err = mgr.List()
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+	}
+
+	if len(plugins) == 0 {
+		render.Info("No plugins installed")
+		return nil
+	}
+
+	auditor := &audit.Auditor{}
+	if lib, err := library.NewLibrary(); err == nil {
+		auditor.Library = lib.List()
+	}
+	if offline, _ := cmd.Flags().GetBool("offline"); !offline {
+		auditor.Checker = audit.NewGitHubChecker(source.GitHubToken())
+	}
+
+	reports := auditor.Audit(cmd.Context(), plugins)
+
+	format, _ := cmd.Flags().GetString("output")
+	if err := outputAuditReports(reports, format); err != nil {
+		return err
+	}
+	for _, r := range reports {
+		if r.Status == audit.SeverityError {
+			return errSilent
+		}
+	}
+	return nil
+}
+
+// outputAuditReports formats and prints audit reports.
+func outputAuditReports(reports []*audit.Report, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "table", "":
+		return outputAuditTable(reports)
+	default:
+		return fmt.Errorf("unknown format: %s (supported: table, json)", format)
+	}
+	return nil
+}
+
+// outputAuditTable prints one row per finding, and one row for clean plugins.
+func outputAuditTable(reports []*audit.Report) error {
+	var ok, warnings, errors int
+	tb := render.NewTableBuilder("PLUGIN", "STATUS", "LAST COMMIT", "FINDING", "SUGGESTION")
+	for _, r := range reports {
+		lastCommit := "-"
+		if r.LastCommit != nil {
+			lastCommit = audit.Age(time.Since(*r.LastCommit)) + " ago"
+		}
+
+		switch r.Status {
+		case audit.SeverityOK:
+			ok++
+		case audit.SeverityWarning:
+			warnings++
+		case audit.SeverityError:
+			errors++
+		}
+
+		if len(r.Findings) == 0 {
+			tb.AddRow(r.Plugin, string(r.Status), lastCommit, "", "")
+			continue
+		}
+		for i, f := range r.Findings {
+			name, status, last := r.Plugin, string(f.Severity), lastCommit
+			if i > 0 {
+				name, last = "", ""
+			}
+			tb.AddRow(name, status, last, render.Truncate(f.Message, 50), render.Truncate(f.Suggestion, 50))
+		}
+	}
+
+	if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	render.Plainf("\n%d ok, %d warning(s), %d error(s)", ok, warnings, errors)
+	return nil
+}
@@ -7,6 +7,9 @@ import (
 	"path/filepath"
 	"sort"
 
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/nvimbridge/provenance"
+
 	"github.com/rmkohlman/MaestroNvim/nvimops"
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroNvim/nvimops/store"
@@ -49,8 +52,23 @@ func getManager() (nvimops.Manager, error) {
 	})
 }
 
-// outputPlugins formats and prints a list of plugins.
-func outputPlugins(plugins []*plugin.Plugin, format string) error {
+// getDataStore returns the shared dvm database opened by the root command,
+// or nil when it is unavailable.
+func getDataStore(cmd *cobra.Command) db.DataStore {
+	ctx := cmd.Context()
+	if ctx == nil {
+		return nil
+	}
+	if ds, ok := ctx.Value("dataStore").(*db.DataStore); ok && ds != nil {
+		return *ds
+	}
+	return nil
+}
+
+// outputPlugins formats and prints a list of plugins. With records (store
+// plugins), YAML and JSON carry each plugin's provenance as annotations and
+// the table gains a SOURCE column.
+func outputPlugins(plugins []*plugin.Plugin, format string, records map[string]provenance.Record) error {
 	// Sort by name
 	sort.Slice(plugins, func(i, j int) bool {
 		return plugins[i].Name < plugins[j].Name
@@ -62,7 +80,7 @@ func outputPlugins(plugins []*plugin.Plugin, format string) error {
 			if i > 0 {
 				fmt.Println("---")
 			}
-			yml := p.ToYAML()
+			yml := annotatedYAML(p, records)
 			data, err := yaml.Marshal(yml)
 			if err != nil {
 				return err
@@ -72,7 +90,7 @@ func outputPlugins(plugins []*plugin.Plugin, format string) error {
 	case "json":
 		var items []*plugin.PluginYAML
 		for _, p := range plugins {
-			items = append(items, p.ToYAML())
+			items = append(items, annotatedYAML(p, records))
 		}
 		data, err := json.MarshalIndent(items, "", "  ")
 		if err != nil {
@@ -80,13 +98,25 @@ func outputPlugins(plugins []*plugin.Plugin, format string) error {
 		}
 		fmt.Println(string(data))
 	case "table", "":
-		tb := render.NewTableBuilder("NAME", "CATEGORY", "ENABLED", "DESCRIPTION")
+		headers := []string{"NAME", "CATEGORY", "ENABLED", "DESCRIPTION"}
+		if records != nil {
+			headers = []string{"NAME", "CATEGORY", "ENABLED", "SOURCE", "DESCRIPTION"}
+		}
+		tb := render.NewTableBuilder(headers...)
 		for _, p := range plugins {
 			enabled := "yes"
 			if !p.Enabled {
 				enabled = "no"
 			}
-			tb.AddRow(p.Name, p.Category, enabled, render.Truncate(p.Description, 40))
+			if records == nil {
+				tb.AddRow(p.Name, p.Category, enabled, render.Truncate(p.Description, 40))
+				continue
+			}
+			source := "-"
+			if r, ok := records[p.Name]; ok {
+				source = r.Source
+			}
+			tb.AddRow(p.Name, p.Category, enabled, source, render.Truncate(p.Description, 40))
 		}
 		return render.OutputWith(format, tb.Build(), render.Options{Type: render.TypeTable})
 	default:
@@ -95,18 +125,19 @@ func outputPlugins(plugins []*plugin.Plugin, format string) error {
 	return nil
 }
 
-// outputPlugin formats and prints a single plugin.
-func outputPlugin(p *plugin.Plugin, format string) error {
+// outputPlugin formats and prints a single plugin, annotated with its
+// provenance record when it has one.
+func outputPlugin(p *plugin.Plugin, format string, records map[string]provenance.Record) error {
 	switch format {
 	case "yaml", "":
-		yml := p.ToYAML()
+		yml := annotatedYAML(p, records)
 		data, err := yaml.Marshal(yml)
 		if err != nil {
 			return err
 		}
 		fmt.Print(string(data))
 	case "json":
-		yml := p.ToYAML()
+		yml := annotatedYAML(p, records)
 		data, err := json.MarshalIndent(yml, "", "  ")
 		if err != nil {
 			return err
@@ -118,6 +149,15 @@ func outputPlugin(p *plugin.Plugin, format string) error {
 	return nil
 }
 
+// annotatedYAML returns the YAML form of p with its provenance annotations.
+func annotatedYAML(p *plugin.Plugin, records map[string]provenance.Record) *plugin.PluginYAML {
+	yml := p.ToYAML()
+	if r, ok := records[p.Name]; ok {
+		provenance.Annotate(yml, r)
+	}
+	return yml
+}
+
 // hiddenAlias creates a hidden command that delegates to the target command.
 // Used to keep deprecated verb names (list, show, install) working without
 // showing them in --help output.
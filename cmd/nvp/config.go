@@ -123,12 +123,14 @@ This creates the full lua/workspace/ directory structure:
   - lua/workspace/core/*.lua (options, keymaps, autocmds)
   - lua/workspace/plugins/*.lua (plugin configurations)
 
-By default, files are written to ~/.config/nvim/
+By default, files are written to ~/.config/nvim/, or for the active profile
+(or --profile) to ~/.config/nvp-<name>/ with the profile's plugins and theme.
 Use --output-dir to specify a different directory.
 
 Examples:
   nvp config generate
   nvp config generate --output-dir /path/to/nvim/config
+  nvp config generate --profile writing
   nvp config generate --dry-run`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load core config
@@ -150,24 +152,39 @@ Examples:
 		}
 		defer mgr.Close()
 
-		plugins, err := mgr.List()
+		prof, err := currentProfile(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to list plugins: %w", err)
+			return err
 		}
 
-		// Filter to enabled
 		var enabled []*plugin.Plugin
-		for _, p := range plugins {
-			if p.Enabled {
-				enabled = append(enabled, p)
+		if prof != nil {
+			if enabled, err = profilePlugins(mgr, prof); err != nil {
+				return err
+			}
+		} else {
+			plugins, err := mgr.List()
+			if err != nil {
+				return fmt.Errorf("failed to list plugins: %w", err)
+			}
+
+			// Filter to enabled
+			for _, p := range plugins {
+				if p.Enabled {
+					enabled = append(enabled, p)
+				}
 			}
 		}
 
 		// Output directory
 		outputDir, _ := cmd.Flags().GetString("output-dir")
 		if outputDir == "" {
-			home, _ := os.UserHomeDir()
-			outputDir = filepath.Join(home, ".config", "nvim")
+			if prof != nil {
+				outputDir = profileConfigDir(prof)
+			} else {
+				home, _ := os.UserHomeDir()
+				outputDir = filepath.Join(home, ".config", "nvim")
+			}
 		}
 
 		// Expand ~
@@ -195,8 +212,7 @@ Examples:
 				render.Plainf("  lua/%s/plugins/%s.lua", ns, p.Name)
 			}
 			// Check for active theme
-			themeStore := getThemeStore()
-			if activeTheme, _ := themeStore.GetActive(); activeTheme != nil {
+			if activeTheme, _ := profileTheme(prof); activeTheme != nil {
 				render.Plainf("  lua/%s/plugins/colorscheme.lua (theme: %s)", ns, activeTheme.Name)
 				render.Plain("  lua/theme/init.lua")
 				render.Plain("  lua/theme/palette.lua")
@@ -211,8 +227,7 @@ Examples:
 		}
 
 		// Generate theme if active
-		themeStore := getThemeStore()
-		activeTheme, _ := themeStore.GetActive()
+		activeTheme, _ := profileTheme(prof)
 		if activeTheme != nil {
 			themeGen := theme.NewGenerator()
 			generated, err := themeGen.Generate(activeTheme)
@@ -294,6 +309,7 @@ func init() {
 	configShowCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml, json")
 	configGenerateCmd.Flags().String("output-dir", "", "Output directory (default: ~/.config/nvim)")
 	configGenerateCmd.Flags().Bool("dry-run", false, "Show what would be generated")
+	configGenerateCmd.Flags().String("profile", "", "Generate this profile instead of the active one")
 
 	// Hidden backward-compat alias for deprecated verb (show→describe)
 	// MUST be after flag definitions — shallow copy captures FlagSet pointer at copy time
@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/provenance"
+
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/spf13/cobra"
 )
@@ -31,20 +34,18 @@ var deleteCmd = &cobra.Command{
 
 		// Confirm unless forced
 		force, _ := cmd.Flags().GetBool("force")
-		if !force {
-			fmt.Printf("Delete plugin '%s'? (y/N): ", name)
-			var response string
-			fmt.Scanln(&response)
-			if response != "y" && response != "Y" {
-				render.Info("Aborted")
-				return nil
-			}
+		if _, err := interactive.Confirm(fmt.Sprintf("Delete plugin '%s'?", name), force); err != nil {
+			return err
 		}
 
 		if err := mgr.Delete(name); err != nil {
 			return fmt.Errorf("failed to delete plugin: %w", err)
 		}
 
+		if err := provenance.NewStore(getConfigDir()).Forget(name); err != nil {
+			render.WarningfToStderr("could not drop the provenance of %s: %v", name, err)
+		}
+
 		render.Successf("Plugin '%s' deleted", name)
 		return nil
 	},
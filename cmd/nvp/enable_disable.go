@@ -1,6 +1,10 @@
 package main
 
 import (
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/profile"
+	"github.com/rmkohlman/MaestroNvim/nvimops"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/spf13/cobra"
 )
@@ -12,7 +16,11 @@ import (
 var enableCmd = &cobra.Command{
 	Use:   "enable <name>...",
 	Short: "Enable plugins for Lua generation",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Enable plugins for Lua generation.
+
+While a profile is active (see 'nvp profile'), the plugins are added to the
+profile instead of the default configuration.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return setPluginsEnabled(args, true)
 	},
@@ -21,7 +29,11 @@ var enableCmd = &cobra.Command{
 var disableCmd = &cobra.Command{
 	Use:   "disable <name>...",
 	Short: "Disable plugins (exclude from Lua generation)",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Disable plugins, excluding them from Lua generation.
+
+While a profile is active (see 'nvp profile'), the plugins are removed from
+the profile instead of the default configuration.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return setPluginsEnabled(args, false)
 	},
@@ -39,6 +51,14 @@ func setPluginsEnabled(names []string, enabled bool) error {
 		action = "disabled"
 	}
 
+	active, err := getProfileStore().Active()
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return setProfilePluginsEnabled(mgr, active, names, enabled, action)
+	}
+
 	for _, name := range names {
 		p, err := mgr.Get(name)
 		if err != nil {
@@ -57,3 +77,43 @@ func setPluginsEnabled(names []string, enabled bool) error {
 
 	return nil
 }
+
+// setProfilePluginsEnabled adds or removes plugins from a profile's set.
+// Plugins can only be added when they exist in the store or library.
+func setProfilePluginsEnabled(mgr nvimops.Manager, p *profile.Profile, names []string, enabled bool, action string) error {
+	if enabled {
+		if _, missing := lookupPlugins(mgr, names); len(missing) > 0 {
+			render.WarningfToStderr("plugin not found: %s", strings.Join(missing, ", "))
+			names = without(names, missing)
+		}
+	}
+
+	var changed []string
+	for _, name := range names {
+		if p.SetEnabled(name, enabled) {
+			changed = append(changed, name)
+		}
+	}
+	if err := getProfileStore().Save(p); err != nil {
+		return err
+	}
+	for _, name := range changed {
+		render.Successf("Plugin '%s' %s in profile '%s'", name, action, p.Name)
+	}
+	return nil
+}
+
+// without returns names minus the entries in drop.
+func without(names, drop []string) []string {
+	skip := make(map[string]bool, len(drop))
+	for _, d := range drop {
+		skip[d] = true
+	}
+	var kept []string
+	for _, n := range names {
+		if !skip[n] {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/gitexport"
+	"devopsmaestro/pkg/nvimbridge/lazyexport"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// EXPORT COMMAND
+// =============================================================================
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the plugin store as a standalone Neovim config repo",
+	Long: `Export all enabled plugins as a standalone lazy.nvim configuration.
+
+The generated tree follows the LazyVim starter layout and has no dependency
+on nvp, so it can be committed to its own repository and shared with users
+who do not run nvp:
+
+  init.lua               - entry point
+  lua/config/lazy.lua    - lazy.nvim bootstrap
+  lua/plugins/<name>.lua - one plugin spec per file
+  lazy-lock.json         - pinned commits (when ~/.nvp/lazy-lock.json exists)
+  README.md              - usage and plugin list
+
+Existing files are never overwritten unless --force is given.
+
+With --to-git, the store's plugin and theme YAMLs are instead committed and
+pushed to a git repository in the layout the github: shorthand expects
+(plugins/<name>.yaml, themes/<name>.yaml), so the setup can be applied
+elsewhere with "nvp apply -f github:user/repo/plugins/". --prune removes
+YAMLs from the repository that are no longer in the store.
+
+Examples:
+  nvp export --format lazyvim --output ~/src/my-nvim-config
+  nvp export --output ./nvim-config --dry-run
+  nvp export --output ./nvim-config --no-lock --force
+  nvp export --to-git git@github.com:alice/nvim-plugins.git
+  nvp export --to-git https://github.com/alice/nvim-plugins --prune --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		outputDir, _ := cmd.Flags().GetString("output")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+		noLock, _ := cmd.Flags().GetBool("no-lock")
+		toGit, _ := cmd.Flags().GetString("to-git")
+
+		if toGit != "" {
+			return runExportToGit(cmd, toGit, dryRun)
+		}
+		if outputDir == "" {
+			return fmt.Errorf("--output or --to-git is required")
+		}
+		if strings.HasPrefix(outputDir, "~") {
+			home, _ := os.UserHomeDir()
+			outputDir = filepath.Join(home, outputDir[1:])
+		}
+
+		exporter, err := lazyexport.New(lazyexport.Format(format))
+		if err != nil {
+			return err
+		}
+
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		plugins, err := mgr.List()
+		if err != nil {
+			return fmt.Errorf("failed to list plugins: %w", err)
+		}
+
+		opts := lazyexport.Options{OutputDir: outputDir, Force: force}
+		if !noLock {
+			lockPath := filepath.Join(getConfigDir(), "lazy-lock.json")
+			if lf, err := plugin.ParseLockFile(lockPath); err == nil {
+				opts.LockFile = lf
+			} else if !os.IsNotExist(err) {
+				render.WarningfToStderr("ignoring unreadable lock file %s: %v", lockPath, err)
+			}
+		}
+
+		if dryRun {
+			files, err := exporter.Plan(plugins, opts)
+			if err != nil {
+				return err
+			}
+			render.Infof("Would export %d files to %s:", len(files), outputDir)
+			for _, f := range files {
+				render.Plainf("  %s", f.Path)
+			}
+			return nil
+		}
+
+		result, err := exporter.Export(plugins, opts)
+		if err != nil {
+			return err
+		}
+
+		if verbose {
+			for _, f := range result.Files {
+				render.Plainf("  Wrote %s", filepath.Join(result.OutputDir, f))
+			}
+		}
+		render.Successf("Exported %d plugins to %s", result.Plugins, result.OutputDir)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", string(lazyexport.FormatLazyVim),
+		"Export format ("+strings.Join(lazyexport.SupportedFormats(), ", ")+")")
+	exportCmd.Flags().String("output", "", "Output directory for the generated config")
+	exportCmd.Flags().Bool("dry-run", false, "Show the files that would be written")
+	exportCmd.Flags().Bool("force", false, "Overwrite existing files in the output directory")
+	exportCmd.Flags().Bool("no-lock", false, "Do not pin commits from ~/.nvp/lazy-lock.json")
+	exportCmd.Flags().String("to-git", "", "Commit and push plugin/theme YAMLs to this git repository")
+	exportCmd.Flags().String("branch", gitexport.DefaultBranch, "Branch to push to with --to-git")
+	exportCmd.Flags().StringP("message", "m", "", "Commit message for --to-git")
+	exportCmd.Flags().Bool("prune", false, "Remove repository YAMLs for plugins/themes no longer in the store (--to-git)")
+	exportCmd.MarkFlagsMutuallyExclusive("to-git", "output")
+}
+
+// runExportToGit publishes the store's plugins and themes to a git repository.
+func runExportToGit(cmd *cobra.Command, repoURL string, dryRun bool) error {
+	branch, _ := cmd.Flags().GetString("branch")
+	message, _ := cmd.Flags().GetString("message")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	mgr, err := getManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	plugins, err := mgr.List()
+	if err != nil {
+		return fmt.Errorf("failed to list plugins: %w", err)
+	}
+	themes, err := getThemeStore().List()
+	if err != nil {
+		return fmt.Errorf("failed to list themes: %w", err)
+	}
+
+	shorthand := gitexport.Shorthand(repoURL)
+	files, err := gitexport.Files(plugins, themes, shorthand)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		render.Infof("Would push %d files to %s (%s):", len(files), repoURL, branch)
+		for _, f := range files {
+			render.Plainf("  %s", f.Path)
+		}
+		return nil
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("Update nvp plugins (%d plugins, %d themes)", len(plugins), len(themes))
+	}
+	result, err := gitexport.Publish(cmd.Context(), files, gitexport.Options{
+		RepoURL: repoURL,
+		Branch:  branch,
+		Message: message,
+		Prune:   prune,
+	})
+	if err != nil {
+		return err
+	}
+
+	if verbose {
+		for _, f := range result.Removed {
+			render.Plainf("  Removed %s", f)
+		}
+	}
+	if result.Commit == "" {
+		render.Infof("%s is already up to date", repoURL)
+	} else {
+		render.Successf("Pushed %d plugins and %d themes to %s (%s, commit %.7s)",
+			len(plugins), len(themes), repoURL, branch, result.Commit)
+	}
+	if shorthand != "" && branch == gitexport.DefaultBranch {
+		render.Plainf("\nApply elsewhere with:\n  nvp apply -f %s/%s/", shorthand, gitexport.PluginsDir)
+	}
+	return nil
+}
@@ -7,6 +7,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge"
+	"devopsmaestro/pkg/nvimbridge/keymaps"
+	"devopsmaestro/pkg/nvimbridge/luavalidate"
+	"devopsmaestro/pkg/workspace"
+	"github.com/rmkohlman/MaestroNvim/nvimops"
 	"github.com/rmkohlman/MaestroNvim/nvimops/library"
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroSDK/render"
@@ -26,10 +33,47 @@ var generateCmd = &cobra.Command{
 By default, files are written to ~/.config/nvim/lua/plugins/nvp/
 Use --output-dir to specify a different directory.
 
+With --package, only the plugins of that package are generated, including
+those it inherits through extends. Packages are looked up in the shared
+database first, then the package library; plugins in the local store first,
+then the plugin library. Unknown plugins are an error.
+
+With --workspace, the plugin set of a dvm workspace is generated from the
+shared database: its explicit plugin list, or else the nvim package resolved
+through the hierarchy (workspace → app → domain → ecosystem → global), plus
+plugins associated with the workspace. Output goes to a per-workspace
+directory (~/.nvp/workspaces/<slug>/lua/plugins/nvp), or with --mount to the
+workspace's nvim config mount, and files for plugins no longer in the set
+are removed.
+
+With --validate, the generated files are first loaded in a headless Neovim
+(nvim must be installed; see --nvim) with an empty config, catching Lua
+syntax errors and specs that fail or do not return a table. Any error is
+reported as file:line and nothing is written. Code inside config and init
+functions is syntax-checked only, since plugins are not installed.
+
+Keymaps claimed by more than one plugin in the set are reported as
+warnings; see 'nvp keymaps check'. A keymap cheatsheet of the set is written
+alongside the specs as nvp_cheatsheet.lua, defining :NvpCheatsheet (see
+'nvp keymaps cheatsheet'); --no-cheatsheet skips it.
+
+When a profile is active (see 'nvp profile'), or with --profile, the
+profile's plugins are generated into its own config directory
+(~/.config/nvp-<name>/lua/plugins/nvp), and files for plugins no longer in
+the profile are removed.
+
+When ~/.nvp/lazy-lock.json exists (see 'nvp lock'), plugins are pinned to the
+commits it records. Use --no-lock to generate unpinned specs.
+
 Examples:
   nvp generate
+  nvp generate --package maestro-go
+  nvp generate --workspace dev --app api
+  nvp generate --workspace dev --mount
+  nvp generate --profile writing
   nvp generate --output-dir ~/.config/nvim/lua/plugins/managed
-  nvp generate --dry-run`,
+  nvp generate --dry-run
+  nvp generate --validate`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mgr, err := getManager()
 		if err != nil {
@@ -38,6 +82,73 @@ Examples:
 		defer mgr.Close()
 
 		outputDir, _ := cmd.Flags().GetString("output-dir")
+		workspaceName, _ := cmd.Flags().GetString("workspace")
+		mount, _ := cmd.Flags().GetBool("mount")
+		if mount && workspaceName == "" {
+			return fmt.Errorf("--mount requires --workspace")
+		}
+
+		var enabled []*plugin.Plugin
+		prune := false
+		switch packageName, _ := cmd.Flags().GetString("package"); {
+		case workspaceName != "":
+			appName, _ := cmd.Flags().GetString("app")
+			ws, set, err := workspacePlugins(cmd, workspaceName, appName)
+			if err != nil {
+				return err
+			}
+			enabled = set.Plugins
+			if len(set.Disabled) > 0 {
+				render.Infof("Skipping disabled plugin(s): %s", strings.Join(set.Disabled, ", "))
+			}
+			if outputDir == "" {
+				// A dedicated directory per workspace keeps workspaces isolated,
+				// so files for plugins no longer in the set are removed.
+				if outputDir, err = workspaceOutputDir(ws.Slug, mount); err != nil {
+					return err
+				}
+				prune = true
+			}
+			slog.Info("generating Lua files", "workspace", ws.Name, "package", set.Package, "associated", len(set.Associated), "plugins", len(enabled))
+		case packageName != "":
+			enabled, err = packagePlugins(cmd, mgr, packageName)
+			if err != nil {
+				return err
+			}
+			slog.Info("generating Lua files", "package", packageName, "plugins", len(enabled))
+		default:
+			p, err := currentProfile(cmd)
+			if err != nil {
+				return err
+			}
+			if p != nil {
+				if enabled, err = profilePlugins(mgr, p); err != nil {
+					return err
+				}
+				if outputDir == "" {
+					// The profile owns its directory, so stale files can be removed
+					outputDir = filepath.Join(profileConfigDir(p), "lua", "plugins", "nvp")
+					prune = true
+				}
+				slog.Info("generating Lua files", "profile", p.Name, "plugins", len(enabled))
+				break
+			}
+
+			plugins, err := mgr.List()
+			if err != nil {
+				return fmt.Errorf("failed to list plugins: %w", err)
+			}
+
+			// Filter to enabled only
+			for _, p := range plugins {
+				if p.Enabled {
+					enabled = append(enabled, p)
+				}
+			}
+
+			slog.Info("generating Lua files", "total", len(plugins), "enabled", len(enabled))
+		}
+
 		if outputDir == "" {
 			home, _ := os.UserHomeDir()
 			outputDir = filepath.Join(home, ".config", "nvim", "lua", "plugins", "nvp")
@@ -52,30 +163,41 @@ Examples:
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
 		slog.Debug("generate command", "outputDir", outputDir, "dryRun", dryRun)
 
-		plugins, err := mgr.List()
-		if err != nil {
-			return fmt.Errorf("failed to list plugins: %w", err)
+		if len(enabled) == 0 {
+			render.Info("No enabled plugins to generate")
+			return nil
 		}
+		warnKeymapConflicts(enabled)
 
-		// Filter to enabled only
-		var enabled []*plugin.Plugin
-		for _, p := range plugins {
-			if p.Enabled {
-				enabled = append(enabled, p)
+		// Generate files
+		gen := newGenerator(cmd)
+		generated := make(map[string]string, len(enabled))
+		var filenames []string
+		for _, p := range enabled {
+			lua, err := gen.GenerateLuaFile(p)
+			if err != nil {
+				render.WarningfToStderr("failed to generate %s: %v", p.Name, err)
+				continue
 			}
+			generated[p.Name+".lua"] = lua
+			filenames = append(filenames, p.Name+".lua")
+		}
+		if noCheatsheet, _ := cmd.Flags().GetBool("no-cheatsheet"); !noCheatsheet {
+			generated[cheatsheetFile] = keymaps.BuildCheatsheet(enabled).Lua()
+			filenames = append(filenames, cheatsheetFile)
 		}
 
-		slog.Info("generating Lua files", "total", len(plugins), "enabled", len(enabled))
-
-		if len(enabled) == 0 {
-			render.Info("No enabled plugins to generate")
-			return nil
+		// Validate before writing, so a failed check leaves the output as it was
+		if validate, _ := cmd.Flags().GetBool("validate"); validate {
+			if err := validateGeneratedLua(cmd, generated); err != nil {
+				return err
+			}
 		}
 
 		if dryRun {
-			render.Infof("Would generate %d Lua files to %s:", len(enabled), outputDir)
-			for _, p := range enabled {
-				render.Plainf("  %s.lua", p.Name)
+			render.Infof("Would generate %d Lua files to %s:", len(filenames), outputDir)
+			for _, filename := range filenames {
+				render.Plainf("  %s", filename)
 			}
 			return nil
 		}
@@ -85,17 +207,9 @@ Examples:
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
-		// Generate files
-		gen := plugin.NewGenerator()
-		for _, p := range enabled {
-			lua, err := gen.GenerateLuaFile(p)
-			if err != nil {
-				render.WarningfToStderr("failed to generate %s: %v", p.Name, err)
-				continue
-			}
-
-			filename := filepath.Join(outputDir, p.Name+".lua")
-			if err := os.WriteFile(filename, []byte(lua), 0644); err != nil {
+		for _, name := range filenames {
+			filename := filepath.Join(outputDir, name)
+			if err := os.WriteFile(filename, []byte(generated[name]), 0644); err != nil {
 				render.WarningfToStderr("failed to write %s: %v", filename, err)
 				continue
 			}
@@ -105,11 +219,148 @@ Examples:
 			}
 		}
 
-		render.Successf("Generated %d Lua files to %s", len(enabled), outputDir)
+		if prune {
+			pruneStaleLuaFiles(outputDir, enabled)
+		}
+
+		render.Successf("Generated %d Lua files to %s", len(filenames), outputDir)
 		return nil
 	},
 }
 
+// validateGeneratedLua loads the generated files in a headless Neovim and
+// fails with file:line diagnostics when any of them errors.
+func validateGeneratedLua(cmd *cobra.Command, files map[string]string) error {
+	nvim, _ := cmd.Flags().GetString("nvim")
+	v := &luavalidate.Validator{Nvim: nvim}
+	diags, err := v.Validate(cmd.Context(), files)
+	if err != nil {
+		return fmt.Errorf("failed to validate generated Lua: %w", err)
+	}
+	if len(diags) == 0 {
+		render.Successf("Validated %d Lua files with headless Neovim", len(files))
+		return nil
+	}
+	for _, d := range diags {
+		render.Errorf("%s", d)
+	}
+	return fmt.Errorf("generated Lua failed validation in %d file(s); nothing was written", len(diags))
+}
+
+// workspacePlugins finds a dvm workspace by slug or name and resolves its
+// plugin set from the shared database.
+func workspacePlugins(cmd *cobra.Command, name, appName string) (*models.Workspace, *nvimbridge.WorkspacePlugins, error) {
+	ds := getDataStore(cmd)
+	if ds == nil {
+		return nil, nil, fmt.Errorf("--workspace requires the dvm database (run 'dvm admin init')")
+	}
+
+	ws, err := findWorkspace(ds, name, appName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ws.Slug == "" {
+		if ws.Slug, err = ds.GetWorkspaceSlug(ws.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to get slug for workspace '%s': %w", ws.Name, err)
+		}
+	}
+
+	set, err := nvimbridge.ResolveWorkspacePlugins(ds, ws)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(set.Missing) > 0 {
+		return nil, nil, fmt.Errorf("workspace '%s' references unknown plugin(s): %s", ws.Name, strings.Join(set.Missing, ", "))
+	}
+	return ws, set, nil
+}
+
+// findWorkspace looks a workspace up by slug, then by name (optionally
+// narrowed by app). Ambiguous names are an error listing the candidates.
+func findWorkspace(ds db.DataStore, name, appName string) (*models.Workspace, error) {
+	if appName == "" {
+		if ws, err := ds.GetWorkspaceBySlug(name); err == nil && ws != nil {
+			return ws, nil
+		}
+	}
+
+	matches, err := ds.FindWorkspaces(models.WorkspaceFilter{AppName: appName, WorkspaceName: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspace '%s': %w", name, err)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("workspace '%s' not found", name)
+	case 1:
+		return matches[0].Workspace, nil
+	}
+
+	slugs := make([]string, len(matches))
+	for i, m := range matches {
+		slugs[i] = m.Workspace.Slug
+	}
+	return nil, fmt.Errorf("workspace name '%s' is ambiguous (%s); use --app or the workspace slug", name, strings.Join(slugs, ", "))
+}
+
+// workspaceOutputDir returns where a workspace's plugin specs are written:
+// the workspace's nvim config mount with --mount, otherwise a per-workspace
+// directory under the nvp config dir.
+func workspaceOutputDir(slug string, mount bool) (string, error) {
+	if mount {
+		configPath, err := workspace.GetWorkspaceConfigPath(slug)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(configPath, "nvim", "lua", "plugins", "nvp"), nil
+	}
+	return filepath.Join(getConfigDir(), "workspaces", slug, "lua", "plugins", "nvp"), nil
+}
+
+// cheatsheetFile is the keymap cheatsheet written next to generated specs.
+const cheatsheetFile = "nvp_cheatsheet.lua"
+
+// pruneStaleLuaFiles removes generated plugin files that are not part of
+// the current set.
+func pruneStaleLuaFiles(dir string, plugins []*plugin.Plugin) {
+	keep := map[string]bool{cheatsheetFile: true}
+	for _, p := range plugins {
+		keep[p.Name+".lua"] = true
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".lua" || keep[e.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			render.WarningfToStderr("failed to remove stale %s: %v", e.Name(), err)
+		} else if verbose {
+			render.Plainf("  Removed %s", e.Name())
+		}
+	}
+}
+
+// packagePlugins returns the flattened plugin set of an nvim package.
+func packagePlugins(cmd *cobra.Command, mgr nvimops.Manager, name string) ([]*plugin.Plugin, error) {
+	var pkgStore nvimbridge.PackageDataStore
+	if ds := getDataStore(cmd); ds != nil {
+		pkgStore = ds
+	}
+
+	res, err := nvimbridge.ResolvePackage(name, nvimbridge.NewPackageLookup(pkgStore))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve package: %w", err)
+	}
+
+	plugins, missing := lookupPlugins(mgr, res.Package.Plugins)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("package '%s' references unknown plugin(s): %s", name, strings.Join(missing, ", "))
+	}
+	return plugins, nil
+}
+
 var generateLuaCmd = &cobra.Command{
 	Use:   "generate-lua <name>",
 	Short: "Generate Lua for a single plugin (stdout)",
@@ -137,7 +388,7 @@ var generateLuaCmd = &cobra.Command{
 			}
 		}
 
-		gen := plugin.NewGenerator()
+		gen := newGenerator(cmd)
 		lua, err := gen.GenerateLuaFile(p)
 		if err != nil {
 			return fmt.Errorf("failed to generate Lua: %w", err)
@@ -151,4 +402,15 @@ var generateLuaCmd = &cobra.Command{
 func init() {
 	generateCmd.Flags().String("output-dir", "", "Output directory")
 	generateCmd.Flags().Bool("dry-run", false, "Show what would be generated")
+	generateCmd.Flags().String("package", "", "Generate only the plugins of this package (inheritance resolved)")
+	generateCmd.Flags().String("workspace", "", "Generate the plugin set of a dvm workspace (name or slug)")
+	generateCmd.Flags().String("app", "", "App of the workspace, when the workspace name is ambiguous")
+	generateCmd.Flags().Bool("mount", false, "With --workspace, write into the workspace's nvim config mount")
+	generateCmd.Flags().Bool("no-lock", false, "Ignore the lock file and generate unpinned specs")
+	generateCmd.Flags().Bool("no-cheatsheet", false, "Do not write the keymap cheatsheet (nvp_cheatsheet.lua)")
+	generateCmd.Flags().Bool("validate", false, "Load the generated Lua in headless Neovim and fail on errors before writing")
+	generateCmd.Flags().String("nvim", "nvim", "Neovim binary used by --validate")
+	generateCmd.Flags().String("profile", "", "Generate this profile instead of the active one")
+	generateCmd.MarkFlagsMutuallyExclusive("package", "workspace", "profile")
+	generateLuaCmd.Flags().Bool("no-lock", false, "Ignore the lock file and generate an unpinned spec")
 }
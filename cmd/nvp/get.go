@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 
+	"devopsmaestro/pkg/nvimbridge/provenance"
+
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroSDK/render"
 
@@ -21,9 +23,16 @@ var getCmd = &cobra.Command{
 With no arguments, lists all plugins in the local store.
 With a name argument, gets a specific plugin definition.
 
+Plugins imported with 'nvp source sync' carry their provenance: the
+source, version, and commit they were synced from and when, shown as
+nvp.devopsmaestro.io/* annotations and in the table's SOURCE column.
+--source lists only the plugins synced from one source ('-' for plugins
+that were not synced).
+
 Examples:
   nvp get                    # List all plugins
   nvp get -c lsp             # List plugins filtered by category
+  nvp get --source lazyvim -o table  # Plugins synced from LazyVim
   nvp get telescope          # Get specific plugin as YAML
   nvp get telescope -o json  # Get specific plugin as JSON`,
 	Args: cobra.MaximumNArgs(1),
@@ -41,6 +50,23 @@ Examples:
 				return fmt.Errorf("failed to list plugins: %w", err)
 			}
 
+			records, err := provenance.NewStore(getConfigDir()).Load()
+			if err != nil {
+				return err
+			}
+
+			// Filter by sync source
+			if source, _ := cmd.Flags().GetString("source"); source != "" {
+				var filtered []*plugin.Plugin
+				for _, p := range plugins {
+					r, ok := records[p.Name]
+					if (ok && r.Source == source) || (!ok && source == "-") {
+						filtered = append(filtered, p)
+					}
+				}
+				plugins = filtered
+			}
+
 			// Filter by category
 			category, _ := cmd.Flags().GetString("category")
 			if category != "" {
@@ -72,7 +98,7 @@ Examples:
 			}
 
 			format, _ := cmd.Flags().GetString("output")
-			return outputPlugins(plugins, format)
+			return outputPlugins(plugins, format, records)
 		}
 		// Single get mode
 		name := args[0]
@@ -105,8 +131,13 @@ Examples:
 			return nil
 		}
 
+		records, err := provenance.NewStore(getConfigDir()).Load()
+		if err != nil {
+			return err
+		}
+
 		format, _ := cmd.Flags().GetString("output")
-		return outputPlugin(p, format)
+		return outputPlugin(p, format, records)
 	},
 }
 
@@ -116,4 +147,8 @@ func init() {
 	getCmd.Flags().Bool("enabled", false, "Show only enabled plugins")
 	getCmd.Flags().Bool("disabled", false, "Show only disabled plugins")
 	getCmd.Flags().Bool("show-deps", false, "Show dependency tree for a plugin")
+	getCmd.Flags().String("source", "", "Show only plugins synced from this source ('-' for none)")
+
+	// Hidden backward-compat alias for the deprecated list verb
+	rootCmd.AddCommand(hiddenAlias("list", getCmd))
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/luaspec"
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// IMPORT COMMAND
+// =============================================================================
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import existing lazy.nvim specs into the local store",
+	Long: `Convert existing lazy.nvim plugin spec files into nvp plugin definitions.
+
+Every .lua file under the --from-lua directory (e.g. ~/.config/nvim/lua/plugins)
+is read and the spec it returns is converted: repo strings, branch/version,
+lazy-loading triggers (event, ft, cmd, keys), dependencies, build, config/init
+functions, and opts. Opts made of literals become YAML; opts using Lua
+expressions are kept as raw Lua.
+
+Constructs nvp cannot express (cond, main, import, local dir plugins, ...)
+are reported as warnings with their file and line. Plugins already in the
+store are skipped unless --force is given. Plugins matching a library
+plugin's repository take the library's name.
+
+Examples:
+  nvp import --from-lua ~/.config/nvim/lua/plugins
+  nvp import --from-lua ./plugins --dry-run     # Print the YAML instead
+  nvp import --from-lua ./plugins --force       # Overwrite existing plugins`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("from-lua")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if strings.HasPrefix(dir, "~") {
+			home, _ := os.UserHomeDir()
+			dir = filepath.Join(home, dir[1:])
+		}
+
+		conv := &luaspec.Converter{}
+		if lib, err := library.NewLibrary(); err == nil {
+			byRepo := make(map[string]string)
+			for _, p := range lib.List() {
+				byRepo[strings.ToLower(p.Repo)] = p.Name
+			}
+			conv.NameFor = func(repo string) string { return byRepo[strings.ToLower(repo)] }
+		}
+
+		result, err := conv.ConvertDir(dir)
+		if err != nil {
+			return err
+		}
+		slog.Info("converted lua specs", "dir", dir, "plugins", len(result.Plugins), "warnings", len(result.Warnings))
+
+		for _, w := range result.Warnings {
+			render.WarningfToStderr("%s", w.String())
+		}
+
+		if dryRun {
+			for i, p := range result.Plugins {
+				data, err := p.ToYAMLBytes()
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s: %w", p.Name, err)
+				}
+				if i > 0 {
+					fmt.Println("---")
+				}
+				fmt.Print(string(data))
+			}
+			return nil
+		}
+
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		imported, skipped := 0, 0
+		for _, p := range result.Plugins {
+			if _, err := mgr.Get(p.Name); err == nil && !force {
+				render.WarningfToStderr("skipped %s: already in the store (use --force to overwrite)", p.Name)
+				skipped++
+				continue
+			}
+			if err := mgr.Apply(p); err != nil {
+				render.WarningfToStderr("failed to import %s: %v", p.Name, err)
+				continue
+			}
+			imported++
+			if verbose {
+				render.Plainf("  Imported %s (%s)", p.Name, p.Repo)
+			}
+		}
+
+		render.Successf("Imported %d plugin(s) from %s (%d skipped, %d warning(s))", imported, dir, skipped, len(result.Warnings))
+		return nil
+	},
+}
+
+func init() {
+	importCmd.Flags().String("from-lua", "", "Directory of lazy.nvim spec files to import")
+	importCmd.Flags().Bool("dry-run", false, "Print the converted plugin YAML without importing")
+	importCmd.Flags().Bool("force", false, "Overwrite plugins that already exist in the store")
+	_ = importCmd.MarkFlagRequired("from-lua")
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/keymaps"
+	"github.com/rmkohlman/MaestroNvim/nvimops"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// KEYMAPS COMMANDS
+// =============================================================================
+
+var keymapsCmd = &cobra.Command{
+	Use:   "keymaps",
+	Short: "Inspect plugin key mappings",
+	Long:  `Inspect the key mappings defined by plugins.`,
+}
+
+var keymapsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report key mappings claimed by more than one plugin",
+	Long: `Merge the lazy-load keys and keymaps of a plugin set and report every mode
+and key mapped by more than one plugin. Only one of those mappings survives
+in Neovim, depending on load order.
+
+Key codes are compared case-insensitively (<Leader>ff and <leader>ff are the
+same key); mappings without a mode are normal-mode mappings. For each
+conflict, the first plugin keeps the key and the others are offered free
+keys under the same prefix.
+
+The plugin set is the enabled plugins in the store, or with --package a
+package's plugins (inheritance resolved), or with --workspace a dvm
+workspace's plugin set. Exits non-zero when conflicts are found.
+
+Examples:
+  nvp keymaps check
+  nvp keymaps check --package maestro-go
+  nvp keymaps check --workspace dev --app api
+  nvp keymaps check -o json`,
+	Args: cobra.NoArgs,
+	RunE: runKeymapsCheck,
+}
+
+func init() {
+	keymapsCmd.AddCommand(keymapsCheckCmd)
+	keymapsCmd.AddCommand(keymapsCheatsheetCmd)
+
+	keymapsCheckCmd.Flags().String("package", "", "Check the plugins of this package (inheritance resolved)")
+	keymapsCheckCmd.Flags().String("workspace", "", "Check the plugin set of a dvm workspace (name or slug)")
+	keymapsCheckCmd.Flags().String("app", "", "App of the workspace, when the workspace name is ambiguous")
+	keymapsCheckCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	keymapsCheatsheetCmd.Flags().String("package", "", "Use the plugins of this package (inheritance resolved)")
+	keymapsCheatsheetCmd.Flags().String("workspace", "", "Use the plugin set of a dvm workspace (name or slug)")
+	keymapsCheatsheetCmd.Flags().String("app", "", "App of the workspace, when the workspace name is ambiguous")
+	keymapsCheatsheetCmd.Flags().StringP("output", "o", "markdown", "Output format: markdown, lua, json")
+	keymapsCheatsheetCmd.Flags().String("file", "", "Write to this file instead of stdout")
+}
+
+func runKeymapsCheck(cmd *cobra.Command, args []string) error {
+	mgr, err := getManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	plugins, err := keymapPluginSet(cmd, mgr)
+	if err != nil {
+		return err
+	}
+	conflicts := keymaps.Check(plugins)
+
+	format, _ := cmd.Flags().GetString("output")
+	if err := outputKeymapConflicts(conflicts, len(plugins), format); err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return errSilent
+	}
+	return nil
+}
+
+// keymapPluginSet returns the plugins a keymaps command inspects: the
+// --workspace or --package set, else the active profile's plugins, else the
+// enabled plugins in the store.
+func keymapPluginSet(cmd *cobra.Command, mgr nvimops.Manager) ([]*plugin.Plugin, error) {
+	packageName, _ := cmd.Flags().GetString("package")
+	workspaceName, _ := cmd.Flags().GetString("workspace")
+	switch {
+	case workspaceName != "":
+		appName, _ := cmd.Flags().GetString("app")
+		_, set, err := workspacePlugins(cmd, workspaceName, appName)
+		if err != nil {
+			return nil, err
+		}
+		return set.Plugins, nil
+	case packageName != "":
+		return packagePlugins(cmd, mgr, packageName)
+	}
+
+	if p, err := currentProfile(cmd); err != nil {
+		return nil, err
+	} else if p != nil {
+		return profilePlugins(mgr, p)
+	}
+
+	all, err := mgr.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+	var plugins []*plugin.Plugin
+	for _, p := range all {
+		if p.Enabled {
+			plugins = append(plugins, p)
+		}
+	}
+	return plugins, nil
+}
+
+var keymapsCheatsheetCmd = &cobra.Command{
+	Use:   "cheatsheet",
+	Short: "Generate a cheatsheet of every plugin key mapping",
+	Long: `Generate a cheatsheet of the lazy-load keys and keymaps of a plugin set,
+grouped by plugin category, so bindings from synced distributions are easy
+to discover.
+
+The markdown format is a document with one table per category. The lua
+format is a lazy.nvim spec file that defines :NvpCheatsheet, which shows the
+same document in a floating window. 'nvp generate' writes the lua format
+next to the plugin specs (as nvp_cheatsheet.lua) on every run.
+
+The plugin set is chosen as for 'nvp keymaps check'.
+
+Examples:
+  nvp keymaps cheatsheet
+  nvp keymaps cheatsheet --file ~/notes/nvim-keys.md
+  nvp keymaps cheatsheet -o lua --package maestro-go
+  nvp keymaps cheatsheet -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		plugins, err := keymapPluginSet(cmd, mgr)
+		if err != nil {
+			return err
+		}
+		sheet := keymaps.BuildCheatsheet(plugins)
+
+		format, _ := cmd.Flags().GetString("output")
+		var content string
+		switch format {
+		case "markdown", "md", "":
+			content = sheet.Markdown()
+		case "lua":
+			content = sheet.Lua()
+		case "json":
+			data, err := json.MarshalIndent(sheet, "", "  ")
+			if err != nil {
+				return err
+			}
+			content = string(data) + "\n"
+		default:
+			return fmt.Errorf("unknown format: %s (supported: markdown, lua, json)", format)
+		}
+
+		path, _ := cmd.Flags().GetString("file")
+		if path == "" {
+			fmt.Print(content)
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		render.Successf("Wrote cheatsheet of %d keymap(s) to %s", sheet.Count(), path)
+		return nil
+	},
+}
+
+// outputKeymapConflicts formats and prints keymap conflicts.
+func outputKeymapConflicts(conflicts []keymaps.Conflict, pluginCount int, format string) error {
+	switch format {
+	case "json":
+		if conflicts == nil {
+			conflicts = []keymaps.Conflict{}
+		}
+		data, err := json.MarshalIndent(conflicts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "table", "":
+		if len(conflicts) == 0 {
+			render.Successf("No keymap conflicts among %d plugin(s)", pluginCount)
+			return nil
+		}
+		tb := render.NewTableBuilder("MODE", "KEY", "PLUGIN", "DESCRIPTION", "ALTERNATIVES")
+		for _, c := range conflicts {
+			suggested := map[string][]string{}
+			for _, alt := range c.Alternatives {
+				suggested[alt.Plugin] = alt.Keys
+			}
+			for i, b := range c.Bindings {
+				mode, key := c.Mode, c.Key
+				if i > 0 {
+					mode, key = "", ""
+				}
+				alternatives := strings.Join(suggested[b.Plugin], ", ")
+				if i == 0 {
+					alternatives = "(keeps key)"
+				}
+				tb.AddRow(mode, key, b.Plugin, render.Truncate(b.Desc, 40), alternatives)
+			}
+		}
+		if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+			return err
+		}
+		render.Blank()
+		render.Warningf("%d keymap conflict(s) among %d plugin(s)", len(conflicts), pluginCount)
+	default:
+		return fmt.Errorf("unknown format: %s (supported: table, json)", format)
+	}
+	return nil
+}
+
+// warnKeymapConflicts prints a warning for each keymap conflict in the
+// plugin set, so generated configs do not silently drop mappings.
+func warnKeymapConflicts(plugins []*plugin.Plugin) {
+	conflicts := keymaps.Check(plugins)
+	for _, c := range conflicts {
+		render.WarningfToStderr("keymap conflict: %s %s is mapped by %s", c.Mode, c.Key, strings.Join(c.Plugins(), ", "))
+	}
+	if len(conflicts) > 0 {
+		render.InfoToStderr("Run 'nvp keymaps check' (with the same --package or --workspace) for free alternatives")
+	}
+}
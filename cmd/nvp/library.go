@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/rmkohlman/MaestroNvim/nvimops"
 	"github.com/rmkohlman/MaestroNvim/nvimops/library"
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroSDK/render"
@@ -51,7 +52,7 @@ var libraryListCmd = &cobra.Command{
 		}
 
 		format, _ := cmd.Flags().GetString("output")
-		return outputPlugins(plugins, format)
+		return outputPlugins(plugins, format, nil)
 	},
 }
 
@@ -73,7 +74,7 @@ var libraryShowCmd = &cobra.Command{
 		}
 
 		format, _ := cmd.Flags().GetString("output")
-		return outputPlugin(p, format)
+		return outputPlugin(p, format, nil)
 	},
 }
 
@@ -123,21 +124,26 @@ Examples:
 			slog.Info("installing plugins from library", "count", len(plugins), "names", args)
 		}
 
-		for _, p := range plugins {
-			p.Enabled = true
-			if err := mgr.Apply(p); err != nil {
-				slog.Error("failed to install plugin", "name", p.Name, "error", err)
-				render.WarningfToStderr("failed to install %s: %v", p.Name, err)
-				continue
-			}
-			slog.Debug("installed plugin", "name", p.Name)
-			render.Successf("Installed %s", p.Name)
-		}
-
+		importLibraryPlugins(mgr, plugins)
 		return nil
 	},
 }
 
+// importLibraryPlugins copies library plugins into the local store,
+// enabled, warning about any that fail.
+func importLibraryPlugins(mgr nvimops.Manager, plugins []*plugin.Plugin) {
+	for _, p := range plugins {
+		p.Enabled = true
+		if err := mgr.Apply(p); err != nil {
+			slog.Error("failed to install plugin", "name", p.Name, "error", err)
+			render.WarningfToStderr("failed to install %s: %v", p.Name, err)
+			continue
+		}
+		slog.Debug("installed plugin", "name", p.Name)
+		render.Successf("Installed %s", p.Name)
+	}
+}
+
 var libraryCategoriesCmd = &cobra.Command{
 	Use:   "categories",
 	Short: "List all plugin categories",
@@ -182,12 +188,16 @@ func init() {
 	libraryCmd.AddCommand(libraryInstallCmd)
 	libraryCmd.AddCommand(libraryCategoriesCmd)
 	libraryCmd.AddCommand(libraryTagsCmd)
+	libraryCmd.AddCommand(libraryBrowseCmd)
 
 	libraryListCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
 	libraryListCmd.Flags().StringP("category", "c", "", "Filter by category")
 	libraryListCmd.Flags().StringP("tag", "t", "", "Filter by tag")
 	libraryShowCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml, json")
 	libraryInstallCmd.Flags().Bool("all", false, "Import all plugins from library")
+	libraryBrowseCmd.Flags().StringP("category", "c", "", "Only show plugins in this category")
+	libraryBrowseCmd.Flags().StringP("tag", "t", "", "Only show plugins with this tag")
+	libraryBrowseCmd.Flags().Bool("readme", false, "Start with the README preview instead of the YAML")
 
 	// Hidden backward-compat aliases for deprecated verbs (list→get, show→describe, install→import)
 	// MUST be after flag definitions — shallow copy captures FlagSet pointer at copy time
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/search"
+	"devopsmaestro/pkg/picker"
+	"devopsmaestro/pkg/source"
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var libraryBrowseCmd = &cobra.Command{
+	Use:   "browse [query]",
+	Short: "Interactively find and import library plugins",
+	Long: `Browse the plugin library in a fuzzy finder and import the plugins you pick.
+
+Type to filter by name and description. Query terms starting with '#' match
+tags and '@' match categories, so "@lsp #rust" narrows to Rust LSP plugins.
+The pane below the list previews the highlighted plugin's YAML; ctrl-r
+switches to its README (fetched from GitHub and cached in ~/.nvp/cache/readme).
+
+Keys:
+  up/down, ctrl-p/ctrl-n   Move
+  tab                      Select or unselect, then move down
+  ctrl-a                   Select all matches, or clear the selection
+  enter                    Import the selection (or the highlighted plugin)
+  esc, ctrl-c              Cancel
+
+Requires a terminal; use 'nvp search' and 'nvp library import' in scripts.
+
+Examples:
+  nvp library browse
+  nvp library browse git
+  nvp library browse --category lsp
+  nvp library browse "#ai" --readme`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lib, err := library.NewLibrary()
+		if err != nil {
+			return fmt.Errorf("failed to load library: %w", err)
+		}
+
+		plugins := lib.List()
+		if category, _ := cmd.Flags().GetString("category"); category != "" {
+			plugins = lib.ListByCategory(category)
+		}
+		if tag, _ := cmd.Flags().GetString("tag"); tag != "" {
+			plugins = filterByTag(plugins, tag)
+		}
+		if len(plugins) == 0 {
+			render.Info("No plugins found")
+			return nil
+		}
+
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		installed := make(map[string]bool)
+		if stored, err := mgr.List(); err == nil {
+			for _, p := range stored {
+				installed[p.Name] = true
+			}
+		}
+
+		items := make([]picker.Item, len(plugins))
+		for i, p := range plugins {
+			detail := p.Description
+			if installed[p.Name] {
+				detail = "[installed] " + detail
+			}
+			items[i] = picker.Item{Title: p.Name, Detail: detail, Category: p.Category, Tags: p.Tags}
+		}
+
+		fetcher := search.NewReadmeFetcher(source.GitHubToken(), filepath.Join(getConfigDir(), "cache", "readme"))
+		previews := []picker.Preview{
+			{Name: "YAML", Render: func(i int) string { return pluginPreviewYAML(plugins[i]) }},
+			{Name: "README", Render: func(i int) string {
+				readme, err := fetcher.Readme(cmd.Context(), plugins[i].Repo)
+				if err != nil {
+					return fmt.Sprintf("README unavailable: %v", err)
+				}
+				return readme
+			}},
+		}
+		if readme, _ := cmd.Flags().GetBool("readme"); readme {
+			previews[0], previews[1] = previews[1], previews[0]
+		}
+
+		opts := picker.Options{Prompt: "library> ", Previews: previews}
+		if len(args) == 1 {
+			opts.Query = args[0] + " "
+		}
+		picked, err := picker.Run(items, opts)
+		if errors.Is(err, interactive.ErrNonInteractive) {
+			return fmt.Errorf("library browse needs a terminal; use 'nvp search' and 'nvp library import' instead")
+		}
+		if err != nil {
+			return err
+		}
+
+		var selected []*plugin.Plugin
+		for _, i := range picked {
+			selected = append(selected, plugins[i])
+		}
+		if len(selected) == 0 {
+			render.Info("No plugins selected")
+			return nil
+		}
+		importLibraryPlugins(mgr, selected)
+		return nil
+	},
+}
+
+// filterByTag keeps the plugins carrying tag.
+func filterByTag(plugins []*plugin.Plugin, tag string) []*plugin.Plugin {
+	var kept []*plugin.Plugin
+	for _, p := range plugins {
+		for _, t := range p.Tags {
+			if strings.EqualFold(t, tag) {
+				kept = append(kept, p)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// pluginPreviewYAML renders a plugin definition for the preview pane.
+func pluginPreviewYAML(p *plugin.Plugin) string {
+	data, err := yaml.Marshal(p.ToYAML())
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
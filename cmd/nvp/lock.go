@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"devopsmaestro/pkg/nvimbridge/pinning"
+	"devopsmaestro/pkg/source"
 	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
 	"github.com/rmkohlman/MaestroSDK/render"
 
@@ -17,19 +20,28 @@ import (
 
 var lockCmd = &cobra.Command{
 	Use:   "lock",
-	Short: "Generate or verify a lazy-lock.json lock file",
-	Long: `Manage the lazy-lock.json lock file for reproducible plugin versions.
+	Short: "Pin plugin commits in a lazy-lock.json lock file",
+	Long: `Pin every enabled plugin to an exact git commit for reproducible environments.
 
-Without flags, generates/updates the lock file from current plugin configuration.
-With --verify, checks if the current config matches the existing lock file.
+Without flags, resolves the commit each plugin's tag or branch points to via
+the GitHub API and records it in ~/.nvp/lazy-lock.json. Plugins that are
+already pinned keep their pin; use 'nvp update --plugin <name>' to move one.
+Set GITHUB_TOKEN (or store a "github-token" secret) for higher API limits.
 
-The lock file pins specific git commits for each plugin, ensuring
-reproducible builds across environments.
+With --from, pins are imported from an existing lazy-lock.json instead, such
+as the one lazy.nvim maintains in your Neovim config.
+
+With --verify, checks that every enabled plugin is pinned and that the lock
+file has no stale entries.
+
+'nvp generate' emits pinned specs (commit = "...") whenever the lock file
+exists.
 
 Examples:
-  nvp lock                 # Generate/update lazy-lock.json
-  nvp lock --verify        # Check config matches lock file
-  nvp lock --output /path  # Write lock file to custom location`,
+  nvp lock                                      # Pin unpinned plugins
+  nvp lock --from ~/.config/nvim/lazy-lock.json # Import pins from lazy.nvim
+  nvp lock --verify                             # Check the lock file is complete
+  nvp lock --output /path                       # Write lock file to custom location`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		verify, _ := cmd.Flags().GetBool("verify")
 		if verify {
@@ -39,6 +51,39 @@ Examples:
 	},
 }
 
+// defaultLockPath returns the lock file nvp reads and writes by default.
+func defaultLockPath() string {
+	return filepath.Join(getConfigDir(), "lazy-lock.json")
+}
+
+// lockPath returns the lock file path from --output, or the default.
+func lockPath(cmd *cobra.Command) string {
+	if path, _ := cmd.Flags().GetString("output"); path != "" {
+		return path
+	}
+	return defaultLockPath()
+}
+
+// loadLockFile reads the lock file at path, returning nil if it does not exist.
+func loadLockFile(path string) (*plugin.LockFile, error) {
+	lf, err := plugin.ParseLockFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return lf, err
+}
+
+// writeLockFile writes lf to path, creating the parent directory.
+func writeLockFile(lf *plugin.LockFile, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := lf.WriteTo(path); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
 func runLockGenerate(cmd *cobra.Command) error {
 	mgr, err := getManager()
 	if err != nil {
@@ -64,24 +109,50 @@ func runLockGenerate(cmd *cobra.Command) error {
 		return nil
 	}
 
-	lf := plugin.GenerateLockFile(enabled)
+	outputPath := lockPath(cmd)
+	current, err := loadLockFile(outputPath)
+	if err != nil {
+		return err
+	}
 
-	// Determine output path
-	outputPath, _ := cmd.Flags().GetString("output")
-	if outputPath == "" {
-		outputPath = filepath.Join(getConfigDir(), "lazy-lock.json")
+	var lf *plugin.LockFile
+	var results []pinning.Result
+	if from, _ := cmd.Flags().GetString("from"); from != "" {
+		imported, err := plugin.ParseLockFile(from)
+		if err != nil {
+			return err
+		}
+		lf, results = pinning.Import(enabled, imported, current)
+	} else {
+		resolver := pinning.NewGitHubResolver(source.GitHubToken())
+		lf, results = pinning.Lock(cmd.Context(), enabled, current, resolver)
 	}
 
-	// Ensure parent directory exists
-	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	failed := 0
+	for _, r := range results {
+		switch r.Status {
+		case pinning.StatusFailed:
+			failed++
+			render.WarningfToStderr("failed to pin %s: %v", r.Plugin, r.Err)
+		case pinning.StatusSkipped:
+			if verbose {
+				render.Plainf("  %-24s skipped (%v)", r.Plugin, r.Err)
+			}
+		default:
+			if verbose || r.Status != pinning.StatusKept {
+				render.Plainf("  %-24s %s %s", r.Plugin, pinning.Short(r.Pin.Commit), r.Status)
+			}
+		}
 	}
 
-	if err := lf.WriteTo(outputPath); err != nil {
-		return fmt.Errorf("failed to write lock file: %w", err)
+	if err := writeLockFile(lf, outputPath); err != nil {
+		return err
 	}
 
 	render.Successf("Lock file written to %s (%d plugins)", outputPath, len(lf.Entries))
+	if failed > 0 {
+		return fmt.Errorf("%d plugin(s) could not be pinned", failed)
+	}
 	return nil
 }
 
@@ -92,21 +163,16 @@ func runLockVerify(cmd *cobra.Command) error {
 	}
 	defer mgr.Close()
 
-	// Determine lock file path
-	lockPath, _ := cmd.Flags().GetString("output")
-	if lockPath == "" {
-		lockPath = filepath.Join(getConfigDir(), "lazy-lock.json")
-	}
-
 	// Parse existing lock file
-	lf, err := plugin.ParseLockFile(lockPath)
+	path := lockPath(cmd)
+	lf, err := loadLockFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
-			render.Error("No lock file found at " + lockPath)
-			render.Info("Run 'nvp lock' to generate one")
-			return errSilent
-		}
-		return fmt.Errorf("failed to read lock file: %w", err)
+		return err
+	}
+	if lf == nil {
+		render.Error("No lock file found at " + path)
+		render.Info("Run 'nvp lock' to generate one")
+		return errSilent
 	}
 
 	// Get current plugins
@@ -115,7 +181,7 @@ func runLockVerify(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to list plugins: %w", err)
 	}
 
-	mismatches := lf.Verify(plugins)
+	mismatches := pinning.Verify(plugins, lf)
 	if len(mismatches) == 0 {
 		render.Success("Lock file is up to date")
 		return nil
@@ -129,7 +195,25 @@ func runLockVerify(cmd *cobra.Command) error {
 	return errSilent
 }
 
+// newGenerator returns a Lua generator that pins plugins to the commits in
+// the default lock file, if one exists and --no-lock is not set.
+func newGenerator(cmd *cobra.Command) *plugin.Generator {
+	if noLock, _ := cmd.Flags().GetBool("no-lock"); noLock {
+		return plugin.NewGenerator()
+	}
+	lf, err := loadLockFile(defaultLockPath())
+	if err != nil {
+		render.WarningfToStderr("ignoring lock file: %v", err)
+	}
+	if lf == nil {
+		return plugin.NewGenerator()
+	}
+	return plugin.NewGeneratorWithLock(lf)
+}
+
 func init() {
-	lockCmd.Flags().Bool("verify", false, "Verify config matches lock file")
+	lockCmd.Flags().Bool("verify", false, "Verify every enabled plugin is pinned")
+	lockCmd.Flags().String("from", "", "Import pins from an existing lazy-lock.json")
 	lockCmd.Flags().String("output", "", "Lock file path (default: ~/.nvp/lazy-lock.json)")
+	lockCmd.MarkFlagsMutuallyExclusive("verify", "from")
 }
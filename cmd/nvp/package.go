@@ -8,6 +8,7 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge"
 	"github.com/rmkohlman/MaestroNvim/nvimops/library"
 	nvimpackage "github.com/rmkohlman/MaestroNvim/nvimops/package"
 	packagelibrary "github.com/rmkohlman/MaestroNvim/nvimops/package/library"
@@ -156,6 +157,7 @@ Examples:
 
 		// Install each plugin
 		var installed, failed []string
+		var pluginDBs []*models.NvimPluginDB
 		for _, pluginName := range pluginNames {
 			// Get plugin from library
 			plugin, ok := pluginLib.Get(pluginName)
@@ -183,11 +185,14 @@ Examples:
 				pluginDB := &models.NvimPluginDB{}
 				if err := pluginDB.FromNvimOpsPlugin(plugin); err != nil {
 					render.Warningf("Failed to convert plugin '%s' for database: %v", pluginName, err)
-				} else if err := (*dataStore).UpsertPlugin(pluginDB); err != nil {
-					render.Warningf("Failed to save plugin '%s' to database: %v", pluginName, err)
+				} else {
+					pluginDBs = append(pluginDBs, pluginDB)
 				}
 			}
 		}
+		if err := (*dataStore).UpsertPlugins(pluginDBs); err != nil {
+			render.Warningf("Failed to save %d plugins to database: %v", len(pluginDBs), err)
+		}
 
 		// Summary
 		render.Blank()
@@ -202,52 +207,23 @@ Examples:
 	},
 }
 
-// resolvePackagePlugins resolves all plugins from a package including inheritance
+// resolvePackagePlugins resolves all plugins from a package including inheritance.
+// Parents are looked up in the library; see nvimbridge.ResolvePackage for the
+// merge rules.
 func resolvePackagePlugins(pkg *nvimpackage.Package, lib *packagelibrary.Library) ([]string, error) {
-	var result []string
-	visited := make(map[string]bool)
-
-	var resolve func(p *nvimpackage.Package) error
-	resolve = func(p *nvimpackage.Package) error {
-		if visited[p.Name] {
-			return fmt.Errorf("circular dependency detected: %s", p.Name)
-		}
-		visited[p.Name] = true
-		defer func() { visited[p.Name] = false }()
-
-		// If this package extends another, resolve parent first
-		if p.Extends != "" {
-			parent, ok := lib.Get(p.Extends)
-			if !ok {
-				return fmt.Errorf("package %s extends %s, but %s not found in library", p.Name, p.Extends, p.Extends)
-			}
-			if err := resolve(parent); err != nil {
-				return err
-			}
-		}
-
-		// Add this package's plugins
-		for _, pluginName := range p.Plugins {
-			if !contains(result, pluginName) {
-				result = append(result, pluginName)
-			}
+	res, err := nvimbridge.ResolvePackage(pkg.Name, func(name string) (*nvimpackage.Package, error) {
+		if name == pkg.Name {
+			return pkg, nil
 		}
-
-		return nil
-	}
-
-	err := resolve(pkg)
-	return result, err
-}
-
-// contains checks if a slice contains a string
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
+		if p, ok := lib.Get(name); ok {
+			return p, nil
 		}
+		return nil, fmt.Errorf("package %s not found in library", name)
+	})
+	if err != nil {
+		return nil, err
 	}
-	return false
+	return res.Package.Plugins, nil
 }
 
 // outputPackages outputs packages in the specified format
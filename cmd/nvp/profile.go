@@ -0,0 +1,406 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/profile"
+	"github.com/rmkohlman/MaestroNvim/nvimops"
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+	theme "github.com/rmkohlman/MaestroTheme"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// PROFILE COMMANDS
+// =============================================================================
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage independent plugin profiles",
+	Long: `Manage named profiles, each with its own set of enabled plugins and theme.
+
+Profiles let you keep separate Neovim setups such as "minimal", "writing",
+and "full-ide" side by side. While a profile is active, 'nvp enable',
+'nvp disable', and 'nvp theme use' change the profile instead of the
+default configuration, and 'nvp generate', 'nvp config generate', and
+'nvp theme generate' write into the profile's own config directory:
+
+  ~/.config/nvp-<name>/
+
+Start Neovim with a profile by setting NVIM_APPNAME (see 'nvp profile env'):
+
+  NVIM_APPNAME=nvp-writing nvim
+
+Examples:
+  nvp profile create minimal --plugins telescope,treesitter
+  nvp profile create full-ide --from-enabled --theme tokyonight-night
+  nvp profile use writing
+  nvp profile use --none              # Back to the default configuration
+  nvp profile get
+  eval "$(nvp profile env writing)"`,
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a profile",
+	Long: `Create a profile with its own enabled plugins and theme.
+
+With --from-enabled, the profile starts with the plugins currently enabled
+in the default configuration.
+
+Examples:
+  nvp profile create minimal --plugins telescope,treesitter
+  nvp profile create full-ide --from-enabled --theme tokyonight-night --use`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := profile.ValidateName(name); err != nil {
+			return err
+		}
+		store := getProfileStore()
+		if store.Exists(name) {
+			return fmt.Errorf("profile '%s' already exists", name)
+		}
+
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		description, _ := cmd.Flags().GetString("description")
+		themeName, _ := cmd.Flags().GetString("theme")
+		names, _ := cmd.Flags().GetStringSlice("plugins")
+		p := &profile.Profile{Name: name, Description: description, Theme: themeName}
+
+		if themeName != "" {
+			if _, err := getThemeStore().Get(themeName); err != nil {
+				return err
+			}
+		}
+		if fromEnabled, _ := cmd.Flags().GetBool("from-enabled"); fromEnabled {
+			plugins, err := mgr.List()
+			if err != nil {
+				return fmt.Errorf("failed to list plugins: %w", err)
+			}
+			for _, pl := range plugins {
+				if pl.Enabled {
+					p.SetEnabled(pl.Name, true)
+				}
+			}
+		}
+		if _, missing := lookupPlugins(mgr, names); len(missing) > 0 {
+			return fmt.Errorf("unknown plugin(s): %s", strings.Join(missing, ", "))
+		}
+		for _, n := range names {
+			p.SetEnabled(n, true)
+		}
+
+		if err := store.Save(p); err != nil {
+			return err
+		}
+		render.Successf("Profile '%s' created with %d plugin(s)", name, len(p.Plugins))
+
+		if use, _ := cmd.Flags().GetBool("use"); use {
+			if err := store.SetActive(name); err != nil {
+				return err
+			}
+			render.Successf("Active profile set to '%s'", name)
+		}
+		return nil
+	},
+}
+
+var profileGetCmd = &cobra.Command{
+	Use:   "get [name]",
+	Short: "Get profile(s)",
+	Long: `Get profiles.
+
+With no arguments, lists all profiles (active profile marked with *).
+With a name argument, gets a specific profile.
+
+Examples:
+  nvp profile get
+  nvp profile get writing -o yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := getProfileStore()
+		format, _ := cmd.Flags().GetString("output")
+
+		var profiles []*profile.Profile
+		if len(args) == 1 {
+			p, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			profiles = []*profile.Profile{p}
+		} else {
+			var err error
+			if profiles, err = store.List(); err != nil {
+				return err
+			}
+			if len(profiles) == 0 && (format == "table" || format == "") {
+				render.Info("No profiles defined")
+				render.Info("Use 'nvp profile create <name>' to create one")
+				return nil
+			}
+		}
+
+		active, err := store.ActiveName()
+		if err != nil {
+			return err
+		}
+		return outputProfiles(profiles, format, active)
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the active profile",
+	Long: `Set the active profile, or with --none return to the default configuration.
+
+Examples:
+  nvp profile use writing
+  nvp profile use --none`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := getProfileStore()
+		if none, _ := cmd.Flags().GetBool("none"); none {
+			if len(args) > 0 {
+				return fmt.Errorf("--none does not take a profile name")
+			}
+			if err := store.ClearActive(); err != nil {
+				return err
+			}
+			render.Success("Using the default configuration (no active profile)")
+			return nil
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("requires a profile name or --none")
+		}
+
+		name := args[0]
+		if err := store.SetActive(name); err != nil {
+			return err
+		}
+		render.Successf("Active profile set to '%s'", name)
+		render.Infof("Run 'nvp generate' to write its plugins, then start Neovim with NVIM_APPNAME=%s%s", profile.AppNamePrefix, name)
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Long: `Delete a profile definition. Its generated config directory is kept;
+remove it by hand if no longer needed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		store := getProfileStore()
+		if _, err := store.Get(name); err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if _, err := interactive.Confirm(fmt.Sprintf("Delete profile '%s'?", name), force); err != nil {
+			return err
+		}
+
+		if err := store.Delete(name); err != nil {
+			return err
+		}
+		render.Successf("Profile '%s' deleted", name)
+		return nil
+	},
+}
+
+var profileEnvCmd = &cobra.Command{
+	Use:   "env [name]",
+	Short: "Print the NVIM_APPNAME setting for a profile",
+	Long: `Print shell code that points Neovim at a profile's config directory.
+
+Without a name, uses the active profile. With --alias, prints an alias
+(nvim-<name>) instead of an export, suitable for a shell rc file.
+
+Examples:
+  eval "$(nvp profile env writing)"
+  nvp profile env --alias >> ~/.zshrc`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := getProfileStore()
+		alias, _ := cmd.Flags().GetBool("alias")
+
+		var profiles []*profile.Profile
+		switch {
+		case len(args) == 1:
+			p, err := store.Get(args[0])
+			if err != nil {
+				return err
+			}
+			profiles = []*profile.Profile{p}
+		case alias:
+			var err error
+			if profiles, err = store.List(); err != nil {
+				return err
+			}
+		default:
+			p, err := store.Active()
+			if err != nil {
+				return err
+			}
+			if p == nil {
+				return fmt.Errorf("no active profile; pass a profile name")
+			}
+			profiles = []*profile.Profile{p}
+		}
+
+		for _, p := range profiles {
+			if alias {
+				fmt.Printf("alias nvim-%s='NVIM_APPNAME=%s nvim'\n", p.Name, p.AppName())
+			} else {
+				fmt.Printf("export NVIM_APPNAME=%s\n", p.AppName())
+			}
+		}
+		return nil
+	},
+}
+
+// getProfileStore returns the profile store under the nvp config dir.
+func getProfileStore() *profile.Store {
+	return profile.NewStore(filepath.Join(getConfigDir(), "profiles"))
+}
+
+// currentProfile returns the profile a command operates on: the one named
+// by its --profile flag, else the active profile, else nil for the default
+// configuration.
+func currentProfile(cmd *cobra.Command) (*profile.Profile, error) {
+	store := getProfileStore()
+	if f := cmd.Flags().Lookup("profile"); f != nil && f.Value.String() != "" {
+		return store.Get(f.Value.String())
+	}
+	return store.Active()
+}
+
+// nvimConfigHome returns the directory Neovim resolves NVIM_APPNAME against.
+func nvimConfigHome() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config")
+}
+
+// profileConfigDir returns the Neovim config directory for p.
+func profileConfigDir(p *profile.Profile) string {
+	return p.ConfigDir(nvimConfigHome())
+}
+
+// profilePlugins returns the plugins enabled in a profile, looked up in the
+// local store first, then the plugin library.
+func profilePlugins(mgr nvimops.Manager, p *profile.Profile) ([]*plugin.Plugin, error) {
+	plugins, missing := lookupPlugins(mgr, p.Plugins)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("profile '%s' references unknown plugin(s): %s", p.Name, strings.Join(missing, ", "))
+	}
+	return plugins, nil
+}
+
+// lookupPlugins resolves plugin names against the local store, then the
+// plugin library, returning the names found in neither.
+func lookupPlugins(mgr nvimops.Manager, names []string) ([]*plugin.Plugin, []string) {
+	lib, _ := library.NewLibrary()
+	var plugins []*plugin.Plugin
+	var missing []string
+	for _, name := range names {
+		if p, err := mgr.Get(name); err == nil {
+			plugins = append(plugins, p)
+			continue
+		}
+		if lib != nil {
+			if p, ok := lib.Get(name); ok {
+				plugins = append(plugins, p)
+				continue
+			}
+		}
+		missing = append(missing, name)
+	}
+	return plugins, missing
+}
+
+// profileTheme returns the theme for p: its own theme when set, otherwise
+// the globally active theme. It returns nil when neither is set.
+func profileTheme(p *profile.Profile) (*theme.Theme, error) {
+	themeStore := getThemeStore()
+	if p != nil && p.Theme != "" {
+		return themeStore.Get(p.Theme)
+	}
+	return themeStore.GetActive()
+}
+
+// outputProfiles formats and prints profiles.
+func outputProfiles(profiles []*profile.Profile, format, active string) error {
+	switch format {
+	case "yaml":
+		for i, p := range profiles {
+			if i > 0 {
+				fmt.Println("---")
+			}
+			data, err := yaml.Marshal(p)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(data))
+		}
+	case "json":
+		data, err := json.MarshalIndent(profiles, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "table", "":
+		tb := render.NewTableBuilder("NAME", "PLUGINS", "THEME", "NVIM_APPNAME", "DESCRIPTION")
+		for _, p := range profiles {
+			name := p.Name
+			if p.Name == active {
+				name += " *"
+			}
+			themeName := p.Theme
+			if themeName == "" {
+				themeName = "-"
+			}
+			tb.AddRow(name, fmt.Sprintf("%d", len(p.Plugins)), themeName, p.AppName(), render.Truncate(p.Description, 40))
+		}
+		return render.OutputWith(format, tb.Build(), render.Options{Type: render.TypeTable})
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+	return nil
+}
+
+func init() {
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileGetCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	profileCmd.AddCommand(profileEnvCmd)
+
+	profileCreateCmd.Flags().String("description", "", "Profile description")
+	profileCreateCmd.Flags().String("theme", "", "Theme for this profile (default: the globally active theme)")
+	profileCreateCmd.Flags().StringSlice("plugins", nil, "Plugins to enable in the profile")
+	profileCreateCmd.Flags().Bool("from-enabled", false, "Start with the plugins enabled in the default configuration")
+	profileCreateCmd.Flags().Bool("use", false, "Set as active profile after creation")
+	profileGetCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	profileUseCmd.Flags().Bool("none", false, "Clear the active profile and use the default configuration")
+	profileDeleteCmd.Flags().Bool("force", false, "Skip confirmation")
+	profileEnvCmd.Flags().Bool("alias", false, "Print nvim-<name> aliases (all profiles when no name is given)")
+}
@@ -9,10 +9,21 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"devopsmaestro/config"
 	"devopsmaestro/db"
 	"devopsmaestro/pkg/colorbridge"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/githubapi"
+	"devopsmaestro/pkg/httpclient"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/execsource"
+	"devopsmaestro/pkg/nvimbridge/lazyvimsource"
+	"devopsmaestro/pkg/nvimbridge/syncfetch"
 	"devopsmaestro/pkg/resource/handlers"
+	"devopsmaestro/pkg/source"
+	"devopsmaestro/pkg/tablestyle"
 	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
 	"github.com/rmkohlman/MaestroNvim/nvimops/sync/sources"
 	"github.com/rmkohlman/MaestroSDK/colors"
@@ -87,6 +98,11 @@ func commandRequiresDatabase(cmd *cobra.Command) bool {
 		return true
 	case commandName == "sync":
 		return true
+	case cmd.Parent() != nil && cmd.Parent().Name() == "sync":
+		// nvp sync history/show read the sync history from the database
+		return true
+	case commandName == "generate" && cmd.Flags().Changed("workspace"):
+		return true
 	case commandName == "get" && cmd.Parent() != nil && cmd.Parent().Name() == "nvp":
 		// "nvp get" reads from DB if available, but can fall back to file store
 		return false
@@ -155,7 +171,15 @@ Configuration is stored in ~/.nvp/ by default.`,
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+
+	// Wait for pending event deliveries; retries left at the deadline are dropped
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if flushErr := events.Flush(ctx); flushErr != nil {
+		slog.Warn("gave up delivering events", "error", flushErr)
+	}
+	return err
 }
 
 func init() {
@@ -164,6 +188,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable debug logging")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to file (JSON format)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	interactive.AddFlags(rootCmd.PersistentFlags())
 
 	// Initialize logging and ColorProvider before any command runs
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
@@ -185,6 +210,7 @@ func init() {
 		if err != nil {
 			slog.Warn("using default colors", "error", err)
 		}
+		tablestyle.Register(colors.FromContextOrDefault(ctx), noColor)
 		cmd.SetContext(ctx)
 
 		// Check if this is a command that doesn't need database
@@ -202,6 +228,17 @@ func init() {
 			if err := setupDatabaseConfig(); err != nil {
 				return fmt.Errorf("database config: %w", err)
 			}
+			if err := httpclient.Configure(config.GetConfig().Network.HTTPSettings()); err != nil {
+				slog.Warn("ignoring network settings", "error", err)
+			}
+			syncfetch.Configure(config.GetConfig().Network.FetchOptions())
+			githubapi.Register(source.GitHubToken, filepath.Join(getConfigDir(), "cache", "github"))
+			eventSettings := config.GetConfig().Events.EventSettings()
+			if err := eventSettings.Validate(); err != nil {
+				slog.Warn("ignoring events settings", "error", err)
+			} else {
+				events.Configure("nvp", eventSettings)
+			}
 
 			// Create DataStore instance
 			dataStore, err := db.CreateDataStore()
@@ -262,6 +299,7 @@ func init() {
 
 	// Register resource handlers for unified pipeline
 	handlers.RegisterAll()
+	handlers.SetWarningHandler(func(msg string) { render.WarningfToStderr("%s", msg) })
 
 	// Initialize sync sources registry with builtin sources
 	if err := sync.InitializeGlobalRegistry(); err != nil {
@@ -274,13 +312,27 @@ func init() {
 		// Log warning but don't fail - some sources will use placeholder handlers
 		slog.Warn("failed to register source handlers", "error", err)
 	}
+	// Fetch LazyVim through the shared parallel pipeline
+	if err := lazyvimsource.Register(sync.GetGlobalRegistry()); err != nil {
+		slog.Warn("failed to register the LazyVim source", "error", err)
+	}
+
+	// Register nvp-source-* executables on PATH as external sources
+	if names := execsource.RegisterAll(sync.GetGlobalRegistry(), os.Getenv("PATH")); len(names) > 0 {
+		slog.Debug("registered external sources", "sources", names)
+	}
+
+	// Register the sources saved with 'nvp source add' and drop disabled ones
+	registerSavedSources()
 
 	// Add all commands
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(libraryCmd)
+	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(packageCmd)
 	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(getCmd)
 	rootCmd.AddCommand(deleteCmd)
 	rootCmd.AddCommand(enableCmd)
@@ -291,7 +343,12 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(keymapsCmd)
 	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(profileCmd)
 }
 
 // initLogging configures the global slog logger based on flags.
@@ -314,6 +314,12 @@ func TestCommandRequiresDatabase(t *testing.T) {
 			parent:      &cobra.Command{Use: "nvp"},
 			wantRequire: true,
 		},
+		{
+			name:        "sync history requires DB",
+			cmd:         &cobra.Command{Use: "history"},
+			parent:      &cobra.Command{Use: "sync"},
+			wantRequire: true,
+		},
 		{
 			name:        "get does not require DB (file store fallback)",
 			cmd:         &cobra.Command{Use: "get"},
@@ -361,6 +367,23 @@ func TestCommandRequiresDatabase(t *testing.T) {
 	}
 }
 
+// Test generate --workspace reads the shared database and so requires it
+func TestCommandRequiresDatabase_GenerateWorkspace(t *testing.T) {
+	cmd := &cobra.Command{Use: "generate"}
+	cmd.Flags().String("workspace", "", "")
+	(&cobra.Command{Use: "nvp"}).AddCommand(cmd)
+
+	if commandRequiresDatabase(cmd) {
+		t.Error("generate without --workspace should not require DB")
+	}
+	if err := cmd.Flags().Set("workspace", "dev"); err != nil {
+		t.Fatal(err)
+	}
+	if !commandRequiresDatabase(cmd) {
+		t.Error("generate --workspace should require DB")
+	}
+}
+
 // =============================================================================
 // Delete Command Flag Tests
 // =============================================================================
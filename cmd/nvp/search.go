@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/search"
+	"devopsmaestro/pkg/source"
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// SEARCH COMMAND
+// =============================================================================
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>...",
+	Short: "Search plugins across the library and local store",
+	Long: `Search plugin names, descriptions, tags, categories, and repositories across
+the embedded library and the local store.
+
+Every query term must match. Results are ranked by where they match (name,
+then tags, repo, category, description) with installed plugins first among
+equals. The STATE column shows whether a plugin is installed and enabled.
+
+With --readme, plugin READMEs are fetched from GitHub (cached in
+~/.nvp/cache/readme for a week) and searched too, showing a snippet around
+the match. With --sources, plugins from the registered sync sources
+(LazyVim, AstroNvim, ...) are included.
+
+Examples:
+  nvp search fuzzy finder
+  nvp search git --installed
+  nvp search "session" --readme
+  nvp search lsp --sources -o json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	searchCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	searchCmd.Flags().Bool("readme", false, "Also search plugin READMEs (fetched from GitHub)")
+	searchCmd.Flags().Bool("sources", false, "Include plugins from registered sync sources")
+	searchCmd.Flags().Bool("installed", false, "Only show installed plugins")
+	searchCmd.Flags().Int("limit", 25, "Maximum number of results (0 for all)")
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	idx := search.NewIndex()
+
+	mgr, err := getManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+	stored, err := mgr.List()
+	if err != nil {
+		return fmt.Errorf("failed to list plugins: %w", err)
+	}
+	idx.AddStore(stored)
+
+	if lib, err := library.NewLibrary(); err == nil {
+		idx.AddPlugins(lib.List(), search.OriginLibrary)
+	} else {
+		render.WarningfToStderr("failed to load library: %v", err)
+	}
+
+	if withSources, _ := cmd.Flags().GetBool("sources"); withSources {
+		addSourcePlugins(cmd, idx)
+	}
+
+	if withReadme, _ := cmd.Flags().GetBool("readme"); withReadme {
+		fetcher := search.NewReadmeFetcher(source.GitHubToken(), filepath.Join(getConfigDir(), "cache", "readme"))
+		errs := idx.FetchReadmes(cmd.Context(), fetcher, 8)
+		if len(errs) > 0 {
+			render.WarningfToStderr("could not fetch %d README(s)", len(errs))
+			if verbose {
+				for repo, err := range errs {
+					render.WarningfToStderr("  %s: %v", repo, err)
+				}
+			}
+		}
+	}
+
+	results := idx.Search(strings.Join(args, " "))
+	if installedOnly, _ := cmd.Flags().GetBool("installed"); installedOnly {
+		var filtered []search.Result
+		for _, r := range results {
+			if r.Installed {
+				filtered = append(filtered, r)
+			}
+		}
+		results = filtered
+	}
+	total := len(results)
+	if limit, _ := cmd.Flags().GetInt("limit"); limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	format, _ := cmd.Flags().GetString("output")
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "table", "":
+	default:
+		return fmt.Errorf("unknown format: %s (supported: table, json)", format)
+	}
+
+	if len(results) == 0 {
+		render.Info("No plugins found")
+		return nil
+	}
+
+	withSnippets := false
+	for _, r := range results {
+		if r.Snippet != "" {
+			withSnippets = true
+			break
+		}
+	}
+
+	headers := []string{"NAME", "STATE", "ORIGIN", "CATEGORY", "DESCRIPTION"}
+	if withSnippets {
+		headers = append(headers, "README")
+	}
+	tb := render.NewTableBuilder(headers...)
+	for _, r := range results {
+		row := []string{r.Name, searchState(r.Entry), r.Origin, r.Category, render.Truncate(r.Description, 40)}
+		if withSnippets {
+			row = append(row, render.Truncate(r.Snippet, 50))
+		}
+		tb.AddRow(row...)
+	}
+	if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	if total > len(results) {
+		render.Infof("Showing %d of %d results (use --limit 0 for all)", len(results), total)
+	}
+	return nil
+}
+
+// searchState describes a result's install state.
+func searchState(e search.Entry) string {
+	switch {
+	case e.Installed && e.Enabled:
+		return "enabled"
+	case e.Installed:
+		return "disabled"
+	default:
+		return "-"
+	}
+}
+
+// addSourcePlugins indexes plugins offered by the registered sync sources.
+func addSourcePlugins(cmd *cobra.Command, idx *search.Index) {
+	factory := sync.NewSourceHandlerFactory()
+	for _, name := range factory.ListSources() {
+		handler, err := factory.CreateHandler(name)
+		if err != nil {
+			render.WarningfToStderr("could not load source %s: %v", name, err)
+			continue
+		}
+		available, err := handler.ListAvailable(cmd.Context())
+		if err != nil {
+			render.WarningfToStderr("could not list plugins from source %s: %v", name, err)
+			continue
+		}
+		for _, p := range available {
+			idx.Add(p.Name, p.Repo, p.Description, p.Category, nil, "source:"+name)
+		}
+	}
+}
@@ -2,8 +2,22 @@ package main
 
 import (
 	"fmt"
+	"log/slog"
+	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+
+	"devopsmaestro/config"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/gitsource"
+	"devopsmaestro/pkg/nvimbridge/provenance"
+	"devopsmaestro/pkg/nvimbridge/sourceconfig"
+	"devopsmaestro/pkg/nvimbridge/syncfetch"
+	"devopsmaestro/pkg/nvimbridge/synchistory"
 
 	nvimpackage "github.com/rmkohlman/MaestroNvim/nvimops/package"
 	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
@@ -25,16 +39,27 @@ External sources allow you to import plugin configurations from popular
 Neovim distributions and configurations. This provides a starting point
 for your own customizations while following proven patterns.
 
+Any executable named nvp-source-<name> on PATH is added as the source
+<name>; see the external sources documentation for its JSON contract.
+Any git repository of plugin YAML or Lua specs can be added with
+'nvp source add'. Added sources and which sources are turned off are
+saved in ~/.nvp/sources.yaml.
+
 Available Commands:
   get       List available sources with descriptions  
   describe  Show detailed information about a source
+  add       Add a git repository as a source
+  remove    Remove an added source
+  enable    Turn a source back on
+  disable   Turn a source off
   sync      Sync plugins from an external source
 
 Examples:
   nvp source get                     # List all available sources
   nvp source describe lazyvim        # Show LazyVim source details
   nvp source sync lazyvim            # Sync all LazyVim plugins
-  nvp source sync lazyvim --dry-run  # Preview what would be synced`,
+  nvp source sync lazyvim --dry-run  # Preview what would be synced
+  nvp source add mycorp git@github.com:corp/nvim-plugins.git --path specs/`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Default behavior is to list sources
 		return sourceListCmd.RunE(cmd, args)
@@ -58,25 +83,29 @@ Examples:
 		factory := sync.NewSourceHandlerFactory()
 		sources := factory.ListSources()
 
-		if len(sources) == 0 {
+		if len(sources) == 0 && len(disabledSources) == 0 {
 			render.Info("No external sources available")
 			render.Info("Check the documentation for how to register external sources.")
 			return nil
 		}
 
 		// Get info for each source
-		var sourceInfos []*sync.SourceInfo
+		var listings []sourceListing
 		for _, sourceName := range sources {
 			info, err := factory.GetHandlerInfo(sourceName)
 			if err != nil {
 				render.WarningfToStderr("could not get info for source %s: %v", sourceName, err)
 				continue
 			}
-			sourceInfos = append(sourceInfos, info)
+			listings = append(listings, sourceListing{SourceInfo: info, Enabled: true})
+		}
+		for _, info := range disabledSources {
+			listings = append(listings, sourceListing{SourceInfo: info})
 		}
+		sort.Slice(listings, func(i, j int) bool { return listings[i].Name < listings[j].Name })
 
 		format, _ := cmd.Flags().GetString("output")
-		return outputSources(sourceInfos, format)
+		return outputSources(listings, format)
 	},
 }
 
@@ -97,21 +126,393 @@ Examples:
 
 		factory := sync.NewSourceHandlerFactory()
 
-		// Check if source exists
-		if !factory.IsSupported(sourceName) {
+		detail := sourceDetail{sourceListing: sourceListing{Enabled: true}}
+		if info, ok := disabledSources[sourceName]; ok {
+			detail.SourceInfo, detail.Enabled = info, false
+		} else if !factory.IsSupported(sourceName) {
+			// Check if source exists
 			return fmt.Errorf("source not found: %s\n\nUse 'nvp source get' to see available sources", sourceName)
+		} else {
+			info, err := factory.GetHandlerInfo(sourceName)
+			if err != nil {
+				return fmt.Errorf("failed to get source info: %w", err)
+			}
+			detail.SourceInfo = info
 		}
+		if saved, err := sourceconfig.NewStore(getConfigDir()).Get(sourceName); err == nil {
+			detail.Config = saved
+		}
+
+		format, _ := cmd.Flags().GetString("output")
+		return outputSource(detail, format)
+	},
+}
+
+var sourceAddCmd = &cobra.Command{
+	Use:   "add <name> <url>",
+	Short: "Add a git repository as a source",
+	Long: `Add a git repository as a plugin source, saved in ~/.nvp/sources.yaml.
+
+Syncing the source fetches the repository and imports every NvimPlugin YAML
+file (.yaml, .yml) and lazy.nvim Lua spec (.lua) selected by --path into the
+plugin store, and creates a package named after the source.
+
+--path takes globs relative to the repository root and may be repeated. A
+path ending in '/' selects everything below it and '**' matches any number
+of directories; without --path the whole repository is read. --ref selects
+a branch, tag, or commit (default: the remote's default branch); 'nvp
+source sync --tag' overrides it for one sync.
+
+-l/--selector saves label filters applied to every sync of the source;
+selectors given to 'nvp source sync' take precedence.
+
+Any URL git can clone works, with the credentials git already uses for it
+(SSH keys, credential helpers). For https remotes that need a token, --auth
+names where to find it; the token itself is never saved:
+  env:<VAR>      the environment variable VAR
+  secret:<name>  MaestroVault, then DVM_SECRET_<NAME>, then <NAME>
+
+Examples:
+  nvp source add mycorp git@github.com:corp/nvim-plugins.git --path specs/
+  nvp source add team https://github.com/team/nvim --ref v2 --path 'lua/plugins/*.lua'
+  nvp source add corp-lsp https://git.corp.dev/nvim/specs.git -l category=lsp --auth env:CORP_TOKEN
+  nvp source add mycorp git@github.com:corp/nvim-plugins.git --path specs/ --path extra/**/*.yaml --force
+  nvp source sync mycorp`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, url := args[0], args[1]
+		ref, _ := cmd.Flags().GetString("ref")
+		paths, _ := cmd.Flags().GetStringSlice("path")
+		selectors, _ := cmd.Flags().GetStringSlice("selector")
+		auth, _ := cmd.Flags().GetString("auth")
+		disabled, _ := cmd.Flags().GetBool("disabled")
+		force, _ := cmd.Flags().GetBool("force")
 
-		info, err := factory.GetHandlerInfo(sourceName)
+		filters, err := parseSelectors(selectors)
 		if err != nil {
-			return fmt.Errorf("failed to get source info: %w", err)
+			return err
+		}
+		src := sourceconfig.Source{
+			Name:     name,
+			Type:     gitsource.Type,
+			URL:      url,
+			Ref:      ref,
+			Paths:    paths,
+			Filters:  filters,
+			Auth:     auth,
+			Disabled: disabled,
+		}
+		if err := src.Validate(); err != nil {
+			return err
 		}
 
-		format, _ := cmd.Flags().GetString("output")
-		return outputSource(info, format)
+		store := sourceconfig.NewStore(getConfigDir())
+		if _, err := store.Get(name); err == nil && !force {
+			return fmt.Errorf("source %q already exists; use --force to replace it", name)
+		}
+		if info, ok := providedSource(name); ok {
+			return fmt.Errorf("source %q is already provided by nvp (%s); choose another name", name, info.Type)
+		}
+
+		if err := store.Save(src); err != nil {
+			return err
+		}
+		render.Successf("Source '%s' added (%s)", name, gitsource.New(src.GitConfig(), sourceCacheDir()).Description())
+		if disabled {
+			render.Info("Enable it with: nvp source enable " + name)
+		} else {
+			render.Info("Sync it with: nvp source sync " + name)
+		}
+		return nil
 	},
 }
 
+var sourceRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an added source",
+	Long: `Remove a source added with 'nvp source add', along with its cached
+checkout. Plugins already synced from it stay in the store unless
+--purge is given, which deletes them too.
+
+Sources provided by nvp itself cannot be removed; turn them off with
+'nvp source disable'.
+
+Examples:
+  nvp source remove mycorp
+  nvp source remove mycorp --purge --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		store := sourceconfig.NewStore(getConfigDir())
+		if _, err := store.Get(name); err != nil {
+			if _, ok := providedSource(name); ok {
+				return fmt.Errorf("source %q is provided by nvp and cannot be removed; use 'nvp source disable %s'", name, name)
+			}
+			return err
+		}
+		purge, _ := cmd.Flags().GetBool("purge")
+		if purge {
+			if err := purgeSourcePlugins(cmd, name); err != nil {
+				return err
+			}
+		}
+		if err := store.Remove(name); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(sourceCacheDir(), name)); err != nil {
+			render.WarningfToStderr("could not remove the cached checkout of %s: %v", name, err)
+		}
+		render.Successf("Source '%s' removed", name)
+		return nil
+	},
+}
+
+// purgeSourcePlugins deletes the plugins synced from source, after
+// confirmation unless --force is set.
+func purgeSourcePlugins(cmd *cobra.Command, source string) error {
+	records := provenance.NewStore(getConfigDir())
+	names, err := records.FromSource(source)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	msg := fmt.Sprintf("Delete %d plugin(s) synced from '%s' (%s)?", len(names), source, strings.Join(names, ", "))
+	if _, err := interactive.Confirm(msg, force); err != nil {
+		return err
+	}
+
+	mgr, err := getManager()
+	if err != nil {
+		return err
+	}
+	defer mgr.Close()
+
+	var deleted []string
+	for _, name := range names {
+		if _, err := mgr.Get(name); err == nil {
+			if err := mgr.Delete(name); err != nil {
+				return fmt.Errorf("failed to delete plugin %s: %w", name, err)
+			}
+			deleted = append(deleted, name)
+		}
+	}
+	// Records of plugins already deleted by hand are dropped too
+	if err := records.Forget(names...); err != nil {
+		return err
+	}
+	if len(deleted) > 0 {
+		render.Infof("Deleted %d plugin(s) synced from %s: %s", len(deleted), source, strings.Join(deleted, ", "))
+	}
+	return nil
+}
+
+var sourceEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Turn a source back on",
+	Long: `Turn a disabled source back on so it can be synced and searched again.
+
+Examples:
+  nvp source enable lunarvim
+  nvp source enable mycorp`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSourceEnabled(args[0], true)
+	},
+}
+
+var sourceDisableCmd = &cobra.Command{
+	Use:   "disable <name>",
+	Short: "Turn a source off",
+	Long: `Turn a source off without removing it. A disabled source is still listed
+by 'nvp source get' but is not registered: it cannot be synced and its
+plugins do not appear in 'nvp search'. Works for built-in, external, and
+added sources alike.
+
+Examples:
+  nvp source disable lunarvim
+  nvp source disable mycorp`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSourceEnabled(args[0], false)
+	},
+}
+
+// setSourceEnabled records a source as enabled or disabled.
+func setSourceEnabled(name string, enabled bool) error {
+	store := sourceconfig.NewStore(getConfigDir())
+	_, savedErr := store.Get(name)
+	_, disabled := disabledSources[name]
+	if savedErr != nil && !disabled && !sync.NewSourceHandlerFactory().IsSupported(name) {
+		return fmt.Errorf("source not found: %s\n\nUse 'nvp source get' to see available sources", name)
+	}
+	if enabled != disabled {
+		state := "enabled"
+		if !enabled {
+			state = "disabled"
+		}
+		render.Infof("Source '%s' is already %s", name, state)
+		return nil
+	}
+	if err := store.SetEnabled(name, enabled); err != nil {
+		return err
+	}
+	if enabled {
+		render.Successf("Source '%s' enabled", name)
+	} else {
+		render.Successf("Source '%s' disabled", name)
+	}
+	return nil
+}
+
+// recordProvenance notes the source, version, and commit of the plugins a
+// sync wrote. The version is the requested tag, else the ref the handler
+// reports reading.
+func recordProvenance(handler sync.SourceHandler, result *sync.SyncResult, tag string) {
+	version, commit := syncRevision(handler, tag)
+	record := provenance.Record{Source: result.SourceName, Version: version, Commit: commit, SyncedAt: time.Now().UTC()}
+	synced := append(append([]string{}, result.PluginsCreated...), result.PluginsUpdated...)
+	if err := provenance.NewStore(getConfigDir()).Set(synced, record); err != nil {
+		render.WarningfToStderr("could not record where the synced plugins came from: %v", err)
+	}
+}
+
+// syncRevision returns the version and commit a sync read: the requested
+// tag, else the ref the handler reports reading.
+func syncRevision(handler sync.SourceHandler, tag string) (version, commit string) {
+	version = tag
+	if r, ok := handler.(provenance.Revisioner); ok {
+		v, c := r.Revision()
+		if version == "" {
+			version = v
+		}
+		commit = c
+	}
+	return version, commit
+}
+
+// recordSyncHistory saves a sync run to the sync_history table, comparing
+// the plugin store with its state before the sync and keeping the old
+// content of the files it wrote for nvp sync rollback. Without the database
+// the run is not recorded.
+func recordSyncHistory(cmd *cobra.Command, handler sync.SourceHandler, result *sync.SyncResult, tag string, dryRun bool, started time.Time, before synchistory.Snapshot) {
+	ds := getDataStore(cmd)
+	if ds == nil {
+		return
+	}
+	var changes []models.SyncChange
+	var files map[string]models.SyncFile
+	if !dryRun && before != nil {
+		names := append(append([]string{}, result.PluginsCreated...), result.PluginsUpdated...)
+		changes = synchistory.Changes(before, getConfigDir(), names)
+		files = synchistory.Files(before, getConfigDir(), synchistory.Written(result))
+	}
+	version, commit := syncRevision(handler, tag)
+	if version == "" && commit != "" {
+		version = commit[:min(len(commit), 12)]
+	}
+	history, err := synchistory.Record(result, version, dryRun, started, changes, files)
+	if err == nil {
+		err = ds.CreateSyncHistory(history)
+	}
+	if err != nil {
+		render.WarningfToStderr("could not record the sync in the history: %v", err)
+	}
+}
+
+// disabledSources describes the sources turned off in sources.yaml, which
+// registerSavedSources keeps out of the registry.
+var disabledSources = map[string]*sync.SourceInfo{}
+
+// registerSavedSources feeds the sources saved in sources.yaml into the
+// global registry and unregisters the disabled ones. It runs after the
+// built-in and external sources are registered.
+func registerSavedSources() {
+	store := sourceconfig.NewStore(getConfigDir())
+	saved, err := store.List()
+	if err != nil {
+		slog.Warn("failed to load saved sources", "error", err)
+		return
+	}
+	registry := sync.GetGlobalRegistry()
+	if names := gitsource.RegisterAll(registry, sourceconfig.GitConfigs(saved), sourceCacheDir()); len(names) > 0 {
+		slog.Debug("registered git sources", "sources", names)
+	}
+
+	disabledSources = map[string]*sync.SourceInfo{}
+	for _, src := range saved {
+		if src.Disabled && src.Type == gitsource.Type {
+			info := gitsource.Info(src.GitConfig())
+			disabledSources[src.Name] = &info
+		}
+	}
+	names, _ := store.Disabled()
+	for _, name := range names {
+		reg, ok := registry.GetRegistration(name)
+		if !ok {
+			continue
+		}
+		info := reg.Info
+		disabledSources[name] = &info
+		if err := registry.Unregister(name); err != nil {
+			slog.Warn("failed to disable source", "source", name, "error", err)
+		}
+	}
+}
+
+// providedSource returns the info of a source nvp provides itself (built in
+// or on PATH), enabled or not, which added sources may not shadow. A
+// placeholder for a source not implemented yet does not count.
+func providedSource(name string) (*sync.SourceInfo, bool) {
+	if info, ok := disabledSources[name]; ok {
+		return info, info.Type != gitsource.Type
+	}
+	factory := sync.NewSourceHandlerFactory()
+	if !factory.IsSupported(name) {
+		return nil, false
+	}
+	info, err := factory.GetHandlerInfo(name)
+	if err != nil || info.Type == gitsource.Type {
+		return nil, false
+	}
+	if h, err := factory.CreateHandler(name); err == nil && isPlaceholder(h) {
+		return nil, false
+	}
+	return info, true
+}
+
+// parseSelectors parses key=value label selectors.
+func parseSelectors(selectors []string) (map[string]string, error) {
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+	filters := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		parts := strings.SplitN(selector, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid selector format '%s'. Use key=value format", selector)
+		}
+		filters[parts[0]] = parts[1]
+	}
+	return filters, nil
+}
+
+// isPlaceholder reports whether h is a built-in source that is not
+// implemented yet, which saved and external sources may replace.
+func isPlaceholder(h sync.SourceHandler) bool {
+	_, ok := h.(*sync.NotImplementedHandler)
+	return ok
+}
+
+// sourceCacheDir is where git sources are checked out.
+func sourceCacheDir() string {
+	return filepath.Join(getConfigDir(), "cache", "sources")
+}
+
 var sourceSyncCmd = &cobra.Command{
 	Use:   "sync <name>",
 	Short: "Sync plugins from an external source",
@@ -138,6 +539,12 @@ Label Filtering:
 Version/Tag Selection:
   Use --tag to sync from a specific version or branch of the source.
 
+Parallel Fetches:
+  Sources fetched over HTTP, such as LazyVim, download their files in
+  parallel, paced per host and retried on transient errors. --workers sets
+  how many run at once; the network.fetch section of config.yaml sets the
+  defaults (workers, hostRate, retries).
+
 Output Control:
   - --dry-run: Preview what would be synced without making changes
   - --force: Overwrite existing plugins 
@@ -161,9 +568,18 @@ Examples:
 		selectors, _ := cmd.Flags().GetStringSlice("selector")
 		tag, _ := cmd.Flags().GetString("tag")
 
+		if cmd.Flags().Changed("workers") {
+			opts := config.GetConfig().Network.FetchOptions()
+			opts.Workers, _ = cmd.Flags().GetInt("workers")
+			syncfetch.Configure(opts)
+		}
+
 		// Create factory and handler
 		factory := sync.NewSourceHandlerFactory()
 
+		if _, disabled := disabledSources[sourceName]; disabled {
+			return fmt.Errorf("source %s is disabled; turn it on with 'nvp source enable %s'", sourceName, sourceName)
+		}
 		if !factory.IsSupported(sourceName) {
 			return fmt.Errorf("source not found: %s\n\nUse 'nvp source get' to see available sources", sourceName)
 		}
@@ -182,13 +598,18 @@ Examples:
 		targetDir := filepath.Join(getConfigDir(), "plugins")
 		optionsBuilder.WithTargetDir(targetDir)
 
-		// Parse selectors (format: key=value)
-		for _, selector := range selectors {
-			parts := strings.SplitN(selector, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid selector format '%s'. Use key=value format", selector)
+		// Saved filters first, so selectors (format: key=value) override them
+		if saved, err := sourceconfig.NewStore(getConfigDir()).Get(sourceName); err == nil {
+			for k, v := range saved.Filters {
+				optionsBuilder.WithFilter(k, v)
 			}
-			optionsBuilder.WithFilter(parts[0], parts[1])
+		}
+		filters, err := parseSelectors(selectors)
+		if err != nil {
+			return err
+		}
+		for k, v := range filters {
+			optionsBuilder.WithFilter(k, v)
 		}
 
 		// Add tag filter if specified
@@ -229,11 +650,30 @@ Examples:
 
 		render.Blank()
 
+		// Snapshot the plugin store so the history can say what changed and
+		// nvp sync rollback can undo it
+		started := time.Now()
+		before, err := synchistory.Take(getConfigDir())
+		if err != nil {
+			slog.Warn("sync history will not summarize changes", "error", err)
+		}
+
 		// Perform the sync
 		result, err := handler.Sync(cmd.Context(), options)
 		if err != nil {
 			return fmt.Errorf("sync operation failed: %w", err)
 		}
+		recordSyncHistory(cmd, handler, result, tag, dryRun, started, before)
+		if !dryRun {
+			recordProvenance(handler, result, tag)
+			events.Emit(events.SyncCompleted, map[string]any{
+				"kind":    "nvp-source",
+				"source":  sourceName,
+				"created": len(result.PluginsCreated),
+				"updated": len(result.PluginsUpdated),
+				"errors":  len(result.Errors),
+			})
+		}
 
 		// Display results
 		return outputSyncResult(result, outputFormat, dryRun)
@@ -247,7 +687,12 @@ func init() {
 	// Add subcommands to source
 	sourceCmd.AddCommand(sourceListCmd)
 	sourceCmd.AddCommand(sourceShowCmd)
+	sourceCmd.AddCommand(sourceAddCmd)
+	sourceCmd.AddCommand(sourceRemoveCmd)
+	sourceCmd.AddCommand(sourceEnableCmd)
+	sourceCmd.AddCommand(sourceDisableCmd)
 	sourceCmd.AddCommand(sourceSyncCmd)
+	sourceCmd.AddCommand(sourcePushCmd)
 
 	// Flags for list command
 	sourceListCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
@@ -255,12 +700,25 @@ func init() {
 	// Flags for show command
 	sourceShowCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml, json")
 
+	// Flags for add command
+	sourceAddCmd.Flags().String("ref", "", "Branch, tag, or commit to sync (default: the remote's default branch)")
+	sourceAddCmd.Flags().StringSlice("path", nil, "Path glob of the spec files to read (repeatable)")
+	sourceAddCmd.Flags().StringSliceP("selector", "l", nil, "Label filter applied to every sync (key=value, repeatable)")
+	sourceAddCmd.Flags().String("auth", "", "Where to find the token for https remotes: env:<VAR> or secret:<name>")
+	sourceAddCmd.Flags().Bool("disabled", false, "Add the source turned off")
+	sourceAddCmd.Flags().Bool("force", false, "Replace an existing source with the same name")
+
+	// Flags for remove command
+	sourceRemoveCmd.Flags().Bool("purge", false, "Also delete the plugins synced from the source")
+	sourceRemoveCmd.Flags().Bool("force", false, "Skip confirmation")
+
 	// Flags for sync command
 	sourceSyncCmd.Flags().Bool("dry-run", false, "Preview changes without applying")
 	sourceSyncCmd.Flags().StringSliceP("selector", "l", nil, "Label selector to filter plugins (key=value)")
 	sourceSyncCmd.Flags().String("tag", "", "Specific version/tag to sync from")
 	sourceSyncCmd.Flags().Bool("force", false, "Overwrite existing plugins")
 	sourceSyncCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+	sourceSyncCmd.Flags().Int("workers", 0, "Files fetched at once (default: network.fetch.workers, or 8)")
 
 	// Hidden backward-compat aliases for deprecated verbs (list→get, show→describe)
 	// MUST be after flag definitions — shallow copy captures FlagSet pointer at copy time
@@ -272,17 +730,33 @@ func init() {
 // OUTPUT FUNCTIONS
 // =============================================================================
 
+// sourceListing is a source with whether it is turned on.
+type sourceListing struct {
+	*sync.SourceInfo `yaml:",inline"`
+	Enabled          bool `yaml:"enabled" json:"enabled"`
+}
+
+// sourceDetail adds a source's saved registration, if it has one.
+type sourceDetail struct {
+	sourceListing `yaml:",inline"`
+	Config        *sourceconfig.Source `yaml:"config,omitempty" json:"config,omitempty"`
+}
+
 // outputSources renders a list of sources in the specified format
-func outputSources(sources []*sync.SourceInfo, format string) error {
+func outputSources(sources []sourceListing, format string) error {
 	switch format {
 	case "yaml":
 		return render.OutputWith("yaml", sources, render.Options{})
 	case "json":
 		return render.OutputWith("json", sources, render.Options{})
 	case "table", "":
-		tb := render.NewTableBuilder("NAME", "TYPE", "DESCRIPTION")
+		tb := render.NewTableBuilder("NAME", "TYPE", "STATUS", "DESCRIPTION")
 		for _, source := range sources {
-			tb.AddRow(source.Name, source.Type, render.Truncate(source.Description, 50))
+			status := "enabled"
+			if !source.Enabled {
+				status = "disabled"
+			}
+			tb.AddRow(source.Name, source.Type, status, render.Truncate(source.Description, 50))
 		}
 		return render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable})
 	default:
@@ -291,7 +765,7 @@ func outputSources(sources []*sync.SourceInfo, format string) error {
 }
 
 // outputSource renders a single source in the specified format
-func outputSource(source *sync.SourceInfo, format string) error {
+func outputSource(source sourceDetail, format string) error {
 	switch format {
 	case "yaml", "":
 		return render.OutputWith("yaml", source, render.Options{})
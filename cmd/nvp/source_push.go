@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"devopsmaestro/pkg/nvimbridge/gitsource"
+	"devopsmaestro/pkg/nvimbridge/provenance"
+	"devopsmaestro/pkg/nvimbridge/sourceconfig"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+var sourcePushCmd = &cobra.Command{
+	Use:   "push <name> [plugin...]",
+	Short: "Push local plugin changes back to a git source",
+	Long: `Commit the plugins you changed locally back to the git source they were
+synced from, on a new branch, and push it for review.
+
+Every plugin synced from the source (or only the plugins named) is compared
+with its definition in the repository; the ones that differ are rewritten
+in the YAML files that define them and committed on the branch. Plugins the
+repository defines in Lua are reported and left out.
+
+A pull request description summarizing the YAML diffs is printed, or written
+to --description-file. For GitHub remotes the link that opens the pull
+request is printed too. Commits use your git identity.
+
+Examples:
+  nvp source push mycorp --dry-run                 # Preview the diffs
+  nvp source push mycorp                           # Push on nvp/mycorp-<time>
+  nvp source push mycorp telescope --branch tweak-telescope
+  nvp source push mycorp --description-file pr.md`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		src, err := sourceconfig.NewStore(getConfigDir()).Get(name)
+		if err != nil {
+			if _, ok := providedSource(name); ok {
+				return fmt.Errorf("source %q is provided by nvp; only git sources added with 'nvp source add' can be pushed to", name)
+			}
+			return err
+		}
+		if src.Type != gitsource.Type {
+			return fmt.Errorf("source %s is a %s source; only git sources can be pushed to", name, src.Type)
+		}
+
+		synced, err := provenance.NewStore(getConfigDir()).FromSource(name)
+		if err != nil {
+			return err
+		}
+		names := synced
+		if len(args) > 1 {
+			names = args[1:]
+			for _, n := range names {
+				if !slices.Contains(synced, n) {
+					return fmt.Errorf("plugin %s was not synced from %s", n, name)
+				}
+			}
+		}
+		if len(names) == 0 {
+			render.Infof("No plugins synced from %s", name)
+			return nil
+		}
+
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+		var local []*plugin.Plugin
+		for _, n := range names {
+			if p, err := mgr.Get(n); err == nil {
+				local = append(local, p)
+			}
+		}
+
+		opts := gitsource.PushOptions{}
+		opts.Branch, _ = cmd.Flags().GetString("branch")
+		opts.Base, _ = cmd.Flags().GetString("base")
+		opts.Message, _ = cmd.Flags().GetString("message")
+		opts.Force, _ = cmd.Flags().GetBool("force")
+		opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+
+		handler := gitsource.New(src.GitConfig(), sourceCacheDir())
+		result, err := handler.Push(cmd.Context(), local, opts)
+		if err != nil {
+			return fmt.Errorf("push failed: %w", err)
+		}
+
+		descriptionFile, _ := cmd.Flags().GetString("description-file")
+		if descriptionFile != "" && result.Description != "" {
+			if err := os.WriteFile(descriptionFile, []byte(result.Description), 0644); err != nil {
+				return fmt.Errorf("failed to write description: %w", err)
+			}
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return outputPushResult(result, outputFormat, opts.DryRun, descriptionFile == "")
+	},
+}
+
+func init() {
+	sourcePushCmd.Flags().String("branch", "", "Branch to commit on (default: nvp/<source>-<time>)")
+	sourcePushCmd.Flags().String("base", "", "Ref the branch starts from (default: the source's ref)")
+	sourcePushCmd.Flags().StringP("message", "m", "", "Commit message (default: names the changed plugins)")
+	sourcePushCmd.Flags().Bool("force", false, "Overwrite an existing remote branch")
+	sourcePushCmd.Flags().Bool("dry-run", false, "Show the changes and description without committing")
+	sourcePushCmd.Flags().String("description-file", "", "Write the pull request description to this file instead of printing it")
+	sourcePushCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+}
+
+func outputPushResult(result *gitsource.PushResult, format string, dryRun, printDescription bool) error {
+	for _, s := range result.Skipped {
+		render.WarningfToStderr("skipped %s", s)
+	}
+
+	switch format {
+	case "yaml", "json":
+		return render.OutputWith(format, result, render.Options{})
+	case "table", "":
+		if len(result.Changes) == 0 {
+			render.Infof("No local changes to push to %s", result.Source)
+			return nil
+		}
+		tb := render.NewTableBuilder("PLUGIN", "FILE", "CHANGES")
+		for _, c := range result.Changes {
+			tb.AddRow(c.Plugin, c.File, fmt.Sprintf("+%d -%d", c.Added, c.Removed))
+		}
+		if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+			return err
+		}
+
+		render.Blank()
+		if dryRun {
+			render.Infof("Would push %d plugin(s) to branch %s of %s", len(result.Changes), result.Branch, result.Source)
+		} else {
+			render.Successf("Pushed %d plugin(s) to branch %s of %s (%s)", len(result.Changes), result.Branch, result.Source, result.Commit[:12])
+			if result.CompareURL != "" {
+				render.Infof("Open a pull request: %s", result.CompareURL)
+			}
+		}
+		if printDescription {
+			render.Blank()
+			render.Plain(result.Description)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
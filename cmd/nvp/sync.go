@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge/provenance"
+	"devopsmaestro/pkg/nvimbridge/synchistory"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// SYNC HISTORY COMMANDS
+// =============================================================================
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Inspect and roll back past source syncs",
+	Long: `Inspect and roll back the runs of 'nvp source sync'.
+
+Every sync is recorded in the dvm database: the source and version, how
+many plugins it offered and wrote, how long it took, its errors, and which
+plugin files it created or changed, with their diffs. Use it to find out
+why a plugin appeared or changed.
+
+The content of every plugin and package file a sync writes is saved
+before the sync, so 'nvp sync rollback' can put the store back when an
+upstream change breaks your setup.`,
+}
+
+var syncHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past source syncs",
+	Long: `List past source syncs, newest first.
+
+Examples:
+  nvp sync history                     # Every sync
+  nvp sync history --source lazyvim    # Syncs of one source
+  nvp sync history --limit 5 -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ds, err := syncHistoryStore(cmd)
+		if err != nil {
+			return err
+		}
+		source, _ := cmd.Flags().GetString("source")
+		limit, _ := cmd.Flags().GetInt("limit")
+		history, err := ds.ListSyncHistory(source, limit)
+		if err != nil {
+			return err
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return outputSyncHistory(history, outputFormat)
+	},
+}
+
+var syncShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a past source sync",
+	Long: `Show one recorded sync: what the source reported, the plugin files it
+created or changed with their diffs, and its errors.
+
+Examples:
+  nvp sync show 12
+  nvp sync show 12 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ds, err := syncHistoryStore(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid sync ID %q", args[0])
+		}
+		history, err := ds.GetSyncHistory(id)
+		if err != nil {
+			if db.IsNotFound(err) {
+				return fmt.Errorf("sync %d not found; see 'nvp sync history'", id)
+			}
+			return err
+		}
+		outputFormat, _ := cmd.Flags().GetString("output")
+		return outputSyncRun(history, outputFormat)
+	},
+}
+
+var syncRollbackCmd = &cobra.Command{
+	Use:   "rollback <id>",
+	Short: "Undo a past source sync",
+	Long: `Restore the plugin and package files a sync wrote to their state before
+it: files it changed get their old content back and files it created are
+deleted, along with the provenance of the plugins it created.
+
+Files edited since the sync, or rewritten by a later sync, are listed and
+left alone unless --force is given; roll back newer syncs of the same
+source first to undo them in order.
+
+Examples:
+  nvp sync history --source lazyvim   # Find the sync
+  nvp sync rollback 12 --dry-run      # Preview the files it would restore
+  nvp sync rollback 12`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ds, err := syncHistoryStore(cmd)
+		if err != nil {
+			return err
+		}
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid sync ID %q", args[0])
+		}
+		history, err := ds.GetSyncHistory(id)
+		if err != nil {
+			if db.IsNotFound(err) {
+				return fmt.Errorf("sync %d not found; see 'nvp sync history'", id)
+			}
+			return err
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if history.DryRun {
+			return fmt.Errorf("sync %d was a dry run; it changed nothing", id)
+		}
+		if history.RolledBackAt.Valid && !force {
+			return fmt.Errorf("sync %d was already rolled back at %s; use --force to restore its snapshot again",
+				id, history.RolledBackAt.Time.Local().Format(time.RFC1123))
+		}
+		files := history.GetSnapshot()
+		if len(files) == 0 {
+			return fmt.Errorf("sync %d has no snapshot to roll back", id)
+		}
+
+		root := getConfigDir()
+		if conflicts := synchistory.Conflicts(root, files); len(conflicts) > 0 {
+			for _, path := range conflicts {
+				render.WarningfToStderr("%s changed since sync %d", path, id)
+			}
+			if !force && !dryRun {
+				return fmt.Errorf("%d file(s) changed since sync %d; use --force to overwrite them", len(conflicts), id)
+			}
+		}
+
+		if dryRun {
+			for _, path := range slices.Sorted(maps.Keys(files)) {
+				if files[path].Before == nil {
+					render.Plainf("  %s (would remove)", path)
+				} else {
+					render.Plainf("  %s (would restore)", path)
+				}
+			}
+			render.Blank()
+			render.Infof("Would roll back sync %d of source '%s'", id, history.Source)
+			return nil
+		}
+
+		result, err := synchistory.Rollback(root, files)
+		if err != nil {
+			return fmt.Errorf("rollback failed: %w", err)
+		}
+		var removed []string
+		for _, name := range history.GetResult().PluginsCreated {
+			if slices.Contains(result.Removed, synchistory.PluginPath(name)) {
+				removed = append(removed, name)
+			}
+		}
+		if err := provenance.NewStore(root).Forget(removed...); err != nil {
+			render.WarningfToStderr("could not forget where the removed plugins came from: %v", err)
+		}
+		if err := ds.MarkSyncHistoryRolledBack(id); err != nil {
+			render.WarningfToStderr("could not mark sync %d rolled back: %v", id, err)
+		}
+
+		outputFormat, _ := cmd.Flags().GetString("output")
+		switch outputFormat {
+		case "yaml", "json":
+			return render.OutputWith(outputFormat, result, render.Options{})
+		case "table", "":
+			for _, path := range result.Restored {
+				render.Plainf("  %s (restored)", path)
+			}
+			for _, path := range result.Removed {
+				render.Plainf("  %s (removed)", path)
+			}
+			render.Blank()
+			render.Successf("Rolled back sync %d of source '%s': %d file(s) restored, %d removed",
+				id, history.Source, len(result.Restored), len(result.Removed))
+			return nil
+		default:
+			return fmt.Errorf("unknown format: %s", outputFormat)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncHistoryCmd)
+	syncCmd.AddCommand(syncShowCmd)
+	syncCmd.AddCommand(syncRollbackCmd)
+
+	syncHistoryCmd.Flags().String("source", "", "Only list syncs of this source")
+	syncHistoryCmd.Flags().Int("limit", 0, "List at most this many syncs")
+	syncHistoryCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+
+	syncShowCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+
+	syncRollbackCmd.Flags().Bool("force", false, "Overwrite files changed since the sync, or roll back again")
+	syncRollbackCmd.Flags().Bool("dry-run", false, "List the files that would be restored or removed")
+	syncRollbackCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
+}
+
+// syncHistoryStore returns the database sync history is kept in.
+func syncHistoryStore(cmd *cobra.Command) (db.DataStore, error) {
+	ds := getDataStore(cmd)
+	if ds == nil {
+		return nil, fmt.Errorf("sync history requires the dvm database (run 'dvm admin init')")
+	}
+	return ds, nil
+}
+
+// outputSyncHistory renders a list of sync runs in the specified format
+func outputSyncHistory(history []*models.SyncHistory, format string) error {
+	switch format {
+	case "yaml", "json":
+		runs := make([]models.SyncHistoryYAML, 0, len(history))
+		for _, h := range history {
+			runs = append(runs, h.ToYAML())
+		}
+		return render.OutputWith(format, runs, render.Options{})
+	case "table", "":
+		if len(history) == 0 {
+			render.Info("No syncs recorded")
+			return nil
+		}
+		tb := render.NewTableBuilder("ID", "SOURCE", "VERSION", "STARTED", "DURATION", "CREATED", "UPDATED", "ERRORS")
+		for _, h := range history {
+			source := h.Source
+			if h.DryRun {
+				source += " (dry run)"
+			} else if h.RolledBackAt.Valid {
+				source += " (rolled back)"
+			}
+			tb.AddRow(strconv.Itoa(h.ID), source, h.Version.String,
+				h.StartedAt.Local().Format("2006-01-02 15:04:05"), h.Duration().Round(time.Millisecond).String(),
+				strconv.Itoa(h.CreatedCount), strconv.Itoa(h.UpdatedCount), strconv.Itoa(h.ErrorCount))
+		}
+		return render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable})
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+// outputSyncRun renders one sync run in the specified format
+func outputSyncRun(h *models.SyncHistory, format string) error {
+	switch format {
+	case "yaml", "json":
+		return render.OutputWith(format, h.ToYAML(), render.Options{})
+	case "table", "":
+		run := h.ToYAML()
+		title := fmt.Sprintf("Sync %d of source '%s'", run.ID, run.Source)
+		if run.Version != "" {
+			title += " at " + run.Version
+		}
+		if run.DryRun {
+			title += " (dry run)"
+		}
+		render.Info(title)
+		render.Plainf("Started:   %s", run.StartedAt.Local().Format(time.RFC1123))
+		if run.RolledBackAt != nil {
+			render.Plainf("Rolled back: %s", run.RolledBackAt.Local().Format(time.RFC1123))
+		}
+		render.Plainf("Duration:  %s", h.Duration().Round(time.Millisecond))
+		render.Plainf("Available: %d plugins", run.TotalAvailable)
+		render.Plainf("Synced:    %d plugins", run.TotalSynced)
+
+		if run.DryRun {
+			for _, section := range []struct {
+				title string
+				names []string
+			}{
+				{"Would create", run.PluginsCreated},
+				{"Would update", run.PluginsUpdated},
+			} {
+				if len(section.names) == 0 {
+					continue
+				}
+				render.Blank()
+				render.Infof("%s (%d):", section.title, len(section.names))
+				for _, name := range section.names {
+					render.Plainf("  %s", name)
+				}
+			}
+		}
+
+		if len(run.Changes) > 0 {
+			render.Blank()
+			tb := render.NewTableBuilder("PLUGIN", "ACTION", "CHANGES")
+			for _, c := range run.Changes {
+				tb.AddRow(c.Plugin, c.Action, fmt.Sprintf("+%d -%d", c.Added, c.Removed))
+			}
+			if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+				return err
+			}
+			for _, c := range run.Changes {
+				if c.Diff == "" {
+					continue
+				}
+				render.Blank()
+				render.Infof("%s:", c.Plugin)
+				render.Plain(strings.TrimRight(c.Diff, "\n"))
+			}
+		}
+
+		if len(run.PackagesCreated)+len(run.PackagesUpdated) > 0 {
+			render.Blank()
+			render.Infof("Packages: %d created, %d updated", len(run.PackagesCreated), len(run.PackagesUpdated))
+		}
+
+		if len(run.Errors) > 0 {
+			render.Blank()
+			render.Infof("Errors (%d):", len(run.Errors))
+			for i, e := range run.Errors {
+				render.Plainf("  %d. %s", i+1, e)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+}
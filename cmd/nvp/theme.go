@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"devopsmaestro/pkg/interactive"
 	"devopsmaestro/pkg/source"
 	"github.com/rmkohlman/MaestroSDK/render"
 	"github.com/rmkohlman/MaestroSDK/resource"
@@ -146,14 +147,8 @@ var themeDeleteCmd = &cobra.Command{
 
 		// Confirm unless forced
 		force, _ := cmd.Flags().GetBool("force")
-		if !force {
-			fmt.Printf("Delete theme '%s'? (y/N): ", name)
-			var response string
-			fmt.Scanln(&response)
-			if response != "y" && response != "Y" {
-				render.Info("Aborted")
-				return nil
-			}
+		if _, err := interactive.Confirm(fmt.Sprintf("Delete theme '%s'?", name), force); err != nil {
+			return err
 		}
 
 		if err := themeStore.Delete(name); err != nil {
@@ -173,6 +168,24 @@ var themeUseCmd = &cobra.Command{
 		name := args[0]
 		themeStore := getThemeStore()
 
+		// With an active profile, the theme belongs to the profile
+		p, err := getProfileStore().Active()
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			if _, err := themeStore.Get(name); err != nil {
+				return err
+			}
+			p.Theme = name
+			if err := getProfileStore().Save(p); err != nil {
+				return err
+			}
+			render.Successf("Theme for profile '%s' set to '%s'", p.Name, name)
+			render.Info("Run 'nvp theme generate' to regenerate the profile's theme files")
+			return nil
+		}
+
 		if err := themeStore.SetActive(name); err != nil {
 			return err
 		}
@@ -188,6 +201,7 @@ func init() {
 	themeCmd.AddCommand(themeGetCmd)
 	themeCmd.AddCommand(themeApplyCmd)
 	themeCmd.AddCommand(themeCreateCmd)
+	themeCmd.AddCommand(themeImportCmd)
 	themeCmd.AddCommand(themeDeleteCmd)
 	themeCmd.AddCommand(themeUseCmd)
 	themeCmd.AddCommand(themeLibraryCmd)
@@ -208,6 +222,13 @@ func init() {
 	themeCreateCmd.Flags().Bool("dry-run", false, "Preview without saving")
 	themeCreateCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml, json, table")
 	themeCreateCmd.Flags().Bool("use", false, "Set as active theme after creation")
+	themeImportCmd.Flags().String("from-colorscheme", "", "Neovim colorscheme to extract, as given to :colorscheme (required)")
+	themeImportCmd.Flags().String("name", "", "Theme name (default: the colorscheme name)")
+	themeImportCmd.Flags().String("palette-file", "", "Read colors from the plugin's Lua palette file instead of running nvim")
+	themeImportCmd.Flags().String("nvim", "", "Path to the nvim binary (default: nvim in PATH)")
+	themeImportCmd.Flags().Bool("dry-run", false, "Preview without saving")
+	themeImportCmd.Flags().StringP("output", "o", "yaml", "Output format: yaml, json, table")
+	themeImportCmd.Flags().Bool("use", false, "Set as active theme after import")
 	themeDeleteCmd.Flags().Bool("force", false, "Skip confirmation")
 	themePreviewCmd.Flags().Bool("all", false, "Preview all library themes")
 	themeLibraryListCmd.Flags().StringP("output", "o", "table", "Output format: table, yaml, json")
@@ -216,6 +237,7 @@ func init() {
 	themeLibraryInstallCmd.Flags().Bool("use", false, "Set as active theme after install")
 	themeGenerateCmd.Flags().String("output-dir", "", "Output directory (default: ~/.config/nvim/lua)")
 	themeGenerateCmd.Flags().Bool("dry-run", false, "Show what would be generated")
+	themeGenerateCmd.Flags().String("profile", "", "Generate the theme of this profile instead of the active one")
 
 	// Hidden backward-compat aliases for deprecated verbs in theme library
 	// MUST be after flag definitions — shallow copy captures FlagSet pointer at copy time
@@ -138,13 +138,18 @@ var themeGenerateCmd = &cobra.Command{
   - theme/init.lua      - Theme setup and helpers
   - plugins/colorscheme.lua - Lazy.nvim plugin spec
 
+With an active profile (or --profile), the profile's theme is generated
+into its config directory.
+
 Other plugins can use the palette:
   local palette = require("theme").palette
   local bg = palette.colors.bg`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		themeStore := getThemeStore()
-
-		t, err := themeStore.GetActive()
+		p, err := currentProfile(cmd)
+		if err != nil {
+			return err
+		}
+		t, err := profileTheme(p)
 		if err != nil {
 			return err
 		}
@@ -154,8 +159,12 @@ Other plugins can use the palette:
 
 		outputDir, _ := cmd.Flags().GetString("output-dir")
 		if outputDir == "" {
-			home, _ := os.UserHomeDir()
-			outputDir = filepath.Join(home, ".config", "nvim", "lua")
+			if p != nil {
+				outputDir = filepath.Join(profileConfigDir(p), "lua")
+			} else {
+				home, _ := os.UserHomeDir()
+				outputDir = filepath.Join(home, ".config", "nvim", "lua")
+			}
 		}
 
 		// Expand ~
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"devopsmaestro/pkg/themeextract"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// themeImportTimeout bounds the headless nvim run, which loads the user's
+// whole config.
+const themeImportTimeout = 30 * time.Second
+
+var themeImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create a theme from an installed Neovim colorscheme",
+	Long: `Create a theme from a Neovim colorscheme that is not in the library.
+
+By default nvp runs nvim headless with your config, loads the colorscheme,
+and reads its colors from the highlight groups (Normal, Comment, Visual,
+DiagnosticError, ...) and the terminal colors it sets. With --palette-file
+it instead reads the "name = '#rrggbb'" entries of the plugin's Lua palette
+file, without running nvim.
+
+A colorscheme of a supported plugin (tokyonight-storm, catppuccin-mocha)
+keeps that plugin and style; any other becomes a standalone theme generated
+from the extracted colors. Either way the theme can be exported to terminal
+palettes like a library theme.
+
+Examples:
+  nvp theme import --from-colorscheme tokyonight-storm
+  nvp theme import --from-colorscheme melange --name my-melange --use
+  nvp theme import --from-colorscheme tokyonight-storm --dry-run -o yaml
+  nvp theme import --from-colorscheme tokyonight-storm \
+    --palette-file ~/.local/share/nvim/lazy/tokyonight.nvim/lua/tokyonight/colors/storm.lua`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		colorscheme, _ := cmd.Flags().GetString("from-colorscheme")
+		name, _ := cmd.Flags().GetString("name")
+		paletteFile, _ := cmd.Flags().GetString("palette-file")
+		nvimPath, _ := cmd.Flags().GetString("nvim")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		output, _ := cmd.Flags().GetString("output")
+
+		if colorscheme == "" {
+			return fmt.Errorf("--from-colorscheme flag is required")
+		}
+		if name == "" {
+			name = colorscheme
+		}
+
+		var colors map[string]string
+		background := ""
+		if paletteFile != "" {
+			data, err := os.ReadFile(paletteFile)
+			if err != nil {
+				return fmt.Errorf("failed to read palette file: %w", err)
+			}
+			colors = themeextract.ParseLuaPalette(data)
+		} else {
+			render.Progress(fmt.Sprintf("Loading colorscheme '%s' in headless nvim...", colorscheme))
+			ctx, cancel := context.WithTimeout(context.Background(), themeImportTimeout)
+			defer cancel()
+			snapshot, err := themeextract.RunHeadless(ctx, nvimPath, colorscheme)
+			if err != nil {
+				return err
+			}
+			colors = snapshot.Colors()
+			background = snapshot.Background
+		}
+		if len(colors) == 0 {
+			return fmt.Errorf("no colors found for colorscheme '%s'", colorscheme)
+		}
+		if colors["bg"] == "" || colors["fg"] == "" {
+			render.Warning("The extracted colors have no bg or fg; check the theme before using it")
+		}
+
+		imported := themeextract.NewTheme(name, colorscheme, colors, background)
+		if err := imported.Validate(); err != nil {
+			return fmt.Errorf("extracted theme is invalid: %w", err)
+		}
+
+		// Dry run - just output the theme
+		if dryRun {
+			render.Info("Extracted theme preview:")
+			return outputTheme(imported, output)
+		}
+
+		themeStore := getThemeStore()
+		if err := themeStore.Init(); err != nil {
+			return err
+		}
+		if err := themeStore.Save(imported); err != nil {
+			return fmt.Errorf("failed to save theme: %w", err)
+		}
+		render.Successf("Imported theme '%s' (%d colors)", imported.Name, len(colors))
+
+		setActive, _ := cmd.Flags().GetBool("use")
+		if setActive {
+			if err := themeStore.SetActive(imported.Name); err != nil {
+				return err
+			}
+			render.Successf("Set '%s' as active theme", imported.Name)
+		}
+
+		render.Blank()
+		render.Info("Next steps:")
+		if !setActive {
+			render.Plainf("  nvp theme use %s      # Set as active theme", imported.Name)
+		}
+		render.Plainf("  nvp theme preview %s  # Preview colors", imported.Name)
+		return nil
+	},
+}
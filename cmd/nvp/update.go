@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/nvimbridge/advisor"
+	"devopsmaestro/pkg/nvimbridge/pinning"
+	"devopsmaestro/pkg/source"
+	"github.com/rmkohlman/MaestroNvim/nvimops"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// =============================================================================
+// UPDATE COMMAND
+// =============================================================================
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Check for plugin updates and refresh pinned commits",
+	Long: `Compare installed plugins against their upstream GitHub releases, or update one.
+
+Without --plugin, lists every enabled plugin pinned to a release tag or a
+semver range that has a newer release, and flags updates likely to break your
+config: major version bumps, minor bumps of 0.x releases, and release notes
+announcing breaking changes. A range ("^1.2", "~1.2", ">=1.0 <2") counts as
+installed at the newest release it allows.
+
+With --plugin, a plugin pinned to a release tag is moved to the newest
+release after showing its release notes and asking for confirmation. Use
+--diff to also show how the default opts the plugin publishes (its lazy.lua
+spec) changed between the two releases. Caret and tilde ranges keep their
+operator when moved. The plugin's commit pin in the lock file is then refreshed, printing a
+summary of the commits between the old and new pin. Branch-tracking plugins
+only have their pin refreshed.
+
+Set GITHUB_TOKEN for higher API limits.
+
+Examples:
+  nvp update                          # List available updates
+  nvp update -o json                  # Advisory as JSON
+  nvp update --plugin telescope       # Update one plugin
+  nvp update --plugin telescope --diff
+  nvp update --plugin telescope --dry-run
+  nvp update --plugin telescope --yes # Accept without prompting`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mgr, err := getManager()
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+
+		releases := advisor.NewGitHubReleases(source.GitHubToken())
+		name, _ := cmd.Flags().GetString("plugin")
+		if name == "" {
+			format, _ := cmd.Flags().GetString("output")
+			return runUpdateCheck(cmd, mgr, releases, format)
+		}
+
+		p, err := mgr.Get(name)
+		if err != nil {
+			return fmt.Errorf("plugin not found: %s", name)
+		}
+
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		advice, err := advisor.Advise(cmd.Context(), p, releases)
+		if err != nil {
+			render.WarningfToStderr("could not check releases: %v", err)
+		} else if advice.HasUpdate() {
+			accepted, err := reviewRelease(cmd, p, advice, dryRun)
+			if err != nil || !accepted {
+				return err
+			}
+			p.Version = advice.Target()
+			if err := mgr.Apply(p); err != nil {
+				return fmt.Errorf("failed to update plugin: %w", err)
+			}
+			render.Successf("Updated %s to %s", name, p.Version)
+		}
+
+		return refreshPin(cmd, p, dryRun)
+	},
+}
+
+func init() {
+	updateCmd.Flags().String("plugin", "", "Plugin to update")
+	updateCmd.Flags().Bool("dry-run", false, "Show the update without applying it")
+	updateCmd.Flags().Bool("diff", false, "Show how the plugin's default opts changed between the installed and new release")
+	updateCmd.Flags().StringP("output", "o", "table", "Output format for the update list: table, json")
+}
+
+// runUpdateCheck prints the update advisory for all enabled plugins.
+func runUpdateCheck(cmd *cobra.Command, mgr nvimops.Manager, releases advisor.ReleaseSource, format string) error {
+	plugins, err := mgr.List()
+	if err != nil {
+		return fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	var advice []*advisor.Advice
+	for _, p := range plugins {
+		if !p.Enabled || !strings.Contains(p.Repo, "/") {
+			continue
+		}
+		a, err := advisor.Advise(cmd.Context(), p, releases)
+		if err != nil {
+			render.WarningfToStderr("%s: %v", p.Name, err)
+			continue
+		}
+		advice = append(advice, a)
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(advice, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case "table", "":
+	default:
+		return fmt.Errorf("unknown format: %s (supported: table, json)", format)
+	}
+
+	tb := render.NewTableBuilder("PLUGIN", "CURRENT", "LATEST", "UPDATE", "BREAKING")
+	updates := 0
+	for _, a := range advice {
+		if !a.HasUpdate() {
+			continue
+		}
+		updates++
+		breaking := ""
+		if a.Breaking {
+			breaking = "yes"
+		}
+		tb.AddRow(a.Plugin, a.Current, a.Latest, string(a.Kind), breaking)
+	}
+	if updates == 0 {
+		render.Success("All release-pinned plugins are up to date")
+		return nil
+	}
+	if err := render.OutputWith("", tb.Build(), render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+	render.Info("Run 'nvp update --plugin <name>' to review and apply an update")
+	return nil
+}
+
+// maxReleaseNotes caps the releases and note lines shown before an update.
+const (
+	maxReleaseNotes     = 5
+	maxReleaseNoteLines = 4
+)
+
+// reviewRelease shows the release notes, breaking-change warnings, and
+// optionally the opts diff for an update, then asks for confirmation.
+func reviewRelease(cmd *cobra.Command, p *plugin.Plugin, advice *advisor.Advice, dryRun bool) (bool, error) {
+	render.Infof("%s: %s → %s (%s)", p.Name, advice.Current, advice.Latest, advice.Kind)
+	for _, reason := range advice.BreakingReasons {
+		render.Warningf("Breaking: %s", reason)
+	}
+
+	for i, r := range advice.Releases {
+		if i == maxReleaseNotes {
+			render.Plainf("  ... and %d older release(s)", len(advice.Releases)-i)
+			break
+		}
+		render.Plainf("\n  %s", r.Tag)
+		shown := 0
+		for _, line := range strings.Split(r.Notes, "\n") {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			if shown == maxReleaseNoteLines {
+				render.Plainf("    ...")
+				break
+			}
+			render.Plainf("    %s", render.Truncate(line, 100))
+			shown++
+		}
+	}
+	render.Plainf("")
+
+	if showDiff, _ := cmd.Flags().GetBool("diff"); showDiff {
+		renderOptsDiff(cmd, p, advice)
+	}
+
+	if dryRun {
+		render.Info("Dry run: plugin not updated")
+		return false, nil
+	}
+	return interactive.Confirm(fmt.Sprintf("Update %s to %s?", p.Name, advice.Latest), false)
+}
+
+// renderOptsDiff prints how the default opts the plugin publishes changed
+// between the installed and the new release.
+func renderOptsDiff(cmd *cobra.Command, p *plugin.Plugin, advice *advisor.Advice) {
+	specs := advisor.NewGitHubSpecs(source.GitHubToken())
+	diff, err := advisor.VersionOptsDiff(cmd.Context(), p, specs, advice.Current, advice.Latest)
+	if errors.Is(err, advisor.ErrNoSpec) {
+		render.Infof("No default opts to compare: %v", err)
+		return
+	}
+	if err != nil {
+		render.WarningfToStderr("could not diff default opts: %v", err)
+		return
+	}
+	if diff == "" {
+		render.Infof("Default opts are unchanged between %s and %s", advice.Current, advice.Latest)
+		return
+	}
+	render.Plainf("Default opts (- %s, + %s):", advice.Current, advice.Latest)
+	render.Plainf("%s", strings.TrimRight(diff, "\n"))
+}
+
+// refreshPin re-resolves p's commit pin in the default lock file and prints
+// the commits between the old and new pin.
+func refreshPin(cmd *cobra.Command, p *plugin.Plugin, dryRun bool) error {
+	path := defaultLockPath()
+	lf, err := loadLockFile(path)
+	if err != nil {
+		return err
+	}
+	if lf == nil {
+		lf = plugin.NewLockFile()
+	}
+
+	resolver := pinning.NewGitHubResolver(source.GitHubToken())
+	old, updated, changes, err := pinning.Update(cmd.Context(), p, lf, resolver)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case old.Commit == updated.Commit:
+		render.Infof("%s is already at the latest commit (%s)", p.Name, pinning.Short(updated.Commit))
+		return nil
+	case old.Commit == "":
+		render.Infof("%s: pinned to %s", p.Name, pinning.Short(updated.Commit))
+	default:
+		renderChanges(p.Name, old, updated, changes)
+	}
+
+	if dryRun {
+		render.Info("Dry run: lock file not changed")
+		return nil
+	}
+	if err := writeLockFile(lf, path); err != nil {
+		return err
+	}
+	render.Successf("Updated %s pin in %s", p.Name, path)
+	return nil
+}
+
+// maxChangelogCommits caps the commit subjects shown for a moved pin.
+const maxChangelogCommits = 10
+
+// renderChanges prints the changelog summary for a moved pin.
+func renderChanges(name string, old, updated pinning.Pin, changes *pinning.Changes) {
+	if changes == nil {
+		render.Infof("%s: %s → %s", name, pinning.Short(old.Commit), pinning.Short(updated.Commit))
+		return
+	}
+	render.Infof("%s: %s → %s (%d commits)", name, pinning.Short(old.Commit), pinning.Short(updated.Commit), changes.Total)
+
+	// The compare API lists commits oldest first; show the newest.
+	commits := changes.Commits
+	if len(commits) > maxChangelogCommits {
+		commits = commits[len(commits)-maxChangelogCommits:]
+	}
+	for i := len(commits) - 1; i >= 0; i-- {
+		render.Plainf("  %s %s", pinning.Short(commits[i].SHA), commits[i].Subject)
+	}
+	if more := changes.Total - len(commits); more > 0 {
+		render.Plainf("  ... and %d more", more)
+	}
+}
@@ -0,0 +1,538 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"devopsmaestro/pkg/jsonpath"
+	"devopsmaestro/pkg/tablestyle"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output contract for -o/--output:
+//
+//	table    human-readable table (default)
+//	wide     table with extra columns; only commands that define them accept it
+//	name     one resource name per line, for loops and xargs
+//	json     machine-readable; field names are stable across releases
+//	yaml     machine-readable; same fields as json
+//	plain    uncolored text
+//	compact  condensed text
+//
+//	jsonpath=TEMPLATE     fields selected by a kubectl-style JSONPath template
+//	go-template=TEMPLATE  a text/template applied to the yaml form
+//
+// Commands that render a render.TableData get name and json/yaml for free via
+// the renderers registered below; json/yaml field names come from
+// outputFields. Commands with a structured model should still pass it for
+// json/yaml. Commands with wide columns build them when the format is wide and
+// render with tableFormat(format); the wide renderer itself rejects output so
+// commands without extra columns do not silently print a plain table.
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputName  = "name"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+
+	outputJSONPath   = "jsonpath"
+	outputGoTemplate = "go-template"
+)
+
+// outputFormats lists the -o values shown in help and completion.
+var outputFormats = []string{outputTable, outputWide, outputName, outputJSON, outputYAML, "plain", "compact"}
+
+// deprecatedOutputFormats are still accepted but not advertised.
+var deprecatedOutputFormats = []string{"colored", "pretty"}
+
+// supportedOutputFormats lists every advertised -o value for help and errors.
+var supportedOutputFormats = strings.Join(outputFormats, ", ") +
+	", " + outputJSONPath + "=TEMPLATE, " + outputGoTemplate + "=TEMPLATE"
+
+// outputFormatUsage is the -o/--output help text.
+var outputFormatUsage = "Output format: " + supportedOutputFormats
+
+func init() {
+	render.Register(nameRenderer{})
+	render.Register(wideRenderer{})
+	for _, name := range []render.RendererName{render.RendererJSON, render.RendererYAML} {
+		if r := render.Get(name); r != nil {
+			render.Register(recordRenderer{Renderer: r})
+		}
+	}
+}
+
+// isOutputFormat reports whether s is an accepted -o value. The empty
+// string means "command default".
+func isOutputFormat(s string) bool {
+	if s == "" {
+		return true
+	}
+	if kind, _, ok := templateOutput(s); ok {
+		return kind != ""
+	}
+	for _, formats := range [][]string{outputFormats, deprecatedOutputFormats} {
+		for _, f := range formats {
+			if s == f {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// outputFormatValue is a pflag.Value that rejects unknown -o formats at parse
+// time instead of silently falling back to a table. Type() is "string" so
+// cmd.Flags().GetString("output") keeps working.
+type outputFormatValue struct {
+	p *string
+}
+
+func newOutputFormatValue(p *string, defaultVal string) *outputFormatValue {
+	*p = defaultVal
+	return &outputFormatValue{p: p}
+}
+
+func (v *outputFormatValue) String() string { return *v.p }
+func (v *outputFormatValue) Type() string   { return "string" }
+
+func (v *outputFormatValue) Set(s string) error {
+	if kind, text, ok := templateOutput(s); ok {
+		if err := registerTemplateRenderer(s, kind, text); err != nil {
+			return err
+		}
+		*v.p = s
+		return nil
+	}
+	if !isOutputFormat(s) {
+		return fmt.Errorf("unsupported output format %q (supported: %s)", s, supportedOutputFormats)
+	}
+	*v.p = s
+	return nil
+}
+
+// isStructuredOutput reports whether format renders the structured
+// (json/yaml) form of the data: json, yaml, jsonpath, and go-template.
+func isStructuredOutput(format string) bool {
+	if format == outputJSON || format == outputYAML {
+		return true
+	}
+	_, _, ok := templateOutput(format)
+	return ok
+}
+
+// completeOutputFormats completes -o/--output values.
+func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if strings.HasPrefix(toComplete, outputJSONPath+"=") || strings.HasPrefix(toComplete, outputGoTemplate+"=") {
+		return nil, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+	formats := append([]string{}, outputFormats...)
+	formats = append(formats, outputJSONPath+"=", outputGoTemplate+"=")
+	return formats, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// =============================================================================
+// Renderers
+// =============================================================================
+
+// activeMarkerPrefix is the display-only prefix activeMarker adds to names;
+// table renderers draw rows carrying it in the theme's primary color.
+const activeMarkerPrefix = tablestyle.ActiveMarker
+
+// nameRenderer implements -o name: the NAME column of a table (or the name
+// field of structured data), one per line, without display markers.
+type nameRenderer struct{}
+
+func (nameRenderer) Name() render.RendererName { return outputName }
+func (nameRenderer) SupportsColor() bool       { return false }
+
+func (r nameRenderer) Render(w io.Writer, data any, opts render.Options) error {
+	return r.RenderWithContext(context.Background(), w, data, opts)
+}
+
+func (nameRenderer) RenderWithContext(ctx context.Context, w io.Writer, data any, opts render.Options) error {
+	if opts.Empty {
+		return nil
+	}
+	for _, name := range resourceNames(data) {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (nameRenderer) RenderMessage(w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(string(render.RendererPlain)).RenderMessage(w, msg)
+}
+
+func (nameRenderer) RenderMessageWithContext(ctx context.Context, w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(string(render.RendererPlain)).RenderMessageWithContext(ctx, w, msg)
+}
+
+// wideRenderer implements -o wide for commands that have no extra columns:
+// it fails with a hint instead of printing the default table. Commands with
+// wide columns never reach it, see tableFormat.
+type wideRenderer struct{}
+
+func (wideRenderer) Name() render.RendererName { return outputWide }
+func (wideRenderer) SupportsColor() bool       { return true }
+
+func (r wideRenderer) Render(w io.Writer, data any, opts render.Options) error {
+	return r.RenderWithContext(context.Background(), w, data, opts)
+}
+
+func (wideRenderer) RenderWithContext(ctx context.Context, w io.Writer, data any, opts render.Options) error {
+	return fmt.Errorf("output format %q is not supported by this command: it has no extra columns (use -o %s)", outputWide, outputTable)
+}
+
+func (wideRenderer) RenderMessage(w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(outputTable).RenderMessage(w, msg)
+}
+
+func (wideRenderer) RenderMessageWithContext(ctx context.Context, w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(outputTable).RenderMessageWithContext(ctx, w, msg)
+}
+
+// tableFormat returns the renderer for a table a command has already built
+// for format: a wide table is drawn by the table renderer.
+func tableFormat(format string) string {
+	if format == outputWide {
+		return outputTable
+	}
+	return format
+}
+
+// templateOutput splits "jsonpath=TEMPLATE" or "go-template=TEMPLATE". ok is
+// true for either prefix, with or without "="; kind is empty when the
+// template text is missing.
+func templateOutput(s string) (kind, text string, ok bool) {
+	name, text, hasText := strings.Cut(s, "=")
+	if name != outputJSONPath && name != outputGoTemplate {
+		return "", "", false
+	}
+	if !hasText || text == "" {
+		return "", "", true
+	}
+	return name, text, true
+}
+
+// registerTemplateRenderer parses the template of a jsonpath or go-template
+// format and registers a renderer under the full format string, so commands
+// that pass the -o value to render.OutputWith pick it up unchanged.
+func registerTemplateRenderer(format, kind, text string) error {
+	if kind == "" {
+		return fmt.Errorf("output format %q requires a template, e.g. -o %s='{.metadata.name}'", format, strings.TrimSuffix(format, "="))
+	}
+	var exec func(io.Writer, any) error
+	switch kind {
+	case outputJSONPath:
+		t, err := jsonpath.Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid jsonpath template: %w", err)
+		}
+		exec = t.Execute
+	case outputGoTemplate:
+		t, err := template.New("output").Parse(text)
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		exec = t.Execute
+	}
+	render.Register(templateRenderer{name: render.RendererName(format), exec: exec})
+	return nil
+}
+
+// templateRenderer implements -o jsonpath=... and -o go-template=... by
+// applying a parsed template to the generic yaml form of the data.
+type templateRenderer struct {
+	name render.RendererName
+	exec func(io.Writer, any) error
+}
+
+func (r templateRenderer) Name() render.RendererName { return r.name }
+func (templateRenderer) SupportsColor() bool         { return false }
+
+func (r templateRenderer) Render(w io.Writer, data any, opts render.Options) error {
+	return r.RenderWithContext(context.Background(), w, data, opts)
+}
+
+func (r templateRenderer) RenderWithContext(ctx context.Context, w io.Writer, data any, opts render.Options) error {
+	if opts.Empty {
+		return nil
+	}
+	// Resource models only carry yaml tags, so the yaml form is the one whose
+	// field names match -o yaml and 'dvm apply' documents.
+	raw, err := yaml.Marshal(tableRecords(data))
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	var generic any
+	if err := yaml.Unmarshal(raw, &generic); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return r.exec(w, generic)
+}
+
+func (templateRenderer) RenderMessage(w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(string(render.RendererPlain)).RenderMessage(w, msg)
+}
+
+func (templateRenderer) RenderMessageWithContext(ctx context.Context, w io.Writer, msg render.Message) error {
+	return render.ResolveRenderer(string(render.RendererPlain)).RenderMessageWithContext(ctx, w, msg)
+}
+
+// recordRenderer wraps the json/yaml renderers so table output becomes a list
+// of records keyed by outputFields instead of display headers.
+type recordRenderer struct {
+	render.Renderer
+}
+
+func (r recordRenderer) Render(w io.Writer, data any, opts render.Options) error {
+	return r.Renderer.Render(w, tableRecords(data), opts)
+}
+
+func (r recordRenderer) RenderWithContext(ctx context.Context, w io.Writer, data any, opts render.Options) error {
+	return r.Renderer.RenderWithContext(ctx, w, tableRecords(data), opts)
+}
+
+// tableRecords converts TableData to records keyed by the outputFields name
+// of each header. When the NAME column carries active markers, the marker is removed
+// and an "active" field is added to every record. Other data is returned
+// unchanged.
+func tableRecords(data any) any {
+	td, ok := data.(render.TableData)
+	if !ok {
+		return data
+	}
+	keys := make([]string, len(td.Headers))
+	for i, h := range td.Headers {
+		keys[i] = tableFieldName(h)
+	}
+	nameCol := tableNameColumn(td.Headers)
+	marked := false
+	for _, row := range td.Rows {
+		if nameCol < len(row) && strings.HasPrefix(row[nameCol], activeMarkerPrefix) {
+			marked = true
+			break
+		}
+	}
+
+	records := make([]map[string]any, 0, len(td.Rows))
+	for _, row := range td.Rows {
+		rec := make(map[string]any, len(keys)+1)
+		for i, cell := range row {
+			if i >= len(keys) {
+				break
+			}
+			if i == nameCol && marked {
+				rec["active"] = strings.HasPrefix(cell, activeMarkerPrefix)
+				cell = strings.TrimPrefix(cell, activeMarkerPrefix)
+			}
+			rec[keys[i]] = cell
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+// outputFields is the json/yaml field name of every table column. The names
+// are part of the output contract: a renamed or reworded header must keep its
+// field name here, and a new column needs an entry (TestOutputFields checks
+// the shared table builders).
+var outputFields = map[string]string{
+	"ACTION":        "action",
+	"ACTIVE":        "active",
+	"APP":           "app",
+	"APPS":          "apps",
+	"AUTO_SYNC":     "autoSync",
+	"BRANCH":        "branch",
+	"CATEGORY":      "category",
+	"COLORS":        "colors",
+	"COMMAND":       "command",
+	"COMMIT":        "commit",
+	"COMPLETED":     "completed",
+	"CONTAINER-ID":  "containerId",
+	"CREATED":       "created",
+	"DATE":          "date",
+	"DESCRIPTION":   "description",
+	"DIR":           "dir",
+	"DOMAIN":        "domain",
+	"DOMAINS":       "domains",
+	"DURATION":      "duration",
+	"ECOSYSTEM":     "ecosystem",
+	"ENABLED":       "enabled",
+	"ENDPOINT":      "endpoint",
+	"ERROR":         "error",
+	"EXPIRES":       "expires",
+	"EXTENDS":       "extends",
+	"GITREPO":       "gitRepo",
+	"GROUP":         "group",
+	"ID":            "id",
+	"IMAGE":         "image",
+	"KEY":           "key",
+	"KIND":          "kind",
+	"LABELS":        "labels",
+	"LANGUAGE":      "language",
+	"LAST-ATTACHED": "lastAttached",
+	"LAST_SYNCED":   "lastSynced",
+	"LIFECYCLE":     "lifecycle",
+	"MANAGER":       "manager",
+	"NAME":          "name",
+	"PALETTE_REF":   "paletteRef",
+	"PATH":          "path",
+	"PLUGIN":        "plugin",
+	"PLUGINS":       "plugins",
+	"PLURAL":        "plural",
+	"PORT":          "port",
+	"PROFILES":      "profiles",
+	"PROMPTS":       "prompts",
+	"REASON":        "reason",
+	"REF":           "ref",
+	"REGISTRY":      "registry",
+	"REPO":          "repo",
+	"REVISION":      "revision",
+	"SCOPE":         "scope",
+	"SHELL":         "shell",
+	"SINGULAR":      "singular",
+	"SIZE":          "size",
+	"SLUG":          "slug",
+	"SOCKET":        "socket",
+	"SOURCE":        "source",
+	"STATE":         "state",
+	"STATUS":        "status",
+	"STYLE":         "style",
+	"SYSTEM":        "system",
+	"TAG":           "tag",
+	"TARGET":        "target",
+	"THEME":         "theme",
+	"THEME SOURCE":  "themeSource",
+	"TYPE":          "type",
+	"UPTIME":        "uptime",
+	"URL":           "url",
+	"VALUE":         "value",
+	"VAULT-SECRET":  "vaultSecret",
+	"VERSION":       "version",
+	"WINDOW":        "window",
+	"WORKSPACE":     "workspace",
+}
+
+// tableFieldName returns the json/yaml field name of a table header from
+// outputFields. Headers of ad-hoc tables not listed there fall back to
+// outputFieldName.
+func tableFieldName(header string) string {
+	if name, ok := outputFields[strings.TrimSpace(header)]; ok {
+		return name
+	}
+	return outputFieldName(header)
+}
+
+// outputFieldName turns a header ("LAST-ATTACHED", "THEME SOURCE") into a
+// camelCase field name ("lastAttached", "themeSource").
+func outputFieldName(header string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range strings.TrimSpace(header) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = b.Len() > 0
+			continue
+		}
+		if upper {
+			b.WriteRune(unicode.ToUpper(r))
+		} else {
+			b.WriteRune(unicode.ToLower(r))
+		}
+		upper = false
+	}
+	return b.String()
+}
+
+// tableNameColumn returns the index of the NAME column, or 0.
+func tableNameColumn(headers []string) int {
+	for i, h := range headers {
+		if strings.EqualFold(strings.TrimSpace(h), "name") {
+			return i
+		}
+	}
+	return 0
+}
+
+// resourceNames extracts names for -o name: the NAME column of a table, the
+// Name pair of key-value data, or the name (or metadata.name) field of each
+// structured item, including the items of a kind: List document.
+func resourceNames(data any) []string {
+	switch v := data.(type) {
+	case nil:
+		return nil
+	case render.TableData:
+		col := tableNameColumn(v.Headers)
+		var names []string
+		for _, row := range v.Rows {
+			if col < len(row) {
+				if name := strings.TrimSpace(strings.TrimPrefix(row[col], activeMarkerPrefix)); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+		return names
+	case render.KeyValueData:
+		for _, kv := range v.Pairs {
+			if strings.EqualFold(kv.Key, "name") {
+				return []string{kv.Value}
+			}
+		}
+		return nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil
+	}
+	var names []string
+	var walk func(any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case []any:
+			for _, item := range n {
+				walk(item)
+			}
+		case map[string]any:
+			if name := structuredName(n); name != "" {
+				names = append(names, name)
+				return
+			}
+			if items, ok := n["items"]; ok {
+				walk(items)
+			}
+		}
+	}
+	walk(generic)
+	return names
+}
+
+// structuredName returns an object's name, metadata.name, or Name field.
+func structuredName(obj map[string]any) string {
+	for _, key := range []string{"name", "Name"} {
+		if s, ok := obj[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	if md, ok := obj["metadata"].(map[string]any); ok {
+		if s, ok := md["name"].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormatValue_RejectsUnknown(t *testing.T) {
+	var format string
+	v := newOutputFormatValue(&format, outputTable)
+	assert.Equal(t, outputTable, format)
+
+	for _, f := range []string{"json", "yaml", "name", "wide", "colored"} {
+		require.NoError(t, v.Set(f), f)
+		assert.Equal(t, f, format)
+	}
+
+	err := v.Set("xml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported output format "xml"`)
+	assert.Equal(t, "colored", format, "rejected value must not be stored")
+}
+
+func TestOutputFieldName(t *testing.T) {
+	tests := map[string]string{
+		"NAME":          "name",
+		"LAST-ATTACHED": "lastAttached",
+		"THEME SOURCE":  "themeSource",
+		" IMAGE_TAG ":   "imageTag",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, outputFieldName(header), header)
+	}
+}
+
+func testOutputTable() render.TableData {
+	return render.TableData{
+		Headers: []string{"APP", "NAME", "LAST-ATTACHED"},
+		Rows: [][]string{
+			{"api", activeMarkerPrefix + "dev", "2h"},
+			{"api", "staging", "-"},
+		},
+	}
+}
+
+func TestTableRecords_ActiveMarker(t *testing.T) {
+	records, ok := tableRecords(testOutputTable()).([]map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, []map[string]any{
+		{"app": "api", "name": "dev", "lastAttached": "2h", "active": true},
+		{"app": "api", "name": "staging", "lastAttached": "-", "active": false},
+	}, records)
+}
+
+func TestResourceNames(t *testing.T) {
+	assert.Equal(t, []string{"dev", "staging"}, resourceNames(testOutputTable()))
+
+	type item struct {
+		Name string `json:"name"`
+	}
+	assert.Equal(t, []string{"a", "b"}, resourceNames([]item{{"a"}, {"b"}}))
+
+	list := map[string]any{
+		"kind": "List",
+		"items": []any{
+			map[string]any{"kind": "App", "metadata": map[string]any{"name": "api"}},
+			map[string]any{"kind": "App", "metadata": map[string]any{"name": "web"}},
+		},
+	}
+	assert.Equal(t, []string{"api", "web"}, resourceNames(list))
+}
+
+func TestOutputRenderers(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, render.OutputTo(&buf, outputName, testOutputTable(), render.Options{}))
+	assert.Equal(t, "dev\nstaging\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, render.OutputTo(&buf, outputJSON, testOutputTable(), render.Options{}))
+	var records []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &records))
+	require.Len(t, records, 2)
+	assert.Equal(t, "dev", records[0]["name"])
+	assert.Equal(t, true, records[0]["active"])
+
+	// Commands with wide columns render them through tableFormat; anything
+	// reaching the wide renderer has none and is rejected.
+	buf.Reset()
+	err := render.OutputTo(&buf, outputWide, testOutputTable(), render.Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no extra columns")
+	assert.Empty(t, buf.String())
+	assert.Equal(t, outputTable, tableFormat(outputWide))
+	assert.Equal(t, outputJSON, tableFormat(outputJSON))
+}
+
+func TestOutputFields(t *testing.T) {
+	builders := []tableBuilder{
+		&ecosystemTableBuilder{}, &domainTableBuilder{}, &systemTableBuilder{},
+		&appTableBuilder{}, &workspaceTableBuilder{}, &credentialTableBuilder{},
+		&registryTableBuilder{}, &gitRepoTableBuilder{}, &nvimPluginTableBuilder{},
+		&nvimThemeTableBuilder{}, &nvimPackageTableBuilder{}, &terminalPromptTableBuilder{},
+		&terminalPackageTableBuilder{}, &terminalPluginTableBuilder{}, &caCertTableBuilder{},
+		&buildArgTableBuilder{}, &crdTableBuilder{},
+	}
+	for _, b := range builders {
+		for _, h := range b.Headers(true) {
+			_, ok := outputFields[h]
+			assert.True(t, ok, "%T column %q has no entry in outputFields", b, h)
+		}
+	}
+
+	// Field names are fixed, not derived from the header text
+	assert.Equal(t, "gitRepo", tableFieldName("GITREPO"))
+	assert.Equal(t, "lastSynced", tableFieldName("LAST_SYNCED"))
+}
+
+func TestOutputFormatValue_Templates(t *testing.T) {
+	var format string
+	v := newOutputFormatValue(&format, "")
+
+	require.NoError(t, v.Set("jsonpath={.items[*].name}"))
+	assert.True(t, isStructuredOutput(format))
+
+	err := v.Set("jsonpath")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a template")
+
+	err = v.Set("jsonpath={.items[?(@.name)]}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid jsonpath template")
+
+	err = v.Set("go-template={{.name")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid go-template")
+}
+
+func TestTemplateRenderers(t *testing.T) {
+	var format string
+	v := newOutputFormatValue(&format, "")
+
+	type workspace struct {
+		Name  string `yaml:"name"`
+		Image string `yaml:"image"`
+	}
+	data := []workspace{{"dev", "dvm-dev-api:1"}, {"staging", "dvm-staging-api:2"}}
+
+	require.NoError(t, v.Set(`jsonpath={range [*]}{.name}={.image}{"\n"}{end}`))
+	var buf bytes.Buffer
+	require.NoError(t, render.OutputTo(&buf, format, data, render.Options{}))
+	assert.Equal(t, "dev=dvm-dev-api:1\nstaging=dvm-staging-api:2\n", buf.String())
+
+	require.NoError(t, v.Set(`go-template={{range .}}{{.name}} {{end}}`))
+	buf.Reset()
+	require.NoError(t, render.OutputTo(&buf, format, data, render.Options{}))
+	assert.Equal(t, "dev staging ", buf.String())
+
+	// Table output is exposed through the same records as -o json
+	require.NoError(t, v.Set(`jsonpath={[0].lastAttached}`))
+	buf.Reset()
+	require.NoError(t, render.OutputTo(&buf, format, testOutputTable(), render.Options{}))
+	assert.Equal(t, "2h", buf.String())
+}
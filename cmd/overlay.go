@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// overlayCmd groups overlay commands.
+// Usage: dvm overlay render overlays/work-laptop
+var overlayCmd = &cobra.Command{
+	Use:     "overlay",
+	Aliases: []string{"overlays"},
+	Short:   "Build resource manifests from a base and per-machine patches",
+	Long: `Overlays keep one base definition of a workspace (or any resources) and a
+thin overlay for each machine or environment that patches only what differs,
+in the style of kustomize. dvm builds them locally; nothing about an overlay
+is stored.
+
+  workspaces/
+    base/workspace.yaml
+    overlays/work-laptop/overlay.yaml    # kind: Overlay
+    overlays/work-laptop/registry.yaml   # a patch
+    overlays/home-desktop/overlay.yaml
+
+An overlay lists its resources (files, directories, or other overlays), labels
+to add to each, and patches: strategic merge (the default), merge, or json,
+inline or from a file, targeting a kind and name.
+
+Examples:
+  dvm overlay render overlays/work-laptop
+  dvm apply -k overlays/work-laptop
+  dvm apply -k overlays/home-desktop --set gpu=all`,
+}
+
+// overlayRenderCmd prints the resources an overlay builds.
+var overlayRenderCmd = &cobra.Command{
+	Use:   "render <dir>",
+	Short: "Print the resources an overlay builds",
+	Long: `Build an overlay and print its resources as the List document
+'dvm apply -k' would apply. ${name} variables are substituted from --set,
+--values, the environment, and defaults as in 'dvm apply'.
+
+Examples:
+  dvm overlay render overlays/work-laptop
+  dvm overlay render overlays/work-laptop --set language=go > work-laptop.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runOverlayRender,
+}
+
+func init() {
+	overlayRenderCmd.Flags().StringArray("set", nil, "Set a ${name} variable in the manifests (name=value, repeatable)")
+	overlayRenderCmd.Flags().StringArray("values", nil, "YAML file of ${name} variables (repeatable; later files win, --set wins over all)")
+
+	overlayCmd.AddCommand(overlayRenderCmd)
+	rootCmd.AddCommand(overlayCmd)
+}
+
+func runOverlayRender(cmd *cobra.Command, args []string) error {
+	ctx, err := buildResourceContext(cmd)
+	if err != nil {
+		return err
+	}
+	vars, err := manifestVarSources(cmd, ctx)
+	if err != nil {
+		return err
+	}
+	list, err := buildOverlay(args[0], vars)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(cmd.OutOrStdout(), string(list))
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+)
+
+var patchFlags HierarchyFlags
+
+var patchCmd = &cobra.Command{
+	Use:   "patch <kind> <name>",
+	Short: "Update fields of a resource with a patch",
+	Long: `Update fields of a stored resource without writing a full manifest.
+
+The patch, given with --patch or --patch-file in JSON or YAML, is applied to
+the resource's YAML (as shown by 'dvm get <kind> <name> -o yaml'), and the
+result is checked and applied like 'dvm apply'. The kind and name cannot be
+changed.
+
+Patch types (--type):
+  strategic  (default) Objects merge and null removes a field. Lists of
+             objects merge by name (mounts by destination); an item with
+             "$patch": "delete" removes the matching item. Other lists
+             are replaced.
+  merge      RFC 7386 JSON merge patch: like strategic, but lists are
+             always replaced.
+  json       RFC 6902 JSON patch: a list of add, remove, replace, move,
+             copy, and test operations on JSON Pointer paths.
+
+Examples:
+  dvm patch workspace dev -p '{"spec":{"image":{"name":"ubuntu:24.04"}}}'
+  dvm patch workspace dev -a api -p '{"spec":{"mounts":[{"destination":"/cache","$patch":"delete"}]}}'
+  dvm patch app api --type merge -p '{"metadata":{"labels":{"tier":null}}}'
+  dvm patch workspace dev --type json -p '[{"op":"add","path":"/spec/nvim/plugins/-","value":"lualine"}]'
+  dvm patch ecosystem prod --patch-file patch.yaml --dry-run`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeResourceKinds,
+	RunE:              runPatch,
+}
+
+func init() {
+	AddHierarchyFlags(patchCmd, &patchFlags)
+	patchCmd.Flags().StringP("patch", "p", "", "The patch, in JSON or YAML")
+	patchCmd.Flags().String("patch-file", "", "File containing the patch")
+	patchCmd.Flags().String("type", string(handlers.StrategicMergePatch), "Patch type: strategic, merge, or json")
+	patchCmd.Flags().Bool("dry-run", false, "Print the patched resource without applying it")
+	patchCmd.Flags().Bool("validate", true, "Reject fields the resource kind doesn't define (--validate=false to skip)")
+
+	rootCmd.AddCommand(patchCmd)
+}
+
+func runPatch(cmd *cobra.Command, args []string) error {
+	defer resetLocalFlags(cmd)
+
+	patchText, _ := cmd.Flags().GetString("patch")
+	patchFile, _ := cmd.Flags().GetString("patch-file")
+	typeName, _ := cmd.Flags().GetString("type")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	validate, _ := cmd.Flags().GetBool("validate")
+	handlers.SetStrict(validate)
+
+	patchType, err := handlers.ParsePatchType(typeName)
+	if err != nil {
+		return err
+	}
+	var patch []byte
+	switch {
+	case patchText != "" && patchFile != "":
+		return fmt.Errorf("use either --patch or --patch-file, not both")
+	case patchText != "":
+		patch = []byte(patchText)
+	case patchFile != "":
+		if patch, err = os.ReadFile(patchFile); err != nil {
+			return fmt.Errorf("failed to read patch file: %w", err)
+		}
+	default:
+		return fmt.Errorf("a patch is required (--patch or --patch-file)")
+	}
+
+	kind, err := resolveResourceKind(args[0])
+	if err != nil {
+		return err
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := resource.Context{DataStore: ds}
+
+	res, err := getStoredResource(ctx, ds, kind, args[1], patchFlags)
+	if err != nil {
+		return err
+	}
+	applied, patched, err := patchResource(ctx, res, patchType, patch, dryRun)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		cmd.Print(string(patched))
+		return nil
+	}
+	render.Success(fmt.Sprintf("%s '%s' patched", kind, applied.GetName()))
+	reportRebuilds(applied)
+	return nil
+}
+
+// patchResource applies patch to res's YAML and applies the result, or with
+// dryRun only validates it. It returns the applied resource and the patched
+// document.
+func patchResource(ctx resource.Context, res resource.Resource, pt handlers.PatchType, patch []byte, dryRun bool) (resource.Resource, []byte, error) {
+	kind, name := res.GetKind(), res.GetName()
+	original, err := resource.ToYAML(res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render %s '%s' as YAML: %w", kind, name, err)
+	}
+	patched, err := handlers.PatchYAML(original, pt, patch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to patch %s '%s': %w", kind, name, err)
+	}
+	if err := checkIdentity(kind, name, patched); err != nil {
+		return nil, nil, err
+	}
+	if dryRun {
+		if err := handlers.Validate(ctx, patched); err != nil {
+			return nil, nil, err
+		}
+		return nil, patched, nil
+	}
+	applied, err := resource.Apply(ctx, patched, "patch")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply patched %s '%s': %w", kind, name, err)
+	}
+	return applied, patched, nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchResource(t *testing.T) {
+	ctx, store, _ := setupEditTest(t)
+	eco, err := store.GetEcosystemByName("prod")
+	require.NoError(t, err)
+	res := handlers.NewEcosystemResource(eco)
+
+	// A dry run validates without storing
+	_, patched, err := patchResource(ctx, res, handlers.StrategicMergePatch, []byte(`{"spec":{"description":"Production"}}`), true)
+	require.NoError(t, err)
+	assert.Contains(t, string(patched), "description: Production")
+	eco, _ = store.GetEcosystemByName("prod")
+	assert.False(t, eco.Description.Valid && eco.Description.String != "", "dry run must not store the patch")
+
+	applied, _, err := patchResource(ctx, res, handlers.JSONPatch, []byte(`[{"op":"add","path":"/spec/description","value":"Production"}]`), false)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", applied.GetName())
+	eco, _ = store.GetEcosystemByName("prod")
+	assert.Equal(t, "Production", eco.Description.String)
+}
+
+func TestPatchResource_Rejects(t *testing.T) {
+	ctx, store, _ := setupEditTest(t)
+	eco, err := store.GetEcosystemByName("prod")
+	require.NoError(t, err)
+	res := handlers.NewEcosystemResource(eco)
+
+	_, _, err = patchResource(ctx, res, handlers.MergePatch, []byte(`{"metadata":{"name":"staging"}}`), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metadata.name cannot be changed")
+
+	_, _, err = patchResource(ctx, res, handlers.MergePatch, []byte(`{"spec":{"descripton":"x"}}`), false)
+	var se *handlers.StrictDecodingError
+	assert.ErrorAs(t, err, &se)
+}
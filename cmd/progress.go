@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"devopsmaestro/pkg/progress"
+
+	"github.com/spf13/cobra"
+)
+
+// newProgressReporter returns a reporter for the --progress mode that
+// writes to the command's output. Callers Close it when their tasks end.
+func newProgressReporter(cmd *cobra.Command) *progress.Reporter {
+	return progress.New(cmd.OutOrStdout(), progressMode)
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/terminalbridge"
+	"devopsmaestro/pkg/terminalbridge/promptgen"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroSDK/colors"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	theme "github.com/rmkohlman/MaestroTheme"
+
+	"github.com/spf13/cobra"
+)
+
+// promptCmd groups host-side shell prompt commands.
+// Usage: dvm prompt generate <name> --shell zsh
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Generate shell prompt configuration",
+	Long: `Generate shell prompt configuration from stored terminal prompts.
+
+Examples:
+  dvm prompt generate starship-default --shell zsh
+  dvm prompt generate dev-omp --shell bash --out ~/.config/oh-my-posh
+  dvm prompt generate p10k-lean --shell zsh --out ~`,
+}
+
+// promptGenerateCmd renders a stored terminal prompt in its native format.
+var promptGenerateCmd = &cobra.Command{
+	Use:   "generate <name>",
+	Short: "Render a stored prompt as starship.toml, oh-my-posh JSON, or .p10k.zsh",
+	Long: `Render a stored terminal prompt in the native format of its type:
+
+  starship       starship.toml
+  oh-my-posh     <name>.omp.json
+  powerlevel10k  .p10k.zsh (zsh only)
+
+Colors come from --theme, otherwise the prompt's palette_ref, otherwise the
+active theme. A palette_ref of "theme" or "active" always means the active
+theme. The prompt's own colors override the theme for prompt segments.
+
+--dvm-context adds a starship segment showing the active dvm context
+(see 'dvm context starship').
+
+Without --out, the config is written to stdout. With --out, the file is
+written there and the line to add to your shell rc file is printed.
+
+Examples:
+  dvm prompt generate starship-default --shell zsh
+  dvm prompt generate dev-omp --shell fish --out ~/.config/oh-my-posh
+  dvm prompt generate p10k-lean --out ~ --theme catppuccin-mocha
+  dvm prompt generate starship-default --dvm-context --out ~/.config`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTerminalPrompts,
+	RunE:              runPromptGenerate,
+}
+
+func init() {
+	promptGenerateCmd.Flags().String("shell", "zsh", "Shell to initialize the prompt in (zsh, bash, fish)")
+	promptGenerateCmd.Flags().String("theme", "", "Theme to take colors from (overrides palette_ref and the active theme)")
+	promptGenerateCmd.Flags().String("out", "", "Output directory (default: stdout)")
+	promptGenerateCmd.Flags().Bool("force", false, "Overwrite an existing config file")
+	promptGenerateCmd.Flags().Bool("dvm-context", false, "Add a segment showing the active dvm context (starship only)")
+	_ = promptGenerateCmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"zsh", "bash", "fish"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	promptCmd.AddCommand(promptGenerateCmd)
+	rootCmd.AddCommand(promptCmd)
+}
+
+func runPromptGenerate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	shell, _ := cmd.Flags().GetString("shell")
+	themeName, _ := cmd.Flags().GetString("theme")
+	outDir, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+	withContext, _ := cmd.Flags().GetBool("dvm-context")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+	p, err := terminalbridge.NewDBPromptStore(ds).Get(name)
+	if err != nil {
+		return fmt.Errorf("terminal prompt '%s' not found: %w", name, err)
+	}
+
+	if withContext {
+		if promptTypeOrDefault(p.Type) != prompt.PromptTypeStarship {
+			return fmt.Errorf("--dvm-context is only supported for starship prompts")
+		}
+		p = promptgen.WithContextModule(p)
+	}
+
+	// Validate the shell up front so p10k + bash fails before anything is written.
+	if _, err := promptgen.InitSnippet(p.Type, shell, ""); err != nil {
+		return err
+	}
+
+	gen, err := promptgen.NewForType(p.Type)
+	if err != nil {
+		return err
+	}
+
+	themeStore, err := getThemeStore(cmd)
+	if err != nil {
+		return err
+	}
+	pal, err := resolvePromptPalette(themeStore, p, themeName)
+	if err != nil {
+		return err
+	}
+	if pal == nil {
+		pal = colors.ToPalette(colors.FromContextOrDefault(cmd.Context()))
+	}
+
+	content, err := gen.GenerateWithPalette(p, pal)
+	if err != nil {
+		return err
+	}
+
+	if outDir == "" {
+		fmt.Fprint(cmd.OutOrStdout(), content)
+		return nil
+	}
+
+	if strings.HasPrefix(outDir, "~") {
+		home, _ := os.UserHomeDir()
+		outDir = filepath.Join(home, outDir[1:])
+	}
+	outPath := filepath.Join(outDir, promptgen.ConfigFileName(p.Type, p.Name))
+	if _, err := os.Stat(outPath); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", outPath)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	snippet, _ := promptgen.InitSnippet(p.Type, shell, outPath)
+	render.Successf("Generated %s config at %s", promptTypeOrDefault(p.Type), outPath)
+	render.Info(fmt.Sprintf("Add to your %s config:", shell))
+	render.Plain("  " + snippet)
+	return nil
+}
+
+// resolvePromptPalette picks the palette for a prompt. Precedence: the
+// explicit theme override > the prompt's palette_ref > the active theme.
+// palette_ref values "theme" and "active" mean the active theme. Returns nil
+// when no theme applies so the caller can fall back to default colors.
+func resolvePromptPalette(store theme.Store, p *prompt.Prompt, override string) (*palette.Palette, error) {
+	name := override
+	if name == "" {
+		switch ref := strings.TrimSpace(p.PaletteRef); ref {
+		case "", "theme", "active":
+		default:
+			name = ref
+		}
+	}
+	t, err := resolveTerminalTheme(store, name)
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		return nil, nil
+	}
+	return t.ToPalette(), nil
+}
+
+func promptTypeOrDefault(t prompt.PromptType) prompt.PromptType {
+	if t == "" {
+		return prompt.PromptTypeStarship
+	}
+	return t
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	theme "github.com/rmkohlman/MaestroTheme"
+)
+
+func TestResolvePromptPalette(t *testing.T) {
+	store := theme.NewMemoryStore()
+
+	t.Run("active theme ref without active theme", func(t *testing.T) {
+		pal, err := resolvePromptPalette(store, &prompt.Prompt{PaletteRef: "theme"}, "")
+		if err != nil || pal != nil {
+			t.Fatalf("got %v, %v; want nil, nil", pal, err)
+		}
+	})
+
+	t.Run("palette_ref names a library theme", func(t *testing.T) {
+		pal, err := resolvePromptPalette(store, &prompt.Prompt{PaletteRef: "catppuccin-mocha"}, "")
+		if err != nil || pal == nil || pal.Name != "catppuccin-mocha" {
+			t.Fatalf("got %v, %v; want catppuccin-mocha", pal, err)
+		}
+	})
+
+	t.Run("override wins over palette_ref", func(t *testing.T) {
+		pal, err := resolvePromptPalette(store, &prompt.Prompt{PaletteRef: "catppuccin-mocha"}, "catppuccin-latte")
+		if err != nil || pal == nil || pal.Name != "catppuccin-latte" {
+			t.Fatalf("got %v, %v; want catppuccin-latte", pal, err)
+		}
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		if _, err := resolvePromptPalette(store, &prompt.Prompt{PaletteRef: "no-such-theme"}, ""); err == nil {
+			t.Fatal("expected error for unknown theme")
+		}
+	})
+}
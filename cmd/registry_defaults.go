@@ -53,23 +53,9 @@ func runRegistrySetDefault(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("database not initialized: %w", err)
 	}
 
-	// Validate type is a known alias
-	allAliases := registry.GetAllAliases()
-	expectedType, isValidAlias := allAliases[aliasType]
-	if !isValidAlias {
-		return fmt.Errorf("unknown type '%s'. Valid types: oci, pypi, npm, go, http", aliasType)
-	}
-
-	// Validate registry exists
-	reg, err := store.GetRegistryByName(registryName)
-	if err != nil {
-		return fmt.Errorf("registry '%s' not found: %w", registryName, err)
-	}
-
-	// Validate registry type matches the alias
-	if reg.Type != expectedType {
-		return fmt.Errorf("registry '%s' is type '%s' but alias '%s' expects type '%s'",
-			registryName, reg.Type, aliasType, expectedType)
+	// Validate the registry exists and matches the alias type
+	if err := checkDefaultRegistry(store, aliasType, registryName); err != nil {
+		return err
 	}
 
 	// Set the default using RegistryDefaults
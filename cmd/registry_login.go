@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"devopsmaestro/config"
+	"devopsmaestro/pkg/registry"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// registryLoginCmd stores the login of a private upstream
+var registryLoginCmd = &cobra.Command{
+	Use:   "login <name>",
+	Short: "Store the login of a registry's private upstream",
+	Long: `Store a token for a private upstream of a registry (spec.config.upstreams)
+in MaestroVault. zot, athens, verdaccio, and devpi use it to pull from the
+upstream the next time the registry starts.
+
+The token is read from a prompt, or from stdin with --password-stdin.
+--upstream picks the upstream when the registry has more than one. An
+upstream with a 'credential' uses that global credential instead.
+
+Examples:
+  dvm registry login zot-local --username octocat
+  echo "$GHCR_TOKEN" | dvm registry login zot-local --upstream ghcr-acme --username octocat --password-stdin
+  dvm registry login verdaccio-local --upstream npm-acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRegistryLogin,
+}
+
+// openRegistryLoginBackend opens the secret backend logins are stored in
+// (replaced in tests).
+var openRegistryLoginBackend = func() (config.WritableBackend, error) {
+	return config.OpenVaultBackend()
+}
+
+func init() {
+	registryCmd.AddCommand(registryLoginCmd)
+
+	registryLoginCmd.Flags().String("upstream", "", "Upstream to log in to (required when the registry has several)")
+	registryLoginCmd.Flags().StringP("username", "u", "", "Upstream username (omit for token-only upstreams)")
+	registryLoginCmd.Flags().Bool("password-stdin", false, "Read the token from stdin")
+}
+
+func runRegistryLogin(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("database not initialized: %w", err)
+	}
+	reg, err := ds.GetRegistryByName(name)
+	if err != nil {
+		return fmt.Errorf("registry '%s' not found: %w", name, err)
+	}
+
+	upstreams, err := registry.UpstreamNames(reg)
+	if err != nil {
+		return err
+	}
+	upstream, _ := cmd.Flags().GetString("upstream")
+	switch {
+	case len(upstreams) == 0:
+		return ErrorWithSuggestion(
+			fmt.Sprintf("registry '%s' has no upstreams", name),
+			"Add them under spec.config.upstreams and apply the registry again",
+		)
+	case upstream == "" && len(upstreams) > 1:
+		return fmt.Errorf("registry '%s' has several upstreams; pick one with --upstream (%s)", name, strings.Join(upstreams, ", "))
+	case upstream == "":
+		upstream = upstreams[0]
+	case !slices.Contains(upstreams, upstream):
+		return fmt.Errorf("registry '%s' has no upstream '%s' (upstreams: %s)", name, upstream, strings.Join(upstreams, ", "))
+	}
+
+	username, _ := cmd.Flags().GetString("username")
+	fromStdin, _ := cmd.Flags().GetBool("password-stdin")
+	token, err := readRegistryToken(cmd.InOrStdin(), cmd.ErrOrStderr(), fromStdin)
+	if err != nil {
+		return err
+	}
+
+	backend, err := openRegistryLoginBackend()
+	if err != nil {
+		return fmt.Errorf("failed to open the secret backend: %w", err)
+	}
+	secret := registry.LoginSecretName(name, upstream)
+	if err := backend.Set(secret, "", token); err != nil {
+		return err
+	}
+	if username != "" {
+		if err := backend.SetField(secret, "", "username", username); err != nil {
+			return err
+		}
+	}
+
+	render.Success(fmt.Sprintf("Login for upstream '%s' of registry '%s' stored", upstream, name))
+	render.Info(fmt.Sprintf("Restart the registry to use it: dvm stop registry %s && dvm start registry %s", name, name))
+	return nil
+}
+
+// readRegistryToken reads a token from in: the first line with fromStdin,
+// else from a prompt without echo on prompt.
+func readRegistryToken(in io.Reader, prompt io.Writer, fromStdin bool) (string, error) {
+	var token string
+	if fromStdin {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read token from stdin: %w", err)
+		}
+		token = line
+	} else {
+		f, ok := in.(*os.File)
+		if !ok || !term.IsTerminal(int(f.Fd())) {
+			return "", fmt.Errorf("no terminal to prompt for the token; use --password-stdin")
+		}
+		fmt.Fprint(prompt, "Token: ")
+		b, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(prompt)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token: %w", err)
+		}
+		token = string(b)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("token is empty")
+	}
+	return token, nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"devopsmaestro/config"
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWritableBackend records the secrets stored by dvm registry login.
+type fakeWritableBackend struct {
+	values map[string]string
+	fields map[string]string
+}
+
+func (f *fakeWritableBackend) Get(name, env string) (string, error) { return f.values[name], nil }
+func (f *fakeWritableBackend) GetField(name, env, field string) (string, error) {
+	return f.fields[name+"/"+field], nil
+}
+func (f *fakeWritableBackend) Health() error { return nil }
+func (f *fakeWritableBackend) Set(name, env, value string) error {
+	f.values[name] = value
+	return nil
+}
+func (f *fakeWritableBackend) SetField(name, env, field, value string) error {
+	f.fields[name+"/"+field] = value
+	return nil
+}
+
+func newRegistryLoginTestCmd(t *testing.T, config string, stdin string) *cobra.Command {
+	t.Helper()
+	store := db.NewMockDataStore()
+	require.NoError(t, store.CreateRegistry(&models.Registry{
+		Name:    "zot-local",
+		Type:    "zot",
+		Storage: "/tmp/zot-local",
+		Config:  sql.NullString{String: config, Valid: config != ""},
+	}))
+
+	cmd := &cobra.Command{RunE: runRegistryLogin}
+	cmd.Flags().String("upstream", "", "")
+	cmd.Flags().StringP("username", "u", "", "")
+	cmd.Flags().Bool("password-stdin", false, "")
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(store)))
+	cmd.SetIn(strings.NewReader(stdin))
+	return cmd
+}
+
+func TestRegistryLogin_StoresToken(t *testing.T) {
+	backend := &fakeWritableBackend{values: map[string]string{}, fields: map[string]string{}}
+	orig := openRegistryLoginBackend
+	defer func() { openRegistryLoginBackend = orig }()
+	openRegistryLoginBackend = func() (config.WritableBackend, error) { return backend, nil }
+
+	cmd := newRegistryLoginTestCmd(t, `{"upstreams":[{"name":"ghcr-acme","url":"https://ghcr.io"}]}`, "ghp_token\n")
+	require.NoError(t, cmd.Flags().Set("username", "octocat"))
+	require.NoError(t, cmd.Flags().Set("password-stdin", "true"))
+	require.NoError(t, runRegistryLogin(cmd, []string{"zot-local"}))
+
+	assert.Equal(t, "ghp_token", backend.values["dvm-registry-zot-local-ghcr-acme"])
+	assert.Equal(t, "octocat", backend.fields["dvm-registry-zot-local-ghcr-acme/username"])
+}
+
+func TestRegistryLogin_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		upstream string
+		stdin    string
+		wantErr  string
+	}{
+		{name: "no upstreams", config: "", wantErr: "has no upstreams"},
+		{name: "ambiguous upstream", config: `{"upstreams":[{"name":"a","url":"https://a.dev"},{"name":"b","url":"https://b.dev"}]}`, wantErr: "pick one with --upstream"},
+		{name: "unknown upstream", config: `{"upstreams":[{"name":"a","url":"https://a.dev"}]}`, upstream: "c", wantErr: "has no upstream 'c'"},
+		{name: "empty token", config: `{"upstreams":[{"name":"a","url":"https://a.dev"}]}`, stdin: "\n", wantErr: "token is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRegistryLoginTestCmd(t, tt.config, tt.stdin)
+			require.NoError(t, cmd.Flags().Set("password-stdin", "true"))
+			if tt.upstream != "" {
+				require.NoError(t, cmd.Flags().Set("upstream", tt.upstream))
+			}
+			err := runRegistryLogin(cmd, []string{"zot-local"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/registry"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// registryStatsCmd reports what each registry has cached
+var registryStatsCmd = &cobra.Command{
+	Use:   "stats [name]",
+	Short: "Show the cache contents and disk usage of registries",
+	Long: `Show what each registry has cached: repositories (zot), modules (athens),
+packages (devpi, verdaccio), or objects (squid), the number of cached
+versions, the disk space they use, and when content was last written.
+
+Counts come from the registry's API while it runs (zot's /v2/_catalog,
+verdaccio's package list) and from its storage otherwise. Cache hits and
+misses are shown for squid, which records them in its access log.
+
+Examples:
+  dvm registry stats
+  dvm registry stats zot-local
+  dvm registry stats -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRegistryStats,
+}
+
+// newRegistryStatsManager creates the manager a registry's stats are read
+// from (replaced in tests).
+var newRegistryStatsManager = func(reg *models.Registry) (registry.ServiceManager, error) {
+	return registry.NewServiceFactory().CreateManager(reg)
+}
+
+func init() {
+	registryCmd.AddCommand(registryStatsCmd)
+	AddOutputFlag(registryStatsCmd, "")
+}
+
+// RegistryStatsOutput is the cache of one registry in dvm registry stats.
+type RegistryStatsOutput struct {
+	Name      string `json:"name" yaml:"name"`
+	Type      string `json:"type" yaml:"type"`
+	State     string `json:"state" yaml:"state"`
+	Kind      string `json:"kind" yaml:"kind"` // what Items counts
+	Items     int    `json:"items" yaml:"items"`
+	Versions  int    `json:"versions" yaml:"versions"`
+	DiskUsage int64  `json:"diskUsage" yaml:"diskUsage"` // bytes
+	Hits      *int64 `json:"hits,omitempty" yaml:"hits,omitempty"`
+	Misses    *int64 `json:"misses,omitempty" yaml:"misses,omitempty"`
+	LastSync  string `json:"lastSync,omitempty" yaml:"lastSync,omitempty"` // RFC 3339
+}
+
+func runRegistryStats(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("output")
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("database not initialized: %w", err)
+	}
+
+	var registries []*models.Registry
+	if len(args) == 1 {
+		reg, err := ds.GetRegistryByName(args[0])
+		if err != nil {
+			return fmt.Errorf("registry '%s' not found: %w", args[0], err)
+		}
+		registries = []*models.Registry{reg}
+	} else {
+		registries, err = ds.ListRegistries()
+		if err != nil {
+			return fmt.Errorf("failed to list registries: %w", err)
+		}
+	}
+	if len(registries) == 0 {
+		render.Info("No registries found")
+		return nil
+	}
+
+	ctx := commandContext(cmd)
+	var outputs []RegistryStatsOutput
+	for _, reg := range registries {
+		mgr, err := newRegistryStatsManager(reg)
+		if err != nil {
+			return fmt.Errorf("registry '%s': %w", reg.Name, err)
+		}
+		reporter, ok := mgr.(registry.StorageReporter)
+		if !ok {
+			continue
+		}
+		out := RegistryStatsOutput{Name: reg.Name, Type: reg.Type, State: "stopped"}
+		if mgr.IsRunning(ctx) {
+			out.State = "running"
+		}
+		stats := reporter.StorageStats(ctx)
+		out.Kind, out.Items, out.Versions, out.DiskUsage = stats.Kind, stats.Items, stats.Versions, stats.DiskUsage
+		if stats.Counters != nil {
+			out.Hits, out.Misses = &stats.Counters.Hits, &stats.Counters.Misses
+		}
+		if !stats.LastSync.IsZero() {
+			out.LastSync = stats.LastSync.UTC().Format(time.RFC3339)
+		}
+		outputs = append(outputs, out)
+	}
+
+	if isStructuredOutput(format) {
+		return render.OutputWith(format, outputs, render.Options{})
+	}
+
+	td := render.TableData{Headers: []string{"NAME", "TYPE", "STATE", "CACHED", "VERSIONS", "DISK", "HITS/MISSES", "LAST SYNC"}}
+	for _, o := range outputs {
+		versions := "-"
+		if o.Versions > 0 {
+			versions = fmt.Sprintf("%d", o.Versions)
+		}
+		td.Rows = append(td.Rows, []string{
+			o.Name, o.Type, o.State,
+			fmt.Sprintf("%d %s", o.Items, o.Kind),
+			versions,
+			formatBytes(o.DiskUsage),
+			formatCacheCounters(o.Hits, o.Misses),
+			formatLastSync(o.LastSync),
+		})
+	}
+	return render.OutputWith(format, td, render.Options{Type: render.TypeTable})
+}
+
+// formatCacheCounters returns hits/misses with the hit rate, e.g.
+// "80/20 (80%)", or "-" when the registry doesn't count them.
+func formatCacheCounters(hits, misses *int64) string {
+	if hits == nil || misses == nil {
+		return "-"
+	}
+	total := *hits + *misses
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", *hits, *misses, *hits*100/total)
+}
+
+// formatLastSync returns how long ago an RFC 3339 time was, or "-".
+func formatLastSync(lastSync string) string {
+	t, err := time.Parse(time.RFC3339, lastSync)
+	if err != nil {
+		return "-"
+	}
+	return formatDuration(time.Since(t)) + " ago"
+}
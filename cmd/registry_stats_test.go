@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/registry"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatsManager is a running registry with fixed storage stats.
+type fakeStatsManager struct {
+	stats registry.StorageStats
+}
+
+func (f *fakeStatsManager) Start(ctx context.Context) error                        { return nil }
+func (f *fakeStatsManager) Stop(ctx context.Context) error                         { return nil }
+func (f *fakeStatsManager) IsRunning(ctx context.Context) bool                     { return true }
+func (f *fakeStatsManager) GetEndpoint() string                                    { return "" }
+func (f *fakeStatsManager) StorageStats(ctx context.Context) registry.StorageStats { return f.stats }
+
+func TestRegistryStats_JSON(t *testing.T) {
+	store := db.NewMockDataStore()
+	require.NoError(t, store.CreateRegistry(&models.Registry{Name: "squid-local", Type: "squid", Storage: "/tmp/squid"}))
+	require.NoError(t, store.CreateRegistry(&models.Registry{Name: "zot-local", Type: "zot", Storage: "/tmp/zot"}))
+
+	lastSync := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	orig := newRegistryStatsManager
+	defer func() { newRegistryStatsManager = orig }()
+	newRegistryStatsManager = func(reg *models.Registry) (registry.ServiceManager, error) {
+		if reg.Type == "squid" {
+			return &fakeStatsManager{stats: registry.StorageStats{Kind: "objects", Items: 7, Counters: &registry.CacheCounters{Hits: 3, Misses: 1}}}, nil
+		}
+		return &fakeStatsManager{stats: registry.StorageStats{Kind: "repositories", Items: 2, Versions: 5, DiskUsage: 2048, LastSync: lastSync}}, nil
+	}
+
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(origWriter)
+
+	cmd := &cobra.Command{RunE: runRegistryStats}
+	cmd.Flags().StringP("output", "o", "json", "")
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, db.DataStore(store)))
+	require.NoError(t, runRegistryStats(cmd, nil))
+
+	var out []RegistryStatsOutput
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Len(t, out, 2)
+	byName := map[string]RegistryStatsOutput{out[0].Name: out[0], out[1].Name: out[1]}
+
+	zot := byName["zot-local"]
+	assert.Equal(t, "running", zot.State)
+	assert.Equal(t, 2, zot.Items)
+	assert.Equal(t, int64(2048), zot.DiskUsage)
+	assert.Equal(t, "2026-10-01T12:00:00Z", zot.LastSync)
+	assert.Nil(t, zot.Hits)
+
+	squid := byName["squid-local"]
+	require.NotNil(t, squid.Hits)
+	assert.Equal(t, int64(3), *squid.Hits)
+	assert.Empty(t, squid.LastSync)
+}
+
+func TestFormatCacheCounters(t *testing.T) {
+	hits, misses, zero := int64(80), int64(20), int64(0)
+	assert.Equal(t, "80/20 (80%)", formatCacheCounters(&hits, &misses))
+	assert.Equal(t, "0/0", formatCacheCounters(&zero, &zero))
+	assert.Equal(t, "-", formatCacheCounters(nil, nil))
+}
@@ -310,8 +310,8 @@ func runRolloutStatusRegistry(cmd *cobra.Command, args []string) error {
 	}
 
 	// For JSON/YAML, use the map form
-	switch format {
-	case "json", "yaml":
+	switch {
+	case isStructuredOutput(format):
 		statusMap := map[string]interface{}{
 			"name":     reg.Name,
 			"enabled":  reg.Enabled,
@@ -382,8 +382,8 @@ func runRolloutHistoryRegistry(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	switch format {
-	case "json", "yaml":
+	switch {
+	case isStructuredOutput(format):
 		// Convert to clean DTOs without sql.Null* wrappers
 		historyYAML := make([]models.RegistryHistoryYAML, len(history))
 		for i, h := range history {
@@ -443,6 +443,10 @@ func outputData(ctx context.Context, format string, data interface{}) error {
 		// Use render.OutputWith for default table formatting
 		return render.OutputWith(format, data, render.Options{})
 	default:
+		if isStructuredOutput(format) {
+			// jsonpath and go-template
+			return render.OutputWith(format, data, render.Options{})
+		}
 		return fmt.Errorf("unsupported output format: %s (use json, yaml, or table)", format)
 	}
 }
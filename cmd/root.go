@@ -2,21 +2,35 @@ package cmd
 
 import (
 	"context"
+	"devopsmaestro/config"
 	"devopsmaestro/db"
+	"devopsmaestro/operators"
 	"devopsmaestro/pkg/colorbridge"
 	"devopsmaestro/pkg/crd"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/githubapi"
+	"devopsmaestro/pkg/httpclient"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/progress"
 	"devopsmaestro/pkg/resource/handlers"
+	"devopsmaestro/pkg/source"
+	"devopsmaestro/pkg/tablestyle"
+	"devopsmaestro/pkg/telemetry"
 	"devopsmaestro/utils"
+	"errors"
 	"fmt"
 	"github.com/rmkohlman/MaestroSDK/colors"
+	"github.com/rmkohlman/MaestroSDK/paths"
 	"github.com/rmkohlman/MaestroSDK/render"
 	theme "github.com/rmkohlman/MaestroTheme"
 	"io/fs"
 	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -29,6 +43,14 @@ var (
 	noColor      bool
 	outputFormat string
 	themeFlag    string
+
+	// commandTimeout bounds the whole command through its context; zero
+	// means no limit.
+	commandTimeout time.Duration
+
+	// progressMode selects how commands with concurrent tasks report
+	// progress (--progress).
+	progressMode = progress.ModeAuto
 )
 
 // errSilent is returned by commands that have already displayed their error
@@ -50,11 +72,41 @@ create, manage, and deploy workspaces, apps, dependencies, and more.`,
 func Execute(dataStore *db.DataStore, executor *Executor, migrationsFS fs.FS) {
 	// Explicit initialization: register all resource handlers at startup
 	handlers.RegisterAll()
+	handlers.SetWarningHandler(func(msg string) { render.WarningfToStderr("%s", msg) })
 
+	cancelTimeout := func() {}
+	shutdownTelemetry := func(context.Context) error { return nil }
+	endCommand := func(error) {}
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		// Initialize logging
 		initLogging()
 
+		// Export traces and metrics when DVM_OTEL_ENDPOINT is set
+		if shutdown, err := telemetry.Init(cmd.Context(), "dvm", Version); err != nil {
+			slog.Warn("telemetry disabled", "error", err)
+		} else {
+			shutdownTelemetry = shutdown
+		}
+
+		// The active ecosystem's config file layers over the user's
+		applyEcosystemConfig(dataStore)
+
+		// Proxy and CA settings for every HTTP client dvm builds
+		if err := httpclient.Configure(config.GetConfig().Network.HTTPSettings()); err != nil {
+			slog.Warn("ignoring network settings", "error", err)
+		}
+
+		// Token auth, ETag caching, and rate-limit backoff for GitHub requests
+		githubapi.Register(source.GitHubToken, githubCacheDir())
+
+		// Lifecycle events for webhooks and local automation
+		eventSettings := config.GetConfig().Events.EventSettings()
+		if err := eventSettings.Validate(); err != nil {
+			slog.Warn("ignoring events settings", "error", err)
+		} else {
+			events.Configure("dvm", eventSettings)
+		}
+
 		// Initialize ColorProvider - construct adapter chain at composition root
 		themePath := colors.GetDefaultThemePath()
 		var paletteProvider colors.PaletteProvider
@@ -70,6 +122,37 @@ func Execute(dataStore *db.DataStore, executor *Executor, migrationsFS fs.FS) {
 		if err != nil {
 			slog.Warn("using default colors", "error", err)
 		}
+		// --theme, DVM_THEME, or the config theme replace the active theme;
+		// "auto" keeps it
+		if name := outputThemeName(); name != "" {
+			themed, err := colors.InitColorProviderWithTheme(cmd.Context(), paletteProvider, name, noColor)
+			switch {
+			case err == nil:
+				ctx = themed
+			case themeFlag != "":
+				return ErrorWithSuggestion(fmt.Sprintf("theme %q not found", name),
+					"Run 'dvm library list themes' to see available themes")
+			default:
+				slog.Warn("using active theme colors", "theme", name, "error", err)
+			}
+		}
+		// Tables are drawn by renderers registered globally, outside the
+		// command context, so they are bound to the resolved colors here
+		tablestyle.Register(colors.FromContextOrDefault(ctx), noColor)
+
+		// --timeout cancels the command's context, and with it any query,
+		// build, or sync still running when the deadline passes
+		if commandTimeout > 0 {
+			ctx, cancelTimeout = context.WithTimeout(ctx, commandTimeout)
+		}
+
+		// The command span is the parent of every build, sync, migration,
+		// and query span below it
+		ctx, span := telemetry.Start(ctx, cmd.CommandPath())
+		endCommand = func(err error) {
+			telemetry.End(span, err)
+			telemetry.RecordCommand(ctx, cmd.CommandPath(), err)
+		}
 
 		// Set the dataStore and executor for all commands
 		ctx = context.WithValue(ctx, CtxKeyDataStore, dataStore)
@@ -77,6 +160,8 @@ func Execute(dataStore *db.DataStore, executor *Executor, migrationsFS fs.FS) {
 		ctx = context.WithValue(ctx, ctxKeyMigrationsFS, migrationsFS)
 		cmd.SetContext(ctx)
 
+		applyDefaultOutput(cmd, config.GetConfig().Output)
+
 		// Auto-migrate database if needed (skip for commands that don't need DB)
 		if shouldSkipAutoMigration(cmd) {
 			return nil
@@ -86,18 +171,26 @@ func Execute(dataStore *db.DataStore, executor *Executor, migrationsFS fs.FS) {
 			driver := (*dataStore).Driver()
 			if driver != nil {
 				// Use version-based auto-migration for better performance
+				_, migrateSpan := telemetry.Start(ctx, "dvm.db.migrate")
 				migrationsApplied, err := db.CheckVersionBasedAutoMigration(driver, migrationsFS, Version, verbose)
+				telemetry.End(migrateSpan, err)
 				if err != nil {
 					// Migration failure is critical - return error via errSilent
 					slog.Error("auto-migration failed", "error", err)
 					render.Errorf("Failed to apply database migrations: %v", err)
-					render.Info("Please run 'dvm admin migrate' to fix migration issues.")
+					var legacy *db.LegacyProjectsError
+					if !errors.As(err, &legacy) {
+						render.Info("Please run 'dvm admin migrate' to fix migration issues.")
+					}
 					return errSilent
 				}
 
 				if migrationsApplied && verbose {
 					slog.Info("database migrations applied successfully")
 				}
+
+				// Start a scheduled backup in the background if one is due
+				maybeRunScheduledBackup(cmd)
 			}
 
 			// Initialize CRD fallback handler for custom resources (v0.29.0)
@@ -109,12 +202,77 @@ func Execute(dataStore *db.DataStore, executor *Executor, migrationsFS fs.FS) {
 		return nil
 	}
 
-	if err := rootCmd.ExecuteContext(buildSignalContext()); err != nil {
-		// errSilent means the command already displayed the error via render.Error()
-		if err != errSilent {
-			render.Errorf("%s", err)
+	err := rootCmd.ExecuteContext(buildSignalContext())
+	cancelTimeout()
+	endCommand(err)
+	flushTelemetry(shutdownTelemetry)
+	flushEvents()
+	if err != nil {
+		// dvm exec propagates the command's exit status without a message
+		var exitErr *operators.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		// errSilent means the command already displayed the error via render.Error(),
+		// and a declined prompt already printed "Aborted"
+		if err != errSilent && !errors.Is(err, interactive.ErrAborted) {
+			renderError(err)
 		}
-		os.Exit(1)
+		os.Exit(interactive.ExitCode(err))
+	}
+}
+
+// applyEcosystemConfig layers in the config file of the active ecosystem,
+// taken from DVM_ECOSYSTEM or the database context.
+func applyEcosystemConfig(dataStore *db.DataStore) {
+	ecosystem := os.Getenv("DVM_ECOSYSTEM")
+	if ecosystem == "" && dataStore != nil && *dataStore != nil {
+		ecosystem, _ = getActiveEcosystemFromContext(*dataStore)
+	}
+	if ecosystem == "" {
+		return
+	}
+	pc, err := paths.Default()
+	if err != nil {
+		return
+	}
+	if err := config.UseEcosystem(pc.Root(), ecosystem); err != nil {
+		slog.Warn("ignoring ecosystem config", "ecosystem", ecosystem, "error", err)
+	}
+}
+
+// applyDefaultOutput sets -o to the configured output format when the
+// command takes an output format and the flag was not given.
+func applyDefaultOutput(cmd *cobra.Command, format string) {
+	f := cmd.Flags().Lookup("output")
+	if format == "" || f == nil || f.Changed {
+		return
+	}
+	if _, ok := f.Value.(*outputFormatValue); !ok {
+		return
+	}
+	if err := f.Value.Set(format); err != nil {
+		slog.Warn("ignoring output setting", "error", err)
+	}
+}
+
+// flushTelemetry sends pending spans and metrics before dvm exits, giving up
+// after a few seconds so an unreachable collector cannot hang the CLI.
+func flushTelemetry(shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdown(ctx); err != nil {
+		slog.Debug("failed to flush telemetry", "error", err)
+	}
+}
+
+// flushEvents waits for pending event deliveries before dvm exits. Retries
+// still pending at the deadline are dropped.
+func flushEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := events.Flush(ctx); err != nil {
+		slog.Warn("gave up delivering events", "error", err)
 	}
 }
 
@@ -144,16 +302,23 @@ func shouldSkipAutoMigration(cmd *cobra.Command) bool {
 		return true
 	}
 
+	// Skip for the migrate subcommands: status and plan report pending
+	// migrations, and down rolls them back, so none may apply them first.
+	if strings.HasPrefix(cmdPath, "dvm admin migrate ") {
+		return true
+	}
+
 	// Skip for commands that don't need database
 	skipCommands := []string{
 		"dvm completion",
 		"dvm version",
 		"dvm help",
-		"dvm generate-docs",     // dev tool: no database needed
-		"dvm generate template", // template generation: no database needed
-		"dvm admin init",        // init handles its own migrations
-		"dvm admin migrate",     // migrate command handles migrations explicitly
-		"dvm sandbox",           // sandboxes are runtime-only, no database needed
+		"dvm generate-docs",          // dev tool: no database needed
+		"dvm generate template",      // template generation: no database needed
+		"dvm admin init",             // init handles its own migrations
+		"dvm admin migrate",          // migrate command handles migrations explicitly
+		"dvm admin migrate-projects", // converts legacy projects before migrating
+		"dvm sandbox",                // sandboxes are runtime-only, no database needed
 		"dvm sandbox create",
 		"dvm sandbox get",
 		"dvm sandbox attach",
@@ -187,16 +352,35 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Set log format (text, json)")
 	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Write logs to file (JSON format)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0,
+		"Abort the command if it runs longer than this (e.g., 30s, 5m; 0 = no limit)")
+	interactive.AddFlags(rootCmd.PersistentFlags())
+	rootCmd.PersistentFlags().Var(&progressMode, "progress",
+		"Progress display for parallel builds and library imports (auto, tty, plain, json)")
+	_ = rootCmd.RegisterFlagCompletionFunc("progress", cobra.FixedCompletions(
+		[]string{"auto", "tty", "plain", "json"}, cobra.ShellCompDirectiveNoFileComp))
 
 	// Output format flag — persistent so all subcommands inherit it
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table",
-		"Output format: table, json, yaml, plain, compact, wide")
+	rootCmd.PersistentFlags().VarP(newOutputFormatValue(&outputFormat, outputTable), "output", "o", outputFormatUsage)
+	_ = rootCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 
 	// Theme flag — persistent so all subcommands inherit it
 	rootCmd.PersistentFlags().StringVar(&themeFlag, "theme", "",
 		"Color theme for output (overrides DVM_THEME and config)")
 }
 
+// outputThemeName returns the theme named by --theme, DVM_THEME, or the
+// config file, or "" when none is set or the setting is "auto".
+func outputThemeName() string {
+	if themeFlag != "" {
+		return themeFlag
+	}
+	if name := config.GetTheme(); name != "auto" {
+		return name
+	}
+	return ""
+}
+
 // initLogging configures the global slog logger based on flags.
 // - Default: WARN level, text format (logs discarded unless level elevated)
 // - With --verbose / -v: DEBUG level to stderr
@@ -227,3 +411,13 @@ func initLogging() {
 
 	utils.InitLogger(effectiveLevel, logFormat)
 }
+
+// githubCacheDir returns the directory for cached GitHub responses, or ""
+// (no caching) when the home directory is unknown.
+func githubCacheDir() string {
+	pc, err := paths.Default()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(pc.Root(), "cache", "github")
+}
@@ -0,0 +1,319 @@
+// Package cmd provides the 'dvm set/get/delete runtime-endpoint' commands for
+// per-ecosystem remote runtimes. When an ecosystem has a runtime endpoint,
+// its workspaces are built and run on that host over SSH instead of on the
+// local container platform.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultRemoteSyncDir is where app files are synced on a remote host,
+// relative to the remote user's home directory.
+const defaultRemoteSyncDir = ".devopsmaestro/workspaces"
+
+// Flags for the runtime-endpoint commands
+var (
+	runtimeEndpointEcosystem string
+	runtimeEndpointHost      string
+	runtimeEndpointType      string
+	runtimeEndpointSyncDir   string
+	runtimeEndpointDryRun    bool
+)
+
+// setRuntimeEndpointCmd stores an ecosystem's runtime endpoint
+var setRuntimeEndpointCmd = &cobra.Command{
+	Use:   "runtime-endpoint",
+	Short: "Run an ecosystem's workspaces on a remote host over SSH",
+	Long: `Set the remote host that builds and runs an ecosystem's workspaces.
+
+The host is an SSH URL. With --type docker (the default) dvm talks to the
+remote Docker daemon with DOCKER_HOST=ssh://...; with --type nerdctl it runs
+nerdctl on the host over ssh. Key-based ssh access to the host is required,
+and rsync must be installed on both ends.
+
+On 'dvm attach', the app's files are synced to the host (under --sync-dir,
+relative to the remote home directory unless absolute) and mounted into the
+workspace, ports published with 'dvm attach --port' are forwarded to
+localhost for the session, and 'dvm detach' syncs the files back.
+
+Examples:
+  dvm set runtime-endpoint --host ssh://dev@buildbox
+  dvm set runtime-endpoint -e healthcare --host ssh://dev@gpu01:2222 --type nerdctl
+  dvm set runtime-endpoint --host ssh://dev@buildbox --sync-dir /srv/dvm`,
+	Args: cobra.NoArgs,
+	RunE: runSetRuntimeEndpoint,
+}
+
+// getRuntimeEndpointCmd shows an ecosystem's runtime endpoint
+var getRuntimeEndpointCmd = &cobra.Command{
+	Use:   "runtime-endpoint",
+	Short: "Get an ecosystem's remote runtime endpoint",
+	Long: `Show the remote host an ecosystem's workspaces run on.
+
+Examples:
+  dvm get runtime-endpoint               # Active ecosystem
+  dvm get runtime-endpoint -e healthcare
+  dvm get runtime-endpoint -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: runGetRuntimeEndpoint,
+}
+
+// deleteRuntimeEndpointCmd removes an ecosystem's runtime endpoint
+var deleteRuntimeEndpointCmd = &cobra.Command{
+	Use:   "runtime-endpoint",
+	Short: "Run an ecosystem's workspaces locally again",
+	Long: `Delete an ecosystem's runtime endpoint. Its workspaces are then built and
+run on the local container platform. Containers and files on the remote host
+are not removed.
+
+Examples:
+  dvm delete runtime-endpoint -e healthcare`,
+	Args: cobra.NoArgs,
+	RunE: runDeleteRuntimeEndpoint,
+}
+
+func init() {
+	setCmd.AddCommand(setRuntimeEndpointCmd)
+	getCmd.AddCommand(getRuntimeEndpointCmd)
+	deleteCmd.AddCommand(deleteRuntimeEndpointCmd)
+
+	for _, c := range []*cobra.Command{setRuntimeEndpointCmd, getRuntimeEndpointCmd, deleteRuntimeEndpointCmd} {
+		c.Flags().StringVarP(&runtimeEndpointEcosystem, "ecosystem", "e", "", "Ecosystem name (defaults to active ecosystem)")
+	}
+	setRuntimeEndpointCmd.Flags().StringVar(&runtimeEndpointHost, "host", "", "Remote host (ssh://[user@]host[:port])")
+	setRuntimeEndpointCmd.Flags().StringVar(&runtimeEndpointType, "type", models.RuntimeEndpointDocker, "Container CLI on the host: docker or nerdctl")
+	setRuntimeEndpointCmd.Flags().StringVar(&runtimeEndpointSyncDir, "sync-dir", "", "Directory for synced app files on the host (default: "+defaultRemoteSyncDir+")")
+	_ = setRuntimeEndpointCmd.MarkFlagRequired("host")
+	AddDryRunFlag(setRuntimeEndpointCmd, &runtimeEndpointDryRun)
+}
+
+// resolveRuntimeEndpointEcosystem returns the ecosystem named by --ecosystem,
+// or the active ecosystem.
+func resolveRuntimeEndpointEcosystem(ds db.DataStore) (*models.Ecosystem, error) {
+	if runtimeEndpointEcosystem == "" {
+		ecosystem, err := getActiveEcosystem(ds)
+		if err != nil {
+			return nil, ErrorWithSuggestion("no ecosystem specified", "Use --ecosystem <name> or 'dvm use ecosystem <name>'")
+		}
+		return ecosystem, nil
+	}
+	ecosystem, err := ds.GetEcosystemByName(runtimeEndpointEcosystem)
+	if err != nil {
+		return nil, ErrorWithSuggestion(fmt.Sprintf("ecosystem %q not found", runtimeEndpointEcosystem),
+			SuggestEcosystemNotFound(runtimeEndpointEcosystem)...)
+	}
+	return ecosystem, nil
+}
+
+func runSetRuntimeEndpoint(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	ecosystem, err := resolveRuntimeEndpointEcosystem(ds)
+	if err != nil {
+		return err
+	}
+	if _, err := operators.NewRemotePlatform(runtimeEndpointHost, runtimeEndpointType); err != nil {
+		return err
+	}
+	endpoint := &models.RuntimeEndpoint{
+		EcosystemID: ecosystem.ID,
+		Type:        runtimeEndpointType,
+		Host:        runtimeEndpointHost,
+		SyncDir:     runtimeEndpointSyncDir,
+	}
+
+	if runtimeEndpointDryRun {
+		render.Info(fmt.Sprintf("Would run workspaces of ecosystem %q with %s on %s", ecosystem.Name, endpoint.Type, endpoint.Host))
+		return nil
+	}
+	if err := ds.SetRuntimeEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	render.Success(fmt.Sprintf("Workspaces of ecosystem %q now run with %s on %s", ecosystem.Name, endpoint.Type, endpoint.Host))
+	render.Info("Rebuild them there with 'dvm build'")
+	return nil
+}
+
+func runGetRuntimeEndpoint(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	ecosystem, err := resolveRuntimeEndpointEcosystem(ds)
+	if err != nil {
+		return err
+	}
+	endpoint, err := ds.GetRuntimeEndpoint(ecosystem.ID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			render.Info(fmt.Sprintf("Ecosystem %q runs workspaces locally (no runtime endpoint)", ecosystem.Name))
+			return nil
+		}
+		return err
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, endpoint, render.Options{})
+	}
+	return outputList(getOutputFormat, render.TableData{
+		Headers: []string{"ECOSYSTEM", "TYPE", "HOST", "SYNC-DIR"},
+		Rows:    [][]string{{ecosystem.Name, endpoint.Type, endpoint.Host, remoteSyncDir(endpoint)}},
+	}, render.Options{Type: render.TypeTable})
+}
+
+func runDeleteRuntimeEndpoint(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	ecosystem, err := resolveRuntimeEndpointEcosystem(ds)
+	if err != nil {
+		return err
+	}
+	if err := ds.DeleteRuntimeEndpoint(ecosystem.ID); err != nil {
+		if db.IsNotFound(err) {
+			render.Info(fmt.Sprintf("Ecosystem %q has no runtime endpoint", ecosystem.Name))
+			return nil
+		}
+		return err
+	}
+	render.Success(fmt.Sprintf("Workspaces of ecosystem %q now run locally", ecosystem.Name))
+	return nil
+}
+
+// remoteSyncDir returns the endpoint's sync directory, or the default.
+func remoteSyncDir(endpoint *models.RuntimeEndpoint) string {
+	if endpoint.SyncDir != "" {
+		return endpoint.SyncDir
+	}
+	return defaultRemoteSyncDir
+}
+
+// workspaceEndpoint returns the runtime endpoint of the workspace's
+// ecosystem, or nil when its workspaces run locally.
+func workspaceEndpoint(ds db.DataStore, workspace *models.Workspace) (*models.RuntimeEndpoint, error) {
+	app, err := ds.GetAppByID(workspace.AppID)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get app: %w", err)
+	}
+	if !app.DomainID.Valid {
+		return nil, nil // no ecosystem to configure an endpoint on
+	}
+	domain, err := ds.GetDomainByID(int(app.DomainID.Int64))
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get domain: %w", err)
+	}
+	if !domain.EcosystemID.Valid {
+		return nil, nil
+	}
+	endpoint, err := ds.GetRuntimeEndpoint(int(domain.EcosystemID.Int64))
+	if err != nil {
+		if db.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get runtime endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// workspacePlatform returns the platform a workspace is built on: its
+// ecosystem's runtime endpoint, or the local platform.
+func workspacePlatform(ds db.DataStore, workspace *models.Workspace) (*operators.Platform, error) {
+	endpoint, err := workspaceEndpoint(ds, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == nil {
+		return detectPlatform()
+	}
+	return operators.NewRemotePlatform(endpoint.Host, endpoint.Type)
+}
+
+// remoteWorkspace is a workspace's app directory synced to a runtime
+// endpoint.
+type remoteWorkspace struct {
+	target    operators.SSHTarget
+	localDir  string
+	remoteDir string
+}
+
+// newRemoteWorkspace returns where the workspace's files at localDir live on
+// the endpoint's host.
+func newRemoteWorkspace(ctx context.Context, endpoint *models.RuntimeEndpoint, workspace *models.Workspace, localDir string) (*remoteWorkspace, error) {
+	target, err := operators.ParseSSHTarget(endpoint.Host)
+	if err != nil {
+		return nil, err
+	}
+	syncDir, err := operators.ResolveRemoteDir(ctx, target, remoteSyncDir(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	return &remoteWorkspace{
+		target:    target,
+		localDir:  localDir,
+		remoteDir: path.Join(syncDir, workspace.Slug),
+	}, nil
+}
+
+// push syncs the local files to the host.
+func (r *remoteWorkspace) push(ctx context.Context) error {
+	render.Progress(fmt.Sprintf("Syncing %s to %s:%s...", r.localDir, r.target.Host, r.remoteDir))
+	return operators.SyncToRemote(ctx, r.target, r.localDir, r.remoteDir)
+}
+
+// pull syncs the files changed on the host back to the local directory.
+func (r *remoteWorkspace) pull(ctx context.Context) error {
+	render.Progress(fmt.Sprintf("Syncing %s:%s back to %s...", r.target.Host, r.remoteDir, r.localDir))
+	return operators.SyncFromRemote(ctx, r.target, r.remoteDir, r.localDir)
+}
+
+// pullRemoteWorkspace syncs a workspace's files back from its ecosystem's
+// runtime endpoint. It does nothing for workspaces that run locally.
+func pullRemoteWorkspace(ctx context.Context, ds db.DataStore, workspace *models.Workspace, appPath string) error {
+	if isKubernetesWorkspace(workspace) {
+		return nil
+	}
+	endpoint, err := workspaceEndpoint(ds, workspace)
+	if err != nil || endpoint == nil {
+		return err
+	}
+	mountPath, err := getMountPath(ds, workspace, appPath)
+	if err != nil {
+		return fmt.Errorf("failed to get mount path: %w", err)
+	}
+	remote, err := newRemoteWorkspace(ctx, endpoint, workspace, mountPath)
+	if err != nil {
+		return err
+	}
+	return remote.pull(ctx)
+}
+
+// joinPorts formats ports as a comma-separated list.
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, port := range ports {
+		parts[i] = strconv.Itoa(port)
+	}
+	return strings.Join(parts, ", ")
+}
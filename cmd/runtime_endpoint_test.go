@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceEndpoint(t *testing.T) {
+	store := db.NewMockDataStore()
+	eco := &models.Ecosystem{Name: "acme"}
+	require.NoError(t, store.CreateEcosystem(eco))
+	domain := &models.Domain{Name: "core", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, store.CreateDomain(domain))
+	app := &models.App{Name: "api", Path: "/src/api", DomainID: sql.NullInt64{Int64: int64(domain.ID), Valid: true}}
+	require.NoError(t, store.CreateApp(app))
+	workspace := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "acme-core-api-dev"}
+
+	endpoint, err := workspaceEndpoint(store, workspace)
+	require.NoError(t, err)
+	assert.Nil(t, endpoint, "ecosystems without an endpoint run locally")
+
+	require.NoError(t, store.SetRuntimeEndpoint(&models.RuntimeEndpoint{
+		EcosystemID: eco.ID, Type: models.RuntimeEndpointNerdctl, Host: "ssh://dev@buildbox",
+	}))
+	endpoint, err = workspaceEndpoint(store, workspace)
+	require.NoError(t, err)
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "ssh://dev@buildbox", endpoint.Host)
+	assert.Equal(t, defaultRemoteSyncDir, remoteSyncDir(endpoint))
+
+	platform, err := workspacePlatform(store, workspace)
+	require.NoError(t, err)
+	assert.True(t, platform.IsRemote())
+	assert.Equal(t, "nerdctl", platform.Tool)
+
+	// Apps outside a domain have no ecosystem to configure
+	orphan := &models.App{Name: "scratch", Path: "/src/scratch"}
+	require.NoError(t, store.CreateApp(orphan))
+	endpoint, err = workspaceEndpoint(store, &models.Workspace{AppID: orphan.ID, Name: "dev"})
+	require.NoError(t, err)
+	assert.Nil(t, endpoint)
+
+	// A failed lookup is an error, not a local workspace
+	store.GetDomainByIDErr = errors.New("database is locked")
+	_, err = workspaceEndpoint(store, workspace)
+	assert.ErrorContains(t, err, "database is locked")
+}
+
+func TestJoinPorts(t *testing.T) {
+	assert.Equal(t, "3000, 5173", joinPorts([]int{3000, 5173}))
+	assert.Equal(t, "", joinPorts(nil))
+}
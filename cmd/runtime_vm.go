@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// getRuntimeCmd shows the container runtime and the health of its VM
+var getRuntimeCmd = &cobra.Command{
+	Use:     "runtime",
+	Aliases: []string{"rt"},
+	Short:   "Show the container runtime and its VM",
+	Long: `Show the container platform dvm uses, whether it is running, and, on
+Colima, the VM's state and size compared with the resources set in
+runtime.colima.cpus, runtime.colima.memory, and runtime.colima.disk.
+
+The Colima profile is runtime.colima.profile, or the detected platform's
+profile.
+
+Examples:
+  dvm get runtime
+  dvm get runtime -o yaml`,
+	RunE: runGetRuntime,
+}
+
+// startRuntimeCmd starts the Colima VM
+var startRuntimeCmd = &cobra.Command{
+	Use:     "runtime",
+	Aliases: []string{"rt"},
+	Short:   "Start the Colima VM",
+	Long: `Start the Colima profile dvm uses (runtime.colima.profile, or the
+detected platform's profile), sized with runtime.colima.cpus,
+runtime.colima.memory, and runtime.colima.disk when they are set.
+
+A running VM keeps its size; stop it with 'colima stop' first to resize it.
+Colima can grow a VM's disk but not shrink it.
+
+With runtime.colima.autoStart: true, 'dvm attach' and 'dvm build' start the
+VM themselves.
+
+Examples:
+  dvm start runtime
+  dvm config set runtime.colima.memory 8g && dvm start runtime`,
+	Args: cobra.NoArgs,
+	RunE: runStartRuntime,
+}
+
+func init() {
+	getCmd.AddCommand(getRuntimeCmd)
+	startCmd.AddCommand(startRuntimeCmd)
+}
+
+// RuntimeOutput is the container runtime shown by dvm get runtime.
+type RuntimeOutput struct {
+	Platform   string              `json:"platform" yaml:"platform"`
+	Type       string              `json:"type" yaml:"type"` // docker or containerd
+	Socket     string              `json:"socket,omitempty" yaml:"socket,omitempty"`
+	Status     string              `json:"status" yaml:"status"` // running, stopped, or not found
+	VM         *operators.VMStatus `json:"vm,omitempty" yaml:"vm,omitempty"`
+	Shortfalls []string            `json:"shortfalls,omitempty" yaml:"shortfalls,omitempty"`
+}
+
+func runGetRuntime(cmd *cobra.Command, args []string) error {
+	out := RuntimeOutput{Platform: "unknown", Type: "unknown", Status: "not found"}
+
+	detector, err := operators.NewPlatformDetector()
+	if err != nil {
+		return fmt.Errorf("failed to create platform detector: %w", err)
+	}
+	platform, detectErr := detector.Detect()
+	if detectErr == nil {
+		out.Platform, out.Socket = platform.Name, platform.SocketPath
+		out.Type = "docker"
+		if platform.IsContainerd() {
+			out.Type = "containerd"
+		}
+		out.Status = "stopped"
+		if platform.IsReachable() {
+			out.Status = "running"
+		}
+	}
+
+	if profile := colimaProfile(); profile != "" {
+		req, err := colimaRequirements()
+		if err != nil {
+			return err
+		}
+		vm, err := operators.NewColimaVM(profile)
+		if err != nil {
+			return err
+		}
+		if out.VM, err = vm.Status(commandContext(cmd)); err != nil {
+			render.Warning(fmt.Sprintf("Failed to get Colima VM status: %v", err))
+		} else {
+			out.Shortfalls = out.VM.Shortfalls(req)
+		}
+	}
+
+	if isStructuredOutput(getOutputFormat) {
+		return render.OutputWith(getOutputFormat, out, render.Options{})
+	}
+	renderRuntime(out, platform)
+	return nil
+}
+
+func renderRuntime(out RuntimeOutput, platform *operators.Platform) {
+	render.Info("Runtime")
+	render.Info(fmt.Sprintf("  Platform: %s", out.Platform))
+	render.Info(fmt.Sprintf("  Type:     %s", out.Type))
+	if out.Socket != "" {
+		render.Info(fmt.Sprintf("  Socket:   %s", out.Socket))
+	}
+	if out.Status == "running" {
+		render.Success(fmt.Sprintf("  Status:   %s", out.Status))
+	} else {
+		render.Warning(fmt.Sprintf("  Status:   %s", out.Status))
+	}
+
+	if vm := out.VM; vm != nil {
+		render.Blank()
+		render.Info("Colima VM")
+		render.Info(fmt.Sprintf("  Profile:  %s", vm.Profile))
+		switch {
+		case !vm.Exists:
+			render.Warning("  Status:   not created")
+		case !vm.Running:
+			render.Warning("  Status:   stopped")
+		default:
+			render.Success("  Status:   running")
+			render.Info(fmt.Sprintf("  CPUs:     %d", vm.CPUs))
+			render.Info(fmt.Sprintf("  Memory:   %s", operators.FormatGiB(vm.MemoryBytes)))
+			render.Info(fmt.Sprintf("  Disk:     %s", operators.FormatGiB(vm.DiskBytes)))
+			if vm.Arch != "" {
+				render.Info(fmt.Sprintf("  Arch:     %s", vm.Arch))
+			}
+		}
+		if len(out.Shortfalls) > 0 {
+			render.Warning("  Smaller than required: " + strings.Join(out.Shortfalls, ", "))
+		}
+		if !vm.Running {
+			render.Info("  Start it with: dvm start runtime")
+		}
+	} else if platform != nil && out.Status == "stopped" {
+		render.Info("  " + platform.GetStartHint())
+	}
+}
+
+func runStartRuntime(cmd *cobra.Command, args []string) error {
+	profile := colimaProfile()
+	if profile == "" {
+		return ErrorWithSuggestion(
+			"no Colima profile to start",
+			"Set one with: dvm config set runtime.colima.profile default",
+		)
+	}
+	req, err := colimaRequirements()
+	if err != nil {
+		return err
+	}
+	vm, err := operators.NewColimaVM(profile)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext(cmd)
+	status, err := vm.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Running {
+		render.Info(fmt.Sprintf("Colima VM (profile: %s) is already running", profile))
+	} else {
+		render.Progress(fmt.Sprintf("Starting Colima VM (profile: %s)...", profile))
+		if err := vm.Start(ctx, req); err != nil {
+			return fmt.Errorf("failed to start Colima VM: %w", err)
+		}
+		if status, err = vm.Status(ctx); err != nil {
+			return err
+		}
+		render.Success(fmt.Sprintf("Colima VM (profile: %s) is running: %d CPUs, %s memory, %s disk",
+			profile, status.CPUs, operators.FormatGiB(status.MemoryBytes), operators.FormatGiB(status.DiskBytes)))
+	}
+	if shortfalls := status.Shortfalls(req); len(shortfalls) > 0 {
+		render.Warning(fmt.Sprintf("The VM is smaller than required: %s", strings.Join(shortfalls, ", ")))
+		render.Info(fmt.Sprintf("Resize it with: colima stop --profile %s && dvm start runtime", profile))
+	}
+	return nil
+}
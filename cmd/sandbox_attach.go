@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"devopsmaestro/operators"
 	"fmt"
 	"log/slog"
@@ -13,7 +12,7 @@ import (
 
 // runSandboxAttach re-attaches to a running sandbox container.
 func runSandboxAttach(cmd *cobra.Command, name string) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
@@ -23,7 +23,7 @@ import (
 // 5. Attach TTY
 // 6. On exit → stop + remove container
 func runSandboxCreate(cmd *cobra.Command, lang string) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	// 1. Resolve preset
 	preset, ok := models.GetPreset(lang)
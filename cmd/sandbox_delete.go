@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"devopsmaestro/operators"
 	"log/slog"
 
@@ -11,7 +10,7 @@ import (
 
 // runSandboxDelete removes a specific sandbox container.
 func runSandboxDelete(cmd *cobra.Command, name string) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
@@ -50,7 +49,7 @@ func runSandboxDelete(cmd *cobra.Command, name string) error {
 
 // runSandboxDeleteAll removes all sandbox containers.
 func runSandboxDeleteAll(cmd *cobra.Command) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
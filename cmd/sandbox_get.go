@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"devopsmaestro/operators"
 
 	"github.com/rmkohlman/MaestroSDK/render"
@@ -15,7 +14,7 @@ var sandboxLabels = map[string]string{
 
 // runSandboxGet lists all active sandbox containers by querying the runtime.
 func runSandboxGet(cmd *cobra.Command) error {
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
@@ -39,7 +38,7 @@ func runSandboxGet(cmd *cobra.Command) error {
 	}
 
 	// Build table for structured/json/yaml output
-	if outputFormat == "json" || outputFormat == "yaml" {
+	if isStructuredOutput(outputFormat) {
 		return renderSandboxStructured(containers)
 	}
 
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"crypto/rand"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/interactive"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -11,7 +12,6 @@ import (
 	"strings"
 
 	"github.com/rmkohlman/MaestroSDK/render"
-	"golang.org/x/term"
 )
 
 // generateSandboxName creates a unique sandbox container name.
@@ -44,9 +44,9 @@ func isValidVersion(preset models.SandboxPreset, version string) bool {
 	return false
 }
 
-// isTTY returns true if stdin is a terminal.
+// isTTY returns true if stdin is a terminal and prompting is enabled.
 func isTTY() bool {
-	return term.IsTerminal(int(os.Stdin.Fd()))
+	return interactive.Enabled()
 }
 
 // pickVersionInteractive shows a numbered list of versions and lets the user pick.
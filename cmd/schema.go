@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [kind]",
+	Short: "Print the JSON Schema of a resource kind",
+	Long: `Print the JSON Schema of a dvm resource kind, generated from the types
+'dvm apply' parses. Editors use it for completion and validation of
+manifests; with yaml-language-server, add a modeline to the YAML file:
+
+  # yaml-language-server: $schema=./schemas/workspace.json
+
+Without a kind, the available kinds and their schema files are listed.
+--output-dir writes every schema to a directory, including
+devopsmaestro.json, which accepts a document of any kind.
+
+Examples:
+  dvm schema
+  dvm schema workspace
+  dvm schema NvimPlugin > nvim-plugin.json
+  dvm schema --output-dir ./schemas`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeSchemaKinds,
+	RunE:              runSchema,
+}
+
+func init() {
+	schemaCmd.Flags().String("output-dir", "", "Write the schemas of all kinds to this directory")
+
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	defer resetLocalFlags(cmd)
+
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	schemas := handlers.SchemaFS()
+
+	if outputDir != "" {
+		if len(args) > 0 {
+			return fmt.Errorf("--output-dir writes every kind; omit the kind argument")
+		}
+		return writeSchemas(cmd, schemas, outputDir)
+	}
+
+	if len(args) == 0 {
+		for _, name := range schemaFiles(schemas) {
+			cmd.Println(strings.TrimSuffix(name, ".json"))
+		}
+		return nil
+	}
+
+	name, ok := schemaFileFor(schemas, args[0])
+	if !ok {
+		return fmt.Errorf("unknown resource kind %q (run 'dvm schema' to list kinds)", args[0])
+	}
+	data, err := fs.ReadFile(schemas, name)
+	if err != nil {
+		return fmt.Errorf("failed to read schema %s: %w", name, err)
+	}
+	cmd.Print(string(data))
+	return nil
+}
+
+func writeSchemas(cmd *cobra.Command, schemas fs.FS, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	names, err := fs.Glob(schemas, "*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(schemas, name)
+		if err != nil {
+			return fmt.Errorf("failed to read schema %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return fmt.Errorf("failed to write schema %s: %w", name, err)
+		}
+	}
+	cmd.Printf("Wrote %d schemas to %s\n", len(names), dir)
+	return nil
+}
+
+// schemaFiles returns the per-kind schema files, without the all-kinds one.
+func schemaFiles(schemas fs.FS) []string {
+	names, _ := fs.Glob(schemas, "*.json")
+	var files []string
+	for _, name := range names {
+		if name != handlers.AllKindsSchemaFile {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// schemaFileFor resolves a kind given as PascalCase (NvimPlugin), kebab-case
+// (nvim-plugin), or without separators (gitrepo) to its schema file.
+func schemaFileFor(schemas fs.FS, kind string) (string, bool) {
+	want := strings.ToLower(strings.ReplaceAll(kind, "-", ""))
+	for _, name := range schemaFiles(schemas) {
+		base := strings.TrimSuffix(name, ".json")
+		if strings.ReplaceAll(base, "-", "") == want {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// completeSchemaKinds provides shell completion for schema kind names.
+func completeSchemaKinds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var kinds []string
+	for _, name := range schemaFiles(handlers.SchemaFS()) {
+		kinds = append(kinds, strings.TrimSuffix(name, ".json"))
+	}
+	return kinds, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runSchemaCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(new(bytes.Buffer))
+	rootCmd.SetArgs(append([]string{"schema"}, args...))
+	defer func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+	err := rootCmd.Execute()
+	return buf.String(), err
+}
+
+func TestSchemaCmd_PrintsKindSchema(t *testing.T) {
+	for _, kind := range []string{"workspace", "Workspace", "nvim-plugin", "NvimPlugin", "gitrepo"} {
+		t.Run(kind, func(t *testing.T) {
+			out, err := runSchemaCmd(t, kind)
+			require.NoError(t, err)
+
+			var schema map[string]any
+			require.NoError(t, json.Unmarshal([]byte(out), &schema), "schema must be valid JSON")
+			assert.Equal(t, "object", schema["type"])
+			assert.Contains(t, schema, "properties")
+		})
+	}
+}
+
+func TestSchemaCmd_ListsKinds(t *testing.T) {
+	out, err := runSchemaCmd(t)
+	require.NoError(t, err)
+	for _, kind := range []string{"ecosystem", "domain", "app", "workspace", "nvim-plugin", "nvim-theme", "terminal-package"} {
+		assert.Contains(t, out, kind+"\n")
+	}
+	assert.NotContains(t, out, "devopsmaestro")
+}
+
+func TestSchemaCmd_UnknownKind(t *testing.T) {
+	_, err := runSchemaCmd(t, "widget")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown resource kind "widget"`)
+}
+
+func TestSchemaCmd_OutputDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "schemas")
+	_, err := runSchemaCmd(t, "--output-dir", dir)
+	require.NoError(t, err)
+
+	for _, name := range []string{"workspace.json", "ecosystem.json", "devopsmaestro.json"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err, "%s should be written", name)
+	}
+}
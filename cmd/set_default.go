@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/pkg/manifestvars"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+)
+
+// setDefaultCmd sets a key of the defaults table
+var setDefaultCmd = &cobra.Command{
+	Use:   "default <key> <value>",
+	Short: "Set a global default",
+	Long: `Set a global default value.
+
+Known keys are validated against their type and, where they name a resource,
+against the database. Unknown keys are stored with a warning. Keys owned by a
+dedicated command (build-args, ca-certs, plugins) must be set through it.
+
+Known keys:
+` + defaultKeysHelp() + `
+Examples:
+  dvm set default theme tokyonight-night
+  dvm set default registry-oci zot-local
+  dvm set default registry-idle-timeout 45m
+  dvm set default var.language go`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runSetDefault,
+	ValidArgsFunction: completeDefaultKeys,
+}
+
+// unsetCmd is the root 'unset' command
+var unsetCmd = &cobra.Command{
+	Use:   "unset",
+	Short: "Remove configured values",
+	Long: `Remove configured values so the built-in default applies again.
+
+Examples:
+  dvm unset default theme`,
+}
+
+// unsetDefaultCmd removes a key from the defaults table
+var unsetDefaultCmd = &cobra.Command{
+	Use:   "default <key>",
+	Short: "Remove a global default",
+	Long: `Remove a global default so the built-in default applies again.
+
+Examples:
+  dvm unset default theme
+  dvm unset default registry-idle-timeout
+  dvm unset default build-args    # clears all global build args`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runUnsetDefault,
+	ValidArgsFunction: completeDefaultKeys,
+}
+
+func init() {
+	setCmd.AddCommand(setDefaultCmd)
+	rootCmd.AddCommand(unsetCmd)
+	unsetCmd.AddCommand(unsetDefaultCmd)
+}
+
+func runSetDefault(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("database not initialized: %w", err)
+	}
+
+	spec, known := lookupDefaultKey(key)
+	switch {
+	case !known:
+		render.Warningf("Unknown default key %q; dvm does not read it", key)
+	case spec.Internal:
+		return fmt.Errorf("default %q is managed by dvm and cannot be set", key)
+	case spec.ManagedBy != "":
+		return ErrorWithSuggestion(fmt.Sprintf("default %q cannot be set directly", key),
+			"Edit it with: "+spec.ManagedBy)
+	default:
+		if err := spec.validateValue(value); err != nil {
+			return err
+		}
+		if spec.Check != nil {
+			if err := spec.Check(ds, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ds.SetDefault(key, value); err != nil {
+		return err
+	}
+	return render.Success(fmt.Sprintf("Set default %s to '%s'", key, value))
+}
+
+func runUnsetDefault(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("database not initialized: %w", err)
+	}
+
+	spec, known := lookupDefaultKey(key)
+	if !known {
+		render.Warningf("Unknown default key %q", key)
+	} else if spec.Internal {
+		return fmt.Errorf("default %q is managed by dvm and cannot be unset", key)
+	}
+
+	if err := ds.DeleteDefault(key); err != nil {
+		return err
+	}
+	return render.Success(fmt.Sprintf("Unset default %s", key))
+}
+
+// defaultKeysHelp lists the editable keys for command help.
+func defaultKeysHelp() string {
+	var b strings.Builder
+	for _, key := range editableDefaultKeys() {
+		spec := defaultKeys[key]
+		fmt.Fprintf(&b, "  %-24s %-9s %s\n", key, spec.Type, spec.Description)
+	}
+	fmt.Fprintf(&b, "  %-24s %-9s %s\n", manifestvars.DefaultsPrefix+"<name>", defaultTypeString, "Variable ${<name>} in applied manifests")
+	return b.String()
+}
+
+// completeDefaultKeys completes the key argument with the known keys.
+func completeDefaultKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, key := range editableDefaultKeys() {
+		keys = append(keys, key+"\t"+defaultKeys[key].Description)
+	}
+	if cmd.Name() == "default" && cmd.Parent() == unsetCmd {
+		for key, spec := range defaultKeys {
+			if spec.ManagedBy != "" {
+				keys = append(keys, key+"\t"+spec.Description)
+			}
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultsTestCmd returns a command whose context carries ds.
+func defaultsTestCmd(ds db.DataStore) *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, ds))
+	return cmd
+}
+
+func TestDefaultKeySpec_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    defaultKeySpec
+		value   string
+		wantErr string
+	}{
+		{"string accepts anything", defaultKeySpec{Type: defaultTypeString}, "x y", ""},
+		{"valid duration", defaultKeySpec{Type: defaultTypeDuration}, "45m", ""},
+		{"invalid duration", defaultKeySpec{Type: defaultTypeDuration}, "45x", "expected a duration"},
+		{"non-positive duration", defaultKeySpec{Type: defaultTypeDuration}, "0s", "must be positive"},
+		{"valid bool", defaultKeySpec{Type: defaultTypeBool}, "true", ""},
+		{"invalid bool", defaultKeySpec{Type: defaultTypeBool}, "yes please", "expected a bool"},
+		{"invalid int", defaultKeySpec{Type: defaultTypeInt}, "1.5", "expected a int"},
+		{"allowed value", defaultKeySpec{Type: defaultTypeString, Allowed: []string{"a", "b"}}, "b", ""},
+		{"disallowed value", defaultKeySpec{Type: defaultTypeString, Allowed: []string{"a", "b"}}, "c", "must be one of a, b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.validateValue(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDefaultKeys_CoverGlobalDefaultsResource(t *testing.T) {
+	// Every key the GlobalDefaults resource clears must be known
+	for _, key := range []string{
+		"theme", "build-args", "ca-certs", "nvim-package", "terminal-package", "plugins",
+		"registry-oci", "registry-pypi", "registry-npm", "registry-go", "registry-http",
+		"registry-idle-timeout",
+	} {
+		_, ok := lookupDefaultKey(key)
+		assert.True(t, ok, "key %q missing from schema", key)
+	}
+	assert.NotContains(t, editableDefaultKeys(), "build-args")
+	assert.NotContains(t, editableDefaultKeys(), "context.previous")
+
+	// Manifest variables are known by prefix
+	spec, ok := lookupDefaultKey("var.language")
+	assert.True(t, ok)
+	assert.Equal(t, defaultTypeString, spec.Type)
+	_, ok = lookupDefaultKey("var.")
+	assert.False(t, ok)
+}
+
+func TestRunSetDefault(t *testing.T) {
+	ds := db.NewMockDataStore()
+	ds.Registries["zot-local"] = &models.Registry{Name: "zot-local", Type: "zot"}
+	ds.Registries["devpi-local"] = &models.Registry{Name: "devpi-local", Type: "devpi"}
+	cmd := defaultsTestCmd(ds)
+
+	require.NoError(t, runSetDefault(cmd, []string{"registry-oci", "zot-local"}))
+	assert.Equal(t, "zot-local", ds.Defaults["registry-oci"])
+
+	err := runSetDefault(cmd, []string{"registry-oci", "devpi-local"})
+	assert.ErrorContains(t, err, "expects type 'zot'")
+	err = runSetDefault(cmd, []string{"registry-oci", "missing"})
+	assert.ErrorContains(t, err, "not found")
+
+	err = runSetDefault(cmd, []string{"registry-idle-timeout", "soon"})
+	assert.ErrorContains(t, err, "expected a duration")
+	_, stored := ds.Defaults["registry-idle-timeout"]
+	assert.False(t, stored, "invalid values must not be stored")
+
+	err = runSetDefault(cmd, []string{"build-args", "{}"})
+	assert.ErrorContains(t, err, "dvm set build-arg --global")
+	err = runSetDefault(cmd, []string{"context.previous", "{}"})
+	assert.ErrorContains(t, err, "managed by dvm")
+
+	// Unknown keys are stored with a warning
+	require.NoError(t, runSetDefault(cmd, []string{"my-key", "v"}))
+	assert.Equal(t, "v", ds.Defaults["my-key"])
+	assert.Equal(t, []string{"my-key"}, unknownDefaultKeys(ds))
+}
+
+func TestRunUnsetDefault(t *testing.T) {
+	ds := db.NewMockDataStore()
+	require.NoError(t, ds.SetDefault("registry-idle-timeout", "45m"))
+	require.NoError(t, ds.SetDefault("build-args", `{"A":"1"}`))
+	require.NoError(t, ds.SetDefault("context.previous", "{}"))
+	cmd := defaultsTestCmd(ds)
+
+	require.NoError(t, runUnsetDefault(cmd, []string{"registry-idle-timeout"}))
+	require.NoError(t, runUnsetDefault(cmd, []string{"build-args"}))
+	require.NoError(t, runUnsetDefault(cmd, []string{"never-set"}))
+	assert.ErrorContains(t, runUnsetDefault(cmd, []string{"context.previous"}), "managed by dvm")
+
+	assert.Equal(t, map[string]string{"context.previous": "{}"}, ds.Defaults)
+}
@@ -90,7 +90,7 @@ func resolveCurrentWorkspaceForPackage(ctx resource.Context) (string, resolver.P
 
 	dbCtx, err := ds.GetContext()
 	if err != nil || dbCtx == nil || dbCtx.ActiveWorkspaceID == nil {
-		return "", 0, 0, fmt.Errorf("no active workspace context (use 'dvm use workspace <name>')")
+		return "", 0, 0, db.NewErrNoActiveContext("workspace")
 	}
 
 	ws, err := ds.GetWorkspaceByID(*dbCtx.ActiveWorkspaceID)
@@ -101,8 +101,108 @@ func resolveCurrentWorkspaceForPackage(ctx resource.Context) (string, resolver.P
 	return ws.Name, resolver.PackageLevelWorkspace, ws.ID, nil
 }
 
+// showPackageResolution displays the nvim and terminal package resolution
+// paths for a given hierarchy level and object ID.
+func showPackageResolution(ds db.DataStore, level resolver.PackageHierarchyLevel, objectID int) error {
+	r := resolver.NewHierarchyPackageResolver(resolver.NewDataStorePackageAdapter(ds))
+	bgCtx := context.Background()
+
+	for _, pkgType := range []string{"nvim", "terminal"} {
+		var res *resolver.PackageResolution
+		var err error
+		if pkgType == "nvim" {
+			res, err = r.ResolveNvimPackage(bgCtx, level, objectID)
+		} else {
+			res, err = r.ResolveTerminalPackage(bgCtx, level, objectID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s package: %w", pkgType, err)
+		}
+
+		render.Blank()
+		render.Info(fmt.Sprintf("%s Package Resolution:", strings.Title(pkgType)))
+		switch {
+		case res.PackageName == "":
+			render.Plain("  Effective package: (none)")
+		case res.Source == resolver.PackageLevelGlobal:
+			render.Plainf("  Effective package: %s", res.PackageName)
+			render.Plain("  Source: global default")
+		default:
+			render.Plainf("  Effective package: %s", res.PackageName)
+			render.Plainf("  Source: %s '%s'", res.Source, res.SourceName)
+		}
+
+		render.Plain("  Resolution path:")
+		for _, step := range res.Path {
+			status := "○"
+			if step.Found {
+				status = "●"
+			}
+			line := fmt.Sprintf("    %s %s '%s'", status, step.Level.String(), step.Name)
+			if step.PackageName != "" {
+				line += fmt.Sprintf(" → %s", step.PackageName)
+			}
+			if step.Error != "" {
+				line += fmt.Sprintf(" (error: %s)", step.Error)
+			}
+			render.Plain(line)
+		}
+	}
+
+	render.Blank()
+	render.Info("Legend: ● package set, ○ no package (inherits from parent)")
+	return nil
+}
+
+// PackageResolutionOutput is the json/yaml form of a resolved package.
+type PackageResolutionOutput struct {
+	Workspace   string              `json:"workspace" yaml:"workspace"`
+	PackageType string              `json:"packageType" yaml:"packageType"`
+	Package     string              `json:"package" yaml:"package"` // empty when none is set
+	Source      string              `json:"source" yaml:"source"`
+	SourceName  string              `json:"sourceName,omitempty" yaml:"sourceName,omitempty"`
+	Path        []PackageStepOutput `json:"path,omitempty" yaml:"path,omitempty"` // with --show-cascade
+}
+
+// PackageStepOutput is one level of a PackageResolutionOutput path.
+type PackageStepOutput struct {
+	Level   string `json:"level" yaml:"level"`
+	Name    string `json:"name" yaml:"name"`
+	Package string `json:"package,omitempty" yaml:"package,omitempty"`
+	Found   bool   `json:"found" yaml:"found"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
 // renderPackageResolution renders a package resolution result for get commands.
 func renderPackageResolution(res *resolver.PackageResolution, wsName string, showCascade bool, outputFmt string) error {
+	switch {
+	case isStructuredOutput(outputFmt):
+		out := PackageResolutionOutput{
+			Workspace:   wsName,
+			PackageType: res.PackageType,
+			Package:     res.PackageName,
+			Source:      res.Source.String(),
+			SourceName:  res.SourceName,
+		}
+		if showCascade {
+			for _, step := range res.Path {
+				out.Path = append(out.Path, PackageStepOutput{
+					Level:   step.Level.String(),
+					Name:    step.Name,
+					Package: step.PackageName,
+					Found:   step.Found,
+					Error:   step.Error,
+				})
+			}
+		}
+		return render.OutputWith(outputFmt, out, render.Options{})
+	case outputFmt == outputName:
+		if res.PackageName == "" {
+			return nil
+		}
+		return render.OutputWith(outputFmt, render.NewOrderedKeyValueData(render.KeyValue{Key: "Name", Value: res.PackageName}), render.Options{})
+	}
+
 	pkgName := res.PackageName
 	if pkgName == "" {
 		pkgName = "(none)"
@@ -189,7 +189,7 @@ func runSetTheme(cmd *cobra.Command, args []string) error {
 	// For JSON/YAML: serialize the ThemeSetResult struct directly to preserve
 	// proper field names and nested structures (cascadeInfo).
 	// For human-readable formats: convert to KeyValueData for table/plain display.
-	if setThemeOutput == "json" || setThemeOutput == "yaml" {
+	if isStructuredOutput(setThemeOutput) {
 		return render.OutputWith(setThemeOutput, result, render.Options{})
 	}
 
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/resource/handlers"
+	"devopsmaestro/pkg/terminalbridge"
+	"devopsmaestro/pkg/terminalbridge/shellgen"
+	"devopsmaestro/pkg/terminalbridge/shellops"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	terminalpkg "github.com/rmkohlman/MaestroTerminal/terminalops/package"
+	terminalpkglib "github.com/rmkohlman/MaestroTerminal/terminalops/package/library"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+	pluginlib "github.com/rmkohlman/MaestroTerminal/terminalops/plugin/library"
+	"github.com/spf13/cobra"
+)
+
+// shellFlags holds the hierarchy flags for the shell command
+var shellFlags HierarchyFlags
+
+// shellCmd opens an interactive shell in a workspace, and groups host shell
+// configuration commands.
+// Usage: dvm shell [workspace]
+//
+//	dvm shell generate --shell zsh --package <terminal-package>
+var shellCmd = &cobra.Command{
+	Use:   "shell [workspace]",
+	Short: "Open a shell in a workspace, or generate host shell configuration",
+	Long: `Open an interactive shell in a running workspace container.
+
+Unlike 'dvm attach', the workspace is never built or started: the shell
+opens in the container that is already running, so it is quick to open
+extra terminals. The workspace is the active one unless a name or hierarchy
+flags are given. A bare name is looked up in the active app first, then
+across all apps.
+
+The 'generate' subcommand writes host shell configuration from terminal
+plugins and packages.
+
+Examples:
+  dvm shell                            # Active workspace
+  dvm shell staging                    # Workspace by name
+  dvm shell -a portal -w dev           # Explicit hierarchy
+  dvm shell generate --shell zsh --package dev-essentials
+  dvm shell generate --shell fish --out ~/.config/fish/conf.d`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+		return runShell(cmd, name)
+	},
+}
+
+func runShell(cmd *cobra.Command, name string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+
+	wh, err := resolveSessionWorkspace(ds, shellFlags, name)
+	if err != nil {
+		renderError(err)
+		return errSilent
+	}
+	render.Info(fmt.Sprintf("Workspace: %s", wh.FullPath()))
+
+	if err := runWorkspaceSession(cmd.Context(), ds, wh, nil, ""); err != nil {
+		renderError(err)
+		return errSilent
+	}
+	return nil
+}
+
+// shellGenerateCmd renders a shell bootstrap script from terminal plugins.
+var shellGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a shell init script that installs and loads terminal plugins",
+	Long: `Generate a shell init script from terminal plugins, so your rc file only
+needs a single source line.
+
+The script installs the plugin frameworks it needs (zinit, oh-my-zsh),
+loads plugins in dependency order, exports plugin environment variables,
+and initializes completions. Plugins come from --package (a stored terminal
+package, or one from the library, including plugins it inherits through
+extends), otherwise all enabled terminal plugins.
+Plugin names are looked up in the database first, then the plugin library.
+
+oh-my-zsh and zinit plugins are zsh-only and are skipped for bash and fish.
+
+Without --out, the script is written to stdout. With --out (a file or
+directory), it is written there and the line to add to your rc file is
+printed.
+
+Examples:
+  dvm shell generate --shell zsh --package dev-essentials
+  dvm shell generate --shell zsh --package dev-essentials --out ~/.config/dvm/shell
+  dvm shell generate --shell bash > ~/.bashrc.dvm`,
+	Args: cobra.NoArgs,
+	RunE: runShellGenerate,
+}
+
+func init() {
+	shellGenerateCmd.Flags().String("shell", "", "Target shell (zsh, bash, fish; default: detected from $SHELL)")
+	shellGenerateCmd.Flags().String("package", "", "Terminal package to take plugins from (default: all enabled plugins)")
+	shellGenerateCmd.Flags().String("plugin-dir", "", "Directory manually managed plugins are cloned into")
+	shellGenerateCmd.Flags().String("out", "", "Output file or directory (default: stdout)")
+	shellGenerateCmd.Flags().Bool("force", false, "Overwrite an existing script")
+	_ = shellGenerateCmd.RegisterFlagCompletionFunc("shell", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"zsh", "bash", "fish"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	AddHierarchyFlags(shellCmd, &shellFlags)
+	shellCmd.AddCommand(shellGenerateCmd)
+	rootCmd.AddCommand(shellCmd)
+}
+
+func runShellGenerate(cmd *cobra.Command, args []string) error {
+	shell, _ := cmd.Flags().GetString("shell")
+	packageName, _ := cmd.Flags().GetString("package")
+	pluginDir, _ := cmd.Flags().GetString("plugin-dir")
+	out, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if shell == "" {
+		shell = shellgen.DetectShell()
+	}
+	gen, err := shellops.NewGenerator(shell)
+	if err != nil {
+		return err
+	}
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	var plugins []*plugin.Plugin
+	if packageName != "" {
+		resolved, err := resolveTerminalPackage(ds, packageName)
+		if err != nil {
+			return err
+		}
+		if plugins, err = lookupTerminalPlugins(ds, resolved.Package.Plugins); err != nil {
+			return fmt.Errorf("terminal package '%s': %w", packageName, err)
+		}
+	} else {
+		if plugins, err = terminalbridge.NewDBPluginStore(ds).List(); err != nil {
+			return err
+		}
+	}
+
+	script, err := gen.Generate(plugins, shellops.Options{PackageName: packageName, PluginDir: pluginDir})
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		fmt.Fprint(cmd.OutOrStdout(), script)
+		return nil
+	}
+
+	if strings.HasPrefix(out, "~") {
+		home, _ := os.UserHomeDir()
+		out = filepath.Join(home, out[1:])
+	}
+	if info, err := os.Stat(out); (err == nil && info.IsDir()) || strings.HasSuffix(out, string(os.PathSeparator)) {
+		out = filepath.Join(out, shellops.ScriptFileName(shell))
+	}
+	if _, err := os.Stat(out); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", out)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(out, []byte(script), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+
+	render.Successf("Generated %s bootstrap at %s", shell, out)
+	render.Info(fmt.Sprintf("Add to your %s config:", shell))
+	render.Plain("  " + shellops.SourceLine(shell, out))
+	return nil
+}
+
+// loadTerminalPackage returns a terminal package from the database, falling
+// back to the embedded package library.
+func loadTerminalPackage(ds db.DataStore, name string) (*terminalpkg.Package, error) {
+	res, err := handlers.NewTerminalPackageHandler().Get(resource.Context{DataStore: ds}, name)
+	if err == nil {
+		if r, ok := res.(*handlers.TerminalPackageResource); ok {
+			return r.Package(), nil
+		}
+	} else if !db.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get terminal package '%s': %w", name, err)
+	}
+
+	if lib, libErr := terminalpkglib.NewLibrary(); libErr == nil {
+		if pkg, ok := lib.Get(name); ok {
+			return pkg, nil
+		}
+	}
+	return nil, fmt.Errorf("terminal package '%s' not found", name)
+}
+
+// resolveTerminalPackage loads a terminal package and flattens its extends
+// chain. Each package in the chain is looked up like loadTerminalPackage.
+func resolveTerminalPackage(ds db.DataStore, name string) (*terminalbridge.ResolvedPackage, error) {
+	return terminalbridge.ResolvePackage(name, func(n string) (*terminalpkg.Package, error) {
+		return loadTerminalPackage(ds, n)
+	})
+}
+
+// lookupTerminalPlugins resolves plugin names from the database, falling
+// back to the embedded plugin library. Unknown names are an error.
+func lookupTerminalPlugins(ds db.DataStore, names []string) ([]*plugin.Plugin, error) {
+	store := terminalbridge.NewDBPluginStore(ds)
+	lib, _ := pluginlib.NewPluginLibrary()
+
+	plugins := make([]*plugin.Plugin, 0, len(names))
+	var missing []string
+	for _, name := range names {
+		if p, err := store.Get(name); err == nil {
+			plugins = append(plugins, p)
+			continue
+		}
+		if lib != nil {
+			if p, err := lib.Get(name); err == nil {
+				plugins = append(plugins, p)
+				continue
+			}
+		}
+		missing = append(missing, name)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unknown terminal plugin(s): %s", strings.Join(missing, ", "))
+	}
+	return plugins, nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"database/sql"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTerminalPackage(t *testing.T) {
+	ds := db.NewMockDataStore()
+	stored := &models.TerminalPackageDB{Name: "team", Extends: sql.NullString{String: "core", Valid: true}}
+	require.NoError(t, stored.SetPlugins([]string{"zsh-autosuggestions"}))
+	require.NoError(t, ds.CreateTerminalPackage(stored))
+
+	pkg, err := loadTerminalPackage(ds, "team")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zsh-autosuggestions"}, pkg.Plugins)
+	assert.Equal(t, "core", pkg.Extends)
+
+	pkg, err = loadTerminalPackage(ds, "core")
+	require.NoError(t, err, "library packages should be found when not stored")
+	assert.NotEmpty(t, pkg.Plugins)
+
+	_, err = loadTerminalPackage(ds, "no-such-package")
+	assert.Error(t, err)
+}
+
+func TestLookupTerminalPlugins(t *testing.T) {
+	ds := db.NewMockDataStore()
+	require.NoError(t, ds.CreateTerminalPlugin(&models.TerminalPluginDB{
+		Name: "team-plugin", Repo: "acme/team-plugin", Manager: "zinit", Enabled: true,
+		Dependencies: "[]", EnvVars: "{}", Labels: "{}",
+	}))
+
+	plugins, err := lookupTerminalPlugins(ds, []string{"team-plugin", "zsh-autosuggestions"})
+	require.NoError(t, err)
+	require.Len(t, plugins, 2)
+	assert.Equal(t, "acme/team-plugin", plugins[0].Repo)
+	assert.Equal(t, "zsh-autosuggestions", plugins[1].Name)
+
+	_, err = lookupTerminalPlugins(ds, []string{"nope"})
+	assert.ErrorContains(t, err, "nope")
+}
+
+func TestResolveTerminalPackage(t *testing.T) {
+	ds := db.NewMockDataStore()
+	stored := &models.TerminalPackageDB{Name: "team", Extends: sql.NullString{String: "core", Valid: true}}
+	require.NoError(t, stored.SetPlugins([]string{"team-plugin"}))
+	require.NoError(t, ds.CreateTerminalPackage(stored))
+
+	core, err := loadTerminalPackage(ds, "core")
+	require.NoError(t, err)
+
+	res, err := resolveTerminalPackage(ds, "team")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team", "core"}, res.Chain)
+	assert.Equal(t, append(append([]string{}, core.Plugins...), "team-plugin"), res.Package.Plugins)
+	assert.Empty(t, res.Package.Extends)
+}
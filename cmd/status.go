@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"devopsmaestro/models"
 	"devopsmaestro/operators"
 	"fmt"
@@ -104,7 +103,7 @@ func runStatus(cmd *cobra.Command) error {
 			Status: "not found",
 		}
 		// Handle output format
-		if outputFormat == "json" || outputFormat == "yaml" {
+		if isStructuredOutput(outputFormat) {
 			return render.OutputWith(outputFormat, status, render.Options{})
 		}
 		renderStatusColored(status)
@@ -119,7 +118,7 @@ func runStatus(cmd *cobra.Command) error {
 	}
 
 	// List running workspaces using the runtime interface
-	workspaces, err := runtime.ListWorkspaces(context.Background())
+	workspaces, err := runtime.ListWorkspaces(commandContext(cmd))
 	if err != nil {
 		slog.Debug("failed to list workspaces", "error", err)
 	} else {
@@ -137,7 +136,7 @@ func runStatus(cmd *cobra.Command) error {
 	}
 
 	// Handle output format
-	if outputFormat == "json" || outputFormat == "yaml" {
+	if isStructuredOutput(outputFormat) {
 		return render.OutputWith(outputFormat, status, render.Options{})
 	}
 
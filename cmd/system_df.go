@@ -73,7 +73,7 @@ func runSystemDF(cmd *cobra.Command, args []string) error {
 
 	data := SystemDFData{Categories: categories}
 
-	if outputFmt == "json" || outputFmt == "yaml" {
+	if isStructuredOutput(outputFmt) {
 		return render.OutputWith(outputFmt, data, render.Options{})
 	}
 
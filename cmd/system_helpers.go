@@ -183,10 +183,10 @@ func getSystems(cmd *cobra.Command) error {
 	}
 
 	// JSON/YAML output
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		handlers.RegisterAll()
 		if len(systems) == 0 {
-			return render.OutputWith(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
+			return outputList(getOutputFormat, resource.NewResourceList(), render.Options{Type: render.TypeAuto})
 		}
 		systemResources := make([]resource.Resource, len(systems))
 		for i, s := range systems {
@@ -198,7 +198,7 @@ func getSystems(cmd *cobra.Command) error {
 		if err != nil {
 			return fmt.Errorf("failed to build resource list: %w", err)
 		}
-		return render.OutputWith(getOutputFormat, list, render.Options{Type: render.TypeAuto})
+		return outputList(getOutputFormat, list, render.Options{Type: render.TypeAuto})
 	}
 
 	if len(systems) == 0 {
@@ -206,7 +206,7 @@ func getSystems(cmd *cobra.Command) error {
 		if allFlag || contextLabel == "(all)" {
 			msg = "No systems found"
 		}
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: msg,
 			EmptyHints:   []string{"dvm create system <name>"},
@@ -222,7 +222,7 @@ func getSystems(cmd *cobra.Command) error {
 	if wide {
 		renderFormat = "table"
 	}
-	return render.OutputWith(renderFormat, td, render.Options{
+	return outputList(renderFormat, td, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -262,8 +262,8 @@ func getSystem(cmd *cobra.Command, name string) error {
 	ecoName := res.(*handlers.SystemResource).EcosystemName()
 
 	// JSON/YAML
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, system.ToYAML(domName, ecoName, nil), render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, system.ToYAML(domName, ecoName, nil), render.Options{})
 	}
 
 	// Human output — detail view
@@ -292,7 +292,7 @@ func getSystem(cmd *cobra.Command, name string) error {
 		render.KeyValue{Key: "Created", Value: system.CreatedAt.Format("2006-01-02 15:04:05")},
 	)
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
 		Title: "System Details",
 	})
@@ -569,7 +569,7 @@ func getActiveSystem(ds db.DataStore) (*models.System, error) {
 	}
 
 	if ctx.ActiveSystemID == nil {
-		return nil, fmt.Errorf("no active system set")
+		return nil, db.NewErrNoActiveContext("system")
 	}
 
 	system, err := ds.GetSystemByID(*ctx.ActiveSystemID)
@@ -122,7 +122,7 @@ func runSystemInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	// JSON/YAML
-	if outputFmt == "json" || outputFmt == "yaml" {
+	if isStructuredOutput(outputFmt) {
 		return render.OutputWith(outputFmt, info, render.Options{})
 	}
 
@@ -69,7 +69,7 @@ func runSystemPrune(cmd *cobra.Command, args []string) error {
 	}
 	platform, err := detector.Detect()
 	if err != nil {
-		return fmt.Errorf("no container runtime found: %w", err)
+		return err
 	}
 	if !platform.IsReachable() {
 		render.Error("Container runtime is not running")
@@ -78,7 +78,7 @@ func runSystemPrune(cmd *cobra.Command, args []string) error {
 	}
 
 	cleaner := operators.NewSystemCleaner(platform)
-	ctx := context.Background()
+	ctx := commandContext(cmd)
 
 	// Confirmation prompt (unless --force or --dry-run)
 	if !pruneForce && !pruneDryRun {
@@ -42,9 +42,10 @@ func BuildTable[T any](builder tableBuilder, items []T, wide bool) render.TableD
 	return td
 }
 
-// renderTable writes a render.TableData to stdout using the current output format.
+// renderTable writes a render.TableData to stdout using the current output
+// format. The table is expected to carry wide columns when the format is wide.
 func renderTable(tableData render.TableData) error {
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(tableFormat(getOutputFormat), tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/envtemplate"
+	"devopsmaestro/pkg/interactive"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"github.com/spf13/cobra"
+)
+
+var templateInstallDryRun bool
+
+// templateCmd groups environment and workspace template commands.
+// Usage: dvm template install org/backend-stack --set domain=payments
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"templates"},
+	Short:   "Install environment templates and manage workspace templates",
+	Long: `Install environment templates: packages of resource YAML with parameter
+placeholders, shared through git repositories or OCI registries.
+
+A template is a directory with a template.yaml manifest and resource files:
+
+  backend-stack/
+    template.yaml          # kind: EnvironmentTemplate (metadata + parameters)
+    resources/*.yaml       # resources using ${param:name} placeholders
+
+Workspace templates (kind: WorkspaceTemplate) are single files bundling a
+workspace setup. 'list', 'add', and 'remove' manage the local catalog, and
+'dvm create workspace --template' instantiates one.
+
+Examples:
+  dvm template install org/backend-stack --set domain=payments
+  dvm template install oci://ghcr.io/org/backend-stack:1.0.0
+  dvm template package ./backend-stack -f backend-stack.tar.gz
+  dvm templates list
+  dvm create workspace api --template go-grpc`,
+}
+
+// templateInstallCmd renders a template with parameters and applies it.
+var templateInstallCmd = &cobra.Command{
+	Use:   "install <template>",
+	Short: "Render a template with parameters and apply its resources",
+	Long: `Fetch an environment template, fill in its parameters, and apply the
+resulting resources (in dependency order) as a single List.
+
+Template references:
+  ./backend-stack                        local directory
+  backend-stack.tar.gz                   packaged template (dvm template package)
+  org/backend-stack[@ref]                GitHub repository
+  org/templates//backend-stack[@ref]     subdirectory of a git repository
+  https://git.example.com/t.git[@ref]    any git URL
+  oci://ghcr.io/org/backend-stack:1.0.0  OCI registry artifact
+
+Parameters are set with --set name=value. Missing required parameters are
+prompted for when stdin is a terminal; otherwise the install fails. Values
+are validated against the template's pattern and enum constraints.
+
+Private OCI registries read a bearer token from DVM_REGISTRY_TOKEN.
+
+Examples:
+  dvm template install org/backend-stack --set domain=payments
+  dvm template install org/backend-stack@v1.2.0 --set domain=payments --set language=python
+  dvm template install ./backend-stack --set domain=payments --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateInstall,
+}
+
+// templatePackageCmd packs a template directory for publishing.
+var templatePackageCmd = &cobra.Command{
+	Use:   "package <dir>",
+	Short: "Validate a template directory and pack it as a .tar.gz",
+	Long: `Validate a template directory and pack it as a gzip tarball. The archive
+can be installed directly or pushed to an OCI registry as the template layer:
+
+  oras push ghcr.io/org/backend-stack:1.0.0 \
+    backend-stack.tar.gz:` + envtemplate.LayerMediaType + `
+
+Git-hosted templates need no packaging; push the directory to a repository.
+
+Examples:
+  dvm template package ./backend-stack
+  dvm template package ./backend-stack -f dist/backend-stack-1.0.0.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplatePackage,
+}
+
+func init() {
+	templateInstallCmd.Flags().StringArray("set", nil, "Set a template parameter (name=value, repeatable)")
+	templateInstallCmd.Flags().Bool("no-prompt", false, "Fail instead of prompting for missing parameters")
+	AddDryRunFlag(templateInstallCmd, &templateInstallDryRun)
+
+	templatePackageCmd.Flags().StringP("file", "f", "", "Output archive path (default: <name>.tar.gz)")
+
+	templateCmd.AddCommand(templateInstallCmd)
+	templateCmd.AddCommand(templatePackageCmd)
+	rootCmd.AddCommand(templateCmd)
+}
+
+func runTemplateInstall(cmd *cobra.Command, args []string) error {
+	sets, _ := cmd.Flags().GetStringArray("set")
+	noPrompt, _ := cmd.Flags().GetBool("no-prompt")
+
+	values, err := parseTemplateSets(sets)
+	if err != nil {
+		return err
+	}
+
+	ref, err := envtemplate.ParseRef(args[0])
+	if err != nil {
+		return err
+	}
+	render.Progress(fmt.Sprintf("Fetching template %s...", ref))
+	tmpl, err := envtemplate.Fetch(cmd.Context(), ref)
+	if err != nil {
+		return err
+	}
+	meta := tmpl.Manifest.Metadata
+	render.Info(fmt.Sprintf("Template %s %s", meta.Name, meta.Version))
+
+	if missing := tmpl.Missing(values); len(missing) > 0 && !noPrompt && interactive.Enabled() {
+		if err := promptTemplateParameters(bufio.NewReader(os.Stdin), cmd.ErrOrStderr(), missing, values); err != nil {
+			return err
+		}
+	}
+
+	list, err := tmpl.Render(values)
+	if err != nil {
+		return err
+	}
+
+	if templateInstallDryRun {
+		render.Info("Dry run: the following resources would be applied")
+		fmt.Fprint(cmd.OutOrStdout(), string(list))
+		return nil
+	}
+
+	ctx, err := buildResourceContext(cmd)
+	if err != nil {
+		return err
+	}
+	applied, err := resource.ApplyList(ctx, list)
+	for _, res := range applied {
+		render.Success(fmt.Sprintf("  %s '%s' applied", res.GetKind(), res.GetName()))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to install template %s: %w", meta.Name, err)
+	}
+	render.Successf("Installed template %s (%d resources)", meta.Name, len(applied))
+	return nil
+}
+
+func runTemplatePackage(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	out, _ := cmd.Flags().GetString("file")
+
+	tmpl, err := envtemplate.Load(dir)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		out = tmpl.Manifest.Metadata.Name
+		if v := tmpl.Manifest.Metadata.Version; v != "" {
+			out += "-" + v
+		}
+		out += ".tar.gz"
+	}
+	if dir := filepath.Dir(out); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	if err := envtemplate.Pack(dir, f); err != nil {
+		f.Close()
+		os.Remove(out)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	render.Successf("Packaged template %s to %s", tmpl.Manifest.Metadata.Name, out)
+	return nil
+}
+
+// parseTemplateSets parses repeated --set name=value flags.
+func parseTemplateSets(sets []string) (map[string]string, error) {
+	values := make(map[string]string, len(sets))
+	for _, s := range sets {
+		k, v, ok := strings.Cut(s, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --set %q (expected name=value)", s)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
+
+// promptTemplateParameters asks for each missing parameter, re-prompting
+// until the value passes the parameter's validation.
+func promptTemplateParameters(in *bufio.Reader, out io.Writer, missing []envtemplate.Parameter, values map[string]string) error {
+	for _, p := range missing {
+		label := p.Name
+		if p.Description != "" {
+			label += " (" + p.Description + ")"
+		}
+		if len(p.Enum) > 0 {
+			label += " [" + strings.Join(p.Enum, "|") + "]"
+		}
+		for {
+			fmt.Fprintf(out, "%s: ", label)
+			line, err := in.ReadString('\n')
+			v := strings.TrimSpace(line)
+			if v == "" {
+				if err != nil {
+					return fmt.Errorf("parameter %q is required", p.Name)
+				}
+				continue
+			}
+			if cerr := p.Check(v); cerr != nil {
+				fmt.Fprintln(out, cerr)
+				if err != nil {
+					return cerr
+				}
+				continue
+			}
+			values[p.Name] = v
+			break
+		}
+	}
+	return nil
+}
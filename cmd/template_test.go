@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"devopsmaestro/pkg/envtemplate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplateSets(t *testing.T) {
+	values, err := parseTemplateSets([]string{"domain=payments", "url=https://x?a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"domain": "payments", "url": "https://x?a=b", "empty": ""}, values)
+
+	_, err = parseTemplateSets([]string{"novalue"})
+	assert.Error(t, err)
+	_, err = parseTemplateSets([]string{"=x"})
+	assert.Error(t, err)
+}
+
+func TestPromptTemplateParameters(t *testing.T) {
+	missing := []envtemplate.Parameter{
+		{Name: "domain", Required: true, Pattern: "^[a-z]+$"},
+		{Name: "tier", Required: true, Enum: []string{"dev", "prod"}},
+	}
+	in := bufio.NewReader(strings.NewReader("\nPayments\npayments\nstaging\nprod\n"))
+	var out bytes.Buffer
+	values := map[string]string{}
+
+	require.NoError(t, promptTemplateParameters(in, &out, missing, values))
+	assert.Equal(t, "payments", values["domain"])
+	assert.Equal(t, "prod", values["tier"])
+	assert.Contains(t, out.String(), "does not match pattern")
+	assert.Contains(t, out.String(), "tier [dev|prod]: ")
+}
+
+func TestPromptTemplateParameters_EOF(t *testing.T) {
+	missing := []envtemplate.Parameter{{Name: "domain", Required: true}}
+	err := promptTemplateParameters(bufio.NewReader(strings.NewReader("")), &bytes.Buffer{}, missing, map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestTemplateInstallCmd_Flags(t *testing.T) {
+	assert.Equal(t, "stringArray", templateInstallCmd.Flags().Lookup("set").Value.Type())
+	assert.NotNil(t, templateInstallCmd.Flags().Lookup("dry-run"))
+	assert.NotNil(t, templateInstallCmd.Flags().Lookup("no-prompt"))
+}
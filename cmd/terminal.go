@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/terminalbridge/emulatorgen"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	theme "github.com/rmkohlman/MaestroTheme"
+	"github.com/rmkohlman/MaestroTheme/library"
+
+	"github.com/spf13/cobra"
+)
+
+// terminalCmd groups host-side terminal configuration commands.
+// Usage: dvm terminal generate --emulator wezterm
+var terminalCmd = &cobra.Command{
+	Use:   "terminal",
+	Short: "Generate host terminal configuration",
+	Long: `Generate configuration files for terminal emulators on the host.
+
+Examples:
+  dvm terminal generate --emulator wezterm --out ~/.config/wezterm
+  dvm terminal generate --emulator kitty --name kitty-poweruser
+  dvm terminal generate --emulator alacritty --theme catppuccin-mocha`,
+}
+
+// terminalGenerateCmd renders a terminal emulator config file.
+var terminalGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Render a terminal emulator config file",
+	Long: `Render a native config file for a terminal emulator.
+
+The stored emulator config (dvt emulator / dvm apply), the resolved theme
+palette, and font settings are merged into the emulator's own format:
+
+  wezterm    wezterm.lua
+  alacritty  alacritty.toml
+  kitty      kitty.conf
+  ghostty    config
+
+The emulator config is selected by --name, otherwise the 'terminal-emulator'
+default when it has the requested type, otherwise the first enabled emulator
+of that type. Without any stored config, only theme colors and font are
+written.
+
+The theme is taken from --theme, then the emulator's themeRef, then the
+active theme. Explicit colors in the stored config always win over the theme.
+
+Without --out, the config is written to stdout.
+
+Examples:
+  dvm terminal generate --emulator wezterm --out ~/.config/wezterm
+  dvm terminal generate --emulator ghostty --out ~/.config/ghostty --font "JetBrains Mono" --font-size 13
+  dvm terminal generate --emulator kitty --name kitty-poweruser > kitty.conf`,
+	RunE: runTerminalGenerate,
+}
+
+func init() {
+	terminalGenerateCmd.Flags().String("emulator", "", "Emulator type ("+strings.Join(emulatorgen.AvailableEmulators(), ", ")+")")
+	terminalGenerateCmd.Flags().String("name", "", "Stored emulator config to use")
+	terminalGenerateCmd.Flags().String("theme", "", "Theme to take colors from (overrides themeRef and the active theme)")
+	terminalGenerateCmd.Flags().String("font", "", "Font family override")
+	terminalGenerateCmd.Flags().Float64("font-size", 0, "Font size override")
+	terminalGenerateCmd.Flags().String("out", "", "Output directory (default: stdout)")
+	terminalGenerateCmd.Flags().Bool("force", false, "Overwrite an existing config file")
+	_ = terminalGenerateCmd.MarkFlagRequired("emulator")
+	_ = terminalGenerateCmd.RegisterFlagCompletionFunc("emulator", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return emulatorgen.AvailableEmulators(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	terminalCmd.AddCommand(terminalGenerateCmd)
+	rootCmd.AddCommand(terminalCmd)
+}
+
+func runTerminalGenerate(cmd *cobra.Command, args []string) error {
+	emulatorType, _ := cmd.Flags().GetString("emulator")
+	name, _ := cmd.Flags().GetString("name")
+	themeName, _ := cmd.Flags().GetString("theme")
+	fontFamily, _ := cmd.Flags().GetString("font")
+	fontSize, _ := cmd.Flags().GetFloat64("font-size")
+	outDir, _ := cmd.Flags().GetString("out")
+	force, _ := cmd.Flags().GetBool("force")
+
+	gen, err := emulatorgen.NewConfigGenerator(emulatorType)
+	if err != nil {
+		return err
+	}
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	emu, err := selectTerminalEmulator(ds, emulatorType, name)
+	if err != nil {
+		return err
+	}
+
+	input := emulatorgen.Input{
+		Font: emulatorgen.Font{Family: fontFamily, Size: fontSize},
+	}
+	if emu != nil {
+		input.Name = emu.Name
+		if input.Config, err = emu.GetConfig(); err != nil {
+			return fmt.Errorf("failed to parse config for emulator '%s': %w", emu.Name, err)
+		}
+		if themeName == "" && emu.ThemeRef.Valid {
+			themeName = emu.ThemeRef.String
+		}
+	}
+
+	themeStore, err := getThemeStore(cmd)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveTerminalTheme(themeStore, themeName)
+	if err != nil {
+		return err
+	}
+	if resolved != nil {
+		input.Palette = resolved.ToPalette()
+	}
+
+	content, err := gen.Generate(input)
+	if err != nil {
+		return fmt.Errorf("failed to generate %s config: %w", emulatorType, err)
+	}
+
+	if outDir == "" {
+		fmt.Fprint(cmd.OutOrStdout(), content)
+		return nil
+	}
+
+	if strings.HasPrefix(outDir, "~") {
+		home, _ := os.UserHomeDir()
+		outDir = filepath.Join(home, outDir[1:])
+	}
+	outPath := filepath.Join(outDir, gen.FileName())
+	if _, err := os.Stat(outPath); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", outPath)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(outPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", outPath, err)
+	}
+
+	render.Successf("Generated %s config at %s", emulatorType, outPath)
+	return nil
+}
+
+// terminalEmulatorSource is the narrow interface needed to pick an emulator config.
+type terminalEmulatorSource interface {
+	db.TerminalEmulatorStore
+	db.DefaultsStore
+}
+
+// selectTerminalEmulator picks the stored emulator config to render.
+// Precedence: explicit name > 'terminal-emulator' default of the same type >
+// first enabled emulator of the type (by name). Returns nil, nil when no stored
+// config applies, so generation proceeds from theme and font alone.
+func selectTerminalEmulator(ds terminalEmulatorSource, emulatorType, name string) (*models.TerminalEmulatorDB, error) {
+	if name != "" {
+		emu, err := ds.GetTerminalEmulator(name)
+		if err != nil {
+			return nil, fmt.Errorf("terminal emulator '%s' not found: %w", name, err)
+		}
+		if emu.Type != emulatorType {
+			return nil, fmt.Errorf("terminal emulator '%s' is type %s, not %s", name, emu.Type, emulatorType)
+		}
+		return emu, nil
+	}
+
+	if defaultName, err := ds.GetDefault("terminal-emulator"); err == nil && defaultName != "" {
+		if emu, err := ds.GetTerminalEmulator(defaultName); err == nil && emu.Type == emulatorType {
+			return emu, nil
+		}
+	}
+
+	emulators, err := ds.ListTerminalEmulatorsByType(emulatorType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s emulators: %w", emulatorType, err)
+	}
+	sort.Slice(emulators, func(i, j int) bool { return emulators[i].Name < emulators[j].Name })
+	for _, emu := range emulators {
+		if emu.Enabled {
+			return emu, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveTerminalTheme loads a theme by name from the store, falling back to the
+// built-in library. An empty name resolves to the active theme, if any.
+func resolveTerminalTheme(store theme.Store, name string) (*theme.Theme, error) {
+	if name == "" {
+		active, err := store.GetActive()
+		if err != nil {
+			return nil, nil // No active theme - generate without colors
+		}
+		return active, nil
+	}
+	if t, err := store.Get(name); err == nil && t != nil {
+		return t, nil
+	}
+	t, err := library.Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("theme '%s' not found", name)
+	}
+	return t, nil
+}
@@ -104,7 +104,7 @@ func getTerminalPackages(cmd *cobra.Command) error {
 	}
 
 	if len(resources) == 0 {
-		return render.OutputWith(getOutputFormat, nil, render.Options{
+		return outputList(getOutputFormat, nil, render.Options{
 			Empty:        true,
 			EmptyMessage: "No terminal packages found",
 			EmptyHints:   []string{"dvm apply -f terminal-package.yaml"},
@@ -119,12 +119,12 @@ func getTerminalPackages(cmd *cobra.Command) error {
 	}
 
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
+	if isStructuredOutput(getOutputFormat) {
 		packagesYAML := make([]*terminalpkg.PackageYAML, len(packages))
 		for i, p := range packages {
 			packagesYAML[i] = p.ToYAML()
 		}
-		return render.OutputWith(getOutputFormat, packagesYAML, render.Options{})
+		return outputList(getOutputFormat, packagesYAML, render.Options{})
 	}
 
 	// For human output, build table data
@@ -156,7 +156,7 @@ func getTerminalPackages(cmd *cobra.Command) error {
 		}
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -174,11 +174,16 @@ func getTerminalPackage(cmd *cobra.Command, name string) error {
 		return fmt.Errorf("failed to get terminal package '%s': %w", name, err)
 	}
 
-	p := res.(*handlers.TerminalPackageResource).Package()
+	return renderTerminalPackageDetail(res.(*handlers.TerminalPackageResource).Package(), nil)
+}
 
+// renderTerminalPackageDetail shows a single terminal package. When inherits
+// is set, the package is a flattened extends chain and the ancestors it was
+// merged from are listed.
+func renderTerminalPackageDetail(p *terminalpkg.Package, inherits []string) error {
 	// For JSON/YAML, output the model data directly
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, p.ToYAML(), render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, p.ToYAML(), render.Options{})
 	}
 
 	// For human output, show detail view
@@ -212,20 +217,34 @@ func getTerminalPackage(cmd *cobra.Command, name string) error {
 		tagsList = strings.Join(p.Tags, ", ")
 	}
 
+	extendsKey, title := "Extends", "Terminal Package Details"
+	if inherits != nil {
+		extendsKey, title = "Inherits", "Resolved Terminal Package"
+		if len(inherits) > 0 {
+			extends = strings.Join(inherits, " → ")
+		}
+	}
+
 	kvData := render.NewOrderedKeyValueData(
 		render.KeyValue{Key: "Name", Value: p.Name},
 		render.KeyValue{Key: "Category", Value: category},
 		render.KeyValue{Key: "Description", Value: p.Description},
-		render.KeyValue{Key: "Extends", Value: extends},
+		render.KeyValue{Key: extendsKey, Value: extends},
 		render.KeyValue{Key: "Plugins", Value: pluginsList},
 		render.KeyValue{Key: "Prompts", Value: promptsList},
 		render.KeyValue{Key: "Profiles", Value: profilesList},
 		render.KeyValue{Key: "Tags", Value: tagsList},
 	)
+	if p.PromptStyle != "" {
+		kvData.Pairs = append(kvData.Pairs, render.KeyValue{Key: "Prompt Style", Value: p.PromptStyle})
+	}
+	if p.Theme != "" {
+		kvData.Pairs = append(kvData.Pairs, render.KeyValue{Key: "Theme", Value: p.Theme})
+	}
 
-	return render.OutputWith(getOutputFormat, kvData, render.Options{
+	return outputList(getOutputFormat, kvData, render.Options{
 		Type:  render.TypeKeyValue,
-		Title: "Terminal Package Details",
+		Title: title,
 	})
 }
 
@@ -256,8 +275,8 @@ func getTerminalDefaults(cmd *cobra.Command) error {
 	}
 
 	// For structured output (JSON/YAML)
-	if getOutputFormat == "json" || getOutputFormat == "yaml" {
-		return render.OutputWith(getOutputFormat, terminalDefaults, render.Options{})
+	if isStructuredOutput(getOutputFormat) {
+		return outputList(getOutputFormat, terminalDefaults, render.Options{})
 	}
 
 	// For table output
@@ -284,7 +303,7 @@ func getTerminalDefaults(cmd *cobra.Command) error {
 		tableData.Rows = append(tableData.Rows, []string{key, displayValue})
 	}
 
-	return render.OutputWith(getOutputFormat, tableData, render.Options{
+	return outputList(getOutputFormat, tableData, render.Options{
 		Type: render.TypeTable,
 	})
 }
@@ -689,6 +689,7 @@ func createMinimalTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -702,6 +703,7 @@ func createMinimalTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE CASCADE,
@@ -719,6 +721,7 @@ func createMinimalTestSchema(driver db.Driver) error {
 			theme TEXT,
 			nvim_package TEXT,
 			terminal_package TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
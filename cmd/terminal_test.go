@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+)
+
+func newEmulatorMock(emulators ...*models.TerminalEmulatorDB) *db.MockDataStore {
+	m := db.NewMockDataStore()
+	for _, e := range emulators {
+		m.TerminalEmulators[e.Name] = e
+	}
+	return m
+}
+
+func TestSelectTerminalEmulator(t *testing.T) {
+	wez := &models.TerminalEmulatorDB{Name: "b-wez", Type: "wezterm", Enabled: true, Config: "{}"}
+	wezDisabled := &models.TerminalEmulatorDB{Name: "a-wez", Type: "wezterm", Enabled: false, Config: "{}"}
+	kitty := &models.TerminalEmulatorDB{Name: "kitty-poweruser", Type: "kitty", Enabled: true, Config: "{}"}
+
+	t.Run("explicit name", func(t *testing.T) {
+		got, err := selectTerminalEmulator(newEmulatorMock(wez, kitty), "kitty", "kitty-poweruser")
+		if err != nil || got == nil || got.Name != "kitty-poweruser" {
+			t.Fatalf("got %v, %v; want kitty-poweruser", got, err)
+		}
+	})
+
+	t.Run("explicit name with wrong type", func(t *testing.T) {
+		if _, err := selectTerminalEmulator(newEmulatorMock(kitty), "wezterm", "kitty-poweruser"); err == nil {
+			t.Fatal("expected type mismatch error")
+		}
+	})
+
+	t.Run("explicit name not found", func(t *testing.T) {
+		if _, err := selectTerminalEmulator(newEmulatorMock(), "wezterm", "missing"); err == nil {
+			t.Fatal("expected not found error")
+		}
+	})
+
+	t.Run("default of matching type", func(t *testing.T) {
+		other := &models.TerminalEmulatorDB{Name: "z-wez", Type: "wezterm", Enabled: true, Config: "{}"}
+		m := newEmulatorMock(wez, other)
+		m.Defaults = map[string]string{"terminal-emulator": "z-wez"}
+		got, err := selectTerminalEmulator(m, "wezterm", "")
+		if err != nil || got == nil || got.Name != "z-wez" {
+			t.Fatalf("got %v, %v; want z-wez", got, err)
+		}
+	})
+
+	t.Run("default of other type is ignored", func(t *testing.T) {
+		m := newEmulatorMock(wez, kitty)
+		m.Defaults = map[string]string{"terminal-emulator": "kitty-poweruser"}
+		got, err := selectTerminalEmulator(m, "wezterm", "")
+		if err != nil || got == nil || got.Name != "b-wez" {
+			t.Fatalf("got %v, %v; want b-wez", got, err)
+		}
+	})
+
+	t.Run("first enabled of type", func(t *testing.T) {
+		got, err := selectTerminalEmulator(newEmulatorMock(wezDisabled, wez), "wezterm", "")
+		if err != nil || got == nil || got.Name != "b-wez" {
+			t.Fatalf("got %v, %v; want b-wez", got, err)
+		}
+	})
+
+	t.Run("none stored", func(t *testing.T) {
+		got, err := selectTerminalEmulator(newEmulatorMock(kitty), "ghostty", "")
+		if err != nil || got != nil {
+			t.Fatalf("got %v, %v; want nil, nil", got, err)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/terminalbridge/themesync"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// themeCmd groups host-side theme commands.
+// Usage: dvm theme apply <name> --all
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Apply a theme across host applications",
+	Long: `Apply a theme to the applications on the host.
+
+Examples:
+  dvm theme apply tokyonight-storm --all
+  dvm theme apply catppuccin-mocha --target nvim,tmux`,
+}
+
+// themeApplyCmd regenerates the theme-dependent configs of several
+// applications from one theme.
+var themeApplyCmd = &cobra.Command{
+	Use:   "apply [name]",
+	Short: "Regenerate nvim, terminal, prompt, and tmux colors from one theme",
+	Long: `Resolve a theme's palette once and regenerate the colors of every
+selected application from it:
+
+  nvim       lua/theme/palette.lua and init.lua of a dvm- or nvp-generated config
+  wezterm    wezterm/dvm-colors.lua      (config.colors = require("dvm-colors"))
+  alacritty  alacritty/dvm-colors.toml   (imported from alacritty.toml)
+  starship   the palette key and [palettes.<name>] table of starship.toml
+  tmux       tmux/dvm-theme.conf, or ~/.tmux/dvm-theme.conf next to ~/.tmux.conf
+             (source-file it from your tmux config)
+
+Only colors are written; the rest of each config is left alone. A target whose
+config is not present on the host is reported as out of scope and skipped.
+
+The theme is looked up in the theme store, then the built-in library. Without
+a name the active theme is applied.
+
+Examples:
+  dvm theme apply tokyonight-storm --all
+  dvm theme apply catppuccin-mocha --target wezterm,starship
+  dvm theme apply --all --dry-run
+  dvm theme apply gruvbox-dark --all -o json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeAllThemes,
+	RunE:              runThemeApply,
+}
+
+func init() {
+	themeApplyCmd.Flags().Bool("all", false, "Apply to every target")
+	themeApplyCmd.Flags().StringSlice("target", nil, "Targets to apply to ("+strings.Join(themesync.Targets(), ", ")+")")
+	themeApplyCmd.Flags().String("config-dir", "", "Config directory to look for targets in (default: $XDG_CONFIG_HOME or ~/.config)")
+	themeApplyCmd.Flags().StringP("output", "o", "", "Output format (json, yaml, table)")
+	themeApplyCmd.Flags().Bool("dry-run", false, "Show which files would be written without writing them")
+	_ = themeApplyCmd.RegisterFlagCompletionFunc("target", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return themesync.Targets(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	themeCmd.AddCommand(themeApplyCmd)
+	rootCmd.AddCommand(themeCmd)
+}
+
+// Theme apply statuses.
+const (
+	themeApplyUpdated     = "updated"
+	themeApplyWouldUpdate = "would update"
+	themeApplyOutOfScope  = "out of scope"
+	themeApplyFailed      = "failed"
+)
+
+// ThemeApplyResult reports what applying a theme did to one target.
+type ThemeApplyResult struct {
+	Target string   `yaml:"target" json:"target"`
+	Status string   `yaml:"status" json:"status"`
+	Paths  []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	Note   string   `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+func runThemeApply(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	targets, _ := cmd.Flags().GetStringSlice("target")
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	output, _ := cmd.Flags().GetString("output")
+
+	switch {
+	case all && len(targets) > 0:
+		return fmt.Errorf("--all cannot be used with --target")
+	case all:
+		targets = themesync.Targets()
+	case len(targets) == 0:
+		return ErrorWithSuggestion("no targets selected",
+			"Use --all, or --target with any of: "+strings.Join(themesync.Targets(), ", "))
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	themeStore, err := getThemeStore(cmd)
+	if err != nil {
+		return err
+	}
+	resolved, err := resolveTerminalTheme(themeStore, name)
+	if err != nil {
+		return err
+	}
+	if resolved == nil {
+		return ErrorWithSuggestion("no theme given and no active theme set",
+			"Pass a theme name: dvm theme apply <name> --all")
+	}
+
+	loc, err := themeApplyLocations(configDir)
+	if err != nil {
+		return err
+	}
+	results, failed := applyThemeTargets(themesync.New(resolved, loc), targets, dryRun)
+
+	if isStructuredOutput(output) {
+		if err := render.OutputWith(output, results, render.Options{}); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			rows = append(rows, []string{r.Target, r.Status, strings.Join(r.Paths, ", "), r.Note})
+		}
+		if err := render.OutputWith(output, render.TableData{
+			Headers: []string{"TARGET", "STATUS", "PATH", "NOTE"},
+			Rows:    rows,
+		}, render.Options{}); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to apply theme '%s' to %d target(s)", resolved.Name, failed)
+	}
+	return nil
+}
+
+// applyThemeTargets plans and, unless dryRun, writes each target. A failing
+// target is reported and does not stop the others; the failure count is
+// returned alongside the results.
+func applyThemeTargets(s *themesync.Syncer, targets []string, dryRun bool) ([]ThemeApplyResult, int) {
+	results := make([]ThemeApplyResult, 0, len(targets))
+	failed := 0
+	for _, target := range targets {
+		result := ThemeApplyResult{Target: target}
+		plan, err := s.Plan(target)
+		if err == nil && plan.InScope() && !dryRun {
+			err = plan.Write()
+		}
+		switch {
+		case err != nil:
+			result.Status, result.Note = themeApplyFailed, err.Error()
+			failed++
+		case !plan.InScope():
+			result.Status, result.Note = themeApplyOutOfScope, plan.Note
+		default:
+			result.Status, result.Note = themeApplyUpdated, plan.Note
+			if dryRun {
+				result.Status = themeApplyWouldUpdate
+			}
+			for _, f := range plan.Files {
+				result.Paths = append(result.Paths, f.Path)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, failed
+}
+
+// themeApplyLocations returns where to look for target configs. configDir
+// overrides $XDG_CONFIG_HOME, which defaults to ~/.config.
+func themeApplyLocations(configDir string) (themesync.Locations, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return themesync.Locations{}, fmt.Errorf("failed to find home directory: %w", err)
+	}
+	if configDir == "" {
+		configDir = os.Getenv("XDG_CONFIG_HOME")
+	}
+	if configDir == "" {
+		configDir = filepath.Join(home, ".config")
+	}
+	if strings.HasPrefix(configDir, "~") {
+		configDir = filepath.Join(home, configDir[1:])
+	}
+	return themesync.Locations{ConfigDir: configDir, Home: home}, nil
+}
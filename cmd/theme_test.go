@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/pkg/terminalbridge/themesync"
+
+	"github.com/rmkohlman/MaestroTheme/library"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyThemeTargets(t *testing.T) {
+	th, err := library.Get("tokyonight-night")
+	require.NoError(t, err)
+
+	config := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(config, "wezterm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(config, "starship.toml"), []byte("[character]\n"), 0644))
+	s := themesync.New(th, themesync.Locations{ConfigDir: config, Home: t.TempDir()})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		results, failed := applyThemeTargets(s, []string{themesync.TargetWezTerm}, true)
+		assert.Zero(t, failed)
+		assert.Equal(t, themeApplyWouldUpdate, results[0].Status)
+		assert.NoFileExists(t, filepath.Join(config, "wezterm", "dvm-colors.lua"))
+	})
+
+	results, failed := applyThemeTargets(s, append(themesync.Targets(), "kitty"), false)
+	assert.Equal(t, 1, failed)
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[r.Target] = r.Status
+	}
+	assert.Equal(t, map[string]string{
+		"nvim":      themeApplyOutOfScope,
+		"wezterm":   themeApplyUpdated,
+		"alacritty": themeApplyOutOfScope,
+		"starship":  themeApplyUpdated,
+		"tmux":      themeApplyOutOfScope,
+		"kitty":     themeApplyFailed,
+	}, statuses)
+
+	assert.FileExists(t, filepath.Join(config, "wezterm", "dvm-colors.lua"))
+	starship, err := os.ReadFile(filepath.Join(config, "starship.toml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(starship), "palette = 'tokyonight-night'")
+	assert.Contains(t, string(starship), "[palettes.tokyonight-night]")
+}
@@ -0,0 +1,403 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// upCmd starts every workspace of an app, system, domain, or ecosystem
+var upCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Build and start every workspace of an app or domain",
+	Long: `Build and start all workspaces in a scope, with their services.
+
+The scope is the active app unless -e, -d, -s, or -a picks an ecosystem,
+domain, system, or app (-w narrows it to matching workspaces).
+
+Workspaces start after the ones they list in spec.dependsOn, otherwise in
+order of path. Dependencies outside the scope are not started. For each
+workspace:
+  1. Its image is built if it has never been built (skip with --no-build)
+  2. Its services (spec.services) are started
+  3. Its container is started, running the preStart, postSync, and
+     postStart hooks
+
+A workspace that fails does not stop the others, but the workspaces that
+depend on it are skipped. 'dvm attach' enters a
+started workspace; with spec.sync the files are copied once on start and
+kept in sync while attached.
+
+Examples:
+  dvm up                    # Every workspace of the active app
+  dvm up -a api             # Every workspace of app 'api'
+  dvm up -d backend         # Every workspace in domain 'backend'
+  dvm up -d backend --no-build
+  dvm up --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runUp,
+}
+
+// downCmd stops every workspace of an app, system, domain, or ecosystem
+var downCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Stop every workspace of an app or domain",
+	Long: `Stop all workspaces in a scope, with their services.
+
+The scope is chosen as for 'dvm up'. Workspaces are stopped in the reverse
+of the order 'dvm up' starts them, so dependents stop first. Each one's
+container is stopped before its services, and copied files (spec.sync,
+runtime endpoints) are synced back first.
+
+Examples:
+  dvm down                  # Every workspace of the active app
+  dvm down -d backend
+  dvm down --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runDown,
+}
+
+var upDryRun, downDryRun bool
+
+func init() {
+	rootCmd.AddCommand(upCmd)
+	rootCmd.AddCommand(downCmd)
+
+	AddHierarchyFlags(upCmd, &HierarchyFlags{})
+	upCmd.Flags().Bool("no-build", false, "Skip workspaces that have not been built instead of building them")
+	AddDryRunFlag(upCmd, &upDryRun)
+
+	AddHierarchyFlags(downCmd, &HierarchyFlags{})
+	AddDryRunFlag(downCmd, &downDryRun)
+}
+
+// Workspace states reported by dvm up and dvm down.
+const (
+	upStatusRunning   = "running"
+	upStatusStopped   = "stopped"
+	upStatusNotBuilt  = "not built"
+	upStatusSkipped   = "skipped"
+	upStatusFailed    = "failed"
+	upStatusCancelled = "cancelled"
+)
+
+// upResult is the outcome of dvm up or dvm down for one workspace.
+type upResult struct {
+	Workspace string
+	Image     string
+	Services  int
+	Status    string
+	Err       error
+}
+
+// Workspace actions of dvm up and dvm down; tests replace them.
+var (
+	upBuildWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) error {
+		return buildSingleWorkspaceForParallel(ctx, ds, wh, os.Stdout, nil)
+	}
+	upStartWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) (int, error) {
+		opts := workspaceStartOptions{EcosystemName: wh.Ecosystem.Name, DomainName: wh.Domain.Name}
+		if wh.System != nil {
+			opts.SystemName = wh.System.Name
+		}
+		started, err := startWorkspaceContainer(ctx, ds, wh.App, wh.Workspace, opts)
+		if err != nil {
+			return 0, err
+		}
+		return started.services, nil
+	}
+	downStopWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) error {
+		_, err := stopWorkspaceContainer(ctx, ds, wh.App, wh.Workspace, workspaceContainerName(wh))
+		return err
+	}
+)
+
+func runUp(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	workspaces, err := resolveUpWorkspaces(cmd, ds)
+	if err != nil {
+		return err
+	}
+	noBuild, _ := cmd.Flags().GetBool("no-build")
+	deps, missing := upWorkspaceDependencies(workspaces)
+	workspaces, err = orderUpWorkspaces(workspaces, deps)
+	if err != nil {
+		return err
+	}
+	for _, m := range missing {
+		render.Warning(m)
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		for _, wh := range workspaces {
+			switch {
+			case workspaceImageBuilt(wh.Workspace):
+				render.Plain(fmt.Sprintf("Would start %s", wh.ShortPath()))
+			case noBuild:
+				render.Plain(fmt.Sprintf("Would skip %s (not built)", wh.ShortPath()))
+			default:
+				render.Plain(fmt.Sprintf("Would build and start %s", wh.ShortPath()))
+			}
+		}
+		return nil
+	}
+
+	ctx := commandContext(cmd)
+	results := make([]upResult, 0, len(workspaces))
+	status := make(map[*models.WorkspaceWithHierarchy]string, len(workspaces))
+	for _, wh := range workspaces {
+		result := upResult{Workspace: wh.ShortPath()}
+		blocker := firstNotRunning(deps[wh], status)
+		switch {
+		case ctx.Err() != nil:
+			result.Status = upStatusCancelled
+		case blocker != nil:
+			result.Status = upStatusSkipped
+			result.Err = fmt.Errorf("dependency %s is %s", blocker.ShortPath(), status[blocker])
+		case !workspaceImageBuilt(wh.Workspace) && noBuild:
+			result.Status = upStatusNotBuilt
+		default:
+			render.Progress(fmt.Sprintf("Starting %s...", wh.ShortPath()))
+			result.Services, result.Err = upWorkspace(ctx, ds, wh)
+			result.Status = upStatusRunning
+			if result.Err != nil {
+				result.Status = upStatusFailed
+			}
+		}
+		result.Image = wh.Workspace.ImageName
+		status[wh] = result.Status
+		results = append(results, result)
+	}
+
+	render.Blank()
+	return renderUpResults(results, true)
+}
+
+// upWorkspace builds a workspace if it has never been built, then starts it
+// and its services.
+func upWorkspace(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) (int, error) {
+	if !workspaceImageBuilt(wh.Workspace) {
+		if err := upBuildWorkspace(ctx, ds, wh); err != nil {
+			return 0, fmt.Errorf("build failed: %w", err)
+		}
+	}
+	return upStartWorkspace(ctx, ds, wh)
+}
+
+func runDown(cmd *cobra.Command, args []string) error {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	workspaces, err := resolveUpWorkspaces(cmd, ds)
+	if err != nil {
+		return err
+	}
+	deps, _ := upWorkspaceDependencies(workspaces)
+	workspaces, err = orderUpWorkspaces(workspaces, deps)
+	if err != nil {
+		return err
+	}
+
+	// Stop in the reverse of the order dvm up starts them
+	for i, j := 0, len(workspaces)-1; i < j; i, j = i+1, j-1 {
+		workspaces[i], workspaces[j] = workspaces[j], workspaces[i]
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		for _, wh := range workspaces {
+			render.Plain(fmt.Sprintf("Would stop %s", wh.ShortPath()))
+		}
+		return nil
+	}
+
+	ctx := commandContext(cmd)
+	results := make([]upResult, 0, len(workspaces))
+	for _, wh := range workspaces {
+		result := upResult{Workspace: wh.ShortPath(), Image: wh.Workspace.ImageName}
+		if ctx.Err() != nil {
+			result.Status = upStatusCancelled
+		} else {
+			result.Err = downStopWorkspace(ctx, ds, wh)
+			result.Status = upStatusStopped
+			if result.Err != nil {
+				result.Status = upStatusFailed
+			}
+		}
+		results = append(results, result)
+	}
+
+	render.Blank()
+	return renderUpResults(results, false)
+}
+
+// resolveUpWorkspaces returns the workspaces in the scope given by the
+// hierarchy flags, or of the active app, sorted by path.
+func resolveUpWorkspaces(cmd *cobra.Command, ds db.DataStore) ([]*models.WorkspaceWithHierarchy, error) {
+	var flags HierarchyFlags
+	flags.Ecosystem, _ = cmd.Flags().GetString("ecosystem")
+	flags.Domain, _ = cmd.Flags().GetString("domain")
+	flags.System, _ = cmd.Flags().GetString("system")
+	flags.App, _ = cmd.Flags().GetString("app")
+	flags.Workspace, _ = cmd.Flags().GetString("workspace")
+
+	if flags.IsEmpty() {
+		app, err := getActiveAppFromContext(ds)
+		if err != nil {
+			return nil, ErrorWithSuggestion("no active app: pick a scope with --app or --domain", SuggestNoActiveApp()...)
+		}
+		flags.App = app
+	}
+
+	filter := flags.ToFilter()
+	workspaces, err := ds.FindWorkspaces(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspaces: %w", err)
+	}
+	if len(workspaces) == 0 {
+		return nil, ErrorWithSuggestion(fmt.Sprintf("no workspaces found (%s)", describeFilter(filter)), SuggestWorkspaceNotFound(flags.Workspace)...)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].FullPath() < workspaces[j].FullPath()
+	})
+	return workspaces, nil
+}
+
+// upWorkspaceDependencies returns, for each workspace, the workspaces in the
+// list that its spec.dependsOn names, resolved within its domain. missing
+// describes dependencies that are not in the list.
+func upWorkspaceDependencies(workspaces []*models.WorkspaceWithHierarchy) (deps map[*models.WorkspaceWithHierarchy][]*models.WorkspaceWithHierarchy, missing []string) {
+	key := func(domainID int, app, workspace string) string {
+		return fmt.Sprintf("%d/%s/%s", domainID, app, workspace)
+	}
+	byKey := make(map[string]*models.WorkspaceWithHierarchy, len(workspaces))
+	for _, wh := range workspaces {
+		byKey[key(wh.Domain.ID, wh.App.Name, wh.Workspace.Name)] = wh
+	}
+
+	deps = make(map[*models.WorkspaceWithHierarchy][]*models.WorkspaceWithHierarchy)
+	for _, wh := range workspaces {
+		for _, dep := range wh.Workspace.ToYAML(wh.App.Name, "").Spec.DependsOn {
+			app, name, ok := strings.Cut(dep, "/")
+			if !ok {
+				app, name = wh.App.Name, dep
+			}
+			target, found := byKey[key(wh.Domain.ID, app, name)]
+			if !found {
+				missing = append(missing, fmt.Sprintf("%s depends on %s/%s, which is not in scope and is not started", wh.ShortPath(), app, name))
+				continue
+			}
+			deps[wh] = append(deps[wh], target)
+		}
+	}
+	return deps, missing
+}
+
+// orderUpWorkspaces orders workspaces so each comes after its dependencies,
+// keeping the given order otherwise. It fails when dependencies form a
+// cycle.
+func orderUpWorkspaces(workspaces []*models.WorkspaceWithHierarchy, deps map[*models.WorkspaceWithHierarchy][]*models.WorkspaceWithHierarchy) ([]*models.WorkspaceWithHierarchy, error) {
+	ordered := make([]*models.WorkspaceWithHierarchy, 0, len(workspaces))
+	placed := make(map[*models.WorkspaceWithHierarchy]bool, len(workspaces))
+	remaining := slices.Clone(workspaces)
+	for len(remaining) > 0 {
+		i := slices.IndexFunc(remaining, func(wh *models.WorkspaceWithHierarchy) bool {
+			return !slices.ContainsFunc(deps[wh], func(dep *models.WorkspaceWithHierarchy) bool { return !placed[dep] })
+		})
+		if i < 0 {
+			names := make([]string, len(remaining))
+			for i, wh := range remaining {
+				names[i] = wh.ShortPath()
+			}
+			return nil, fmt.Errorf("spec.dependsOn has a cycle; cannot order %s", strings.Join(names, ", "))
+		}
+		ordered = append(ordered, remaining[i])
+		placed[remaining[i]] = true
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	return ordered, nil
+}
+
+// firstNotRunning returns the first of deps that dvm up did not start, or
+// nil when all are running.
+func firstNotRunning(deps []*models.WorkspaceWithHierarchy, status map[*models.WorkspaceWithHierarchy]string) *models.WorkspaceWithHierarchy {
+	for _, dep := range deps {
+		if status[dep] != upStatusRunning {
+			return dep
+		}
+	}
+	return nil
+}
+
+// workspaceContainerName returns the container name of a workspace.
+func workspaceContainerName(wh *models.WorkspaceWithHierarchy) string {
+	systemName := ""
+	if wh.System != nil {
+		systemName = wh.System.Name
+	}
+	return operators.NewHierarchicalNamingStrategy().GenerateName(
+		wh.Ecosystem.Name, wh.Domain.Name, systemName, wh.App.Name, wh.Workspace.Name)
+}
+
+// renderUpResults prints the status table of dvm up or dvm down, then the
+// errors of failed workspaces. It returns an error when any failed.
+func renderUpResults(results []upResult, withServices bool) error {
+	headers := []string{"WORKSPACE", "IMAGE", "STATUS"}
+	if withServices {
+		headers = []string{"WORKSPACE", "IMAGE", "SERVICES", "STATUS"}
+	}
+	tableData := render.TableData{Headers: headers}
+	counts := map[string]int{}
+	failed := 0
+	for _, r := range results {
+		row := []string{r.Workspace, r.Image, r.Status}
+		if withServices {
+			row = []string{r.Workspace, r.Image, strconv.Itoa(r.Services), r.Status}
+		}
+		tableData.Rows = append(tableData.Rows, row)
+		counts[r.Status]++
+		if r.Status == upStatusFailed {
+			failed++
+		}
+	}
+	if err := render.OutputWith("", tableData, render.Options{Type: render.TypeTable}); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Err != nil {
+			render.Error(fmt.Sprintf("%s: %v", r.Workspace, r.Err))
+		}
+	}
+
+	var summary string
+	for _, status := range []string{upStatusRunning, upStatusStopped, upStatusNotBuilt, upStatusSkipped, upStatusFailed, upStatusCancelled} {
+		if counts[status] == 0 {
+			continue
+		}
+		if summary != "" {
+			summary += ", "
+		}
+		summary += fmt.Sprintf("%d %s", counts[status], status)
+	}
+	if failed > 0 {
+		render.Warning(summary)
+		return fmt.Errorf("%d of %d workspace(s) failed", failed, len(results))
+	}
+	render.Success(summary)
+	return nil
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/render"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupUpTest creates app "test-app" with a built workspace "api" and an
+// unbuilt workspace "web", and replaces the workspace actions of dvm up and
+// dvm down with ones that record the workspaces they were called for.
+func setupUpTest(t *testing.T) (*db.MockDataStore, *[]string) {
+	t.Helper()
+	store, app := setupTestContext()
+	require.NoError(t, store.CreateWorkspace(&models.Workspace{Name: "web", Slug: "web", AppID: app.ID, ImageName: "dvm-web-test-app:pending"}))
+	require.NoError(t, store.CreateWorkspace(&models.Workspace{Name: "api", Slug: "api", AppID: app.ID, ImageName: "dvm-api-test-app:20260101-000000"}))
+
+	var calls []string
+	origBuild, origStart, origStop := upBuildWorkspace, upStartWorkspace, downStopWorkspace
+	t.Cleanup(func() { upBuildWorkspace, upStartWorkspace, downStopWorkspace = origBuild, origStart, origStop })
+	upBuildWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) error {
+		calls = append(calls, "build "+wh.Workspace.Name)
+		wh.Workspace.ImageName = "dvm-" + wh.Workspace.Name + "-test-app:20260102-000000"
+		return nil
+	}
+	upStartWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) (int, error) {
+		calls = append(calls, "start "+wh.Workspace.Name)
+		return 2, nil
+	}
+	downStopWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) error {
+		calls = append(calls, "stop "+wh.Workspace.Name)
+		return nil
+	}
+	return store, &calls
+}
+
+// setDependsOn sets spec.dependsOn of workspace name of app "test-app".
+func setDependsOn(t *testing.T, store *db.MockDataStore, name string, deps ...string) {
+	t.Helper()
+	app, err := store.GetAppByNameGlobal("test-app")
+	require.NoError(t, err)
+	ws, err := store.GetWorkspaceByName(app.ID, name)
+	require.NoError(t, err)
+	b, err := json.Marshal(models.DevBuildConfig{DependsOn: deps})
+	require.NoError(t, err)
+	ws.BuildConfig = sql.NullString{String: string(b), Valid: true}
+	require.NoError(t, store.UpdateWorkspace(ws))
+}
+
+func newUpTestCmd(store db.DataStore, runE func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := &cobra.Command{RunE: runE}
+	AddHierarchyFlags(cmd, &HierarchyFlags{})
+	cmd.Flags().Bool("no-build", false, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.SetContext(context.WithValue(context.Background(), CtxKeyDataStore, store))
+	return cmd
+}
+
+func captureRender(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	origWriter := render.GetWriter()
+	render.SetWriter(&buf)
+	t.Cleanup(func() { render.SetWriter(origWriter) })
+	return &buf
+}
+
+func TestUp_BuildsWhenNeededAndStartsInOrder(t *testing.T) {
+	store, calls := setupUpTest(t)
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, runUp(cmd, nil))
+
+	assert.Equal(t, []string{"start api", "build web", "start web"}, *calls)
+	out := buf.String()
+	assert.Contains(t, out, "SERVICES")
+	assert.Contains(t, out, "dvm-web-test-app:20260102-000000")
+	assert.Contains(t, out, "2 running")
+}
+
+func TestUp_NoBuildSkipsUnbuilt(t *testing.T) {
+	store, calls := setupUpTest(t)
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("domain", "test-domain"))
+	require.NoError(t, cmd.Flags().Set("no-build", "true"))
+	require.NoError(t, runUp(cmd, nil))
+
+	assert.Equal(t, []string{"start api"}, *calls)
+	assert.Contains(t, buf.String(), "1 running, 1 not built")
+}
+
+func TestUp_FailureDoesNotStopOthers(t *testing.T) {
+	store, calls := setupUpTest(t)
+	buf := captureRender(t)
+	upBuildWorkspace = func(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy) error {
+		*calls = append(*calls, "build "+wh.Workspace.Name)
+		return errors.New("Dockerfile not found")
+	}
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	err := runUp(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 workspace(s) failed")
+
+	assert.Equal(t, []string{"start api", "build web"}, *calls)
+	out := buf.String()
+	assert.Contains(t, out, "Dockerfile not found")
+	assert.Contains(t, out, "1 running, 1 failed")
+}
+
+func TestUp_DryRun(t *testing.T) {
+	store, calls := setupUpTest(t)
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, cmd.Flags().Set("dry-run", "true"))
+	require.NoError(t, runUp(cmd, nil))
+
+	assert.Empty(t, *calls)
+	assert.Contains(t, buf.String(), "Would start test-app/api")
+	assert.Contains(t, buf.String(), "Would build and start test-app/web")
+}
+
+func TestUp_UnknownScope(t *testing.T) {
+	store, _ := setupUpTest(t)
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("domain", "missing"))
+	err := runUp(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no workspaces found")
+}
+
+func TestDown_StopsInReverseOrder(t *testing.T) {
+	store, calls := setupUpTest(t)
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runDown)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, runDown(cmd, nil))
+
+	assert.Equal(t, []string{"stop web", "stop api"}, *calls)
+	out := buf.String()
+	assert.NotContains(t, out, "SERVICES")
+	assert.Contains(t, out, "2 stopped")
+}
+
+func TestUp_StartsDependenciesFirst(t *testing.T) {
+	store, calls := setupUpTest(t)
+	setDependsOn(t, store, "api", "web")
+	captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, runUp(cmd, nil))
+	assert.Equal(t, []string{"build web", "start web", "start api"}, *calls)
+
+	*calls = nil
+	down := newUpTestCmd(store, runDown)
+	require.NoError(t, down.Flags().Set("app", "test-app"))
+	require.NoError(t, runDown(down, nil))
+	assert.Equal(t, []string{"stop api", "stop web"}, *calls)
+}
+
+func TestUp_SkipsDependentsOfFailedWorkspaces(t *testing.T) {
+	store, calls := setupUpTest(t)
+	setDependsOn(t, store, "api", "test-app/web")
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, cmd.Flags().Set("no-build", "true"))
+	require.NoError(t, runUp(cmd, nil))
+
+	assert.Empty(t, *calls)
+	out := buf.String()
+	assert.Contains(t, out, "test-app/api: dependency test-app/web is not built")
+	assert.Contains(t, out, "1 not built, 1 skipped")
+}
+
+func TestUp_DependencyCycle(t *testing.T) {
+	store, calls := setupUpTest(t)
+	setDependsOn(t, store, "api", "web")
+	setDependsOn(t, store, "web", "api")
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	err := runUp(cmd, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spec.dependsOn has a cycle; cannot order test-app/api, test-app/web")
+	assert.Empty(t, *calls)
+}
+
+func TestUp_WarnsAboutDependenciesOutOfScope(t *testing.T) {
+	store, calls := setupUpTest(t)
+	setDependsOn(t, store, "api", "other-app/db")
+	buf := captureRender(t)
+
+	cmd := newUpTestCmd(store, runUp)
+	require.NoError(t, cmd.Flags().Set("app", "test-app"))
+	require.NoError(t, runUp(cmd, nil))
+
+	assert.Equal(t, []string{"start api", "build web", "start web"}, *calls)
+	assert.Contains(t, buf.String(), "test-app/api depends on other-app/db, which is not in scope")
+}
@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"devopsmaestro/db"
+	"devopsmaestro/models"
 	"encoding/json"
 	"fmt"
 	"github.com/rmkohlman/MaestroNvim/nvimops/package/library"
 	"github.com/rmkohlman/MaestroSDK/render"
 	terminalpkglib "github.com/rmkohlman/MaestroTerminal/terminalops/package/library"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -211,6 +213,10 @@ Examples:
 			return errSilent
 		}
 
+		if err := checkNotArchived(ds, models.ArchiveKindApp, app.ID, appName); err != nil {
+			return err
+		}
+
 		// Handle --export flag: print export statement and return
 		exportFlag, _ := cmd.Flags().GetBool("export")
 		if exportFlag {
@@ -248,7 +254,12 @@ var useWorkspaceCmd = &cobra.Command{
 	Aliases: []string{"ws"},
 	Short:   "Switch to a workspace",
 	Long: `Set the specified workspace as the active context.
-Requires an active app to be set first (unless clearing with 'none').
+
+A bare name is looked up in the active app first, then in all apps, so a
+name that is unique across the hierarchy works without an active app. A
+path (app/ws, domain/app/ws, or ecosystem/domain/app/ws) names a workspace
+anywhere. Switching to a workspace in another app also switches the active
+app.
 
 Use 'none' as the name to clear the workspace context (keeps app).
 
@@ -256,6 +267,7 @@ Examples:
   dvm use workspace main        # Set active workspace
   dvm use ws main               # Short form
   dvm use workspace dev         # Switch to another workspace
+  dvm use ws prod/payments/api/dev  # Switch to a workspace by path
   dvm use workspace none        # Clear workspace context`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -282,26 +294,27 @@ Examples:
 			return fmt.Errorf("dataStore not initialized: %w", err)
 		}
 
-		// Get active app (DB-backed)
-		appName, err := getActiveAppFromContext(ds)
-		if err != nil {
-			render.Error("No active app set")
-			render.Plain(FormatSuggestions(SuggestNoActiveApp()...))
-			return errSilent
+		// Look a bare name up in the active app, then resolve it (or a
+		// path) across the hierarchy
+		var app *models.App
+		var workspace *models.Workspace
+		if appName, err := getActiveAppFromContext(ds); err == nil && !strings.Contains(workspaceName, "/") {
+			if app, err = ds.GetAppByNameGlobal(appName); err == nil {
+				workspace, _ = ds.GetWorkspaceByName(app.ID, workspaceName)
+			}
 		}
-
-		// Get app to get its ID
-		app, err := ds.GetAppByNameGlobal(appName)
-		if err != nil {
-			return fmt.Errorf("failed to get app: %v", err)
+		if workspace == nil {
+			wh, err := resolveWorkspace(ds, models.WorkspaceFilter{WorkspaceName: workspaceName})
+			if err != nil {
+				return err
+			}
+			app, workspace = wh.App, wh.Workspace
 		}
+		appName := app.Name
+		workspaceName = workspace.Name
 
-		// Verify workspace exists
-		workspace, err := ds.GetWorkspaceByName(app.ID, workspaceName)
-		if err != nil {
-			render.Error(fmt.Sprintf("Workspace '%s' not found in app '%s': %v", workspaceName, appName, err))
-			render.Plain(FormatSuggestions(SuggestWorkspaceNotFound(workspaceName)...))
-			return errSilent
+		if err := checkNotArchived(ds, "workspace", workspace.ID, workspaceName); err != nil {
+			return err
 		}
 
 		// Handle --export flag: print export statement and return
@@ -322,7 +335,10 @@ Examples:
 			return fmt.Errorf("failed to save previous context: %w", err)
 		}
 
-		// Update database context
+		// Update database context; the workspace may be in another app
+		if err := ds.SetActiveApp(&app.ID); err != nil {
+			return fmt.Errorf("failed to set active app: %v", err)
+		}
 		if err := ds.SetActiveWorkspace(&workspace.ID); err != nil {
 			return fmt.Errorf("failed to set active workspace: %v", err)
 		}
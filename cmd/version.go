@@ -51,7 +51,7 @@ Examples:
 		}
 
 		// Structured output for JSON/YAML
-		if outputFormat == "json" || outputFormat == "yaml" {
+		if isStructuredOutput(outputFormat) {
 			info := VersionInfo{
 				Version:   versionDisplay,
 				Commit:    Commit,
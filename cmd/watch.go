@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ansiClearScreen = "\x1b[H\x1b[2J"
+	ansiReverse     = "\x1b[7m"
+	ansiReset       = "\x1b[0m"
+)
+
+// runWatch calls draw every interval until interrupted, clearing the screen
+// between frames. Lines that differ from the previous frame are highlighted.
+// format is the command's -o value; structured formats cannot be watched.
+func runWatch(cmd *cobra.Command, format string, interval time.Duration, draw func() error) error {
+	if isStructuredOutput(format) || format == outputName {
+		return fmt.Errorf("--watch cannot be used with -o %s", format)
+	}
+	if interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s, got %s", interval)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	out := render.GetWriter()
+	highlight := os.Getenv("NO_COLOR") == ""
+	var prev []string
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		frame, err := captureFrame(draw)
+		if err != nil {
+			return err
+		}
+		lines := strings.Split(strings.TrimRight(frame, "\n"), "\n")
+		shown := lines
+		if prev != nil && highlight {
+			shown = highlightChangedLines(prev, lines)
+		}
+		prev = lines
+
+		fmt.Fprint(out, ansiClearScreen)
+		fmt.Fprintln(out, strings.Join(shown, "\n"))
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "Every %s (last: %s) — Ctrl+C to stop\n", interval, time.Now().Format("15:04:05"))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// captureFrame runs draw with render output redirected to a buffer.
+func captureFrame(draw func() error) (string, error) {
+	var buf bytes.Buffer
+	saved := render.GetWriter()
+	render.SetWriter(&buf)
+	defer render.SetWriter(saved)
+	err := draw()
+	return buf.String(), err
+}
+
+// highlightChangedLines returns cur with every line that does not appear in
+// prev shown in reverse video. Repeated lines are matched by count, so a
+// duplicated row is highlighted once per extra copy.
+func highlightChangedLines(prev, cur []string) []string {
+	seen := make(map[string]int, len(prev))
+	for _, line := range prev {
+		seen[line]++
+	}
+	out := make([]string, len(cur))
+	for i, line := range cur {
+		if seen[line] > 0 {
+			seen[line]--
+			out[i] = line
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			out[i] = line
+			continue
+		}
+		// Colored cells end with a reset; restart reverse video after each
+		out[i] = ansiReverse + strings.ReplaceAll(line, ansiReset, ansiReset+ansiReverse) + ansiReset
+	}
+	return out
+}
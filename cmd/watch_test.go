@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightChangedLines(t *testing.T) {
+	prev := []string{"NAME  STATUS", "dev   running", "prod  stopped", ""}
+	cur := []string{"NAME  STATUS", "dev   stopped", "prod  stopped", "", "new   \x1b[32mrunning\x1b[0m"}
+
+	got := highlightChangedLines(prev, cur)
+	assert.Equal(t, []string{
+		"NAME  STATUS",
+		ansiReverse + "dev   stopped" + ansiReset,
+		"prod  stopped",
+		"",
+		ansiReverse + "new   \x1b[32mrunning" + ansiReset + ansiReverse + ansiReset,
+	}, got)
+}
+
+func TestHighlightChangedLines_Duplicates(t *testing.T) {
+	got := highlightChangedLines([]string{"a"}, []string{"a", "a"})
+	assert.Equal(t, []string{"a", ansiReverse + "a" + ansiReset}, got)
+}
+
+func TestCaptureFrame(t *testing.T) {
+	saved := render.GetWriter()
+	frame, err := captureFrame(func() error {
+		return render.Plain("hello")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", frame)
+	assert.Equal(t, saved, render.GetWriter(), "writer must be restored")
+}
+
+func TestRunWatch_RejectsStructuredOutput(t *testing.T) {
+	cmd := &cobra.Command{}
+	for _, format := range []string{"json", "yaml", "name"} {
+		err := runWatch(cmd, format, time.Second, func() error { return nil })
+		require.Error(t, err, format)
+		assert.Contains(t, err.Error(), "--watch cannot be used")
+	}
+
+	err := runWatch(cmd, "", 100*time.Millisecond, func() error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--interval")
+}
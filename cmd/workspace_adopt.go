@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	ws "devopsmaestro/pkg/workspace"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// workspaceAdoptCmd turns an existing container into a workspace.
+var workspaceAdoptCmd = &cobra.Command{
+	Use:   "adopt <container>",
+	Short: "Adopt an existing container as a workspace",
+	Long: `Adopt a container built outside dvm as a workspace of an app.
+
+The container, given by ID or name, is inspected and a workspace is created
+from it: its image, its bind mounts as spec.mounts, and its published TCP
+ports as spec.container.ports. Volumes, tmpfs mounts, and UDP ports have no
+dvm equivalent and are reported as warnings.
+
+The container itself is left as it is. dvm records it as the workspace's
+container, so 'dvm get workspaces' follows whether it is running. 'dvm build'
+gives the workspace a dvm-built image; 'dvm attach' then starts a dvm
+container with the adopted mounts and ports.
+
+Examples:
+  dvm workspace adopt 3f2a9c1b7d4e --app my-app --name legacy
+  dvm workspace adopt legacy-box --name legacy --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorkspaceAdopt,
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceAdoptCmd)
+
+	workspaceAdoptCmd.Flags().StringP("app", "a", "", "App name (defaults to active app)")
+	workspaceAdoptCmd.Flags().String("name", "", "Workspace name (default: the container name)")
+	workspaceAdoptCmd.Flags().Bool("dry-run", false, "Print the workspace that would be created without creating it")
+}
+
+func runWorkspaceAdopt(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	appName, _ := cmd.Flags().GetString("app")
+	name, _ := cmd.Flags().GetString("name")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("DataStore not initialized: %w", err)
+	}
+	if appName == "" {
+		if appName, err = getActiveAppFromContext(ds); err != nil {
+			render.Error("No app specified")
+			render.Plain(FormatSuggestions(SuggestNoActiveApp()...))
+			return errSilent
+		}
+	}
+	app, err := resolveAppByNameScoped(ds, appName)
+	if err != nil {
+		render.Error(fmt.Sprintf("App '%s' not found: %v", appName, err))
+		render.Plain(FormatSuggestions(SuggestAppNotFound(appName)...))
+		return errSilent
+	}
+
+	// The container is inspected on the runtime the app's workspaces use
+	runtime, err := workspaceRuntime(ds, &models.Workspace{AppID: app.ID})
+	if err != nil {
+		return fmt.Errorf("failed to create container runtime: %w", err)
+	}
+	inspector, ok := runtime.(operators.ContainerInspector)
+	if !ok {
+		return fmt.Errorf("adopting containers is not supported on %s", runtime.GetPlatformName())
+	}
+	details, err := inspector.InspectContainer(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = details.Name
+	}
+	if err := ValidateResourceName(name, "workspace"); err != nil {
+		return err
+	}
+	workspaceYAML, warnings, err := adoptedWorkspaceYAML(details, app.Name, name)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		render.WarningToStderr(w)
+	}
+
+	if dryRun {
+		data, err := yaml.Marshal(workspaceYAML)
+		if err != nil {
+			return fmt.Errorf("failed to marshal workspace: %w", err)
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+
+	workspace, err := adoptContainer(ds, app, details, workspaceYAML)
+	if err != nil {
+		return err
+	}
+
+	render.Success(fmt.Sprintf("Adopted container %s as workspace '%s' in app '%s'", shortContainerID(details.ID), name, app.Name))
+	render.Info(fmt.Sprintf("Image:  %s", workspace.ImageName))
+	render.Info(fmt.Sprintf("Status: %s", workspace.Status))
+	render.Blank()
+	render.Info("Next steps:")
+	render.Info("  1. Review the adopted spec:")
+	render.Info(fmt.Sprintf("     dvm get workspace %s -a %s -o yaml", name, app.Name))
+	render.Info("  2. Move it onto a dvm-built image:")
+	render.Info(fmt.Sprintf("     dvm build -a %s -w %s && dvm attach -a %s -w %s", app.Name, name, app.Name, name))
+	return nil
+}
+
+// adoptContainer stores a workspace synthesized from details and records
+// details as its container, with its current status.
+func adoptContainer(ds db.DataStore, app *models.App, details *operators.ContainerDetails, workspaceYAML models.WorkspaceYAML) (*models.Workspace, error) {
+	existing, err := ds.ListWorkspacesByApp(app.ID)
+	if err == nil {
+		for _, w := range existing {
+			if w.Name == workspaceYAML.Metadata.Name {
+				return nil, fmt.Errorf("workspace '%s' already exists in app '%s'", w.Name, app.Name)
+			}
+			if w.ContainerID.Valid && containerIDMatches(w.ContainerID.String, details.ID) {
+				return nil, fmt.Errorf("container %s is already workspace '%s' of app '%s'", shortContainerID(details.ID), w.Name, app.Name)
+			}
+		}
+	}
+
+	workspace := &models.Workspace{AppID: app.ID}
+	workspace.FromYAML(workspaceYAML)
+	workspace.Status = "stopped"
+	if isRunning(details.Status) {
+		workspace.Status = "running"
+	}
+	if err := ws.PrepareDefaults(workspace, ds); err != nil {
+		return nil, fmt.Errorf("failed to prepare workspace defaults: %w", err)
+	}
+	if err := ds.CreateWorkspace(workspace); err != nil {
+		return nil, fmt.Errorf("failed to create workspace: %w", err)
+	}
+	if err := ds.SetWorkspaceContainerID(workspace.ID, details.ID); err != nil {
+		return nil, fmt.Errorf("failed to record container: %w", err)
+	}
+	workspace.ContainerID = sql.NullString{String: details.ID, Valid: true}
+	// The image the container runs is the one image drift compares against
+	if details.ImageID != "" {
+		if err := ds.SetWorkspaceImageDigest(workspace.ID, details.ImageID); err != nil {
+			return nil, fmt.Errorf("failed to record image digest: %w", err)
+		}
+	}
+	return workspace, nil
+}
+
+// adoptedWorkspaceYAML synthesizes the workspace spec of an adopted
+// container: its image, bind mounts, and published TCP ports. Settings dvm
+// can't carry over are returned as warnings.
+func adoptedWorkspaceYAML(details *operators.ContainerDetails, appName, name string) (models.WorkspaceYAML, []string, error) {
+	var warnings []string
+	if details.Image == "" {
+		return models.WorkspaceYAML{}, nil, fmt.Errorf("container %s reports no image", shortContainerID(details.ID))
+	}
+
+	var mounts []models.MountConfig
+	for _, m := range details.Mounts {
+		switch {
+		case m.Type != "bind":
+			warnings = append(warnings, fmt.Sprintf("%s mount %s at %s not adopted: only bind mounts are supported", m.Type, m.Source, m.Destination))
+		case m.Destination == "/workspace":
+			warnings = append(warnings, fmt.Sprintf("bind mount %s at /workspace not adopted: dvm mounts the app there", m.Source))
+		default:
+			mounts = append(mounts, models.MountConfig{Type: "bind", Source: m.Source, Destination: m.Destination, ReadOnly: m.ReadOnly})
+		}
+	}
+
+	var ports []int
+	seen := map[int]bool{}
+	for _, p := range details.Ports {
+		switch {
+		case p.Protocol != "tcp":
+			warnings = append(warnings, fmt.Sprintf("port %d/%s not adopted: only TCP ports are published", p.ContainerPort, p.Protocol))
+		case seen[p.ContainerPort]:
+		default:
+			seen[p.ContainerPort] = true
+			ports = append(ports, p.ContainerPort)
+			if p.HostPort != p.ContainerPort {
+				warnings = append(warnings, fmt.Sprintf("port %d is published on host port %d; dvm publishes it on 127.0.0.1:%d", p.ContainerPort, p.HostPort, p.ContainerPort))
+			}
+		}
+	}
+
+	description := fmt.Sprintf("Adopted from container %s", shortContainerID(details.ID))
+	if details.Name != "" {
+		description = fmt.Sprintf("Adopted from container %s (%s)", details.Name, shortContainerID(details.ID))
+	}
+	workspaceYAML := models.WorkspaceYAML{
+		APIVersion: "devopsmaestro.io/v1",
+		Kind:       "Workspace",
+		Metadata: models.WorkspaceMetadata{
+			Name:        name,
+			App:         appName,
+			Annotations: map[string]string{"description": description},
+		},
+		Spec: models.WorkspaceSpec{
+			Image:     models.ImageConfig{Name: details.Image},
+			Mounts:    mounts,
+			Container: models.ContainerConfig{Ports: ports},
+		},
+	}
+	if err := models.ValidateMounts(mounts); err != nil {
+		return models.WorkspaceYAML{}, nil, fmt.Errorf("container %s: %w", shortContainerID(details.ID), err)
+	}
+	return workspaceYAML, warnings, nil
+}
+
+// shortContainerID abbreviates a container ID for display.
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
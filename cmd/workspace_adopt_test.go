@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAdoptedContainer() *operators.ContainerDetails {
+	return &operators.ContainerDetails{
+		ID:      "3f2a9c1b7d4e5f6a7b8c9d0e",
+		Name:    "legacy-box",
+		Image:   "acme/toolbox:2",
+		ImageID: "sha256:0123abcd",
+		Status:  "running",
+		Mounts: []operators.MountConfig{
+			{Type: "bind", Source: "/home/me/.aws", Destination: "/home/dev/.aws", ReadOnly: true},
+			{Type: "bind", Source: "/home/me/src", Destination: "/workspace"},
+			{Type: "volume", Source: "cache", Destination: "/cache"},
+		},
+		Ports: []operators.PublishedPort{
+			{ContainerPort: 3000, HostPort: 3000, Protocol: "tcp"},
+			{ContainerPort: 5353, HostPort: 5353, Protocol: "udp"},
+			{ContainerPort: 8080, HostPort: 18080, Protocol: "tcp"},
+		},
+	}
+}
+
+func TestAdoptedWorkspaceYAML(t *testing.T) {
+	workspaceYAML, warnings, err := adoptedWorkspaceYAML(testAdoptedContainer(), "api", "legacy")
+	require.NoError(t, err)
+
+	assert.Equal(t, "legacy", workspaceYAML.Metadata.Name)
+	assert.Equal(t, "api", workspaceYAML.Metadata.App)
+	assert.Equal(t, "Adopted from container legacy-box (3f2a9c1b7d4e)", workspaceYAML.Metadata.Annotations["description"])
+	assert.Equal(t, "acme/toolbox:2", workspaceYAML.Spec.Image.Name)
+	assert.Equal(t, []models.MountConfig{
+		{Type: "bind", Source: "/home/me/.aws", Destination: "/home/dev/.aws", ReadOnly: true},
+	}, workspaceYAML.Spec.Mounts)
+	assert.Equal(t, []int{3000, 8080}, workspaceYAML.Spec.Container.Ports)
+
+	require.Len(t, warnings, 4)
+	assert.Contains(t, warnings[0], "not adopted: dvm mounts the app there")
+	assert.Contains(t, warnings[1], "volume mount cache at /cache not adopted")
+	assert.Contains(t, warnings[2], "port 5353/udp not adopted")
+	assert.Contains(t, warnings[3], "port 8080 is published on host port 18080")
+}
+
+func TestAdoptedWorkspaceYAML_NoImage(t *testing.T) {
+	_, _, err := adoptedWorkspaceYAML(&operators.ContainerDetails{ID: "abc"}, "api", "legacy")
+	assert.Error(t, err)
+}
+
+func TestAdoptContainer(t *testing.T) {
+	mockStore := db.NewMockDataStore()
+	app := &models.App{Name: "api", Path: "/src/api"}
+	require.NoError(t, mockStore.CreateApp(app))
+
+	details := testAdoptedContainer()
+	workspaceYAML, _, err := adoptedWorkspaceYAML(details, app.Name, "legacy")
+	require.NoError(t, err)
+
+	workspace, err := adoptContainer(mockStore, app, details, workspaceYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "running", workspace.Status)
+	assert.Equal(t, "acme/toolbox:2", workspace.ImageName)
+
+	stored, err := mockStore.GetWorkspaceByID(workspace.ID)
+	require.NoError(t, err)
+	assert.Equal(t, details.ID, stored.ContainerID.String)
+	assert.Equal(t, []int{3000, 8080}, stored.ToYAML(app.Name, "").Spec.Container.Ports)
+	digests, err := mockStore.ListWorkspaceImageDigests()
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:0123abcd", digests[workspace.ID])
+
+	// The same container can't be adopted twice
+	workspaceYAML.Metadata.Name = "other"
+	_, err = adoptContainer(mockStore, app, details, workspaceYAML)
+	assert.ErrorContains(t, err, "is already workspace 'legacy'")
+}
+
+func TestInspectRecordedContainers(t *testing.T) {
+	runtime := operators.NewMockContainerRuntime()
+	runtime.Containers["3f2a9c1b7d4e5f6a"] = &operators.ContainerDetails{ID: "3f2a9c1b7d4e5f6a", Name: "legacy-box", Status: "running"}
+
+	adopted := makeWS("legacy", "3f2a9c1b7d4e5f6a", "stopped")
+	labeled := makeWS("dev", "0123456789abcdef", "running")
+	gone := makeWS("old", "fedcba9876543210", "running")
+	infos := []operators.WorkspaceInfo{makeInfo("0123456789ab", "dvm-api-dev", "Up 5 minutes")}
+
+	infos = inspectRecordedContainers(t.Context(), runtime, []*models.Workspace{adopted, labeled, gone}, infos)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "3f2a9c1b7d4e", infos[1].ID)
+	assert.Equal(t, 2, runtime.CallCount("InspectContainer"), "the listed container is not inspected")
+
+	applyWorkspaceStatusReconcile([]*models.Workspace{adopted, gone}, infos, nil)
+	assert.Equal(t, "running", adopted.Status)
+	assert.Equal(t, "stopped", gone.Status)
+}
+
+func TestWorkspacePorts(t *testing.T) {
+	assert.Nil(t, workspacePorts(nil, nil))
+	assert.Equal(t, []int{3000, 8080, 5173}, workspacePorts([]int{3000, 8080}, []int{8080, 5173}))
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceDevices(t *testing.T) {
+	devices, gpus, err := workspaceDevices(models.WorkspaceSpec{})
+	require.NoError(t, err)
+	assert.Nil(t, devices)
+	assert.True(t, gpus.IsZero())
+
+	devices, gpus, err = workspaceDevices(models.WorkspaceSpec{
+		Devices: []models.DeviceConfig{{Path: "/dev/fuse"}, {Path: "/dev/video0", ContainerPath: "/dev/cam", Permissions: "r"}},
+		GPUs:    "all",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []operators.DeviceMapping{
+		{HostPath: "/dev/fuse"},
+		{HostPath: "/dev/video0", ContainerPath: "/dev/cam", Permissions: "r"},
+	}, devices)
+	assert.Equal(t, operators.GPURequest{All: true}, gpus)
+
+	_, _, err = workspaceDevices(models.WorkspaceSpec{Devices: []models.DeviceConfig{{Path: "fuse"}}})
+	assert.ErrorContains(t, err, "spec.devices[0].path")
+	_, _, err = workspaceDevices(models.WorkspaceSpec{GPUs: "many"})
+	assert.ErrorContains(t, err, "spec.gpus")
+}
+
+func TestWorkspaceDevices_RuntimeSupport(t *testing.T) {
+	devices, gpus, err := workspaceDevices(models.WorkspaceSpec{Devices: []models.DeviceConfig{{Path: "/dev/fuse"}}, GPUs: "1"})
+	require.NoError(t, err)
+
+	mock := operators.NewMockContainerRuntime()
+	err = operators.DeviceSupportOf(mock).Check(mock.GetPlatformName(), devices, gpus)
+	assert.ErrorContains(t, err, "host devices (spec.devices) are not supported on Mock Platform")
+
+	mock.Passthrough = operators.DeviceSupport{Devices: true, Reason: "no GPUs here"}
+	err = operators.DeviceSupportOf(mock).Check(mock.GetPlatformName(), devices, gpus)
+	assert.EqualError(t, err, `spec.gpus "1" is not supported on Mock Platform: no GPUs here`)
+
+	mock.Passthrough.GPUCount = true
+	assert.NoError(t, operators.DeviceSupportOf(mock).Check(mock.GetPlatformName(), devices, gpus))
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/envresolver"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// resolveWorkspaceEnv resolves the layered spec.env of a workspace (ecosystem
+// < domain < app < workspace) with ((credential:NAME)) references replaced by
+// values from credentials. If the hierarchy cannot be loaded, only the
+// workspace's own env is used.
+func resolveWorkspaceEnv(ds db.DataStore, workspace *models.Workspace, credentials map[string]string) *envresolver.Resolution {
+	layers, err := envresolver.Layers(ds, workspace.ID)
+	if err != nil {
+		slog.Warn("failed to load hierarchy env, using workspace env only", "workspace", workspace.Name, "error", err)
+		layers = []envresolver.Layer{{Level: envresolver.LevelWorkspace, Name: workspace.Name, Env: workspace.GetEnv()}}
+	}
+	return envresolver.Resolve(layers, credentials)
+}
+
+// showEnvResolution prints the resolved spec.env of a workspace with
+// credential values masked. Credentials are only checked to exist, never
+// resolved, so no secret is read.
+func showEnvResolution(ds db.DataStore, app *models.App, workspace *models.Workspace) error {
+	names := map[string]string{}
+	for _, scope := range loadCredentialScopes(ds, app, workspace) {
+		for name := range scope.Credentials {
+			names[name] = envresolver.MaskedValue
+		}
+	}
+	res := resolveWorkspaceEnv(ds, workspace, names)
+
+	render.Blank()
+	render.Info("Environment:")
+	if len(res.Vars) == 0 {
+		render.Plain("  (none)")
+	} else {
+		rows := make([][]string, 0, len(res.Vars))
+		for _, v := range res.Masked() {
+			rows = append(rows, []string{v.Key, v.Value, v.Source})
+		}
+		if err := render.OutputWith("", render.TableData{
+			Headers: []string{"KEY", "VALUE", "SOURCE"},
+			Rows:    rows,
+		}, render.Options{Type: render.TypeTable}); err != nil {
+			return err
+		}
+	}
+	for _, w := range res.Warnings {
+		render.Warning(w)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+)
+
+// workspaceLabelScope returns the place in the hierarchy the labels of a
+// workspace's container, image, and services record.
+func workspaceLabelScope(ds db.DataStore, app *models.App, workspaceName string) operators.LabelScope {
+	f := imageNameFields(ds, app, workspaceName)
+	scope := operators.LabelScope{Ecosystem: f.Ecosystem, Domain: f.Domain, App: f.App, Workspace: f.Workspace}
+	if ds != nil && app.SystemID.Valid {
+		if system, err := ds.GetSystemByID(int(app.SystemID.Int64)); err == nil {
+			scope.System = system.Name
+		}
+	}
+	return scope
+}
+
+// labelScope returns the label scope of this build's image.
+func (bc *buildContext) labelScope() operators.LabelScope {
+	if bc.app == nil {
+		return operators.LabelScope{App: bc.appName, Workspace: bc.workspaceName}
+	}
+	return workspaceLabelScope(bc.ds, bc.app, bc.workspaceName)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"devopsmaestro/config"
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// appNetwork is the network the workspaces of an app (or of a domain) and
+// their services share.
+type appNetwork struct {
+	name        string
+	aliasDomain string // Workspaces are <workspace>.<app> on it; "" without DNS aliases
+}
+
+// appNetworkScope returns the scope of the network the workspace of scope
+// joins under the containerNetworks.scope setting mode: its app, or its
+// domain for "domain" when the app is in one. It returns false for "none".
+func appNetworkScope(scope operators.LabelScope, mode string) (operators.LabelScope, string, bool) {
+	switch strings.ToLower(mode) {
+	case "none":
+		return operators.LabelScope{}, "", false
+	case operators.NetworkScopeDomain:
+		if scope.Domain != "" {
+			return operators.LabelScope{Ecosystem: scope.Ecosystem, Domain: scope.Domain}, operators.NetworkScopeDomain, true
+		}
+	}
+	return operators.LabelScope{
+		Ecosystem: scope.Ecosystem,
+		Domain:    scope.Domain,
+		System:    scope.System,
+		App:       scope.App,
+	}, operators.NetworkScopeApp, true
+}
+
+// workspaceAppNetwork returns the network the workspace of scope joins, or
+// nil when container networks are off. aliases tells whether the runtime
+// gives containers DNS aliases.
+func workspaceAppNetwork(scope operators.LabelScope, aliases bool) *appNetwork {
+	netScope, _, ok := appNetworkScope(scope, config.GetConfig().ContainerNetworks.Scope)
+	if !ok {
+		return nil
+	}
+	network := &appNetwork{name: operators.AppNetworkName(netScope)}
+	if aliases {
+		network.aliasDomain = strings.ToLower(scope.Workspace + "." + scope.App)
+	}
+	return network
+}
+
+// ensureAppNetwork creates the network the workspace of scope joins, unless
+// it exists. It returns nil when container networks are off or the runtime
+// can't manage networks, leaving the workspace on the default network.
+func ensureAppNetwork(ctx context.Context, runtime operators.ContainerRuntime, scope operators.LabelScope) (*appNetwork, error) {
+	manager, ok := runtime.(operators.NetworkManager)
+	if !ok {
+		return nil, nil
+	}
+	// Only Docker gives containers DNS aliases
+	_, aliases := runtime.(*operators.DockerRuntime)
+	network := workspaceAppNetwork(scope, aliases)
+	if network == nil {
+		return nil, nil
+	}
+
+	netScope, kind, _ := appNetworkScope(scope, config.GetConfig().ContainerNetworks.Scope)
+	labels := operators.ResourceLabels(netScope)
+	labels[operators.LabelNetwork] = kind
+	created, err := manager.EnsureNetwork(ctx, network.name, labels)
+	if errors.Is(err, operators.ErrNotImplemented) {
+		slog.Debug("container networks not supported", "platform", runtime.GetPlatformName())
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if created {
+		render.Info(fmt.Sprintf("Created network %s", network.name))
+	}
+	return network, nil
+}
+
+// aliases returns the DNS names of the workspace container on the network.
+func (n *appNetwork) aliases() []string {
+	if n == nil || n.aliasDomain == "" {
+		return nil
+	}
+	return []string{n.aliasDomain}
+}
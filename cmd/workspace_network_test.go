@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"devopsmaestro/operators"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setContainerNetworkScope(t *testing.T, scope string) {
+	t.Helper()
+	viper.Set("containerNetworks.scope", scope)
+	t.Cleanup(func() { viper.Set("containerNetworks.scope", "") })
+}
+
+func TestAppNetworkScope(t *testing.T) {
+	scope := operators.LabelScope{Ecosystem: "acme", Domain: "payments", System: "billing", App: "api", Workspace: "dev"}
+	appScope := operators.LabelScope{Ecosystem: "acme", Domain: "payments", System: "billing", App: "api"}
+
+	for _, mode := range []string{"", "app", "APP", "unknown"} {
+		got, kind, ok := appNetworkScope(scope, mode)
+		assert.True(t, ok, mode)
+		assert.Equal(t, appScope, got, mode)
+		assert.Equal(t, operators.NetworkScopeApp, kind, mode)
+	}
+
+	got, kind, ok := appNetworkScope(scope, "domain")
+	assert.True(t, ok)
+	assert.Equal(t, operators.LabelScope{Ecosystem: "acme", Domain: "payments"}, got)
+	assert.Equal(t, operators.NetworkScopeDomain, kind)
+
+	// An app outside any domain gets a network of its own
+	got, kind, ok = appNetworkScope(operators.LabelScope{Ecosystem: "acme", App: "api"}, "domain")
+	assert.True(t, ok)
+	assert.Equal(t, operators.LabelScope{Ecosystem: "acme", App: "api"}, got)
+	assert.Equal(t, operators.NetworkScopeApp, kind)
+
+	_, _, ok = appNetworkScope(scope, "none")
+	assert.False(t, ok)
+}
+
+func TestEnsureAppNetwork(t *testing.T) {
+	ctx := context.Background()
+	scope := operators.LabelScope{Ecosystem: "acme", Domain: "payments", App: "API", Workspace: "Dev"}
+
+	t.Run("app", func(t *testing.T) {
+		mock := operators.NewMockContainerRuntime()
+		network, err := ensureAppNetwork(ctx, mock, scope)
+		require.NoError(t, err)
+		require.NotNil(t, network)
+		assert.Equal(t, "dvm-net-acme-payments-api", network.name)
+		assert.Empty(t, network.aliases(), "only Docker gives containers aliases")
+
+		created := mock.Networks["dvm-net-acme-payments-api"]
+		require.NotNil(t, created)
+		assert.Equal(t, "true", created.Labels[operators.LabelManaged])
+		assert.Equal(t, "API", created.Labels[operators.LabelApp])
+		assert.Equal(t, operators.NetworkScopeApp, created.Labels[operators.LabelNetwork])
+		assert.NotContains(t, created.Labels, operators.LabelWorkspace)
+
+		// A second workspace of the app joins the same network
+		again, err := ensureAppNetwork(ctx, mock, operators.LabelScope{Ecosystem: "acme", Domain: "payments", App: "api", Workspace: "staging"})
+		require.NoError(t, err)
+		assert.Equal(t, network.name, again.name)
+		assert.Len(t, mock.Networks, 1)
+	})
+
+	t.Run("domain", func(t *testing.T) {
+		setContainerNetworkScope(t, "domain")
+		mock := operators.NewMockContainerRuntime()
+		network, err := ensureAppNetwork(ctx, mock, scope)
+		require.NoError(t, err)
+		assert.Equal(t, "dvm-net-acme-payments", network.name)
+		created := mock.Networks["dvm-net-acme-payments"]
+		assert.Equal(t, operators.NetworkScopeDomain, created.Labels[operators.LabelNetwork])
+		assert.NotContains(t, created.Labels, operators.LabelApp)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		setContainerNetworkScope(t, "none")
+		mock := operators.NewMockContainerRuntime()
+		network, err := ensureAppNetwork(ctx, mock, scope)
+		require.NoError(t, err)
+		assert.Nil(t, network)
+		assert.Empty(t, mock.Networks)
+		assert.Nil(t, network.aliases())
+	})
+}
+
+func TestWorkspaceAppNetwork_Aliases(t *testing.T) {
+	network := workspaceAppNetwork(operators.LabelScope{App: "API", Workspace: "Dev"}, true)
+	require.NotNil(t, network)
+	assert.Equal(t, "dvm-net-api", network.name)
+	assert.Equal(t, "dev.api", network.aliasDomain)
+	assert.Equal(t, []string{"dev.api"}, network.aliases())
+}
+
+func TestGCNetworkItems(t *testing.T) {
+	ctx := context.Background()
+	mock := operators.NewMockContainerRuntime()
+	managed := map[string]string{operators.LabelManaged: "true"}
+	mock.Networks["dvm-net-api"] = &operators.NetworkInfo{Name: "dvm-net-api", Labels: managed, Containers: 2}
+	mock.Networks["dvm-net-old"] = &operators.NetworkInfo{Name: "dvm-net-old", Labels: managed}
+	mock.Networks["bridge"] = &operators.NetworkInfo{Name: "bridge"}
+
+	items, err := gcNetworkItems(ctx, mock)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "network", items[0].Kind)
+	assert.Equal(t, "dvm-net-old", items[0].Name)
+	assert.Equal(t, "no containers", items[0].Reason)
+
+	_, err = items[0].remove(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, mock.Networks, "dvm-net-old")
+	assert.Contains(t, mock.Networks, "bridge")
+}
+
+func TestNetworkTable(t *testing.T) {
+	table := networkTable([]operators.NetworkInfo{
+		{Name: "dvm-net-acme-api", Containers: 3, Labels: map[string]string{
+			operators.LabelNetwork:   operators.NetworkScopeApp,
+			operators.LabelEcosystem: "acme",
+			operators.LabelApp:       "api",
+		}},
+		{Name: "dvm-api-dev_default", Labels: map[string]string{
+			operators.LabelApp:       "api",
+			operators.LabelWorkspace: "dev",
+		}},
+	})
+	assert.Equal(t, []string{"NAME", "SCOPE", "ECOSYSTEM", "DOMAIN", "APP", "CONTAINERS"}, table.Headers)
+	assert.Equal(t, [][]string{
+		{"dvm-net-acme-api", "app", "acme", "-", "api", "3"},
+		{"dvm-api-dev_default", "workspace", "-", "-", "api/dev", "0"},
+	}, table.Rows)
+}
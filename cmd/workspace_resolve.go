@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/resolver"
+)
+
+// resolveWorkspace finds the one workspace a command operates on.
+//
+// The workspace name in filter may be a path (app/ws, domain/app/ws,
+// eco/domain/app/ws, or eco/domain/system/app/ws) that fills in the rest of
+// the filter. A bare name is first looked up in the active app, then
+// everywhere, with ecosystem/domain/app inference, so a name that is unique
+// across the hierarchy is enough.
+//
+// When several workspaces match, the user picks one from a numbered list;
+// without a terminal, or with --yes or --non-interactive, the error lists
+// the matches instead.
+func resolveWorkspace(ds db.DataStore, filter models.WorkspaceFilter) (*models.WorkspaceWithHierarchy, error) {
+	filter, err := resolver.ExpandWorkspaceRef(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.AppName == "" && filter.WorkspaceName != "" &&
+		filter.EcosystemName == "" && filter.DomainName == "" && filter.SystemName == "" {
+		// Prefer the active app's workspace of that name
+		if appName, err := getActiveAppFromContext(ds); err == nil {
+			scoped := filter
+			scoped.AppName = appName
+			if wh, err := resolver.NewWorkspaceResolver(ds).Resolve(scoped); err == nil {
+				return wh, nil
+			}
+		}
+	}
+
+	wh, err := resolver.NewInferenceResolver(ds).ResolveWithInference(filter)
+	if err == nil {
+		return wh, nil
+	}
+	if ambiguousErr, ok := resolver.IsAmbiguousError(err); ok {
+		return chooseWorkspace(filter, ambiguousErr.Matches)
+	}
+	if resolver.IsNoWorkspaceFoundError(err) {
+		return nil, fmt.Errorf("%w (%s)\n\n%s", resolver.ErrNoWorkspaceFound, describeFilter(filter),
+			FormatSuggestions(SuggestWorkspaceNotFound(filter.WorkspaceName)...))
+	}
+	return nil, fmt.Errorf("failed to resolve workspace: %w", err)
+}
+
+// chooseWorkspace asks the user which of several matching workspaces they
+// meant, or returns an error listing them when prompting is disabled.
+func chooseWorkspace(filter models.WorkspaceFilter, matches []*models.WorkspaceWithHierarchy) (*models.WorkspaceWithHierarchy, error) {
+	paths := make([]string, len(matches))
+	for i, wh := range matches {
+		paths[i] = wh.FullPath()
+	}
+
+	i, err := interactive.Choose(fmt.Sprintf("Multiple workspaces match %s:", describeFilter(filter)), paths)
+	if err == nil {
+		return matches[i], nil
+	}
+	if !errors.Is(err, interactive.ErrNonInteractive) {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "ambiguous workspace selection: %d workspaces match %s:\n", len(matches), describeFilter(filter))
+	for _, p := range paths {
+		b.WriteString("\n  " + p)
+	}
+	return nil, ErrorWithSuggestion(b.String(), SuggestAmbiguousWorkspace()...)
+}
+
+// describeFilter formats a workspace filter for error messages.
+func describeFilter(f models.WorkspaceFilter) string {
+	desc := ""
+	add := func(label, value string) {
+		if value == "" {
+			return
+		}
+		if desc != "" {
+			desc += ", "
+		}
+		desc += label + "=" + value
+	}
+	add("ecosystem", f.EcosystemName)
+	add("domain", f.DomainName)
+	add("system", f.SystemName)
+	add("app", f.AppName)
+	add("workspace", f.WorkspaceName)
+	return desc
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveWorkspace_Paths(t *testing.T) {
+	t.Setenv("DVM_APP", "")
+	t.Setenv("DVM_WORKSPACE", "")
+
+	for _, ref := range []string{"web/dev", "dom/web/dev", "eco/dom/web/dev"} {
+		t.Run(ref, func(t *testing.T) {
+			wh, err := resolveWorkspace(newSessionMock(t), models.WorkspaceFilter{WorkspaceName: ref})
+			require.NoError(t, err)
+			assert.Equal(t, 3, wh.Workspace.ID)
+		})
+	}
+
+	t.Run("path conflicts with --app", func(t *testing.T) {
+		_, err := resolveWorkspace(newSessionMock(t), models.WorkspaceFilter{AppName: "api", WorkspaceName: "web/dev"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--app")
+	})
+
+	t.Run("path through resolveSessionWorkspace", func(t *testing.T) {
+		wh, err := resolveSessionWorkspace(newSessionMock(t), HierarchyFlags{}, "api/dev")
+		require.NoError(t, err)
+		assert.Equal(t, 1, wh.Workspace.ID)
+	})
+}
+
+func TestResolveWorkspace_AmbiguousListsMatches(t *testing.T) {
+	t.Setenv("DVM_APP", "")
+	t.Setenv("DVM_WORKSPACE", "")
+
+	// Tests have no terminal, so the matches are listed instead of prompted
+	_, err := resolveWorkspace(newSessionMock(t), models.WorkspaceFilter{WorkspaceName: "dev"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ambiguous workspace selection: 2 workspaces match workspace=dev")
+	assert.Contains(t, err.Error(), "eco/dom/api/dev")
+	assert.Contains(t, err.Error(), "eco/dom/web/dev")
+}
+
+func TestUseWorkspace_PathSwitchesApp(t *testing.T) {
+	t.Setenv("DVM_APP", "")
+	t.Setenv("DVM_WORKSPACE", "")
+	mock := newSessionMock(t)
+	mock.Context = &models.Context{ID: 1, ActiveAppID: intPtr(1)}
+
+	useWorkspaceCmd.SetContext(newCmdContextWithDS(mock))
+	require.NoError(t, useWorkspaceCmd.RunE(useWorkspaceCmd, []string{"web/dev"}))
+
+	require.NotNil(t, mock.Context.ActiveAppID)
+	assert.Equal(t, 2, *mock.Context.ActiveAppID)
+	require.NotNil(t, mock.Context.ActiveWorkspaceID)
+	assert.Equal(t, 3, *mock.Context.ActiveWorkspaceID)
+}
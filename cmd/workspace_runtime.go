@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// isKubernetesWorkspace reports whether a workspace runs on Kubernetes
+// (spec.runtime: kubernetes).
+func isKubernetesWorkspace(workspace *models.Workspace) bool {
+	return workspace.ToYAML("", "").Spec.Runtime == models.WorkspaceRuntimeKubernetes
+}
+
+// workspaceRuntime returns the runtime a workspace runs on: a Kubernetes
+// runtime for spec.runtime: kubernetes, the remote runtime of its ecosystem's
+// runtime endpoint, otherwise the local container runtime.
+func workspaceRuntime(ds db.DataStore, workspace *models.Workspace) (operators.ContainerRuntime, error) {
+	spec := workspace.ToYAML("", "").Spec
+	if spec.Runtime != models.WorkspaceRuntimeKubernetes {
+		endpoint, err := workspaceEndpoint(ds, workspace)
+		if err != nil {
+			return nil, err
+		}
+		if endpoint == nil {
+			return operators.NewContainerRuntime()
+		}
+		platform, err := operators.NewRemotePlatform(endpoint.Host, endpoint.Type)
+		if err != nil {
+			return nil, err
+		}
+		return operators.NewRemoteRuntime(platform)
+	}
+	runtime, err := operators.NewKubernetesRuntime(operators.KubernetesOptions{
+		Context:      spec.Kubernetes.Context,
+		Namespace:    spec.Kubernetes.Namespace,
+		StorageClass: spec.Kubernetes.StorageClass,
+		StorageSize:  spec.Kubernetes.StorageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runtime, nil
+}
+
+// pushWorkspaceImage pushes a locally built workspace image to the
+// workspace's spec.kubernetes.imageRegistry and returns the pushed name. The
+// image is returned unchanged when no registry is configured; the cluster
+// must then be able to pull it as is.
+func pushWorkspaceImage(ctx context.Context, workspace *models.Workspace, imageName string) (string, error) {
+	registry := strings.TrimSuffix(workspace.ToYAML("", "").Spec.Kubernetes.ImageRegistry, "/")
+	if registry == "" {
+		return imageName, nil
+	}
+	platform, err := detectPlatform()
+	if err != nil {
+		return "", err
+	}
+	target := registry + "/" + imageName
+	render.Progress(fmt.Sprintf("Pushing %s...", target))
+	if err := operators.PushImage(ctx, platform, imageName, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	ws "devopsmaestro/pkg/workspace"
+	"devopsmaestro/pkg/workspace/services"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// planWorkspaceServices resolves the workspace's spec.services and writes the
+// Compose file for its inline services, the labels of scope, and network, if
+// any. It returns nil when the workspace has no services.
+func planWorkspaceServices(workspace *models.Workspace, appPath string, scope operators.LabelScope, network *appNetwork) (*services.Stack, error) {
+	cfg := workspace.ToYAML("", "").Spec.Services
+	stack, err := services.Plan(cfg, appPath, workspace.Slug)
+	if err != nil || stack == nil {
+		return nil, err
+	}
+	stack.Labels = operators.ResourceLabels(scope)
+	if network != nil {
+		stack.AppNetwork, stack.AliasDomain = network.name, network.aliasDomain
+	}
+	configDir, err := ws.GetWorkspaceConfigPath(workspace.Slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace config path: %w", err)
+	}
+	if err := stack.WriteInline(configDir); err != nil {
+		return nil, err
+	}
+	return stack, nil
+}
+
+// startWorkspaceServices brings up the workspace's services, on network when
+// set. It returns nil when the workspace has no services.
+func startWorkspaceServices(ctx context.Context, workspace *models.Workspace, appPath string, scope operators.LabelScope, network *appNetwork) (*services.Stack, error) {
+	stack, err := planWorkspaceServices(workspace, appPath, scope, network)
+	if err != nil || stack == nil {
+		return nil, err
+	}
+	platform, err := detectPlatform()
+	if err != nil {
+		return nil, err
+	}
+
+	render.Progress(fmt.Sprintf("Starting %d service(s)...", len(stack.Services)))
+	project := operators.NewComposeProject(platform, stack.Project, stack.ComposeFiles)
+	if err := project.Up(ctx, stack.Only...); err != nil {
+		return nil, fmt.Errorf("failed to start services: %w", err)
+	}
+	slog.Info("workspace services started", "project", stack.Project, "services", len(stack.Services))
+	return stack, nil
+}
+
+// stopWorkspaceServices tears down the workspace's services, if it has any.
+func stopWorkspaceServices(ctx context.Context, ds db.DataStore, workspace *models.Workspace, appPath string) error {
+	if isKubernetesWorkspace(workspace) {
+		return nil // services are not started for Kubernetes workspaces
+	}
+	if endpoint, err := workspaceEndpoint(ds, workspace); err != nil {
+		return err
+	} else if endpoint != nil {
+		return nil // nor on runtime endpoints
+	}
+	mountPath, err := getMountPath(ds, workspace, appPath)
+	if err != nil {
+		return fmt.Errorf("failed to get mount path: %w", err)
+	}
+	var scope operators.LabelScope
+	if app, err := ds.GetAppByID(workspace.AppID); err == nil && app != nil {
+		scope = workspaceLabelScope(ds, app, workspace.Name)
+	}
+	// The app network outlives the project; naming it keeps Compose off it
+	stack, err := planWorkspaceServices(workspace, mountPath, scope, workspaceAppNetwork(scope, false))
+	if err != nil || stack == nil {
+		return err
+	}
+	platform, err := detectPlatform()
+	if err != nil {
+		return err
+	}
+
+	render.Progress(fmt.Sprintf("Stopping services of workspace '%s'...", workspace.Name))
+	project := operators.NewComposeProject(platform, stack.Project, stack.ComposeFiles)
+	if err := project.Down(ctx); err != nil {
+		return fmt.Errorf("failed to stop services: %w", err)
+	}
+	slog.Info("workspace services stopped", "project", stack.Project)
+	return nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// resolveSessionWorkspace finds the workspace for dvm exec / dvm shell and
+// the other commands that take an optional workspace argument.
+//
+// An optional positional workspace name or path is merged into the
+// hierarchy flags and resolved with resolveWorkspace. With neither, the
+// active context is used.
+func resolveSessionWorkspace(ds db.DataStore, flags HierarchyFlags, name string) (*models.WorkspaceWithHierarchy, error) {
+	if name != "" {
+		if flags.Workspace != "" && flags.Workspace != name {
+			return nil, fmt.Errorf("workspace given both as argument (%q) and --workspace (%q)", name, flags.Workspace)
+		}
+		flags.Workspace = name
+	}
+
+	filter := flags.ToFilter()
+	if filter.IsEmpty() {
+		appName, err := getActiveAppFromContext(ds)
+		if err != nil {
+			return nil, err
+		}
+		workspaceName, err := getActiveWorkspaceFromContext(ds)
+		if err != nil {
+			return nil, err
+		}
+		filter = models.WorkspaceFilter{AppName: appName, WorkspaceName: workspaceName}
+	}
+	return resolveWorkspace(ds, filter)
+}
+
+// runWorkspaceSession runs an interactive shell (command empty) or a command
+// in a running workspace container, with the same environment as dvm attach,
+// and records the attach time for dvm get workspaces.
+func runWorkspaceSession(ctx context.Context, ds db.DataStore, wh *models.WorkspaceWithHierarchy, command []string, workDir string) error {
+	workspace := wh.Workspace
+	app := wh.App
+	ecosystemName, domainName, systemName := "", "", ""
+	if wh.Ecosystem != nil {
+		ecosystemName = wh.Ecosystem.Name
+	}
+	if wh.Domain != nil {
+		domainName = wh.Domain.Name
+	}
+	if wh.System != nil {
+		systemName = wh.System.Name
+	}
+
+	runtime, err := workspaceRuntime(ds, workspace)
+	if err != nil {
+		return fmt.Errorf("failed to create container runtime: %w", err)
+	}
+
+	containerName := operators.NewHierarchicalNamingStrategy().GenerateName(ecosystemName, domainName, systemName, app.Name, workspace.Name)
+	status, err := runtime.GetWorkspaceStatus(ctx, containerName)
+	if err != nil || status != "running" {
+		slog.Debug("workspace container not running", "container", containerName, "status", status, "error", err)
+		return ErrorWithSuggestion(
+			fmt.Sprintf("workspace %q is not running", wh.FullPath()),
+			"Start it with: dvm attach -a "+app.Name+" -w "+workspace.Name,
+		)
+	}
+
+	workspaceYAML := workspace.ToYAML(app.Name, "")
+
+	themeEnv := map[string]string{}
+	if themeName := getThemeName(workspace); themeName != "" {
+		if te, err := loadThemeEnvVars(themeName); err == nil {
+			themeEnv = te
+		}
+	}
+	registryEnv, _ := loadRegistryEnv(ds)
+	credentialEnv, credWarnings := loadBuildCredentials(ds, app, workspace)
+	for _, w := range credWarnings {
+		render.WarningfToStderr("%s", w)
+	}
+	resolvedEnv := resolveWorkspaceEnv(ds, workspace, credentialEnv)
+	for _, w := range resolvedEnv.Warnings {
+		render.WarningfToStderr("%s", w)
+	}
+	envVars := buildRuntimeEnv(app.Name, workspace.Name, ecosystemName, domainName, systemName, themeEnv, registryEnv, credentialEnv, resolvedEnv.Env())
+
+	opts := operators.AttachOptions{
+		WorkspaceID: containerName,
+		Env:         envVars,
+		Shell:       "/bin/zsh",
+		LoginShell:  true,
+		UID:         workspaceYAML.Spec.Container.UID,
+		GID:         workspaceYAML.Spec.Container.GID,
+		Command:     command,
+		WorkingDir:  workDir,
+	}
+
+	recordWorkspaceAttach(ds, workspace.ID)
+
+	slog.Info("starting workspace session", "container", containerName, "command", command)
+	if len(command) == 0 {
+		fmt.Fprintf(os.Stderr, "\x1b]0;[dvm] %s/%s\x07", app.Name, workspace.Name)
+		defer fmt.Fprintf(os.Stderr, "\x1b]0;\x07")
+	}
+	return runtime.AttachToWorkspace(ctx, opts)
+}
+
+// recordWorkspaceAttach stores the attach time; failures only affect the
+// LAST-ATTACHED display, so they are logged rather than returned.
+func recordWorkspaceAttach(ds db.DataStore, workspaceID int) {
+	if err := ds.RecordWorkspaceAttach(workspaceID); err != nil {
+		slog.Warn("failed to record workspace attach", "workspace_id", workspaceID, "error", err)
+	}
+}
+
+// formatLastAttached formats a last-attached time for table output.
+func formatLastAttached(lastAttached map[int]time.Time, workspaceID int) string {
+	at, ok := lastAttached[workspaceID]
+	if !ok {
+		return "never"
+	}
+	return formatDuration(time.Since(at)) + " ago"
+}
+
+// loadLastAttached returns workspace attach times for wide output. Errors
+// (e.g. a database without the column yet) just leave the column as "never".
+func loadLastAttached(ds db.DataStore, wide bool) map[int]time.Time {
+	if !wide {
+		return nil
+	}
+	lastAttached, err := ds.ListWorkspaceLastAttached()
+	if err != nil {
+		slog.Debug("failed to load workspace attach times", "error", err)
+	}
+	return lastAttached
+}
@@ -0,0 +1,430 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/hookrunner"
+	"devopsmaestro/pkg/workspace/filesync"
+	"devopsmaestro/pkg/workspace/services"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// workspaceStartOptions holds the container settings for starting a
+// workspace. The hierarchy names go into the container name and env.
+type workspaceStartOptions struct {
+	EcosystemName string
+	DomainName    string
+	SystemName    string
+	NetworkMode   string
+	CPUs          float64
+	Memory        string
+	Ports         []int
+}
+
+// startedWorkspace is a running workspace container, as needed to attach to it.
+type startedWorkspace struct {
+	runtime       operators.ContainerRuntime
+	containerName string
+	env           map[string]string
+	uid, gid      int
+	remote        *remoteWorkspace  // Set when it runs on a runtime endpoint
+	sync          *filesync.Session // Set with spec.sync; the caller watches it
+	services      int               // Number of services started for it
+	ports         []int             // Container ports published on localhost
+}
+
+// startWorkspaceContainer brings up a built workspace without attaching to
+// it: its services, then its container, running the preStart, postSync, and
+// postStart hooks around them. dvm attach and dvm up share it.
+func startWorkspaceContainer(ctx context.Context, ds db.DataStore, app *models.App, workspace *models.Workspace, opts workspaceStartOptions) (*startedWorkspace, error) {
+	// Start the Colima VM of a local workspace if needed, and check it fits the limits
+	if !isKubernetesWorkspace(workspace) {
+		if endpoint, err := workspaceEndpoint(ds, workspace); err == nil && endpoint == nil {
+			memory, _ := operators.ParseMemoryString(opts.Memory)
+			if err := ensureColimaVM(ctx, os.Stdout, operators.VMRequirements{CPUs: opts.CPUs, MemoryBytes: memory}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Create the workspace's container runtime (local, remote endpoint, or Kubernetes)
+	runtime, err := workspaceRuntime(ds, workspace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container runtime: %w", err)
+	}
+
+	slog.Info("using runtime", "type", runtime.GetRuntimeType(), "platform", runtime.GetPlatformName())
+	render.Info(fmt.Sprintf("Platform: %s", runtime.GetPlatformName()))
+
+	// Use image name from workspace
+	imageName := workspace.ImageName
+
+	// Check if workspace has been built
+	if !workspaceImageBuilt(workspace) {
+		slog.Warn("workspace image may not be built", "image", imageName)
+		render.Warning(fmt.Sprintf("Workspace image '%s' has not been built yet.", imageName))
+		render.Plain(FormatSuggestions(SuggestWorkspaceNotBuilt()...))
+		render.Blank()
+		return nil, fmt.Errorf("workspace not built: run 'dvm build' first")
+	}
+
+	// Kubernetes pulls the image from a registry
+	kubernetes := isKubernetesWorkspace(workspace)
+	if kubernetes {
+		if imageName, err = pushWorkspaceImage(ctx, workspace, imageName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Runtime endpoints run the workspace on a remote host
+	var endpoint *models.RuntimeEndpoint
+	if !kubernetes {
+		if endpoint, err = workspaceEndpoint(ds, workspace); err != nil {
+			return nil, err
+		}
+	}
+
+	// Compute container name using hierarchical naming strategy
+	namingStrategy := operators.NewHierarchicalNamingStrategy()
+	containerName := namingStrategy.GenerateName(opts.EcosystemName, opts.DomainName, opts.SystemName, app.Name, workspace.Name)
+	slog.Debug("container details", "name", containerName, "image", imageName)
+
+	// Start workspace (handles existing containers automatically)
+	render.Progress("Starting workspace container...")
+
+	// Get correct mount path (workspace repo path if GitRepoID set, else app.Path)
+	mountPath, err := getMountPath(ds, workspace, app.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mount path: %w", err)
+	}
+	hostDir := mountPath
+
+	// Sync the files to the remote host and mount them from there
+	var remote *remoteWorkspace
+	if endpoint != nil {
+		if remote, err = newRemoteWorkspace(ctx, endpoint, workspace, mountPath); err != nil {
+			return nil, err
+		}
+		if err := remote.push(ctx); err != nil {
+			return nil, fmt.Errorf("failed to sync files: %w", err)
+		}
+		mountPath = remote.remoteDir
+	}
+
+	// Mount bare git repos into container and rewrite git remote to local path (#379)
+	var extraMounts []operators.MountConfig
+	if workspace.GitRepoID.Valid && remote != nil {
+		render.Warning("Git mirror mounts are not available on remote hosts; the synced repo keeps its remote")
+	} else if workspace.GitRepoID.Valid {
+		gitRepo, err := ds.GetGitRepoByID(workspace.GitRepoID.Int64)
+		if err == nil && gitRepo != nil {
+			mounts, rewriteErr := setupGitMirrorMounts(gitRepo.Slug, mountPath)
+			if rewriteErr != nil {
+				slog.Warn("failed to setup git mirror mounts", "error", rewriteErr)
+			} else {
+				extraMounts = mounts
+			}
+		}
+	}
+
+	// Get workspace container config for UID/GID
+	workspaceYAML := workspace.ToYAML(app.Name, "")
+	containerUID := workspaceYAML.Spec.Container.UID
+	containerGID := workspaceYAML.Spec.Container.GID
+
+	// With spec.sync the files are copied into the container instead of bind mounted
+	syncFiles := workspaceYAML.Spec.Sync.Enabled()
+	if syncFiles && (kubernetes || remote != nil) {
+		render.Warning("Ignoring spec.sync: Kubernetes workspaces and remote hosts already copy the files")
+		syncFiles = false
+	}
+	appPath := mountPath
+	if syncFiles {
+		appPath = ""
+	}
+
+	// Validate container options (network mode and resource limits)
+	if err := operators.ValidateNetworkMode(opts.NetworkMode); err != nil {
+		return nil, err
+	}
+	if err := operators.ValidateCPUs(opts.CPUs); err != nil {
+		return nil, err
+	}
+	if opts.Memory != "" {
+		if _, err := operators.ParseMemoryString(opts.Memory); err != nil {
+			return nil, err
+		}
+	}
+	ports := workspacePorts(workspaceYAML.Spec.Container.Ports, opts.Ports)
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %d: must be between 1 and 65535", port)
+		}
+	}
+
+	// Devices and GPUs fail before anything starts when the runtime can't
+	// provide them
+	devices, gpus, err := workspaceDevices(workspaceYAML.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := operators.DeviceSupportOf(runtime).Check(runtime.GetPlatformName(), devices, gpus); err != nil {
+		return nil, fmt.Errorf("workspace '%s': %w", workspace.Name, err)
+	}
+
+	// Host credentials stay on this machine
+	sshAgentForwarding, gitCredentialMounting := workspace.SSHAgentForwarding, workspace.GitCredentialMounting
+	if remote != nil {
+		if sshAgentForwarding {
+			render.Warning("SSH agent forwarding is not available on remote hosts")
+		}
+		if gitCredentialMounting {
+			render.Warning("Git credential mounting is not available on remote hosts")
+		}
+		sshAgentForwarding, gitCredentialMounting = false, false
+	}
+
+	// spec.mounts bind host paths, so they only apply to local containers
+	if mounts := workspaceYAML.Spec.Mounts; len(mounts) > 0 {
+		if kubernetes || remote != nil {
+			render.Warning("Ignoring spec.mounts: host paths are not available to Kubernetes workspaces or remote hosts")
+		} else {
+			extraMounts = append(extraMounts, workspaceSpecMounts(mounts, app.Path)...)
+		}
+	}
+
+	scope := operators.LabelScope{
+		Ecosystem: opts.EcosystemName,
+		Domain:    opts.DomainName,
+		System:    opts.SystemName,
+		App:       app.Name,
+		Workspace: workspace.Name,
+	}
+
+	// Local workspaces join their app's network, created on demand, unless
+	// --network picks another
+	var network *appNetwork
+	if !kubernetes && remote == nil && opts.NetworkMode == "" {
+		if network, err = ensureAppNetwork(ctx, runtime, scope); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bring up the workspace's services; the container joins their network
+	var stack *services.Stack
+	if kubernetes || remote != nil {
+		if !workspaceYAML.Spec.Services.IsZero() {
+			render.Warning("Workspace services are not started for Kubernetes workspaces or on remote hosts")
+		}
+	} else if stack, err = startWorkspaceServices(ctx, workspace, mountPath, scope, network); err != nil {
+		return nil, err
+	}
+	networkMode := opts.NetworkMode
+	if network != nil {
+		networkMode = network.name
+	}
+	var serviceEnv map[string]string
+	if stack != nil {
+		serviceEnv = stack.Env()
+		if networkMode == "" {
+			networkMode = stack.Network()
+		} else if networkMode != stack.Network() {
+			render.Warning(fmt.Sprintf("Using --network %s: services on network '%s' may be unreachable", networkMode, stack.Network()))
+		}
+	}
+
+	// A container created for the other mode is re-created
+	if !syncFiles && !kubernetes && remote == nil {
+		if err := discardSyncedContainer(ctx, runtime, workspace, containerName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Load theme env
+	themeEnv := map[string]string{}
+	themeName := getThemeName(workspace)
+	if themeName != "" {
+		if te, err := loadThemeEnvVars(themeName); err == nil {
+			themeEnv = te
+			slog.Info("loaded theme colors", "theme", themeName, "colors", len(themeEnv))
+		} else {
+			slog.Warn("failed to load theme colors", "theme", themeName, "error", err)
+		}
+	}
+
+	// Load registry env (WI-3)
+	registryEnv, _ := loadRegistryEnv(ds)
+
+	// Load credential env (WI-2)
+	credentialEnv, credWarnings := loadBuildCredentials(ds, app, workspace)
+	for _, w := range credWarnings {
+		render.Warning(w)
+	}
+
+	// Resolve the layered spec.env (ecosystem < domain < app < workspace);
+	// it overrides the service connection env
+	resolvedEnv := resolveWorkspaceEnv(ds, workspace, credentialEnv)
+	for _, w := range resolvedEnv.Warnings {
+		render.Warning(w)
+	}
+	wsEnv := resolvedEnv.Env()
+	if len(serviceEnv) > 0 {
+		merged := make(map[string]string, len(serviceEnv)+len(wsEnv))
+		for k, v := range serviceEnv {
+			merged[k] = v
+		}
+		for k, v := range wsEnv {
+			merged[k] = v
+		}
+		wsEnv = merged
+	}
+
+	// Build the merged env
+	envVars := buildRuntimeEnv(app.Name, workspace.Name, opts.EcosystemName, opts.DomainName, opts.SystemName, themeEnv, registryEnv, credentialEnv, wsEnv)
+
+	// Hooks see the session env; host hooks run in the app's local directory
+	hooks := workspaceLifecycleHooks(app, workspace)
+	hookRunner := &hookrunner.Runner{
+		HostDir: hostDir,
+		Env:     envVars,
+		Exec:    containerHookExec(runtime, containerName, containerUID, containerGID),
+		Output:  os.Stdout,
+	}
+	if err := runLifecycleHooks(ctx, os.Stdout, hookRunner, hooks, models.HookPreStart); err != nil {
+		return nil, err
+	}
+
+	startOpts := operators.StartOptions{
+		ImageName:             imageName,
+		WorkspaceName:         workspace.Name,
+		ContainerName:         containerName,
+		AppName:               app.Name,
+		EcosystemName:         opts.EcosystemName,
+		DomainName:            opts.DomainName,
+		SystemName:            opts.SystemName,
+		AppPath:               appPath,
+		UID:                   containerUID,
+		GID:                   containerGID,
+		SSHAgentForwarding:    sshAgentForwarding,
+		GitCredentialMounting: gitCredentialMounting,
+		NetworkMode:           networkMode,
+		NetworkAliases:        network.aliases(),
+		CPUs:                  opts.CPUs,
+		Memory:                opts.Memory,
+		Mounts:                extraMounts,
+		Env:                   serviceEnv,
+		Ports:                 ports,
+		Devices:               devices,
+		GPUs:                  gpus,
+	}
+	containerID, err := runtime.StartWorkspace(ctx, startOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start workspace: %w", err)
+	}
+
+	slog.Info("workspace started", "container_id", containerID)
+	events.Emit(events.WorkspaceStarted, map[string]any{
+		"app":       app.Name,
+		"workspace": workspace.Name,
+		"container": containerName,
+		"image":     imageName,
+	})
+
+	started := &startedWorkspace{
+		runtime:       runtime,
+		containerName: containerName,
+		env:           envVars,
+		uid:           containerUID,
+		gid:           containerGID,
+		remote:        remote,
+		ports:         ports,
+	}
+	if stack != nil {
+		started.services = len(stack.Services)
+	}
+
+	// Copy the files in; the caller keeps syncing them while it needs to
+	if syncFiles {
+		session, err := newSyncSession(ctx, ds, runtime, workspace, app.Name, containerName, mountPath)
+		if err != nil {
+			return nil, err
+		}
+		if mounted, err := session.ContainerDirMounted(ctx); err != nil {
+			return nil, fmt.Errorf("failed to inspect workspace container: %w", err)
+		} else if mounted {
+			render.Info("Re-creating the container without the bind mount for spec.sync...")
+			if err := runtime.RemoveContainer(ctx, containerName, true); err != nil {
+				return nil, fmt.Errorf("failed to remove workspace container: %w", err)
+			}
+			if _, err := runtime.StartWorkspace(ctx, startOpts); err != nil {
+				return nil, fmt.Errorf("failed to start workspace: %w", err)
+			}
+			if session, err = newSyncSession(ctx, ds, runtime, workspace, app.Name, containerName, mountPath); err != nil {
+				return nil, err
+			}
+		}
+		render.Progress(fmt.Sprintf("Syncing files (%s)...", session.Mode))
+		plan, err := session.Sync(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync files: %w", err)
+		}
+		reportSync(plan)
+		emitSyncCompleted(app.Name, workspace.Name, plan)
+		started.sync = session
+		if err := runLifecycleHooks(ctx, os.Stdout, hookRunner, hooks, models.HookPostSync); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := runLifecycleHooks(ctx, os.Stdout, hookRunner, hooks, models.HookPostStart); err != nil {
+		return nil, err
+	}
+
+	return started, nil
+}
+
+// workspaceImageBuilt reports whether a workspace's image has been built; a
+// pending tag means dvm build has not run yet.
+func workspaceImageBuilt(workspace *models.Workspace) bool {
+	return !strings.HasSuffix(workspace.ImageName, ":pending") && strings.HasPrefix(workspace.ImageName, "dvm-")
+}
+
+// workspaceDevices converts a workspace's spec.devices and spec.gpus to
+// start options.
+func workspaceDevices(spec models.WorkspaceSpec) ([]operators.DeviceMapping, operators.GPURequest, error) {
+	if err := models.ValidateDevices(spec.Devices, spec.Runtime); err != nil {
+		return nil, operators.GPURequest{}, fmt.Errorf("spec.%w", err)
+	}
+	gpus, err := models.ParseGPURequest(spec.GPUs)
+	if err != nil {
+		return nil, operators.GPURequest{}, fmt.Errorf("spec.gpus: %w", err)
+	}
+	var devices []operators.DeviceMapping
+	for _, d := range spec.Devices {
+		devices = append(devices, operators.DeviceMapping{HostPath: d.Path, ContainerPath: d.ContainerPath, Permissions: d.Permissions})
+	}
+	return devices, operators.GPURequest(gpus), nil
+}
+
+// workspacePorts returns the ports to publish: spec.container.ports followed
+// by the --port flags, each once.
+func workspacePorts(specPorts, flagPorts []int) []int {
+	var ports []int
+	seen := map[int]bool{}
+	for _, port := range append(append([]int{}, specPorts...), flagPorts...) {
+		if !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
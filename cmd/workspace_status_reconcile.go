@@ -2,12 +2,58 @@ package cmd
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
+	"devopsmaestro/db"
 	"devopsmaestro/models"
 	"devopsmaestro/operators"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
 )
 
+// workspaceDrift is a workspace whose recorded status disagreed with the
+// container runtime, or whose running container uses another image than its
+// last build produced. An image drift sets the digests instead of the
+// statuses.
+type workspaceDrift struct {
+	Workspace *models.Workspace
+	Recorded  string // status in the database before reconcile
+	Actual    string // status reported by the runtime ("running" or "stopped")
+	SaveErr   error  // set when the corrected status could not be stored
+
+	BuiltDigest   string // digest of the image the last build produced
+	RunningDigest string // digest of the image the container runs
+}
+
+// isImageDrift reports whether the drift is about the container's image.
+func (d workspaceDrift) isImageDrift() bool {
+	return d.RunningDigest != ""
+}
+
+// workspaceDrifts is the result of one reconcile pass.
+type workspaceDrifts []workspaceDrift
+
+// status returns the workspace's status for table output, marked when it
+// drifted.
+func (d workspaceDrifts) status(ws *models.Workspace) string {
+	status := ws.Status
+	for _, drift := range d {
+		if drift.Workspace == ws || (drift.Workspace.ID != 0 && drift.Workspace.ID == ws.ID) {
+			if drift.isImageDrift() {
+				status += " (image drift)"
+			} else {
+				status += " (drifted)"
+			}
+		}
+	}
+	return status
+}
+
 // reconcileWorkspaceStatuses updates the Status field of each workspace in-place
 // to reflect the current state of the container runtime.
 //
@@ -24,93 +70,266 @@ import (
 // (runtime unavailable, etc.) we leave the DB-cached values untouched so
 // listing still works in offline scenarios — best effort, not strict.
 //
-// Matching strategy:
-//  1. By full container ID
-//  2. By 12-char short container ID prefix (Docker/containerd convention)
-//  3. By workspace name — matched against both the runtime's container Name
-//     (Docker uses the workspace name as the container name) and the
-//     `io.devopsmaestro.workspace` label (containerd uses the container ID
-//     hash as the Name, so the label is the only reliable mapping back to
-//     the workspace name there). See issue #418.
-func reconcileWorkspaceStatuses(workspaces []*models.Workspace) {
+// Workspaces whose recorded status disagrees about whether the container is
+// running are reported as drifts, and the corrected status is written back
+// to the database so other commands see it too. Lifecycle states such as
+// "created" are only overwritten in the output, not in the database.
+//
+// Containers of workspaces are found by their labels, so one created outside
+// dvm is adopted: its ID is recorded on the workspace (see
+// adoptWorkspaceContainers). A recorded container without dvm's labels, such
+// as one adopted with 'dvm workspace adopt', is inspected by ID instead (see
+// inspectRecordedContainers). Workspaces are then matched to containers by
+// workspaceInfoMatches.
+func reconcileWorkspaceStatuses(ds db.DataStore, workspaces []*models.Workspace) workspaceDrifts {
 	if len(workspaces) == 0 {
-		return
+		return nil
 	}
 
 	runtime, err := operators.NewContainerRuntime()
 	if err != nil {
 		slog.Debug("workspace status reconcile: failed to create runtime", "error", err)
-		return
+		return nil
 	}
 
-	infos, err := runtime.ListWorkspaces(context.Background())
+	ctx := context.Background()
+	infos, err := runtime.ListWorkspaces(ctx)
 	if err != nil {
 		slog.Debug("workspace status reconcile: failed to list workspaces", "error", err)
-		return
+		return nil
+	}
+	if inspector, ok := runtime.(operators.ContainerInspector); ok {
+		infos = inspectRecordedContainers(ctx, inspector, workspaces, infos)
 	}
 
-	applyWorkspaceStatusReconcile(workspaces, infos)
+	appNames := workspaceAppNames(ds)
+	for _, ws := range adoptWorkspaceContainers(workspaces, infos, appNames) {
+		if err := ds.SetWorkspaceContainerID(ws.ID, ws.ContainerID.String); err != nil {
+			slog.Warn("workspace status reconcile: failed to record adopted container", "workspace", ws.Name, "error", err)
+			continue
+		}
+		slog.Info("workspace container adopted", "workspace", ws.Name, "container_id", ws.ContainerID.String)
+	}
+
+	drifts := applyWorkspaceStatusReconcile(workspaces, infos, appNames)
+	persistWorkspaceDrifts(ds, drifts)
+
+	digests, err := ds.ListWorkspaceImageDigests()
+	if err != nil {
+		slog.Debug("workspace status reconcile: failed to list image digests", "error", err)
+		return drifts
+	}
+	return append(drifts, applyWorkspaceImageDrift(workspaces, infos, digests, appNames)...)
 }
 
-// applyWorkspaceStatusReconcile applies the matching logic against a pre-fetched
-// slice of runtime WorkspaceInfos. Extracted for unit-testability.
-func applyWorkspaceStatusReconcile(workspaces []*models.Workspace, infos []operators.WorkspaceInfo) {
-	runningByID := make(map[string]bool, len(infos))
-	runningByShortID := make(map[string]bool, len(infos))
-	runningByName := make(map[string]bool, len(infos))
-	for _, info := range infos {
-		if !isRunning(info.Status) {
+// workspaceAppNames returns the name of every app keyed by ID, or nil when
+// apps can't be listed, in which case containers are matched without their
+// app label.
+func workspaceAppNames(ds db.DataStore) map[int]string {
+	apps, err := ds.ListAllApps()
+	if err != nil {
+		slog.Debug("workspace status reconcile: failed to list apps", "error", err)
+		return nil
+	}
+	names := make(map[int]string, len(apps))
+	for _, app := range apps {
+		names[app.ID] = app.Name
+	}
+	return names
+}
+
+// inspectRecordedContainers appends to infos the recorded containers of
+// workspaces that ListWorkspaces did not report, because they carry no dvm
+// labels. Containers that no longer exist are left out.
+func inspectRecordedContainers(ctx context.Context, inspector operators.ContainerInspector, workspaces []*models.Workspace, infos []operators.WorkspaceInfo) []operators.WorkspaceInfo {
+	for _, ws := range workspaces {
+		if ws == nil || !ws.ContainerID.Valid || ws.ContainerID.String == "" {
 			continue
 		}
-		if info.ID != "" {
-			runningByID[info.ID] = true
-			if len(info.ID) >= 12 {
-				runningByShortID[info.ID[:12]] = true
+		listed := false
+		for _, info := range infos {
+			if info.ID != "" && containerIDMatches(ws.ContainerID.String, info.ID) {
+				listed = true
+				break
 			}
 		}
-		if info.Name != "" {
-			runningByName[info.Name] = true
+		if listed {
+			continue
+		}
+		details, err := inspector.InspectContainer(ctx, ws.ContainerID.String)
+		if err != nil {
+			slog.Debug("workspace status reconcile: failed to inspect recorded container", "workspace", ws.Name, "container_id", ws.ContainerID.String, "error", err)
+			continue
+		}
+		infos = append(infos, details.WorkspaceInfo())
+	}
+	return infos
+}
+
+// adoptWorkspaceContainers records on each workspace the container labeled
+// with its app and workspace name, when its recorded container ID names no
+// container the runtime knows: one created outside dvm, or re-created since.
+// A running container is preferred over a stopped one. It returns the
+// workspaces whose container ID changed; workspaces of unknown apps are left
+// alone, since a workspace label alone is ambiguous across apps.
+func adoptWorkspaceContainers(workspaces []*models.Workspace, infos []operators.WorkspaceInfo, appNames map[int]string) []*models.Workspace {
+	var adopted []*models.Workspace
+	for _, ws := range workspaces {
+		if ws == nil || ws.ID == 0 || appNames[ws.AppID] == "" {
+			continue
+		}
+		selector := operators.ManagedSelector(operators.LabelScope{App: appNames[ws.AppID], Workspace: ws.Name})
+		var found *operators.WorkspaceInfo
+		recorded := false
+		for i := range infos {
+			info := &infos[i]
+			if info.ID != "" && ws.ContainerID.Valid && containerIDMatches(ws.ContainerID.String, info.ID) {
+				recorded = true
+				break
+			}
+			if info.ID == "" || !operators.MatchesLabels(info.Labels, selector) {
+				continue
+			}
+			if found == nil || (!isRunning(found.Status) && isRunning(info.Status)) {
+				found = info
+			}
+		}
+		if recorded || found == nil {
+			continue
+		}
+		ws.ContainerID = sql.NullString{String: found.ID, Valid: true}
+		adopted = append(adopted, ws)
+	}
+	return adopted
+}
+
+// persistWorkspaceDrifts writes corrected statuses back to the database.
+// Failures are recorded on the drift rather than returned, since listing
+// should still work.
+func persistWorkspaceDrifts(ds db.DataStore, drifts workspaceDrifts) {
+	for i := range drifts {
+		d := &drifts[i]
+		if d.Workspace.ID == 0 || d.isImageDrift() {
+			continue
 		}
-		// Containerd's WorkspaceInfo.Name is the full container ID hash, not
-		// the workspace name. The workspace name lives in the
-		// io.devopsmaestro.workspace label (surfaced as info.Workspace).
-		// Index by it so reconcile works on containerd as well as Docker
-		// (issue #418).
-		if info.Workspace != "" {
-			runningByName[info.Workspace] = true
+		if err := ds.UpdateWorkspaceStatus(d.Workspace.ID, d.Actual); err != nil {
+			slog.Warn("workspace status reconcile: failed to store status", "workspace", d.Workspace.Name, "error", err)
+			d.SaveErr = err
+			continue
 		}
+		slog.Info("workspace status reconciled", "workspace", d.Workspace.Name, "recorded", d.Recorded, "actual", d.Actual)
 	}
+}
 
+// applyWorkspaceStatusReconcile applies the matching logic against a pre-fetched
+// slice of runtime WorkspaceInfos and returns the drifted workspaces.
+// appNames, keyed by app ID, tells apart same-named workspaces of different
+// apps; it may be nil. Extracted for unit-testability.
+func applyWorkspaceStatusReconcile(workspaces []*models.Workspace, infos []operators.WorkspaceInfo, appNames map[int]string) workspaceDrifts {
+	var drifts workspaceDrifts
 	for _, ws := range workspaces {
 		if ws == nil {
 			continue
 		}
+		recorded := ws.Status
 		running := false
-		if ws.ContainerID.Valid && ws.ContainerID.String != "" {
-			cid := ws.ContainerID.String
-			if runningByID[cid] {
-				running = true
-			} else if len(cid) >= 12 && runningByShortID[cid[:12]] {
+		for _, info := range infos {
+			if isRunning(info.Status) && workspaceInfoMatches(ws, appNames[ws.AppID], info) {
 				running = true
+				break
 			}
 		}
-		if !running && runningByName[ws.Name] {
-			running = true
-		}
 		if running {
 			ws.Status = "running"
 		} else {
 			ws.Status = "stopped"
 		}
+		if isRunning(recorded) != running {
+			drifts = append(drifts, workspaceDrift{Workspace: ws, Recorded: recorded, Actual: ws.Status})
+		}
+	}
+	return drifts
+}
+
+// applyWorkspaceImageDrift returns the running workspaces whose container
+// runs another image than the digest recorded by their last build, keyed by
+// workspace ID in digests. Workspaces are matched to containers the way
+// applyWorkspaceStatusReconcile matches them; runtimes that don't report
+// image digests never drift.
+func applyWorkspaceImageDrift(workspaces []*models.Workspace, infos []operators.WorkspaceInfo, digests map[int]string, appNames map[int]string) workspaceDrifts {
+	var drifts workspaceDrifts
+	for _, ws := range workspaces {
+		if ws == nil || digests[ws.ID] == "" {
+			continue
+		}
+		for _, info := range infos {
+			if !isRunning(info.Status) || info.ImageID == "" || !workspaceInfoMatches(ws, appNames[ws.AppID], info) {
+				continue
+			}
+			if info.ImageID != digests[ws.ID] {
+				drifts = append(drifts, workspaceDrift{
+					Workspace:     ws,
+					Recorded:      ws.Status,
+					Actual:        ws.Status,
+					BuiltDigest:   digests[ws.ID],
+					RunningDigest: info.ImageID,
+				})
+			}
+			break
+		}
+	}
+	return drifts
+}
+
+// workspaceInfoMatches reports whether a runtime container belongs to ws of
+// app appName (empty when unknown). Matching strategy:
+//  1. By full container ID
+//  2. By 12-char short container ID prefix (Docker/containerd convention)
+//  3. By workspace name — matched against both the runtime's container Name
+//     (Docker uses the workspace name as the container name) and the
+//     `io.devopsmaestro.workspace` label (containerd uses the container ID
+//     hash as the Name, so the label is the only reliable mapping back to
+//     the workspace name there). See issue #418. When both the app and the
+//     container's app label are known they must agree, so workspaces of
+//     different apps sharing a name aren't mistaken for each other.
+func workspaceInfoMatches(ws *models.Workspace, appName string, info operators.WorkspaceInfo) bool {
+	if ws.ContainerID.Valid && ws.ContainerID.String != "" && info.ID != "" &&
+		containerIDMatches(ws.ContainerID.String, info.ID) {
+		return true
+	}
+	if info.Name == ws.Name {
+		return true
 	}
+	return info.Workspace == ws.Name && (appName == "" || info.App == "" || info.App == appName)
+}
+
+// containerIDMatches compares container IDs, either of which may be the
+// 12-char short form.
+func containerIDMatches(a, b string) bool {
+	return a == b || (len(a) >= 12 && len(b) >= 12 && a[:12] == b[:12])
+}
+
+// shortDigest abbreviates an image digest for display.
+func shortDigest(digest string) string {
+	algo, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		algo, hex = "", digest
+	}
+	if len(hex) > 12 {
+		hex = hex[:12]
+	}
+	if algo == "" {
+		return hex
+	}
+	return algo + ":" + hex
 }
 
 // reconcileWorkspaceHierarchyStatuses is a convenience wrapper that reconciles
 // statuses for resolver results (which wrap *models.Workspace in a hierarchy
 // envelope).
-func reconcileWorkspaceHierarchyStatuses(results []*models.WorkspaceWithHierarchy) {
+func reconcileWorkspaceHierarchyStatuses(ds db.DataStore, results []*models.WorkspaceWithHierarchy) workspaceDrifts {
 	if len(results) == 0 {
-		return
+		return nil
 	}
 	workspaces := make([]*models.Workspace, 0, len(results))
 	for _, wh := range results {
@@ -118,5 +337,66 @@ func reconcileWorkspaceHierarchyStatuses(results []*models.WorkspaceWithHierarch
 			workspaces = append(workspaces, wh.Workspace)
 		}
 	}
-	reconcileWorkspaceStatuses(workspaces)
+	return reconcileWorkspaceStatuses(ds, workspaces)
+}
+
+// renderWorkspaceDrifts explains drifted statuses after table output, with
+// the command that brings each workspace back in line.
+func renderWorkspaceDrifts(ds db.DataStore, drifts workspaceDrifts) {
+	var statusDrifts, imageDrifts workspaceDrifts
+	for _, d := range drifts {
+		if d.isImageDrift() {
+			imageDrifts = append(imageDrifts, d)
+		} else {
+			statusDrifts = append(statusDrifts, d)
+		}
+	}
+	appName := func(ws *models.Workspace) string {
+		if app, err := ds.GetAppByID(ws.AppID); err == nil && app != nil {
+			return app.Name
+		}
+		return ""
+	}
+
+	if len(imageDrifts) > 0 {
+		render.Blank()
+		render.Warning(fmt.Sprintf("%d running workspace(s) use another image than their last build:", len(imageDrifts)))
+		for _, d := range imageDrifts {
+			name := appName(d.Workspace)
+			render.Plain(fmt.Sprintf("  %s/%s: container runs %s, last build produced %s",
+				name, d.Workspace.Name, shortDigest(d.RunningDigest), shortDigest(d.BuiltDigest)))
+			render.Plain(fmt.Sprintf("    restart it on the new image with: dvm detach -a %s -w %s && dvm attach -a %s -w %s",
+				name, d.Workspace.Name, name, d.Workspace.Name))
+		}
+	}
+
+	if len(statusDrifts) == 0 {
+		return
+	}
+	render.Blank()
+	render.Warning(fmt.Sprintf("%d workspace status(es) drifted from the container runtime:", len(statusDrifts)))
+	for _, d := range statusDrifts {
+		appName := appName(d.Workspace)
+		target := fmt.Sprintf("-a %s -w %s", appName, d.Workspace.Name)
+		line := fmt.Sprintf("  %s/%s: recorded %q, container is %s", appName, d.Workspace.Name, d.Recorded, d.Actual)
+		if d.SaveErr != nil {
+			line += fmt.Sprintf(" (could not update database: %v)", d.SaveErr)
+		} else {
+			line += " (database updated)"
+		}
+		render.Plain(line)
+		if d.Actual == "running" {
+			render.Plain(fmt.Sprintf("    started outside dvm; stop it with: dvm detach %s", target))
+		} else {
+			render.Plain(fmt.Sprintf("    stopped outside dvm; start it with: dvm attach %s", target))
+		}
+	}
+}
+
+// watchWorkspaces redraws 'dvm get workspaces' every interval until
+// interrupted, so recorded statuses keep following the container runtime.
+func watchWorkspaces(cmd *cobra.Command, interval time.Duration) error {
+	return runWatch(cmd, getOutputFormat, interval, func() error {
+		return getWorkspaces(cmd)
+	})
 }
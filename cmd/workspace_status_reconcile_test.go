@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"database/sql"
+	"errors"
 	"testing"
 
+	"devopsmaestro/db"
 	"devopsmaestro/models"
 	"devopsmaestro/operators"
 )
@@ -27,7 +29,7 @@ func TestApplyWorkspaceStatusReconcile_MatchByFullID(t *testing.T) {
 	ws := makeWS("myws", fullID, "stopped")
 	infos := []operators.WorkspaceInfo{makeInfo(fullID, "myws", "Up 5 minutes")}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "running" {
 		t.Errorf("expected running, got %q", ws.Status)
@@ -41,7 +43,7 @@ func TestApplyWorkspaceStatusReconcile_MatchByShortID(t *testing.T) {
 	ws := makeWS("myws", shortID, "stopped")
 	infos := []operators.WorkspaceInfo{makeInfo(longID, "myws", "Up 1 hour")}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "running" {
 		t.Errorf("expected running, got %q", ws.Status)
@@ -53,7 +55,7 @@ func TestApplyWorkspaceStatusReconcile_MatchByName(t *testing.T) {
 	ws := makeWS("myws", "", "stopped")
 	infos := []operators.WorkspaceInfo{makeInfo("someid123456", "myws", "running")}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "running" {
 		t.Errorf("expected running, got %q", ws.Status)
@@ -64,7 +66,7 @@ func TestApplyWorkspaceStatusReconcile_NoMatch_SetsStopped(t *testing.T) {
 	ws := makeWS("ghost", "deadbeef123456789", "running")
 	infos := []operators.WorkspaceInfo{makeInfo("aabbccdd1234", "other", "Up 2 hours")}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "stopped" {
 		t.Errorf("expected stopped, got %q", ws.Status)
@@ -76,7 +78,7 @@ func TestApplyWorkspaceStatusReconcile_NonRunningRuntimeEntry_NotCounted(t *test
 	// Runtime says "Exited" — should not count as running.
 	infos := []operators.WorkspaceInfo{makeInfo("aabbccdd1234", "myws", "Exited (0)")}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "stopped" {
 		t.Errorf("expected stopped, got %q", ws.Status)
@@ -88,7 +90,7 @@ func TestApplyWorkspaceStatusReconcile_NilEntries_Skipped(t *testing.T) {
 	infos := []operators.WorkspaceInfo{makeInfo("aabbccdd123456", "ok", "running")}
 
 	// nil entries must not panic.
-	applyWorkspaceStatusReconcile([]*models.Workspace{nil, ws, nil}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{nil, ws, nil}, infos, nil)
 
 	if ws.Status != "running" {
 		t.Errorf("expected running, got %q", ws.Status)
@@ -98,7 +100,7 @@ func TestApplyWorkspaceStatusReconcile_NilEntries_Skipped(t *testing.T) {
 func TestApplyWorkspaceStatusReconcile_EmptyInfos_AllStopped(t *testing.T) {
 	ws := makeWS("myws", "aabbccdd1234", "running")
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, nil)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, nil, nil)
 
 	if ws.Status != "stopped" {
 		t.Errorf("expected stopped, got %q", ws.Status)
@@ -125,7 +127,7 @@ func TestApplyWorkspaceStatusReconcile_MultipleWorkspaces(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ws := makeWS(tc.wsName, tc.containerID, tc.initial)
 			infos := []operators.WorkspaceInfo{makeInfo(tc.runtimeID, tc.runtimeName, tc.runtimeStat)}
-			applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+			applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 			if ws.Status != tc.wantStatus {
 				t.Errorf("got %q, want %q", ws.Status, tc.wantStatus)
 			}
@@ -149,13 +151,74 @@ func TestApplyWorkspaceStatusReconcile_MatchByWorkspaceLabel_Containerd(t *testi
 		},
 	}
 
-	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos)
+	applyWorkspaceStatusReconcile([]*models.Workspace{ws}, infos, nil)
 
 	if ws.Status != "running" {
 		t.Errorf("expected running, got %q", ws.Status)
 	}
 }
 
+func TestApplyWorkspaceStatusReconcile_AppLabelDisambiguates(t *testing.T) {
+	// Two apps each have a "dev" workspace; only api's container runs.
+	apiDev := makeWS("dev", "", "stopped")
+	apiDev.AppID = 1
+	webDev := makeWS("dev", "", "stopped")
+	webDev.AppID = 2
+	infos := []operators.WorkspaceInfo{
+		{ID: "4afae9349447", Name: "4afae9349447d717", Status: "running", App: "api", Workspace: "dev"},
+	}
+
+	applyWorkspaceStatusReconcile([]*models.Workspace{apiDev, webDev}, infos, map[int]string{1: "api", 2: "web"})
+
+	if apiDev.Status != "running" {
+		t.Errorf("api/dev: expected running, got %q", apiDev.Status)
+	}
+	if webDev.Status != "stopped" {
+		t.Errorf("web/dev: expected stopped, got %q", webDev.Status)
+	}
+}
+
+func TestAdoptWorkspaceContainers(t *testing.T) {
+	labels := func(app, ws string) map[string]string {
+		return operators.ResourceLabels(operators.LabelScope{App: app, Workspace: ws})
+	}
+	outOfBand := makeWS("dev", "", "stopped") // created with docker run --label ...
+	outOfBand.ID, outOfBand.AppID = 1, 1
+	recreated := makeWS("test", "0000stale000", "running")
+	recreated.ID, recreated.AppID = 2, 1
+	tracked := makeWS("prod", "bbbb1111cccc", "running")
+	tracked.ID, tracked.AppID = 3, 1
+	unknownApp := makeWS("dev", "", "stopped")
+	unknownApp.ID, unknownApp.AppID = 4, 9
+
+	infos := []operators.WorkspaceInfo{
+		{ID: "aaaa0000aaaa", Status: "Exited (0)", Labels: labels("api", "dev")},
+		{ID: "aaaa1111aaaa", Status: "Up 2 minutes", Labels: labels("api", "dev")},
+		{ID: "dddd2222dddd", Status: "Up 1 minute", Labels: labels("api", "test")},
+		{ID: "bbbb1111cccc", Status: "running", Labels: labels("api", "prod")},
+		{ID: "eeee3333eeee", Status: "running", Labels: labels("api", "prod")},
+		{ID: "ffff4444ffff", Status: "running", Labels: map[string]string{operators.LabelWorkspace: "dev"}},
+	}
+
+	adopted := adoptWorkspaceContainers([]*models.Workspace{outOfBand, recreated, tracked, unknownApp, nil}, infos, map[int]string{1: "api"})
+
+	if len(adopted) != 2 || adopted[0] != outOfBand || adopted[1] != recreated {
+		t.Fatalf("adopted = %+v, want dev and test", adopted)
+	}
+	if outOfBand.ContainerID.String != "aaaa1111aaaa" {
+		t.Errorf("dev adopted %q, want the running container", outOfBand.ContainerID.String)
+	}
+	if recreated.ContainerID.String != "dddd2222dddd" {
+		t.Errorf("test adopted %q, want its new container", recreated.ContainerID.String)
+	}
+	if tracked.ContainerID.String != "bbbb1111cccc" {
+		t.Errorf("prod container = %q, want the recorded one kept", tracked.ContainerID.String)
+	}
+	if unknownApp.ContainerID.Valid {
+		t.Errorf("workspace of an unknown app adopted %q", unknownApp.ContainerID.String)
+	}
+}
+
 func TestReconcileWorkspaceHierarchyStatuses_NilEntries(t *testing.T) {
 	// Should not panic on nil or nil-workspace entries.
 	results := []*models.WorkspaceWithHierarchy{
@@ -165,5 +228,98 @@ func TestReconcileWorkspaceHierarchyStatuses_NilEntries(t *testing.T) {
 	// We can't inject a runtime, so this just exercises the nil guard path.
 	// On a machine without a container runtime, the function returns early and
 	// DB values are preserved — acceptable offline behavior.
-	reconcileWorkspaceHierarchyStatuses(results) // must not panic
+	reconcileWorkspaceHierarchyStatuses(db.NewMockDataStore(), results) // must not panic
+}
+
+func TestApplyWorkspaceStatusReconcile_ReportsDrift(t *testing.T) {
+	wentDown := makeWS("down", "aaaa1234bbbb", "running")
+	cameUp := makeWS("up", "cccc5678dddd", "stopped")
+	fresh := makeWS("fresh", "", "created")
+	inSync := makeWS("sync", "eeee9999ffff", "running")
+	infos := []operators.WorkspaceInfo{
+		makeInfo("cccc5678dddd", "up", "Up 3 minutes"),
+		makeInfo("eeee9999ffff", "sync", "running"),
+	}
+
+	drifts := applyWorkspaceStatusReconcile([]*models.Workspace{wentDown, cameUp, fresh, inSync}, infos, nil)
+
+	if len(drifts) != 2 {
+		t.Fatalf("expected 2 drifts, got %d: %+v", len(drifts), drifts)
+	}
+	if drifts[0].Workspace != wentDown || drifts[0].Recorded != "running" || drifts[0].Actual != "stopped" {
+		t.Errorf("unexpected drift %+v", drifts[0])
+	}
+	if drifts[1].Workspace != cameUp || drifts[1].Recorded != "stopped" || drifts[1].Actual != "running" {
+		t.Errorf("unexpected drift %+v", drifts[1])
+	}
+	if got := drifts.status(wentDown); got != "stopped (drifted)" {
+		t.Errorf("status(wentDown) = %q", got)
+	}
+	if got := drifts.status(fresh); got != "stopped" {
+		t.Errorf("status(fresh) = %q, lifecycle states are not drift", got)
+	}
+}
+
+func TestPersistWorkspaceDrifts(t *testing.T) {
+	mock := db.NewMockDataStore()
+	mock.Workspaces[1] = &models.Workspace{ID: 1, Name: "down", Status: "running"}
+	listed := &models.Workspace{ID: 1, Name: "down", Status: "stopped"}
+	drifts := workspaceDrifts{{Workspace: listed, Recorded: "running", Actual: "stopped"}}
+
+	persistWorkspaceDrifts(mock, drifts)
+
+	if mock.Workspaces[1].Status != "stopped" {
+		t.Errorf("stored status = %q, want stopped", mock.Workspaces[1].Status)
+	}
+	if drifts[0].SaveErr != nil {
+		t.Errorf("unexpected SaveErr %v", drifts[0].SaveErr)
+	}
+
+	mock.UpdateWorkspaceStatusErr = errors.New("database is locked")
+	persistWorkspaceDrifts(mock, drifts)
+	if drifts[0].SaveErr == nil {
+		t.Error("expected SaveErr to be recorded")
+	}
+}
+
+func TestApplyWorkspaceImageDrift(t *testing.T) {
+	current := makeWS("current", "", "running")
+	current.ID = 1
+	drifted := makeWS("drifted", "def456def456789", "running")
+	drifted.ID = 2
+	stopped := makeWS("stopped", "", "stopped")
+	stopped.ID = 3
+	untracked := makeWS("untracked", "", "running")
+	untracked.ID = 4
+
+	infos := []operators.WorkspaceInfo{
+		{ID: "aaa", Name: "current", Status: "Up 1 minute", ImageID: "sha256:new"},
+		{ID: "def456def456", Name: "other-name", Status: "running", ImageID: "sha256:old"},
+		{ID: "ccc", Name: "stopped", Status: "Exited (0)", ImageID: "sha256:old"},
+		{ID: "ddd", Name: "untracked", Status: "Up 1 minute", ImageID: "sha256:old"},
+	}
+	digests := map[int]string{1: "sha256:new", 2: "sha256:new", 3: "sha256:new"}
+
+	drifts := applyWorkspaceImageDrift([]*models.Workspace{current, drifted, stopped, untracked}, infos, digests, nil)
+	if len(drifts) != 1 || drifts[0].Workspace != drifted {
+		t.Fatalf("drifts = %+v, want only %q", drifts, drifted.Name)
+	}
+	if drifts[0].RunningDigest != "sha256:old" || drifts[0].BuiltDigest != "sha256:new" {
+		t.Errorf("drift digests = %q running, %q built", drifts[0].RunningDigest, drifts[0].BuiltDigest)
+	}
+	if got := drifts.status(drifted); got != "running (image drift)" {
+		t.Errorf("status() = %q, want image drift marked", got)
+	}
+	if got := drifts.status(current); got != "running" {
+		t.Errorf("status() = %q for a current workspace", got)
+	}
+}
+
+func TestShortDigest(t *testing.T) {
+	if got := shortDigest("sha256:0123456789abcdef"); got != "sha256:0123456789ab" {
+		t.Errorf("shortDigest() = %q", got)
+	}
+	if got := shortDigest("0123456789abcdef"); got != "0123456789ab" {
+		t.Errorf("shortDigest() = %q", got)
+	}
 }
@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/hookrunner"
+	ws "devopsmaestro/pkg/workspace"
+	"devopsmaestro/pkg/workspace/filesync"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+// syncInterval is how often attach syncs a workspace with spec.sync.
+const syncInterval = 2 * time.Second
+
+var (
+	workspaceSyncFlags    HierarchyFlags
+	workspaceSyncWatch    bool
+	workspaceSyncInterval time.Duration
+	workspaceSyncOutput   string
+)
+
+// workspaceCmd is the parent command for operations on a workspace's files.
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Work with a workspace's files and containers",
+	Long: `Work with the files and containers of workspaces.
+
+Workspaces with spec.sync.mode one-way or two-way keep a copy of the app's
+files in the container instead of bind mounting them, which is much faster
+on macOS (Colima, Docker Desktop). 'dvm attach' syncs them while the session
+is open; 'dvm workspace sync' runs a pass by hand.
+
+'dvm workspace adopt' turns a container built outside dvm into a workspace.`,
+}
+
+// workspaceSyncCmd runs a sync pass for a workspace with spec.sync.
+var workspaceSyncCmd = &cobra.Command{
+	Use:   "sync [workspace]",
+	Short: "Sync a workspace's files with the container",
+	Long: `Sync the app's files with a running workspace container (spec.sync).
+
+Host changes are copied into the container. In two-way mode, changes made in
+the container are copied back. Files changed on both sides since the last
+sync are conflicts: they are reported and left alone until one side is made
+to match the other. The app's .gitignore and spec.sync.ignore decide which
+files are synced.
+
+Examples:
+  dvm workspace sync                 # One pass for the active workspace
+  dvm workspace sync staging         # Workspace by name
+  dvm workspace sync --watch         # Keep syncing until interrupted
+  dvm workspace sync status          # Show pending changes and conflicts`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runWorkspaceSync(cmd, args); err != nil {
+			renderError(err)
+			return errSilent
+		}
+		return nil
+	},
+}
+
+// workspaceSyncStatusCmd shows what the next sync pass would do.
+var workspaceSyncStatusCmd = &cobra.Command{
+	Use:   "status [workspace]",
+	Short: "Show pending sync changes and conflicts",
+	Long: `Show the changes the next sync pass would copy, and the files changed on
+both sides since the last sync (conflicts).
+
+Examples:
+  dvm workspace sync status
+  dvm workspace sync status -a portal -w dev
+  dvm workspace sync status -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runWorkspaceSyncStatus(cmd, args); err != nil {
+			renderError(err)
+			return errSilent
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceSyncCmd)
+	workspaceSyncCmd.AddCommand(workspaceSyncStatusCmd)
+
+	AddHierarchyFlags(workspaceSyncCmd, &workspaceSyncFlags)
+	workspaceSyncCmd.Flags().BoolVar(&workspaceSyncWatch, "watch", false, "Keep syncing until interrupted")
+	workspaceSyncCmd.Flags().DurationVar(&workspaceSyncInterval, "interval", syncInterval, "Time between passes with --watch")
+
+	AddHierarchyFlags(workspaceSyncStatusCmd, &workspaceSyncFlags)
+	workspaceSyncStatusCmd.Flags().StringVarP(&workspaceSyncOutput, "output", "o", "", "Output format (json, yaml, table)")
+}
+
+func runWorkspaceSync(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	session, wh, err := resolveSyncSession(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	plan, err := session.Sync(ctx)
+	if err != nil {
+		return err
+	}
+	reportSync(plan)
+	emitSyncCompleted(wh.App.Name, wh.Workspace.Name, plan)
+	if err := runPostSyncHooks(cmd, session, wh); err != nil {
+		return err
+	}
+	if !workspaceSyncWatch {
+		return nil
+	}
+	if workspaceSyncInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	render.Info(fmt.Sprintf("Watching for changes every %s (Ctrl+C to stop)", workspaceSyncInterval))
+	session.Watch(ctx, workspaceSyncInterval, func(plan *filesync.Plan, err error) {
+		if err != nil {
+			render.Warning(fmt.Sprintf("Sync failed: %v", err))
+			return
+		}
+		reportSync(plan)
+	})
+	return nil
+}
+
+// syncStatus is the structured output of dvm workspace sync status.
+type syncStatus struct {
+	Workspace     string `json:"workspace" yaml:"workspace"`
+	Mode          string `json:"mode" yaml:"mode"`
+	LastSynced    string `json:"lastSynced,omitempty" yaml:"lastSynced,omitempty"`
+	filesync.Plan `yaml:",inline"`
+}
+
+func runWorkspaceSyncStatus(cmd *cobra.Command, args []string) error {
+	ctx := commandContext(cmd)
+	session, wh, err := resolveSyncSession(cmd, args)
+	if err != nil {
+		return err
+	}
+	workspacePath := wh.FullPath()
+	plan, err := session.Status(ctx)
+	if err != nil {
+		return err
+	}
+	lastSynced, err := session.LastSynced()
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(workspaceSyncOutput) {
+		status := syncStatus{Workspace: workspacePath, Mode: session.Mode, Plan: *plan}
+		if !lastSynced.IsZero() {
+			status.LastSynced = lastSynced.Format(time.RFC3339)
+		}
+		return render.OutputWith(workspaceSyncOutput, status, render.Options{})
+	}
+
+	if lastSynced.IsZero() {
+		render.Info(fmt.Sprintf("Mode: %s, not synced yet", session.Mode))
+	} else {
+		render.Info(fmt.Sprintf("Mode: %s, last synced %s", session.Mode, lastSynced.Local().Format(time.DateTime)))
+	}
+	if plan.Empty() {
+		render.Success("Up to date")
+		return nil
+	}
+
+	table := render.TableData{Headers: []string{"CHANGE", "PATH"}}
+	add := func(change string, paths []string) {
+		for _, p := range paths {
+			table.Rows = append(table.Rows, []string{change, p})
+		}
+	}
+	add("push", plan.Push)
+	add("pull", plan.Pull)
+	add("delete in container", plan.DeleteInContainer)
+	add("delete on host", plan.DeleteOnHost)
+	for _, c := range plan.Conflicts {
+		table.Rows = append(table.Rows, []string{"conflict", c.Path + " (" + c.Reason + ")"})
+	}
+	return render.OutputWith(workspaceSyncOutput, table, render.Options{Type: render.TypeTable})
+}
+
+// runPostSyncHooks runs the workspace's postSync hooks after the first pass
+// of dvm workspace sync. Passes in watch mode do not run them.
+func runPostSyncHooks(cmd *cobra.Command, session *filesync.Session, wh *models.WorkspaceWithHierarchy) error {
+	hooks := workspaceLifecycleHooks(wh.App, wh.Workspace)
+	if len(hooks.At(models.HookPostSync)) == 0 {
+		return nil
+	}
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	ecosystemName, domainName, systemName := "", "", ""
+	if wh.Ecosystem != nil {
+		ecosystemName = wh.Ecosystem.Name
+	}
+	if wh.Domain != nil {
+		domainName = wh.Domain.Name
+	}
+	if wh.System != nil {
+		systemName = wh.System.Name
+	}
+	runner := &hookrunner.Runner{
+		HostDir: session.HostDir,
+		Env:     hookEnv(ds, wh.App, wh.Workspace, ecosystemName, domainName, systemName, os.Stdout),
+		Exec: func(ctx context.Context, command []string, stdout io.Writer) error {
+			return session.Exec(ctx, command, nil, stdout)
+		},
+		Output: os.Stdout,
+	}
+	return runLifecycleHooks(commandContext(cmd), os.Stdout, runner, hooks, models.HookPostSync)
+}
+
+// resolveSyncSession resolves the workspace of a sync command and connects to
+// its running container. It also returns the workspace with its hierarchy.
+func resolveSyncSession(cmd *cobra.Command, args []string) (*filesync.Session, *models.WorkspaceWithHierarchy, error) {
+	ds, err := getDataStore(cmd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("dataStore not initialized: %w", err)
+	}
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+	wh, err := resolveSessionWorkspace(ds, workspaceSyncFlags, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workspace, app := wh.Workspace, wh.App
+	ecosystemName, domainName, systemName := "", "", ""
+	if wh.Ecosystem != nil {
+		ecosystemName = wh.Ecosystem.Name
+	}
+	if wh.Domain != nil {
+		domainName = wh.Domain.Name
+	}
+	if wh.System != nil {
+		systemName = wh.System.Name
+	}
+	if !workspace.ToYAML(app.Name, "").Spec.Sync.Enabled() {
+		return nil, nil, ErrorWithSuggestion(
+			fmt.Sprintf("workspace %q bind mounts its files", wh.FullPath()),
+			"Set spec.sync.mode to one-way or two-way with 'dvm apply', then re-create the container",
+		)
+	}
+
+	runtime, err := workspaceRuntime(ds, workspace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create container runtime: %w", err)
+	}
+	containerName := operators.NewHierarchicalNamingStrategy().GenerateName(ecosystemName, domainName, systemName, app.Name, workspace.Name)
+	hostDir, err := getMountPath(ds, workspace, app.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mount path: %w", err)
+	}
+	ctx := commandContext(cmd)
+	session, err := newSyncSession(ctx, ds, runtime, workspace, app.Name, containerName, hostDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mounted, err := session.ContainerDirMounted(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to inspect workspace container: %w", err)
+	} else if mounted {
+		return nil, nil, ErrorWithSuggestion(
+			fmt.Sprintf("workspace container %s bind mounts the app's files", containerName),
+			"Re-attach with 'dvm attach' to re-create it for spec.sync",
+		)
+	}
+	return session, wh, nil
+}
+
+// newSyncSession connects a sync session to a running workspace container.
+func newSyncSession(ctx context.Context, ds db.DataStore, runtime operators.ContainerRuntime, workspace *models.Workspace, appName, containerName, hostDir string) (*filesync.Session, error) {
+	spec := workspace.ToYAML(appName, "").Spec
+	execer, ok := runtime.(operators.WorkspaceExecer)
+	if !ok || isKubernetesWorkspace(workspace) {
+		return nil, fmt.Errorf("file sync is not supported on %s", runtime.GetPlatformName())
+	}
+	if endpoint, err := workspaceEndpoint(ds, workspace); err != nil {
+		return nil, err
+	} else if endpoint != nil {
+		return nil, fmt.Errorf("file sync is not supported on remote hosts; files are copied with rsync on attach and detach")
+	}
+
+	info, err := runtime.FindWorkspace(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workspace container: %w", err)
+	}
+	if info == nil || info.Status != "running" {
+		return nil, ErrorWithSuggestion(
+			fmt.Sprintf("workspace container %s is not running", containerName),
+			"Start it with: dvm attach -a "+appName+" -w "+workspace.Name,
+		)
+	}
+
+	ignore, err := filesync.LoadIgnore(hostDir, spec.Sync.Ignore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore patterns: %w", err)
+	}
+	statePath, err := syncStatePath(workspace)
+	if err != nil {
+		return nil, err
+	}
+	uid, gid := spec.Container.UID, spec.Container.GID
+	return &filesync.Session{
+		Mode:        spec.Sync.Mode,
+		HostDir:     hostDir,
+		ContainerID: info.ID,
+		StatePath:   statePath,
+		Ignore:      ignore,
+		Exec: func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+			return execer.ExecInWorkspace(ctx, operators.ExecOptions{
+				WorkspaceID: containerName,
+				Command:     command,
+				UID:         uid,
+				GID:         gid,
+				Stdin:       stdin,
+				Stdout:      stdout,
+			})
+		},
+	}, nil
+}
+
+// syncStatePath returns where the sync state of a workspace is kept.
+func syncStatePath(workspace *models.Workspace) (string, error) {
+	workspacePath, err := ws.GetWorkspacePath(workspace.Slug)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(workspacePath, ".dvm", filesync.StateFile), nil
+}
+
+// discardSyncedContainer removes the container of a workspace that was synced
+// (spec.sync) and now bind mounts its files, so it is re-created with the
+// mount. The final pass of the last session already copied its changes back.
+func discardSyncedContainer(ctx context.Context, runtime operators.ContainerRuntime, workspace *models.Workspace, containerName string) error {
+	statePath, err := syncStatePath(workspace)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(statePath); err != nil {
+		return nil
+	}
+	if info, err := runtime.FindWorkspace(ctx, containerName); err == nil && info != nil {
+		render.Info("Re-creating the container to bind mount the app's files...")
+		if err := runtime.RemoveContainer(ctx, containerName, true); err != nil {
+			return fmt.Errorf("failed to remove workspace container: %w", err)
+		}
+	}
+	return os.Remove(statePath)
+}
+
+// startSyncWatch syncs the workspace in the background while a session is
+// attached. The terminal belongs to the session, so passes are only logged.
+// The returned function stops watching and runs a final pass.
+func startSyncWatch(ctx context.Context, session *filesync.Session) func() {
+	watchCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		session.Watch(watchCtx, syncInterval, func(plan *filesync.Plan, err error) {
+			if err != nil {
+				slog.Warn("workspace sync failed", "error", err)
+				return
+			}
+			slog.Info("workspace synced", "pushed", len(plan.Push)+len(plan.DeleteInContainer),
+				"pulled", len(plan.Pull)+len(plan.DeleteOnHost), "conflicts", len(plan.Conflicts))
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+		plan, err := session.Sync(ctx)
+		if err != nil {
+			render.Warning(fmt.Sprintf("Final sync failed: %v", err))
+			return
+		}
+		reportSync(plan)
+	}
+}
+
+// syncBeforeDetach runs a last pass for a workspace with spec.sync before
+// its container stops. A workspace that is not running has nothing to sync.
+func syncBeforeDetach(ctx context.Context, ds db.DataStore, runtime operators.ContainerRuntime, workspace *models.Workspace, app *models.App, containerName string) {
+	if isKubernetesWorkspace(workspace) || !workspace.ToYAML(app.Name, "").Spec.Sync.Enabled() {
+		return
+	}
+	hostDir, err := getMountPath(ds, workspace, app.Path)
+	if err != nil {
+		render.Warning(fmt.Sprintf("Skipping final sync: %v", err))
+		return
+	}
+	session, err := newSyncSession(ctx, ds, runtime, workspace, app.Name, containerName, hostDir)
+	if err != nil {
+		slog.Debug("skipping final sync", "container", containerName, "error", err)
+		return
+	}
+	if mounted, err := session.ContainerDirMounted(ctx); err != nil || mounted {
+		return
+	}
+	plan, err := session.Sync(ctx)
+	if err != nil {
+		render.Warning(fmt.Sprintf("Final sync failed: %v", err))
+		return
+	}
+	reportSync(plan)
+}
+
+// emitSyncCompleted sends the sync.completed event for a one-off sync pass.
+// Passes in watch mode are not reported.
+func emitSyncCompleted(app, workspace string, plan *filesync.Plan) {
+	events.Emit(events.SyncCompleted, map[string]any{
+		"kind":      "workspace",
+		"app":       app,
+		"workspace": workspace,
+		"pushed":    len(plan.Push) + len(plan.DeleteInContainer),
+		"pulled":    len(plan.Pull) + len(plan.DeleteOnHost),
+		"conflicts": len(plan.Conflicts),
+	})
+}
+
+// reportSync summarizes a sync pass.
+func reportSync(plan *filesync.Plan) {
+	pushed := len(plan.Push) + len(plan.DeleteInContainer)
+	pulled := len(plan.Pull) + len(plan.DeleteOnHost)
+	switch {
+	case pushed > 0 && pulled > 0:
+		render.Success(fmt.Sprintf("Synced %d change(s) into the container and %d back", pushed, pulled))
+	case pushed > 0:
+		render.Success(fmt.Sprintf("Synced %d change(s) into the container", pushed))
+	case pulled > 0:
+		render.Success(fmt.Sprintf("Synced %d change(s) from the container", pulled))
+	}
+	if len(plan.Conflicts) > 0 {
+		paths := make([]string, len(plan.Conflicts))
+		for i, c := range plan.Conflicts {
+			paths[i] = c.Path
+		}
+		render.Warning(fmt.Sprintf("%d conflict(s) left unsynced: %s", len(paths), strings.Join(paths, ", ")))
+		render.Plain("  See 'dvm workspace sync status'; make one side match the other to resolve")
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceSyncCmd_Wiring(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"workspace", "sync", "status"})
+	require.NoError(t, err)
+	assert.Equal(t, "status", cmd.Name())
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+	assert.NotNil(t, cmd.Flags().Lookup("workspace"))
+
+	cmd, _, err = rootCmd.Find([]string{"workspace", "sync"})
+	require.NoError(t, err)
+	assert.NotNil(t, cmd.Flags().Lookup("watch"))
+	assert.NotNil(t, cmd.Flags().Lookup("interval"))
+}
+
+func TestDiscardSyncedContainer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+	workspace := &models.Workspace{Name: "dev", Slug: "acme-core-api-dev"}
+	runtime := operators.NewMockContainerRuntime()
+	runtime.SetWorkspaceStatus("dvm-acme-api-dev", "running")
+
+	// Never synced: the container is kept
+	require.NoError(t, discardSyncedContainer(ctx, runtime, workspace, "dvm-acme-api-dev"))
+	assert.Equal(t, 0, runtime.CallCount("RemoveContainer"))
+
+	// Synced before: the container is re-created with the bind mount
+	statePath, err := syncStatePath(workspace)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(statePath), 0700))
+	require.NoError(t, os.WriteFile(statePath, []byte(`{"containerId":"mock-dvm-acme-api-dev"}`), 0600))
+
+	require.NoError(t, discardSyncedContainer(ctx, runtime, workspace, "dvm-acme-api-dev"))
+	assert.Equal(t, 1, runtime.CallCount("RemoveContainer"))
+	assert.NoFileExists(t, statePath)
+}
@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/interactive"
+	"devopsmaestro/pkg/source"
+	"devopsmaestro/pkg/wstemplate"
+
+	"github.com/rmkohlman/MaestroSDK/paths"
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/cobra"
+)
+
+var templateListOutput string
+
+// templateListCmd lists the workspace template catalog.
+var templateListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List workspace templates",
+	Long: `List the workspace templates 'dvm create workspace --template' can use:
+the templates built into dvm plus those added with 'dvm template add'.
+A local template with the same name as a built-in one replaces it.
+
+Examples:
+  dvm templates list
+  dvm template list -o yaml`,
+	Args: cobra.NoArgs,
+	RunE: runTemplateList,
+}
+
+// templateShowCmd prints a workspace template.
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a workspace template's YAML",
+	Long: `Print a workspace template from the catalog, including its parameters.
+
+Examples:
+  dvm template show go-grpc`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkspaceTemplateNames,
+	RunE:              runTemplateShow,
+}
+
+// templateAddCmd stores a workspace template in the local catalog.
+var templateAddCmd = &cobra.Command{
+	Use:   "add <file|url|github:user/repo/path>",
+	Short: "Add a workspace template to the local catalog",
+	Long: `Validate a workspace template (kind: WorkspaceTemplate) and store it in
+the local catalog (~/.devopsmaestro/templates/workspaces) under its name.
+
+Examples:
+  dvm template add ./go-grpc.yaml
+  dvm template add github:org/dev-templates/workspaces/go-grpc.yaml
+  dvm template add https://example.com/go-grpc.yaml --force   # Replace an existing template`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateAdd,
+}
+
+// templateRemoveCmd deletes a workspace template from the local catalog.
+var templateRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a workspace template from the local catalog",
+	Long: `Remove a workspace template added with 'dvm template add'. Built-in
+templates cannot be removed; removing a local template that replaced a
+built-in one restores the built-in one.
+
+Examples:
+  dvm template remove go-grpc
+  dvm template remove go-grpc --force   # Skip confirmation`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkspaceTemplateNames,
+	RunE:              runTemplateRemove,
+}
+
+func init() {
+	templateListCmd.Flags().StringVarP(&templateListOutput, "output", "o", "", "Output format (json, yaml)")
+	templateAddCmd.Flags().Bool("force", false, "Replace an existing template with the same name")
+	AddForceConfirmFlag(templateRemoveCmd)
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateAddCmd)
+	templateCmd.AddCommand(templateRemoveCmd)
+}
+
+// workspaceTemplateCatalog returns the catalog under the dvm templates
+// directory.
+func workspaceTemplateCatalog() (*wstemplate.Catalog, error) {
+	pc, err := paths.Default()
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	return wstemplate.NewCatalog(filepath.Join(pc.TemplatesDir(), "workspaces")), nil
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return err
+	}
+	templates, err := catalog.List()
+	if err != nil {
+		return err
+	}
+
+	if isStructuredOutput(templateListOutput) {
+		return render.OutputWith(templateListOutput, templates, render.Options{})
+	}
+	if len(templates) == 0 {
+		return render.OutputWith(templateListOutput, nil, render.Options{
+			Empty:        true,
+			EmptyMessage: "No workspace templates found",
+			EmptyHints:   []string{"dvm template add ./my-template.yaml"},
+		})
+	}
+
+	tableData := render.TableData{Headers: []string{"NAME", "VERSION", "SOURCE", "PARAMETERS", "DESCRIPTION"}}
+	for _, t := range templates {
+		params := make([]string, 0, len(t.Spec.Parameters))
+		for _, p := range t.Spec.Parameters {
+			params = append(params, p.Name)
+		}
+		tableData.Rows = append(tableData.Rows, []string{
+			t.Metadata.Name,
+			orDash(t.Metadata.Version),
+			t.Source,
+			orDash(strings.Join(params, ", ")),
+			orDash(t.Metadata.Description),
+		})
+	}
+	return render.OutputWith(templateListOutput, tableData, render.Options{Type: render.TypeTable})
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return err
+	}
+	t, err := catalog.Get(args[0])
+	if err != nil {
+		return workspaceTemplateError(err, args[0])
+	}
+	fmt.Fprint(cmd.OutOrStdout(), string(t.Data))
+	return nil
+}
+
+func runTemplateAdd(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return err
+	}
+	data, _, err := source.Resolve(args[0]).Read()
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+	t, err := catalog.Add(data, force)
+	if err != nil {
+		return err
+	}
+	render.Successf("Added workspace template %s", t.Metadata.Name)
+	render.Info(fmt.Sprintf("Use it with: dvm create workspace <name> --template %s", t.Metadata.Name))
+	return nil
+}
+
+func runTemplateRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return err
+	}
+	t, err := catalog.Get(name)
+	if err != nil {
+		return workspaceTemplateError(err, name)
+	}
+	if t.Source == wstemplate.SourceBuiltin {
+		return fmt.Errorf("workspace template %q is built in and cannot be removed", name)
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	confirmed, err := confirmDelete(fmt.Sprintf("Remove workspace template '%s'?", name), force)
+	if err != nil || !confirmed {
+		return err
+	}
+	if err := catalog.Remove(name); err != nil {
+		return err
+	}
+	render.Successf("Workspace template '%s' removed", name)
+	return nil
+}
+
+// loadWorkspaceTemplate loads ref from the catalog (or a file, URL, or
+// github: reference) and resolves its parameters from --set values,
+// prompting for missing required ones when stdin is a terminal.
+func loadWorkspaceTemplate(cmd *cobra.Command, ref string, sets []string) (*wstemplate.Template, map[string]string, error) {
+	values, err := parseTemplateSets(sets)
+	if err != nil {
+		return nil, nil, err
+	}
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := catalog.Load(ref)
+	if err != nil {
+		return nil, nil, workspaceTemplateError(err, ref)
+	}
+	if missing := t.Missing(values); len(missing) > 0 && interactive.Enabled() {
+		if err := promptTemplateParameters(bufio.NewReader(os.Stdin), cmd.ErrOrStderr(), missing, values); err != nil {
+			return nil, nil, err
+		}
+	}
+	return t, values, nil
+}
+
+// applyWorkspaceTemplate instantiates t onto workspace, keeping what the
+// template does not set.
+func applyWorkspaceTemplate(workspace *models.Workspace, t *wstemplate.Template, values map[string]string) error {
+	wsYAML := workspace.ToYAML("", "")
+	spec, err := t.Instantiate(wsYAML.Spec, values)
+	if err != nil {
+		return err
+	}
+	wsYAML.Spec = spec
+	status := workspace.Status
+	workspace.FromYAML(wsYAML)
+	workspace.Status = status
+	return nil
+}
+
+// workspaceTemplateError adds a hint to catalog lookup failures.
+func workspaceTemplateError(err error, name string) error {
+	if errors.Is(err, wstemplate.ErrNotFound) {
+		return ErrorWithSuggestion(fmt.Sprintf("workspace template '%s' not found", name),
+			"List workspace templates with: dvm templates list")
+	}
+	return err
+}
+
+// completeWorkspaceTemplateNames completes catalog template names.
+func completeWorkspaceTemplateNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	catalog, err := workspaceTemplateCatalog()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	templates, err := catalog.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, t := range templates {
+		if strings.HasPrefix(t.Metadata.Name, toComplete) {
+			names = append(names, t.Metadata.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyWorkspaceTemplate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tmpl, values, err := loadWorkspaceTemplate(&cobra.Command{}, "go-grpc", []string{"theme=catppuccin-mocha"})
+	require.NoError(t, err)
+
+	workspace := &models.Workspace{AppID: 1, Name: "api", ImageName: "dvm-api-app:pending", Status: "stopped"}
+	workspace.SetEnv(map[string]string{"KEEP": "1"})
+	require.NoError(t, applyWorkspaceTemplate(workspace, tmpl, values))
+
+	assert.Equal(t, "api", workspace.Name)
+	assert.Equal(t, "stopped", workspace.Status)
+	assert.Equal(t, "dvm-api-app:pending", workspace.ImageName)
+	assert.Equal(t, "maestro-go", workspace.NvimPackage.String)
+	assert.Equal(t, "catppuccin-mocha", workspace.Theme.String)
+	assert.Equal(t, "developer", workspace.TerminalPackage.String)
+	assert.Equal(t, map[string]string{"KEEP": "1"}, workspace.GetEnv())
+
+	spec := workspace.ToYAML("app", "").Spec
+	assert.Contains(t, spec.Build.DevStage.Packages, "protobuf-compiler")
+}
+
+func TestLoadWorkspaceTemplate_Errors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, _, err := loadWorkspaceTemplate(&cobra.Command{}, "no-such-template", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "workspace template 'no-such-template' not found")
+
+	_, _, err = loadWorkspaceTemplate(&cobra.Command{}, "go-grpc", []string{"theme"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected name=value")
+}
+
+func TestWorkspaceSpecMounts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	appPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(home, "cache"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(appPath, "data"), 0755))
+	t.Setenv("DVM_TEST_MOUNT", filepath.Join(home, "cache"))
+
+	got := workspaceSpecMounts([]models.MountConfig{
+		{Source: "~/cache", Destination: "/home/dev/.cache", ReadOnly: true},
+		{Type: "bind", Source: "${APP_PATH}/data", Destination: "/data"},
+		{Type: "bind", Source: "${DVM_TEST_MOUNT}", Destination: "/env"},
+		{Type: "volume", Source: "cache", Destination: "/volume"},
+		{Type: "bind", Source: "~/missing", Destination: "/missing"},
+	}, appPath)
+
+	require.Len(t, got, 3)
+	assert.Equal(t, filepath.Join(home, "cache"), got[0].Source)
+	assert.True(t, got[0].ReadOnly)
+	assert.Equal(t, filepath.Join(appPath, "data"), got[1].Source)
+	assert.Equal(t, "/env", got[2].Destination)
+}
@@ -4,6 +4,11 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/events"
+	"devopsmaestro/pkg/httpclient"
+	"devopsmaestro/pkg/nvimbridge/syncfetch"
 
 	"github.com/spf13/viper"
 )
@@ -24,12 +29,189 @@ type BuildLogsConfig struct {
 	Compress   bool   `mapstructure:"compress"`   // default true
 }
 
+// BackupConfig controls scheduled database backups.
+// See pkg/backup for the implementation.
+type BackupConfig struct {
+	Enabled     bool                    `mapstructure:"enabled"`     // default false
+	Schedule    string                  `mapstructure:"schedule"`    // hourly, daily, weekly, or a Go duration; default daily
+	Retention   int                     `mapstructure:"retention"`   // backups to keep at the destination; default 7
+	Destination BackupDestinationConfig `mapstructure:"destination"` // where archives are stored
+	Encryption  BackupEncryptionConfig  `mapstructure:"encryption"`  // optional archive encryption
+}
+
+// BackupDestinationConfig selects where backup archives are written.
+type BackupDestinationConfig struct {
+	Type string `mapstructure:"type"` // local, s3, or git; default local
+	Path string `mapstructure:"path"` // local: directory (default ~/.devopsmaestro/backups); git: subdirectory in the repo
+
+	// S3-compatible object storage
+	Endpoint     string `mapstructure:"endpoint"`     // e.g. https://s3.us-east-1.amazonaws.com or a MinIO URL
+	Bucket       string `mapstructure:"bucket"`       // bucket name
+	Prefix       string `mapstructure:"prefix"`       // key prefix inside the bucket
+	Region       string `mapstructure:"region"`       // signing region; default us-east-1
+	AccessKeyEnv string `mapstructure:"accessKeyEnv"` // env var holding the access key; default AWS_ACCESS_KEY_ID
+	SecretKeyEnv string `mapstructure:"secretKeyEnv"` // env var holding the secret key; default AWS_SECRET_ACCESS_KEY
+
+	// Git repository
+	Repo   string `mapstructure:"repo"`   // clone URL
+	Branch string `mapstructure:"branch"` // default main
+}
+
+// BackupEncryptionConfig enables passphrase-based archive encryption.
+type BackupEncryptionConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`       // default false
+	PassphraseEnv string `mapstructure:"passphraseEnv"` // env var holding the passphrase; default DVM_BACKUP_PASSPHRASE
+}
+
+// ArtifactsConfig controls how release artifacts are downloaded and verified.
+// See pkg/fetch for the implementation.
+type ArtifactsConfig struct {
+	CacheDir   string                             `mapstructure:"cacheDir"`   // verified downloads by digest; default ~/.devopsmaestro/cache/artifacts
+	Prefetch   bool                               `mapstructure:"prefetch"`   // download builder-stage artifacts on the host through the cache; default false
+	Offline    bool                               `mapstructure:"offline"`    // only use cached artifacts, never download; implies prefetch; default false
+	Signatures map[string]ArtifactSignatureConfig `mapstructure:"signatures"` // tool name (e.g. neovim, kubectl) → signature to verify
+}
+
+// ArtifactSignatureConfig requires a detached signature on an artifact.
+type ArtifactSignatureConfig struct {
+	Method string `mapstructure:"method"` // gpg or cosign
+	URL    string `mapstructure:"url"`    // signature URL; {url} expands to the artifact URL, e.g. "{url}.sig"
+	Key    string `mapstructure:"key"`    // GPG keyring or cosign public key file
+}
+
+// ImagesConfig names the images dvm builds for workspaces. See pkg/imagename
+// for the template syntax.
+type ImagesConfig struct {
+	Name string `mapstructure:"name"` // image name template; default dvm-{workspace}-{app}:{timestamp}
+}
+
+// ContainerNetworksConfig sets the networks dvm creates for workspace
+// containers and their services.
+type ContainerNetworksConfig struct {
+	Scope string `mapstructure:"scope"` // app, domain, or none; default app
+}
+
+// NetworkConfig sets the proxy and trusted CAs for dvm's own HTTP requests.
+// See pkg/httpclient for the implementation.
+type NetworkConfig struct {
+	Proxy   string              `mapstructure:"proxy"`   // proxy URL for HTTP and HTTPS; default HTTPS_PROXY / HTTP_PROXY
+	NoProxy string              `mapstructure:"noProxy"` // comma-separated hosts that bypass the proxy; default NO_PROXY
+	CACerts []string            `mapstructure:"caCerts"` // PEM bundles trusted in addition to the system roots
+	Hosts   []NetworkHostConfig `mapstructure:"hosts"`   // per-host overrides, also applying to subdomains
+	Fetch   NetworkFetchConfig  `mapstructure:"fetch"`   // parallel fetches of nvp sync sources
+}
+
+// NetworkFetchConfig tunes the fetch pipeline of nvp's sync sources. See
+// pkg/nvimbridge/syncfetch for the implementation.
+type NetworkFetchConfig struct {
+	Workers  int     `mapstructure:"workers"`  // concurrent fetches; default 8
+	HostRate float64 `mapstructure:"hostRate"` // requests per second per host; default 10, -1 unlimited
+	Retries  int     `mapstructure:"retries"`  // retries of a failed fetch; default 3, -1 disables
+}
+
+// NetworkHostConfig overrides the network settings for one host. Hosts are a
+// list rather than a map because viper splits map keys on dots.
+type NetworkHostConfig struct {
+	Host    string   `mapstructure:"host"`    // e.g. git.internal.example.com
+	Proxy   string   `mapstructure:"proxy"`   // proxy URL, or "direct" to bypass the proxy
+	CACerts []string `mapstructure:"caCerts"` // PEM bundles trusted in addition to network.caCerts
+	Rate    float64  `mapstructure:"rate"`    // requests per second of sync fetches, overriding network.fetch.hostRate
+}
+
+// FetchOptions converts the fetch settings, with the per-host rates, for
+// pkg/nvimbridge/syncfetch.
+func (n NetworkConfig) FetchOptions() syncfetch.Options {
+	opts := syncfetch.Options{Workers: n.Fetch.Workers, HostRate: n.Fetch.HostRate, Retries: n.Fetch.Retries}
+	for _, h := range n.Hosts {
+		if h.Rate != 0 {
+			if opts.HostRates == nil {
+				opts.HostRates = make(map[string]float64)
+			}
+			opts.HostRates[h.Host] = h.Rate
+		}
+	}
+	return opts
+}
+
+// EventsConfig sends lifecycle events to webhooks and a local socket.
+// See pkg/events for the implementation.
+type EventsConfig struct {
+	Webhooks []EventWebhookConfig `mapstructure:"webhooks"` // HTTP endpoints receiving events
+	Socket   string               `mapstructure:"socket"`   // unix socket receiving one JSON event per line
+	Retries  int                  `mapstructure:"retries"`  // retries after a failed delivery; default 3, -1 disables
+}
+
+// EventWebhookConfig is one webhook receiving events.
+type EventWebhookConfig struct {
+	URL       string   `mapstructure:"url"`
+	SecretEnv string   `mapstructure:"secretEnv"` // env var holding the HMAC signing secret; unsigned when unset
+	Events    []string `mapstructure:"events"`    // event types to send, e.g. build.finished; default all
+	Format    string   `mapstructure:"format"`    // json (default) or slack
+}
+
+// EventSettings converts the events section for pkg/events, reading webhook
+// secrets from their environment variables.
+func (e EventsConfig) EventSettings() events.Settings {
+	settings := events.Settings{Retries: e.Retries}
+	if e.Socket != "" {
+		settings.Socket = expandHomeAll([]string{e.Socket})[0]
+	}
+	for _, w := range e.Webhooks {
+		hook := events.Webhook{URL: w.URL, Format: w.Format}
+		if w.SecretEnv != "" {
+			hook.Secret = os.Getenv(w.SecretEnv)
+		}
+		for _, t := range w.Events {
+			hook.Events = append(hook.Events, events.Type(t))
+		}
+		settings.Webhooks = append(settings.Webhooks, hook)
+	}
+	return settings
+}
+
+// HTTPSettings converts the network section for pkg/httpclient, expanding a
+// leading ~ in CA bundle paths.
+func (n NetworkConfig) HTTPSettings() httpclient.Settings {
+	settings := httpclient.Settings{
+		Proxy:   n.Proxy,
+		NoProxy: n.NoProxy,
+		CACerts: expandHomeAll(n.CACerts),
+	}
+	if len(n.Hosts) > 0 {
+		settings.Hosts = make(map[string]httpclient.HostSettings, len(n.Hosts))
+		for _, h := range n.Hosts {
+			settings.Hosts[h.Host] = httpclient.HostSettings{Proxy: h.Proxy, CACerts: expandHomeAll(h.CACerts)}
+		}
+	}
+	return settings
+}
+
+func expandHomeAll(paths []string) []string {
+	var expanded []string
+	for _, p := range paths {
+		if rest, ok := strings.CutPrefix(p, "~/"); ok {
+			if home, err := os.UserHomeDir(); err == nil {
+				p = filepath.Join(home, rest)
+			}
+		}
+		expanded = append(expanded, p)
+	}
+	return expanded
+}
+
 // Config represents the application configuration
 type Config struct {
-	Theme       string          `mapstructure:"theme"`       // UI theme (auto, catppuccin-mocha, etc.)
-	Credentials Credentials     `mapstructure:"credentials"` // Global credentials for builds
-	Vault       VaultConfig     `mapstructure:"vault"`       // MaestroVault configuration
-	BuildLogs   BuildLogsConfig `mapstructure:"buildLogs"`   // Build log capture / rotation
+	Theme             string                  `mapstructure:"theme"`             // UI theme (auto, catppuccin-mocha, etc.)
+	Output            string                  `mapstructure:"output"`            // Default -o format for commands that accept one
+	Credentials       Credentials             `mapstructure:"credentials"`       // Global credentials for builds
+	Vault             VaultConfig             `mapstructure:"vault"`             // MaestroVault configuration
+	BuildLogs         BuildLogsConfig         `mapstructure:"buildLogs"`         // Build log capture / rotation
+	Backup            BackupConfig            `mapstructure:"backup"`            // Scheduled database backups
+	Artifacts         ArtifactsConfig         `mapstructure:"artifacts"`         // Release artifact cache and verification
+	Images            ImagesConfig            `mapstructure:"images"`            // Workspace image naming
+	Network           NetworkConfig           `mapstructure:"network"`           // Proxy and CA settings for HTTP requests
+	Events            EventsConfig            `mapstructure:"events"`            // Lifecycle event webhooks and socket
+	ContainerNetworks ContainerNetworksConfig `mapstructure:"containerNetworks"` // Shared networks of workspace containers
 }
 
 // GetConfig returns the current configuration
@@ -75,13 +257,9 @@ func GetTheme() string {
 	return "auto"
 }
 
-// LoadConfig loads configuration from the specified path
+// LoadConfig loads the layered configuration with configPath as the user
+// config directory, see Load.
 func LoadConfig(configPath string) {
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configPath)
-	viper.AutomaticEnv()
-
 	// Set defaults
 	viper.SetDefault("theme", "auto")
 	viper.SetDefault("buildLogs.enabled", true)
@@ -90,13 +268,15 @@ func LoadConfig(configPath string) {
 	viper.SetDefault("buildLogs.maxAgeDays", 7)
 	viper.SetDefault("buildLogs.maxBackups", 10)
 	viper.SetDefault("buildLogs.compress", true)
+	viper.SetDefault("backup.enabled", false)
+	viper.SetDefault("backup.schedule", "daily")
+	viper.SetDefault("backup.retention", 7)
+	viper.SetDefault("backup.destination.type", "local")
+	viper.SetDefault("artifacts.cacheDir", "~/.devopsmaestro/cache/artifacts")
 
-	err := viper.ReadInConfig()
-	if err != nil {
-		// It's okay if config file doesn't exist, we'll use defaults
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			slog.Warn("error loading config file", "error", err)
-		}
+	// Missing files are fine, we'll use defaults
+	if err := Load(configPath); err != nil {
+		slog.Warn("error loading config file", "error", err)
 	}
 }
 
@@ -132,6 +312,18 @@ func CreateDefaultConfig(configPath string) error {
 # Default: auto (automatically adapts to your terminal's light/dark theme)
 theme: auto
 
+# Output Format
+# Default -o format for commands that take one (table, wide, json, yaml, ...).
+# An explicit -o always wins.
+#
+# Example:
+# output: wide
+
+# Layering
+# Settings here override /etc/devopsmaestro/config.yaml and are overridden by
+# ~/.devopsmaestro/ecosystems/<active ecosystem>.yaml and DVM_<KEY>
+# environment variables (e.g. DVM_RUNTIME_TYPE). See: dvm config view --resolved
+
 # Global Credentials
 # These are used during 'dvm build' for private repository access.
 # Credentials are inherited: Global -> Ecosystem -> Domain -> App -> Workspace
@@ -150,6 +342,101 @@ theme: auto
 # To store secrets in MaestroVault:
 #   mav set github-pat production "ghp_yourtoken"
 credentials: {}
+
+# Scheduled Backups
+# When enabled, dvm snapshots its database whenever a backup is due and keeps
+# the newest 'retention' archives at the destination.
+# Check with: dvm admin backup status
+#
+# Example:
+# backup:
+#   enabled: true
+#   schedule: daily          # hourly, daily, weekly, or a duration like 12h
+#   retention: 7
+#   destination:
+#     type: local            # local, s3, or git
+#     path: ~/.devopsmaestro/backups
+#     # type: s3
+#     # endpoint: https://s3.us-east-1.amazonaws.com
+#     # bucket: my-dvm-backups
+#     # prefix: laptop/
+#     # type: git
+#     # repo: git@github.com:me/dvm-backups.git
+#     # branch: main
+#   encryption:
+#     enabled: true
+#     passphraseEnv: DVM_BACKUP_PASSPHRASE
+
+# Release Artifacts
+# Builder stages download pinned releases (Neovim, lazygit, kubectl, ...) and
+# check them against SHA256 digests. With prefetch, dvm downloads them on the
+# host into a verified cache instead, so rebuilds and offline builds reuse them.
+#
+# Example:
+# artifacts:
+#   cacheDir: ~/.devopsmaestro/cache/artifacts
+#   prefetch: true
+#   offline: false           # true: fail instead of downloading a missing artifact
+#   signatures:
+#     neovim:
+#       method: cosign       # gpg or cosign
+#       url: "{url}.sig"     # {url} is the artifact URL
+#       key: ~/.config/cosign/neovim.pub
+
+# Workspace Images
+# Template for the names of workspace images. {ecosystem}, {domain}, {app},
+# and {workspace} may name the repository, which must start with "dvm-";
+# the tag must use {hash} (of the build inputs and Dockerfile, so unchanged
+# workspaces aren't rebuilt) or {timestamp}.
+# Default: dvm-{workspace}-{app}:{timestamp}
+#
+# Example:
+# images:
+#   name: "dvm-{ecosystem}-{app}-{workspace}:{hash}"
+
+# Container Networks
+# The workspaces of an app and their services share a network, created on
+# first start, so they reach each other by name. With scope: domain every app
+# of a domain shares one; scope: none leaves each workspace on the default
+# network. On Docker, workspaces are also reachable as <workspace>.<app> and
+# services as <service>.<workspace>.<app>.
+# Default: app
+#
+# Example:
+# containerNetworks:
+#   scope: domain
+
+# Network
+# dvm honors HTTPS_PROXY, HTTP_PROXY, and NO_PROXY. Settings here take
+# precedence and also apply to source fetches, artifact downloads, registry
+# probes, and GitHub API calls. Per-host overrides also match subdomains.
+#
+# Example:
+# network:
+#   proxy: http://proxy.corp.example.com:3128
+#   noProxy: localhost,.corp.example.com
+#   caCerts:
+#     - ~/.devopsmaestro/certs/corp-root.pem
+#   hosts:
+#     - host: git.internal.example.com
+#       proxy: direct
+#       caCerts:
+#         - ~/.devopsmaestro/certs/internal-ca.pem
+
+# Events
+# Lifecycle events (workspace.started, build.finished, sync.completed,
+# registry.unhealthy) are POSTed as JSON to each webhook and written as JSON
+# lines to the socket. Set secretEnv to sign requests with HMAC-SHA256.
+#
+# Example:
+# events:
+#   webhooks:
+#     - url: https://hooks.slack.com/services/T000/B000/XXXX
+#       format: slack
+#       events: [build.finished, registry.unhealthy]
+#     - url: http://localhost:9000/dvm
+#       secretEnv: DVM_WEBHOOK_SECRET
+#   socket: ~/.devopsmaestro/events.sock
 `
 
 	return os.WriteFile(configFile, []byte(defaultConfig), 0600)
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"devopsmaestro/pkg/events"
+
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -221,3 +223,66 @@ func TestConfigStruct(t *testing.T) {
 
 	assert.Equal(t, "catppuccin-latte", cfg.Theme)
 }
+
+func TestNetworkConfig_HTTPSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	n := NetworkConfig{
+		Proxy:   "http://proxy.corp:3128",
+		NoProxy: "localhost",
+		CACerts: []string{"~/certs/root.pem", "/etc/ssl/corp.pem"},
+		Hosts: []NetworkHostConfig{
+			{Host: "git.internal.example.com", Proxy: "direct", CACerts: []string{"~/certs/internal.pem"}},
+		},
+	}
+	s := n.HTTPSettings()
+
+	assert.Equal(t, "http://proxy.corp:3128", s.Proxy)
+	assert.Equal(t, "localhost", s.NoProxy)
+	assert.Equal(t, []string{filepath.Join(home, "certs/root.pem"), "/etc/ssl/corp.pem"}, s.CACerts)
+	host := s.Hosts["git.internal.example.com"]
+	assert.Equal(t, "direct", host.Proxy)
+	assert.Equal(t, []string{filepath.Join(home, "certs/internal.pem")}, host.CACerts)
+
+	assert.Nil(t, NetworkConfig{}.HTTPSettings().Hosts)
+}
+
+func TestNetworkConfig_FetchOptions(t *testing.T) {
+	n := NetworkConfig{
+		Fetch: NetworkFetchConfig{Workers: 16, Retries: -1},
+		Hosts: []NetworkHostConfig{
+			{Host: "git.internal.example.com", Proxy: "direct"},
+			{Host: "raw.githubusercontent.com", Rate: 25},
+		},
+	}
+	opts := n.FetchOptions()
+	assert.Equal(t, 16, opts.Workers)
+	assert.Equal(t, -1, opts.Retries)
+	assert.Equal(t, map[string]float64{"raw.githubusercontent.com": 25}, opts.HostRates)
+
+	assert.Nil(t, NetworkConfig{}.FetchOptions().HostRates)
+}
+
+func TestEventsConfig_EventSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cret")
+
+	e := EventsConfig{
+		Socket:  "~/events.sock",
+		Retries: 5,
+		Webhooks: []EventWebhookConfig{
+			{URL: "http://localhost:9000", SecretEnv: "TEST_WEBHOOK_SECRET", Events: []string{"build.finished"}},
+			{URL: "https://hooks.slack.com/x", Format: "slack"},
+		},
+	}
+	s := e.EventSettings()
+
+	assert.Equal(t, filepath.Join(home, "events.sock"), s.Socket)
+	assert.Equal(t, 5, s.Retries)
+	assert.Equal(t, "s3cret", s.Webhooks[0].Secret)
+	assert.Equal(t, []events.Type{events.BuildFinished}, s.Webhooks[0].Events)
+	assert.Equal(t, "slack", s.Webhooks[1].Format)
+	assert.Empty(t, s.Webhooks[1].Secret)
+}
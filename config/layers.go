@@ -0,0 +1,317 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration layers, lowest precedence first. Each layer overrides the
+// keys it sets in the layers before it; lists are replaced, not appended.
+const (
+	LayerSystem    = "system"    // SystemConfigDir/config.yaml, shared by all users
+	LayerUser      = "user"      // ~/.devopsmaestro/config.yaml
+	LayerEcosystem = "ecosystem" // ~/.devopsmaestro/ecosystems/<name>.yaml for the active ecosystem
+	LayerEnv       = "env"       // DVM_<KEY> environment variables
+)
+
+// EnvPrefix starts the environment variables that override config keys. The
+// rest of the name is the key upper-cased with dots replaced by underscores:
+// DVM_THEME sets theme, DVM_RUNTIME_TYPE sets runtime.type.
+const EnvPrefix = "DVM"
+
+// SystemConfigDir holds the system-wide config.yaml.
+var SystemConfigDir = "/etc/devopsmaestro"
+
+// Layer is one source of configuration.
+type Layer struct {
+	Name   string         // LayerSystem, LayerUser, LayerEcosystem, or LayerEnv
+	Path   string         // file the layer was read from; empty for LayerEnv
+	Values map[string]any // values by dotted key, e.g. "runtime.type"
+}
+
+// Resolved is the effective value of a key and where it came from.
+type Resolved struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  any    `json:"value" yaml:"value"`
+	Layer  string `json:"layer" yaml:"layer"`
+	Origin string `json:"origin" yaml:"origin"` // file path or environment variable
+}
+
+// extraKeys are config keys read directly through viper rather than through
+// Config, so they can still be overridden from the environment.
+var extraKeys = []string{
+	"runtime.type",
+	"runtime.platform",
+	"runtime.kubernetes.context",
+	"runtime.kubernetes.namespace",
+	"runtime.colima.profile",
+	"runtime.colima.autoStart",
+	"runtime.colima.cpus",
+	"runtime.colima.memory",
+	"runtime.colima.disk",
+	"database.type",
+	"database.path",
+	"database.host",
+	"database.port",
+	"database.name",
+	"database.username",
+	"database.password",
+	"database.sslmode",
+}
+
+var (
+	layersMu   sync.Mutex
+	fileLayers []Layer
+	envLayer   Layer
+)
+
+// Load reads the system and user config files from SystemConfigDir and
+// userDir into viper, then applies DVM_* environment overrides. Missing
+// files are skipped; an unreadable or malformed file is an error.
+func Load(userDir string) error {
+	layersMu.Lock()
+	defer layersMu.Unlock()
+
+	var loaded []Layer
+	for _, l := range []Layer{
+		{Name: LayerSystem, Path: filepath.Join(SystemConfigDir, "config.yaml")},
+		{Name: LayerUser, Path: filepath.Join(userDir, "config.yaml")},
+	} {
+		values, err := readLayer(l.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		l.Values = values
+		loaded = append(loaded, l)
+	}
+	fileLayers = loaded
+	return apply()
+}
+
+// UseEcosystem adds the layer for ecosystem from userDir/ecosystems, above
+// the user file and below the environment. An empty name removes it. Keys
+// read before the database is open, such as database.*, are not affected.
+func UseEcosystem(userDir, ecosystem string) error {
+	layersMu.Lock()
+	defer layersMu.Unlock()
+
+	layers := make([]Layer, 0, len(fileLayers)+1)
+	for _, l := range fileLayers {
+		if l.Name != LayerEcosystem {
+			layers = append(layers, l)
+		}
+	}
+	if ecosystem != "" {
+		path := EcosystemConfigFile(userDir, ecosystem)
+		values, err := readLayer(path)
+		switch {
+		case err == nil:
+			layers = append(layers, Layer{Name: LayerEcosystem, Path: path, Values: values})
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+	}
+	fileLayers = layers
+	return apply()
+}
+
+// EcosystemConfigFile is the config file layered in while ecosystem is active.
+func EcosystemConfigFile(userDir, ecosystem string) string {
+	return filepath.Join(userDir, "ecosystems", ecosystem+".yaml")
+}
+
+// Layers returns the layers applied, lowest precedence first.
+func Layers() []Layer {
+	layersMu.Lock()
+	defer layersMu.Unlock()
+
+	layers := append([]Layer{}, fileLayers...)
+	if len(envLayer.Values) > 0 {
+		layers = append(layers, envLayer)
+	}
+	return layers
+}
+
+// Resolve returns every key set by a layer with its effective value and the
+// layer that set it, sorted by key.
+func Resolve() []Resolved {
+	byKey := map[string]Resolved{}
+	for _, l := range Layers() {
+		for key, value := range l.Values {
+			origin := l.Path
+			if l.Name == LayerEnv {
+				origin = EnvVar(key)
+			}
+			byKey[strings.ToLower(key)] = Resolved{Key: key, Value: value, Layer: l.Name, Origin: origin}
+		}
+	}
+	resolved := make([]Resolved, 0, len(byKey))
+	for _, r := range byKey {
+		resolved = append(resolved, r)
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Key < resolved[j].Key })
+	return resolved
+}
+
+// EnvVar returns the environment variable that overrides key.
+func EnvVar(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// apply rebuilds viper's config from the file layers and the environment.
+// Callers hold layersMu.
+func apply() error {
+	merged := map[string]any{}
+	for _, l := range fileLayers {
+		for key, value := range l.Values {
+			setNested(merged, key, value)
+		}
+	}
+	// ReadConfig replaces what earlier calls loaded, so a removed layer
+	// leaves nothing behind
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("failed to merge config layers: %w", err)
+	}
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to merge config layers: %w", err)
+	}
+
+	// Direct viper lookups of any key see DVM_* variables; the explicit
+	// overrides below also reach Config, which viper only fills from the
+	// environment for keys it already knows.
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(envKeyReplacer)
+	viper.AutomaticEnv()
+
+	envLayer = Layer{Name: LayerEnv, Values: map[string]any{}}
+	for _, key := range knownKeys() {
+		if value, ok := os.LookupEnv(EnvVar(key)); ok && value != "" {
+			envLayer.Values[key] = value
+			viper.Set(key, value)
+		}
+	}
+	return nil
+}
+
+// knownKeys returns the keys of Config and RegistryConfig, extraKeys, and
+// every key set in a file layer.
+func knownKeys() []string {
+	seen := map[string]bool{}
+	var keys []string
+	add := func(key string) {
+		if !seen[strings.ToLower(key)] {
+			seen[strings.ToLower(key)] = true
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range structKeys("", reflect.TypeOf(Config{})) {
+		add(key)
+	}
+	for _, key := range structKeys("registry", reflect.TypeOf(RegistryConfig{})) {
+		add(key)
+	}
+	for _, key := range extraKeys {
+		add(key)
+	}
+	for _, l := range fileLayers {
+		for key := range l.Values {
+			add(key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// structKeys returns the dotted mapstructure keys of t's scalar and
+// string-list fields.
+func structKeys(prefix string, t reflect.Type) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		switch {
+		case f.Type.Kind() == reflect.Struct && f.Type != durationType:
+			keys = append(keys, structKeys(name, f.Type)...)
+		case f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.String,
+			f.Type.Kind() == reflect.String, f.Type.Kind() == reflect.Bool,
+			f.Type.Kind() >= reflect.Int && f.Type.Kind() <= reflect.Float64:
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// readLayer reads a YAML config file into dotted keys.
+func readLayer(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	values := map[string]any{}
+	flatten("", doc, values)
+	return values, nil
+}
+
+// flatten stores the leaves of m in out under dotted keys. Lists are
+// leaves; empty maps and nulls set nothing, so a placeholder such as
+// "credentials: {}" does not hide a lower layer's values.
+func flatten(prefix string, m map[string]any, out map[string]any) {
+	for k, v := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := v.(type) {
+		case nil:
+		case map[string]any:
+			flatten(key, v, out)
+		default:
+			out[key] = v
+		}
+	}
+}
+
+// setNested stores value in m under a dotted key.
+func setNested(m map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := m[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			m[p] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = value
+}
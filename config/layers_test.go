@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layeredDirs points SystemConfigDir at a temp dir for the test and returns
+// it with a user config dir.
+func layeredDirs(t *testing.T) (system, user string) {
+	t.Helper()
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+	prev := SystemConfigDir
+	SystemConfigDir = t.TempDir()
+	t.Cleanup(func() { SystemConfigDir = prev })
+	return SystemConfigDir, t.TempDir()
+}
+
+func writeYAML(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// resolvedByKey indexes Resolve() by key.
+func resolvedByKey() map[string]Resolved {
+	m := map[string]Resolved{}
+	for _, r := range Resolve() {
+		m[r.Key] = r
+	}
+	return m
+}
+
+func TestLoad_Layers(t *testing.T) {
+	system, user := layeredDirs(t)
+	writeYAML(t, filepath.Join(system, "config.yaml"), `
+theme: nord
+output: wide
+runtime:
+  type: docker
+network:
+  proxy: http://proxy.corp:3128
+`)
+	writeYAML(t, filepath.Join(user, "config.yaml"), `
+theme: dracula
+credentials: {}
+runtime:
+  type: containerd
+`)
+	writeYAML(t, EcosystemConfigFile(user, "acme"), `
+runtime:
+  type: kubernetes
+`)
+	t.Setenv("DVM_OUTPUT", "json")
+
+	require.NoError(t, Load(user))
+	assert.Equal(t, "dracula", viper.GetString("theme"))
+	assert.Equal(t, "containerd", viper.GetString("runtime.type"))
+	assert.Equal(t, "http://proxy.corp:3128", GetConfig().Network.Proxy, "an empty map must not hide lower layers")
+	assert.Equal(t, "json", GetConfig().Output)
+
+	require.NoError(t, UseEcosystem(user, "acme"))
+	assert.Equal(t, "kubernetes", viper.GetString("runtime.type"))
+
+	r := resolvedByKey()
+	assert.Equal(t, Resolved{Key: "theme", Value: "dracula", Layer: LayerUser, Origin: filepath.Join(user, "config.yaml")}, r["theme"])
+	assert.Equal(t, LayerSystem, r["network.proxy"].Layer)
+	assert.Equal(t, LayerEcosystem, r["runtime.type"].Layer)
+	assert.Equal(t, Resolved{Key: "output", Value: "json", Layer: LayerEnv, Origin: "DVM_OUTPUT"}, r["output"])
+
+	// Switching away from the ecosystem drops its values
+	require.NoError(t, UseEcosystem(user, ""))
+	assert.Equal(t, "containerd", viper.GetString("runtime.type"))
+	assert.Equal(t, LayerUser, resolvedByKey()["runtime.type"].Layer)
+}
+
+func TestLoad_EnvOverridesNestedKeys(t *testing.T) {
+	_, user := layeredDirs(t)
+	t.Setenv("DVM_BUILDLOGS_MAXSIZEMB", "5")
+	t.Setenv("DVM_RUNTIME_TYPE", "kubernetes")
+	t.Setenv("DVM_REGISTRY_PORT", "5001")
+
+	require.NoError(t, Load(user))
+	assert.Equal(t, 5, GetConfig().BuildLogs.MaxSizeMB)
+	assert.Equal(t, "kubernetes", viper.GetString("runtime.type"))
+	assert.Equal(t, 5001, GetRegistryConfig().Port)
+	assert.Equal(t, "DVM_RUNTIME_TYPE", resolvedByKey()["runtime.type"].Origin)
+}
+
+func TestLoad_MissingAndInvalidFiles(t *testing.T) {
+	_, user := layeredDirs(t)
+	require.NoError(t, Load(user), "missing files are skipped")
+	assert.Empty(t, Resolve())
+
+	writeYAML(t, filepath.Join(user, "config.yaml"), "theme: [unclosed")
+	assert.ErrorContains(t, Load(user), "failed to parse")
+
+	require.NoError(t, UseEcosystem(user, "no-such-ecosystem"))
+}
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "DVM_THEME", EnvVar("theme"))
+	assert.Equal(t, "DVM_RUNTIME_KUBERNETES_NAMESPACE", EnvVar("runtime.kubernetes.namespace"))
+	assert.Equal(t, "DVM_BUILDLOGS_MAXSIZEMB", EnvVar("buildLogs.maxSizeMB"))
+}
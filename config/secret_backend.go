@@ -17,3 +17,11 @@ type FieldCapableBackend interface {
 	SecretBackend
 	GetField(name, env, field string) (string, error)
 }
+
+// WritableBackend extends FieldCapableBackend with storing secrets, e.g. the
+// upstream tokens of dvm registry login.
+type WritableBackend interface {
+	FieldCapableBackend
+	Set(name, env, value string) error
+	SetField(name, env, field, value string) error
+}
@@ -62,5 +62,43 @@ func (v *VaultBackend) GetField(name, env, field string) (string, error) {
 	return value, nil
 }
 
+// Set stores a secret in MaestroVault, replacing its value if it exists.
+func (v *VaultBackend) Set(name, env, value string) error {
+	if name == "" {
+		return fmt.Errorf("vault secret name is required")
+	}
+	if err := v.client.Set(name, env, value, nil); err != nil {
+		return fmt.Errorf("vault store failed for %q (env=%q): %w", name, env, err)
+	}
+	return nil
+}
+
+// SetField stores a single field of a MaestroVault secret.
+func (v *VaultBackend) SetField(name, env, field, value string) error {
+	if name == "" {
+		return fmt.Errorf("vault secret name is required")
+	}
+	if field == "" {
+		return fmt.Errorf("vault field name is required")
+	}
+	if err := v.client.SetField(name, env, field, value); err != nil {
+		return fmt.Errorf("vault field store failed for %q field %q (env=%q): %w", name, field, env, err)
+	}
+	return nil
+}
+
+// OpenVaultBackend returns a VaultBackend for the resolved vault token
+// (see ResolveVaultToken), starting the MaestroVault daemon if needed.
+func OpenVaultBackend() (*VaultBackend, error) {
+	token, err := ResolveVaultToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := EnsureVaultDaemon(); err != nil {
+		return nil, err
+	}
+	return NewVaultBackend(token)
+}
+
 // Compile-time interface check
-var _ FieldCapableBackend = (*VaultBackend)(nil)
+var _ WritableBackend = (*VaultBackend)(nil)
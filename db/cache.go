@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"devopsmaestro/models"
+)
+
+// ChangeTracker is implemented by drivers that can cheaply tell whether the
+// database changed. SQLDataStore uses it to keep its read cache valid.
+type ChangeTracker interface {
+	// DataVersion returns a token that differs after any write is committed,
+	// by this process or another one sharing the database. An error means
+	// changes cannot be tracked and nothing should be cached.
+	DataVersion(ctx context.Context) (string, error)
+}
+
+// readCache is a read-through cache for hot single-row lookups (the context
+// row, hierarchy objects by ID, defaults). Entries are only valid for the
+// data version they were loaded at: when the version changes, because this
+// process or another (nvp, a second dvm) committed a write, the whole cache
+// is dropped.
+type readCache struct {
+	tracker ChangeTracker
+
+	mu      sync.Mutex
+	version string
+	entries map[string]interface{}
+}
+
+// newReadCache returns a cache for the driver, or nil (caching disabled)
+// when the driver cannot track changes.
+func newReadCache(driver Driver) *readCache {
+	tracker, ok := driver.(ChangeTracker)
+	if !ok {
+		return nil
+	}
+	return &readCache{tracker: tracker, entries: map[string]interface{}{}}
+}
+
+// cacheKey builds the key of a cached row.
+func cacheKey(kind string, id interface{}) string {
+	return fmt.Sprintf("%s:%v", kind, id)
+}
+
+// lookup returns the entry for key and the data version it was checked
+// against. An empty version means the cache cannot be used right now.
+func (c *readCache) lookup(key string) (interface{}, string, bool) {
+	if c == nil {
+		return nil, "", false
+	}
+	version, err := c.tracker.DataVersion(context.Background())
+	if err != nil {
+		return nil, "", false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version != c.version {
+		c.version = version
+		c.entries = map[string]interface{}{}
+		return nil, version, false
+	}
+	value, ok := c.entries[key]
+	return value, version, ok
+}
+
+// store records value for key if the data has not changed since version was
+// read, so a row loaded while a write committed is never cached.
+func (c *readCache) store(key, version string, value interface{}) {
+	if c == nil || version == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version == c.version {
+		c.entries[key] = value
+	}
+}
+
+// cachedRow returns a copy of the cached *T for key, loading it with load
+// on a miss. Callers get their own copy, so mutating a result never changes
+// the cache.
+func cachedRow[T any](c *readCache, key string, load func() (*T, error), clone func(T) T) (*T, error) {
+	value, version, ok := c.lookup(key)
+	if ok {
+		row := clone(value.(T))
+		return &row, nil
+	}
+	row, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, version, clone(*row))
+	return row, nil
+}
+
+// copyRow is the clone for rows whose fields are all values.
+func copyRow[T any](row T) T {
+	return row
+}
+
+// cloneContext copies a context row including its pointer fields.
+func cloneContext(c models.Context) models.Context {
+	c.ActiveEcosystemID = cloneIntPtr(c.ActiveEcosystemID)
+	c.ActiveDomainID = cloneIntPtr(c.ActiveDomainID)
+	c.ActiveSystemID = cloneIntPtr(c.ActiveSystemID)
+	c.ActiveAppID = cloneIntPtr(c.ActiveAppID)
+	c.ActiveWorkspaceID = cloneIntPtr(c.ActiveWorkspaceID)
+	return c
+}
+
+func cloneIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+)
+
+// createFileTestDataStore creates a file-backed store with the test schema
+// and a defaults table.
+func createFileTestDataStore(tb testing.TB, path string) *SQLDataStore {
+	tb.Helper()
+	driver, err := NewSQLiteDriver(DriverConfig{Type: DriverSQLite, Path: path})
+	if err != nil {
+		tb.Fatalf("NewSQLiteDriver() error = %v", err)
+	}
+	if err := driver.Connect(); err != nil {
+		tb.Fatalf("Connect() error = %v", err)
+	}
+	tb.Cleanup(func() { driver.Close() })
+	if err := createTestSchema(driver); err != nil {
+		tb.Fatalf("Failed to create test schema: %v", err)
+	}
+	if _, err := driver.Execute(`CREATE TABLE IF NOT EXISTS defaults (key TEXT PRIMARY KEY, value TEXT NOT NULL, updated_at DATETIME)`); err != nil {
+		tb.Fatalf("Failed to create defaults table: %v", err)
+	}
+	return NewSQLDataStore(driver, nil)
+}
+
+func TestReadCache_HitAndInvalidateOnWrite(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	eco := &models.Ecosystem{Name: "eco", Theme: sql.NullString{String: "tokyonight", Valid: true}}
+	if err := ds.CreateEcosystem(eco); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ds.GetEcosystemByID(eco.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ds.cache.entries[cacheKey("ecosystem", eco.ID)]; !ok {
+		t.Fatal("GetEcosystemByID did not cache the row")
+	}
+
+	// callers get copies: changing a result must not change the cache
+	got.Theme.String = "changed"
+	again, err := ds.GetEcosystemByID(eco.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Theme.String != "tokyonight" {
+		t.Errorf("cached theme = %q, want tokyonight", again.Theme.String)
+	}
+
+	eco.Theme.String = "gruvbox"
+	if err := ds.UpdateEcosystem(eco); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := ds.GetEcosystemByID(eco.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Theme.String != "gruvbox" {
+		t.Errorf("theme after update = %q, want gruvbox", updated.Theme.String)
+	}
+}
+
+func TestReadCache_ContextCopiesPointers(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	eco := &models.Ecosystem{Name: "eco"}
+	if err := ds.CreateEcosystem(eco); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.SetActiveEcosystem(&eco.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := ds.GetContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	*ctx.ActiveEcosystemID = 999
+
+	ctx, err = ds.GetContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctx.ActiveEcosystemID == nil || *ctx.ActiveEcosystemID != eco.ID {
+		t.Errorf("ActiveEcosystemID = %v, want %d", ctx.ActiveEcosystemID, eco.ID)
+	}
+}
+
+func TestReadCache_InvalidatedByTransaction(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	eco := &models.Ecosystem{Name: "eco"}
+	if err := ds.CreateEcosystem(eco); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ds.GetEcosystemByID(eco.ID); err != nil {
+		t.Fatal(err)
+	}
+	// DeleteEcosystem runs in a transaction
+	if err := ds.DeleteEcosystem(eco.Name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ds.GetEcosystemByID(eco.ID); !IsNotFound(err) {
+		t.Errorf("GetEcosystemByID after delete error = %v, want not found", err)
+	}
+}
+
+// TestReadCache_InvalidatedByOtherProcess uses two stores on one file, as
+// dvm and nvp do: a write through one must not leave the other stale.
+func TestReadCache_InvalidatedByOtherProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.db")
+	dvm := createFileTestDataStore(t, path)
+	nvp := createFileTestDataStore(t, path)
+
+	if err := dvm.SetDefault("theme", "tokyonight"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := dvm.GetDefault("theme"); got != "tokyonight" {
+		t.Fatalf("GetDefault() = %q, want tokyonight", got)
+	}
+
+	if err := nvp.SetDefault("theme", "gruvbox"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := dvm.GetDefault("theme"); got != "gruvbox" {
+		t.Errorf("GetDefault() after another store's write = %q, want gruvbox", got)
+	}
+}
+
+func TestReadCache_DisabledWithoutChangeTracker(t *testing.T) {
+	if cache := newReadCache(NewMockDriver()); cache != nil {
+		t.Error("newReadCache() should be nil for a driver without DataVersion")
+	}
+}
+
+// BenchmarkHierarchyLookups resolves a workspace's hierarchy the way theme
+// resolution does, with and without the read cache.
+func BenchmarkHierarchyLookups(b *testing.B) {
+	ds := createFileTestDataStore(b, filepath.Join(b.TempDir(), "bench.db"))
+	eco := &models.Ecosystem{Name: "eco"}
+	if err := ds.CreateEcosystem(eco); err != nil {
+		b.Fatal(err)
+	}
+	dom := &models.Domain{Name: "dom", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	if err := ds.CreateDomain(dom); err != nil {
+		b.Fatal(err)
+	}
+	app := &models.App{Name: "app", Path: "/src/app", DomainID: sql.NullInt64{Int64: int64(dom.ID), Valid: true}}
+	if err := ds.CreateApp(app); err != nil {
+		b.Fatal(err)
+	}
+	ws := &models.Workspace{Name: "dev", Slug: "eco-dom-app-dev", AppID: app.ID, ImageName: "img", Status: "stopped"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		b.Fatal(err)
+	}
+
+	resolve := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ds.GetContext(); err != nil {
+				b.Fatal(err)
+			}
+			w, err := ds.GetWorkspaceByID(ws.ID)
+			if err != nil {
+				b.Fatal(err)
+			}
+			a, err := ds.GetAppByID(w.AppID)
+			if err != nil {
+				b.Fatal(err)
+			}
+			d, err := ds.GetDomainByID(int(a.DomainID.Int64))
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ds.GetEcosystemByID(int(d.EcosystemID.Int64)); err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ds.GetDefault("theme"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("cached", resolve)
+	cache := ds.cache
+	ds.cache = nil
+	b.Run("uncached", resolve)
+	ds.cache = cache
+}
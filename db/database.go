@@ -11,7 +11,6 @@ import (
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
@@ -325,6 +324,12 @@ func RunMigrations(driver Driver, migrationsFS fs.FS) error {
 		return fmt.Errorf("failed to create migration source: %w", err)
 	}
 
+	// Count legacy projects that migrations must not drop unconverted
+	legacyProjects, err := countLegacyProjects(driver)
+	if err != nil {
+		return err
+	}
+
 	// Get the Migration DSN from the driver
 	migrationDSN := driver.MigrationDSN()
 
@@ -335,6 +340,11 @@ func RunMigrations(driver Driver, migrationsFS fs.FS) error {
 	}
 	defer m.Close()
 
+	// Stop before dropping legacy projects that haven't been converted
+	if err := migrateUpToLegacyProjects(m, legacyProjects); err != nil {
+		return err
+	}
+
 	// Apply the migrations
 	err = m.Up()
 	if err != nil && err != migrate.ErrNoChange {
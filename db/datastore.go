@@ -1,5 +1,7 @@
 package db
 
+import "context"
+
 // DataStore is the high-level interface for application data operations.
 // It composes all domain-specific sub-interfaces for backward compatibility.
 //
@@ -29,6 +31,13 @@ type DataStore interface {
 	RegistryStore
 	RegistryHistoryStore
 	CustomResourceStore
+	ArchiveStore
+	BuildTemplateStore
+	BaseImageStore
+	SyncHistoryStore
+	ManagedFieldStore
+	ResourceRevisionStore
+	LegacyProjectStore
 	BuildSessionStore
 	MigrationStore
 
@@ -38,6 +47,10 @@ type DataStore interface {
 	// Useful for advanced operations or transactions.
 	Driver() Driver
 
+	// WithContext returns a view of the DataStore whose operations run
+	// under ctx and stop when it is cancelled or its deadline passes.
+	WithContext(ctx context.Context) DataStore
+
 	// Health and Maintenance
 
 	// Close releases any resources held by the DataStore.
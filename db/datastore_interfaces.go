@@ -49,6 +49,16 @@ type EcosystemStore interface {
 
 	// CountEcosystems returns the total number of ecosystems.
 	CountEcosystems() (int, error)
+
+	// GetRuntimeEndpoint retrieves an ecosystem's remote runtime endpoint.
+	// Returns a not-found error when the ecosystem runs workspaces locally.
+	GetRuntimeEndpoint(ecosystemID int) (*models.RuntimeEndpoint, error)
+
+	// SetRuntimeEndpoint creates or replaces an ecosystem's runtime endpoint.
+	SetRuntimeEndpoint(endpoint *models.RuntimeEndpoint) error
+
+	// DeleteRuntimeEndpoint removes an ecosystem's runtime endpoint.
+	DeleteRuntimeEndpoint(ecosystemID int) error
 }
 
 // DomainStore defines operations for managing domains (bounded context within an ecosystem).
@@ -182,6 +192,16 @@ type AppStore interface {
 	// On any failure the transaction is rolled back.
 	// Returns an error if the app, target domain, or target system does not exist.
 	MoveApp(appID int, newDomainID, newSystemID sql.NullInt64) error
+
+	// GetAppSessionLayout retrieves an app's terminal multiplexer layout.
+	// Returns a not-found error when the app has none.
+	GetAppSessionLayout(appID int) (*models.AppSessionLayout, error)
+
+	// SetAppSessionLayout creates or replaces an app's multiplexer layout.
+	SetAppSessionLayout(layout *models.AppSessionLayout) error
+
+	// DeleteAppSessionLayout removes an app's multiplexer layout.
+	DeleteAppSessionLayout(appID int) error
 }
 
 // WorkspaceStore defines operations for managing workspaces.
@@ -212,6 +232,10 @@ type WorkspaceStore interface {
 	// ListAllWorkspaces retrieves all workspaces across all apps.
 	ListAllWorkspaces() ([]*models.Workspace, error)
 
+	// ListAllWorkspacesPage retrieves one page of all workspaces, in the
+	// order of ListAllWorkspaces.
+	ListAllWorkspacesPage(opts ListOptions) ([]*models.Workspace, error)
+
 	// FindWorkspaces searches for workspaces matching the given filter criteria.
 	// Returns workspaces with their full hierarchy information (ecosystem, domain, app).
 	// Use this for smart workspace resolution when the user provides partial criteria.
@@ -219,6 +243,46 @@ type WorkspaceStore interface {
 
 	// GetWorkspaceSlug returns the slug for a workspace.
 	GetWorkspaceSlug(workspaceID int) (string, error)
+
+	// RecordWorkspaceAttach sets a workspace's last-attached time to now.
+	// It does not touch updated_at, which tracks spec changes.
+	RecordWorkspaceAttach(workspaceID int) error
+
+	// ListWorkspaceLastAttached returns the last-attached time of every
+	// workspace that has been attached to, keyed by workspace ID.
+	ListWorkspaceLastAttached() (map[int]time.Time, error)
+
+	// UpdateWorkspaceStatus sets a workspace's status column, e.g. after
+	// reconciling it against the container runtime.
+	UpdateWorkspaceStatus(workspaceID int, status string) error
+
+	// SetWorkspaceContainerID records the container a workspace runs in. An
+	// empty ID clears it.
+	SetWorkspaceContainerID(workspaceID int, containerID string) error
+
+	// SetWorkspaceRebuildReason records why a workspace's image is out of
+	// date. An empty reason clears it.
+	SetWorkspaceRebuildReason(workspaceID int, reason string) error
+
+	// ListWorkspaceRebuildReasons returns the rebuild reason of every
+	// workspace with a pending rebuild, keyed by workspace ID.
+	ListWorkspaceRebuildReasons() (map[int]string, error)
+
+	// SetWorkspaceBuildInputs records the hash of the build inputs (app
+	// source and build config) a workspace's image was built from.
+	SetWorkspaceBuildInputs(workspaceID int, hash string) error
+
+	// ListWorkspaceBuildInputs returns the build inputs hash of every
+	// workspace built since it was tracked, keyed by workspace ID.
+	ListWorkspaceBuildInputs() (map[int]string, error)
+
+	// SetWorkspaceImageDigest records the digest of the image a workspace's
+	// last build produced.
+	SetWorkspaceImageDigest(workspaceID int, digest string) error
+
+	// ListWorkspaceImageDigests returns the image digest of every workspace
+	// built since it was tracked, keyed by workspace ID.
+	ListWorkspaceImageDigests() (map[int]string, error)
 }
 
 // ContextStore defines operations for active selection state tracking.
@@ -260,12 +324,22 @@ type PluginStore interface {
 	// UpsertPlugin creates or updates a plugin (by name).
 	UpsertPlugin(plugin *models.NvimPluginDB) error
 
+	// CreatePlugins inserts many plugins in one transaction.
+	CreatePlugins(plugins []*models.NvimPluginDB) error
+
+	// UpsertPlugins creates or updates many plugins (by name) in one transaction.
+	UpsertPlugins(plugins []*models.NvimPluginDB) error
+
 	// DeletePlugin removes a plugin by name.
 	DeletePlugin(name string) error
 
 	// ListPlugins retrieves all plugins.
 	ListPlugins() ([]*models.NvimPluginDB, error)
 
+	// ListPluginsPage retrieves one page of plugins, in the order of
+	// ListPlugins.
+	ListPluginsPage(opts ListOptions) ([]*models.NvimPluginDB, error)
+
 	// ListPluginsByCategory retrieves plugins filtered by category.
 	ListPluginsByCategory(category string) ([]*models.NvimPluginDB, error)
 
@@ -283,6 +357,14 @@ type PluginStore interface {
 
 	// SetWorkspacePluginEnabled enables or disables a plugin for a workspace.
 	SetWorkspacePluginEnabled(workspaceID int, pluginID int, enabled bool) error
+
+	// CountOrphanedWorkspacePlugins counts workspace_plugins rows whose
+	// plugin or workspace no longer exists.
+	CountOrphanedWorkspacePlugins() (int, error)
+
+	// DeleteOrphanedWorkspacePlugins removes workspace_plugins rows whose
+	// plugin or workspace no longer exists. Returns the number removed.
+	DeleteOrphanedWorkspacePlugins() (int64, error)
 }
 
 // ThemeStore defines operations for managing nvim themes.
@@ -513,6 +595,9 @@ type NvimPackageStore interface {
 	// UpsertPackage creates or updates an nvim package (by name).
 	UpsertPackage(pkg *models.NvimPackageDB) error
 
+	// UpsertPackages creates or updates many nvim packages (by name) in one transaction.
+	UpsertPackages(pkgs []*models.NvimPackageDB) error
+
 	// DeletePackage removes a package by name.
 	DeletePackage(name string) error
 
@@ -643,6 +728,112 @@ type CustomResourceStore interface {
 	ListCustomResources(kind string) ([]*models.CustomResource, error)
 }
 
+// ArchiveStore defines operations for archiving ecosystems, domains, and
+// apps. Archived resources keep their data but are hidden from default
+// listings until restored or deleted.
+type ArchiveStore interface {
+	// ArchiveResource marks an ecosystem, domain, or app (by models.ArchiveKind*
+	// and ID) as archived now.
+	ArchiveResource(kind string, id int) error
+
+	// RestoreResource clears the archived mark of an ecosystem, domain, or app.
+	RestoreResource(kind string, id int) error
+
+	// ListArchivedResources retrieves the archived ecosystems, domains, and
+	// apps, oldest archive first.
+	ListArchivedResources() ([]*models.ArchivedResource, error)
+
+	// GetArchivedIDs returns the IDs of the resources hidden by archiving:
+	// archived ones and everything below them.
+	GetArchivedIDs() (*models.ArchivedIDs, error)
+}
+
+// BuildTemplateStore defines operations for managing build templates.
+type BuildTemplateStore interface {
+	// CreateBuildTemplate inserts a new build template.
+	CreateBuildTemplate(tmpl *models.BuildTemplate) error
+
+	// UpdateBuildTemplate updates an existing build template (by name).
+	UpdateBuildTemplate(tmpl *models.BuildTemplate) error
+
+	// GetBuildTemplate retrieves a build template by name.
+	GetBuildTemplate(name string) (*models.BuildTemplate, error)
+
+	// ListBuildTemplates retrieves all build templates ordered by name.
+	ListBuildTemplates() ([]*models.BuildTemplate, error)
+
+	// DeleteBuildTemplate removes a build template by name.
+	DeleteBuildTemplate(name string) error
+}
+
+// BaseImageStore defines operations for shared per-language base images,
+// one per language version.
+type BaseImageStore interface {
+	// SetBaseImage records a built base image, replacing the one recorded
+	// for its language version.
+	SetBaseImage(img *models.BaseImage) error
+
+	// GetBaseImage retrieves the base image of a language version.
+	GetBaseImage(language, version string) (*models.BaseImage, error)
+
+	// ListBaseImages retrieves all base images ordered by language and
+	// version.
+	ListBaseImages() ([]*models.BaseImage, error)
+
+	// DeleteBaseImage removes the base image of a language version.
+	DeleteBaseImage(language, version string) error
+}
+
+// SyncHistoryStore defines operations for the history of nvp source syncs.
+type SyncHistoryStore interface {
+	// CreateSyncHistory records a sync run.
+	CreateSyncHistory(history *models.SyncHistory) error
+
+	// GetSyncHistory retrieves a sync run by ID.
+	GetSyncHistory(id int) (*models.SyncHistory, error)
+
+	// MarkSyncHistoryRolledBack records that a sync run was rolled back.
+	MarkSyncHistoryRolledBack(id int) error
+
+	// ListSyncHistory retrieves sync runs newest first, only those of source
+	// when it is not empty, and at most limit when limit is positive.
+	ListSyncHistory(source string, limit int) ([]*models.SyncHistory, error)
+}
+
+// ManagedFieldStore defines operations for field managers, the record of
+// which writer (a manifest apply, a CLI command, or a sync) last set each
+// field of a resource.
+type ManagedFieldStore interface {
+	// ListManagedFields retrieves the recorded fields of every resource of
+	// kind named name, whatever its parents, ordered by scope and field.
+	ListManagedFields(kind, name string) ([]*models.ManagedField, error)
+
+	// ReplaceManagedFields replaces the recorded fields of the resource of
+	// kind named name within scope with fields.
+	ReplaceManagedFields(kind, name, scope string, fields []*models.ManagedField) error
+
+	// DeleteManagedFields removes the recorded fields of every resource of
+	// kind named name.
+	DeleteManagedFields(kind, name string) error
+}
+
+// ResourceRevisionStore defines operations for the revision history of
+// resources, recorded on every apply.
+type ResourceRevisionStore interface {
+	// CreateResourceRevision records rev as the next revision of its
+	// resource, setting rev.Revision, and removes all but the newest keep
+	// revisions.
+	CreateResourceRevision(rev *models.ResourceRevision, keep int) error
+
+	// ListResourceRevisions retrieves the revisions of the resource of kind
+	// named name within scope, oldest first.
+	ListResourceRevisions(kind, name, scope string) ([]*models.ResourceRevision, error)
+
+	// DeleteResourceRevisions removes the revisions of every resource of
+	// kind named name.
+	DeleteResourceRevisions(kind, name string) error
+}
+
 // BuildSessionStore defines operations for managing build session persistence.
 // Build sessions track batches of workspace builds with per-workspace status.
 type BuildSessionStore interface {
@@ -674,9 +865,34 @@ type BuildSessionStore interface {
 	// GetBuildSessionWorkspaces retrieves all workspace entries for a build session.
 	GetBuildSessionWorkspaces(sessionID string) ([]*models.BuildSessionWorkspace, error)
 
+	// GetLatestBuildSessionWorkspace retrieves the workspace's entry in the
+	// most recent build session that included it. Returns nil, nil when the
+	// workspace has never been built in a session.
+	GetLatestBuildSessionWorkspace(workspaceID int) (*models.BuildSessionWorkspace, error)
+
+	// SetBuildSessionWorkspaceSBOM records the SBOM of a workspace entry's image.
+	SetBuildSessionWorkspaceSBOM(id int, sbom string) error
+
+	// GetLatestBuildSBOM retrieves the workspace's most recent entry that
+	// recorded an SBOM, or nil when there is none.
+	GetLatestBuildSBOM(workspaceID int) (*models.BuildSessionWorkspace, error)
+
 	// GetBuildSessionStats returns the succeeded and failed counts for a build session.
 	GetBuildSessionStats(sessionID string) (succeeded int, failed int, err error)
 
 	// UpdateWorkspaceImage updates the image_name field of a workspace by ID.
 	UpdateWorkspaceImage(workspaceID int, imageTag string) error
 }
+
+// LegacyProjectStore defines operations for converting the projects of
+// databases created before apps replaced them.
+type LegacyProjectStore interface {
+	// ListLegacyProjects retrieves the rows of the legacy projects table,
+	// ordered by name, or none when the database has no such table.
+	ListLegacyProjects() ([]*models.LegacyProject, error)
+
+	// RetireLegacyProject moves the workspaces and active context that
+	// reference the legacy project projectID to the app appID, and deletes
+	// the project.
+	RetireLegacyProject(projectID, appID int) error
+}
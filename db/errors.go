@@ -49,3 +49,62 @@ func IsUniqueViolation(err error) bool {
 	var target *ErrUniqueViolation
 	return errors.As(err, &target)
 }
+
+// ErrConflict indicates a write conflicts with existing data, such as
+// creating a resource whose name is already taken.
+type ErrConflict struct {
+	Resource string
+	Key      interface{}
+	Err      error
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("%s '%v' already exists", e.Resource, e.Key)
+}
+
+func (e *ErrConflict) Unwrap() error {
+	return e.Err
+}
+
+// NewErrConflict creates a new ErrConflict error wrapping the underlying
+// database error, if any.
+func NewErrConflict(resource string, key interface{}, err error) error {
+	return &ErrConflict{Resource: resource, Key: key, Err: err}
+}
+
+// IsConflict checks if an error is an ErrConflict or a unique constraint
+// violation.
+func IsConflict(err error) bool {
+	var target *ErrConflict
+	return errors.As(err, &target) || IsUniqueViolation(err)
+}
+
+// asConflict returns an ErrConflict for the resource if err is a unique or
+// primary key constraint failure, and err unchanged otherwise.
+func asConflict(err error, resource string, key interface{}) error {
+	if isConstraintConflict(err) {
+		return NewErrConflict(resource, key, err)
+	}
+	return err
+}
+
+// ErrNoActiveContext indicates a command needs an active resource (set with
+// 'dvm use') but none is set.
+type ErrNoActiveContext struct {
+	Resource string
+}
+
+func (e *ErrNoActiveContext) Error() string {
+	return fmt.Sprintf("no active %s context", e.Resource)
+}
+
+// NewErrNoActiveContext creates a new ErrNoActiveContext error.
+func NewErrNoActiveContext(resource string) error {
+	return &ErrNoActiveContext{Resource: resource}
+}
+
+// IsNoActiveContext checks if an error is an ErrNoActiveContext.
+func IsNoActiveContext(err error) bool {
+	var target *ErrNoActiveContext
+	return errors.As(err, &target)
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+
+	"devopsmaestro/models"
 )
 
 // =============================================================================
@@ -178,3 +180,63 @@ func TestErrNotFound_TypeAssertion(t *testing.T) {
 		}
 	}
 }
+
+// =============================================================================
+// ErrConflict Tests
+// =============================================================================
+
+func TestErrConflict(t *testing.T) {
+	cause := errors.New("UNIQUE constraint failed: ecosystems.name")
+	err := fmt.Errorf("failed to create ecosystem: %w", NewErrConflict("ecosystem", "prod", cause))
+
+	if got, want := err.Error(), "failed to create ecosystem: ecosystem 'prod' already exists"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !IsConflict(err) {
+		t.Error("IsConflict() should be true for a wrapped ErrConflict")
+	}
+	if !errors.Is(err, cause) {
+		t.Error("ErrConflict should unwrap to the database error")
+	}
+	if !IsConflict(NewErrUniqueViolation("name", "prod")) {
+		t.Error("IsConflict() should be true for a unique violation")
+	}
+	if IsConflict(NewErrNotFound("ecosystem", "prod")) {
+		t.Error("IsConflict() should be false for ErrNotFound")
+	}
+}
+
+func TestCreateEcosystem_DuplicateIsConflict(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	if err := ds.CreateEcosystem(&models.Ecosystem{Name: "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	err := ds.CreateEcosystem(&models.Ecosystem{Name: "prod"})
+	var conflict *ErrConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("CreateEcosystem() duplicate error = %v, want ErrConflict", err)
+	}
+	if conflict.Resource != "ecosystem" || conflict.Key != "prod" {
+		t.Errorf("ErrConflict = %+v, want ecosystem 'prod'", conflict)
+	}
+}
+
+// =============================================================================
+// ErrNoActiveContext Tests
+// =============================================================================
+
+func TestErrNoActiveContext(t *testing.T) {
+	err := fmt.Errorf("resolve workspace: %w", NewErrNoActiveContext("workspace"))
+
+	if got, want := err.Error(), "resolve workspace: no active workspace context"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !IsNoActiveContext(err) {
+		t.Error("IsNoActiveContext() should be true for a wrapped ErrNoActiveContext")
+	}
+	if IsNoActiveContext(NewErrNotFound("workspace", "dev")) {
+		t.Error("IsNoActiveContext() should be false for ErrNotFound")
+	}
+}
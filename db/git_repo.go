@@ -257,7 +257,7 @@ func (ds *SQLDataStore) ListGitRepos() ([]models.GitRepoDB, error) {
 func (ds *SQLDataStore) ListAppsByGitRepoID(gitRepoID int64) ([]*models.App, error) {
 	query := `
 		SELECT id, domain_id, system_id, name, path, description, theme, nvim_package,
-		       terminal_package, language, build_config, git_repo_id,
+		       terminal_package, language, build_config, git_repo_id, env,
 		       created_at, updated_at
 		FROM apps
 		WHERE git_repo_id = ?
@@ -285,6 +285,7 @@ func (ds *SQLDataStore) ListAppsByGitRepoID(gitRepoID int64) ([]*models.App, err
 			&app.Language,
 			&app.BuildConfig,
 			&app.GitRepoID,
+			&app.Env,
 			&app.CreatedAt,
 			&app.UpdatedAt,
 		)
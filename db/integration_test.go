@@ -507,6 +507,7 @@ func createIntegrationSchema(driver Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -520,6 +521,7 @@ func createIntegrationSchema(driver Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id),
@@ -564,6 +566,7 @@ func createIntegrationSchema(driver Driver) error {
 			language TEXT,
 			build_config TEXT,
 			git_repo_id INTEGER,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (domain_id) REFERENCES domains(id),
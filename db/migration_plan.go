@@ -0,0 +1,265 @@
+package db
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// Migration is one migration shipped with this build.
+type Migration struct {
+	// Version is the number the migration files start with.
+	Version uint
+
+	// Name is the description part of the file name ("add_vault_fields").
+	Name string
+
+	// UpFile and DownFile are the file names; DownFile is empty when the
+	// migration cannot be rolled back.
+	UpFile   string
+	DownFile string
+}
+
+// MigrationStatus compares a database's schema version with the migrations
+// shipped with this build.
+type MigrationStatus struct {
+	// Current is the applied version, or 0 when no migration has run.
+	Current uint
+
+	// Dirty is set when a migration failed part-way through.
+	Dirty bool
+
+	// Latest is the newest migration shipped with this build.
+	Latest uint
+
+	// Pending lists the migrations newer than Current, oldest first.
+	Pending []Migration
+}
+
+// MigrationStep is one migration in a plan, with the SQL it runs.
+type MigrationStep struct {
+	Migration
+
+	// Direction is "up" or "down".
+	Direction string
+
+	// File is the migration file run by this step.
+	File string
+
+	// SQL is the contents of the migration file run by this step.
+	SQL string
+}
+
+// migrationsSubFS returns the migrations directory for the driver's database type.
+func migrationsSubFS(driver Driver, migrationsFS fs.FS) (fs.FS, error) {
+	if driver == nil {
+		return nil, fmt.Errorf("driver is nil")
+	}
+	dbType := string(driver.Type())
+	if dbType == string(DriverMemory) {
+		dbType = "sqlite" // Memory driver uses sqlite migrations
+	}
+	subFS, err := fs.Sub(migrationsFS, dbType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrations subdirectory for %s: %w", dbType, err)
+	}
+	return subFS, nil
+}
+
+// newMigrate opens golang-migrate on the driver's database.
+func newMigrate(driver Driver, subFS fs.FS) (*migrate.Migrate, error) {
+	sourceDriver, err := iofs.New(subFS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration source: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, driver.MigrationDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	return m, nil
+}
+
+// listMigrations reads the migration files in subFS, oldest first.
+func listMigrations(subFS fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(subFS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		filename := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(filename, ".sql") {
+			continue
+		}
+		base, direction := strings.TrimSuffix(filename, ".sql"), ""
+		switch {
+		case strings.HasSuffix(base, ".up"):
+			base, direction = strings.TrimSuffix(base, ".up"), "up"
+		case strings.HasSuffix(base, ".down"):
+			base, direction = strings.TrimSuffix(base, ".down"), "down"
+		default:
+			continue
+		}
+		versionStr, name, ok := strings.Cut(base, "_")
+		if !ok {
+			continue
+		}
+		version, err := parseVersionNumber(versionStr)
+		if err != nil {
+			continue
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.UpFile = filename
+		} else {
+			m.DownFile = filename
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpFile != "" {
+			migrations = append(migrations, *m)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// GetMigrationStatus reports the database's schema version and the
+// migrations this build would apply to it.
+func GetMigrationStatus(driver Driver, migrationsFS fs.FS) (*MigrationStatus, error) {
+	subFS, err := migrationsSubFS(driver, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := listMigrations(subFS)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newMigrate(driver, subFS)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	status := &MigrationStatus{}
+	current, dirty, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, fmt.Errorf("failed to get current database version: %w", err)
+	}
+	status.Current, status.Dirty = current, dirty
+
+	for _, mig := range migrations {
+		if mig.Version > status.Current {
+			status.Pending = append(status.Pending, mig)
+		}
+		status.Latest = mig.Version
+	}
+	return status, nil
+}
+
+// PlanMigrations returns the steps 'dvm admin migrate' would run, with
+// their SQL, without changing the database.
+func PlanMigrations(driver Driver, migrationsFS fs.FS) ([]MigrationStep, error) {
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+	subFS, err := migrationsSubFS(driver, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]MigrationStep, 0, len(status.Pending))
+	for _, mig := range status.Pending {
+		sql, err := fs.ReadFile(subFS, mig.UpFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", mig.UpFile, err)
+		}
+		steps = append(steps, MigrationStep{Migration: mig, Direction: "up", File: mig.UpFile, SQL: string(sql)})
+	}
+	return steps, nil
+}
+
+// PlanMigrateDown returns the down steps that take the database from its
+// current version back to version to, newest first. The target must be a
+// shipped migration older than the current version, and every migration
+// above it must have a down file.
+func PlanMigrateDown(driver Driver, migrationsFS fs.FS, to uint) ([]MigrationStep, error) {
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("database is in dirty state at version %d - fix the failed migration before rolling back", status.Current)
+	}
+	if to >= status.Current {
+		return nil, fmt.Errorf("target version %d is not older than the current version %d", to, status.Current)
+	}
+
+	subFS, err := migrationsSubFS(driver, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := listMigrations(subFS)
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	var steps []MigrationStep
+	for i := len(migrations) - 1; i >= 0; i-- {
+		mig := migrations[i]
+		if mig.Version == to {
+			found = true
+		}
+		if mig.Version <= to || mig.Version > status.Current {
+			continue
+		}
+		if mig.DownFile == "" {
+			return nil, fmt.Errorf("migration %d (%s) has no down migration", mig.Version, mig.Name)
+		}
+		sql, err := fs.ReadFile(subFS, mig.DownFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", mig.DownFile, err)
+		}
+		steps = append(steps, MigrationStep{Migration: mig, Direction: "down", File: mig.DownFile, SQL: string(sql)})
+	}
+	if !found {
+		return nil, fmt.Errorf("version %d is not a known migration", to)
+	}
+	return steps, nil
+}
+
+// MigrateDown rolls the database back to version to by running the down
+// migrations PlanMigrateDown lists.
+func MigrateDown(driver Driver, migrationsFS fs.FS, to uint) error {
+	if _, err := PlanMigrateDown(driver, migrationsFS, to); err != nil {
+		return err
+	}
+	subFS, err := migrationsSubFS(driver, migrationsFS)
+	if err != nil {
+		return err
+	}
+	m, err := newMigrate(driver, subFS)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Migrate(to); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("failed to roll back migrations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMigrationTestDriver(t *testing.T) (Driver, fs.FS) {
+	t.Helper()
+	driver, err := NewSQLiteDriver(DriverConfig{Type: DriverSQLite, Path: filepath.Join(t.TempDir(), "test.db")})
+	require.NoError(t, err)
+	require.NoError(t, driver.Connect())
+	t.Cleanup(func() { driver.Close() })
+
+	migrationsSubFS, err := fs.Sub(testMigrationsFS, "migrations")
+	require.NoError(t, err)
+	return driver, migrationsSubFS
+}
+
+func TestGetMigrationStatus(t *testing.T) {
+	driver, migrationsFS := newMigrationTestDriver(t)
+
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Zero(t, status.Current)
+	assert.False(t, status.Dirty)
+	require.NotEmpty(t, status.Pending)
+	assert.Equal(t, uint(1), status.Pending[0].Version)
+	assert.Equal(t, status.Latest, status.Pending[len(status.Pending)-1].Version)
+
+	require.NoError(t, RunMigrations(driver, migrationsFS))
+	status, err = GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, status.Latest, status.Current)
+	assert.Empty(t, status.Pending)
+}
+
+func TestPlanMigrations(t *testing.T) {
+	driver, migrationsFS := newMigrationTestDriver(t)
+
+	steps, err := PlanMigrations(driver, migrationsFS)
+	require.NoError(t, err)
+	require.NotEmpty(t, steps)
+	assert.Equal(t, "up", steps[0].Direction)
+	assert.True(t, strings.HasSuffix(steps[0].File, ".up.sql"))
+	assert.Contains(t, steps[0].SQL, "CREATE TABLE")
+
+	// planning must not touch the database
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Zero(t, status.Current)
+}
+
+func TestMigrateDown(t *testing.T) {
+	driver, migrationsFS := newMigrationTestDriver(t)
+	require.NoError(t, RunMigrations(driver, migrationsFS))
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	latest := status.Latest
+	to := latest - 2
+
+	steps, err := PlanMigrateDown(driver, migrationsFS, to)
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.Equal(t, latest, steps[0].Version, "newest migration is rolled back first")
+	assert.Equal(t, "down", steps[0].Direction)
+	assert.True(t, strings.HasSuffix(steps[0].File, ".down.sql"))
+
+	require.NoError(t, MigrateDown(driver, migrationsFS, to))
+	status, err = GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, to, status.Current)
+	assert.Len(t, status.Pending, 2)
+
+	// migrating up again restores the latest schema
+	require.NoError(t, RunMigrations(driver, migrationsFS))
+	status, err = GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, latest, status.Current)
+}
+
+func TestPlanMigrateDown_Rejects(t *testing.T) {
+	driver, migrationsFS := newMigrationTestDriver(t)
+	require.NoError(t, RunMigrations(driver, migrationsFS))
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+
+	_, err = PlanMigrateDown(driver, migrationsFS, status.Current)
+	assert.ErrorContains(t, err, "not older than the current version")
+
+	_, err = PlanMigrateDown(driver, migrationsFS, 0)
+	assert.ErrorContains(t, err, "not a known migration")
+
+	_, err = driver.Execute("UPDATE schema_migrations SET dirty = 1")
+	require.NoError(t, err)
+	_, err = PlanMigrateDown(driver, migrationsFS, 1)
+	assert.ErrorContains(t, err, "dirty state")
+}
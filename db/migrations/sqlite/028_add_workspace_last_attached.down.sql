@@ -0,0 +1,3 @@
+-- Remove last_attached_at column from workspaces table
+
+ALTER TABLE workspaces DROP COLUMN last_attached_at;
@@ -0,0 +1,4 @@
+-- Add last_attached_at to workspaces, set by dvm attach/shell/exec
+-- NULL means the workspace has never been attached to
+
+ALTER TABLE workspaces ADD COLUMN last_attached_at DATETIME;
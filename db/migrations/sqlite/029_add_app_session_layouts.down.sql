@@ -0,0 +1,3 @@
+-- Remove per-app terminal multiplexer layouts
+
+DROP TABLE IF EXISTS app_session_layouts;
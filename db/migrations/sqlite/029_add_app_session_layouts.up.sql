@@ -0,0 +1,9 @@
+-- Per-app terminal multiplexer layouts used by dvm attach
+-- spec holds the layout YAML (multiplexer + windows)
+
+CREATE TABLE IF NOT EXISTS app_session_layouts (
+    app_id INTEGER PRIMARY KEY REFERENCES apps(id) ON DELETE CASCADE,
+    spec TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Remove per-ecosystem remote runtime endpoints
+
+DROP TABLE IF EXISTS ecosystem_runtime_endpoints;
@@ -0,0 +1,11 @@
+-- Per-ecosystem remote runtime endpoints (SSH Docker or nerdctl hosts)
+-- used to build and run the ecosystem's workspaces
+
+CREATE TABLE IF NOT EXISTS ecosystem_runtime_endpoints (
+    ecosystem_id INTEGER PRIMARY KEY REFERENCES ecosystems(id) ON DELETE CASCADE,
+    type TEXT NOT NULL DEFAULT 'docker',
+    host TEXT NOT NULL,
+    sync_dir TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Remove sbom column from build_session_workspaces table
+
+ALTER TABLE build_session_workspaces DROP COLUMN sbom;
@@ -0,0 +1,5 @@
+-- Add sbom to build_session_workspaces: the JSON inventory of a successful
+-- image build, exported as SPDX or CycloneDX by dvm build sbom
+-- NULL means no SBOM was recorded (failed, skipped, or older builds)
+
+ALTER TABLE build_session_workspaces ADD COLUMN sbom TEXT;
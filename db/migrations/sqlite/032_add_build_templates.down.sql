@@ -0,0 +1,4 @@
+-- Remove build templates and pending workspace rebuilds
+
+ALTER TABLE workspaces DROP COLUMN rebuild_reason;
+DROP TABLE IF EXISTS build_templates;
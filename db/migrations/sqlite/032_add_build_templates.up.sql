@@ -0,0 +1,17 @@
+-- Named build templates that apps reference with spec.build.template
+-- build_config holds the template's build settings as JSON (same shape as
+-- an app's build_config)
+
+CREATE TABLE IF NOT EXISTS build_templates (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT,
+    build_config TEXT NOT NULL DEFAULT '{}',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Why a workspace's image is out of date, e.g. its build template changed
+-- NULL means no rebuild is pending; a successful build clears it
+
+ALTER TABLE workspaces ADD COLUMN rebuild_reason TEXT;
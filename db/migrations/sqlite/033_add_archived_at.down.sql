@@ -0,0 +1,5 @@
+-- Remove archived_at from ecosystems, domains, and apps
+
+ALTER TABLE apps DROP COLUMN archived_at;
+ALTER TABLE domains DROP COLUMN archived_at;
+ALTER TABLE ecosystems DROP COLUMN archived_at;
@@ -0,0 +1,7 @@
+-- Add archived_at to ecosystems, domains, and apps, set by dvm archive
+-- NULL means the resource is not archived. dvm gc deletes resources that
+-- have been archived for longer than its --archive-retention
+
+ALTER TABLE ecosystems ADD COLUMN archived_at DATETIME;
+ALTER TABLE domains ADD COLUMN archived_at DATETIME;
+ALTER TABLE apps ADD COLUMN archived_at DATETIME;
@@ -0,0 +1,5 @@
+-- Remove env from ecosystems, domains, and apps
+
+ALTER TABLE apps DROP COLUMN env;
+ALTER TABLE domains DROP COLUMN env;
+ALTER TABLE ecosystems DROP COLUMN env;
@@ -0,0 +1,7 @@
+-- Add env to ecosystems, domains, and apps: JSON objects of environment
+-- variables layered under each workspace's own env at container start
+-- NULL means the resource sets no environment variables
+
+ALTER TABLE ecosystems ADD COLUMN env TEXT;
+ALTER TABLE domains ADD COLUMN env TEXT;
+ALTER TABLE apps ADD COLUMN env TEXT;
@@ -0,0 +1,4 @@
+-- Remove nvp sync history
+
+DROP INDEX IF EXISTS idx_sync_history_source;
+DROP TABLE IF EXISTS sync_history;
@@ -0,0 +1,23 @@
+-- History of nvp source sync runs, shown by nvp sync history/show
+-- result holds the synced plugin and package names, changes the per-plugin
+-- diff summary, and errors the run's error messages, all as JSON
+
+CREATE TABLE IF NOT EXISTS sync_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source TEXT NOT NULL,
+    version TEXT,
+    dry_run BOOLEAN NOT NULL DEFAULT 0,
+    total_available INTEGER NOT NULL DEFAULT 0,
+    total_synced INTEGER NOT NULL DEFAULT 0,
+    created_count INTEGER NOT NULL DEFAULT 0,
+    updated_count INTEGER NOT NULL DEFAULT 0,
+    error_count INTEGER NOT NULL DEFAULT 0,
+    result TEXT NOT NULL DEFAULT '{}',
+    changes TEXT NOT NULL DEFAULT '[]',
+    errors TEXT NOT NULL DEFAULT '[]',
+    started_at DATETIME NOT NULL,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_history_source ON sync_history(source);
@@ -0,0 +1,4 @@
+-- Remove nvp sync rollback snapshots
+
+ALTER TABLE sync_history DROP COLUMN rolled_back_at;
+ALTER TABLE sync_history DROP COLUMN snapshot;
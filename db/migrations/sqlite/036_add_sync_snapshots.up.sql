@@ -0,0 +1,7 @@
+-- Snapshots for nvp sync rollback
+-- snapshot holds each plugin and package file a sync wrote, with its content
+-- before and after the sync, as JSON keyed by path; rolled_back_at is set by
+-- nvp sync rollback (NULL means the sync has not been rolled back)
+
+ALTER TABLE sync_history ADD COLUMN snapshot TEXT NOT NULL DEFAULT '{}';
+ALTER TABLE sync_history ADD COLUMN rolled_back_at DATETIME;
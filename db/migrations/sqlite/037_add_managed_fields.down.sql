@@ -0,0 +1,4 @@
+-- Remove field managers
+
+DROP INDEX IF EXISTS idx_managed_fields_resource;
+DROP TABLE IF EXISTS managed_fields;
@@ -0,0 +1,19 @@
+-- Field managers: which writer last set each field of a resource
+-- scope holds the resource's parents as ecosystem/domain/system/app; field is
+-- a dotted path such as spec.build.args.FOO; manager is manifest, cli, or
+-- sync ('' for fields no manager set, such as defaults); value is the field's
+-- value as JSON
+
+CREATE TABLE IF NOT EXISTS managed_fields (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    field TEXT NOT NULL,
+    manager TEXT NOT NULL DEFAULT '',
+    value TEXT NOT NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(kind, name, scope, field)
+);
+
+CREATE INDEX IF NOT EXISTS idx_managed_fields_resource ON managed_fields(kind, name);
@@ -0,0 +1,4 @@
+-- Remove resource revision history
+
+DROP INDEX IF EXISTS idx_resource_revisions_resource;
+DROP TABLE IF EXISTS resource_revisions;
@@ -0,0 +1,19 @@
+-- Revision history of resources, shown by dvm history/diff and restored by
+-- dvm rollback
+-- content is the resource's YAML after an apply; scope holds its parents as
+-- ecosystem/domain/system/app; revision counts up per resource and only the
+-- newest revisions are kept
+
+CREATE TABLE IF NOT EXISTS resource_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    scope TEXT NOT NULL DEFAULT '',
+    revision INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    manager TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(kind, name, scope, revision)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_revisions_resource ON resource_revisions(kind, name);
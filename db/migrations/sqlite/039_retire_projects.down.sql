@@ -0,0 +1,6 @@
+-- Revert Migration 039: nothing to restore
+-- The projects table and active_project_id only existed in databases created
+-- before apps replaced projects, and their rows were converted to apps, so
+-- the current schema stays as it is.
+
+SELECT 1;
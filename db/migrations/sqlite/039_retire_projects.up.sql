@@ -0,0 +1,26 @@
+-- Retire the legacy projects table and context.active_project_id
+-- Projects were replaced by apps, but databases created before then can still
+-- hold them. dvm admin migrate-projects converts the remaining projects to
+-- apps first; this migration does not run while any remain (see
+-- db.RetireProjectsMigration).
+--
+-- The context table is rebuilt with its current columns, which drops
+-- active_project_id where an old database still has it.
+
+DROP TABLE IF EXISTS projects;
+
+CREATE TABLE context_new (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    active_workspace_id INTEGER,
+    active_ecosystem_id INTEGER REFERENCES ecosystems(id) ON DELETE SET NULL,
+    active_domain_id INTEGER REFERENCES domains(id) ON DELETE SET NULL,
+    active_app_id INTEGER REFERENCES apps(id) ON DELETE SET NULL,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    active_system_id INTEGER REFERENCES systems(id) ON DELETE SET NULL,
+    FOREIGN KEY (active_workspace_id) REFERENCES workspaces(id) ON DELETE SET NULL
+);
+
+INSERT INTO context_new (id, active_workspace_id, active_ecosystem_id, active_domain_id, active_app_id, updated_at, active_system_id)
+    SELECT id, active_workspace_id, active_ecosystem_id, active_domain_id, active_app_id, updated_at, active_system_id FROM context;
+DROP TABLE context;
+ALTER TABLE context_new RENAME TO context;
@@ -0,0 +1,3 @@
+-- Remove the workspace build inputs hash
+
+ALTER TABLE workspaces DROP COLUMN build_inputs_hash;
@@ -0,0 +1,5 @@
+-- Content hash of what a workspace's image was last built from: the app
+-- source (the Docker build context) and its build config
+-- NULL means the workspace hasn't been built since this was tracked
+
+ALTER TABLE workspaces ADD COLUMN build_inputs_hash TEXT;
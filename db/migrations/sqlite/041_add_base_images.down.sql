@@ -0,0 +1,3 @@
+-- Remove shared base images
+
+DROP TABLE IF EXISTS base_images;
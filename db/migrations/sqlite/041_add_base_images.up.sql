@@ -0,0 +1,17 @@
+-- Shared per-language base images, built by dvm base-image build for
+-- workspace builds to start from
+-- image is the local tag; registry_ref the image pushed to the local registry
+-- ('' when it wasn't pushed); dockerfile_hash identifies the Dockerfile it was
+-- built from, so an image built by an older dvm shows as outdated
+
+CREATE TABLE IF NOT EXISTS base_images (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    language TEXT NOT NULL,
+    version TEXT NOT NULL,
+    image TEXT NOT NULL,
+    registry_ref TEXT NOT NULL DEFAULT '',
+    dockerfile_hash TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(language, version)
+);
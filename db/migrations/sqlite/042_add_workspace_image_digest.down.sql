@@ -0,0 +1,3 @@
+-- Remove the workspace image digest
+
+ALTER TABLE workspaces DROP COLUMN image_digest;
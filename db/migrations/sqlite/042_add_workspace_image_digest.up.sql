@@ -0,0 +1,6 @@
+-- Digest of the image a workspace's last build produced, as the container
+-- runtime reports it (the image ID on Docker, the manifest digest on
+-- containerd), to tell when a running container uses an older image
+-- NULL means the workspace hasn't been built since this was tracked
+
+ALTER TABLE workspaces ADD COLUMN image_digest TEXT;
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -22,6 +23,13 @@ type MockDataStore struct {
 	Systems                map[int]*models.System // keyed by ID for easier lookup
 	Apps                   map[int]*models.App    // keyed by ID for easier lookup
 	Workspaces             map[int]*models.Workspace
+	WorkspaceLastAttached  map[int]time.Time                // keyed by workspace ID
+	WorkspaceRebuilds      map[int]string                   // rebuild reasons keyed by workspace ID
+	WorkspaceBuildInputs   map[int]string                   // build inputs hashes keyed by workspace ID
+	WorkspaceImageDigests  map[int]string                   // image digests keyed by workspace ID
+	AppSessionLayouts      map[int]*models.AppSessionLayout // keyed by app ID
+	RuntimeEndpoints       map[int]*models.RuntimeEndpoint  // keyed by ecosystem ID
+	Archived               map[string]map[int]time.Time     // archive times keyed by kind, then ID
 	Plugins                map[string]*models.NvimPluginDB
 	Packages               map[string]*models.NvimPackageDB      // keyed by name
 	TerminalPackages       map[string]*models.TerminalPackageDB  // keyed by name
@@ -37,6 +45,12 @@ type MockDataStore struct {
 	Defaults               map[string]string                           // keyed by default key
 	CRDs                   map[string]*models.CustomResourceDefinition // keyed by kind
 	CustomResources        map[string]*models.CustomResource           // keyed by "kind:name:namespace"
+	BuildTemplates         map[string]*models.BuildTemplate            // keyed by name
+	BaseImages             map[string]*models.BaseImage                // keyed by "language:version"
+	SyncHistory            []*models.SyncHistory                       // in insertion order
+	ManagedFields          []*models.ManagedField                      // in insertion order
+	ResourceRevisions      []*models.ResourceRevision                  // in insertion order
+	LegacyProjects         []*models.LegacyProject                     // rows of a legacy projects table
 	BuildSessions          map[string]*models.BuildSession             // keyed by session ID
 	BuildSessionWorkspaces map[int]*models.BuildSessionWorkspace       // keyed by auto-inc ID
 	ActiveTheme            string
@@ -60,6 +74,8 @@ type MockDataStore struct {
 	NextRegistryHistoryID  int64
 	NextCRDID              int
 	NextCustomResourceID   int
+	NextBuildTemplateID    int
+	NextSyncHistoryID      int
 
 	// WorkspacePlugins maps workspaceID -> pluginIDs
 	WorkspacePlugins map[int]map[int]bool
@@ -99,6 +115,8 @@ type MockDataStore struct {
 	UpdateAppErr                        error
 	DeleteAppErr                        error
 	MoveAppErr                          error
+	SetAppSessionLayoutErr              error
+	SetRuntimeEndpointErr               error
 	ListAppsByDomainErr                 error
 	ListAllAppsErr                      error
 	FindAppsByNameErr                   error
@@ -111,6 +129,9 @@ type MockDataStore struct {
 	ListWorkspacesByAppErr              error
 	ListAllWorkspacesErr                error
 	FindWorkspacesErr                   error
+	RecordWorkspaceAttachErr            error
+	UpdateWorkspaceStatusErr            error
+	SetWorkspaceRebuildReasonErr        error
 	GetContextErr                       error
 	SetActiveEcosystemErr               error
 	SetActiveDomainErr                  error
@@ -122,6 +143,8 @@ type MockDataStore struct {
 	GetPluginByIDErr                    error
 	UpdatePluginErr                     error
 	UpsertPluginErr                     error
+	CreatePluginsErr                    error
+	UpsertPluginsErr                    error
 	DeletePluginErr                     error
 	ListPluginsErr                      error
 	ListPluginsByCategoryErr            error
@@ -162,6 +185,7 @@ type MockDataStore struct {
 	CreatePackageErr                    error
 	UpdatePackageErr                    error
 	UpsertPackageErr                    error
+	UpsertPackagesErr                   error
 	DeletePackageErr                    error
 	GetPackageErr                       error
 	ListPackagesErr                     error
@@ -223,6 +247,15 @@ type MockDataStore struct {
 	UpdateCustomResourceErr             error
 	DeleteCustomResourceErr             error
 	ListCustomResourcesErr              error
+	CreateBuildTemplateErr              error
+	UpdateBuildTemplateErr              error
+	GetBuildTemplateErr                 error
+	ListBuildTemplatesErr               error
+	DeleteBuildTemplateErr              error
+	CreateSyncHistoryErr                error
+	GetSyncHistoryErr                   error
+	MarkSyncHistoryRolledBackErr        error
+	ListSyncHistoryErr                  error
 	CreateBuildSessionErr               error
 	UpdateBuildSessionErr               error
 	GetLatestBuildSessionErr            error
@@ -270,6 +303,13 @@ func NewMockDataStore() *MockDataStore {
 		Systems:                make(map[int]*models.System),
 		Apps:                   make(map[int]*models.App),
 		Workspaces:             make(map[int]*models.Workspace),
+		WorkspaceLastAttached:  make(map[int]time.Time),
+		WorkspaceRebuilds:      make(map[int]string),
+		WorkspaceBuildInputs:   make(map[int]string),
+		WorkspaceImageDigests:  make(map[int]string),
+		AppSessionLayouts:      make(map[int]*models.AppSessionLayout),
+		RuntimeEndpoints:       make(map[int]*models.RuntimeEndpoint),
+		Archived:               make(map[string]map[int]time.Time),
 		Plugins:                make(map[string]*models.NvimPluginDB),
 		Packages:               make(map[string]*models.NvimPackageDB),
 		TerminalPackages:       make(map[string]*models.TerminalPackageDB),
@@ -283,6 +323,8 @@ func NewMockDataStore() *MockDataStore {
 		RegistryHistories:      make(map[string]*models.RegistryHistory),
 		CRDs:                   make(map[string]*models.CustomResourceDefinition),
 		CustomResources:        make(map[string]*models.CustomResource),
+		BuildTemplates:         make(map[string]*models.BuildTemplate),
+		BaseImages:             make(map[string]*models.BaseImage),
 		BuildSessions:          make(map[string]*models.BuildSession),
 		BuildSessionWorkspaces: make(map[int]*models.BuildSessionWorkspace),
 		WorkspacePlugins:       make(map[int]map[int]bool),
@@ -931,6 +973,26 @@ func (m *MockDataStore) ListAllWorkspaces() ([]*models.Workspace, error) {
 	return workspaces, nil
 }
 
+func (m *MockDataStore) ListAllWorkspacesPage(opts ListOptions) ([]*models.Workspace, error) {
+	m.recordCall("ListAllWorkspacesPage", opts)
+	if m.ListAllWorkspacesErr != nil {
+		return nil, m.ListAllWorkspacesErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var workspaces []*models.Workspace
+	for _, ws := range m.Workspaces {
+		workspaces = append(workspaces, ws)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		if workspaces[i].AppID != workspaces[j].AppID {
+			return workspaces[i].AppID < workspaces[j].AppID
+		}
+		return workspaces[i].Name < workspaces[j].Name
+	})
+	return mockPage(workspaces, opts), nil
+}
+
 func (m *MockDataStore) FindWorkspaces(filter models.WorkspaceFilter) ([]*models.WorkspaceWithHierarchy, error) {
 	m.recordCall("FindWorkspaces", filter)
 	if m.FindWorkspacesErr != nil {
@@ -1008,6 +1070,170 @@ func (m *MockDataStore) GetWorkspaceSlug(workspaceID int) (string, error) {
 	return ws.Slug, nil
 }
 
+// RecordWorkspaceAttach sets a workspace's last-attached time to now.
+func (m *MockDataStore) RecordWorkspaceAttach(workspaceID int) error {
+	m.recordCall("RecordWorkspaceAttach", workspaceID)
+	if m.RecordWorkspaceAttachErr != nil {
+		return m.RecordWorkspaceAttachErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Workspaces[workspaceID]; !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	m.WorkspaceLastAttached[workspaceID] = time.Now()
+	return nil
+}
+
+// UpdateWorkspaceStatus sets a workspace's status.
+func (m *MockDataStore) UpdateWorkspaceStatus(workspaceID int, status string) error {
+	m.recordCall("UpdateWorkspaceStatus", workspaceID, status)
+	if m.UpdateWorkspaceStatusErr != nil {
+		return m.UpdateWorkspaceStatusErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ws, ok := m.Workspaces[workspaceID]
+	if !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	ws.Status = status
+	return nil
+}
+
+// SetWorkspaceContainerID records or, with an empty ID, clears a workspace's
+// container ID.
+func (m *MockDataStore) SetWorkspaceContainerID(workspaceID int, containerID string) error {
+	m.recordCall("SetWorkspaceContainerID", workspaceID, containerID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ws, ok := m.Workspaces[workspaceID]
+	if !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	ws.ContainerID = sql.NullString{String: containerID, Valid: containerID != ""}
+	return nil
+}
+
+// SetWorkspaceRebuildReason records or, with an empty reason, clears a
+// workspace's pending rebuild.
+func (m *MockDataStore) SetWorkspaceRebuildReason(workspaceID int, reason string) error {
+	m.recordCall("SetWorkspaceRebuildReason", workspaceID, reason)
+	if m.SetWorkspaceRebuildReasonErr != nil {
+		return m.SetWorkspaceRebuildReasonErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Workspaces[workspaceID]; !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	if m.WorkspaceRebuilds == nil {
+		m.WorkspaceRebuilds = make(map[int]string)
+	}
+	if reason == "" {
+		delete(m.WorkspaceRebuilds, workspaceID)
+	} else {
+		m.WorkspaceRebuilds[workspaceID] = reason
+	}
+	return nil
+}
+
+// ListWorkspaceRebuildReasons returns the recorded rebuild reasons.
+func (m *MockDataStore) ListWorkspaceRebuildReasons() (map[int]string, error) {
+	m.recordCall("ListWorkspaceRebuildReasons")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[int]string, len(m.WorkspaceRebuilds))
+	for id, reason := range m.WorkspaceRebuilds {
+		result[id] = reason
+	}
+	return result, nil
+}
+
+// SetWorkspaceBuildInputs records a workspace's build inputs hash.
+func (m *MockDataStore) SetWorkspaceBuildInputs(workspaceID int, hash string) error {
+	m.recordCall("SetWorkspaceBuildInputs", workspaceID, hash)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Workspaces[workspaceID]; !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	if m.WorkspaceBuildInputs == nil {
+		m.WorkspaceBuildInputs = make(map[int]string)
+	}
+	if hash == "" {
+		delete(m.WorkspaceBuildInputs, workspaceID)
+	} else {
+		m.WorkspaceBuildInputs[workspaceID] = hash
+	}
+	return nil
+}
+
+// ListWorkspaceBuildInputs returns the recorded build inputs hashes.
+func (m *MockDataStore) ListWorkspaceBuildInputs() (map[int]string, error) {
+	m.recordCall("ListWorkspaceBuildInputs")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[int]string, len(m.WorkspaceBuildInputs))
+	for id, hash := range m.WorkspaceBuildInputs {
+		result[id] = hash
+	}
+	return result, nil
+}
+
+// SetWorkspaceImageDigest records a workspace's image digest.
+func (m *MockDataStore) SetWorkspaceImageDigest(workspaceID int, digest string) error {
+	m.recordCall("SetWorkspaceImageDigest", workspaceID, digest)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Workspaces[workspaceID]; !ok {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	if m.WorkspaceImageDigests == nil {
+		m.WorkspaceImageDigests = make(map[int]string)
+	}
+	if digest == "" {
+		delete(m.WorkspaceImageDigests, workspaceID)
+	} else {
+		m.WorkspaceImageDigests[workspaceID] = digest
+	}
+	return nil
+}
+
+// ListWorkspaceImageDigests returns the recorded image digests.
+func (m *MockDataStore) ListWorkspaceImageDigests() (map[int]string, error) {
+	m.recordCall("ListWorkspaceImageDigests")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[int]string, len(m.WorkspaceImageDigests))
+	for id, digest := range m.WorkspaceImageDigests {
+		result[id] = digest
+	}
+	return result, nil
+}
+
+// ListWorkspaceLastAttached returns the recorded last-attached times.
+func (m *MockDataStore) ListWorkspaceLastAttached() (map[int]time.Time, error) {
+	m.recordCall("ListWorkspaceLastAttached")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[int]time.Time, len(m.WorkspaceLastAttached))
+	for id, t := range m.WorkspaceLastAttached {
+		result[id] = t
+	}
+	return result, nil
+}
+
 // =============================================================================
 // Context Operations
 // =============================================================================
@@ -1150,6 +1376,45 @@ func (m *MockDataStore) UpsertPlugin(plugin *models.NvimPluginDB) error {
 	return nil
 }
 
+func (m *MockDataStore) CreatePlugins(plugins []*models.NvimPluginDB) error {
+	m.recordCall("CreatePlugins", plugins)
+	if m.CreatePluginsErr != nil {
+		return m.CreatePluginsErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, plugin := range plugins {
+		if _, exists := m.Plugins[plugin.Name]; exists {
+			return fmt.Errorf("plugin %s already exists", plugin.Name)
+		}
+	}
+	for _, plugin := range plugins {
+		plugin.ID = m.nextPluginID
+		m.nextPluginID++
+		m.Plugins[plugin.Name] = plugin
+	}
+	return nil
+}
+
+func (m *MockDataStore) UpsertPlugins(plugins []*models.NvimPluginDB) error {
+	m.recordCall("UpsertPlugins", plugins)
+	if m.UpsertPluginsErr != nil {
+		return m.UpsertPluginsErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, plugin := range plugins {
+		if existing, exists := m.Plugins[plugin.Name]; exists {
+			plugin.ID = existing.ID
+		} else {
+			m.NextPluginID++
+			plugin.ID = m.NextPluginID
+		}
+		m.Plugins[plugin.Name] = plugin
+	}
+	return nil
+}
+
 func (m *MockDataStore) DeletePlugin(name string) error {
 	m.recordCall("DeletePlugin", name)
 	if m.DeletePluginErr != nil {
@@ -1175,6 +1440,31 @@ func (m *MockDataStore) ListPlugins() ([]*models.NvimPluginDB, error) {
 	return plugins, nil
 }
 
+func (m *MockDataStore) ListPluginsPage(opts ListOptions) ([]*models.NvimPluginDB, error) {
+	m.recordCall("ListPluginsPage", opts)
+	if m.ListPluginsErr != nil {
+		return nil, m.ListPluginsErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var plugins []*models.NvimPluginDB
+	for _, p := range m.Plugins {
+		plugins = append(plugins, p)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return mockPage(plugins, opts), nil
+}
+
+// mockPage returns the page of items selected by opts.
+func mockPage[T any](items []T, opts ListOptions) []T {
+	start := min(max(opts.Offset, 0), len(items))
+	end := len(items)
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, end)
+	}
+	return items[start:end]
+}
+
 func (m *MockDataStore) ListPluginsByCategory(category string) ([]*models.NvimPluginDB, error) {
 	m.recordCall("ListPluginsByCategory", category)
 	if m.ListPluginsByCategoryErr != nil {
@@ -1244,6 +1534,57 @@ func (m *MockDataStore) RemovePluginFromWorkspace(workspaceID int, pluginID int)
 	return nil
 }
 
+// orphanedWorkspacePluginsLocked returns workspace -> plugin IDs whose plugin
+// or workspace no longer exists. Caller must hold m.mu.
+func (m *MockDataStore) orphanedWorkspacePluginsLocked() map[int][]int {
+	pluginIDs := make(map[int]bool, len(m.Plugins))
+	for _, p := range m.Plugins {
+		pluginIDs[p.ID] = true
+	}
+	orphans := make(map[int][]int)
+	for wsID, plugins := range m.WorkspacePlugins {
+		_, wsExists := m.Workspaces[wsID]
+		for pluginID := range plugins {
+			if !wsExists || !pluginIDs[pluginID] {
+				orphans[wsID] = append(orphans[wsID], pluginID)
+			}
+		}
+	}
+	return orphans
+}
+
+// CountOrphanedWorkspacePlugins counts associations to missing plugins or workspaces.
+func (m *MockDataStore) CountOrphanedWorkspacePlugins() (int, error) {
+	m.recordCall("CountOrphanedWorkspacePlugins")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, pluginIDs := range m.orphanedWorkspacePluginsLocked() {
+		count += len(pluginIDs)
+	}
+	return count, nil
+}
+
+// DeleteOrphanedWorkspacePlugins removes associations to missing plugins or workspaces.
+func (m *MockDataStore) DeleteOrphanedWorkspacePlugins() (int64, error) {
+	m.recordCall("DeleteOrphanedWorkspacePlugins")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var removed int64
+	for wsID, pluginIDs := range m.orphanedWorkspacePluginsLocked() {
+		for _, pluginID := range pluginIDs {
+			delete(m.WorkspacePlugins[wsID], pluginID)
+			removed++
+		}
+		if len(m.WorkspacePlugins[wsID]) == 0 {
+			delete(m.WorkspacePlugins, wsID)
+		}
+	}
+	return removed, nil
+}
+
 func (m *MockDataStore) GetWorkspacePlugins(workspaceID int) ([]*models.NvimPluginDB, error) {
 	m.recordCall("GetWorkspacePlugins", workspaceID)
 	if m.GetWorkspacePluginsErr != nil {
@@ -1905,6 +2246,30 @@ func (m *MockDataStore) UpsertPackage(pkg *models.NvimPackageDB) error {
 	return nil
 }
 
+func (m *MockDataStore) UpsertPackages(pkgs []*models.NvimPackageDB) error {
+	m.recordCall("UpsertPackages", pkgs)
+	if m.UpsertPackagesErr != nil {
+		return m.UpsertPackagesErr
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, pkg := range pkgs {
+		stored := *pkg
+		if existing, exists := m.Packages[pkg.Name]; exists {
+			stored.ID = existing.ID
+		} else {
+			stored.ID = m.nextPackageID
+			m.nextPackageID++
+		}
+		m.Packages[pkg.Name] = &stored
+		pkg.ID = stored.ID
+	}
+
+	return nil
+}
+
 func (m *MockDataStore) DeletePackage(name string) error {
 	m.recordCall("DeletePackage", name)
 	if m.DeletePackageErr != nil {
@@ -2529,6 +2894,12 @@ func (m *MockDataStore) Ping() error {
 	return nil
 }
 
+// WithContext returns the mock itself, so tests observe every call made
+// through context-bound views.
+func (m *MockDataStore) WithContext(ctx context.Context) DataStore {
+	return m
+}
+
 // MigrationVersion returns the mock migration version.
 func (m *MockDataStore) MigrationVersion() (int, error) {
 	m.recordCall("MigrationVersion")
@@ -3468,11 +3839,13 @@ func (m *MockDataStore) UpdateBuildSessionWorkspace(bsw *models.BuildSessionWork
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, exists := m.BuildSessionWorkspaces[bsw.ID]; !exists {
+	existing, exists := m.BuildSessionWorkspaces[bsw.ID]
+	if !exists {
 		return NewErrNotFound("build session workspace", bsw.ID)
 	}
 
 	clone := *bsw
+	clone.SBOM = existing.SBOM // Only SetBuildSessionWorkspaceSBOM writes it
 	m.BuildSessionWorkspaces[bsw.ID] = &clone
 	return nil
 }
@@ -3500,6 +3873,80 @@ func (m *MockDataStore) GetBuildSessionWorkspaces(sessionID string) ([]*models.B
 	return results, nil
 }
 
+func (m *MockDataStore) GetLatestBuildSessionWorkspace(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	m.recordCall("GetLatestBuildSessionWorkspace", workspaceID)
+	if m.GetBuildSessionWorkspacesErr != nil {
+		return nil, m.GetBuildSessionWorkspacesErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest *models.BuildSessionWorkspace
+	var latestStart time.Time
+	for _, bsw := range m.BuildSessionWorkspaces {
+		if bsw.WorkspaceID != workspaceID {
+			continue
+		}
+		var started time.Time
+		if session, ok := m.BuildSessions[bsw.SessionID]; ok {
+			started = session.StartedAt
+		}
+		if latest == nil || started.After(latestStart) || (started.Equal(latestStart) && bsw.ID > latest.ID) {
+			latest, latestStart = bsw, started
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	clone := *latest
+	return &clone, nil
+}
+
+func (m *MockDataStore) SetBuildSessionWorkspaceSBOM(id int, sbom string) error {
+	m.recordCall("SetBuildSessionWorkspaceSBOM", id, sbom)
+	if m.UpdateBuildSessionWorkspaceErr != nil {
+		return m.UpdateBuildSessionWorkspaceErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bsw, exists := m.BuildSessionWorkspaces[id]
+	if !exists {
+		return NewErrNotFound("build session workspace", id)
+	}
+	bsw.SBOM = sql.NullString{String: sbom, Valid: true}
+	return nil
+}
+
+func (m *MockDataStore) GetLatestBuildSBOM(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	m.recordCall("GetLatestBuildSBOM", workspaceID)
+	if m.GetBuildSessionWorkspacesErr != nil {
+		return nil, m.GetBuildSessionWorkspacesErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest *models.BuildSessionWorkspace
+	var latestStart time.Time
+	for _, bsw := range m.BuildSessionWorkspaces {
+		if bsw.WorkspaceID != workspaceID || !bsw.SBOM.Valid {
+			continue
+		}
+		var started time.Time
+		if session, ok := m.BuildSessions[bsw.SessionID]; ok {
+			started = session.StartedAt
+		}
+		if latest == nil || started.After(latestStart) || (started.Equal(latestStart) && bsw.ID > latest.ID) {
+			latest, latestStart = bsw, started
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	clone := *latest
+	return &clone, nil
+}
+
 func (m *MockDataStore) GetBuildSessionStats(sessionID string) (succeeded int, failed int, err error) {
 	m.recordCall("GetBuildSessionStats", sessionID)
 	if m.GetBuildSessionStatsErr != nil {
@@ -3592,5 +4039,616 @@ func (m *MockDataStore) MoveApp(appID int, newDomainID, newSystemID sql.NullInt6
 	return nil
 }
 
+// GetAppSessionLayout retrieves an app's multiplexer layout.
+func (m *MockDataStore) GetAppSessionLayout(appID int) (*models.AppSessionLayout, error) {
+	m.recordCall("GetAppSessionLayout", appID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	layout, ok := m.AppSessionLayouts[appID]
+	if !ok {
+		return nil, NewErrNotFound("session layout", appID)
+	}
+	clone := *layout
+	return &clone, nil
+}
+
+// SetAppSessionLayout creates or replaces an app's multiplexer layout.
+func (m *MockDataStore) SetAppSessionLayout(layout *models.AppSessionLayout) error {
+	m.recordCall("SetAppSessionLayout", layout)
+	if m.SetAppSessionLayoutErr != nil {
+		return m.SetAppSessionLayoutErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	clone := *layout
+	clone.UpdatedAt = now
+	if existing, ok := m.AppSessionLayouts[layout.AppID]; ok {
+		clone.CreatedAt = existing.CreatedAt
+	} else {
+		clone.CreatedAt = now
+	}
+	m.AppSessionLayouts[layout.AppID] = &clone
+	return nil
+}
+
+// DeleteAppSessionLayout removes an app's multiplexer layout.
+func (m *MockDataStore) DeleteAppSessionLayout(appID int) error {
+	m.recordCall("DeleteAppSessionLayout", appID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.AppSessionLayouts[appID]; !ok {
+		return NewErrNotFound("session layout", appID)
+	}
+	delete(m.AppSessionLayouts, appID)
+	return nil
+}
+
+// GetRuntimeEndpoint retrieves an ecosystem's runtime endpoint.
+func (m *MockDataStore) GetRuntimeEndpoint(ecosystemID int) (*models.RuntimeEndpoint, error) {
+	m.recordCall("GetRuntimeEndpoint", ecosystemID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoint, ok := m.RuntimeEndpoints[ecosystemID]
+	if !ok {
+		return nil, NewErrNotFound("runtime endpoint", ecosystemID)
+	}
+	clone := *endpoint
+	return &clone, nil
+}
+
+// SetRuntimeEndpoint creates or replaces an ecosystem's runtime endpoint.
+func (m *MockDataStore) SetRuntimeEndpoint(endpoint *models.RuntimeEndpoint) error {
+	m.recordCall("SetRuntimeEndpoint", endpoint)
+	if m.SetRuntimeEndpointErr != nil {
+		return m.SetRuntimeEndpointErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	clone := *endpoint
+	clone.UpdatedAt = now
+	if existing, ok := m.RuntimeEndpoints[endpoint.EcosystemID]; ok {
+		clone.CreatedAt = existing.CreatedAt
+	} else {
+		clone.CreatedAt = now
+	}
+	m.RuntimeEndpoints[endpoint.EcosystemID] = &clone
+	return nil
+}
+
+// DeleteRuntimeEndpoint removes an ecosystem's runtime endpoint.
+func (m *MockDataStore) DeleteRuntimeEndpoint(ecosystemID int) error {
+	m.recordCall("DeleteRuntimeEndpoint", ecosystemID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.RuntimeEndpoints[ecosystemID]; !ok {
+		return NewErrNotFound("runtime endpoint", ecosystemID)
+	}
+	delete(m.RuntimeEndpoints, ecosystemID)
+	return nil
+}
+
+// =============================================================================
+// Archive Operations
+// =============================================================================
+
+// mockArchiveName returns the name and parent path of an archivable
+// resource, and whether it exists. The caller holds m.mu.
+func (m *MockDataStore) mockArchiveName(kind string, id int) (name, parent string, ok bool) {
+	ecosystemName := func(id int64) string {
+		for _, e := range m.Ecosystems {
+			if int64(e.ID) == id {
+				return e.Name
+			}
+		}
+		return ""
+	}
+	switch kind {
+	case models.ArchiveKindEcosystem:
+		for _, e := range m.Ecosystems {
+			if e.ID == id {
+				return e.Name, "", true
+			}
+		}
+	case models.ArchiveKindDomain:
+		if d, found := m.Domains[id]; found {
+			return d.Name, ecosystemName(d.EcosystemID.Int64), true
+		}
+	case models.ArchiveKindApp:
+		if a, found := m.Apps[id]; found {
+			if d, found := m.Domains[int(a.DomainID.Int64)]; found {
+				return a.Name, joinPath(ecosystemName(d.EcosystemID.Int64), d.Name), true
+			}
+			return a.Name, "", true
+		}
+	}
+	return "", "", false
+}
+
+func (m *MockDataStore) ArchiveResource(kind string, id int) error {
+	m.recordCall("ArchiveResource", kind, id)
+	if _, err := archiveTable(kind); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, _, ok := m.mockArchiveName(kind, id); !ok {
+		return NewErrNotFound(kind, id)
+	}
+	if m.Archived[kind] == nil {
+		m.Archived[kind] = make(map[int]time.Time)
+	}
+	if _, archived := m.Archived[kind][id]; !archived {
+		m.Archived[kind][id] = time.Now()
+	}
+	return nil
+}
+
+func (m *MockDataStore) RestoreResource(kind string, id int) error {
+	m.recordCall("RestoreResource", kind, id)
+	if _, err := archiveTable(kind); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, _, ok := m.mockArchiveName(kind, id); !ok {
+		return NewErrNotFound(kind, id)
+	}
+	delete(m.Archived[kind], id)
+	return nil
+}
+
+func (m *MockDataStore) ListArchivedResources() ([]*models.ArchivedResource, error) {
+	m.recordCall("ListArchivedResources")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var archived []*models.ArchivedResource
+	for kind, ids := range m.Archived {
+		for id, at := range ids {
+			name, parent, ok := m.mockArchiveName(kind, id)
+			if !ok {
+				continue
+			}
+			archived = append(archived, &models.ArchivedResource{Kind: kind, ID: id, Name: name, Parent: parent, ArchivedAt: at})
+		}
+	}
+	sortArchived(archived)
+	return archived, nil
+}
+
+func (m *MockDataStore) GetArchivedIDs() (*models.ArchivedIDs, error) {
+	m.recordCall("GetArchivedIDs")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := &models.ArchivedIDs{
+		Ecosystems: make(map[int]bool),
+		Domains:    make(map[int]bool),
+		Apps:       make(map[int]bool),
+		Workspaces: make(map[int]bool),
+	}
+	for id := range m.Archived[models.ArchiveKindEcosystem] {
+		ids.Ecosystems[id] = true
+	}
+	for id, d := range m.Domains {
+		if _, archived := m.Archived[models.ArchiveKindDomain][id]; archived || ids.Ecosystems[int(d.EcosystemID.Int64)] {
+			ids.Domains[id] = true
+		}
+	}
+	for id, a := range m.Apps {
+		if _, archived := m.Archived[models.ArchiveKindApp][id]; archived || ids.Domains[int(a.DomainID.Int64)] {
+			ids.Apps[id] = true
+		}
+	}
+	for id, w := range m.Workspaces {
+		if ids.Apps[w.AppID] {
+			ids.Workspaces[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// =============================================================================
+// Build Template Operations
+// =============================================================================
+
+func (m *MockDataStore) CreateBuildTemplate(tmpl *models.BuildTemplate) error {
+	m.recordCall("CreateBuildTemplate", tmpl.Name)
+	if m.CreateBuildTemplateErr != nil {
+		return m.CreateBuildTemplateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.BuildTemplates == nil {
+		m.BuildTemplates = make(map[string]*models.BuildTemplate)
+	}
+	if _, exists := m.BuildTemplates[tmpl.Name]; exists {
+		return fmt.Errorf("build template already exists: %s", tmpl.Name)
+	}
+	m.NextBuildTemplateID++
+	tmpl.ID = m.NextBuildTemplateID
+	now := time.Now()
+	tmpl.CreatedAt, tmpl.UpdatedAt = now, now
+	clone := *tmpl
+	m.BuildTemplates[tmpl.Name] = &clone
+	return nil
+}
+
+func (m *MockDataStore) UpdateBuildTemplate(tmpl *models.BuildTemplate) error {
+	m.recordCall("UpdateBuildTemplate", tmpl.Name)
+	if m.UpdateBuildTemplateErr != nil {
+		return m.UpdateBuildTemplateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, exists := m.BuildTemplates[tmpl.Name]
+	if !exists {
+		return NewErrNotFound("build template", tmpl.Name)
+	}
+	tmpl.ID = existing.ID
+	tmpl.CreatedAt = existing.CreatedAt
+	tmpl.UpdatedAt = time.Now()
+	clone := *tmpl
+	m.BuildTemplates[tmpl.Name] = &clone
+	return nil
+}
+
+func (m *MockDataStore) GetBuildTemplate(name string) (*models.BuildTemplate, error) {
+	m.recordCall("GetBuildTemplate", name)
+	if m.GetBuildTemplateErr != nil {
+		return nil, m.GetBuildTemplateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tmpl, exists := m.BuildTemplates[name]
+	if !exists {
+		return nil, NewErrNotFound("build template", name)
+	}
+	clone := *tmpl
+	return &clone, nil
+}
+
+func (m *MockDataStore) ListBuildTemplates() ([]*models.BuildTemplate, error) {
+	m.recordCall("ListBuildTemplates")
+	if m.ListBuildTemplatesErr != nil {
+		return nil, m.ListBuildTemplatesErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	templates := make([]*models.BuildTemplate, 0, len(m.BuildTemplates))
+	for _, tmpl := range m.BuildTemplates {
+		clone := *tmpl
+		templates = append(templates, &clone)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+func (m *MockDataStore) DeleteBuildTemplate(name string) error {
+	m.recordCall("DeleteBuildTemplate", name)
+	if m.DeleteBuildTemplateErr != nil {
+		return m.DeleteBuildTemplateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.BuildTemplates[name]; !exists {
+		return NewErrNotFound("build template", name)
+	}
+	delete(m.BuildTemplates, name)
+	return nil
+}
+
+// =============================================================================
+// Sync History Operations
+// =============================================================================
+
+func (m *MockDataStore) CreateSyncHistory(history *models.SyncHistory) error {
+	m.recordCall("CreateSyncHistory", history.Source)
+	if m.CreateSyncHistoryErr != nil {
+		return m.CreateSyncHistoryErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.NextSyncHistoryID++
+	history.ID = m.NextSyncHistoryID
+	if history.CreatedAt.IsZero() {
+		history.CreatedAt = time.Now()
+	}
+	clone := *history
+	m.SyncHistory = append(m.SyncHistory, &clone)
+	return nil
+}
+
+func (m *MockDataStore) GetSyncHistory(id int) (*models.SyncHistory, error) {
+	m.recordCall("GetSyncHistory", id)
+	if m.GetSyncHistoryErr != nil {
+		return nil, m.GetSyncHistoryErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, h := range m.SyncHistory {
+		if h.ID == id {
+			clone := *h
+			return &clone, nil
+		}
+	}
+	return nil, NewErrNotFound("sync history", id)
+}
+
+func (m *MockDataStore) MarkSyncHistoryRolledBack(id int) error {
+	m.recordCall("MarkSyncHistoryRolledBack", id)
+	if m.MarkSyncHistoryRolledBackErr != nil {
+		return m.MarkSyncHistoryRolledBackErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, h := range m.SyncHistory {
+		if h.ID == id {
+			h.RolledBackAt = sql.NullTime{Time: time.Now(), Valid: true}
+			return nil
+		}
+	}
+	return NewErrNotFound("sync history", id)
+}
+
+func (m *MockDataStore) ListSyncHistory(source string, limit int) ([]*models.SyncHistory, error) {
+	m.recordCall("ListSyncHistory", source, limit)
+	if m.ListSyncHistoryErr != nil {
+		return nil, m.ListSyncHistoryErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var history []*models.SyncHistory
+	for i := len(m.SyncHistory) - 1; i >= 0; i-- {
+		if limit > 0 && len(history) == limit {
+			break
+		}
+		if h := m.SyncHistory[i]; source == "" || h.Source == source {
+			clone := *h
+			history = append(history, &clone)
+		}
+	}
+	return history, nil
+}
+
+// =============================================================================
+// Managed Field Operations
+// =============================================================================
+
+func (m *MockDataStore) ListManagedFields(kind, name string) ([]*models.ManagedField, error) {
+	m.recordCall("ListManagedFields", kind, name)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var fields []*models.ManagedField
+	for _, f := range m.ManagedFields {
+		if f.Kind == kind && f.Name == name {
+			clone := *f
+			fields = append(fields, &clone)
+		}
+	}
+	return fields, nil
+}
+
+func (m *MockDataStore) ReplaceManagedFields(kind, name, scope string, fields []*models.ManagedField) error {
+	m.recordCall("ReplaceManagedFields", kind, name, scope)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.ManagedFields[:0]
+	for _, f := range m.ManagedFields {
+		if f.Kind != kind || f.Name != name || f.Scope != scope {
+			kept = append(kept, f)
+		}
+	}
+	for _, f := range fields {
+		clone := *f
+		clone.Kind, clone.Name, clone.Scope, clone.UpdatedAt = kind, name, scope, time.Now()
+		kept = append(kept, &clone)
+	}
+	m.ManagedFields = kept
+	return nil
+}
+
+func (m *MockDataStore) DeleteManagedFields(kind, name string) error {
+	m.recordCall("DeleteManagedFields", kind, name)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.ManagedFields[:0]
+	for _, f := range m.ManagedFields {
+		if f.Kind != kind || f.Name != name {
+			kept = append(kept, f)
+		}
+	}
+	m.ManagedFields = kept
+	return nil
+}
+
+// =============================================================================
+// Resource Revision Operations
+// =============================================================================
+
+func (m *MockDataStore) CreateResourceRevision(rev *models.ResourceRevision, keep int) error {
+	m.recordCall("CreateResourceRevision", rev.Kind, rev.Name, rev.Scope)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sameResource := func(r *models.ResourceRevision) bool {
+		return r.Kind == rev.Kind && r.Name == rev.Name && r.Scope == rev.Scope
+	}
+	latest := 0
+	for _, r := range m.ResourceRevisions {
+		if sameResource(r) {
+			latest = max(latest, r.Revision)
+		}
+	}
+	rev.Revision = latest + 1
+	rev.ID = len(m.ResourceRevisions) + 1
+	clone := *rev
+	clone.CreatedAt = time.Now()
+
+	kept := m.ResourceRevisions[:0]
+	for _, r := range m.ResourceRevisions {
+		if !sameResource(r) || keep <= 0 || r.Revision > rev.Revision-keep {
+			kept = append(kept, r)
+		}
+	}
+	m.ResourceRevisions = append(kept, &clone)
+	return nil
+}
+
+func (m *MockDataStore) ListResourceRevisions(kind, name, scope string) ([]*models.ResourceRevision, error) {
+	m.recordCall("ListResourceRevisions", kind, name, scope)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var revisions []*models.ResourceRevision
+	for _, r := range m.ResourceRevisions {
+		if r.Kind == kind && r.Name == name && r.Scope == scope {
+			clone := *r
+			revisions = append(revisions, &clone)
+		}
+	}
+	return revisions, nil
+}
+
+func (m *MockDataStore) DeleteResourceRevisions(kind, name string) error {
+	m.recordCall("DeleteResourceRevisions", kind, name)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.ResourceRevisions[:0]
+	for _, r := range m.ResourceRevisions {
+		if r.Kind != kind || r.Name != name {
+			kept = append(kept, r)
+		}
+	}
+	m.ResourceRevisions = kept
+	return nil
+}
+
+// =============================================================================
+// Base Image Operations
+// =============================================================================
+
+func (m *MockDataStore) SetBaseImage(img *models.BaseImage) error {
+	m.recordCall("SetBaseImage", img.Language, img.Version)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.BaseImages == nil {
+		m.BaseImages = make(map[string]*models.BaseImage)
+	}
+	key := img.Language + ":" + img.Version
+	now := time.Now()
+	img.CreatedAt, img.UpdatedAt = now, now
+	if existing, exists := m.BaseImages[key]; exists {
+		img.ID = existing.ID
+		img.CreatedAt = existing.CreatedAt
+	} else {
+		img.ID = len(m.BaseImages) + 1
+	}
+	clone := *img
+	m.BaseImages[key] = &clone
+	return nil
+}
+
+func (m *MockDataStore) GetBaseImage(language, version string) (*models.BaseImage, error) {
+	m.recordCall("GetBaseImage", language, version)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	img, exists := m.BaseImages[language+":"+version]
+	if !exists {
+		return nil, NewErrNotFound("base image", language+":"+version)
+	}
+	clone := *img
+	return &clone, nil
+}
+
+func (m *MockDataStore) ListBaseImages() ([]*models.BaseImage, error) {
+	m.recordCall("ListBaseImages")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	images := make([]*models.BaseImage, 0, len(m.BaseImages))
+	for _, img := range m.BaseImages {
+		clone := *img
+		images = append(images, &clone)
+	}
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Language != images[j].Language {
+			return images[i].Language < images[j].Language
+		}
+		return images[i].Version < images[j].Version
+	})
+	return images, nil
+}
+
+func (m *MockDataStore) DeleteBaseImage(language, version string) error {
+	m.recordCall("DeleteBaseImage", language, version)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := language + ":" + version
+	if _, exists := m.BaseImages[key]; !exists {
+		return NewErrNotFound("base image", key)
+	}
+	delete(m.BaseImages, key)
+	return nil
+}
+
+// =============================================================================
+// Legacy Project Operations
+// =============================================================================
+
+func (m *MockDataStore) ListLegacyProjects() ([]*models.LegacyProject, error) {
+	m.recordCall("ListLegacyProjects")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	projects := make([]*models.LegacyProject, 0, len(m.LegacyProjects))
+	for _, p := range m.LegacyProjects {
+		clone := *p
+		projects = append(projects, &clone)
+	}
+	return projects, nil
+}
+
+func (m *MockDataStore) RetireLegacyProject(projectID, appID int) error {
+	m.recordCall("RetireLegacyProject", projectID, appID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.LegacyProjects[:0]
+	for _, p := range m.LegacyProjects {
+		if p.ID != projectID {
+			kept = append(kept, p)
+		}
+	}
+	m.LegacyProjects = kept
+	return nil
+}
+
 // Ensure MockDataStore implements DataStore
 var _ DataStore = (*MockDataStore)(nil)
@@ -0,0 +1,141 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strings"
+)
+
+// Backuper is implemented by drivers that can copy a live database with the
+// engine's online backup API. Unlike a file copy, the copy is consistent even
+// while other connections are writing.
+type Backuper interface {
+	// BackupTo copies the live database into the file at path.
+	BackupTo(ctx context.Context, path string) error
+
+	// RestoreFrom replaces the contents of the live database with the
+	// database in the file at path. Open connections stay valid.
+	RestoreFrom(ctx context.Context, path string) error
+}
+
+// BackupTo copies the live database into the file at path using the SQLite
+// online backup API.
+func (d *SQLiteDriver) BackupTo(ctx context.Context, path string) error {
+	other, err := sql.Open("sqlite3", sqliteFileDSN(path, "rwc"))
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer other.Close()
+	return sqliteBackup(ctx, other, d.conn)
+}
+
+// RestoreFrom replaces the live database with the database at path using
+// the SQLite online backup API.
+func (d *SQLiteDriver) RestoreFrom(ctx context.Context, path string) error {
+	other, err := sql.Open("sqlite3", sqliteFileDSN(path, "ro"))
+	if err != nil {
+		return fmt.Errorf("failed to open restore file: %w", err)
+	}
+	defer other.Close()
+
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+	if err := sqliteBackup(ctx, d.conn, other); err != nil {
+		return err
+	}
+	d.writes.Add(1)
+	return nil
+}
+
+// sqliteFileDSN returns a file: URI for the database at path opened with
+// the given mode. The path is percent-escaped so that '?', '#' and '%' in
+// file names are not read as URI syntax.
+func sqliteFileDSN(path, mode string) string {
+	u := url.URL{Scheme: "file", Path: path, RawQuery: "mode=" + mode}
+	return u.String()
+}
+
+// SnapshotInfo describes a database file checked by VerifySnapshot.
+type SnapshotInfo struct {
+	// SchemaVersion is the migration version recorded in the file.
+	SchemaVersion int
+
+	// LatestVersion is the newest migration shipped with this build, or 0
+	// when no migrations were given.
+	LatestVersion int
+}
+
+// NeedsMigration reports whether the snapshot's schema is older than this build.
+func (s *SnapshotInfo) NeedsMigration() bool {
+	return s.SchemaVersion < s.LatestVersion
+}
+
+// VerifySnapshot checks the SQLite database file at path. It must pass
+// PRAGMA integrity_check. When migrationsFS is non-nil, it must also be a
+// dvm database whose schema version is clean and not newer than the newest
+// SQLite migration in migrationsFS.
+func VerifySnapshot(path string, migrationsFS fs.FS) (*SnapshotInfo, error) {
+	conn, err := sql.Open("sqlite3", sqliteFileDSN(path, "ro"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.Query("PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("integrity check failed: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	if len(problems) > 0 {
+		if len(problems) > 3 {
+			problems = append(problems[:3], fmt.Sprintf("... %d more", len(problems)-3))
+		}
+		return nil, fmt.Errorf("integrity check failed: %s", strings.Join(problems, "; "))
+	}
+
+	info := &SnapshotInfo{}
+	if migrationsFS == nil {
+		return info, nil
+	}
+
+	var dirty bool
+	err = conn.QueryRow("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&info.SchemaVersion, &dirty)
+	if err != nil {
+		return nil, fmt.Errorf("not a dvm database: no schema version recorded")
+	}
+	if dirty {
+		return nil, fmt.Errorf("snapshot schema is in a dirty migration state at version %d", info.SchemaVersion)
+	}
+
+	subFS, err := fs.Sub(migrationsFS, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	latest, _, err := getLatestMigrationVersion(subFS)
+	if err != nil {
+		return nil, err
+	}
+	info.LatestVersion = int(latest)
+	if info.SchemaVersion > info.LatestVersion {
+		return nil, fmt.Errorf("snapshot schema version %d is newer than this dvm supports (%d); upgrade dvm first",
+			info.SchemaVersion, info.LatestVersion)
+	}
+	return info, nil
+}
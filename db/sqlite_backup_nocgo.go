@@ -0,0 +1,15 @@
+//go:build !cgo
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// sqliteBackup needs the SQLite online backup API, which go-sqlite3 only
+// provides with cgo. nvp is built without it and never takes backups.
+func sqliteBackup(ctx context.Context, dst, src *sql.DB) error {
+	return fmt.Errorf("backups require a cgo build")
+}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func newBackupTestDriver(t *testing.T, version int) Driver {
+	t.Helper()
+	driver, err := NewSQLiteDriver(DriverConfig{Type: DriverSQLite, Path: filepath.Join(t.TempDir(), "live.db")})
+	if err != nil {
+		t.Fatalf("NewSQLiteDriver() error = %v", err)
+	}
+	t.Cleanup(func() { driver.Close() })
+	for _, q := range []string{
+		"CREATE TABLE schema_migrations (version INTEGER, dirty BOOLEAN)",
+		"CREATE TABLE items (name TEXT)",
+		"INSERT INTO items (name) VALUES ('original')",
+	} {
+		if _, err := driver.Execute(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := driver.Execute("INSERT INTO schema_migrations VALUES (?, 0)", version); err != nil {
+		t.Fatal(err)
+	}
+	return driver
+}
+
+var backupTestMigrations = fstest.MapFS{
+	"sqlite/001_init.up.sql":   {Data: []byte("")},
+	"sqlite/002_more.up.sql":   {Data: []byte("")},
+	"sqlite/002_more.down.sql": {Data: []byte("")},
+}
+
+func TestSQLiteDriver_BackupAndRestore(t *testing.T) {
+	driver := newBackupTestDriver(t, 2)
+	ctx := context.Background()
+
+	// URI syntax in the file name must not be interpreted
+	snap := filepath.Join(t.TempDir(), "snap?mode=ro#100%.db")
+	if err := driver.(Backuper).BackupTo(ctx, snap); err != nil {
+		t.Fatalf("BackupTo() error = %v", err)
+	}
+	if _, err := os.Stat(snap); err != nil {
+		t.Fatalf("backup not written to %q: %v", snap, err)
+	}
+	info, err := VerifySnapshot(snap, backupTestMigrations)
+	if err != nil {
+		t.Fatalf("VerifySnapshot() error = %v", err)
+	}
+	if info.SchemaVersion != 2 || info.LatestVersion != 2 || info.NeedsMigration() {
+		t.Errorf("VerifySnapshot() = %+v, want version 2 of 2", info)
+	}
+
+	if _, err := driver.Execute("UPDATE items SET name = 'changed'"); err != nil {
+		t.Fatal(err)
+	}
+	if err := driver.(Backuper).RestoreFrom(ctx, snap); err != nil {
+		t.Fatalf("RestoreFrom() error = %v", err)
+	}
+	var name string
+	if err := driver.QueryRow("SELECT name FROM items").Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "original" {
+		t.Errorf("after restore name = %q, want original", name)
+	}
+}
+
+func TestVerifySnapshot_Rejects(t *testing.T) {
+	ctx := context.Background()
+
+	newer := newBackupTestDriver(t, 5)
+	snap := filepath.Join(t.TempDir(), "newer.db")
+	if err := newer.(Backuper).BackupTo(ctx, snap); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifySnapshot(snap, backupTestMigrations); err == nil || !strings.Contains(err.Error(), "newer than this dvm supports") {
+		t.Errorf("VerifySnapshot(newer) error = %v", err)
+	}
+	// Without migrations only integrity is checked
+	if _, err := VerifySnapshot(snap, nil); err != nil {
+		t.Errorf("VerifySnapshot(nil migrations) error = %v", err)
+	}
+
+	garbage := filepath.Join(t.TempDir(), "garbage.db")
+	if err := os.WriteFile(garbage, []byte(strings.Repeat("not a database ", 100)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifySnapshot(garbage, nil); err == nil {
+		t.Error("VerifySnapshot(garbage) expected error")
+	}
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// dvm and nvp open the same database file, often at the same time (a shell
+// prompt running 'dvm context' while 'nvp apply' writes). SQLite allows one
+// writer at a time, so the SQLite driver coordinates writes in three layers:
+//
+//   - every pooled connection gets the busy_timeout and synchronous settings
+//     from Configure, passed as DSN parameters, so a locked database is
+//     waited on, not failed on;
+//   - transactions start with BEGIN IMMEDIATE (_txlock=immediate), taking the
+//     write lock up front instead of failing with SQLITE_BUSY when a read
+//     transaction later tries to upgrade;
+//   - writes within one process are serialized by a write lock, and a write
+//     that still hits SQLITE_BUSY after busy_timeout is retried with backoff.
+
+const (
+	// busyRetries is how many more times a write is tried after it fails
+	// with SQLITE_BUSY or SQLITE_LOCKED.
+	busyRetries = 3
+
+	// busyBackoff is the wait before the first retry; it doubles each time.
+	busyBackoff = 100 * time.Millisecond
+)
+
+// retryBusy runs fn, retrying it while it fails with SQLITE_BUSY or
+// SQLITE_LOCKED, up to busyRetries times or until ctx is done.
+func retryBusy(ctx context.Context, fn func() error) error {
+	delay := busyBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !isBusy(err) || attempt == busyRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// connDSN returns the DSN with the per-connection options set by Configure.
+// go-sqlite3 applies _busy_timeout and _sync to every connection it opens;
+// PRAGMAs run through database/sql only reach one pooled connection.
+func (d *SQLiteDriver) connDSN() string {
+	d.optsMu.Lock()
+	defer d.optsMu.Unlock()
+
+	dsn := d.dsn
+	if d.connOpts.BusyTimeoutMs > 0 {
+		dsn += fmt.Sprintf("&_busy_timeout=%d", d.connOpts.BusyTimeoutMs)
+	}
+	if d.connOpts.SynchronousMode != "" {
+		dsn += "&_sync=" + d.connOpts.SynchronousMode
+	}
+	return dsn
+}
+
+// open returns a connection pool for connDSN with the configured pool
+// settings.
+func (d *SQLiteDriver) open() (*sql.DB, error) {
+	conn, err := sql.Open("sqlite3", d.connDSN())
+	if err != nil {
+		return nil, err
+	}
+	if d.cfg.MaxOpenConns > 0 {
+		conn.SetMaxOpenConns(d.cfg.MaxOpenConns)
+	}
+	if d.cfg.MaxIdleConns > 0 {
+		conn.SetMaxIdleConns(d.cfg.MaxIdleConns)
+	}
+	return conn, nil
+}
+
+// reopen replaces the connection pool so that changed per-connection
+// options reach every connection. Configure calls it, before the driver
+// is shared.
+func (d *SQLiteDriver) reopen() error {
+	conn, err := d.open()
+	if err != nil {
+		return fmt.Errorf("failed to reopen SQLite database: %w", err)
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to reopen SQLite database: %w", err)
+	}
+
+	d.versionMu.Lock()
+	if d.versionConn != nil {
+		d.versionConn.Close()
+		d.versionConn = nil
+	}
+	d.versionMu.Unlock()
+
+	old := d.conn
+	d.conn = conn
+	return old.Close()
+}
+
+// setConnOptions records the per-connection options from Configure for
+// connDSN and reports whether they changed. Zero values keep the previous
+// setting.
+func (d *SQLiteDriver) setConnOptions(opts DriverOptions) bool {
+	d.optsMu.Lock()
+	defer d.optsMu.Unlock()
+
+	changed := false
+	if opts.BusyTimeoutMs > 0 && opts.BusyTimeoutMs != d.connOpts.BusyTimeoutMs {
+		d.connOpts.BusyTimeoutMs = opts.BusyTimeoutMs
+		changed = true
+	}
+	if opts.SynchronousMode != "" && opts.SynchronousMode != d.connOpts.SynchronousMode {
+		d.connOpts.SynchronousMode = opts.SynchronousMode
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// openFileDriver connects a file-backed SQLite driver for concurrency tests.
+func openFileDriver(t *testing.T, path string) *SQLiteDriver {
+	t.Helper()
+	driver, err := NewSQLiteDriver(DriverConfig{Type: DriverSQLite, Path: path})
+	if err != nil {
+		t.Fatalf("NewSQLiteDriver() error = %v", err)
+	}
+	if err := driver.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { driver.Close() })
+	return driver.(*SQLiteDriver)
+}
+
+// TestSQLiteDriver_ConcurrentWriters simulates dvm and nvp writing to the
+// same database file at once, through separate drivers and transactions.
+func TestSQLiteDriver_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.db")
+	dvm := openFileDriver(t, path)
+	nvp := openFileDriver(t, path)
+	if _, err := dvm.Execute("CREATE TABLE items (id INTEGER PRIMARY KEY, owner TEXT, n INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+
+	const perWriter = 300
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*perWriter)
+	for _, w := range []struct {
+		name   string
+		driver *SQLiteDriver
+	}{{"dvm", dvm}, {"nvp", nvp}} {
+		for g := 0; g < 2; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perWriter; i++ {
+					tx, err := w.driver.Begin()
+					if err != nil {
+						errs <- err
+						return
+					}
+					// read then write: without BEGIN IMMEDIATE this upgrade
+					// fails with SQLITE_BUSY under contention
+					var count int
+					if err := tx.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+						tx.Rollback()
+						errs <- err
+						return
+					}
+					if _, err := tx.Execute("INSERT INTO items (owner, n) VALUES (?, ?)", w.name, count); err != nil {
+						tx.Rollback()
+						errs <- err
+						return
+					}
+					if err := tx.Commit(); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("concurrent write failed: %v", err)
+	}
+
+	var total int
+	if err := dvm.QueryRow("SELECT COUNT(*) FROM items").Scan(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 4*perWriter {
+		t.Errorf("rows = %d, want %d", total, 4*perWriter)
+	}
+}
+
+// TestSQLiteDriver_Configure_AppliesToAllConnections verifies that options
+// set by Configure reach every pooled connection, not just the one the
+// PRAGMA happened to run on.
+func TestSQLiteDriver_Configure_AppliesToAllConnections(t *testing.T) {
+	driver := openFileDriver(t, filepath.Join(t.TempDir(), "pool.db"))
+	if err := driver.Configure(DriverOptions{BusyTimeoutMs: 1234, SynchronousMode: "FULL"}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		// hold each connection so the pool must open a new one
+		conn, err := driver.conn.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		var timeout, synchronous int
+		if err := conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&timeout); err != nil {
+			t.Fatal(err)
+		}
+		if err := conn.QueryRowContext(ctx, "PRAGMA synchronous").Scan(&synchronous); err != nil {
+			t.Fatal(err)
+		}
+		if timeout != 1234 || synchronous != 2 {
+			t.Errorf("connection %d: busy_timeout=%d synchronous=%d, want 1234 and 2 (FULL)", i, timeout, synchronous)
+		}
+	}
+}
+
+// TestSQLiteDriver_TransactionReleasesWriteLock verifies that Commit and
+// Rollback both release the write lock, and that a Rollback after Commit is
+// harmless.
+func TestSQLiteDriver_TransactionReleasesWriteLock(t *testing.T) {
+	driver := openFileDriver(t, filepath.Join(t.TempDir(), "lock.db"))
+	if _, err := driver.Execute("CREATE TABLE t (v TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := driver.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+
+	tx, err = driver.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := driver.Execute("INSERT INTO t (v) VALUES ('ok')"); err != nil {
+		t.Errorf("Execute after transactions error = %v", err)
+	}
+}
+
+// TestSQLiteDriver_WritesSurviveMigrations verifies that running migrations
+// next to an open driver keeps its writes visible to new connections.
+// golang-migrate must use go-sqlite3 for that; see MigrationDSN.
+func TestSQLiteDriver_WritesSurviveMigrations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "migrate.db")
+	driver := openFileDriver(t, path)
+	migrationsFS, err := fs.Sub(testMigrationsFS, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RunMigrations(driver, migrationsFS); err != nil {
+		t.Fatalf("RunMigrations() error = %v", err)
+	}
+	if _, err := driver.Execute("CREATE TABLE notes (v TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetMigrationStatus(driver, migrationsFS); err != nil {
+		t.Fatalf("GetMigrationStatus() error = %v", err)
+	}
+	if _, err := driver.Execute("INSERT INTO notes (v) VALUES ('after migrate')"); err != nil {
+		t.Fatal(err)
+	}
+
+	other := openFileDriver(t, path)
+	var count int
+	if err := other.QueryRow("SELECT COUNT(*) FROM notes").Scan(&count); err != nil {
+		t.Fatalf("new connection can't read the driver's writes: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("rows seen by a new connection = %d, want 1", count)
+	}
+}
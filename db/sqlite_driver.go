@@ -4,15 +4,33 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-
-	_ "github.com/mattn/go-sqlite3"
+	"sync"
+	"sync/atomic"
 )
 
 // SQLiteDriver implements the Driver interface for SQLite databases.
+// See sqlite_concurrency.go for how writes are coordinated.
 type SQLiteDriver struct {
 	conn *sql.DB
 	cfg  DriverConfig
 	dsn  string
+
+	// writeMu serializes writes and transactions within this process.
+	writeMu sync.Mutex
+
+	// writes counts writes committed through this driver; with versionConn
+	// it makes up DataVersion.
+	writes atomic.Uint64
+
+	// versionMu guards versionConn, a connection held open only to read
+	// PRAGMA data_version, which is per connection.
+	versionMu   sync.Mutex
+	versionConn *sql.Conn
+
+	// optsMu guards connOpts, the per-connection options applied to each
+	// new pool connection.
+	optsMu   sync.Mutex
+	connOpts DriverOptions
 }
 
 // sqliteRow wraps sql.Row to implement the Row interface.
@@ -63,8 +81,11 @@ func (r *sqliteResult) RowsAffected() (int64, error) {
 }
 
 // sqliteTransaction wraps sql.Tx to implement the Transaction interface.
+// It holds the driver's write lock until Commit or Rollback.
 type sqliteTransaction struct {
-	tx *sql.Tx
+	tx      *sql.Tx
+	release func()
+	writes  *atomic.Uint64
 }
 
 func (t *sqliteTransaction) Execute(query string, args ...interface{}) (Result, error) {
@@ -88,10 +109,16 @@ func (t *sqliteTransaction) Query(query string, args ...interface{}) (Rows, erro
 }
 
 func (t *sqliteTransaction) Commit() error {
-	return t.tx.Commit()
+	defer t.release()
+	if err := t.tx.Commit(); err != nil {
+		return err
+	}
+	t.writes.Add(1)
+	return nil
 }
 
 func (t *sqliteTransaction) Rollback() error {
+	defer t.release()
 	return t.tx.Rollback()
 }
 
@@ -108,26 +135,22 @@ func NewSQLiteDriver(cfg DriverConfig) (Driver, error) {
 		return nil, fmt.Errorf("failed to expand path: %w", err)
 	}
 
-	dsn := fmt.Sprintf("file:%s?cache=shared&mode=rwc&_foreign_keys=on", path)
+	// No cache=shared: shared-cache table locks fail with SQLITE_LOCKED
+	// without waiting for busy_timeout. _txlock=immediate makes every
+	// transaction take the write lock when it begins.
+	dsn := fmt.Sprintf("file:%s?mode=rwc&_foreign_keys=on&_txlock=immediate", path)
 
-	conn, err := sql.Open("sqlite3", dsn)
+	d := &SQLiteDriver{
+		cfg: cfg,
+		dsn: dsn,
+	}
+	d.setConnOptions(DefaultDriverOptions())
+	d.conn, err = d.open()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
 	}
 
-	// Apply connection pool settings
-	if cfg.MaxOpenConns > 0 {
-		conn.SetMaxOpenConns(cfg.MaxOpenConns)
-	}
-	if cfg.MaxIdleConns > 0 {
-		conn.SetMaxIdleConns(cfg.MaxIdleConns)
-	}
-
-	return &SQLiteDriver{
-		conn: conn,
-		cfg:  cfg,
-		dsn:  dsn,
-	}, nil
+	return d, nil
 }
 
 // NewMemorySQLiteDriver creates an in-memory SQLite driver for testing.
@@ -140,20 +163,21 @@ func NewMemorySQLiteDriver(cfg DriverConfig) (Driver, error) {
 	// 3. Connection pooling works correctly for concurrent access tests
 	dsn := ":memory:?cache=shared&_foreign_keys=on"
 
-	conn, err := sql.Open("sqlite3", dsn)
+	d := &SQLiteDriver{
+		cfg: cfg,
+		dsn: dsn,
+	}
+	conn, err := d.open()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open in-memory SQLite database: %w", err)
 	}
+	d.conn = conn
 
 	// CRITICAL: Set connection pool to 1 for test isolation
 	// This prevents race conditions but allows reuse of the same connection
-	conn.SetMaxOpenConns(1)
+	d.conn.SetMaxOpenConns(1)
 
-	return &SQLiteDriver{
-		conn: conn,
-		cfg:  cfg,
-		dsn:  dsn,
-	}, nil
+	return d, nil
 }
 
 // Connect establishes the database connection and applies default configuration.
@@ -207,11 +231,23 @@ func (d *SQLiteDriver) Configure(opts DriverOptions) error {
 		}
 	}
 
+	// The PRAGMAs above reached one pooled connection; reopen the pool so
+	// every connection gets changed options. An in-memory database has one
+	// connection and would not survive a reopen.
+	if d.setConnOptions(opts) && d.cfg.Type != DriverMemory {
+		return d.reopen()
+	}
 	return nil
 }
 
 // Close closes the database connection.
 func (d *SQLiteDriver) Close() error {
+	d.versionMu.Lock()
+	if d.versionConn != nil {
+		d.versionConn.Close()
+		d.versionConn = nil
+	}
+	d.versionMu.Unlock()
 	return d.conn.Close()
 }
 
@@ -222,19 +258,24 @@ func (d *SQLiteDriver) Ping() error {
 
 // Execute runs a command that doesn't return rows.
 func (d *SQLiteDriver) Execute(query string, args ...interface{}) (Result, error) {
-	result, err := d.conn.Exec(query, args...)
-	if err != nil {
-		return nil, err
-	}
-	return &sqliteResult{result: result}, nil
+	return d.ExecuteContext(context.Background(), query, args...)
 }
 
-// ExecuteContext runs a command with context support.
+// ExecuteContext runs a command with context support. It waits for the
+// write lock and retries when the database stays busy.
 func (d *SQLiteDriver) ExecuteContext(ctx context.Context, query string, args ...interface{}) (Result, error) {
-	result, err := d.conn.ExecContext(ctx, query, args...)
+	d.writeMu.Lock()
+	defer d.writeMu.Unlock()
+
+	var result sql.Result
+	err := retryBusy(ctx, func() (err error) {
+		result, err = d.conn.ExecContext(ctx, query, args...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+	d.writes.Add(1)
 	return &sqliteResult{result: result}, nil
 }
 
@@ -268,20 +309,25 @@ func (d *SQLiteDriver) QueryContext(ctx context.Context, query string, args ...i
 
 // Begin starts a new transaction.
 func (d *SQLiteDriver) Begin() (Transaction, error) {
-	tx, err := d.conn.Begin()
-	if err != nil {
-		return nil, err
-	}
-	return &sqliteTransaction{tx: tx}, nil
+	return d.BeginContext(context.Background())
 }
 
-// BeginContext starts a new transaction with context.
+// BeginContext starts a new transaction with context. The transaction
+// holds the write lock until it commits or rolls back, so the driver must
+// not be written to directly while it is open.
 func (d *SQLiteDriver) BeginContext(ctx context.Context) (Transaction, error) {
-	tx, err := d.conn.BeginTx(ctx, nil)
+	d.writeMu.Lock()
+
+	var tx *sql.Tx
+	err := retryBusy(ctx, func() (err error) {
+		tx, err = d.conn.BeginTx(ctx, nil)
+		return err
+	})
 	if err != nil {
+		d.writeMu.Unlock()
 		return nil, err
 	}
-	return &sqliteTransaction{tx: tx}, nil
+	return &sqliteTransaction{tx: tx, release: sync.OnceFunc(d.writeMu.Unlock), writes: &d.writes}, nil
 }
 
 // Type returns the driver type.
@@ -297,13 +343,19 @@ func (d *SQLiteDriver) DSN() string {
 	return d.dsn
 }
 
-// MigrationDSN returns the DSN formatted for golang-migrate.
+// MigrationDSN returns the DSN formatted for golang-migrate. Migrations run
+// through go-sqlite3 (sqlite3://), like the driver's own connections. With
+// the pure-Go sqlite:// driver, SQLite runs twice in one process: the two
+// copies don't see each other's POSIX file locks, so when the migration
+// connection closes it checkpoints and deletes the WAL file the driver's
+// open connections are still using, and their later writes are lost to
+// every new connection.
 func (d *SQLiteDriver) MigrationDSN() string {
 	if d.cfg.Type == DriverMemory {
 		return "sqlite3://:memory:"
 	}
 	path, _ := ExpandPath(d.cfg.Path)
-	return fmt.Sprintf("sqlite:///%s", path)
+	return fmt.Sprintf("sqlite3:///%s", path)
 }
 
 // Stats returns connection pool statistics.
@@ -318,9 +370,42 @@ func (d *SQLiteDriver) Stats() DriverStats {
 	}
 }
 
+// DataVersion returns a token that changes whenever a write is committed.
+// Writes through this driver are counted directly; writes by other
+// processes show up in PRAGMA data_version, read on a connection of its
+// own because the value only reflects commits by other connections.
+func (d *SQLiteDriver) DataVersion(ctx context.Context) (string, error) {
+	writes := d.writes.Load()
+	if d.cfg.Type == DriverMemory {
+		// no other process can reach an in-memory database
+		return fmt.Sprintf("%d", writes), nil
+	}
+	if d.cfg.MaxOpenConns == 1 {
+		return "", fmt.Errorf("data version needs a second connection (max open connections is 1)")
+	}
+
+	d.versionMu.Lock()
+	defer d.versionMu.Unlock()
+	if d.versionConn == nil {
+		conn, err := d.conn.Conn(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to open data version connection: %w", err)
+		}
+		d.versionConn = conn
+	}
+	var version int64
+	if err := d.versionConn.QueryRowContext(ctx, "PRAGMA data_version").Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to read data version: %w", err)
+	}
+	return fmt.Sprintf("%d.%d", writes, version), nil
+}
+
 // Ensure SQLiteDriver implements Driver interface
 var _ Driver = (*SQLiteDriver)(nil)
 
+// Ensure SQLiteDriver can keep the DataStore read cache valid
+var _ ChangeTracker = (*SQLiteDriver)(nil)
+
 // Ensure wrapper types implement their interfaces
 var _ Row = (*sqliteRow)(nil)
 var _ Rows = (*sqliteRows)(nil)
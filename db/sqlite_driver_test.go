@@ -111,7 +111,7 @@ func TestSQLiteDriver_MigrationDSN(t *testing.T) {
 		defer driver.Close()
 
 		dsn := driver.MigrationDSN()
-		expected := "sqlite:///" + dbPath
+		expected := "sqlite3:///" + dbPath
 		if dsn != expected {
 			t.Errorf("MigrationDSN() = %q, want %q", dsn, expected)
 		}
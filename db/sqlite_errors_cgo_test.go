@@ -0,0 +1,40 @@
+//go:build cgo
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestRetryBusy(t *testing.T) {
+	busy := sqlite3.Error{Code: sqlite3.ErrBusy}
+
+	calls := 0
+	err := retryBusy(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return busy
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("retryBusy() = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	calls = 0
+	other := errors.New("syntax error")
+	if err := retryBusy(context.Background(), func() error { calls++; return other }); err != other || calls != 1 {
+		t.Errorf("non-busy error: got %v after %d calls, want it returned after 1", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	if err := retryBusy(ctx, func() error { calls++; return busy }); !isBusy(err) || calls != 1 {
+		t.Errorf("cancelled context: got %v after %d calls, want busy error after 1", err, calls)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !cgo
+
+package db
+
+import "strings"
+
+// isBusy reports whether err means another connection holds the lock.
+// go-sqlite3's error types need cgo, so this matches SQLite's messages for
+// SQLITE_BUSY and SQLITE_LOCKED.
+func isBusy(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
+
+// isConstraintConflict reports whether err is a unique or primary key
+// constraint failure, by SQLite's message for them.
+func isConstraintConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "PRIMARY KEY constraint failed")
+}
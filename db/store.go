@@ -10,6 +10,10 @@ import (
 type SQLDataStore struct {
 	driver       Driver
 	queryBuilder QueryBuilder
+
+	// cache holds hot single-row reads; nil when the driver cannot track
+	// changes. See cache.go.
+	cache *readCache
 }
 
 // NewSQLDataStore creates a new SQLDataStore with the given driver.
@@ -21,6 +25,7 @@ func NewSQLDataStore(driver Driver, queryBuilder QueryBuilder) *SQLDataStore {
 	return &SQLDataStore{
 		driver:       driver,
 		queryBuilder: queryBuilder,
+		cache:        newReadCache(driver),
 	}
 }
 
@@ -35,7 +40,7 @@ func NewDataStore(cfg DataStoreConfig) (DataStore, error) {
 
 // Driver returns the underlying database driver.
 func (ds *SQLDataStore) Driver() Driver {
-	return ds.driver
+	return ds.baseDriver()
 }
 
 // Close releases any resources held by the DataStore.
@@ -14,12 +14,12 @@ import (
 
 // CreateApp inserts a new app into the database.
 func (ds *SQLDataStore) CreateApp(app *models.App) error {
-	query := fmt.Sprintf(`INSERT INTO apps (domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
+	query := fmt.Sprintf(`INSERT INTO apps (domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
 
-	result, err := ds.driver.Execute(query, app.DomainID, app.SystemID, app.Name, app.Path, app.Description, app.Theme, app.NvimPackage, app.TerminalPackage, app.Language, app.BuildConfig, app.GitRepoID)
+	result, err := ds.driver.Execute(query, app.DomainID, app.SystemID, app.Name, app.Path, app.Description, app.Theme, app.NvimPackage, app.TerminalPackage, app.Language, app.BuildConfig, app.GitRepoID, app.Env)
 	if err != nil {
-		return err
+		return asConflict(err, "app", app.Name)
 	}
 
 	id, err := result.LastInsertId()
@@ -38,14 +38,14 @@ func (ds *SQLDataStore) GetAppByName(domainID sql.NullInt64, name string) (*mode
 	var row Row
 
 	if domainID.Valid {
-		query = `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps WHERE domain_id = ? AND name = ?`
+		query = `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps WHERE domain_id = ? AND name = ?`
 		row = ds.driver.QueryRow(query, domainID.Int64, name)
 	} else {
-		query = `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps WHERE domain_id IS NULL AND name = ?`
+		query = `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps WHERE domain_id IS NULL AND name = ?`
 		row = ds.driver.QueryRow(query, name)
 	}
 
-	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.CreatedAt, &app.UpdatedAt); err != nil {
+	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.Env, &app.CreatedAt, &app.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("app", name)
 		}
@@ -59,10 +59,10 @@ func (ds *SQLDataStore) GetAppByName(domainID sql.NullInt64, name string) (*mode
 // Returns the first match if multiple apps have the same name in different domains.
 func (ds *SQLDataStore) GetAppByNameGlobal(name string) (*models.App, error) {
 	app := &models.App{}
-	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps WHERE name = ? LIMIT 1`
+	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps WHERE name = ? LIMIT 1`
 
 	row := ds.driver.QueryRow(query, name)
-	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.CreatedAt, &app.UpdatedAt); err != nil {
+	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.Env, &app.CreatedAt, &app.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("app", name)
 		}
@@ -74,11 +74,18 @@ func (ds *SQLDataStore) GetAppByNameGlobal(name string) (*models.App, error) {
 
 // GetAppByID retrieves an app by its ID.
 func (ds *SQLDataStore) GetAppByID(id int) (*models.App, error) {
+	return cachedRow(ds.cache, cacheKey("app", id), func() (*models.App, error) {
+		return ds.loadAppByID(id)
+	}, copyRow[models.App])
+}
+
+// loadAppByID reads an app from the database, bypassing the cache.
+func (ds *SQLDataStore) loadAppByID(id int) (*models.App, error) {
 	app := &models.App{}
-	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps WHERE id = ?`
+	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps WHERE id = ?`
 
 	row := ds.driver.QueryRow(query, id)
-	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.CreatedAt, &app.UpdatedAt); err != nil {
+	if err := row.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.Env, &app.CreatedAt, &app.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("app", id)
 		}
@@ -90,10 +97,10 @@ func (ds *SQLDataStore) GetAppByID(id int) (*models.App, error) {
 
 // UpdateApp updates an existing app.
 func (ds *SQLDataStore) UpdateApp(app *models.App) error {
-	query := fmt.Sprintf(`UPDATE apps SET domain_id = ?, system_id = ?, name = ?, path = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, language = ?, build_config = ?, git_repo_id = ?, updated_at = %s WHERE id = ?`,
+	query := fmt.Sprintf(`UPDATE apps SET domain_id = ?, system_id = ?, name = ?, path = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, language = ?, build_config = ?, git_repo_id = ?, env = ?, updated_at = %s WHERE id = ?`,
 		ds.queryBuilder.Now())
 
-	_, err := ds.driver.Execute(query, app.DomainID, app.SystemID, app.Name, app.Path, app.Description, app.Theme, app.NvimPackage, app.TerminalPackage, app.Language, app.BuildConfig, app.GitRepoID, app.ID)
+	_, err := ds.driver.Execute(query, app.DomainID, app.SystemID, app.Name, app.Path, app.Description, app.Theme, app.NvimPackage, app.TerminalPackage, app.Language, app.BuildConfig, app.GitRepoID, app.Env, app.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update app: %w", err)
 	}
@@ -138,7 +145,7 @@ func (ds *SQLDataStore) DeleteApp(id int) error {
 
 // ListAppsByDomain retrieves all apps for a domain.
 func (ds *SQLDataStore) ListAppsByDomain(domainID int) ([]*models.App, error) {
-	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps WHERE domain_id = ? ORDER BY name`
+	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps WHERE domain_id = ? ORDER BY name`
 
 	rows, err := ds.driver.Query(query, domainID)
 	if err != nil {
@@ -149,7 +156,7 @@ func (ds *SQLDataStore) ListAppsByDomain(domainID int) ([]*models.App, error) {
 	var apps []*models.App
 	for rows.Next() {
 		app := &models.App{}
-		if err := rows.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.CreatedAt, &app.UpdatedAt); err != nil {
+		if err := rows.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.Env, &app.CreatedAt, &app.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan app: %w", err)
 		}
 		apps = append(apps, app)
@@ -164,7 +171,7 @@ func (ds *SQLDataStore) ListAppsByDomain(domainID int) ([]*models.App, error) {
 
 // ListAllApps retrieves all apps across all domains.
 func (ds *SQLDataStore) ListAllApps() ([]*models.App, error) {
-	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, created_at, updated_at FROM apps ORDER BY domain_id, name`
+	query := `SELECT id, domain_id, system_id, name, path, description, theme, nvim_package, terminal_package, language, build_config, git_repo_id, env, created_at, updated_at FROM apps ORDER BY domain_id, name`
 
 	rows, err := ds.driver.Query(query)
 	if err != nil {
@@ -175,7 +182,7 @@ func (ds *SQLDataStore) ListAllApps() ([]*models.App, error) {
 	var apps []*models.App
 	for rows.Next() {
 		app := &models.App{}
-		if err := rows.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.CreatedAt, &app.UpdatedAt); err != nil {
+		if err := rows.Scan(&app.ID, &app.DomainID, &app.SystemID, &app.Name, &app.Path, &app.Description, &app.Theme, &app.NvimPackage, &app.TerminalPackage, &app.Language, &app.BuildConfig, &app.GitRepoID, &app.Env, &app.CreatedAt, &app.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan app: %w", err)
 		}
 		apps = append(apps, app)
@@ -286,3 +293,50 @@ func (ds *SQLDataStore) FindAppsByName(name string) ([]*models.AppWithHierarchy,
 
 	return results, nil
 }
+
+// GetAppSessionLayout retrieves an app's terminal multiplexer layout.
+func (ds *SQLDataStore) GetAppSessionLayout(appID int) (*models.AppSessionLayout, error) {
+	query := `SELECT app_id, spec, created_at, updated_at FROM app_session_layouts WHERE app_id = ?`
+
+	layout := &models.AppSessionLayout{}
+	row := ds.driver.QueryRow(query, appID)
+	if err := row.Scan(&layout.AppID, &layout.Spec, &layout.CreatedAt, &layout.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, NewErrNotFound("session layout", appID)
+		}
+		return nil, fmt.Errorf("failed to get session layout: %w", err)
+	}
+
+	return layout, nil
+}
+
+// SetAppSessionLayout creates or replaces an app's multiplexer layout.
+func (ds *SQLDataStore) SetAppSessionLayout(layout *models.AppSessionLayout) error {
+	query := fmt.Sprintf(`INSERT INTO app_session_layouts (app_id, spec, created_at, updated_at)
+		VALUES (?, ?, %s, %s) %s`,
+		ds.queryBuilder.Now(), ds.queryBuilder.Now(),
+		ds.queryBuilder.UpsertSuffix([]string{"app_id"}, []string{"spec", "updated_at"}))
+
+	if _, err := ds.driver.Execute(query, layout.AppID, layout.Spec); err != nil {
+		return fmt.Errorf("failed to set session layout: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAppSessionLayout removes an app's multiplexer layout.
+func (ds *SQLDataStore) DeleteAppSessionLayout(appID int) error {
+	result, err := ds.driver.Execute(`DELETE FROM app_session_layouts WHERE app_id = ?`, appID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session layout: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("session layout", appID)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Archive Operations
+// =============================================================================
+
+// archiveTables maps each archivable kind to its table.
+var archiveTables = map[string]string{
+	models.ArchiveKindEcosystem: "ecosystems",
+	models.ArchiveKindDomain:    "domains",
+	models.ArchiveKindApp:       "apps",
+}
+
+// Subqueries selecting the IDs hidden by archiving: a resource is hidden
+// when it or any of its parents is archived.
+const (
+	archivedEcosystemIDs = `SELECT id FROM ecosystems WHERE archived_at IS NOT NULL`
+	archivedDomainIDs    = `SELECT id FROM domains WHERE archived_at IS NOT NULL OR ecosystem_id IN (` + archivedEcosystemIDs + `)`
+	archivedAppIDs       = `SELECT id FROM apps WHERE archived_at IS NOT NULL OR domain_id IN (` + archivedDomainIDs + `)`
+	archivedWorkspaceIDs = `SELECT id FROM workspaces WHERE app_id IN (` + archivedAppIDs + `)`
+)
+
+// archiveTable returns the table of an archivable kind.
+func archiveTable(kind string) (string, error) {
+	table, ok := archiveTables[kind]
+	if !ok {
+		return "", fmt.Errorf("cannot archive %q: only ecosystems, domains, and apps can be archived", kind)
+	}
+	return table, nil
+}
+
+// ArchiveResource marks an ecosystem, domain, or app as archived now.
+// Archiving an archived resource keeps its original archive time.
+func (ds *SQLDataStore) ArchiveResource(kind string, id int) error {
+	table, err := archiveTable(kind)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`UPDATE %s SET archived_at = COALESCE(archived_at, %s) WHERE id = ?`, table, ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", kind, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound(kind, id)
+	}
+	return nil
+}
+
+// RestoreResource clears the archived mark of an ecosystem, domain, or app.
+func (ds *SQLDataStore) RestoreResource(kind string, id int) error {
+	table, err := archiveTable(kind)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`UPDATE %s SET archived_at = NULL WHERE id = ?`, table)
+
+	result, err := ds.driver.Execute(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s: %w", kind, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound(kind, id)
+	}
+	return nil
+}
+
+// ListArchivedResources retrieves the archived ecosystems, domains, and apps,
+// oldest archive first. Resources hidden only because a parent is archived
+// are not included.
+func (ds *SQLDataStore) ListArchivedResources() ([]*models.ArchivedResource, error) {
+	queries := []struct {
+		kind  string
+		query string
+	}{
+		{models.ArchiveKindEcosystem, `SELECT id, name, '', archived_at FROM ecosystems WHERE archived_at IS NOT NULL`},
+		{models.ArchiveKindDomain, `SELECT d.id, d.name, COALESCE(e.name, ''), d.archived_at
+			FROM domains d LEFT JOIN ecosystems e ON d.ecosystem_id = e.id
+			WHERE d.archived_at IS NOT NULL`},
+		{models.ArchiveKindApp, `SELECT a.id, a.name, COALESCE(e.name, ''), COALESCE(d.name, ''), a.archived_at
+			FROM apps a LEFT JOIN domains d ON a.domain_id = d.id LEFT JOIN ecosystems e ON d.ecosystem_id = e.id
+			WHERE a.archived_at IS NOT NULL`},
+	}
+
+	var archived []*models.ArchivedResource
+	for _, q := range queries {
+		rows, err := ds.driver.Query(q.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list archived %ss: %w", q.kind, err)
+		}
+		for rows.Next() {
+			r := &models.ArchivedResource{Kind: q.kind}
+			var archivedAt sql.NullTime
+			var scanErr error
+			if q.kind == models.ArchiveKindApp {
+				var ecoName, domName string
+				scanErr = rows.Scan(&r.ID, &r.Name, &ecoName, &domName, &archivedAt)
+				r.Parent = joinPath(ecoName, domName)
+			} else {
+				scanErr = rows.Scan(&r.ID, &r.Name, &r.Parent, &archivedAt)
+			}
+			if scanErr != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan archived %s: %w", q.kind, scanErr)
+			}
+			r.ArchivedAt = archivedAt.Time
+			archived = append(archived, r)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error iterating over archived %ss: %w", q.kind, err)
+		}
+	}
+
+	sortArchived(archived)
+	return archived, nil
+}
+
+// GetArchivedIDs returns the IDs of the resources hidden by archiving.
+func (ds *SQLDataStore) GetArchivedIDs() (*models.ArchivedIDs, error) {
+	ids := &models.ArchivedIDs{}
+	for _, q := range []struct {
+		dst   *map[int]bool
+		query string
+	}{
+		{&ids.Ecosystems, archivedEcosystemIDs},
+		{&ids.Domains, archivedDomainIDs},
+		{&ids.Apps, archivedAppIDs},
+		{&ids.Workspaces, archivedWorkspaceIDs},
+	} {
+		set, err := ds.queryIDSet(q.query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list archived resources: %w", err)
+		}
+		*q.dst = set
+	}
+	return ids, nil
+}
+
+// queryIDSet runs a query selecting one integer column and returns its
+// values as a set.
+func (ds *SQLDataStore) queryIDSet(query string) (map[int]bool, error) {
+	rows, err := ds.driver.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	set := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		set[id] = true
+	}
+	return set, rows.Err()
+}
+
+// joinPath joins the non-empty parts of a hierarchy path.
+func joinPath(parts ...string) string {
+	var nonEmpty []string
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// sortArchived orders archived resources oldest archive first, then by path.
+func sortArchived(archived []*models.ArchivedResource) {
+	sort.SliceStable(archived, func(i, j int) bool {
+		if !archived[i].ArchivedAt.Equal(archived[j].ArchivedAt) {
+			return archived[i].ArchivedAt.Before(archived[j].ArchivedAt)
+		}
+		return archived[i].Path() < archived[j].Path()
+	})
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Base Image Operations
+// =============================================================================
+
+const baseImageColumns = `id, language, version, image, registry_ref, dockerfile_hash, created_at, updated_at`
+
+// scanBaseImage scans a single row into a BaseImage struct.
+func scanBaseImage(s interface{ Scan(dest ...any) error }) (*models.BaseImage, error) {
+	img := &models.BaseImage{}
+	if err := s.Scan(&img.ID, &img.Language, &img.Version, &img.Image, &img.RegistryRef,
+		&img.DockerfileHash, &img.CreatedAt, &img.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// SetBaseImage records a built base image, replacing the one recorded for its
+// language version.
+func (ds *SQLDataStore) SetBaseImage(img *models.BaseImage) error {
+	query := fmt.Sprintf(`INSERT INTO base_images (language, version, image, registry_ref, dockerfile_hash, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, %s, %s) %s`,
+		ds.queryBuilder.Now(), ds.queryBuilder.Now(),
+		ds.queryBuilder.UpsertSuffix([]string{"language", "version"}, []string{"image", "registry_ref", "dockerfile_hash", "updated_at"}))
+
+	if _, err := ds.driver.Execute(query, img.Language, img.Version, img.Image, img.RegistryRef, img.DockerfileHash); err != nil {
+		return fmt.Errorf("failed to set base image: %w", err)
+	}
+	return nil
+}
+
+// GetBaseImage retrieves the base image of a language version.
+func (ds *SQLDataStore) GetBaseImage(language, version string) (*models.BaseImage, error) {
+	row := ds.driver.QueryRow(`SELECT `+baseImageColumns+` FROM base_images WHERE language = ? AND version = ?`, language, version)
+	img, err := scanBaseImage(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, NewErrNotFound("base image", language+":"+version)
+		}
+		return nil, fmt.Errorf("failed to scan base image: %w", err)
+	}
+	return img, nil
+}
+
+// ListBaseImages retrieves all base images ordered by language and version.
+func (ds *SQLDataStore) ListBaseImages() ([]*models.BaseImage, error) {
+	rows, err := ds.driver.Query(`SELECT ` + baseImageColumns + ` FROM base_images ORDER BY language, version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list base images: %w", err)
+	}
+	defer rows.Close()
+
+	var images []*models.BaseImage
+	for rows.Next() {
+		img, err := scanBaseImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan base image: %w", err)
+		}
+		images = append(images, img)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating base images: %w", err)
+	}
+	return images, nil
+}
+
+// DeleteBaseImage removes the base image of a language version.
+func (ds *SQLDataStore) DeleteBaseImage(language, version string) error {
+	result, err := ds.driver.Execute(`DELETE FROM base_images WHERE language = ? AND version = ?`, language, version)
+	if err != nil {
+		return fmt.Errorf("failed to delete base image: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("base image", language+":"+version)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =============================================================================
+// Batch Writes
+// =============================================================================
+
+// maxBatchParams caps the bound parameters in one multi-row statement, to stay
+// under SQLite's default SQLITE_MAX_VARIABLE_NUMBER of 999.
+const maxBatchParams = 999
+
+// batchRow is one row of a batch write: its unique name, the values of the
+// batch's columns in order, and a callback receiving the row's ID.
+type batchRow struct {
+	name  string
+	args  []interface{}
+	setID func(id int)
+}
+
+// writeBatch inserts rows into table using multi-row INSERT statements in a
+// single transaction, then assigns each row its ID. columns must start with
+// "name"; created_at and updated_at are set to now. suffix is appended to
+// every statement (an upsert clause, or "" for a plain insert). If a name
+// appears more than once, the last row wins, as with repeated single upserts.
+// Any failure rolls back the whole batch.
+func (ds *SQLDataStore) writeBatch(table string, columns []string, rows []batchRow, suffix string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	setters := make(map[string][]func(int), len(rows))
+	for _, row := range rows {
+		setters[row.name] = append(setters[row.name], row.setID)
+	}
+	rows = lastByName(rows)
+	width := len(columns)
+	now := ds.queryBuilder.Now()
+	rowSQL := "(" + strings.Repeat("?, ", width) + now + ", " + now + ")"
+
+	tx, err := ds.driver.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	perStmt := maxBatchParams / width
+	for start := 0; start < len(rows); start += perStmt {
+		chunk := rows[start:min(start+perStmt, len(rows))]
+		values := make([]string, len(chunk))
+		args := make([]interface{}, 0, len(chunk)*width)
+		for i, row := range chunk {
+			values[i] = rowSQL
+			args = append(args, row.args...)
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s, created_at, updated_at) VALUES %s %s",
+			table, strings.Join(columns, ", "), strings.Join(values, ", "), suffix)
+		if _, err := tx.Execute(query, args...); err != nil {
+			return fmt.Errorf("failed to write %s rows %d-%d: %w", table, start+1, start+len(chunk), err)
+		}
+	}
+
+	if err := assignBatchIDs(tx, table, rows, setters); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// assignBatchIDs looks up the IDs of the written rows by name. Multi-row
+// inserts do not report per-row IDs, and an upsert that updated a row keeps
+// its existing ID. setters holds the ID callbacks of every row with a name,
+// including dropped duplicates.
+func assignBatchIDs(tx Transaction, table string, rows []batchRow, setters map[string][]func(int)) error {
+	for start := 0; start < len(rows); start += maxBatchParams {
+		chunk := rows[start:min(start+maxBatchParams, len(rows))]
+		names := make([]interface{}, len(chunk))
+		for i, row := range chunk {
+			names[i] = row.name
+		}
+		query := fmt.Sprintf("SELECT id, name FROM %s WHERE name IN (?%s)",
+			table, strings.Repeat(", ?", len(chunk)-1))
+		result, err := tx.Query(query, names...)
+		if err != nil {
+			return fmt.Errorf("failed to read %s IDs: %w", table, err)
+		}
+		for result.Next() {
+			var id int
+			var name string
+			if err := result.Scan(&id, &name); err != nil {
+				result.Close()
+				return fmt.Errorf("failed to scan %s ID: %w", table, err)
+			}
+			for _, setID := range setters[name] {
+				setID(id)
+			}
+		}
+		err = result.Err()
+		result.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s IDs: %w", table, err)
+		}
+	}
+	return nil
+}
+
+// lastByName drops all but the last row for each name, keeping order.
+func lastByName(rows []batchRow) []batchRow {
+	last := make(map[string]int, len(rows))
+	for i, row := range rows {
+		last[row.name] = i
+	}
+	if len(last) == len(rows) {
+		return rows
+	}
+	unique := make([]batchRow, 0, len(last))
+	for i, row := range rows {
+		if last[row.name] == i {
+			unique = append(unique, row)
+		}
+	}
+	return unique
+}
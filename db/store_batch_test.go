@@ -0,0 +1,154 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"devopsmaestro/models"
+)
+
+// batchTestPlugins returns n plugins named plugin-000, plugin-001, ...
+func batchTestPlugins(n int, description string) []*models.NvimPluginDB {
+	plugins := make([]*models.NvimPluginDB, n)
+	for i := range plugins {
+		plugins[i] = &models.NvimPluginDB{
+			Name:        fmt.Sprintf("plugin-%03d", i),
+			Repo:        fmt.Sprintf("user/plugin-%03d", i),
+			Description: sql.NullString{String: description, Valid: true},
+			Enabled:     true,
+		}
+	}
+	return plugins
+}
+
+func TestSQLDataStore_UpsertPlugins(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	// more plugins than fit in one statement
+	n := 2*(maxBatchParams/len(pluginColumns)) + 3
+	plugins := batchTestPlugins(n, "v1")
+	if err := ds.UpsertPlugins(plugins); err != nil {
+		t.Fatalf("UpsertPlugins() (create) error = %v", err)
+	}
+	ids := map[int]bool{}
+	for _, p := range plugins {
+		if p.ID == 0 || ids[p.ID] {
+			t.Fatalf("UpsertPlugins() gave %s ID %d, want a unique non-zero ID", p.Name, p.ID)
+		}
+		ids[p.ID] = true
+	}
+
+	updated := batchTestPlugins(n, "v2")
+	if err := ds.UpsertPlugins(updated); err != nil {
+		t.Fatalf("UpsertPlugins() (update) error = %v", err)
+	}
+	for i, p := range updated {
+		if p.ID != plugins[i].ID {
+			t.Errorf("UpsertPlugins() (update) changed %s ID from %d to %d", p.Name, plugins[i].ID, p.ID)
+		}
+	}
+
+	all, err := ds.ListPlugins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != n {
+		t.Errorf("ListPlugins() returned %d plugins, want %d", len(all), n)
+	}
+	for _, p := range all {
+		if p.Description.String != "v2" {
+			t.Errorf("%s description = %q, want v2", p.Name, p.Description.String)
+		}
+	}
+}
+
+func TestSQLDataStore_UpsertPlugins_DuplicateNames(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	first := &models.NvimPluginDB{Name: "dup", Repo: "user/first"}
+	second := &models.NvimPluginDB{Name: "dup", Repo: "user/second"}
+	if err := ds.UpsertPlugins([]*models.NvimPluginDB{first, second}); err != nil {
+		t.Fatalf("UpsertPlugins() error = %v", err)
+	}
+	if first.ID == 0 || first.ID != second.ID {
+		t.Errorf("IDs = %d and %d, want the same non-zero ID", first.ID, second.ID)
+	}
+
+	got, err := ds.GetPluginByName("dup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Repo != "user/second" {
+		t.Errorf("Repo = %q, want the last entry's user/second", got.Repo)
+	}
+}
+
+func TestSQLDataStore_CreatePlugins_RollsBackOnConflict(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	if err := ds.CreatePlugin(&models.NvimPluginDB{Name: "plugin-002", Repo: "user/existing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ds.CreatePlugins(batchTestPlugins(5, "new")); err == nil {
+		t.Fatal("CreatePlugins() with an existing name should fail")
+	}
+
+	all, err := ds.ListPlugins()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 {
+		t.Errorf("ListPlugins() returned %d plugins after a failed batch, want 1", len(all))
+	}
+}
+
+func TestSQLDataStore_UpsertPackages(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	if _, err := ds.driver.Execute(`CREATE TABLE nvim_packages (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT,
+		category TEXT,
+		labels TEXT,
+		plugins TEXT NOT NULL,
+		extends TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		t.Fatal(err)
+	}
+
+	existing := &models.NvimPackageDB{Name: "core", Plugins: `["telescope"]`}
+	if err := ds.CreatePackage(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs := []*models.NvimPackageDB{
+		{Name: "core", Plugins: `["telescope","treesitter"]`},
+		{Name: "go-dev", Plugins: `["gopls"]`, Extends: sql.NullString{String: "core", Valid: true}},
+	}
+	if err := ds.UpsertPackages(pkgs); err != nil {
+		t.Fatalf("UpsertPackages() error = %v", err)
+	}
+	if pkgs[0].ID != existing.ID {
+		t.Errorf("core ID = %d, want existing ID %d", pkgs[0].ID, existing.ID)
+	}
+	if pkgs[1].ID == 0 || pkgs[1].ID == existing.ID {
+		t.Errorf("go-dev ID = %d, want a new ID", pkgs[1].ID)
+	}
+
+	core, err := ds.GetPackage("core")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if core.Plugins != `["telescope","treesitter"]` {
+		t.Errorf("core plugins = %s, want the upserted list", core.Plugins)
+	}
+}
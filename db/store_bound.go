@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+
+	"devopsmaestro/pkg/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// contextDriver binds a Driver to a context: the context-free query methods
+// run through their Context variants, so every DataStore method honors the
+// context's cancellation and deadline without taking a ctx parameter. Each
+// statement, and each transaction as a whole, is traced as a child of any
+// span in the context.
+type contextDriver struct {
+	Driver
+	ctx context.Context
+}
+
+func (d *contextDriver) Execute(query string, args ...interface{}) (Result, error) {
+	ctx, span := d.startSpan("db.execute", query)
+	result, err := d.Driver.ExecuteContext(ctx, query, args...)
+	telemetry.End(span, err)
+	return result, err
+}
+
+func (d *contextDriver) QueryRow(query string, args ...interface{}) Row {
+	ctx, span := d.startSpan("db.query_row", query)
+	defer span.End()
+	return d.Driver.QueryRowContext(ctx, query, args...)
+}
+
+func (d *contextDriver) Query(query string, args ...interface{}) (Rows, error) {
+	ctx, span := d.startSpan("db.query", query)
+	rows, err := d.Driver.QueryContext(ctx, query, args...)
+	telemetry.End(span, err)
+	return rows, err
+}
+
+func (d *contextDriver) Begin() (Transaction, error) {
+	ctx, span := telemetry.Start(d.ctx, "db.transaction", attribute.String("db.system", string(d.Type())))
+	tx, err := d.Driver.BeginContext(ctx)
+	if err != nil {
+		telemetry.End(span, err)
+		return nil, err
+	}
+	return &tracedTransaction{Transaction: tx, span: span}, nil
+}
+
+func (d *contextDriver) startSpan(name, query string) (context.Context, trace.Span) {
+	return telemetry.Start(d.ctx, name,
+		attribute.String("db.system", string(d.Type())),
+		attribute.String("db.statement", query),
+	)
+}
+
+// tracedTransaction ends its transaction's span on commit or rollback.
+// Rollback after a commit leaves the already ended span unchanged.
+type tracedTransaction struct {
+	Transaction
+	span trace.Span
+}
+
+func (tx *tracedTransaction) Commit() error {
+	err := tx.Transaction.Commit()
+	telemetry.End(tx.span, err)
+	return err
+}
+
+func (tx *tracedTransaction) Rollback() error {
+	err := tx.Transaction.Rollback()
+	tx.span.End()
+	return err
+}
+
+// WithContext returns a view of the store whose queries and transactions run
+// under ctx, so cancelling ctx (Ctrl-C, --timeout) aborts them and rolls back
+// open transactions. The view shares the connection and read cache with ds.
+func (ds *SQLDataStore) WithContext(ctx context.Context) DataStore {
+	return &SQLDataStore{
+		driver:       &contextDriver{Driver: ds.baseDriver(), ctx: ctx},
+		queryBuilder: ds.queryBuilder,
+		cache:        ds.cache,
+	}
+}
+
+// baseDriver returns the driver without any context binding.
+func (ds *SQLDataStore) baseDriver() Driver {
+	if d, ok := ds.driver.(*contextDriver); ok {
+		return d.Driver
+	}
+	return ds.driver
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/telemetry"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSQLDataStore_WithContext(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	bound := ds.WithContext(context.Background())
+	eco := &models.Ecosystem{Name: "eco"}
+	if err := bound.CreateEcosystem(eco); err != nil {
+		t.Fatalf("CreateEcosystem() through bound store error = %v", err)
+	}
+	if _, err := ds.GetEcosystemByName("eco"); err != nil {
+		t.Errorf("bound store write not visible to the base store: %v", err)
+	}
+	if bound.Driver() != ds.Driver() {
+		t.Error("Driver() of the bound store should be the base driver")
+	}
+}
+
+func TestSQLDataStore_WithContext_Cancelled(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bound := ds.WithContext(ctx)
+
+	if _, err := bound.ListEcosystems(); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListEcosystems() error = %v, want context.Canceled", err)
+	}
+	// DeleteEcosystem runs in a transaction
+	if err := bound.DeleteEcosystem("missing"); !errors.Is(err, context.Canceled) {
+		t.Errorf("DeleteEcosystem() error = %v, want context.Canceled", err)
+	}
+	if err := bound.CreateEcosystem(&models.Ecosystem{Name: "eco"}); err == nil {
+		t.Error("CreateEcosystem() with a cancelled context should fail")
+	}
+
+	// rebinding replaces the cancelled context
+	if _, err := bound.WithContext(context.Background()).ListEcosystems(); err != nil {
+		t.Errorf("ListEcosystems() after rebinding error = %v", err)
+	}
+}
+
+func TestSQLDataStore_WithContext_Spans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	ctx, parent := telemetry.Start(context.Background(), "dvm test")
+	bound := ds.WithContext(ctx)
+	if err := bound.CreateEcosystem(&models.Ecosystem{Name: "eco"}); err != nil {
+		t.Fatal(err)
+	}
+	// DeleteEcosystem runs in a transaction
+	if err := bound.DeleteEcosystem("eco"); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	names := map[string]int{}
+	for _, span := range recorder.Ended() {
+		if span.Name() == "dvm test" {
+			continue
+		}
+		names[span.Name()]++
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("span %s is not a child of the command span", span.Name())
+		}
+	}
+	if names["db.execute"] == 0 {
+		t.Error("CreateEcosystem() recorded no db.execute span")
+	}
+	if names["db.transaction"] != 1 {
+		t.Errorf("recorded %d db.transaction spans, want 1", names["db.transaction"])
+	}
+}
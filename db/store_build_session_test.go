@@ -354,6 +354,79 @@ func TestSQLDataStore_GetBuildSessionWorkspaces_EmptyForUnknownSession(t *testin
 	assert.Empty(t, workspaces)
 }
 
+func TestSQLDataStore_GetLatestBuildSessionWorkspace(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	ws := createTestWorkspaceForSession(t, ds, "latest001")
+
+	latest, err := ds.GetLatestBuildSessionWorkspace(ws.ID)
+	require.NoError(t, err)
+	assert.Nil(t, latest, "never-built workspace should have no entry")
+
+	older := newTestSession("bsw-latest-old", "completed", 1)
+	older.StartedAt = older.StartedAt.Add(-time.Hour)
+	newer := newTestSession("bsw-latest-new", "failed", 1)
+	// Insert the newer session first so ordering cannot come from row IDs
+	for _, s := range []*models.BuildSession{newer, older} {
+		require.NoError(t, ds.CreateBuildSession(s))
+		require.NoError(t, ds.CreateBuildSessionWorkspace(&models.BuildSessionWorkspace{
+			SessionID:   s.ID,
+			WorkspaceID: ws.ID,
+			Status:      s.Status,
+		}))
+	}
+
+	latest, err = ds.GetLatestBuildSessionWorkspace(ws.ID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "bsw-latest-new", latest.SessionID)
+	assert.Equal(t, "failed", latest.Status)
+}
+
+func TestSQLDataStore_BuildSBOM(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	ws := createTestWorkspaceForSession(t, ds, "sbom001")
+
+	latest, err := ds.GetLatestBuildSBOM(ws.ID)
+	require.NoError(t, err)
+	assert.Nil(t, latest, "never-built workspace should have no SBOM")
+
+	older := newTestSession("bsw-sbom-old", "completed", 1)
+	older.StartedAt = older.StartedAt.Add(-time.Hour)
+	newer := newTestSession("bsw-sbom-new", "completed", 1)
+	entries := map[string]*models.BuildSessionWorkspace{}
+	for _, s := range []*models.BuildSession{older, newer} {
+		require.NoError(t, ds.CreateBuildSession(s))
+		bsw := &models.BuildSessionWorkspace{SessionID: s.ID, WorkspaceID: ws.ID, Status: "building"}
+		require.NoError(t, ds.CreateBuildSessionWorkspace(bsw))
+		entries[s.ID] = bsw
+	}
+	require.NoError(t, ds.SetBuildSessionWorkspaceSBOM(entries[older.ID].ID, `{"image":"old"}`))
+
+	// Finishing the build must not clear the SBOM
+	entries[older.ID].Status = "succeeded"
+	require.NoError(t, ds.UpdateBuildSessionWorkspace(entries[older.ID]))
+
+	// The newer build recorded no SBOM (e.g. the image was already up to date)
+	latest, err = ds.GetLatestBuildSBOM(ws.ID)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, older.ID, latest.SessionID)
+	assert.Equal(t, "succeeded", latest.Status)
+	assert.Equal(t, `{"image":"old"}`, latest.SBOM.String)
+
+	require.NoError(t, ds.SetBuildSessionWorkspaceSBOM(entries[newer.ID].ID, `{"image":"new"}`))
+	latest, err = ds.GetLatestBuildSBOM(ws.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newer.ID, latest.SessionID)
+
+	err = ds.SetBuildSessionWorkspaceSBOM(99999, "{}")
+	assert.True(t, IsNotFound(err), "expected not found, got %v", err)
+}
+
 // =============================================================================
 // (f) GetBuildSessionStats aggregation
 // =============================================================================
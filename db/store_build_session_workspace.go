@@ -1,6 +1,8 @@
 package db
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -159,6 +161,91 @@ func (ds *SQLDataStore) GetBuildSessionWorkspaces(sessionID string) ([]*models.B
 	return results, nil
 }
 
+// GetLatestBuildSessionWorkspace retrieves the workspace's entry in the most
+// recent build session that included it, or nil when there is none.
+func (ds *SQLDataStore) GetLatestBuildSessionWorkspace(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	query := `SELECT bsw.id, bsw.session_id, bsw.workspace_id, bsw.status, bsw.started_at, bsw.completed_at,
+		bsw.duration_seconds, bsw.image_tag, bsw.error_message
+		FROM build_session_workspaces bsw
+		JOIN build_sessions bs ON bs.id = bsw.session_id
+		WHERE bsw.workspace_id = ?
+		ORDER BY bs.started_at DESC, bsw.id DESC LIMIT 1`
+
+	bsw := &models.BuildSessionWorkspace{}
+	err := ds.driver.QueryRow(query, workspaceID).Scan(
+		&bsw.ID,
+		&bsw.SessionID,
+		&bsw.WorkspaceID,
+		&bsw.Status,
+		&bsw.StartedAt,
+		&bsw.CompletedAt,
+		&bsw.DurationSeconds,
+		&bsw.ImageTag,
+		&bsw.ErrorMessage,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest build session workspace: %w", err)
+	}
+
+	return bsw, nil
+}
+
+// SetBuildSessionWorkspaceSBOM records the SBOM of a workspace entry's image.
+func (ds *SQLDataStore) SetBuildSessionWorkspaceSBOM(id int, sbom string) error {
+	query := `UPDATE build_session_workspaces SET sbom = ? WHERE id = ?`
+
+	result, err := ds.driver.Execute(query, sbom, id)
+	if err != nil {
+		return fmt.Errorf("failed to record build SBOM: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return NewErrNotFound("build session workspace", id)
+	}
+
+	return nil
+}
+
+// GetLatestBuildSBOM retrieves the workspace's most recent entry that recorded
+// an SBOM, or nil when there is none.
+func (ds *SQLDataStore) GetLatestBuildSBOM(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	query := `SELECT bsw.id, bsw.session_id, bsw.workspace_id, bsw.status, bsw.started_at, bsw.completed_at,
+		bsw.duration_seconds, bsw.image_tag, bsw.error_message, bsw.sbom
+		FROM build_session_workspaces bsw
+		JOIN build_sessions bs ON bs.id = bsw.session_id
+		WHERE bsw.workspace_id = ? AND bsw.sbom IS NOT NULL
+		ORDER BY bs.started_at DESC, bsw.id DESC LIMIT 1`
+
+	bsw := &models.BuildSessionWorkspace{}
+	err := ds.driver.QueryRow(query, workspaceID).Scan(
+		&bsw.ID,
+		&bsw.SessionID,
+		&bsw.WorkspaceID,
+		&bsw.Status,
+		&bsw.StartedAt,
+		&bsw.CompletedAt,
+		&bsw.DurationSeconds,
+		&bsw.ImageTag,
+		&bsw.ErrorMessage,
+		&bsw.SBOM,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest build SBOM: %w", err)
+	}
+
+	return bsw, nil
+}
+
 // GetBuildSessionStats returns the succeeded and failed counts for a build session.
 func (ds *SQLDataStore) GetBuildSessionStats(sessionID string) (succeeded int, failed int, err error) {
 	query := `SELECT 
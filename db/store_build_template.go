@@ -0,0 +1,109 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Build Template Operations
+// =============================================================================
+
+const buildTemplateColumns = `id, name, description, build_config, created_at, updated_at`
+
+// scanBuildTemplate scans a single row into a BuildTemplate struct.
+func scanBuildTemplate(s interface{ Scan(dest ...any) error }) (*models.BuildTemplate, error) {
+	tmpl := &models.BuildTemplate{}
+	if err := s.Scan(&tmpl.ID, &tmpl.Name, &tmpl.Description, &tmpl.BuildConfig, &tmpl.CreatedAt, &tmpl.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// CreateBuildTemplate inserts a new build template.
+func (ds *SQLDataStore) CreateBuildTemplate(tmpl *models.BuildTemplate) error {
+	if tmpl.BuildConfig == "" {
+		tmpl.BuildConfig = "{}"
+	}
+
+	query := fmt.Sprintf(`INSERT INTO build_templates (name, description, build_config, created_at, updated_at)
+		VALUES (?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query, tmpl.Name, tmpl.Description, tmpl.BuildConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create build template: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get build template ID: %w", err)
+	}
+	tmpl.ID = int(id)
+	return nil
+}
+
+// UpdateBuildTemplate updates an existing build template (by name).
+func (ds *SQLDataStore) UpdateBuildTemplate(tmpl *models.BuildTemplate) error {
+	if tmpl.BuildConfig == "" {
+		tmpl.BuildConfig = "{}"
+	}
+
+	query := fmt.Sprintf(`UPDATE build_templates SET description = ?, build_config = ?, updated_at = %s WHERE name = ?`,
+		ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query, tmpl.Description, tmpl.BuildConfig, tmpl.Name)
+	if err != nil {
+		return fmt.Errorf("failed to update build template: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("build template", tmpl.Name)
+	}
+	return nil
+}
+
+// GetBuildTemplate retrieves a build template by name.
+func (ds *SQLDataStore) GetBuildTemplate(name string) (*models.BuildTemplate, error) {
+	row := ds.driver.QueryRow(`SELECT `+buildTemplateColumns+` FROM build_templates WHERE name = ?`, name)
+	tmpl, err := scanBuildTemplate(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, NewErrNotFound("build template", name)
+		}
+		return nil, fmt.Errorf("failed to scan build template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ListBuildTemplates retrieves all build templates ordered by name.
+func (ds *SQLDataStore) ListBuildTemplates() ([]*models.BuildTemplate, error) {
+	rows, err := ds.driver.Query(`SELECT ` + buildTemplateColumns + ` FROM build_templates ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list build templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*models.BuildTemplate
+	for rows.Next() {
+		tmpl, err := scanBuildTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan build template: %w", err)
+		}
+		templates = append(templates, tmpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating build templates: %w", err)
+	}
+	return templates, nil
+}
+
+// DeleteBuildTemplate removes a build template by name.
+func (ds *SQLDataStore) DeleteBuildTemplate(name string) error {
+	return ds.deleteByName("build_templates", "build template", name)
+}
@@ -14,6 +14,11 @@ import (
 
 // GetContext retrieves the current context.
 func (ds *SQLDataStore) GetContext() (*models.Context, error) {
+	return cachedRow(ds.cache, cacheKey("context", 1), ds.loadContext, cloneContext)
+}
+
+// loadContext reads the context row from the database, bypassing the cache.
+func (ds *SQLDataStore) loadContext() (*models.Context, error) {
 	context := &models.Context{}
 	query := `SELECT id, active_ecosystem_id, active_domain_id, active_system_id, active_app_id, active_workspace_id, updated_at FROM context WHERE id = 1`
 
@@ -49,6 +49,7 @@ func createTestCredentialStore(t *testing.T) *SQLDataStore {
 			terminal_package TEXT,
 			build_args  TEXT,
 			ca_certs    TEXT,
+			env         TEXT,
 			created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -349,6 +350,7 @@ func createTestVaultCredentialStore(t *testing.T) *SQLDataStore {
 			terminal_package TEXT,
 			build_args  TEXT,
 			ca_certs    TEXT,
+			env         TEXT,
 			created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -13,6 +13,18 @@ import (
 // GetDefault retrieves a default value by key.
 // Returns empty string if key is not found (not an error).
 func (ds *SQLDataStore) GetDefault(key string) (string, error) {
+	value, err := cachedRow(ds.cache, cacheKey("default", key), func() (*string, error) {
+		value, err := ds.loadDefault(key)
+		return &value, err
+	}, copyRow[string])
+	if err != nil {
+		return "", err
+	}
+	return *value, nil
+}
+
+// loadDefault reads a default from the database, bypassing the cache.
+func (ds *SQLDataStore) loadDefault(key string) (string, error) {
 	query := `SELECT value FROM defaults WHERE key = ?`
 
 	var value string
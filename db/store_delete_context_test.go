@@ -51,6 +51,7 @@ func createCascadeTestDataStore(t *testing.T) *SQLDataStore {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -65,6 +66,7 @@ func createCascadeTestDataStore(t *testing.T) *SQLDataStore {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE CASCADE,
@@ -101,6 +103,7 @@ func createCascadeTestDataStore(t *testing.T) *SQLDataStore {
 			language TEXT,
 			build_config TEXT,
 			git_repo_id INTEGER,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE,
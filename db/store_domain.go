@@ -14,12 +14,12 @@ import (
 
 // CreateDomain inserts a new domain into the database.
 func (ds *SQLDataStore) CreateDomain(domain *models.Domain) error {
-	query := fmt.Sprintf(`INSERT INTO domains (ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
+	query := fmt.Sprintf(`INSERT INTO domains (ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
 
-	result, err := ds.driver.Execute(query, domain.EcosystemID, domain.Name, domain.Description, domain.Theme, domain.NvimPackage, domain.TerminalPackage, domain.BuildArgs, domain.CACerts)
+	result, err := ds.driver.Execute(query, domain.EcosystemID, domain.Name, domain.Description, domain.Theme, domain.NvimPackage, domain.TerminalPackage, domain.BuildArgs, domain.CACerts, domain.Env)
 	if err != nil {
-		return fmt.Errorf("failed to create domain: %w", err)
+		return fmt.Errorf("failed to create domain: %w", asConflict(err, "domain", domain.Name))
 	}
 
 	id, err := result.LastInsertId()
@@ -38,14 +38,14 @@ func (ds *SQLDataStore) GetDomainByName(ecosystemID sql.NullInt64, name string)
 	var row Row
 
 	if ecosystemID.Valid {
-		query = `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM domains WHERE ecosystem_id = ? AND name = ?`
+		query = `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM domains WHERE ecosystem_id = ? AND name = ?`
 		row = ds.driver.QueryRow(query, ecosystemID.Int64, name)
 	} else {
-		query = `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM domains WHERE ecosystem_id IS NULL AND name = ?`
+		query = `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM domains WHERE ecosystem_id IS NULL AND name = ?`
 		row = ds.driver.QueryRow(query, name)
 	}
 
-	if err := row.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
+	if err := row.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.Env, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("domain", name)
 		}
@@ -57,11 +57,18 @@ func (ds *SQLDataStore) GetDomainByName(ecosystemID sql.NullInt64, name string)
 
 // GetDomainByID retrieves a domain by its ID.
 func (ds *SQLDataStore) GetDomainByID(id int) (*models.Domain, error) {
+	return cachedRow(ds.cache, cacheKey("domain", id), func() (*models.Domain, error) {
+		return ds.loadDomainByID(id)
+	}, copyRow[models.Domain])
+}
+
+// loadDomainByID reads a domain from the database, bypassing the cache.
+func (ds *SQLDataStore) loadDomainByID(id int) (*models.Domain, error) {
 	domain := &models.Domain{}
-	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM domains WHERE id = ?`
+	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM domains WHERE id = ?`
 
 	row := ds.driver.QueryRow(query, id)
-	if err := row.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
+	if err := row.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.Env, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("domain", id)
 		}
@@ -73,10 +80,10 @@ func (ds *SQLDataStore) GetDomainByID(id int) (*models.Domain, error) {
 
 // UpdateDomain updates an existing domain.
 func (ds *SQLDataStore) UpdateDomain(domain *models.Domain) error {
-	query := fmt.Sprintf(`UPDATE domains SET ecosystem_id = ?, name = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, build_args = ?, ca_certs = ?, updated_at = %s WHERE id = ?`,
+	query := fmt.Sprintf(`UPDATE domains SET ecosystem_id = ?, name = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, build_args = ?, ca_certs = ?, env = ?, updated_at = %s WHERE id = ?`,
 		ds.queryBuilder.Now())
 
-	_, err := ds.driver.Execute(query, domain.EcosystemID, domain.Name, domain.Description, domain.Theme, domain.NvimPackage, domain.TerminalPackage, domain.BuildArgs, domain.CACerts, domain.ID)
+	_, err := ds.driver.Execute(query, domain.EcosystemID, domain.Name, domain.Description, domain.Theme, domain.NvimPackage, domain.TerminalPackage, domain.BuildArgs, domain.CACerts, domain.Env, domain.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update domain: %w", err)
 	}
@@ -129,7 +136,7 @@ func (ds *SQLDataStore) DeleteDomain(id int) error {
 
 // ListDomainsByEcosystem retrieves all domains for an ecosystem.
 func (ds *SQLDataStore) ListDomainsByEcosystem(ecosystemID int) ([]*models.Domain, error) {
-	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM domains WHERE ecosystem_id = ? ORDER BY name`
+	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM domains WHERE ecosystem_id = ? ORDER BY name`
 
 	rows, err := ds.driver.Query(query, ecosystemID)
 	if err != nil {
@@ -140,7 +147,7 @@ func (ds *SQLDataStore) ListDomainsByEcosystem(ecosystemID int) ([]*models.Domai
 	var domains []*models.Domain
 	for rows.Next() {
 		domain := &models.Domain{}
-		if err := rows.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
+		if err := rows.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.Env, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan domain: %w", err)
 		}
 		domains = append(domains, domain)
@@ -155,7 +162,7 @@ func (ds *SQLDataStore) ListDomainsByEcosystem(ecosystemID int) ([]*models.Domai
 
 // ListAllDomains retrieves all domains across all ecosystems.
 func (ds *SQLDataStore) ListAllDomains() ([]*models.Domain, error) {
-	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM domains ORDER BY ecosystem_id, name`
+	query := `SELECT id, ecosystem_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM domains ORDER BY ecosystem_id, name`
 
 	rows, err := ds.driver.Query(query)
 	if err != nil {
@@ -166,7 +173,7 @@ func (ds *SQLDataStore) ListAllDomains() ([]*models.Domain, error) {
 	var domains []*models.Domain
 	for rows.Next() {
 		domain := &models.Domain{}
-		if err := rows.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
+		if err := rows.Scan(&domain.ID, &domain.EcosystemID, &domain.Name, &domain.Description, &domain.Theme, &domain.NvimPackage, &domain.TerminalPackage, &domain.BuildArgs, &domain.CACerts, &domain.Env, &domain.CreatedAt, &domain.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan domain: %w", err)
 		}
 		domains = append(domains, domain)
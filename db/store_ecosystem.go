@@ -14,12 +14,12 @@ import (
 
 // CreateEcosystem inserts a new ecosystem into the database.
 func (ds *SQLDataStore) CreateEcosystem(ecosystem *models.Ecosystem) error {
-	query := fmt.Sprintf(`INSERT INTO ecosystems (name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
+	query := fmt.Sprintf(`INSERT INTO ecosystems (name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, %s, %s)`, ds.queryBuilder.Now(), ds.queryBuilder.Now())
 
-	result, err := ds.driver.Execute(query, ecosystem.Name, ecosystem.Description, ecosystem.Theme, ecosystem.NvimPackage, ecosystem.TerminalPackage, ecosystem.BuildArgs, ecosystem.CACerts)
+	result, err := ds.driver.Execute(query, ecosystem.Name, ecosystem.Description, ecosystem.Theme, ecosystem.NvimPackage, ecosystem.TerminalPackage, ecosystem.BuildArgs, ecosystem.CACerts, ecosystem.Env)
 	if err != nil {
-		return fmt.Errorf("failed to create ecosystem: %w", err)
+		return fmt.Errorf("failed to create ecosystem: %w", asConflict(err, "ecosystem", ecosystem.Name))
 	}
 
 	id, err := result.LastInsertId()
@@ -33,10 +33,10 @@ func (ds *SQLDataStore) CreateEcosystem(ecosystem *models.Ecosystem) error {
 // GetEcosystemByName retrieves an ecosystem by its name.
 func (ds *SQLDataStore) GetEcosystemByName(name string) (*models.Ecosystem, error) {
 	ecosystem := &models.Ecosystem{}
-	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM ecosystems WHERE name = ?`
+	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM ecosystems WHERE name = ?`
 
 	row := ds.driver.QueryRow(query, name)
-	if err := row.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
+	if err := row.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.Env, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("ecosystem", name)
 		}
@@ -48,11 +48,18 @@ func (ds *SQLDataStore) GetEcosystemByName(name string) (*models.Ecosystem, erro
 
 // GetEcosystemByID retrieves an ecosystem by its ID.
 func (ds *SQLDataStore) GetEcosystemByID(id int) (*models.Ecosystem, error) {
+	return cachedRow(ds.cache, cacheKey("ecosystem", id), func() (*models.Ecosystem, error) {
+		return ds.loadEcosystemByID(id)
+	}, copyRow[models.Ecosystem])
+}
+
+// loadEcosystemByID reads an ecosystem from the database, bypassing the cache.
+func (ds *SQLDataStore) loadEcosystemByID(id int) (*models.Ecosystem, error) {
 	ecosystem := &models.Ecosystem{}
-	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM ecosystems WHERE id = ?`
+	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM ecosystems WHERE id = ?`
 
 	row := ds.driver.QueryRow(query, id)
-	if err := row.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
+	if err := row.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.Env, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, NewErrNotFound("ecosystem", id)
 		}
@@ -64,10 +71,10 @@ func (ds *SQLDataStore) GetEcosystemByID(id int) (*models.Ecosystem, error) {
 
 // UpdateEcosystem updates an existing ecosystem.
 func (ds *SQLDataStore) UpdateEcosystem(ecosystem *models.Ecosystem) error {
-	query := fmt.Sprintf(`UPDATE ecosystems SET name = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, build_args = ?, ca_certs = ?, updated_at = %s WHERE id = ?`,
+	query := fmt.Sprintf(`UPDATE ecosystems SET name = ?, description = ?, theme = ?, nvim_package = ?, terminal_package = ?, build_args = ?, ca_certs = ?, env = ?, updated_at = %s WHERE id = ?`,
 		ds.queryBuilder.Now())
 
-	_, err := ds.driver.Execute(query, ecosystem.Name, ecosystem.Description, ecosystem.Theme, ecosystem.NvimPackage, ecosystem.TerminalPackage, ecosystem.BuildArgs, ecosystem.CACerts, ecosystem.ID)
+	_, err := ds.driver.Execute(query, ecosystem.Name, ecosystem.Description, ecosystem.Theme, ecosystem.NvimPackage, ecosystem.TerminalPackage, ecosystem.BuildArgs, ecosystem.CACerts, ecosystem.Env, ecosystem.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update ecosystem: %w", err)
 	}
@@ -132,7 +139,7 @@ func (ds *SQLDataStore) DeleteEcosystem(name string) error {
 
 // ListEcosystems retrieves all ecosystems.
 func (ds *SQLDataStore) ListEcosystems() ([]*models.Ecosystem, error) {
-	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM ecosystems ORDER BY name`
+	query := `SELECT id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, env, created_at, updated_at FROM ecosystems ORDER BY name`
 
 	rows, err := ds.driver.Query(query)
 	if err != nil {
@@ -143,7 +150,7 @@ func (ds *SQLDataStore) ListEcosystems() ([]*models.Ecosystem, error) {
 	var ecosystems []*models.Ecosystem
 	for rows.Next() {
 		ecosystem := &models.Ecosystem{}
-		if err := rows.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
+		if err := rows.Scan(&ecosystem.ID, &ecosystem.Name, &ecosystem.Description, &ecosystem.Theme, &ecosystem.NvimPackage, &ecosystem.TerminalPackage, &ecosystem.BuildArgs, &ecosystem.CACerts, &ecosystem.Env, &ecosystem.CreatedAt, &ecosystem.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan ecosystem: %w", err)
 		}
 		ecosystems = append(ecosystems, ecosystem)
@@ -168,3 +175,50 @@ func (ds *SQLDataStore) CountEcosystems() (int, error) {
 
 	return count, nil
 }
+
+// GetRuntimeEndpoint retrieves an ecosystem's remote runtime endpoint.
+func (ds *SQLDataStore) GetRuntimeEndpoint(ecosystemID int) (*models.RuntimeEndpoint, error) {
+	query := `SELECT ecosystem_id, type, host, sync_dir, created_at, updated_at FROM ecosystem_runtime_endpoints WHERE ecosystem_id = ?`
+
+	endpoint := &models.RuntimeEndpoint{}
+	row := ds.driver.QueryRow(query, ecosystemID)
+	if err := row.Scan(&endpoint.EcosystemID, &endpoint.Type, &endpoint.Host, &endpoint.SyncDir, &endpoint.CreatedAt, &endpoint.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, NewErrNotFound("runtime endpoint", ecosystemID)
+		}
+		return nil, fmt.Errorf("failed to get runtime endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+// SetRuntimeEndpoint creates or replaces an ecosystem's runtime endpoint.
+func (ds *SQLDataStore) SetRuntimeEndpoint(endpoint *models.RuntimeEndpoint) error {
+	query := fmt.Sprintf(`INSERT INTO ecosystem_runtime_endpoints (ecosystem_id, type, host, sync_dir, created_at, updated_at)
+		VALUES (?, ?, ?, ?, %s, %s) %s`,
+		ds.queryBuilder.Now(), ds.queryBuilder.Now(),
+		ds.queryBuilder.UpsertSuffix([]string{"ecosystem_id"}, []string{"type", "host", "sync_dir", "updated_at"}))
+
+	if _, err := ds.driver.Execute(query, endpoint.EcosystemID, endpoint.Type, endpoint.Host, endpoint.SyncDir); err != nil {
+		return fmt.Errorf("failed to set runtime endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRuntimeEndpoint removes an ecosystem's runtime endpoint.
+func (ds *SQLDataStore) DeleteRuntimeEndpoint(ecosystemID int) error {
+	result, err := ds.driver.Execute(`DELETE FROM ecosystem_runtime_endpoints WHERE ecosystem_id = ?`, ecosystemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete runtime endpoint: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("runtime endpoint", ecosystemID)
+	}
+
+	return nil
+}
@@ -2,9 +2,29 @@ package db
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 )
 
+// ListOptions pages a list query. The zero value lists every row.
+type ListOptions struct {
+	// Limit is the maximum number of rows returned; 0 means no limit.
+	Limit int
+	// Offset is the number of rows skipped before the first one returned.
+	Offset int
+}
+
+// limitClause returns the LIMIT/OFFSET clause for opts, or "" when opts
+// does not page. An offset without a limit is sent with the largest limit,
+// since SQLite only accepts OFFSET after LIMIT.
+func (ds *SQLDataStore) limitClause(opts ListOptions) string {
+	limit := opts.Limit
+	if limit <= 0 && opts.Offset > 0 {
+		limit = math.MaxInt64
+	}
+	return ds.queryBuilder.LimitOffset(limit, opts.Offset)
+}
+
 // validLabelKeyPattern matches label keys that are safe for use in JSON extract paths.
 // Only allows alphanumeric characters, hyphens, underscores, and dots.
 var validLabelKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"fmt"
+	"slices"
+
+	"devopsmaestro/models"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// =============================================================================
+// Legacy Project Operations
+// =============================================================================
+//
+// Apps replaced projects, but a database created before then can still hold
+// a projects table, with workspaces and the context referencing projects by
+// project_id and active_project_id. 'dvm admin migrate-projects' converts
+// each project into an app, and migration RetireProjectsMigration then drops
+// the table and columns. That migration doesn't run while projects remain,
+// so they aren't dropped unconverted.
+
+// RetireProjectsMigration is the migration that drops the legacy projects
+// table and context.active_project_id.
+const RetireProjectsMigration = 39
+
+// LegacyProjectsError is returned by RunMigrations when it stops before
+// RetireProjectsMigration because projects remain to be converted.
+type LegacyProjectsError struct {
+	Count int
+}
+
+func (e *LegacyProjectsError) Error() string {
+	return fmt.Sprintf("%d legacy project(s) must be converted to apps before migration %03d; run 'dvm admin migrate-projects --domain <name>'",
+		e.Count, RetireProjectsMigration)
+}
+
+// countLegacyProjects returns how many rows the legacy projects table holds,
+// or 0 when there is no such table. Only SQLite databases can have one.
+func countLegacyProjects(driver Driver) (int, error) {
+	if driver.Type() != DriverSQLite && driver.Type() != DriverMemory {
+		return 0, nil
+	}
+	var tables int
+	if err := driver.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'projects'`).Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to look for legacy projects: %w", err)
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+	var count int
+	if err := driver.QueryRow(`SELECT COUNT(*) FROM projects`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count legacy projects: %w", err)
+	}
+	return count, nil
+}
+
+// migrateUpToLegacyProjects applies the migrations before
+// RetireProjectsMigration and returns a *LegacyProjectsError when count
+// legacy projects remain that it would drop. It returns nil when there are
+// none, or the database is already past it, so the caller applies every
+// migration.
+func migrateUpToLegacyProjects(m *migrate.Migrate, count int) error {
+	if count == 0 {
+		return nil
+	}
+	version, _, err := m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version >= RetireProjectsMigration {
+		return nil
+	}
+	if version < RetireProjectsMigration-1 {
+		if err := m.Migrate(RetireProjectsMigration - 1); err != nil && err != migrate.ErrNoChange {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+	return &LegacyProjectsError{Count: count}
+}
+
+// columnExists reports whether table has column.
+func (ds *SQLDataStore) columnExists(table, column string) (bool, error) {
+	var n int
+	if err := ds.driver.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&n); err != nil {
+		return false, fmt.Errorf("failed to inspect %s: %w", table, err)
+	}
+	return n > 0, nil
+}
+
+// ListLegacyProjects retrieves the rows of the legacy projects table, ordered
+// by name, or none when the database has no such table.
+func (ds *SQLDataStore) ListLegacyProjects() ([]*models.LegacyProject, error) {
+	if count, err := countLegacyProjects(ds.driver); err != nil || count == 0 {
+		return nil, err
+	}
+
+	// Old schemas varied; read the columns this one has
+	var columns []string
+	rows, err := ds.driver.Query(`SELECT name FROM pragma_table_info('projects')`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect projects: %w", err)
+	}
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to inspect projects: %w", err)
+		}
+		columns = append(columns, c)
+	}
+	rows.Close()
+	if !slices.Contains(columns, "id") || !slices.Contains(columns, "name") {
+		return nil, fmt.Errorf("the legacy projects table has no id and name columns")
+	}
+	optional := func(c string) string {
+		if slices.Contains(columns, c) {
+			return "COALESCE(p." + c + ", '')"
+		}
+		return "''"
+	}
+	workspaces := "0"
+	if ok, err := ds.columnExists("workspaces", "project_id"); err != nil {
+		return nil, err
+	} else if ok {
+		workspaces = "(SELECT COUNT(*) FROM workspaces w WHERE w.project_id = p.id)"
+	}
+
+	query := fmt.Sprintf(`SELECT p.id, p.name, %s, %s, %s FROM projects p ORDER BY p.name`,
+		optional("path"), optional("description"), workspaces)
+	rows, err = ds.driver.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list legacy projects: %w", err)
+	}
+	defer rows.Close()
+
+	var projects []*models.LegacyProject
+	for rows.Next() {
+		p := &models.LegacyProject{}
+		if err := rows.Scan(&p.ID, &p.Name, &p.Path, &p.Description, &p.Workspaces); err != nil {
+			return nil, fmt.Errorf("failed to scan legacy project: %w", err)
+		}
+		projects = append(projects, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating legacy projects: %w", err)
+	}
+	return projects, nil
+}
+
+// RetireLegacyProject moves what references the legacy project projectID to
+// the app appID, its workspaces and an active project, and deletes the
+// project, in one transaction.
+func (ds *SQLDataStore) RetireLegacyProject(projectID, appID int) error {
+	workspaces, err := ds.columnExists("workspaces", "project_id")
+	if err != nil {
+		return err
+	}
+	activeProject, err := ds.columnExists("context", "active_project_id")
+	if err != nil {
+		return err
+	}
+
+	tx, err := ds.driver.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	if workspaces {
+		query := fmt.Sprintf(`UPDATE workspaces SET app_id = ?, project_id = NULL, updated_at = %s WHERE project_id = ?`, ds.queryBuilder.Now())
+		if _, err := tx.Execute(query, appID, projectID); err != nil {
+			return fmt.Errorf("failed to move workspaces to app: %w", err)
+		}
+	}
+	if activeProject {
+		if _, err := tx.Execute(`UPDATE context SET active_app_id = ?, active_project_id = NULL WHERE active_project_id = ?`, appID, projectID); err != nil {
+			return fmt.Errorf("failed to move active project to app: %w", err)
+		}
+	}
+	if _, err := tx.Execute(`DELETE FROM projects WHERE id = ?`, projectID); err != nil {
+		return fmt.Errorf("failed to delete legacy project: %w", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyProjects(t *testing.T) {
+	driver, migrationsFS := newMigrationTestDriver(t)
+	ds := NewSQLDataStore(driver, NewSQLiteQueryBuilder())
+
+	// A database from before apps replaced projects
+	_, err := driver.Execute(`CREATE TABLE projects (id INTEGER PRIMARY KEY, name TEXT NOT NULL, path TEXT, description TEXT)`)
+	require.NoError(t, err)
+	_, err = driver.Execute(`INSERT INTO projects (id, name, path) VALUES (7, 'api', '/src/api')`)
+	require.NoError(t, err)
+
+	// Migrations stop before the one that drops them
+	err = RunMigrations(driver, migrationsFS)
+	var legacy *LegacyProjectsError
+	require.True(t, errors.As(err, &legacy), "RunMigrations() error = %v, want *LegacyProjectsError", err)
+	assert.Equal(t, 1, legacy.Count)
+	status, err := GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, uint(RetireProjectsMigration-1), status.Current)
+
+	// Workspaces and the context still reference the project
+	_, err = driver.Execute(`ALTER TABLE workspaces ADD COLUMN project_id INTEGER`)
+	require.NoError(t, err)
+	_, err = driver.Execute(`ALTER TABLE context ADD COLUMN active_project_id INTEGER`)
+	require.NoError(t, err)
+	eco := &models.Ecosystem{Name: "platform"}
+	require.NoError(t, ds.CreateEcosystem(eco))
+	domain := &models.Domain{Name: "legacy", EcosystemID: sql.NullInt64{Int64: int64(eco.ID), Valid: true}}
+	require.NoError(t, ds.CreateDomain(domain))
+	domainID := sql.NullInt64{Int64: int64(domain.ID), Valid: true}
+	placeholder := &models.App{Name: "placeholder", Path: "/tmp", DomainID: domainID}
+	require.NoError(t, ds.CreateApp(placeholder))
+	_, err = driver.Execute(`INSERT INTO workspaces (app_id, name, slug, image_name, project_id) VALUES (?, 'dev', 'legacy-dev', 'ubuntu', 7)`, placeholder.ID)
+	require.NoError(t, err)
+	_, err = driver.Execute(`UPDATE context SET active_project_id = 7`)
+	require.NoError(t, err)
+
+	projects, err := ds.ListLegacyProjects()
+	require.NoError(t, err)
+	require.Len(t, projects, 1)
+	assert.Equal(t, models.LegacyProject{ID: 7, Name: "api", Path: "/src/api", Workspaces: 1}, *projects[0])
+
+	app := &models.App{Name: "api", Path: "/src/api", DomainID: domainID}
+	require.NoError(t, ds.CreateApp(app))
+	require.NoError(t, ds.RetireLegacyProject(7, app.ID))
+
+	var appID int
+	require.NoError(t, driver.QueryRow(`SELECT app_id FROM workspaces WHERE slug = 'legacy-dev'`).Scan(&appID))
+	assert.Equal(t, app.ID, appID, "workspace moved to the app")
+	var activeApp sql.NullInt64
+	require.NoError(t, driver.QueryRow(`SELECT active_app_id FROM context WHERE id = 1`).Scan(&activeApp))
+	assert.Equal(t, int64(app.ID), activeApp.Int64, "active project became the active app")
+	projects, err = ds.ListLegacyProjects()
+	require.NoError(t, err)
+	assert.Empty(t, projects)
+
+	// With none left, the projects table and active_project_id are retired
+	require.NoError(t, RunMigrations(driver, migrationsFS))
+	status, err = GetMigrationStatus(driver, migrationsFS)
+	require.NoError(t, err)
+	assert.Equal(t, status.Latest, status.Current)
+	ok, err := ds.columnExists("context", "active_project_id")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	count, err := countLegacyProjects(driver)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	require.NoError(t, driver.QueryRow(`SELECT active_app_id FROM context WHERE id = 1`).Scan(&activeApp))
+	assert.Equal(t, int64(app.ID), activeApp.Int64, "context kept across the rebuild")
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"fmt"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Managed Field Operations
+// =============================================================================
+
+// ListManagedFields retrieves the recorded fields of every resource of kind
+// named name, whatever its parents, ordered by scope and field.
+func (ds *SQLDataStore) ListManagedFields(kind, name string) ([]*models.ManagedField, error) {
+	rows, err := ds.driver.Query(`SELECT id, kind, name, scope, field, manager, value, updated_at
+		FROM managed_fields WHERE kind = ? AND name = ? ORDER BY scope, field`, kind, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed fields: %w", err)
+	}
+	defer rows.Close()
+
+	var fields []*models.ManagedField
+	for rows.Next() {
+		f := &models.ManagedField{}
+		if err := rows.Scan(&f.ID, &f.Kind, &f.Name, &f.Scope, &f.Field, &f.Manager, &f.Value, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan managed field: %w", err)
+		}
+		fields = append(fields, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating managed fields: %w", err)
+	}
+	return fields, nil
+}
+
+// ReplaceManagedFields replaces the recorded fields of the resource of kind
+// named name within scope with fields, in one transaction.
+func (ds *SQLDataStore) ReplaceManagedFields(kind, name, scope string, fields []*models.ManagedField) error {
+	tx, err := ds.driver.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	if _, err := tx.Execute(`DELETE FROM managed_fields WHERE kind = ? AND name = ? AND scope = ?`, kind, name, scope); err != nil {
+		return fmt.Errorf("failed to clear managed fields: %w", err)
+	}
+	query := fmt.Sprintf(`INSERT INTO managed_fields (kind, name, scope, field, manager, value, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, %s)`, ds.queryBuilder.Now())
+	for _, f := range fields {
+		if _, err := tx.Execute(query, kind, name, scope, f.Field, f.Manager, f.Value); err != nil {
+			return fmt.Errorf("failed to record managed field %s: %w", f.Field, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteManagedFields removes the recorded fields of every resource of kind
+// named name.
+func (ds *SQLDataStore) DeleteManagedFields(kind, name string) error {
+	if _, err := ds.driver.Execute(`DELETE FROM managed_fields WHERE kind = ? AND name = ?`, kind, name); err != nil {
+		return fmt.Errorf("failed to delete managed fields: %w", err)
+	}
+	return nil
+}
@@ -73,6 +73,28 @@ func (ds *SQLDataStore) UpsertPackage(pkg *models.NvimPackageDB) error {
 	return nil
 }
 
+// UpsertPackages creates or updates many nvim packages (by name) in one
+// transaction using multi-row statements. If any package fails, none are
+// written.
+func (ds *SQLDataStore) UpsertPackages(pkgs []*models.NvimPackageDB) error {
+	columns := []string{"name", "description", "category", "labels", "plugins", "extends"}
+	rows := make([]batchRow, len(pkgs))
+	for i, pkg := range pkgs {
+		rows[i] = batchRow{
+			name:  pkg.Name,
+			args:  []interface{}{pkg.Name, pkg.Description, pkg.Category, pkg.Labels, pkg.Plugins, pkg.Extends},
+			setID: func(id int) { pkg.ID = id },
+		}
+	}
+
+	suffix := fmt.Sprintf("%s, updated_at = %s",
+		ds.queryBuilder.UpsertSuffix([]string{"name"}, columns[1:]), ds.queryBuilder.Now())
+	if err := ds.writeBatch("nvim_packages", columns, rows, suffix); err != nil {
+		return fmt.Errorf("failed to upsert packages: %w", err)
+	}
+	return nil
+}
+
 // DeletePackage removes a package by name.
 func (ds *SQLDataStore) DeletePackage(name string) error {
 	return ds.deleteByName("nvim_packages", "package", name)
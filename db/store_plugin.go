@@ -27,7 +27,7 @@ func (ds *SQLDataStore) CreatePlugin(plugin *models.NvimPluginDB) error {
 		plugin.Config, plugin.Init, plugin.Opts, plugin.Keymaps, plugin.Category, plugin.Tags, plugin.Enabled)
 
 	if err != nil {
-		return fmt.Errorf("failed to create plugin: %w", err)
+		return fmt.Errorf("failed to create plugin: %w", asConflict(err, "plugin", plugin.Name))
 	}
 
 	id, err := result.LastInsertId()
@@ -139,11 +139,60 @@ func (ds *SQLDataStore) UpsertPlugin(plugin *models.NvimPluginDB) error {
 	return nil
 }
 
+// pluginColumns are the nvim_plugins columns written by batch operations.
+var pluginColumns = []string{
+	"name", "description", "repo", "branch", "version", "priority", "lazy",
+	"event", "ft", "keys", "cmd", "dependencies", "build", "config", "init",
+	"opts", "keymaps", "category", "tags", "enabled",
+}
+
+// pluginBatchRows converts plugins to batch rows in pluginColumns order.
+func pluginBatchRows(plugins []*models.NvimPluginDB) []batchRow {
+	rows := make([]batchRow, len(plugins))
+	for i, plugin := range plugins {
+		rows[i] = batchRow{
+			name: plugin.Name,
+			args: []interface{}{
+				plugin.Name, plugin.Description, plugin.Repo, plugin.Branch, plugin.Version, plugin.Priority,
+				plugin.Lazy, plugin.Event, plugin.Ft, plugin.Keys, plugin.Cmd, plugin.Dependencies, plugin.Build,
+				plugin.Config, plugin.Init, plugin.Opts, plugin.Keymaps, plugin.Category, plugin.Tags, plugin.Enabled,
+			},
+			setID: func(id int) { plugin.ID = id },
+		}
+	}
+	return rows
+}
+
+// CreatePlugins inserts many plugins in one transaction. If any plugin
+// cannot be inserted (for example, its name already exists), none are.
+func (ds *SQLDataStore) CreatePlugins(plugins []*models.NvimPluginDB) error {
+	if err := ds.writeBatch("nvim_plugins", pluginColumns, pluginBatchRows(plugins), ""); err != nil {
+		return fmt.Errorf("failed to create plugins: %w", err)
+	}
+	return nil
+}
+
+// UpsertPlugins creates or updates many plugins (by name) in one transaction
+// using multi-row statements. If any plugin fails, none are written.
+func (ds *SQLDataStore) UpsertPlugins(plugins []*models.NvimPluginDB) error {
+	suffix := fmt.Sprintf("%s, updated_at = %s",
+		ds.queryBuilder.UpsertSuffix([]string{"name"}, pluginColumns[1:]), ds.queryBuilder.Now())
+	if err := ds.writeBatch("nvim_plugins", pluginColumns, pluginBatchRows(plugins), suffix); err != nil {
+		return fmt.Errorf("failed to upsert plugins: %w", err)
+	}
+	return nil
+}
+
 // ListPlugins retrieves all plugins.
 func (ds *SQLDataStore) ListPlugins() ([]*models.NvimPluginDB, error) {
+	return ds.ListPluginsPage(ListOptions{})
+}
+
+// ListPluginsPage retrieves one page of plugins.
+func (ds *SQLDataStore) ListPluginsPage(opts ListOptions) ([]*models.NvimPluginDB, error) {
 	query := `SELECT id, name, description, repo, branch, version, priority, lazy, event, ft, keys, cmd,
 		dependencies, build, config, init, opts, keymaps, category, tags, enabled, created_at, updated_at
-		FROM nvim_plugins ORDER BY name`
+		FROM nvim_plugins ORDER BY name ` + ds.limitClause(opts)
 
 	rows, err := ds.driver.Query(query)
 	if err != nil {
@@ -324,3 +373,30 @@ func (ds *SQLDataStore) SetWorkspacePluginEnabled(workspaceID int, pluginID int,
 	}
 	return nil
 }
+
+// orphanedWorkspacePluginsWhere matches association rows left behind by a
+// deleted plugin or workspace (e.g. from databases created before foreign
+// keys were enforced).
+const orphanedWorkspacePluginsWhere = `plugin_id NOT IN (SELECT id FROM nvim_plugins)
+	OR workspace_id NOT IN (SELECT id FROM workspaces)`
+
+// CountOrphanedWorkspacePlugins counts workspace_plugins rows whose plugin or
+// workspace no longer exists.
+func (ds *SQLDataStore) CountOrphanedWorkspacePlugins() (int, error) {
+	var count int
+	row := ds.driver.QueryRow(`SELECT COUNT(*) FROM workspace_plugins WHERE ` + orphanedWorkspacePluginsWhere)
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count orphaned workspace plugins: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteOrphanedWorkspacePlugins removes workspace_plugins rows whose plugin
+// or workspace no longer exists.
+func (ds *SQLDataStore) DeleteOrphanedWorkspacePlugins() (int64, error) {
+	result, err := ds.driver.Execute(`DELETE FROM workspace_plugins WHERE ` + orphanedWorkspacePluginsWhere)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orphaned workspace plugins: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"fmt"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Resource Revision Operations
+// =============================================================================
+
+// CreateResourceRevision records rev as the next revision of its resource,
+// setting rev.Revision, and removes all but the newest keep revisions.
+func (ds *SQLDataStore) CreateResourceRevision(rev *models.ResourceRevision, keep int) error {
+	tx, err := ds.driver.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	var latest int
+	if err := tx.QueryRow(`SELECT COALESCE(MAX(revision), 0) FROM resource_revisions
+		WHERE kind = ? AND name = ? AND scope = ?`, rev.Kind, rev.Name, rev.Scope).Scan(&latest); err != nil {
+		return fmt.Errorf("failed to get latest revision: %w", err)
+	}
+	rev.Revision = latest + 1
+
+	query := fmt.Sprintf(`INSERT INTO resource_revisions (kind, name, scope, revision, content, manager, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, %s)`, ds.queryBuilder.Now())
+	result, err := tx.Execute(query, rev.Kind, rev.Name, rev.Scope, rev.Revision, rev.Content, rev.Manager)
+	if err != nil {
+		return fmt.Errorf("failed to create resource revision: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get resource revision ID: %w", err)
+	}
+	rev.ID = int(id)
+
+	if keep > 0 {
+		if _, err := tx.Execute(`DELETE FROM resource_revisions
+			WHERE kind = ? AND name = ? AND scope = ? AND revision <= ?`,
+			rev.Kind, rev.Name, rev.Scope, rev.Revision-keep); err != nil {
+			return fmt.Errorf("failed to prune resource revisions: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// ListResourceRevisions retrieves the revisions of the resource of kind
+// named name within scope, oldest first.
+func (ds *SQLDataStore) ListResourceRevisions(kind, name, scope string) ([]*models.ResourceRevision, error) {
+	rows, err := ds.driver.Query(`SELECT id, kind, name, scope, revision, content, manager, created_at
+		FROM resource_revisions WHERE kind = ? AND name = ? AND scope = ? ORDER BY revision`, kind, name, scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.ResourceRevision
+	for rows.Next() {
+		r := &models.ResourceRevision{}
+		if err := rows.Scan(&r.ID, &r.Kind, &r.Name, &r.Scope, &r.Revision, &r.Content, &r.Manager, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan resource revision: %w", err)
+		}
+		revisions = append(revisions, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating resource revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// DeleteResourceRevisions removes the revisions of every resource of kind
+// named name.
+func (ds *SQLDataStore) DeleteResourceRevisions(kind, name string) error {
+	if _, err := ds.driver.Execute(`DELETE FROM resource_revisions WHERE kind = ? AND name = ?`, kind, name); err != nil {
+		return fmt.Errorf("failed to delete resource revisions: %w", err)
+	}
+	return nil
+}
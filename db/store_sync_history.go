@@ -0,0 +1,141 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"devopsmaestro/models"
+)
+
+// =============================================================================
+// Sync History Operations
+// =============================================================================
+
+const syncHistoryColumns = `id, source, version, dry_run, total_available, total_synced,
+	created_count, updated_count, error_count, result, changes, errors, snapshot, started_at, duration_ms,
+	rolled_back_at, created_at`
+
+// scanSyncHistory scans a single row into a SyncHistory struct.
+func scanSyncHistory(s interface{ Scan(dest ...any) error }) (*models.SyncHistory, error) {
+	h := &models.SyncHistory{}
+	if err := s.Scan(&h.ID, &h.Source, &h.Version, &h.DryRun, &h.TotalAvailable, &h.TotalSynced,
+		&h.CreatedCount, &h.UpdatedCount, &h.ErrorCount, &h.Result, &h.Changes, &h.Errors, &h.Snapshot,
+		&h.StartedAt, &h.DurationMS, &h.RolledBackAt, &h.CreatedAt); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// CreateSyncHistory records a sync run.
+func (ds *SQLDataStore) CreateSyncHistory(history *models.SyncHistory) error {
+	if history.Result == "" {
+		history.Result = "{}"
+	}
+	if history.Changes == "" {
+		history.Changes = "[]"
+	}
+	if history.Errors == "" {
+		history.Errors = "[]"
+	}
+	if history.Snapshot == "" {
+		history.Snapshot = "{}"
+	}
+
+	query := fmt.Sprintf(`INSERT INTO sync_history
+		(source, version, dry_run, total_available, total_synced, created_count, updated_count, error_count,
+		 result, changes, errors, snapshot, started_at, duration_ms, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, %s)`, ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query,
+		history.Source,
+		history.Version,
+		history.DryRun,
+		history.TotalAvailable,
+		history.TotalSynced,
+		history.CreatedCount,
+		history.UpdatedCount,
+		history.ErrorCount,
+		history.Result,
+		history.Changes,
+		history.Errors,
+		history.Snapshot,
+		history.StartedAt,
+		history.DurationMS,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create sync history: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get sync history ID: %w", err)
+	}
+	history.ID = int(id)
+	return nil
+}
+
+// GetSyncHistory retrieves a sync run by ID.
+func (ds *SQLDataStore) GetSyncHistory(id int) (*models.SyncHistory, error) {
+	row := ds.driver.QueryRow(`SELECT `+syncHistoryColumns+` FROM sync_history WHERE id = ?`, id)
+	h, err := scanSyncHistory(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, NewErrNotFound("sync history", id)
+		}
+		return nil, fmt.Errorf("failed to scan sync history: %w", err)
+	}
+	return h, nil
+}
+
+// MarkSyncHistoryRolledBack records that a sync run was rolled back.
+func (ds *SQLDataStore) MarkSyncHistoryRolledBack(id int) error {
+	query := fmt.Sprintf(`UPDATE sync_history SET rolled_back_at = %s WHERE id = ?`, ds.queryBuilder.Now())
+	result, err := ds.driver.Execute(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark sync history rolled back: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("sync history", id)
+	}
+	return nil
+}
+
+// ListSyncHistory retrieves sync runs newest first, only those of source
+// when it is not empty, and at most limit when limit is positive.
+func (ds *SQLDataStore) ListSyncHistory(source string, limit int) ([]*models.SyncHistory, error) {
+	query := `SELECT ` + syncHistoryColumns + ` FROM sync_history`
+	var args []any
+	if source != "" {
+		query += ` WHERE source = ?`
+		args = append(args, source)
+	}
+	query += ` ORDER BY id DESC`
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := ds.driver.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*models.SyncHistory
+	for rows.Next() {
+		h, err := scanSyncHistory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sync history: %w", err)
+		}
+		history = append(history, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sync history: %w", err)
+	}
+	return history, nil
+}
@@ -19,7 +19,7 @@ func (ds *SQLDataStore) CreateSystem(system *models.System) error {
 
 	result, err := ds.driver.Execute(query, system.EcosystemID, system.DomainID, system.Name, system.Description, system.Theme, system.NvimPackage, system.TerminalPackage, system.BuildArgs, system.CACerts)
 	if err != nil {
-		return fmt.Errorf("failed to create system: %w", err)
+		return fmt.Errorf("failed to create system: %w", asConflict(err, "system", system.Name))
 	}
 
 	id, err := result.LastInsertId()
@@ -32,6 +32,13 @@ func (ds *SQLDataStore) CreateSystem(system *models.System) error {
 
 // GetSystemByID retrieves a system by its ID.
 func (ds *SQLDataStore) GetSystemByID(id int) (*models.System, error) {
+	return cachedRow(ds.cache, cacheKey("system", id), func() (*models.System, error) {
+		return ds.loadSystemByID(id)
+	}, copyRow[models.System])
+}
+
+// loadSystemByID reads a system from the database, bypassing the cache.
+func (ds *SQLDataStore) loadSystemByID(id int) (*models.System, error) {
 	system := &models.System{}
 	query := `SELECT id, ecosystem_id, domain_id, name, description, theme, nvim_package, terminal_package, build_args, ca_certs, created_at, updated_at FROM systems WHERE id = ?`
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 )
 
 // =============================================================================
@@ -47,8 +48,10 @@ func createTestSchema(driver Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME
 		)`,
 		`CREATE TABLE IF NOT EXISTS domains (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -60,8 +63,10 @@ func createTestSchema(driver Driver) error {
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME,
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE CASCADE,
 			UNIQUE(ecosystem_id, name)
 		)`,
@@ -95,8 +100,10 @@ func createTestSchema(driver Driver) error {
 			language TEXT,
 			build_config TEXT,
 			git_repo_id INTEGER,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			archived_at DATETIME,
 			FOREIGN KEY (domain_id) REFERENCES domains(id),
 			FOREIGN KEY (system_id) REFERENCES systems(id),
 			FOREIGN KEY (git_repo_id) REFERENCES git_repos(id) ON DELETE SET NULL,
@@ -123,6 +130,10 @@ func createTestSchema(driver Driver) error {
 			env TEXT NOT NULL DEFAULT '{}',
 			build_config TEXT,
 			git_credential_mounting BOOLEAN NOT NULL DEFAULT 0,
+			last_attached_at DATETIME,
+			rebuild_reason TEXT,
+			build_inputs_hash TEXT,
+			image_digest TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (app_id) REFERENCES apps(id),
@@ -311,11 +322,93 @@ func createTestSchema(driver Driver) error {
 			duration_seconds INTEGER,
 			image_tag TEXT,
 			error_message TEXT,
+			sbom TEXT,
 			FOREIGN KEY (session_id) REFERENCES build_sessions(id) ON DELETE CASCADE,
 			FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_build_sessions_started ON build_sessions(started_at DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_build_session_workspaces_session ON build_session_workspaces(session_id)`,
+		// App session layouts (migration 029)
+		`CREATE TABLE IF NOT EXISTS app_session_layouts (
+			app_id INTEGER PRIMARY KEY REFERENCES apps(id) ON DELETE CASCADE,
+			spec TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Ecosystem runtime endpoints (migration 030)
+		`CREATE TABLE IF NOT EXISTS ecosystem_runtime_endpoints (
+			ecosystem_id INTEGER PRIMARY KEY REFERENCES ecosystems(id) ON DELETE CASCADE,
+			type TEXT NOT NULL DEFAULT 'docker',
+			host TEXT NOT NULL,
+			sync_dir TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Build templates (migration 032)
+		`CREATE TABLE IF NOT EXISTS build_templates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT,
+			build_config TEXT NOT NULL DEFAULT '{}',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// nvp sync history (migrations 035, 036)
+		`CREATE TABLE IF NOT EXISTS sync_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			source TEXT NOT NULL,
+			version TEXT,
+			dry_run BOOLEAN NOT NULL DEFAULT 0,
+			total_available INTEGER NOT NULL DEFAULT 0,
+			total_synced INTEGER NOT NULL DEFAULT 0,
+			created_count INTEGER NOT NULL DEFAULT 0,
+			updated_count INTEGER NOT NULL DEFAULT 0,
+			error_count INTEGER NOT NULL DEFAULT 0,
+			result TEXT NOT NULL DEFAULT '{}',
+			changes TEXT NOT NULL DEFAULT '[]',
+			errors TEXT NOT NULL DEFAULT '[]',
+			snapshot TEXT NOT NULL DEFAULT '{}',
+			started_at DATETIME NOT NULL,
+			duration_ms INTEGER NOT NULL DEFAULT 0,
+			rolled_back_at DATETIME,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// Field managers (migration 037)
+		`CREATE TABLE IF NOT EXISTS managed_fields (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			scope TEXT NOT NULL DEFAULT '',
+			field TEXT NOT NULL,
+			manager TEXT NOT NULL DEFAULT '',
+			value TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(kind, name, scope, field)
+		)`,
+		// Resource revisions (migration 038)
+		`CREATE TABLE IF NOT EXISTS resource_revisions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind TEXT NOT NULL,
+			name TEXT NOT NULL,
+			scope TEXT NOT NULL DEFAULT '',
+			revision INTEGER NOT NULL,
+			content TEXT NOT NULL,
+			manager TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(kind, name, scope, revision)
+		)`,
+		// Base images (migration 041)
+		`CREATE TABLE IF NOT EXISTS base_images (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			language TEXT NOT NULL,
+			version TEXT NOT NULL,
+			image TEXT NOT NULL,
+			registry_ref TEXT NOT NULL DEFAULT '',
+			dockerfile_hash TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(language, version)
+		)`,
 	}
 
 	for _, query := range queries {
@@ -1495,6 +1588,574 @@ func TestSQLDataStore_UpdateWorkspace(t *testing.T) {
 	}
 }
 
+func TestSQLDataStore_RecordWorkspaceAttach(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "attach-ws")
+
+	attached := &models.Workspace{AppID: app.ID, Name: "attached", Slug: "eco-dom-app-attached", ImageName: "img:latest", Status: "stopped"}
+	never := &models.Workspace{AppID: app.ID, Name: "never", Slug: "eco-dom-app-never", ImageName: "img:latest", Status: "stopped"}
+	for _, ws := range []*models.Workspace{attached, never} {
+		if err := ds.CreateWorkspace(ws); err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+	}
+
+	if err := ds.RecordWorkspaceAttach(attached.ID); err != nil {
+		t.Fatalf("RecordWorkspaceAttach() error = %v", err)
+	}
+
+	lastAttached, err := ds.ListWorkspaceLastAttached()
+	if err != nil {
+		t.Fatalf("ListWorkspaceLastAttached() error = %v", err)
+	}
+	if len(lastAttached) != 1 {
+		t.Fatalf("ListWorkspaceLastAttached() returned %d entries, want 1", len(lastAttached))
+	}
+	if at, ok := lastAttached[attached.ID]; !ok || time.Since(at) > time.Hour {
+		t.Errorf("ListWorkspaceLastAttached()[%d] = %v, want a recent time", attached.ID, at)
+	}
+
+	if err := ds.RecordWorkspaceAttach(9999); !IsNotFound(err) {
+		t.Errorf("RecordWorkspaceAttach(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_WorkspaceRebuildReason(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "rebuild-ws")
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "eco-dom-app-dev", ImageName: "img:latest", Status: "stopped"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	if err := ds.SetWorkspaceRebuildReason(ws.ID, "build template go-service changed"); err != nil {
+		t.Fatalf("SetWorkspaceRebuildReason() error = %v", err)
+	}
+	reasons, err := ds.ListWorkspaceRebuildReasons()
+	if err != nil {
+		t.Fatalf("ListWorkspaceRebuildReasons() error = %v", err)
+	}
+	if got := reasons[ws.ID]; got != "build template go-service changed" || len(reasons) != 1 {
+		t.Errorf("ListWorkspaceRebuildReasons() = %v, want the reason for workspace %d", reasons, ws.ID)
+	}
+
+	if err := ds.SetWorkspaceRebuildReason(ws.ID, ""); err != nil {
+		t.Fatalf("SetWorkspaceRebuildReason(clear) error = %v", err)
+	}
+	if reasons, _ := ds.ListWorkspaceRebuildReasons(); len(reasons) != 0 {
+		t.Errorf("ListWorkspaceRebuildReasons() after clear = %v, want empty", reasons)
+	}
+
+	if err := ds.SetWorkspaceRebuildReason(9999, "x"); !IsNotFound(err) {
+		t.Errorf("SetWorkspaceRebuildReason(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_WorkspaceBuildInputs(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "inputs-ws")
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "eco-dom-app-dev", ImageName: "img:latest", Status: "stopped"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+	if hashes, err := ds.ListWorkspaceBuildInputs(); err != nil || len(hashes) != 0 {
+		t.Fatalf("ListWorkspaceBuildInputs() = %v, %v, want none before a build", hashes, err)
+	}
+
+	if err := ds.SetWorkspaceBuildInputs(ws.ID, "sha256:abc"); err != nil {
+		t.Fatalf("SetWorkspaceBuildInputs() error = %v", err)
+	}
+	hashes, err := ds.ListWorkspaceBuildInputs()
+	if err != nil {
+		t.Fatalf("ListWorkspaceBuildInputs() error = %v", err)
+	}
+	if got := hashes[ws.ID]; got != "sha256:abc" || len(hashes) != 1 {
+		t.Errorf("ListWorkspaceBuildInputs() = %v, want the hash for workspace %d", hashes, ws.ID)
+	}
+
+	if err := ds.SetWorkspaceBuildInputs(9999, "sha256:abc"); !IsNotFound(err) {
+		t.Errorf("SetWorkspaceBuildInputs(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_WorkspaceImageDigests(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "digest-ws")
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "eco-dom-app-dev", ImageName: "img:latest", Status: "stopped"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	if err := ds.SetWorkspaceImageDigest(ws.ID, "sha256:abc"); err != nil {
+		t.Fatalf("SetWorkspaceImageDigest() error = %v", err)
+	}
+	digests, err := ds.ListWorkspaceImageDigests()
+	if err != nil {
+		t.Fatalf("ListWorkspaceImageDigests() error = %v", err)
+	}
+	if got := digests[ws.ID]; got != "sha256:abc" || len(digests) != 1 {
+		t.Errorf("ListWorkspaceImageDigests() = %v, want the digest for workspace %d", digests, ws.ID)
+	}
+
+	if err := ds.SetWorkspaceImageDigest(ws.ID, ""); err != nil {
+		t.Fatalf("SetWorkspaceImageDigest(clear) error = %v", err)
+	}
+	if digests, _ := ds.ListWorkspaceImageDigests(); len(digests) != 0 {
+		t.Errorf("ListWorkspaceImageDigests() = %v after clearing, want none", digests)
+	}
+	if err := ds.SetWorkspaceImageDigest(9999, "sha256:abc"); !IsNotFound(err) {
+		t.Errorf("SetWorkspaceImageDigest(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_SetWorkspaceContainerID(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "adopt-ws")
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "eco-dom-app-dev", ImageName: "img:latest", Status: "stopped"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	if err := ds.SetWorkspaceContainerID(ws.ID, "abc123"); err != nil {
+		t.Fatalf("SetWorkspaceContainerID() error = %v", err)
+	}
+	got, err := ds.GetWorkspaceByID(ws.ID)
+	if err != nil {
+		t.Fatalf("GetWorkspaceByID() error = %v", err)
+	}
+	if !got.ContainerID.Valid || got.ContainerID.String != "abc123" {
+		t.Errorf("ContainerID = %v, want abc123", got.ContainerID)
+	}
+
+	if err := ds.SetWorkspaceContainerID(ws.ID, ""); err != nil {
+		t.Fatalf("SetWorkspaceContainerID(clear) error = %v", err)
+	}
+	if got, _ := ds.GetWorkspaceByID(ws.ID); got.ContainerID.Valid {
+		t.Errorf("ContainerID = %v after clearing, want NULL", got.ContainerID)
+	}
+	if err := ds.SetWorkspaceContainerID(9999, "abc123"); !IsNotFound(err) {
+		t.Errorf("SetWorkspaceContainerID(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_BaseImages(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	img := &models.BaseImage{Language: "golang", Version: "1.22", Image: "dvm-base-golang:1.22", DockerfileHash: "sha256:a"}
+	if err := ds.SetBaseImage(img); err != nil {
+		t.Fatalf("SetBaseImage() error = %v", err)
+	}
+	if err := ds.SetBaseImage(&models.BaseImage{Language: "python", Version: "3.11", Image: "dvm-base-python:3.11", DockerfileHash: "sha256:b"}); err != nil {
+		t.Fatalf("SetBaseImage() error = %v", err)
+	}
+
+	// Rebuilding replaces the recorded image
+	img.RegistryRef = "localhost:5001/dvm-base/golang:1.22"
+	img.DockerfileHash = "sha256:c"
+	if err := ds.SetBaseImage(img); err != nil {
+		t.Fatalf("SetBaseImage() (replace) error = %v", err)
+	}
+	got, err := ds.GetBaseImage("golang", "1.22")
+	if err != nil {
+		t.Fatalf("GetBaseImage() error = %v", err)
+	}
+	if got.RegistryRef != img.RegistryRef || got.DockerfileHash != "sha256:c" {
+		t.Errorf("GetBaseImage() = %+v, want the replaced image", got)
+	}
+
+	images, err := ds.ListBaseImages()
+	if err != nil {
+		t.Fatalf("ListBaseImages() error = %v", err)
+	}
+	if len(images) != 2 || images[0].Language != "golang" || images[1].Language != "python" {
+		t.Errorf("ListBaseImages() = %v, want golang and python", images)
+	}
+
+	if err := ds.DeleteBaseImage("golang", "1.22"); err != nil {
+		t.Fatalf("DeleteBaseImage() error = %v", err)
+	}
+	if _, err := ds.GetBaseImage("golang", "1.22"); !IsNotFound(err) {
+		t.Errorf("GetBaseImage() after delete error = %v, want not found", err)
+	}
+	if err := ds.DeleteBaseImage("golang", "1.22"); !IsNotFound(err) {
+		t.Errorf("DeleteBaseImage() of a missing image error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_BuildTemplates(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	if _, err := ds.GetBuildTemplate("go-service"); !IsNotFound(err) {
+		t.Fatalf("GetBuildTemplate() before create error = %v, want not found", err)
+	}
+
+	tmpl := &models.BuildTemplate{Name: "go-service", BuildConfig: `{"args":{"GO_VERSION":"1.22"}}`}
+	if err := ds.CreateBuildTemplate(tmpl); err != nil {
+		t.Fatalf("CreateBuildTemplate() error = %v", err)
+	}
+	if tmpl.ID == 0 {
+		t.Error("CreateBuildTemplate() did not set ID")
+	}
+
+	tmpl.BuildConfig = `{"args":{"GO_VERSION":"1.23"}}`
+	tmpl.Description = sql.NullString{String: "Go services", Valid: true}
+	if err := ds.UpdateBuildTemplate(tmpl); err != nil {
+		t.Fatalf("UpdateBuildTemplate() error = %v", err)
+	}
+	got, err := ds.GetBuildTemplate("go-service")
+	if err != nil {
+		t.Fatalf("GetBuildTemplate() error = %v", err)
+	}
+	if got.GetBuildConfig().Args["GO_VERSION"] != "1.23" || got.Description.String != "Go services" {
+		t.Errorf("GetBuildTemplate() = %+v, want the updated template", got)
+	}
+
+	if err := ds.CreateBuildTemplate(&models.BuildTemplate{Name: "base"}); err != nil {
+		t.Fatalf("CreateBuildTemplate() error = %v", err)
+	}
+	list, err := ds.ListBuildTemplates()
+	if err != nil {
+		t.Fatalf("ListBuildTemplates() error = %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "base" || list[1].Name != "go-service" {
+		t.Errorf("ListBuildTemplates() = %v, want base and go-service in order", list)
+	}
+
+	if err := ds.DeleteBuildTemplate("base"); err != nil {
+		t.Fatalf("DeleteBuildTemplate() error = %v", err)
+	}
+	if err := ds.DeleteBuildTemplate("base"); !IsNotFound(err) {
+		t.Errorf("DeleteBuildTemplate() twice error = %v, want not found", err)
+	}
+	if err := ds.UpdateBuildTemplate(&models.BuildTemplate{Name: "base"}); !IsNotFound(err) {
+		t.Errorf("UpdateBuildTemplate(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_SyncHistory(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	if _, err := ds.GetSyncHistory(1); !IsNotFound(err) {
+		t.Fatalf("GetSyncHistory() before create error = %v, want not found", err)
+	}
+
+	started := time.Now().UTC().Truncate(time.Second)
+	for _, source := range []string{"lazyvim", "mycorp", "lazyvim"} {
+		h := &models.SyncHistory{Source: source, TotalAvailable: 3, TotalSynced: 2, StartedAt: started, DurationMS: 1500}
+		if err := h.SetChanges([]models.SyncChange{
+			{Plugin: "telescope", Action: models.SyncChangeCreated, Added: 5},
+			{Plugin: "flash", Action: models.SyncChangeUpdated, Added: 1, Removed: 1},
+		}); err != nil {
+			t.Fatalf("SetChanges() error = %v", err)
+		}
+		if err := h.SetErrors([]string{"failed to write plugin noice"}); err != nil {
+			t.Fatalf("SetErrors() error = %v", err)
+		}
+		if err := h.SetSnapshot(map[string]models.SyncFile{"plugins/telescope.yaml": {After: "name: telescope\n"}}); err != nil {
+			t.Fatalf("SetSnapshot() error = %v", err)
+		}
+		if err := ds.CreateSyncHistory(h); err != nil {
+			t.Fatalf("CreateSyncHistory() error = %v", err)
+		}
+		if h.ID == 0 {
+			t.Error("CreateSyncHistory() did not set ID")
+		}
+	}
+
+	got, err := ds.GetSyncHistory(2)
+	if err != nil {
+		t.Fatalf("GetSyncHistory() error = %v", err)
+	}
+	if got.Source != "mycorp" || got.CreatedCount != 1 || got.UpdatedCount != 1 || got.ErrorCount != 1 {
+		t.Errorf("GetSyncHistory() = %+v, want the mycorp run with its counts", got)
+	}
+	if changes := got.GetChanges(); len(changes) != 2 || changes[1].Plugin != "flash" {
+		t.Errorf("GetChanges() = %v, want telescope and flash", changes)
+	}
+	if !got.StartedAt.Equal(started) || got.Duration() != 1500*time.Millisecond {
+		t.Errorf("GetSyncHistory() started %v after %v, want %v after 1.5s", got.StartedAt, got.Duration(), started)
+	}
+
+	if f, ok := got.GetSnapshot()["plugins/telescope.yaml"]; !ok || f.Before != nil || f.After != "name: telescope\n" {
+		t.Errorf("GetSnapshot() = %v, want the created telescope file", got.GetSnapshot())
+	}
+	if got.RolledBackAt.Valid {
+		t.Errorf("GetSyncHistory() RolledBackAt = %v, want not rolled back", got.RolledBackAt)
+	}
+	if err := ds.MarkSyncHistoryRolledBack(2); err != nil {
+		t.Fatalf("MarkSyncHistoryRolledBack() error = %v", err)
+	}
+	if got, _ := ds.GetSyncHistory(2); !got.RolledBackAt.Valid {
+		t.Error("MarkSyncHistoryRolledBack() did not set RolledBackAt")
+	}
+	if err := ds.MarkSyncHistoryRolledBack(99); !IsNotFound(err) {
+		t.Errorf("MarkSyncHistoryRolledBack(missing) error = %v, want not found", err)
+	}
+
+	list, err := ds.ListSyncHistory("lazyvim", 0)
+	if err != nil {
+		t.Fatalf("ListSyncHistory() error = %v", err)
+	}
+	if len(list) != 2 || list[0].ID != 3 || list[1].ID != 1 {
+		t.Errorf("ListSyncHistory(lazyvim) = %v, want runs 3 and 1", list)
+	}
+	if list, _ := ds.ListSyncHistory("", 1); len(list) != 1 || list[0].ID != 3 {
+		t.Errorf("ListSyncHistory(limit 1) = %v, want run 3", list)
+	}
+}
+
+func TestSQLDataStore_UpdateWorkspaceStatus(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "status-ws")
+	ws := &models.Workspace{AppID: app.ID, Name: "dev", Slug: "eco-dom-app-dev", ImageName: "img:latest", Status: "running"}
+	if err := ds.CreateWorkspace(ws); err != nil {
+		t.Fatalf("Setup error: %v", err)
+	}
+
+	if err := ds.UpdateWorkspaceStatus(ws.ID, "stopped"); err != nil {
+		t.Fatalf("UpdateWorkspaceStatus() error = %v", err)
+	}
+	retrieved, err := ds.GetWorkspaceByID(ws.ID)
+	if err != nil {
+		t.Fatalf("GetWorkspaceByID() error = %v", err)
+	}
+	if retrieved.Status != "stopped" {
+		t.Errorf("Status = %q, want %q", retrieved.Status, "stopped")
+	}
+
+	if err := ds.UpdateWorkspaceStatus(9999, "stopped"); !IsNotFound(err) {
+		t.Errorf("UpdateWorkspaceStatus(missing) error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_AppSessionLayout(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "layout-app")
+
+	if _, err := ds.GetAppSessionLayout(app.ID); !IsNotFound(err) {
+		t.Fatalf("GetAppSessionLayout() before set error = %v, want not found", err)
+	}
+
+	for _, spec := range []string{"windows: [{name: editor}]\n", "multiplexer: zellij\nwindows: [{name: shell}]\n"} {
+		if err := ds.SetAppSessionLayout(&models.AppSessionLayout{AppID: app.ID, Spec: spec}); err != nil {
+			t.Fatalf("SetAppSessionLayout() error = %v", err)
+		}
+		layout, err := ds.GetAppSessionLayout(app.ID)
+		if err != nil {
+			t.Fatalf("GetAppSessionLayout() error = %v", err)
+		}
+		if layout.Spec != spec {
+			t.Errorf("GetAppSessionLayout() Spec = %q, want %q", layout.Spec, spec)
+		}
+	}
+
+	if err := ds.DeleteAppSessionLayout(app.ID); err != nil {
+		t.Fatalf("DeleteAppSessionLayout() error = %v", err)
+	}
+	if err := ds.DeleteAppSessionLayout(app.ID); !IsNotFound(err) {
+		t.Errorf("DeleteAppSessionLayout() twice error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_RuntimeEndpoint(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	ecosystem := &models.Ecosystem{Name: "remote-eco"}
+	if err := ds.CreateEcosystem(ecosystem); err != nil {
+		t.Fatalf("CreateEcosystem() error = %v", err)
+	}
+
+	if _, err := ds.GetRuntimeEndpoint(ecosystem.ID); !IsNotFound(err) {
+		t.Fatalf("GetRuntimeEndpoint() before set error = %v, want not found", err)
+	}
+
+	for _, want := range []models.RuntimeEndpoint{
+		{EcosystemID: ecosystem.ID, Type: models.RuntimeEndpointDocker, Host: "ssh://dev@build-1"},
+		{EcosystemID: ecosystem.ID, Type: models.RuntimeEndpointNerdctl, Host: "ssh://dev@build-2:2222", SyncDir: "/srv/dvm"},
+	} {
+		if err := ds.SetRuntimeEndpoint(&want); err != nil {
+			t.Fatalf("SetRuntimeEndpoint() error = %v", err)
+		}
+		got, err := ds.GetRuntimeEndpoint(ecosystem.ID)
+		if err != nil {
+			t.Fatalf("GetRuntimeEndpoint() error = %v", err)
+		}
+		if got.Type != want.Type || got.Host != want.Host || got.SyncDir != want.SyncDir {
+			t.Errorf("GetRuntimeEndpoint() = %+v, want %+v", got, want)
+		}
+	}
+
+	if err := ds.DeleteRuntimeEndpoint(ecosystem.ID); err != nil {
+		t.Fatalf("DeleteRuntimeEndpoint() error = %v", err)
+	}
+	if err := ds.DeleteRuntimeEndpoint(ecosystem.ID); !IsNotFound(err) {
+		t.Errorf("DeleteRuntimeEndpoint() twice error = %v, want not found", err)
+	}
+}
+
+func TestSQLDataStore_Archive(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "archived")
+	ws := createTestWorkspace(t, ds, app.ID, "dev")
+	domainID := int(app.DomainID.Int64)
+	domain, err := ds.GetDomainByID(domainID)
+	if err != nil {
+		t.Fatalf("GetDomainByID() error = %v", err)
+	}
+	ecosystemID := int(domain.EcosystemID.Int64)
+
+	if err := ds.ArchiveResource(models.ArchiveKindDomain, domainID); err != nil {
+		t.Fatalf("ArchiveResource() error = %v", err)
+	}
+
+	archived, err := ds.ListArchivedResources()
+	if err != nil {
+		t.Fatalf("ListArchivedResources() error = %v", err)
+	}
+	if len(archived) != 1 || archived[0].Kind != models.ArchiveKindDomain || archived[0].Path() != "test-eco-archived/test-domain-archived" {
+		t.Fatalf("ListArchivedResources() = %+v, want the domain", archived)
+	}
+	if archived[0].ArchivedAt.IsZero() {
+		t.Error("ListArchivedResources() has no archive time")
+	}
+
+	ids, err := ds.GetArchivedIDs()
+	if err != nil {
+		t.Fatalf("GetArchivedIDs() error = %v", err)
+	}
+	if ids.Ecosystems[ecosystemID] || !ids.Domains[domainID] || !ids.Apps[app.ID] || !ids.Workspaces[ws.ID] {
+		t.Errorf("GetArchivedIDs() = %+v, want the domain and everything below it", ids)
+	}
+
+	// Archived resources still exist for direct lookups
+	if _, err := ds.GetAppByID(app.ID); err != nil {
+		t.Errorf("GetAppByID() of an archived app error = %v", err)
+	}
+
+	if err := ds.RestoreResource(models.ArchiveKindDomain, domainID); err != nil {
+		t.Fatalf("RestoreResource() error = %v", err)
+	}
+	if ids, _ := ds.GetArchivedIDs(); len(ids.Domains) != 0 || len(ids.Workspaces) != 0 {
+		t.Errorf("GetArchivedIDs() after restore = %+v, want nothing", ids)
+	}
+
+	if err := ds.ArchiveResource(models.ArchiveKindApp, 9999); !IsNotFound(err) {
+		t.Errorf("ArchiveResource() of a missing app error = %v, want not found", err)
+	}
+	if err := ds.ArchiveResource("workspace", ws.ID); err == nil {
+		t.Error("ArchiveResource() of a workspace succeeded")
+	}
+}
+
+func TestSQLDataStore_HierarchyEnv(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "env")
+	app.SetEnv(map[string]string{"PORT": "8080"})
+	if err := ds.UpdateApp(app); err != nil {
+		t.Fatalf("UpdateApp() error = %v", err)
+	}
+	domain, err := ds.GetDomainByID(int(app.DomainID.Int64))
+	if err != nil {
+		t.Fatalf("GetDomainByID() error = %v", err)
+	}
+	domain.SetEnv(map[string]string{"LOG_LEVEL": "info"})
+	if err := ds.UpdateDomain(domain); err != nil {
+		t.Fatalf("UpdateDomain() error = %v", err)
+	}
+	eco := &models.Ecosystem{Name: "env-eco"}
+	eco.SetEnv(map[string]string{"REGION": "eu-west-1"})
+	if err := ds.CreateEcosystem(eco); err != nil {
+		t.Fatalf("CreateEcosystem() error = %v", err)
+	}
+
+	gotApp, err := ds.GetAppByID(app.ID)
+	if err != nil || gotApp.GetEnv()["PORT"] != "8080" {
+		t.Errorf("GetAppByID() env = %v, %v", gotApp.GetEnv(), err)
+	}
+	gotDomain, err := ds.GetDomainByID(domain.ID)
+	if err != nil || gotDomain.GetEnv()["LOG_LEVEL"] != "info" {
+		t.Errorf("GetDomainByID() env = %v, %v", gotDomain.GetEnv(), err)
+	}
+	gotEco, err := ds.GetEcosystemByName("env-eco")
+	if err != nil || gotEco.GetEnv()["REGION"] != "eu-west-1" {
+		t.Errorf("GetEcosystemByName() env = %v, %v", gotEco.GetEnv(), err)
+	}
+}
+
+func TestSQLDataStore_OrphanedWorkspacePlugins(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "gc-app")
+	ws := createTestWorkspace(t, ds, app.ID, "gc")
+	plugin := &models.NvimPluginDB{Name: "kept-plugin", Repo: "user/kept-plugin", Enabled: true}
+	if err := ds.CreatePlugin(plugin); err != nil {
+		t.Fatalf("CreatePlugin() error = %v", err)
+	}
+	if err := ds.AddPluginToWorkspace(ws.ID, plugin.ID); err != nil {
+		t.Fatalf("AddPluginToWorkspace() error = %v", err)
+	}
+
+	// Rows left behind by deletes made without foreign key enforcement
+	if _, err := ds.driver.Execute("PRAGMA foreign_keys = OFF"); err != nil {
+		t.Fatalf("PRAGMA error = %v", err)
+	}
+	for _, row := range [][2]int{{ws.ID, 9999}, {9999, plugin.ID}} {
+		if _, err := ds.driver.Execute("INSERT INTO workspace_plugins (workspace_id, plugin_id) VALUES (?, ?)", row[0], row[1]); err != nil {
+			t.Fatalf("insert orphan error = %v", err)
+		}
+	}
+	if _, err := ds.driver.Execute("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatalf("PRAGMA error = %v", err)
+	}
+
+	count, err := ds.CountOrphanedWorkspacePlugins()
+	if err != nil {
+		t.Fatalf("CountOrphanedWorkspacePlugins() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountOrphanedWorkspacePlugins() = %d, want 2", count)
+	}
+
+	removed, err := ds.DeleteOrphanedWorkspacePlugins()
+	if err != nil {
+		t.Fatalf("DeleteOrphanedWorkspacePlugins() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("DeleteOrphanedWorkspacePlugins() = %d, want 2", removed)
+	}
+
+	plugins, err := ds.GetWorkspacePlugins(ws.ID)
+	if err != nil {
+		t.Fatalf("GetWorkspacePlugins() error = %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].ID != plugin.ID {
+		t.Errorf("GetWorkspacePlugins() = %v, want only the kept plugin", plugins)
+	}
+}
+
 func TestSQLDataStore_DeleteWorkspace(t *testing.T) {
 	ds := createTestDataStore(t)
 	defer ds.Close()
@@ -1584,6 +2245,49 @@ func TestSQLDataStore_ListAllWorkspaces(t *testing.T) {
 	}
 }
 
+func TestSQLDataStore_ListAllWorkspacesPage(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	app := createTestApp(t, ds, "page")
+	for w := 1; w <= 5; w++ {
+		ws := &models.Workspace{
+			AppID:     app.ID,
+			Name:      fmt.Sprintf("ws-%d", w),
+			Slug:      fmt.Sprintf("eco-dom-page-ws-%d", w),
+			ImageName: "img:latest",
+			Status:    "stopped",
+		}
+		if err := ds.CreateWorkspace(ws); err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		opts ListOptions
+		want []string
+	}{
+		{ListOptions{}, []string{"ws-1", "ws-2", "ws-3", "ws-4", "ws-5"}},
+		{ListOptions{Limit: 2}, []string{"ws-1", "ws-2"}},
+		{ListOptions{Limit: 2, Offset: 2}, []string{"ws-3", "ws-4"}},
+		{ListOptions{Offset: 3}, []string{"ws-4", "ws-5"}},
+		{ListOptions{Limit: 2, Offset: 10}, nil},
+	}
+	for _, tt := range tests {
+		workspaces, err := ds.ListAllWorkspacesPage(tt.opts)
+		if err != nil {
+			t.Fatalf("ListAllWorkspacesPage(%+v) error = %v", tt.opts, err)
+		}
+		var got []string
+		for _, ws := range workspaces {
+			got = append(got, ws.Name)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("ListAllWorkspacesPage(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+}
+
 // =============================================================================
 // Context Tests
 // =============================================================================
@@ -1953,6 +2657,26 @@ func TestSQLDataStore_ListPlugins(t *testing.T) {
 	}
 }
 
+func TestSQLDataStore_ListPluginsPage(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	for _, name := range []string{"c-plugin", "a-plugin", "b-plugin"} {
+		plugin := &models.NvimPluginDB{Name: name, Repo: "user/" + name, Enabled: true}
+		if err := ds.CreatePlugin(plugin); err != nil {
+			t.Fatalf("Setup error: %v", err)
+		}
+	}
+
+	plugins, err := ds.ListPluginsPage(ListOptions{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListPluginsPage() error = %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Name != "b-plugin" {
+		t.Errorf("ListPluginsPage(limit 1, offset 1) = %v, want [b-plugin]", plugins)
+	}
+}
+
 func TestSQLDataStore_ListPluginsByCategory(t *testing.T) {
 	ds := createTestDataStore(t)
 	defer ds.Close()
@@ -2174,6 +2898,7 @@ func TestSQLDataStore_MigrationSchema_AppsTableHasLanguageAndBuildConfig(t *test
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		);
@@ -2188,6 +2913,7 @@ func TestSQLDataStore_MigrationSchema_AppsTableHasLanguageAndBuildConfig(t *test
 			terminal_package TEXT,
 			build_args TEXT,
 			ca_certs TEXT,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id),
@@ -2241,6 +2967,7 @@ func TestSQLDataStore_MigrationSchema_AppsTableHasLanguageAndBuildConfig(t *test
 			language TEXT,
 			build_config TEXT,
 			git_repo_id INTEGER,
+			env TEXT,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE CASCADE,
@@ -2362,14 +3089,17 @@ func TestSQLDataStore_CreateApp_ErrorNotDuplicated(t *testing.T) {
 
 	// The error should NOT contain duplicated phrases
 	// Before fix: "failed to create app: failed to create app: UNIQUE constraint failed"
-	// After fix: "UNIQUE constraint failed" (raw error from sqlite)
+	// After fix: "app 'duplicate-app' already exists"
 	if strings.Contains(errMsg, "failed to create app: failed to create app") {
 		t.Errorf("CreateApp() error message contains duplicate wrapping: %q", errMsg)
 	}
 
 	// Verify it's still a meaningful error
-	if !strings.Contains(errMsg, "UNIQUE") {
-		t.Errorf("CreateApp() error should mention UNIQUE constraint: %q", errMsg)
+	if !IsConflict(err) {
+		t.Errorf("CreateApp() error should be a conflict: %q", errMsg)
+	}
+	if !strings.Contains(errMsg, "duplicate-app") {
+		t.Errorf("CreateApp() error should name the app: %q", errMsg)
 	}
 }
 
@@ -3058,3 +3788,78 @@ func TestSQLDataStore_UpdateWorkspace_Env(t *testing.T) {
 		t.Errorf("GetEnv()[UPDATED_KEY] = %q, want %q", gotEnv["UPDATED_KEY"], "updated_value")
 	}
 }
+
+func TestSQLDataStore_ManagedFields(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	record := func(scope string, fields ...*models.ManagedField) {
+		t.Helper()
+		if err := ds.ReplaceManagedFields("Workspace", "dev", scope, fields); err != nil {
+			t.Fatalf("ReplaceManagedFields() error = %v", err)
+		}
+	}
+	record("prod/api//api", &models.ManagedField{Field: "spec.image.name", Manager: "manifest", Value: `"ubuntu:24.04"`})
+	record("prod/web//web",
+		&models.ManagedField{Field: "spec.env.DEBUG", Manager: "cli", Value: `"1"`},
+		&models.ManagedField{Field: "spec.shell", Value: `"zsh"`},
+	)
+	// Replacing a scope's fields leaves the other scope alone
+	record("prod/api//api", &models.ManagedField{Field: "spec.image.name", Manager: "cli", Value: `"debian:12"`})
+
+	fields, err := ds.ListManagedFields("Workspace", "dev")
+	if err != nil {
+		t.Fatalf("ListManagedFields() error = %v", err)
+	}
+	if len(fields) != 3 {
+		t.Fatalf("ListManagedFields() = %d fields, want 3", len(fields))
+	}
+	if f := fields[0]; f.Scope != "prod/api//api" || f.Manager != "cli" || f.Value != `"debian:12"` {
+		t.Errorf("fields[0] = %+v, want the replaced image owned by cli", f)
+	}
+	if f := fields[2]; f.Field != "spec.shell" || f.Manager != "" {
+		t.Errorf("fields[2] = %+v, want spec.shell with no manager", f)
+	}
+
+	if err := ds.DeleteManagedFields("Workspace", "dev"); err != nil {
+		t.Fatalf("DeleteManagedFields() error = %v", err)
+	}
+	if fields, _ := ds.ListManagedFields("Workspace", "dev"); len(fields) != 0 {
+		t.Errorf("ListManagedFields() after delete = %d fields, want none", len(fields))
+	}
+}
+
+func TestSQLDataStore_ResourceRevisions(t *testing.T) {
+	ds := createTestDataStore(t)
+	defer ds.Close()
+
+	for i := 1; i <= 4; i++ {
+		rev := &models.ResourceRevision{Kind: "App", Name: "api", Scope: "prod/backend//", Content: fmt.Sprintf("revision: %d\n", i), Manager: "manifest"}
+		if err := ds.CreateResourceRevision(rev, 3); err != nil {
+			t.Fatalf("CreateResourceRevision() error = %v", err)
+		}
+		if rev.Revision != i || rev.ID == 0 {
+			t.Errorf("CreateResourceRevision() set revision %d, ID %d, want revision %d", rev.Revision, rev.ID, i)
+		}
+	}
+	// Another scope numbers its own revisions
+	other := &models.ResourceRevision{Kind: "App", Name: "api", Scope: "prod/frontend//", Content: "x: 1\n"}
+	if err := ds.CreateResourceRevision(other, 3); err != nil || other.Revision != 1 {
+		t.Fatalf("CreateResourceRevision() in another scope = revision %d, %v, want 1", other.Revision, err)
+	}
+
+	revisions, err := ds.ListResourceRevisions("App", "api", "prod/backend//")
+	if err != nil {
+		t.Fatalf("ListResourceRevisions() error = %v", err)
+	}
+	if len(revisions) != 3 || revisions[0].Revision != 2 || revisions[2].Content != "revision: 4\n" {
+		t.Errorf("ListResourceRevisions() = %d revisions from %v, want revisions 2 to 4", len(revisions), revisions)
+	}
+
+	if err := ds.DeleteResourceRevisions("App", "api"); err != nil {
+		t.Fatalf("DeleteResourceRevisions() error = %v", err)
+	}
+	if revisions, _ := ds.ListResourceRevisions("App", "api", "prod/frontend//"); len(revisions) != 0 {
+		t.Errorf("ListResourceRevisions() after delete = %d revisions, want none", len(revisions))
+	}
+}
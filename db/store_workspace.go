@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"devopsmaestro/models"
 )
@@ -26,7 +27,7 @@ func (ds *SQLDataStore) CreateWorkspace(workspace *models.Workspace) error {
 
 	result, err := ds.driver.Execute(query, workspace.AppID, workspace.Name, workspace.Slug, workspace.Description, workspace.ImageName, workspace.Status, workspace.SSHAgentForwarding, workspace.NvimStructure, workspace.NvimPlugins, workspace.Theme, workspace.TerminalPrompt, workspace.TerminalPlugins, workspace.TerminalPackage, workspace.NvimPackage, workspace.GitRepoID, workspace.Env, workspace.BuildConfig, workspace.GitCredentialMounting)
 	if err != nil {
-		return fmt.Errorf("failed to create workspace: %w", err)
+		return fmt.Errorf("failed to create workspace: %w", asConflict(err, "workspace", workspace.Name))
 	}
 
 	id, err := result.LastInsertId()
@@ -58,6 +59,13 @@ func (ds *SQLDataStore) GetWorkspaceByName(appID int, name string) (*models.Work
 
 // GetWorkspaceByID retrieves a workspace by its ID.
 func (ds *SQLDataStore) GetWorkspaceByID(id int) (*models.Workspace, error) {
+	return cachedRow(ds.cache, cacheKey("workspace", id), func() (*models.Workspace, error) {
+		return ds.loadWorkspaceByID(id)
+	}, copyRow[models.Workspace])
+}
+
+// loadWorkspaceByID reads a workspace from the database, bypassing the cache.
+func (ds *SQLDataStore) loadWorkspaceByID(id int) (*models.Workspace, error) {
 	workspace := &models.Workspace{}
 	query := `SELECT id, app_id, name, slug, description, image_name, container_id, status, ssh_agent_forwarding, nvim_structure, nvim_plugins, theme, terminal_prompt, terminal_plugins, terminal_package, nvim_package, git_repo_id, env, build_config, git_credential_mounting, created_at, updated_at 
 		FROM workspaces WHERE id = ?`
@@ -171,8 +179,13 @@ func (ds *SQLDataStore) ListWorkspacesByApp(appID int) ([]*models.Workspace, err
 
 // ListAllWorkspaces retrieves all workspaces across all apps.
 func (ds *SQLDataStore) ListAllWorkspaces() ([]*models.Workspace, error) {
+	return ds.ListAllWorkspacesPage(ListOptions{})
+}
+
+// ListAllWorkspacesPage retrieves one page of all workspaces.
+func (ds *SQLDataStore) ListAllWorkspacesPage(opts ListOptions) ([]*models.Workspace, error) {
 	query := `SELECT id, app_id, name, slug, description, image_name, container_id, status, ssh_agent_forwarding, nvim_structure, nvim_plugins, theme, terminal_prompt, terminal_plugins, terminal_package, nvim_package, git_repo_id, env, build_config, git_credential_mounting, created_at, updated_at 
-		FROM workspaces ORDER BY app_id, name`
+		FROM workspaces ORDER BY app_id, name ` + ds.limitClause(opts)
 
 	rows, err := ds.driver.Query(query)
 	if err != nil {
@@ -375,3 +388,217 @@ func (ds *SQLDataStore) GetWorkspaceSlug(workspaceID int) (string, error) {
 
 	return slug, nil
 }
+
+// RecordWorkspaceAttach sets a workspace's last-attached time to now.
+func (ds *SQLDataStore) RecordWorkspaceAttach(workspaceID int) error {
+	query := fmt.Sprintf(`UPDATE workspaces SET last_attached_at = %s WHERE id = ?`, ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to record workspace attach: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// UpdateWorkspaceStatus sets a workspace's status column.
+func (ds *SQLDataStore) UpdateWorkspaceStatus(workspaceID int, status string) error {
+	query := fmt.Sprintf(`UPDATE workspaces SET status = ?, updated_at = %s WHERE id = ?`, ds.queryBuilder.Now())
+
+	result, err := ds.driver.Execute(query, status, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace status: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// SetWorkspaceContainerID records the container a workspace runs in, e.g. one
+// adopted by its labels after being created outside dvm. An empty ID clears it.
+func (ds *SQLDataStore) SetWorkspaceContainerID(workspaceID int, containerID string) error {
+	value := sql.NullString{String: containerID, Valid: containerID != ""}
+
+	result, err := ds.driver.Execute(`UPDATE workspaces SET container_id = ? WHERE id = ?`, value, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to set workspace container ID: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// SetWorkspaceRebuildReason records why a workspace's image is out of date.
+// An empty reason clears it. It does not touch updated_at, which tracks spec
+// changes.
+func (ds *SQLDataStore) SetWorkspaceRebuildReason(workspaceID int, reason string) error {
+	value := sql.NullString{String: reason, Valid: reason != ""}
+
+	result, err := ds.driver.Execute(`UPDATE workspaces SET rebuild_reason = ? WHERE id = ?`, value, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to set workspace rebuild reason: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// ListWorkspaceRebuildReasons returns the rebuild reason of every workspace
+// with a pending rebuild, keyed by workspace ID.
+func (ds *SQLDataStore) ListWorkspaceRebuildReasons() (map[int]string, error) {
+	rows, err := ds.driver.Query(`SELECT id, rebuild_reason FROM workspaces WHERE rebuild_reason IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace rebuild reasons: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var reason string
+		if err := rows.Scan(&id, &reason); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace rebuild reason: %w", err)
+		}
+		result[id] = reason
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over workspace rebuild reasons: %w", err)
+	}
+	return result, nil
+}
+
+// SetWorkspaceBuildInputs records the hash of the build inputs a workspace's
+// image was built from. Like the rebuild reason it leaves updated_at alone.
+func (ds *SQLDataStore) SetWorkspaceBuildInputs(workspaceID int, hash string) error {
+	value := sql.NullString{String: hash, Valid: hash != ""}
+
+	result, err := ds.driver.Execute(`UPDATE workspaces SET build_inputs_hash = ? WHERE id = ?`, value, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to set workspace build inputs: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// ListWorkspaceBuildInputs returns the build inputs hash of every workspace
+// built since it was tracked, keyed by workspace ID.
+func (ds *SQLDataStore) ListWorkspaceBuildInputs() (map[int]string, error) {
+	rows, err := ds.driver.Query(`SELECT id, build_inputs_hash FROM workspaces WHERE build_inputs_hash IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace build inputs: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace build inputs: %w", err)
+		}
+		result[id] = hash
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over workspace build inputs: %w", err)
+	}
+	return result, nil
+}
+
+// SetWorkspaceImageDigest records the digest of the image a workspace's last
+// build produced. Like the build inputs it leaves updated_at alone.
+func (ds *SQLDataStore) SetWorkspaceImageDigest(workspaceID int, digest string) error {
+	value := sql.NullString{String: digest, Valid: digest != ""}
+
+	result, err := ds.driver.Execute(`UPDATE workspaces SET image_digest = ? WHERE id = ?`, value, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to set workspace image digest: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return NewErrNotFound("workspace", workspaceID)
+	}
+	return nil
+}
+
+// ListWorkspaceImageDigests returns the image digest of every workspace built
+// since it was tracked, keyed by workspace ID.
+func (ds *SQLDataStore) ListWorkspaceImageDigests() (map[int]string, error) {
+	rows, err := ds.driver.Query(`SELECT id, image_digest FROM workspaces WHERE image_digest IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace image digests: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var digest string
+		if err := rows.Scan(&id, &digest); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace image digest: %w", err)
+		}
+		result[id] = digest
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over workspace image digests: %w", err)
+	}
+	return result, nil
+}
+
+// ListWorkspaceLastAttached returns the last-attached time of every workspace
+// that has been attached to, keyed by workspace ID.
+func (ds *SQLDataStore) ListWorkspaceLastAttached() (map[int]time.Time, error) {
+	query := `SELECT id, last_attached_at FROM workspaces WHERE last_attached_at IS NOT NULL`
+
+	rows, err := ds.driver.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace attach times: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int]time.Time)
+	for rows.Next() {
+		var id int
+		var attachedAt sql.NullTime
+		if err := rows.Scan(&id, &attachedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace attach time: %w", err)
+		}
+		if attachedAt.Valid {
+			result[id] = attachedAt.Time
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over workspace attach times: %w", err)
+	}
+
+	return result, nil
+}
@@ -2,7 +2,6 @@ package db
 
 import (
 	"devopsmaestro/models"
-	"strings"
 	"testing"
 )
 
@@ -159,11 +158,8 @@ func TestWorkspaceSlugUniqueness(t *testing.T) {
 	if err == nil {
 		t.Errorf("CreateWorkspace() expected error for duplicate slug, got nil")
 	}
-	if err != nil {
-		errLower := strings.ToLower(err.Error())
-		if !strings.Contains(errLower, "unique") && !strings.Contains(errLower, "duplicate") {
-			t.Errorf("CreateWorkspace() expected uniqueness error, got: %v", err)
-		}
+	if err != nil && !IsConflict(err) {
+		t.Errorf("CreateWorkspace() expected conflict error, got: %v", err)
 	}
 }
 
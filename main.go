@@ -2,6 +2,7 @@ package main
 
 import (
 	"devopsmaestro/cmd"
+	"devopsmaestro/config"
 	"devopsmaestro/db"
 	"devopsmaestro/operators"
 	"devopsmaestro/ui"
@@ -37,19 +38,10 @@ func loadConfig() error {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	// Set config path to ~/.devopsmaestro
-	configPath := pc.Root()
-	viper.SetConfigName("config")
-	viper.SetConfigType("yaml")
-	viper.AddConfigPath(configPath)
-	viper.AutomaticEnv()
-
-	// Try to read config, but don't fail if it doesn't exist (init command will create it)
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return fmt.Errorf("failed to read config: %w", err)
-		}
-		// Config not found is OK - init command will create it
+	// Layer /etc/devopsmaestro, ~/.devopsmaestro, and DVM_* variables. A
+	// missing config is OK - init command will create it
+	if err := config.Load(pc.Root()); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
 	}
 
 	return nil
@@ -62,6 +54,7 @@ func main() {
 
 	// Set version information for the CLI
 	cmd.Version = Version
+	operators.DVMVersion = Version
 	cmd.BuildTime = BuildTime
 	cmd.Commit = Commit
 
@@ -25,6 +25,7 @@ type App struct {
 	Language    sql.NullString `db:"language" json:"language,omitempty" yaml:"-"`
 	BuildConfig sql.NullString `db:"build_config" json:"build_config,omitempty" yaml:"-"`
 	GitRepoID   sql.NullInt64  `db:"git_repo_id" json:"git_repo_id,omitempty" yaml:"-"`
+	Env         sql.NullString `db:"env" json:"env,omitempty" yaml:"-"`
 	CreatedAt   time.Time      `db:"created_at" json:"created_at" yaml:"-"`
 	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at" yaml:"-"`
 }
@@ -59,6 +60,7 @@ type AppSpec struct {
 	Dependencies    AppDependencies    `yaml:"dependencies,omitempty"`
 	Services        []AppServiceConfig `yaml:"services,omitempty"`
 	Env             map[string]string  `yaml:"env,omitempty"`
+	Hooks           LifecycleHooks     `yaml:"hooks,omitempty"` // Scripts run at lifecycle points of the app's workspaces
 	Ports           []string           `yaml:"ports,omitempty"`
 	Workspaces      []string           `yaml:"workspaces,omitempty"`
 }
@@ -82,6 +84,15 @@ type AppBuildConfig struct {
 	// "language" → force language detection (legacy ubuntu/alpine path)
 	// "auto" (or empty) → run signal-based detection
 	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	// Template names a BuildTemplate this config inherits from; the fields
+	// above override the template's (see Inherit).
+	Template string `yaml:"template,omitempty" json:"template,omitempty"`
+	// Hooks are Dockerfile snippets spliced into the generated Dockerfile,
+	// before the workspace's own hooks.
+	Hooks *DockerfileHooks `yaml:"hooks,omitempty" json:"hooks,omitempty"`
+	// Lifecycle is spec.hooks, stored in the build config JSON to avoid a
+	// schema migration and mapped to the spec by ToYAML/FromYAML.
+	Lifecycle LifecycleHooks `yaml:"-" json:"lifecycle,omitempty"`
 }
 
 // IsEmpty returns true if all fields of AppBuildConfig are zero/empty.
@@ -92,7 +103,10 @@ func (c AppBuildConfig) IsEmpty() bool {
 		len(c.CACerts) == 0 &&
 		c.Target == "" &&
 		c.Context == "" &&
-		c.Kind == ""
+		c.Kind == "" &&
+		c.Template == "" &&
+		c.Hooks.IsZero() &&
+		c.Lifecycle.IsZero()
 }
 
 // GetKind returns the app's build-kind override from spec.build.kind (#404).
@@ -115,11 +129,11 @@ type AppDependencies struct {
 
 // AppServiceConfig defines services the app needs (databases, caches, etc.)
 type AppServiceConfig struct {
-	Name    string            `yaml:"name"`              // postgres, redis, mongodb, etc.
-	Image   string            `yaml:"image,omitempty"`   // Custom image (default: official)
-	Version string            `yaml:"version,omitempty"` // Service version
-	Port    int               `yaml:"port,omitempty"`    // Port to expose
-	Env     map[string]string `yaml:"env,omitempty"`     // Service environment variables
+	Name    string            `yaml:"name" json:"name"`                           // postgres, redis, mongodb, etc.
+	Image   string            `yaml:"image,omitempty" json:"image,omitempty"`     // Custom image (default: official)
+	Version string            `yaml:"version,omitempty" json:"version,omitempty"` // Service version
+	Port    int               `yaml:"port,omitempty" json:"port,omitempty"`       // Port to expose
+	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`         // Service environment variables
 }
 
 // ToYAML converts an App to YAML format.
@@ -148,6 +162,8 @@ func (a *App) ToYAML(domainName string, workspaceNames []string, gitRepoName str
 	if a.BuildConfig.Valid && a.BuildConfig.String != "" {
 		_ = json.Unmarshal([]byte(a.BuildConfig.String), &buildConfig)
 	}
+	hooks := buildConfig.Lifecycle
+	buildConfig.Lifecycle = LifecycleHooks{}
 
 	theme := ""
 	if a.Theme.Valid {
@@ -182,6 +198,8 @@ func (a *App) ToYAML(domainName string, workspaceNames []string, gitRepoName str
 			GitRepo:         gitRepoName,
 			Language:        langConfig,
 			Build:           buildConfig,
+			Env:             nonEmptyEnv(a.GetEnv()),
+			Hooks:           hooks,
 			Workspaces:      workspaceNames,
 		},
 	}
@@ -215,12 +233,16 @@ func (a *App) FromYAML(yaml AppYAML) {
 		}
 	}
 
-	// Store build config as JSON
-	if !yaml.Spec.Build.IsEmpty() {
-		if buildJSON, err := json.Marshal(yaml.Spec.Build); err == nil {
+	// Store build config, with spec.hooks, as JSON
+	build := yaml.Spec.Build
+	build.Lifecycle = yaml.Spec.Hooks
+	if !build.IsEmpty() {
+		if buildJSON, err := json.Marshal(build); err == nil {
 			a.BuildConfig = sql.NullString{String: string(buildJSON), Valid: true}
 		}
 	}
+
+	a.SetEnv(yaml.Spec.Env)
 }
 
 // GetLanguageConfig parses and returns the language configuration.
@@ -252,3 +274,11 @@ func (a *App) GetBuildConfig() *AppBuildConfig {
 	}
 	return &cfg
 }
+
+// GetLifecycleHooks returns the app's spec.hooks.
+func (a *App) GetLifecycleHooks() LifecycleHooks {
+	if cfg := a.GetBuildConfig(); cfg != nil {
+		return cfg.Lifecycle
+	}
+	return LifecycleHooks{}
+}
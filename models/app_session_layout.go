@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// AppSessionLayout is an app's terminal multiplexer layout. Spec is the
+// layout YAML (see pkg/terminalbridge/muxlayout); dvm attach uses it to
+// create or re-attach the workspace's tmux/zellij session.
+type AppSessionLayout struct {
+	AppID     int       `db:"app_id" json:"app_id"`
+	Spec      string    `db:"spec" json:"spec"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Kinds of resources that can be archived.
+const (
+	ArchiveKindEcosystem = "ecosystem"
+	ArchiveKindDomain    = "domain"
+	ArchiveKindApp       = "app"
+)
+
+// ArchivedResource is an ecosystem, domain, or app set aside by dvm archive.
+// Archived resources and everything below them are hidden from default
+// listings and context switching until restored, and are deleted by dvm gc
+// once they have been archived longer than its retention.
+type ArchivedResource struct {
+	Kind string `json:"kind" yaml:"kind"`
+	ID   int    `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	// Parent is the path of the resource's parents, e.g. "eco/domain" for an
+	// app; empty for an ecosystem.
+	Parent     string    `json:"parent,omitempty" yaml:"parent,omitempty"`
+	ArchivedAt time.Time `json:"archived_at" yaml:"archived_at"`
+}
+
+// Path returns the resource's full path, e.g. "eco/domain/app".
+func (r *ArchivedResource) Path() string {
+	if r.Parent == "" {
+		return r.Name
+	}
+	return r.Parent + "/" + r.Name
+}
+
+// ArchivedIDs holds the IDs of the ecosystems, domains, apps, and
+// workspaces that are hidden because they or one of their parents are
+// archived.
+type ArchivedIDs struct {
+	Ecosystems map[int]bool
+	Domains    map[int]bool
+	Apps       map[int]bool
+	Workspaces map[int]bool
+}
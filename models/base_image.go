@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BaseImage is a shared base image for the workspaces of a language version:
+// the language image with the dev tooling every workspace gets, so workspace
+// builds only add their own layers.
+type BaseImage struct {
+	ID             int
+	Language       string
+	Version        string
+	Image          string // local tag, e.g. dvm-base-golang:1.22
+	RegistryRef    string // the image in the local registry; empty when not pushed
+	DockerfileHash string // hash of the Dockerfile it was built from
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
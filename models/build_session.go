@@ -28,4 +28,9 @@ type BuildSessionWorkspace struct {
 	DurationSeconds sql.NullInt64
 	ImageTag        sql.NullString
 	ErrorMessage    sql.NullString
+
+	// SBOM is the JSON inventory of the built image (see pkg/sbom). It is
+	// written by SetBuildSessionWorkspaceSBOM and only loaded by
+	// GetLatestBuildSBOM; create and update leave it untouched.
+	SBOM sql.NullString
 }
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// BuildTemplate is a named build configuration shared by apps. An app
+// references it with spec.build.template and inherits its Dockerfile, args,
+// CA certs, and target; anything the app sets in spec.build overrides it.
+type BuildTemplate struct {
+	ID          int            `db:"id" json:"id" yaml:"-"`
+	Name        string         `db:"name" json:"name" yaml:"name"`
+	Description sql.NullString `db:"description" json:"description,omitempty" yaml:"description,omitempty"`
+	BuildConfig string         `db:"build_config" json:"build_config" yaml:"-"` // AppBuildConfig as JSON
+	CreatedAt   time.Time      `db:"created_at" json:"created_at" yaml:"-"`
+	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at" yaml:"-"`
+}
+
+// BuildTemplateYAML represents the YAML serialization format for a build template
+type BuildTemplateYAML struct {
+	APIVersion string                `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string                `yaml:"kind" json:"kind"`
+	Metadata   BuildTemplateMetadata `yaml:"metadata" json:"metadata"`
+	Spec       AppBuildConfig        `yaml:"spec" json:"spec"`
+}
+
+// BuildTemplateMetadata contains build template metadata
+type BuildTemplateMetadata struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// GetBuildConfig parses and returns the template's build configuration.
+// Returns an empty config if parsing fails.
+func (t *BuildTemplate) GetBuildConfig() AppBuildConfig {
+	var cfg AppBuildConfig
+	if t.BuildConfig != "" {
+		_ = json.Unmarshal([]byte(t.BuildConfig), &cfg)
+	}
+	return cfg
+}
+
+// ToYAML converts a BuildTemplate to YAML format.
+func (t *BuildTemplate) ToYAML() BuildTemplateYAML {
+	y := BuildTemplateYAML{
+		APIVersion: "devopsmaestro.io/v1",
+		Kind:       "BuildTemplate",
+		Metadata:   BuildTemplateMetadata{Name: t.Name},
+		Spec:       t.GetBuildConfig(),
+	}
+	if t.Description.Valid {
+		y.Metadata.Description = t.Description.String
+	}
+	return y
+}
+
+// FromYAML converts YAML format to a BuildTemplate.
+func (t *BuildTemplate) FromYAML(y BuildTemplateYAML) error {
+	t.Name = y.Metadata.Name
+	t.Description = sql.NullString{String: y.Metadata.Description, Valid: y.Metadata.Description != ""}
+	data, err := json.Marshal(y.Spec)
+	if err != nil {
+		return err
+	}
+	t.BuildConfig = string(data)
+	return nil
+}
+
+// Inherit returns c layered over the template configuration base: fields c
+// sets win (hooks per extension point), args are merged key by key, and CA
+// certs are merged by name.
+// The result keeps c's Template reference and lifecycle hooks.
+func (c AppBuildConfig) Inherit(base AppBuildConfig) AppBuildConfig {
+	merged := base
+	merged.Template, merged.Lifecycle = c.Template, c.Lifecycle
+	override := func(dst *string, src string) {
+		if src != "" {
+			*dst = src
+		}
+	}
+	override(&merged.Dockerfile, c.Dockerfile)
+	override(&merged.Buildpack, c.Buildpack)
+	override(&merged.Target, c.Target)
+	override(&merged.Context, c.Context)
+	override(&merged.Kind, c.Kind)
+
+	if !c.Hooks.IsZero() {
+		hooks := DockerfileHooks{}
+		if base.Hooks != nil {
+			hooks = *base.Hooks
+		}
+		override(&hooks.PreBase, c.Hooks.PreBase)
+		override(&hooks.PostTools, c.Hooks.PostTools)
+		override(&hooks.PostNvim, c.Hooks.PostNvim)
+		merged.Hooks = &hooks
+	}
+
+	if len(base.Args) > 0 || len(c.Args) > 0 {
+		merged.Args = make(map[string]string, len(base.Args)+len(c.Args))
+		for k, v := range base.Args {
+			merged.Args[k] = v
+		}
+		for k, v := range c.Args {
+			merged.Args[k] = v
+		}
+	}
+
+	if len(c.CACerts) > 0 {
+		merged.CACerts = nil
+		overridden := make(map[string]bool, len(c.CACerts))
+		for _, cert := range c.CACerts {
+			overridden[cert.Name] = true
+		}
+		for _, cert := range base.CACerts {
+			if !overridden[cert.Name] {
+				merged.CACerts = append(merged.CACerts, cert)
+			}
+		}
+		merged.CACerts = append(merged.CACerts, c.CACerts...)
+	}
+	return merged
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppBuildConfig_Inherit(t *testing.T) {
+	base := AppBuildConfig{
+		Dockerfile: "Dockerfile.go",
+		Target:     "runtime",
+		Args:       map[string]string{"GO_VERSION": "1.22", "CGO_ENABLED": "0"},
+		CACerts: []CACertConfig{
+			{Name: "corp", VaultSecret: "corp-ca"},
+			{Name: "proxy", VaultSecret: "proxy-ca"},
+		},
+	}
+	app := AppBuildConfig{
+		Template: "go-service",
+		Target:   "dev",
+		Args:     map[string]string{"GO_VERSION": "1.23"},
+		CACerts:  []CACertConfig{{Name: "proxy", VaultSecret: "proxy-ca-v2"}},
+	}
+
+	got := app.Inherit(base)
+
+	assert.Equal(t, "go-service", got.Template)
+	assert.Equal(t, "Dockerfile.go", got.Dockerfile, "template value kept when app leaves it unset")
+	assert.Equal(t, "dev", got.Target, "app value overrides template")
+	assert.Equal(t, map[string]string{"GO_VERSION": "1.23", "CGO_ENABLED": "0"}, got.Args)
+	require.Len(t, got.CACerts, 2)
+	assert.Equal(t, "corp", got.CACerts[0].Name)
+	assert.Equal(t, "proxy-ca-v2", got.CACerts[1].VaultSecret)
+
+	// The base's args must not be modified by the merge.
+	assert.Equal(t, "1.22", base.Args["GO_VERSION"])
+}
+
+func TestAppBuildConfig_Inherit_EmptyApp(t *testing.T) {
+	base := AppBuildConfig{Dockerfile: "Dockerfile", CACerts: []CACertConfig{{Name: "corp"}}}
+
+	got := AppBuildConfig{Template: "base"}.Inherit(base)
+
+	assert.Equal(t, "Dockerfile", got.Dockerfile)
+	assert.Equal(t, base.CACerts, got.CACerts)
+	assert.Nil(t, got.Args)
+}
+
+func TestBuildTemplate_YAMLRoundTrip(t *testing.T) {
+	y := BuildTemplateYAML{
+		APIVersion: "devopsmaestro.io/v1",
+		Kind:       "BuildTemplate",
+		Metadata:   BuildTemplateMetadata{Name: "go-service", Description: "Go services"},
+		Spec: AppBuildConfig{
+			Dockerfile: "Dockerfile.go",
+			Args:       map[string]string{"GO_VERSION": "1.22"},
+		},
+	}
+
+	tmpl := &BuildTemplate{}
+	require.NoError(t, tmpl.FromYAML(y))
+	assert.Equal(t, "go-service", tmpl.Name)
+	assert.True(t, tmpl.Description.Valid)
+	assert.Equal(t, "Dockerfile.go", tmpl.GetBuildConfig().Dockerfile)
+
+	assert.Equal(t, y, tmpl.ToYAML())
+}
+
+func TestBuildTemplate_GetBuildConfig_Invalid(t *testing.T) {
+	tmpl := &BuildTemplate{BuildConfig: "not json"}
+	assert.True(t, tmpl.GetBuildConfig().IsEmpty())
+}
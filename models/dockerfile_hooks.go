@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DockerfileHooks are Dockerfile snippets spliced into the generated
+// Dockerfile at fixed extension points. Snippets run as root; a snippet that
+// switches USER is followed by USER root so the generated steps after it are
+// unaffected.
+type DockerfileHooks struct {
+	PreBase   string `yaml:"preBase,omitempty" json:"preBase,omitempty"`     // Start of the base stage, right after FROM
+	PostTools string `yaml:"postTools,omitempty" json:"postTools,omitempty"` // Dev stage, after dev tools and the dev user are installed
+	PostNvim  string `yaml:"postNvim,omitempty" json:"postNvim,omitempty"`   // Dev stage, after Neovim and its plugins are installed
+}
+
+// dockerfileHookInstructions are the instructions a hook snippet may use.
+// FROM would start a new stage, and CMD/ENTRYPOINT/HEALTHCHECK belong to dvm.
+var dockerfileHookInstructions = map[string]bool{
+	"ADD": true, "ARG": true, "COPY": true, "ENV": true, "EXPOSE": true, "LABEL": true,
+	"RUN": true, "SHELL": true, "USER": true, "VOLUME": true, "WORKDIR": true,
+}
+
+// heredocPattern matches the start of a BuildKit heredoc (<<EOF, <<-"EOF").
+var heredocPattern = regexp.MustCompile(`<<-?["']?([A-Za-z_][A-Za-z0-9_]*)["']?`)
+
+// IsZero implements the yaml.v3 IsZero interface for omitempty support.
+func (h *DockerfileHooks) IsZero() bool {
+	return h == nil || (h.PreBase == "" && h.PostTools == "" && h.PostNvim == "")
+}
+
+// Validate checks that every snippet only uses supported instructions.
+// A nil receiver is valid.
+func (h *DockerfileHooks) Validate() error {
+	if h == nil {
+		return nil
+	}
+	for _, hook := range []struct{ name, snippet string }{
+		{"preBase", h.PreBase},
+		{"postTools", h.PostTools},
+		{"postNvim", h.PostNvim},
+	} {
+		if _, err := ParseDockerfileSnippet(hook.snippet); err != nil {
+			return fmt.Errorf("hooks.%s: %w", hook.name, err)
+		}
+	}
+	return nil
+}
+
+// MergeDockerfileHooks joins the snippets of each hook set, in order, per
+// extension point. Nil sets are skipped.
+func MergeDockerfileHooks(sets ...*DockerfileHooks) DockerfileHooks {
+	var merged DockerfileHooks
+	join := func(dst *string, src string) {
+		src = strings.TrimRight(src, "\n")
+		if strings.TrimSpace(src) == "" {
+			return
+		}
+		if *dst != "" {
+			*dst += "\n"
+		}
+		*dst += src
+	}
+	for _, h := range sets {
+		if h == nil {
+			continue
+		}
+		join(&merged.PreBase, h.PreBase)
+		join(&merged.PostTools, h.PostTools)
+		join(&merged.PostNvim, h.PostNvim)
+	}
+	return merged
+}
+
+// ParseDockerfileSnippet returns the instruction keywords of a Dockerfile
+// snippet, in order, skipping comments, line continuations, and heredoc
+// bodies. It returns an error for instructions hooks may not use.
+func ParseDockerfileSnippet(snippet string) ([]string, error) {
+	var instructions []string
+	continued := false
+	heredoc, heredocLine := "", 0
+	for i, line := range strings.Split(snippet, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case heredoc != "":
+			if trimmed == heredoc {
+				heredoc = ""
+			}
+			continue
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case !continued:
+			keyword := strings.ToUpper(strings.Fields(trimmed)[0])
+			if keyword == "FROM" {
+				return nil, fmt.Errorf("line %d: FROM is not allowed; hooks run inside a generated stage", i+1)
+			}
+			if !dockerfileHookInstructions[keyword] {
+				return nil, fmt.Errorf("line %d: unsupported instruction %q", i+1, keyword)
+			}
+			instructions = append(instructions, keyword)
+		}
+		if m := heredocPattern.FindStringSubmatch(trimmed); m != nil {
+			heredoc, heredocLine = m[1], i+1
+		}
+		continued = strings.HasSuffix(trimmed, "\\")
+	}
+	if heredoc != "" {
+		return nil, fmt.Errorf("line %d: heredoc %s is not terminated", heredocLine, heredoc)
+	}
+	if continued {
+		return nil, fmt.Errorf("last line ends with a line continuation")
+	}
+	return instructions, nil
+}
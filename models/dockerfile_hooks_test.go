@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDockerfileSnippet(t *testing.T) {
+	tests := []struct {
+		name    string
+		snippet string
+		want    []string
+		wantErr string
+	}{
+		{name: "empty", snippet: ""},
+		{
+			name:    "instructions and comments",
+			snippet: "# company certs\nCOPY certs/ /usr/local/share/ca-certificates/\nrun update-ca-certificates\n",
+			want:    []string{"COPY", "RUN"},
+		},
+		{
+			name:    "line continuation",
+			snippet: "RUN apt-get update && \\\n    apt-get install -y jq\nENV A=1",
+			want:    []string{"RUN", "ENV"},
+		},
+		{
+			name:    "heredoc",
+			snippet: "RUN <<EOF\nFROM is fine in a heredoc body\nEOF\nUSER dev",
+			want:    []string{"RUN", "USER"},
+		},
+		{name: "from", snippet: "RUN true\nFROM alpine", wantErr: "line 2: FROM is not allowed"},
+		{name: "cmd", snippet: `CMD ["sh"]`, wantErr: `line 1: unsupported instruction "CMD"`},
+		{name: "unterminated heredoc", snippet: "RUN <<'EOF'\necho hi", wantErr: "heredoc EOF is not terminated"},
+		{name: "trailing continuation", snippet: "RUN true \\", wantErr: "line continuation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDockerfileSnippet(tt.snippet)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDockerfileHooks_Validate(t *testing.T) {
+	var nilHooks *DockerfileHooks
+	assert.NoError(t, nilHooks.Validate())
+
+	err := (&DockerfileHooks{PostNvim: "ENTRYPOINT [\"x\"]"}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hooks.postNvim: line 1")
+}
+
+func TestMergeDockerfileHooks(t *testing.T) {
+	app := &DockerfileHooks{PreBase: "RUN echo app\n", PostTools: "RUN echo tools"}
+	ws := &DockerfileHooks{PreBase: "RUN echo ws", PostNvim: "  \n"}
+
+	got := MergeDockerfileHooks(app, nil, ws)
+
+	assert.Equal(t, "RUN echo app\nRUN echo ws", got.PreBase)
+	assert.Equal(t, "RUN echo tools", got.PostTools)
+	assert.Empty(t, got.PostNvim)
+}
+
+func TestAppBuildConfig_Inherit_Hooks(t *testing.T) {
+	base := AppBuildConfig{Hooks: &DockerfileHooks{PreBase: "RUN echo tmpl", PostTools: "RUN echo tmpl-tools"}}
+	app := AppBuildConfig{Hooks: &DockerfileHooks{PreBase: "RUN echo app"}}
+
+	got := app.Inherit(base)
+
+	require.NotNil(t, got.Hooks)
+	assert.Equal(t, "RUN echo app", got.Hooks.PreBase)
+	assert.Equal(t, "RUN echo tmpl-tools", got.Hooks.PostTools)
+	assert.Equal(t, "RUN echo tmpl", base.Hooks.PreBase, "template hooks must not be modified")
+}
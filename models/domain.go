@@ -20,6 +20,7 @@ type Domain struct {
 	TerminalPackage sql.NullString `db:"terminal_package" json:"terminal_package,omitempty" yaml:"terminal_package,omitempty"`
 	BuildArgs       sql.NullString `db:"build_args" json:"build_args,omitempty" yaml:"-"`
 	CACerts         sql.NullString `db:"ca_certs" json:"ca_certs,omitempty" yaml:"-"`
+	Env             sql.NullString `db:"env" json:"env,omitempty" yaml:"-"`
 	CreatedAt       time.Time      `db:"created_at" json:"created_at" yaml:"-"`
 	UpdatedAt       time.Time      `db:"updated_at" json:"updated_at" yaml:"-"`
 }
@@ -48,6 +49,8 @@ type DomainSpec struct {
 	Apps            []string        `yaml:"apps,omitempty"`
 	Build           BuildArgsConfig `yaml:"build,omitempty"`
 	CACerts         []CACertConfig  `yaml:"caCerts,omitempty"`
+	// Env is layered under the env of every workspace in the domain.
+	Env map[string]string `yaml:"env,omitempty"`
 }
 
 // ToYAML converts a Domain to YAML format.
@@ -112,6 +115,7 @@ func (d *Domain) ToYAML(ecosystemName string, appNames []string) DomainYAML {
 			Apps:            appNames,
 			Build:           buildConfig,
 			CACerts:         caCerts,
+			Env:             nonEmptyEnv(d.GetEnv()),
 		},
 	}
 }
@@ -149,4 +153,6 @@ func (d *Domain) FromYAML(yaml DomainYAML) {
 			d.CACerts = sql.NullString{String: string(b), Valid: true}
 		}
 	}
+
+	d.SetEnv(yaml.Spec.Env)
 }
@@ -32,6 +32,7 @@ type Ecosystem struct {
 	TerminalPackage sql.NullString `db:"terminal_package" json:"terminal_package,omitempty" yaml:"terminal_package,omitempty"`
 	BuildArgs       sql.NullString `db:"build_args" json:"build_args,omitempty" yaml:"-"`
 	CACerts         sql.NullString `db:"ca_certs" json:"ca_certs,omitempty" yaml:"-"`
+	Env             sql.NullString `db:"env" json:"env,omitempty" yaml:"-"`
 	CreatedAt       time.Time      `db:"created_at" json:"created_at" yaml:"-"`
 	UpdatedAt       time.Time      `db:"updated_at" json:"updated_at" yaml:"-"`
 }
@@ -60,6 +61,8 @@ type EcosystemSpec struct {
 	Domains         []string        `yaml:"domains,omitempty" json:"domains,omitempty"`
 	Build           BuildArgsConfig `yaml:"build,omitempty" json:"build,omitempty"`
 	CACerts         []CACertConfig  `yaml:"caCerts,omitempty" json:"caCerts,omitempty"`
+	// Env is layered under the env of every workspace in the ecosystem.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
 }
 
 // ToYAML converts an Ecosystem to YAML format.
@@ -124,6 +127,7 @@ func (e *Ecosystem) ToYAML(domainNames []string) EcosystemYAML {
 			Domains:         domainNames,
 			Build:           buildConfig,
 			CACerts:         caCerts,
+			Env:             nonEmptyEnv(e.GetEnv()),
 		},
 	}
 }
@@ -164,4 +168,6 @@ func (e *Ecosystem) FromYAML(yaml EcosystemYAML) {
 			e.CACerts = sql.NullString{String: string(b), Valid: true}
 		}
 	}
+
+	e.SetEnv(yaml.Spec.Env)
 }
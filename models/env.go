@@ -0,0 +1,61 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// Environment variables set on ecosystems, domains, and apps are stored as a
+// JSON object in each table's env column and layered under the workspace's
+// own env when its container starts.
+
+// GetEnv returns the environment variables set on the ecosystem.
+func (e *Ecosystem) GetEnv() map[string]string { return envFromJSON(e.Env) }
+
+// SetEnv stores the environment variables set on the ecosystem.
+func (e *Ecosystem) SetEnv(env map[string]string) { e.Env = envToJSON(env) }
+
+// GetEnv returns the environment variables set on the domain.
+func (d *Domain) GetEnv() map[string]string { return envFromJSON(d.Env) }
+
+// SetEnv stores the environment variables set on the domain.
+func (d *Domain) SetEnv(env map[string]string) { d.Env = envToJSON(env) }
+
+// GetEnv returns the environment variables set on the app.
+func (a *App) GetEnv() map[string]string { return envFromJSON(a.Env) }
+
+// SetEnv stores the environment variables set on the app.
+func (a *App) SetEnv(env map[string]string) { a.Env = envToJSON(env) }
+
+// envFromJSON parses an env column, returning an empty (non-nil) map when
+// it is unset or malformed.
+func envFromJSON(s sql.NullString) map[string]string {
+	env := map[string]string{}
+	if !s.Valid || s.String == "" {
+		return env
+	}
+	if err := json.Unmarshal([]byte(s.String), &env); err != nil || env == nil {
+		return map[string]string{}
+	}
+	return env
+}
+
+// envToJSON encodes env for an env column; an empty env is stored as NULL.
+func envToJSON(env map[string]string) sql.NullString {
+	if len(env) == 0 {
+		return sql.NullString{}
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(b), Valid: true}
+}
+
+// nonEmptyEnv returns env, or nil when it is empty so YAML omits it.
+func nonEmptyEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+	return env
+}
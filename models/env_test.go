@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHierarchyEnv_RoundTrip(t *testing.T) {
+	env := map[string]string{"LOG_LEVEL": "info", "API_KEY": "((credential:api-key))"}
+
+	eco := &Ecosystem{}
+	eco.FromYAML(EcosystemYAML{Metadata: EcosystemMetadata{Name: "corp"}, Spec: EcosystemSpec{Env: env}})
+	assert.Equal(t, env, eco.ToYAML(nil).Spec.Env)
+
+	domain := &Domain{}
+	domain.FromYAML(DomainYAML{Metadata: DomainMetadata{Name: "backend"}, Spec: DomainSpec{Env: env}})
+	assert.Equal(t, env, domain.ToYAML("corp", nil).Spec.Env)
+
+	app := &App{}
+	app.FromYAML(AppYAML{Metadata: AppMetadata{Name: "api"}, Spec: AppSpec{Path: "/src/api", Env: env}})
+	assert.Equal(t, env, app.ToYAML("backend", nil, "", "").Spec.Env)
+}
+
+func TestHierarchyEnv_OmittedWhenEmpty(t *testing.T) {
+	eco := &Ecosystem{Name: "corp"}
+	assert.False(t, eco.Env.Valid, "empty env should be stored as NULL")
+	out, err := yaml.Marshal(eco.ToYAML(nil))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(out), "env:")
+}
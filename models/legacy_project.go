@@ -0,0 +1,12 @@
+package models
+
+// LegacyProject is a row of the projects table that databases created
+// before apps replaced projects may still hold. 'dvm admin migrate-projects'
+// converts each into an app.
+type LegacyProject struct {
+	ID          int
+	Name        string
+	Path        string
+	Description string
+	Workspaces  int // workspaces that still reference the project by project_id
+}
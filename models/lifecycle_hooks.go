@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lifecycle hook points (spec.hooks).
+const (
+	HookPreBuild  = "preBuild"  // Before the workspace image is built
+	HookPostBuild = "postBuild" // After the image is built (not when the build is skipped)
+	HookPreStart  = "preStart"  // Before the workspace container is started on attach
+	HookPostStart = "postStart" // After the container is started, before the session
+	HookPostSync  = "postSync"  // After a one-off spec.sync pass (attach, dvm workspace sync)
+)
+
+// Where a lifecycle hook runs (spec.hooks.<point>[].on).
+const (
+	HookOnHost      = "host"      // On this machine, in the app's directory (default)
+	HookOnContainer = "container" // In the workspace container, in /workspace
+)
+
+// What a failing lifecycle hook does (spec.hooks.<point>[].onFailure).
+const (
+	HookFailureAbort = "abort" // Stop the build or attach (default)
+	HookFailureWarn  = "warn"  // Print a warning and go on
+)
+
+// DefaultHookTimeout is how long a hook may run without spec.hooks.<point>[].timeout.
+const DefaultHookTimeout = 5 * time.Minute
+
+// LifecycleHooks are scripts run at points of a workspace's lifecycle, such
+// as database migrations after the container starts. App hooks run before
+// the workspace's own hooks at each point.
+type LifecycleHooks struct {
+	PreBuild  []LifecycleHook `yaml:"preBuild,omitempty" json:"preBuild,omitempty"`
+	PostBuild []LifecycleHook `yaml:"postBuild,omitempty" json:"postBuild,omitempty"`
+	PreStart  []LifecycleHook `yaml:"preStart,omitempty" json:"preStart,omitempty"`
+	PostStart []LifecycleHook `yaml:"postStart,omitempty" json:"postStart,omitempty"`
+	PostSync  []LifecycleHook `yaml:"postSync,omitempty" json:"postSync,omitempty"`
+}
+
+// LifecycleHook is one script of a hook point. The script runs with sh -c.
+type LifecycleHook struct {
+	Name      string            `yaml:"name,omitempty" json:"name,omitempty"`           // Shown in output (default: the hook point and index)
+	Run       string            `yaml:"run" json:"run"`                                 // Shell script
+	On        string            `yaml:"on,omitempty" json:"on,omitempty"`               // host (default) or container
+	Timeout   string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`     // Go duration (default: 5m)
+	Env       map[string]string `yaml:"env,omitempty" json:"env,omitempty"`             // Added to the hook's environment
+	OnFailure string            `yaml:"onFailure,omitempty" json:"onFailure,omitempty"` // abort (default) or warn
+}
+
+// IsZero implements yaml.v3 IsZero for omitempty support.
+func (h LifecycleHooks) IsZero() bool {
+	return len(h.PreBuild) == 0 && len(h.PostBuild) == 0 && len(h.PreStart) == 0 &&
+		len(h.PostStart) == 0 && len(h.PostSync) == 0
+}
+
+// At returns the hooks of a hook point, or nil for an unknown point.
+func (h LifecycleHooks) At(point string) []LifecycleHook {
+	switch point {
+	case HookPreBuild:
+		return h.PreBuild
+	case HookPostBuild:
+		return h.PostBuild
+	case HookPreStart:
+		return h.PreStart
+	case HookPostStart:
+		return h.PostStart
+	case HookPostSync:
+		return h.PostSync
+	}
+	return nil
+}
+
+// Validate checks every hook. Hooks before the container exists (preBuild,
+// postBuild, preStart) can only run on the host.
+func (h LifecycleHooks) Validate() error {
+	for _, point := range []string{HookPreBuild, HookPostBuild, HookPreStart, HookPostStart, HookPostSync} {
+		for i, hook := range h.At(point) {
+			if err := hook.validate(point); err != nil {
+				return fmt.Errorf("hooks.%s[%d]: %w", point, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (h LifecycleHook) validate(point string) error {
+	if h.Run == "" {
+		return fmt.Errorf("run is required")
+	}
+	switch h.On {
+	case "", HookOnHost:
+	case HookOnContainer:
+		if point == HookPreBuild || point == HookPostBuild || point == HookPreStart {
+			return fmt.Errorf("%s hooks run on the host; the workspace container is not running", point)
+		}
+	default:
+		return fmt.Errorf("invalid on %q (supported: %s, %s)", h.On, HookOnHost, HookOnContainer)
+	}
+	switch h.OnFailure {
+	case "", HookFailureAbort, HookFailureWarn:
+	default:
+		return fmt.Errorf("invalid onFailure %q (supported: %s, %s)", h.OnFailure, HookFailureAbort, HookFailureWarn)
+	}
+	if h.Timeout != "" {
+		if d, err := time.ParseDuration(h.Timeout); err != nil || d <= 0 {
+			return fmt.Errorf("invalid timeout %q: must be a positive duration such as 30s or 5m", h.Timeout)
+		}
+	}
+	return nil
+}
+
+// TimeoutDuration returns the hook's timeout, or DefaultHookTimeout when it
+// is unset or invalid.
+func (h LifecycleHook) TimeoutDuration() time.Duration {
+	if d, err := time.ParseDuration(h.Timeout); err == nil && d > 0 {
+		return d
+	}
+	return DefaultHookTimeout
+}
+
+// MergeLifecycleHooks appends the hooks of each set, in order, per hook point.
+func MergeLifecycleHooks(sets ...LifecycleHooks) LifecycleHooks {
+	var merged LifecycleHooks
+	for _, h := range sets {
+		merged.PreBuild = append(merged.PreBuild, h.PreBuild...)
+		merged.PostBuild = append(merged.PostBuild, h.PostBuild...)
+		merged.PreStart = append(merged.PreStart, h.PreStart...)
+		merged.PostStart = append(merged.PostStart, h.PostStart...)
+		merged.PostSync = append(merged.PostSync, h.PostSync...)
+	}
+	return merged
+}
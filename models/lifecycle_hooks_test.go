@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleHooks_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		hooks   LifecycleHooks
+		wantErr string
+	}{
+		{name: "empty"},
+		{
+			name: "valid",
+			hooks: LifecycleHooks{
+				PreBuild:  []LifecycleHook{{Run: "make generate"}},
+				PostStart: []LifecycleHook{{Name: "migrate", Run: "make migrate", On: HookOnContainer, Timeout: "2m", OnFailure: HookFailureWarn}},
+				PostSync:  []LifecycleHook{{Run: "go mod download", On: HookOnContainer}},
+			},
+		},
+		{name: "missing run", hooks: LifecycleHooks{PostStart: []LifecycleHook{{Name: "x"}}}, wantErr: "hooks.postStart[0]: run is required"},
+		{name: "container before start", hooks: LifecycleHooks{PreStart: []LifecycleHook{{Run: "true", On: HookOnContainer}}}, wantErr: "preStart hooks run on the host"},
+		{name: "invalid on", hooks: LifecycleHooks{PostSync: []LifecycleHook{{Run: "true", On: "vm"}}}, wantErr: `invalid on "vm"`},
+		{name: "invalid onFailure", hooks: LifecycleHooks{PostBuild: []LifecycleHook{{Run: "true", OnFailure: "ignore"}}}, wantErr: `invalid onFailure "ignore"`},
+		{name: "invalid timeout", hooks: LifecycleHooks{PreBuild: []LifecycleHook{{Run: "true", Timeout: "soon"}}}, wantErr: `invalid timeout "soon"`},
+		{name: "zero timeout", hooks: LifecycleHooks{PreBuild: []LifecycleHook{{Run: "true", Timeout: "0s"}}}, wantErr: `invalid timeout "0s"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hooks.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLifecycleHook_TimeoutDuration(t *testing.T) {
+	assert.Equal(t, DefaultHookTimeout, LifecycleHook{}.TimeoutDuration())
+	assert.Equal(t, 30*time.Second, LifecycleHook{Timeout: "30s"}.TimeoutDuration())
+}
+
+func TestMergeLifecycleHooks(t *testing.T) {
+	app := LifecycleHooks{PostStart: []LifecycleHook{{Name: "app"}}}
+	ws := LifecycleHooks{PostStart: []LifecycleHook{{Name: "workspace"}}, PreBuild: []LifecycleHook{{Name: "gen"}}}
+
+	merged := MergeLifecycleHooks(app, ws)
+	require.Len(t, merged.PostStart, 2)
+	assert.Equal(t, "app", merged.PostStart[0].Name)
+	assert.Equal(t, "workspace", merged.PostStart[1].Name)
+	assert.Len(t, merged.At(HookPreBuild), 1)
+	assert.Nil(t, merged.At("preStop"))
+}
+
+func TestLifecycleHooks_RoundTrip(t *testing.T) {
+	hooks := LifecycleHooks{PostStart: []LifecycleHook{{Name: "migrate", Run: "make migrate", On: HookOnContainer}}}
+
+	var ws Workspace
+	ws.FromYAML(WorkspaceYAML{Metadata: WorkspaceMetadata{Name: "dev"}, Spec: WorkspaceSpec{Hooks: hooks}})
+	assert.Equal(t, hooks, ws.ToYAML("api", "").Spec.Hooks)
+	assert.True(t, ws.ToYAML("api", "").Spec.Build.IsZero(), "hooks must not leak into spec.build")
+
+	var app App
+	app.FromYAML(AppYAML{Metadata: AppMetadata{Name: "api"}, Spec: AppSpec{Path: "/src/api", Hooks: hooks}})
+	assert.Equal(t, hooks, app.GetLifecycleHooks())
+	y := app.ToYAML("backend", nil, "", "")
+	assert.Equal(t, hooks, y.Spec.Hooks)
+	assert.True(t, y.Spec.Build.IsEmpty(), "hooks must not leak into spec.build")
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ManagedField records the last value written to one field of a resource
+// and the manager that wrote it, so an apply can tell when it would undo a
+// change made another way.
+type ManagedField struct {
+	ID        int
+	Kind      string
+	Name      string
+	Scope     string // the resource's parents, as ecosystem/domain/system/app
+	Field     string // dotted path, such as spec.build.args.FOO
+	Manager   string // manifest, cli, or sync; empty when no manager set it
+	Value     string // the field's value as JSON
+	UpdatedAt time.Time
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ResourceRevision is the YAML of a resource as one apply left it. Each
+// resource keeps its newest revisions, numbered from 1.
+type ResourceRevision struct {
+	ID        int
+	Kind      string
+	Name      string
+	Scope     string // the resource's parents, as ecosystem/domain/system/app
+	Revision  int
+	Content   string // the resource's YAML
+	Manager   string // the field manager of the apply, such as manifest or cli
+	CreatedAt time.Time
+}
+
+// ResourceRevisionYAML is the DTO for JSON/YAML output of a revision,
+// without its content.
+type ResourceRevisionYAML struct {
+	Revision  int       `json:"revision" yaml:"revision"`
+	Manager   string    `json:"manager,omitempty" yaml:"manager,omitempty"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+}
+
+// ToYAML converts a ResourceRevision to its DTO.
+func (r *ResourceRevision) ToYAML() ResourceRevisionYAML {
+	return ResourceRevisionYAML{Revision: r.Revision, Manager: r.Manager, CreatedAt: r.CreatedAt}
+}
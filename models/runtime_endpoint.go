@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Runtime endpoint types: the container CLI run on the remote host.
+const (
+	RuntimeEndpointDocker  = "docker"
+	RuntimeEndpointNerdctl = "nerdctl"
+)
+
+// RuntimeEndpoint is the remote host an ecosystem's workspaces are built and
+// run on instead of the local container platform. Host is an SSH URL
+// (ssh://user@host[:port]); Type is the container CLI on that host. App files
+// are synced to SyncDir on the host, which is relative to the remote user's
+// home unless absolute.
+type RuntimeEndpoint struct {
+	EcosystemID int       `db:"ecosystem_id" json:"ecosystem_id" yaml:"ecosystem_id"`
+	Type        string    `db:"type" json:"type" yaml:"type"`
+	Host        string    `db:"host" json:"host" yaml:"host"`
+	SyncDir     string    `db:"sync_dir" json:"sync_dir" yaml:"sync_dir"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at" yaml:"updated_at"`
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// SyncHistory is one recorded run of nvp source sync: what the source
+// offered, what the run wrote to the plugin store, and how long it took.
+type SyncHistory struct {
+	ID             int
+	Source         string
+	Version        sql.NullString // requested tag, or the revision the source reported
+	DryRun         bool
+	TotalAvailable int
+	TotalSynced    int
+	CreatedCount   int
+	UpdatedCount   int
+	ErrorCount     int
+	Result         string // SyncHistoryResult as JSON
+	Changes        string // []SyncChange as JSON
+	Errors         string // []string as JSON
+	Snapshot       string // map[string]SyncFile as JSON, keyed by path
+	RolledBackAt   sql.NullTime
+	StartedAt      time.Time
+	DurationMS     int64
+	CreatedAt      time.Time
+}
+
+// SyncHistoryResult names the plugins and packages a sync reported.
+type SyncHistoryResult struct {
+	PluginsCreated  []string `json:"pluginsCreated,omitempty" yaml:"pluginsCreated,omitempty"`
+	PluginsUpdated  []string `json:"pluginsUpdated,omitempty" yaml:"pluginsUpdated,omitempty"`
+	PackagesCreated []string `json:"packagesCreated,omitempty" yaml:"packagesCreated,omitempty"`
+	PackagesUpdated []string `json:"packagesUpdated,omitempty" yaml:"packagesUpdated,omitempty"`
+}
+
+// Sync change actions.
+const (
+	SyncChangeCreated   = "created"
+	SyncChangeUpdated   = "updated"
+	SyncChangeUnchanged = "unchanged"
+)
+
+// SyncChange summarizes how a sync changed one plugin's YAML file.
+type SyncChange struct {
+	Plugin  string `json:"plugin" yaml:"plugin"`
+	Action  string `json:"action" yaml:"action"`
+	Added   int    `json:"added" yaml:"added"`
+	Removed int    `json:"removed" yaml:"removed"`
+	Diff    string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// SyncFile is a file a sync wrote, with its content before the sync (nil
+// when the sync created it) and after.
+type SyncFile struct {
+	Before *string `json:"before"`
+	After  string  `json:"after"`
+}
+
+// SyncHistoryYAML is the clean DTO for JSON/YAML serialization of SyncHistory.
+type SyncHistoryYAML struct {
+	ID             int          `json:"id" yaml:"id"`
+	Source         string       `json:"source" yaml:"source"`
+	Version        string       `json:"version,omitempty" yaml:"version,omitempty"`
+	DryRun         bool         `json:"dryRun" yaml:"dryRun"`
+	StartedAt      time.Time    `json:"startedAt" yaml:"startedAt"`
+	Duration       string       `json:"duration" yaml:"duration"`
+	TotalAvailable int          `json:"totalAvailable" yaml:"totalAvailable"`
+	TotalSynced    int          `json:"totalSynced" yaml:"totalSynced"`
+	Changes        []SyncChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Errors         []string     `json:"errors,omitempty" yaml:"errors,omitempty"`
+	RolledBackAt   *time.Time   `json:"rolledBackAt,omitempty" yaml:"rolledBackAt,omitempty"`
+
+	SyncHistoryResult `json:",inline" yaml:",inline"`
+}
+
+// Duration returns how long the sync ran.
+func (h *SyncHistory) Duration() time.Duration {
+	return time.Duration(h.DurationMS) * time.Millisecond
+}
+
+// GetResult parses the synced plugin and package names.
+// Returns an empty result if parsing fails.
+func (h *SyncHistory) GetResult() SyncHistoryResult {
+	var r SyncHistoryResult
+	if h.Result != "" {
+		_ = json.Unmarshal([]byte(h.Result), &r)
+	}
+	return r
+}
+
+// SetResult stores the synced plugin and package names as JSON.
+func (h *SyncHistory) SetResult(r SyncHistoryResult) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	h.Result = string(data)
+	return nil
+}
+
+// GetChanges parses the per-plugin change summary.
+// Returns nil if parsing fails.
+func (h *SyncHistory) GetChanges() []SyncChange {
+	var changes []SyncChange
+	if h.Changes != "" {
+		_ = json.Unmarshal([]byte(h.Changes), &changes)
+	}
+	return changes
+}
+
+// SetChanges stores the per-plugin change summary as JSON and counts the
+// created and updated plugins.
+func (h *SyncHistory) SetChanges(changes []SyncChange) error {
+	if changes == nil {
+		changes = []SyncChange{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+	h.Changes = string(data)
+	h.CreatedCount, h.UpdatedCount = 0, 0
+	for _, c := range changes {
+		switch c.Action {
+		case SyncChangeCreated:
+			h.CreatedCount++
+		case SyncChangeUpdated:
+			h.UpdatedCount++
+		}
+	}
+	return nil
+}
+
+// GetErrors parses the run's error messages.
+// Returns nil if parsing fails.
+func (h *SyncHistory) GetErrors() []string {
+	var errs []string
+	if h.Errors != "" {
+		_ = json.Unmarshal([]byte(h.Errors), &errs)
+	}
+	return errs
+}
+
+// SetErrors stores the run's error messages as JSON and counts them.
+func (h *SyncHistory) SetErrors(errs []string) error {
+	if errs == nil {
+		errs = []string{}
+	}
+	data, err := json.Marshal(errs)
+	if err != nil {
+		return err
+	}
+	h.Errors = string(data)
+	h.ErrorCount = len(errs)
+	return nil
+}
+
+// GetSnapshot parses the files the sync wrote, keyed by their path
+// relative to the nvp directory. Returns nil if parsing fails.
+func (h *SyncHistory) GetSnapshot() map[string]SyncFile {
+	var files map[string]SyncFile
+	if h.Snapshot != "" {
+		_ = json.Unmarshal([]byte(h.Snapshot), &files)
+	}
+	return files
+}
+
+// SetSnapshot stores the files the sync wrote as JSON.
+func (h *SyncHistory) SetSnapshot(files map[string]SyncFile) error {
+	if files == nil {
+		files = map[string]SyncFile{}
+	}
+	data, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	h.Snapshot = string(data)
+	return nil
+}
+
+// ToYAML converts SyncHistory to a clean SyncHistoryYAML DTO.
+func (h *SyncHistory) ToYAML() SyncHistoryYAML {
+	y := SyncHistoryYAML{
+		ID:                h.ID,
+		Source:            h.Source,
+		Version:           h.Version.String,
+		DryRun:            h.DryRun,
+		StartedAt:         h.StartedAt,
+		Duration:          h.Duration().String(),
+		TotalAvailable:    h.TotalAvailable,
+		TotalSynced:       h.TotalSynced,
+		Changes:           h.GetChanges(),
+		Errors:            h.GetErrors(),
+		SyncHistoryResult: h.GetResult(),
+	}
+	if h.RolledBackAt.Valid {
+		t := h.RolledBackAt.Time
+		y.RolledBackAt = &t
+	}
+	return y
+}
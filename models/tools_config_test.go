@@ -72,6 +72,11 @@ func TestToolsConfig_IsZero_ReturnsTrueWhenAllFalse(t *testing.T) {
 			cfg:      ToolsConfig{Opencode: true},
 			wantZero: false,
 		},
+		{
+			name:     "nerdFont set — not zero",
+			cfg:      ToolsConfig{NerdFont: "JetBrainsMono"},
+			wantZero: false,
+		},
 	}
 
 	for _, tt := range tests {
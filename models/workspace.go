@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -66,17 +67,53 @@ type WorkspaceMetadata struct {
 //
 // App-level concerns (language, build, services, ports) belong in AppSpec.
 type WorkspaceSpec struct {
-	Image     ImageConfig       `yaml:"image"`
-	Build     DevBuildConfig    `yaml:"build,omitempty"`
-	Shell     ShellConfig       `yaml:"shell"`
-	Terminal  TerminalConfig    `yaml:"terminal,omitempty"`
-	Nvim      NvimConfig        `yaml:"nvim"`
-	Tools     ToolsConfig       `yaml:"tools,omitempty"`
-	Mounts    []MountConfig     `yaml:"mounts,omitempty"`
-	SSHKey    SSHKeyConfig      `yaml:"sshKey,omitempty"`
-	Env       map[string]string `yaml:"env"`
-	Container ContainerConfig   `yaml:"container"`
-	GitRepo   string            `yaml:"gitrepo,omitempty"` // Name of GitRepo resource to clone
+	Image      ImageConfig       `yaml:"image"`
+	Build      DevBuildConfig    `yaml:"build,omitempty"`
+	Shell      ShellConfig       `yaml:"shell"`
+	Terminal   TerminalConfig    `yaml:"terminal,omitempty"`
+	Nvim       NvimConfig        `yaml:"nvim"`
+	Tools      ToolsConfig       `yaml:"tools,omitempty"`
+	Mounts     []MountConfig     `yaml:"mounts,omitempty"`
+	Devices    []DeviceConfig    `yaml:"devices,omitempty"` // Host devices passed into the container
+	GPUs       string            `yaml:"gpus,omitempty"`    // GPUs passed into the container: "all", a count, or "device=<id>,..."
+	SSHKey     SSHKeyConfig      `yaml:"sshKey,omitempty"`
+	Env        map[string]string `yaml:"env"`
+	Container  ContainerConfig   `yaml:"container"`
+	Services   ServicesConfig    `yaml:"services,omitempty"`
+	DependsOn  []string          `yaml:"dependsOn,omitempty"` // Workspaces dvm up starts first: "<workspace>" in the same app or "<app>/<workspace>"
+	Sync       SyncConfig        `yaml:"sync,omitempty"`
+	Hooks      LifecycleHooks    `yaml:"hooks,omitempty"`   // Scripts run at lifecycle points, after the app's
+	Runtime    string            `yaml:"runtime,omitempty"` // Where the workspace runs: "" (local container runtime) or "kubernetes"
+	Kubernetes KubernetesConfig  `yaml:"kubernetes,omitempty"`
+	GitRepo    string            `yaml:"gitrepo,omitempty"` // Name of GitRepo resource to clone
+}
+
+// WorkspaceRuntimeKubernetes runs the workspace as a pod on a Kubernetes
+// cluster instead of a local container (spec.runtime).
+const WorkspaceRuntimeKubernetes = "kubernetes"
+
+// KubernetesConfig defines where and how a workspace with
+// spec.runtime: kubernetes is scheduled. Empty fields use kubectl's defaults.
+type KubernetesConfig struct {
+	Context       string `yaml:"context,omitempty" json:"context,omitempty"`             // kubeconfig context (default: current context)
+	Namespace     string `yaml:"namespace,omitempty" json:"namespace,omitempty"`         // Namespace for the pod and volume
+	StorageClass  string `yaml:"storageClass,omitempty" json:"storageClass,omitempty"`   // Storage class of the /workspace volume
+	StorageSize   string `yaml:"storageSize,omitempty" json:"storageSize,omitempty"`     // Size of the /workspace volume (default: 10Gi)
+	ImageRegistry string `yaml:"imageRegistry,omitempty" json:"imageRegistry,omitempty"` // Registry the workspace image is pushed to for the cluster to pull
+}
+
+// IsZero implements yaml.v3 IsZero for omitempty support.
+func (k KubernetesConfig) IsZero() bool {
+	return k == KubernetesConfig{}
+}
+
+// ValidateWorkspaceRuntime checks a workspace's spec.runtime.
+func ValidateWorkspaceRuntime(runtime string) error {
+	switch runtime {
+	case "", WorkspaceRuntimeKubernetes:
+		return nil
+	}
+	return fmt.Errorf("invalid workspace runtime %q (supported: %s)", runtime, WorkspaceRuntimeKubernetes)
 }
 
 // ToolsConfig defines optional workspace-level tools that are installed
@@ -87,13 +124,67 @@ type WorkspaceSpec struct {
 // in DevStageConfig.DevTools) and NOT nvim plugins (which live in NvimConfig).
 type ToolsConfig struct {
 	Opencode bool `yaml:"opencode,omitempty" json:"opencode,omitempty"`
+	// NerdFont is a Nerd Font catalog name (see 'dvt font get') installed
+	// into the image's system fonts, for GUI Neovim clients attached to the
+	// workspace. Empty installs none.
+	NerdFont string `yaml:"nerdFont,omitempty" json:"nerdFont,omitempty"`
 	// Future: Lazydocker, K9s, etc. will be added as fields here
 }
 
 // IsZero implements yaml.v3 IsZero for omitempty support.
 // Returns true when no tools are enabled.
 func (t ToolsConfig) IsZero() bool {
-	return !t.Opencode
+	return !t.Opencode && t.NerdFont == ""
+}
+
+// ServicesConfig defines the backing services (databases, queues, caches) run
+// next to the workspace container while it is attached. Services come from a
+// Docker Compose file, inline definitions, or both; they share a network with
+// the workspace, which reaches each one by its service name.
+type ServicesConfig struct {
+	ComposeFile string             `yaml:"composeFile,omitempty" json:"composeFile,omitempty"` // Compose file, relative to the app path
+	Only        []string           `yaml:"only,omitempty" json:"only,omitempty"`               // Compose services to start (default: all)
+	Inline      []AppServiceConfig `yaml:"inline,omitempty" json:"inline,omitempty"`           // Services defined in the workspace spec
+}
+
+// IsZero implements yaml.v3 IsZero for omitempty support.
+// Returns true when no services are configured.
+func (s ServicesConfig) IsZero() bool {
+	return s.ComposeFile == "" && len(s.Inline) == 0
+}
+
+// Sync modes for getting the app's files into the workspace (spec.sync.mode).
+const (
+	SyncModeBind   = "bind"    // Bind mount the app path at /workspace (default)
+	SyncModeOneWay = "one-way" // Copy host changes into the container
+	SyncModeTwoWay = "two-way" // Copy changes in both directions
+)
+
+// SyncConfig selects how the app's files reach /workspace. Bind mounts are
+// slow on macOS VMs (Colima, Docker Desktop); the sync modes keep a copy in
+// the container instead and sync it while the workspace is attached.
+type SyncConfig struct {
+	Mode   string   `yaml:"mode,omitempty" json:"mode,omitempty"`     // bind (default), one-way, or two-way
+	Ignore []string `yaml:"ignore,omitempty" json:"ignore,omitempty"` // .gitignore-style patterns, added to the app's .gitignore
+}
+
+// IsZero implements yaml.v3 IsZero for omitempty support.
+func (s SyncConfig) IsZero() bool {
+	return s.Mode == "" && len(s.Ignore) == 0
+}
+
+// Enabled reports whether the files are synced instead of bind mounted.
+func (s SyncConfig) Enabled() bool {
+	return s.Mode == SyncModeOneWay || s.Mode == SyncModeTwoWay
+}
+
+// ValidateSyncConfig checks a workspace's spec.sync.
+func ValidateSyncConfig(s SyncConfig) error {
+	switch s.Mode {
+	case "", SyncModeBind, SyncModeOneWay, SyncModeTwoWay:
+		return nil
+	}
+	return fmt.Errorf("invalid sync mode %q (supported: %s, %s, %s)", s.Mode, SyncModeBind, SyncModeOneWay, SyncModeTwoWay)
 }
 
 // ImageConfig defines the container image configuration
@@ -121,16 +212,30 @@ type CACertConfig struct {
 // DevBuildConfig defines the build configuration for the dev environment.
 // This focuses on developer tools added on top of the app's base image.
 //
-// Tools and Shell are persisted here as JSON inside the BuildConfig column
+// Tools, Shell, Services, Sync, Lifecycle, Runtime, Kubernetes, Mounts, Ports, Devices, GPUs, and DependsOn are persisted here as JSON inside the BuildConfig column
 // to avoid schema migrations. They are mapped to/from WorkspaceSpec fields
 // by ToYAML/FromYAML for YAML round-trip fidelity (issue #132).
 type DevBuildConfig struct {
-	Args      map[string]string `yaml:"args,omitempty" json:"args,omitempty"`
-	CACerts   []CACertConfig    `yaml:"caCerts,omitempty" json:"caCerts,omitempty"`
-	BaseStage BaseStageConfig   `yaml:"baseStage,omitempty" json:"baseStage,omitempty"`
-	DevStage  DevStageConfig    `yaml:"devStage,omitempty" json:"devStage,omitempty"`
-	Tools     ToolsConfig       `yaml:"-" json:"tools,omitempty"` // Stored in JSON only, mapped to spec.Tools by ToYAML/FromYAML
-	Shell     ShellConfig       `yaml:"-" json:"shell,omitempty"` // Stored in JSON only, mapped to spec.Shell by ToYAML/FromYAML
+	Args       map[string]string `yaml:"args,omitempty" json:"args,omitempty"`
+	CACerts    []CACertConfig    `yaml:"caCerts,omitempty" json:"caCerts,omitempty"`
+	BaseStage  BaseStageConfig   `yaml:"baseStage,omitempty" json:"baseStage,omitempty"`
+	DevStage   DevStageConfig    `yaml:"devStage,omitempty" json:"devStage,omitempty"`
+	Tools      ToolsConfig       `yaml:"-" json:"tools,omitempty"`      // Stored in JSON only, mapped to spec.Tools by ToYAML/FromYAML
+	Shell      ShellConfig       `yaml:"-" json:"shell,omitempty"`      // Stored in JSON only, mapped to spec.Shell by ToYAML/FromYAML
+	Services   ServicesConfig    `yaml:"-" json:"services,omitempty"`   // Stored in JSON only, mapped to spec.Services by ToYAML/FromYAML
+	Sync       SyncConfig        `yaml:"-" json:"sync,omitempty"`       // Stored in JSON only, mapped to spec.Sync by ToYAML/FromYAML
+	Lifecycle  LifecycleHooks    `yaml:"-" json:"lifecycle,omitempty"`  // Stored in JSON only, mapped to spec.Hooks by ToYAML/FromYAML
+	Runtime    string            `yaml:"-" json:"runtime,omitempty"`    // Stored in JSON only, mapped to spec.Runtime by ToYAML/FromYAML
+	Kubernetes KubernetesConfig  `yaml:"-" json:"kubernetes,omitempty"` // Stored in JSON only, mapped to spec.Kubernetes by ToYAML/FromYAML
+	Mounts     []MountConfig     `yaml:"-" json:"mounts,omitempty"`     // Stored in JSON only, mapped to spec.Mounts by ToYAML/FromYAML
+	Ports      []int             `yaml:"-" json:"ports,omitempty"`      // Stored in JSON only, mapped to spec.Container.Ports by ToYAML/FromYAML
+	Devices    []DeviceConfig    `yaml:"-" json:"devices,omitempty"`    // Stored in JSON only, mapped to spec.Devices by ToYAML/FromYAML
+	GPUs       string            `yaml:"-" json:"gpus,omitempty"`       // Stored in JSON only, mapped to spec.GPUs by ToYAML/FromYAML
+	DependsOn  []string          `yaml:"-" json:"dependsOn,omitempty"`  // Stored in JSON only, mapped to spec.DependsOn by ToYAML/FromYAML
+
+	// Hooks are Dockerfile snippets spliced into the generated Dockerfile,
+	// after the app's hooks.
+	Hooks *DockerfileHooks `yaml:"hooks,omitempty" json:"hooks,omitempty"`
 }
 
 // IsZero implements the yaml.v3 IsZero interface for omitempty support.
@@ -143,7 +248,8 @@ func (d DevBuildConfig) IsZero() bool {
 		len(d.BaseStage.Packages) == 0 &&
 		len(d.DevStage.Packages) == 0 &&
 		len(d.DevStage.DevTools) == 0 &&
-		len(d.DevStage.CustomCommands) == 0
+		len(d.DevStage.CustomCommands) == 0 &&
+		d.Hooks.IsZero()
 }
 
 // DevStageConfig defines what developer tools to add in the dev stage.
@@ -183,14 +289,155 @@ type NvimConfig struct {
 	CustomConfig           string   `yaml:"customConfig,omitempty"`           // Raw Lua config
 	ExtraMasonTools        []string `yaml:"extraMasonTools,omitempty"`        // Additional Mason tools to install at build time
 	ExtraTreesitterParsers []string `yaml:"extraTreesitterParsers,omitempty"` // Additional Treesitter parsers to install at build time
+	Languages              []string `yaml:"languages,omitempty"`              // Languages besides the detected one to install parsers and Mason tools for
 }
 
 // MountConfig defines a container mount
 type MountConfig struct {
-	Type        string `yaml:"type"` // bind, volume, tmpfs
-	Source      string `yaml:"source"`
-	Destination string `yaml:"destination"`
-	ReadOnly    bool   `yaml:"readOnly,omitempty"`
+	Type        string `yaml:"type" json:"type"` // bind, volume, tmpfs
+	Source      string `yaml:"source" json:"source,omitempty"`
+	Destination string `yaml:"destination" json:"destination"`
+	ReadOnly    bool   `yaml:"readOnly,omitempty" json:"readOnly,omitempty"`
+}
+
+// ValidateMounts checks a workspace's spec.mounts: each needs an absolute
+// destination, unique within the workspace, and bind and volume mounts
+// need a source.
+func ValidateMounts(mounts []MountConfig) error {
+	seen := map[string]bool{}
+	for i, m := range mounts {
+		switch m.Type {
+		case "", "bind", "volume":
+			if m.Source == "" {
+				return fmt.Errorf("mounts[%d].source is required", i)
+			}
+		case "tmpfs":
+		default:
+			return fmt.Errorf("mounts[%d].type: invalid mount type %q (supported: bind, volume, tmpfs)", i, m.Type)
+		}
+		if !strings.HasPrefix(m.Destination, "/") {
+			return fmt.Errorf("mounts[%d].destination must be an absolute container path, got %q", i, m.Destination)
+		}
+		if seen[m.Destination] {
+			return fmt.Errorf("mounts[%d].destination %s is mounted twice", i, m.Destination)
+		}
+		seen[m.Destination] = true
+	}
+	return nil
+}
+
+// ValidateDependsOn checks a workspace's spec.dependsOn: each entry names
+// a workspace of the same app or "<app>/<workspace>", not the workspace
+// itself, and appears once.
+func ValidateDependsOn(dependsOn []string, app, workspace string) error {
+	seen := map[string]bool{}
+	for i, dep := range dependsOn {
+		depApp, depName, ok := strings.Cut(dep, "/")
+		if !ok {
+			depApp, depName = app, dep
+		}
+		if depApp == "" || depName == "" || strings.Contains(depName, "/") {
+			return fmt.Errorf("dependsOn[%d]: invalid workspace %q (want <workspace> or <app>/<workspace>)", i, dep)
+		}
+		key := depApp + "/" + depName
+		if key == app+"/"+workspace {
+			return fmt.Errorf("dependsOn[%d]: workspace %s depends on itself", i, workspace)
+		}
+		if seen[key] {
+			return fmt.Errorf("dependsOn[%d]: %s is listed twice", i, key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// DeviceConfig passes a host device into the workspace container. The path
+// is on the machine that runs the containers: the VM on macOS, the remote
+// host for runtime endpoints.
+type DeviceConfig struct {
+	Path          string `yaml:"path" json:"path"`                                       // Host device, e.g. /dev/fuse
+	ContainerPath string `yaml:"containerPath,omitempty" json:"containerPath,omitempty"` // Path in the container (default: path)
+	Permissions   string `yaml:"permissions,omitempty" json:"permissions,omitempty"`     // Any of r, w, m (default: rwm)
+}
+
+// ValidateDevices checks a workspace's spec.devices: each needs an absolute
+// host path under /dev, an absolute container path used once, and
+// permissions made of r, w, and m. Kubernetes workspaces can't use devices.
+func ValidateDevices(devices []DeviceConfig, runtime string) error {
+	if len(devices) > 0 && runtime == WorkspaceRuntimeKubernetes {
+		return fmt.Errorf("devices: host devices are not supported with spec.runtime: kubernetes")
+	}
+	seen := map[string]bool{}
+	for i, d := range devices {
+		if !strings.HasPrefix(d.Path, "/dev/") {
+			return fmt.Errorf("devices[%d].path must be a device under /dev, got %q", i, d.Path)
+		}
+		containerPath := d.ContainerPath
+		if containerPath == "" {
+			containerPath = d.Path
+		}
+		if !strings.HasPrefix(containerPath, "/") {
+			return fmt.Errorf("devices[%d].containerPath must be an absolute container path, got %q", i, containerPath)
+		}
+		if seen[containerPath] {
+			return fmt.Errorf("devices[%d].containerPath %s is used twice", i, containerPath)
+		}
+		seen[containerPath] = true
+		for _, p := range d.Permissions {
+			if !strings.ContainsRune("rwm", p) || strings.Count(d.Permissions, string(p)) > 1 {
+				return fmt.Errorf("devices[%d].permissions: invalid permissions %q (any of r, w, m)", i, d.Permissions)
+			}
+		}
+	}
+	return nil
+}
+
+// GPURequest is a parsed spec.gpus: all GPUs, a number of them, or the ones
+// with the given IDs. The zero value requests none.
+type GPURequest struct {
+	All       bool
+	Count     int
+	DeviceIDs []string
+}
+
+// ParseGPURequest parses a workspace's spec.gpus: "all", a positive count,
+// or "device=" followed by comma-separated GPU IDs or UUIDs. Surrounding
+// whitespace is ignored.
+func ParseGPURequest(gpus string) (GPURequest, error) {
+	gpus = strings.TrimSpace(gpus)
+	switch {
+	case gpus == "":
+		return GPURequest{}, nil
+	case gpus == "all":
+		return GPURequest{All: true}, nil
+	case strings.HasPrefix(gpus, "device="):
+		var ids []string
+		for _, id := range strings.Split(strings.TrimPrefix(gpus, "device="), ",") {
+			if id = strings.TrimSpace(id); id == "" {
+				return GPURequest{}, fmt.Errorf("invalid value %q: empty device ID", gpus)
+			}
+			ids = append(ids, id)
+		}
+		return GPURequest{DeviceIDs: ids}, nil
+	}
+	n, err := strconv.Atoi(gpus)
+	if err != nil || n < 1 {
+		return GPURequest{}, fmt.Errorf("invalid value %q: use all, a count, or device=<id>,...", gpus)
+	}
+	return GPURequest{Count: n}, nil
+}
+
+// ValidateGPUs checks a workspace's spec.gpus with ParseGPURequest.
+// Kubernetes schedules GPUs by count only.
+func ValidateGPUs(gpus, runtime string) error {
+	req, err := ParseGPURequest(gpus)
+	if err != nil {
+		return fmt.Errorf("gpus: %w", err)
+	}
+	if (req.All || len(req.DeviceIDs) > 0) && runtime == WorkspaceRuntimeKubernetes {
+		return fmt.Errorf("gpus: %q is not supported with spec.runtime: kubernetes, which requests GPUs by count", strings.TrimSpace(gpus))
+	}
+	return nil
 }
 
 // SSHKeyConfig defines SSH key configuration
@@ -200,7 +447,8 @@ type SSHKeyConfig struct {
 }
 
 // ContainerConfig defines container runtime settings for the dev environment.
-// Port exposure is handled at the App level, not here.
+// Ports are container ports published on the host's 127.0.0.1 whenever the
+// workspace's container is created, in addition to dvm attach --port.
 type ContainerConfig struct {
 	User                  string         `yaml:"user,omitempty"`
 	UID                   int            `yaml:"uid,omitempty"`
@@ -212,6 +460,23 @@ type ContainerConfig struct {
 	SSHAgentForwarding    bool           `yaml:"sshAgentForwarding,omitempty"`
 	GitCredentialMounting bool           `yaml:"gitCredentialMounting,omitempty"`
 	NetworkMode           string         `yaml:"networkMode,omitempty"`
+	Ports                 []int          `yaml:"ports,omitempty"`
+}
+
+// ValidatePorts checks a workspace's spec.container.ports: each must be a
+// valid port, listed once.
+func ValidatePorts(ports []int) error {
+	seen := map[int]bool{}
+	for i, port := range ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("container.ports[%d]: invalid port %d: must be between 1 and 65535", i, port)
+		}
+		if seen[port] {
+			return fmt.Errorf("container.ports[%d]: port %d is listed twice", i, port)
+		}
+		seen[port] = true
+	}
+	return nil
 }
 
 // ResourceLimits defines container resource limits
@@ -280,11 +545,27 @@ func (w *Workspace) ToYAML(appName string, gitRepoName string) WorkspaceYAML {
 	// These are stored in the JSON but mapped to top-level spec fields in YAML.
 	toolsConfig := buildConfig.Tools
 	shellConfig := buildConfig.Shell
+	servicesConfig := buildConfig.Services
+	syncConfig := buildConfig.Sync
+	lifecycleHooks := buildConfig.Lifecycle
+	runtimeName, kubernetesConfig := buildConfig.Runtime, buildConfig.Kubernetes
+	mounts := buildConfig.Mounts
+	ports := buildConfig.Ports
+	devices, gpus := buildConfig.Devices, buildConfig.GPUs
+	dependsOn := buildConfig.DependsOn
 
 	// Clear Tools/Shell from buildConfig so they don't appear in spec.build YAML
 	// (they are yaml:"-" so this is defensive only)
 	buildConfig.Tools = ToolsConfig{}
 	buildConfig.Shell = ShellConfig{}
+	buildConfig.Services = ServicesConfig{}
+	buildConfig.Sync = SyncConfig{}
+	buildConfig.Lifecycle = LifecycleHooks{}
+	buildConfig.Runtime, buildConfig.Kubernetes = "", KubernetesConfig{}
+	buildConfig.Mounts = nil
+	buildConfig.Ports = nil
+	buildConfig.Devices, buildConfig.GPUs = nil, ""
+	buildConfig.DependsOn = nil
 
 	// Create default spec with minimal configuration
 	// This will be enhanced when we implement config storage in DB
@@ -297,6 +578,9 @@ func (w *Workspace) ToYAML(appName string, gitRepoName string) WorkspaceYAML {
 		Tools:    toolsConfig,
 		Nvim:     nvimConfig,
 		Terminal: terminalConfig,
+		Mounts:   mounts,
+		Devices:  devices,
+		GPUs:     gpus,
 		Env:      envMap,
 		Container: ContainerConfig{
 			User:                  "dev",
@@ -306,7 +590,14 @@ func (w *Workspace) ToYAML(appName string, gitRepoName string) WorkspaceYAML {
 			Command:               []string{"/bin/zsh", "-l"},
 			SSHAgentForwarding:    w.SSHAgentForwarding,
 			GitCredentialMounting: w.GitCredentialMounting,
+			Ports:                 ports,
 		},
+		Services:   servicesConfig,
+		DependsOn:  dependsOn,
+		Sync:       syncConfig,
+		Hooks:      lifecycleHooks,
+		Runtime:    runtimeName,
+		Kubernetes: kubernetesConfig,
 	}
 
 	// Add gitrepo if provided
@@ -377,18 +668,30 @@ func (w *Workspace) FromYAML(yaml WorkspaceYAML) {
 	// GitCredentialMounting — stored as a dedicated bool column (#374)
 	w.GitCredentialMounting = yaml.Spec.Container.GitCredentialMounting
 
-	// Persist build config (args, caCerts, baseStage, devStage, tools, shell) as JSON.
+	// Persist build config (args, caCerts, baseStage, devStage, tools, shell, services, sync, hooks, runtime, mounts, ports, devices, gpus, dependsOn) as JSON.
 	// Tools and Shell are embedded in the BuildConfig JSON blob to avoid
 	// schema migrations (issue #132).
 	build := yaml.Spec.Build
 	build.Tools = yaml.Spec.Tools
 	build.Shell = yaml.Spec.Shell
+	build.Services = yaml.Spec.Services
+	build.Sync = yaml.Spec.Sync
+	build.Lifecycle = yaml.Spec.Hooks
+	build.Runtime, build.Kubernetes = yaml.Spec.Runtime, yaml.Spec.Kubernetes
+	build.Mounts = yaml.Spec.Mounts
+	build.Ports = yaml.Spec.Container.Ports
+	build.Devices, build.GPUs = yaml.Spec.Devices, yaml.Spec.GPUs
+	build.DependsOn = yaml.Spec.DependsOn
 
 	hasContent := len(build.Args) > 0 || len(build.CACerts) > 0 ||
 		len(build.BaseStage.Packages) > 0 ||
 		len(build.DevStage.Packages) > 0 || len(build.DevStage.DevTools) > 0 || len(build.DevStage.CustomCommands) > 0 ||
 		!build.Tools.IsZero() ||
-		build.Shell.Type != "" || build.Shell.Framework != "" || build.Shell.Theme != ""
+		build.Shell.Type != "" || build.Shell.Framework != "" || build.Shell.Theme != "" ||
+		!build.Services.IsZero() || !build.Sync.IsZero() || !build.Lifecycle.IsZero() ||
+		build.Runtime != "" || !build.Kubernetes.IsZero() ||
+		len(build.Mounts) > 0 || len(build.Ports) > 0 || len(build.Devices) > 0 || build.GPUs != "" ||
+		len(build.DependsOn) > 0 || !build.Hooks.IsZero()
 
 	if hasContent {
 		if b, err := json.Marshal(build); err == nil {
@@ -842,3 +842,101 @@ func TestValidatePEMContent_EmptyAndGarbage(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		mounts  []models.MountConfig
+		wantErr string
+	}{
+		{"none", nil, ""},
+		{"bind and tmpfs", []models.MountConfig{
+			{Source: "~/.aws", Destination: "/home/dev/.aws", ReadOnly: true},
+			{Type: "tmpfs", Destination: "/tmp/cache"},
+		}, ""},
+		{"relative destination", []models.MountConfig{{Source: "~/.aws", Destination: ".aws"}}, "mounts[0].destination"},
+		{"missing source", []models.MountConfig{{Type: "volume", Destination: "/data"}}, "mounts[0].source is required"},
+		{"unknown type", []models.MountConfig{{Type: "nfs", Source: "host:/x", Destination: "/x"}}, "invalid mount type"},
+		{"duplicate destination", []models.MountConfig{
+			{Source: "/a", Destination: "/data"},
+			{Source: "/b", Destination: "/data"},
+		}, "mounts[1].destination /data is mounted twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := models.ValidateMounts(tt.mounts)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidatePorts(t *testing.T) {
+	assert.NoError(t, models.ValidatePorts(nil))
+	assert.NoError(t, models.ValidatePorts([]int{3000, 5173}))
+	assert.ErrorContains(t, models.ValidatePorts([]int{0}), "container.ports[0]: invalid port 0")
+	assert.ErrorContains(t, models.ValidatePorts([]int{3000, 70000}), "container.ports[1]: invalid port 70000")
+	assert.ErrorContains(t, models.ValidatePorts([]int{3000, 3000}), "port 3000 is listed twice")
+}
+
+func TestValidateDevices(t *testing.T) {
+	assert.NoError(t, models.ValidateDevices(nil, ""))
+	assert.NoError(t, models.ValidateDevices([]models.DeviceConfig{
+		{Path: "/dev/fuse"},
+		{Path: "/dev/video0", ContainerPath: "/dev/cam", Permissions: "rw"},
+	}, ""))
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "fuse"}}, ""), "devices[0].path must be a device under /dev")
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "/dev/fuse", ContainerPath: "fuse"}}, ""), "devices[0].containerPath must be an absolute")
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "/dev/fuse"}, {Path: "/dev/fuse"}}, ""), "devices[1].containerPath /dev/fuse is used twice")
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "/dev/fuse", Permissions: "rx"}}, ""), `invalid permissions "rx"`)
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "/dev/fuse", Permissions: "rr"}}, ""), `invalid permissions "rr"`)
+	assert.ErrorContains(t, models.ValidateDevices([]models.DeviceConfig{{Path: "/dev/fuse"}}, models.WorkspaceRuntimeKubernetes), "not supported with spec.runtime: kubernetes")
+}
+
+func TestParseGPURequest(t *testing.T) {
+	tests := []struct {
+		in   string
+		want models.GPURequest
+	}{
+		{"", models.GPURequest{}},
+		{" all ", models.GPURequest{All: true}},
+		{"2", models.GPURequest{Count: 2}},
+		{"device=0, GPU-3a1f", models.GPURequest{DeviceIDs: []string{"0", "GPU-3a1f"}}},
+	}
+	for _, tt := range tests {
+		got, err := models.ParseGPURequest(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+	for _, in := range []string{"0", "-1", "some", "device=", "device=0,"} {
+		_, err := models.ParseGPURequest(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestValidateGPUs(t *testing.T) {
+	for _, gpus := range []string{"", "all", " all", "1", "4", "device=0", "device=0,GPU-3a1f"} {
+		assert.NoError(t, models.ValidateGPUs(gpus, ""), gpus)
+	}
+	for _, gpus := range []string{"0", "-2", "some", "device=", "device=0,"} {
+		assert.Error(t, models.ValidateGPUs(gpus, ""), gpus)
+	}
+	assert.NoError(t, models.ValidateGPUs("2", models.WorkspaceRuntimeKubernetes))
+	assert.ErrorContains(t, models.ValidateGPUs("all", models.WorkspaceRuntimeKubernetes), "requests GPUs by count")
+	assert.ErrorContains(t, models.ValidateGPUs("device=0", models.WorkspaceRuntimeKubernetes), "requests GPUs by count")
+}
+
+func TestValidateDependsOn(t *testing.T) {
+	assert.NoError(t, models.ValidateDependsOn(nil, "shop", "api"))
+	assert.NoError(t, models.ValidateDependsOn([]string{"db", "auth/api"}, "shop", "api"))
+	assert.ErrorContains(t, models.ValidateDependsOn([]string{"api"}, "shop", "api"), "dependsOn[0]: workspace api depends on itself")
+	assert.ErrorContains(t, models.ValidateDependsOn([]string{"shop/api"}, "shop", "api"), "depends on itself")
+	assert.ErrorContains(t, models.ValidateDependsOn([]string{"db", "shop/db"}, "shop", "api"), "dependsOn[1]: shop/db is listed twice")
+	for _, dep := range []string{"", "/db", "shop/", "a/b/c"} {
+		assert.ErrorContains(t, models.ValidateDependsOn([]string{dep}, "shop", "api"), "invalid workspace", dep)
+	}
+}
@@ -817,3 +817,169 @@ func TestWorkspace_GitCredentialMounting_RoundTrip(t *testing.T) {
 	assert.Equal(t, ws.GitCredentialMounting, ws2.GitCredentialMounting,
 		"GitCredentialMounting should survive a YAML round-trip")
 }
+
+func TestWorkspace_Services_RoundTrip(t *testing.T) {
+	yamlContent := `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  services:
+    composeFile: docker-compose.yml
+    only: [db]
+    inline:
+      - name: redis
+        version: "7"
+`
+	var parsed WorkspaceYAML
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &parsed))
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+	require.True(t, ws.BuildConfig.Valid, "services should be stored in the BuildConfig JSON")
+
+	result := ws.ToYAML("api", "")
+	assert.Equal(t, parsed.Spec.Services, result.Spec.Services)
+	assert.Empty(t, result.Spec.Build.Services, "services should not appear under spec.build")
+
+	data, err := yaml.Marshal(result)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "composeFile: docker-compose.yml")
+}
+
+func TestWorkspace_Runtime_RoundTrip(t *testing.T) {
+	yamlContent := `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  runtime: kubernetes
+  kubernetes:
+    context: shared
+    namespace: dev-envs
+    storageSize: 20Gi
+    imageRegistry: registry.example.com/team
+`
+	var parsed WorkspaceYAML
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &parsed))
+	require.NoError(t, ValidateWorkspaceRuntime(parsed.Spec.Runtime))
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+
+	result := ws.ToYAML("api", "")
+	assert.Equal(t, WorkspaceRuntimeKubernetes, result.Spec.Runtime)
+	assert.Equal(t, parsed.Spec.Kubernetes, result.Spec.Kubernetes)
+
+	assert.Error(t, ValidateWorkspaceRuntime("nomad"))
+}
+
+func TestWorkspace_Sync_RoundTrip(t *testing.T) {
+	yamlContent := `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  sync:
+    mode: two-way
+    ignore: [node_modules/, "*.log"]
+`
+	var parsed WorkspaceYAML
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &parsed))
+	require.NoError(t, ValidateSyncConfig(parsed.Spec.Sync))
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+	require.True(t, ws.BuildConfig.Valid, "sync should be stored in the BuildConfig JSON")
+
+	result := ws.ToYAML("api", "")
+	assert.Equal(t, parsed.Spec.Sync, result.Spec.Sync)
+	assert.True(t, result.Spec.Sync.Enabled())
+
+	assert.False(t, SyncConfig{Mode: SyncModeBind}.Enabled())
+	assert.Error(t, ValidateSyncConfig(SyncConfig{Mode: "mirror"}))
+}
+
+func TestWorkspace_Mounts_RoundTrip(t *testing.T) {
+	yamlContent := `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  mounts:
+    - type: bind
+      source: ~/.aws
+      destination: /home/dev/.aws
+      readOnly: true
+`
+	var parsed WorkspaceYAML
+	require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &parsed))
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+	require.True(t, ws.BuildConfig.Valid, "mounts should be stored in the BuildConfig JSON")
+	assert.Contains(t, ws.BuildConfig.String, `"mounts":[{"type":"bind","source":"~/.aws","destination":"/home/dev/.aws","readOnly":true}]`)
+
+	result := ws.ToYAML("api", "")
+	assert.Equal(t, parsed.Spec.Mounts, result.Spec.Mounts)
+}
+
+func TestWorkspace_ContainerPorts_RoundTrip(t *testing.T) {
+	var parsed WorkspaceYAML
+	parsed.Metadata.Name = "dev"
+	parsed.Spec.Container.Ports = []int{3000, 5173}
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+	require.True(t, ws.BuildConfig.Valid, "ports should be stored in the BuildConfig JSON")
+	assert.Contains(t, ws.BuildConfig.String, `"ports":[3000,5173]`)
+
+	result := ws.ToYAML("api", "")
+	assert.Equal(t, []int{3000, 5173}, result.Spec.Container.Ports)
+	assert.Nil(t, result.Spec.Build.Ports)
+}
+
+func TestWorkspace_DevicesAndGPUs_RoundTrip(t *testing.T) {
+	var parsed WorkspaceYAML
+	require.NoError(t, yaml.Unmarshal([]byte(`
+metadata:
+  name: train
+spec:
+  devices:
+    - path: /dev/fuse
+    - path: /dev/video0
+      containerPath: /dev/cam
+      permissions: r
+  gpus: 2
+`), &parsed))
+	assert.Equal(t, "2", parsed.Spec.GPUs, "a count in YAML reads as a string")
+
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(parsed)
+	require.True(t, ws.BuildConfig.Valid, "devices should be stored in the BuildConfig JSON")
+	assert.Contains(t, ws.BuildConfig.String, `"gpus":"2"`)
+
+	result := ws.ToYAML("ml", "")
+	assert.Equal(t, parsed.Spec.Devices, result.Spec.Devices)
+	assert.Equal(t, "2", result.Spec.GPUs)
+	assert.Nil(t, result.Spec.Build.Devices)
+	assert.Empty(t, result.Spec.Build.GPUs)
+}
+
+func TestWorkspace_DependsOn_RoundTrip(t *testing.T) {
+	ws := &Workspace{AppID: 1}
+	ws.FromYAML(WorkspaceYAML{Spec: WorkspaceSpec{DependsOn: []string{"db", "auth/api"}}})
+	require.True(t, ws.BuildConfig.Valid, "dependsOn should be stored in the BuildConfig JSON")
+
+	result := ws.ToYAML("shop", "")
+	assert.Equal(t, []string{"db", "auth/api"}, result.Spec.DependsOn)
+	assert.Nil(t, result.Spec.Build.DependsOn)
+}
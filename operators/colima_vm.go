@@ -0,0 +1,161 @@
+package operators
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ColimaVM manages the Colima virtual machine behind a Colima platform with
+// the colima CLI: its status, its resources, and starting it.
+type ColimaVM struct {
+	// Profile is the Colima profile name ("default" when empty).
+	Profile string
+
+	// run runs the colima CLI and returns its stdout (replaced in tests).
+	run func(ctx context.Context, args ...string) ([]byte, error)
+}
+
+// NewColimaVM returns the VM of a Colima profile.
+func NewColimaVM(profile string) (*ColimaVM, error) {
+	if profile == "" {
+		profile = "default"
+	}
+	if !isValidColimaProfileName(profile) {
+		return nil, fmt.Errorf("invalid Colima profile name %q", profile)
+	}
+	return &ColimaVM{Profile: profile, run: runColima}, nil
+}
+
+// VMStatus is the state and size of a Colima VM.
+type VMStatus struct {
+	Profile     string `json:"profile" yaml:"profile"`
+	Exists      bool   `json:"exists" yaml:"exists"` // false when the profile was never started
+	Running     bool   `json:"running" yaml:"running"`
+	Runtime     string `json:"runtime,omitempty" yaml:"runtime,omitempty"` // docker or containerd
+	Arch        string `json:"arch,omitempty" yaml:"arch,omitempty"`
+	CPUs        int    `json:"cpus,omitempty" yaml:"cpus,omitempty"`
+	MemoryBytes int64  `json:"memory_bytes,omitempty" yaml:"memory_bytes,omitempty"`
+	DiskBytes   int64  `json:"disk_bytes,omitempty" yaml:"disk_bytes,omitempty"`
+	Address     string `json:"address,omitempty" yaml:"address,omitempty"`
+}
+
+// VMRequirements are the resources workspaces need from the VM. Zero
+// fields are not checked.
+type VMRequirements struct {
+	CPUs        float64
+	MemoryBytes int64
+	DiskBytes   int64
+}
+
+// colimaListEntry is one line of `colima list --json`.
+type colimaListEntry struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Arch    string `json:"arch"`
+	CPUs    int    `json:"cpus"`
+	Memory  int64  `json:"memory"`
+	Disk    int64  `json:"disk"`
+	Runtime string `json:"runtime"`
+	Address string `json:"address"`
+}
+
+// Status returns the VM's state. A profile colima does not list is
+// reported with Exists false rather than as an error.
+func (v *ColimaVM) Status(ctx context.Context) (*VMStatus, error) {
+	out, err := v.run(ctx, "list", "--json")
+	if err != nil {
+		return nil, err
+	}
+	status := &VMStatus{Profile: v.Profile}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry colimaListEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse colima list output: %w", err)
+		}
+		if entry.Name != v.Profile {
+			continue
+		}
+		status.Exists = true
+		status.Running = strings.EqualFold(entry.Status, "Running")
+		status.Runtime = entry.Runtime
+		status.Arch = entry.Arch
+		status.CPUs = entry.CPUs
+		status.MemoryBytes = entry.Memory
+		status.DiskBytes = entry.Disk
+		status.Address = entry.Address
+	}
+	return status, scanner.Err()
+}
+
+// Start starts the VM. Requirements that are set size it: colima applies
+// CPUs and memory on every start, and can only grow the disk.
+func (v *ColimaVM) Start(ctx context.Context, req VMRequirements) error {
+	args := []string{"start", "--profile", v.Profile}
+	if req.CPUs > 0 {
+		args = append(args, "--cpu", strconv.Itoa(int(req.CPUs+0.999)))
+	}
+	if req.MemoryBytes > 0 {
+		args = append(args, "--memory", strconv.FormatInt(ceilGiB(req.MemoryBytes), 10))
+	}
+	if req.DiskBytes > 0 {
+		args = append(args, "--disk", strconv.FormatInt(ceilGiB(req.DiskBytes), 10))
+	}
+	_, err := v.run(ctx, args...)
+	return err
+}
+
+// Shortfalls lists the requirements the VM does not meet, e.g.
+// "memory: 2.0 GiB < 4.0 GiB required". A VM that is not running has no
+// known size, so nothing is reported.
+func (s *VMStatus) Shortfalls(req VMRequirements) []string {
+	if !s.Running {
+		return nil
+	}
+	var shortfalls []string
+	if req.CPUs > 0 && float64(s.CPUs) < req.CPUs {
+		shortfalls = append(shortfalls, fmt.Sprintf("cpus: %d < %s required", s.CPUs, strconv.FormatFloat(req.CPUs, 'f', -1, 64)))
+	}
+	if req.MemoryBytes > 0 && s.MemoryBytes < req.MemoryBytes {
+		shortfalls = append(shortfalls, fmt.Sprintf("memory: %s < %s required", FormatGiB(s.MemoryBytes), FormatGiB(req.MemoryBytes)))
+	}
+	if req.DiskBytes > 0 && s.DiskBytes < req.DiskBytes {
+		shortfalls = append(shortfalls, fmt.Sprintf("disk: %s < %s required", FormatGiB(s.DiskBytes), FormatGiB(req.DiskBytes)))
+	}
+	return shortfalls
+}
+
+// FormatGiB formats a byte count in GiB with one decimal, e.g. "7.8 GiB".
+func FormatGiB(bytes int64) string {
+	return fmt.Sprintf("%.1f GiB", float64(bytes)/(1<<30))
+}
+
+// ceilGiB rounds a byte count up to whole GiB, the unit of colima's flags.
+func ceilGiB(bytes int64) int64 {
+	return (bytes + 1<<30 - 1) >> 30
+}
+
+// runColima runs the colima CLI and includes its stderr in the error.
+func runColima(ctx context.Context, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("colima"); err != nil {
+		return nil, fmt.Errorf("colima is not installed: brew install colima")
+	}
+	cmd := exec.CommandContext(ctx, "colima", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("colima %s failed: %w\n%s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package operators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const colimaListOutput = `{"name":"default","status":"Running","arch":"aarch64","cpus":4,"memory":8589934592,"disk":107374182400,"runtime":"docker","address":"192.168.106.2"}
+{"name":"work","status":"Stopped","arch":"aarch64","cpus":2,"memory":2147483648,"disk":64424509440,"runtime":"containerd"}
+`
+
+func fakeColimaVM(profile string, output string, calls *[][]string) *ColimaVM {
+	return &ColimaVM{Profile: profile, run: func(ctx context.Context, args ...string) ([]byte, error) {
+		if calls != nil {
+			*calls = append(*calls, args)
+		}
+		return []byte(output), nil
+	}}
+}
+
+func TestNewColimaVM(t *testing.T) {
+	vm, err := NewColimaVM("")
+	require.NoError(t, err)
+	assert.Equal(t, "default", vm.Profile)
+
+	_, err = NewColimaVM("bad profile; rm")
+	assert.Error(t, err)
+}
+
+func TestColimaVM_Status(t *testing.T) {
+	status, err := fakeColimaVM("default", colimaListOutput, nil).Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &VMStatus{
+		Profile:     "default",
+		Exists:      true,
+		Running:     true,
+		Runtime:     "docker",
+		Arch:        "aarch64",
+		CPUs:        4,
+		MemoryBytes: 8 << 30,
+		DiskBytes:   100 << 30,
+		Address:     "192.168.106.2",
+	}, status)
+
+	status, err = fakeColimaVM("work", colimaListOutput, nil).Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.Exists)
+	assert.False(t, status.Running)
+
+	status, err = fakeColimaVM("missing", colimaListOutput, nil).Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.Exists)
+	assert.False(t, status.Running)
+
+	_, err = fakeColimaVM("default", "not json\n", nil).Status(context.Background())
+	assert.Error(t, err)
+}
+
+func TestColimaVM_Start(t *testing.T) {
+	var calls [][]string
+	vm := fakeColimaVM("work", "", &calls)
+
+	require.NoError(t, vm.Start(context.Background(), VMRequirements{}))
+	require.NoError(t, vm.Start(context.Background(), VMRequirements{CPUs: 2.5, MemoryBytes: 6<<30 + 1, DiskBytes: 80 << 30}))
+	assert.Equal(t, [][]string{
+		{"start", "--profile", "work"},
+		{"start", "--profile", "work", "--cpu", "3", "--memory", "7", "--disk", "80"},
+	}, calls)
+}
+
+func TestVMStatus_Shortfalls(t *testing.T) {
+	status := &VMStatus{Running: true, CPUs: 2, MemoryBytes: 2 << 30, DiskBytes: 60 << 30}
+
+	assert.Empty(t, status.Shortfalls(VMRequirements{}))
+	assert.Empty(t, status.Shortfalls(VMRequirements{CPUs: 2, MemoryBytes: 2 << 30, DiskBytes: 60 << 30}))
+	assert.Equal(t, []string{
+		"cpus: 2 < 2.5 required",
+		"memory: 2.0 GiB < 4.0 GiB required",
+		"disk: 60.0 GiB < 100.0 GiB required",
+	}, status.Shortfalls(VMRequirements{CPUs: 2.5, MemoryBytes: 4 << 30, DiskBytes: 100 << 30}))
+
+	stopped := &VMStatus{}
+	assert.Empty(t, stopped.Shortfalls(VMRequirements{CPUs: 4}))
+}
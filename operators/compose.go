@@ -0,0 +1,81 @@
+package operators
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ComposeProject runs a Compose project with `docker compose`, or
+// `nerdctl compose` on containerd platforms. Workspaces use one to run their
+// backing services (spec.services).
+type ComposeProject struct {
+	platform *Platform
+
+	// Name is the Compose project name.
+	Name string
+
+	// Files are the project's Compose files.
+	Files []string
+}
+
+// NewComposeProject creates a ComposeProject on the given platform.
+func NewComposeProject(platform *Platform, name string, files []string) *ComposeProject {
+	return &ComposeProject{platform: platform, Name: name, Files: files}
+}
+
+// Up creates and starts the named services (all of them when none are named)
+// in the background.
+func (p *ComposeProject) Up(ctx context.Context, services ...string) error {
+	return p.run(ctx, "up", append(p.args("up", "-d"), services...))
+}
+
+// Down stops and removes the project's containers and network.
+func (p *ComposeProject) Down(ctx context.Context) error {
+	return p.run(ctx, "down", p.args("down", "--remove-orphans"))
+}
+
+// args returns the CLI arguments for a compose subcommand.
+func (p *ComposeProject) args(subcommand ...string) []string {
+	var args []string
+	if p.platform.Type == PlatformColima && p.platform.IsContainerd() {
+		// nerdctl needs the address for containerd
+		args = append(args, "--address", p.platform.SocketPath)
+	}
+	args = append(args, "compose", "-p", p.Name)
+	for _, f := range p.Files {
+		args = append(args, "-f", f)
+	}
+	return append(args, subcommand...)
+}
+
+// run runs the compose CLI and includes its output in the error on failure.
+func (p *ComposeProject) run(ctx context.Context, subcommand string, args []string) error {
+	cmd := platformCommand(ctx, p.platform, args...)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s compose %s failed: %w\n%s", filepath.Base(cmd.Path), subcommand, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// platformCommand returns a command running the platform's container CLI:
+// docker, or nerdctl on containerd platforms.
+func platformCommand(ctx context.Context, platform *Platform, args ...string) *exec.Cmd {
+	tool := "docker"
+	if platform.IsContainerd() {
+		tool = "nerdctl"
+	}
+	cmd := exec.CommandContext(ctx, tool, args...)
+	cmd.Env = os.Environ()
+	if platform.IsDockerCompatible() {
+		cmd.Env = append(cmd.Env, "DOCKER_HOST="+platform.DockerHost())
+	}
+	return cmd
+}
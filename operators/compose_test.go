@@ -0,0 +1,25 @@
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposeProject_Args(t *testing.T) {
+	files := []string{"/src/api/docker-compose.yml", "/gen/services-compose.yaml"}
+
+	docker := NewComposeProject(&Platform{Type: PlatformOrbStack, SocketPath: "/tmp/docker.sock"}, "dvm-api-dev", files)
+	assert.Equal(t, []string{
+		"compose", "-p", "dvm-api-dev",
+		"-f", "/src/api/docker-compose.yml", "-f", "/gen/services-compose.yaml",
+		"up", "-d",
+	}, docker.args("up", "-d"))
+
+	containerd := NewComposeProject(&Platform{Type: PlatformColima, SocketPath: "/colima/containerd.sock"}, "dvm-api-dev", files[:1])
+	assert.Equal(t, []string{
+		"--address", "/colima/containerd.sock",
+		"compose", "-p", "dvm-api-dev", "-f", "/src/api/docker-compose.yml",
+		"down", "--remove-orphans",
+	}, containerd.args("down", "--remove-orphans"))
+}
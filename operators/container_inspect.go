@@ -0,0 +1,234 @@
+package operators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContainerDetails describes a container as the runtime's inspect reports
+// it, including containers dvm did not create.
+type ContainerDetails struct {
+	ID         string            // Full container ID
+	Name       string            // Container name, without Docker's leading slash
+	Image      string            // Image name the container was created from
+	ImageID    string            // Image ID or digest; empty when unknown
+	Status     string            // running, exited, created, ...
+	Mounts     []MountConfig     // Bind mounts, volumes, and tmpfs mounts
+	Ports      []PublishedPort   // Container ports published on the host
+	Env        map[string]string // Environment, including the image's
+	WorkingDir string            // Working directory; empty for the image's default
+	User       string            // User the container runs as; empty for the image's default
+	Labels     map[string]string // All labels
+}
+
+// PublishedPort is a container port published on the host.
+type PublishedPort struct {
+	ContainerPort int
+	HostPort      int
+	HostIP        string
+	Protocol      string // tcp or udp
+}
+
+// WorkspaceInfo returns the container as ListWorkspaces would report it, so
+// a container found by ID can be matched like a labeled one.
+func (d *ContainerDetails) WorkspaceInfo() WorkspaceInfo {
+	return WorkspaceInfo{
+		ID:        shortID(d.ID),
+		Name:      d.Name,
+		Status:    d.Status,
+		Image:     d.Image,
+		ImageID:   d.ImageID,
+		App:       d.Labels[LabelApp],
+		Workspace: d.Labels[LabelWorkspace],
+		Ecosystem: d.Labels[LabelEcosystem],
+		Domain:    d.Labels[LabelDomain],
+		System:    d.Labels[LabelSystem],
+		Version:   d.Labels[LabelVersion],
+		Labels:    d.Labels,
+	}
+}
+
+// inspectedContainer is the part of Docker's container inspect JSON dvm
+// reads. nerdctl inspect prints the same format.
+type inspectedContainer struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	Image string `json:"Image"`
+	State struct {
+		Status string `json:"Status"`
+	} `json:"State"`
+	Config struct {
+		Image      string            `json:"Image"`
+		Env        []string          `json:"Env"`
+		WorkingDir string            `json:"WorkingDir"`
+		User       string            `json:"User"`
+		Labels     map[string]string `json:"Labels"`
+	} `json:"Config"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+		RW          bool   `json:"RW"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Ports map[string][]inspectedPortBinding `json:"Ports"`
+	} `json:"NetworkSettings"`
+	HostConfig struct {
+		PortBindings map[string][]inspectedPortBinding `json:"PortBindings"`
+	} `json:"HostConfig"`
+}
+
+type inspectedPortBinding struct {
+	HostIP   string `json:"HostIp"`
+	HostPort string `json:"HostPort"`
+}
+
+// parseContainerInspect parses container inspect output: one JSON object as
+// the Docker API returns it, or the array `docker inspect` and
+// `nerdctl inspect` print.
+func parseContainerInspect(out []byte) (*ContainerDetails, error) {
+	out = bytes.TrimSpace(out)
+	var c inspectedContainer
+	if bytes.HasPrefix(out, []byte("[")) {
+		var list []inspectedContainer
+		if err := json.Unmarshal(out, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse container inspect output: %w", err)
+		}
+		if len(list) == 0 {
+			return nil, fmt.Errorf("container not found")
+		}
+		c = list[0]
+	} else if err := json.Unmarshal(out, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse container inspect output: %w", err)
+	}
+
+	details := &ContainerDetails{
+		ID:         c.ID,
+		Name:       strings.TrimPrefix(c.Name, "/"),
+		Image:      c.Config.Image,
+		ImageID:    c.Image,
+		Status:     c.State.Status,
+		WorkingDir: c.Config.WorkingDir,
+		User:       c.Config.User,
+		Env:        map[string]string{},
+		Labels:     c.Config.Labels,
+	}
+	// nerdctl reports the image name in Image and leaves Config.Image empty
+	if details.Image == "" {
+		details.Image, details.ImageID = c.Image, ""
+	}
+	if details.Labels == nil {
+		details.Labels = map[string]string{}
+	}
+	for _, kv := range c.Config.Env {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			details.Env[key] = value
+		}
+	}
+	for _, m := range c.Mounts {
+		source := m.Source
+		if m.Type == "volume" && m.Name != "" {
+			source = m.Name
+		}
+		details.Mounts = append(details.Mounts, MountConfig{
+			Type:        m.Type,
+			Source:      source,
+			Destination: m.Destination,
+			ReadOnly:    !m.RW,
+		})
+	}
+
+	// Running containers report their bindings in NetworkSettings; stopped
+	// ones only in HostConfig
+	bindings := c.NetworkSettings.Ports
+	if len(bindings) == 0 {
+		bindings = c.HostConfig.PortBindings
+	}
+	ports, err := publishedPorts(bindings)
+	if err != nil {
+		return nil, err
+	}
+	details.Ports = ports
+	return details, nil
+}
+
+// publishedPorts converts inspect port bindings ("8080/tcp" to host
+// bindings) to published ports, sorted by container port. Exposed ports
+// without a host binding are not published and are left out.
+func publishedPorts(bindings map[string][]inspectedPortBinding) ([]PublishedPort, error) {
+	var ports []PublishedPort
+	for spec, hosts := range bindings {
+		portStr, proto, _ := strings.Cut(spec, "/")
+		if proto == "" {
+			proto = "tcp"
+		}
+		containerPort, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q in container inspect output", spec)
+		}
+		for _, host := range hosts {
+			hostPort, _ := strconv.Atoi(host.HostPort)
+			if hostPort == 0 {
+				continue
+			}
+			ports = append(ports, PublishedPort{ContainerPort: containerPort, HostPort: hostPort, HostIP: host.HostIP, Protocol: proto})
+			// IPv4 and IPv6 bindings of one port are one published port
+			break
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].ContainerPort != ports[j].ContainerPort {
+			return ports[i].ContainerPort < ports[j].ContainerPort
+		}
+		return ports[i].Protocol < ports[j].Protocol
+	})
+	return ports, nil
+}
+
+// InspectContainer inspects a container by ID or name.
+func (d *DockerRuntime) InspectContainer(ctx context.Context, idOrName string) (*ContainerDetails, error) {
+	_, raw, err := d.client.ContainerInspectWithRaw(ctx, idOrName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w", idOrName, err)
+	}
+	return parseContainerInspect(raw)
+}
+
+// InspectContainer inspects a container by ID or name with nerdctl on the
+// remote host.
+func (n *NerdctlSSHRuntime) InspectContainer(ctx context.Context, idOrName string) (*ContainerDetails, error) {
+	out, err := n.nerdctl(ctx, nil, "inspect", "--mode", "dockercompat", idOrName)
+	if err != nil {
+		return nil, err
+	}
+	return parseContainerInspect(out)
+}
+
+// InspectContainer inspects a container by ID or name. Only Colima is
+// supported, through nerdctl in the VM.
+func (r *ContainerdRuntimeV2) InspectContainer(ctx context.Context, idOrName string) (*ContainerDetails, error) {
+	if r.platform.Type != PlatformColima {
+		return nil, fmt.Errorf("InspectContainer: %w", ErrNotImplemented)
+	}
+	profile := r.platform.Profile
+	if profile == "" {
+		profile = "default"
+	}
+
+	inspectCmd := fmt.Sprintf("sudo nerdctl --namespace %s inspect --mode dockercompat %s",
+		shellEscape(r.namespace), shellEscape(idOrName))
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "colima", "--profile", profile, "ssh", "--", "sh", "-c", inspectCmd)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to inspect container %s: %w\n%s", idOrName, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseContainerInspect(stdout.Bytes())
+}
@@ -0,0 +1,85 @@
+package operators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDockerInspect = `{
+  "Id": "3f2a9c1b7d4e5f6a7b8c9d0e",
+  "Name": "/legacy-box",
+  "Image": "sha256:0123abcd",
+  "State": {"Status": "running"},
+  "Config": {
+    "Image": "acme/toolbox:2",
+    "Env": ["PATH=/usr/bin", "EDITOR=vim"],
+    "WorkingDir": "/src",
+    "User": "dev",
+    "Labels": {"team": "platform"}
+  },
+  "Mounts": [
+    {"Type": "bind", "Source": "/home/me/src", "Destination": "/src", "RW": true},
+    {"Type": "volume", "Name": "cache", "Source": "/var/lib/docker/volumes/cache/_data", "Destination": "/cache", "RW": false}
+  ],
+  "NetworkSettings": {"Ports": {
+    "8080/tcp": [{"HostIp": "0.0.0.0", "HostPort": "18080"}, {"HostIp": "::", "HostPort": "18080"}],
+    "3000/tcp": [{"HostIp": "127.0.0.1", "HostPort": "3000"}],
+    "9000/tcp": null,
+    "5353/udp": [{"HostIp": "0.0.0.0", "HostPort": "5353"}]
+  }},
+  "HostConfig": {"PortBindings": {}}
+}`
+
+func TestParseContainerInspect_Docker(t *testing.T) {
+	details, err := parseContainerInspect([]byte(testDockerInspect))
+	require.NoError(t, err)
+
+	assert.Equal(t, "legacy-box", details.Name)
+	assert.Equal(t, "acme/toolbox:2", details.Image)
+	assert.Equal(t, "sha256:0123abcd", details.ImageID)
+	assert.Equal(t, "running", details.Status)
+	assert.Equal(t, "/src", details.WorkingDir)
+	assert.Equal(t, "vim", details.Env["EDITOR"])
+	assert.Equal(t, []MountConfig{
+		{Type: "bind", Source: "/home/me/src", Destination: "/src"},
+		{Type: "volume", Source: "cache", Destination: "/cache", ReadOnly: true},
+	}, details.Mounts)
+	assert.Equal(t, []PublishedPort{
+		{ContainerPort: 3000, HostPort: 3000, HostIP: "127.0.0.1", Protocol: "tcp"},
+		{ContainerPort: 5353, HostPort: 5353, HostIP: "0.0.0.0", Protocol: "udp"},
+		{ContainerPort: 8080, HostPort: 18080, HostIP: "0.0.0.0", Protocol: "tcp"},
+	}, details.Ports)
+
+	info := details.WorkspaceInfo()
+	assert.Equal(t, "3f2a9c1b7d4e", info.ID)
+	assert.Equal(t, "platform", info.Labels["team"])
+}
+
+func TestNerdctlSSHRuntime_InspectContainer(t *testing.T) {
+	n, fake := newFakeNerdctlSSHRuntime(map[string]string{"inspect": `[{
+  "Id": "0123456789abcdef",
+  "Name": "legacy-box",
+  "Image": "docker.io/acme/toolbox:2",
+  "State": {"Status": "exited"},
+  "Config": {"Labels": null},
+  "HostConfig": {"PortBindings": {"8080/tcp": [{"HostIp": "127.0.0.1", "HostPort": "8080"}]}}
+}]`})
+
+	details, err := n.InspectContainer(context.Background(), "legacy-box")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inspect", "--mode", "dockercompat", "legacy-box"}, fake.calls[0])
+	assert.Equal(t, "docker.io/acme/toolbox:2", details.Image)
+	assert.Empty(t, details.ImageID)
+	assert.Equal(t, "exited", details.Status)
+	assert.NotNil(t, details.Labels)
+	// A stopped container reports its bindings in HostConfig only
+	assert.Equal(t, []PublishedPort{{ContainerPort: 8080, HostPort: 8080, HostIP: "127.0.0.1", Protocol: "tcp"}}, details.Ports)
+}
+
+func TestParseContainerInspect_NotFound(t *testing.T) {
+	_, err := parseContainerInspect([]byte("[]"))
+	assert.Error(t, err)
+}
@@ -46,7 +46,11 @@ func NewContainerdRuntimeV2WithPlatform(platform *Platform) (*ContainerdRuntimeV
 	// Create containerd client
 	ctdClient, err := client.New(platform.SocketPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create containerd client: %w\n%s", err, platform.GetStartHint())
+		return nil, &ErrRuntimeUnavailable{
+			Platform: platform.Name,
+			Hint:     platform.GetStartHint(),
+			Err:      fmt.Errorf("failed to create containerd client: %w", err),
+		}
 	}
 
 	// Verify connection
@@ -54,7 +58,11 @@ func NewContainerdRuntimeV2WithPlatform(platform *Platform) (*ContainerdRuntimeV
 	version, err := ctdClient.Version(ctx)
 	if err != nil {
 		ctdClient.Close()
-		return nil, fmt.Errorf("failed to connect to containerd: %w\n%s", err, platform.GetStartHint())
+		return nil, &ErrRuntimeUnavailable{
+			Platform: platform.Name,
+			Hint:     platform.GetStartHint(),
+			Err:      err,
+		}
 	}
 
 	render.Infof("Connected to containerd %s (%s, namespace: %s)",
@@ -2,12 +2,14 @@ package operators
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/moby/term"
 )
 
 // AttachToWorkspace attaches to a running workspace container
@@ -47,12 +49,6 @@ func (r *ContainerdRuntimeV2) attachViaColima(ctx context.Context, opts AttachOp
 		return fmt.Errorf("container is not running (status: %s)", status)
 	}
 
-	// Build shell command with options
-	shell := opts.Shell
-	if shell == "" {
-		shell = "/bin/zsh"
-	}
-
 	// Compute effective UID/GID for exec session (default to 1000 if not set)
 	uid := opts.UID
 	if uid == 0 {
@@ -66,7 +62,15 @@ func (r *ContainerdRuntimeV2) attachViaColima(ctx context.Context, opts AttachOp
 
 	// Start building the nerdctl exec command
 	var cmdParts []string
-	cmdParts = append(cmdParts, "sudo", "nerdctl", "--namespace", shellEscape(r.namespace), "exec", "-it")
+	// Interactive shells always get a TTY; commands only when stdin is a terminal
+	tty := len(opts.Command) == 0 || term.IsTerminal(os.Stdin.Fd())
+	cmdParts = append(cmdParts, "sudo", "nerdctl", "--namespace", shellEscape(r.namespace), "exec", "-i")
+	if tty {
+		cmdParts = append(cmdParts, "-t")
+	}
+	if opts.WorkingDir != "" {
+		cmdParts = append(cmdParts, "--workdir", shellEscape(opts.WorkingDir))
+	}
 
 	// Defense-in-depth: explicitly set user for exec sessions
 	cmdParts = append(cmdParts, "--user", userStr)
@@ -80,10 +84,9 @@ func (r *ContainerdRuntimeV2) attachViaColima(ctx context.Context, opts AttachOp
 	// Add container name
 	cmdParts = append(cmdParts, shellEscape(opts.WorkspaceID))
 
-	// Add shell and login flag
-	cmdParts = append(cmdParts, shellEscape(shell))
-	if opts.LoginShell {
-		cmdParts = append(cmdParts, "-l")
+	// Add the shell (or command) to run
+	for _, arg := range opts.ComputeCommand() {
+		cmdParts = append(cmdParts, shellEscape(arg))
 	}
 
 	// Convert to command string for SSH execution
@@ -107,12 +110,47 @@ func (r *ContainerdRuntimeV2) attachViaColima(ctx context.Context, opts AttachOp
 
 	// Run the command
 	if err := execProc.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if len(opts.Command) > 0 && errors.As(err, &exitErr) {
+			return &ExitError{Code: exitErr.ExitCode()}
+		}
 		return fmt.Errorf("failed to attach: %w", err)
 	}
 
 	return nil
 }
 
+// ExecInWorkspace runs a command in a workspace container with piped input
+// and output. Like attach, it goes through nerdctl in the Colima VM.
+func (r *ContainerdRuntimeV2) ExecInWorkspace(ctx context.Context, opts ExecOptions) error {
+	if r.platform.Type != PlatformColima {
+		return fmt.Errorf("exec is not supported on platform %s with containerd runtime", r.platform.Name)
+	}
+	profile := r.platform.Profile
+	if profile == "" {
+		profile = "default"
+	}
+
+	cmdParts := []string{"sudo", "nerdctl", "--namespace", shellEscape(r.namespace), "exec"}
+	if opts.Stdin != nil {
+		cmdParts = append(cmdParts, "-i")
+	}
+	cmdParts = append(cmdParts, "--user", execUser(opts.UID, opts.GID), shellEscape(opts.WorkspaceID))
+	for _, arg := range opts.Command {
+		cmdParts = append(cmdParts, shellEscape(arg))
+	}
+
+	var stderr strings.Builder
+	execProc := exec.CommandContext(ctx, "colima", "--profile", profile, "ssh", "--", "sh", "-c", strings.Join(cmdParts, " "))
+	execProc.Stdin = opts.Stdin
+	execProc.Stdout = opts.Stdout
+	execProc.Stderr = &stderr
+	if err := execProc.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", opts.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // attachDirectAPI attaches to a container using containerd API directly
 func (r *ContainerdRuntimeV2) attachDirectAPI(ctx context.Context, opts AttachOptions) error {
 	ctx = namespaces.WithNamespace(ctx, r.namespace)
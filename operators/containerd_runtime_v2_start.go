@@ -3,8 +3,10 @@ package operators
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"syscall"
 
@@ -23,6 +25,9 @@ func (r *ContainerdRuntimeV2) StartWorkspace(ctx context.Context, opts StartOpti
 	if err := validateStartOptionsMounts(opts); err != nil {
 		return "", fmt.Errorf("invalid mount configuration: %w", err)
 	}
+	if err := r.DeviceSupport().Check(r.GetPlatformName(), opts.Devices, opts.GPUs); err != nil {
+		return "", err
+	}
 
 	// For Colima, use nerdctl via SSH to handle host path mounting correctly
 	// The containerd API cannot handle macOS host paths directly since containerd
@@ -32,6 +37,9 @@ func (r *ContainerdRuntimeV2) StartWorkspace(ctx context.Context, opts StartOpti
 	}
 
 	// For other containerd platforms, use direct API (may need similar handling)
+	if len(opts.Ports) > 0 {
+		render.Warning("Publishing ports is not supported with the containerd API; ignoring --port")
+	}
 	return r.startWorkspaceDirectAPI(ctx, opts)
 }
 
@@ -58,8 +66,8 @@ func (r *ContainerdRuntimeV2) startWorkspaceViaColima(ctx context.Context, opts
 	if containerExists {
 		// Get the image the existing container was created with from our label
 		// Containers created before v0.18.18 won't have this label
-		imageCmd := fmt.Sprintf("sudo nerdctl --namespace %s inspect -f '{{index .Config.Labels \"io.devopsmaestro.image\"}}' %s 2>/dev/null || echo ''",
-			shellEscape(r.namespace), shellEscape(containerName))
+		imageCmd := fmt.Sprintf("sudo nerdctl --namespace %s inspect -f '{{index .Config.Labels \"%s\"}}' %s 2>/dev/null || echo ''",
+			shellEscape(r.namespace), LabelImage, shellEscape(containerName))
 		imageExec := exec.CommandContext(ctx, "colima", "--profile", profile, "ssh", "--", "sh", "-c", imageCmd)
 		imageOutput, _ := imageExec.Output()
 		existingImage := strings.TrimSpace(string(imageOutput))
@@ -190,31 +198,25 @@ func (r *ContainerdRuntimeV2) startWorkspaceViaColima(ctx context.Context, opts
 	}
 
 	// Add labels for DVM management
-	nerdctlArgs = append(nerdctlArgs,
-		"--label", "io.devopsmaestro.managed=true",
-		"--label", fmt.Sprintf("io.devopsmaestro.app=%s", opts.AppName),
-		"--label", fmt.Sprintf("io.devopsmaestro.workspace=%s", opts.WorkspaceName),
-		"--label", fmt.Sprintf("io.devopsmaestro.image=%s", opts.ImageName),
-	)
-
-	// Add ecosystem label if provided
-	if opts.EcosystemName != "" {
-		nerdctlArgs = append(nerdctlArgs, "--label", fmt.Sprintf("io.devopsmaestro.ecosystem=%s", opts.EcosystemName))
+	labels := ResourceLabels(opts.LabelScope())
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		nerdctlArgs = append(nerdctlArgs, "--label", fmt.Sprintf("%s=%s", k, labels[k]))
 	}
+	nerdctlArgs = append(nerdctlArgs, "--label", fmt.Sprintf("%s=%s", LabelImage, opts.ImageName))
 
-	// Add domain label if provided
-	if opts.DomainName != "" {
-		nerdctlArgs = append(nerdctlArgs, "--label", fmt.Sprintf("io.devopsmaestro.domain=%s", opts.DomainName))
+	// Network isolation (issue #91)
+	if opts.NetworkMode != "" {
+		nerdctlArgs = append(nerdctlArgs, "--network", opts.NetworkMode)
 	}
 
-	// Add system label if provided
-	if opts.SystemName != "" {
-		nerdctlArgs = append(nerdctlArgs, "--label", fmt.Sprintf("io.devopsmaestro.system=%s", opts.SystemName))
+	// Published ports (loopback only)
+	for _, port := range opts.Ports {
+		nerdctlArgs = append(nerdctlArgs, "-p", fmt.Sprintf("127.0.0.1:%d:%d", port, port))
 	}
 
-	// Network isolation (issue #91)
-	if opts.NetworkMode != "" {
-		nerdctlArgs = append(nerdctlArgs, "--network", opts.NetworkMode)
+	// Devices of the VM
+	for _, device := range opts.Devices {
+		nerdctlArgs = append(nerdctlArgs, "--device", device.String())
 	}
 
 	// Resource limits (issue #92)
@@ -414,6 +416,11 @@ func (r *ContainerdRuntimeV2) startWorkspaceDirectAPI(ctx context.Context, opts
 		ociOpts = append(ociOpts, oci.WithMemoryLimit(uint64(memBytes)))
 	}
 
+	// Host devices
+	for _, device := range opts.Devices {
+		ociOpts = append(ociOpts, oci.WithDevices(device.HostPath, device.containerPath(), device.permissions()))
+	}
+
 	// Network isolation (issue #91): set network namespace for "none" mode
 	if opts.NetworkMode == "none" {
 		ociOpts = append(ociOpts, oci.WithLinuxNamespace(specs.LinuxNamespace{
@@ -85,7 +85,7 @@ func (r *ContainerdRuntimeV2) ListWorkspaces(ctx context.Context) ([]WorkspaceIn
 		}
 
 		// Check for DVM management label
-		if labels["io.devopsmaestro.managed"] != "true" {
+		if labels[LabelManaged] != "true" {
 			continue
 		}
 
@@ -101,9 +101,10 @@ func (r *ContainerdRuntimeV2) ListWorkspaces(ctx context.Context) ([]WorkspaceIn
 
 		// Get image
 		image, _ := c.Image(ctx)
-		imageName := ""
+		imageName, imageID := "", ""
 		if image != nil {
 			imageName = image.Name()
+			imageID = image.Target().Digest.String()
 		}
 
 		workspaces = append(workspaces, WorkspaceInfo{
@@ -111,11 +112,13 @@ func (r *ContainerdRuntimeV2) ListWorkspaces(ctx context.Context) ([]WorkspaceIn
 			Name:      c.ID(), // containerd uses ID as name
 			Status:    status,
 			Image:     imageName,
-			App:       labels["io.devopsmaestro.app"],
-			Workspace: labels["io.devopsmaestro.workspace"],
-			Ecosystem: labels["io.devopsmaestro.ecosystem"],
-			Domain:    labels["io.devopsmaestro.domain"],
-			System:    labels["io.devopsmaestro.system"],
+			ImageID:   imageID,
+			App:       labels[LabelApp],
+			Workspace: labels[LabelWorkspace],
+			Ecosystem: labels[LabelEcosystem],
+			Domain:    labels[LabelDomain],
+			System:    labels[LabelSystem],
+			Version:   labels[LabelVersion],
 			Labels:    labels,
 		})
 	}
@@ -138,7 +141,7 @@ func (r *ContainerdRuntimeV2) FindWorkspace(ctx context.Context, name string) (*
 	}
 
 	// Verify it's DVM-managed
-	if labels["io.devopsmaestro.managed"] != "true" {
+	if labels[LabelManaged] != "true" {
 		return nil, nil
 	}
 
@@ -154,9 +157,10 @@ func (r *ContainerdRuntimeV2) FindWorkspace(ctx context.Context, name string) (*
 
 	// Get image
 	image, _ := container.Image(ctx)
-	imageName := ""
+	imageName, imageID := "", ""
 	if image != nil {
 		imageName = image.Name()
+		imageID = image.Target().Digest.String()
 	}
 
 	return &WorkspaceInfo{
@@ -164,11 +168,13 @@ func (r *ContainerdRuntimeV2) FindWorkspace(ctx context.Context, name string) (*
 		Name:      container.ID(),
 		Status:    status,
 		Image:     imageName,
-		App:       labels["io.devopsmaestro.app"],
-		Workspace: labels["io.devopsmaestro.workspace"],
-		Ecosystem: labels["io.devopsmaestro.ecosystem"],
-		Domain:    labels["io.devopsmaestro.domain"],
-		System:    labels["io.devopsmaestro.system"],
+		ImageID:   imageID,
+		App:       labels[LabelApp],
+		Workspace: labels[LabelWorkspace],
+		Ecosystem: labels[LabelEcosystem],
+		Domain:    labels[LabelDomain],
+		System:    labels[LabelSystem],
+		Version:   labels[LabelVersion],
 		Labels:    labels,
 	}, nil
 }
@@ -216,3 +222,18 @@ func restoreTerminal() {
 }
 
 var terminalState *term.State
+
+// ImageDigest returns the manifest digest of an image in the runtime's
+// namespace. Images loaded with nerdctl are stored under their normalized
+// name, so a short name is also looked up as docker.io/library/<name>.
+func (r *ContainerdRuntimeV2) ImageDigest(ctx context.Context, imageName string) (string, error) {
+	ctx = namespaces.WithNamespace(ctx, r.namespace)
+	image, err := r.client.GetImage(ctx, imageName)
+	if err != nil && !strings.Contains(imageName, "/") {
+		image, err = r.client.GetImage(ctx, "docker.io/library/"+imageName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get image %s: %w", imageName, err)
+	}
+	return image.Target().Digest.String(), nil
+}
@@ -0,0 +1,148 @@
+package operators
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"strconv"
+	"strings"
+)
+
+// DeviceMapping is a host device passed into a container.
+type DeviceMapping struct {
+	HostPath      string
+	ContainerPath string // Path in the container; empty for HostPath
+	Permissions   string // cgroup permissions, any of r, w, m; empty for rwm
+}
+
+// String formats the mapping as the --device flag of docker and nerdctl
+// takes it: host:container:permissions.
+func (d DeviceMapping) String() string {
+	return d.HostPath + ":" + d.containerPath() + ":" + d.permissions()
+}
+
+func (d DeviceMapping) containerPath() string {
+	if d.ContainerPath == "" {
+		return d.HostPath
+	}
+	return d.ContainerPath
+}
+
+func (d DeviceMapping) permissions() string {
+	if d.Permissions == "" {
+		return "rwm"
+	}
+	return d.Permissions
+}
+
+// GPURequest asks for GPUs: all of them, a number of them, or the ones with
+// the given IDs. The zero value requests none. It has the fields of
+// models.GPURequest, which parses a workspace's spec.gpus, so one converts
+// to the other.
+type GPURequest struct {
+	All       bool
+	Count     int
+	DeviceIDs []string
+}
+
+// IsZero reports whether no GPUs are requested.
+func (g GPURequest) IsZero() bool {
+	return !g.All && g.Count == 0 && len(g.DeviceIDs) == 0
+}
+
+// String formats the request as the --gpus flag of docker and nerdctl takes
+// it, or "" for none.
+func (g GPURequest) String() string {
+	switch {
+	case g.All:
+		return "all"
+	case len(g.DeviceIDs) > 0:
+		return "device=" + strings.Join(g.DeviceIDs, ",")
+	case g.Count > 0:
+		return strconv.Itoa(g.Count)
+	}
+	return ""
+}
+
+// DeviceSupport is the device passthrough a runtime provides.
+type DeviceSupport struct {
+	Devices  bool   // Host devices (spec.devices)
+	GPUAll   bool   // All GPUs
+	GPUCount bool   // A number of GPUs
+	GPUIDs   bool   // GPUs by ID
+	Reason   string // Why something is missing, appended to errors
+}
+
+// DevicePassthrough is implemented by runtimes that can pass host devices
+// or GPUs into containers. Runtimes without it support neither.
+type DevicePassthrough interface {
+	DeviceSupport() DeviceSupport
+}
+
+// DeviceSupportOf returns the device passthrough runtime provides.
+func DeviceSupportOf(runtime ContainerRuntime) DeviceSupport {
+	if p, ok := runtime.(DevicePassthrough); ok {
+		return p.DeviceSupport()
+	}
+	return DeviceSupport{}
+}
+
+// Check returns an error naming the first of devices and gpus that s lacks,
+// on the runtime platform names.
+func (s DeviceSupport) Check(platform string, devices []DeviceMapping, gpus GPURequest) error {
+	if len(devices) > 0 && !s.Devices {
+		return s.unsupported(fmt.Sprintf("host devices (spec.devices) are not supported on %s", platform))
+	}
+	supported := true
+	switch {
+	case gpus.All:
+		supported = s.GPUAll
+	case len(gpus.DeviceIDs) > 0:
+		supported = s.GPUIDs
+	case gpus.Count > 0:
+		supported = s.GPUCount
+	}
+	if !supported {
+		return s.unsupported(fmt.Sprintf("spec.gpus %q is not supported on %s", gpus.String(), platform))
+	}
+	return nil
+}
+
+func (s DeviceSupport) unsupported(msg string) error {
+	if s.Reason != "" {
+		return fmt.Errorf("%s: %s", msg, s.Reason)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// noVMGPUs explains why containers in a macOS VM get no GPUs.
+const noVMGPUs = "containers on macOS run in a Linux VM without GPU access"
+
+// DeviceSupport reports host devices and GPUs of every kind, except on
+// macOS, where the Docker VM has no GPUs. Remote hosts run Linux.
+func (d *DockerRuntime) DeviceSupport() DeviceSupport {
+	if goruntime.GOOS == "darwin" && !d.platform.IsRemote() {
+		return DeviceSupport{Devices: true, Reason: noVMGPUs}
+	}
+	return DeviceSupport{Devices: true, GPUAll: true, GPUCount: true, GPUIDs: true}
+}
+
+// DeviceSupport reports host devices only: the Colima VM has no GPUs, and
+// the containerd API runtime doesn't request them.
+func (r *ContainerdRuntimeV2) DeviceSupport() DeviceSupport {
+	if r.platform.Type == PlatformColima {
+		return DeviceSupport{Devices: true, Reason: noVMGPUs}
+	}
+	return DeviceSupport{Devices: true, Reason: "use Docker or nerdctl for GPU workspaces"}
+}
+
+// DeviceSupport reports host devices and GPUs of every kind; nerdctl passes
+// them on the remote host.
+func (n *NerdctlSSHRuntime) DeviceSupport() DeviceSupport {
+	return DeviceSupport{Devices: true, GPUAll: true, GPUCount: true, GPUIDs: true}
+}
+
+// DeviceSupport reports GPUs by count, which the pod requests as
+// nvidia.com/gpu resources. Pods get no host devices.
+func (k *KubernetesRuntime) DeviceSupport() DeviceSupport {
+	return DeviceSupport{GPUCount: true, Reason: "Kubernetes schedules GPUs by count and has no host devices"}
+}
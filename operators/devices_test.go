@@ -0,0 +1,82 @@
+package operators
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGPURequest_String(t *testing.T) {
+	tests := []struct {
+		in   GPURequest
+		want string
+	}{
+		{GPURequest{}, ""},
+		{GPURequest{All: true}, "all"},
+		{GPURequest{Count: 2}, "2"},
+		{GPURequest{DeviceIDs: []string{"0", "GPU-3a1f"}}, "device=0,GPU-3a1f"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.in.String())
+		assert.Equal(t, tt.want == "", tt.in.IsZero(), tt.want)
+	}
+}
+
+func TestDeviceMapping_String(t *testing.T) {
+	assert.Equal(t, "/dev/fuse:/dev/fuse:rwm", DeviceMapping{HostPath: "/dev/fuse"}.String())
+	assert.Equal(t, "/dev/video0:/dev/cam:r", DeviceMapping{HostPath: "/dev/video0", ContainerPath: "/dev/cam", Permissions: "r"}.String())
+}
+
+func TestDeviceSupport_Check(t *testing.T) {
+	devices := []DeviceMapping{{HostPath: "/dev/fuse"}}
+	full := DeviceSupport{Devices: true, GPUAll: true, GPUCount: true, GPUIDs: true}
+	assert.NoError(t, full.Check("Docker", devices, GPURequest{All: true}))
+	assert.NoError(t, DeviceSupport{}.Check("Mock", nil, GPURequest{}), "nothing requested needs no support")
+
+	colima := DeviceSupport{Devices: true, Reason: noVMGPUs}
+	assert.NoError(t, colima.Check("Colima", devices, GPURequest{}))
+	err := colima.Check("Colima", nil, GPURequest{Count: 1})
+	require.Error(t, err)
+	assert.Equal(t, `spec.gpus "1" is not supported on Colima: `+noVMGPUs, err.Error())
+
+	kubernetes := (&KubernetesRuntime{}).DeviceSupport()
+	assert.NoError(t, kubernetes.Check("Kubernetes", nil, GPURequest{Count: 2}))
+	assert.ErrorContains(t, kubernetes.Check("Kubernetes", nil, GPURequest{All: true}), `spec.gpus "all" is not supported on Kubernetes`)
+	assert.ErrorContains(t, kubernetes.Check("Kubernetes", nil, GPURequest{DeviceIDs: []string{"0"}}), "device=0")
+	assert.ErrorContains(t, kubernetes.Check("Kubernetes", devices, GPURequest{}), "host devices (spec.devices) are not supported on Kubernetes")
+}
+
+func TestNerdctlSSHRuntime_StartWorkspace_Devices(t *testing.T) {
+	n, fake := newFakeNerdctlSSHRuntime(map[string]string{"run": "abc"})
+	_, err := n.StartWorkspace(context.Background(), StartOptions{
+		ImageName:     "dvm-dev-ml:1",
+		ContainerName: "dvm-ml-dev",
+		Devices:       []DeviceMapping{{HostPath: "/dev/fuse"}},
+		GPUs:          GPURequest{DeviceIDs: []string{"0", "1"}},
+	})
+	require.NoError(t, err)
+	run := fake.calls[len(fake.calls)-1]
+	assert.Subset(t, run, []string{"--device", "/dev/fuse:/dev/fuse:rwm", "--gpus", "device=0,1"})
+}
+
+func TestKubernetesRuntime_StartWorkspace_GPUs(t *testing.T) {
+	k, fake := newFakeKubernetesRuntime(KubernetesOptions{}, map[string]string{"get": `{"items": []}`})
+	_, err := k.StartWorkspace(context.Background(), StartOptions{ContainerName: "dvm-ml-dev", GPUs: GPURequest{Count: 2}})
+	require.NoError(t, err)
+
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(fake.stdin, &list))
+	container := list.Items[1]["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	assert.Equal(t, map[string]any{"nvidia.com/gpu": "2"}, container["resources"].(map[string]any)["limits"])
+
+	// Unsupported requests fail before anything is scheduled
+	k, fake = newFakeKubernetesRuntime(KubernetesOptions{}, nil)
+	_, err = k.StartWorkspace(context.Background(), StartOptions{ContainerName: "dvm-ml-dev", Devices: []DeviceMapping{{HostPath: "/dev/fuse"}}})
+	assert.Error(t, err)
+	assert.Empty(t, fake.calls)
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/rmkohlman/MaestroSDK/render"
@@ -15,8 +16,11 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/moby/go-archive"
 	"github.com/moby/term"
 )
@@ -41,21 +45,21 @@ func NewDockerRuntime(platform *Platform) (*DockerRuntime, error) {
 		return nil, fmt.Errorf("platform cannot be nil")
 	}
 
-	// Create Docker client using the platform socket directly.
+	// Create Docker client using the platform socket (or SSH host) directly.
 	// This avoids os.Setenv("DOCKER_HOST") which mutates process-wide state,
 	// is racy under concurrency, and pollutes tests.
-	cli, err := client.NewClientWithOpts(
-		client.WithHost(dockerHost(platform)),
-		client.WithAPIVersionNegotiation(),
-	)
+	cli, err := NewDockerClient(platform)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
 	}
 
 	// Verify connection
 	if _, err := cli.Ping(context.Background()); err != nil {
-		return nil, fmt.Errorf("failed to connect to %s: %w\n%s",
-			platform.Name, err, platform.GetStartHint())
+		return nil, &ErrRuntimeUnavailable{
+			Platform: platform.Name,
+			Hint:     platform.GetStartHint(),
+			Err:      err,
+		}
 	}
 
 	return &DockerRuntime{
@@ -113,10 +117,18 @@ func (d *DockerRuntime) BuildImage(ctx context.Context, opts BuildOptions) error
 // 3. Container exists but uses a different image -> remove it and create new one
 // 4. Container doesn't exist -> create and start it
 func (d *DockerRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (string, error) {
-	// SECURITY: Validate all mount source paths before passing to container runtime
-	if err := validateStartOptionsMounts(opts); err != nil {
+	// SECURITY: Validate all mount source paths before passing to container runtime.
+	// Paths on remote hosts cannot be checked on disk.
+	validateMounts := validateStartOptionsMounts
+	if d.platform.IsRemote() {
+		validateMounts = validateRemoteStartOptionsMounts
+	}
+	if err := validateMounts(opts); err != nil {
 		return "", fmt.Errorf("invalid mount configuration: %w", err)
 	}
+	if err := d.DeviceSupport().Check(d.GetPlatformName(), opts.Devices, opts.GPUs); err != nil {
+		return "", err
+	}
 
 	// Determine container name using helper
 	containerName := opts.ComputeContainerName()
@@ -156,6 +168,11 @@ func (d *DockerRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (
 
 			// Fall through to create new container
 		} else {
+			// Containers created before their app network existed join it
+			if err := d.joinNetwork(ctx, existing, opts); err != nil {
+				return "", err
+			}
+
 			// Same image - check if it's running
 			if existing.State == "running" {
 				return existing.ID[:12], nil
@@ -259,6 +276,37 @@ func (d *DockerRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (
 		hostConfig.NetworkMode = container.NetworkMode(opts.NetworkMode)
 	}
 
+	// Published ports (loopback only)
+	if len(opts.Ports) > 0 {
+		containerConfig.ExposedPorts = nat.PortSet{}
+		hostConfig.PortBindings = nat.PortMap{}
+		for _, p := range opts.Ports {
+			port := nat.Port(fmt.Sprintf("%d/tcp", p))
+			containerConfig.ExposedPorts[port] = struct{}{}
+			hostConfig.PortBindings[port] = []nat.PortBinding{{HostIP: "127.0.0.1", HostPort: fmt.Sprint(p)}}
+		}
+	}
+
+	// Host devices and GPUs
+	for _, device := range opts.Devices {
+		hostConfig.Resources.Devices = append(hostConfig.Resources.Devices, container.DeviceMapping{
+			PathOnHost:        device.HostPath,
+			PathInContainer:   device.containerPath(),
+			CgroupPermissions: device.permissions(),
+		})
+	}
+	if !opts.GPUs.IsZero() {
+		request := container.DeviceRequest{
+			Capabilities: [][]string{{"gpu"}},
+			Count:        opts.GPUs.Count,
+			DeviceIDs:    opts.GPUs.DeviceIDs,
+		}
+		if opts.GPUs.All {
+			request.Count = -1
+		}
+		hostConfig.Resources.DeviceRequests = []container.DeviceRequest{request}
+	}
+
 	// Resource limits (issue #92)
 	if opts.CPUs > 0 {
 		// Docker uses NanoCPUs (1 CPU = 1e9 NanoCPUs)
@@ -272,12 +320,22 @@ func (d *DockerRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (
 		hostConfig.Resources.Memory = memBytes
 	}
 
+	// DNS aliases on a custom network
+	var networkingConfig *network.NetworkingConfig
+	if opts.NetworkMode != "" && len(opts.NetworkAliases) > 0 {
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				opts.NetworkMode: {Aliases: opts.NetworkAliases},
+			},
+		}
+	}
+
 	// Create container
 	resp, err := d.client.ContainerCreate(
 		ctx,
 		containerConfig,
 		hostConfig,
-		nil,
+		networkingConfig,
 		nil,
 		containerName,
 	)
@@ -295,18 +353,16 @@ func (d *DockerRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (
 
 // AttachToWorkspace attaches an interactive terminal to a running workspace
 func (d *DockerRuntime) AttachToWorkspace(ctx context.Context, opts AttachOptions) error {
-	render.Info("Attaching to workspace (press Ctrl+D to exit)...")
-
-	// Build shell command with options
-	shell := opts.Shell
-	if shell == "" {
-		shell = "/bin/zsh"
+	isCommand := len(opts.Command) > 0
+	if !isCommand {
+		render.Info("Attaching to workspace (press Ctrl+D to exit)...")
 	}
 
-	cmd := []string{shell}
-	if opts.LoginShell {
-		cmd = append(cmd, "-l")
-	}
+	cmd := opts.ComputeCommand()
+
+	// A TTY is always used for interactive shells; commands only get one when
+	// stdin is a terminal so output can be piped (dvm exec ws -- ls | grep x).
+	tty := !isCommand || term.IsTerminal(os.Stdin.Fd())
 
 	// Build environment variables
 	var env []string
@@ -325,14 +381,14 @@ func (d *DockerRuntime) AttachToWorkspace(ctx context.Context, opts AttachOption
 	}
 	userStr := fmt.Sprintf("%d:%d", uid, gid)
 
-	// Execute shell in the container with interactive TTY
 	execConfig := container.ExecOptions{
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
-		Tty:          true,
+		Tty:          tty,
 		Cmd:          cmd,
 		Env:          env,
+		WorkingDir:   opts.WorkingDir,
 		User:         userStr, // Defense-in-depth: explicitly set user for exec sessions
 	}
 
@@ -343,56 +399,88 @@ func (d *DockerRuntime) AttachToWorkspace(ctx context.Context, opts AttachOption
 
 	// Attach to the exec
 	attachResp, err := d.client.ContainerExecAttach(ctx, execResp.ID, container.ExecStartOptions{
-		Tty: true,
+		Tty: tty,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to attach: %w", err)
 	}
 	defer attachResp.Close()
 
-	// Put terminal in raw mode
-	oldState, err := term.SetRawTerminal(os.Stdin.Fd())
-	if err != nil {
-		return fmt.Errorf("failed to set raw terminal: %w", err)
-	}
-	defer term.RestoreTerminal(os.Stdin.Fd(), oldState)
-
-	// Set initial terminal size
-	if err := d.resizeExecTTY(ctx, execResp.ID); err != nil {
-		// Non-fatal: log and continue
-		render.WarningfToStderr("failed to set terminal size: %v", err)
-	}
+	if tty {
+		// Put terminal in raw mode
+		oldState, err := term.SetRawTerminal(os.Stdin.Fd())
+		if err != nil {
+			return fmt.Errorf("failed to set raw terminal: %w", err)
+		}
+		defer term.RestoreTerminal(os.Stdin.Fd(), oldState)
 
-	// Monitor for terminal resize signals (SIGWINCH)
-	sigchan := make(chan os.Signal, 1)
-	signal.Notify(sigchan, syscall.SIGWINCH)
-	go func() {
-		for range sigchan {
-			d.resizeExecTTY(ctx, execResp.ID)
+		// Set initial terminal size
+		if err := d.resizeExecTTY(ctx, execResp.ID); err != nil {
+			// Non-fatal: log and continue
+			render.WarningfToStderr("failed to set terminal size: %v", err)
 		}
-	}()
-	defer func() {
-		signal.Stop(sigchan)
-		close(sigchan)
-	}()
+
+		// Monitor for terminal resize signals (SIGWINCH)
+		sigchan := make(chan os.Signal, 1)
+		signal.Notify(sigchan, syscall.SIGWINCH)
+		go func() {
+			for range sigchan {
+				d.resizeExecTTY(ctx, execResp.ID)
+			}
+		}()
+		defer func() {
+			signal.Stop(sigchan)
+			close(sigchan)
+		}()
+	}
 
 	// Stream I/O
-	errChan := make(chan error, 2)
+	outputDone := make(chan error, 1)
 
-	// Copy from container to stdout/stderr
+	// Copy from container to stdout/stderr. Without a TTY the stream is
+	// multiplexed and must be split back into stdout and stderr.
 	go func() {
-		_, err := io.Copy(os.Stdout, attachResp.Reader)
-		errChan <- err
+		var err error
+		if tty {
+			_, err = io.Copy(os.Stdout, attachResp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, attachResp.Reader)
+		}
+		outputDone <- err
 	}()
 
-	// Copy from stdin to container
+	// Copy from stdin to container, closing the write side on EOF so
+	// commands reading stdin terminate.
+	inputDone := make(chan error, 1)
 	go func() {
 		_, err := io.Copy(attachResp.Conn, os.Stdin)
-		errChan <- err
+		if !tty {
+			attachResp.CloseWrite()
+		}
+		inputDone <- err
 	}()
 
-	// Wait for I/O to finish
-	<-errChan
+	// Wait for I/O to finish. A TTY session ends when either side closes;
+	// a command ends when its output stream does.
+	if tty {
+		select {
+		case <-outputDone:
+		case <-inputDone:
+		}
+	} else {
+		<-outputDone
+	}
+
+	if isCommand {
+		inspect, err := d.client.ContainerExecInspect(ctx, execResp.ID)
+		if err != nil {
+			return fmt.Errorf("failed to inspect exec: %w", err)
+		}
+		if inspect.ExitCode != 0 {
+			return &ExitError{Code: inspect.ExitCode}
+		}
+		return nil
+	}
 
 	render.Blank()
 	render.Success("Detached from workspace")
@@ -415,6 +503,50 @@ func (d *DockerRuntime) resizeExecTTY(ctx context.Context, execID string) error
 	return nil
 }
 
+// ExecInWorkspace runs a command in a workspace container without a TTY,
+// streaming opts.Stdin to it and its stdout to opts.Stdout.
+func (d *DockerRuntime) ExecInWorkspace(ctx context.Context, opts ExecOptions) error {
+	execResp, err := d.client.ContainerExecCreate(ctx, opts.WorkspaceID, container.ExecOptions{
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          opts.Command,
+		User:         execUser(opts.UID, opts.GID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create exec: %w", err)
+	}
+	attachResp, err := d.client.ContainerExecAttach(ctx, execResp.ID, container.ExecStartOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to attach: %w", err)
+	}
+	defer attachResp.Close()
+
+	if opts.Stdin != nil {
+		go func() {
+			io.Copy(attachResp.Conn, opts.Stdin)
+			attachResp.CloseWrite()
+		}()
+	}
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	var stderr strings.Builder
+	if _, err := stdcopy.StdCopy(stdout, &stderr, attachResp.Reader); err != nil {
+		return fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspect, err := d.client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	if inspect.ExitCode != 0 {
+		return fmt.Errorf("%s exited with status %d: %s", opts.Command[0], inspect.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 // StopWorkspace stops a running workspace
 func (d *DockerRuntime) StopWorkspace(ctx context.Context, workspaceID string) error {
 	render.Progress("Stopping workspace...")
@@ -469,7 +601,7 @@ func (d *DockerRuntime) ListWorkspaces(ctx context.Context) ([]WorkspaceInfo, er
 	containers, err := d.client.ContainerList(ctx, container.ListOptions{
 		All: true, // Include stopped containers
 		Filters: filters.NewArgs(
-			filters.Arg("label", "io.devopsmaestro.managed=true"),
+			filters.Arg("label", LabelManaged+"=true"),
 		),
 	})
 	if err != nil {
@@ -492,11 +624,13 @@ func (d *DockerRuntime) ListWorkspaces(ctx context.Context) ([]WorkspaceInfo, er
 			Name:      name,
 			Status:    c.Status,
 			Image:     c.Image,
-			App:       c.Labels["io.devopsmaestro.app"],
-			Workspace: c.Labels["io.devopsmaestro.workspace"],
-			Ecosystem: c.Labels["io.devopsmaestro.ecosystem"],
-			Domain:    c.Labels["io.devopsmaestro.domain"],
-			System:    c.Labels["io.devopsmaestro.system"],
+			ImageID:   c.ImageID,
+			App:       c.Labels[LabelApp],
+			Workspace: c.Labels[LabelWorkspace],
+			Ecosystem: c.Labels[LabelEcosystem],
+			Domain:    c.Labels[LabelDomain],
+			System:    c.Labels[LabelSystem],
+			Version:   c.Labels[LabelVersion],
 			Labels:    c.Labels,
 		})
 	}
@@ -510,7 +644,7 @@ func (d *DockerRuntime) FindWorkspace(ctx context.Context, name string) (*Worksp
 	containers, err := d.client.ContainerList(ctx, container.ListOptions{
 		All: true,
 		Filters: filters.NewArgs(
-			filters.Arg("label", "io.devopsmaestro.managed=true"),
+			filters.Arg("label", LabelManaged+"=true"),
 			filters.Arg("name", name),
 		),
 	})
@@ -536,11 +670,13 @@ func (d *DockerRuntime) FindWorkspace(ctx context.Context, name string) (*Worksp
 		Name:      containerName,
 		Status:    c.Status,
 		Image:     c.Image,
-		App:       c.Labels["io.devopsmaestro.app"],
-		Workspace: c.Labels["io.devopsmaestro.workspace"],
-		Ecosystem: c.Labels["io.devopsmaestro.ecosystem"],
-		Domain:    c.Labels["io.devopsmaestro.domain"],
-		System:    c.Labels["io.devopsmaestro.system"],
+		ImageID:   c.ImageID,
+		App:       c.Labels[LabelApp],
+		Workspace: c.Labels[LabelWorkspace],
+		Ecosystem: c.Labels[LabelEcosystem],
+		Domain:    c.Labels[LabelDomain],
+		System:    c.Labels[LabelSystem],
+		Version:   c.Labels[LabelVersion],
 		Labels:    c.Labels,
 	}, nil
 }
@@ -550,7 +686,7 @@ func (d *DockerRuntime) StopAllWorkspaces(ctx context.Context) (int, error) {
 	// List running DVM containers
 	containers, err := d.client.ContainerList(ctx, container.ListOptions{
 		Filters: filters.NewArgs(
-			filters.Arg("label", "io.devopsmaestro.managed=true"),
+			filters.Arg("label", LabelManaged+"=true"),
 			filters.Arg("status", "running"),
 		),
 	})
@@ -635,6 +771,15 @@ func (d *DockerRuntime) ImageExists(ctx context.Context, imageName string) (bool
 	return true, nil
 }
 
+// ImageDigest returns the ID of a local image.
+func (d *DockerRuntime) ImageDigest(ctx context.Context, imageName string) (string, error) {
+	inspect, _, err := d.client.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image %s: %w", imageName, err)
+	}
+	return inspect.ID, nil
+}
+
 // Helper function to convert map to env slice
 func envMapToSlice(envMap map[string]string) []string {
 	var envSlice []string
@@ -644,30 +789,10 @@ func envMapToSlice(envMap map[string]string) []string {
 	return envSlice
 }
 
-// buildDVMLabels creates the standard DVM labels with ecosystem and domain support.
+// buildDVMLabels creates the standard DVM labels (see ResourceLabels).
 // If opts.Labels is provided, those labels are merged (custom labels take precedence).
 func buildDVMLabels(opts StartOptions) map[string]string {
-	labels := map[string]string{
-		"io.devopsmaestro.managed":   "true",
-		"io.devopsmaestro.namespace": "devopsmaestro",
-		"io.devopsmaestro.app":       opts.AppName,
-		"io.devopsmaestro.workspace": opts.WorkspaceName,
-	}
-
-	// Add ecosystem label if provided
-	if opts.EcosystemName != "" {
-		labels["io.devopsmaestro.ecosystem"] = opts.EcosystemName
-	}
-
-	// Add domain label if provided
-	if opts.DomainName != "" {
-		labels["io.devopsmaestro.domain"] = opts.DomainName
-	}
-
-	// Add system label if provided
-	if opts.SystemName != "" {
-		labels["io.devopsmaestro.system"] = opts.SystemName
-	}
+	labels := ResourceLabels(opts.LabelScope())
 
 	// Merge custom labels (sandbox labels, etc.)
 	for k, v := range opts.Labels {
@@ -0,0 +1,518 @@
+package operators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moby/term"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// defaultKubernetesStorageSize is the size of a workspace's /workspace volume.
+const defaultKubernetesStorageSize = "10Gi"
+
+// KubernetesOptions configures where a KubernetesRuntime schedules workspaces.
+// Empty fields use kubectl's defaults.
+type KubernetesOptions struct {
+	Context      string // kubeconfig context
+	Namespace    string // Namespace for pods and volumes
+	StorageClass string // Storage class of the /workspace volume
+	StorageSize  string // Size of the /workspace volume (default: 10Gi)
+}
+
+// KubernetesRuntime implements ContainerRuntime by running each workspace as
+// a pod on a Kubernetes cluster, through kubectl. The pod mounts a persistent
+// volume at /workspace, which is seeded with the app's files on first start
+// and survives the pod, so stopping a workspace deletes only its pod.
+//
+// Images are not built on the cluster: the workspace image is built with the
+// local runtime and must be pullable by the cluster.
+type KubernetesRuntime struct {
+	opts KubernetesOptions
+
+	// kubectl runs kubectl with the given stdin and returns its stdout.
+	kubectl func(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error)
+}
+
+// NewKubernetesRuntime creates a KubernetesRuntime. It fails when kubectl is
+// not installed.
+func NewKubernetesRuntime(opts KubernetesOptions) (*KubernetesRuntime, error) {
+	if _, err := exec.LookPath("kubectl"); err != nil {
+		return nil, &ErrRuntimeUnavailable{
+			Platform: "Kubernetes",
+			Hint:     "Install kubectl: https://kubernetes.io/docs/tasks/tools/",
+			Err:      err,
+		}
+	}
+	k := &KubernetesRuntime{opts: opts}
+	k.kubectl = k.runKubectl
+	return k, nil
+}
+
+// args prefixes kubectl arguments with the configured context and namespace.
+func (k *KubernetesRuntime) args(args ...string) []string {
+	var global []string
+	if k.opts.Context != "" {
+		global = append(global, "--context", k.opts.Context)
+	}
+	if k.opts.Namespace != "" {
+		global = append(global, "--namespace", k.opts.Namespace)
+	}
+	return append(global, args...)
+}
+
+// runKubectl runs kubectl and includes its stderr in the error on failure.
+func (k *KubernetesRuntime) runKubectl(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "kubectl", k.args(args...)...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("kubectl %s failed: %w\n%s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// BuildImage is not supported: images are built with the local runtime.
+func (k *KubernetesRuntime) BuildImage(ctx context.Context, opts BuildOptions) error {
+	return fmt.Errorf("the kubernetes runtime does not build images: build with the local runtime and push the image to a registry the cluster can pull from")
+}
+
+// StartWorkspace schedules the workspace pod and its volume, waits for the pod
+// to be ready, and seeds an empty /workspace with the files at AppPath.
+// Returns the pod name.
+func (k *KubernetesRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (string, error) {
+	name := opts.ContainerName
+	if name == "" {
+		name = opts.WorkspaceName
+	}
+	if err := k.DeviceSupport().Check(k.GetPlatformName(), opts.Devices, opts.GPUs); err != nil {
+		return "", err
+	}
+
+	existing, err := k.FindWorkspace(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil && existing.Status != "running" && existing.Status != "pending" {
+		// Finished pods cannot be restarted; replace them
+		if err := k.RemoveContainer(ctx, name, false); err != nil {
+			return "", err
+		}
+		existing = nil
+	}
+
+	if existing == nil {
+		for _, warning := range unsupportedKubernetesOptions(opts) {
+			render.Warning(warning)
+		}
+		manifest, err := k.manifest(name, opts)
+		if err != nil {
+			return "", err
+		}
+		if _, err := k.kubectl(ctx, bytes.NewReader(manifest), "apply", "-f", "-"); err != nil {
+			return "", err
+		}
+	}
+
+	render.Progress(fmt.Sprintf("Waiting for pod '%s' to be ready...", name))
+	if _, err := k.kubectl(ctx, nil, "wait", "--for=condition=Ready", "pod/"+name, "--timeout=5m"); err != nil {
+		return "", err
+	}
+
+	if opts.AppPath != "" {
+		if err := k.seedWorkspace(ctx, name, opts.AppPath); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+// seedWorkspace copies the app's files into the pod's /workspace volume when
+// it is empty, i.e. on the workspace's first start.
+func (k *KubernetesRuntime) seedWorkspace(ctx context.Context, name, appPath string) error {
+	out, err := k.kubectl(ctx, nil, "exec", name, "-c", "workspace", "--", "ls", "-A", "/workspace")
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(out)) > 0 {
+		return nil
+	}
+	render.Progress(fmt.Sprintf("Copying %s to the workspace volume...", appPath))
+	_, err = k.kubectl(ctx, nil, "cp", strings.TrimSuffix(appPath, "/")+"/.", name+":/workspace", "-c", "workspace")
+	return err
+}
+
+// unsupportedKubernetesOptions returns warnings for start options that only
+// apply to local containers.
+func unsupportedKubernetesOptions(opts StartOptions) []string {
+	var warnings []string
+	if opts.NetworkMode != "" {
+		warnings = append(warnings, fmt.Sprintf("Ignoring network mode '%s' on Kubernetes", opts.NetworkMode))
+	}
+	if opts.SSHAgentForwarding {
+		warnings = append(warnings, "SSH agent forwarding is not available on Kubernetes")
+	}
+	if opts.GitCredentialMounting {
+		warnings = append(warnings, "Git credential mounting is not available on Kubernetes")
+	}
+	for _, m := range opts.Mounts {
+		warnings = append(warnings, fmt.Sprintf("Ignoring host mount %s on Kubernetes", m.Destination))
+	}
+	if len(opts.Ports) > 0 {
+		warnings = append(warnings, "Ignoring published ports on Kubernetes; use kubectl port-forward")
+	}
+	return warnings
+}
+
+// volumeName returns the name of the persistent volume claim of a workspace pod.
+func volumeName(podName string) string {
+	return podName + "-workspace"
+}
+
+// manifest returns the workspace's PersistentVolumeClaim and Pod as a
+// kubectl List.
+func (k *KubernetesRuntime) manifest(name string, opts StartOptions) ([]byte, error) {
+	labels := map[string]string{}
+	for key, value := range buildDVMLabels(opts) {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+
+	storageSize := k.opts.StorageSize
+	if storageSize == "" {
+		storageSize = defaultKubernetesStorageSize
+	}
+	pvcSpec := map[string]any{
+		"accessModes": []string{"ReadWriteOnce"},
+		"resources":   map[string]any{"requests": map[string]string{"storage": storageSize}},
+	}
+	if k.opts.StorageClass != "" {
+		pvcSpec["storageClassName"] = k.opts.StorageClass
+	}
+	pvc := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata":   map[string]any{"name": volumeName(name), "labels": labels},
+		"spec":       pvcSpec,
+	}
+
+	command := opts.Command
+	if len(command) == 0 {
+		command = []string{"/bin/sleep", "infinity"}
+	}
+	workingDir := opts.WorkingDir
+	if workingDir == "" {
+		workingDir = "/workspace"
+	}
+	uid, gid := opts.UID, opts.GID
+	if uid == 0 {
+		uid = 1000
+	}
+	if gid == 0 {
+		gid = 1000
+	}
+
+	keys := make([]string, 0, len(opts.Env))
+	for key := range opts.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	env := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, map[string]string{"name": key, "value": opts.Env[key]})
+	}
+
+	container := map[string]any{
+		"name":         "workspace",
+		"image":        opts.ImageName,
+		"command":      command,
+		"workingDir":   workingDir,
+		"env":          env,
+		"volumeMounts": []map[string]string{{"name": "workspace", "mountPath": "/workspace"}},
+	}
+	limits := map[string]string{}
+	if opts.CPUs > 0 {
+		limits["cpu"] = strconv.FormatFloat(opts.CPUs, 'f', -1, 64)
+	}
+	if opts.Memory != "" {
+		memory, err := ParseMemoryString(opts.Memory)
+		if err != nil {
+			return nil, err
+		}
+		limits["memory"] = strconv.FormatInt(memory, 10)
+	}
+	if opts.GPUs.Count > 0 {
+		limits["nvidia.com/gpu"] = strconv.Itoa(opts.GPUs.Count)
+	}
+	if len(limits) > 0 {
+		container["resources"] = map[string]any{"limits": limits}
+	}
+
+	pod := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]any{"name": name, "labels": labels},
+		"spec": map[string]any{
+			"securityContext": map[string]int{"runAsUser": uid, "runAsGroup": gid, "fsGroup": gid},
+			"containers":      []any{container},
+			"volumes": []any{map[string]any{
+				"name":                  "workspace",
+				"persistentVolumeClaim": map[string]string{"claimName": volumeName(name)},
+			}},
+		},
+	}
+
+	return json.Marshal(map[string]any{"apiVersion": "v1", "kind": "List", "items": []any{pvc, pod}})
+}
+
+// AttachToWorkspace runs a shell or command in the workspace pod with
+// kubectl exec, connected to the terminal.
+func (k *KubernetesRuntime) AttachToWorkspace(ctx context.Context, opts AttachOptions) error {
+	isCommand := len(opts.Command) > 0
+	if !isCommand {
+		render.Info("Attaching to workspace (press Ctrl+D to exit)...")
+	}
+
+	cmd := exec.CommandContext(ctx, "kubectl", k.execArgs(opts, !isCommand || term.IsTerminal(os.Stdin.Fd()))...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if isCommand {
+		if errors.As(err, &exitErr) {
+			return &ExitError{Code: exitErr.ExitCode()}
+		}
+		return err
+	}
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to attach: %w", err)
+	}
+
+	render.Blank()
+	render.Success("Detached from workspace")
+	return nil
+}
+
+// execArgs returns the kubectl exec arguments for an attach session. The pod
+// already runs as the workspace user, so UID and GID need no exec option.
+func (k *KubernetesRuntime) execArgs(opts AttachOptions, tty bool) []string {
+	args := []string{"exec", "-i"}
+	if tty {
+		args = append(args, "-t")
+	}
+	args = append(args, opts.WorkspaceID, "-c", "workspace", "--", "env")
+
+	keys := make([]string, 0, len(opts.Env))
+	for key := range opts.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, key+"="+opts.Env[key])
+	}
+
+	if opts.WorkingDir != "" {
+		args = append(args, "sh", "-c", `cd "$0" && exec "$@"`, opts.WorkingDir)
+	}
+	return k.args(append(args, opts.ComputeCommand()...)...)
+}
+
+// StopWorkspace deletes the workspace pod. Its volume is kept, so the next
+// start resumes with the same /workspace.
+func (k *KubernetesRuntime) StopWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := k.kubectl(ctx, nil, "delete", "pod", workspaceID, "--ignore-not-found")
+	return err
+}
+
+// GetWorkspaceStatus returns the status of a workspace pod: running,
+// pending, succeeded, failed, or stopped when there is no pod.
+func (k *KubernetesRuntime) GetWorkspaceStatus(ctx context.Context, workspaceID string) (string, error) {
+	info, err := k.FindWorkspace(ctx, workspaceID)
+	if err != nil {
+		return "unknown", err
+	}
+	if info == nil {
+		return "stopped", nil
+	}
+	return info.Status, nil
+}
+
+// GetRuntimeType returns "kubernetes"
+func (k *KubernetesRuntime) GetRuntimeType() string {
+	return string(RuntimeKubernetes)
+}
+
+// GetPlatformName returns "Kubernetes", with the kubeconfig context if set.
+func (k *KubernetesRuntime) GetPlatformName() string {
+	if k.opts.Context != "" {
+		return fmt.Sprintf("Kubernetes (%s)", k.opts.Context)
+	}
+	return "Kubernetes"
+}
+
+// podList is the part of `kubectl get pods -o json` the runtime reads.
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name   string            `json:"name"`
+			UID    string            `json:"uid"`
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			Containers []struct {
+				Image string `json:"image"`
+			} `json:"containers"`
+		} `json:"spec"`
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// listPods returns the pods matching the label selector as ContainerInfo.
+func (k *KubernetesRuntime) listPods(ctx context.Context, labels map[string]string) ([]ContainerInfo, error) {
+	selectors := make([]string, 0, len(labels))
+	for key, value := range labels {
+		selectors = append(selectors, key+"="+value)
+	}
+	sort.Strings(selectors)
+
+	out, err := k.kubectl(ctx, nil, "get", "pods", "-l", strings.Join(selectors, ","), "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	var pods podList
+	if err := json.Unmarshal(out, &pods); err != nil {
+		return nil, fmt.Errorf("failed to parse kubectl output: %w", err)
+	}
+
+	infos := make([]ContainerInfo, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		info := ContainerInfo{
+			ID:     pod.Metadata.UID,
+			Name:   pod.Metadata.Name,
+			Status: strings.ToLower(pod.Status.Phase),
+			Labels: pod.Metadata.Labels,
+		}
+		if len(pod.Spec.Containers) > 0 {
+			info.Image = pod.Spec.Containers[0].Image
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// ListWorkspaces lists all DVM-managed workspace pods
+func (k *KubernetesRuntime) ListWorkspaces(ctx context.Context) ([]WorkspaceInfo, error) {
+	pods, err := k.listPods(ctx, map[string]string{LabelManaged: "true"})
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]WorkspaceInfo, 0, len(pods))
+	for _, pod := range pods {
+		workspaces = append(workspaces, WorkspaceInfo{
+			ID:        pod.ID,
+			Name:      pod.Name,
+			Status:    pod.Status,
+			Image:     pod.Image,
+			App:       pod.Labels[LabelApp],
+			Workspace: pod.Labels[LabelWorkspace],
+			Ecosystem: pod.Labels[LabelEcosystem],
+			Domain:    pod.Labels[LabelDomain],
+			System:    pod.Labels[LabelSystem],
+			Version:   pod.Labels[LabelVersion],
+			Labels:    pod.Labels,
+		})
+	}
+	return workspaces, nil
+}
+
+// FindWorkspace finds a workspace pod by name. Returns nil if not found.
+func (k *KubernetesRuntime) FindWorkspace(ctx context.Context, name string) (*WorkspaceInfo, error) {
+	workspaces, err := k.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		if ws.Name == name {
+			return &ws, nil
+		}
+	}
+	return nil, nil
+}
+
+// StopAllWorkspaces deletes all DVM-managed workspace pods that are running
+func (k *KubernetesRuntime) StopAllWorkspaces(ctx context.Context) (int, error) {
+	workspaces, err := k.ListWorkspaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stopped := 0
+	for _, ws := range workspaces {
+		if ws.Status != "running" {
+			continue
+		}
+		if err := k.StopWorkspace(ctx, ws.Name); err != nil {
+			render.Warning(fmt.Sprintf("Failed to stop %s: %v", ws.Name, err))
+			continue
+		}
+		stopped++
+	}
+	return stopped, nil
+}
+
+// RemoveContainer deletes a pod. Its volume is kept.
+func (k *KubernetesRuntime) RemoveContainer(ctx context.Context, containerID string, force bool) error {
+	args := []string{"delete", "pod", containerID, "--ignore-not-found"}
+	if force {
+		args = append(args, "--grace-period=0", "--force")
+	}
+	_, err := k.kubectl(ctx, nil, args...)
+	return err
+}
+
+// RemoveImage is not supported: images live in the cluster's registry.
+func (k *KubernetesRuntime) RemoveImage(ctx context.Context, imageID string) error {
+	return fmt.Errorf("the kubernetes runtime does not manage images")
+}
+
+// ListContainers lists pods matching the given labels
+func (k *KubernetesRuntime) ListContainers(ctx context.Context, labels map[string]string) ([]ContainerInfo, error) {
+	return k.listPods(ctx, labels)
+}
+
+// ImageExists reports true: the cluster pulls the image when the pod starts,
+// and a missing image surfaces as a pod that never becomes ready.
+func (k *KubernetesRuntime) ImageExists(ctx context.Context, imageName string) (bool, error) {
+	return true, nil
+}
+
+// PushImage tags a local image as target and pushes it with the platform's
+// container CLI, so a cluster can pull it.
+func PushImage(ctx context.Context, platform *Platform, image, target string) error {
+	for _, args := range [][]string{{"tag", image, target}, {"push", target}} {
+		subcommand := args[0]
+		if platform.Type == PlatformColima && platform.IsContainerd() {
+			args = append([]string{"--address", platform.SocketPath}, args...)
+		}
+		cmd := platformCommand(ctx, platform, args...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to %s %s: %w\n%s", subcommand, target, err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package operators
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKubectl records kubectl invocations and answers them from a table
+// keyed by the first argument.
+type fakeKubectl struct {
+	calls    [][]string
+	stdin    []byte
+	response map[string]string
+}
+
+func (f *fakeKubectl) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	if stdin != nil {
+		f.stdin, _ = io.ReadAll(stdin)
+	}
+	return []byte(f.response[args[0]]), nil
+}
+
+func newFakeKubernetesRuntime(opts KubernetesOptions, response map[string]string) (*KubernetesRuntime, *fakeKubectl) {
+	fake := &fakeKubectl{response: response}
+	return &KubernetesRuntime{opts: opts, kubectl: fake.run}, fake
+}
+
+const testPodList = `{"items": [
+  {"metadata": {"name": "dvm-acme-api-dev", "uid": "u1", "labels": {"io.devopsmaestro.managed": "true", "io.devopsmaestro.app": "api", "io.devopsmaestro.workspace": "dev"}},
+   "spec": {"containers": [{"image": "registry.example.com/dvm-dev-api:1"}]},
+   "status": {"phase": "Running"}},
+  {"metadata": {"name": "dvm-acme-web-dev", "uid": "u2", "labels": {"io.devopsmaestro.managed": "true"}},
+   "spec": {"containers": [{"image": "dvm-dev-web:1"}]},
+   "status": {"phase": "Succeeded"}}
+]}`
+
+func TestKubernetesRuntime_Args(t *testing.T) {
+	k := &KubernetesRuntime{opts: KubernetesOptions{Context: "shared", Namespace: "dev"}}
+	assert.Equal(t, []string{"--context", "shared", "--namespace", "dev", "get", "pods"}, k.args("get", "pods"))
+	assert.Equal(t, "Kubernetes (shared)", k.GetPlatformName())
+	assert.Equal(t, "kubernetes", k.GetRuntimeType())
+}
+
+func TestKubernetesRuntime_ListAndFind(t *testing.T) {
+	k, fake := newFakeKubernetesRuntime(KubernetesOptions{}, map[string]string{"get": testPodList})
+
+	workspaces, err := k.ListWorkspaces(context.Background())
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "running", workspaces[0].Status)
+	assert.Equal(t, "api", workspaces[0].App)
+	assert.Equal(t, "dev", workspaces[0].Workspace)
+	assert.Equal(t, "succeeded", workspaces[1].Status)
+	assert.Equal(t, []string{"get", "pods", "-l", "io.devopsmaestro.managed=true", "-o", "json"}, fake.calls[0])
+
+	found, err := k.FindWorkspace(context.Background(), "dvm-acme-api-dev")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, "u1", found.ID)
+
+	status, err := k.GetWorkspaceStatus(context.Background(), "dvm-acme-missing-dev")
+	require.NoError(t, err)
+	assert.Equal(t, "stopped", status)
+}
+
+func TestKubernetesRuntime_StartWorkspace(t *testing.T) {
+	k, fake := newFakeKubernetesRuntime(
+		KubernetesOptions{StorageClass: "fast"},
+		map[string]string{"get": `{"items": []}`, "exec": ""},
+	)
+
+	name, err := k.StartWorkspace(context.Background(), StartOptions{
+		ImageName:     "registry.example.com/dvm-dev-api:1",
+		WorkspaceName: "dev",
+		ContainerName: "dvm-acme-api-dev",
+		AppName:       "api",
+		AppPath:       "/src/api/",
+		Env:           map[string]string{"DATABASE_URL": "postgres://db"},
+		CPUs:          1.5,
+		Memory:        "2g",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dvm-acme-api-dev", name)
+
+	var ops []string
+	for _, call := range fake.calls {
+		ops = append(ops, call[0])
+	}
+	assert.Equal(t, []string{"get", "apply", "wait", "exec", "cp"}, ops)
+	assert.Equal(t, []string{"cp", "/src/api/.", "dvm-acme-api-dev:/workspace", "-c", "workspace"}, fake.calls[4])
+
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(fake.stdin, &list))
+	require.Len(t, list.Items, 2)
+	pvc, pod := list.Items[0], list.Items[1]
+	assert.Equal(t, "PersistentVolumeClaim", pvc["kind"])
+	assert.Equal(t, "fast", pvc["spec"].(map[string]any)["storageClassName"])
+	assert.Equal(t, "dvm-acme-api-dev-workspace", pvc["metadata"].(map[string]any)["name"])
+
+	assert.Equal(t, "Pod", pod["kind"])
+	spec := pod["spec"].(map[string]any)
+	container := spec["containers"].([]any)[0].(map[string]any)
+	assert.Equal(t, "registry.example.com/dvm-dev-api:1", container["image"])
+	assert.Equal(t, []any{"/bin/sleep", "infinity"}, container["command"])
+	assert.Equal(t, []any{map[string]any{"name": "DATABASE_URL", "value": "postgres://db"}}, container["env"])
+	assert.Equal(t, map[string]any{"cpu": "1.5", "memory": "2147483648"}, container["resources"].(map[string]any)["limits"])
+	assert.Equal(t, map[string]any{"runAsUser": float64(1000), "runAsGroup": float64(1000), "fsGroup": float64(1000)}, spec["securityContext"])
+}
+
+func TestKubernetesRuntime_StartWorkspace_Running(t *testing.T) {
+	k, fake := newFakeKubernetesRuntime(KubernetesOptions{}, map[string]string{"get": testPodList, "exec": "main.go\n"})
+
+	_, err := k.StartWorkspace(context.Background(), StartOptions{ContainerName: "dvm-acme-api-dev", AppPath: "/src/api"})
+	require.NoError(t, err)
+
+	var ops []string
+	for _, call := range fake.calls {
+		ops = append(ops, call[0])
+	}
+	assert.Equal(t, []string{"get", "wait", "exec"}, ops, "a running pod is reused and a seeded volume is not copied again")
+}
+
+func TestKubernetesRuntime_ExecArgs(t *testing.T) {
+	k := &KubernetesRuntime{opts: KubernetesOptions{Namespace: "dev"}}
+	args := k.execArgs(AttachOptions{
+		WorkspaceID: "dvm-acme-api-dev",
+		Env:         map[string]string{"B": "2", "A": "1"},
+		Command:     []string{"go", "test"},
+		WorkingDir:  "/workspace/cmd",
+	}, false)
+	assert.Equal(t, "--namespace dev exec -i dvm-acme-api-dev -c workspace -- env A=1 B=2 "+
+		`sh -c cd "$0" && exec "$@" /workspace/cmd go test`, strings.Join(args, " "))
+
+	args = k.execArgs(AttachOptions{WorkspaceID: "dvm-acme-api-dev", Shell: "/bin/bash", LoginShell: true}, true)
+	assert.Equal(t, "--namespace dev exec -i -t dvm-acme-api-dev -c workspace -- env /bin/bash -l", strings.Join(args, " "))
+}
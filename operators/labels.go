@@ -0,0 +1,92 @@
+package operators
+
+// Labels dvm puts on the containers, images, and networks it creates. They
+// tell dvm's resources apart from everything else on the runtime, and map a
+// resource back to its place in the hierarchy without the database, which is
+// what lets reconcile and gc find containers by label query and adopt ones
+// created outside dvm.
+const (
+	LabelManaged   = "io.devopsmaestro.managed"
+	LabelNamespace = "io.devopsmaestro.namespace"
+	LabelEcosystem = "io.devopsmaestro.ecosystem"
+	LabelDomain    = "io.devopsmaestro.domain"
+	LabelSystem    = "io.devopsmaestro.system"
+	LabelApp       = "io.devopsmaestro.app"
+	LabelWorkspace = "io.devopsmaestro.workspace"
+	LabelImage     = "io.devopsmaestro.image"
+	LabelVersion   = "io.devopsmaestro.version"
+	LabelNetwork   = "io.devopsmaestro.network-scope"
+)
+
+// DVMVersion is the dvm version recorded in LabelVersion. main sets it from
+// the build's version.
+var DVMVersion = "dev"
+
+// LabelScope is the place in the hierarchy a resource belongs to. Empty
+// fields are left unlabeled: a base image belongs to no app, and an app
+// needn't be in a domain.
+type LabelScope struct {
+	Ecosystem string
+	Domain    string
+	System    string
+	App       string
+	Workspace string
+}
+
+// LabelScope returns the scope of the workspace container opts start.
+func (opts StartOptions) LabelScope() LabelScope {
+	return LabelScope{
+		Ecosystem: opts.EcosystemName,
+		Domain:    opts.DomainName,
+		System:    opts.SystemName,
+		App:       opts.AppName,
+		Workspace: opts.WorkspaceName,
+	}
+}
+
+// ResourceLabels returns the labels of a resource dvm creates for scope.
+func ResourceLabels(scope LabelScope) map[string]string {
+	labels := ManagedSelector(scope)
+	labels[LabelNamespace] = "devopsmaestro"
+	labels[LabelVersion] = DVMVersion
+	return labels
+}
+
+// ManagedSelector returns the label query matching dvm's resources in scope:
+// every managed resource for an empty scope, narrowed by each field set.
+func ManagedSelector(scope LabelScope) map[string]string {
+	labels := map[string]string{LabelManaged: "true"}
+	for key, value := range map[string]string{
+		LabelEcosystem: scope.Ecosystem,
+		LabelDomain:    scope.Domain,
+		LabelSystem:    scope.System,
+		LabelApp:       scope.App,
+		LabelWorkspace: scope.Workspace,
+	} {
+		if value != "" {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// ScopeFromLabels reads the hierarchy back from a resource's labels.
+func ScopeFromLabels(labels map[string]string) LabelScope {
+	return LabelScope{
+		Ecosystem: labels[LabelEcosystem],
+		Domain:    labels[LabelDomain],
+		System:    labels[LabelSystem],
+		App:       labels[LabelApp],
+		Workspace: labels[LabelWorkspace],
+	}
+}
+
+// MatchesLabels reports whether labels carry every label of selector.
+func MatchesLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
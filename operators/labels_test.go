@@ -0,0 +1,51 @@
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceLabels(t *testing.T) {
+	defer func(v string) { DVMVersion = v }(DVMVersion)
+	DVMVersion = "v1.2.3"
+
+	labels := ResourceLabels(LabelScope{Ecosystem: "acme", App: "api", Workspace: "dev"})
+	assert.Equal(t, map[string]string{
+		LabelManaged:   "true",
+		LabelNamespace: "devopsmaestro",
+		LabelVersion:   "v1.2.3",
+		LabelEcosystem: "acme",
+		LabelApp:       "api",
+		LabelWorkspace: "dev",
+	}, labels)
+	assert.Equal(t, LabelScope{Ecosystem: "acme", App: "api", Workspace: "dev"}, ScopeFromLabels(labels))
+
+	// Resources outside the hierarchy, such as base images, are still dvm's
+	assert.Equal(t, map[string]string{LabelManaged: "true", LabelNamespace: "devopsmaestro", LabelVersion: "v1.2.3"},
+		ResourceLabels(LabelScope{}))
+}
+
+func TestManagedSelector(t *testing.T) {
+	labels := ResourceLabels(LabelScope{Domain: "payments", App: "api", Workspace: "dev"})
+
+	assert.Equal(t, map[string]string{LabelManaged: "true"}, ManagedSelector(LabelScope{}))
+	assert.True(t, MatchesLabels(labels, ManagedSelector(LabelScope{})))
+	assert.True(t, MatchesLabels(labels, ManagedSelector(LabelScope{App: "api", Workspace: "dev"})))
+	assert.False(t, MatchesLabels(labels, ManagedSelector(LabelScope{App: "web", Workspace: "dev"})))
+	assert.False(t, MatchesLabels(map[string]string{LabelApp: "api", LabelWorkspace: "dev"}, ManagedSelector(LabelScope{App: "api"})),
+		"containers without the managed label aren't dvm's")
+}
+
+func TestBuildDVMLabels(t *testing.T) {
+	labels := buildDVMLabels(StartOptions{
+		AppName:       "api",
+		WorkspaceName: "dev",
+		DomainName:    "payments",
+		Labels:        map[string]string{"dvm.sandbox": "true"},
+	})
+	assert.Equal(t, "payments", labels[LabelDomain])
+	assert.Equal(t, DVMVersion, labels[LabelVersion])
+	assert.Equal(t, "true", labels["dvm.sandbox"])
+	assert.NotContains(t, labels, LabelEcosystem)
+}
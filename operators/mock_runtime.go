@@ -24,6 +24,22 @@ type MockContainerRuntime struct {
 	// Key: imageName, Value: true if built
 	Images map[string]bool
 
+	// ImageDigests are the digests ImageDigest reports
+	// Key: imageName, Value: digest
+	ImageDigests map[string]string
+
+	// Containers are the containers InspectContainer reports
+	// Key: container ID or name
+	Containers map[string]*ContainerDetails
+
+	// Networks are the networks EnsureNetwork created and ListNetworks reports
+	// Key: network name
+	Networks map[string]*NetworkInfo
+
+	// Passthrough is the device passthrough DeviceSupport reports; none by
+	// default
+	Passthrough DeviceSupport
+
 	// Calls records all method calls for verification
 	Calls []MockRuntimeCall
 
@@ -58,10 +74,13 @@ type MockRuntimeCall struct {
 // NewMockContainerRuntime creates a new mock runtime with default settings
 func NewMockContainerRuntime() *MockContainerRuntime {
 	return &MockContainerRuntime{
-		Workspaces:  make(map[string]string),
-		Images:      make(map[string]bool),
-		Calls:       make([]MockRuntimeCall, 0),
-		RuntimeType: "mock",
+		Workspaces:   make(map[string]string),
+		Images:       make(map[string]bool),
+		ImageDigests: make(map[string]string),
+		Containers:   make(map[string]*ContainerDetails),
+		Networks:     make(map[string]*NetworkInfo),
+		Calls:        make([]MockRuntimeCall, 0),
+		RuntimeType:  "mock",
 	}
 }
 
@@ -106,6 +125,9 @@ func (m *MockContainerRuntime) StartWorkspace(ctx context.Context, opts StartOpt
 	if m.StartWorkspaceError != nil {
 		return "", m.StartWorkspaceError
 	}
+	if err := m.Passthrough.Check("Mock Platform", opts.Devices, opts.GPUs); err != nil {
+		return "", err
+	}
 
 	// Check if image exists (optional validation)
 	if len(m.Images) > 0 && !m.Images[opts.ImageName] {
@@ -229,9 +251,9 @@ func (m *MockContainerRuntime) ListWorkspaces(ctx context.Context) ([]WorkspaceI
 			App:       "mock-app",
 			Workspace: name,
 			Labels: map[string]string{
-				"io.devopsmaestro.managed":   "true",
-				"io.devopsmaestro.app":       "mock-app",
-				"io.devopsmaestro.workspace": name,
+				LabelManaged:   "true",
+				LabelApp:       "mock-app",
+				LabelWorkspace: name,
 			},
 		})
 	}
@@ -262,9 +284,9 @@ func (m *MockContainerRuntime) FindWorkspace(ctx context.Context, name string) (
 		App:       "mock-app",
 		Workspace: name,
 		Labels: map[string]string{
-			"io.devopsmaestro.managed":   "true",
-			"io.devopsmaestro.app":       "mock-app",
-			"io.devopsmaestro.workspace": name,
+			LabelManaged:   "true",
+			LabelApp:       "mock-app",
+			LabelWorkspace: name,
 		},
 	}, nil
 }
@@ -374,6 +396,101 @@ func (m *MockContainerRuntime) ImageExists(ctx context.Context, imageName string
 	return m.Images[imageName], nil
 }
 
+// ImageDigest returns the digest set in ImageDigests
+func (m *MockContainerRuntime) ImageDigest(ctx context.Context, imageName string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Calls = append(m.Calls, MockRuntimeCall{
+		Method: "ImageDigest",
+		Args:   []interface{}{imageName},
+	})
+
+	digest, ok := m.ImageDigests[imageName]
+	if !ok {
+		return "", fmt.Errorf("image not found: %s", imageName)
+	}
+	return digest, nil
+}
+
+// InspectContainer returns the container set in Containers
+func (m *MockContainerRuntime) InspectContainer(ctx context.Context, idOrName string) (*ContainerDetails, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Calls = append(m.Calls, MockRuntimeCall{
+		Method: "InspectContainer",
+		Args:   []interface{}{idOrName},
+	})
+
+	details, ok := m.Containers[idOrName]
+	if !ok {
+		return nil, fmt.Errorf("container not found: %s", idOrName)
+	}
+	return details, nil
+}
+
+// DeviceSupport returns Passthrough
+func (m *MockContainerRuntime) DeviceSupport() DeviceSupport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.Passthrough
+}
+
+// EnsureNetwork records a network in Networks unless one of that name exists
+func (m *MockContainerRuntime) EnsureNetwork(ctx context.Context, name string, labels map[string]string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Calls = append(m.Calls, MockRuntimeCall{
+		Method: "EnsureNetwork",
+		Args:   []interface{}{name, labels},
+	})
+
+	if _, ok := m.Networks[name]; ok {
+		return false, nil
+	}
+	m.Networks[name] = &NetworkInfo{ID: name, Name: name, Labels: labels}
+	return true, nil
+}
+
+// ListNetworks returns the networks in Networks matching labels
+func (m *MockContainerRuntime) ListNetworks(ctx context.Context, labels map[string]string) ([]NetworkInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Calls = append(m.Calls, MockRuntimeCall{
+		Method: "ListNetworks",
+		Args:   []interface{}{labels},
+	})
+
+	var result []NetworkInfo
+	for _, n := range m.Networks {
+		if MatchesLabels(n.Labels, labels) {
+			result = append(result, *n)
+		}
+	}
+	sortNetworks(result)
+	return result, nil
+}
+
+// RemoveNetwork removes a network from Networks
+func (m *MockContainerRuntime) RemoveNetwork(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Calls = append(m.Calls, MockRuntimeCall{
+		Method: "RemoveNetwork",
+		Args:   []interface{}{name},
+	})
+
+	if _, ok := m.Networks[name]; !ok {
+		return fmt.Errorf("network not found: %s", name)
+	}
+	delete(m.Networks, name)
+	return nil
+}
+
 // =============================================================================
 // Test Helper Methods
 // =============================================================================
@@ -385,6 +502,10 @@ func (m *MockContainerRuntime) Reset() {
 
 	m.Workspaces = make(map[string]string)
 	m.Images = make(map[string]bool)
+	m.ImageDigests = make(map[string]string)
+	m.Containers = make(map[string]*ContainerDetails)
+	m.Networks = make(map[string]*NetworkInfo)
+	m.Passthrough = DeviceSupport{}
 	m.Calls = make([]MockRuntimeCall, 0)
 	m.BuildImageError = nil
 	m.StartWorkspaceError = nil
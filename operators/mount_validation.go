@@ -3,6 +3,7 @@ package operators
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -133,3 +134,32 @@ func validateStartOptionsMounts(opts StartOptions) error {
 
 	return nil
 }
+
+// validateRemoteStartOptionsMounts validates the mount source paths of
+// StartOptions for a remote platform. The paths are on the remote host, so
+// they cannot be checked on disk; they must be absolute, clean and not the
+// filesystem root.
+func validateRemoteStartOptionsMounts(opts StartOptions) error {
+	check := func(p string) error {
+		switch {
+		case !path.IsAbs(p):
+			return fmt.Errorf("remote mount source path %q is not absolute", p)
+		case path.Clean(p) != p || strings.Contains(p, ".."):
+			return fmt.Errorf("remote mount source path %q contains path traversal", p)
+		case p == "/":
+			return fmt.Errorf("mounting the filesystem root is not allowed")
+		}
+		return nil
+	}
+	if opts.AppPath != "" {
+		if err := check(opts.AppPath); err != nil {
+			return fmt.Errorf("AppPath: %w", err)
+		}
+	}
+	for i, mount := range opts.Mounts {
+		if err := check(mount.Source); err != nil {
+			return fmt.Errorf("Mounts[%d] (%s): %w", i, mount.Destination, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,561 @@
+package operators
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moby/term"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// remoteBuildDir is where image build contexts are synced on a remote host,
+// relative to the remote user's home directory.
+const remoteBuildDir = ".devopsmaestro/build"
+
+// NerdctlSSHRuntime implements ContainerRuntime for a remote host that runs
+// nerdctl, by running nerdctl over ssh. Bind mount sources (AppPath, Mounts)
+// are paths on the remote host.
+type NerdctlSSHRuntime struct {
+	platform *Platform
+	target   SSHTarget
+
+	// nerdctl runs nerdctl on the remote host with the given stdin and
+	// returns its stdout.
+	nerdctl func(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error)
+}
+
+// NewNerdctlSSHRuntime creates a NerdctlSSHRuntime for a remote nerdctl
+// platform. It fails when the host cannot be reached or nerdctl does not run.
+func NewNerdctlSSHRuntime(platform *Platform) (*NerdctlSSHRuntime, error) {
+	target, err := ParseSSHTarget(platform.Host)
+	if err != nil {
+		return nil, err
+	}
+	n := &NerdctlSSHRuntime{platform: platform, target: target}
+	n.nerdctl = n.runNerdctl
+	if _, err := n.nerdctl(context.Background(), nil, "version"); err != nil {
+		return nil, &ErrRuntimeUnavailable{
+			Platform: platform.Name,
+			Hint:     platform.GetStartHint(),
+			Err:      err,
+		}
+	}
+	return n, nil
+}
+
+// runNerdctl runs nerdctl over ssh and includes its stderr in the error on
+// failure.
+func (n *NerdctlSSHRuntime) runNerdctl(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "ssh", n.target.Args(nil, append([]string{"nerdctl"}, args...)...)...)
+	cmd.Stdin = stdin
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("nerdctl %s on %s failed: %w\n%s", args[0], n.target.Host, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// NerdctlBuildOptions describes an image build on a remote nerdctl host.
+type NerdctlBuildOptions struct {
+	ContextDir string            // Local build context, synced to the host
+	Dockerfile string            // Dockerfile; must be inside ContextDir
+	ImageName  string            // Name of the image to build
+	Tags       []string          // Additional tags for the image
+	BuildArgs  map[string]string // Build arguments
+	Labels     map[string]string // Image labels
+	Target     string            // Target stage (optional)
+	NoCache    bool              // Disable the build cache
+	Pull       bool              // Always pull the base image
+	Stdout     io.Writer         // Build output (default: os.Stdout)
+	Stderr     io.Writer         // Build errors (default: os.Stderr)
+}
+
+// Build syncs the build context to the remote host and builds the image
+// there with nerdctl build.
+func (n *NerdctlSSHRuntime) Build(ctx context.Context, opts NerdctlBuildOptions) error {
+	dockerfile := "Dockerfile"
+	if opts.Dockerfile != "" {
+		rel, err := filepath.Rel(opts.ContextDir, opts.Dockerfile)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("the Dockerfile %s must be inside the build context %s to build on a remote host", opts.Dockerfile, opts.ContextDir)
+		}
+		dockerfile = filepath.ToSlash(rel)
+	}
+
+	remoteDir := remoteBuildDir + "/" + strings.NewReplacer("/", "_", ":", "_").Replace(opts.ImageName)
+	if err := SyncToRemote(ctx, n.target, opts.ContextDir, remoteDir); err != nil {
+		return fmt.Errorf("failed to sync the build context: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", n.target.Args(nil, nerdctlBuildArgs(opts, remoteDir, dockerfile)...)...)
+	cmd.Stdout, cmd.Stderr = opts.Stdout, opts.Stderr
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("nerdctl build on %s failed: %w", n.target.Host, err)
+	}
+	return nil
+}
+
+// nerdctlBuildArgs returns the remote command building an image from the
+// context synced to remoteDir.
+func nerdctlBuildArgs(opts NerdctlBuildOptions, remoteDir, dockerfile string) []string {
+	args := []string{"nerdctl", "build", "-t", opts.ImageName}
+	for _, tag := range opts.Tags {
+		args = append(args, "-t", tag)
+	}
+	args = append(args, "-f", remoteDir+"/"+dockerfile)
+
+	keys := make([]string, 0, len(opts.BuildArgs))
+	for key := range opts.BuildArgs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--build-arg", key+"="+opts.BuildArgs[key])
+	}
+	labelKeys := make([]string, 0, len(opts.Labels))
+	for key := range opts.Labels {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+	for _, key := range labelKeys {
+		args = append(args, "--label", key+"="+opts.Labels[key])
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	if opts.NoCache {
+		args = append(args, "--no-cache")
+	}
+	if opts.Pull {
+		args = append(args, "--pull")
+	}
+	return append(args, remoteDir)
+}
+
+// BuildImage builds an image on the remote host.
+func (n *NerdctlSSHRuntime) BuildImage(ctx context.Context, opts BuildOptions) error {
+	render.Progressf("Building image '%s' using %s...", opts.ImageName, n.platform.Name)
+	err := n.Build(ctx, NerdctlBuildOptions{
+		ContextDir: opts.BuildContext,
+		Dockerfile: opts.Dockerfile,
+		ImageName:  opts.ImageName,
+		Tags:       opts.Tags,
+		BuildArgs:  opts.BuildArgs,
+	})
+	if err != nil {
+		return err
+	}
+	render.Successf("Image '%s' built successfully", opts.ImageName)
+	return nil
+}
+
+// StartWorkspace creates and starts the workspace container on the remote
+// host, or starts the existing one. A container with a different image is
+// replaced. Returns the container ID.
+func (n *NerdctlSSHRuntime) StartWorkspace(ctx context.Context, opts StartOptions) (string, error) {
+	if err := validateRemoteStartOptionsMounts(opts); err != nil {
+		return "", fmt.Errorf("invalid mount configuration: %w", err)
+	}
+	if opts.SSHAgentForwarding {
+		return "", fmt.Errorf("SSH agent forwarding is not available on remote hosts")
+	}
+	if opts.GitCredentialMounting {
+		return "", fmt.Errorf("git credential mounting is not available on remote hosts")
+	}
+	if err := n.DeviceSupport().Check(n.GetPlatformName(), opts.Devices, opts.GPUs); err != nil {
+		return "", err
+	}
+
+	name := opts.ComputeContainerName()
+	existing, err := n.FindWorkspace(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		if existing.Image == opts.ImageName {
+			if existing.Status != "running" {
+				if _, err := n.nerdctl(ctx, nil, "start", existing.ID); err != nil {
+					return "", err
+				}
+			}
+			return shortID(existing.ID), nil
+		}
+		render.Infof("Image changed: %s -> %s", existing.Image, opts.ImageName)
+		render.Info("Recreating container with new image...")
+		if err := n.RemoveContainer(ctx, existing.ID, true); err != nil {
+			return "", err
+		}
+	}
+
+	args, err := nerdctlRunArgs(name, opts)
+	if err != nil {
+		return "", err
+	}
+	out, err := n.nerdctl(ctx, nil, args...)
+	if err != nil {
+		return "", err
+	}
+	return shortID(strings.TrimSpace(string(out))), nil
+}
+
+// nerdctlRunArgs returns the nerdctl run arguments creating a workspace
+// container.
+func nerdctlRunArgs(name string, opts StartOptions) ([]string, error) {
+	workingDir := opts.WorkingDir
+	if workingDir == "" {
+		workingDir = "/workspace"
+	}
+	uid, gid := opts.UID, opts.GID
+	if uid == 0 {
+		uid = 1000
+	}
+	if gid == 0 {
+		gid = 1000
+	}
+
+	args := []string{"run", "-d", "-t", "-i", "--name", name,
+		"-u", fmt.Sprintf("%d:%d", uid, gid), "-w", workingDir}
+
+	labels := buildDVMLabels(opts)
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--label", key+"="+labels[key])
+	}
+
+	env := envMapToSlice(opts.Env)
+	sort.Strings(env)
+	if opts.AppName != "" {
+		env = append(env, "DVM_APP="+opts.AppName)
+	}
+	if opts.WorkspaceName != "" {
+		env = append(env, "DVM_WORKSPACE="+opts.WorkspaceName)
+	}
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+
+	if opts.AppPath != "" {
+		args = append(args, "-v", opts.AppPath+":/workspace")
+	}
+	for _, mount := range opts.Mounts {
+		bind := mount.Source + ":" + mount.Destination
+		if mount.ReadOnly {
+			bind += ":ro"
+		}
+		args = append(args, "-v", bind)
+	}
+	for _, port := range opts.Ports {
+		args = append(args, "-p", fmt.Sprintf("127.0.0.1:%d:%d", port, port))
+	}
+	for _, device := range opts.Devices {
+		args = append(args, "--device", device.String())
+	}
+	if !opts.GPUs.IsZero() {
+		args = append(args, "--gpus", opts.GPUs.String())
+	}
+
+	if opts.NetworkMode != "" {
+		args = append(args, "--network", opts.NetworkMode)
+	}
+	if opts.CPUs > 0 {
+		args = append(args, "--cpus", fmt.Sprintf("%g", opts.CPUs))
+	}
+	if opts.Memory != "" {
+		if _, err := ParseMemoryString(opts.Memory); err != nil {
+			return nil, fmt.Errorf("invalid memory limit: %w", err)
+		}
+		args = append(args, "--memory", opts.Memory)
+	}
+
+	args = append(args, opts.ImageName)
+	return append(args, opts.ComputeCommand()...), nil
+}
+
+// shortID returns the 12-character short form of a container ID.
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// AttachToWorkspace runs a shell or command in the workspace container with
+// nerdctl exec over ssh, connected to the terminal.
+func (n *NerdctlSSHRuntime) AttachToWorkspace(ctx context.Context, opts AttachOptions) error {
+	isCommand := len(opts.Command) > 0
+	if !isCommand {
+		render.Info("Attaching to workspace (press Ctrl+D to exit)...")
+	}
+
+	cmd := exec.CommandContext(ctx, "ssh", n.execArgs(opts, !isCommand || term.IsTerminal(os.Stdin.Fd()))...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if isCommand {
+		if errors.As(err, &exitErr) {
+			return &ExitError{Code: exitErr.ExitCode()}
+		}
+		return err
+	}
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to attach: %w", err)
+	}
+
+	render.Blank()
+	render.Success("Detached from workspace")
+	return nil
+}
+
+// execArgs returns the ssh arguments of an attach session.
+func (n *NerdctlSSHRuntime) execArgs(opts AttachOptions, tty bool) []string {
+	var sshOptions []string
+	args := []string{"nerdctl", "exec", "-i"}
+	if tty {
+		sshOptions = append(sshOptions, "-t")
+		args = append(args, "-t")
+	}
+
+	uid, gid := opts.UID, opts.GID
+	if uid == 0 {
+		uid = 1000
+	}
+	if gid == 0 {
+		gid = 1000
+	}
+	args = append(args, "-u", fmt.Sprintf("%d:%d", uid, gid))
+	if opts.WorkingDir != "" {
+		args = append(args, "-w", opts.WorkingDir)
+	}
+
+	env := envMapToSlice(opts.Env)
+	sort.Strings(env)
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+
+	args = append(args, opts.WorkspaceID)
+	return n.target.Args(sshOptions, append(args, opts.ComputeCommand()...)...)
+}
+
+// StopWorkspace stops the workspace container.
+func (n *NerdctlSSHRuntime) StopWorkspace(ctx context.Context, workspaceID string) error {
+	_, err := n.nerdctl(ctx, nil, "stop", workspaceID)
+	return err
+}
+
+// GetWorkspaceStatus returns the status of a workspace container: running,
+// created, exited, or stopped when there is no container.
+func (n *NerdctlSSHRuntime) GetWorkspaceStatus(ctx context.Context, workspaceID string) (string, error) {
+	info, err := n.FindWorkspace(ctx, workspaceID)
+	if err != nil {
+		return "unknown", err
+	}
+	if info == nil {
+		return "stopped", nil
+	}
+	return info.Status, nil
+}
+
+// GetRuntimeType returns "nerdctl"
+func (n *NerdctlSSHRuntime) GetRuntimeType() string {
+	return "nerdctl"
+}
+
+// GetPlatformName returns the remote platform's name.
+func (n *NerdctlSSHRuntime) GetPlatformName() string {
+	return n.platform.Name
+}
+
+// nerdctlContainer is the part of `nerdctl ps --format '{{json .}}'` the
+// runtime reads.
+type nerdctlContainer struct {
+	ID     string `json:"ID"`
+	Names  string `json:"Names"`
+	Image  string `json:"Image"`
+	Status string `json:"Status"`
+	Labels string `json:"Labels"`
+}
+
+// listContainers returns the containers matching the labels.
+func (n *NerdctlSSHRuntime) listContainers(ctx context.Context, labels map[string]string) ([]ContainerInfo, error) {
+	args := []string{"ps", "-a", "--no-trunc", "--format", "{{json .}}"}
+	filters := make([]string, 0, len(labels))
+	for key, value := range labels {
+		filters = append(filters, "label="+key+"="+value)
+	}
+	sort.Strings(filters)
+	for _, filter := range filters {
+		args = append(args, "--filter", filter)
+	}
+
+	out, err := n.nerdctl(ctx, nil, args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseNerdctlContainers(out)
+}
+
+// parseNerdctlContainers parses `nerdctl ps --format '{{json .}}'` output,
+// one JSON object per line.
+func parseNerdctlContainers(out []byte) ([]ContainerInfo, error) {
+	var infos []ContainerInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var c nerdctlContainer
+		if err := json.Unmarshal(line, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse nerdctl output: %w", err)
+		}
+		infos = append(infos, ContainerInfo{
+			ID:     c.ID,
+			Name:   c.Names,
+			Status: nerdctlState(c.Status),
+			Image:  c.Image,
+			Labels: parseNerdctlLabels(c.Labels),
+		})
+	}
+	return infos, scanner.Err()
+}
+
+// nerdctlState converts a nerdctl ps status ("Up", "Exited (0) 2 hours ago",
+// "Created") to a container state.
+func nerdctlState(status string) string {
+	switch {
+	case strings.HasPrefix(status, "Up"):
+		return "running"
+	case strings.HasPrefix(status, "Created"):
+		return "created"
+	case status == "":
+		return "unknown"
+	}
+	return "exited"
+}
+
+// parseNerdctlLabels parses the comma-separated key=value labels of nerdctl
+// ps output.
+func parseNerdctlLabels(s string) map[string]string {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// ListWorkspaces lists all DVM-managed workspace containers on the host
+func (n *NerdctlSSHRuntime) ListWorkspaces(ctx context.Context) ([]WorkspaceInfo, error) {
+	containers, err := n.listContainers(ctx, map[string]string{LabelManaged: "true"})
+	if err != nil {
+		return nil, err
+	}
+	workspaces := make([]WorkspaceInfo, 0, len(containers))
+	for _, c := range containers {
+		workspaces = append(workspaces, WorkspaceInfo{
+			ID:        c.ID,
+			Name:      c.Name,
+			Status:    c.Status,
+			Image:     c.Image,
+			App:       c.Labels[LabelApp],
+			Workspace: c.Labels[LabelWorkspace],
+			Ecosystem: c.Labels[LabelEcosystem],
+			Domain:    c.Labels[LabelDomain],
+			System:    c.Labels[LabelSystem],
+			Version:   c.Labels[LabelVersion],
+			Labels:    c.Labels,
+		})
+	}
+	return workspaces, nil
+}
+
+// FindWorkspace finds a workspace container by name or ID. Returns nil if
+// not found.
+func (n *NerdctlSSHRuntime) FindWorkspace(ctx context.Context, name string) (*WorkspaceInfo, error) {
+	workspaces, err := n.ListWorkspaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ws := range workspaces {
+		if ws.Name == name || (name != "" && strings.HasPrefix(ws.ID, name)) {
+			return &ws, nil
+		}
+	}
+	return nil, nil
+}
+
+// StopAllWorkspaces stops all running DVM-managed workspace containers
+func (n *NerdctlSSHRuntime) StopAllWorkspaces(ctx context.Context) (int, error) {
+	workspaces, err := n.ListWorkspaces(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stopped := 0
+	for _, ws := range workspaces {
+		if ws.Status != "running" {
+			continue
+		}
+		if err := n.StopWorkspace(ctx, ws.ID); err != nil {
+			render.Warning(fmt.Sprintf("Failed to stop %s: %v", ws.Name, err))
+			continue
+		}
+		stopped++
+	}
+	return stopped, nil
+}
+
+// RemoveContainer removes a container, stopping it first when force is set.
+func (n *NerdctlSSHRuntime) RemoveContainer(ctx context.Context, containerID string, force bool) error {
+	args := []string{"rm"}
+	if force {
+		args = append(args, "-f")
+	}
+	_, err := n.nerdctl(ctx, nil, append(args, containerID)...)
+	return err
+}
+
+// RemoveImage removes an image from the host.
+func (n *NerdctlSSHRuntime) RemoveImage(ctx context.Context, imageID string) error {
+	_, err := n.nerdctl(ctx, nil, "rmi", imageID)
+	return err
+}
+
+// ListContainers lists containers matching the given labels
+func (n *NerdctlSSHRuntime) ListContainers(ctx context.Context, labels map[string]string) ([]ContainerInfo, error) {
+	return n.listContainers(ctx, labels)
+}
+
+// ImageExists checks whether an image exists on the host.
+func (n *NerdctlSSHRuntime) ImageExists(ctx context.Context, imageName string) (bool, error) {
+	if _, err := n.nerdctl(ctx, nil, "image", "inspect", imageName); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,133 @@
+package operators
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNerdctl records remote nerdctl invocations and answers them from a
+// table keyed by the first argument.
+type fakeNerdctl struct {
+	calls    [][]string
+	response map[string]string
+}
+
+func (f *fakeNerdctl) run(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, args)
+	return []byte(f.response[args[0]]), nil
+}
+
+func newFakeNerdctlSSHRuntime(response map[string]string) (*NerdctlSSHRuntime, *fakeNerdctl) {
+	fake := &fakeNerdctl{response: response}
+	platform, _ := NewRemotePlatform("ssh://dev@buildbox", "nerdctl")
+	return &NerdctlSSHRuntime{platform: platform, target: SSHTarget{User: "dev", Host: "buildbox"}, nerdctl: fake.run}, fake
+}
+
+const testNerdctlPS = `{"ID":"0123456789abcdef","Names":"dvm-acme-api-dev","Image":"dvm-dev-api:1","Status":"Up","Labels":"io.devopsmaestro.managed=true,io.devopsmaestro.app=api,io.devopsmaestro.workspace=dev"}
+{"ID":"fedcba9876543210","Names":"dvm-acme-web-dev","Image":"dvm-dev-web:1","Status":"Exited (0) 2 hours ago","Labels":"io.devopsmaestro.managed=true"}
+`
+
+func TestNerdctlSSHRuntime_ListAndFind(t *testing.T) {
+	n, fake := newFakeNerdctlSSHRuntime(map[string]string{"ps": testNerdctlPS})
+
+	workspaces, err := n.ListWorkspaces(context.Background())
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "running", workspaces[0].Status)
+	assert.Equal(t, "api", workspaces[0].App)
+	assert.Equal(t, "dev", workspaces[0].Workspace)
+	assert.Equal(t, "exited", workspaces[1].Status)
+	assert.Equal(t, []string{"ps", "-a", "--no-trunc", "--format", "{{json .}}", "--filter", "label=io.devopsmaestro.managed=true"}, fake.calls[0])
+
+	ws, err := n.FindWorkspace(context.Background(), "dvm-acme-web-dev")
+	require.NoError(t, err)
+	require.NotNil(t, ws)
+	assert.Equal(t, "dvm-dev-web:1", ws.Image)
+
+	ws, err = n.FindWorkspace(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, ws)
+}
+
+func TestNerdctlSSHRuntime_StartWorkspace(t *testing.T) {
+	opts := StartOptions{
+		ImageName:     "dvm-dev-api:2",
+		WorkspaceName: "dev",
+		ContainerName: "dvm-acme-api-dev",
+		AppName:       "api",
+		AppPath:       "/home/dev/.devopsmaestro/workspaces/api-dev",
+		Env:           map[string]string{"DATABASE_URL": "postgres://db"},
+		Ports:         []int{3000},
+	}
+
+	t.Run("new container", func(t *testing.T) {
+		n, fake := newFakeNerdctlSSHRuntime(map[string]string{"run": "abcdef0123456789abcdef\n"})
+		id, err := n.StartWorkspace(context.Background(), opts)
+		require.NoError(t, err)
+		assert.Equal(t, "abcdef012345", id)
+
+		run := fake.calls[len(fake.calls)-1]
+		assert.Equal(t, "run", run[0])
+		assert.Subset(t, run, []string{"-v", "/home/dev/.devopsmaestro/workspaces/api-dev:/workspace", "-p", "127.0.0.1:3000:3000", "-e", "DATABASE_URL=postgres://db", "-u", "1000:1000"})
+		assert.Equal(t, []string{"dvm-dev-api:2", "/bin/sleep", "infinity"}, run[len(run)-3:])
+	})
+
+	t.Run("image changed", func(t *testing.T) {
+		n, fake := newFakeNerdctlSSHRuntime(map[string]string{"ps": testNerdctlPS, "run": "abc"})
+		_, err := n.StartWorkspace(context.Background(), opts)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"rm", "-f", "0123456789abcdef"}, fake.calls[1])
+	})
+
+	t.Run("same image", func(t *testing.T) {
+		n, fake := newFakeNerdctlSSHRuntime(map[string]string{"ps": testNerdctlPS})
+		same := opts
+		same.ImageName = "dvm-dev-api:1"
+		id, err := n.StartWorkspace(context.Background(), same)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789ab", id)
+		assert.Len(t, fake.calls, 1)
+	})
+
+	t.Run("local paths and credentials are rejected", func(t *testing.T) {
+		n, _ := newFakeNerdctlSSHRuntime(nil)
+		bad := opts
+		bad.AppPath = "relative"
+		_, err := n.StartWorkspace(context.Background(), bad)
+		assert.Error(t, err)
+
+		bad = opts
+		bad.SSHAgentForwarding = true
+		_, err = n.StartWorkspace(context.Background(), bad)
+		assert.Error(t, err)
+	})
+}
+
+func TestNerdctlSSHRuntime_ExecArgs(t *testing.T) {
+	n, _ := newFakeNerdctlSSHRuntime(nil)
+	args := n.execArgs(AttachOptions{
+		WorkspaceID: "dvm-acme-api-dev",
+		Env:         map[string]string{"TERM": "xterm"},
+		Command:     []string{"ls", "-la"},
+		WorkingDir:  "/workspace/src",
+	}, true)
+	assert.Equal(t, []string{"-t", "--", "dev@buildbox", "'nerdctl'", "'exec'", "'-i'", "'-t'", "'-u'", "'1000:1000'",
+		"'-w'", "'/workspace/src'", "'-e'", "'TERM=xterm'", "'dvm-acme-api-dev'", "'ls'", "'-la'"}, args)
+}
+
+func TestNerdctlBuildArgs(t *testing.T) {
+	args := nerdctlBuildArgs(NerdctlBuildOptions{
+		ImageName: "dvm-dev-api:1",
+		BuildArgs: map[string]string{"B": "2", "A": "1"},
+		Labels:    map[string]string{LabelWorkspace: "dev", LabelApp: "api"},
+		NoCache:   true,
+	}, ".devopsmaestro/build/dvm-dev-api_1", "Dockerfile.dvm")
+	assert.Equal(t, []string{"nerdctl", "build", "-t", "dvm-dev-api:1", "-f", ".devopsmaestro/build/dvm-dev-api_1/Dockerfile.dvm",
+		"--build-arg", "A=1", "--build-arg", "B=2",
+		"--label", "io.devopsmaestro.app=api", "--label", "io.devopsmaestro.workspace=dev",
+		"--no-cache", ".devopsmaestro/build/dvm-dev-api_1"}, args)
+}
@@ -0,0 +1,243 @@
+package operators
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// Network scopes recorded in LabelNetwork: the workspaces and services of
+// one app, or of every app in a domain, share a network.
+const (
+	NetworkScopeApp    = "app"
+	NetworkScopeDomain = "domain"
+)
+
+// NetworkInfo describes a container network.
+type NetworkInfo struct {
+	ID         string
+	Name       string
+	Labels     map[string]string
+	Containers int // Containers attached to it, running or not
+}
+
+// Scope returns the network's scope: app or domain for app networks,
+// workspace for the network of a workspace's services, or "" for networks
+// dvm doesn't know.
+func (n NetworkInfo) Scope() string {
+	switch {
+	case n.Labels[LabelNetwork] != "":
+		return n.Labels[LabelNetwork]
+	case n.Labels[LabelWorkspace] != "":
+		return "workspace"
+	}
+	return ""
+}
+
+// AppNetworkName returns the name of the network of scope: dvm-net- followed
+// by the hierarchy names scope sets, in lower case.
+func AppNetworkName(scope LabelScope) string {
+	parts := []string{"dvm-net"}
+	for _, name := range []string{scope.Ecosystem, scope.Domain, scope.System, scope.App} {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// EnsureNetwork creates a bridge network with the given labels unless one
+// of that name exists. It reports whether the network was created.
+func (d *DockerRuntime) EnsureNetwork(ctx context.Context, name string, labels map[string]string) (bool, error) {
+	if _, err := d.client.NetworkInspect(ctx, name, network.InspectOptions{}); err == nil {
+		return false, nil
+	} else if !client.IsErrNotFound(err) {
+		return false, fmt.Errorf("failed to inspect network %s: %w", name, err)
+	}
+	if _, err := d.client.NetworkCreate(ctx, name, network.CreateOptions{Driver: "bridge", Labels: labels}); err != nil {
+		return false, fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// ListNetworks lists the networks matching the given label selectors, with
+// the number of containers on each.
+func (d *DockerRuntime) ListNetworks(ctx context.Context, labels map[string]string) ([]NetworkInfo, error) {
+	filterArgs := filters.NewArgs()
+	for k, v := range labels {
+		filterArgs.Add("label", fmt.Sprintf("%s=%s", k, v))
+	}
+	networks, err := d.client.NetworkList(ctx, network.ListOptions{Filters: filterArgs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var result []NetworkInfo
+	for _, n := range networks {
+		// Stopped containers are not connected but still reference the network
+		containers, err := d.client.ContainerList(ctx, container.ListOptions{
+			All:     true,
+			Filters: filters.NewArgs(filters.Arg("network", n.ID)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list containers of network %s: %w", n.Name, err)
+		}
+		result = append(result, NetworkInfo{ID: shortID(n.ID), Name: n.Name, Labels: n.Labels, Containers: len(containers)})
+	}
+	sortNetworks(result)
+	return result, nil
+}
+
+// RemoveNetwork removes a network by name or ID.
+func (d *DockerRuntime) RemoveNetwork(ctx context.Context, name string) error {
+	return d.client.NetworkRemove(ctx, name)
+}
+
+// joinNetwork connects an existing container to the custom network opts
+// start it on, with opts' aliases, unless it is already connected or runs
+// without a network of its own.
+func (d *DockerRuntime) joinNetwork(ctx context.Context, existing container.Summary, opts StartOptions) error {
+	mode := opts.NetworkMode
+	if mode == "" || mode == "bridge" || mode == "none" || mode == "host" || strings.HasPrefix(mode, "container:") {
+		return nil
+	}
+	switch existing.HostConfig.NetworkMode {
+	case "none", "host":
+		return nil
+	}
+	if existing.NetworkSettings != nil {
+		if _, ok := existing.NetworkSettings.Networks[mode]; ok {
+			return nil
+		}
+	}
+	if err := d.client.NetworkConnect(ctx, mode, existing.ID, &network.EndpointSettings{Aliases: opts.NetworkAliases}); err != nil {
+		return fmt.Errorf("failed to connect container to network %s: %w", mode, err)
+	}
+	return nil
+}
+
+// EnsureNetwork creates a network with the given labels unless one of that
+// name exists. Only Colima is supported, through nerdctl in the VM.
+func (r *ContainerdRuntimeV2) EnsureNetwork(ctx context.Context, name string, labels map[string]string) (bool, error) {
+	if _, err := r.colimaNerdctl(ctx, "network", "inspect", name); err == nil {
+		return false, nil
+	}
+	args := []string{"network", "create"}
+	for _, label := range sortedLabels(labels) {
+		args = append(args, "--label", label)
+	}
+	if _, err := r.colimaNerdctl(ctx, append(args, name)...); err != nil {
+		return false, fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// ListNetworks lists the networks matching the given label selectors, with
+// the number of containers on each. Only Colima is supported.
+func (r *ContainerdRuntimeV2) ListNetworks(ctx context.Context, labels map[string]string) ([]NetworkInfo, error) {
+	out, err := r.colimaNerdctl(ctx, "network", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	networks, err := parseNerdctlNetworks(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []NetworkInfo
+	for _, n := range networks {
+		if !MatchesLabels(n.Labels, labels) {
+			continue
+		}
+		ids, err := r.colimaNerdctl(ctx, "ps", "-a", "-q", "--filter", "network="+n.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list containers of network %s: %w", n.Name, err)
+		}
+		n.Containers = len(strings.Fields(string(ids)))
+		result = append(result, n)
+	}
+	sortNetworks(result)
+	return result, nil
+}
+
+// RemoveNetwork removes a network by name or ID. Only Colima is supported.
+func (r *ContainerdRuntimeV2) RemoveNetwork(ctx context.Context, name string) error {
+	_, err := r.colimaNerdctl(ctx, "network", "rm", name)
+	return err
+}
+
+// colimaNerdctl runs nerdctl in the runtime's namespace inside the Colima VM
+// and includes its stderr in the error on failure.
+func (r *ContainerdRuntimeV2) colimaNerdctl(ctx context.Context, args ...string) ([]byte, error) {
+	if r.platform.Type != PlatformColima {
+		return nil, fmt.Errorf("nerdctl %s: %w", args[0], ErrNotImplemented)
+	}
+	profile := r.platform.Profile
+	if profile == "" {
+		profile = "default"
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellEscape(arg)
+	}
+	command := fmt.Sprintf("sudo nerdctl --namespace %s %s", shellEscape(r.namespace), strings.Join(quoted, " "))
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "colima", "--profile", profile, "ssh", "--", "sh", "-c", command)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("nerdctl %s failed: %w\n%s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// nerdctlNetwork is the part of `nerdctl network ls --format '{{json .}}'`
+// the runtime reads.
+type nerdctlNetwork struct {
+	ID     string `json:"ID"`
+	Name   string `json:"Name"`
+	Labels string `json:"Labels"`
+}
+
+// parseNerdctlNetworks parses `nerdctl network ls --format '{{json .}}'`
+// output, one JSON object per line.
+func parseNerdctlNetworks(out []byte) ([]NetworkInfo, error) {
+	var networks []NetworkInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var n nerdctlNetwork
+		if err := json.Unmarshal(line, &n); err != nil {
+			return nil, fmt.Errorf("failed to parse nerdctl output: %w", err)
+		}
+		networks = append(networks, NetworkInfo{ID: n.ID, Name: n.Name, Labels: parseNerdctlLabels(n.Labels)})
+	}
+	return networks, scanner.Err()
+}
+
+// sortedLabels returns labels as sorted key=value pairs.
+func sortedLabels(labels map[string]string) []string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// sortNetworks sorts networks by name.
+func sortNetworks(networks []NetworkInfo) {
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+}
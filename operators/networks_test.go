@@ -0,0 +1,66 @@
+package operators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppNetworkName(t *testing.T) {
+	assert.Equal(t, "dvm-net-acme-payments-api", AppNetworkName(LabelScope{Ecosystem: "Acme", Domain: "payments", App: "API"}))
+	assert.Equal(t, "dvm-net-acme-payments", AppNetworkName(LabelScope{Ecosystem: "acme", Domain: "payments"}))
+	assert.Equal(t, "dvm-net-acme-payments-billing-api", AppNetworkName(LabelScope{Ecosystem: "acme", Domain: "payments", System: "billing", App: "api"}))
+	assert.Equal(t, "dvm-net-api", AppNetworkName(LabelScope{App: "api", Workspace: "dev"}), "the workspace is not part of the name")
+}
+
+func TestNetworkInfo_Scope(t *testing.T) {
+	assert.Equal(t, "app", NetworkInfo{Labels: map[string]string{LabelNetwork: NetworkScopeApp, LabelApp: "api"}}.Scope())
+	assert.Equal(t, "domain", NetworkInfo{Labels: map[string]string{LabelNetwork: NetworkScopeDomain}}.Scope())
+	assert.Equal(t, "workspace", NetworkInfo{Labels: map[string]string{LabelApp: "api", LabelWorkspace: "dev"}}.Scope())
+	assert.Equal(t, "", NetworkInfo{Labels: map[string]string{LabelManaged: "true"}}.Scope())
+}
+
+func TestParseNerdctlNetworks(t *testing.T) {
+	out := []byte(`{"ID":"17f29b073143","Name":"bridge","Labels":""}
+{"ID":"8a1c2e3d4f5a","Name":"dvm-net-acme-api","Labels":"io.devopsmaestro.managed=true,io.devopsmaestro.network-scope=app"}
+
+`)
+	networks, err := parseNerdctlNetworks(out)
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	assert.Equal(t, "bridge", networks[0].Name)
+	assert.Empty(t, networks[0].Labels)
+	assert.Equal(t, NetworkInfo{
+		ID:     "8a1c2e3d4f5a",
+		Name:   "dvm-net-acme-api",
+		Labels: map[string]string{LabelManaged: "true", LabelNetwork: "app"},
+	}, networks[1])
+
+	_, err = parseNerdctlNetworks([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestMockRuntime_Networks(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockContainerRuntime()
+	labels := map[string]string{LabelManaged: "true", LabelApp: "api"}
+
+	created, err := mock.EnsureNetwork(ctx, "dvm-net-api", labels)
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = mock.EnsureNetwork(ctx, "dvm-net-api", labels)
+	require.NoError(t, err)
+	assert.False(t, created, "an existing network is reused")
+
+	networks, err := mock.ListNetworks(ctx, map[string]string{LabelApp: "api"})
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	networks, err = mock.ListNetworks(ctx, map[string]string{LabelApp: "web"})
+	require.NoError(t, err)
+	assert.Empty(t, networks)
+
+	require.NoError(t, mock.RemoveNetwork(ctx, "dvm-net-api"))
+	assert.Error(t, mock.RemoveNetwork(ctx, "dvm-net-api"))
+}
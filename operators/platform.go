@@ -30,6 +30,7 @@ const (
 	PlatformDockerDesktop PlatformType = "docker-desktop"
 	PlatformPodman        PlatformType = "podman"
 	PlatformLinuxNative   PlatformType = "linux-native"
+	PlatformRemote        PlatformType = "remote" // Docker or nerdctl host reached over SSH
 	PlatformUnknown       PlatformType = "unknown"
 )
 
@@ -40,6 +41,8 @@ type Platform struct {
 	Profile    string // For platforms that support profiles (e.g., Colima)
 	Name       string // Human-readable name
 	HomeDir    string // Home directory (for building paths)
+	Host       string // SSH URL of remote platforms (ssh://user@host[:port])
+	Tool       string // Container CLI of remote platforms: docker or nerdctl
 }
 
 // PlatformDetector defines the interface for detecting available container platforms.
@@ -151,7 +154,9 @@ func (pd *DefaultPlatformDetector) autoDetect() (*Platform, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("no container runtime found. Please install one of: OrbStack, Colima, Docker Desktop, or Podman")
+	return nil, &ErrRuntimeUnavailable{
+		Hint: "Install one of: OrbStack, Colima, Docker Desktop, or Podman",
+	}
 }
 
 // DetectAll finds all installed container platforms (socket files on disk).
@@ -250,7 +255,8 @@ func (pd *DefaultPlatformDetector) detectColima() *Platform {
 }
 
 // detectAllColima enumerates all Colima profiles and returns platforms for each.
-// If COLIMA_DOCKER_PROFILE or COLIMA_ACTIVE_PROFILE is set, only that profile is checked.
+// If COLIMA_DOCKER_PROFILE or COLIMA_ACTIVE_PROFILE is set, only that profile is checked,
+// then runtime.colima.profile from the config.
 // Otherwise, all profile directories under ~/.colima/ are scanned.
 func (pd *DefaultPlatformDetector) detectAllColima() []*Platform {
 	// If an env var is set, only check that specific profile
@@ -269,6 +275,11 @@ func (pd *DefaultPlatformDetector) detectAllColima() []*Platform {
 		}
 	}
 
+	// The configured profile (runtime.colima.profile) is the only candidate
+	if profile := viper.GetString("runtime.colima.profile"); isValidColimaProfileName(profile) {
+		return pd.detectColimaProfile(profile)
+	}
+
 	// Enumerate all profile directories under ~/.colima/
 	colimaDir := filepath.Join(pd.homeDir, ".colima")
 	entries, err := os.ReadDir(colimaDir)
@@ -450,6 +461,8 @@ func (p *Platform) GetStartHint() string {
 		return "Start Podman machine with: podman machine start"
 	case PlatformLinuxNative:
 		return "Start Docker daemon with: sudo systemctl start docker"
+	case PlatformRemote:
+		return fmt.Sprintf("Check that %s is reachable with ssh and runs %s", p.Host, p.Tool)
 	default:
 		return "Please start your container runtime"
 	}
@@ -481,6 +494,8 @@ func (p *Platform) IsDockerCompatible() bool {
 		return true
 	case PlatformColima:
 		return !p.IsContainerd()
+	case PlatformRemote:
+		return p.Tool == "docker"
 	default:
 		return false
 	}
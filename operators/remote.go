@@ -0,0 +1,202 @@
+package operators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/docker/client"
+)
+
+// NewRemotePlatform returns the platform of a remote runtime endpoint: a host
+// reached over SSH (ssh://user@host[:port]) that runs docker or nerdctl.
+//
+// Docker hosts are used through DOCKER_HOST=ssh://..., so builds and the
+// Docker API work as they do locally. nerdctl hosts are driven by running
+// nerdctl over ssh (see NerdctlSSHRuntime).
+func NewRemotePlatform(host, tool string) (*Platform, error) {
+	target, err := ParseSSHTarget(host)
+	if err != nil {
+		return nil, err
+	}
+	if tool != "docker" && tool != "nerdctl" {
+		return nil, fmt.Errorf("unsupported remote runtime %q (supported: docker, nerdctl)", tool)
+	}
+	return &Platform{
+		Type: PlatformRemote,
+		Host: host,
+		Tool: tool,
+		Name: fmt.Sprintf("%s on %s", tool, target.Host),
+	}, nil
+}
+
+// IsRemote returns true for platforms on a remote host (runtime endpoints).
+func (p *Platform) IsRemote() bool {
+	return p.Type == PlatformRemote
+}
+
+// DockerHost returns the DOCKER_HOST of a Docker-compatible platform: the
+// SSH URL of remote platforms, the unix socket otherwise.
+func (p *Platform) DockerHost() string {
+	if p.IsRemote() {
+		return p.Host
+	}
+	return dockerHost(p)
+}
+
+// NewDockerClient creates a Docker API client for a Docker-compatible
+// platform. Remote platforms are reached through `docker system dial-stdio`
+// over ssh.
+func NewDockerClient(platform *Platform) (*client.Client, error) {
+	if !platform.IsRemote() {
+		return client.NewClientWithOpts(
+			client.WithHost(dockerHost(platform)),
+			client.WithAPIVersionNegotiation(),
+		)
+	}
+	helper, err := connhelper.GetConnectionHelper(platform.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote host %s: %w", platform.Host, err)
+	}
+	return client.NewClientWithOpts(
+		client.WithHost(helper.Host),
+		client.WithDialContext(helper.Dialer),
+		client.WithAPIVersionNegotiation(),
+	)
+}
+
+// NewRemoteRuntime creates the ContainerRuntime of a remote platform: the
+// Docker runtime over ssh for docker hosts, NerdctlSSHRuntime for nerdctl
+// hosts.
+func NewRemoteRuntime(platform *Platform) (ContainerRuntime, error) {
+	if platform.IsDockerCompatible() {
+		return NewDockerRuntime(platform)
+	}
+	return NewNerdctlSSHRuntime(platform)
+}
+
+// SSHTarget is the destination of an ssh:// URL.
+type SSHTarget struct {
+	User string
+	Host string
+	Port int // 0 for the ssh default
+}
+
+// ParseSSHTarget parses ssh://[user@]host[:port].
+func ParseSSHTarget(rawURL string) (SSHTarget, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "ssh" || u.Hostname() == "" {
+		return SSHTarget{}, fmt.Errorf("invalid remote host %q: expected ssh://[user@]host[:port]", rawURL)
+	}
+	if u.Path != "" && u.Path != "/" {
+		return SSHTarget{}, fmt.Errorf("invalid remote host %q: paths are not supported", rawURL)
+	}
+	target := SSHTarget{User: u.User.Username(), Host: u.Hostname()}
+	// ssh and rsync would read a leading "-" as an option
+	if strings.HasPrefix(target.User, "-") || strings.HasPrefix(target.Host, "-") {
+		return SSHTarget{}, fmt.Errorf("invalid remote host %q: user and host must not start with '-'", rawURL)
+	}
+	if p := u.Port(); p != "" {
+		if target.Port, err = strconv.Atoi(p); err != nil {
+			return SSHTarget{}, fmt.Errorf("invalid remote host %q: bad port", rawURL)
+		}
+	}
+	return target, nil
+}
+
+// Destination returns the ssh destination: [user@]host.
+func (t SSHTarget) Destination() string {
+	if t.User != "" {
+		return t.User + "@" + t.Host
+	}
+	return t.Host
+}
+
+// Args returns ssh arguments running command on the target, with the given
+// ssh options (e.g. -t, -L ...). Options end with "--" before the
+// destination. The command words are quoted for the remote user's shell,
+// which ssh runs the command with.
+func (t SSHTarget) Args(options []string, command ...string) []string {
+	var args []string
+	if t.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(t.Port))
+	}
+	args = append(args, options...)
+	args = append(args, "--", t.Destination())
+	for _, word := range command {
+		args = append(args, shellEscape(word))
+	}
+	return args
+}
+
+// rsyncShell returns the remote shell rsync uses to reach the target.
+func (t SSHTarget) rsyncShell() string {
+	if t.Port != 0 {
+		return fmt.Sprintf("ssh -p %d", t.Port)
+	}
+	return "ssh"
+}
+
+// ResolveRemoteDir returns dir as an absolute path on the target: relative
+// paths are resolved against the remote user's home directory.
+func ResolveRemoteDir(ctx context.Context, target SSHTarget, dir string) (string, error) {
+	if path.IsAbs(dir) {
+		return dir, nil
+	}
+	out, err := exec.CommandContext(ctx, "ssh", target.Args(nil, "pwd")...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to reach %s: %w", target.Destination(), err)
+	}
+	return path.Join(strings.TrimSpace(string(out)), dir), nil
+}
+
+// SyncToRemote copies the contents of localDir to remoteDir on the target
+// with rsync. Files that are newer on the remote are kept, and nothing is
+// deleted.
+func SyncToRemote(ctx context.Context, target SSHTarget, localDir, remoteDir string) error {
+	if out, err := exec.CommandContext(ctx, "ssh", target.Args(nil, "mkdir", "-p", remoteDir)...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create %s on %s: %w\n%s", remoteDir, target.Host, err, strings.TrimSpace(string(out)))
+	}
+	return rsync(ctx, target, strings.TrimSuffix(localDir, "/")+"/", target.Destination()+":"+remoteDir+"/")
+}
+
+// SyncFromRemote copies the contents of remoteDir on the target back to
+// localDir with rsync. Files that are newer locally are kept, and nothing is
+// deleted.
+func SyncFromRemote(ctx context.Context, target SSHTarget, remoteDir, localDir string) error {
+	return rsync(ctx, target, target.Destination()+":"+strings.TrimSuffix(remoteDir, "/")+"/", strings.TrimSuffix(localDir, "/")+"/")
+}
+
+func rsync(ctx context.Context, target SSHTarget, src, dst string) error {
+	cmd := exec.CommandContext(ctx, "rsync", "-az", "--update", "-e", target.rsyncShell(), src, dst)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("rsync %s -> %s failed: %w\n%s", src, dst, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ForwardPorts forwards localhost ports to the same ports on the target's
+// loopback interface with an ssh tunnel running in the background. The
+// returned function closes the tunnel.
+func ForwardPorts(ctx context.Context, target SSHTarget, ports []int) (func(), error) {
+	if len(ports) == 0 {
+		return func() {}, nil
+	}
+	options := []string{"-N", "-o", "ExitOnForwardFailure=yes"}
+	for _, port := range ports {
+		options = append(options, "-L", fmt.Sprintf("%d:127.0.0.1:%d", port, port))
+	}
+	cmd := exec.CommandContext(ctx, "ssh", target.Args(options)...)
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start port forwarding: %w", err)
+	}
+	return func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}, nil
+}
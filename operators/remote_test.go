@@ -0,0 +1,67 @@
+package operators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSSHTarget(t *testing.T) {
+	target, err := ParseSSHTarget("ssh://dev@buildbox:2222")
+	require.NoError(t, err)
+	assert.Equal(t, SSHTarget{User: "dev", Host: "buildbox", Port: 2222}, target)
+	assert.Equal(t, "dev@buildbox", target.Destination())
+
+	target, err = ParseSSHTarget("ssh://buildbox")
+	require.NoError(t, err)
+	assert.Equal(t, "buildbox", target.Destination())
+
+	for _, bad := range []string{"buildbox", "tcp://buildbox:2375", "ssh://", "ssh://dev@buildbox/srv",
+		"ssh://-oProxyCommand=id", "ssh://-oProxyCommand=id@buildbox", "ssh://%2Doops@buildbox"} {
+		_, err := ParseSSHTarget(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestSSHTarget_Args(t *testing.T) {
+	target := SSHTarget{User: "dev", Host: "buildbox", Port: 2222}
+	assert.Equal(t,
+		[]string{"-p", "2222", "-t", "--", "dev@buildbox", "'mkdir'", "'-p'", "'/srv/my app'"},
+		target.Args([]string{"-t"}, "mkdir", "-p", "/srv/my app"))
+	assert.Equal(t, []string{"-N", "--", "buildbox"}, SSHTarget{Host: "buildbox"}.Args([]string{"-N"}))
+	assert.Equal(t, "ssh -p 2222", target.rsyncShell())
+}
+
+func TestNewRemotePlatform(t *testing.T) {
+	platform, err := NewRemotePlatform("ssh://dev@buildbox", "docker")
+	require.NoError(t, err)
+	assert.True(t, platform.IsRemote())
+	assert.True(t, platform.IsDockerCompatible())
+	assert.False(t, platform.IsContainerd())
+	assert.Equal(t, "ssh://dev@buildbox", platform.DockerHost())
+	assert.Equal(t, "docker on buildbox", platform.Name)
+
+	platform, err = NewRemotePlatform("ssh://dev@buildbox", "nerdctl")
+	require.NoError(t, err)
+	assert.False(t, platform.IsDockerCompatible())
+
+	_, err = NewRemotePlatform("ssh://dev@buildbox", "podman")
+	assert.Error(t, err)
+	_, err = NewRemotePlatform("buildbox", "docker")
+	assert.Error(t, err)
+
+	local := &Platform{Type: PlatformOrbStack, SocketPath: "/tmp/docker.sock"}
+	assert.False(t, local.IsRemote())
+	assert.Equal(t, "unix:///tmp/docker.sock", local.DockerHost())
+}
+
+func TestValidateRemoteStartOptionsMounts(t *testing.T) {
+	assert.NoError(t, validateRemoteStartOptionsMounts(StartOptions{
+		AppPath: "/home/dev/.devopsmaestro/workspaces/api-dev",
+		Mounts:  []MountConfig{{Source: "/srv/cache", Destination: "/cache"}},
+	}))
+	for _, bad := range []string{"relative/path", "/", "/srv/../etc", "/srv//app"} {
+		assert.Error(t, validateRemoteStartOptionsMounts(StartOptions{AppPath: bad}), bad)
+	}
+}
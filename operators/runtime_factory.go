@@ -48,9 +48,12 @@ func NewContainerRuntimeWith(detector PlatformDetector) (ContainerRuntime, error
 	case RuntimeContainerd:
 		return NewContainerdRuntimeV2WithPlatform(config.Platform)
 	case RuntimeKubernetes:
-		return nil, fmt.Errorf("kubernetes runtime not yet implemented (coming in Phase 3)")
+		return NewKubernetesRuntime(KubernetesOptions{
+			Context:   viper.GetString("runtime.kubernetes.context"),
+			Namespace: viper.GetString("runtime.kubernetes.namespace"),
+		})
 	default:
-		return nil, fmt.Errorf("unknown runtime type: %s (supported: docker, containerd)", config.Type)
+		return nil, fmt.Errorf("unknown runtime type: %s (supported: docker, containerd, kubernetes)", config.Type)
 	}
 }
 
@@ -87,7 +90,7 @@ func resolveRuntimeConfig(detector PlatformDetector) (*RuntimeConfig, error) {
 		case "kubernetes", "k8s":
 			rt = RuntimeKubernetes
 		default:
-			return nil, fmt.Errorf("unknown runtime type: %s (supported: docker, containerd, auto)", runtimeType)
+			return nil, fmt.Errorf("unknown runtime type: %s (supported: docker, containerd, kubernetes, auto)", runtimeType)
 		}
 	}
 
@@ -2,7 +2,9 @@ package operators
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/rmkohlman/MaestroSDK/paths"
@@ -70,6 +72,60 @@ type AttachOptions struct {
 	LoginShell  bool              // Use login shell (default: true)
 	UID         int               // User ID for exec session (default: 1000)
 	GID         int               // Group ID for exec session (default: 1000)
+	Command     []string          // Command to run instead of the shell (dvm exec); a TTY is only allocated when stdin is a terminal
+	WorkingDir  string            // Working directory for the session (default: container default)
+}
+
+// ComputeCommand returns the command to run for an attach session: Command if
+// set, otherwise the shell (with -l for a login shell).
+func (opts AttachOptions) ComputeCommand() []string {
+	if len(opts.Command) > 0 {
+		return opts.Command
+	}
+	shell := opts.Shell
+	if shell == "" {
+		shell = "/bin/zsh"
+	}
+	cmd := []string{shell}
+	if opts.LoginShell {
+		cmd = append(cmd, "-l")
+	}
+	return cmd
+}
+
+// ExitError reports a non-zero exit status from a command run in a workspace
+// with AttachOptions.Command, so callers can propagate it as their own.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with status %d", e.Code)
+}
+
+// ErrRuntimeUnavailable reports that no container runtime could be reached:
+// none was detected, or the detected platform is not running.
+type ErrRuntimeUnavailable struct {
+	Platform string // detected platform name; empty when none was found
+	Hint     string // how to install or start the runtime
+	Err      error
+}
+
+func (e *ErrRuntimeUnavailable) Error() string {
+	if e.Platform == "" {
+		return "no container runtime found"
+	}
+	return fmt.Sprintf("failed to connect to %s: %v", e.Platform, e.Err)
+}
+
+func (e *ErrRuntimeUnavailable) Unwrap() error {
+	return e.Err
+}
+
+// IsRuntimeUnavailable checks if an error is an ErrRuntimeUnavailable.
+func IsRuntimeUnavailable(err error) bool {
+	var target *ErrRuntimeUnavailable
+	return errors.As(err, &target)
 }
 
 // WorkspaceInfo contains information about a running workspace
@@ -78,11 +134,13 @@ type WorkspaceInfo struct {
 	Name      string            // Workspace name (container name)
 	Status    string            // Running, Stopped, etc.
 	Image     string            // Image name
+	ImageID   string            // Digest of the image, as ImageInspector reports it; empty when unknown
 	App       string            // App name from labels
 	Workspace string            // Workspace name from labels
 	Ecosystem string            // Ecosystem name from labels
 	Domain    string            // Domain name from labels
 	System    string            // System name from labels
+	Version   string            // dvm version that created the container, from labels
 	Labels    map[string]string // All labels
 }
 
@@ -135,9 +193,68 @@ type StartOptions struct {
 	UID                   int               // Container user ID (default: 1000)
 	GID                   int               // Container group ID (default: 1000)
 	NetworkMode           string            // Network mode: "bridge" (default), "none", "host", or custom name
+	NetworkAliases        []string          // DNS names of the container on a custom NetworkMode network (Docker only)
 	CPUs                  float64           // CPU limit (e.g., 1.5 for 1.5 cores; 0 = no limit)
 	Memory                string            // Memory limit (e.g., "512m", "2g"; "" = no limit)
 	Labels                map[string]string // Additional container labels (merged with DVM defaults)
+	Ports                 []int             // Container ports published on the host's 127.0.0.1
+	Devices               []DeviceMapping   // Host devices passed into the container
+	GPUs                  GPURequest        // GPUs passed into the container
+}
+
+// ExecOptions contains options for running a non-interactive command in a
+// workspace container with piped input and output.
+type ExecOptions struct {
+	WorkspaceID string    // Container name or ID
+	Command     []string  // Command and arguments
+	UID         int       // User ID to run as (default: 1000)
+	GID         int       // Group ID to run as (default: 1000)
+	Stdin       io.Reader // Command input; nil for none
+	Stdout      io.Writer // Command output; nil discards it
+}
+
+// WorkspaceExecer is implemented by runtimes that can run a command in a
+// workspace container with piped stdin/stdout. File sync (spec.sync) streams
+// tar archives through it. Stderr is included in the error on failure.
+type WorkspaceExecer interface {
+	ExecInWorkspace(ctx context.Context, opts ExecOptions) error
+}
+
+// ImageInspector is implemented by runtimes that can report the digest of a
+// local image: the image ID on Docker, the manifest digest on containerd.
+// Builds record it so a container still running an older image shows up as
+// drift; WorkspaceInfo.ImageID reports digests the same way.
+type ImageInspector interface {
+	ImageDigest(ctx context.Context, imageName string) (string, error)
+}
+
+// ContainerInspector is implemented by runtimes that can inspect any
+// container by ID or name, not only the ones dvm labeled. dvm workspace adopt
+// reads a hand-built container's image, mounts, and ports through it, and
+// reconcile uses it to follow adopted containers that carry no dvm labels.
+type ContainerInspector interface {
+	InspectContainer(ctx context.Context, idOrName string) (*ContainerDetails, error)
+}
+
+// NetworkManager is implemented by runtimes that can manage container
+// networks. dvm creates a network per app (or per domain) on demand so an
+// app's workspaces and services reach each other by name, lists them for
+// dvm get networks, and removes unused ones in gc.
+type NetworkManager interface {
+	EnsureNetwork(ctx context.Context, name string, labels map[string]string) (bool, error)
+	ListNetworks(ctx context.Context, labels map[string]string) ([]NetworkInfo, error)
+	RemoveNetwork(ctx context.Context, name string) error
+}
+
+// execUser formats the user of an exec session, defaulting to 1000:1000.
+func execUser(uid, gid int) string {
+	if uid == 0 {
+		uid = 1000
+	}
+	if gid == 0 {
+		gid = 1000
+	}
+	return fmt.Sprintf("%d:%d", uid, gid)
 }
 
 // ContainerNamingStrategy defines the interface for generating and parsing container names
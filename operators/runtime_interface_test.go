@@ -0,0 +1,33 @@
+package operators
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachOptions_ComputeCommand(t *testing.T) {
+	assert.Equal(t, []string{"/bin/zsh"}, AttachOptions{}.ComputeCommand())
+	assert.Equal(t, []string{"/bin/bash", "-l"}, AttachOptions{Shell: "/bin/bash", LoginShell: true}.ComputeCommand())
+	assert.Equal(t, []string{"go", "test", "./..."},
+		AttachOptions{Shell: "/bin/zsh", LoginShell: true, Command: []string{"go", "test", "./..."}}.ComputeCommand())
+}
+
+func TestExitError(t *testing.T) {
+	err := &ExitError{Code: 3}
+	assert.Equal(t, "command exited with status 3", err.Error())
+}
+
+func TestErrRuntimeUnavailable(t *testing.T) {
+	none := &ErrRuntimeUnavailable{Hint: "Install one of: OrbStack, Colima"}
+	assert.Equal(t, "no container runtime found", none.Error())
+
+	cause := errors.New("connection refused")
+	down := fmt.Errorf("create runtime: %w", &ErrRuntimeUnavailable{Platform: "Colima", Err: cause})
+	assert.Equal(t, "create runtime: failed to connect to Colima: connection refused", down.Error())
+	assert.True(t, IsRuntimeUnavailable(down))
+	assert.ErrorIs(t, down, cause)
+	assert.False(t, IsRuntimeUnavailable(cause))
+}
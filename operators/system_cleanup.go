@@ -270,7 +270,7 @@ func (sc *SystemCleaner) markInUseImages(images []ImageInfo, activeContainerName
 func (sc *SystemCleaner) buildEnv() []string {
 	env := os.Environ()
 	if sc.platform.IsDockerCompatible() {
-		env = append(env, fmt.Sprintf("DOCKER_HOST=unix://%s", sc.platform.SocketPath))
+		env = append(env, "DOCKER_HOST="+sc.platform.DockerHost())
 	}
 	return env
 }
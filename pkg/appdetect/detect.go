@@ -0,0 +1,139 @@
+// Package appdetect inspects an app's source tree and proposes the settings
+// that otherwise have to be entered by hand: the language and its version, a
+// build config, and the nvim package with the matching LSP set.
+//
+// A manifest at the root of the app (go.mod, Cargo.toml, package.json,
+// pyproject.toml, ...) decides the language; manifests are checked in a fixed
+// order, so a Go service with a package.json for tooling is still a Go app.
+// Without a root manifest, the language is the one with the most indicator
+// files in the tree (utils.DetectLanguage).
+package appdetect
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge"
+	"devopsmaestro/utils"
+)
+
+// manifest is a root file that identifies an app's language.
+type manifest struct {
+	file     string
+	language string
+}
+
+// manifests are checked in order; the first one present wins.
+var manifests = []manifest{
+	{"go.mod", "golang"},
+	{"Cargo.toml", "rust"},
+	{"pyproject.toml", "python"},
+	{"requirements.txt", "python"},
+	{"setup.py", "python"},
+	{"Pipfile", "python"},
+	{"package.json", "nodejs"},
+	{"pom.xml", "java"},
+	{"build.gradle.kts", "kotlin"},
+	{"build.gradle", "java"},
+	{"build.sbt", "scala"},
+	{"Gemfile", "ruby"},
+	{"composer.json", "php"},
+	{"mix.exs", "elixir"},
+	{"gleam.toml", "gleam"},
+	{"Package.swift", "swift"},
+	{"build.zig", "zig"},
+	{"pubspec.yaml", "dart"},
+	{"global.json", "dotnet"},
+	{"stack.yaml", "haskell"},
+	{"cpanfile", "perl"},
+}
+
+// Result is what Detect learned about an app.
+type Result struct {
+	// Language is the detected language and version. Name is empty when no
+	// language was recognized.
+	Language models.AppLanguageConfig
+
+	// Build uses the app's Dockerfile when it has one, and otherwise names
+	// the language as the buildpack.
+	Build models.AppBuildConfig
+
+	// NvimPackage is the nvim package for the language, or empty.
+	NvimPackage string
+
+	// Manifest is the root file that decided the language, or empty when the
+	// language was inferred from source files.
+	Manifest string
+}
+
+// Detected reports whether a language was recognized.
+func (r *Result) Detected() bool {
+	return r.Language.Name != ""
+}
+
+// Detect inspects the app at path.
+func Detect(path string) (*Result, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, m := range manifests {
+		if _, err := os.Stat(filepath.Join(path, m.file)); err == nil {
+			result.Language.Name = m.language
+			result.Manifest = m.file
+			break
+		}
+	}
+	if !result.Detected() {
+		lang, err := utils.DetectLanguage(path)
+		if err != nil {
+			return nil, err
+		}
+		if lang != nil {
+			result.Language.Name = lang.Name
+		}
+	}
+
+	if has, dockerfile := utils.HasDockerfile(path); has {
+		if rel, err := filepath.Rel(path, dockerfile); err == nil {
+			dockerfile = rel
+		}
+		result.Build.Dockerfile = dockerfile
+	}
+
+	if result.Detected() {
+		result.Language.Version = utils.DetectVersion(result.Language.Name, path)
+		result.NvimPackage = nvimbridge.GetLanguagePackage(result.Language.Name)
+		if result.Build.Dockerfile == "" {
+			result.Build.Buildpack = result.Language.Name
+		}
+	}
+	return result, nil
+}
+
+// Apply stores the detected language, build config, and nvim package on app.
+// Settings that were not detected are left unchanged.
+func (r *Result) Apply(app *models.App) error {
+	if r.Detected() {
+		data, err := json.Marshal(r.Language)
+		if err != nil {
+			return err
+		}
+		app.Language = sql.NullString{String: string(data), Valid: true}
+	}
+	if !r.Build.IsEmpty() {
+		data, err := json.Marshal(r.Build)
+		if err != nil {
+			return err
+		}
+		app.BuildConfig = sql.NullString{String: string(data), Valid: true}
+	}
+	if r.NvimPackage != "" {
+		app.NvimPackage = sql.NullString{String: r.NvimPackage, Valid: true}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package appdetect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestDetect_Manifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		language string
+		version  string
+		manifest string
+		pkg      string
+	}{
+		{"go", map[string]string{"go.mod": "module example.com/api\n\ngo 1.22\n"}, "golang", "1.22", "go.mod", "maestro-go"},
+		{"node", map[string]string{"package.json": `{"name":"web","engines":{"node":">=20.1"}}`}, "nodejs", "", "package.json", "maestro-node"},
+		{"python", map[string]string{"pyproject.toml": "[project]\nname = \"svc\"\n", ".python-version": "3.12\n"}, "python", "3.12", "pyproject.toml", "maestro-python"},
+		{"rust", map[string]string{"Cargo.toml": "[package]\nname = \"cli\"\n"}, "rust", "", "Cargo.toml", "maestro-rust"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Detect(writeFiles(t, tt.files))
+			require.NoError(t, err)
+			assert.Equal(t, tt.language, result.Language.Name)
+			if tt.version != "" {
+				assert.Equal(t, tt.version, result.Language.Version)
+			}
+			assert.Equal(t, tt.manifest, result.Manifest)
+			assert.Equal(t, tt.pkg, result.NvimPackage)
+			assert.Equal(t, tt.language, result.Build.Buildpack)
+			assert.Empty(t, result.Build.Dockerfile)
+		})
+	}
+}
+
+func TestDetect_ManifestOrder(t *testing.T) {
+	// a Go service with a package.json for tooling and many scripts is a Go app
+	dir := writeFiles(t, map[string]string{
+		"go.mod":          "module example.com/api\n",
+		"package.json":    "{}",
+		"scripts/a.py":    "",
+		"scripts/b.py":    "",
+		"scripts/c.py":    "",
+		"cmd/api/main.go": "package main\n",
+	})
+	result, err := Detect(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "golang", result.Language.Name)
+	assert.Equal(t, "go.mod", result.Manifest)
+}
+
+func TestDetect_FallsBackToSourceFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"src/main.rb": "", "src/util.rb": ""})
+	result, err := Detect(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "ruby", result.Language.Name)
+	assert.Empty(t, result.Manifest)
+}
+
+func TestDetect_Dockerfile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"go.mod": "module x\n", "Dockerfile": "FROM scratch\n"})
+	result, err := Detect(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "Dockerfile", result.Build.Dockerfile)
+	assert.Empty(t, result.Build.Buildpack, "the Dockerfile builds the app")
+}
+
+func TestDetect_Nothing(t *testing.T) {
+	result, err := Detect(t.TempDir())
+	require.NoError(t, err)
+	assert.False(t, result.Detected())
+	assert.Empty(t, result.NvimPackage)
+	assert.True(t, result.Build.IsEmpty())
+
+	app := &models.App{Name: "empty"}
+	require.NoError(t, result.Apply(app))
+	assert.False(t, app.Language.Valid)
+	assert.False(t, app.BuildConfig.Valid)
+	assert.False(t, app.NvimPackage.Valid)
+}
+
+func TestDetect_MissingPath(t *testing.T) {
+	_, err := Detect(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestResult_Apply(t *testing.T) {
+	result, err := Detect(writeFiles(t, map[string]string{"go.mod": "module x\n\ngo 1.23\n"}))
+	require.NoError(t, err)
+
+	app := &models.App{Name: "api"}
+	require.NoError(t, result.Apply(app))
+
+	lang := app.GetLanguageConfig()
+	require.NotNil(t, lang)
+	assert.Equal(t, "golang", lang.Name)
+	assert.Equal(t, "1.23", lang.Version)
+	build := app.GetBuildConfig()
+	require.NotNil(t, build)
+	assert.Equal(t, "golang", build.Buildpack)
+	assert.Equal(t, "maestro-go", app.NvimPackage.String)
+}
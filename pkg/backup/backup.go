@@ -0,0 +1,362 @@
+// Package backup provides scheduled, retention-managed database backups.
+//
+// A backup is a consistent SQLite snapshot, gzip-compressed and optionally
+// encrypted, written to a Destination: a local directory, an S3-compatible
+// bucket, or a git repository. After each successful run only the newest
+// Retention archives are kept at the destination. Run state is recorded in a
+// small JSON file so callers can report the last run and when the next is due.
+//
+// # Usage
+//
+//	r, err := backup.New(opts)
+//	if due, _ := r.Due(); due {
+//		state, err := r.Run(ctx, snapshot)
+//	}
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ArchivePrefix starts every archive name. Retention only ever touches
+	// objects with this prefix, so destinations can be shared with other files.
+	ArchivePrefix = "devopsmaestro-"
+
+	// ArchiveExt is the extension of an unencrypted archive.
+	ArchiveExt = ".db.gz"
+
+	// EncryptedExt is appended to ArchiveExt for encrypted archives.
+	EncryptedExt = ".enc"
+
+	// DefaultRetention is the number of archives kept when Retention is unset.
+	DefaultRetention = 7
+
+	// DefaultPassphraseEnv is the env var read for the passphrase when
+	// EncryptionOptions.PassphraseEnv is unset.
+	DefaultPassphraseEnv = "DVM_BACKUP_PASSPHRASE"
+
+	archiveTimeFormat = "20060102T150405Z"
+)
+
+// Options configures a Runner.
+type Options struct {
+	// Schedule is "hourly", "daily", "weekly", or a Go duration ("12h").
+	// Default: daily.
+	Schedule string
+
+	// Retention is the number of archives kept at the destination. Default: 7.
+	Retention int
+
+	// Destination selects where archives are written.
+	Destination DestinationOptions
+
+	// Encryption enables passphrase-based archive encryption.
+	Encryption EncryptionOptions
+
+	// StateFile is the JSON file recording the last run. Required.
+	StateFile string
+}
+
+// EncryptionOptions configures archive encryption.
+type EncryptionOptions struct {
+	Enabled bool
+
+	// PassphraseEnv names the env var holding the passphrase.
+	// Default: DVM_BACKUP_PASSPHRASE.
+	PassphraseEnv string
+}
+
+// SnapshotFunc writes a consistent copy of the database to path.
+type SnapshotFunc func(path string) error
+
+// Runner performs backups and reports their status.
+type Runner struct {
+	opts     Options
+	interval time.Duration
+	dest     Destination
+	now      func() time.Time
+}
+
+// New validates opts, applies defaults, and returns a Runner.
+func New(opts Options) (*Runner, error) {
+	if opts.StateFile == "" {
+		return nil, fmt.Errorf("backup: state file is required")
+	}
+	interval, err := ParseSchedule(opts.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	if opts.Encryption.Enabled && opts.Encryption.PassphraseEnv == "" {
+		opts.Encryption.PassphraseEnv = DefaultPassphraseEnv
+	}
+	dest, err := NewDestination(opts.Destination)
+	if err != nil {
+		return nil, err
+	}
+	return &Runner{opts: opts, interval: interval, dest: dest, now: time.Now}, nil
+}
+
+// Destination returns the configured destination.
+func (r *Runner) Destination() Destination { return r.dest }
+
+// Encrypted reports whether archives written by this Runner are encrypted.
+func (r *Runner) Encrypted() bool { return r.opts.Encryption.Enabled }
+
+// Run snapshots the database, uploads the archive, prunes archives beyond
+// the retention count, and records the outcome in the state file. The state
+// is recorded even when the run fails.
+func (r *Runner) Run(ctx context.Context, snapshot SnapshotFunc) (*State, error) {
+	started := r.now().UTC()
+	name, err := r.run(ctx, snapshot, started)
+
+	state, _ := LoadState(r.opts.StateFile)
+	state.LastRun = started
+	if err != nil {
+		state.LastStatus = StatusFailed
+		state.LastError = err.Error()
+	} else {
+		state.LastStatus = StatusSucceeded
+		state.LastError = ""
+		state.LastSuccess = started
+		state.LastArchive = name
+	}
+	if saveErr := SaveState(r.opts.StateFile, state); saveErr != nil && err == nil {
+		err = fmt.Errorf("backup succeeded but state could not be saved: %w", saveErr)
+	}
+	return state, err
+}
+
+func (r *Runner) run(ctx context.Context, snapshot SnapshotFunc, started time.Time) (string, error) {
+	name, data, err := r.archive(snapshot, started)
+	if err != nil {
+		return "", err
+	}
+	if err := r.dest.Put(ctx, name, bytes.NewReader(data)); err != nil {
+		return "", fmt.Errorf("failed to upload %s to %s: %w", name, r.dest.Name(), err)
+	}
+	if err := r.prune(ctx); err != nil {
+		return "", fmt.Errorf("backup written but retention failed: %w", err)
+	}
+	return name, nil
+}
+
+// Export writes a single archive to the file at path instead of the
+// destination. When path is a directory, the archive is written inside it
+// under its standard name. Export neither prunes nor records run state. It
+// returns the path written.
+func (r *Runner) Export(snapshot SnapshotFunc, path string) (string, error) {
+	name, data, err := r.archive(snapshot, r.now().UTC())
+	if err != nil {
+		return "", err
+	}
+	path, err = expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, name)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// archive snapshots the database and returns the archive name and contents:
+// gzip-compressed and, when enabled, encrypted.
+func (r *Runner) archive(snapshot SnapshotFunc, started time.Time) (string, []byte, error) {
+	var passphrase string
+	if r.opts.Encryption.Enabled {
+		var err error
+		if passphrase, err = r.Passphrase(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "dvm-backup-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	snapPath := filepath.Join(tmpDir, "devopsmaestro.db")
+	if err := snapshot(snapPath); err != nil {
+		return "", nil, fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	data, err := compressFile(snapPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := ArchiveName(started, r.opts.Encryption.Enabled)
+	if r.opts.Encryption.Enabled {
+		if data, err = Encrypt(data, passphrase); err != nil {
+			return "", nil, err
+		}
+	}
+	return name, data, nil
+}
+
+// Passphrase returns the encryption passphrase from the configured env var.
+func (r *Runner) Passphrase() (string, error) {
+	env := r.opts.Encryption.PassphraseEnv
+	if env == "" {
+		env = DefaultPassphraseEnv
+	}
+	passphrase := os.Getenv(env)
+	if passphrase == "" {
+		return "", fmt.Errorf("backup passphrase not set: export $%s", env)
+	}
+	return passphrase, nil
+}
+
+// prune deletes the oldest archives beyond the retention count.
+func (r *Runner) prune(ctx context.Context) error {
+	archives, err := r.Archives(ctx)
+	if err != nil {
+		return err
+	}
+	if len(archives) <= r.opts.Retention {
+		return nil
+	}
+	for _, name := range archives[:len(archives)-r.opts.Retention] {
+		if err := r.dest.Delete(ctx, name); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Archives lists backup archives at the destination, oldest first.
+func (r *Runner) Archives(ctx context.Context) ([]string, error) {
+	names, err := r.dest.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", r.dest.Name(), err)
+	}
+	archives := names[:0]
+	for _, n := range names {
+		if IsArchiveName(n) {
+			archives = append(archives, n)
+		}
+	}
+	// Timestamps are fixed-width UTC, so lexical order is chronological.
+	sort.Strings(archives)
+	return archives, nil
+}
+
+// Due reports whether a backup should run now. Scheduling is based on the
+// last attempt rather than the last success, so an unreachable destination
+// is retried once per interval instead of on every invocation.
+func (r *Runner) Due() (bool, error) {
+	status, err := r.Status()
+	if err != nil {
+		return false, err
+	}
+	return !r.now().Before(status.NextDue), nil
+}
+
+// Status returns the recorded state together with the schedule.
+func (r *Runner) Status() (*Status, error) {
+	state, err := LoadState(r.opts.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	s := &Status{
+		State:       *state,
+		Schedule:    r.opts.Schedule,
+		Retention:   r.opts.Retention,
+		Encrypted:   r.opts.Encryption.Enabled,
+		Destination: r.dest.Name(),
+	}
+	if s.Schedule == "" {
+		s.Schedule = "daily"
+	}
+	if state.LastRun.IsZero() {
+		s.NextDue = r.now().UTC()
+	} else {
+		s.NextDue = state.LastRun.Add(r.interval)
+	}
+	return s, nil
+}
+
+// ArchiveName returns the archive name for a backup taken at t.
+func ArchiveName(t time.Time, encrypted bool) string {
+	name := ArchivePrefix + t.UTC().Format(archiveTimeFormat) + ArchiveExt
+	if encrypted {
+		name += EncryptedExt
+	}
+	return name
+}
+
+// IsArchiveName reports whether name looks like an archive written by Run.
+func IsArchiveName(name string) bool {
+	if !strings.HasPrefix(name, ArchivePrefix) {
+		return false
+	}
+	return strings.HasSuffix(name, ArchiveExt) || strings.HasSuffix(name, ArchiveExt+EncryptedExt)
+}
+
+// ParseSchedule converts a schedule string into an interval.
+func ParseSchedule(schedule string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(schedule)) {
+	case "", "daily":
+		return 24 * time.Hour, nil
+	case "hourly":
+		return time.Hour, nil
+	case "weekly":
+		return 7 * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(schedule)
+	if err != nil {
+		return 0, fmt.Errorf("backup: invalid schedule %q (use hourly, daily, weekly, or a duration like 12h)", schedule)
+	}
+	if d < time.Minute {
+		return 0, fmt.Errorf("backup: schedule %q is shorter than one minute", schedule)
+	}
+	return d, nil
+}
+
+// compressFile gzips the file at path into memory.
+func compressFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, f); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// expandHome expands a leading ~ to the current user's home directory.
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("backup: cannot resolve home directory: %w", err)
+	}
+	return filepath.Join(home, path[1:]), nil
+}
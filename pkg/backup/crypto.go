@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// Encrypted archive layout:
+//
+//	magic (7) | salt (16) | nonce (12) | AES-256-GCM ciphertext
+//
+// The key is derived from the passphrase with PBKDF2-HMAC-SHA256.
+var encryptMagic = []byte("DVMBAK1")
+
+const (
+	saltSize         = 16
+	keySize          = 32
+	pbkdf2Iterations = 600_000
+)
+
+// ErrNotEncrypted is returned by Decrypt when data lacks the archive header.
+var ErrNotEncrypted = errors.New("backup: data is not an encrypted archive")
+
+// Encrypt seals data with a key derived from passphrase.
+func Encrypt(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("backup: failed to generate salt: %w", err)
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("backup: failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(encryptMagic)+saltSize+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, encryptMagic...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, encryptMagic), nil
+}
+
+// Decrypt opens data produced by Encrypt.
+func Decrypt(data []byte, passphrase string) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptMagic) {
+		return nil, ErrNotEncrypted
+	}
+	rest := data[len(encryptMagic):]
+	if len(rest) < saltSize {
+		return nil, fmt.Errorf("backup: encrypted archive is truncated")
+	}
+	salt, rest := rest[:saltSize], rest[saltSize:]
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, fmt.Errorf("backup: encrypted archive is truncated")
+	}
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, encryptMagic)
+	if err != nil {
+		return nil, fmt.Errorf("backup: decryption failed (wrong passphrase or corrupted archive)")
+	}
+	return plain, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("backup: key derivation failed: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Destination types accepted by NewDestination.
+const (
+	DestinationLocal = "local"
+	DestinationS3    = "s3"
+	DestinationGit   = "git"
+)
+
+// Destination stores backup archives by name. Names are flat: they never
+// contain path separators.
+type Destination interface {
+	// Name describes the destination for status output, e.g. "s3://bucket/prefix".
+	Name() string
+
+	// Put stores an archive, replacing any existing archive of the same name.
+	Put(ctx context.Context, name string, r io.Reader) error
+
+	// Get opens an archive for reading.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+
+	// List returns the names of all objects at the destination.
+	List(ctx context.Context) ([]string, error)
+
+	// Delete removes an archive.
+	Delete(ctx context.Context, name string) error
+}
+
+// DestinationOptions configures a Destination. Only the fields relevant to
+// Type are read.
+type DestinationOptions struct {
+	// Type is "local", "s3", or "git". Default: local.
+	Type string
+
+	// Path is the directory for local destinations (required), or the
+	// subdirectory inside the repository for git destinations.
+	Path string
+
+	// S3-compatible object storage.
+	Endpoint     string
+	Bucket       string
+	Prefix       string
+	Region       string // default us-east-1
+	AccessKeyEnv string // default AWS_ACCESS_KEY_ID
+	SecretKeyEnv string // default AWS_SECRET_ACCESS_KEY
+
+	// Git repository.
+	Repo   string
+	Branch string // default main
+}
+
+// NewDestination creates the Destination selected by opts.Type.
+func NewDestination(opts DestinationOptions) (Destination, error) {
+	switch strings.ToLower(opts.Type) {
+	case "", DestinationLocal:
+		return NewLocalDestination(opts.Path)
+	case DestinationS3:
+		return NewS3Destination(opts)
+	case DestinationGit:
+		return NewGitDestination(opts)
+	default:
+		return nil, fmt.Errorf("backup: unsupported destination type %q (supported: local, s3, git)", opts.Type)
+	}
+}
+
+// validateName rejects archive names that could escape the destination.
+func validateName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("backup: invalid archive name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// GitDestination stores archives as commits in a git repository. Each
+// operation works on a fresh shallow clone in a temp directory, so nothing is
+// left behind between runs. Authentication is whatever the git CLI already
+// uses (SSH agent, credential helper).
+type GitDestination struct {
+	repo   string
+	branch string
+	subdir string
+}
+
+// NewGitDestination validates opts. The git binary must be on PATH.
+func NewGitDestination(opts DestinationOptions) (*GitDestination, error) {
+	if opts.Repo == "" {
+		return nil, fmt.Errorf("backup: git destination requires repo")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("backup: git destination requires git on PATH")
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = "main"
+	}
+	subdir := strings.Trim(path.Clean("/"+filepath.ToSlash(opts.Path)), "/")
+	return &GitDestination{repo: opts.Repo, branch: branch, subdir: subdir}, nil
+}
+
+// Name returns "git:repo@branch[/subdir]".
+func (d *GitDestination) Name() string {
+	name := "git:" + d.repo + "@" + d.branch
+	if d.subdir != "" {
+		name += "/" + d.subdir
+	}
+	return name
+}
+
+// Put commits the archive and pushes.
+func (d *GitDestination) Put(ctx context.Context, name string, r io.Reader) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return d.withClone(ctx, func(dir string) (string, error) {
+		target := filepath.Join(dir, filepath.FromSlash(d.subdir), name)
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(target, data, 0600); err != nil {
+			return "", err
+		}
+		return "Add backup " + name, git(ctx, dir, "add", "--", d.relPath(name))
+	})
+}
+
+// Get reads the archive from a fresh clone.
+func (d *GitDestination) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	var data []byte
+	err := d.withClone(ctx, func(dir string) (string, error) {
+		var err error
+		data, err = os.ReadFile(filepath.Join(dir, filepath.FromSlash(d.subdir), name))
+		return "", err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// List returns the files in the backup directory of the branch.
+func (d *GitDestination) List(ctx context.Context) ([]string, error) {
+	var names []string
+	err := d.withClone(ctx, func(dir string) (string, error) {
+		entries, err := os.ReadDir(filepath.Join(dir, filepath.FromSlash(d.subdir)))
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() {
+				names = append(names, e.Name())
+			}
+		}
+		return "", nil
+	})
+	return names, err
+}
+
+// Delete removes the archive in a new commit and pushes. History still holds
+// the old archive; rewrite or squash the branch if that matters.
+func (d *GitDestination) Delete(ctx context.Context, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	return d.withClone(ctx, func(dir string) (string, error) {
+		return "Prune backup " + name, git(ctx, dir, "rm", "--quiet", "--", d.relPath(name))
+	})
+}
+
+func (d *GitDestination) relPath(name string) string {
+	if d.subdir == "" {
+		return name
+	}
+	return d.subdir + "/" + name
+}
+
+// withClone clones the branch into a temp dir and runs fn there. When fn
+// returns a non-empty commit message, the staged change is committed and
+// pushed. A branch that does not exist yet (e.g. in an empty repo) starts as
+// an orphan and is created by the push.
+func (d *GitDestination) withClone(ctx context.Context, fn func(dir string) (string, error)) error {
+	dir, err := os.MkdirTemp("", "dvm-backup-git-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	heads, err := gitOutput(ctx, "", "ls-remote", "--heads", "--", d.repo, d.branch)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(heads) != "" {
+		if err := git(ctx, "", "clone", "--quiet", "--depth", "1", "--branch", d.branch, "--", d.repo, dir); err != nil {
+			return err
+		}
+	} else {
+		for _, args := range [][]string{
+			{"init", "--quiet"},
+			{"remote", "add", "origin", "--", d.repo},
+			{"checkout", "--quiet", "--orphan", d.branch},
+		} {
+			if err := git(ctx, dir, args...); err != nil {
+				return err
+			}
+		}
+	}
+
+	msg, err := fn(dir)
+	if err != nil || msg == "" {
+		return err
+	}
+	if err := git(ctx, dir, "commit", "--quiet", "-m", msg); err != nil {
+		return err
+	}
+	return git(ctx, dir, "push", "--quiet", "origin", "HEAD:refs/heads/"+d.branch)
+}
+
+// git runs a git command in dir, folding stderr into the error.
+func git(ctx context.Context, dir string, args ...string) error {
+	_, err := gitOutput(ctx, dir, args...)
+	return err
+}
+
+// gitOutput runs a git command in dir and returns its stdout. Commits are
+// authored as "dvm" so backups work without a configured git identity.
+func gitOutput(ctx context.Context, dir string, args ...string) (string, error) {
+	verb := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_AUTHOR_NAME=dvm", "GIT_AUTHOR_EMAIL=dvm@localhost",
+		"GIT_COMMITTER_NAME=dvm", "GIT_COMMITTER_EMAIL=dvm@localhost",
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", verb, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
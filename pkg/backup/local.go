@@ -0,0 +1,93 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalDestination stores archives in a directory on this machine.
+type LocalDestination struct {
+	dir string
+}
+
+// NewLocalDestination returns a destination rooted at dir. A leading ~ is
+// expanded; the directory is created on first Put.
+func NewLocalDestination(dir string) (*LocalDestination, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("backup: local destination requires a path")
+	}
+	expanded, err := expandHome(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalDestination{dir: expanded}, nil
+}
+
+// Name returns the directory path.
+func (d *LocalDestination) Name() string { return d.dir }
+
+// Put writes the archive via a temp file and rename so a partial write never
+// looks like a complete archive.
+func (d *LocalDestination) Put(_ context.Context, name string, r io.Reader) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.dir, ".partial-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(d.dir, name))
+}
+
+// Get opens the archive file.
+func (d *LocalDestination) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+	return os.Open(filepath.Join(d.dir, name))
+}
+
+// List returns the regular files in the directory. A missing directory is empty.
+func (d *LocalDestination) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// Delete removes the archive file.
+func (d *LocalDestination) Delete(_ context.Context, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(d.dir, name))
+}
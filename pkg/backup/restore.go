@@ -0,0 +1,84 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LatestArchive is the archive name Fetch resolves to the newest archive.
+const LatestArchive = "latest"
+
+// sqliteMagic starts every SQLite database file.
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Fetch reads an archive from the destination and returns its name and
+// contents. The name "latest" selects the newest archive.
+func (r *Runner) Fetch(ctx context.Context, name string) (string, []byte, error) {
+	if name == LatestArchive {
+		archives, err := r.Archives(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(archives) == 0 {
+			return "", nil, fmt.Errorf("no backups found at %s", r.dest.Name())
+		}
+		name = archives[len(archives)-1]
+	}
+	rc, err := r.dest.Get(ctx, name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read %s from %s: %w", name, r.dest.Name(), err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read %s from %s: %w", name, r.dest.Name(), err)
+	}
+	return name, data, nil
+}
+
+// IsEncrypted reports whether data is an encrypted archive.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptMagic)
+}
+
+// Extract writes the database held in data to the file at path. data may be
+// an archive written by Run or Export (gzip-compressed, optionally
+// encrypted) or a plain SQLite database file. passphrase is only used for
+// encrypted archives.
+func Extract(data []byte, passphrase, path string) error {
+	if IsEncrypted(data) {
+		if passphrase == "" {
+			return fmt.Errorf("backup: archive is encrypted but no passphrase was given")
+		}
+		plain, err := Decrypt(data, passphrase)
+		if err != nil {
+			return err
+		}
+		data = plain
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("backup: invalid archive: %w", err)
+		}
+		plain, err := io.ReadAll(zr)
+		if err != nil {
+			return fmt.Errorf("backup: invalid archive: %w", err)
+		}
+		data = plain
+	}
+	if !bytes.HasPrefix(data, sqliteMagic) {
+		return fmt.Errorf("backup: not a database archive")
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("backup: failed to write %s: %w", path, err)
+	}
+	return nil
+}
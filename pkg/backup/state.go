@@ -0,0 +1,67 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Run outcomes recorded in State.LastStatus.
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// State is the persisted record of the most recent backup run.
+type State struct {
+	LastRun     time.Time `json:"lastRun,omitempty" yaml:"lastRun,omitempty"`
+	LastSuccess time.Time `json:"lastSuccess,omitempty" yaml:"lastSuccess,omitempty"`
+	LastStatus  string    `json:"lastStatus,omitempty" yaml:"lastStatus,omitempty"`
+	LastArchive string    `json:"lastArchive,omitempty" yaml:"lastArchive,omitempty"`
+	LastError   string    `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+}
+
+// Status combines the persisted State with the configured schedule.
+type Status struct {
+	State       `yaml:",inline"`
+	Schedule    string    `json:"schedule" yaml:"schedule"`
+	Retention   int       `json:"retention" yaml:"retention"`
+	Encrypted   bool      `json:"encrypted" yaml:"encrypted"`
+	Destination string    `json:"destination" yaml:"destination"`
+	NextDue     time.Time `json:"nextDue" yaml:"nextDue"`
+}
+
+// LoadState reads the state file. A missing file yields an empty State.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return &State{}, fmt.Errorf("failed to read backup state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return &State{}, fmt.Errorf("failed to parse backup state %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// SaveState atomically writes the state file.
+func SaveState(path string, s *State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -7,6 +7,7 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/buildtemplate"
 	"devopsmaestro/pkg/envvalidation"
 )
 
@@ -91,7 +92,16 @@ func (r *HierarchyBuildArgsResolver) Resolve(ctx context.Context, workspaceID in
 	globalArgs := parseDirectMap(getDefault(r.store, defaultsBuildArgsKey))
 
 	// ─── Parse each level ────────────────────────────────────────────────────
-	appArgs := parseWrappedArgs(nullableString(app.BuildConfig.String, app.BuildConfig.Valid))
+	// The app level includes the args of the app's build template; the
+	// app's own args win
+	appBuild, err := buildtemplate.Resolve(r.store, app)
+	if err != nil {
+		return nil, fmt.Errorf("resolving build args: %w", err)
+	}
+	appArgs := appBuild.Args
+	if appArgs == nil {
+		appArgs = map[string]string{}
+	}
 	wsArgs := parseWrappedArgs(nullableString(ws.BuildConfig.String, ws.BuildConfig.Valid))
 
 	// ─── Build path (always 6 entries) ───────────────────────────────────────
@@ -158,7 +168,7 @@ func parseDirectMap(raw string) map[string]string {
 }
 
 // parseWrappedArgs parses a JSON string of the form {"args": {...}}.
-// Used for: app.build_config, workspace.build_config.
+// Used for: workspace.build_config.
 func parseWrappedArgs(raw string) map[string]string {
 	if raw == "" {
 		return map[string]string{}
@@ -0,0 +1,96 @@
+// Package buildinputs hashes what a workspace image is built from, so dvm can
+// tell when an image is stale: the app source staged as the Docker build
+// context, and the build config.
+//
+// A workspace records the hash of its inputs after each successful build;
+// when the hash of its current inputs differs, a rebuild is recommended.
+package buildinputs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Prefix prefixes every hash, naming its algorithm.
+const Prefix = "sha256:"
+
+// Dir returns the content hash of the files under dir, walked in lexical
+// order. A file's path relative to dir, executable bit, and content are
+// hashed; symlinks are hashed by their target. skip, when set, reports
+// relative paths (with slashes) to leave out, as the build leaves them out of
+// the staged source; a skipped directory is not walked.
+func Dir(dir string, skip func(rel string) bool) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if skip != nil && skip(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case d.IsDir():
+			return nil
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(h, "L %q %q\n", rel, target)
+			return nil
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fmt.Fprintf(h, "F %q %t %d\n", rel, info.Mode().Perm()&0o111 != 0, info.Size())
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash build context %s: %w", dir, err)
+	}
+	return Prefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Hash returns the hash of a build's inputs: source, the hash Dir returned
+// for its build context, and the JSON encoding of each config.
+func Hash(source string, configs ...any) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "S %s\n", source)
+	for i, c := range configs {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash build config %d: %w", i, err)
+		}
+		fmt.Fprintf(h, "C %d\n", len(data))
+		h.Write(data)
+	}
+	return Prefix + hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,83 @@
+package buildinputs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func dirHash(t *testing.T, dir string) string {
+	t.Helper()
+	h, err := Dir(dir, func(rel string) bool { return rel == "node_modules" || strings.HasSuffix(rel, ".log") })
+	require.NoError(t, err)
+	return h
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "Dockerfile", "FROM golang:1.22\n")
+	writeFile(t, dir, "cmd/main.go", "package main\n")
+	base := dirHash(t, dir)
+	assert.True(t, strings.HasPrefix(base, Prefix))
+	assert.Equal(t, base, dirHash(t, dir), "hash is stable")
+
+	// Skipped files don't count
+	writeFile(t, dir, "build.log", "noise")
+	writeFile(t, dir, "node_modules/x/index.js", "module.exports = 1")
+	assert.Equal(t, base, dirHash(t, dir))
+
+	// Content, names, and the executable bit do
+	writeFile(t, dir, "cmd/main.go", "package main\n\nfunc main() {}\n")
+	changed := dirHash(t, dir)
+	assert.NotEqual(t, base, changed)
+
+	require.NoError(t, os.Rename(filepath.Join(dir, "cmd", "main.go"), filepath.Join(dir, "cmd", "app.go")))
+	renamed := dirHash(t, dir)
+	assert.NotEqual(t, changed, renamed)
+
+	require.NoError(t, os.Chmod(filepath.Join(dir, "Dockerfile"), 0o755))
+	assert.NotEqual(t, renamed, dirHash(t, dir))
+}
+
+func TestDir_Missing(t *testing.T) {
+	_, err := Dir(filepath.Join(t.TempDir(), "gone"), nil)
+	assert.Error(t, err)
+}
+
+func TestHash(t *testing.T) {
+	type config struct {
+		Dockerfile string            `json:"dockerfile,omitempty"`
+		Args       map[string]string `json:"args,omitempty"`
+	}
+	source := Prefix + "abc"
+
+	base, err := Hash(source, config{Dockerfile: "Dockerfile"}, "")
+	require.NoError(t, err)
+	same, err := Hash(source, config{Dockerfile: "Dockerfile"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, base, same)
+
+	for name, configs := range map[string][]any{
+		"config":        {config{Dockerfile: "Dockerfile", Args: map[string]string{"GO": "1.22"}}, ""},
+		"second config": {config{Dockerfile: "Dockerfile"}, `{"packages":["jq"]}`},
+	} {
+		got, err := Hash(source, configs...)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, got, name)
+	}
+
+	other, err := Hash(Prefix+"def", config{Dockerfile: "Dockerfile"}, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, base, other, "source")
+}
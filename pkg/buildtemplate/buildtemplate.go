@@ -0,0 +1,87 @@
+// Package buildtemplate resolves app build configuration against the named
+// build templates apps reference with spec.build.template, and tracks which
+// workspaces need a rebuild after a template changes.
+//
+// An app inherits everything in its template and overrides it field by
+// field: scalar fields the app sets replace the template's, args are merged
+// key by key, and CA certs are merged by name (see models.AppBuildConfig.Inherit).
+package buildtemplate
+
+import (
+	"fmt"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+)
+
+// Store is the subset of db.DataStore the package needs.
+type Store interface {
+	db.BuildTemplateStore
+	db.AppStore
+	db.WorkspaceStore
+}
+
+// Resolve returns app's effective build configuration: its own spec.build
+// layered over the template it references, if any. A reference to a missing
+// template is an error.
+func Resolve(store db.BuildTemplateStore, app *models.App) (models.AppBuildConfig, error) {
+	var cfg models.AppBuildConfig
+	if c := app.GetBuildConfig(); c != nil {
+		cfg = *c
+	}
+	if cfg.Template == "" {
+		return cfg, nil
+	}
+	tmpl, err := store.GetBuildTemplate(cfg.Template)
+	if err != nil {
+		if db.IsNotFound(err) {
+			return cfg, fmt.Errorf("app %s uses build template %q, which does not exist", app.Name, cfg.Template)
+		}
+		return cfg, fmt.Errorf("failed to get build template %q: %w", cfg.Template, err)
+	}
+	return cfg.Inherit(tmpl.GetBuildConfig()), nil
+}
+
+// Dependents returns the apps that reference the template name.
+func Dependents(store db.AppStore, name string) ([]*models.App, error) {
+	apps, err := store.ListAllApps()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list apps: %w", err)
+	}
+	var dependents []*models.App
+	for _, app := range apps {
+		if cfg := app.GetBuildConfig(); cfg != nil && cfg.Template == name {
+			dependents = append(dependents, app)
+		}
+	}
+	return dependents, nil
+}
+
+// MarkDependents records a pending rebuild for every workspace of every app
+// that uses the template name, and returns the workspaces marked.
+func MarkDependents(store Store, name string) ([]*models.Workspace, error) {
+	apps, err := Dependents(store, name)
+	if err != nil {
+		return nil, err
+	}
+	reason := RebuildReason(name)
+	var marked []*models.Workspace
+	for _, app := range apps {
+		workspaces, err := store.ListWorkspacesByApp(app.ID)
+		if err != nil {
+			return marked, fmt.Errorf("failed to list workspaces of app %s: %w", app.Name, err)
+		}
+		for _, ws := range workspaces {
+			if err := store.SetWorkspaceRebuildReason(ws.ID, reason); err != nil {
+				return marked, fmt.Errorf("failed to mark workspace %s for rebuild: %w", ws.Name, err)
+			}
+			marked = append(marked, ws)
+		}
+	}
+	return marked, nil
+}
+
+// RebuildReason is the rebuild reason recorded when the template name changes.
+func RebuildReason(name string) string {
+	return fmt.Sprintf("build template %s changed", name)
+}
@@ -0,0 +1,86 @@
+package buildtemplate
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newApp creates an app in store whose spec.build is cfg.
+func newApp(t *testing.T, store *db.MockDataStore, name string, cfg models.AppBuildConfig) *models.App {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	app := &models.App{Name: name, BuildConfig: sql.NullString{String: string(data), Valid: true}}
+	require.NoError(t, store.CreateApp(app))
+	return app
+}
+
+func newTemplate(t *testing.T, store *db.MockDataStore, name string, cfg models.AppBuildConfig) {
+	t.Helper()
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateBuildTemplate(&models.BuildTemplate{Name: name, BuildConfig: string(data)}))
+}
+
+func TestResolve_NoTemplate(t *testing.T) {
+	store := db.NewMockDataStore()
+	app := newApp(t, store, "api", models.AppBuildConfig{Dockerfile: "Dockerfile.dev"})
+
+	cfg, err := Resolve(store, app)
+	require.NoError(t, err)
+	assert.Equal(t, "Dockerfile.dev", cfg.Dockerfile)
+}
+
+func TestResolve_InheritsTemplate(t *testing.T) {
+	store := db.NewMockDataStore()
+	newTemplate(t, store, "go-service", models.AppBuildConfig{
+		Dockerfile: "Dockerfile.go",
+		Args:       map[string]string{"GO_VERSION": "1.22", "CGO_ENABLED": "0"},
+	})
+	app := newApp(t, store, "api", models.AppBuildConfig{
+		Template: "go-service",
+		Args:     map[string]string{"GO_VERSION": "1.23"},
+	})
+
+	cfg, err := Resolve(store, app)
+	require.NoError(t, err)
+	assert.Equal(t, "Dockerfile.go", cfg.Dockerfile)
+	assert.Equal(t, map[string]string{"GO_VERSION": "1.23", "CGO_ENABLED": "0"}, cfg.Args)
+}
+
+func TestResolve_MissingTemplate(t *testing.T) {
+	store := db.NewMockDataStore()
+	app := newApp(t, store, "api", models.AppBuildConfig{Template: "gone"})
+
+	_, err := Resolve(store, app)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `build template "gone", which does not exist`)
+}
+
+func TestMarkDependents(t *testing.T) {
+	store := db.NewMockDataStore()
+	newTemplate(t, store, "go-service", models.AppBuildConfig{Dockerfile: "Dockerfile.go"})
+	api := newApp(t, store, "api", models.AppBuildConfig{Template: "go-service"})
+	other := newApp(t, store, "web", models.AppBuildConfig{Dockerfile: "Dockerfile"})
+
+	dev := &models.Workspace{Name: "dev", AppID: api.ID}
+	require.NoError(t, store.CreateWorkspace(dev))
+	unrelated := &models.Workspace{Name: "dev", AppID: other.ID}
+	require.NoError(t, store.CreateWorkspace(unrelated))
+
+	marked, err := MarkDependents(store, "go-service")
+	require.NoError(t, err)
+	require.Len(t, marked, 1)
+	assert.Equal(t, dev.ID, marked[0].ID)
+
+	reasons, err := store.ListWorkspaceRebuildReasons()
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{dev.ID: RebuildReason("go-service")}, reasons)
+}
@@ -7,6 +7,7 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/buildtemplate"
 )
 
 // defaultsCACertsKey is the key used in the defaults table to store global CA certs.
@@ -87,7 +88,13 @@ func (r *HierarchyCACertsResolver) Resolve(ctx context.Context, workspaceID int)
 
 	// ─── Parse each level ────────────────────────────────────────────────────
 	globalCerts := parseGlobalCACerts(r.store)
-	appCerts := parseWrappedCACerts(nullableString(app.BuildConfig.String, app.BuildConfig.Valid))
+	// The app level includes the certs of the app's build template; the
+	// app's own certs win by name
+	appBuild, err := buildtemplate.Resolve(r.store, app)
+	if err != nil {
+		return nil, fmt.Errorf("resolving CA certs: %w", err)
+	}
+	appCerts := appBuild.CACerts
 	wsCerts := parseWrappedDevBuildCACerts(nullableString(ws.BuildConfig.String, ws.BuildConfig.Valid))
 
 	// ─── Build path (always 6 entries) ───────────────────────────────────────
@@ -167,21 +174,6 @@ func parseDirectCACerts(raw string) []models.CACertConfig {
 	return certs
 }
 
-// parseWrappedCACerts parses a JSON string of the form {"caCerts": [...]}
-// (AppBuildConfig format). Used for: app.build_config.
-func parseWrappedCACerts(raw string) []models.CACertConfig {
-	if raw == "" {
-		return nil
-	}
-	var wrapper struct {
-		CACerts []models.CACertConfig `json:"caCerts"`
-	}
-	if err := json.Unmarshal([]byte(raw), &wrapper); err != nil {
-		return nil
-	}
-	return wrapper.CACerts
-}
-
 // parseWrappedDevBuildCACerts parses a JSON string of the form {"caCerts": [...]}
 // (DevBuildConfig format). Used for: workspace.build_config.
 func parseWrappedDevBuildCACerts(raw string) []models.CACertConfig {
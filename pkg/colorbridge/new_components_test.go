@@ -222,6 +222,22 @@ func TestThemeStoreAdapter_GetPalette_NotFound(t *testing.T) {
 	}
 }
 
+func TestThemeStoreAdapter_GetPalette_LibraryFallback(t *testing.T) {
+	store := &mockThemeStore{
+		themes:   map[string]*theme.Theme{},
+		basePath: "/test",
+	}
+	adapter := colorbridge.NewThemeStoreAdapter(store)
+
+	result, err := adapter.GetPalette("catppuccin-latte")
+	if err != nil {
+		t.Fatalf("GetPalette() error = %v, want library theme", err)
+	}
+	if result.Name != "catppuccin-latte" {
+		t.Errorf("GetPalette().Name = %q, want %q", result.Name, "catppuccin-latte")
+	}
+}
+
 func TestThemeStoreAdapter_GetPalette_NilStore(t *testing.T) {
 	adapter := colorbridge.NewThemeStoreAdapter(nil)
 
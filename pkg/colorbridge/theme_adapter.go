@@ -6,6 +6,7 @@ import (
 	"github.com/rmkohlman/MaestroPalette"
 	"github.com/rmkohlman/MaestroSDK/colors"
 	theme "github.com/rmkohlman/MaestroTheme"
+	"github.com/rmkohlman/MaestroTheme/library"
 )
 
 // ThemeStoreAdapter bridges theme.Store to colors.PaletteProvider interface.
@@ -50,7 +51,9 @@ func (a *ThemeStoreAdapter) GetActivePalette() (*palette.Palette, error) {
 }
 
 // GetPalette returns the palette for a specific theme by name.
-// Returns an error if the theme is not found.
+// Themes in the store take precedence over library themes of the same name,
+// so --theme and DVM_THEME accept any library theme without installing it.
+// Returns an error if the theme is found in neither.
 func (a *ThemeStoreAdapter) GetPalette(name string) (*palette.Palette, error) {
 	if a.store == nil {
 		// NoProviderError.message is unexported in the SDK, so we use fmt.Errorf
@@ -59,7 +62,11 @@ func (a *ThemeStoreAdapter) GetPalette(name string) (*palette.Palette, error) {
 
 	themeData, err := a.store.Get(name)
 	if err != nil {
-		return nil, err
+		libraryTheme, libErr := library.Get(name)
+		if libErr != nil {
+			return nil, err
+		}
+		themeData = libraryTheme
 	}
 
 	return themeData.ToPalette(), nil
@@ -176,6 +176,17 @@ func (m *MockDataStore) DeleteWorkspaceByID(id int) error
 func (m *MockDataStore) ListWorkspaces() ([]*models.Workspace, error)               { return nil, nil }
 func (m *MockDataStore) ListWorkspacesByApp(appID int) ([]*models.Workspace, error) { return nil, nil }
 func (m *MockDataStore) ListAllWorkspaces() ([]*models.Workspace, error)            { return nil, nil }
+func (m *MockDataStore) ListAllWorkspacesPage(opts db.ListOptions) ([]*models.Workspace, error) {
+	return nil, nil
+}
+func (m *MockDataStore) ArchiveResource(kind string, id int) error { return nil }
+func (m *MockDataStore) RestoreResource(kind string, id int) error { return nil }
+func (m *MockDataStore) ListArchivedResources() ([]*models.ArchivedResource, error) {
+	return nil, nil
+}
+func (m *MockDataStore) GetArchivedIDs() (*models.ArchivedIDs, error) {
+	return &models.ArchivedIDs{}, nil
+}
 func (m *MockDataStore) FindWorkspaces(filter models.WorkspaceFilter) ([]*models.WorkspaceWithHierarchy, error) {
 	return nil, nil
 }
@@ -193,8 +204,13 @@ func (m *MockDataStore) GetPluginByName(name string) (*models.NvimPluginDB, erro
 func (m *MockDataStore) GetPluginByID(id int) (*models.NvimPluginDB, error)        { return nil, nil }
 func (m *MockDataStore) UpdatePlugin(plugin *models.NvimPluginDB) error            { return nil }
 func (m *MockDataStore) UpsertPlugin(plugin *models.NvimPluginDB) error            { return nil }
+func (m *MockDataStore) CreatePlugins(plugins []*models.NvimPluginDB) error        { return nil }
+func (m *MockDataStore) UpsertPlugins(plugins []*models.NvimPluginDB) error        { return nil }
 func (m *MockDataStore) DeletePlugin(name string) error                            { return nil }
 func (m *MockDataStore) ListPlugins() ([]*models.NvimPluginDB, error)              { return nil, nil }
+func (m *MockDataStore) ListPluginsPage(opts db.ListOptions) ([]*models.NvimPluginDB, error) {
+	return nil, nil
+}
 func (m *MockDataStore) ListPluginsByCategory(category string) ([]*models.NvimPluginDB, error) {
 	return nil, nil
 }
@@ -266,7 +282,8 @@ func (m *MockDataStore) GetCredentialByName(name string) (*models.CredentialDB,
 func (m *MockDataStore) Driver() db.Driver { return nil }
 
 // Connection methods
-func (m *MockDataStore) Ping() error { return nil }
+func (m *MockDataStore) Ping() error                                  { return nil }
+func (m *MockDataStore) WithContext(ctx context.Context) db.DataStore { return m }
 
 // Migration methods
 func (m *MockDataStore) MigrationVersion() (int, error) { return 0, nil }
@@ -313,6 +330,7 @@ func (m *MockDataStore) ListRegistriesByStatus(status string) ([]*models.Registr
 func (m *MockDataStore) CreatePackage(pkg *models.NvimPackageDB) error         { return nil }
 func (m *MockDataStore) UpdatePackage(pkg *models.NvimPackageDB) error         { return nil }
 func (m *MockDataStore) UpsertPackage(pkg *models.NvimPackageDB) error         { return nil }
+func (m *MockDataStore) UpsertPackages(pkgs []*models.NvimPackageDB) error     { return nil }
 func (m *MockDataStore) DeletePackage(name string) error                       { return nil }
 func (m *MockDataStore) GetPackage(name string) (*models.NvimPackageDB, error) { return nil, nil }
 func (m *MockDataStore) ListPackages() ([]*models.NvimPackageDB, error)        { return nil, nil }
@@ -528,6 +546,13 @@ func (m *MockDataStore) UpdateBuildSessionWorkspace(bsw *models.BuildSessionWork
 func (m *MockDataStore) GetBuildSessionWorkspaces(sessionID string) ([]*models.BuildSessionWorkspace, error) {
 	return nil, nil
 }
+func (m *MockDataStore) GetLatestBuildSessionWorkspace(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	return nil, nil
+}
+func (m *MockDataStore) SetBuildSessionWorkspaceSBOM(id int, sbom string) error { return nil }
+func (m *MockDataStore) GetLatestBuildSBOM(workspaceID int) (*models.BuildSessionWorkspace, error) {
+	return nil, nil
+}
 func (m *MockDataStore) GetBuildSessionStats(sessionID string) (int, int, error)     { return 0, 0, nil }
 func (m *MockDataStore) UpdateWorkspaceImage(workspaceID int, imageTag string) error { return nil }
 func (m *MockDataStore) ListAppsByGitRepoID(gitRepoID int64) ([]*models.App, error) {
@@ -563,6 +588,95 @@ func (m *MockDataStore) MoveApp(appID int, newDomainID, newSystemID sql.NullInt6
 	return nil
 }
 
+// Workspace attach tracking stubs.
+func (m *MockDataStore) RecordWorkspaceAttach(workspaceID int) error { return nil }
+func (m *MockDataStore) UpdateWorkspaceStatus(workspaceID int, status string) error {
+	return nil
+}
+func (m *MockDataStore) SetWorkspaceContainerID(workspaceID int, containerID string) error {
+	return nil
+}
+func (m *MockDataStore) ListWorkspaceLastAttached() (map[int]time.Time, error) {
+	return nil, nil
+}
+func (m *MockDataStore) SetWorkspaceRebuildReason(workspaceID int, reason string) error {
+	return nil
+}
+func (m *MockDataStore) ListWorkspaceRebuildReasons() (map[int]string, error) { return nil, nil }
+func (m *MockDataStore) SetWorkspaceBuildInputs(workspaceID int, hash string) error {
+	return nil
+}
+func (m *MockDataStore) ListWorkspaceBuildInputs() (map[int]string, error) { return nil, nil }
+func (m *MockDataStore) SetWorkspaceImageDigest(workspaceID int, digest string) error {
+	return nil
+}
+func (m *MockDataStore) ListWorkspaceImageDigests() (map[int]string, error) { return nil, nil }
+
+// Orphaned workspace plugin stubs.
+func (m *MockDataStore) CountOrphanedWorkspacePlugins() (int, error)    { return 0, nil }
+func (m *MockDataStore) DeleteOrphanedWorkspacePlugins() (int64, error) { return 0, nil }
+
+// App session layout stubs.
+func (m *MockDataStore) GetAppSessionLayout(appID int) (*models.AppSessionLayout, error) {
+	return nil, nil
+}
+func (m *MockDataStore) SetAppSessionLayout(layout *models.AppSessionLayout) error { return nil }
+func (m *MockDataStore) DeleteAppSessionLayout(appID int) error                    { return nil }
+
+// Runtime endpoint stubs.
+func (m *MockDataStore) GetRuntimeEndpoint(ecosystemID int) (*models.RuntimeEndpoint, error) {
+	return nil, nil
+}
+func (m *MockDataStore) SetRuntimeEndpoint(endpoint *models.RuntimeEndpoint) error { return nil }
+func (m *MockDataStore) DeleteRuntimeEndpoint(ecosystemID int) error               { return nil }
+
+// Build template stubs.
+func (m *MockDataStore) CreateBuildTemplate(tmpl *models.BuildTemplate) error { return nil }
+func (m *MockDataStore) UpdateBuildTemplate(tmpl *models.BuildTemplate) error { return nil }
+func (m *MockDataStore) GetBuildTemplate(name string) (*models.BuildTemplate, error) {
+	return nil, nil
+}
+func (m *MockDataStore) ListBuildTemplates() ([]*models.BuildTemplate, error) { return nil, nil }
+func (m *MockDataStore) DeleteBuildTemplate(name string) error                { return nil }
+
+// Sync history stubs.
+func (m *MockDataStore) CreateSyncHistory(history *models.SyncHistory) error { return nil }
+func (m *MockDataStore) GetSyncHistory(id int) (*models.SyncHistory, error)  { return nil, nil }
+func (m *MockDataStore) MarkSyncHistoryRolledBack(id int) error              { return nil }
+func (m *MockDataStore) ListSyncHistory(source string, limit int) ([]*models.SyncHistory, error) {
+	return nil, nil
+}
+
+// Base image stubs.
+func (m *MockDataStore) SetBaseImage(img *models.BaseImage) error { return nil }
+func (m *MockDataStore) GetBaseImage(language, version string) (*models.BaseImage, error) {
+	return nil, nil
+}
+func (m *MockDataStore) ListBaseImages() ([]*models.BaseImage, error)   { return nil, nil }
+func (m *MockDataStore) DeleteBaseImage(language, version string) error { return nil }
+
+// Managed field stubs.
+func (m *MockDataStore) ListManagedFields(kind, name string) ([]*models.ManagedField, error) {
+	return nil, nil
+}
+func (m *MockDataStore) ReplaceManagedFields(kind, name, scope string, fields []*models.ManagedField) error {
+	return nil
+}
+func (m *MockDataStore) DeleteManagedFields(kind, name string) error { return nil }
+
+// Resource revision stubs.
+func (m *MockDataStore) CreateResourceRevision(rev *models.ResourceRevision, keep int) error {
+	return nil
+}
+func (m *MockDataStore) ListResourceRevisions(kind, name, scope string) ([]*models.ResourceRevision, error) {
+	return nil, nil
+}
+func (m *MockDataStore) DeleteResourceRevisions(kind, name string) error { return nil }
+func (m *MockDataStore) ListLegacyProjects() ([]*models.LegacyProject, error) {
+	return nil, nil
+}
+func (m *MockDataStore) RetireLegacyProject(projectID, appID int) error { return nil }
+
 // MockThemeStore implements theme.Store for testing
 type MockThemeStore struct {
 	themes   map[string]*theme.Theme
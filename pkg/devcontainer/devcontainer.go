@@ -0,0 +1,175 @@
+// Package devcontainer converts between VS Code devcontainer.json files and
+// dvm App and Workspace resources, so a team can share one environment
+// definition whether its members use dvm or VS Code.
+//
+// Only the settings with a dvm equivalent are converted: the image or
+// Dockerfile, language features, forwarded ports, mounts, container
+// environment, the working directory, and postCreateCommand. Everything else
+// is reported as a warning rather than dropped silently.
+package devcontainer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is the subset of devcontainer.json that dvm reads and writes.
+type Config struct {
+	Name              string            `json:"name,omitempty"`
+	Image             string            `json:"image,omitempty"`
+	Build             *BuildConfig      `json:"build,omitempty"`
+	DockerComposeFile any               `json:"dockerComposeFile,omitempty"`
+	Features          map[string]any    `json:"features,omitempty"`
+	ForwardPorts      []any             `json:"forwardPorts,omitempty"`
+	Mounts            []any             `json:"mounts,omitempty"`
+	ContainerEnv      map[string]string `json:"containerEnv,omitempty"`
+	RemoteEnv         map[string]string `json:"remoteEnv,omitempty"`
+	ContainerUser     string            `json:"containerUser,omitempty"`
+	RemoteUser        string            `json:"remoteUser,omitempty"`
+	WorkspaceFolder   string            `json:"workspaceFolder,omitempty"`
+	PostCreateCommand any               `json:"postCreateCommand,omitempty"`
+}
+
+// BuildConfig is the build section of devcontainer.json. Paths are relative
+// to the directory holding devcontainer.json.
+type BuildConfig struct {
+	Dockerfile string            `json:"dockerfile,omitempty"`
+	Context    string            `json:"context,omitempty"`
+	Args       map[string]string `json:"args,omitempty"`
+	Target     string            `json:"target,omitempty"`
+}
+
+// ErrNotFound is returned by Find when a directory has no devcontainer.json.
+var ErrNotFound = errors.New("no devcontainer.json found")
+
+// Find returns the devcontainer.json for path. A file path is returned as is;
+// for a directory, .devcontainer/devcontainer.json and .devcontainer.json are
+// checked in that order.
+func Find(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	for _, candidate := range []string{
+		filepath.Join(path, ".devcontainer", "devcontainer.json"),
+		filepath.Join(path, ".devcontainer.json"),
+	} {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w in %s", ErrNotFound, path)
+}
+
+// Load reads and parses the devcontainer.json at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse parses devcontainer.json content. Comments and trailing commas are
+// allowed, as VS Code allows them.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(standardize(data), &cfg); err != nil {
+		return nil, fmt.Errorf("invalid devcontainer.json: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Marshal returns cfg as indented JSON.
+func (c *Config) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// standardize turns JSON with comments into plain JSON: it removes // and /*
+// */ comments and commas that directly precede a closing bracket.
+func standardize(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, c)
+		}
+	}
+	return dropTrailingCommas(out)
+}
+
+// dropTrailingCommas removes commas followed only by whitespace and a closing
+// bracket. data must not contain comments.
+func dropTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+		}
+		if c == ',' {
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,210 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"devopsmaestro/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleConfig = `{
+  // Go service with a Postgres client
+  "name": "api",
+  "image": "mcr.microsoft.com/devcontainers/go:1-1.22-bookworm",
+  "features": {
+    "ghcr.io/devcontainers/features/common-utils:2": {},
+    "ghcr.io/devcontainers/features/node:1": { "version": "20" },
+    "ghcr.io/devcontainers/features/docker-in-docker:2": {},
+  },
+  "forwardPorts": [8080, "localhost:9090", "db:5432"],
+  "mounts": [
+    "source=${localEnv:HOME}/.ssh,target=/home/vscode/.ssh,type=bind,readonly",
+    { "source": "api-cache", "target": "/cache", "type": "volume" }
+  ],
+  "containerEnv": { "GOFLAGS": "-mod=mod" },
+  "remoteEnv": { "PATH": "${containerEnv:PATH}:/extra" },
+  "workspaceFolder": "/workspace",
+  /* runs once after the container is created */
+  "postCreateCommand": "go mod download",
+}`
+
+func TestParse_AcceptsCommentsAndTrailingCommas(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "api", cfg.Name)
+	assert.Len(t, cfg.Features, 3)
+	assert.Equal(t, "go mod download", cfg.PostCreateCommand)
+}
+
+func TestParse_KeepsCommentMarkersInStrings(t *testing.T) {
+	cfg, err := Parse([]byte(`{"image": "example.com/a//b:1", "postCreateCommand": "echo '/* x */', done",}`))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/a//b:1", cfg.Image)
+	assert.Equal(t, "echo '/* x */', done", cfg.PostCreateCommand)
+}
+
+func TestImport(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	require.NoError(t, err)
+
+	imp := Import(cfg, ImportOptions{
+		AppName: "api", WorkspaceName: "dev", Domain: "backend", Ecosystem: "acme",
+		AppPath: "/src/api", ConfigDir: "/src/api/.devcontainer",
+	})
+
+	app := imp.App
+	assert.Equal(t, "App", app.Kind)
+	assert.Equal(t, models.AppMetadata{Name: "api", Domain: "backend", Ecosystem: "acme"}, app.Metadata)
+	assert.Equal(t, "/src/api", app.Spec.Path)
+	assert.Equal(t, models.AppLanguageConfig{Name: "golang", Version: "1.22"}, app.Spec.Language)
+	assert.Equal(t, []string{"8080:8080", "9090:9090"}, app.Spec.Ports)
+
+	ws := imp.Workspace
+	assert.Equal(t, "Workspace", ws.Kind)
+	assert.Equal(t, "api", ws.Metadata.App)
+	assert.Empty(t, ws.Spec.Image.BaseImage, "language images only set the language")
+	assert.Equal(t, []string{"nodejs"}, ws.Spec.Nvim.Languages)
+	assert.Equal(t, []models.MountConfig{
+		{Type: "bind", Source: "${HOME}/.ssh", Destination: "/home/dev/.ssh", ReadOnly: true},
+		{Type: "volume", Source: "api-cache", Destination: "/cache"},
+	}, ws.Spec.Mounts)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-mod=mod"}, ws.Spec.Env)
+	assert.Equal(t, "/workspace", ws.Spec.Container.WorkingDir)
+	assert.Equal(t, []string{"go mod download"}, ws.Spec.Build.DevStage.CustomCommands)
+
+	assert.Len(t, imp.Warnings, 4)
+	assert.Contains(t, imp.Warnings[0], "docker-in-docker")
+	assert.Contains(t, imp.Warnings[1], "db:5432")
+	assert.Contains(t, imp.Warnings[2], "PATH")
+	assert.Contains(t, imp.Warnings[3], "postCreateCommand")
+}
+
+func TestImport_DockerfileBuild(t *testing.T) {
+	cfg := &Config{
+		Image: "registry.example.com/team/base:2",
+		Build: &BuildConfig{Dockerfile: "Dockerfile", Context: "..", Args: map[string]string{"V": "1"}},
+	}
+	imp := Import(cfg, ImportOptions{AppName: "api", WorkspaceName: "dev", AppPath: "/src/api", ConfigDir: "/src/api/.devcontainer"})
+
+	assert.Equal(t, ".devcontainer/Dockerfile", imp.App.Spec.Build.Dockerfile)
+	assert.Equal(t, ".", imp.App.Spec.Build.Context)
+	assert.Equal(t, map[string]string{"V": "1"}, imp.App.Spec.Build.Args)
+	assert.Equal(t, "registry.example.com/team/base:2", imp.Workspace.Spec.Image.BaseImage)
+	assert.Empty(t, imp.App.Spec.Language.Name)
+}
+
+func TestCommandList(t *testing.T) {
+	assert.Equal(t, []string{"make setup"}, commandList("make setup"))
+	assert.Equal(t, []string{"npm install 'a b'"}, commandList([]any{"npm", "install", "a b"}))
+	assert.Equal(t, []string{"go mod download", "npm ci"}, commandList(map[string]any{"web": "npm ci", "api": "go mod download"}))
+	assert.Nil(t, commandList(nil))
+}
+
+func TestImageLanguage(t *testing.T) {
+	tests := []struct {
+		image, name, version string
+	}{
+		{"golang:1.22", "golang", "1.22"},
+		{"python:3.12-slim", "python", "3.12"},
+		{"node:20-alpine", "nodejs", "20"},
+		{"mcr.microsoft.com/devcontainers/python:1-3.12-bookworm", "python", "3.12"},
+		{"mcr.microsoft.com/devcontainers/typescript-node:20", "nodejs", "20"},
+		{"mcr.microsoft.com/devcontainers/rust:latest", "rust", ""},
+		{"mcr.microsoft.com/devcontainers/base:ubuntu", "", ""},
+		{"localhost:5000/golang", "golang", ""},
+	}
+	for _, tt := range tests {
+		name, version := imageLanguage(tt.image)
+		assert.Equal(t, tt.name, name, tt.image)
+		assert.Equal(t, tt.version, version, tt.image)
+	}
+}
+
+func TestExport(t *testing.T) {
+	app := models.AppYAML{
+		Metadata: models.AppMetadata{Name: "api"},
+		Spec: models.AppSpec{
+			Language: models.AppLanguageConfig{Name: "golang", Version: "1.22"},
+			Ports:    []string{"8080:8080", "9000"},
+		},
+	}
+	ws := models.WorkspaceYAML{
+		Metadata: models.WorkspaceMetadata{Name: "dev"},
+		Spec: models.WorkspaceSpec{
+			Nvim: models.NvimConfig{Languages: []string{"typescript", "go"}},
+			Mounts: []models.MountConfig{
+				{Type: "bind", Source: "${APP_PATH}/data", Destination: "/data"},
+				{Type: "bind", Source: "${HOME}/.ssh", Destination: "/home/dev/.ssh", ReadOnly: true},
+			},
+			Env: map[string]string{"GOFLAGS": "-mod=mod"},
+			Build: models.DevBuildConfig{DevStage: models.DevStageConfig{
+				CustomCommands: []string{"go mod download", "make tools"},
+			}},
+		},
+	}
+
+	cfg, warnings := Export(app, ws)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "api-dev", cfg.Name)
+	assert.Equal(t, DefaultImage, cfg.Image)
+	assert.Equal(t, map[string]any{
+		"ghcr.io/devcontainers/features/go:1":   map[string]any{"version": "1.22"},
+		"ghcr.io/devcontainers/features/node:1": map[string]any{},
+	}, cfg.Features)
+	assert.Equal(t, []any{8080, 9000}, cfg.ForwardPorts)
+	assert.Equal(t, []any{
+		"source=${localWorkspaceFolder}/data,target=/data,type=bind",
+		"source=${localEnv:HOME}/.ssh,target=/home/dev/.ssh,type=bind,readonly",
+	}, cfg.Mounts)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-mod=mod"}, cfg.ContainerEnv)
+	assert.Equal(t, "go mod download && make tools", cfg.PostCreateCommand)
+}
+
+func TestExport_Dockerfile(t *testing.T) {
+	app := models.AppYAML{Spec: models.AppSpec{
+		Language: models.AppLanguageConfig{Name: "elixir"},
+		Build:    models.AppBuildConfig{Dockerfile: "build/Dockerfile", Target: "dev"},
+	}}
+	cfg, warnings := Export(app, models.WorkspaceYAML{})
+	assert.Equal(t, &BuildConfig{Dockerfile: "../build/Dockerfile", Context: "..", Target: "dev"}, cfg.Build)
+	assert.Empty(t, cfg.Image)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "elixir")
+}
+
+// TestRoundTrip checks that an exported config imports back to the same
+// language, ports, mounts, env, and commands.
+func TestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := Parse([]byte(sampleConfig))
+	require.NoError(t, err)
+	imp := Import(cfg, ImportOptions{AppName: "api", WorkspaceName: "dev", AppPath: dir, ConfigDir: filepath.Join(dir, ".devcontainer")})
+
+	exported, _ := Export(imp.App, imp.Workspace)
+	data, err := exported.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".devcontainer"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".devcontainer", "devcontainer.json"), data, 0644))
+
+	path, err := Find(dir)
+	require.NoError(t, err)
+	reloaded, err := Load(path)
+	require.NoError(t, err)
+	again := Import(reloaded, ImportOptions{AppName: "api", WorkspaceName: "dev", AppPath: dir, ConfigDir: filepath.Dir(path)})
+
+	assert.Equal(t, imp.App.Spec.Language, again.App.Spec.Language)
+	assert.Equal(t, imp.App.Spec.Ports, again.App.Spec.Ports)
+	assert.Equal(t, imp.Workspace.Spec.Nvim.Languages, again.Workspace.Spec.Nvim.Languages)
+	assert.Equal(t, imp.Workspace.Spec.Mounts, again.Workspace.Spec.Mounts)
+	assert.Equal(t, imp.Workspace.Spec.Env, again.Workspace.Spec.Env)
+	assert.Equal(t, imp.Workspace.Spec.Build.DevStage.CustomCommands, again.Workspace.Spec.Build.DevStage.CustomCommands)
+	assert.Empty(t, again.Workspace.Spec.Image.BaseImage)
+}
+
+func TestFind_NotFound(t *testing.T) {
+	_, err := Find(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,135 @@
+package devcontainer
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/models"
+)
+
+// DefaultImage is the image an exported devcontainer.json uses when the app
+// has no Dockerfile and the workspace no base image; language features
+// install the toolchain on top of it.
+const DefaultImage = baseImageRepo + ":ubuntu"
+
+// Export converts an app and one of its workspaces into a devcontainer.json
+// meant for the app's .devcontainer directory: build paths are relative to
+// it. It returns the config and the settings that could not be exported.
+func Export(app models.AppYAML, workspace models.WorkspaceYAML) (*Config, []string) {
+	var warnings []string
+	cfg := &Config{Name: app.Metadata.Name + "-" + workspace.Metadata.Name}
+
+	switch build := app.Spec.Build; {
+	case build.Dockerfile != "":
+		context := build.Context
+		if context == "" {
+			context = "."
+		}
+		cfg.Build = &BuildConfig{
+			Dockerfile: path.Join("..", build.Dockerfile),
+			Context:    path.Join("..", context),
+			Args:       build.Args,
+			Target:     build.Target,
+		}
+	case workspace.Spec.Image.BaseImage != "":
+		cfg.Image = workspace.Spec.Image.BaseImage
+	default:
+		cfg.Image = DefaultImage
+	}
+
+	languages := append([]string{app.Spec.Language.Name}, workspace.Spec.Nvim.Languages...)
+	for i, lang := range languages {
+		if lang == "" {
+			continue
+		}
+		feature := languageFeature(lang)
+		if feature == "" {
+			warnings = append(warnings, "language "+lang+" has no devcontainer feature; install it in the image")
+			continue
+		}
+		if cfg.Features == nil {
+			cfg.Features = map[string]any{}
+		}
+		id := officialFeatures + feature + ":1"
+		if _, ok := cfg.Features[id]; ok {
+			continue
+		}
+		options := map[string]any{}
+		if i == 0 && app.Spec.Language.Version != "" {
+			options["version"] = app.Spec.Language.Version
+		}
+		cfg.Features[id] = options
+	}
+
+	for _, p := range app.Spec.Ports {
+		// "host:container" forwards the container port.
+		port := p[strings.LastIndex(p, ":")+1:]
+		if n, err := strconv.Atoi(port); err == nil {
+			cfg.ForwardPorts = append(cfg.ForwardPorts, n)
+		} else {
+			warnings = append(warnings, "port "+p+" is not a port mapping; skipped")
+		}
+	}
+
+	for _, m := range workspace.Spec.Mounts {
+		fields := []string{
+			"source=" + devcontainerVariables(m.Source),
+			"target=" + m.Destination,
+			"type=" + m.Type,
+		}
+		if m.ReadOnly {
+			fields = append(fields, "readonly")
+		}
+		cfg.Mounts = append(cfg.Mounts, strings.Join(fields, ","))
+	}
+
+	if len(workspace.Spec.Env) > 0 {
+		cfg.ContainerEnv = workspace.Spec.Env
+	}
+
+	if commands := workspace.Spec.Build.DevStage.CustomCommands; len(commands) > 0 {
+		cfg.PostCreateCommand = strings.Join(commands, " && ")
+	}
+	if len(workspace.Spec.Build.BaseStage.Packages) > 0 || len(workspace.Spec.Build.DevStage.Packages) > 0 {
+		warnings = append(warnings, "system packages (spec.build.baseStage/devStage.packages) are not exported; add them to the image")
+	}
+	if len(app.Spec.Services) > 0 {
+		warnings = append(warnings, "app services are not exported; run them with Docker Compose")
+	}
+	return cfg, warnings
+}
+
+// languageFeature returns the official feature that installs a language, or
+// "" when there is none. Both dvm language names and feature names work.
+func languageFeature(lang string) string {
+	lang = strings.ToLower(lang)
+	if _, ok := featureLanguages[lang]; ok {
+		return lang
+	}
+	switch lang {
+	case "javascript", "typescript":
+		return "node"
+	}
+	for feature, l := range featureLanguages {
+		if l == lang {
+			return feature
+		}
+	}
+	return ""
+}
+
+var envVariablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// devcontainerVariables rewrites dvm's host variables to devcontainer ones,
+// the reverse of hostVariables.
+func devcontainerVariables(s string) string {
+	return envVariablePattern.ReplaceAllStringFunc(s, func(v string) string {
+		name := envVariablePattern.FindStringSubmatch(v)[1]
+		if name == "APP_PATH" {
+			return "${localWorkspaceFolder}"
+		}
+		return "${localEnv:" + name + "}"
+	})
+}
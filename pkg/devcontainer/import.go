@@ -0,0 +1,426 @@
+package devcontainer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge"
+)
+
+// officialFeatures is the registry prefix of the features maintained by the
+// devcontainers project.
+const officialFeatures = "ghcr.io/devcontainers/features/"
+
+// featureLanguages maps official feature names to dvm language names.
+var featureLanguages = map[string]string{
+	"go":     "golang",
+	"python": "python",
+	"node":   "nodejs",
+	"rust":   "rust",
+	"java":   "java",
+	"dotnet": "dotnet",
+	"ruby":   "ruby",
+	"php":    "php",
+}
+
+// builtinFeatures install tools every dvm image already has.
+var builtinFeatures = map[string]bool{
+	"common-utils": true,
+	"git":          true,
+}
+
+// imageLanguages maps image repository names, both the devcontainers images
+// and the official Docker Hub ones, to dvm language names.
+var imageLanguages = map[string]string{
+	"go":              "golang",
+	"golang":          "golang",
+	"python":          "python",
+	"node":            "nodejs",
+	"javascript-node": "nodejs",
+	"typescript-node": "nodejs",
+	"rust":            "rust",
+	"java":            "java",
+	"openjdk":         "java",
+	"eclipse-temurin": "java",
+	"dotnet":          "dotnet",
+	"ruby":            "ruby",
+	"php":             "php",
+}
+
+// baseImageRepo is the devcontainers image without a language toolchain.
+const baseImageRepo = "mcr.microsoft.com/devcontainers/base"
+
+var versionPattern = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// ImportOptions names the resources Import creates and locates the app.
+type ImportOptions struct {
+	AppName       string
+	WorkspaceName string
+	Domain        string
+	Ecosystem     string
+
+	// AppPath is the app's source directory.
+	AppPath string
+
+	// ConfigDir is the directory holding devcontainer.json; build paths in
+	// the file are relative to it.
+	ConfigDir string
+}
+
+// Imported is the result of Import.
+type Imported struct {
+	App       models.AppYAML
+	Workspace models.WorkspaceYAML
+
+	// Warnings lists the settings that were not converted, or were converted
+	// with a change in behavior.
+	Warnings []string
+}
+
+// Import converts cfg into an App and a Workspace.
+func Import(cfg *Config, opts ImportOptions) *Imported {
+	imp := &Imported{
+		App: models.AppYAML{
+			APIVersion: "devopsmaestro.io/v1",
+			Kind:       "App",
+			Metadata: models.AppMetadata{
+				Name:      opts.AppName,
+				Domain:    opts.Domain,
+				Ecosystem: opts.Ecosystem,
+			},
+			Spec: models.AppSpec{Path: opts.AppPath},
+		},
+		Workspace: models.WorkspaceYAML{
+			APIVersion: "devopsmaestro.io/v1",
+			Kind:       "Workspace",
+			Metadata: models.WorkspaceMetadata{
+				Name:      opts.WorkspaceName,
+				App:       opts.AppName,
+				Domain:    opts.Domain,
+				Ecosystem: opts.Ecosystem,
+			},
+			Spec: models.WorkspaceSpec{
+				Image: models.ImageConfig{Name: fmt.Sprintf("dvm-%s-%s:pending", opts.WorkspaceName, opts.AppName)},
+				Env:   map[string]string{},
+			},
+		},
+	}
+
+	imp.importImage(cfg, opts)
+	imp.importFeatures(cfg)
+	imp.importPorts(cfg)
+	imp.importMounts(cfg)
+	imp.importEnv(cfg)
+	imp.importCommands(cfg)
+
+	if cfg.WorkspaceFolder != "" {
+		imp.Workspace.Spec.Container.WorkingDir = cfg.WorkspaceFolder
+	}
+	if lang := imp.App.Spec.Language.Name; lang != "" {
+		imp.App.Spec.NvimPackage = nvimbridge.GetLanguagePackage(lang)
+	}
+	return imp
+}
+
+func (imp *Imported) warnf(format string, args ...any) {
+	imp.Warnings = append(imp.Warnings, fmt.Sprintf(format, args...))
+}
+
+// importImage maps a Dockerfile build to the app's build config, and the image
+// to the app language or spec.image.baseImage.
+func (imp *Imported) importImage(cfg *Config, opts ImportOptions) {
+	if cfg.DockerComposeFile != nil {
+		imp.warnf("dockerComposeFile is not supported; declare the services in the app's spec.services")
+	}
+	if cfg.Build != nil && cfg.Build.Dockerfile != "" {
+		build := &imp.App.Spec.Build
+		build.Dockerfile = appRelative(opts, cfg.Build.Dockerfile)
+		if cfg.Build.Context != "" {
+			build.Context = appRelative(opts, cfg.Build.Context)
+		}
+		build.Args = cfg.Build.Args
+		build.Target = cfg.Build.Target
+	}
+	if cfg.Image == "" {
+		return
+	}
+	// dvm builds its own image for a language, so a language image only
+	// contributes the language and version, and the generic base image
+	// nothing.
+	if name, version := imageLanguage(cfg.Image); name != "" {
+		imp.App.Spec.Language = models.AppLanguageConfig{Name: name, Version: version}
+		return
+	}
+	if strings.HasPrefix(cfg.Image, baseImageRepo+":") {
+		return
+	}
+	imp.Workspace.Spec.Image.BaseImage = cfg.Image
+}
+
+// appRelative converts a path relative to the devcontainer.json directory
+// into one relative to the app.
+func appRelative(opts ImportOptions, p string) string {
+	abs := filepath.Join(opts.ConfigDir, p)
+	if rel, err := filepath.Rel(opts.AppPath, abs); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return abs
+}
+
+// imageLanguage returns the language and version of a language image such as
+// golang:1.22 or mcr.microsoft.com/devcontainers/python:1-3.12-bookworm.
+func imageLanguage(image string) (name, version string) {
+	repo, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		repo, tag = image[:i], image[i+1:]
+	}
+	name = imageLanguages[path.Base(repo)]
+	if name == "" {
+		return "", ""
+	}
+	parts := strings.Split(tag, "-")
+	// devcontainers images prefix the language version with their own major
+	// version (1-3.12-bookworm).
+	if strings.Contains(repo, "/devcontainers/") && len(parts) > 1 &&
+		versionPattern.MatchString(parts[1]) && !strings.Contains(parts[0], ".") {
+		return name, parts[1]
+	}
+	if versionPattern.MatchString(parts[0]) {
+		return name, parts[0]
+	}
+	return name, ""
+}
+
+// importFeatures maps language features to the app language and the
+// workspace's nvim.languages. Features are visited in sorted order, so the
+// result does not depend on map iteration.
+func (imp *Imported) importFeatures(cfg *Config) {
+	ids := make([]string, 0, len(cfg.Features))
+	for id := range cfg.Features {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		name, official := featureName(id)
+		if official && builtinFeatures[name] {
+			continue
+		}
+		lang := ""
+		if official {
+			lang = featureLanguages[name]
+		}
+		if lang == "" {
+			imp.warnf("feature %s has no dvm equivalent; skipped", id)
+			continue
+		}
+
+		version := ""
+		if opts, ok := cfg.Features[id].(map[string]any); ok {
+			version, _ = opts["version"].(string)
+		} else if v, ok := cfg.Features[id].(string); ok {
+			version = v
+		}
+		if version == "latest" || version == "lts" || version == "none" {
+			version = ""
+		}
+
+		current := &imp.App.Spec.Language
+		switch {
+		case current.Name == "":
+			*current = models.AppLanguageConfig{Name: lang, Version: version}
+		case current.Name == lang:
+			if current.Version == "" {
+				current.Version = version
+			}
+		default:
+			nvim := &imp.Workspace.Spec.Nvim
+			if !slices.Contains(nvim.Languages, lang) {
+				nvim.Languages = append(nvim.Languages, lang)
+			}
+		}
+	}
+}
+
+// featureName returns the short name of a feature ID and whether it is an
+// official feature. Legacy IDs without a registry ("go") are official.
+func featureName(id string) (string, bool) {
+	ref := id
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	if !strings.Contains(ref, "/") {
+		return ref, true
+	}
+	return path.Base(ref), strings.HasPrefix(ref, officialFeatures)
+}
+
+// importPorts maps forwardPorts to app ports. Ports of other compose services
+// ("db:5432") have no dvm equivalent.
+func (imp *Imported) importPorts(cfg *Config) {
+	for _, p := range cfg.ForwardPorts {
+		switch v := p.(type) {
+		case float64:
+			port := strconv.Itoa(int(v))
+			imp.App.Spec.Ports = append(imp.App.Spec.Ports, port+":"+port)
+		case string:
+			port := v
+			if host, p, ok := strings.Cut(v, ":"); ok {
+				if host != "localhost" && host != "127.0.0.1" {
+					imp.warnf("forwarded port %s belongs to another service; skipped", v)
+					continue
+				}
+				port = p
+			}
+			if _, err := strconv.Atoi(port); err != nil {
+				imp.warnf("forwarded port %q is not a port number; skipped", v)
+				continue
+			}
+			imp.App.Spec.Ports = append(imp.App.Spec.Ports, port+":"+port)
+		}
+	}
+}
+
+// importMounts maps mounts to workspace mounts, rewriting devcontainer
+// variables to the ones dvm expands and the remote user's home to /home/dev.
+func (imp *Imported) importMounts(cfg *Config) {
+	user := cfg.RemoteUser
+	if user == "" {
+		user = cfg.ContainerUser
+	}
+	if user == "" {
+		user = "vscode"
+	}
+	homes := strings.NewReplacer("/home/"+user+"/", "/home/dev/")
+
+	for _, m := range cfg.Mounts {
+		var mount models.MountConfig
+		switch v := m.(type) {
+		case string:
+			mount = parseMountString(v)
+		case map[string]any:
+			mount.Type, _ = v["type"].(string)
+			mount.Source, _ = v["source"].(string)
+			mount.Destination, _ = v["target"].(string)
+		}
+		if mount.Destination == "" {
+			imp.warnf("mount %v has no target; skipped", m)
+			continue
+		}
+		if mount.Type == "" {
+			mount.Type = "bind"
+		}
+		mount.Source = hostVariables(mount.Source)
+		mount.Destination = homes.Replace(mount.Destination)
+		imp.Workspace.Spec.Mounts = append(imp.Workspace.Spec.Mounts, mount)
+	}
+}
+
+// parseMountString parses the Docker --mount syntax used in devcontainer.json:
+// "source=...,target=...,type=bind,readonly".
+func parseMountString(s string) models.MountConfig {
+	var mount models.MountConfig
+	for _, field := range strings.Split(s, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "type":
+			mount.Type = value
+		case "source", "src":
+			mount.Source = value
+		case "target", "destination", "dst":
+			mount.Destination = value
+		case "readonly", "ro":
+			mount.ReadOnly = value == "" || value == "true" || value == "1"
+		}
+	}
+	return mount
+}
+
+var localEnvPattern = regexp.MustCompile(`\$\{localEnv:([A-Za-z_][A-Za-z0-9_]*)(:[^}]*)?\}`)
+
+// hostVariables rewrites devcontainer host variables to dvm's: the app
+// directory becomes ${APP_PATH} and ${localEnv:X} becomes ${X}.
+func hostVariables(s string) string {
+	s = strings.ReplaceAll(s, "${localWorkspaceFolder}", "${APP_PATH}")
+	return localEnvPattern.ReplaceAllString(s, "${$1}")
+}
+
+// importEnv maps containerEnv and remoteEnv to workspace env. Values that
+// reference devcontainer variables are skipped: dvm sets env values verbatim.
+func (imp *Imported) importEnv(cfg *Config) {
+	for _, env := range []map[string]string{cfg.ContainerEnv, cfg.RemoteEnv} {
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if strings.Contains(env[k], "${") {
+				imp.warnf("env %s references a devcontainer variable (%s); skipped", k, env[k])
+				continue
+			}
+			imp.Workspace.Spec.Env[k] = env[k]
+		}
+	}
+}
+
+// importCommands maps postCreateCommand to dev stage custom commands.
+func (imp *Imported) importCommands(cfg *Config) {
+	commands := commandList(cfg.PostCreateCommand)
+	if len(commands) == 0 {
+		return
+	}
+	imp.Workspace.Spec.Build.DevStage.CustomCommands = commands
+	imp.warnf("postCreateCommand runs while the image is built (spec.build.devStage.customCommands), before the app source is mounted")
+}
+
+// commandList flattens a lifecycle command: a shell string, an argument list,
+// or an object of named commands run in parallel (taken in name order).
+func commandList(cmd any) []string {
+	switch v := cmd.(type) {
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		return []string{v}
+	case []any:
+		args := make([]string, 0, len(v))
+		for _, a := range v {
+			args = append(args, shellQuote(fmt.Sprint(a)))
+		}
+		if len(args) == 0 {
+			return nil
+		}
+		return []string{strings.Join(args, " ")}
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var commands []string
+		for _, name := range names {
+			commands = append(commands, commandList(v[name])...)
+		}
+		return commands
+	}
+	return nil
+}
+
+// shellQuote quotes s for sh when it contains anything but safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@%+,") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,180 @@
+// Package envresolver resolves the environment of a workspace container. It
+// layers the env set on the workspace's ecosystem, domain, and app under the
+// workspace's own env (most specific wins) and substitutes
+// ((credential:NAME)) references with resolved credential values.
+package envresolver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/pkg/envvalidation"
+)
+
+// Hierarchy levels an env layer can come from, least specific first.
+const (
+	LevelEcosystem = "ecosystem"
+	LevelDomain    = "domain"
+	LevelApp       = "app"
+	LevelWorkspace = "workspace"
+)
+
+// MaskedValue replaces the value of a variable that holds a credential.
+const MaskedValue = "********"
+
+// credentialRef matches a ((credential:NAME)) reference in an env value.
+var credentialRef = regexp.MustCompile(`\(\(credential:([^()\s]+)\)\)`)
+
+// Layer is the env set at one hierarchy level.
+type Layer struct {
+	Level string
+	Name  string
+	Env   map[string]string
+}
+
+// Var is one resolved environment variable.
+type Var struct {
+	Key   string `yaml:"key" json:"key"`
+	Value string `yaml:"value" json:"value"`
+	// Source is the level the value comes from, e.g. "domain/backend".
+	Source string `yaml:"source" json:"source"`
+	// Secret is true when the value holds a credential.
+	Secret bool `yaml:"secret,omitempty" json:"secret,omitempty"`
+}
+
+// Resolution is the resolved environment of a workspace.
+type Resolution struct {
+	// Vars are the resolved variables, sorted by key.
+	Vars []Var
+	// Warnings report variables that were dropped: invalid keys and
+	// references to credentials that did not resolve.
+	Warnings []string
+}
+
+// Env returns the resolved variables as a map, for container injection.
+func (r *Resolution) Env() map[string]string {
+	env := make(map[string]string, len(r.Vars))
+	for _, v := range r.Vars {
+		env[v.Key] = v.Value
+	}
+	return env
+}
+
+// Masked returns the resolved variables with credential values masked.
+func (r *Resolution) Masked() []Var {
+	vars := make([]Var, len(r.Vars))
+	for i, v := range r.Vars {
+		if v.Secret {
+			v.Value = MaskedValue
+		}
+		vars[i] = v
+	}
+	return vars
+}
+
+// Validate checks the keys of a spec.env, in sorted order so the first
+// invalid key reported is stable.
+func Validate(env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := envvalidation.ValidateEnvKey(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Layers loads the env layers of a workspace from the store: its ecosystem,
+// domain, app, and the workspace itself. Levels the workspace has no parent
+// at are left out.
+func Layers(store db.DataStore, workspaceID int) ([]Layer, error) {
+	ws, err := store.GetWorkspaceByID(workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving env: getting workspace %d: %w", workspaceID, err)
+	}
+	app, err := store.GetAppByID(ws.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("resolving env: getting app %d: %w", ws.AppID, err)
+	}
+
+	var layers []Layer
+	if app.DomainID.Valid {
+		domain, err := store.GetDomainByID(int(app.DomainID.Int64))
+		if err != nil {
+			return nil, fmt.Errorf("resolving env: getting domain %d: %w", app.DomainID.Int64, err)
+		}
+		if domain.EcosystemID.Valid {
+			eco, err := store.GetEcosystemByID(int(domain.EcosystemID.Int64))
+			if err != nil {
+				return nil, fmt.Errorf("resolving env: getting ecosystem %d: %w", domain.EcosystemID.Int64, err)
+			}
+			layers = append(layers, Layer{Level: LevelEcosystem, Name: eco.Name, Env: eco.GetEnv()})
+		}
+		layers = append(layers, Layer{Level: LevelDomain, Name: domain.Name, Env: domain.GetEnv()})
+	}
+	return append(layers,
+		Layer{Level: LevelApp, Name: app.Name, Env: app.GetEnv()},
+		Layer{Level: LevelWorkspace, Name: ws.Name, Env: ws.GetEnv()},
+	), nil
+}
+
+// Resolve merges layers, least specific first, and substitutes credential
+// references from credentials (resolved credential values by name). A
+// variable with an invalid key or an unresolved reference is dropped with a
+// warning; it does not fall back to a less specific layer's value.
+func Resolve(layers []Layer, credentials map[string]string) *Resolution {
+	merged := make(map[string]Var)
+	for _, layer := range layers {
+		for k, v := range layer.Env {
+			merged[k] = Var{Key: k, Value: v, Source: layer.Level + "/" + layer.Name}
+		}
+	}
+
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := &Resolution{}
+	for _, k := range keys {
+		v := merged[k]
+		if err := envvalidation.ValidateEnvKey(k); err != nil {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("env %s (%s) skipped: %v", k, v.Source, err))
+			continue
+		}
+		value, secret, missing := substitute(v.Value, credentials)
+		if len(missing) > 0 {
+			res.Warnings = append(res.Warnings, fmt.Sprintf("env %s (%s) skipped: credential %s not found",
+				k, v.Source, strings.Join(missing, ", ")))
+			continue
+		}
+		v.Value, v.Secret = value, secret
+		res.Vars = append(res.Vars, v)
+	}
+	return res
+}
+
+// substitute replaces the credential references in value. It reports whether
+// value held any reference and which referenced credentials are missing.
+func substitute(value string, credentials map[string]string) (string, bool, []string) {
+	var missing []string
+	secret := false
+	out := credentialRef.ReplaceAllStringFunc(value, func(ref string) string {
+		secret = true
+		name := credentialRef.FindStringSubmatch(ref)[1]
+		cred, ok := credentials[name]
+		if !ok {
+			missing = append(missing, name)
+		}
+		return cred
+	})
+	return out, secret, missing
+}
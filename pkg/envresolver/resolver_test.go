@@ -0,0 +1,94 @@
+package envresolver
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+)
+
+func TestLayers(t *testing.T) {
+	store := db.NewMockDataStore()
+	eco := &models.Ecosystem{ID: 1, Name: "corp"}
+	eco.SetEnv(map[string]string{"LOG_LEVEL": "info"})
+	store.Ecosystems[eco.Name] = eco
+	domain := &models.Domain{ID: 2, Name: "backend", EcosystemID: sql.NullInt64{Int64: 1, Valid: true}}
+	store.Domains[domain.ID] = domain
+	app := &models.App{ID: 3, Name: "api", DomainID: sql.NullInt64{Int64: 2, Valid: true}}
+	app.SetEnv(map[string]string{"PORT": "8080"})
+	store.Apps[app.ID] = app
+	ws := &models.Workspace{ID: 4, Name: "dev", AppID: 3}
+	ws.SetEnv(map[string]string{"LOG_LEVEL": "debug"})
+	store.Workspaces[ws.ID] = ws
+
+	layers, err := Layers(store, ws.ID)
+	if err != nil {
+		t.Fatalf("Layers() error = %v", err)
+	}
+	var got []string
+	for _, l := range layers {
+		got = append(got, l.Level+"/"+l.Name)
+	}
+	want := []string{"ecosystem/corp", "domain/backend", "app/api", "workspace/dev"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Layers() = %v, want %v", got, want)
+	}
+
+	env := Resolve(layers, nil).Env()
+	wantEnv := map[string]string{"LOG_LEVEL": "debug", "PORT": "8080"}
+	if !reflect.DeepEqual(env, wantEnv) {
+		t.Errorf("Resolve().Env() = %v, want %v", env, wantEnv)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	layers := []Layer{
+		{Level: LevelEcosystem, Name: "corp", Env: map[string]string{
+			"REGION":   "eu-west-1",
+			"API_KEY":  "((credential:eco-key))",
+			"DB_URL":   "postgres://app:((credential:DB_PASSWORD))@db:5432/app",
+			"lower":    "x",
+			"GH_TOKEN": "plain",
+		}},
+		{Level: LevelWorkspace, Name: "dev", Env: map[string]string{
+			"GH_TOKEN": "((credential:missing))",
+		}},
+	}
+	res := Resolve(layers, map[string]string{"eco-key": "k3y", "DB_PASSWORD": "s3cret"})
+
+	want := []Var{
+		{Key: "API_KEY", Value: "k3y", Source: "ecosystem/corp", Secret: true},
+		{Key: "DB_URL", Value: "postgres://app:s3cret@db:5432/app", Source: "ecosystem/corp", Secret: true},
+		{Key: "REGION", Value: "eu-west-1", Source: "ecosystem/corp"},
+	}
+	if !reflect.DeepEqual(res.Vars, want) {
+		t.Errorf("Resolve().Vars = %+v, want %+v", res.Vars, want)
+	}
+	if len(res.Warnings) != 2 ||
+		!strings.Contains(res.Warnings[0], "GH_TOKEN (workspace/dev) skipped: credential missing not found") ||
+		!strings.Contains(res.Warnings[1], "lower") {
+		t.Errorf("Resolve().Warnings = %v", res.Warnings)
+	}
+
+	masked := res.Masked()
+	if masked[0].Value != MaskedValue || masked[2].Value != "eu-west-1" {
+		t.Errorf("Masked() = %+v", masked)
+	}
+	if res.Vars[0].Value != "k3y" {
+		t.Error("Masked() modified the resolution")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(map[string]string{"PORT": "1", "LOG_LEVEL": "info"}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, key := range []string{"lower", "LD_PRELOAD", "DVM_APP"} {
+		if err := Validate(map[string]string{key: "x"}); err == nil {
+			t.Errorf("Validate(%q) = nil, want error", key)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+// Package envtemplate implements portable environment templates: a directory
+// containing a template.yaml manifest (metadata and parameters) plus resource
+// YAML files with ${param:name} placeholders. Templates are shared through git
+// repositories or OCI registries and instantiated into a single List document
+// that the apply pipeline understands.
+//
+// # Layout
+//
+//	backend-stack/
+//	  template.yaml          # kind: EnvironmentTemplate
+//	  resources/domain.yaml  # any YAML, may contain several documents
+//	  resources/apps.yaml
+//
+// # Placeholders
+//
+// ${param:name} is replaced with the parameter value. ${{param:name}} is an
+// escape that renders as the literal text ${param:name}. Placeholders are
+// substituted inside YAML scalars after parsing, never into the raw text, so
+// a parameter value cannot inject YAML structure.
+package envtemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ManifestFile is the file name of the template manifest at the package root.
+	ManifestFile = "template.yaml"
+	// Kind is the manifest kind.
+	Kind = "EnvironmentTemplate"
+	// APIVersion is the manifest API version.
+	APIVersion = "devopsmaestro.io/v1"
+)
+
+// Manifest is the parsed template.yaml.
+type Manifest struct {
+	APIVersion string   `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string   `yaml:"kind" json:"kind"`
+	Metadata   Metadata `yaml:"metadata" json:"metadata"`
+	Spec       Spec     `yaml:"spec" json:"spec"`
+}
+
+// Metadata describes a template.
+type Metadata struct {
+	Name        string            `yaml:"name" json:"name"`
+	Version     string            `yaml:"version,omitempty" json:"version,omitempty"`
+	Description string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Author      string            `yaml:"author,omitempty" json:"author,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// Spec lists the template's parameters and resource files.
+type Spec struct {
+	Parameters []Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	// Resources are file paths or globs relative to the package root.
+	// Defaults to resources/*.yaml.
+	Resources []string `yaml:"resources,omitempty" json:"resources,omitempty"`
+}
+
+// Parameter declares a value the user supplies at install time.
+type Parameter struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     string   `yaml:"default,omitempty" json:"default,omitempty"`
+	Required    bool     `yaml:"required,omitempty" json:"required,omitempty"`
+	Pattern     string   `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Enum        []string `yaml:"enum,omitempty" json:"enum,omitempty"`
+}
+
+// DefaultResources is used when the manifest lists no resources.
+var DefaultResources = []string{"resources/*.yaml", "resources/*.yml"}
+
+// paramNameRegex restricts parameter names to identifier-like strings.
+var paramNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.-]*$`)
+
+// placeholderRegex matches ${param:name} and its escaped form ${{param:name}}.
+var placeholderRegex = regexp.MustCompile(`\$\{(\{)?param:([a-zA-Z_][a-zA-Z0-9_.-]*)\}(\})?`)
+
+// Template is a loaded template package.
+type Template struct {
+	Manifest Manifest
+	// Files maps slash-separated paths (relative to the package root) to
+	// the raw resource file contents, in manifest order.
+	Files map[string][]byte
+	// Order is the order resource files are rendered in.
+	Order []string
+}
+
+// ParseManifest parses and validates template.yaml content.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks the manifest structure (not parameter values).
+func (m *Manifest) Validate() error {
+	if m.Kind != Kind {
+		return fmt.Errorf("%s: expected kind %s, got %q", ManifestFile, Kind, m.Kind)
+	}
+	if m.Metadata.Name == "" {
+		return fmt.Errorf("%s: metadata.name is required", ManifestFile)
+	}
+	if err := ValidateParameters(m.Spec.Parameters); err != nil {
+		return fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	return nil
+}
+
+// ValidateParameters checks parameter declarations: names, uniqueness,
+// patterns, and that defaults pass their own checks.
+func ValidateParameters(params []Parameter) error {
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		if !paramNameRegex.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name %q", p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate parameter %q", p.Name)
+		}
+		seen[p.Name] = true
+		if p.Pattern != "" {
+			if _, err := regexp.Compile(p.Pattern); err != nil {
+				return fmt.Errorf("parameter %q has invalid pattern: %w", p.Name, err)
+			}
+		}
+		if p.Default != "" {
+			if err := p.Check(p.Default); err != nil {
+				return fmt.Errorf("default for %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Check validates a single value against the parameter's pattern and enum.
+func (p Parameter) Check(value string) error {
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return fmt.Errorf("parameter %q has invalid pattern: %w", p.Name, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("parameter %q: value %q does not match pattern %s", p.Name, value, p.Pattern)
+		}
+	}
+	if len(p.Enum) > 0 {
+		for _, allowed := range p.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("parameter %q: value %q must be one of: %s", p.Name, value, strings.Join(p.Enum, ", "))
+	}
+	return nil
+}
+
+// Load reads a template package from a directory.
+func Load(dir string) (*Template, error) {
+	return LoadFS(os.DirFS(dir))
+}
+
+// LoadFS reads a template package from fsys, whose root is the package root.
+func LoadFS(fsys fs.FS) (*Template, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("not a template package: %w", err)
+	}
+	m, err := ParseManifest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := m.Spec.Resources
+	if len(patterns) == 0 {
+		patterns = DefaultResources
+	}
+
+	t := &Template{Manifest: *m, Files: make(map[string][]byte)}
+	for _, pattern := range patterns {
+		pattern = path.Clean(filepath.ToSlash(pattern))
+		if path.IsAbs(pattern) || strings.HasPrefix(pattern, "../") {
+			return nil, fmt.Errorf("%s: resource path %q must be inside the package", ManifestFile, pattern)
+		}
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid resource pattern %q: %w", ManifestFile, pattern, err)
+		}
+		sort.Strings(matches)
+		for _, name := range matches {
+			if _, dup := t.Files[name]; dup {
+				continue
+			}
+			content, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			t.Files[name] = content
+			t.Order = append(t.Order, name)
+		}
+	}
+	if len(t.Order) == 0 {
+		return nil, fmt.Errorf("template %q contains no resource files", m.Metadata.Name)
+	}
+	return t, nil
+}
+
+// Parameter returns the named parameter declaration.
+func (t *Template) Parameter(name string) (Parameter, bool) {
+	return findParameter(t.Manifest.Spec.Parameters, name)
+}
+
+func findParameter(params []Parameter, name string) (Parameter, bool) {
+	for _, p := range params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Parameter{}, false
+}
+
+// Missing returns the required parameters that have neither a value in
+// values nor a default, in declaration order.
+func (t *Template) Missing(values map[string]string) []Parameter {
+	return MissingParameters(t.Manifest.Spec.Parameters, values)
+}
+
+// MissingParameters returns the required params that have neither a value
+// in values nor a default, in declaration order.
+func MissingParameters(params []Parameter, values map[string]string) []Parameter {
+	var out []Parameter
+	for _, p := range params {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+		if p.Required && p.Default == "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Resolve merges values with parameter defaults and validates the result.
+// Unknown keys in values are rejected so typos in --set are caught.
+func (t *Template) Resolve(values map[string]string) (map[string]string, error) {
+	return ResolveParameters(t.Manifest.Metadata.Name, t.Manifest.Spec.Parameters, values)
+}
+
+// ResolveParameters merges values with the defaults of params and validates
+// the result. template names the owning template in errors.
+func ResolveParameters(template string, params []Parameter, values map[string]string) (map[string]string, error) {
+	for k := range values {
+		if _, ok := findParameter(params, k); !ok {
+			return nil, fmt.Errorf("unknown parameter %q for template %q", k, template)
+		}
+	}
+
+	resolved := make(map[string]string, len(params))
+	var problems []string
+	for _, p := range params {
+		v, ok := values[p.Name]
+		if !ok {
+			v = p.Default
+		}
+		if v == "" {
+			if p.Required {
+				problems = append(problems, fmt.Sprintf("parameter %q is required", p.Name))
+			}
+			resolved[p.Name] = ""
+			continue
+		}
+		if err := p.Check(v); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		resolved[p.Name] = v
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid parameters:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return resolved, nil
+}
+
+// Render substitutes the resolved parameters into every resource file and
+// returns a List document with the resources sorted in dependency order.
+// Placeholders naming undeclared parameters are an error.
+func (t *Template) Render(values map[string]string) ([]byte, error) {
+	resolved, err := t.Resolve(values)
+	if err != nil {
+		return nil, err
+	}
+
+	type item struct {
+		kind string
+		doc  map[string]any
+	}
+	var items []item
+	for _, name := range t.Order {
+		dec := yaml.NewDecoder(bytes.NewReader(t.Files[name]))
+		for i := 0; ; i++ {
+			var node yaml.Node
+			if err := dec.Decode(&node); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("%s: document %d: %w", name, i+1, err)
+			}
+			if err := Substitute(&node, resolved); err != nil {
+				return nil, fmt.Errorf("%s: document %d: %w", name, i+1, err)
+			}
+			var doc map[string]any
+			if err := node.Decode(&doc); err != nil {
+				return nil, fmt.Errorf("%s: document %d: %w", name, i+1, err)
+			}
+			if len(doc) == 0 {
+				continue
+			}
+			kind, _ := doc["kind"].(string)
+			if kind == "" {
+				return nil, fmt.Errorf("%s: document %d has no kind", name, i+1)
+			}
+			if kind == "List" {
+				return nil, fmt.Errorf("%s: List documents are not allowed in templates", name)
+			}
+			items = append(items, item{kind: kind, doc: doc})
+		}
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("template %q rendered no resources", t.Manifest.Metadata.Name)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return dependencyRank(items[i].kind) < dependencyRank(items[j].kind)
+	})
+
+	list := resource.NewResourceList()
+	list.Metadata["template"] = t.Manifest.Metadata.Name
+	if t.Manifest.Metadata.Version != "" {
+		list.Metadata["templateVersion"] = t.Manifest.Metadata.Version
+	}
+	for _, it := range items {
+		list.Items = append(list.Items, it.doc)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(list); err != nil {
+		return nil, fmt.Errorf("failed to encode rendered resources: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Substitute replaces ${param:name} placeholders in the scalars of a parsed
+// document. Values are substituted after parsing, so a value containing
+// ": ", "#" or a newline stays a single string and cannot change the
+// document structure. Plain scalars have their tag cleared so that, for
+// example, "replicas: ${param:count}" still decodes as a number.
+func Substitute(node *yaml.Node, values map[string]string) error {
+	var unknown []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode {
+			if v := substituteString(n.Value, values, &unknown); v != n.Value {
+				n.Value = v
+				if n.Style == 0 {
+					n.Tag = ""
+				}
+			}
+			return
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(node)
+	if len(unknown) > 0 {
+		return fmt.Errorf("undeclared parameter(s): %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// substituteString replaces ${param:name} placeholders in s, recording
+// names missing from values in unknown.
+func substituteString(s string, values map[string]string, unknown *[]string) string {
+	return placeholderRegex.ReplaceAllStringFunc(s, func(match string) string {
+		m := placeholderRegex.FindStringSubmatch(match)
+		if m[1] != "" && m[3] != "" {
+			return "${param:" + m[2] + "}"
+		}
+		v, ok := values[m[2]]
+		if !ok {
+			*unknown = append(*unknown, m[2])
+			return match
+		}
+		return v + m[3]
+	})
+}
+
+// dependencyRank orders kinds by resource.DependencyOrder; unknown kinds
+// sort after the known ones, keeping their file order.
+func dependencyRank(kind string) int {
+	for i, k := range resource.DependencyOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(resource.DependencyOrder)
+}
@@ -0,0 +1,286 @@
+package envtemplate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testManifest = `apiVersion: devopsmaestro.io/v1
+kind: EnvironmentTemplate
+metadata:
+  name: backend-stack
+  version: 1.0.0
+spec:
+  parameters:
+    - name: domain
+      required: true
+      pattern: "^[a-z][a-z0-9-]*$"
+    - name: language
+      default: go
+      enum: [go, python]
+`
+
+const testResources = `apiVersion: devopsmaestro.io/v1
+kind: App
+metadata:
+  name: ${param:domain}-api
+  domain: ${param:domain}
+spec:
+  language: ${param:language}
+  note: "literal ${{param:domain}}"
+---
+apiVersion: devopsmaestro.io/v1
+kind: Domain
+metadata:
+  name: ${param:domain}
+`
+
+func writeTemplate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "resources"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(testManifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "resources", "stack.yaml"), []byte(testResources), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := Load(writeTemplate(t))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	out, err := tmpl.Render(map[string]string{"domain": "payments"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	var list struct {
+		Kind     string            `yaml:"kind"`
+		Metadata map[string]string `yaml:"metadata"`
+		Items    []map[string]any  `yaml:"items"`
+	}
+	if err := yaml.Unmarshal(out, &list); err != nil {
+		t.Fatalf("rendered output is not YAML: %v", err)
+	}
+	if list.Kind != "List" || list.Metadata["template"] != "backend-stack" || len(list.Items) != 2 {
+		t.Fatalf("unexpected list: %s", out)
+	}
+	if list.Items[0]["kind"] != "Domain" {
+		t.Errorf("Domain should be ordered before App, got %v", list.Items[0]["kind"])
+	}
+	s := string(out)
+	for _, want := range []string{"name: payments-api", "language: go", "literal ${param:domain}"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("rendered output missing %q:\n%s", want, s)
+		}
+	}
+}
+
+func TestResolve_Validation(t *testing.T) {
+	tmpl, _ := Load(writeTemplate(t))
+
+	if missing := tmpl.Missing(nil); len(missing) != 1 || missing[0].Name != "domain" {
+		t.Errorf("Missing() = %+v", missing)
+	}
+	for name, values := range map[string]map[string]string{
+		"missing required": {},
+		"pattern":          {"domain": "Payments"},
+		"enum":             {"domain": "payments", "language": "rust"},
+		"unknown":          {"domain": "payments", "domian": "x"},
+	} {
+		if _, err := tmpl.Resolve(values); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestRender_UndeclaredPlaceholder(t *testing.T) {
+	dir := writeTemplate(t)
+	extra := "kind: Workspace\nmetadata:\n  name: ${param:nope}\n"
+	os.WriteFile(filepath.Join(dir, "resources", "ws.yaml"), []byte(extra), 0644)
+
+	tmpl, _ := Load(dir)
+	if _, err := tmpl.Render(map[string]string{"domain": "payments"}); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("expected undeclared parameter error, got %v", err)
+	}
+}
+
+func TestParseManifest_Invalid(t *testing.T) {
+	for name, doc := range map[string]string{
+		"wrong kind":    "kind: Workspace\nmetadata: {name: x}\n",
+		"no name":       "kind: EnvironmentTemplate\n",
+		"bad default":   "kind: EnvironmentTemplate\nmetadata: {name: x}\nspec:\n  parameters:\n    - {name: a, default: c, enum: [a, b]}\n",
+		"duplicate":     "kind: EnvironmentTemplate\nmetadata: {name: x}\nspec:\n  parameters:\n    - {name: a}\n    - {name: a}\n",
+		"bad pattern":   "kind: EnvironmentTemplate\nmetadata: {name: x}\nspec:\n  parameters:\n    - {name: a, pattern: '['}\n",
+		"bad parameter": "kind: EnvironmentTemplate\nmetadata: {name: x}\nspec:\n  parameters:\n    - {name: 'a b'}\n",
+	} {
+		if _, err := ParseManifest([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	local := t.TempDir()
+	tests := []struct {
+		in   string
+		want Ref
+	}{
+		{local, Ref{Type: RefLocal, Location: local}},
+		{"org/backend-stack", Ref{Type: RefGit, Location: "https://github.com/org/backend-stack.git"}},
+		{"org/backend-stack@v1.2.0", Ref{Type: RefGit, Location: "https://github.com/org/backend-stack.git", Revision: "v1.2.0"}},
+		{"github:org/templates//backend@main", Ref{Type: RefGit, Location: "https://github.com/org/templates.git", Subdir: "backend", Revision: "main"}},
+		{"git@github.com:org/t.git", Ref{Type: RefGit, Location: "git@github.com:org/t.git"}},
+		{"https://git.example.com/t.git//stacks/api", Ref{Type: RefGit, Location: "https://git.example.com/t.git", Subdir: "stacks/api"}},
+		{"oci://ghcr.io/org/stack:1.0.0", Ref{Type: RefOCI, Location: "ghcr.io/org/stack:1.0.0"}},
+		{"oci+http://localhost:5000/stack", Ref{Type: RefOCI, Location: "localhost:5000/stack", PlainHTTP: true}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRef(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRef(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "./missing", "missing.tar.gz", "justaname"} {
+		if _, err := ParseRef(bad); err == nil {
+			t.Errorf("ParseRef(%q) expected error", bad)
+		}
+	}
+}
+
+func TestPackUnpackRoundTrip(t *testing.T) {
+	dir := writeTemplate(t)
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+	os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref"), 0644)
+
+	var buf bytes.Buffer
+	if err := Pack(dir, &buf); err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	archive := filepath.Join(t.TempDir(), "stack.tar.gz")
+	os.WriteFile(archive, buf.Bytes(), 0644)
+
+	ref, err := ParseRef(archive)
+	if err != nil || ref.Type != RefArchive {
+		t.Fatalf("ParseRef(archive) = %+v, %v", ref, err)
+	}
+	tmpl, err := Fetch(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Fetch(archive) error = %v", err)
+	}
+	if tmpl.Manifest.Metadata.Name != "backend-stack" || len(tmpl.Order) != 1 {
+		t.Errorf("unexpected template: %+v", tmpl)
+	}
+
+	out := t.TempDir()
+	Unpack(bytes.NewReader(buf.Bytes()), out)
+	if _, err := os.Stat(filepath.Join(out, ".git")); !os.IsNotExist(err) {
+		t.Error("hidden directories should not be packed")
+	}
+}
+
+func TestFetchOCI(t *testing.T) {
+	var layer bytes.Buffer
+	if err := Pack(writeTemplate(t), &layer); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(layer.Bytes())
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			json.NewEncoder(w).Encode(map[string]string{"token": "anon"})
+			return
+		case r.Header.Get("Authorization") != "Bearer anon":
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		case r.URL.Path == "/v2/org/stack/manifests/1.0.0":
+			json.NewEncoder(w).Encode(ociManifest{SchemaVersion: 2, Layers: []ociDescriptor{
+				{MediaType: LayerMediaType, Digest: digest, Size: int64(layer.Len())},
+			}})
+		case r.URL.Path == "/v2/org/stack/blobs/"+digest:
+			w.Write(layer.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(RegistryTokenEnv, "")
+	ref, _ := ParseRef("oci+http://" + strings.TrimPrefix(srv.URL, "http://") + "/org/stack:1.0.0")
+	tmpl, err := Fetch(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("Fetch(oci) error = %v", err)
+	}
+	if tmpl.Manifest.Metadata.Version != "1.0.0" {
+		t.Errorf("unexpected manifest: %+v", tmpl.Manifest)
+	}
+}
+
+func TestRender_ValuesCannotInjectYAML(t *testing.T) {
+	dir := t.TempDir()
+	manifest := "apiVersion: devopsmaestro.io/v1\nkind: EnvironmentTemplate\nmetadata:\n  name: inject\nspec:\n  parameters:\n    - name: note\n    - name: count\n"
+	resources := "kind: Domain\nmetadata:\n  name: d\n  description: ${param:note}\nspec:\n  replicas: ${param:count}\n"
+	if err := os.MkdirAll(filepath.Join(dir, "resources"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0644)
+	os.WriteFile(filepath.Join(dir, "resources", "d.yaml"), []byte(resources), 0644)
+	tmpl, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	for _, note := range []string{
+		"a: b",
+		"x # not a comment",
+		"line one\nkind: Secret",
+		"evil\nspec:\n  privileged: true",
+	} {
+		out, err := tmpl.Render(map[string]string{"note": note, "count": "3"})
+		if err != nil {
+			t.Fatalf("Render(%q) error = %v", note, err)
+		}
+		var list struct {
+			Items []struct {
+				Kind     string            `yaml:"kind"`
+				Metadata map[string]string `yaml:"metadata"`
+				Spec     map[string]any    `yaml:"spec"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(out, &list); err != nil {
+			t.Fatalf("Render(%q) output is not YAML: %v\n%s", note, err, out)
+		}
+		if len(list.Items) != 1 || list.Items[0].Kind != "Domain" {
+			t.Fatalf("Render(%q) items = %+v", note, list.Items)
+		}
+		item := list.Items[0]
+		if item.Metadata["description"] != note {
+			t.Errorf("description = %q, want %q", item.Metadata["description"], note)
+		}
+		if len(item.Spec) != 1 || item.Spec["replicas"] != 3 {
+			t.Errorf("Render(%q) spec = %v, want only replicas: 3", note, item.Spec)
+		}
+	}
+}
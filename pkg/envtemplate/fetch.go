@@ -0,0 +1,202 @@
+package envtemplate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// RefType identifies where a template reference points.
+type RefType string
+
+const (
+	RefLocal   RefType = "local"   // template directory on disk
+	RefGit     RefType = "git"     // git repository
+	RefOCI     RefType = "oci"     // OCI registry artifact
+	RefArchive RefType = "archive" // local .tar.gz/.tgz package
+)
+
+// Ref is a parsed template reference.
+type Ref struct {
+	Type RefType
+	// Location is the directory, archive path, git URL, or OCI reference
+	// (host/repository[:tag|@digest]).
+	Location string
+	// Revision is the git branch or tag to check out (git only).
+	Revision string
+	// Subdir is the package directory inside a git repository (git only).
+	Subdir string
+	// PlainHTTP talks to the OCI registry over http (oci+http://).
+	PlainHTTP bool
+}
+
+// ParseRef parses a template reference:
+//
+//	./backend-stack                        local directory
+//	backend-stack.tar.gz                   local packaged template
+//	org/backend-stack[@v1.2.0]             GitHub repository shorthand
+//	github:org/backend-stack[@ref]         GitHub repository shorthand
+//	https://git.example.com/t.git[@ref]    any git URL (also git@host:path.git)
+//	oci://ghcr.io/org/backend-stack:1.2.0  OCI registry artifact
+//	oci+http://localhost:5000/stack:dev    OCI registry over plain http
+//
+// A git reference may select a subdirectory with "//path", e.g.
+// org/templates//backend-stack@main.
+func ParseRef(s string) (Ref, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Ref{}, fmt.Errorf("empty template reference")
+	}
+
+	switch {
+	case strings.HasPrefix(s, "oci://"):
+		return Ref{Type: RefOCI, Location: strings.TrimPrefix(s, "oci://")}, nil
+	case strings.HasPrefix(s, "oci+http://"):
+		return Ref{Type: RefOCI, Location: strings.TrimPrefix(s, "oci+http://"), PlainHTTP: true}, nil
+	}
+
+	if info, err := os.Stat(s); err == nil {
+		if info.IsDir() {
+			return Ref{Type: RefLocal, Location: s}, nil
+		}
+		if isArchiveName(s) {
+			return Ref{Type: RefArchive, Location: s}, nil
+		}
+		return Ref{}, fmt.Errorf("%s is not a template directory or .tar.gz package", s)
+	}
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "/") || strings.HasPrefix(s, "~") || isArchiveName(s) {
+		return Ref{}, fmt.Errorf("template %s not found", s)
+	}
+
+	ref := Ref{Type: RefGit}
+	rest := strings.TrimPrefix(s, "github:")
+	fromGitHub := rest != s
+
+	// Split "@revision" from the final path element so git@host URLs survive.
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") && i > 0 {
+		ref.Revision = rest[i+1:]
+		rest = rest[:i]
+	}
+	// Split "//subdir", skipping the scheme separator.
+	searchFrom := 0
+	if i := strings.Index(rest, "://"); i >= 0 {
+		searchFrom = i + 3
+	}
+	if i := strings.Index(rest[searchFrom:], "//"); i >= 0 {
+		ref.Subdir = strings.Trim(rest[searchFrom+i+2:], "/")
+		rest = rest[:searchFrom+i]
+	}
+
+	switch {
+	case strings.Contains(rest, "://") || strings.HasPrefix(rest, "git@"):
+		ref.Location = rest
+	case fromGitHub || isGitHubShorthand(rest):
+		ref.Location = "https://github.com/" + strings.TrimSuffix(rest, ".git") + ".git"
+	default:
+		return Ref{}, fmt.Errorf("unrecognized template reference %q (expected a path, org/repo, git URL, or oci:// reference)", s)
+	}
+	if strings.Contains(ref.Subdir, "..") {
+		return Ref{}, fmt.Errorf("invalid template subdirectory %q", ref.Subdir)
+	}
+	return ref, nil
+}
+
+// String formats the reference for display.
+func (r Ref) String() string {
+	switch r.Type {
+	case RefOCI:
+		if r.PlainHTTP {
+			return "oci+http://" + r.Location
+		}
+		return "oci://" + r.Location
+	case RefGit:
+		s := r.Location
+		if r.Subdir != "" {
+			s += "//" + r.Subdir
+		}
+		if r.Revision != "" {
+			s += "@" + r.Revision
+		}
+		return s
+	default:
+		return r.Location
+	}
+}
+
+// Fetch materializes the template package a reference points to and loads
+// it. Remote packages are downloaded into a temporary directory that is
+// removed before Fetch returns.
+func Fetch(ctx context.Context, ref Ref) (*Template, error) {
+	switch ref.Type {
+	case RefLocal:
+		return Load(ref.Location)
+	case RefArchive:
+		return fetchArchive(ref.Location)
+	case RefGit:
+		return fetchGit(ctx, ref)
+	case RefOCI:
+		return fetchOCI(ctx, ref)
+	default:
+		return nil, fmt.Errorf("unsupported template reference type %q", ref.Type)
+	}
+}
+
+func fetchArchive(path string) (*Template, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir, err := os.MkdirTemp("", "dvm-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := Unpack(f, dir); err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %w", path, err)
+	}
+	return Load(dir)
+}
+
+func fetchGit(ctx context.Context, ref Ref) (*Template, error) {
+	dir, err := os.MkdirTemp("", "dvm-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if ref.Revision != "" {
+		args = append(args, "--branch", ref.Revision)
+	}
+	args = append(args, ref.Location, dir)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("git clone %s failed: %w: %s", ref.Location, err, strings.TrimSpace(string(out)))
+	}
+
+	root := dir
+	if ref.Subdir != "" {
+		root = filepath.Join(dir, filepath.FromSlash(ref.Subdir))
+	}
+	return Load(root)
+}
+
+// isGitHubShorthand reports whether s looks like "org/repo".
+func isGitHubShorthand(s string) bool {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return false
+	}
+	return !strings.Contains(parts[0], ".") && !strings.Contains(parts[0], ":")
+}
+
+func isArchiveName(s string) bool {
+	return strings.HasSuffix(s, ".tar.gz") || strings.HasSuffix(s, ".tgz")
+}
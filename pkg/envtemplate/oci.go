@@ -0,0 +1,252 @@
+package envtemplate
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+const (
+	// LayerMediaType is the media type of the packaged template layer.
+	// Publish with e.g.:
+	//
+	//	oras push ghcr.io/org/backend-stack:1.0.0 \
+	//	  backend-stack.tar.gz:application/vnd.devopsmaestro.template.layer.v1.tar+gzip
+	LayerMediaType = "application/vnd.devopsmaestro.template.layer.v1.tar+gzip"
+
+	// RegistryTokenEnv holds a bearer token for private registries.
+	RegistryTokenEnv = "DVM_REGISTRY_TOKEN"
+
+	maxManifestSize = 4 << 20
+)
+
+var manifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+var ociHTTPClient = httpclient.New(60 * time.Second)
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+// ociRef is a parsed host/repository[:tag|@digest].
+type ociRef struct {
+	host, repo, reference string
+}
+
+func parseOCIRef(s string) (ociRef, error) {
+	slash := strings.Index(s, "/")
+	if slash <= 0 {
+		return ociRef{}, fmt.Errorf("invalid OCI reference %q (expected host/repository[:tag])", s)
+	}
+	r := ociRef{host: s[:slash], repo: s[slash+1:], reference: "latest"}
+	if i := strings.Index(r.repo, "@"); i >= 0 {
+		r.repo, r.reference = r.repo[:i], r.repo[i+1:]
+	} else if i := strings.LastIndex(r.repo, ":"); i >= 0 {
+		r.repo, r.reference = r.repo[:i], r.repo[i+1:]
+	}
+	if r.repo == "" || r.reference == "" {
+		return ociRef{}, fmt.Errorf("invalid OCI reference %q", s)
+	}
+	return r, nil
+}
+
+// fetchOCI pulls the template layer of an OCI artifact via the registry
+// HTTP API (anonymous or DVM_REGISTRY_TOKEN bearer auth), verifies its
+// digest, and loads the unpacked package.
+func fetchOCI(ctx context.Context, ref Ref) (*Template, error) {
+	r, err := parseOCIRef(ref.Location)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "https"
+	if ref.PlainHTTP {
+		scheme = "http"
+	}
+	c := &ociClient{base: scheme + "://" + r.host, token: os.Getenv(RegistryTokenEnv)}
+
+	body, err := c.get(ctx, fmt.Sprintf("/v2/%s/manifests/%s", r.repo, r.reference), manifestAccept, r.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest for %s: %w", ref, err)
+	}
+	var m ociManifest
+	err = json.NewDecoder(io.LimitReader(body, maxManifestSize)).Decode(&m)
+	body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest for %s: %w", ref, err)
+	}
+
+	layer, ok := templateLayer(m.Layers)
+	if !ok {
+		return nil, fmt.Errorf("%s has no template layer (%s)", ref, LayerMediaType)
+	}
+	if layer.Size > maxUnpackSize {
+		return nil, fmt.Errorf("%s: template layer is too large (%d bytes)", ref, layer.Size)
+	}
+
+	blob, err := c.get(ctx, fmt.Sprintf("/v2/%s/blobs/%s", r.repo, layer.Digest), "", r.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch template layer: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(blob, maxUnpackSize+1))
+	blob.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch template layer: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != layer.Digest {
+		return nil, fmt.Errorf("template layer digest mismatch: got %s, want %s", got, layer.Digest)
+	}
+
+	dir, err := os.MkdirTemp("", "dvm-template-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := Unpack(bytes.NewReader(data), dir); err != nil {
+		return nil, fmt.Errorf("failed to unpack template layer: %w", err)
+	}
+	return Load(dir)
+}
+
+// templateLayer picks the template layer: the dvm media type, or failing
+// that the only gzip tarball layer.
+func templateLayer(layers []ociDescriptor) (ociDescriptor, bool) {
+	var tarballs []ociDescriptor
+	for _, l := range layers {
+		if l.MediaType == LayerMediaType {
+			return l, true
+		}
+		if strings.HasSuffix(l.MediaType, "tar+gzip") {
+			tarballs = append(tarballs, l)
+		}
+	}
+	if len(tarballs) == 1 {
+		return tarballs[0], true
+	}
+	return ociDescriptor{}, false
+}
+
+// ociClient is a minimal registry client for pulls.
+type ociClient struct {
+	base  string
+	token string
+}
+
+// get performs a GET, answering one bearer-token challenge if needed.
+func (c *ociClient) get(ctx context.Context, path, accept, repo string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		token, err := c.anonymousToken(ctx, challenge, repo)
+		if err != nil {
+			return nil, err
+		}
+		c.token = token
+		if resp, err = c.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (c *ociClient) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return ociHTTPClient.Do(req)
+}
+
+// anonymousToken requests a pull token from the realm named in a Bearer
+// challenge (the Docker registry token flow).
+func (c *ociClient) anonymousToken(ctx context.Context, challenge, repo string) (string, error) {
+	params := parseChallenge(challenge)
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("registry requires authentication (set %s)", RegistryTokenEnv)
+	}
+	q := url.Values{}
+	if s := params["service"]; s != "" {
+		q.Set("service", s)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + repo + ":pull"
+	}
+	q.Set("scope", scope)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ociHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token request returned %s (set %s for private registries)", resp.Status, RegistryTokenEnv)
+	}
+	var tok struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("invalid registry token response: %w", err)
+	}
+	if tok.Token != "" {
+		return tok.Token, nil
+	}
+	return tok.AccessToken, nil
+}
+
+// parseChallenge parses `Bearer realm="...",service="...",scope="..."`.
+func parseChallenge(h string) map[string]string {
+	out := map[string]string{}
+	h = strings.TrimSpace(h)
+	if !strings.HasPrefix(strings.ToLower(h), "bearer ") {
+		return out
+	}
+	for _, part := range strings.Split(h[len("bearer "):], ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok {
+			out[strings.ToLower(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return out
+}
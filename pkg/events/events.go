@@ -0,0 +1,351 @@
+// Package events sends dvm lifecycle events (workspace started, build
+// finished, sync completed, registry unhealthy) to webhooks and a local unix
+// socket as JSON, so builds and workspaces can drive Slack notifications or
+// local automation.
+//
+// Nothing is sent until Configure is called with at least one target. Emit
+// delivers in the background and retries failed deliveries; Flush waits for
+// pending deliveries and must be called before the process exits.
+//
+// # Usage
+//
+//	events.Configure("dvm", settings)
+//	defer events.Flush(ctx)
+//
+//	events.Emit(events.BuildFinished, map[string]any{"workspace": "dev", "status": "succeeded"})
+//
+// # Webhook requests
+//
+// Each event is POSTed as its JSON encoding (or, with FormatSlack, as a Slack
+// incoming-webhook message). The X-DVM-Event and X-DVM-Delivery headers carry
+// the event type and ID. When the webhook has a secret, X-DVM-Signature-256
+// is "sha256=" followed by the hex HMAC-SHA256 of the body, keyed with the
+// secret.
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+// Type names a lifecycle event.
+type Type string
+
+// Event types.
+const (
+	WorkspaceStarted  Type = "workspace.started"
+	BuildFinished     Type = "build.finished"
+	SyncCompleted     Type = "sync.completed"
+	RegistryUnhealthy Type = "registry.unhealthy"
+)
+
+// Webhook formats.
+const (
+	FormatJSON  = "json"  // the Event itself
+	FormatSlack = "slack" // {"text": "..."} for Slack incoming webhooks
+)
+
+// DefaultRetries is how often a failed delivery is retried.
+const DefaultRetries = 3
+
+// deliveryTimeout bounds one delivery attempt.
+const deliveryTimeout = 10 * time.Second
+
+// Event is the JSON document delivered for each lifecycle event.
+type Event struct {
+	ID     string         `json:"id"`
+	Type   Type           `json:"type"`
+	Time   time.Time      `json:"time"`
+	Source string         `json:"source"` // dvm or nvp
+	Data   map[string]any `json:"data,omitempty"`
+}
+
+// Webhook is an HTTP endpoint that receives events.
+type Webhook struct {
+	URL    string
+	Secret string // HMAC-SHA256 signing key; empty sends unsigned requests
+	Events []Type // types to deliver; empty delivers all
+	Format string // FormatJSON (default) or FormatSlack
+}
+
+// Settings configure where events go.
+type Settings struct {
+	Webhooks []Webhook
+	Socket   string // unix socket receiving one JSON event per line
+	Retries  int    // retries after a failed delivery; 0 uses DefaultRetries, negative disables
+}
+
+// Validate checks the webhook formats.
+func (s Settings) Validate() error {
+	for i, w := range s.Webhooks {
+		if w.URL == "" {
+			return errors.New("webhook URL is required")
+		}
+		switch w.Format {
+		case "", FormatJSON, FormatSlack:
+		default:
+			return fmt.Errorf("%s: unknown format %q (want %s or %s)", w.target(i), w.Format, FormatJSON, FormatSlack)
+		}
+	}
+	return nil
+}
+
+// Emitter delivers events to the configured targets.
+type Emitter struct {
+	source   string
+	settings Settings
+	client   *http.Client
+	wg       sync.WaitGroup
+
+	// backoff returns the wait before retry n (from 1)
+	backoff func(n int) time.Duration
+}
+
+// New returns an emitter for events from source (e.g. "dvm").
+func New(source string, s Settings) *Emitter {
+	return &Emitter{
+		source:   source,
+		settings: s,
+		client:   httpclient.New(deliveryTimeout),
+		backoff:  func(n int) time.Duration { return time.Duration(1<<(n-1)) * time.Second },
+	}
+}
+
+// Enabled reports whether the emitter has any target.
+func (e *Emitter) Enabled() bool {
+	return e != nil && (len(e.settings.Webhooks) > 0 || e.settings.Socket != "")
+}
+
+// Emit delivers an event of type t to every target in the background.
+func (e *Emitter) Emit(t Type, data map[string]any) {
+	if !e.Enabled() {
+		return
+	}
+	ev := Event{ID: newID(), Type: t, Time: time.Now().UTC(), Source: e.source, Data: data}
+	for i, w := range e.settings.Webhooks {
+		if !w.wants(t) {
+			continue
+		}
+		e.deliver(ev, w.target(i), func(ctx context.Context) (bool, error) {
+			return e.postWebhook(ctx, w, ev)
+		})
+	}
+	if e.settings.Socket != "" {
+		e.deliver(ev, "socket "+e.settings.Socket, func(ctx context.Context) (bool, error) {
+			return true, writeSocket(ctx, e.settings.Socket, ev)
+		})
+	}
+}
+
+// Flush waits for pending deliveries until ctx is done.
+func (e *Emitter) Flush(ctx context.Context) error {
+	if e == nil {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// deliver runs send with retries in the background. send reports whether a
+// failure is worth retrying.
+func (e *Emitter) deliver(ev Event, target string, send func(context.Context) (bool, error)) {
+	retries := e.settings.Retries
+	if retries == 0 {
+		retries = DefaultRetries
+	}
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		for attempt := 0; ; attempt++ {
+			ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+			retry, err := send(ctx)
+			cancel()
+			if err == nil {
+				slog.Debug("event delivered", "type", ev.Type, "id", ev.ID, "target", target)
+				return
+			}
+			if !retry || attempt >= retries {
+				slog.Warn("event delivery failed", "type", ev.Type, "id", ev.ID, "target", target, "error", err)
+				return
+			}
+			time.Sleep(e.backoff(attempt + 1))
+		}
+	}()
+}
+
+func (w Webhook) wants(t Type) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, want := range w.Events {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// target names the i-th webhook in logs and errors. Webhook URLs can be
+// bearer secrets (Slack incoming webhooks), so only the scheme and host are
+// shown.
+func (w Webhook) target(i int) string {
+	u, err := url.Parse(w.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Sprintf("webhook %d", i+1)
+	}
+	return fmt.Sprintf("webhook %d (%s://%s)", i+1, u.Scheme, u.Host)
+}
+
+// postWebhook sends ev to w. Server errors and 429 are retried; other
+// client errors are not.
+func (e *Emitter) postWebhook(ctx context.Context, w Webhook, ev Event) (bool, error) {
+	var payload any = ev
+	if w.Format == FormatSlack {
+		payload = map[string]string{"text": Summary(ev)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		// the parse error quotes the URL
+		return false, errors.New("invalid webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", e.source)
+	req.Header.Set("X-DVM-Event", string(ev.Type))
+	req.Header.Set("X-DVM-Delivery", ev.ID)
+	if w.Secret != "" {
+		req.Header.Set("X-DVM-Signature-256", Sign(w.Secret, body))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		// *url.Error quotes the URL; keep only the cause
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = fmt.Errorf("%s: %w", ue.Op, ue.Err)
+		}
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook returned %s", resp.Status)
+}
+
+// Sign returns the X-DVM-Signature-256 header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeSocket writes ev as one JSON line to the unix socket at path.
+func writeSocket(ctx context.Context, path string, ev Event) error {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	_, err = conn.Write(append(line, '\n'))
+	return err
+}
+
+// Summary is a one-line human-readable description of ev, used for Slack
+// messages.
+func Summary(ev Event) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", ev.Source, ev.Type)
+	keys := make([]string, 0, len(ev.Data))
+	for k := range ev.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		sep := ", "
+		if i == 0 {
+			sep = " ("
+		}
+		fmt.Fprintf(&b, "%s%s=%v", sep, k, ev.Data[k])
+	}
+	if len(keys) > 0 {
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+var (
+	defaultMu      sync.Mutex
+	defaultEmitter *Emitter
+)
+
+// Configure sets up the process-wide emitter used by Emit and Flush.
+func Configure(source string, s Settings) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultEmitter = New(source, s)
+}
+
+func current() *Emitter {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return defaultEmitter
+}
+
+// Emit sends an event through the process-wide emitter. It does nothing
+// until Configure has been called with a target.
+func Emit(t Type, data map[string]any) {
+	current().Emit(t, data)
+}
+
+// Flush waits for the process-wide emitter's pending deliveries.
+func Flush(ctx context.Context) error {
+	return current().Flush(ctx)
+}
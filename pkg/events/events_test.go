@@ -0,0 +1,167 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver records the requests a test webhook receives and answers with
+// the next status in statuses (200 once they run out).
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newEmitter(s Settings) *Emitter {
+	e := New("dvm", s)
+	e.backoff = func(int) time.Duration { return time.Millisecond }
+	return e
+}
+
+func flush(t *testing.T, e *Emitter) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, e.Flush(ctx))
+}
+
+func TestEmitter_Webhook(t *testing.T) {
+	rec := &receiver{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	e := newEmitter(Settings{Webhooks: []Webhook{{URL: srv.URL, Secret: "s3cret"}}})
+	e.Emit(BuildFinished, map[string]any{"workspace": "dev", "status": "succeeded"})
+	flush(t, e)
+
+	require.Len(t, rec.requests, 1)
+	req, body := rec.requests[0], rec.bodies[0]
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "build.finished", req.Header.Get("X-DVM-Event"))
+	assert.Equal(t, Sign("s3cret", body), req.Header.Get("X-DVM-Signature-256"))
+
+	var ev Event
+	require.NoError(t, json.Unmarshal(body, &ev))
+	assert.Equal(t, BuildFinished, ev.Type)
+	assert.Equal(t, "dvm", ev.Source)
+	assert.Equal(t, req.Header.Get("X-DVM-Delivery"), ev.ID)
+	assert.Equal(t, map[string]any{"workspace": "dev", "status": "succeeded"}, ev.Data)
+}
+
+func TestEmitter_WebhookFilterAndSlack(t *testing.T) {
+	rec := &receiver{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	e := newEmitter(Settings{Webhooks: []Webhook{{URL: srv.URL, Format: FormatSlack, Events: []Type{RegistryUnhealthy}}}})
+	e.Emit(BuildFinished, nil)
+	e.Emit(RegistryUnhealthy, map[string]any{"registry": "zot", "workspace": "dev"})
+	flush(t, e)
+
+	require.Len(t, rec.bodies, 1)
+	assert.JSONEq(t, `{"text":"dvm: registry.unhealthy (registry=zot, workspace=dev)"}`, string(rec.bodies[0]))
+	assert.Empty(t, rec.requests[0].Header.Get("X-DVM-Signature-256"))
+}
+
+func TestEmitter_Retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		want     int
+	}{
+		{"server errors are retried", []int{500, 503}, 0, 3},
+		{"rate limits are retried", []int{429}, 0, 2},
+		{"client errors are not retried", []int{400}, 0, 1},
+		{"retries are bounded", []int{500, 500, 500}, 1, 2},
+		{"negative disables retries", []int{500}, -1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &receiver{statuses: tt.statuses}
+			srv := httptest.NewServer(rec)
+			defer srv.Close()
+
+			e := newEmitter(Settings{Webhooks: []Webhook{{URL: srv.URL}}, Retries: tt.retries})
+			e.Emit(SyncCompleted, nil)
+			flush(t, e)
+			assert.Len(t, rec.requests, tt.want)
+		})
+	}
+}
+
+func TestEmitter_Socket(t *testing.T) {
+	// Unix socket paths are limited to ~100 bytes, shorter than t.TempDir() on some systems
+	dir, err := os.MkdirTemp("", "dvm-events")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.sock")
+
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer ln.Close()
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		lines <- line
+	}()
+
+	e := newEmitter(Settings{Socket: path})
+	e.Emit(WorkspaceStarted, map[string]any{"workspace": "dev"})
+	flush(t, e)
+
+	var ev Event
+	require.NoError(t, json.Unmarshal([]byte(<-lines), &ev))
+	assert.Equal(t, WorkspaceStarted, ev.Type)
+}
+
+func TestEmitter_Disabled(t *testing.T) {
+	var e *Emitter
+	assert.False(t, e.Enabled())
+	e.Emit(BuildFinished, nil)
+	assert.NoError(t, e.Flush(context.Background()))
+	assert.False(t, New("dvm", Settings{}).Enabled())
+}
+
+func TestSettings_Validate(t *testing.T) {
+	assert.NoError(t, Settings{Webhooks: []Webhook{{URL: "http://x", Format: FormatSlack}}}.Validate())
+	assert.ErrorContains(t, Settings{Webhooks: []Webhook{{URL: "http://x", Format: "xml"}}}.Validate(), "unknown format")
+	assert.ErrorContains(t, Settings{Webhooks: []Webhook{{}}}.Validate(), "URL is required")
+
+	err := Settings{Webhooks: []Webhook{{URL: "https://hooks.slack.com/services/T0/B0/secret", Format: "xml"}}}.Validate()
+	assert.ErrorContains(t, err, "webhook 1 (https://hooks.slack.com)")
+	assert.NotContains(t, err.Error(), "secret")
+}
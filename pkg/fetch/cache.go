@@ -0,0 +1,92 @@
+package fetch
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The cache keeps each verified download under sha256/<digest> and records
+// the digest each URL resolved to in index.json, so artifacts whose digest
+// comes from a manifest can be found again without the network.
+
+const indexFile = "index.json"
+
+// CachePath returns where the cache keeps the artifact with the given digest.
+func (f *Fetcher) CachePath(digest string) string {
+	return filepath.Join(f.CacheDir, "sha256", strings.ToLower(digest))
+}
+
+// cached returns the cache entry for an artifact when one exists and still
+// matches its digest. Signatures were checked when the entry was stored.
+func (f *Fetcher) cached(a Artifact) (string, bool) {
+	if f.CacheDir == "" {
+		return "", false
+	}
+	digest := a.SHA256
+	if digest == "" {
+		digest = f.readIndex()[a.URL]
+	}
+	if digest == "" {
+		return "", false
+	}
+	path := f.CachePath(digest)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	if err := VerifyFile(path, digest); err != nil {
+		slog.Warn("dropping corrupt cached artifact", "path", path, "error", err)
+		os.Remove(path)
+		return "", false
+	}
+	return path, true
+}
+
+// store copies a verified download into the cache and indexes its URL.
+func (f *Fetcher) store(url, path, digest string) error {
+	if f.CacheDir == "" {
+		return nil
+	}
+	entry := f.CachePath(digest)
+	if err := os.MkdirAll(filepath.Dir(entry), 0755); err != nil {
+		return err
+	}
+	if err := copyFile(path, entry, 0644); err != nil {
+		return err
+	}
+	index := f.readIndex()
+	if index[url] == digest {
+		return nil
+	}
+	index[url] = digest
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(f.CacheDir, indexFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(f.CacheDir, indexFile))
+}
+
+// readIndex loads the URL → digest index, empty when there is none.
+func (f *Fetcher) readIndex() map[string]string {
+	index := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(f.CacheDir, indexFile))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Warn("ignoring unreadable artifact cache index", "dir", f.CacheDir, "error", err)
+		}
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		slog.Warn("ignoring corrupt artifact cache index", "dir", f.CacheDir, "error", err)
+		return map[string]string{}
+	}
+	return index
+}
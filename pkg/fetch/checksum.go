@@ -0,0 +1,51 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxManifestSize caps checksum manifest downloads.
+const maxManifestSize = 64 * 1024
+
+// ManifestChecksum downloads a release's checksum manifest and returns the
+// digest it lists for filename.
+func (f *Fetcher) ManifestChecksum(ctx context.Context, manifestURL, filename string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create checksum request: %w", err)
+	}
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("checksum download failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum manifest: %w", err)
+	}
+	return LookupChecksum(string(body), filename)
+}
+
+// LookupChecksum returns the digest a sha256sum-style manifest lists for
+// filename. Both the text ("<digest>  <file>") and binary
+// ("<digest> *<file>") line formats are accepted.
+func LookupChecksum(manifest, filename string) (string, error) {
+	for _, line := range strings.Split(manifest, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+		if strings.TrimPrefix(parts[1], "*") == filename {
+			return parts[0], nil
+		}
+	}
+	return "", fmt.Errorf("checksum not found for %q in manifest", filename)
+}
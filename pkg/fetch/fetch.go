@@ -0,0 +1,253 @@
+// Package fetch downloads release artifacts and verifies them before they are
+// used. Every artifact needs a SHA256 digest — pinned in code or looked up in
+// the release's checksum manifest — and may also need a valid GPG or cosign
+// signature. Verified downloads are kept in a content-addressed cache
+// directory, so later fetches skip the network and offline fetches work from
+// the cache alone.
+package fetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+var (
+	// ErrDownloadFailed reports a request that failed or returned a non-200 status.
+	ErrDownloadFailed = errors.New("download failed")
+
+	// ErrChecksumMismatch reports a download whose SHA256 digest differs from
+	// the expected one.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
+	// ErrNotPinned reports an artifact with neither a pinned digest nor a way
+	// to look one up.
+	ErrNotPinned = errors.New("no checksum pinned")
+
+	// ErrTooLarge reports a download over the fetcher's size limit.
+	ErrTooLarge = errors.New("download exceeds maximum size")
+
+	// ErrNotCached reports an offline fetch of an artifact the cache lacks.
+	ErrNotCached = errors.New("artifact not in offline cache")
+
+	// ErrSignature reports a signature that could not be verified.
+	ErrSignature = errors.New("signature verification failed")
+)
+
+// Artifact describes one download and how to verify it.
+type Artifact struct {
+	Name string // Shown in errors and logs; defaults to the URL's file name
+	URL  string
+
+	// SHA256 is the pinned hex digest. When empty, ResolveChecksum looks it
+	// up after the download, e.g. from the release's checksum manifest.
+	SHA256          string
+	ResolveChecksum func(ctx context.Context) (string, error)
+
+	// Signature is verified in addition to the digest when set.
+	Signature *Signature
+
+	Mode os.FileMode // Mode of the written file; defaults to 0644
+}
+
+// displayName returns the name used in errors and logs.
+func (a Artifact) displayName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return filepath.Base(a.URL)
+}
+
+// Fetcher downloads and verifies artifacts. The zero value downloads with
+// the proxy and CA settings of package httpclient, has no size limit, and
+// caches nothing.
+type Fetcher struct {
+	// CacheDir keeps verified downloads by digest. Empty disables the cache.
+	CacheDir string
+
+	// Offline serves artifacts from the cache only and never downloads.
+	Offline bool
+
+	// MaxSize caps a download in bytes. Zero means no limit.
+	MaxSize int64
+
+	// Client performs downloads; nil uses httpclient.New with no timeout.
+	Client *http.Client
+
+	// run executes signature verification tools; nil runs them with os/exec.
+	run runFunc
+}
+
+// Fetch writes the verified artifact to dest, from the cache when it holds
+// the artifact and otherwise by downloading it. The file only appears at
+// dest once every check has passed.
+func (f *Fetcher) Fetch(ctx context.Context, a Artifact, dest string) error {
+	name := a.displayName()
+	mode := a.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+
+	if path, ok := f.cached(a); ok {
+		slog.Debug("using cached artifact", "name", name, "path", path)
+		return copyFile(path, dest, mode)
+	}
+	if f.Offline {
+		return fmt.Errorf("%w: %s (%s)", ErrNotCached, name, a.URL)
+	}
+
+	tmp, digest, err := f.download(ctx, a, dest+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	expected := a.SHA256
+	if expected == "" && a.ResolveChecksum != nil {
+		if expected, err = a.ResolveChecksum(ctx); err != nil {
+			return fmt.Errorf("checksum lookup for %s failed: %w", name, err)
+		}
+	}
+	if expected == "" {
+		return fmt.Errorf("%w for %s", ErrNotPinned, name)
+	}
+	expected = strings.ToLower(expected)
+	if subtle.ConstantTimeCompare([]byte(digest), []byte(expected)) != 1 {
+		return fmt.Errorf("%w for %s: expected %s, got %s", ErrChecksumMismatch, name, expected, digest)
+	}
+
+	if a.Signature != nil {
+		if err := f.verifySignature(ctx, a, tmp); err != nil {
+			return err
+		}
+	}
+
+	if err := f.store(a.URL, tmp, digest); err != nil {
+		slog.Warn("failed to cache artifact", "name", name, "error", err)
+	}
+	if err := os.Chmod(tmp, mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return fmt.Errorf("failed to move %s into place: %w", name, err)
+	}
+	slog.Debug("fetched artifact", "name", name, "sha256", digest)
+	return nil
+}
+
+// download streams the artifact to tmp and returns the path and its digest.
+func (f *Fetcher) download(ctx context.Context, a Artifact, tmp string) (string, string, error) {
+	name := a.displayName()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := f.client().Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s", ErrDownloadFailed, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("%w: HTTP %d from %s", ErrDownloadFailed, resp.StatusCode, a.URL)
+	}
+
+	body := io.Reader(resp.Body)
+	if f.MaxSize > 0 {
+		if resp.ContentLength > f.MaxSize {
+			return "", "", fmt.Errorf("%w: %s size %d exceeds maximum allowed %d", ErrTooLarge, name, resp.ContentLength, f.MaxSize)
+		}
+		body = io.LimitReader(resp.Body, f.MaxSize+1)
+	}
+
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(file, hash), body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && f.MaxSize > 0 && n > f.MaxSize {
+		err = fmt.Errorf("%w: %s size exceeds maximum allowed %d", ErrTooLarge, name, f.MaxSize)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		if errors.Is(err, ErrTooLarge) {
+			return "", "", err
+		}
+		return "", "", fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	return tmp, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (f *Fetcher) client() *http.Client {
+	if f.Client != nil {
+		return f.Client
+	}
+	return httpclient.New(0)
+}
+
+// VerifyFile checks that the file at path has the given SHA256 hex digest.
+func VerifyFile(path, expected string) error {
+	actual, err := fileDigest(path)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(actual), []byte(strings.ToLower(expected))) != 1 {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// copyFile writes src to dest through a temp file next to dest.
+func copyFile(src, dest string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp := dest + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp, mode)
+	}
+	if err == nil {
+		err = os.Rename(tmp, dest)
+	}
+	if err != nil {
+		os.Remove(tmp)
+	}
+	return err
+}
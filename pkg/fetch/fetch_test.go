@@ -0,0 +1,205 @@
+package fetch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const payload = "release-asset-bytes"
+
+func digestOf(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// newServer serves payload at /asset, a manifest at /checksums.txt, and a
+// signature at /asset.sig, counting asset requests.
+func newServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/asset":
+			atomic.AddInt32(hits, 1)
+			w.Write([]byte(payload))
+		case "/checksums.txt":
+			w.Write([]byte(digestOf("other") + "  other\n" + digestOf(payload) + " *asset\n"))
+		case "/asset.sig":
+			w.Write([]byte("signature"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetch_PinnedChecksum(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+	dest := filepath.Join(t.TempDir(), "asset")
+
+	f := &Fetcher{}
+	err := f.Fetch(context.Background(), Artifact{URL: server.URL + "/asset", SHA256: digestOf(payload), Mode: 0755}, dest)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(data))
+	info, err := os.Stat(dest)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+}
+
+func TestFetch_ChecksumMismatch(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+	dest := filepath.Join(t.TempDir(), "asset")
+
+	f := &Fetcher{}
+	err := f.Fetch(context.Background(), Artifact{URL: server.URL + "/asset", SHA256: digestOf("tampered")}, dest)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	assert.NoFileExists(t, dest)
+	assert.NoFileExists(t, dest+".tmp")
+}
+
+func TestFetch_RequiresChecksum(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+
+	f := &Fetcher{}
+	err := f.Fetch(context.Background(), Artifact{URL: server.URL + "/asset"}, filepath.Join(t.TempDir(), "asset"))
+	assert.ErrorIs(t, err, ErrNotPinned)
+}
+
+func TestFetch_ResolvesChecksumFromManifest(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+	dest := filepath.Join(t.TempDir(), "asset")
+
+	f := &Fetcher{}
+	a := Artifact{
+		URL: server.URL + "/asset",
+		ResolveChecksum: func(ctx context.Context) (string, error) {
+			return f.ManifestChecksum(ctx, server.URL+"/checksums.txt", "asset")
+		},
+	}
+	require.NoError(t, f.Fetch(context.Background(), a, dest))
+	assert.FileExists(t, dest)
+}
+
+func TestFetch_MaxSize(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+
+	f := &Fetcher{MaxSize: 4}
+	err := f.Fetch(context.Background(), Artifact{URL: server.URL + "/asset", SHA256: digestOf(payload)}, filepath.Join(t.TempDir(), "asset"))
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestFetch_HTTPError(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+
+	f := &Fetcher{}
+	err := f.Fetch(context.Background(), Artifact{URL: server.URL + "/missing", SHA256: digestOf(payload)}, filepath.Join(t.TempDir(), "asset"))
+	assert.ErrorIs(t, err, ErrDownloadFailed)
+}
+
+func TestFetch_Cache(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+	cacheDir := t.TempDir()
+	destDir := t.TempDir()
+
+	pinned := Artifact{URL: server.URL + "/asset", SHA256: digestOf(payload)}
+	f := &Fetcher{CacheDir: cacheDir}
+	require.NoError(t, f.Fetch(context.Background(), pinned, filepath.Join(destDir, "one")))
+	require.NoError(t, f.Fetch(context.Background(), pinned, filepath.Join(destDir, "two")))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits), "the second fetch should come from the cache")
+	assert.FileExists(t, f.CachePath(digestOf(payload)))
+
+	t.Run("offline finds unpinned artifacts through the index", func(t *testing.T) {
+		offline := &Fetcher{CacheDir: cacheDir, Offline: true}
+		dest := filepath.Join(destDir, "three")
+		require.NoError(t, offline.Fetch(context.Background(), Artifact{URL: pinned.URL}, dest))
+		data, err := os.ReadFile(dest)
+		require.NoError(t, err)
+		assert.Equal(t, payload, string(data))
+	})
+
+	t.Run("offline misses fail", func(t *testing.T) {
+		offline := &Fetcher{CacheDir: t.TempDir(), Offline: true}
+		err := offline.Fetch(context.Background(), pinned, filepath.Join(destDir, "four"))
+		assert.ErrorIs(t, err, ErrNotCached)
+	})
+
+	t.Run("corrupt entries are downloaded again", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(f.CachePath(digestOf(payload)), []byte("corrupt"), 0644))
+		require.NoError(t, f.Fetch(context.Background(), pinned, filepath.Join(destDir, "five")))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+		require.NoError(t, VerifyFile(f.CachePath(digestOf(payload)), digestOf(payload)))
+	})
+}
+
+func TestFetch_Signature(t *testing.T) {
+	var hits int32
+	server := newServer(t, &hits)
+
+	var gotTool string
+	var gotArgs []string
+	verifyErr := error(nil)
+	f := &Fetcher{run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		gotTool, gotArgs = name, args
+		return []byte("BAD signature"), verifyErr
+	}}
+	a := Artifact{
+		URL:       server.URL + "/asset",
+		SHA256:    digestOf(payload),
+		Signature: &Signature{Method: MethodCosign, URL: server.URL + "/asset.sig", Key: "/keys/cosign.pub"},
+	}
+
+	dest := filepath.Join(t.TempDir(), "asset")
+	require.NoError(t, f.Fetch(context.Background(), a, dest))
+	assert.Equal(t, "cosign", gotTool)
+	assert.Equal(t, []string{"verify-blob", "--key", "/keys/cosign.pub", "--signature", dest + ".tmp.sig", dest + ".tmp"}, gotArgs)
+
+	a.Signature.Method = MethodGPG
+	require.NoError(t, f.Fetch(context.Background(), a, dest))
+	assert.Equal(t, "gpg", gotTool)
+	assert.Contains(t, gotArgs, "/keys/cosign.pub")
+
+	verifyErr = errors.New("exit status 1")
+	err := f.Fetch(context.Background(), a, filepath.Join(t.TempDir(), "asset"))
+	require.ErrorIs(t, err, ErrSignature)
+	assert.Contains(t, err.Error(), "BAD signature")
+
+	a.Signature.Method = "minisign"
+	err = f.Fetch(context.Background(), a, filepath.Join(t.TempDir(), "asset"))
+	assert.ErrorIs(t, err, ErrSignature)
+}
+
+func TestLookupChecksum(t *testing.T) {
+	manifest := "aaa  zot-linux-amd64\nbbb *zot-linux-arm64\n\nmalformed\n"
+
+	sum, err := LookupChecksum(manifest, "zot-linux-arm64")
+	require.NoError(t, err)
+	assert.Equal(t, "bbb", sum)
+
+	sum, err = LookupChecksum(manifest, "zot-linux-amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "aaa", sum)
+
+	_, err = LookupChecksum(manifest, "zot-darwin-arm64")
+	assert.Error(t, err)
+}
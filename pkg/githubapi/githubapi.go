@@ -0,0 +1,145 @@
+// Package githubapi is the small GitHub REST API client shared by everything
+// in dvm and nvp that talks to api.github.com: plugin pinning, audits,
+// release advice, README search, and GitHub directory sources.
+package githubapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+// DefaultBaseURL is the GitHub REST API base URL.
+const DefaultBaseURL = "https://api.github.com"
+
+// Media types for the Accept header.
+const (
+	// AcceptJSON requests the standard JSON representation.
+	AcceptJSON = "application/vnd.github.v3+json"
+	// AcceptRaw requests raw file contents (e.g. for /readme).
+	AcceptRaw = "application/vnd.github.raw"
+)
+
+// ErrRateLimited is returned when the API rate limit is exhausted.
+var ErrRateLimited = errors.New("GitHub API rate limit exceeded. Set GITHUB_TOKEN env var for higher limits (5000/hour vs 60/hour)")
+
+// StatusError is returned for responses other than 200 OK.
+type StatusError struct {
+	StatusCode int
+	Path       string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("GitHub API error %d for %s", e.StatusCode, e.Path)
+	}
+	return fmt.Sprintf("GitHub API error %d for %s: %s", e.StatusCode, e.Path, e.Body)
+}
+
+// IsStatus reports whether err is a StatusError with the given code.
+func IsStatus(err error, code int) bool {
+	var se *StatusError
+	return errors.As(err, &se) && se.StatusCode == code
+}
+
+// Client issues authenticated GET requests against the GitHub REST API.
+type Client struct {
+	// BaseURL is the API root (default DefaultBaseURL).
+	BaseURL string
+	// Token, if set, authenticates requests (raising the rate limit from
+	// 60 to 5000 requests per hour).
+	Token string
+	// HTTPClient is the HTTP client used for requests (default a client
+	// with the proxy and CA settings of package httpclient).
+	HTTPClient *http.Client
+	// UserAgent is sent with every request (default "devopsmaestro").
+	UserAgent string
+}
+
+// NewClient creates a client for api.github.com with a 30 second timeout.
+func NewClient(token string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Token:      token,
+		HTTPClient: httpclient.New(30 * time.Second),
+	}
+}
+
+// Get fetches path (relative to BaseURL, may include a query) and decodes
+// the JSON response into v.
+func (c *Client) Get(ctx context.Context, path string, v any) error {
+	resp, err := c.do(ctx, path, AcceptJSON)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse GitHub API response: %w", err)
+	}
+	return nil
+}
+
+// GetRaw fetches path with the given Accept media type and returns at most
+// limit bytes of the body.
+func (c *Client) GetRaw(ctx context.Context, path, accept string, limit int64) ([]byte, error) {
+	resp, err := c.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub API response: %w", err)
+	}
+	return data, nil
+}
+
+// do sends the request and returns the response when it is 200 OK. Any
+// other status is turned into ErrRateLimited or a *StatusError.
+func (c *Client) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	ua := c.UserAgent
+	if ua == "" {
+		ua = "devopsmaestro"
+	}
+	req.Header.Set("User-Agent", ua)
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = httpclient.New(0)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests) &&
+		resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return nil, ErrRateLimited
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, &StatusError{StatusCode: resp.StatusCode, Path: path, Body: strings.TrimSpace(string(body))}
+}
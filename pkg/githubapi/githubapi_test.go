@@ -0,0 +1,55 @@
+package githubapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Get(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "token secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/repos/a/b":
+			if got := r.Header.Get("Accept"); got != AcceptJSON {
+				t.Errorf("Accept = %q", got)
+			}
+			w.Write([]byte(`{"full_name":"a/b"}`))
+		case "/repos/a/b/readme":
+			w.Write([]byte("# Title\nbody"))
+		case "/limited":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{BaseURL: srv.URL + "/", Token: "secret", HTTPClient: srv.Client()}
+	ctx := context.Background()
+
+	var repo struct {
+		FullName string `json:"full_name"`
+	}
+	if err := c.Get(ctx, "/repos/a/b", &repo); err != nil || repo.FullName != "a/b" {
+		t.Errorf("Get() = %+v, %v", repo, err)
+	}
+
+	data, err := c.GetRaw(ctx, "/repos/a/b/readme", AcceptRaw, 7)
+	if err != nil || string(data) != "# Title" {
+		t.Errorf("GetRaw() = %q, %v (want the first 7 bytes)", data, err)
+	}
+
+	if err := c.Get(ctx, "/limited", &repo); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Get(rate limited) error = %v", err)
+	}
+	err = c.Get(ctx, "/missing", &repo)
+	if !IsStatus(err, http.StatusNotFound) {
+		t.Errorf("Get(missing) error = %v, want 404 StatusError", err)
+	}
+}
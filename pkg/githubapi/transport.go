@@ -0,0 +1,262 @@
+package githubapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+// Hosts are the GitHub hosts Register intercepts.
+var Hosts = []string{"api.github.com", "raw.githubusercontent.com"}
+
+// DefaultMaxWait is how long Transport waits for a rate limit to reset
+// before giving up.
+const DefaultMaxWait = time.Minute
+
+// maxRetries bounds how often one request is retried after a rate limit.
+const maxRetries = 3
+
+// maxCachedBody is the largest response Transport keeps in its ETag cache.
+const maxCachedBody = 10 << 20
+
+// Transport adds token auth, conditional requests, and rate-limit backoff to
+// GET requests. Responses that carry an ETag are kept in CacheDir and
+// revalidated with If-None-Match, so an unchanged file costs a 304, which
+// does not count against the rate limit.
+type Transport struct {
+	// Next sends the request (default http.DefaultTransport).
+	Next http.RoundTripper
+	// Token returns the token for requests without an Authorization header.
+	Token func() string
+	// CacheDir holds cached responses; empty disables caching.
+	CacheDir string
+	// MaxWait is the longest rate-limit reset to wait for (default
+	// DefaultMaxWait). Longer waits return the rate-limited response.
+	MaxWait time.Duration
+
+	sleep func(context.Context, time.Duration) error
+}
+
+// Register routes every request to Hosts, including those from clients
+// built by dependencies, through a Transport with the given token source
+// and cache directory. The token is looked up once, on the first request.
+func Register(token func() string, cacheDir string) {
+	var once sync.Once
+	var cached string
+	lazyToken := func() string {
+		once.Do(func() {
+			if token != nil {
+				cached = token()
+			}
+		})
+		return cached
+	}
+	for _, host := range Hosts {
+		httpclient.Intercept(host, func(next http.RoundTripper) http.RoundTripper {
+			return &Transport{Next: next, Token: lazyToken, CacheDir: cacheDir}
+		})
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet {
+		return next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	if req.Header.Get("Authorization") == "" && t.Token != nil {
+		if token := t.Token(); token != "" {
+			req.Header.Set("Authorization", "token "+token)
+		}
+	}
+	key := t.cacheKey(req)
+	// A caller's own conditional request is passed through untouched
+	var entry *cacheEntry
+	if req.Header.Get("If-None-Match") == "" {
+		entry = t.load(key)
+	} else {
+		key = ""
+	}
+	if entry != nil {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case resp.StatusCode == http.StatusNotModified && entry != nil:
+			resp.Body.Close()
+			slog.Debug("GitHub response not modified", "url", req.URL.String())
+			return entry.response(req, resp.Header), nil
+		case resp.StatusCode == http.StatusOK && key != "" && resp.Header.Get("ETag") != "":
+			return t.store(key, req, resp), nil
+		}
+
+		wait, limited := rateLimitWait(resp, time.Now())
+		if !limited || attempt >= maxRetries || wait > t.maxWait() {
+			return resp, nil
+		}
+		resp.Body.Close()
+		slog.Warn("GitHub rate limit reached, waiting", "url", req.URL.String(), "wait", wait)
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *Transport) maxWait() time.Duration {
+	if t.MaxWait > 0 {
+		return t.MaxWait
+	}
+	return DefaultMaxWait
+}
+
+func (t *Transport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitWait reports whether resp is a primary or secondary rate limit,
+// and how long until it resets.
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, true
+	}
+	// The reset time has one-second resolution
+	wait := time.Unix(reset, 0).Sub(now) + time.Second
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// cacheEntry is a cached response body with its validator.
+type cacheEntry struct {
+	URL         string `json:"url"`
+	ETag        string `json:"etag"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// response turns the entry into a 200 response to req, keeping the 304's
+// headers (such as the rate-limit counters).
+func (e *cacheEntry) response(req *http.Request, header http.Header) *http.Response {
+	header = header.Clone()
+	header.Set("ETag", e.ETag)
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies a request's cached response; the Accept header picks
+// the representation and the credentials decide what is visible.
+func (t *Transport) cacheKey(req *http.Request) string {
+	if t.CacheDir == "" || req.Header.Get("Range") != "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + req.Header.Get("Authorization")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (t *Transport) load(key string) *cacheEntry {
+	if key == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(t.CacheDir, key+".json"))
+	if err != nil {
+		return nil
+	}
+	var e cacheEntry
+	if err := json.Unmarshal(data, &e); err != nil || e.ETag == "" {
+		return nil
+	}
+	return &e
+}
+
+// store caches the body of resp to req and returns resp with the body still
+// readable. Bodies larger than maxCachedBody are passed through uncached.
+func (t *Transport) store(key string, req *http.Request, resp *http.Response) *http.Response {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedBody+1))
+	if err != nil || len(body) > maxCachedBody {
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	data, err := json.Marshal(cacheEntry{
+		URL:         req.URL.String(),
+		ETag:        resp.Header.Get("ETag"),
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	})
+	if err == nil {
+		err = os.MkdirAll(t.CacheDir, 0700)
+	}
+	if err == nil {
+		tmp := filepath.Join(t.CacheDir, key+".json.tmp")
+		if err = os.WriteFile(tmp, data, 0600); err == nil {
+			err = os.Rename(tmp, filepath.Join(t.CacheDir, key+".json"))
+		}
+	}
+	if err != nil {
+		slog.Debug("failed to cache GitHub response", "error", err)
+	}
+	return resp
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,134 @@
+package githubapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, tr *Transport, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := (&http.Client{Transport: tr}).Get(url)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", url, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	return resp, string(body)
+}
+
+func TestTransport_ETagCache(t *testing.T) {
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("Authorization"); got != "token secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.Header().Set("X-RateLimit-Remaining", "59")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("plugin spec"))
+	}))
+	defer srv.Close()
+
+	tr := &Transport{Token: func() string { return "secret" }, CacheDir: t.TempDir()}
+	for i := 0; i < 2; i++ {
+		resp, body := get(t, tr, srv.URL+"/lua/plugins/ui.lua")
+		if resp.StatusCode != http.StatusOK || body != "plugin spec" {
+			t.Errorf("request %d = %d %q", i, resp.StatusCode, body)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests = %d, not modified = %d; want the second request revalidated", requests, notModified)
+	}
+
+	// Without a cache directory every request is unconditional
+	requests, notModified = 0, 0
+	get(t, &Transport{Token: func() string { return "secret" }}, srv.URL+"/lua/plugins/ui.lua")
+	if notModified != 0 {
+		t.Error("uncached transport sent a conditional request")
+	}
+}
+
+func TestTransport_RateLimitBackoff(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/secondary":
+			if requests == 1 {
+				w.Header().Set("Retry-After", "2")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte("ok"))
+		case "/exhausted":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	tr := &Transport{sleep: func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}}
+
+	resp, body := get(t, tr, srv.URL+"/secondary")
+	if resp.StatusCode != http.StatusOK || body != "ok" {
+		t.Errorf("after backoff = %d %q", resp.StatusCode, body)
+	}
+	if len(waits) != 1 || waits[0] != 2*time.Second {
+		t.Errorf("waits = %v, want [2s]", waits)
+	}
+
+	// A reset further away than MaxWait is returned to the caller
+	requests, waits = 0, nil
+	resp, _ = get(t, tr, srv.URL+"/exhausted")
+	if resp.StatusCode != http.StatusForbidden || requests != 1 || len(waits) != 0 {
+		t.Errorf("exhausted = %d after %d requests, waits %v", resp.StatusCode, requests, waits)
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Unix(1000, 0)
+	tests := []struct {
+		name        string
+		status      int
+		header      map[string]string
+		wantWait    time.Duration
+		wantLimited bool
+	}{
+		{"ok", http.StatusOK, nil, 0, false},
+		{"forbidden", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "10"}, 0, false},
+		{"retry after", http.StatusTooManyRequests, map[string]string{"Retry-After": "30"}, 30 * time.Second, true},
+		{"reset", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1010"}, 11 * time.Second, true},
+		{"reset passed", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "900"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			for k, v := range tt.header {
+				resp.Header.Set(k, v)
+			}
+			wait, limited := rateLimitWait(resp, now)
+			if wait != tt.wantWait || limited != tt.wantLimited {
+				t.Errorf("rateLimitWait() = %v, %v; want %v, %v", wait, limited, tt.wantWait, tt.wantLimited)
+			}
+		})
+	}
+}
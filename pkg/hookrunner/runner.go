@@ -0,0 +1,111 @@
+// Package hookrunner runs the lifecycle hooks of a workspace (spec.hooks):
+// shell scripts run on the host or in the workspace container at points such
+// as preBuild and postStart, each with a timeout, extra environment
+// variables, and a failure policy.
+package hookrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"devopsmaestro/models"
+)
+
+// ContainerExec runs a command in the workspace container, writing its
+// output to stdout. Stderr is included in the error on failure.
+type ContainerExec func(ctx context.Context, command []string, stdout io.Writer) error
+
+// Runner runs the hooks of a hook point.
+type Runner struct {
+	HostDir string            // Working directory of host hooks
+	Env     map[string]string // Added to the environment of every hook
+	Exec    ContainerExec     // Runs container hooks; nil when there is no container
+	Output  io.Writer         // Hook output; nil discards it
+}
+
+// Run runs hooks in order. A hook that fails with onFailure: abort stops the
+// run and returns its error; one with onFailure: warn is reported in the
+// returned warnings and the next hook runs.
+func (r *Runner) Run(ctx context.Context, point string, hooks []models.LifecycleHook) ([]string, error) {
+	var warnings []string
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("%s[%d]", point, i)
+		}
+		err := r.runHook(ctx, point, hook)
+		if err == nil {
+			continue
+		}
+		if hook.OnFailure == models.HookFailureWarn {
+			warnings = append(warnings, fmt.Sprintf("%s hook %s failed: %v", point, name, err))
+			continue
+		}
+		return warnings, fmt.Errorf("%s hook %s failed: %w", point, name, err)
+	}
+	return warnings, nil
+}
+
+func (r *Runner) runHook(ctx context.Context, point string, hook models.LifecycleHook) error {
+	timeout := hook.TimeoutDuration()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	env := r.environ(point, hook)
+	var err error
+	if hook.On == models.HookOnContainer {
+		if r.Exec == nil {
+			return fmt.Errorf("the workspace container is not available")
+		}
+		command := append([]string{"env"}, env...)
+		err = r.Exec(ctx, append(command, "sh", "-c", hook.Run), r.output())
+	} else {
+		cmd := exec.CommandContext(ctx, "sh", "-c", hook.Run)
+		cmd.Dir = r.HostDir
+		cmd.Env = append(os.Environ(), env...)
+		cmd.Stdout, cmd.Stderr = r.output(), r.output()
+		cmd.WaitDelay = time.Second
+		err = cmd.Run()
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	return err
+}
+
+// environ returns the hook's variables as sorted KEY=VALUE pairs: the
+// runner's, then the hook's own, then DVM_HOOK with the hook point.
+func (r *Runner) environ(point string, hook models.LifecycleHook) []string {
+	vars := make(map[string]string, len(r.Env)+len(hook.Env)+1)
+	for k, v := range r.Env {
+		vars[k] = v
+	}
+	for k, v := range hook.Env {
+		vars[k] = v
+	}
+	vars["DVM_HOOK"] = point
+
+	keys := make([]string, 0, len(vars))
+	for k := range vars {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	env := make([]string, len(keys))
+	for i, k := range keys {
+		env[i] = k + "=" + vars[k]
+	}
+	return env
+}
+
+func (r *Runner) output() io.Writer {
+	if r.Output == nil {
+		return io.Discard
+	}
+	return r.Output
+}
@@ -0,0 +1,92 @@
+package hookrunner
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+)
+
+func TestRunner_HostHooks(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	r := &Runner{HostDir: dir, Env: map[string]string{"DVM_APP": "api", "GREETING": "hello"}, Output: &out}
+
+	warnings, err := r.Run(context.Background(), models.HookPostStart, []models.LifecycleHook{
+		{Run: `echo "$GREETING $NAME $DVM_APP $DVM_HOOK"; pwd`, Env: map[string]string{"NAME": "world"}},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+	got := out.String()
+	if !strings.Contains(got, "hello world api postStart") {
+		t.Errorf("output = %q, want the injected env", got)
+	}
+	if !strings.Contains(got, dir) {
+		t.Errorf("output = %q, want the working directory %s", got, dir)
+	}
+}
+
+func TestRunner_FailurePolicies(t *testing.T) {
+	var out bytes.Buffer
+	r := &Runner{HostDir: t.TempDir(), Output: &out}
+
+	warnings, err := r.Run(context.Background(), models.HookPreBuild, []models.LifecycleHook{
+		{Name: "lint", Run: "exit 3", OnFailure: models.HookFailureWarn},
+		{Run: "echo after"},
+		{Run: "exit 1"},
+		{Run: "echo unreachable"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "preBuild hook preBuild[2] failed") {
+		t.Fatalf("Run() error = %v, want the aborting hook", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "preBuild hook lint failed") {
+		t.Errorf("warnings = %v, want the warn hook", warnings)
+	}
+	if !strings.Contains(out.String(), "after") || strings.Contains(out.String(), "unreachable") {
+		t.Errorf("output = %q, want hooks up to the aborting one", out.String())
+	}
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	r := &Runner{HostDir: t.TempDir()}
+	_, err := r.Run(context.Background(), models.HookPreStart, []models.LifecycleHook{
+		{Name: "slow", Run: "sleep 5", Timeout: "100ms"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 100ms") {
+		t.Fatalf("Run() error = %v, want a timeout", err)
+	}
+}
+
+func TestRunner_ContainerHooks(t *testing.T) {
+	var got []string
+	r := &Runner{
+		Env: map[string]string{"DATABASE_URL": "postgres://db"},
+		Exec: func(ctx context.Context, command []string, stdout io.Writer) error {
+			got = command
+			return nil
+		},
+	}
+	if _, err := r.Run(context.Background(), models.HookPostSync, []models.LifecycleHook{
+		{Run: "make migrate", On: models.HookOnContainer},
+	}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{"env", "DATABASE_URL=postgres://db", "DVM_HOOK=postSync", "sh", "-c", "make migrate"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+
+	r.Exec = nil
+	if _, err := r.Run(context.Background(), models.HookPostStart, []models.LifecycleHook{
+		{Run: "true", On: models.HookOnContainer},
+	}); err == nil {
+		t.Error("Run() without a container = nil error, want one")
+	}
+}
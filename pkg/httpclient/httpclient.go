@@ -0,0 +1,277 @@
+// Package httpclient builds the HTTP clients dvm uses for network access, so
+// proxy settings and extra CA certificates apply the same way to source
+// fetches, artifact downloads, registry probes, and GitHub API calls.
+//
+// Until Configure is called with non-empty settings, requests go through
+// http.DefaultTransport, which already honors HTTPS_PROXY, HTTP_PROXY, and
+// NO_PROXY. Once configured, or once a host has an interceptor, the package's
+// Transport also replaces http.DefaultTransport, so clients built by
+// dependencies (such as the nvp sync source handlers) follow the same
+// settings.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Direct, as a proxy setting, turns the proxy off for matching hosts.
+const Direct = "direct"
+
+// Settings configure every client the package builds.
+type Settings struct {
+	Proxy   string   // Proxy URL for HTTP and HTTPS; empty uses HTTPS_PROXY / HTTP_PROXY
+	NoProxy string   // Comma-separated hosts that bypass the proxy; empty uses NO_PROXY
+	CACerts []string // PEM files trusted in addition to the system roots
+
+	// Hosts overrides the settings for a host and its subdomains. The most
+	// specific matching host wins.
+	Hosts map[string]HostSettings
+}
+
+// HostSettings override Settings for one host.
+type HostSettings struct {
+	Proxy   string   // Proxy URL or Direct; empty keeps the global proxy
+	CACerts []string // Trusted in addition to the global CACerts
+}
+
+func (s Settings) empty() bool {
+	return s.Proxy == "" && s.NoProxy == "" && len(s.CACerts) == 0 && len(s.Hosts) == 0
+}
+
+// routes holds the transports built from the current settings.
+type routes struct {
+	base  http.RoundTripper
+	hosts map[string]http.RoundTripper
+}
+
+// Interceptor wraps the transport for requests to one host, e.g. to add
+// authentication or caching.
+type Interceptor func(next http.RoundTripper) http.RoundTripper
+
+var (
+	current      atomic.Pointer[routes]
+	interceptors atomic.Pointer[map[string]Interceptor]
+
+	installMu sync.Mutex
+	// original is http.DefaultTransport from before the package replaced it.
+	original atomic.Pointer[http.RoundTripper]
+)
+
+// Configure applies settings to all clients, including ones created before
+// the call. It fails without changing anything when a proxy URL or CA file
+// is invalid.
+func Configure(s Settings) error {
+	installMu.Lock()
+	defer installMu.Unlock()
+
+	if s.empty() {
+		current.Store(nil)
+		if len(loadInterceptors()) == 0 {
+			uninstall()
+		}
+		return nil
+	}
+	base, err := newTransport(s.Proxy, s.NoProxy, s.CACerts)
+	if err != nil {
+		return err
+	}
+	r := &routes{base: base, hosts: map[string]http.RoundTripper{}}
+	for host, hs := range s.Hosts {
+		proxy := s.Proxy
+		if hs.Proxy != "" {
+			proxy = hs.Proxy
+		}
+		t, err := newTransport(proxy, s.NoProxy, append(append([]string{}, s.CACerts...), hs.CACerts...))
+		if err != nil {
+			return fmt.Errorf("host %s: %w", host, err)
+		}
+		r.hosts[strings.ToLower(strings.TrimPrefix(host, "."))] = t
+	}
+	current.Store(r)
+	install()
+	return nil
+}
+
+// Intercept routes requests to host and its subdomains through the
+// round tripper wrap returns, whatever the proxy settings. The most specific
+// host wins; a nil wrap removes the interceptor.
+func Intercept(host string, wrap Interceptor) {
+	installMu.Lock()
+	defer installMu.Unlock()
+
+	next := map[string]Interceptor{}
+	for h, w := range loadInterceptors() {
+		next[h] = w
+	}
+	host = strings.ToLower(strings.TrimPrefix(host, "."))
+	if wrap == nil {
+		delete(next, host)
+	} else {
+		next[host] = wrap
+	}
+	interceptors.Store(&next)
+
+	if len(next) > 0 {
+		install()
+	} else if current.Load() == nil {
+		uninstall()
+	}
+}
+
+func loadInterceptors() map[string]Interceptor {
+	if m := interceptors.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// install makes Transport http.DefaultTransport. Callers hold installMu.
+func install() {
+	if original.Load() == nil {
+		prev := http.DefaultTransport
+		original.Store(&prev)
+		http.DefaultTransport = Transport
+	}
+}
+
+// uninstall restores http.DefaultTransport. Callers hold installMu.
+func uninstall() {
+	if prev := original.Load(); prev != nil {
+		http.DefaultTransport = *prev
+		original.Store(nil)
+	}
+}
+
+// fallback is the transport used without configured settings.
+func fallback() http.RoundTripper {
+	if prev := original.Load(); prev != nil {
+		return *prev
+	}
+	return http.DefaultTransport
+}
+
+// New returns a client that uses the configured proxy and CAs.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: Transport}
+}
+
+// Transport routes each request through the transport configured for its
+// host and the host's interceptor. Use it when a client needs settings New
+// does not cover.
+var Transport http.RoundTripper = transport{}
+
+type transport struct{}
+
+func (transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	next := fallback()
+	if r := current.Load(); r != nil {
+		next = r.forHost(host)
+	}
+	if wrap, ok := lookup(loadInterceptors(), host); ok {
+		next = wrap(next)
+	}
+	return next.RoundTrip(req)
+}
+
+// forHost returns the transport of the most specific host override matching
+// host, or the base transport.
+func (r *routes) forHost(host string) http.RoundTripper {
+	if t, ok := lookup(r.hosts, host); ok {
+		return t
+	}
+	return r.base
+}
+
+// lookup returns the entry for host or its closest parent domain.
+func lookup[T any](m map[string]T, host string) (T, bool) {
+	host = strings.ToLower(host)
+	for {
+		if v, ok := m[host]; ok {
+			return v, true
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			var zero T
+			return zero, false
+		}
+		host = host[dot+1:]
+	}
+}
+
+// newTransport clones the default transport's settings with the given proxy
+// and extra CA files.
+func newTransport(proxy, noProxy string, caFiles []string) (*http.Transport, error) {
+	t := &http.Transport{}
+	if bt, ok := fallback().(*http.Transport); ok {
+		t = bt.Clone()
+	}
+
+	proxyFunc, err := proxyFor(proxy, noProxy)
+	if err != nil {
+		return nil, err
+	}
+	t.Proxy = proxyFunc
+
+	if len(caFiles) > 0 {
+		pool, err := certPool(caFiles)
+		if err != nil {
+			return nil, err
+		}
+		t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return t, nil
+}
+
+// proxyFor returns the proxy selection for a transport. Loopback addresses
+// never go through the proxy.
+func proxyFor(proxy, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	switch {
+	case proxy == Direct:
+		return nil, nil
+	case proxy == "" && noProxy == "":
+		return http.ProxyFromEnvironment, nil
+	}
+	cfg := httpproxy.FromEnvironment()
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", proxy)
+		}
+		cfg.HTTPProxy, cfg.HTTPSProxy = proxy, proxy
+	}
+	if noProxy != "" {
+		cfg.NoProxy = noProxy
+	}
+	fn := cfg.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) { return fn(req.URL) }, nil
+}
+
+// certPool returns the system roots plus the certificates in files.
+func certPool(files []string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", file)
+		}
+	}
+	return pool, nil
+}
@@ -0,0 +1,153 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// configure applies s for the duration of the test.
+func configure(t *testing.T, s Settings) {
+	t.Helper()
+	require.NoError(t, Configure(s))
+	t.Cleanup(func() { _ = Configure(Settings{}) })
+}
+
+// writeServerCA writes the TLS test server's certificate as a PEM file.
+func writeServerCA(t *testing.T, srv *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	return path
+}
+
+func TestConfigure_EmptyUsesDefaultTransport(t *testing.T) {
+	original := http.DefaultTransport
+	configure(t, Settings{})
+	assert.Nil(t, current.Load())
+	assert.Equal(t, original, http.DefaultTransport)
+}
+
+func TestConfigure_InstallsAndRestoresDefaultTransport(t *testing.T) {
+	original := http.DefaultTransport
+	require.NoError(t, Configure(Settings{NoProxy: "example.com"}))
+	assert.Equal(t, Transport, http.DefaultTransport)
+
+	// Reconfiguring must not record Transport as the one to restore
+	require.NoError(t, Configure(Settings{NoProxy: "example.org"}))
+	assert.Equal(t, Transport, http.DefaultTransport)
+
+	require.NoError(t, Configure(Settings{}))
+	assert.Equal(t, original, http.DefaultTransport)
+}
+
+func TestConfigure_InvalidSettings(t *testing.T) {
+	err := Configure(Settings{Proxy: "not a url"})
+	assert.ErrorContains(t, err, "invalid proxy URL")
+
+	err = Configure(Settings{CACerts: []string{filepath.Join(t.TempDir(), "missing.pem")}})
+	assert.ErrorContains(t, err, "failed to read CA bundle")
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0644))
+	err = Configure(Settings{Hosts: map[string]HostSettings{"example.com": {CACerts: []string{notPEM}}}})
+	assert.ErrorContains(t, err, "host example.com")
+
+	assert.Nil(t, current.Load(), "failed Configure must not change settings")
+}
+
+func TestRoutes_ForHost(t *testing.T) {
+	base, corp, internal := &http.Transport{}, &http.Transport{}, &http.Transport{}
+	r := &routes{base: base, hosts: map[string]http.RoundTripper{
+		"corp.example":          corp,
+		"internal.corp.example": internal,
+	}}
+
+	assert.Same(t, corp, r.forHost("corp.example"))
+	assert.Same(t, corp, r.forHost("git.corp.example"))
+	assert.Same(t, internal, r.forHost("api.internal.corp.example"))
+	assert.Same(t, internal, r.forHost("API.Internal.Corp.Example"))
+	assert.Same(t, base, r.forHost("github.com"))
+	assert.Same(t, base, r.forHost("example"))
+}
+
+func TestProxyFor(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://github.com/repo", nil)
+
+	fn, err := proxyFor(Direct, "")
+	require.NoError(t, err)
+	assert.Nil(t, fn)
+
+	fn, err = proxyFor("http://proxy.corp:3128", "")
+	require.NoError(t, err)
+	u, err := fn(req)
+	require.NoError(t, err)
+	assert.Equal(t, "proxy.corp:3128", u.Host)
+
+	fn, err = proxyFor("http://proxy.corp:3128", "github.com")
+	require.NoError(t, err)
+	u, err = fn(req)
+	require.NoError(t, err)
+	assert.Nil(t, u, "NoProxy host bypasses the proxy")
+}
+
+func TestNew_TrustsConfiguredCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	_, err := New(0).Get(srv.URL)
+	require.Error(t, err, "the test server's certificate is not trusted by default")
+
+	configure(t, Settings{Hosts: map[string]HostSettings{
+		"127.0.0.1": {Proxy: Direct, CACerts: []string{writeServerCA(t, srv)}},
+	}})
+	resp, err := New(0).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// Clients without a transport, as dependencies build them, follow it too
+	resp, err = (&http.Client{}).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestIntercept(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Intercepted")))
+	}))
+	defer srv.Close()
+
+	original := http.DefaultTransport
+	Intercept("127.0.0.1", func(next http.RoundTripper) http.RoundTripper {
+		return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("X-Intercepted", "yes")
+			return next.RoundTrip(req)
+		})
+	})
+	assert.Equal(t, Transport, http.DefaultTransport)
+
+	resp, err := (&http.Client{}).Get(srv.URL)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "yes", string(body))
+
+	Intercept("127.0.0.1", nil)
+	assert.Equal(t, original, http.DefaultTransport)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
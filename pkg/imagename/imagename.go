@@ -0,0 +1,171 @@
+// Package imagename renders workspace image names from a naming template,
+// such as "dvm-{app}-{workspace}:{hash}".
+//
+// Placeholders are {ecosystem}, {domain}, {app}, and {workspace} for the
+// workspace's place in the hierarchy, and {hash} and {timestamp} for the
+// build. The repository (before the tag) names one workspace's images, so it
+// may only use the hierarchy placeholders, and must start with "dvm-" for dvm
+// to recognize its images. The tag tells builds apart, so it must use {hash}
+// or {timestamp}.
+package imagename
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultTemplate names images as dvm always has: a timestamp tag on a
+// per-workspace repository.
+const DefaultTemplate = "dvm-{workspace}-{app}:{timestamp}"
+
+// TimestampFormat is the layout {timestamp} is rendered with.
+const TimestampFormat = "20060102-150405"
+
+// HashLength is how many hex digits of the content hash {hash} renders.
+const HashLength = 12
+
+// Fields are the values of the placeholders.
+type Fields struct {
+	Ecosystem string
+	Domain    string
+	App       string
+	Workspace string
+	Hash      string // content hash, with or without a "sha256:" prefix
+	Time      time.Time
+}
+
+var (
+	placeholderRe = regexp.MustCompile(`\{([^{}]*)\}`)
+	componentRe   = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+	tagRe         = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	invalidRe     = regexp.MustCompile(`[^a-z0-9._-]+`)
+	separatorsRe  = regexp.MustCompile(`[._-]*-[._-]*|[._-]{2,}`)
+)
+
+var hierarchyPlaceholders = []string{"ecosystem", "domain", "app", "workspace"}
+var buildPlaceholders = []string{"hash", "timestamp"}
+
+// Validate checks a naming template without rendering it.
+func Validate(template string) error {
+	repo, tag, err := split(template)
+	if err != nil {
+		return err
+	}
+	for _, m := range placeholderRe.FindAllStringSubmatch(template, -1) {
+		if !slices.Contains(hierarchyPlaceholders, m[1]) && !slices.Contains(buildPlaceholders, m[1]) {
+			return fmt.Errorf("image name template %q: unknown placeholder {%s} (use {%s})",
+				template, m[1], strings.Join(append(hierarchyPlaceholders, buildPlaceholders...), "}, {"))
+		}
+	}
+	for _, p := range buildPlaceholders {
+		if strings.Contains(repo, "{"+p+"}") {
+			return fmt.Errorf("image name template %q: {%s} belongs in the tag, after the colon", template, p)
+		}
+	}
+	if !strings.Contains(tag, "{hash}") && !strings.Contains(tag, "{timestamp}") {
+		return fmt.Errorf("image name template %q: the tag must use {hash} or {timestamp} so builds don't overwrite each other", template)
+	}
+	if !strings.HasPrefix(repo, "dvm-") {
+		return fmt.Errorf("image name template %q: the repository must start with \"dvm-\"", template)
+	}
+	return nil
+}
+
+// Render returns the image name template names for fields. An empty
+// template means DefaultTemplate.
+func Render(template string, f Fields) (string, error) {
+	if template == "" {
+		template = DefaultTemplate
+	}
+	if err := Validate(template); err != nil {
+		return "", err
+	}
+	repo, tag, _ := split(template)
+
+	repo = normalizeRepository(expand(repo, f))
+	if !strings.HasPrefix(repo, "dvm-") {
+		return "", fmt.Errorf("image name template %q renders repository %q without the \"dvm-\" prefix", template, repo)
+	}
+	for _, component := range strings.Split(repo, "/") {
+		if !componentRe.MatchString(component) {
+			return "", fmt.Errorf("image name template %q renders invalid repository %q", template, repo)
+		}
+	}
+	tag = expand(tag, f)
+	if !tagRe.MatchString(tag) {
+		return "", fmt.Errorf("image name template %q renders invalid tag %q", template, tag)
+	}
+	return repo + ":" + tag, nil
+}
+
+// Repository returns the repository of the images template names for a
+// workspace: the part before the tag, which every build of it shares. The
+// build fields may be left empty.
+func Repository(template string, f Fields) (string, error) {
+	if f.Hash == "" {
+		f.Hash = "0"
+	}
+	name, err := Render(template, f)
+	if err != nil {
+		return "", err
+	}
+	return name[:strings.LastIndex(name, ":")], nil
+}
+
+// split separates a template into its repository and tag.
+func split(template string) (repo, tag string, err error) {
+	idx := strings.LastIndex(template, ":")
+	if idx <= strings.LastIndex(template, "/") {
+		return "", "", fmt.Errorf("image name template %q has no tag (add one after a colon, e.g. :{hash})", template)
+	}
+	return template[:idx], template[idx+1:], nil
+}
+
+func expand(s string, f Fields) string {
+	return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		switch m[1 : len(m)-1] {
+		case "ecosystem":
+			return f.Ecosystem
+		case "domain":
+			return f.Domain
+		case "app":
+			return f.App
+		case "workspace":
+			return f.Workspace
+		case "hash":
+			hash := strings.TrimPrefix(f.Hash, "sha256:")
+			if len(hash) > HashLength {
+				hash = hash[:HashLength]
+			}
+			return hash
+		case "timestamp":
+			return f.Time.Format(TimestampFormat)
+		}
+		return m
+	})
+}
+
+// normalizeRepository lowercases a rendered repository, replaces characters
+// registries don't allow, and drops the separators left over by empty
+// placeholders, so "dvm-{ecosystem}-{app}" with no ecosystem renders
+// "dvm-api" rather than "dvm--api".
+func normalizeRepository(repo string) string {
+	var components []string
+	for _, component := range strings.Split(strings.ToLower(repo), "/") {
+		component = invalidRe.ReplaceAllString(component, "-")
+		component = separatorsRe.ReplaceAllStringFunc(component, func(m string) string {
+			if strings.Contains(m, "-") {
+				return "-"
+			}
+			return m[:1]
+		})
+		component = strings.Trim(component, "._-")
+		if component != "" {
+			components = append(components, component)
+		}
+	}
+	return strings.Join(components, "/")
+}
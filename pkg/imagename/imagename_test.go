@@ -0,0 +1,72 @@
+package imagename
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fields = Fields{
+	Ecosystem: "acme",
+	Domain:    "payments",
+	App:       "api",
+	Workspace: "dev",
+	Hash:      "sha256:0123456789abcdef0123",
+	Time:      time.Date(2026, 4, 15, 23, 42, 18, 0, time.UTC),
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		template string
+		fields   Fields
+		want     string
+	}{
+		{"", fields, "dvm-dev-api:20260415-234218"},
+		{DefaultTemplate, fields, "dvm-dev-api:20260415-234218"},
+		{"dvm-{ecosystem}-{domain}-{app}-{workspace}:{hash}", fields, "dvm-acme-payments-api-dev:0123456789ab"},
+		{"dvm-{ecosystem}/{app}-{workspace}:{timestamp}-{hash}", fields, "dvm-acme/api-dev:20260415-234218-0123456789ab"},
+		// Empty placeholders leave no stray separators
+		{"dvm-{ecosystem}-{domain}-{app}:{hash}", Fields{App: "api", Hash: "abc"}, "dvm-api:abc"},
+		// Repositories are lowercased and cleaned
+		{"dvm-{app}:{hash}", Fields{App: "My App", Hash: "abc"}, "dvm-my-app:abc"},
+	}
+	for _, tt := range tests {
+		got, err := Render(tt.template, tt.fields)
+		require.NoError(t, err, tt.template)
+		assert.Equal(t, tt.want, got, tt.template)
+	}
+}
+
+func TestRender_Invalid(t *testing.T) {
+	for template, wantErr := range map[string]string{
+		"dvm-{app}":                     "no tag",
+		"dvm-{app}:latest":              "{hash} or {timestamp}",
+		"dvm-{app}-{hash}:{timestamp}":  "belongs in the tag",
+		"dvm-{app}:{hash}-{branch}":     "unknown placeholder {branch}",
+		"{app}-{workspace}:{hash}":      `must start with "dvm-"`,
+		"dvm-{ecosystem}/{app}:{hash}":  `without the "dvm-" prefix`,
+		"dvm-{app}:{workspace}.{hash}!": "invalid tag",
+	} {
+		_, err := Render(template, Fields{App: "api", Workspace: "dev", Hash: "abc"})
+		require.Error(t, err, template)
+		assert.Contains(t, err.Error(), wantErr, template)
+	}
+}
+
+func TestRepository(t *testing.T) {
+	repo, err := Repository("dvm-{ecosystem}-{app}-{workspace}:{hash}", fields)
+	require.NoError(t, err)
+	assert.Equal(t, "dvm-acme-api-dev", repo)
+
+	// The repository doesn't depend on the build
+	other := fields
+	other.Hash, other.Time = "", time.Time{}
+	repo, err = Repository(DefaultTemplate, other)
+	require.NoError(t, err)
+	assert.Equal(t, "dvm-dev-api", repo)
+	repo, err = Repository("dvm-{app}-{workspace}:{hash}", other)
+	require.NoError(t, err)
+	assert.Equal(t, "dvm-api-dev", repo)
+}
@@ -0,0 +1,146 @@
+// Package interactive implements the prompts shared by dvm, nvp, and dvt
+// and the global --yes and --non-interactive flags that answer or refuse
+// them, so the CLIs can be scripted.
+//
+// A prompt is only shown when stdin is a terminal and neither --yes nor
+// --non-interactive is set. --yes accepts every confirmation and takes the
+// default answer to every other question without asking. A confirmation
+// that can neither be shown nor skipped fails with ErrNonInteractive, and
+// one the user declines returns ErrAborted, which the CLIs turn into
+// ExitAborted instead of the generic failure status.
+package interactive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// Exit statuses of the CLIs.
+const (
+	// ExitFailure is the status of a command that failed.
+	ExitFailure = 1
+	// ExitAborted is the status of a command the user declined at a prompt.
+	ExitAborted = 2
+)
+
+var (
+	// ErrAborted is returned when the user declines a confirmation.
+	ErrAborted = errors.New("aborted by user")
+	// ErrNonInteractive is returned when a confirmation is needed but
+	// prompting is disabled.
+	ErrNonInteractive = errors.New("confirmation required but prompting is disabled (--non-interactive or stdin is not a terminal)")
+)
+
+var (
+	// AssumeYes accepts every confirmation without prompting (--yes).
+	AssumeYes bool
+	// NonInteractive disables all prompts (--non-interactive).
+	NonInteractive bool
+)
+
+// Overridden in tests.
+var (
+	stdin      io.Reader = os.Stdin
+	stdout     io.Writer = os.Stdout
+	isTerminal           = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+)
+
+// AddFlags registers --yes/-y and --non-interactive on a root command's
+// persistent flags.
+func AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVarP(&AssumeYes, "yes", "y", false, "Answer yes to all confirmation prompts")
+	fs.BoolVar(&NonInteractive, "non-interactive", false, "Never prompt; fail when a confirmation is needed without --yes or --force")
+}
+
+// Enabled reports whether prompts may be shown. Callers that ask for
+// something other than a confirmation fall back to their default answer
+// when it returns false.
+func Enabled() bool {
+	return !NonInteractive && !AssumeYes && isTerminal()
+}
+
+// Confirm asks a [y/N] question. It returns true without asking when skip
+// (typically the command's --force) or --yes is set. When prompting is
+// disabled it returns ErrNonInteractive; when the user declines it prints
+// "Aborted" and returns ErrAborted.
+func Confirm(message string, skip bool) (bool, error) {
+	if skip || AssumeYes {
+		return true, nil
+	}
+	if !Enabled() {
+		return false, fmt.Errorf("%w — use --yes (or --force) to confirm", ErrNonInteractive)
+	}
+	if !ask(message) {
+		render.Info("Aborted")
+		return false, ErrAborted
+	}
+	return true, nil
+}
+
+// Offer asks a [y/N] question about an optional step. Unlike Confirm,
+// declining is not an error: it returns false when the user says no or
+// prompting is disabled, and true under --yes.
+func Offer(message string) bool {
+	if AssumeYes {
+		return true
+	}
+	if !Enabled() {
+		return false
+	}
+	return ask(message)
+}
+
+// Choose asks the user to pick one of options by number and returns its
+// index. There is no default answer, so it returns ErrNonInteractive when
+// prompting is disabled (including under --yes); an empty answer returns
+// ErrAborted, and an invalid one is asked again.
+func Choose(message string, options []string) (int, error) {
+	if !Enabled() {
+		return -1, ErrNonInteractive
+	}
+	fmt.Fprintln(stdout, message)
+	for i, option := range options {
+		fmt.Fprintf(stdout, "  %d) %s\n", i+1, option)
+	}
+	reader := bufio.NewReader(stdin)
+	for {
+		fmt.Fprintf(stdout, "Enter a number (1-%d), or press Enter to cancel: ", len(options))
+		response, err := reader.ReadString('\n')
+		response = strings.TrimSpace(response)
+		if response == "" {
+			render.Info("Aborted")
+			return -1, ErrAborted
+		}
+		if n, convErr := strconv.Atoi(response); convErr == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		if err != nil {
+			return -1, ErrAborted
+		}
+		fmt.Fprintf(stdout, "%q is not one of the choices.\n", response)
+	}
+}
+
+// ExitCode returns the process exit status for a command error.
+func ExitCode(err error) int {
+	if errors.Is(err, ErrAborted) {
+		return ExitAborted
+	}
+	return ExitFailure
+}
+
+func ask(message string) bool {
+	fmt.Fprintf(stdout, "%s [y/N]: ", message)
+	response, _ := bufio.NewReader(stdin).ReadString('\n')
+	response = strings.TrimSpace(response)
+	return response == "y" || response == "Y"
+}
@@ -0,0 +1,156 @@
+package interactive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// setup resets the flags and fakes a terminal (or not) answering input.
+func setup(t *testing.T, terminal bool, input string) *bytes.Buffer {
+	t.Helper()
+	oldIn, oldOut, oldTerm := stdin, stdout, isTerminal
+	oldYes, oldNon := AssumeYes, NonInteractive
+	t.Cleanup(func() {
+		stdin, stdout, isTerminal = oldIn, oldOut, oldTerm
+		AssumeYes, NonInteractive = oldYes, oldNon
+	})
+	out := &bytes.Buffer{}
+	stdin = strings.NewReader(input)
+	stdout = out
+	isTerminal = func() bool { return terminal }
+	AssumeYes, NonInteractive = false, false
+	return out
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name     string
+		terminal bool
+		input    string
+		skip     bool
+		yes      bool
+		non      bool
+		want     bool
+		wantErr  error
+		asked    bool
+	}{
+		{name: "accepted", terminal: true, input: "y\n", want: true, asked: true},
+		{name: "accepted upper case", terminal: true, input: "Y\n", want: true, asked: true},
+		{name: "declined", terminal: true, input: "n\n", wantErr: ErrAborted, asked: true},
+		{name: "empty answer declines", terminal: true, input: "\n", wantErr: ErrAborted, asked: true},
+		{name: "skip", terminal: true, skip: true, want: true},
+		{name: "yes", terminal: true, yes: true, want: true},
+		{name: "yes without terminal", yes: true, want: true},
+		{name: "no terminal", wantErr: ErrNonInteractive},
+		{name: "non-interactive", terminal: true, non: true, wantErr: ErrNonInteractive},
+		{name: "skip overrides non-interactive", non: true, skip: true, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := setup(t, tt.terminal, tt.input)
+			AssumeYes, NonInteractive = tt.yes, tt.non
+
+			got, err := Confirm("Delete it?", tt.skip)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Confirm() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+			if asked := strings.Contains(out.String(), "Delete it? [y/N]"); asked != tt.asked {
+				t.Errorf("prompt shown = %v, want %v (output %q)", asked, tt.asked, out.String())
+			}
+		})
+	}
+}
+
+func TestOffer(t *testing.T) {
+	setup(t, true, "y\n")
+	if !Offer("Create it?") {
+		t.Error("Offer() = false after answering y")
+	}
+
+	setup(t, true, "n\n")
+	if Offer("Create it?") {
+		t.Error("Offer() = true after answering n")
+	}
+
+	setup(t, false, "")
+	if Offer("Create it?") {
+		t.Error("Offer() = true without a terminal")
+	}
+
+	setup(t, false, "")
+	AssumeYes = true
+	if !Offer("Create it?") {
+		t.Error("Offer() = false with --yes")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	setup(t, true, "")
+	if !Enabled() {
+		t.Error("Enabled() = false on a terminal")
+	}
+	AssumeYes = true
+	if Enabled() {
+		t.Error("Enabled() = true with --yes")
+	}
+	AssumeYes, NonInteractive = false, true
+	if Enabled() {
+		t.Error("Enabled() = true with --non-interactive")
+	}
+}
+
+func TestChoose(t *testing.T) {
+	options := []string{"eco/dom/api/dev", "eco/dom/web/dev"}
+
+	out := setup(t, true, "2\n")
+	if got, err := Choose("Which workspace?", options); err != nil || got != 1 {
+		t.Errorf("Choose() = %d, %v, want 1, nil", got, err)
+	}
+	if !strings.Contains(out.String(), "  1) eco/dom/api/dev") {
+		t.Errorf("Choose() did not list the options:\n%s", out.String())
+	}
+
+	out = setup(t, true, "7\n1\n")
+	if got, err := Choose("Which workspace?", options); err != nil || got != 0 {
+		t.Errorf("Choose() after an invalid answer = %d, %v, want 0, nil", got, err)
+	}
+	if !strings.Contains(out.String(), `"7" is not one of the choices`) {
+		t.Errorf("Choose() did not reject the invalid answer:\n%s", out.String())
+	}
+
+	setup(t, true, "\n")
+	if _, err := Choose("Which workspace?", options); !errors.Is(err, ErrAborted) {
+		t.Errorf("Choose() with an empty answer error = %v, want ErrAborted", err)
+	}
+
+	setup(t, true, "x")
+	if _, err := Choose("Which workspace?", options); !errors.Is(err, ErrAborted) {
+		t.Errorf("Choose() at end of input error = %v, want ErrAborted", err)
+	}
+
+	setup(t, false, "1\n")
+	if _, err := Choose("Which workspace?", options); !errors.Is(err, ErrNonInteractive) {
+		t.Errorf("Choose() without a terminal error = %v, want ErrNonInteractive", err)
+	}
+
+	setup(t, true, "1\n")
+	AssumeYes = true
+	if _, err := Choose("Which workspace?", options); !errors.Is(err, ErrNonInteractive) {
+		t.Errorf("Choose() with --yes error = %v, want ErrNonInteractive", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(fmt.Errorf("delete: %w", ErrAborted)); got != ExitAborted {
+		t.Errorf("ExitCode(aborted) = %d, want %d", got, ExitAborted)
+	}
+	if got := ExitCode(errors.New("boom")); got != ExitFailure {
+		t.Errorf("ExitCode(failure) = %d, want %d", got, ExitFailure)
+	}
+}
@@ -0,0 +1,52 @@
+// Package gitcmd runs the git CLI for the nvimbridge packages that clone,
+// fetch, and push plugin repositories.
+package gitcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Timeout bounds one git command.
+const Timeout = 5 * time.Minute
+
+// Run runs a git command in dir (the current directory when empty) with
+// prompts disabled and returns its stdout.
+func Run(ctx context.Context, dir string, args ...string) (string, error) {
+	return RunEnv(ctx, nil, dir, args...)
+}
+
+// RunEnv is Run with extra environment variables.
+func RunEnv(ctx context.Context, env []string, dir string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), env...)
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("git %s timed out after 5 minutes", verb(args))
+	}
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", verb(args), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// verb returns the subcommand of a git argument list for error messages.
+func verb(args []string) string {
+	if len(args) > 2 && args[0] == "-C" {
+		return args[2]
+	}
+	return args[0]
+}
@@ -0,0 +1,28 @@
+package gitcmd
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	_, err := Run(ctx, dir, "init", "--quiet")
+	require.NoError(t, err)
+	out, err := RunEnv(ctx, []string{"GIT_AUTHOR_NAME=nvp", "GIT_AUTHOR_EMAIL=nvp@localhost"}, dir, "var", "GIT_AUTHOR_IDENT")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "nvp <nvp@localhost>"), out)
+
+	_, err = Run(ctx, dir, "rev-parse", "--verify", "--quiet", "refs/heads/missing")
+	assert.ErrorContains(t, err, "git rev-parse failed")
+}
@@ -0,0 +1,38 @@
+// Package listmerge merges the string lists of packages that extend other
+// packages, shared by the nvim and terminal package resolvers.
+package listmerge
+
+import "strings"
+
+// Merge appends child entries to base, skipping duplicates. Entries
+// prefixed with "-" remove the named item instead.
+func Merge(base, child []string) []string {
+	if len(child) == 0 {
+		return base
+	}
+
+	removed := make(map[string]bool)
+	for _, item := range child {
+		if strings.HasPrefix(item, "-") {
+			removed[strings.TrimPrefix(item, "-")] = true
+		}
+	}
+
+	out := make([]string, 0, len(base)+len(child))
+	seen := make(map[string]bool)
+	for _, item := range base {
+		if removed[item] || seen[item] {
+			continue
+		}
+		seen[item] = true
+		out = append(out, item)
+	}
+	for _, item := range child {
+		if strings.HasPrefix(item, "-") || seen[item] {
+			continue
+		}
+		seen[item] = true
+		out = append(out, item)
+	}
+	return out
+}
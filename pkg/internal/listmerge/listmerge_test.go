@@ -0,0 +1,14 @@
+package listmerge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	base := []string{"a", "b", "c"}
+	assert.Equal(t, base, Merge(base, nil))
+	assert.Equal(t, []string{"a", "c", "d"}, Merge(base, []string{"-b", "c", "d", "d"}))
+	assert.Equal(t, []string{"x"}, Merge([]string{"x", "x"}, []string{"-y"}))
+}
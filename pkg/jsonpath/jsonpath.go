@@ -0,0 +1,389 @@
+// Package jsonpath evaluates the subset of kubectl JSONPath templates used by
+// dvm -o jsonpath=... against generic data: the map[string]any, []any, and
+// scalar values produced by decoding JSON or YAML into an any.
+//
+// # Syntax
+//
+// Text outside braces is copied as is. Inside braces:
+//
+//	{.metadata.name}          field access, relative to the current object
+//	{$.items[0]}              $ is the root object, @ the current object
+//	{.items[*].metadata.name} [n] indexes (negative counts from the end),
+//	                          [*] and .* select every element or value
+//	{.labels['app.kind']}     bracketed keys may contain dots
+//	{..name}                  recursive descent
+//	{"\n"}                    quoted literal with Go escapes
+//	{range .items[*]}...{end} repeat the body for each result
+//
+// A path that selects several values prints them separated by spaces.
+// Missing keys select nothing, so they print as empty text. Maps and lists
+// print as JSON. Filters ([?(...)]) and slices ([a:b]) are not supported.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Template is a parsed JSONPath template.
+type Template struct {
+	nodes []node
+}
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	pathNode
+	rangeNode
+)
+
+type node struct {
+	kind  nodeKind
+	text  string // textNode
+	path  path   // pathNode, rangeNode
+	nodes []node // rangeNode body
+}
+
+type stepKind int
+
+const (
+	fieldStep stepKind = iota
+	indexStep
+	wildcardStep
+	recursiveStep
+)
+
+type step struct {
+	kind  stepKind
+	name  string // fieldStep, recursiveStep
+	index int    // indexStep
+}
+
+type path struct {
+	root  bool // starts at $ rather than the current object
+	steps []step
+}
+
+// Parse parses a JSONPath template. Like kubectl, a template without any
+// braces is treated as a single expression, so ".metadata.name" and
+// "{.metadata.name}" are equivalent.
+func Parse(text string) (*Template, error) {
+	if !strings.Contains(text, "{") {
+		text = "{" + text + "}"
+	}
+	nodes, _, err := parseNodes(text, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// parseNodes parses text up to the end of input or, when inRange is set, up
+// to the matching {end}. It returns the unparsed remainder after {end}.
+func parseNodes(text string, inRange bool) ([]node, string, error) {
+	var nodes []node
+	for text != "" {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			nodes = append(nodes, node{kind: textNode, text: text})
+			text = ""
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, node{kind: textNode, text: text[:open]})
+		}
+		end := closingBrace(text[open:])
+		if end < 0 {
+			return nil, "", fmt.Errorf("jsonpath: unclosed action in %q", text[open:])
+		}
+		action := strings.TrimSpace(text[open+1 : open+end])
+		text = text[open+end+1:]
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("jsonpath: {end} without {range}")
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(action, "range "):
+			p, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+			body, rest, err := parseNodes(text, true)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node{kind: rangeNode, path: p, nodes: body})
+			text = rest
+		case strings.HasPrefix(action, `"`):
+			s, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, "", fmt.Errorf("jsonpath: invalid literal %s", action)
+			}
+			nodes = append(nodes, node{kind: textNode, text: s})
+		default:
+			p, err := parsePath(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, node{kind: pathNode, path: p})
+		}
+	}
+	if inRange {
+		return nil, "", fmt.Errorf("jsonpath: {range} without {end}")
+	}
+	return nodes, "", nil
+}
+
+// closingBrace returns the index of the brace that closes the action opened
+// at s[0], skipping braces inside quotes, or -1.
+func closingBrace(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '}':
+			return i
+		}
+	}
+	return -1
+}
+
+// parsePath parses an expression such as $.items[*].metadata.name.
+func parsePath(expr string) (path, error) {
+	var p path
+	s := expr
+	switch {
+	case strings.HasPrefix(s, "$"):
+		p.root = true
+		s = s[1:]
+	case strings.HasPrefix(s, "@"):
+		s = s[1:]
+	}
+	if s == "" && expr != "" {
+		return p, nil
+	}
+	if s == "" || (s[0] != '.' && s[0] != '[') {
+		return p, fmt.Errorf("jsonpath: invalid expression %q", expr)
+	}
+
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := splitName(s[2:])
+			if name == "" {
+				return p, fmt.Errorf("jsonpath: missing field after .. in %q", expr)
+			}
+			p.steps = append(p.steps, step{kind: recursiveStep, name: name})
+			s = rest
+		case s[0] == '.':
+			name, rest := splitName(s[1:])
+			switch name {
+			case "":
+				// A lone "." selects the current object
+				if rest != "" {
+					return p, fmt.Errorf("jsonpath: missing field name in %q", expr)
+				}
+			case "*":
+				p.steps = append(p.steps, step{kind: wildcardStep})
+			default:
+				p.steps = append(p.steps, step{kind: fieldStep, name: name})
+			}
+			s = rest
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return p, fmt.Errorf("jsonpath: unclosed [ in %q", expr)
+			}
+			st, err := parseSubscript(strings.TrimSpace(s[1:end]), expr)
+			if err != nil {
+				return p, err
+			}
+			p.steps = append(p.steps, st)
+			s = s[end+1:]
+		default:
+			return p, fmt.Errorf("jsonpath: unexpected %q in %q", s, expr)
+		}
+	}
+	return p, nil
+}
+
+// splitName splits a field name off the front of s.
+func splitName(s string) (string, string) {
+	i := strings.IndexAny(s, ".[")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// parseSubscript parses the inside of [...].
+func parseSubscript(sub, expr string) (step, error) {
+	switch {
+	case sub == "*":
+		return step{kind: wildcardStep}, nil
+	case len(sub) >= 2 && (sub[0] == '\'' || sub[0] == '"') && sub[len(sub)-1] == sub[0]:
+		return step{kind: fieldStep, name: sub[1 : len(sub)-1]}, nil
+	case strings.HasPrefix(sub, "?"):
+		return step{}, fmt.Errorf("jsonpath: filters are not supported in %q", expr)
+	case strings.Contains(sub, ":") || strings.Contains(sub, ","):
+		return step{}, fmt.Errorf("jsonpath: slices and unions are not supported in %q", expr)
+	}
+	n, err := strconv.Atoi(sub)
+	if err != nil {
+		return step{}, fmt.Errorf("jsonpath: invalid subscript [%s] in %q", sub, expr)
+	}
+	return step{kind: indexStep, index: n}, nil
+}
+
+// Execute writes the template applied to data.
+func (t *Template) Execute(w io.Writer, data any) error {
+	return execute(w, t.nodes, data, data)
+}
+
+func execute(w io.Writer, nodes []node, root, cur any) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			if _, err := io.WriteString(w, n.text); err != nil {
+				return err
+			}
+		case pathNode:
+			values := n.path.eval(root, cur)
+			parts := make([]string, 0, len(values))
+			for _, v := range values {
+				s, err := format(v)
+				if err != nil {
+					return err
+				}
+				parts = append(parts, s)
+			}
+			if _, err := io.WriteString(w, strings.Join(parts, " ")); err != nil {
+				return err
+			}
+		case rangeNode:
+			values := n.path.eval(root, cur)
+			// {range .items} iterates the list itself, like {range .items[*]}
+			if len(values) == 1 {
+				if list, ok := values[0].([]any); ok {
+					values = list
+				}
+			}
+			for _, v := range values {
+				if err := execute(w, n.nodes, root, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// eval returns the values the path selects.
+func (p path) eval(root, cur any) []any {
+	values := []any{cur}
+	if p.root {
+		values = []any{root}
+	}
+	for _, st := range p.steps {
+		var next []any
+		for _, v := range values {
+			next = append(next, st.apply(v)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func (st step) apply(v any) []any {
+	switch st.kind {
+	case fieldStep:
+		if m, ok := v.(map[string]any); ok {
+			if fv, ok := m[st.name]; ok {
+				return []any{fv}
+			}
+		}
+	case indexStep:
+		if list, ok := v.([]any); ok {
+			i := st.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i >= 0 && i < len(list) {
+				return []any{list[i]}
+			}
+		}
+	case wildcardStep:
+		switch c := v.(type) {
+		case []any:
+			return c
+		case map[string]any:
+			out := make([]any, 0, len(c))
+			for _, k := range slices.Sorted(maps.Keys(c)) {
+				out = append(out, c[k])
+			}
+			return out
+		}
+	case recursiveStep:
+		var out []any
+		collect(v, st.name, &out)
+		return out
+	}
+	return nil
+}
+
+// collect appends every value stored under name at any depth of v.
+func collect(v any, name string, out *[]any) {
+	switch c := v.(type) {
+	case map[string]any:
+		if fv, ok := c[name]; ok {
+			*out = append(*out, fv)
+		}
+		for _, k := range slices.Sorted(maps.Keys(c)) {
+			collect(c[k], name, out)
+		}
+	case []any:
+		for _, item := range c {
+			collect(item, name, out)
+		}
+	}
+}
+
+// format prints a selected value: strings raw, numbers without a trailing
+// .0, times as RFC 3339, null as empty text, and maps and lists as JSON.
+func format(v any) (string, error) {
+	switch c := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return c, nil
+	case bool:
+		return strconv.FormatBool(c), nil
+	case float64:
+		return strconv.FormatFloat(c, 'f', -1, 64), nil
+	case json.Number:
+		return c.String(), nil
+	case time.Time:
+		return c.Format(time.RFC3339), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("jsonpath: %w", err)
+	}
+	return string(b), nil
+}
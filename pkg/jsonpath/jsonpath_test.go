@@ -0,0 +1,96 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDoc = `{
+  "kind": "List",
+  "items": [
+    {"metadata": {"name": "dev", "labels": {"app.kind": "api"}}, "spec": {"image": "dvm-dev-api:1", "ports": [8080, 9090]}},
+    {"metadata": {"name": "staging"}, "spec": {"image": "dvm-staging-api:2", "enabled": true}}
+  ]
+}`
+
+func testData(t *testing.T) any {
+	t.Helper()
+	var data any
+	if err := json.Unmarshal([]byte(testDoc), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"field", "{.kind}", "List"},
+		{"without braces", ".kind", "List"},
+		{"index", "{.items[0].spec.image}", "dvm-dev-api:1"},
+		{"negative index", "{.items[-1].metadata.name}", "staging"},
+		{"wildcard", "{.items[*].metadata.name}", "dev staging"},
+		{"root", "{$.items[1].spec.enabled}", "true"},
+		{"number", "{.items[0].spec.ports[1]}", "9090"},
+		{"quoted key", "{.items[0].metadata.labels['app.kind']}", "api"},
+		{"recursive", "{..name}", "dev staging"},
+		{"missing", "{.items[0].spec.missing}", ""},
+		{"list as json", "{.items[0].spec.ports}", "[8080,9090]"},
+		{"text and literal", `kind={.kind}{"\n"}`, "kind=List\n"},
+		{"range", `{range .items[*]}{.metadata.name}={.spec.image}{"\n"}{end}`, "dev=dvm-dev-api:1\nstaging=dvm-staging-api:2\n"},
+		{"range list", `{range .items}{.metadata.name},{end}`, "dev,staging,"},
+		{"root inside range", `{range .items[*]}{$.kind} {end}`, "List List "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Parse(tt.template)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.template, err)
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, testData(t)); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("Execute(%q) = %q, want %q", tt.template, b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, template := range []string{
+		"{.items",
+		"{range .items[*]}{.name}",
+		"{.name}{end}",
+		"{items}",
+		"{.items[?(@.name=='dev')]}",
+		"{.items[0:2]}",
+		"{.items[x]}",
+		`{"unterminated}`,
+	} {
+		if _, err := Parse(template); err == nil {
+			t.Errorf("Parse(%q) expected error", template)
+		}
+	}
+}
+
+func TestExecuteTime(t *testing.T) {
+	tmpl, err := Parse("{.created}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := tmpl.Execute(&b, map[string]any{"created": created}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "2026-01-02T03:04:05Z" {
+		t.Errorf("Execute() = %q", b.String())
+	}
+}
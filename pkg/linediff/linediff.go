@@ -0,0 +1,86 @@
+// Package linediff diffs two texts line by line using their longest common
+// subsequence. The texts dvm diffs (plugin definitions, opts blocks,
+// resource YAML) are small, so the quadratic table is fine.
+package linediff
+
+import "strings"
+
+// Op says whether a diff line is in both texts, only the old one, or only
+// the new one. Its value is the line's prefix in unified diff format.
+type Op byte
+
+const (
+	Equal  Op = ' '
+	Delete Op = '-'
+	Insert Op = '+'
+)
+
+// Line is one line of a diff.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines diffs a and b, returning every line of both in order. Where lines
+// were replaced, the deleted lines come before the inserted ones.
+func Lines(a, b string) []Line {
+	x := splitLines(a)
+	y := splitLines(b)
+
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			lines = append(lines, Line{Equal, x[i]})
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, Line{Delete, x[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, y[j]})
+			j++
+		}
+	}
+	return lines
+}
+
+// Unified diffs a and b in unified diff line format, without headers or
+// hunks, and counts the added and removed lines.
+func Unified(a, b string) (diff string, added, removed int) {
+	var sb strings.Builder
+	for _, l := range Lines(a, b) {
+		switch l.Op {
+		case Insert:
+			added++
+		case Delete:
+			removed++
+		}
+		sb.WriteByte(byte(l.Op))
+		sb.WriteString(l.Text + "\n")
+	}
+	return sb.String(), added, removed
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
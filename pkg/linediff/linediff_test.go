@@ -0,0 +1,30 @@
+package linediff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	assert.Equal(t, []Line{
+		{Equal, "a"},
+		{Delete, "b"},
+		{Insert, "c"},
+		{Equal, "d"},
+	}, Lines("a\nb\nd\n", "a\nc\nd"))
+
+	assert.Nil(t, Lines("", "\n"))
+	assert.Equal(t, []Line{{Insert, "x"}}, Lines("", "x\n"))
+}
+
+func TestUnified(t *testing.T) {
+	diff, added, removed := Unified("kind: App\nspec:\n  path: /old\n", "kind: App\nspec:\n  path: /new\n  language: go\n")
+	assert.Equal(t, " kind: App\n spec:\n-  path: /old\n+  path: /new\n+  language: go\n", diff)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, 1, removed)
+
+	diff, added, removed = Unified("same\n", "same\n")
+	assert.Equal(t, " same\n", diff)
+	assert.Zero(t, added+removed)
+}
@@ -0,0 +1,337 @@
+// Package manifestvars substitutes ${NAME} variables in resource manifests at
+// apply time, so one manifest can describe the same resources for several
+// ecosystems, languages, or environments.
+//
+// # Placeholders
+//
+// ${NAME} is replaced with the value of the variable NAME. ${NAME:-fallback}
+// uses fallback when NAME has no value. $${NAME} is an escape that renders as
+// the literal text ${NAME}. A placeholder without a value or fallback is left
+// as it is, so runtime placeholders such as ${HOME} in an environment variable
+// pass through unchanged. ${secret:...} and ${param:...} are not variables.
+//
+// Like envtemplate, placeholders are substituted inside YAML scalars after
+// parsing, never into the raw text, so a value cannot inject YAML structure.
+//
+// # Values
+//
+// Variables are looked up, first match wins, in:
+//
+//  1. Sources.Values: dvm apply --set name=value, then --values files
+//  2. the environment variable DVM_VAR_<NAME>
+//  3. the manifest's own valuesFrom list, later entries winning
+//  4. the defaults table key var.<name>
+//
+// A document lists values files and environment variables to read with a
+// top-level valuesFrom key, which is removed before the document is applied:
+//
+//	valuesFrom:
+//	  - file: values/prod.yaml   # relative to the manifest
+//	  - env: LANGUAGE            # ${LANGUAGE} from the environment
+package manifestvars
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesFromKey is the top-level document key listing value sources.
+const ValuesFromKey = "valuesFrom"
+
+// EnvPrefix prefixes the environment variables that set variables.
+const EnvPrefix = "DVM_VAR_"
+
+// DefaultsPrefix prefixes the defaults table keys that set variables.
+const DefaultsPrefix = "var."
+
+// placeholderRegex matches ${NAME}, ${NAME:-fallback}, and the escaped form
+// $${NAME}.
+var placeholderRegex = regexp.MustCompile(`\$(\$)?\{([A-Za-z_][A-Za-z0-9_.-]*)(:-([^}]*))?\}`)
+
+// nameRegex matches a valid variable name.
+var nameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Sources are where Render looks up variables.
+type Sources struct {
+	// Values are the variables given on the command line.
+	Values map[string]string
+	// Env looks up an environment variable; nil disables the environment.
+	Env func(key string) (string, bool)
+	// Defaults looks up a defaults table key; nil disables the table.
+	Defaults func(key string) (string, bool)
+	// BaseDir is the directory valuesFrom files are relative to.
+	BaseDir string
+	// Local reports whether the manifest was read from the local machine.
+	// Manifests from URLs may not read local files through valuesFrom.
+	Local bool
+}
+
+// ValueSource is an entry of a document's valuesFrom list.
+type ValueSource struct {
+	File string `yaml:"file,omitempty"`
+	Env  string `yaml:"env,omitempty"`
+}
+
+// Render substitutes variables in every document of data. It returns data
+// unchanged when no document has placeholders to substitute or a valuesFrom
+// key to remove.
+func Render(data []byte, src Sources) ([]byte, error) {
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 0; ; i++ {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+		docs = append(docs, &node)
+	}
+
+	changed := false
+	for i, doc := range docs {
+		entries, found, err := takeValuesFrom(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+		own, err := src.load(entries)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i+1, err)
+		}
+		if substitute(doc, func(name string) (string, bool) { return src.lookup(name, own) }) || found {
+			changed = true
+		}
+	}
+	if !changed {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, fmt.Errorf("failed to encode rendered manifest: %w", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode rendered manifest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// lookup returns the value of the variable name, with own the values of the
+// document's valuesFrom list.
+func (s Sources) lookup(name string, own map[string]string) (string, bool) {
+	if v, ok := s.Values[name]; ok {
+		return v, true
+	}
+	if s.Env != nil {
+		if v, ok := s.Env(EnvKey(name)); ok {
+			return v, true
+		}
+	}
+	if v, ok := own[name]; ok {
+		return v, true
+	}
+	if s.Defaults != nil {
+		if v, ok := s.Defaults(DefaultsPrefix + name); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// load reads the values of a valuesFrom list, later entries winning.
+func (s Sources) load(entries []ValueSource) (map[string]string, error) {
+	values := map[string]string{}
+	for i, e := range entries {
+		switch {
+		case e.File != "" && e.Env != "":
+			return nil, fmt.Errorf("%s[%d]: set one of file and env", ValuesFromKey, i)
+		case e.File != "":
+			if !s.Local {
+				return nil, fmt.Errorf("%s[%d]: files are only read for local manifests", ValuesFromKey, i)
+			}
+			path := e.File
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(s.BaseDir, path)
+			}
+			file, err := LoadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", ValuesFromKey, i, err)
+			}
+			for k, v := range file {
+				values[k] = v
+			}
+		case e.Env != "":
+			if !nameRegex.MatchString(e.Env) {
+				return nil, fmt.Errorf("%s[%d]: invalid variable name %q", ValuesFromKey, i, e.Env)
+			}
+			if s.Env == nil {
+				continue
+			}
+			if v, ok := s.Env(e.Env); ok {
+				values[e.Env] = v
+			}
+		default:
+			return nil, fmt.Errorf("%s[%d]: set file or env", ValuesFromKey, i)
+		}
+	}
+	return values, nil
+}
+
+// takeValuesFrom removes the top-level valuesFrom key of doc and returns its
+// entries, and whether it was there.
+func takeValuesFrom(doc *yaml.Node) ([]ValueSource, bool, error) {
+	root := doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, false, nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != ValuesFromKey {
+			continue
+		}
+		var entries []ValueSource
+		if err := root.Content[i+1].Decode(&entries); err != nil {
+			return nil, false, fmt.Errorf("invalid %s: %w", ValuesFromKey, err)
+		}
+		root.Content = slices.Delete(root.Content, i, i+2)
+		return entries, true, nil
+	}
+	return nil, false, nil
+}
+
+// substitute replaces placeholders in the scalars of a parsed document and
+// reports whether any scalar changed. Plain scalars have their tag cleared so
+// that, for example, "replicas: ${count}" still decodes as a number.
+func substitute(node *yaml.Node, lookup func(string) (string, bool)) bool {
+	changed := false
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode {
+			if v := substituteString(n.Value, lookup); v != n.Value {
+				n.Value = v
+				if n.Style == 0 {
+					n.Tag = ""
+				}
+				changed = true
+			}
+			return
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(node)
+	return changed
+}
+
+// substituteString replaces the placeholders in s.
+func substituteString(s string, lookup func(string) (string, bool)) string {
+	return placeholderRegex.ReplaceAllStringFunc(s, func(match string) string {
+		m := placeholderRegex.FindStringSubmatch(match)
+		if m[1] != "" {
+			return match[1:]
+		}
+		if v, ok := lookup(m[2]); ok {
+			return v
+		}
+		if m[3] != "" {
+			return m[4]
+		}
+		return match
+	})
+}
+
+// EnvKey returns the environment variable that sets the variable name:
+// DVM_VAR_ and the name in upper case, with dots and dashes as underscores.
+func EnvKey(name string) string {
+	return EnvPrefix + strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(name))
+}
+
+// ParseAssignment parses a name=value --set argument.
+func ParseAssignment(s string) (string, string, error) {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid variable %q: expected name=value", s)
+	}
+	if !nameRegex.MatchString(name) {
+		return "", "", fmt.Errorf("invalid variable name %q", name)
+	}
+	return name, value, nil
+}
+
+// LoadFile reads a values file: a YAML mapping of variable names to
+// values. Nested mappings name their variables with dotted paths, so
+// {db: {host: x}} sets ${db.host}. Lists are not values.
+func LoadFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file: %w", err)
+	}
+	values, err := ParseValues(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ParseValues parses the contents of a values file.
+func ParseValues(data []byte) (map[string]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	if len(doc.Content) == 0 {
+		return values, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("values must be a mapping of names to values")
+	}
+	var walk func(prefix string, n *yaml.Node) error
+	walk = func(prefix string, n *yaml.Node) error {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			name := n.Content[i].Value
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if !nameRegex.MatchString(name) {
+				return fmt.Errorf("invalid variable name %q", name)
+			}
+			switch v := n.Content[i+1]; v.Kind {
+			case yaml.ScalarNode:
+				if v.Tag == "!!null" {
+					continue
+				}
+				values[name] = v.Value
+			case yaml.MappingNode:
+				if err := walk(name, v); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("%s: values must be scalars or mappings", name)
+			}
+		}
+		return nil
+	}
+	if err := walk("", root); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,187 @@
+package manifestvars
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testManifest = `apiVersion: devopsmaestro.io/v1
+kind: App
+metadata:
+  name: ${name}-api
+  domain: ${domain:-backend}
+spec:
+  language: ${language}
+  replicas: ${replicas}
+  path: ${APP_PATH}
+  build:
+    args:
+      GREETING: "$${name}"
+`
+
+func TestRender(t *testing.T) {
+	env := map[string]string{"DVM_VAR_LANGUAGE": "python", "DVM_VAR_REPLICAS": "3"}
+	defaults := map[string]string{"var.name": "orders", "var.language": "rust"}
+	src := Sources{
+		Values:   map[string]string{"language": "go"},
+		Env:      func(k string) (string, bool) { v, ok := env[k]; return v, ok },
+		Defaults: func(k string) (string, bool) { v, ok := defaults[k]; return v, ok },
+	}
+
+	out, err := Render([]byte(testManifest), src)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var doc struct {
+		Metadata map[string]string `yaml:"metadata"`
+		Spec     struct {
+			Language string `yaml:"language"`
+			Replicas any    `yaml:"replicas"`
+			Path     string `yaml:"path"`
+			Build    struct {
+				Args map[string]string `yaml:"args"`
+			} `yaml:"build"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("rendered manifest does not parse: %v\n%s", err, out)
+	}
+	checks := map[string]string{
+		"metadata.name":            doc.Metadata["name"],
+		"metadata.domain":          doc.Metadata["domain"],
+		"spec.language":            doc.Spec.Language,
+		"spec.path":                doc.Spec.Path,
+		"spec.build.args.GREETING": doc.Spec.Build.Args["GREETING"],
+	}
+	want := map[string]string{
+		"metadata.name":            "orders-api",  // defaults table
+		"metadata.domain":          "backend",     // fallback
+		"spec.language":            "go",          // --set wins over the environment
+		"spec.path":                "${APP_PATH}", // unresolved placeholders stay
+		"spec.build.args.GREETING": "${name}",     // escaped
+	}
+	for field, got := range checks {
+		if got != want[field] {
+			t.Errorf("%s = %q, want %q", field, got, want[field])
+		}
+	}
+	if doc.Spec.Replicas != 3 {
+		t.Errorf("spec.replicas = %#v, want the number 3", doc.Spec.Replicas)
+	}
+}
+
+func TestRender_Unchanged(t *testing.T) {
+	data := []byte("kind: Workspace\nmetadata:   {name: dev}\nspec:\n  env:\n    TOKEN: ${secret:github}\n    HOME: ${HOME}\n")
+	out, err := Render(data, Sources{Env: func(string) (string, bool) { return "", false }})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if string(out) != string(data) {
+		t.Errorf("Render() = %q, want the manifest unchanged", out)
+	}
+}
+
+func TestRender_ValuesFrom(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "prod.yaml"), []byte("language: go\ndb:\n  host: db.prod\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte(`valuesFrom:
+  - file: prod.yaml
+  - env: TEAM
+kind: App
+metadata:
+  name: api
+spec:
+  language: ${language}
+  env:
+    DB_HOST: ${db.host}
+    TEAM: ${TEAM}
+`)
+	env := func(k string) (string, bool) {
+		if k == "TEAM" {
+			return "platform", true
+		}
+		return "", false
+	}
+
+	out, err := Render(data, Sources{Env: env, BaseDir: dir, Local: true})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(string(out), ValuesFromKey) {
+		t.Errorf("rendered manifest still has %s:\n%s", ValuesFromKey, out)
+	}
+	for _, want := range []string{"language: go", "DB_HOST: db.prod", "TEAM: platform"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("rendered manifest missing %q:\n%s", want, out)
+		}
+	}
+
+	// The command line wins over the manifest's own values
+	out, err = Render(data, Sources{Values: map[string]string{"language": "python"}, Env: env, BaseDir: dir, Local: true})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(string(out), "language: python") {
+		t.Errorf("--set value not used:\n%s", out)
+	}
+
+	// A remote manifest may not read local files
+	if _, err := Render(data, Sources{Env: env}); err == nil || !strings.Contains(err.Error(), "only read for local manifests") {
+		t.Errorf("Render() of a remote manifest error = %v, want the local files error", err)
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	tests := []struct {
+		in        string
+		name, val string
+		wantErr   bool
+	}{
+		{in: "language=go", name: "language", val: "go"},
+		{in: "url=http://x?a=b", name: "url", val: "http://x?a=b"},
+		{in: "empty=", name: "empty", val: ""},
+		{in: "novalue", wantErr: true},
+		{in: "1bad=x", wantErr: true},
+	}
+	for _, tt := range tests {
+		name, val, err := ParseAssignment(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAssignment(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if name != tt.name || val != tt.val {
+			t.Errorf("ParseAssignment(%q) = %q, %q, want %q, %q", tt.in, name, val, tt.name, tt.val)
+		}
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	values, err := ParseValues([]byte("language: go\nreplicas: 2\nunset: null\ndb:\n  host: localhost\n"))
+	if err != nil {
+		t.Fatalf("ParseValues() error = %v", err)
+	}
+	want := map[string]string{"language": "go", "replicas": "2", "db.host": "localhost"}
+	if len(values) != len(want) {
+		t.Errorf("ParseValues() = %v, want %v", values, want)
+	}
+	for k, v := range want {
+		if values[k] != v {
+			t.Errorf("ParseValues()[%s] = %q, want %q", k, values[k], v)
+		}
+	}
+	if _, err := ParseValues([]byte("plugins: [a, b]\n")); err == nil {
+		t.Error("ParseValues() of a list succeeded, want an error")
+	}
+}
+
+func TestEnvKey(t *testing.T) {
+	if got := EnvKey("db.host-name"); got != "DVM_VAR_DB_HOST_NAME" {
+		t.Errorf("EnvKey() = %q, want DVM_VAR_DB_HOST_NAME", got)
+	}
+}
@@ -8,6 +8,10 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"devopsmaestro/pkg/telemetry"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GitMirrorManager implements MirrorManager for managing bare git mirrors.
@@ -77,8 +81,12 @@ func (g *GitMirrorManager) Clone(url string, slug string) (string, error) {
 	return mirrorPath, nil
 }
 
-// Sync updates an existing mirror from its remote.
-func (g *GitMirrorManager) Sync(slug string) error {
+// Sync updates an existing mirror from its remote. The update is limited to
+// 5 minutes, or less if ctx has an earlier deadline.
+func (g *GitMirrorManager) Sync(ctx context.Context, slug string) (err error) {
+	ctx, span := telemetry.Start(ctx, "dvm.mirror.sync", attribute.String("dvm.mirror", slug))
+	defer func() { telemetry.End(span, err) }()
+
 	// Validate slug
 	if err := ValidateSlug(slug); err != nil {
 		return err
@@ -91,7 +99,7 @@ func (g *GitMirrorManager) Sync(slug string) error {
 	}
 
 	// 5 minute timeout for sync operations
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
 	// Execute: git remote update --prune
@@ -100,7 +108,10 @@ func (g *GitMirrorManager) Sync(slug string) error {
 
 	output, err := cmd.CombinedOutput()
 	if ctx.Err() == context.DeadlineExceeded {
-		return fmt.Errorf("git remote update timed out after 5 minutes")
+		return fmt.Errorf("git remote update timed out: %w", ctx.Err())
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("git remote update cancelled: %w", ctx.Err())
 	}
 	if err != nil {
 		return fmt.Errorf("git remote update failed: %w: %s", err, sanitizeGitOutput(output))
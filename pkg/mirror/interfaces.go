@@ -1,12 +1,15 @@
 package mirror
 
+import "context"
+
 // MirrorManager handles bare git repository mirrors.
 type MirrorManager interface {
 	// Clone creates a new bare mirror from a remote URL.
 	Clone(url string, slug string) (string, error)
 
-	// Sync updates an existing mirror from its remote.
-	Sync(slug string) error
+	// Sync updates an existing mirror from its remote. It stops early if
+	// ctx is cancelled or its deadline passes.
+	Sync(ctx context.Context, slug string) error
 
 	// Delete removes a mirror from disk.
 	Delete(slug string) error
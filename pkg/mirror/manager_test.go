@@ -1,6 +1,7 @@
 package mirror
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -403,7 +404,7 @@ func TestMirrorManager_Sync(t *testing.T) {
 	require.NoError(t, err)
 
 	// Sync mirror
-	err = mgr.Sync(slug)
+	err = mgr.Sync(context.Background(), slug)
 	require.NoError(t, err)
 
 	// Verify mirror has the new commit
@@ -417,7 +418,7 @@ func TestMirrorManager_Sync(t *testing.T) {
 func TestMirrorManager_Sync_NotExist(t *testing.T) {
 	mgr := setupTestMirrorManager(t)
 
-	err := mgr.Sync("nonexistent_mirror")
+	err := mgr.Sync(context.Background(), "nonexistent_mirror")
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not exist", "error should indicate mirror doesn't exist")
 }
@@ -0,0 +1,225 @@
+// Package advisor compares installed nvp plugins against upstream releases
+// and flags updates that are likely to break a config: major version bumps,
+// 0.x minor bumps, and releases whose notes announce breaking changes.
+package advisor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Release is an upstream release of a plugin.
+type Release struct {
+	Tag        string `json:"tag"`
+	Name       string `json:"name,omitempty"`
+	Notes      string `json:"notes,omitempty"`
+	Prerelease bool   `json:"prerelease,omitempty"`
+}
+
+// ReleaseSource lists a repository's releases.
+type ReleaseSource interface {
+	// Releases returns the releases of repo ("owner/name"), newest first.
+	Releases(ctx context.Context, repo string) ([]Release, error)
+}
+
+// Kind classifies an available update.
+type Kind string
+
+const (
+	KindNone    Kind = "none"    // already on the latest release
+	KindPatch   Kind = "patch"   // patch release
+	KindMinor   Kind = "minor"   // minor release
+	KindMajor   Kind = "major"   // major release
+	KindBranch  Kind = "branch"  // plugin tracks a branch, not a release
+	KindUnknown Kind = "unknown" // versions are neither semver nor a range
+)
+
+// Advice is the update assessment for one plugin.
+type Advice struct {
+	Plugin string `json:"plugin"`
+	Repo   string `json:"repo"`
+
+	// Current is the installed release. For a plugin whose version is a
+	// range, it is the newest release the range allows and Constraint
+	// holds the range.
+	Current    string `json:"current"`
+	Constraint string `json:"constraint,omitempty"`
+	Latest     string `json:"latest,omitempty"`
+	Kind       Kind   `json:"kind"`
+
+	// Breaking is set when the update is likely to need config changes;
+	// BreakingReasons says why.
+	Breaking        bool     `json:"breaking"`
+	BreakingReasons []string `json:"breakingReasons,omitempty"`
+
+	// Releases lists the releases after Current up to Latest, newest first.
+	Releases []Release `json:"releases,omitempty"`
+}
+
+// HasUpdate reports whether a newer release than the installed one exists.
+func (a *Advice) HasUpdate() bool {
+	return a.Kind != KindNone && a.Kind != KindBranch && a.Latest != ""
+}
+
+// breakingPattern matches release notes that announce breaking changes,
+// including conventional-commit "feat!:" style entries.
+var breakingPattern = regexp.MustCompile(`(?im)breaking[ _-]?change|^\W*BREAKING\b|\w!:\s`)
+
+// Advise compares p with its upstream releases. Plugins pinned to a release
+// tag are compared with the newest stable release, and plugins with a semver
+// range ("^1.2", "~1.2", ">=1.0 <2") with the newest release outside it;
+// branch-tracking plugins report the latest release for reference only.
+func Advise(ctx context.Context, p *plugin.Plugin, src ReleaseSource) (*Advice, error) {
+	advice := &Advice{Plugin: p.Name, Repo: p.Repo, Current: p.Version}
+	if !strings.Contains(p.Repo, "/") {
+		return nil, fmt.Errorf("plugin %s has no GitHub repo", p.Name)
+	}
+
+	releases, err := src.Releases(ctx, p.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases for %s: %w", p.Repo, err)
+	}
+	var stable []Release
+	for _, r := range releases {
+		if !r.Prerelease {
+			stable = append(stable, r)
+		}
+	}
+	sort.SliceStable(stable, func(i, j int) bool { return compareTags(stable[i].Tag, stable[j].Tag) > 0 })
+	if len(stable) > 0 {
+		advice.Latest = stable[0].Tag
+	}
+
+	current, ok := parseVersion(p.Version)
+	if !ok {
+		c, isRange := parseConstraint(p.Version)
+		if !isRange {
+			advice.Kind = KindBranch
+			if advice.Current == "" {
+				advice.Current = p.Branch
+			}
+			if p.Version != "" {
+				advice.Kind = KindUnknown
+			}
+			return advice, nil
+		}
+		// A range installs the newest release it allows, so only releases
+		// outside it are updates.
+		advice.Constraint = p.Version
+		current = c.floor()
+		for _, r := range stable {
+			if v, ok := parseVersion(r.Tag); ok && c.allows(v) {
+				advice.Current, current = r.Tag, v
+				break
+			}
+		}
+	}
+
+	for _, r := range stable {
+		if v, ok := parseVersion(r.Tag); ok && v.compare(current) > 0 {
+			advice.Releases = append(advice.Releases, r)
+		}
+	}
+	if len(advice.Releases) == 0 {
+		advice.Kind = KindNone
+		advice.Latest = advice.Current
+		return advice, nil
+	}
+
+	latest, _ := parseVersion(advice.Latest)
+	switch {
+	case latest.major != current.major:
+		advice.Kind = KindMajor
+		advice.addReason(fmt.Sprintf("major version bump %s → %s", advice.Current, advice.Latest))
+	case latest.minor != current.minor:
+		advice.Kind = KindMinor
+		if current.major == 0 {
+			advice.addReason(fmt.Sprintf("minor bump of a 0.x release (%s → %s) may break the API", advice.Current, advice.Latest))
+		}
+	default:
+		advice.Kind = KindPatch
+	}
+	for _, r := range advice.Releases {
+		if breakingPattern.MatchString(r.Notes) {
+			advice.addReason(fmt.Sprintf("%s release notes mention breaking changes", r.Tag))
+		}
+	}
+	return advice, nil
+}
+
+// Target returns the version to write when applying the update. A caret or
+// tilde range is moved to the latest release keeping its operator; anything
+// else is pinned to the latest tag.
+func (a *Advice) Target() string {
+	if op := strings.TrimSpace(a.Constraint); strings.HasPrefix(op, "^") || strings.HasPrefix(op, "~") {
+		return op[:1] + strings.TrimPrefix(a.Latest, "v")
+	}
+	return a.Latest
+}
+
+func (a *Advice) addReason(reason string) {
+	a.Breaking = true
+	a.BreakingReasons = append(a.BreakingReasons, reason)
+}
+
+// version is a parsed semantic version; extra components are ignored.
+type version struct {
+	major, minor, patch int
+}
+
+// parseVersion parses tags like "v1.2.3", "1.2" or "v2".
+func parseVersion(tag string) (version, bool) {
+	s := strings.TrimPrefix(strings.TrimSpace(tag), "v")
+	if s == "" {
+		return version{}, false
+	}
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return version{}, false
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		nums[i] = n
+	}
+	return version{nums[0], nums[1], nums[2]}, true
+}
+
+func (v version) compare(o version) int {
+	switch {
+	case v.major != o.major:
+		return v.major - o.major
+	case v.minor != o.minor:
+		return v.minor - o.minor
+	default:
+		return v.patch - o.patch
+	}
+}
+
+// compareTags orders semver tags before non-semver ones.
+func compareTags(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case okA && okB:
+		return va.compare(vb)
+	case okA:
+		return 1
+	case okB:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,146 @@
+package advisor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"devopsmaestro/pkg/githubapi"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReleases []Release
+
+func (f fakeReleases) Releases(ctx context.Context, repo string) ([]Release, error) {
+	return f, nil
+}
+
+func TestAdvise(t *testing.T) {
+	src := fakeReleases{
+		{Tag: "v2.1.0", Notes: "fixes"},
+		{Tag: "v3.0.0-rc1", Prerelease: true},
+		{Tag: "v2.0.0", Notes: "## BREAKING CHANGES\n- setup() renamed"},
+		{Tag: "v1.4.0", Notes: "feat!: drop nvim 0.8"},
+		{Tag: "v1.3.1"},
+		{Tag: "v1.3.0"},
+	}
+
+	tests := []struct {
+		name     string
+		version  string
+		kind     Kind
+		current  string
+		latest   string
+		breaking bool
+		releases int
+	}{
+		{"major", "v1.3.0", KindMajor, "v1.3.0", "v2.1.0", true, 4},
+		{"patch", "v2.0.1", KindMinor, "v2.0.1", "v2.1.0", false, 1},
+		{"current", "v2.1.0", KindNone, "v2.1.0", "v2.1.0", false, 0},
+		{"branch", "", KindBranch, "main", "v2.1.0", false, 0},
+		{"commit", "abc1234", KindUnknown, "abc1234", "v2.1.0", false, 0},
+		{"caret range", "^1.0", KindMajor, "v1.4.0", "v2.1.0", true, 2},
+		{"tilde range", "~2.0", KindMinor, "v2.0.0", "v2.1.0", false, 1},
+		{"wildcard range", "1.3.x", KindMajor, "v1.3.1", "v2.1.0", true, 3},
+		{"bounded range", ">=1.0 <3", KindNone, "v2.1.0", "v2.1.0", false, 0},
+		{"any", "*", KindNone, "v2.1.0", "v2.1.0", false, 0},
+		{"unsatisfied range", "^3.0", KindNone, "^3.0", "^3.0", false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &plugin.Plugin{Name: "x", Repo: "acme/x", Version: tt.version, Branch: "main"}
+			advice, err := Advise(context.Background(), p, src)
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, advice.Kind)
+			assert.Equal(t, tt.current, advice.Current)
+			assert.Equal(t, tt.latest, advice.Latest)
+			assert.Equal(t, tt.breaking, advice.Breaking)
+			assert.Len(t, advice.Releases, tt.releases)
+		})
+	}
+}
+
+func TestAdvice_Target(t *testing.T) {
+	assert.Equal(t, "^2.1.0", (&Advice{Constraint: "^1.0", Latest: "v2.1.0"}).Target())
+	assert.Equal(t, "~2.1.0", (&Advice{Constraint: "~2.0", Latest: "v2.1.0"}).Target())
+	assert.Equal(t, "v2.1.0", (&Advice{Constraint: "1.x", Latest: "v2.1.0"}).Target())
+	assert.Equal(t, "v2.1.0", (&Advice{Latest: "v2.1.0"}).Target())
+}
+
+func TestAdvise_BreakingReasons(t *testing.T) {
+	src := fakeReleases{{Tag: "v0.3.0", Notes: "BREAKING: opts.foo removed"}, {Tag: "v0.2.0"}}
+	advice, err := Advise(context.Background(), &plugin.Plugin{Name: "x", Repo: "acme/x", Version: "v0.2.0"}, src)
+	require.NoError(t, err)
+	assert.True(t, advice.HasUpdate())
+	assert.Len(t, advice.BreakingReasons, 2, "0.x minor bump and release notes")
+
+	advice, err = Advise(context.Background(), &plugin.Plugin{Name: "x", Repo: "acme/x", Version: "v1.0.0"}, fakeReleases{{Tag: "v1.0.1", Notes: "Fix the break in the loop"}})
+	require.NoError(t, err)
+	assert.Equal(t, KindPatch, advice.Kind)
+	assert.False(t, advice.Breaking)
+}
+
+func TestOptsDiff(t *testing.T) {
+	installed := &plugin.Plugin{Name: "x", Opts: map[string]interface{}{"a": 1, "b": true}}
+	candidate := &plugin.Plugin{Name: "x", Opts: map[string]interface{}{"a": 1, "c": "new"}}
+
+	diff, err := OptsDiff(installed, candidate)
+	require.NoError(t, err)
+	assert.Equal(t, "  a: 1\n- b: true\n+ c: new\n", diff)
+
+	diff, err = OptsDiff(installed, installed)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestGitHubReleases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/tool/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{"tag_name": "v1.0.0", "name": "One", "body": "notes", "prerelease": false}]`)
+	}))
+	defer srv.Close()
+
+	g := &GitHubReleases{API: &githubapi.Client{BaseURL: srv.URL, HTTPClient: srv.Client()}}
+	releases, err := g.Releases(context.Background(), "acme/tool")
+	require.NoError(t, err)
+	assert.Equal(t, []Release{{Tag: "v1.0.0", Name: "One", Notes: "notes"}}, releases)
+
+	_, err = g.Releases(context.Background(), "acme/missing")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestVersionOptsDiff(t *testing.T) {
+	specs := map[string]string{
+		"v1.0.0": `return { "acme/tool", opts = { width = 80, border = "single" } }`,
+		"v2.0.0": `return { { "acme/tool", opts = { width = 80, border = "rounded" } }, { "acme/dep" } }`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spec, ok := specs[r.URL.Query().Get("ref")]
+		if r.URL.Path != "/repos/acme/tool/contents/lazy.lua" || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		assert.Equal(t, githubapi.AcceptRaw, r.Header.Get("Accept"))
+		fmt.Fprint(w, spec)
+	}))
+	defer srv.Close()
+
+	src := &GitHubSpecs{API: &githubapi.Client{BaseURL: srv.URL, HTTPClient: srv.Client()}}
+	p := &plugin.Plugin{Name: "tool", Repo: "acme/tool"}
+
+	diff, err := VersionOptsDiff(context.Background(), p, src, "v1.0.0", "v2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "- border: single\n+ border: rounded\n  width: 80\n", diff)
+
+	_, err = VersionOptsDiff(context.Background(), p, src, "v0.9.0", "v2.0.0")
+	assert.ErrorIs(t, err, ErrNoSpec)
+	assert.ErrorContains(t, err, "v0.9.0")
+}
@@ -0,0 +1,145 @@
+package advisor
+
+import (
+	"strconv"
+	"strings"
+)
+
+// constraint is a semver range as lazy.nvim's version field accepts it:
+// space-separated comparators that must all hold.
+type constraint []comparator
+
+// comparator is one bound of a range.
+type comparator struct {
+	op string // ">=", ">", "<=", "<", or "="
+	v  version
+}
+
+// parseConstraint parses ranges like "*", "^1.2", "~1.2.3", "1.x",
+// ">=1.0 <2.0". Plain versions ("v1.2.3") are not ranges and are rejected,
+// as are "||" alternatives.
+func parseConstraint(s string) (constraint, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	c := constraint{}
+	for _, f := range fields {
+		cmps, ok := parseComparator(f)
+		if !ok {
+			return nil, false
+		}
+		c = append(c, cmps...)
+	}
+	if len(fields) == 1 {
+		if _, exact := parseVersion(fields[0]); exact {
+			return nil, false
+		}
+	}
+	return c, true
+}
+
+// parseComparator expands one range term into its bounds.
+func parseComparator(s string) ([]comparator, bool) {
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(s, op) {
+			v, _, ok := parsePartial(s[len(op):])
+			if !ok {
+				return nil, false
+			}
+			return []comparator{{op, v}}, true
+		}
+	}
+
+	op := ""
+	if strings.HasPrefix(s, "^") || strings.HasPrefix(s, "~") {
+		op, s = s[:1], s[1:]
+	}
+	v, parts, ok := parsePartial(s)
+	if !ok {
+		return nil, false
+	}
+	var upper version
+	switch {
+	case op == "^" && (v.major > 0 || parts == 1):
+		upper = version{v.major + 1, 0, 0}
+	case op == "^" && (v.minor > 0 || parts == 2):
+		upper = version{0, v.minor + 1, 0}
+	case op == "^":
+		upper = version{0, 0, v.patch + 1}
+	case parts == 0:
+		return nil, true // "*" allows anything
+	case parts == 1:
+		upper = version{v.major + 1, 0, 0}
+	case parts == 2 || op == "~":
+		upper = version{v.major, v.minor + 1, 0}
+	default:
+		return []comparator{{"=", v}}, true
+	}
+	return []comparator{{">=", v}, {"<", upper}}, true
+}
+
+// parsePartial parses a version that may stop early or end in wildcards
+// ("1", "1.2", "1.x", "1.2.*", "*"). It returns how many parts were given.
+func parsePartial(s string) (version, int, bool) {
+	s = strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return version{}, 0, false
+	}
+	var nums [3]int
+	parts := 0
+	for i, part := range strings.Split(s, ".") {
+		if i == 3 {
+			return version{}, 0, false
+		}
+		if part == "x" || part == "X" || part == "*" {
+			break
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, 0, false
+		}
+		nums[i] = n
+		parts++
+	}
+	return version{nums[0], nums[1], nums[2]}, parts, true
+}
+
+// allows reports whether v satisfies every bound.
+func (c constraint) allows(v version) bool {
+	for _, b := range c {
+		d := v.compare(b.v)
+		ok := true
+		switch b.op {
+		case ">=":
+			ok = d >= 0
+		case ">":
+			ok = d > 0
+		case "<=":
+			ok = d <= 0
+		case "<":
+			ok = d < 0
+		case "=":
+			ok = d == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// floor returns the lowest version the range names, used as the installed
+// version when no release satisfies it.
+func (c constraint) floor() version {
+	var v version
+	for _, b := range c {
+		if (b.op == ">=" || b.op == ">" || b.op == "=") && b.v.compare(v) > 0 {
+			v = b.v
+		}
+	}
+	return v
+}
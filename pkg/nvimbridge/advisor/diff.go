@@ -0,0 +1,44 @@
+package advisor
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/pkg/linediff"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+// OptsDiff returns a line diff of the opts of two plugin definitions as
+// YAML, with removed lines prefixed "-" and added lines "+". It returns an
+// empty string when the opts are identical.
+func OptsDiff(installed, candidate *plugin.Plugin) (string, error) {
+	before, err := optsYAML(installed)
+	if err != nil {
+		return "", err
+	}
+	after, err := optsYAML(candidate)
+	if err != nil {
+		return "", err
+	}
+	if before == after {
+		return "", nil
+	}
+	var sb strings.Builder
+	for _, l := range linediff.Lines(before, after) {
+		sb.WriteString(string(l.Op) + " " + l.Text + "\n")
+	}
+	return sb.String(), nil
+}
+
+func optsYAML(p *plugin.Plugin) (string, error) {
+	if p == nil || p.Opts == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(p.Opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal opts of %s: %w", p.Name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,40 @@
+package advisor
+
+import (
+	"context"
+
+	"devopsmaestro/pkg/githubapi"
+)
+
+// GitHubReleases lists releases with the GitHub REST API.
+type GitHubReleases struct {
+	API *githubapi.Client
+}
+
+// Compile-time interface check.
+var _ ReleaseSource = (*GitHubReleases)(nil)
+
+// NewGitHubReleases creates a release source for api.github.com.
+func NewGitHubReleases(token string) *GitHubReleases {
+	return &GitHubReleases{API: githubapi.NewClient(token)}
+}
+
+// Releases returns up to the 50 most recent releases of repo. Draft releases
+// are not visible to the API and are never returned.
+func (g *GitHubReleases) Releases(ctx context.Context, repo string) ([]Release, error) {
+	var raw []struct {
+		TagName    string `json:"tag_name"`
+		Name       string `json:"name"`
+		Body       string `json:"body"`
+		Prerelease bool   `json:"prerelease"`
+	}
+	if err := g.API.Get(ctx, "/repos/"+repo+"/releases?per_page=50", &raw); err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(raw))
+	for _, r := range raw {
+		releases = append(releases, Release{Tag: r.TagName, Name: r.Name, Notes: r.Body, Prerelease: r.Prerelease})
+	}
+	return releases, nil
+}
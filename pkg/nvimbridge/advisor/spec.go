@@ -0,0 +1,78 @@
+package advisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"devopsmaestro/pkg/githubapi"
+	"devopsmaestro/pkg/nvimbridge/luaspec"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// ErrNoSpec is returned when a plugin publishes no default opts at a version.
+var ErrNoSpec = errors.New("no lazy.lua spec published")
+
+// SpecSource returns the default opts a plugin publishes at a version.
+type SpecSource interface {
+	// DefaultOpts returns the opts of repo ("owner/name") at ref, or
+	// ErrNoSpec when the plugin publishes none there.
+	DefaultOpts(ctx context.Context, repo, ref string) (interface{}, error)
+}
+
+// maxSpecBytes caps the size of a fetched lazy.lua.
+const maxSpecBytes = 256 << 10
+
+// GitHubSpecs reads the lazy.lua packspec a plugin ships in its repository
+// root, which lazy.nvim uses as the plugin's own default spec.
+type GitHubSpecs struct {
+	API *githubapi.Client
+}
+
+// Compile-time interface check.
+var _ SpecSource = (*GitHubSpecs)(nil)
+
+// NewGitHubSpecs creates a spec source for api.github.com.
+func NewGitHubSpecs(token string) *GitHubSpecs {
+	return &GitHubSpecs{API: githubapi.NewClient(token)}
+}
+
+// DefaultOpts fetches lazy.lua at ref and returns the opts of the spec
+// entry for repo itself.
+func (g *GitHubSpecs) DefaultOpts(ctx context.Context, repo, ref string) (interface{}, error) {
+	path := "/repos/" + repo + "/contents/lazy.lua?ref=" + url.QueryEscape(ref)
+	data, err := g.API.GetRaw(ctx, path, githubapi.AcceptRaw, maxSpecBytes)
+	if githubapi.IsStatus(err, http.StatusNotFound) {
+		return nil, ErrNoSpec
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := (&luaspec.Converter{}).Convert(string(data), "lazy.lua")
+	for _, p := range result.Plugins {
+		if strings.EqualFold(p.Repo, repo) && p.Opts != nil {
+			return p.Opts, nil
+		}
+	}
+	return nil, ErrNoSpec
+}
+
+// VersionOptsDiff diffs the default opts p publishes at from against those
+// at to. It returns ErrNoSpec, wrapped with the version, when either
+// version publishes none.
+func VersionOptsDiff(ctx context.Context, p *plugin.Plugin, src SpecSource, from, to string) (string, error) {
+	before, err := src.DefaultOpts(ctx, p.Repo, from)
+	if err != nil {
+		return "", fmt.Errorf("%s at %s: %w", p.Repo, from, err)
+	}
+	after, err := src.DefaultOpts(ctx, p.Repo, to)
+	if err != nil {
+		return "", fmt.Errorf("%s at %s: %w", p.Repo, to, err)
+	}
+	return OptsDiff(&plugin.Plugin{Name: p.Name, Opts: before}, &plugin.Plugin{Name: p.Name, Opts: after})
+}
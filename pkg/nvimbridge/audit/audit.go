@@ -0,0 +1,278 @@
+// Package audit checks the health of stored nvp plugins: whether their
+// repositories still exist, are archived, or have gone quiet, whether they
+// were renamed or superseded, and whether their lazy-load events are
+// deprecated. Replacements are suggested from the plugin library.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Severity ranks audit findings.
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Check names identify the rule that produced a finding.
+const (
+	CheckRepo     = "repo"
+	CheckArchived = "archived"
+	CheckStale    = "stale"
+	CheckRenamed  = "renamed"
+	CheckEvent    = "event"
+)
+
+// DefaultStaleAfter is how long a repository may go without commits before
+// it is flagged.
+const DefaultStaleAfter = 365 * 24 * time.Hour
+
+// ErrRepoNotFound is returned by a RepoChecker when a repository does not exist.
+var ErrRepoNotFound = errors.New("repository not found")
+
+// RepoInfo describes a repository's current state.
+type RepoInfo struct {
+	// FullName is the canonical "owner/name"; it differs from the requested
+	// repo when the repository was renamed or transferred.
+	FullName   string
+	Archived   bool
+	LastCommit time.Time
+}
+
+// RepoChecker looks up repository metadata.
+type RepoChecker interface {
+	// RepoInfo returns the state of repo ("owner/name"), or ErrRepoNotFound.
+	RepoInfo(ctx context.Context, repo string) (*RepoInfo, error)
+}
+
+// Finding is a single audit issue.
+type Finding struct {
+	Check      string   `json:"check"`
+	Severity   Severity `json:"severity"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+// Report is the audit result for one plugin.
+type Report struct {
+	Plugin     string     `json:"plugin"`
+	Repo       string     `json:"repo"`
+	Status     Severity   `json:"status"`
+	LastCommit *time.Time `json:"lastCommit,omitempty"`
+	Findings   []Finding  `json:"findings,omitempty"`
+}
+
+func (r *Report) add(f Finding) {
+	r.Findings = append(r.Findings, f)
+	if f.Severity == SeverityError || (f.Severity == SeverityWarning && r.Status == SeverityOK) {
+		r.Status = f.Severity
+	}
+}
+
+// Rename describes a plugin repository that moved or was superseded.
+type Rename struct {
+	Repo string // replacement "owner/name"
+	Note string
+}
+
+// KnownRenames maps retired plugin repositories (lowercase) to their
+// successors.
+var KnownRenames = map[string]Rename{
+	"jose-elias-alvarez/null-ls.nvim":    {Repo: "nvimtools/none-ls.nvim", Note: "null-ls is archived; none-ls is the community fork"},
+	"jose-elias-alvarez/typescript.nvim": {Repo: "pmizio/typescript-tools.nvim", Note: "typescript.nvim is archived"},
+	"simrat39/rust-tools.nvim":           {Repo: "mrcjkb/rustaceanvim", Note: "rust-tools is archived; rustaceanvim is its successor"},
+	"folke/neodev.nvim":                  {Repo: "folke/lazydev.nvim", Note: "neodev is deprecated in favor of lazydev"},
+	"folke/lua-dev.nvim":                 {Repo: "folke/lazydev.nvim", Note: "lua-dev was renamed to neodev, now lazydev"},
+	"williamboman/mason.nvim":            {Repo: "mason-org/mason.nvim", Note: "mason moved to the mason-org organization"},
+	"williamboman/mason-lspconfig.nvim":  {Repo: "mason-org/mason-lspconfig.nvim", Note: "mason-lspconfig moved to the mason-org organization"},
+	"kyazdani42/nvim-tree.lua":           {Repo: "nvim-tree/nvim-tree.lua", Note: "nvim-tree moved to the nvim-tree organization"},
+	"kyazdani42/nvim-web-devicons":       {Repo: "nvim-tree/nvim-web-devicons", Note: "nvim-web-devicons moved to the nvim-tree organization"},
+	"glepnir/lspsaga.nvim":               {Repo: "nvimdev/lspsaga.nvim", Note: "lspsaga moved to the nvimdev organization"},
+	"glepnir/dashboard-nvim":             {Repo: "nvimdev/dashboard-nvim", Note: "dashboard-nvim moved to the nvimdev organization"},
+	"hrsh7th/nvim-compe":                 {Repo: "hrsh7th/nvim-cmp", Note: "nvim-compe is deprecated in favor of nvim-cmp"},
+	"nvim-lua/completion-nvim":           {Repo: "hrsh7th/nvim-cmp", Note: "completion-nvim is archived"},
+	"ggandor/lightspeed.nvim":            {Repo: "ggandor/leap.nvim", Note: "lightspeed is deprecated in favor of leap"},
+}
+
+// DeprecatedEvents maps lazy-load events that no longer work as intended to
+// their replacements.
+var DeprecatedEvents = map[string]string{
+	"LazyFile":       "BufReadPost, BufNewFile, BufWritePre",
+	"User LazyFile":  "BufReadPost, BufNewFile, BufWritePre",
+	"User AstroFile": "BufReadPost, BufNewFile",
+	"User FilePost":  "BufReadPost, BufNewFile",
+}
+
+// Auditor runs audit checks against a set of plugins.
+type Auditor struct {
+	// Checker looks up repositories; nil skips the repository checks.
+	Checker RepoChecker
+
+	// Library is searched for replacement suggestions.
+	Library []*plugin.Plugin
+
+	// StaleAfter is the commit age flagged as stale (default DefaultStaleAfter).
+	StaleAfter time.Duration
+
+	// Now returns the current time (default time.Now).
+	Now func() time.Time
+}
+
+// Audit checks each plugin and returns one report per plugin, in order.
+func (a *Auditor) Audit(ctx context.Context, plugins []*plugin.Plugin) []*Report {
+	reports := make([]*Report, 0, len(plugins))
+	for _, p := range plugins {
+		reports = append(reports, a.auditPlugin(ctx, p))
+	}
+	return reports
+}
+
+func (a *Auditor) auditPlugin(ctx context.Context, p *plugin.Plugin) *Report {
+	r := &Report{Plugin: p.Name, Repo: p.Repo, Status: SeverityOK}
+
+	if rename, ok := KnownRenames[strings.ToLower(p.Repo)]; ok {
+		r.add(Finding{
+			Check:      CheckRenamed,
+			Severity:   SeverityWarning,
+			Message:    rename.Note,
+			Suggestion: a.suggestRepo(rename.Repo),
+		})
+	}
+
+	for _, event := range p.Event {
+		if replacement, ok := DeprecatedEvents[strings.TrimSpace(event)]; ok {
+			r.add(Finding{
+				Check:      CheckEvent,
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("event %q is deprecated or only defined by other distributions", event),
+				Suggestion: replacement,
+			})
+		}
+	}
+
+	if a.Checker != nil && strings.Contains(p.Repo, "/") {
+		a.checkRepo(ctx, p, r)
+	}
+	return r
+}
+
+func (a *Auditor) checkRepo(ctx context.Context, p *plugin.Plugin, r *Report) {
+	info, err := a.Checker.RepoInfo(ctx, p.Repo)
+	if errors.Is(err, ErrRepoNotFound) {
+		r.add(Finding{
+			Check:      CheckRepo,
+			Severity:   SeverityError,
+			Message:    "repository not found",
+			Suggestion: a.suggestAlternative(p),
+		})
+		return
+	}
+	if err != nil {
+		r.add(Finding{Check: CheckRepo, Severity: SeverityWarning, Message: fmt.Sprintf("could not check repository: %v", err)})
+		return
+	}
+
+	if info.FullName != "" && !strings.EqualFold(info.FullName, p.Repo) {
+		if _, known := KnownRenames[strings.ToLower(p.Repo)]; !known {
+			r.add(Finding{
+				Check:      CheckRenamed,
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("repository moved to %s", info.FullName),
+				Suggestion: a.suggestRepo(info.FullName),
+			})
+		}
+	}
+
+	if info.Archived {
+		r.add(Finding{
+			Check:      CheckArchived,
+			Severity:   SeverityError,
+			Message:    "repository is archived",
+			Suggestion: a.suggestAlternative(p),
+		})
+	}
+
+	if !info.LastCommit.IsZero() {
+		last := info.LastCommit
+		r.LastCommit = &last
+
+		staleAfter := a.StaleAfter
+		if staleAfter == 0 {
+			staleAfter = DefaultStaleAfter
+		}
+		if age := a.now().Sub(last); age > staleAfter && !info.Archived {
+			r.add(Finding{
+				Check:    CheckStale,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("no commits in %s", Age(age)),
+			})
+		}
+	}
+}
+
+func (a *Auditor) now() time.Time {
+	if a.Now != nil {
+		return a.Now()
+	}
+	return time.Now()
+}
+
+// suggestRepo names the library plugin for repo, or the repo itself.
+func (a *Auditor) suggestRepo(repo string) string {
+	for _, lp := range a.Library {
+		if strings.EqualFold(lp.Repo, repo) {
+			return fmt.Sprintf("use library plugin '%s' (%s)", lp.Name, lp.Repo)
+		}
+	}
+	return "use " + repo
+}
+
+// suggestAlternative suggests a replacement for a dead plugin: its known
+// successor, else library plugins in the same category.
+func (a *Auditor) suggestAlternative(p *plugin.Plugin) string {
+	if rename, ok := KnownRenames[strings.ToLower(p.Repo)]; ok {
+		return a.suggestRepo(rename.Repo)
+	}
+	if p.Category == "" {
+		return ""
+	}
+	var names []string
+	for _, lp := range a.Library {
+		if lp.Category == p.Category && lp.Name != p.Name && !strings.EqualFold(lp.Repo, p.Repo) {
+			names = append(names, lp.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	if len(names) > 3 {
+		names = names[:3]
+	}
+	return fmt.Sprintf("library alternatives in '%s': %s", p.Category, strings.Join(names, ", "))
+}
+
+// Age formats a duration in days, months, or years for display.
+func Age(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days < 60:
+		return fmt.Sprintf("%dd", days)
+	case days < 730:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"devopsmaestro/pkg/githubapi"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type fakeChecker map[string]*RepoInfo
+
+func (f fakeChecker) RepoInfo(ctx context.Context, repo string) (*RepoInfo, error) {
+	if info, ok := f[repo]; ok {
+		return info, nil
+	}
+	return nil, ErrRepoNotFound
+}
+
+func findingChecks(r *Report) []string {
+	var checks []string
+	for _, f := range r.Findings {
+		checks = append(checks, f.Check)
+	}
+	return checks
+}
+
+func TestAudit(t *testing.T) {
+	checker := fakeChecker{
+		"folke/flash.nvim":                {FullName: "folke/flash.nvim", LastCommit: now.AddDate(0, 0, -3)},
+		"jose-elias-alvarez/null-ls.nvim": {FullName: "jose-elias-alvarez/null-ls.nvim", Archived: true, LastCommit: now.AddDate(-2, 0, 0)},
+		"old/quiet.nvim":                  {FullName: "old/quiet.nvim", LastCommit: now.AddDate(-2, 0, 0)},
+		"someone/moved.nvim":              {FullName: "org/moved.nvim", LastCommit: now},
+	}
+	a := &Auditor{
+		Checker: checker,
+		Library: []*plugin.Plugin{
+			{Name: "none-ls", Repo: "nvimtools/none-ls.nvim", Category: "lsp"},
+			{Name: "conform", Repo: "stevearc/conform.nvim", Category: "formatting"},
+		},
+		Now: func() time.Time { return now },
+	}
+
+	reports := a.Audit(context.Background(), []*plugin.Plugin{
+		{Name: "flash", Repo: "folke/flash.nvim", Event: []string{"VeryLazy", "BufRead", "BufReadPre *"}},
+		{Name: "null-ls", Repo: "jose-elias-alvarez/null-ls.nvim", Category: "lsp"},
+		{Name: "quiet", Repo: "old/quiet.nvim", Event: []string{"LazyFile"}},
+		{Name: "moved", Repo: "someone/moved.nvim"},
+		{Name: "gone", Repo: "ghost/gone.nvim", Category: "formatting"},
+	})
+	require.Len(t, reports, 5)
+
+	assert.Equal(t, SeverityOK, reports[0].Status)
+	assert.Empty(t, reports[0].Findings)
+	require.NotNil(t, reports[0].LastCommit)
+	data, err := json.Marshal(reports[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"lastCommit":`)
+
+	assert.Equal(t, SeverityError, reports[1].Status)
+	assert.Equal(t, []string{CheckRenamed, CheckArchived}, findingChecks(reports[1]))
+	assert.Contains(t, reports[1].Findings[0].Suggestion, "'none-ls'")
+
+	assert.Equal(t, SeverityWarning, reports[2].Status)
+	assert.Equal(t, []string{CheckEvent, CheckStale}, findingChecks(reports[2]))
+	assert.Equal(t, "BufReadPost, BufNewFile, BufWritePre", reports[2].Findings[0].Suggestion)
+
+	assert.Equal(t, []string{CheckRenamed}, findingChecks(reports[3]))
+	assert.Contains(t, reports[3].Findings[0].Message, "org/moved.nvim")
+
+	assert.Equal(t, SeverityError, reports[4].Status)
+	assert.Equal(t, "library alternatives in 'formatting': conform", reports[4].Findings[0].Suggestion)
+}
+
+func TestAudit_Offline(t *testing.T) {
+	a := &Auditor{}
+	reports := a.Audit(context.Background(), []*plugin.Plugin{{Name: "neodev", Repo: "folke/neodev.nvim"}})
+	require.Len(t, reports, 1)
+	assert.Equal(t, []string{CheckRenamed}, findingChecks(reports[0]))
+	assert.Equal(t, "use folke/lazydev.nvim", reports[0].Findings[0].Suggestion)
+}
+
+func TestAge(t *testing.T) {
+	day := 24 * time.Hour
+	assert.Equal(t, "today", Age(time.Hour))
+	assert.Equal(t, "12d", Age(12*day))
+	assert.Equal(t, "14mo", Age(420*day))
+	assert.Equal(t, "3y", Age(1100*day))
+}
+
+func TestGitHubChecker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/tool":
+			fmt.Fprint(w, `{"full_name": "acme/tool", "archived": true}`)
+		case "/repos/acme/tool/commits":
+			assert.Equal(t, "1", r.URL.Query().Get("per_page"))
+			fmt.Fprint(w, `[{"commit": {"committer": {"date": "2025-01-02T03:04:05Z"}}}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &GitHubChecker{API: &githubapi.Client{BaseURL: srv.URL, HTTPClient: srv.Client()}}
+	info, err := c.RepoInfo(context.Background(), "acme/tool")
+	require.NoError(t, err)
+	assert.True(t, info.Archived)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), info.LastCommit)
+
+	_, err = c.RepoInfo(context.Background(), "acme/missing")
+	assert.ErrorIs(t, err, ErrRepoNotFound)
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"devopsmaestro/pkg/githubapi"
+)
+
+// GitHubChecker looks up repositories with the GitHub REST API. Renamed and
+// transferred repositories are followed through GitHub's redirects, so
+// RepoInfo reports their new full name.
+type GitHubChecker struct {
+	API *githubapi.Client
+}
+
+// Compile-time interface check.
+var _ RepoChecker = (*GitHubChecker)(nil)
+
+// NewGitHubChecker creates a checker for api.github.com.
+func NewGitHubChecker(token string) *GitHubChecker {
+	return &GitHubChecker{API: githubapi.NewClient(token)}
+}
+
+// RepoInfo returns the state of repo and the date of the latest commit on its
+// default branch.
+func (c *GitHubChecker) RepoInfo(ctx context.Context, repo string) (*RepoInfo, error) {
+	var meta struct {
+		FullName string `json:"full_name"`
+		Archived bool   `json:"archived"`
+	}
+	if err := c.API.Get(ctx, "/repos/"+repo, &meta); err != nil {
+		if githubapi.IsStatus(err, http.StatusNotFound) {
+			return nil, ErrRepoNotFound
+		}
+		return nil, err
+	}
+	info := &RepoInfo{FullName: meta.FullName, Archived: meta.Archived}
+
+	var commits []struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	// Empty repositories answer 409 Conflict: there is no last commit.
+	err := c.API.Get(ctx, "/repos/"+repo+"/commits?per_page=1", &commits)
+	if err != nil && !githubapi.IsStatus(err, http.StatusConflict) {
+		return nil, err
+	}
+	if len(commits) > 0 {
+		info.LastCommit = commits[0].Commit.Committer.Date
+	}
+	return info, nil
+}
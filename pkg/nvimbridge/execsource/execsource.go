@@ -0,0 +1,319 @@
+// Package execsource lets third parties add nvp sync sources without
+// recompiling nvp. An executable named nvp-source-<name> on PATH becomes the
+// source <name>.
+//
+// nvp runs the executable with one verb argument (validate, list, or sync),
+// writes a JSON Request to its stdin, and reads a JSON Response from its
+// stdout. Stderr is for diagnostics. A non-zero exit status or a non-empty
+// Response.Error fails the verb.
+//
+//   - validate checks the source is reachable; the response carries nothing.
+//   - list returns the available plugins in Response.Plugins.
+//   - sync writes nvp plugin YAML files for the plugins matching
+//     Options.Filters into Options.TargetDir (unless Options.DryRun) and
+//     reports their names. nvp then creates the source's package itself.
+package execsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+)
+
+// Prefix is the executable name prefix that marks a source.
+const Prefix = "nvp-source-"
+
+// ProtocolVersion is sent in every request. It changes only when the
+// contract changes incompatibly.
+const ProtocolVersion = 1
+
+// Verbs of the contract.
+const (
+	VerbValidate = "validate"
+	VerbList     = "list"
+	VerbSync     = "sync"
+)
+
+// maxStderr bounds the diagnostics kept for logs and error messages.
+const maxStderr = 4096
+
+// Request is written to the executable's stdin.
+type Request struct {
+	Version int      `json:"version"`
+	Verb    string   `json:"verb"`
+	Options *Options `json:"options,omitempty"` // sync only
+}
+
+// Options are the sync options an executable receives.
+type Options struct {
+	DryRun    bool              `json:"dryRun"`
+	Overwrite bool              `json:"overwrite"`
+	TargetDir string            `json:"targetDir"`
+	Filters   map[string]string `json:"filters,omitempty"`
+}
+
+// Plugin is an available plugin in a list response.
+type Plugin struct {
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	Repo         string            `json:"repo"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Config       string            `json:"config,omitempty"`
+	Dependencies []string          `json:"dependencies,omitempty"`
+}
+
+// Response is read from the executable's stdout.
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	// list
+	Plugins []Plugin `json:"plugins,omitempty"`
+
+	// sync
+	PluginsCreated []string `json:"pluginsCreated,omitempty"`
+	PluginsUpdated []string `json:"pluginsUpdated,omitempty"`
+	Errors         []string `json:"errors,omitempty"`
+	TotalAvailable int      `json:"totalAvailable,omitempty"`
+}
+
+// Handler is a sync.SourceHandler backed by an executable.
+type Handler struct {
+	name string
+	path string
+}
+
+// New returns the handler for the source name implemented by the executable
+// at path.
+func New(name, path string) *Handler {
+	return &Handler{name: name, path: path}
+}
+
+// Name returns the source name.
+func (h *Handler) Name() string { return h.name }
+
+// Description names the executable providing the source.
+func (h *Handler) Description() string {
+	return "External source (" + h.path + ")"
+}
+
+// Validate runs the validate verb.
+func (h *Handler) Validate(ctx context.Context) error {
+	_, err := h.call(ctx, Request{Verb: VerbValidate})
+	return err
+}
+
+// ListAvailable runs the list verb.
+func (h *Handler) ListAvailable(ctx context.Context) ([]sync.AvailablePlugin, error) {
+	resp, err := h.call(ctx, Request{Verb: VerbList})
+	if err != nil {
+		return nil, err
+	}
+	plugins := make([]sync.AvailablePlugin, 0, len(resp.Plugins))
+	for _, p := range resp.Plugins {
+		plugins = append(plugins, sync.AvailablePlugin{
+			Name:         p.Name,
+			Description:  p.Description,
+			Category:     p.Category,
+			Repo:         p.Repo,
+			Labels:       p.Labels,
+			Config:       p.Config,
+			Dependencies: p.Dependencies,
+			SourceName:   h.name,
+		})
+	}
+	return plugins, nil
+}
+
+// Sync runs the sync verb, then creates the source's package from the
+// plugins the executable wrote.
+func (h *Handler) Sync(ctx context.Context, options sync.SyncOptions) (*sync.SyncResult, error) {
+	resp, err := h.call(ctx, Request{Verb: VerbSync, Options: &Options{
+		DryRun:    options.DryRun,
+		Overwrite: options.Overwrite,
+		TargetDir: options.TargetDir,
+		Filters:   options.Filters,
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sync.SyncResult{SourceName: h.name, TotalAvailable: resp.TotalAvailable}
+	for _, name := range resp.PluginsCreated {
+		result.AddPluginCreated(name)
+	}
+	for _, name := range resp.PluginsUpdated {
+		result.AddPluginUpdated(name)
+	}
+	for _, msg := range resp.Errors {
+		result.AddError(errors.New(msg))
+	}
+
+	synced := append(append([]string{}, resp.PluginsCreated...), resp.PluginsUpdated...)
+	if options.PackageCreator != nil && len(synced) > 0 {
+		if options.DryRun {
+			result.AddPackageCreated(h.name)
+		} else if err := options.PackageCreator.CreatePackage(h.name, synced); err != nil {
+			result.AddError(fmt.Errorf("failed to create package: %w", err))
+		} else {
+			result.AddPackageCreated(h.name)
+		}
+	}
+	return result, nil
+}
+
+// call runs one verb and decodes the response.
+func (h *Handler) call(ctx context.Context, req Request) (*Response, error) {
+	req.Version = ProtocolVersion
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	stderr := &limitedBuffer{max: maxStderr}
+	cmd := exec.CommandContext(ctx, h.path, req.Verb)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	runErr := cmd.Run()
+	if stderr.Len() > 0 {
+		slog.Debug("external source stderr", "source", h.name, "verb", req.Verb, "stderr", stderr.String())
+	}
+	if runErr != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("source %s: %s failed: %w: %s", h.name, req.Verb, runErr, msg)
+		}
+		return nil, fmt.Errorf("source %s: %s failed: %w", h.name, req.Verb, runErr)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("source %s: invalid %s response: %w", h.name, req.Verb, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("source %s: %s", h.name, resp.Error)
+	}
+	return &resp, nil
+}
+
+// validName matches the source names an executable may provide.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Discover returns the source executables on the search path pathList
+// (formatted like PATH), keyed by source name. The first directory
+// providing a name wins, as it would for the shell. Relative directories
+// are skipped, as exec.LookPath refuses them, so a checkout can't supply
+// sources from the current directory.
+func Discover(pathList string) map[string]string {
+	found := map[string]string{}
+	for _, dir := range filepath.SplitList(pathList) {
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name, ok := sourceName(e.Name())
+			if !ok || found[name] != "" {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			if isExecutable(path) {
+				found[name] = path
+			}
+		}
+	}
+	return found
+}
+
+func sourceName(file string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		file = strings.TrimSuffix(strings.ToLower(file), ".exe")
+	}
+	name, ok := strings.CutPrefix(file, Prefix)
+	return name, ok && validName.MatchString(name)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode().Perm()&0111 != 0
+}
+
+// RegisterAll registers every source executable on pathList in registry and
+// returns the names registered. An executable may replace a built-in
+// placeholder source but never a working handler.
+func RegisterAll(registry *sync.SourceRegistry, pathList string) []string {
+	found := Discover(pathList)
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var registered []string
+	for _, name := range names {
+		path := found[name]
+		if existing, ok := registry.GetRegistration(name); ok {
+			if _, placeholder := existing.CreateFunc().(*sync.NotImplementedHandler); !placeholder {
+				slog.Warn("ignoring external source that shadows a built-in source", "source", name, "path", path)
+				continue
+			}
+			if err := registry.Unregister(name); err != nil {
+				slog.Warn("failed to replace placeholder source", "source", name, "error", err)
+				continue
+			}
+		}
+
+		h := New(name, path)
+		err := registry.Register(sync.HandlerRegistration{
+			Name: name,
+			Info: sync.SourceInfo{
+				Name:        name,
+				Description: h.Description(),
+				Type:        "external",
+			},
+			CreateFunc: func() sync.SourceHandler { return New(name, path) },
+		})
+		if err != nil {
+			slog.Warn("failed to register external source", "source", name, "error", err)
+			continue
+		}
+		registered = append(registered, name)
+	}
+	return registered
+}
+
+// limitedBuffer keeps the first max bytes written to it.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
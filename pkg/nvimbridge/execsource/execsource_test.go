@@ -0,0 +1,142 @@
+package execsource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// demoSource is a source executable that records each request next to
+// itself and answers every verb.
+const demoSource = `#!/bin/sh
+cat > "$(dirname "$0")/request-$1.json"
+case "$1" in
+validate) echo '{}' ;;
+list) echo '{"plugins":[{"name":"oil","repo":"stevearc/oil.nvim","category":"navigation"}]}' ;;
+sync) echo '{"pluginsCreated":["oil"],"errors":["skipped broken"],"totalAvailable":2}' ;;
+*) echo "unknown verb $1" >&2; exit 2 ;;
+esac
+`
+
+func writeSource(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, Prefix+name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+type recordingCreator struct {
+	source  string
+	plugins []string
+}
+
+func (c *recordingCreator) CreatePackage(source string, plugins []string) error {
+	c.source, c.plugins = source, plugins
+	return nil
+}
+
+func TestHandler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script source")
+	}
+	dir := t.TempDir()
+	h := New("demo", writeSource(t, dir, "demo", demoSource))
+	ctx := context.Background()
+
+	require.NoError(t, h.Validate(ctx))
+
+	plugins, err := h.ListAvailable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []sync.AvailablePlugin{{
+		Name: "oil", Repo: "stevearc/oil.nvim", Category: "navigation", SourceName: "demo",
+	}}, plugins)
+
+	creator := &recordingCreator{}
+	result, err := h.Sync(ctx, sync.SyncOptions{
+		TargetDir:      "/plugins",
+		Filters:        map[string]string{"category": "navigation"},
+		PackageCreator: creator,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oil"}, result.PluginsCreated)
+	assert.Equal(t, 2, result.TotalAvailable)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, []string{"demo"}, result.PackagesCreated)
+	assert.Equal(t, &recordingCreator{source: "demo", plugins: []string{"oil"}}, creator)
+
+	req, err := os.ReadFile(filepath.Join(dir, "request-sync.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":1,"verb":"sync","options":{"dryRun":false,"overwrite":false,"targetDir":"/plugins","filters":{"category":"navigation"}}}`, string(req))
+}
+
+func TestHandler_Errors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script source")
+	}
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	failing := New("failing", writeSource(t, dir, "failing", "#!/bin/sh\necho 'token missing' >&2\nexit 1\n"))
+	assert.ErrorContains(t, failing.Validate(ctx), "token missing")
+
+	refusing := New("refusing", writeSource(t, dir, "refusing", "#!/bin/sh\necho '{\"error\":\"repository archived\"}'\n"))
+	assert.EqualError(t, refusing.Validate(ctx), "source refusing: repository archived")
+
+	garbled := New("garbled", writeSource(t, dir, "garbled", "#!/bin/sh\necho 'not json'\n"))
+	_, err := garbled.ListAvailable(ctx)
+	assert.ErrorContains(t, err, "invalid list response")
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on the executable bit")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	demo := writeSource(t, first, "demo", demoSource)
+	writeSource(t, second, "demo", demoSource)
+	other := writeSource(t, second, "other", demoSource)
+	require.NoError(t, os.WriteFile(filepath.Join(second, Prefix+"notexec"), []byte(demoSource), 0644))
+	writeSource(t, second, "Bad_Name", demoSource)
+
+	found := Discover(first + string(os.PathListSeparator) + second)
+	assert.Equal(t, map[string]string{"demo": demo, "other": other}, found)
+
+	// Relative entries such as "." are not searched
+	t.Chdir(second)
+	assert.Empty(t, Discover("."+string(os.PathListSeparator)+"bin"))
+}
+
+func TestRegisterAll(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on the executable bit")
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"demo", "astronvim", "lazyvim"} {
+		writeSource(t, dir, name, demoSource)
+	}
+
+	registry := sync.NewSourceRegistry()
+	require.NoError(t, sync.RegisterBuiltinSources(registry))
+	require.NoError(t, registry.Unregister("lazyvim"))
+	require.NoError(t, registry.Register(sync.HandlerRegistration{
+		Name:       "lazyvim",
+		CreateFunc: func() sync.SourceHandler { return New("lazyvim", "builtin") },
+	}))
+
+	registered := RegisterAll(registry, dir)
+	assert.Equal(t, []string{"astronvim", "demo"}, registered, "placeholders are replaced, working handlers kept")
+
+	reg, ok := registry.GetRegistration("astronvim")
+	require.True(t, ok)
+	assert.Equal(t, "external", reg.Info.Type)
+	assert.IsType(t, &Handler{}, reg.CreateFunc())
+
+	reg, _ = registry.GetRegistration("lazyvim")
+	assert.Equal(t, "builtin", reg.CreateFunc().(*Handler).path)
+}
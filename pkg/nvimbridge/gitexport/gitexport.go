@@ -0,0 +1,256 @@
+// Package gitexport publishes the nvp store to a git repository so it can be
+// applied elsewhere with the github: shorthand.
+//
+// The repository layout is flat and predictable:
+//
+//	plugins/<name>.yaml - one NvimPlugin per file
+//	themes/<name>.yaml  - one NvimTheme per file
+//	README.md           - apply instructions
+//
+// which maps directly onto "nvp apply -f github:user/repo/plugins/<name>.yaml"
+// (or the whole plugins/ directory).
+package gitexport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"devopsmaestro/pkg/internal/gitcmd"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	theme "github.com/rmkohlman/MaestroTheme"
+)
+
+// Directories in the exported repository.
+const (
+	PluginsDir = "plugins"
+	ThemesDir  = "themes"
+)
+
+// DefaultBranch is the branch github: shorthand reads from.
+const DefaultBranch = "main"
+
+// File is a file to write into the repository, relative to its root.
+type File struct {
+	Path string
+	Data []byte
+}
+
+// Files renders plugins and themes into the repository layout. Shorthand is
+// the github:owner/repo prefix used in the generated README; it may be empty.
+func Files(plugins []*plugin.Plugin, themes []*theme.Theme, shorthand string) ([]File, error) {
+	var files []File
+	var pluginNames, themeNames []string
+
+	for _, p := range plugins {
+		if err := checkName("plugin", p.Name); err != nil {
+			return nil, err
+		}
+		data, err := p.ToYAMLBytes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal plugin %s: %w", p.Name, err)
+		}
+		files = append(files, File{Path: PluginsDir + "/" + p.Name + ".yaml", Data: data})
+		pluginNames = append(pluginNames, p.Name)
+	}
+	for _, t := range themes {
+		if err := checkName("theme", t.Name); err != nil {
+			return nil, err
+		}
+		data, err := t.ToYAML()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal theme %s: %w", t.Name, err)
+		}
+		files = append(files, File{Path: ThemesDir + "/" + t.Name + ".yaml", Data: data})
+		themeNames = append(themeNames, t.Name)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	sort.Strings(pluginNames)
+	sort.Strings(themeNames)
+	files = append(files, File{Path: "README.md", Data: readme(pluginNames, themeNames, shorthand)})
+	return files, nil
+}
+
+// checkName rejects names that would not map to a single file inside their
+// directory: empty names and names containing path separators or "..".
+func checkName(kind, name string) error {
+	if name == "" || strings.ContainsAny(name, "/\\\x00") || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid %s name %q: must not be empty or contain '/', '\\', or '..'", kind, name)
+	}
+	return nil
+}
+
+func readme(plugins, themes []string, shorthand string) []byte {
+	if shorthand == "" {
+		shorthand = "github:<user>/<repo>"
+	}
+	var b strings.Builder
+	b.WriteString("# nvp plugins\n\n")
+	b.WriteString("Neovim plugin and theme definitions exported with `nvp export --to-git`.\n\n")
+	b.WriteString("## Usage\n\n```sh\n")
+	fmt.Fprintf(&b, "# Apply everything\nnvp apply -f %s/%s/\n", shorthand, PluginsDir)
+	if len(themes) > 0 {
+		fmt.Fprintf(&b, "nvp apply -f %s/%s/\n", shorthand, ThemesDir)
+	}
+	if len(plugins) > 0 {
+		fmt.Fprintf(&b, "\n# Apply a single plugin\nnvp apply -f %s/%s/%s.yaml\n", shorthand, PluginsDir, plugins[0])
+	}
+	b.WriteString("```\n")
+	if len(plugins) > 0 {
+		b.WriteString("\n## Plugins\n\n")
+		for _, name := range plugins {
+			fmt.Fprintf(&b, "- [%s](%s/%s.yaml)\n", name, PluginsDir, name)
+		}
+	}
+	if len(themes) > 0 {
+		b.WriteString("\n## Themes\n\n")
+		for _, name := range themes {
+			fmt.Fprintf(&b, "- [%s](%s/%s.yaml)\n", name, ThemesDir, name)
+		}
+	}
+	return []byte(b.String())
+}
+
+// Shorthand returns the github:owner/repo prefix for a GitHub remote URL
+// (https, ssh, or scp-style), or an empty string for other hosts.
+func Shorthand(repoURL string) string {
+	s := strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "ssh://git@github.com/", "git@github.com:"} {
+		if strings.HasPrefix(s, prefix) {
+			parts := strings.Split(strings.TrimPrefix(s, prefix), "/")
+			if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+				return "github:" + parts[0] + "/" + parts[1]
+			}
+		}
+	}
+	return ""
+}
+
+// Options configures a publish.
+type Options struct {
+	RepoURL string
+	Branch  string // defaults to DefaultBranch
+	Message string // commit message
+	// Prune removes YAML files under plugins/ and themes/ that are not part
+	// of this export, so the repository mirrors the store exactly.
+	Prune bool
+}
+
+// Result describes a publish.
+type Result struct {
+	Shorthand string
+	Written   []string
+	Removed   []string
+	Commit    string // empty when there was nothing to commit
+}
+
+// Publish clones the repository, writes files into it, and commits and
+// pushes the change. Nothing is pushed when the files already match.
+func Publish(ctx context.Context, files []File, opts Options) (*Result, error) {
+	if opts.RepoURL == "" {
+		return nil, fmt.Errorf("repository URL is required")
+	}
+	for _, f := range files {
+		if !filepath.IsLocal(filepath.FromSlash(f.Path)) {
+			return nil, fmt.Errorf("refusing to write %s outside the repository", f.Path)
+		}
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = DefaultBranch
+	}
+	message := opts.Message
+	if message == "" {
+		message = "Update nvp plugins"
+	}
+
+	workDir, err := os.MkdirTemp("", "nvp-export-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	if _, err := gitcmd.Run(ctx, "", "clone", "--", opts.RepoURL, workDir); err != nil {
+		return nil, err
+	}
+	if _, err := gitcmd.Run(ctx, workDir, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err == nil {
+		_, err = gitcmd.Run(ctx, workDir, "checkout", "-B", branch, "origin/"+branch)
+		if err != nil {
+			return nil, err
+		}
+	} else if _, err := gitcmd.Run(ctx, workDir, "checkout", "-B", branch); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Shorthand: Shorthand(opts.RepoURL)}
+	keep := make(map[string]bool)
+	for _, f := range files {
+		path := filepath.Join(workDir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(path, f.Data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+		keep[f.Path] = true
+		result.Written = append(result.Written, f.Path)
+	}
+
+	if opts.Prune {
+		for _, dir := range []string{PluginsDir, ThemesDir} {
+			entries, err := os.ReadDir(filepath.Join(workDir, dir))
+			if err != nil {
+				continue
+			}
+			for _, e := range entries {
+				rel := dir + "/" + e.Name()
+				if e.IsDir() || keep[rel] || !isYAML(e.Name()) {
+					continue
+				}
+				if err := os.Remove(filepath.Join(workDir, dir, e.Name())); err != nil {
+					return nil, fmt.Errorf("failed to remove %s: %w", rel, err)
+				}
+				result.Removed = append(result.Removed, rel)
+			}
+		}
+	}
+
+	if _, err := gitcmd.Run(ctx, workDir, "add", "-A"); err != nil {
+		return nil, err
+	}
+	status, err := gitcmd.Run(ctx, workDir, "status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(status) == "" {
+		slog.Info("export repository already up to date", "repo", opts.RepoURL, "branch", branch)
+		return result, nil
+	}
+
+	if _, err := gitcmd.Run(ctx, workDir, "commit", "-m", message); err != nil {
+		return nil, err
+	}
+	sha, err := gitcmd.Run(ctx, workDir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := gitcmd.Run(ctx, workDir, "push", "origin", "HEAD:refs/heads/"+branch); err != nil {
+		return nil, err
+	}
+	result.Commit = strings.TrimSpace(sha)
+
+	slog.Info("published plugins to git", "repo", opts.RepoURL, "branch", branch, "commit", result.Commit,
+		"written", len(result.Written), "removed", len(result.Removed))
+	return result, nil
+}
+
+func isYAML(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".yaml") || strings.HasSuffix(lower, ".yml")
+}
@@ -0,0 +1,162 @@
+package gitexport
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"devopsmaestro/pkg/internal/gitcmd"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	theme "github.com/rmkohlman/MaestroTheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShorthand(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/alice/nvim-plugins":      "github:alice/nvim-plugins",
+		"https://github.com/alice/nvim-plugins.git":  "github:alice/nvim-plugins",
+		"git@github.com:alice/nvim-plugins.git":      "github:alice/nvim-plugins",
+		"ssh://git@github.com/alice/nvim-plugins":    "github:alice/nvim-plugins",
+		"https://gitlab.com/alice/nvim-plugins.git":  "",
+		"https://github.com/alice":                   "",
+		"https://github.com/alice/nvim-plugins/tree": "",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, Shorthand(in), in)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	plugins := []*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Enabled: true},
+		{Name: "flash", Repo: "folke/flash.nvim", Enabled: true},
+	}
+	themes := []*theme.Theme{{Name: "tokyonight-night", Plugin: theme.ThemePlugin{Repo: "folke/tokyonight.nvim"}}}
+
+	files, err := Files(plugins, themes, "github:alice/nvim-plugins")
+	require.NoError(t, err)
+
+	var paths []string
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"plugins/flash.yaml", "plugins/telescope.yaml", "themes/tokyonight-night.yaml", "README.md"}, paths)
+
+	parsed, err := plugin.ParseYAML(files[1].Data)
+	require.NoError(t, err)
+	assert.Equal(t, "nvim-telescope/telescope.nvim", parsed.Repo)
+
+	readme := string(files[3].Data)
+	assert.Contains(t, readme, "nvp apply -f github:alice/nvim-plugins/plugins/\n")
+	assert.Contains(t, readme, "nvp apply -f github:alice/nvim-plugins/plugins/flash.yaml")
+	assert.Contains(t, readme, "- [tokyonight-night](themes/tokyonight-night.yaml)")
+}
+
+func TestFiles_RejectsUnsafeNames(t *testing.T) {
+	for _, name := range []string{"", "../evil", "a/b", `a\b`, ".."} {
+		_, err := Files([]*plugin.Plugin{{Name: name, Repo: "acme/x"}}, nil, "")
+		assert.Error(t, err, "plugin %q", name)
+	}
+	_, err := Files(nil, []*theme.Theme{{Name: "../../.ssh/config"}}, "")
+	assert.ErrorContains(t, err, "invalid theme name")
+}
+
+// setupRemote creates a bare repository with one commit on main containing
+// the given files.
+func setupRemote(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	ctx := context.Background()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	_, err := gitcmd.Run(ctx, "", "init", "--bare", "--initial-branch=main", remote)
+	require.NoError(t, err)
+
+	if len(files) == 0 {
+		return remote
+	}
+	seed := t.TempDir()
+	_, err = gitcmd.Run(ctx, "", "clone", "--", remote, seed)
+	require.NoError(t, err)
+	_, err = gitcmd.Run(ctx, seed, "checkout", "-B", "main")
+	require.NoError(t, err)
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(seed, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(seed, path), []byte(content), 0644))
+	}
+	_, err = gitcmd.Run(ctx, seed, "add", "-A")
+	require.NoError(t, err)
+	_, err = gitcmd.Run(ctx, seed, "commit", "-m", "seed")
+	require.NoError(t, err)
+	_, err = gitcmd.Run(ctx, seed, "push", "origin", "HEAD:refs/heads/main")
+	require.NoError(t, err)
+	return remote
+}
+
+func remoteFiles(t *testing.T, remote string) []string {
+	t.Helper()
+	out, err := gitcmd.Run(context.Background(), remote, "ls-tree", "-r", "--name-only", "main")
+	require.NoError(t, err)
+	return strings.Fields(out)
+}
+
+func TestPublish_EmptyRemote(t *testing.T) {
+	remote := setupRemote(t, nil)
+	files := []File{{Path: "plugins/flash.yaml", Data: []byte("kind: NvimPlugin\n")}}
+
+	res, err := Publish(context.Background(), files, Options{RepoURL: remote})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Commit)
+	assert.Equal(t, []string{"plugins/flash.yaml"}, remoteFiles(t, remote))
+
+	// Publishing the same files again is a no-op.
+	res, err = Publish(context.Background(), files, Options{RepoURL: remote})
+	require.NoError(t, err)
+	assert.Empty(t, res.Commit)
+}
+
+func TestPublish_Prune(t *testing.T) {
+	remote := setupRemote(t, map[string]string{
+		"plugins/old.yaml":   "kind: NvimPlugin\n",
+		"plugins/notes.txt":  "keep me\n",
+		"themes/gone.yaml":   "kind: NvimTheme\n",
+		"other/extra.yaml":   "untouched\n",
+		"plugins/flash.yaml": "stale\n",
+	})
+	files := []File{{Path: "plugins/flash.yaml", Data: []byte("kind: NvimPlugin\n")}}
+
+	res, err := Publish(context.Background(), files, Options{RepoURL: remote, Prune: true, Message: "sync"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"plugins/old.yaml", "themes/gone.yaml"}, res.Removed)
+	assert.Equal(t, []string{"other/extra.yaml", "plugins/flash.yaml", "plugins/notes.txt"}, remoteFiles(t, remote))
+
+	msg, err := gitcmd.Run(context.Background(), remote, "log", "-1", "--format=%s", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "sync", strings.TrimSpace(msg))
+}
+
+func TestPublish_CloneError(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	_, err := Publish(context.Background(), nil, Options{RepoURL: filepath.Join(t.TempDir(), "missing.git")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "git clone failed")
+
+	_, err = Publish(context.Background(), nil, Options{})
+	assert.Error(t, err)
+
+	_, err = Publish(context.Background(), []File{{Path: "../outside.yaml"}}, Options{RepoURL: filepath.Join(t.TempDir(), "missing.git")})
+	assert.ErrorContains(t, err, "outside the repository")
+}
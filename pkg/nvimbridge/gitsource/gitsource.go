@@ -0,0 +1,538 @@
+// Package gitsource is an nvp sync source backed by any git repository.
+//
+// A source is a clone URL, an optional ref (branch, tag, or commit), and
+// optional path globs selecting the files to read. Every matching
+// NvimPlugin YAML file (.yaml, .yml; several documents per file allowed)
+// and lazy.nvim Lua spec (.lua) becomes an available plugin:
+//
+//	nvp source add mycorp git@github.com:corp/nvim-plugins.git --path specs/
+//	nvp source sync mycorp
+//
+// The repository is fetched shallowly into a cache directory that is reused
+// between syncs.
+package gitsource
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"devopsmaestro/pkg/internal/gitcmd"
+	"devopsmaestro/pkg/nvimbridge/luaspec"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Type is the source type of git sources.
+const Type = "git"
+
+// Config describes a git source.
+type Config struct {
+	Name string `yaml:"name" json:"name"`
+	URL  string `yaml:"url" json:"url"`
+	// Ref is the branch, tag, or commit to read; empty means the remote's
+	// default branch. A "tag" sync filter overrides it.
+	Ref string `yaml:"ref,omitempty" json:"ref,omitempty"`
+	// Paths are globs, relative to the repository root, selecting the files
+	// to read. A path ending in "/" selects everything below it, "**"
+	// matches any number of directories, and no paths select the whole
+	// repository.
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// Token, when set, returns the token sent to https remotes. SSH remotes
+	// use the keys git already knows about.
+	Token func(ctx context.Context) (string, error) `yaml:"-" json:"-"`
+}
+
+// Validate checks the configuration is complete and its globs are valid.
+func (c Config) Validate() error {
+	if !validName.MatchString(c.Name) {
+		return fmt.Errorf("invalid source name %q: use lowercase letters, digits, and '-'", c.Name)
+	}
+	if c.URL == "" {
+		return fmt.Errorf("source %s: url is required", c.Name)
+	}
+	if strings.HasPrefix(c.Ref, "-") {
+		return fmt.Errorf("source %s: invalid ref %q", c.Name, c.Ref)
+	}
+	for _, p := range c.Paths {
+		if _, err := globRegexp(p); err != nil {
+			return fmt.Errorf("source %s: invalid path %q: %w", c.Name, p, err)
+		}
+	}
+	return nil
+}
+
+// validName matches source names; it is the same rule external sources use.
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Handler is a sync.SourceHandler for a git source.
+type Handler struct {
+	cfg      Config
+	cacheDir string
+
+	// ref and commit are what the last fetch read.
+	ref, commit string
+}
+
+// New returns the handler for cfg. The repository is checked out in
+// cacheDir/<name>.
+func New(cfg Config, cacheDir string) *Handler {
+	return &Handler{cfg: cfg, cacheDir: cacheDir}
+}
+
+// Name returns the source name.
+func (h *Handler) Name() string { return h.cfg.Name }
+
+// Description names the repository and ref the source reads.
+func (h *Handler) Description() string {
+	d := "Git source (" + h.cfg.URL
+	if h.cfg.Ref != "" {
+		d += "@" + h.cfg.Ref
+	}
+	return d + ")"
+}
+
+// Validate checks the repository is reachable.
+func (h *Handler) Validate(ctx context.Context) error {
+	if err := h.cfg.Validate(); err != nil {
+		return err
+	}
+	token, err := h.token(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = gitcmd.RunEnv(ctx, authEnv(token), "", "ls-remote", "--exit-code", "--", h.cfg.URL, "HEAD")
+	return err
+}
+
+// Revision returns the ref and commit the last sync or listing read; the
+// ref is empty when it was the remote's default branch.
+func (h *Handler) Revision() (ref, commit string) {
+	return h.ref, h.commit
+}
+
+// token resolves the configured token, if any.
+func (h *Handler) token(ctx context.Context) (string, error) {
+	if h.cfg.Token == nil {
+		return "", nil
+	}
+	token, err := h.cfg.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("source %s: %w", h.cfg.Name, err)
+	}
+	return token, nil
+}
+
+// ListAvailable fetches the repository and lists the plugins it defines.
+func (h *Handler) ListAvailable(ctx context.Context) ([]sync.AvailablePlugin, error) {
+	scan, err := h.fetchAndScan(ctx, h.cfg.Ref)
+	if err != nil {
+		return nil, err
+	}
+	available := make([]sync.AvailablePlugin, 0, len(scan.Plugins))
+	for _, p := range scan.Plugins {
+		available = append(available, h.available(p))
+	}
+	return available, nil
+}
+
+// Sync writes the plugins matching the options' filters into the target
+// directory, then creates the source's package from them.
+func (h *Handler) Sync(ctx context.Context, options sync.SyncOptions) (*sync.SyncResult, error) {
+	ref := h.cfg.Ref
+	filters := make(map[string]string, len(options.Filters))
+	for k, v := range options.Filters {
+		if k == "tag" {
+			ref = v
+			continue
+		}
+		filters[k] = v
+	}
+	options.Filters = filters
+
+	scan, err := h.fetchAndScan(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &sync.SyncResult{SourceName: h.cfg.Name, TotalAvailable: len(scan.Plugins)}
+	for _, e := range scan.Errors {
+		result.AddError(e)
+	}
+	if !options.DryRun && options.TargetDir != "" {
+		if err := os.MkdirAll(options.TargetDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create target directory: %w", err)
+		}
+	}
+
+	var synced []string
+	for _, p := range scan.Plugins {
+		if !options.MatchesAvailablePlugin(h.available(p)) {
+			continue
+		}
+		if strings.ContainsAny(p.Name, `/\`) || strings.Contains(p.Name, "..") {
+			result.AddError(fmt.Errorf("skipped plugin %q: name must not contain '/', '\\', or '..'", p.Name))
+			continue
+		}
+
+		exists := false
+		var path string
+		if options.TargetDir != "" {
+			path = filepath.Join(options.TargetDir, p.Name+".yaml")
+			_, statErr := os.Stat(path)
+			exists = statErr == nil
+		}
+		if exists && !options.Overwrite {
+			slog.Debug("skipping existing plugin", "source", h.cfg.Name, "plugin", p.Name)
+			continue
+		}
+
+		if !options.DryRun && path != "" {
+			data, err := h.pluginYAML(p)
+			if err != nil {
+				result.AddError(fmt.Errorf("failed to serialize plugin %s: %w", p.Name, err))
+				continue
+			}
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				result.AddError(fmt.Errorf("failed to write plugin %s: %w", p.Name, err))
+				continue
+			}
+		}
+		if exists {
+			result.AddPluginUpdated(p.Name)
+		} else {
+			result.AddPluginCreated(p.Name)
+		}
+		synced = append(synced, p.Name)
+	}
+
+	if options.PackageCreator != nil && len(synced) > 0 {
+		if options.DryRun {
+			result.AddPackageCreated(h.cfg.Name)
+		} else if err := options.PackageCreator.CreatePackage(h.cfg.Name, synced); err != nil {
+			result.AddError(fmt.Errorf("failed to create package: %w", err))
+		} else {
+			result.AddPackageCreated(h.cfg.Name)
+		}
+	}
+	return result, nil
+}
+
+// available describes p as an available plugin of this source.
+func (h *Handler) available(p *plugin.Plugin) sync.AvailablePlugin {
+	var deps []string
+	for _, d := range p.Dependencies {
+		deps = append(deps, d.Repo)
+	}
+	return sync.AvailablePlugin{
+		Name:         p.Name,
+		Description:  p.Description,
+		Category:     p.Category,
+		Repo:         p.Repo,
+		Labels:       h.labels(),
+		Config:       p.Config,
+		Dependencies: deps,
+		SourceName:   h.cfg.Name,
+	}
+}
+
+// labels are the labels of every plugin synced from this source.
+func (h *Handler) labels() map[string]string {
+	return map[string]string{"source": h.cfg.Name}
+}
+
+// pluginYAML serializes p labeled with the source it came from.
+func (h *Handler) pluginYAML(p *plugin.Plugin) ([]byte, error) {
+	py := p.ToYAML()
+	if py.Metadata.Labels == nil {
+		py.Metadata.Labels = make(map[string]string)
+	}
+	for k, v := range h.labels() {
+		py.Metadata.Labels[k] = v
+	}
+	return yaml.Marshal(py)
+}
+
+// checkoutDir is where the repository is checked out.
+func (h *Handler) checkoutDir() string {
+	return filepath.Join(h.cacheDir, h.cfg.Name)
+}
+
+func (h *Handler) fetchAndScan(ctx context.Context, ref string) (*ScanResult, error) {
+	if err := h.cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("source %s: invalid ref %q", h.cfg.Name, ref)
+	}
+	token, err := h.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dir := h.checkoutDir()
+	commit, err := Fetch(ctx, h.cfg.URL, ref, dir, token)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: %w", h.cfg.Name, err)
+	}
+	h.ref, h.commit = ref, commit
+	slog.Debug("fetched git source", "source", h.cfg.Name, "url", h.cfg.URL, "ref", ref, "commit", commit)
+	return Scan(dir, h.cfg.Paths)
+}
+
+// Fetch makes dir a shallow checkout of ref (the default branch when empty)
+// of the repository at url and returns the checked-out commit. An existing
+// checkout is updated in place. A non-empty token authenticates https
+// fetches.
+func Fetch(ctx context.Context, url, ref, dir, token string) (string, error) {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create checkout directory: %w", err)
+		}
+		if _, err := gitcmd.Run(ctx, dir, "init", "--quiet"); err != nil {
+			return "", err
+		}
+	}
+	if _, err := gitcmd.Run(ctx, dir, "remote", "get-url", "origin"); err != nil {
+		if _, err := gitcmd.Run(ctx, dir, "remote", "add", "origin", url); err != nil {
+			return "", err
+		}
+	} else if _, err := gitcmd.Run(ctx, dir, "remote", "set-url", "origin", url); err != nil {
+		return "", err
+	}
+
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if _, err := gitcmd.RunEnv(ctx, authEnv(token), dir, "fetch", "--quiet", "--depth", "1", "--force", "origin", ref); err != nil {
+		return "", err
+	}
+	if _, err := gitcmd.Run(ctx, dir, "checkout", "--quiet", "--force", "--detach", "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+	if _, err := gitcmd.Run(ctx, dir, "clean", "-fdxq"); err != nil {
+		return "", err
+	}
+	sha, err := gitcmd.Run(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sha), nil
+}
+
+// ScanResult is what Scan found in a checkout.
+type ScanResult struct {
+	// Plugins are sorted by name. When two files define the same plugin,
+	// the first in path order wins.
+	Plugins []*plugin.Plugin
+	// Files maps each plugin name to the repository path, slash separated,
+	// of the file defining it.
+	Files map[string]string
+	// Errors describe files that were skipped.
+	Errors []error
+	// Warnings are Lua conversion notes; the plugins were still imported.
+	Warnings []luaspec.Warning
+}
+
+// Scan reads the plugin definitions in the files under root matching paths.
+// Hidden files and directories, such as .git and .github, are skipped.
+func Scan(root string, paths []string) (*ScanResult, error) {
+	var matchers []*regexp.Regexp
+	for _, p := range paths {
+		re, err := globRegexp(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: %w", p, err)
+		}
+		matchers = append(matchers, re)
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !isSpecFile(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if matchAny(matchers, filepath.ToSlash(rel)) {
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	sort.Strings(files)
+
+	result := &ScanResult{Files: make(map[string]string)}
+	converter := &luaspec.Converter{}
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+
+		var plugins []*plugin.Plugin
+		if strings.HasSuffix(rel, ".lua") {
+			converted := converter.Convert(string(data), rel)
+			plugins = converted.Plugins
+			result.Warnings = append(result.Warnings, converted.Warnings...)
+		} else if plugins, err = plugin.ParseYAMLMultiple(data); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("skipped %s: %w", rel, err))
+			continue
+		}
+
+		for _, p := range plugins {
+			if first, ok := result.Files[p.Name]; ok {
+				result.Errors = append(result.Errors, fmt.Errorf("skipped plugin %s in %s: already defined in %s", p.Name, rel, first))
+				continue
+			}
+			result.Files[p.Name] = rel
+			result.Plugins = append(result.Plugins, p)
+		}
+	}
+	sort.Slice(result.Plugins, func(i, j int) bool { return result.Plugins[i].Name < result.Plugins[j].Name })
+	return result, nil
+}
+
+func isSpecFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".lua":
+		return true
+	}
+	return false
+}
+
+func matchAny(matchers []*regexp.Regexp, rel string) bool {
+	if len(matchers) == 0 {
+		return true
+	}
+	for _, re := range matchers {
+		if re.MatchString(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// globRegexp compiles a path glob. "*" and "?" do not cross directories,
+// "**" does, and a trailing "/" (or a pattern naming a directory) also
+// matches everything below it.
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+	if glob == "" || strings.HasPrefix(glob, "/") || strings.Contains("/"+glob+"/", "/../") {
+		return nil, fmt.Errorf("must be a relative path inside the repository")
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '/':
+			if i == len(glob)-1 {
+				continue
+			}
+			b.WriteByte('/')
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+	return regexp.Compile(b.String())
+}
+
+// RegisterAll registers a handler for each config in registry and returns
+// the names registered. Like external sources, a git source may replace a
+// built-in placeholder (or an earlier git registration of the same name)
+// but never a working handler.
+func RegisterAll(registry *sync.SourceRegistry, configs []Config, cacheDir string) []string {
+	var registered []string
+	for _, cfg := range configs {
+		if err := cfg.Validate(); err != nil {
+			slog.Warn("ignoring invalid git source", "source", cfg.Name, "error", err)
+			continue
+		}
+		if existing, ok := registry.GetRegistration(cfg.Name); ok {
+			switch existing.CreateFunc().(type) {
+			case *sync.NotImplementedHandler, *Handler:
+			default:
+				slog.Warn("ignoring git source that shadows another source", "source", cfg.Name)
+				continue
+			}
+			if err := registry.Unregister(cfg.Name); err != nil {
+				slog.Warn("failed to replace placeholder source", "source", cfg.Name, "error", err)
+				continue
+			}
+		}
+
+		err := registry.Register(sync.HandlerRegistration{
+			Name:       cfg.Name,
+			Info:       Info(cfg),
+			CreateFunc: func() sync.SourceHandler { return New(cfg, cacheDir) },
+		})
+		if err != nil {
+			slog.Warn("failed to register git source", "source", cfg.Name, "error", err)
+			continue
+		}
+		registered = append(registered, cfg.Name)
+	}
+	return registered
+}
+
+// Info describes the source cfg configures.
+func Info(cfg Config) sync.SourceInfo {
+	return sync.SourceInfo{
+		Name:         cfg.Name,
+		Description:  New(cfg, "").Description(),
+		URL:          cfg.URL,
+		Type:         Type,
+		RequiresAuth: cfg.Token != nil,
+		ConfigKeys:   []string{"tag"},
+	}
+}
+
+// authEnv configures git, through the environment so the token stays out
+// of the process list and the checkout's config, to send token as HTTP
+// basic auth, which GitHub, GitLab, Gitea, and Bitbucket all accept.
+func authEnv(token string) []string {
+	if token == "" {
+		return nil
+	}
+	basic := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.extraHeader",
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + basic,
+	}
+}
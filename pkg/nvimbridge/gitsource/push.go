@@ -0,0 +1,323 @@
+package gitsource
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/internal/gitcmd"
+	"devopsmaestro/pkg/linediff"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PushOptions configures Push.
+type PushOptions struct {
+	// Branch is the branch the changes are committed on; empty generates
+	// nvp/<source>-<timestamp>.
+	Branch string
+	// Base is the ref the branch starts from; empty means the source's ref.
+	Base string
+	// Message is the commit message; empty generates one naming the plugins.
+	Message string
+	// Force overwrites an existing remote branch of the same name.
+	Force bool
+	// DryRun computes the changes and description without committing.
+	DryRun bool
+}
+
+// Change is a local edit of a plugin defined in the repository.
+type Change struct {
+	Plugin string `yaml:"plugin" json:"plugin"`
+	// File is the repository path of the file defining the plugin.
+	File string `yaml:"file" json:"file"`
+	// Diff is a line diff of the plugin's YAML, repository side first.
+	Diff    string `yaml:"diff" json:"diff"`
+	Added   int    `yaml:"added" json:"added"`
+	Removed int    `yaml:"removed" json:"removed"`
+}
+
+// PushResult is what Push did.
+type PushResult struct {
+	Source string `yaml:"source" json:"source"`
+	Branch string `yaml:"branch" json:"branch"`
+	// Base and BaseCommit are the ref and commit the branch starts from.
+	Base       string `yaml:"base,omitempty" json:"base,omitempty"`
+	BaseCommit string `yaml:"baseCommit" json:"baseCommit"`
+	// Commit is the pushed commit; empty for a dry run or no changes.
+	Commit  string   `yaml:"commit,omitempty" json:"commit,omitempty"`
+	Changes []Change `yaml:"changes" json:"changes"`
+	// Skipped explains plugins that were not pushed.
+	Skipped []string `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+	// Description is a Markdown pull request description of the changes.
+	Description string `yaml:"description" json:"description"`
+	// CompareURL opens a pull request for GitHub remotes.
+	CompareURL string `yaml:"compareURL,omitempty" json:"compareURL,omitempty"`
+}
+
+// Push compares the local plugins with their definitions in the repository
+// and commits the ones that differ on a new branch, which is then pushed to
+// the remote for review. Plugins the repository defines in Lua are skipped:
+// nvp cannot rewrite a Lua spec faithfully. Labels and annotations of the
+// rewritten YAML documents are kept, as are the other documents of a file.
+func (h *Handler) Push(ctx context.Context, local []*plugin.Plugin, opts PushOptions) (*PushResult, error) {
+	base := opts.Base
+	if base == "" {
+		base = h.cfg.Ref
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = fmt.Sprintf("nvp/%s-%s", h.cfg.Name, time.Now().UTC().Format("20060102-150405"))
+	}
+	if _, err := gitcmd.Run(ctx, "", "check-ref-format", "--branch", branch); err != nil || strings.HasPrefix(branch, "-") {
+		return nil, fmt.Errorf("invalid branch name %q", branch)
+	}
+
+	scan, err := h.fetchAndScan(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	result := &PushResult{
+		Source:     h.cfg.Name,
+		Branch:     branch,
+		Base:       base,
+		BaseCommit: h.commit,
+		CompareURL: compareURL(h.cfg.URL, branch),
+	}
+
+	upstream := make(map[string]*plugin.Plugin, len(scan.Plugins))
+	for _, p := range scan.Plugins {
+		upstream[p.Name] = p
+	}
+	replace := make(map[string]map[string]*plugin.Plugin) // file → plugin name → local plugin
+	for _, p := range local {
+		theirs, ok := upstream[p.Name]
+		if !ok {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: not defined in %s", p.Name, h.cfg.Name))
+			continue
+		}
+		before, err := comparableYAML(theirs)
+		if err != nil {
+			return nil, err
+		}
+		after, err := comparableYAML(p)
+		if err != nil {
+			return nil, err
+		}
+		if before == after {
+			continue
+		}
+		file := scan.Files[p.Name]
+		if strings.HasSuffix(file, ".lua") {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: defined in Lua (%s); edit it upstream", p.Name, file))
+			continue
+		}
+		diff, added, removed := linediff.Unified(before, after)
+		result.Changes = append(result.Changes, Change{Plugin: p.Name, File: file, Diff: diff, Added: added, Removed: removed})
+		if replace[file] == nil {
+			replace[file] = make(map[string]*plugin.Plugin)
+		}
+		replace[file][p.Name] = p
+	}
+	sort.Slice(result.Changes, func(i, j int) bool { return result.Changes[i].Plugin < result.Changes[j].Plugin })
+	result.Description = result.describe()
+
+	if opts.DryRun || len(result.Changes) == 0 {
+		return result, nil
+	}
+
+	dir := h.checkoutDir()
+	if _, err := gitcmd.Run(ctx, dir, "checkout", "--quiet", "-B", branch); err != nil {
+		return nil, err
+	}
+	var files []string
+	for file, plugins := range replace {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		data, err = rewriteYAML(data, plugins)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite %s: %w", file, err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	message := opts.Message
+	if message == "" {
+		message = result.title()
+	}
+	if _, err := gitcmd.Run(ctx, dir, append([]string{"add", "--"}, files...)...); err != nil {
+		return nil, err
+	}
+	if _, err := gitcmd.Run(ctx, dir, "commit", "--quiet", "-m", message); err != nil {
+		return nil, err
+	}
+	sha, err := gitcmd.Run(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	result.Commit = strings.TrimSpace(sha)
+
+	token, err := h.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"push", "--quiet"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, "origin", "HEAD:refs/heads/"+branch)
+	if _, err := gitcmd.RunEnv(ctx, authEnv(token), dir, args...); err != nil {
+		return nil, fmt.Errorf("source %s: %w", h.cfg.Name, err)
+	}
+	return result, nil
+}
+
+// comparableYAML serializes p without labels and annotations, which the
+// local store does not keep, so a synced plugin equals its definition.
+func comparableYAML(p *plugin.Plugin) (string, error) {
+	py := p.ToYAML()
+	py.Metadata.Labels = nil
+	py.Metadata.Annotations = nil
+	data, err := yaml.Marshal(py)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize plugin %s: %w", p.Name, err)
+	}
+	return string(data), nil
+}
+
+// rewriteYAML replaces the NvimPlugin documents of a YAML file named in
+// plugins, keeping their labels and annotations, and leaves the other
+// documents as they are.
+func rewriteYAML(data []byte, plugins map[string]*plugin.Plugin) ([]byte, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var docs []*yaml.Node
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		docs = append(docs, &doc)
+	}
+
+	for i, doc := range docs {
+		var orig plugin.PluginYAML
+		if err := doc.Decode(&orig); err != nil {
+			continue
+		}
+		p, ok := plugins[orig.Metadata.Name]
+		if !ok || (orig.Kind != "" && orig.Kind != "NvimPlugin") {
+			continue
+		}
+		py := p.ToYAML()
+		py.Metadata.Labels = orig.Metadata.Labels
+		py.Metadata.Annotations = orig.Metadata.Annotations
+		var node yaml.Node
+		if err := node.Encode(py); err != nil {
+			return nil, err
+		}
+		keepHeadComments(doc.Content[0], &node)
+		docs[i] = &yaml.Node{Kind: yaml.DocumentNode, HeadComment: doc.HeadComment, Content: []*yaml.Node{&node}}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	for _, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// keepHeadComments moves the comments above a replaced document, which
+// yaml.v3 attaches to the mapping or its first key, to the replacement.
+func keepHeadComments(from, to *yaml.Node) {
+	to.HeadComment = from.HeadComment
+	if len(from.Content) > 0 && len(to.Content) > 0 {
+		to.Content[0].HeadComment = from.Content[0].HeadComment
+	}
+}
+
+// title is the commit message and pull request title.
+func (r *PushResult) title() string {
+	names := make([]string, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		names = append(names, c.Plugin)
+	}
+	if len(names) > 3 {
+		return fmt.Sprintf("Update %d plugins from nvp", len(names))
+	}
+	return "Update " + strings.Join(names, ", ") + " from nvp"
+}
+
+// describe writes the pull request description: a summary table and the
+// YAML diff of each plugin.
+func (r *PushResult) describe() string {
+	if len(r.Changes) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n\n", r.title())
+	base := shortCommit(r.BaseCommit)
+	if r.Base != "" {
+		base = r.Base + " @ " + base
+	}
+	fmt.Fprintf(&b, "Local plugin changes pushed back to the `%s` source from nvp (base `%s`).\n\n", r.Source, base)
+	b.WriteString("| Plugin | File | Changes |\n|---|---|---|\n")
+	for _, c := range r.Changes {
+		fmt.Fprintf(&b, "| %s | `%s` | +%d -%d |\n", c.Plugin, c.File, c.Added, c.Removed)
+	}
+	for _, c := range r.Changes {
+		fmt.Fprintf(&b, "\n### %s\n\n```diff\n%s```\n", c.Plugin, c.Diff)
+	}
+	if len(r.Skipped) > 0 {
+		b.WriteString("\n<details><summary>Not included</summary>\n\n")
+		for _, s := range r.Skipped {
+			fmt.Fprintf(&b, "- %s\n", s)
+		}
+		b.WriteString("\n</details>\n")
+	}
+	return b.String()
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// githubRemote matches the owner/repo of GitHub https and ssh URLs.
+var githubRemote = regexp.MustCompile(`^(?:https://|ssh://git@|git@)github\.com[/:]([^/]+/[^/]+?)(?:\.git)?/?$`)
+
+// compareURL returns the page opening a pull request for branch on GitHub
+// remotes, or "" for other hosts.
+func compareURL(url, branch string) string {
+	m := githubRemote.FindStringSubmatch(url)
+	if m == nil {
+		return ""
+	}
+	return "https://github.com/" + m[1] + "/compare/" + branch + "?expand=1"
+}
@@ -0,0 +1,77 @@
+package gitsource
+
+import (
+	"context"
+	"testing"
+
+	"devopsmaestro/pkg/internal/gitcmd"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPush(t *testing.T) {
+	repo := initRepo(t, map[string]string{
+		"specs/core.yaml":     "# core plugins\n" + telescopeYAML,
+		"lua/plugins/oil.lua": oilLua,
+	})
+	ctx := context.Background()
+	remote := t.TempDir()
+	_, err := gitcmd.Run(ctx, "", "clone", "--quiet", "--bare", repo, remote)
+	require.NoError(t, err)
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	h := New(Config{Name: "mycorp", URL: "file://" + remote}, t.TempDir())
+	local := []*plugin.Plugin{
+		{Name: "telescope", Category: "navigation", Repo: "nvim-telescope/telescope.nvim", Enabled: true, Cmd: []string{"Telescope"}},
+		{Name: "harpoon", Category: "navigation", Repo: "ThePrimeagen/harpoon", Enabled: true},
+		{Name: "oil", Repo: "stevearc/oil.nvim", Enabled: true},
+		{Name: "mine", Repo: "me/mine.nvim", Enabled: true},
+	}
+
+	result, err := h.Push(ctx, local, PushOptions{Branch: "nvp/telescope", DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 1)
+	change := result.Changes[0]
+	assert.Equal(t, "telescope", change.Plugin)
+	assert.Equal(t, "specs/core.yaml", change.File)
+	assert.Equal(t, 1, change.Added)
+	assert.Equal(t, 0, change.Removed)
+	assert.Contains(t, change.Diff, "+    cmd: Telescope\n")
+	assert.Len(t, result.Skipped, 2) // oil is Lua, mine is not in the source
+	assert.Contains(t, result.Description, "## Update telescope from nvp")
+	assert.Contains(t, result.Description, "```diff\n")
+	assert.Empty(t, result.Commit)
+
+	_, err = gitcmd.Run(ctx, remote, "rev-parse", "--verify", "--quiet", "refs/heads/nvp/telescope")
+	assert.Error(t, err, "a dry run pushes nothing")
+
+	result, err = h.Push(ctx, local, PushOptions{Branch: "nvp/telescope"})
+	require.NoError(t, err)
+	require.Len(t, result.Commit, 40)
+
+	pushed, err := gitcmd.Run(ctx, remote, "show", "nvp/telescope:specs/core.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, pushed, "# core plugins")
+	assert.Contains(t, pushed, "cmd: Telescope")
+	plugins, err := plugin.ParseYAMLMultiple([]byte(pushed))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"telescope", "harpoon"}, pluginNames(plugins))
+
+	message, err := gitcmd.Run(ctx, remote, "log", "-1", "--format=%s", "nvp/telescope")
+	require.NoError(t, err)
+	assert.Equal(t, "Update telescope from nvp\n", message)
+
+	_, err = h.Push(ctx, local, PushOptions{Branch: "--bad"})
+	assert.Error(t, err)
+}
+
+func TestCompareURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/corp/plugins/compare/nvp/x?expand=1", compareURL("git@github.com:corp/plugins.git", "nvp/x"))
+	assert.Equal(t, "https://github.com/corp/plugins/compare/nvp/x?expand=1", compareURL("https://github.com/corp/plugins", "nvp/x"))
+	assert.Empty(t, compareURL("https://gitlab.com/corp/plugins.git", "nvp/x"))
+}
@@ -0,0 +1,168 @@
+package keymaps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Uncategorized groups plugins without a category in a cheatsheet.
+const Uncategorized = "uncategorized"
+
+// CheatsheetCommand is the Neovim user command the generated Lua defines.
+const CheatsheetCommand = "NvpCheatsheet"
+
+// Section is one category of a cheatsheet.
+type Section struct {
+	Category string    `json:"category"`
+	Bindings []Binding `json:"bindings"`
+}
+
+// Cheatsheet lists every binding of a plugin set, grouped by the plugins'
+// categories.
+type Cheatsheet struct {
+	Sections []Section `json:"sections"`
+}
+
+// BuildCheatsheet groups the plugins' bindings by category. Sections are
+// sorted by category (uncategorized last) and bindings by plugin, key, and
+// mode. A binding without a description takes its plugin's.
+func BuildCheatsheet(plugins []*plugin.Plugin) Cheatsheet {
+	category := make(map[string]string, len(plugins))
+	description := make(map[string]string, len(plugins))
+	for _, p := range plugins {
+		category[p.Name] = p.Category
+		description[p.Name] = p.Description
+	}
+
+	byCategory := map[string][]Binding{}
+	for _, b := range Bindings(plugins) {
+		c := category[b.Plugin]
+		if c == "" {
+			c = Uncategorized
+		}
+		if b.Desc == "" {
+			b.Desc = description[b.Plugin]
+		}
+		byCategory[c] = append(byCategory[c], b)
+	}
+
+	var sheet Cheatsheet
+	for c, bindings := range byCategory {
+		sort.SliceStable(bindings, func(i, j int) bool {
+			a, b := bindings[i], bindings[j]
+			if a.Plugin != b.Plugin {
+				return a.Plugin < b.Plugin
+			}
+			if na, nb := Normalize(a.Key), Normalize(b.Key); na != nb {
+				return na < nb
+			}
+			return a.Mode < b.Mode
+		})
+		sheet.Sections = append(sheet.Sections, Section{Category: c, Bindings: bindings})
+	}
+	sort.Slice(sheet.Sections, func(i, j int) bool {
+		a, b := sheet.Sections[i].Category, sheet.Sections[j].Category
+		if (a == Uncategorized) != (b == Uncategorized) {
+			return b == Uncategorized
+		}
+		return a < b
+	})
+	return sheet
+}
+
+// Count returns the number of bindings in the cheatsheet.
+func (s Cheatsheet) Count() int {
+	n := 0
+	for _, sec := range s.Sections {
+		n += len(sec.Bindings)
+	}
+	return n
+}
+
+// Markdown renders the cheatsheet as a markdown document with one table
+// per category.
+func (s Cheatsheet) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Keymap Cheatsheet\n")
+	if len(s.Sections) == 0 {
+		b.WriteString("\nNo plugin keymaps.\n")
+		return b.String()
+	}
+	for _, sec := range s.Sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", title(sec.Category))
+		b.WriteString("| Key | Mode | Description | Plugin |\n")
+		b.WriteString("|-----|------|-------------|--------|\n")
+		for _, bd := range sec.Bindings {
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s |\n", cell(bd.Key), bd.Mode, cell(bd.Desc), bd.Plugin)
+		}
+	}
+	return b.String()
+}
+
+// Lua renders a lazy.nvim spec file that defines :NvpCheatsheet, which
+// shows the cheatsheet in a floating window (q or <Esc> closes it). The
+// spec itself is empty, so lazy.nvim loads the file without adding a plugin.
+func (s Cheatsheet) Lua() string {
+	text := s.Markdown()
+	level := 0
+	for strings.Contains(text, "]"+strings.Repeat("=", level)+"]") {
+		level++
+	}
+	eq := strings.Repeat("=", level)
+
+	var b strings.Builder
+	b.WriteString("-- Generated by nvp: keymap cheatsheet of the enabled plugins.\n")
+	fmt.Fprintf(&b, "-- Open it with :%s.\n", CheatsheetCommand)
+	fmt.Fprintf(&b, "local cheatsheet = [%s[\n%s]%s]\n\n", eq, text, eq)
+	fmt.Fprintf(&b, `vim.api.nvim_create_user_command(%q, function()
+  local lines = vim.split(cheatsheet, "\n", { trimempty = true })
+  local width = 0
+  for _, line in ipairs(lines) do
+    width = math.max(width, vim.fn.strdisplaywidth(line))
+  end
+  width = math.min(width + 2, math.floor(vim.o.columns * 0.9))
+  local height = math.min(#lines, math.floor(vim.o.lines * 0.8))
+
+  local buf = vim.api.nvim_create_buf(false, true)
+  vim.api.nvim_buf_set_lines(buf, 0, -1, false, lines)
+  vim.bo[buf].modifiable = false
+  vim.bo[buf].bufhidden = "wipe"
+  vim.bo[buf].filetype = "markdown"
+
+  local win = vim.api.nvim_open_win(buf, true, {
+    relative = "editor",
+    width = width,
+    height = height,
+    row = math.floor((vim.o.lines - height) / 2),
+    col = math.floor((vim.o.columns - width) / 2),
+    style = "minimal",
+    border = "rounded",
+    title = " Keymaps ",
+    title_pos = "center",
+  })
+  for _, key in ipairs({ "q", "<Esc>" }) do
+    vim.keymap.set("n", key, function()
+      vim.api.nvim_win_close(win, true)
+    end, { buffer = buf, nowait = true })
+  end
+end, { desc = "Show the nvp keymap cheatsheet" })
+
+return {}
+`, CheatsheetCommand)
+	return b.String()
+}
+
+// cell escapes a markdown table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,71 @@
+package keymaps
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cheatsheetPlugins() []*plugin.Plugin {
+	return []*plugin.Plugin{
+		{
+			Name:     "telescope",
+			Category: "navigation",
+			Keys: []plugin.Keymap{
+				{Key: "<leader>fg", Desc: "Live grep"},
+				{Key: "<leader>ff", Desc: "Find files"},
+			},
+		},
+		{
+			Name:        "harpoon",
+			Category:    "navigation",
+			Description: "Quick file marks",
+			Keymaps:     []plugin.Keymap{{Key: "<leader>a", Mode: []string{"n", "v"}}},
+		},
+		{Name: "misc", Keys: []plugin.Keymap{{Key: "<leader>|", Desc: "Split | vertically"}}},
+		{Name: "gitsigns", Category: "git", Keys: []plugin.Keymap{{Key: "]c", Desc: "Next hunk"}}},
+	}
+}
+
+func TestBuildCheatsheet(t *testing.T) {
+	sheet := BuildCheatsheet(cheatsheetPlugins())
+
+	require.Len(t, sheet.Sections, 3)
+	assert.Equal(t, "git", sheet.Sections[0].Category)
+	assert.Equal(t, "navigation", sheet.Sections[1].Category)
+	assert.Equal(t, Uncategorized, sheet.Sections[2].Category)
+	assert.Equal(t, 6, sheet.Count())
+
+	nav := sheet.Sections[1].Bindings
+	assert.Equal(t, []Binding{
+		{Plugin: "harpoon", Mode: "n", Key: "<leader>a", Desc: "Quick file marks"},
+		{Plugin: "harpoon", Mode: "v", Key: "<leader>a", Desc: "Quick file marks"},
+		{Plugin: "telescope", Mode: "n", Key: "<leader>ff", Desc: "Find files"},
+		{Plugin: "telescope", Mode: "n", Key: "<leader>fg", Desc: "Live grep"},
+	}, nav)
+}
+
+func TestCheatsheetMarkdown(t *testing.T) {
+	md := BuildCheatsheet(cheatsheetPlugins()).Markdown()
+
+	assert.True(t, strings.HasPrefix(md, "# Keymap Cheatsheet\n"))
+	assert.Contains(t, md, "## Navigation\n")
+	assert.Contains(t, md, "| `<leader>ff` | n | Find files | telescope |")
+	assert.Contains(t, md, "| `<leader>\\|` | n | Split \\| vertically | misc |")
+
+	assert.Contains(t, Cheatsheet{}.Markdown(), "No plugin keymaps.")
+}
+
+func TestCheatsheetLua(t *testing.T) {
+	lua := BuildCheatsheet(cheatsheetPlugins()).Lua()
+	assert.Contains(t, lua, "local cheatsheet = [[\n# Keymap Cheatsheet")
+	assert.Contains(t, lua, `vim.api.nvim_create_user_command("NvpCheatsheet"`)
+	assert.True(t, strings.HasSuffix(lua, "return {}\n"))
+
+	// The long string's level grows until the text cannot close it early
+	sheet := BuildCheatsheet([]*plugin.Plugin{{Name: "x", Keys: []plugin.Keymap{{Key: "]]"}}}})
+	assert.Contains(t, sheet.Lua(), "local cheatsheet = [=[\n")
+}
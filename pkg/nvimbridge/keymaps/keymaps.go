@@ -0,0 +1,255 @@
+// Package keymaps finds key mapping collisions in a set of nvp plugins.
+//
+// Every plugin's lazy-load keys and keymaps are merged per mode. Two plugins
+// mapping the same left-hand side in the same mode is a conflict: only one
+// mapping survives in Neovim, depending on load order. For each conflict,
+// keys that are still free under the same prefix are suggested.
+package keymaps
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// DefaultMode is the mode of a mapping that does not name one.
+const DefaultMode = "n"
+
+// maxAlternatives caps the suggestions offered per conflicting binding.
+const maxAlternatives = 3
+
+// Binding is one plugin's mapping of a key in one mode.
+type Binding struct {
+	Plugin string `json:"plugin"`
+	Mode   string `json:"mode"`
+	Key    string `json:"key"`
+	Desc   string `json:"desc,omitempty"`
+}
+
+// Alternative suggests free keys for a plugin whose binding conflicts.
+type Alternative struct {
+	Plugin string   `json:"plugin"`
+	Keys   []string `json:"keys"`
+}
+
+// Conflict is a mode and key mapped by more than one plugin. Bindings are
+// ordered by plugin name; the first plugin keeps the key and the others get
+// Alternatives.
+type Conflict struct {
+	Mode         string        `json:"mode"`
+	Key          string        `json:"key"`
+	Bindings     []Binding     `json:"bindings"`
+	Alternatives []Alternative `json:"alternatives,omitempty"`
+}
+
+// Plugins returns the names of the plugins in the conflict.
+func (c Conflict) Plugins() []string {
+	names := make([]string, len(c.Bindings))
+	for i, b := range c.Bindings {
+		names[i] = b.Plugin
+	}
+	return names
+}
+
+// Bindings flattens the keys and keymaps of plugins into one binding per
+// mode, in plugin order. A plugin mapping the same key twice in a mode (for
+// example as a lazy-load key and a keymap) yields one binding.
+func Bindings(plugins []*plugin.Plugin) []Binding {
+	var bindings []Binding
+	for _, p := range plugins {
+		seen := map[string]bool{}
+		for _, km := range append(append([]plugin.Keymap{}, p.Keys...), p.Keymaps...) {
+			if strings.TrimSpace(km.Key) == "" {
+				continue
+			}
+			modes := km.Mode
+			if len(modes) == 0 {
+				modes = []string{DefaultMode}
+			}
+			for _, mode := range modes {
+				if mode == "" {
+					mode = DefaultMode
+				}
+				id := mode + " " + Normalize(km.Key)
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				bindings = append(bindings, Binding{Plugin: p.Name, Mode: mode, Key: km.Key, Desc: km.Desc})
+			}
+		}
+	}
+	return bindings
+}
+
+// Check returns the conflicts among the plugins' mappings, sorted by mode
+// and key. The result does not depend on the order of plugins.
+func Check(plugins []*plugin.Plugin) []Conflict {
+	bindings := Bindings(plugins)
+
+	byKey := map[string][]Binding{}
+	var order []string
+	used := map[string]map[string]bool{} // mode -> normalized keys
+	for _, b := range bindings {
+		norm := Normalize(b.Key)
+		id := b.Mode + " " + norm
+		if _, ok := byKey[id]; !ok {
+			order = append(order, id)
+		}
+		byKey[id] = append(byKey[id], b)
+		if used[b.Mode] == nil {
+			used[b.Mode] = map[string]bool{}
+		}
+		used[b.Mode][norm] = true
+	}
+
+	// ids are "mode key", so this orders conflicts by mode and key
+	sort.Strings(order)
+	var conflicts []Conflict
+	for _, id := range order {
+		group := byKey[id]
+		if len(group) < 2 {
+			continue
+		}
+		// plugin sets are not always ordered, so rank by name to keep the
+		// result stable
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Plugin < group[j].Plugin })
+		c := Conflict{Mode: group[0].Mode, Key: group[0].Key, Bindings: group}
+		for _, b := range group[1:] {
+			keys := alternatives(b, used[b.Mode])
+			if len(keys) == 0 {
+				continue
+			}
+			// reserve the first suggestion so two plugins are not offered
+			// the same key
+			used[b.Mode][Normalize(keys[0])] = true
+			c.Alternatives = append(c.Alternatives, Alternative{Plugin: b.Plugin, Keys: keys})
+		}
+		conflicts = append(conflicts, c)
+	}
+	return conflicts
+}
+
+// Normalize returns the canonical form of a left-hand side. Key codes in
+// angle brackets are case-insensitive in Neovim, so <Leader>, <leader>, and
+// <LEADER> are the same key, as are <C-a> and <c-A>.
+func Normalize(lhs string) string {
+	var b strings.Builder
+	for _, unit := range split(lhs) {
+		if isKeyCode(unit) {
+			unit = strings.ToLower(unit)
+		}
+		b.WriteString(unit)
+	}
+	return b.String()
+}
+
+// split breaks a left-hand side into keys: <...> key codes and single
+// characters.
+func split(lhs string) []string {
+	var units []string
+	for len(lhs) > 0 {
+		if lhs[0] == '<' {
+			if end := strings.IndexByte(lhs, '>'); end > 1 {
+				units = append(units, lhs[:end+1])
+				lhs = lhs[end+1:]
+				continue
+			}
+		}
+		r := []rune(lhs)[0]
+		units = append(units, string(r))
+		lhs = lhs[len(string(r)):]
+	}
+	return units
+}
+
+func isKeyCode(unit string) bool {
+	return len(unit) > 2 && unit[0] == '<' && unit[len(unit)-1] == '>'
+}
+
+// alternatives returns up to maxAlternatives keys that keep b's prefix and
+// change its last key, and that neither collide with a used key nor shadow
+// or are shadowed by one (Neovim would wait for more input). Letters from
+// the plugin name and description are tried first, so suggestions stay
+// mnemonic. A single-key mapping has no prefix and gets no suggestions.
+func alternatives(b Binding, used map[string]bool) []string {
+	units := split(b.Key)
+	if len(units) < 2 && !hasModifier(units) {
+		return nil
+	}
+	prefix := strings.Join(units[:len(units)-1], "")
+	last := units[len(units)-1]
+
+	var keys []string
+	tried := map[string]bool{}
+	for _, r := range candidateRunes(b) {
+		key := prefix + replaceLast(last, r)
+		norm := Normalize(key)
+		if tried[norm] {
+			continue
+		}
+		tried[norm] = true
+		if !free(norm, used) {
+			continue
+		}
+		keys = append(keys, key)
+		if len(keys) == maxAlternatives {
+			break
+		}
+	}
+	return keys
+}
+
+// hasModifier reports whether a single-key mapping is a modified key such as
+// <C-n>, whose letter can be swapped.
+func hasModifier(units []string) bool {
+	return len(units) == 1 && modifierPrefix(units[0]) != ""
+}
+
+// modifierPrefix returns "<C-" for "<C-n>", or "" when unit is not a
+// modified single character.
+func modifierPrefix(unit string) string {
+	if !isKeyCode(unit) {
+		return ""
+	}
+	dash := strings.LastIndexByte(unit, '-')
+	if dash < 0 || len([]rune(unit[dash+1:len(unit)-1])) != 1 {
+		return ""
+	}
+	return unit[:dash+1]
+}
+
+// replaceLast swaps the character of the last key for r, keeping any
+// modifiers.
+func replaceLast(last string, r rune) string {
+	if mods := modifierPrefix(last); mods != "" {
+		return mods + string(r) + ">"
+	}
+	return string(r)
+}
+
+// candidateRunes lists the letters to try for b: those of the plugin name
+// and description first, then the alphabet and digits.
+func candidateRunes(b Binding) []rune {
+	var runes []rune
+	for _, r := range strings.ToLower(b.Plugin + b.Desc + "abcdefghijklmnopqrstuvwxyz0123456789") {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// free reports whether norm can be mapped without clashing with used keys.
+func free(norm string, used map[string]bool) bool {
+	if used[norm] {
+		return false
+	}
+	for u := range used {
+		if strings.HasPrefix(norm, u) || strings.HasPrefix(u, norm) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+package keymaps
+
+import (
+	"testing"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		lhs  string
+		want string
+	}{
+		{"<leader>ff", "<leader>ff"},
+		{"<Leader>ff", "<leader>ff"},
+		{"<LEADER>fF", "<leader>fF"},
+		{"<C-n>", "<c-n>"},
+		{"gd", "gd"},
+		{"<", "<"},
+		{"a<b", "a<b"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Normalize(tt.lhs), tt.lhs)
+	}
+}
+
+func TestBindings_ModesAndDuplicates(t *testing.T) {
+	plugins := []*plugin.Plugin{{
+		Name: "telescope",
+		Keys: []plugin.Keymap{{Key: "<leader>ff"}},
+		Keymaps: []plugin.Keymap{
+			{Key: "<Leader>ff", Desc: "Find files"},
+			{Key: "<leader>fg", Mode: []string{"n", "v"}},
+		},
+	}}
+
+	got := Bindings(plugins)
+	assert.Equal(t, []Binding{
+		{Plugin: "telescope", Mode: "n", Key: "<leader>ff"},
+		{Plugin: "telescope", Mode: "n", Key: "<leader>fg"},
+		{Plugin: "telescope", Mode: "v", Key: "<leader>fg"},
+	}, got)
+}
+
+func TestCheck(t *testing.T) {
+	plugins := []*plugin.Plugin{
+		{
+			Name:    "telescope",
+			Keymaps: []plugin.Keymap{{Key: "<leader>ff", Desc: "Find files"}, {Key: "<leader>fg"}},
+		},
+		{
+			Name:    "fzf-lua",
+			Keys:    []plugin.Keymap{{Key: "<Leader>ff", Desc: "Fzf files"}},
+			Keymaps: []plugin.Keymap{{Key: "<leader>fg", Mode: []string{"v"}}},
+		},
+		{
+			Name:    "harpoon",
+			Keymaps: []plugin.Keymap{{Key: "<leader>ff"}},
+		},
+	}
+
+	conflicts := Check(plugins)
+	require.Len(t, conflicts, 1, "the v-mode <leader>fg does not collide with the n-mode one")
+	c := conflicts[0]
+	assert.Equal(t, "n", c.Mode)
+	assert.Equal(t, "<Leader>ff", c.Key, "spelled as by the plugin that keeps it")
+	assert.Equal(t, []string{"fzf-lua", "harpoon", "telescope"}, c.Plugins())
+
+	require.Len(t, c.Alternatives, 2)
+	assert.Equal(t, "harpoon", c.Alternatives[0].Plugin)
+	assert.Equal(t, "<leader>fh", c.Alternatives[0].Keys[0], "letters of the plugin name come first")
+	assert.Equal(t, "telescope", c.Alternatives[1].Plugin)
+	assert.Equal(t, "<leader>ft", c.Alternatives[1].Keys[0])
+	for _, alt := range c.Alternatives {
+		assert.NotContains(t, alt.Keys, "<leader>fg", "suggestions must be free")
+	}
+}
+
+func TestCheck_IndependentOfPluginOrder(t *testing.T) {
+	a := &plugin.Plugin{Name: "a", Keymaps: []plugin.Keymap{{Key: "<leader>x"}, {Key: "<leader>y"}}}
+	b := &plugin.Plugin{Name: "b", Keymaps: []plugin.Keymap{{Key: "<leader>y"}, {Key: "<leader>x"}}}
+	assert.Equal(t, Check([]*plugin.Plugin{a, b}), Check([]*plugin.Plugin{b, a}))
+}
+
+func TestCheck_NoConflictWithinOnePlugin(t *testing.T) {
+	plugins := []*plugin.Plugin{{
+		Name:    "oil",
+		Keys:    []plugin.Keymap{{Key: "-"}},
+		Keymaps: []plugin.Keymap{{Key: "-", Desc: "Open parent directory"}},
+	}}
+	assert.Empty(t, Check(plugins))
+}
+
+func TestCheck_Alternatives(t *testing.T) {
+	t.Run("single key has none", func(t *testing.T) {
+		conflicts := Check([]*plugin.Plugin{
+			{Name: "leap", Keymaps: []plugin.Keymap{{Key: "s"}}},
+			{Name: "flash", Keymaps: []plugin.Keymap{{Key: "s"}}},
+		})
+		require.Len(t, conflicts, 1)
+		assert.Empty(t, conflicts[0].Alternatives)
+	})
+
+	t.Run("modified key keeps its modifier", func(t *testing.T) {
+		conflicts := Check([]*plugin.Plugin{
+			{Name: "cmp", Keymaps: []plugin.Keymap{{Key: "<C-n>", Mode: []string{"i"}}}},
+			{Name: "luasnip", Keymaps: []plugin.Keymap{{Key: "<c-n>", Mode: []string{"i"}}}},
+		})
+		require.Len(t, conflicts, 1)
+		require.Len(t, conflicts[0].Alternatives, 1)
+		assert.Equal(t, []string{"<c-l>", "<c-u>", "<c-a>"}, conflicts[0].Alternatives[0].Keys)
+	})
+
+	t.Run("skips keys that shadow a mapping", func(t *testing.T) {
+		conflicts := Check([]*plugin.Plugin{
+			{Name: "a", Keymaps: []plugin.Keymap{{Key: "<leader>x"}, {Key: "<leader>bx"}}},
+			{Name: "b", Keymaps: []plugin.Keymap{{Key: "<leader>x"}}},
+		})
+		require.Len(t, conflicts, 1)
+		require.Len(t, conflicts[0].Alternatives, 1)
+		// "b" is taken as a prefix of <leader>bx
+		assert.Equal(t, []string{"<leader>a", "<leader>c", "<leader>d"}, conflicts[0].Alternatives[0].Keys)
+	})
+}
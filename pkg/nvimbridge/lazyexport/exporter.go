@@ -0,0 +1,259 @@
+// Package lazyexport writes nvp plugin definitions back out as a standalone,
+// LazyVim-compatible Neovim configuration repository.
+//
+// The exported tree has no dependency on nvp: it bootstraps lazy.nvim itself,
+// imports one idiomatic plugin spec file per plugin from lua/plugins/, and
+// carries an optional lazy-lock.json so the pinned commits travel with it.
+// This lets users leave nvp, or share a config with non-nvp users, without
+// lock-in.
+package lazyexport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Format identifies an export layout.
+type Format string
+
+const (
+	// FormatLazyVim produces a LazyVim starter-style layout
+	// (init.lua → lua/config/lazy.lua → lua/plugins/*.lua).
+	FormatLazyVim Format = "lazyvim"
+)
+
+// SupportedFormats returns the export formats understood by the Exporter.
+func SupportedFormats() []string {
+	return []string{string(FormatLazyVim)}
+}
+
+// Options controls an export run.
+type Options struct {
+	// OutputDir is the root of the generated config repository.
+	OutputDir string
+	// LockFile, if non-nil, pins commits in the generated specs and is
+	// written alongside them as lazy-lock.json.
+	LockFile *plugin.LockFile
+	// Force allows overwriting files that already exist in OutputDir.
+	Force bool
+}
+
+// File is a single generated file, with Path relative to the output directory.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// Result summarizes a completed export.
+type Result struct {
+	OutputDir string
+	Files     []string
+	Plugins   int
+}
+
+// Exporter renders plugins into a lazy.nvim config repository.
+type Exporter struct {
+	format Format
+}
+
+// New creates an Exporter for the given format.
+func New(format Format) (*Exporter, error) {
+	switch format {
+	case FormatLazyVim:
+		return &Exporter{format: format}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q (supported: %s)",
+			format, strings.Join(SupportedFormats(), ", "))
+	}
+}
+
+// Plan renders the full file set for the given plugins without touching disk.
+// Only enabled plugins are exported; files are returned in a stable order.
+func (e *Exporter) Plan(plugins []*plugin.Plugin, opts Options) ([]File, error) {
+	enabled := make([]*plugin.Plugin, 0, len(plugins))
+	for _, p := range plugins {
+		if p != nil && p.Enabled {
+			enabled = append(enabled, p)
+		}
+	}
+	sort.Slice(enabled, func(i, j int) bool {
+		return enabled[i].Name < enabled[j].Name
+	})
+
+	gen := plugin.NewGenerator()
+	if opts.LockFile != nil {
+		gen = plugin.NewGeneratorWithLock(opts.LockFile)
+	}
+
+	files := []File{
+		{Path: "init.lua", Content: []byte(initLua)},
+		{Path: filepath.Join("lua", "config", "lazy.lua"), Content: []byte(lazyBootstrapLua)},
+	}
+
+	seen := make(map[string]string, len(enabled))
+	for _, p := range enabled {
+		lua, err := gen.GenerateLua(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate spec for %s: %w", p.Name, err)
+		}
+
+		fileName := SpecFileName(p.Name)
+		if other, dup := seen[fileName]; dup {
+			return nil, fmt.Errorf("plugins %q and %q map to the same spec file %s", other, p.Name, fileName)
+		}
+		seen[fileName] = p.Name
+
+		files = append(files, File{
+			Path:    filepath.Join("lua", "plugins", fileName),
+			Content: []byte(specHeader(p) + lua),
+		})
+	}
+
+	if opts.LockFile != nil && len(opts.LockFile.Entries) > 0 {
+		data, err := opts.LockFile.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal lock file: %w", err)
+		}
+		files = append(files, File{Path: "lazy-lock.json", Content: data})
+	}
+
+	files = append(files, File{Path: "README.md", Content: []byte(renderReadme(enabled, opts.LockFile != nil))})
+	return files, nil
+}
+
+// Export renders the plugins and writes them under opts.OutputDir.
+// Existing files are only overwritten when opts.Force is set; the check is
+// performed for every file before anything is written.
+func (e *Exporter) Export(plugins []*plugin.Plugin, opts Options) (*Result, error) {
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+
+	files, err := e.Plan(plugins, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Force {
+		var existing []string
+		for _, f := range files {
+			if _, err := os.Stat(filepath.Join(opts.OutputDir, f.Path)); err == nil {
+				existing = append(existing, f.Path)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("refusing to overwrite existing files in %s: %s (use --force)",
+				opts.OutputDir, strings.Join(existing, ", "))
+		}
+	}
+
+	result := &Result{OutputDir: opts.OutputDir}
+	for _, f := range files {
+		dest := filepath.Join(opts.OutputDir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(dest, f.Content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+		result.Files = append(result.Files, f.Path)
+		if strings.HasPrefix(f.Path, filepath.Join("lua", "plugins")+string(filepath.Separator)) {
+			result.Plugins++
+		}
+	}
+
+	return result, nil
+}
+
+// SpecFileName returns the lua/plugins file name used for a plugin.
+// Characters that are awkward in Lua module paths are replaced with '-'.
+func SpecFileName(name string) string {
+	clean := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+	return strings.Trim(clean, "-") + ".lua"
+}
+
+// specHeader returns the comment block placed above each exported spec.
+func specHeader(p *plugin.Plugin) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- %s\n", p.Name)
+	if p.Description != "" {
+		fmt.Fprintf(&b, "-- %s\n", p.Description)
+	}
+	b.WriteString("-- Exported by nvp; safe to edit by hand.\n\n")
+	return b.String()
+}
+
+// renderReadme documents the exported repository and lists its plugins.
+func renderReadme(plugins []*plugin.Plugin, pinned bool) string {
+	var b strings.Builder
+	b.WriteString("# Neovim configuration\n\n")
+	b.WriteString("A [lazy.nvim](https://github.com/folke/lazy.nvim) configuration exported by `nvp export --format lazyvim`.\n")
+	b.WriteString("It has no dependency on nvp.\n\n")
+	b.WriteString("## Usage\n\n")
+	b.WriteString("```bash\n")
+	b.WriteString("git clone <this-repo> ~/.config/nvim\n")
+	b.WriteString("nvim\n")
+	b.WriteString("```\n\n")
+	b.WriteString("lazy.nvim is bootstrapped on first start from `lua/config/lazy.lua`.\n")
+	b.WriteString("Each file in `lua/plugins/` returns one plugin spec; add, edit, or remove files freely.\n")
+	if pinned {
+		b.WriteString("Plugin commits are pinned in `lazy-lock.json`; run `:Lazy restore` to install exactly those versions.\n")
+	}
+	b.WriteString("\n## Plugins\n\n")
+	if len(plugins) == 0 {
+		b.WriteString("_No plugins exported._\n")
+		return b.String()
+	}
+	b.WriteString("| Plugin | Repository | Category | Description |\n")
+	b.WriteString("|--------|------------|----------|-------------|\n")
+	for _, p := range plugins {
+		fmt.Fprintf(&b, "| %s | [%s](https://github.com/%s) | %s | %s |\n",
+			p.Name, p.Repo, p.Repo, p.Category, strings.ReplaceAll(p.Description, "|", "\\|"))
+	}
+	return b.String()
+}
+
+const initLua = `-- Entry point: bootstrap lazy.nvim and load plugin specs from lua/plugins/
+require("config.lazy")
+`
+
+const lazyBootstrapLua = `-- Bootstrap lazy.nvim
+local lazypath = vim.fn.stdpath("data") .. "/lazy/lazy.nvim"
+if not (vim.uv or vim.loop).fs_stat(lazypath) then
+  local lazyrepo = "https://github.com/folke/lazy.nvim.git"
+  local out = vim.fn.system({ "git", "clone", "--filter=blob:none", "--branch=stable", lazyrepo, lazypath })
+  if vim.v.shell_error ~= 0 then
+    vim.api.nvim_echo({
+      { "Failed to clone lazy.nvim:\n", "ErrorMsg" },
+      { out, "WarningMsg" },
+      { "\nPress any key to exit..." },
+    }, true, {})
+    vim.fn.getchar()
+    os.exit(1)
+  end
+end
+vim.opt.rtp:prepend(lazypath)
+
+vim.g.mapleader = " "
+vim.g.maplocalleader = "\\"
+
+require("lazy").setup({
+  spec = {
+    { import = "plugins" },
+  },
+  lockfile = vim.fn.stdpath("config") .. "/lazy-lock.json",
+  checker = { enabled = false },
+})
+`
@@ -0,0 +1,138 @@
+package lazyexport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+func testPlugins() []*plugin.Plugin {
+	return []*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Category: "navigation", Description: "Fuzzy finder", Enabled: true, Cmd: []string{"Telescope"}},
+		{Name: "treesitter", Repo: "nvim-treesitter/nvim-treesitter", Build: ":TSUpdate", Enabled: true},
+		{Name: "disabled-one", Repo: "someone/disabled.nvim", Enabled: false},
+	}
+}
+
+func planByPath(t *testing.T, files []File) map[string]string {
+	t.Helper()
+	out := make(map[string]string, len(files))
+	for _, f := range files {
+		out[filepath.ToSlash(f.Path)] = string(f.Content)
+	}
+	return out
+}
+
+func TestNew_UnsupportedFormat(t *testing.T) {
+	if _, err := New(Format("nvchad")); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+	if _, err := New(FormatLazyVim); err != nil {
+		t.Fatalf("New(lazyvim) error = %v", err)
+	}
+}
+
+func TestPlan_Layout(t *testing.T) {
+	e, _ := New(FormatLazyVim)
+	files, err := e.Plan(testPlugins(), Options{})
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	got := planByPath(t, files)
+
+	for _, want := range []string{"init.lua", "lua/config/lazy.lua", "lua/plugins/telescope.lua", "lua/plugins/treesitter.lua", "README.md"} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("missing file %s", want)
+		}
+	}
+	if _, ok := got["lua/plugins/disabled-one.lua"]; ok {
+		t.Error("disabled plugins must not be exported")
+	}
+	if _, ok := got["lazy-lock.json"]; ok {
+		t.Error("lazy-lock.json must not be written without a lock file")
+	}
+
+	spec := got["lua/plugins/telescope.lua"]
+	if !strings.Contains(spec, `"nvim-telescope/telescope.nvim"`) || !strings.Contains(spec, "return {") {
+		t.Errorf("telescope spec is not a lazy.nvim spec:\n%s", spec)
+	}
+	if !strings.Contains(got["init.lua"], `require("config.lazy")`) {
+		t.Error("init.lua must load config.lazy")
+	}
+	if !strings.Contains(got["lua/config/lazy.lua"], `{ import = "plugins" }`) {
+		t.Error("lazy.lua must import the plugins directory")
+	}
+	if !strings.Contains(got["README.md"], "nvim-telescope/telescope.nvim") {
+		t.Error("README must list exported plugins")
+	}
+}
+
+func TestPlan_WithLockFile(t *testing.T) {
+	lf := plugin.NewLockFile()
+	lf.Entries["telescope.nvim"] = plugin.LockEntry{Branch: "master", Commit: "abc123"}
+
+	e, _ := New(FormatLazyVim)
+	files, err := e.Plan(testPlugins(), Options{LockFile: lf})
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	got := planByPath(t, files)
+
+	if !strings.Contains(got["lazy-lock.json"], "abc123") {
+		t.Errorf("lazy-lock.json missing pinned commit:\n%s", got["lazy-lock.json"])
+	}
+	if !strings.Contains(got["lua/plugins/telescope.lua"], `commit = "abc123"`) {
+		t.Errorf("spec should be pinned to lock commit:\n%s", got["lua/plugins/telescope.lua"])
+	}
+}
+
+func TestPlan_DuplicateSpecFile(t *testing.T) {
+	e, _ := New(FormatLazyVim)
+	_, err := e.Plan([]*plugin.Plugin{
+		{Name: "foo.bar", Repo: "a/foo", Enabled: true},
+		{Name: "foo-bar", Repo: "b/foo", Enabled: true},
+	}, Options{})
+	if err == nil {
+		t.Fatal("expected error when two plugins map to the same file")
+	}
+}
+
+func TestExport_WritesAndRefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	e, _ := New(FormatLazyVim)
+
+	res, err := e.Export(testPlugins(), Options{OutputDir: dir})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if res.Plugins != 2 {
+		t.Errorf("Plugins = %d, want 2", res.Plugins)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "lua", "plugins", "treesitter.lua")); err != nil {
+		t.Errorf("expected treesitter spec on disk: %v", err)
+	}
+
+	if _, err := e.Export(testPlugins(), Options{OutputDir: dir}); err == nil {
+		t.Fatal("expected second export without Force to fail")
+	}
+	if _, err := e.Export(testPlugins(), Options{OutputDir: dir, Force: true}); err != nil {
+		t.Fatalf("Export(Force) error = %v", err)
+	}
+}
+
+func TestSpecFileName(t *testing.T) {
+	tests := map[string]string{
+		"telescope":     "telescope.lua",
+		"mini.ai":       "mini-ai.lua",
+		"which_key":     "which_key.lua",
+		"owner/plugin/": "owner-plugin.lua",
+	}
+	for in, want := range tests {
+		if got := SpecFileName(in); got != want {
+			t.Errorf("SpecFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+// Package lazyvimsource is nvp's LazyVim sync source. It reads the same
+// files and produces the same plugins as the MaestroNvim handler it
+// replaces, but fetches the plugin files in parallel through the shared
+// syncfetch pipeline instead of one after another, which makes a full
+// LazyVim sync several times faster.
+package lazyvimsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/syncfetch"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Name is the source name.
+const Name = "lazyvim"
+
+// DefaultBaseURL is the GitHub API URL of the LazyVim repository.
+const DefaultBaseURL = "https://api.github.com/repos/LazyVim/LazyVim"
+
+// Handler is a sync.SourceHandler for LazyVim.
+type Handler struct {
+	baseURL  string
+	pipeline func() *syncfetch.Pipeline
+
+	// version is the release (or short commit) the last listing read.
+	version string
+}
+
+// New returns a handler reading the repository at baseURL (a GitHub API
+// repository URL) through pipeline; a nil pipeline uses syncfetch.Default.
+func New(baseURL string, pipeline *syncfetch.Pipeline) *Handler {
+	h := &Handler{baseURL: baseURL, pipeline: syncfetch.Default}
+	if pipeline != nil {
+		h.pipeline = func() *syncfetch.Pipeline { return pipeline }
+	}
+	return h
+}
+
+// Name returns the source name.
+func (h *Handler) Name() string { return Name }
+
+// Description describes the source.
+func (h *Handler) Description() string {
+	return "LazyVim - A Neovim config for lazy people"
+}
+
+// Validate checks the repository is reachable.
+func (h *Handler) Validate(ctx context.Context) error {
+	if _, err := h.pipeline().Get(ctx, h.baseURL); err != nil {
+		return fmt.Errorf("failed to access LazyVim repository: %w", err)
+	}
+	return nil
+}
+
+// Revision returns the LazyVim version the last listing read.
+func (h *Handler) Revision() (version, commit string) {
+	return h.version, ""
+}
+
+// content is a file in a GitHub contents API listing.
+type content struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	DownloadURL string `json:"download_url"`
+}
+
+// ListAvailable lists the plugins of LazyVim's core plugin files. The
+// version lookup, the file listing, and the files themselves are fetched
+// concurrently.
+func (h *Handler) ListAvailable(ctx context.Context) ([]sync.AvailablePlugin, error) {
+	p := h.pipeline()
+
+	versionDone := make(chan string, 1)
+	go func() { versionDone <- h.fetchVersion(ctx, p) }()
+
+	var files []content
+	data, err := p.Get(ctx, h.baseURL+"/contents/lua/lazyvim/plugins")
+	if err == nil {
+		err = json.Unmarshal(data, &files)
+	}
+	h.version = <-versionDone
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin files: %w", err)
+	}
+
+	var luaFiles []content
+	for _, f := range files {
+		if strings.HasSuffix(f.Name, ".lua") && f.DownloadURL != "" {
+			luaFiles = append(luaFiles, f)
+		}
+	}
+	parsed, errs := syncfetch.Map(ctx, p, luaFiles, func(ctx context.Context, f content) ([]sync.AvailablePlugin, error) {
+		data, err := p.Get(ctx, f.DownloadURL)
+		if err != nil {
+			return nil, err
+		}
+		return h.parseLua(string(data), f.Name), nil
+	})
+
+	var available []sync.AvailablePlugin
+	for i, plugins := range parsed {
+		if errs[i] != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// A file that cannot be fetched leaves out its plugins
+			slog.Warn("skipping LazyVim plugin file", "file", luaFiles[i].Name, "error", errs[i])
+			continue
+		}
+		available = append(available, plugins...)
+	}
+	return available, nil
+}
+
+// fetchVersion returns the latest release tag, the short commit of main
+// when there are no releases, or "unknown".
+func (h *Handler) fetchVersion(ctx context.Context, p *syncfetch.Pipeline) string {
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	data, err := p.Get(ctx, h.baseURL+"/releases/latest")
+	if err == nil && json.Unmarshal(data, &release) == nil && release.TagName != "" {
+		return release.TagName
+	}
+	var se *syncfetch.StatusError
+	if errors.As(err, &se) && se.StatusCode == http.StatusNotFound {
+		var branch struct {
+			Commit struct {
+				SHA string `json:"sha"`
+			} `json:"commit"`
+		}
+		data, err := p.Get(ctx, h.baseURL+"/branches/main")
+		if err == nil && json.Unmarshal(data, &branch) == nil && len(branch.Commit.SHA) >= 7 {
+			return branch.Commit.SHA[:7]
+		}
+	}
+	return "unknown"
+}
+
+// Sync writes the plugins matching the options' filters into the target
+// directory, then creates the source's package from them.
+func (h *Handler) Sync(ctx context.Context, options sync.SyncOptions) (*sync.SyncResult, error) {
+	result := &sync.SyncResult{SourceName: Name}
+
+	available, err := h.ListAvailable(ctx)
+	if err != nil {
+		result.AddError(fmt.Errorf("failed to list available plugins: %w", err))
+		return result, nil
+	}
+	result.TotalAvailable = len(available)
+
+	var synced []string
+	for _, a := range available {
+		if !options.MatchesAvailablePlugin(a) {
+			continue
+		}
+		if !options.DryRun && options.TargetDir != "" {
+			if err := os.MkdirAll(options.TargetDir, 0755); err != nil {
+				result.AddError(fmt.Errorf("failed to create target directory: %w", err))
+				continue
+			}
+			data, err := yaml.Marshal(pluginYAML(a))
+			if err != nil {
+				result.AddError(fmt.Errorf("failed to serialize plugin %s: %w", a.Name, err))
+				continue
+			}
+			if err := os.WriteFile(filepath.Join(options.TargetDir, a.Name+".yaml"), data, 0644); err != nil {
+				result.AddError(fmt.Errorf("failed to write plugin %s: %w", a.Name, err))
+				continue
+			}
+		}
+		result.AddPluginCreated(a.Name)
+		synced = append(synced, a.Name)
+	}
+
+	if options.PackageCreator != nil && len(synced) > 0 {
+		if options.DryRun {
+			result.AddPackageCreated(Name)
+		} else if err := options.PackageCreator.CreatePackage(Name, synced); err != nil {
+			result.AddError(fmt.Errorf("failed to create package: %w", err))
+		} else {
+			result.AddPackageCreated(Name)
+		}
+	}
+	return result, nil
+}
+
+var (
+	pluginPattern = regexp.MustCompile(`\{\s*["']([^/]+/[^"']+)["'][^}]*\}`)
+	configPattern = regexp.MustCompile(`config\s*=\s*function\(\)[^}]*end`)
+	optsPattern   = regexp.MustCompile(`opts\s*=\s*\{[^}]*\}`)
+	depsPattern   = regexp.MustCompile(`dependencies\s*=\s*\{([^}]*)\}`)
+	repoPattern   = regexp.MustCompile(`["']([^/]+/[^"']+)["']`)
+)
+
+// parseLua extracts the plugin specs of a LazyVim plugin file. Like the
+// MaestroNvim handler, it matches the common { "owner/repo", ... } shape
+// rather than evaluating the Lua.
+func (h *Handler) parseLua(src, filename string) []sync.AvailablePlugin {
+	category := category(filename)
+	var plugins []sync.AvailablePlugin
+	for _, m := range pluginPattern.FindAllStringSubmatch(src, -1) {
+		repo := m[1]
+		a := sync.AvailablePlugin{
+			Name:        "lazyvim-" + pluginName(repo),
+			Description: "LazyVim plugin: " + repo,
+			Category:    category,
+			Repo:        repo,
+			SourceName:  Name,
+			Labels: map[string]string{
+				"source":       Name,
+				"category":     category,
+				"lazyvim-file": filename,
+			},
+		}
+		if h.version != "" {
+			a.Labels["lazyvim-version"] = h.version
+		}
+		if c := configPattern.FindString(m[0]); c != "" {
+			a.Config = c
+		} else {
+			a.Config = optsPattern.FindString(m[0])
+		}
+		if deps := depsPattern.FindStringSubmatch(m[0]); deps != nil {
+			for _, d := range repoPattern.FindAllStringSubmatch(deps[1], -1) {
+				a.Dependencies = append(a.Dependencies, d[1])
+			}
+		}
+		plugins = append(plugins, a)
+	}
+	return plugins
+}
+
+// category maps a LazyVim plugin file to an nvp category.
+func category(filename string) string {
+	switch name := strings.TrimSuffix(filename, ".lua"); name {
+	case "coding", "editor", "formatting", "linting", "ui":
+		return name
+	case "colorscheme":
+		return "theme"
+	case "treesitter":
+		return "syntax"
+	case "util":
+		return "utility"
+	default:
+		if strings.Contains(name, "lsp") {
+			return "lsp"
+		}
+		return "misc"
+	}
+}
+
+// pluginName derives a plugin name from owner/repo.
+func pluginName(repo string) string {
+	_, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return repo
+	}
+	name, _, _ = strings.Cut(name, "/")
+	name = strings.TrimSuffix(name, ".nvim")
+	name = strings.TrimSuffix(name, "-nvim")
+	name = strings.TrimSuffix(name, ".vim")
+	return strings.TrimPrefix(name, "nvim-")
+}
+
+// pluginYAML converts an available plugin to the YAML nvp stores. LazyVim
+// plugins are lazy-loaded.
+func pluginYAML(a sync.AvailablePlugin) *plugin.PluginYAML {
+	py := plugin.NewPluginYAML(a.Name, a.Repo)
+	py.Metadata.Description = a.Description
+	py.Metadata.Category = a.Category
+	py.Metadata.Labels = make(map[string]string, len(a.Labels))
+	for k, v := range a.Labels {
+		py.Metadata.Labels[k] = v
+	}
+	py.Spec.Config = a.Config
+	for _, dep := range a.Dependencies {
+		py.Spec.Dependencies = append(py.Spec.Dependencies, plugin.DependencyYAML{Repo: dep})
+	}
+	py.Spec.Lazy = true
+	return py
+}
+
+// Register replaces the lazyvim registration in registry, keeping its
+// source info, with this handler.
+func Register(registry *sync.SourceRegistry) error {
+	info := sync.SourceInfo{
+		Name:        Name,
+		Description: New(DefaultBaseURL, nil).Description(),
+		URL:         "https://github.com/LazyVim/LazyVim",
+		Type:        string(sync.SourceTypeGitHub),
+	}
+	if existing, err := registry.GetSourceInfo(Name); err == nil {
+		info = *existing
+	}
+	if registry.IsRegistered(Name) {
+		if err := registry.Unregister(Name); err != nil {
+			return err
+		}
+	}
+	return registry.Register(sync.HandlerRegistration{
+		Name:       Name,
+		Info:       info,
+		CreateFunc: func() sync.SourceHandler { return New(DefaultBaseURL, nil) },
+	})
+}
@@ -0,0 +1,127 @@
+package lazyvimsource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"devopsmaestro/pkg/nvimbridge/syncfetch"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editorLua = `return {
+  { "nvim-telescope/telescope.nvim", cmd = "Telescope", dependencies = { "nvim-lua/plenary.nvim" } },
+  { "folke/flash.nvim", opts = { modes = {} } },
+}
+`
+
+const uiLua = `return {
+  { "folke/noice.nvim", config = function() require("noice").setup() end },
+}
+`
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	var srv *httptest.Server
+	mux.HandleFunc("/repo", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/repo/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name": "v15.1.0"}`))
+	})
+	mux.HandleFunc("/repo/contents/lua/lazyvim/plugins", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]map[string]string{
+			{"name": "editor.lua", "type": "file", "download_url": srv.URL + "/raw/editor.lua"},
+			{"name": "ui.lua", "type": "file", "download_url": srv.URL + "/raw/ui.lua"},
+			{"name": "broken.lua", "type": "file", "download_url": srv.URL + "/raw/broken.lua"},
+			{"name": "extras", "type": "dir"},
+		})
+	})
+	mux.HandleFunc("/raw/editor.lua", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(editorLua)) })
+	mux.HandleFunc("/raw/ui.lua", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(uiLua)) })
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func names(available []sync.AvailablePlugin) []string {
+	var out []string
+	for _, a := range available {
+		out = append(out, a.Name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestListAvailable(t *testing.T) {
+	srv := newServer(t)
+	h := New(srv.URL+"/repo", syncfetch.New(syncfetch.Options{Workers: 2, HostRate: -1, Retries: -1}))
+	ctx := context.Background()
+
+	require.NoError(t, h.Validate(ctx))
+	available, err := h.ListAvailable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lazyvim-flash", "lazyvim-noice", "lazyvim-telescope"}, names(available))
+
+	byName := map[string]sync.AvailablePlugin{}
+	for _, a := range available {
+		byName[a.Name] = a
+	}
+	telescope := byName["lazyvim-telescope"]
+	assert.Equal(t, "editor", telescope.Category)
+	assert.Equal(t, []string{"nvim-lua/plenary.nvim"}, telescope.Dependencies)
+	assert.Equal(t, map[string]string{
+		"source": "lazyvim", "category": "editor", "lazyvim-file": "editor.lua", "lazyvim-version": "v15.1.0",
+	}, telescope.Labels)
+	assert.Equal(t, "opts = { modes = {}", byName["lazyvim-flash"].Config)
+	assert.Contains(t, byName["lazyvim-noice"].Config, "config = function()")
+
+	version, _ := h.Revision()
+	assert.Equal(t, "v15.1.0", version)
+}
+
+func TestSync(t *testing.T) {
+	srv := newServer(t)
+	h := New(srv.URL+"/repo", syncfetch.New(syncfetch.Options{HostRate: -1, Retries: -1}))
+	target := t.TempDir()
+
+	result, err := h.Sync(context.Background(), sync.SyncOptions{
+		TargetDir: target,
+		Filters:   map[string]string{"category": "editor"},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"lazyvim-telescope", "lazyvim-flash"}, result.PluginsCreated)
+	assert.Equal(t, 3, result.TotalAvailable)
+
+	data, err := os.ReadFile(filepath.Join(target, "lazyvim-telescope.yaml"))
+	require.NoError(t, err)
+	p, err := plugin.ParseYAML(data)
+	require.NoError(t, err)
+	assert.Equal(t, "nvim-telescope/telescope.nvim", p.Repo)
+	assert.True(t, p.Lazy)
+}
+
+func TestCategoryAndName(t *testing.T) {
+	assert.Equal(t, "theme", category("colorscheme.lua"))
+	assert.Equal(t, "lsp", category("lsp.lua"))
+	assert.Equal(t, "misc", category("init.lua"))
+	assert.Equal(t, "telescope", pluginName("nvim-telescope/telescope.nvim"))
+	assert.Equal(t, "lspconfig", pluginName("neovim/nvim-lspconfig"))
+	assert.Equal(t, "fugitive", pluginName("tpope/fugitive.vim"))
+}
+
+func TestRegister(t *testing.T) {
+	registry := sync.NewSourceRegistry()
+	require.NoError(t, Register(registry))
+	require.NoError(t, Register(registry))
+	reg, ok := registry.GetRegistration(Name)
+	require.True(t, ok)
+	assert.IsType(t, &Handler{}, reg.CreateFunc())
+}
@@ -0,0 +1,555 @@
+// Package luaspec converts existing lazy.nvim plugin spec files into nvp
+// plugin definitions.
+//
+// Spec files are read with a constrained Lua parser: literals, tables, and
+// functions are decoded, and any other expression is kept as source text.
+// Functions become config/init/build code and key actions; opts tables that
+// cannot be represented as YAML data are kept as raw Lua. Spec fields nvp
+// has no equivalent for are dropped with a warning.
+package luaspec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Warning is a construct that could not be translated.
+type Warning struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Plugin  string `json:"plugin,omitempty"`
+	Message string `json:"message"`
+}
+
+func (w Warning) String() string {
+	loc := fmt.Sprintf("%s:%d", w.File, w.Line)
+	if w.Plugin != "" {
+		return fmt.Sprintf("%s: %s: %s", loc, w.Plugin, w.Message)
+	}
+	return fmt.Sprintf("%s: %s", loc, w.Message)
+}
+
+// Result is the outcome of a conversion.
+type Result struct {
+	Plugins  []*plugin.Plugin
+	Warnings []Warning
+}
+
+// Converter turns spec files into plugins.
+type Converter struct {
+	// NameFor returns the nvp plugin name for a repo without an explicit
+	// name; nil uses DefaultName.
+	NameFor func(repo string) string
+}
+
+// unsupportedFields are lazy.nvim spec fields nvp cannot express.
+var unsupportedFields = map[string]string{
+	"cond":        "conditional loading",
+	"main":        "main module override",
+	"module":      "module-based lazy loading",
+	"submodules":  "submodule control",
+	"pin":         "update pinning (use nvp lock)",
+	"dev":         "local development checkouts",
+	"optional":    "optional specs",
+	"specs":       "nested specs",
+	"opts_extend": "opts list merging",
+	"import":      "spec imports",
+}
+
+// ConvertDir converts every .lua file under dir, in path order.
+func (c *Converter) ConvertDir(dir string) (*Result, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".lua") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .lua files found in %s", dir)
+	}
+	sort.Strings(files)
+
+	result := &Result{}
+	seen := make(map[string]bool)
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		rel, _ := filepath.Rel(dir, path)
+		fileResult := c.Convert(string(data), rel)
+		result.Warnings = append(result.Warnings, fileResult.Warnings...)
+		for _, p := range fileResult.Plugins {
+			if seen[p.Name] {
+				// lazy.nvim merges repeated specs; keep the first and say so.
+				result.Warnings = append(result.Warnings, Warning{File: rel, Plugin: p.Name,
+					Message: "duplicate spec for the same plugin; only the first was imported"})
+				continue
+			}
+			seen[p.Name] = true
+			result.Plugins = append(result.Plugins, p)
+		}
+	}
+	return result, nil
+}
+
+// Convert converts the spec returned by one Lua file.
+func (c *Converter) Convert(src, file string) *Result {
+	conv := &conversion{Converter: c, file: file, result: &Result{}}
+	v, err := Parse(src)
+	if err != nil {
+		conv.warn(0, "", "could not parse file: %v", err)
+		return conv.result
+	}
+	conv.specList(v)
+	return conv.result
+}
+
+// conversion holds the state of converting one file.
+type conversion struct {
+	*Converter
+	file   string
+	result *Result
+}
+
+func (c *conversion) warn(line int, pluginName, format string, args ...interface{}) {
+	c.result.Warnings = append(c.result.Warnings, Warning{File: c.file, Line: line, Plugin: pluginName, Message: fmt.Sprintf(format, args...)})
+}
+
+// specList handles a value that is a single spec or a list of specs.
+func (c *conversion) specList(v *Value) {
+	switch v.Kind {
+	case KindString:
+		c.spec(&Value{Kind: KindTable, Table: &Table{Items: []*Value{v}}, Line: v.Line})
+	case KindTable:
+		if isSpec(v.Table) {
+			c.spec(v)
+			return
+		}
+		for _, f := range v.Table.Fields {
+			c.warn(f.Value.Line, "", "ignored field %q in spec list", f.Key)
+		}
+		for _, item := range v.Table.Items {
+			c.specList(item)
+		}
+	default:
+		c.warn(v.Line, "", "expected a spec table, got %s", describe(v))
+	}
+}
+
+// isSpec reports whether t is a plugin spec rather than a list of specs,
+// following lazy.nvim: a table with more than one positional item, or only
+// nested tables, is a list.
+func isSpec(t *Table) bool {
+	if len(t.Items) > 1 {
+		return false
+	}
+	if len(t.Items) == 1 {
+		return t.Items[0].Kind == KindString
+	}
+	for _, key := range []string{"url", "dir", "name", "import"} {
+		if t.Get(key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *conversion) spec(v *Value) {
+	t := v.Table
+	var repo string
+	if len(t.Items) > 0 && t.Items[0].Kind == KindString {
+		repo = t.Items[0].Str
+	}
+	if url := t.Get("url"); url != nil && url.Kind == KindString {
+		repo = repoFromURL(url.Str)
+		if repo == "" {
+			c.warn(url.Line, "", "url %q is not a GitHub repository; spec skipped", url.Str)
+			return
+		}
+	}
+	if imp := t.Get("import"); imp != nil && repo == "" {
+		c.warn(imp.Line, "", "import of %s is not followed; import that directory separately", imp.Source)
+		return
+	}
+	if dir := t.Get("dir"); dir != nil && repo == "" {
+		c.warn(dir.Line, "", "local plugin (dir = %s) is not supported; spec skipped", dir.Source)
+		return
+	}
+	if repo == "" {
+		c.warn(v.Line, "", "spec has no repository; skipped")
+		return
+	}
+
+	name := c.nameFor(repo)
+	if n := t.Get("name"); n != nil && n.Kind == KindString {
+		name = n.Str
+	}
+	p := plugin.NewPlugin(name, repo)
+	if !strings.Contains(repo, "/") {
+		c.warn(v.Line, name, "%q is not an owner/name repository", repo)
+	}
+	for _, f := range t.Fields {
+		c.field(p, f)
+	}
+	for _, k := range t.Computed {
+		c.warn(k.Line, name, "ignored field with computed key")
+	}
+	c.result.Plugins = append(c.result.Plugins, p)
+}
+
+func (c *conversion) field(p *plugin.Plugin, f Field) {
+	v := f.Value
+	switch f.Key {
+	case "name", "url", "dir":
+		// Handled in spec.
+	case "branch":
+		p.Branch = c.str(p, f)
+	case "tag":
+		p.Version = c.str(p, f)
+	case "version":
+		if v.Kind == KindBool && !v.Bool {
+			return
+		}
+		p.Version = c.str(p, f)
+	case "commit":
+		c.warn(v.Line, p.Name, "commit pins are not imported; run 'nvp lock --from <lazy-lock.json>' to pin commits")
+	case "lazy":
+		p.Lazy = c.boolean(p, f, p.Lazy)
+	case "enabled":
+		p.Enabled = c.boolean(p, f, p.Enabled)
+	case "priority":
+		if v.Kind == KindNumber {
+			p.Priority = int(v.Number)
+		} else {
+			c.warn(v.Line, p.Name, "priority must be a number, got %s", describe(v))
+		}
+	case "event":
+		p.Event = c.strings(p, f)
+	case "ft":
+		p.Ft = c.strings(p, f)
+	case "cmd":
+		p.Cmd = c.strings(p, f)
+	case "keys":
+		p.Keys = c.keys(p, v)
+	case "dependencies":
+		p.Dependencies = c.dependencies(p, v)
+	case "build":
+		switch v.Kind {
+		case KindString:
+			p.Build = v.Str
+		case KindFunction:
+			p.Build = v.Source
+		default:
+			c.warn(v.Line, p.Name, "build %s is not supported", describe(v))
+		}
+	case "config":
+		switch {
+		case v.Kind == KindBool && v.Bool:
+			p.Config = "true"
+		case v.Kind == KindBool:
+		case v.Kind == KindFunction:
+			p.Config = c.functionBody(p, f)
+		default:
+			c.warn(v.Line, p.Name, "config %s is not supported", describe(v))
+		}
+	case "init":
+		if v.Kind == KindFunction {
+			p.Init = c.functionBody(p, f)
+		} else {
+			c.warn(v.Line, p.Name, "init %s is not supported", describe(v))
+		}
+	case "opts":
+		p.Opts = c.opts(v)
+	default:
+		if what, ok := unsupportedFields[f.Key]; ok {
+			c.warn(v.Line, p.Name, "%q (%s) is not supported by nvp and was dropped", f.Key, what)
+		} else {
+			c.warn(v.Line, p.Name, "unknown field %q was dropped", f.Key)
+		}
+	}
+}
+
+func (c *conversion) str(p *plugin.Plugin, f Field) string {
+	if f.Value.Kind == KindString {
+		return f.Value.Str
+	}
+	c.warn(f.Value.Line, p.Name, "%s must be a string, got %s", f.Key, describe(f.Value))
+	return ""
+}
+
+func (c *conversion) boolean(p *plugin.Plugin, f Field, fallback bool) bool {
+	if f.Value.Kind == KindBool {
+		return f.Value.Bool
+	}
+	c.warn(f.Value.Line, p.Name, "%s %s cannot be evaluated; kept as %t", f.Key, describe(f.Value), fallback)
+	return fallback
+}
+
+// strings reads a string or a list of strings.
+func (c *conversion) strings(p *plugin.Plugin, f Field) []string {
+	v := f.Value
+	switch v.Kind {
+	case KindString:
+		return []string{v.Str}
+	case KindTable:
+		var out []string
+		for _, item := range v.Table.Items {
+			if item.Kind == KindString {
+				out = append(out, item.Str)
+			} else {
+				c.warn(item.Line, p.Name, "%s entry %s is not a string; dropped", f.Key, describe(item))
+			}
+		}
+		return out
+	}
+	c.warn(v.Line, p.Name, "%s %s cannot be evaluated; dropped", f.Key, describe(v))
+	return nil
+}
+
+func (c *conversion) functionBody(p *plugin.Plugin, f Field) string {
+	if params := f.Value.Params; len(params) > 0 {
+		c.warn(f.Value.Line, p.Name, "%s function parameters (%s) are not passed by nvp's generated spec",
+			f.Key, strings.Join(params, ", "))
+	}
+	return f.Value.Body
+}
+
+func (c *conversion) keys(p *plugin.Plugin, v *Value) []plugin.Keymap {
+	if v.Kind != KindTable {
+		c.warn(v.Line, p.Name, "keys %s cannot be evaluated; dropped", describe(v))
+		return nil
+	}
+	var keys []plugin.Keymap
+	for _, item := range v.Table.Items {
+		switch item.Kind {
+		case KindString:
+			keys = append(keys, plugin.Keymap{Key: item.Str})
+		case KindTable:
+			if km, ok := c.keymap(p, item.Table, item.Line); ok {
+				keys = append(keys, km)
+			}
+		default:
+			c.warn(item.Line, p.Name, "key %s cannot be evaluated; dropped", describe(item))
+		}
+	}
+	return keys
+}
+
+func (c *conversion) keymap(p *plugin.Plugin, t *Table, line int) (plugin.Keymap, bool) {
+	if len(t.Items) == 0 || t.Items[0].Kind != KindString {
+		c.warn(line, p.Name, "key spec without a key string; dropped")
+		return plugin.Keymap{}, false
+	}
+	km := plugin.Keymap{Key: t.Items[0].Str}
+	if len(t.Items) > 1 {
+		rhs := t.Items[1]
+		switch rhs.Kind {
+		case KindString:
+			km.Action = rhs.Str
+		case KindFunction:
+			km.Action = rhs.Source
+		case KindExpr:
+			km.Action = rhs.Source
+			if !strings.HasPrefix(rhs.Source, "require") {
+				c.warn(rhs.Line, p.Name, "key %s action %s would be emitted as a string; wrap it in a function", km.Key, rhs.Source)
+			}
+		default:
+			c.warn(rhs.Line, p.Name, "key %s action %s is not supported", km.Key, describe(rhs))
+		}
+	}
+	for _, f := range t.Fields {
+		switch f.Key {
+		case "desc":
+			if f.Value.Kind == KindString {
+				km.Desc = f.Value.Str
+			}
+		case "mode":
+			km.Mode = c.strings(p, f)
+		default:
+			c.warn(f.Value.Line, p.Name, "key %s option %q was dropped", km.Key, f.Key)
+		}
+	}
+	return km, true
+}
+
+func (c *conversion) dependencies(p *plugin.Plugin, v *Value) []plugin.Dependency {
+	items := []*Value{v}
+	if v.Kind == KindTable && !isSpec(v.Table) {
+		items = v.Table.Items
+	}
+	var deps []plugin.Dependency
+	for _, item := range items {
+		switch item.Kind {
+		case KindString:
+			deps = append(deps, plugin.Dependency{Repo: item.Str})
+		case KindTable:
+			t := item.Table
+			if len(t.Items) == 0 || t.Items[0].Kind != KindString {
+				c.warn(item.Line, p.Name, "dependency without a repository; dropped")
+				continue
+			}
+			dep := plugin.Dependency{Repo: t.Items[0].Str}
+			var dropped []string
+			for _, f := range t.Fields {
+				switch {
+				case f.Key == "build" && f.Value.Kind == KindString:
+					dep.Build = f.Value.Str
+				case (f.Key == "version" || f.Key == "tag") && f.Value.Kind == KindString:
+					dep.Version = f.Value.Str
+				case f.Key == "branch" && f.Value.Kind == KindString:
+					dep.Branch = f.Value.Str
+				case f.Key == "config" && f.Value.Kind == KindBool:
+					dep.Config = f.Value.Bool
+				default:
+					dropped = append(dropped, f.Key)
+				}
+			}
+			if len(dropped) > 0 {
+				c.warn(item.Line, p.Name, "dependency %s: %s dropped; import it as its own plugin to keep them",
+					dep.Repo, strings.Join(dropped, ", "))
+			}
+			deps = append(deps, dep)
+		default:
+			c.warn(item.Line, p.Name, "dependency %s cannot be evaluated; dropped", describe(item))
+		}
+	}
+	return deps
+}
+
+// opts converts an opts value to YAML data when possible, and otherwise
+// keeps its Lua source, which nvp emits verbatim.
+func (c *conversion) opts(v *Value) interface{} {
+	if v.Kind == KindTable {
+		if data, ok := tableData(v.Table); ok {
+			return data
+		}
+	}
+	return v.Source
+}
+
+// tableData converts a table of literals to a map or list. Functions are
+// allowed as map values, where nvp emits them as Lua.
+func tableData(t *Table) (interface{}, bool) {
+	if len(t.Computed) > 0 || (len(t.Items) > 0 && len(t.Fields) > 0) {
+		return nil, false
+	}
+	if len(t.Items) > 0 {
+		list := make([]interface{}, 0, len(t.Items))
+		for _, item := range t.Items {
+			if item.Kind == KindFunction {
+				return nil, false
+			}
+			d, ok := valueData(item)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, d)
+		}
+		return list, true
+	}
+	m := make(map[string]interface{}, len(t.Fields))
+	for _, f := range t.Fields {
+		if f.Value.Kind == KindFunction {
+			m[f.Key] = f.Value.Source
+			continue
+		}
+		d, ok := valueData(f.Value)
+		if !ok {
+			return nil, false
+		}
+		m[f.Key] = d
+	}
+	return m, true
+}
+
+func valueData(v *Value) (interface{}, bool) {
+	switch v.Kind {
+	case KindBool:
+		return v.Bool, true
+	case KindNumber:
+		if v.Number == float64(int(v.Number)) {
+			return int(v.Number), true
+		}
+		return v.Number, true
+	case KindString:
+		// Strings that look like Lua functions would be emitted unquoted.
+		if strings.HasPrefix(strings.TrimSpace(v.Str), "function") {
+			return nil, false
+		}
+		return v.Str, true
+	case KindTable:
+		return tableData(v.Table)
+	}
+	return nil, false
+}
+
+func (c *conversion) nameFor(repo string) string {
+	if c.NameFor != nil {
+		if name := c.NameFor(repo); name != "" {
+			return name
+		}
+	}
+	return DefaultName(repo)
+}
+
+// DefaultName derives a plugin name from a repo the way the library names
+// plugins: "nvim-telescope/telescope.nvim" → "telescope",
+// "windwp/nvim-autopairs" → "autopairs".
+func DefaultName(repo string) string {
+	name := strings.ToLower(repo)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	for _, suffix := range []string{".git", ".nvim", ".lua", ".vim", "-nvim", "_nvim"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if trimmed := strings.TrimPrefix(name, "nvim-"); trimmed != "" {
+		name = trimmed
+	}
+	return name
+}
+
+// repoFromURL extracts owner/name from a GitHub URL.
+func repoFromURL(url string) string {
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "git@github.com:"} {
+		if strings.HasPrefix(url, prefix) {
+			return strings.TrimSuffix(strings.TrimSuffix(url[len(prefix):], "/"), ".git")
+		}
+	}
+	return ""
+}
+
+func describe(v *Value) string {
+	switch v.Kind {
+	case KindNil:
+		return "nil"
+	case KindBool:
+		return fmt.Sprintf("%t", v.Bool)
+	case KindNumber:
+		return v.Source
+	case KindString:
+		return fmt.Sprintf("%q", v.Str)
+	case KindTable:
+		return "(table)"
+	case KindFunction:
+		return "(function)"
+	}
+	src := strings.Join(strings.Fields(v.Source), " ")
+	if len(src) > 40 {
+		src = src[:37] + "..."
+	}
+	return "`" + src + "`"
+}
@@ -0,0 +1,204 @@
+package luaspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind classifies a Lua token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokKeyword
+	tokString
+	tokNumber
+	tokPunct
+)
+
+// token is a lexed Lua token. For strings, text is the decoded value.
+type token struct {
+	kind       tokenKind
+	text       string
+	start, end int // byte offsets into the source
+	line       int
+}
+
+var keywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true,
+	"end": true, "false": true, "for": true, "function": true, "goto": true,
+	"if": true, "in": true, "local": true, "nil": true, "not": true,
+	"or": true, "repeat": true, "return": true, "then": true, "true": true,
+	"until": true, "while": true,
+}
+
+// multi-character operators, longest first.
+var operators = []string{"...", "..", "==", "~=", "<=", ">=", "//", "::", "<<", ">>"}
+
+// lex splits Lua source into tokens, dropping whitespace and comments.
+func lex(src string) ([]token, error) {
+	var toks []token
+	line := 1
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "--"):
+			i += 2
+			if level, ok := longBracket(src[i:]); ok {
+				end, n := closeLongBracket(src, i+level+2, level)
+				if end < 0 {
+					return nil, fmt.Errorf("line %d: unterminated comment", line)
+				}
+				line += n
+				i = end
+				continue
+			}
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			start, startLine := i, line
+			value, end, err := quotedString(src, i)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", startLine, err)
+			}
+			line += strings.Count(src[start:end], "\n")
+			i = end
+			toks = append(toks, token{kind: tokString, text: value, start: start, end: end, line: startLine})
+		case c == '[' && isLongBracketStart(src[i:]):
+			level, _ := longBracket(src[i:])
+			start, startLine := i, line
+			bodyStart := i + level + 2
+			end, n := closeLongBracket(src, bodyStart, level)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated long string", line)
+			}
+			body := src[bodyStart : end-level-2]
+			body = strings.TrimPrefix(body, "\n")
+			line += n
+			i = end
+			toks = append(toks, token{kind: tokString, text: body, start: start, end: end, line: startLine})
+		case isNameStart(c):
+			start := i
+			for i < len(src) && isNameChar(src[i]) {
+				i++
+			}
+			kind := tokName
+			if keywords[src[start:i]] {
+				kind = tokKeyword
+			}
+			toks = append(toks, token{kind: kind, text: src[start:i], start: start, end: i, line: line})
+		case isDigit(c) || (c == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			start := i
+			for i < len(src) && (isNameChar(src[i]) || src[i] == '.' ||
+				((src[i] == '-' || src[i] == '+') && (src[i-1] == 'e' || src[i-1] == 'E' || src[i-1] == 'p' || src[i-1] == 'P'))) {
+				i++
+			}
+			toks = append(toks, token{kind: tokNumber, text: src[start:i], start: start, end: i, line: line})
+		default:
+			op := string(c)
+			for _, candidate := range operators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			toks = append(toks, token{kind: tokPunct, text: op, start: i, end: i + len(op), line: line})
+			i += len(op)
+		}
+	}
+	toks = append(toks, token{kind: tokEOF, start: len(src), end: len(src), line: line})
+	return toks, nil
+}
+
+// longBracket reports the level of a long bracket opening ("[[", "[==[")
+// at the start of s.
+func longBracket(s string) (int, bool) {
+	if !strings.HasPrefix(s, "[") {
+		return 0, false
+	}
+	level := 0
+	for level+1 < len(s) && s[level+1] == '=' {
+		level++
+	}
+	if level+1 < len(s) && s[level+1] == '[' {
+		return level, true
+	}
+	return 0, false
+}
+
+func isLongBracketStart(s string) bool {
+	_, ok := longBracket(s)
+	return ok
+}
+
+// closeLongBracket finds the closing bracket of the given level starting at
+// from. It returns the offset just past the bracket and the newlines
+// skipped, or -1 if unterminated.
+func closeLongBracket(src string, from, level int) (int, int) {
+	closer := "]" + strings.Repeat("=", level) + "]"
+	idx := strings.Index(src[from:], closer)
+	if idx < 0 {
+		return -1, 0
+	}
+	end := from + idx + len(closer)
+	return end, strings.Count(src[from:end], "\n")
+}
+
+// quotedString decodes a short string starting at src[i].
+func quotedString(src string, i int) (string, int, error) {
+	quote := src[i]
+	var sb strings.Builder
+	i++
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == quote:
+			return sb.String(), i + 1, nil
+		case c == '\n':
+			return "", 0, fmt.Errorf("unterminated string")
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch e := src[i]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '\\', '"', '\'':
+				sb.WriteByte(e)
+			case '\n':
+				sb.WriteByte('\n')
+			default:
+				// Keep other escapes (\x, \u{...}, \ddd) verbatim.
+				sb.WriteByte('\\')
+				sb.WriteByte(e)
+			}
+			i++
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,179 @@
+package luaspec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const telescopeSpec = `-- Fuzzy finder
+return {
+  "nvim-telescope/telescope.nvim",
+  branch = "0.1.x",
+  cmd = "Telescope",
+  dependencies = {
+    "nvim-lua/plenary.nvim",
+    { "nvim-telescope/telescope-fzf-native.nvim", build = "make", cond = vim.fn.executable("make") == 1 },
+  },
+  keys = {
+    { "<leader>ff", "<cmd>Telescope find_files<cr>", desc = "Find files" },
+    { "<leader>fg", function() require("telescope.builtin").live_grep() end, desc = "Grep", mode = { "n", "v" } },
+  },
+  opts = {
+    defaults = {
+      prompt_prefix = "> ",
+      layout_config = { width = 0.9 },
+      file_ignore_patterns = { "node_modules", [[\.git/]] },
+    },
+  },
+  config = function(_, opts)
+    local telescope = require("telescope")
+    telescope.setup(opts)
+    if vim.g.loaded then
+      telescope.load_extension("fzf")
+    end
+  end,
+}
+`
+
+func TestParse(t *testing.T) {
+	v, err := Parse(telescopeSpec)
+	require.NoError(t, err)
+	require.Equal(t, KindTable, v.Kind)
+	assert.Equal(t, "nvim-telescope/telescope.nvim", v.Table.Items[0].Str)
+
+	cfg := v.Table.Get("config")
+	require.NotNil(t, cfg)
+	assert.Equal(t, KindFunction, cfg.Kind)
+	assert.Equal(t, []string{"_", "opts"}, cfg.Params)
+	assert.True(t, strings.HasPrefix(cfg.Body, "local telescope"))
+	assert.Contains(t, cfg.Body, "\n  telescope.load_extension")
+
+	dep := v.Table.Get("dependencies").Table.Items[1].Table
+	cond := dep.Get("cond")
+	assert.Equal(t, KindExpr, cond.Kind)
+	assert.Equal(t, `vim.fn.executable("make") == 1`, cond.Source)
+}
+
+func TestParse_LocalTable(t *testing.T) {
+	v, err := Parse(`local M = { "folke/flash.nvim", event = "VeryLazy" }
+local function helper() return 1 end
+return M`)
+	require.NoError(t, err)
+	assert.Equal(t, "folke/flash.nvim", v.Table.Items[0].Str)
+
+	_, err = Parse(`vim.g.x = 1`)
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	res := (&Converter{}).Convert(telescopeSpec, "telescope.lua")
+	require.Len(t, res.Plugins, 1)
+	p := res.Plugins[0]
+
+	assert.Equal(t, "telescope", p.Name)
+	assert.Equal(t, "nvim-telescope/telescope.nvim", p.Repo)
+	assert.Equal(t, "0.1.x", p.Branch)
+	assert.Equal(t, []string{"Telescope"}, p.Cmd)
+	assert.True(t, p.Enabled)
+
+	require.Len(t, p.Dependencies, 2)
+	assert.Equal(t, "make", p.Dependencies[1].Build)
+
+	require.Len(t, p.Keys, 2)
+	assert.Equal(t, "<cmd>Telescope find_files<cr>", p.Keys[0].Action)
+	assert.Equal(t, "Find files", p.Keys[0].Desc)
+	assert.True(t, strings.HasPrefix(p.Keys[1].Action, "function()"))
+	assert.Equal(t, []string{"n", "v"}, p.Keys[1].Mode)
+
+	opts, ok := p.Opts.(map[string]interface{})
+	require.True(t, ok, "literal opts should convert to data")
+	defaults := opts["defaults"].(map[string]interface{})
+	assert.Equal(t, "> ", defaults["prompt_prefix"])
+	assert.Equal(t, 0.9, defaults["layout_config"].(map[string]interface{})["width"])
+	assert.Equal(t, []interface{}{"node_modules", `\.git/`}, defaults["file_ignore_patterns"])
+
+	assert.True(t, strings.HasPrefix(p.Config, "local telescope"))
+
+	var messages []string
+	for _, w := range res.Warnings {
+		messages = append(messages, w.String())
+	}
+	require.Len(t, messages, 2, "%v", messages)
+	assert.Contains(t, messages[0], "telescope.lua:8: telescope: dependency nvim-telescope/telescope-fzf-native.nvim: cond dropped")
+	assert.Contains(t, messages[1], "config function parameters (_, opts)")
+}
+
+func TestConvert_SpecList(t *testing.T) {
+	src := `return {
+  "tpope/vim-fugitive",
+  { "folke/tokyonight.nvim", lazy = false, priority = 1000, version = false },
+  { "stevearc/oil.nvim", name = "oil", opts = { view = vim.g.oil_view }, cond = false },
+  { url = "https://github.com/windwp/nvim-autopairs.git", event = { "InsertEnter" }, config = true },
+  { dir = "~/dev/local.nvim" },
+  { import = "plugins.extras" },
+}`
+	res := (&Converter{}).Convert(src, "init.lua")
+	require.Len(t, res.Plugins, 4)
+
+	assert.Equal(t, "vim-fugitive", res.Plugins[0].Name)
+
+	tokyo := res.Plugins[1]
+	assert.Equal(t, "tokyonight", tokyo.Name)
+	assert.False(t, tokyo.Lazy)
+	assert.Equal(t, 1000, tokyo.Priority)
+	assert.Empty(t, tokyo.Version)
+
+	oil := res.Plugins[2]
+	assert.Equal(t, "oil", oil.Name)
+	assert.Equal(t, "{ view = vim.g.oil_view }", oil.Opts, "opts with expressions are kept as Lua")
+
+	autopairs := res.Plugins[3]
+	assert.Equal(t, "autopairs", autopairs.Name)
+	assert.Equal(t, "windwp/nvim-autopairs", autopairs.Repo)
+	assert.Equal(t, "true", autopairs.Config)
+
+	require.Len(t, res.Warnings, 3)
+	assert.Contains(t, res.Warnings[0].Message, `"cond"`)
+	assert.Contains(t, res.Warnings[1].Message, "local plugin")
+	assert.Contains(t, res.Warnings[2].Message, "import of")
+}
+
+func TestConvertDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "lang"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.lua"), []byte(`return { "folke/flash.nvim" }`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "lang", "go.lua"), []byte(`return { { "folke/flash.nvim" }, { "ray-x/go.nvim", ft = "go" } }`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.lua"), []byte(`return { "unterminated`), 0644))
+
+	res, err := (&Converter{NameFor: func(repo string) string {
+		if repo == "ray-x/go.nvim" {
+			return "go-nvim"
+		}
+		return ""
+	}}).ConvertDir(dir)
+	require.NoError(t, err)
+
+	require.Len(t, res.Plugins, 2)
+	assert.Equal(t, "flash", res.Plugins[0].Name)
+	assert.Equal(t, "go-nvim", res.Plugins[1].Name)
+
+	require.Len(t, res.Warnings, 2)
+	assert.Contains(t, res.Warnings[0].Message, "could not parse")
+	assert.Contains(t, res.Warnings[1].Message, "duplicate")
+
+	_, err = (&Converter{}).ConvertDir(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestDefaultName(t *testing.T) {
+	assert.Equal(t, "telescope", DefaultName("nvim-telescope/telescope.nvim"))
+	assert.Equal(t, "autopairs", DefaultName("windwp/nvim-autopairs"))
+	assert.Equal(t, "copilot", DefaultName("zbirenbaum/copilot.lua"))
+	assert.Equal(t, "alpha", DefaultName("goolord/alpha-nvim"))
+	assert.Equal(t, "comment", DefaultName("numToStr/Comment.nvim"))
+}
@@ -0,0 +1,384 @@
+package luaspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind classifies a parsed Lua value.
+type Kind int
+
+const (
+	KindNil Kind = iota
+	KindBool
+	KindNumber
+	KindString
+	KindTable
+	KindFunction
+	// KindExpr is any other expression (calls, variables, operators),
+	// kept only as source text.
+	KindExpr
+)
+
+// Value is a Lua value from a spec file. Source is the exact source text of
+// the value; for functions Params and Body hold the parameter names and the
+// dedented body.
+type Value struct {
+	Kind   Kind
+	Bool   bool
+	Number float64
+	Str    string
+	Table  *Table
+	Params []string
+	Body   string
+	Source string
+	Line   int
+}
+
+// Field is a named table field.
+type Field struct {
+	Key   string
+	Value *Value
+}
+
+// Table is a Lua table constructor: positional items and named fields, in
+// source order. Fields with computed keys ([expr] = v) are kept in Computed.
+type Table struct {
+	Items    []*Value
+	Fields   []Field
+	Computed []*Value
+}
+
+// Get returns the named field, or nil.
+func (t *Table) Get(key string) *Value {
+	for _, f := range t.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// parser reads the constrained subset of Lua used by plugin spec files:
+// a chunk whose return value (or a local it returns) is a table literal.
+// Anything that is not a literal is captured as source text.
+type parser struct {
+	src  string
+	toks []token
+	pos  int
+}
+
+// Parse returns the value a Lua spec file returns. A chunk of the form
+// "local M = { ... } ... return M" resolves to the table assigned to M.
+func Parse(src string) (*Value, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, toks: toks}
+
+	locals := make(map[string]*Value)
+	depth := 0
+	for p.peek().kind != tokEOF {
+		t := p.peek()
+		switch {
+		case depth == 0 && t.kind == tokKeyword && t.text == "return":
+			p.next()
+			if p.peek().kind == tokEOF {
+				return nil, fmt.Errorf("line %d: empty return", t.line)
+			}
+			v, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if v.Kind == KindExpr {
+				if local, ok := locals[strings.TrimSpace(v.Source)]; ok {
+					return local, nil
+				}
+			}
+			return v, nil
+		case depth == 0 && t.kind == tokKeyword && t.text == "local" &&
+			p.peekAt(1).kind == tokName && p.peekAt(2).text == "=" && p.peekAt(3).text == "{":
+			name := p.peekAt(1).text
+			p.pos += 3
+			v, err := p.table()
+			if err != nil {
+				return nil, err
+			}
+			locals[name] = v
+		default:
+			depth += blockDelta(t)
+			p.next()
+		}
+	}
+	return nil, fmt.Errorf("no return statement found")
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// blockDelta tracks Lua block nesting: function/if/do/repeat open a block,
+// end/until close one (while and for open theirs with "do").
+func blockDelta(t token) int {
+	if t.kind != tokKeyword {
+		return 0
+	}
+	switch t.text {
+	case "function", "if", "do", "repeat":
+		return 1
+	case "end", "until":
+		return -1
+	}
+	return 0
+}
+
+// isTerminator reports whether t ends an expression inside a table or chunk.
+func isTerminator(t token) bool {
+	if t.kind == tokEOF {
+		return true
+	}
+	if t.kind == tokKeyword {
+		switch t.text {
+		case "end", "local", "return", "else", "elseif", "until":
+			return true
+		}
+	}
+	return t.kind == tokPunct && (t.text == "," || t.text == ";" || t.text == "}" || t.text == ")" || t.text == "]")
+}
+
+// expr parses a value. Literals, tables, and functions are decoded; if
+// anything follows them (a call, an operator), the whole expression is
+// captured as KindExpr.
+func (p *parser) expr() (*Value, error) {
+	start := p.pos
+	startTok := p.peek()
+	v, err := p.simple()
+	if err != nil {
+		return nil, err
+	}
+	if v != nil && isTerminator(p.peek()) {
+		return v, nil
+	}
+	p.pos = start
+	if err := p.skipExpr(); err != nil {
+		return nil, err
+	}
+	end := p.toks[p.pos-1].end
+	return &Value{Kind: KindExpr, Source: p.src[startTok.start:end], Line: startTok.line}, nil
+}
+
+// simple parses a literal, table, or function, or returns nil for other
+// expressions.
+func (p *parser) simple() (*Value, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokString:
+		p.next()
+		return &Value{Kind: KindString, Str: t.text, Source: p.src[t.start:t.end], Line: t.line}, nil
+	case t.kind == tokNumber:
+		p.next()
+		n, err := parseNumber(t.text)
+		if err != nil {
+			return &Value{Kind: KindExpr, Source: t.text, Line: t.line}, nil
+		}
+		return &Value{Kind: KindNumber, Number: n, Source: t.text, Line: t.line}, nil
+	case t.kind == tokKeyword && (t.text == "true" || t.text == "false"):
+		p.next()
+		return &Value{Kind: KindBool, Bool: t.text == "true", Source: t.text, Line: t.line}, nil
+	case t.kind == tokKeyword && t.text == "nil":
+		p.next()
+		return &Value{Kind: KindNil, Source: t.text, Line: t.line}, nil
+	case t.kind == tokPunct && t.text == "{":
+		return p.table()
+	case t.kind == tokKeyword && t.text == "function":
+		return p.function()
+	}
+	return nil, nil
+}
+
+func parseNumber(s string) (float64, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		n, err := strconv.ParseInt(s[2:], 16, 64)
+		return float64(n), err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func (p *parser) table() (*Value, error) {
+	open := p.next()
+	t := &Table{}
+	for {
+		tok := p.peek()
+		switch {
+		case tok.kind == tokEOF:
+			return nil, fmt.Errorf("line %d: unterminated table", open.line)
+		case tok.kind == tokPunct && tok.text == "}":
+			p.next()
+			return &Value{Kind: KindTable, Table: t, Source: p.src[open.start:tok.end], Line: open.line}, nil
+		case tok.kind == tokPunct && (tok.text == "," || tok.text == ";"):
+			p.next()
+		case tok.kind == tokName && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "=":
+			p.pos += 2
+			v, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			t.Fields = append(t.Fields, Field{Key: tok.text, Value: v})
+		case tok.kind == tokPunct && tok.text == "[":
+			// Computed key: [expr] = value. String keys are named fields.
+			p.next()
+			key, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if p.next().text != "]" || p.next().text != "=" {
+				return nil, fmt.Errorf("line %d: malformed table key", tok.line)
+			}
+			v, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if key.Kind == KindString {
+				t.Fields = append(t.Fields, Field{Key: key.Str, Value: v})
+			} else {
+				t.Computed = append(t.Computed, v)
+			}
+		default:
+			before := p.pos
+			v, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if p.pos == before {
+				return nil, fmt.Errorf("line %d: unexpected %q in table", tok.line, tok.text)
+			}
+			t.Items = append(t.Items, v)
+		}
+	}
+}
+
+func (p *parser) function() (*Value, error) {
+	fn := p.next()
+	if p.peek().text != "(" {
+		return nil, fmt.Errorf("line %d: expected ( after function", fn.line)
+	}
+	p.next()
+	var params []string
+	for p.peek().text != ")" {
+		t := p.next()
+		if t.kind == tokEOF {
+			return nil, fmt.Errorf("line %d: unterminated parameter list", fn.line)
+		}
+		if t.kind == tokName || t.text == "..." {
+			params = append(params, t.text)
+		}
+	}
+	closeParen := p.next()
+
+	depth := 1
+	for {
+		t := p.next()
+		if t.kind == tokEOF {
+			return nil, fmt.Errorf("line %d: unterminated function", fn.line)
+		}
+		depth += blockDelta(t)
+		if depth == 0 {
+			return &Value{
+				Kind:   KindFunction,
+				Params: params,
+				Body:   dedent(p.src[closeParen.end:t.start]),
+				Source: p.src[fn.start:t.end],
+				Line:   fn.line,
+			}, nil
+		}
+	}
+}
+
+// skipExpr advances past an arbitrary expression, stopping at a terminator
+// outside any brackets or blocks.
+func (p *parser) skipExpr() error {
+	start := p.peek()
+	if isTerminator(start) {
+		return fmt.Errorf("line %d: expected expression, got %q", start.line, start.text)
+	}
+	brackets := 0
+	blocks := 0
+	for {
+		t := p.peek()
+		if t.kind == tokEOF {
+			if brackets > 0 || blocks > 0 {
+				return fmt.Errorf("line %d: unterminated expression", start.line)
+			}
+			return nil
+		}
+		if brackets == 0 && blocks == 0 && isTerminator(t) {
+			return nil
+		}
+		if t.kind == tokPunct {
+			switch t.text {
+			case "(", "{", "[":
+				brackets++
+			case ")", "}", "]":
+				brackets--
+			}
+		}
+		blocks += blockDelta(t)
+		p.next()
+	}
+}
+
+// dedent strips surrounding blank lines and the common leading indentation.
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first || !strings.HasPrefix(indent, prefix) {
+			if first {
+				prefix = indent
+			} else {
+				prefix = commonPrefix(prefix, indent)
+			}
+			first = false
+		}
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(strings.TrimPrefix(line, prefix), " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
@@ -0,0 +1,210 @@
+// Package luavalidate checks generated lazy.nvim plugin spec files by
+// loading them in a headless Neovim.
+//
+// The files are copied into a throwaway sandbox whose XDG directories are
+// empty, so the user's own config and plugins are never loaded. A generated
+// init.lua then loads each file (catching Lua syntax errors), runs it
+// (catching errors at the top level of the spec), and checks that it returns
+// a table. Errors are reported with the file and line they point at.
+//
+// Plugins are not installed, so code inside config, init, and opts
+// functions is only syntax-checked.
+package luavalidate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds one validation run.
+const DefaultTimeout = 30 * time.Second
+
+// ErrNvimNotFound is returned when the Neovim binary cannot be found.
+var ErrNvimNotFound = errors.New("nvim not found in PATH (install Neovim or pass its path)")
+
+// Diagnostic is a problem found in one file.
+type Diagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as file:line: message.
+func (d Diagnostic) String() string {
+	if d.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.File, d.Message)
+}
+
+// Validator runs the headless Neovim check.
+type Validator struct {
+	// Nvim is the Neovim binary (default "nvim", looked up in PATH).
+	Nvim string
+
+	// Timeout bounds the Neovim run (default DefaultTimeout).
+	Timeout time.Duration
+}
+
+// Validate checks files, which maps file names (e.g., "telescope.lua") to
+// their contents, and returns one diagnostic per failing file, sorted by
+// file name. An error means validation itself could not run.
+func (v *Validator) Validate(ctx context.Context, files map[string]string) ([]Diagnostic, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	nvim := v.Nvim
+	if nvim == "" {
+		nvim = "nvim"
+	}
+	nvimPath, err := exec.LookPath(nvim)
+	if err != nil {
+		return nil, ErrNvimNotFound
+	}
+
+	sandbox, err := os.MkdirTemp("", "nvp-validate-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validation sandbox: %w", err)
+	}
+	defer os.RemoveAll(sandbox)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specDir := filepath.Join(sandbox, "specs")
+	if err := os.MkdirAll(specDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create validation sandbox: %w", err)
+	}
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(specDir, filepath.Base(name))
+		if err := os.WriteFile(paths[i], []byte(files[name]), 0600); err != nil {
+			return nil, fmt.Errorf("failed to write %s to the validation sandbox: %w", name, err)
+		}
+	}
+
+	reportPath := filepath.Join(sandbox, "report.txt")
+	initPath := filepath.Join(sandbox, "init.lua")
+	if err := os.WriteFile(initPath, []byte(initScript(paths, reportPath)), 0600); err != nil {
+		return nil, fmt.Errorf("failed to write validation init.lua: %w", err)
+	}
+
+	timeout := v.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, nvimPath, "--headless", "-n", "-i", "NONE", "--noplugin", "-u", initPath)
+	cmd.Dir = sandbox
+	cmd.Env = append(os.Environ(),
+		"XDG_CONFIG_HOME="+filepath.Join(sandbox, "config"),
+		"XDG_DATA_HOME="+filepath.Join(sandbox, "data"),
+		"XDG_STATE_HOME="+filepath.Join(sandbox, "state"),
+		"XDG_CACHE_HOME="+filepath.Join(sandbox, "cache"),
+	)
+	output, runErr := cmd.CombinedOutput()
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("nvim validation did not finish: %w", ctx.Err())
+	}
+
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		// init.lua never finished: nvim itself failed
+		if runErr != nil {
+			return nil, fmt.Errorf("nvim failed: %w: %s", runErr, strings.TrimSpace(string(output)))
+		}
+		return nil, fmt.Errorf("nvim did not write a validation report: %s", strings.TrimSpace(string(output)))
+	}
+
+	byPath := make(map[string]string, len(paths))
+	for i, path := range paths {
+		byPath[path] = names[i]
+	}
+	return parseReport(report, byPath), nil
+}
+
+// initScript returns the init.lua that checks each spec file and writes one
+// "path<TAB>error" line per failure to reportPath.
+func initScript(paths []string, reportPath string) string {
+	var b strings.Builder
+	b.WriteString("local files = {\n")
+	for _, path := range paths {
+		fmt.Fprintf(&b, "  %s,\n", luaString(path))
+	}
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "local report = assert(io.open(%s, \"w\"))\n", luaString(reportPath))
+	b.WriteString(`local function fail(file, msg)
+  report:write(file, "\t", (tostring(msg):gsub("[\r\n]+", " ")), "\n")
+end
+for _, file in ipairs(files) do
+  local chunk, err = loadfile(file)
+  if not chunk then
+    fail(file, err)
+  else
+    local ok, spec = pcall(chunk)
+    if not ok then
+      fail(file, spec)
+    elseif type(spec) ~= "table" then
+      fail(file, "spec must return a table, got " .. type(spec))
+    end
+  end
+end
+report:close()
+vim.cmd("qall!")
+`)
+	return b.String()
+}
+
+// luaString quotes s as a Lua long string, which needs no escaping.
+func luaString(s string) string {
+	level := ""
+	for strings.Contains(s, "]"+level+"]") {
+		level += "="
+	}
+	return "[" + level + "[" + s + "]" + level + "]"
+}
+
+// luaErrorPos matches the "chunkname:line: " prefix of a Lua error. Lua
+// shortens long chunk names, so the name itself is not compared.
+var luaErrorPos = regexp.MustCompile(`^.*?:(\d+): (.*)$`)
+
+// parseReport turns report lines into diagnostics, naming files as the
+// caller did.
+func parseReport(report []byte, byPath map[string]string) []Diagnostic {
+	var diags []Diagnostic
+	scanner := bufio.NewScanner(bytes.NewReader(report))
+	for scanner.Scan() {
+		path, msg, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		d := Diagnostic{File: byPath[path], Message: msg}
+		if d.File == "" {
+			d.File = filepath.Base(path)
+		}
+		if m := luaErrorPos.FindStringSubmatch(msg); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = m[2]
+		}
+		diags = append(diags, d)
+	}
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].File < diags[j].File })
+	return diags
+}
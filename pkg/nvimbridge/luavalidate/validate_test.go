@@ -0,0 +1,105 @@
+package luavalidate
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNvim writes a script standing in for nvim: it writes report to the
+// report file next to the init.lua passed with -u, replacing SPECS with the
+// sandbox's spec directory.
+func fakeNvim(t *testing.T, report string) string {
+	t.Helper()
+	dir := t.TempDir()
+	canned := filepath.Join(dir, "report.txt")
+	require.NoError(t, os.WriteFile(canned, []byte(report), 0644))
+	path := filepath.Join(dir, "nvim")
+	script := `#!/bin/sh
+while [ "$#" -gt 0 ]; do
+  if [ "$1" = "-u" ]; then dir=$(dirname "$2"); fi
+  shift
+done
+sed "s#SPECS#$dir/specs#g" "` + canned + `" > "$dir/report.txt"
+`
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func TestValidate_ReportsDiagnostics(t *testing.T) {
+	nvim := fakeNvim(t, "SPECS/telescope.lua\tSPECS/telescope.lua:3: '}' expected near 'end'\n"+
+		"SPECS/oil.lua\tspec must return a table, got nil\n")
+
+	v := &Validator{Nvim: nvim}
+	diags, err := v.Validate(context.Background(), map[string]string{
+		"telescope.lua": "return {",
+		"oil.lua":       "",
+		"cmp.lua":       "return {}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{
+		{File: "oil.lua", Message: "spec must return a table, got nil"},
+		{File: "telescope.lua", Line: 3, Message: "'}' expected near 'end'"},
+	}, diags)
+	assert.Equal(t, "telescope.lua:3: '}' expected near 'end'", diags[1].String())
+}
+
+func TestValidate_Clean(t *testing.T) {
+	v := &Validator{Nvim: fakeNvim(t, "")}
+	diags, err := v.Validate(context.Background(), map[string]string{"cmp.lua": "return {}"})
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+}
+
+func TestValidate_NvimFailsWithoutReport(t *testing.T) {
+	nvim := filepath.Join(t.TempDir(), "nvim")
+	require.NoError(t, os.WriteFile(nvim, []byte("#!/bin/sh\necho 'E5113: broken' >&2\nexit 1\n"), 0755))
+
+	v := &Validator{Nvim: nvim}
+	_, err := v.Validate(context.Background(), map[string]string{"cmp.lua": "return {}"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "E5113: broken")
+}
+
+func TestValidate_NvimNotFound(t *testing.T) {
+	v := &Validator{Nvim: filepath.Join(t.TempDir(), "missing-nvim")}
+	_, err := v.Validate(context.Background(), map[string]string{"cmp.lua": "return {}"})
+	assert.ErrorIs(t, err, ErrNvimNotFound)
+}
+
+func TestParseReport_ShortenedChunkName(t *testing.T) {
+	report := []byte("/tmp/nvp-validate-1/specs/a-plugin-with-a-long-name.lua\t...lidate-1/specs/a-plugin-with-a-long-name.lua:12: attempt to call a nil value\n")
+	diags := parseReport(report, map[string]string{
+		"/tmp/nvp-validate-1/specs/a-plugin-with-a-long-name.lua": "a-plugin-with-a-long-name.lua",
+	})
+	assert.Equal(t, []Diagnostic{{File: "a-plugin-with-a-long-name.lua", Line: 12, Message: "attempt to call a nil value"}}, diags)
+}
+
+func TestLuaString(t *testing.T) {
+	assert.Equal(t, "[[/tmp/a.lua]]", luaString("/tmp/a.lua"))
+	assert.Equal(t, "[=[/tmp/]]odd.lua]=]", luaString("/tmp/]]odd.lua"))
+}
+
+// TestValidate_RealNeovim runs the check in an installed Neovim.
+func TestValidate_RealNeovim(t *testing.T) {
+	if _, err := exec.LookPath("nvim"); err != nil {
+		t.Skip("nvim not installed")
+	}
+
+	v := &Validator{}
+	diags, err := v.Validate(context.Background(), map[string]string{
+		"good.lua":   "return {\n  \"folke/which-key.nvim\",\n  config = function() require(\"which-key\").setup() end,\n}\n",
+		"syntax.lua": "return {\n  \"a/b\",\n  opts = { x = 1 \n}\nend\n",
+		"nil.lua":    "local x = 1\n",
+	})
+	require.NoError(t, err)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "nil.lua", diags[0].File)
+	assert.Equal(t, "syntax.lua", diags[1].File)
+	assert.Positive(t, diags[1].Line)
+}
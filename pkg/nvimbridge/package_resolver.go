@@ -0,0 +1,173 @@
+// Package nvimbridge provides database adapters that bridge MaestroNvim types
+// with dvm's database layer (models, db packages).
+// This file implements nvim package inheritance: resolving a package's
+// extends chain (e.g. core → maestro-go → personal overrides) into a single
+// flattened package.
+package nvimbridge
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/internal/listmerge"
+
+	nvimpackage "github.com/rmkohlman/MaestroNvim/nvimops/package"
+	packagelibrary "github.com/rmkohlman/MaestroNvim/nvimops/package/library"
+)
+
+// PackageLookup returns the named nvim package, or an error if it does not
+// exist.
+type PackageLookup func(name string) (*nvimpackage.Package, error)
+
+// PackageDataStore is the subset of db.NvimPackageStore needed to look up
+// stored packages.
+type PackageDataStore interface {
+	GetPackage(name string) (*models.NvimPackageDB, error)
+}
+
+// NewPackageLookup returns a lookup that checks the store first, so user
+// packages can shadow library packages of the same name, and falls back to
+// the embedded package library. A nil store uses the library only.
+func NewPackageLookup(store PackageDataStore) PackageLookup {
+	lib, libErr := packagelibrary.NewLibrary()
+	return func(name string) (*nvimpackage.Package, error) {
+		if store != nil {
+			if dbPkg, err := store.GetPackage(name); err == nil && dbPkg != nil {
+				return PackageFromDBModel(dbPkg), nil
+			}
+		}
+		if libErr != nil {
+			return nil, fmt.Errorf("failed to load package library: %w", libErr)
+		}
+		if pkg, ok := lib.Get(name); ok {
+			return pkg, nil
+		}
+		return nil, fmt.Errorf("package '%s' not found in library or database", name)
+	}
+}
+
+// PackageFromDBModel converts a stored package to a nvimpackage.Package.
+// Tags and the enabled flag are read back from labels.
+func PackageFromDBModel(dbPkg *models.NvimPackageDB) *nvimpackage.Package {
+	pkg := &nvimpackage.Package{
+		Name:    dbPkg.Name,
+		Enabled: true,
+	}
+	for _, name := range dbPkg.GetPlugins() {
+		if name = strings.TrimSpace(name); name != "" {
+			pkg.Plugins = append(pkg.Plugins, name)
+		}
+	}
+	if dbPkg.Description.Valid {
+		pkg.Description = dbPkg.Description.String
+	}
+	if dbPkg.Category.Valid {
+		pkg.Category = dbPkg.Category.String
+	}
+	if dbPkg.Extends.Valid {
+		pkg.Extends = dbPkg.Extends.String
+	}
+
+	labels := dbPkg.GetLabels()
+	for _, tag := range strings.Split(labels["tags"], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			pkg.Tags = append(pkg.Tags, tag)
+		}
+	}
+	if labels["enabled"] == "false" {
+		pkg.Enabled = false
+	}
+	if !dbPkg.CreatedAt.IsZero() {
+		pkg.CreatedAt = &dbPkg.CreatedAt
+	}
+	if !dbPkg.UpdatedAt.IsZero() {
+		pkg.UpdatedAt = &dbPkg.UpdatedAt
+	}
+	return pkg
+}
+
+// ResolvedPackage is an nvim package with its extends chain flattened.
+type ResolvedPackage struct {
+	// Package is the merged result. Its Extends field is cleared since
+	// inherited plugins have already been applied.
+	Package *nvimpackage.Package
+
+	// Chain lists the packages that contributed, starting with the requested
+	// package and ending at the root of the extends chain.
+	Chain []string
+}
+
+// Inherited returns the ancestors of the resolved package, nearest first.
+func (r *ResolvedPackage) Inherited() []string {
+	if len(r.Chain) <= 1 {
+		return nil
+	}
+	return r.Chain[1:]
+}
+
+// ResolvePackage walks the extends chain of the named package and merges it
+// into a single package, applying ancestors first so each layer builds on
+// the one it extends.
+//
+// Plugins and Tags are concatenated parent-first with duplicates dropped; a
+// plugin entry prefixed with "-" removes that plugin inherited from an
+// ancestor (e.g. "-copilot"). Description and Category come from the nearest
+// package that sets them. Name and Enabled come from the requested package.
+//
+// A cycle in the chain is an error naming the packages involved.
+func ResolvePackage(name string, lookup PackageLookup) (*ResolvedPackage, error) {
+	var chain []*nvimpackage.Package
+	var names []string
+	seen := make(map[string]bool)
+
+	for current := name; current != ""; {
+		if seen[current] {
+			return nil, fmt.Errorf("nvim package extends cycle: %s -> %s", strings.Join(names, " -> "), current)
+		}
+		seen[current] = true
+
+		p, err := lookup(current)
+		if err != nil {
+			if len(names) > 0 {
+				return nil, fmt.Errorf("package '%s' extends '%s': %w", names[len(names)-1], current, err)
+			}
+			return nil, err
+		}
+		chain = append(chain, p)
+		names = append(names, current)
+		current = p.Extends
+	}
+
+	merged := &nvimpackage.Package{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		p := chain[i]
+		if p.Description != "" {
+			merged.Description = p.Description
+		}
+		if p.Category != "" {
+			merged.Category = p.Category
+		}
+		merged.Plugins = listmerge.Merge(merged.Plugins, p.Plugins)
+		merged.Tags = listmerge.Merge(merged.Tags, p.Tags)
+	}
+	merged.Name = chain[0].Name
+	merged.Enabled = chain[0].Enabled
+	merged.CreatedAt = chain[0].CreatedAt
+	merged.UpdatedAt = chain[0].UpdatedAt
+
+	return &ResolvedPackage{Package: merged, Chain: names}, nil
+}
+
+// MissingPlugins returns the plugin names, ignoring any "-" removal prefix,
+// for which exists reports false. Order follows names.
+func MissingPlugins(names []string, exists func(name string) bool) []string {
+	var missing []string
+	for _, name := range names {
+		name = strings.TrimPrefix(name, "-")
+		if name != "" && !exists(name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,109 @@
+package nvimbridge
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"devopsmaestro/models"
+
+	nvimpackage "github.com/rmkohlman/MaestroNvim/nvimops/package"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapPackageStore map[string]*models.NvimPackageDB
+
+func (m mapPackageStore) GetPackage(name string) (*models.NvimPackageDB, error) {
+	if p, ok := m[name]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("package not found: %s", name)
+}
+
+func storedPackage(t *testing.T, name, extends string, plugins ...string) *models.NvimPackageDB {
+	t.Helper()
+	p := &models.NvimPackageDB{Name: name}
+	if extends != "" {
+		p.Extends = sql.NullString{String: extends, Valid: true}
+	}
+	require.NoError(t, p.SetPlugins(plugins))
+	return p
+}
+
+func TestResolvePackage_Layers(t *testing.T) {
+	store := mapPackageStore{
+		"personal": storedPackage(t, "personal", "maestro-go", "-copilot", "my-plugin", "telescope"),
+	}
+
+	res, err := ResolvePackage("personal", NewPackageLookup(store))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"personal", "maestro-go", "maestro"}, res.Chain[:3])
+	assert.Equal(t, res.Chain[1:], res.Inherited())
+	assert.NotContains(t, res.Package.Plugins, "copilot", "removal entry should drop inherited plugin")
+	assert.Contains(t, res.Package.Plugins, "nvim-dap-go", "parent plugins should be inherited")
+	assert.Equal(t, "my-plugin", res.Package.Plugins[len(res.Package.Plugins)-1])
+	assert.Empty(t, res.Package.Extends)
+
+	seen := make(map[string]bool)
+	for _, p := range res.Package.Plugins {
+		assert.False(t, seen[p], "duplicate plugin %s", p)
+		seen[p] = true
+	}
+}
+
+func TestResolvePackage_StoreShadowsLibrary(t *testing.T) {
+	store := mapPackageStore{"core": storedPackage(t, "core", "", "telescope")}
+
+	res, err := ResolvePackage("core", NewPackageLookup(store))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"telescope"}, res.Package.Plugins)
+
+	res, err = ResolvePackage("core", NewPackageLookup(nil))
+	require.NoError(t, err)
+	assert.Greater(t, len(res.Package.Plugins), 1)
+}
+
+func TestResolvePackage_Errors(t *testing.T) {
+	cycle := mapPackageStore{
+		"a": storedPackage(t, "a", "b", "telescope"),
+		"b": storedPackage(t, "b", "a", "treesitter"),
+	}
+	_, err := ResolvePackage("a", NewPackageLookup(cycle))
+	assert.ErrorContains(t, err, "a -> b -> a")
+
+	missing := mapPackageStore{"a": storedPackage(t, "a", "ghost", "telescope")}
+	_, err = ResolvePackage("a", NewPackageLookup(missing))
+	assert.ErrorContains(t, err, "'a' extends 'ghost'")
+
+	_, err = ResolvePackage("nope", NewPackageLookup(nil))
+	assert.Error(t, err)
+}
+
+func TestResolvePackage_Metadata(t *testing.T) {
+	lookup := func(name string) (*nvimpackage.Package, error) {
+		switch name {
+		case "base":
+			return &nvimpackage.Package{Name: "base", Description: "Base", Category: "core", Tags: []string{"lsp"}, Plugins: []string{"a"}}, nil
+		case "child":
+			return &nvimpackage.Package{Name: "child", Extends: "base", Description: "Child", Tags: []string{"go", "lsp"}, Plugins: []string{"b"}, Enabled: true}, nil
+		}
+		return nil, fmt.Errorf("not found: %s", name)
+	}
+
+	res, err := ResolvePackage("child", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "child", res.Package.Name)
+	assert.Equal(t, "Child", res.Package.Description)
+	assert.Equal(t, "core", res.Package.Category)
+	assert.Equal(t, []string{"lsp", "go"}, res.Package.Tags)
+	assert.Equal(t, []string{"a", "b"}, res.Package.Plugins)
+	assert.True(t, res.Package.Enabled)
+}
+
+func TestMissingPlugins(t *testing.T) {
+	known := map[string]bool{"telescope": true, "copilot": true}
+	missing := MissingPlugins([]string{"telescope", "-copilot", "-ghost", "nope"}, func(name string) bool { return known[name] })
+	assert.Equal(t, []string{"ghost", "nope"}, missing)
+}
@@ -0,0 +1,72 @@
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"devopsmaestro/pkg/githubapi"
+)
+
+// GitHubResolver resolves refs and commit ranges with the GitHub REST API.
+type GitHubResolver struct {
+	API *githubapi.Client
+}
+
+// Compile-time interface check.
+var _ Resolver = (*GitHubResolver)(nil)
+
+// NewGitHubResolver creates a resolver for api.github.com.
+func NewGitHubResolver(token string) *GitHubResolver {
+	return &GitHubResolver{API: githubapi.NewClient(token)}
+}
+
+// Resolve returns the commit ref points to in repo ("owner/name"). An empty
+// ref resolves the repository's default branch.
+func (r *GitHubResolver) Resolve(ctx context.Context, repo, ref string) (Pin, error) {
+	branch := ref
+	if ref == "" {
+		var info struct {
+			DefaultBranch string `json:"default_branch"`
+		}
+		if err := r.API.Get(ctx, "/repos/"+repo, &info); err != nil {
+			return Pin{}, err
+		}
+		ref, branch = info.DefaultBranch, info.DefaultBranch
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := r.API.Get(ctx, "/repos/"+repo+"/commits/"+url.PathEscape(ref), &commit); err != nil {
+		return Pin{}, err
+	}
+	if commit.SHA == "" {
+		return Pin{}, fmt.Errorf("%s: no commit found for %q", repo, ref)
+	}
+	return Pin{Branch: branch, Commit: commit.SHA}, nil
+}
+
+// Compare lists the commits in repo between base and head.
+func (r *GitHubResolver) Compare(ctx context.Context, repo, base, head string) (*Changes, error) {
+	var cmp struct {
+		TotalCommits int `json:"total_commits"`
+		Commits      []struct {
+			SHA    string `json:"sha"`
+			Commit struct {
+				Message string `json:"message"`
+			} `json:"commit"`
+		} `json:"commits"`
+	}
+	if err := r.API.Get(ctx, fmt.Sprintf("/repos/%s/compare/%s...%s", repo, base, head), &cmp); err != nil {
+		return nil, err
+	}
+
+	changes := &Changes{Total: cmp.TotalCommits}
+	for _, c := range cmp.Commits {
+		subject, _, _ := strings.Cut(c.Commit.Message, "\n")
+		changes.Commits = append(changes.Commits, Commit{SHA: c.SHA, Subject: subject})
+	}
+	return changes, nil
+}
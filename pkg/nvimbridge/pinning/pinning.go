@@ -0,0 +1,221 @@
+// Package pinning pins nvp plugins to exact commits for reproducible
+// environments.
+//
+// Pins live in a lazy-lock.json file (the format lazy.nvim itself writes),
+// keyed by the short repository name, so the same file can be imported from
+// or copied into a Neovim config. Commits are resolved from the plugin's tag
+// or branch through a Resolver (normally the GitHub API), or imported from an
+// existing lazy-lock.json.
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Pin is a plugin's locked branch and commit.
+type Pin struct {
+	Branch string
+	Commit string
+}
+
+// Commit is a single commit in a change summary.
+type Commit struct {
+	SHA     string
+	Subject string
+}
+
+// Changes summarizes the commits between two pins.
+type Changes struct {
+	// Total is the number of commits in the range; Commits may be truncated
+	// by the resolver.
+	Total   int
+	Commits []Commit
+}
+
+// Resolver looks up commits for a repository ("owner/name").
+type Resolver interface {
+	// Resolve returns the commit ref points to. An empty ref means the
+	// repository's default branch.
+	Resolve(ctx context.Context, repo, ref string) (Pin, error)
+
+	// Compare lists the commits after base up to and including head.
+	Compare(ctx context.Context, repo, base, head string) (*Changes, error)
+}
+
+// Status describes what Lock did with a plugin.
+type Status string
+
+const (
+	StatusPinned   Status = "pinned"   // newly pinned
+	StatusKept     Status = "kept"     // already pinned, left unchanged
+	StatusImported Status = "imported" // pin taken from an imported lock file
+	StatusSkipped  Status = "skipped"  // no pin (no GitHub repo, or not in the import)
+	StatusFailed   Status = "failed"   // resolution failed
+)
+
+// Result is the outcome of locking one plugin.
+type Result struct {
+	Plugin string
+	Key    string
+	Status Status
+	Pin    Pin
+	Err    error
+}
+
+// ShortName returns the lock file key for a repo: the last path segment,
+// matching lazy.nvim (e.g. "nvim-telescope/telescope.nvim" → "telescope.nvim").
+func ShortName(repo string) string {
+	repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	if i := strings.LastIndex(repo, "/"); i >= 0 {
+		return repo[i+1:]
+	}
+	return repo
+}
+
+// RefFor returns the git ref a plugin tracks: its version when that names a
+// tag or commit, otherwise its branch. Semver ranges such as "^1.0" or "*"
+// are resolved by lazy.nvim at install time, so they fall back to the branch.
+func RefFor(p *plugin.Plugin) string {
+	if p.Version != "" && !strings.ContainsAny(p.Version, "*^~<>= ") {
+		return p.Version
+	}
+	return p.Branch
+}
+
+// Lock pins every enabled plugin. Existing pins in current are kept, so
+// re-running Lock is stable; use Update to move a pin. Pins for plugins that
+// are no longer enabled are dropped. current may be nil.
+func Lock(ctx context.Context, plugins []*plugin.Plugin, current *plugin.LockFile, resolver Resolver) (*plugin.LockFile, []Result) {
+	return lock(plugins, current, func(p *plugin.Plugin, key string) Result {
+		pin, err := resolver.Resolve(ctx, p.Repo, RefFor(p))
+		if err != nil {
+			return Result{Status: StatusFailed, Err: err}
+		}
+		return Result{Status: StatusPinned, Pin: pin}
+	})
+}
+
+// Import pins enabled plugins from an existing lazy-lock.json, such as the
+// one lazy.nvim maintains in a Neovim config. Imported entries replace
+// existing pins; entries for unknown plugins are ignored, and plugins missing
+// from source keep their pins in current (which may be nil).
+func Import(plugins []*plugin.Plugin, source, current *plugin.LockFile) (*plugin.LockFile, []Result) {
+	return lock(plugins, nil, func(p *plugin.Plugin, key string) Result {
+		if pin, ok := lookup(source, key); ok {
+			return Result{Status: StatusImported, Pin: pin}
+		}
+		if pin, ok := lookup(current, key); ok {
+			return Result{Status: StatusKept, Pin: pin}
+		}
+		return Result{Status: StatusSkipped, Err: fmt.Errorf("not in imported lock file")}
+	})
+}
+
+// lock walks enabled plugins in name order, keeping pins found in current
+// and asking pin for the rest.
+func lock(plugins []*plugin.Plugin, current *plugin.LockFile, pin func(p *plugin.Plugin, key string) Result) (*plugin.LockFile, []Result) {
+	lf := plugin.NewLockFile()
+	var results []Result
+
+	sorted := append([]*plugin.Plugin(nil), plugins...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, p := range sorted {
+		if !p.Enabled {
+			continue
+		}
+		key := ShortName(p.Repo)
+		if !strings.Contains(p.Repo, "/") {
+			results = append(results, Result{Plugin: p.Name, Key: key, Status: StatusSkipped, Err: fmt.Errorf("no GitHub repo")})
+			continue
+		}
+
+		res := Result{Status: StatusKept}
+		if existing, ok := lookup(current, key); ok {
+			res.Pin = existing
+		} else {
+			res = pin(p, key)
+		}
+		res.Plugin, res.Key = p.Name, key
+		if res.Status != StatusFailed && res.Status != StatusSkipped {
+			lf.Entries[key] = plugin.LockEntry{Branch: res.Pin.Branch, Commit: res.Pin.Commit}
+		}
+		results = append(results, res)
+	}
+	return lf, results
+}
+
+func lookup(lf *plugin.LockFile, key string) (Pin, bool) {
+	if lf == nil {
+		return Pin{}, false
+	}
+	entry, ok := lf.Entries[key]
+	if !ok || entry.Commit == "" {
+		return Pin{}, false
+	}
+	return Pin{Branch: entry.Branch, Commit: entry.Commit}, true
+}
+
+// Update re-resolves one plugin's pin and records it in lf. It returns the
+// previous pin (zero if the plugin was unpinned), the new pin, and the
+// commits between them when both exist and differ.
+func Update(ctx context.Context, p *plugin.Plugin, lf *plugin.LockFile, resolver Resolver) (old, updated Pin, changes *Changes, err error) {
+	key := ShortName(p.Repo)
+	if !strings.Contains(p.Repo, "/") {
+		return Pin{}, Pin{}, nil, fmt.Errorf("plugin %s has no GitHub repo to resolve", p.Name)
+	}
+	old, _ = lookup(lf, key)
+
+	updated, err = resolver.Resolve(ctx, p.Repo, RefFor(p))
+	if err != nil {
+		return old, Pin{}, nil, fmt.Errorf("failed to resolve %s: %w", p.Repo, err)
+	}
+	lf.Entries[key] = plugin.LockEntry{Branch: updated.Branch, Commit: updated.Commit}
+
+	if old.Commit != "" && old.Commit != updated.Commit {
+		changes, err = resolver.Compare(ctx, p.Repo, old.Commit, updated.Commit)
+		if err != nil {
+			// The pin moved; a missing changelog is not fatal.
+			return old, updated, nil, nil
+		}
+	}
+	return old, updated, changes, nil
+}
+
+// Verify reports enabled plugins without a pin and pins for plugins that
+// are not enabled. Unlike LockFile.Verify it does not compare commits with
+// plugin versions, since pins are exact commits resolved from those versions.
+func Verify(plugins []*plugin.Plugin, lf *plugin.LockFile) []plugin.LockMismatch {
+	var mismatches []plugin.LockMismatch
+	current := make(map[string]bool)
+	for _, p := range plugins {
+		if !p.Enabled || !strings.Contains(p.Repo, "/") {
+			continue
+		}
+		key := ShortName(p.Repo)
+		current[key] = true
+		if _, ok := lookup(lf, key); !ok {
+			mismatches = append(mismatches, plugin.LockMismatch{Plugin: key, Field: "missing_in_lock"})
+		}
+	}
+	for key := range lf.Entries {
+		if !current[key] {
+			mismatches = append(mismatches, plugin.LockMismatch{Plugin: key, Field: "missing_in_config"})
+		}
+	}
+	sort.Slice(mismatches, func(i, j int) bool { return mismatches[i].Plugin < mismatches[j].Plugin })
+	return mismatches
+}
+
+// Short abbreviates a commit SHA for display.
+func Short(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
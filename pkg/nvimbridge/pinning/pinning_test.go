@@ -0,0 +1,166 @@
+package pinning
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"devopsmaestro/pkg/githubapi"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct {
+	pins    map[string]Pin
+	changes *Changes
+	calls   int
+}
+
+func (f *fakeResolver) Resolve(ctx context.Context, repo, ref string) (Pin, error) {
+	f.calls++
+	pin, ok := f.pins[repo+"@"+ref]
+	if !ok {
+		return Pin{}, fmt.Errorf("unknown ref %s@%s", repo, ref)
+	}
+	return pin, nil
+}
+
+func (f *fakeResolver) Compare(ctx context.Context, repo, base, head string) (*Changes, error) {
+	return f.changes, nil
+}
+
+func testPlugins() []*plugin.Plugin {
+	return []*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Branch: "master", Enabled: true},
+		{Name: "harpoon", Repo: "ThePrimeagen/harpoon", Version: "v2.0", Enabled: true},
+		{Name: "disabled", Repo: "acme/disabled", Enabled: false},
+		{Name: "local", Repo: "local", Enabled: true},
+	}
+}
+
+func TestShortNameAndRefFor(t *testing.T) {
+	assert.Equal(t, "telescope.nvim", ShortName("nvim-telescope/telescope.nvim"))
+	assert.Equal(t, "repo", ShortName("owner/repo.git"))
+	assert.Equal(t, "local", ShortName("local"))
+
+	assert.Equal(t, "v2.0", RefFor(&plugin.Plugin{Version: "v2.0", Branch: "main"}))
+	assert.Equal(t, "main", RefFor(&plugin.Plugin{Version: "^1.0", Branch: "main"}))
+	assert.Equal(t, "", RefFor(&plugin.Plugin{Version: "*"}))
+}
+
+func TestLock(t *testing.T) {
+	resolver := &fakeResolver{pins: map[string]Pin{
+		"nvim-telescope/telescope.nvim@master": {Branch: "master", Commit: "aaa111"},
+		"ThePrimeagen/harpoon@v2.0":            {Branch: "v2.0", Commit: "bbb222"},
+	}}
+	current := plugin.NewLockFile()
+	current.Entries["harpoon"] = plugin.LockEntry{Branch: "v2.0", Commit: "old000"}
+	current.Entries["disabled"] = plugin.LockEntry{Commit: "ddd444"}
+
+	lf, results := Lock(context.Background(), testPlugins(), current, resolver)
+
+	assert.Equal(t, 1, resolver.calls, "existing pins should not be re-resolved")
+	assert.Equal(t, "aaa111", lf.Entries["telescope.nvim"].Commit)
+	assert.Equal(t, "old000", lf.Entries["harpoon"].Commit)
+	assert.NotContains(t, lf.Entries, "disabled")
+
+	require.Len(t, results, 3)
+	assert.Equal(t, StatusKept, results[0].Status)
+	assert.Equal(t, StatusSkipped, results[1].Status)
+	assert.Equal(t, StatusPinned, results[2].Status)
+}
+
+func TestLock_Failure(t *testing.T) {
+	lf, results := Lock(context.Background(), testPlugins()[:1], nil, &fakeResolver{})
+	assert.Empty(t, lf.Entries)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusFailed, results[0].Status)
+	assert.Error(t, results[0].Err)
+}
+
+func TestImport(t *testing.T) {
+	source := plugin.NewLockFile()
+	source.Entries["telescope.nvim"] = plugin.LockEntry{Branch: "master", Commit: "new111"}
+	source.Entries["unrelated"] = plugin.LockEntry{Commit: "zzz"}
+	current := plugin.NewLockFile()
+	current.Entries["telescope.nvim"] = plugin.LockEntry{Commit: "old111"}
+	current.Entries["harpoon"] = plugin.LockEntry{Commit: "old222"}
+
+	lf, results := Import(testPlugins(), source, current)
+
+	assert.Equal(t, "new111", lf.Entries["telescope.nvim"].Commit, "import should replace existing pins")
+	assert.Equal(t, "old222", lf.Entries["harpoon"].Commit)
+	assert.NotContains(t, lf.Entries, "unrelated")
+	assert.Equal(t, StatusKept, results[0].Status)
+	assert.Equal(t, StatusImported, results[2].Status)
+}
+
+func TestUpdate(t *testing.T) {
+	p := testPlugins()[0]
+	resolver := &fakeResolver{
+		pins:    map[string]Pin{"nvim-telescope/telescope.nvim@master": {Branch: "master", Commit: "new111"}},
+		changes: &Changes{Total: 2, Commits: []Commit{{SHA: "c1", Subject: "fix"}, {SHA: "new111", Subject: "feat"}}},
+	}
+	lf := plugin.NewLockFile()
+	lf.Entries["telescope.nvim"] = plugin.LockEntry{Branch: "master", Commit: "old111"}
+
+	old, updated, changes, err := Update(context.Background(), p, lf, resolver)
+	require.NoError(t, err)
+	assert.Equal(t, "old111", old.Commit)
+	assert.Equal(t, "new111", updated.Commit)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.Total)
+	assert.Equal(t, "new111", lf.Entries["telescope.nvim"].Commit)
+
+	_, _, _, err = Update(context.Background(), testPlugins()[3], lf, resolver)
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	lf := plugin.NewLockFile()
+	lf.Entries["telescope.nvim"] = plugin.LockEntry{Commit: "aaa"}
+	lf.Entries["stale"] = plugin.LockEntry{Commit: "bbb"}
+
+	mismatches := Verify(testPlugins(), lf)
+	require.Len(t, mismatches, 2)
+	assert.Equal(t, "harpoon", mismatches[0].Plugin)
+	assert.Equal(t, "missing_in_lock", mismatches[0].Field)
+	assert.Equal(t, "stale", mismatches[1].Plugin)
+	assert.Equal(t, "missing_in_config", mismatches[1].Field)
+}
+
+func TestGitHubResolver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/acme/tool":
+			fmt.Fprint(w, `{"default_branch": "main"}`)
+		case "/repos/acme/tool/commits/main":
+			fmt.Fprint(w, `{"sha": "abc1234567"}`)
+		case "/repos/acme/tool/compare/aaa...bbb":
+			fmt.Fprint(w, `{"total_commits": 1, "commits": [{"sha": "bbb", "commit": {"message": "feat: thing\n\nbody"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	r := &GitHubResolver{API: &githubapi.Client{BaseURL: srv.URL, Token: "secret", HTTPClient: srv.Client()}}
+
+	pin, err := r.Resolve(context.Background(), "acme/tool", "")
+	require.NoError(t, err)
+	assert.Equal(t, Pin{Branch: "main", Commit: "abc1234567"}, pin)
+	assert.Equal(t, "abc1234", Short(pin.Commit))
+
+	changes, err := r.Compare(context.Background(), "acme/tool", "aaa", "bbb")
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes.Total)
+	assert.Equal(t, "feat: thing", changes.Commits[0].Subject)
+
+	_, err = r.Resolve(context.Background(), "acme/tool", "nope")
+	assert.ErrorContains(t, err, "404")
+}
@@ -0,0 +1,231 @@
+// Package profile manages named nvp profiles: independent plugin sets, each
+// with its own theme, such as "minimal", "writing", and "full-ide".
+//
+// Profiles live as YAML files under <nvp dir>/profiles/, and the active one
+// is recorded in profiles/.active. Each profile is generated into its own
+// Neovim config directory, ~/.config/nvp-<name>, so it can be started with
+// NVIM_APPNAME=nvp-<name> alongside the default config.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AppNamePrefix prefixes a profile's name to form its NVIM_APPNAME.
+const AppNamePrefix = "nvp-"
+
+// activeFile records the active profile's name inside the profiles dir.
+const activeFile = ".active"
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Profile is a named plugin set with its own theme.
+type Profile struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Theme       string   `yaml:"theme,omitempty"`
+	Plugins     []string `yaml:"plugins,omitempty"`
+}
+
+// Has reports whether the profile enables the named plugin.
+func (p *Profile) Has(name string) bool {
+	for _, n := range p.Plugins {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetEnabled adds or removes a plugin from the profile, keeping the list
+// sorted. It reports whether the profile changed.
+func (p *Profile) SetEnabled(name string, enabled bool) bool {
+	if p.Has(name) == enabled {
+		return false
+	}
+	if enabled {
+		p.Plugins = append(p.Plugins, name)
+		sort.Strings(p.Plugins)
+		return true
+	}
+	kept := p.Plugins[:0]
+	for _, n := range p.Plugins {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	p.Plugins = kept
+	return true
+}
+
+// AppName returns the NVIM_APPNAME the profile is generated for.
+func (p *Profile) AppName() string {
+	return AppNamePrefix + p.Name
+}
+
+// ConfigDir returns the Neovim config directory for the profile under
+// configHome (normally $XDG_CONFIG_HOME or ~/.config).
+func (p *Profile) ConfigDir(configHome string) string {
+	return filepath.Join(configHome, p.AppName())
+}
+
+// ValidateName checks that name is usable as a profile, file, and
+// NVIM_APPNAME component.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// Store persists profiles as YAML files in a directory.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a store rooted at dir (typically <nvp dir>/profiles).
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store's directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+".yaml")
+}
+
+// Get loads a profile by name.
+func (s *Store) Get(name string) (*Profile, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("profile %q not found", name)
+		}
+		return nil, fmt.Errorf("failed to read profile %q: %w", name, err)
+	}
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse profile %q: %w", name, err)
+	}
+	p.Name = name
+	return &p, nil
+}
+
+// Exists reports whether a profile with the given name is stored.
+func (s *Store) Exists(name string) bool {
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}
+
+// List returns all profiles sorted by name.
+func (s *Store) List() ([]*Profile, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	var profiles []*Profile
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		p, err := s.Get(strings.TrimSuffix(e.Name(), ".yaml"))
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Save writes a profile, creating the store directory if needed.
+func (s *Store) Save(p *Profile) error {
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+	sort.Strings(p.Plugins)
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode profile %q: %w", p.Name, err)
+	}
+	if err := os.WriteFile(s.path(p.Name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write profile %q: %w", p.Name, err)
+	}
+	return nil
+}
+
+// Delete removes a profile, clearing it as active if it was.
+func (s *Store) Delete(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile %q not found", name)
+		}
+		return fmt.Errorf("failed to delete profile %q: %w", name, err)
+	}
+	if active, _ := s.ActiveName(); active == name {
+		return s.ClearActive()
+	}
+	return nil
+}
+
+// ActiveName returns the active profile's name, or "" when none is active.
+func (s *Store) ActiveName() (string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, activeFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read active profile: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Active returns the active profile, or nil when none is active.
+func (s *Store) Active() (*Profile, error) {
+	name, err := s.ActiveName()
+	if err != nil || name == "" {
+		return nil, err
+	}
+	return s.Get(name)
+}
+
+// SetActive makes an existing profile the active one.
+func (s *Store) SetActive(name string) error {
+	if !s.Exists(name) {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if err := os.WriteFile(filepath.Join(s.dir, activeFile), []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write active profile: %w", err)
+	}
+	return nil
+}
+
+// ClearActive returns to the default (profile-less) configuration.
+func (s *Store) ClearActive() error {
+	if err := os.Remove(filepath.Join(s.dir, activeFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear active profile: %w", err)
+	}
+	return nil
+}
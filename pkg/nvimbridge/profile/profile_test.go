@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "profiles"))
+
+	profiles, err := s.List()
+	require.NoError(t, err)
+	assert.Empty(t, profiles)
+
+	require.NoError(t, s.Save(&Profile{Name: "writing", Theme: "catppuccin-latte", Plugins: []string{"zen-mode", "markdown-preview"}}))
+	require.NoError(t, s.Save(&Profile{Name: "minimal"}))
+
+	p, err := s.Get("writing")
+	require.NoError(t, err)
+	assert.Equal(t, "catppuccin-latte", p.Theme)
+	assert.Equal(t, []string{"markdown-preview", "zen-mode"}, p.Plugins)
+
+	profiles, err = s.List()
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "minimal", profiles[0].Name)
+
+	_, err = s.Get("missing")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestStoreActive(t *testing.T) {
+	s := NewStore(t.TempDir())
+
+	active, err := s.Active()
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	assert.Error(t, s.SetActive("writing"))
+
+	require.NoError(t, s.Save(&Profile{Name: "writing"}))
+	require.NoError(t, s.SetActive("writing"))
+	active, err = s.Active()
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, "writing", active.Name)
+
+	// Deleting the active profile clears it
+	require.NoError(t, s.Delete("writing"))
+	name, err := s.ActiveName()
+	require.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestSetEnabled(t *testing.T) {
+	p := &Profile{Name: "full-ide", Plugins: []string{"telescope"}}
+
+	assert.True(t, p.SetEnabled("lspconfig", true))
+	assert.False(t, p.SetEnabled("lspconfig", true))
+	assert.Equal(t, []string{"lspconfig", "telescope"}, p.Plugins)
+
+	assert.True(t, p.SetEnabled("telescope", false))
+	assert.False(t, p.SetEnabled("telescope", false))
+	assert.Equal(t, []string{"lspconfig"}, p.Plugins)
+}
+
+func TestNamesAndDirs(t *testing.T) {
+	assert.NoError(t, ValidateName("full-ide"))
+	assert.Error(t, ValidateName("Full IDE"))
+	assert.Error(t, ValidateName("../etc"))
+
+	p := &Profile{Name: "writing"}
+	assert.Equal(t, "nvp-writing", p.AppName())
+	assert.Equal(t, filepath.Join("/home/u/.config", "nvp-writing"), p.ConfigDir("/home/u/.config"))
+}
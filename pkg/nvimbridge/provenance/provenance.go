@@ -0,0 +1,161 @@
+// Package provenance records where synced plugins came from: the sync
+// source, its version and commit when known, and when the plugin was last
+// synced. Records live in <nvp dir>/provenance.yaml, keyed by plugin name,
+// next to the plugin store rather than inside it so editing a plugin keeps
+// its origin.
+package provenance
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the provenance file inside the nvp directory.
+const FileName = "provenance.yaml"
+
+// Annotation keys under which Annotate shows a record on a plugin.
+const (
+	AnnotationSource   = "nvp.devopsmaestro.io/source"
+	AnnotationVersion  = "nvp.devopsmaestro.io/source-version"
+	AnnotationCommit   = "nvp.devopsmaestro.io/source-commit"
+	AnnotationSyncedAt = "nvp.devopsmaestro.io/synced-at"
+)
+
+// Record is the origin of one plugin.
+type Record struct {
+	Source   string    `yaml:"source" json:"source"`
+	Version  string    `yaml:"version,omitempty" json:"version,omitempty"`
+	Commit   string    `yaml:"commit,omitempty" json:"commit,omitempty"`
+	SyncedAt time.Time `yaml:"syncedAt" json:"syncedAt"`
+}
+
+// Revisioner is implemented by sync source handlers that know the version
+// and commit their last sync read, such as git sources.
+type Revisioner interface {
+	Revision() (version, commit string)
+}
+
+// Store reads and writes the provenance file.
+type Store struct {
+	path string
+}
+
+// NewStore returns the store for the nvp directory dir.
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Load returns every record keyed by plugin name. A missing file means no
+// records.
+func (s *Store) Load() (map[string]Record, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]Record{}, nil
+		}
+		return nil, fmt.Errorf("failed to read provenance: %w", err)
+	}
+	records := map[string]Record{}
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	return records, nil
+}
+
+// Get returns the record of a plugin.
+func (s *Store) Get(name string) (Record, bool, error) {
+	records, err := s.Load()
+	if err != nil {
+		return Record{}, false, err
+	}
+	r, ok := records[name]
+	return r, ok, nil
+}
+
+// Set records r as the origin of the named plugins.
+func (s *Store) Set(names []string, r Record) error {
+	if len(names) == 0 {
+		return nil
+	}
+	records, err := s.Load()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		records[name] = r
+	}
+	return s.write(records)
+}
+
+// Forget drops the records of the named plugins.
+func (s *Store) Forget(names ...string) error {
+	records, err := s.Load()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, name := range names {
+		if _, ok := records[name]; ok {
+			delete(records, name)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.write(records)
+}
+
+// FromSource returns the names of the plugins synced from source, sorted.
+func (s *Store) FromSource(source string) ([]string, error) {
+	records, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name, r := range records {
+		if r.Source == source {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) write(records map[string]Record) error {
+	data, err := yaml.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write provenance: %w", err)
+	}
+	return nil
+}
+
+// Annotate adds r to a plugin's YAML form as metadata annotations.
+func Annotate(py *plugin.PluginYAML, r Record) {
+	if py.Metadata.Annotations == nil {
+		py.Metadata.Annotations = make(map[string]string)
+	}
+	py.Metadata.Annotations[AnnotationSource] = r.Source
+	if r.Version != "" {
+		py.Metadata.Annotations[AnnotationVersion] = r.Version
+	}
+	if r.Commit != "" {
+		py.Metadata.Annotations[AnnotationCommit] = r.Commit
+	}
+	if !r.SyncedAt.IsZero() {
+		py.Metadata.Annotations[AnnotationSyncedAt] = r.SyncedAt.UTC().Format(time.RFC3339)
+	}
+}
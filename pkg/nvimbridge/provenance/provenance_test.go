@@ -0,0 +1,56 @@
+package provenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	records, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	synced := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Set([]string{"telescope", "harpoon"}, Record{Source: "mycorp", Version: "v1", Commit: "abc123", SyncedAt: synced}))
+	require.NoError(t, store.Set([]string{"oil"}, Record{Source: "lazyvim", SyncedAt: synced}))
+
+	r, ok, err := store.Get("telescope")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, Record{Source: "mycorp", Version: "v1", Commit: "abc123", SyncedAt: synced}, r)
+
+	names, err := store.FromSource("mycorp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"harpoon", "telescope"}, names)
+
+	// A re-sync from another source takes over the plugin
+	require.NoError(t, store.Set([]string{"harpoon"}, Record{Source: "lazyvim", SyncedAt: synced}))
+	names, err = store.FromSource("lazyvim")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"harpoon", "oil"}, names)
+
+	require.NoError(t, store.Forget("telescope", "missing"))
+	_, ok, err = store.Get("telescope")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	names, err = store.FromSource("mycorp")
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestAnnotate(t *testing.T) {
+	py := (&plugin.Plugin{Name: "telescope", Repo: "nvim-telescope/telescope.nvim"}).ToYAML()
+	Annotate(py, Record{Source: "mycorp", Commit: "abc123", SyncedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)})
+
+	assert.Equal(t, map[string]string{
+		AnnotationSource:   "mycorp",
+		AnnotationCommit:   "abc123",
+		AnnotationSyncedAt: "2026-10-01T12:00:00Z",
+	}, py.Metadata.Annotations)
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/githubapi"
+)
+
+// DefaultReadmeTTL is how long cached READMEs are reused.
+const DefaultReadmeTTL = 7 * 24 * time.Hour
+
+// maxReadmeBytes caps how much of a README is kept for searching.
+const maxReadmeBytes = 64 * 1024
+
+// ReadmeFetcher downloads plugin READMEs from GitHub, caching them on disk
+// so repeated searches stay offline.
+type ReadmeFetcher struct {
+	API *githubapi.Client
+	// CacheDir stores fetched READMEs; empty disables caching.
+	CacheDir string
+	// TTL is how long cached READMEs are reused (default DefaultReadmeTTL).
+	TTL time.Duration
+}
+
+// NewReadmeFetcher creates a fetcher for api.github.com caching in cacheDir.
+func NewReadmeFetcher(token, cacheDir string) *ReadmeFetcher {
+	return &ReadmeFetcher{API: githubapi.NewClient(token), CacheDir: cacheDir}
+}
+
+// Readme returns the README of repo ("owner/name").
+func (f *ReadmeFetcher) Readme(ctx context.Context, repo string) (string, error) {
+	cachePath := ""
+	if f.CacheDir != "" {
+		cachePath = filepath.Join(f.CacheDir, strings.ReplaceAll(repo, "/", "__")+".md")
+		ttl := f.TTL
+		if ttl == 0 {
+			ttl = DefaultReadmeTTL
+		}
+		if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < ttl {
+			data, err := os.ReadFile(cachePath)
+			if err == nil {
+				return string(data), nil
+			}
+		}
+	}
+
+	data, err := f.API.GetRaw(ctx, "/repos/"+repo+"/readme", githubapi.AcceptRaw, maxReadmeBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(f.CacheDir, 0755); err == nil {
+			_ = os.WriteFile(cachePath, data, 0644)
+		}
+	}
+	return string(data), nil
+}
+
+// FetchReadmes fills in Readme for every entry with a GitHub repo, fetching
+// up to workers READMEs at a time. Failures are returned per repo and leave
+// the entry's Readme empty.
+func (idx *Index) FetchReadmes(ctx context.Context, f *ReadmeFetcher, workers int) map[string]error {
+	if workers < 1 {
+		workers = 1
+	}
+	type job struct {
+		entry *Entry
+		text  string
+		err   error
+	}
+
+	jobs := make(chan *job)
+	done := make(chan *job)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.text, j.err = f.Readme(ctx, j.entry.Repo)
+				done <- j
+			}
+		}()
+	}
+
+	var pending []*job
+	for _, e := range idx.entries {
+		if strings.Contains(e.Repo, "/") {
+			pending = append(pending, &job{entry: e})
+		}
+	}
+	go func() {
+		for _, j := range pending {
+			jobs <- j
+		}
+		close(jobs)
+	}()
+
+	errs := make(map[string]error)
+	for range pending {
+		j := <-done
+		if j.err != nil {
+			errs[j.entry.Repo] = j.err
+			continue
+		}
+		j.entry.Readme = j.text
+	}
+	return errs
+}
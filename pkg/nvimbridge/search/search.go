@@ -0,0 +1,231 @@
+// Package search ranks nvp plugins from the embedded library, the local
+// store, and external sync sources against a free-text query.
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Origin names where an entry was found.
+const (
+	OriginStore   = "store"
+	OriginLibrary = "library"
+)
+
+// Entry is a searchable plugin.
+type Entry struct {
+	Name        string   `json:"name"`
+	Repo        string   `json:"repo"`
+	Description string   `json:"description,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	// Origin is OriginStore, OriginLibrary, or "source:<name>".
+	Origin string `json:"origin"`
+
+	// Installed and Enabled reflect the plugin's state in the local store.
+	Installed bool `json:"installed"`
+	Enabled   bool `json:"enabled"`
+
+	// Readme is the plugin's README text, when fetched.
+	Readme string `json:"-"`
+}
+
+// Result is a ranked match.
+type Result struct {
+	Entry
+	Score   int      `json:"score"`
+	Matched []string `json:"matched"`
+
+	// Snippet is README context around the first README match.
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// Field weights: a name hit outranks a tag, which outranks prose.
+const (
+	scoreNameExact   = 100
+	scoreNamePrefix  = 50
+	scoreName        = 30
+	scoreTag         = 20
+	scoreRepo        = 15
+	scoreCategory    = 10
+	scoreDescription = 8
+	scoreReadme      = 3
+)
+
+// Index merges plugins into a de-duplicated entry list. Store plugins mark
+// their name as installed; library and source entries fill in names the
+// store does not have. Earlier sources win for the same name.
+type Index struct {
+	entries []*Entry
+	byName  map[string]*Entry
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{byName: make(map[string]*Entry)}
+}
+
+// AddStore adds the plugins installed in the local store.
+func (idx *Index) AddStore(plugins []*plugin.Plugin) {
+	for _, p := range plugins {
+		e := idx.add(p.Name, p.Repo, p.Description, p.Category, p.Tags, OriginStore)
+		e.Installed = true
+		e.Enabled = p.Enabled
+	}
+}
+
+// AddPlugins adds plugins from a non-installed origin such as the library.
+func (idx *Index) AddPlugins(plugins []*plugin.Plugin, origin string) {
+	for _, p := range plugins {
+		idx.add(p.Name, p.Repo, p.Description, p.Category, p.Tags, origin)
+	}
+}
+
+// Add adds a single entry from a non-installed origin.
+func (idx *Index) Add(name, repo, description, category string, tags []string, origin string) {
+	idx.add(name, repo, description, category, tags, origin)
+}
+
+func (idx *Index) add(name, repo, description, category string, tags []string, origin string) *Entry {
+	if e, ok := idx.byName[name]; ok {
+		// Fill gaps in the stored definition from later origins.
+		if e.Description == "" {
+			e.Description = description
+		}
+		if e.Category == "" {
+			e.Category = category
+		}
+		if len(e.Tags) == 0 {
+			e.Tags = tags
+		}
+		return e
+	}
+	e := &Entry{Name: name, Repo: repo, Description: description, Category: category, Tags: tags, Origin: origin}
+	idx.entries = append(idx.entries, e)
+	idx.byName[name] = e
+	return e
+}
+
+// Entries returns the indexed entries in insertion order.
+func (idx *Index) Entries() []*Entry {
+	return idx.entries
+}
+
+// Search returns entries matching every term of query, best match first.
+// Ties go to installed plugins, then by name.
+func (idx *Index) Search(query string) []Result {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var results []Result
+	for _, e := range idx.entries {
+		if r, ok := match(e, terms); ok {
+			results = append(results, r)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Installed != b.Installed {
+			return a.Installed
+		}
+		return a.Name < b.Name
+	})
+	return results
+}
+
+func match(e *Entry, terms []string) (Result, bool) {
+	r := Result{Entry: *e}
+	name := strings.ToLower(e.Name)
+	readme := strings.ToLower(e.Readme)
+	matched := make(map[string]bool)
+	hit := func(field string, score int) {
+		r.Score += score
+		if !matched[field] {
+			matched[field] = true
+			r.Matched = append(r.Matched, field)
+		}
+	}
+
+	for _, term := range terms {
+		before := r.Score
+		switch {
+		case name == term:
+			hit("name", scoreNameExact)
+		case strings.HasPrefix(name, term):
+			hit("name", scoreNamePrefix)
+		case strings.Contains(name, term):
+			hit("name", scoreName)
+		}
+		for _, tag := range e.Tags {
+			if strings.EqualFold(tag, term) {
+				hit("tags", scoreTag)
+				break
+			}
+		}
+		if strings.Contains(strings.ToLower(e.Repo), term) {
+			hit("repo", scoreRepo)
+		}
+		if strings.EqualFold(e.Category, term) {
+			hit("category", scoreCategory)
+		}
+		if strings.Contains(strings.ToLower(e.Description), term) {
+			hit("description", scoreDescription)
+		}
+		if readme != "" && strings.Contains(readme, term) {
+			hit("readme", scoreReadme)
+			if r.Snippet == "" {
+				r.Snippet = Snippet(e.Readme, term, 80)
+			}
+		}
+		if r.Score == before {
+			return Result{}, false
+		}
+	}
+	return r, true
+}
+
+// Snippet returns about width characters of text around the first
+// case-insensitive occurrence of term, on a single line.
+func Snippet(text, term string, width int) string {
+	i := strings.Index(strings.ToLower(text), strings.ToLower(term))
+	if i < 0 {
+		return ""
+	}
+	start := i - width/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > len(text) {
+		end = len(text)
+	}
+	// Avoid splitting multi-byte runes at either edge.
+	for start > 0 && !isRuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !isRuneStart(text[end]) {
+		end++
+	}
+
+	snippet := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
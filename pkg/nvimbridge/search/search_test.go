@@ -0,0 +1,105 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"devopsmaestro/pkg/githubapi"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex() *Index {
+	idx := NewIndex()
+	idx.AddStore([]*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Description: "Fuzzy finder", Enabled: true},
+	})
+	idx.AddPlugins([]*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Category: "navigation", Tags: []string{"finder"}},
+		{Name: "fzf-lua", Repo: "ibhagwan/fzf-lua", Description: "Fuzzy finder written in Lua", Category: "navigation", Tags: []string{"finder", "fuzzy"}},
+		{Name: "harpoon", Repo: "ThePrimeagen/harpoon", Description: "Mark files", Category: "navigation"},
+	}, OriginLibrary)
+	return idx
+}
+
+func resultNames(results []Result) []string {
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestIndex_Merge(t *testing.T) {
+	idx := testIndex()
+	require.Len(t, idx.Entries(), 3)
+
+	telescope := idx.Entries()[0]
+	assert.Equal(t, OriginStore, telescope.Origin)
+	assert.True(t, telescope.Installed)
+	assert.Equal(t, "Fuzzy finder", telescope.Description, "store definition wins")
+	assert.Equal(t, "navigation", telescope.Category, "library fills gaps")
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := testIndex()
+
+	assert.Equal(t, []string{"fzf-lua", "telescope"}, resultNames(idx.Search("fuzzy")))
+	assert.Equal(t, []string{"telescope"}, resultNames(idx.Search("telescope finder")), "every term must match")
+	assert.Equal(t, []string{"harpoon"}, resultNames(idx.Search("HARP")))
+	assert.Empty(t, idx.Search("nothing-matches"))
+	assert.Empty(t, idx.Search("  "))
+
+	// Equal scores rank installed plugins first.
+	results := idx.Search("navigation")
+	assert.Equal(t, "telescope", results[0].Name)
+	assert.Equal(t, []string{"category"}, results[0].Matched)
+}
+
+func TestIndex_SearchReadme(t *testing.T) {
+	idx := testIndex()
+	idx.Entries()[2].Readme = "# Harpoon\n\nQuickly jump between a handful of marked files per project."
+
+	results := idx.Search("project")
+	require.Len(t, results, 1)
+	assert.Equal(t, []string{"readme"}, results[0].Matched)
+	assert.Contains(t, results[0].Snippet, "marked files per project")
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "short text", Snippet("short\ntext", "text", 80))
+	assert.Equal(t, "…bcde…", Snippet("abcdefgh", "de", 4))
+	assert.Empty(t, Snippet("abc", "z", 10))
+}
+
+func TestFetchReadmes(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/repos/ThePrimeagen/harpoon/readme" {
+			fmt.Fprint(w, "harpoon readme")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	f := &ReadmeFetcher{API: &githubapi.Client{BaseURL: srv.URL, HTTPClient: srv.Client()}, CacheDir: t.TempDir()}
+	idx := testIndex()
+	errs := idx.FetchReadmes(context.Background(), f, 2)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "harpoon readme", idx.Entries()[2].Readme)
+
+	// Cached READMEs are served without a request.
+	before := requests.Load()
+	text, err := f.Readme(context.Background(), "ThePrimeagen/harpoon")
+	require.NoError(t, err)
+	assert.Equal(t, "harpoon readme", text)
+	assert.Equal(t, before, requests.Load())
+}
@@ -0,0 +1,294 @@
+// Package sourceconfig persists nvp's sync source registrations in
+// <nvp dir>/sources.yaml: the sources a user adds with "nvp source add",
+// such as git repositories, and which sources are turned off.
+//
+//	sources:
+//	  - name: mycorp
+//	    type: git
+//	    url: git@github.com:corp/nvim-plugins.git
+//	    paths: [specs/]
+//	    filters: {category: lsp}
+//	    auth: env:CORP_TOKEN
+//	disabled: [lunarvim]
+//
+// Disabled lists sources nvp provides itself (built in or on PATH) that
+// are turned off; a saved source carries its own disabled flag.
+package sourceconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"devopsmaestro/pkg/nvimbridge/gitsource"
+	"devopsmaestro/pkg/secrets"
+	"devopsmaestro/pkg/secrets/providers"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the sources file inside the nvp directory.
+const FileName = "sources.yaml"
+
+// Source is a saved source registration.
+type Source struct {
+	Name  string   `yaml:"name" json:"name"`
+	Type  string   `yaml:"type" json:"type"`
+	URL   string   `yaml:"url" json:"url"`
+	Ref   string   `yaml:"ref,omitempty" json:"ref,omitempty"`
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// Filters are applied to every sync of the source, under any given on
+	// the command line.
+	Filters map[string]string `yaml:"filters,omitempty" json:"filters,omitempty"`
+	// Auth references the token used to fetch the source; see ResolveAuth.
+	// The token itself is never stored.
+	Auth     string `yaml:"auth,omitempty" json:"auth,omitempty"`
+	Disabled bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+}
+
+// Enabled reports whether the source is registered at startup.
+func (s Source) Enabled() bool {
+	return !s.Disabled
+}
+
+// GitConfig returns the git source configuration of s. A source with an
+// auth reference resolves it each time the repository is fetched.
+func (s Source) GitConfig() gitsource.Config {
+	cfg := gitsource.Config{Name: s.Name, URL: s.URL, Ref: s.Ref, Paths: s.Paths}
+	if s.Auth != "" {
+		ref := s.Auth
+		cfg.Token = func(ctx context.Context) (string, error) { return ResolveAuth(ctx, ref) }
+	}
+	return cfg
+}
+
+// Validate checks the source is complete for its type.
+func (s Source) Validate() error {
+	if err := ValidateAuth(s.Auth); err != nil {
+		return fmt.Errorf("source %s: %w", s.Name, err)
+	}
+	switch s.Type {
+	case gitsource.Type:
+		return s.GitConfig().Validate()
+	default:
+		return fmt.Errorf("source %s: unsupported type %q", s.Name, s.Type)
+	}
+}
+
+// Auth reference schemes.
+const (
+	// AuthEnv reads the token from an environment variable: env:CORP_TOKEN.
+	AuthEnv = "env"
+	// AuthSecret reads the token from the secret providers nvp uses for its
+	// GitHub token (MaestroVault, then DVM_SECRET_<NAME>, then <NAME>):
+	// secret:corp-token.
+	AuthSecret = "secret"
+)
+
+// ValidateAuth checks an auth reference is well formed. Empty means none.
+func ValidateAuth(ref string) error {
+	if ref == "" {
+		return nil
+	}
+	scheme, name, ok := strings.Cut(ref, ":")
+	if !ok || name == "" || (scheme != AuthEnv && scheme != AuthSecret) {
+		return fmt.Errorf("invalid auth reference %q: use env:<VAR> or secret:<name>", ref)
+	}
+	return nil
+}
+
+// ResolveAuth returns the token an auth reference points at. It fails when
+// the reference is set but resolves to nothing, so a missing credential is
+// reported rather than silently fetching anonymously.
+func ResolveAuth(ctx context.Context, ref string) (string, error) {
+	if err := ValidateAuth(ref); err != nil || ref == "" {
+		return "", err
+	}
+	scheme, name, _ := strings.Cut(ref, ":")
+	switch scheme {
+	case AuthEnv:
+		if token := os.Getenv(name); token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("auth %s: environment variable %s is not set", ref, name)
+	default:
+		req := secrets.SecretRequest{Name: name}
+		if vault := providers.NewVaultProvider(); vault.IsAvailable() {
+			if token, err := vault.GetSecret(ctx, req); err == nil && token != "" {
+				return token, nil
+			}
+		}
+		env := providers.NewEnvProvider()
+		token, err := env.GetSecret(ctx, req)
+		if err != nil || token == "" {
+			envVar := secrets.ConvertNameToEnvVar(name)
+			return "", fmt.Errorf("auth %s: secret %q not found in MaestroVault, %s%s, or %s", ref, name, env.Prefix(), envVar, envVar)
+		}
+		return token, nil
+	}
+}
+
+type file struct {
+	Sources  []Source `yaml:"sources,omitempty"`
+	Disabled []string `yaml:"disabled,omitempty"`
+}
+
+// Store reads and writes the sources file.
+type Store struct {
+	path string
+}
+
+// NewStore returns the store for the nvp directory dir.
+func NewStore(dir string) *Store {
+	return &Store{path: filepath.Join(dir, FileName)}
+}
+
+// Path returns the sources file path.
+func (s *Store) Path() string {
+	return s.path
+}
+
+func (s *Store) load() (*file, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &file{}, nil
+		}
+		return nil, fmt.Errorf("failed to read sources: %w", err)
+	}
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	sort.Slice(f.Sources, func(i, j int) bool { return f.Sources[i].Name < f.Sources[j].Name })
+	return &f, nil
+}
+
+// List returns the saved sources sorted by name. A missing file means no
+// sources.
+func (s *Store) List() ([]Source, error) {
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return f.Sources, nil
+}
+
+// Get returns the saved source with the given name.
+func (s *Store) Get(name string) (*Source, error) {
+	sources, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range sources {
+		if sources[i].Name == name {
+			return &sources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("source %q not found", name)
+}
+
+// Save adds src, replacing a saved source of the same name.
+func (s *Store) Save(src Source) error {
+	if err := src.Validate(); err != nil {
+		return err
+	}
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range f.Sources {
+		if f.Sources[i].Name == src.Name {
+			f.Sources[i], replaced = src, true
+		}
+	}
+	if !replaced {
+		f.Sources = append(f.Sources, src)
+	}
+	return s.write(f)
+}
+
+// Remove deletes a saved source.
+func (s *Store) Remove(name string) error {
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	n := len(f.Sources)
+	f.Sources = slices.DeleteFunc(f.Sources, func(src Source) bool { return src.Name == name })
+	if len(f.Sources) == n {
+		return fmt.Errorf("source %q not found", name)
+	}
+	return s.write(f)
+}
+
+// SetEnabled turns a source on or off: a saved source's own flag, or, for
+// any other name, its entry in the disabled list.
+func (s *Store) SetEnabled(name string, enabled bool) error {
+	f, err := s.load()
+	if err != nil {
+		return err
+	}
+	saved := false
+	for i := range f.Sources {
+		if f.Sources[i].Name == name {
+			f.Sources[i].Disabled, saved = !enabled, true
+		}
+	}
+	if !saved {
+		f.Disabled = slices.DeleteFunc(f.Disabled, func(n string) bool { return n == name })
+		if !enabled {
+			f.Disabled = append(f.Disabled, name)
+			sort.Strings(f.Disabled)
+		}
+	}
+	return s.write(f)
+}
+
+// Disabled returns the names of every disabled source, saved or not,
+// sorted.
+func (s *Store) Disabled() ([]string, error) {
+	f, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	names := append([]string{}, f.Disabled...)
+	for _, src := range f.Sources {
+		if src.Disabled {
+			names = append(names, src.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Store) write(f *file) error {
+	sort.Slice(f.Sources, func(i, j int) bool { return f.Sources[i].Name < f.Sources[j].Name })
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode sources: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(s.path), err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sources: %w", err)
+	}
+	return nil
+}
+
+// GitConfigs returns the configurations of the enabled git sources.
+func GitConfigs(sources []Source) []gitsource.Config {
+	var configs []gitsource.Config
+	for _, s := range sources {
+		if s.Type == gitsource.Type && s.Enabled() {
+			configs = append(configs, s.GitConfig())
+		}
+	}
+	return configs
+}
@@ -0,0 +1,107 @@
+package sourceconfig
+
+import (
+	"context"
+	"testing"
+
+	"devopsmaestro/pkg/nvimbridge/gitsource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	sources, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+
+	require.NoError(t, store.Save(Source{Name: "zeta", Type: gitsource.Type, URL: "https://example.com/z.git"}))
+	require.NoError(t, store.Save(Source{Name: "mycorp", Type: gitsource.Type, URL: "git@github.com:corp/p.git", Paths: []string{"specs/"}}))
+
+	sources, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "mycorp", sources[0].Name)
+
+	// Saving again replaces
+	require.NoError(t, store.Save(Source{Name: "mycorp", Type: gitsource.Type, URL: "git@github.com:corp/p.git", Ref: "v2"}))
+	src, err := store.Get("mycorp")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", src.Ref)
+	assert.Empty(t, src.Paths)
+
+	_, err = store.Get("missing")
+	assert.Error(t, err)
+
+	assert.Error(t, store.Save(Source{Name: "x", Type: "ftp", URL: "ftp://x"}))
+	assert.Error(t, store.Save(Source{Name: "x", Type: gitsource.Type}))
+}
+
+func TestGitConfigs(t *testing.T) {
+	configs := GitConfigs([]Source{
+		{Name: "mycorp", Type: gitsource.Type, URL: "u", Ref: "main", Paths: []string{"specs/"}},
+		{Name: "other", Type: "other"},
+	})
+	assert.Equal(t, []gitsource.Config{{Name: "mycorp", URL: "u", Ref: "main", Paths: []string{"specs/"}}}, configs)
+}
+
+func TestStore_EnableRemove(t *testing.T) {
+	store := NewStore(t.TempDir())
+	require.NoError(t, store.Save(Source{Name: "mycorp", Type: gitsource.Type, URL: "u"}))
+
+	require.NoError(t, store.SetEnabled("mycorp", false))
+	require.NoError(t, store.SetEnabled("lunarvim", false))
+	require.NoError(t, store.SetEnabled("nvchad", false))
+	disabled, err := store.Disabled()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lunarvim", "mycorp", "nvchad"}, disabled)
+
+	src, err := store.Get("mycorp")
+	require.NoError(t, err)
+	assert.False(t, src.Enabled())
+	assert.Empty(t, GitConfigs([]Source{*src}))
+
+	require.NoError(t, store.SetEnabled("nvchad", true))
+	require.NoError(t, store.SetEnabled("mycorp", true))
+	disabled, err = store.Disabled()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lunarvim"}, disabled)
+
+	require.NoError(t, store.Remove("mycorp"))
+	assert.Error(t, store.Remove("mycorp"))
+	sources, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+}
+
+func TestAuth(t *testing.T) {
+	assert.NoError(t, ValidateAuth(""))
+	assert.NoError(t, ValidateAuth("env:CORP_TOKEN"))
+	assert.NoError(t, ValidateAuth("secret:corp-token"))
+	assert.Error(t, ValidateAuth("CORP_TOKEN"))
+	assert.Error(t, ValidateAuth("file:/tmp/token"))
+	assert.Error(t, ValidateAuth("env:"))
+
+	ctx := context.Background()
+	t.Setenv("NVP_TEST_CORP_TOKEN", "s3cret")
+	token, err := ResolveAuth(ctx, "env:NVP_TEST_CORP_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", token)
+
+	_, err = ResolveAuth(ctx, "env:NVP_TEST_UNSET_TOKEN")
+	assert.ErrorContains(t, err, "NVP_TEST_UNSET_TOKEN is not set")
+
+	t.Setenv("DVM_SECRET_NVP_TEST_CORP", "from-secret")
+	token, err = ResolveAuth(ctx, "secret:nvp-test-corp")
+	require.NoError(t, err)
+	assert.Equal(t, "from-secret", token)
+
+	// The resolved token reaches the git configuration, the reference stays
+	// in the saved source
+	cfg := Source{Name: "mycorp", Type: gitsource.Type, URL: "u", Auth: "env:NVP_TEST_CORP_TOKEN"}.GitConfig()
+	require.NotNil(t, cfg.Token)
+	token, err = cfg.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", token)
+}
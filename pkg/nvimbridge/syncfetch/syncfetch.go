@@ -0,0 +1,315 @@
+// Package syncfetch is the fetch pipeline nvp's sync source handlers share.
+// It runs fetches on a bounded pool of workers, paces the requests to each
+// host, and retries transient failures (network errors, 429, and 5xx) with
+// jittered exponential backoff, honoring Retry-After.
+//
+// Requests go through http.DefaultTransport, so the proxy, CA, and GitHub
+// auth settings of pkg/httpclient and pkg/githubapi apply to them too.
+// Configure sets the options of the pipeline Default returns; nvp calls it
+// with the network.fetch section of the config file.
+package syncfetch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+// Defaults of the zero Options fields.
+const (
+	DefaultWorkers   = 8
+	DefaultHostRate  = 10
+	DefaultRetries   = 3
+	DefaultBaseDelay = 250 * time.Millisecond
+	DefaultMaxDelay  = 10 * time.Second
+	DefaultTimeout   = 30 * time.Second
+)
+
+// maxBody bounds one fetched response.
+const maxBody = 20 << 20
+
+// Options configure a Pipeline. Zero fields take the defaults above.
+type Options struct {
+	// Workers is how many fetches run at once.
+	Workers int
+	// HostRate is the most requests per second sent to one host; negative
+	// means unlimited.
+	HostRate float64
+	// HostRates override HostRate for a host and its subdomains; the most
+	// specific matching host wins.
+	HostRates map[string]float64
+	// Retries is how often a failed fetch is retried; negative disables
+	// retries.
+	Retries int
+	// BaseDelay is the delay before the first retry. It doubles with each
+	// retry, up to MaxDelay, and is jittered by up to half.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Timeout bounds one attempt.
+	Timeout time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = DefaultWorkers
+	}
+	if o.HostRate == 0 {
+		o.HostRate = DefaultHostRate
+	}
+	if o.Retries == 0 {
+		o.Retries = DefaultRetries
+	} else if o.Retries < 0 {
+		o.Retries = 0
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = DefaultBaseDelay
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = DefaultMaxDelay
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	return o
+}
+
+var current atomic.Pointer[Pipeline]
+
+// Configure replaces the pipeline Default returns. Limits are per pipeline,
+// so fetches already running keep the old ones.
+func Configure(o Options) {
+	current.Store(New(o))
+}
+
+// Default returns the pipeline configured with Configure, or one with the
+// default options.
+func Default() *Pipeline {
+	if p := current.Load(); p != nil {
+		return p
+	}
+	current.CompareAndSwap(nil, New(Options{}))
+	return current.Load()
+}
+
+// StatusError is a fetch answered with an unexpected HTTP status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("GET %s: status %d", e.URL, e.StatusCode)
+}
+
+// Pipeline fetches URLs with bounded concurrency, per-host rate limits, and
+// retries. It is safe for concurrent use.
+type Pipeline struct {
+	opts   Options
+	client *http.Client
+
+	mu    sync.Mutex
+	hosts map[string]*limiter
+
+	sleep func(context.Context, time.Duration) error
+}
+
+// New returns a pipeline with options o.
+func New(o Options) *Pipeline {
+	o = o.withDefaults()
+	return &Pipeline{
+		opts:   o,
+		client: httpclient.New(o.Timeout),
+		hosts:  make(map[string]*limiter),
+	}
+}
+
+// Options returns the pipeline's options with defaults applied.
+func (p *Pipeline) Options() Options {
+	return p.opts
+}
+
+// Get fetches url and returns the body of a 200 response. Other statuses
+// are returned as a *StatusError after any retries.
+func (p *Pipeline) Get(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	for attempt := 0; ; attempt++ {
+		if err := p.wait(ctx, p.limiter(u.Host).reserve(time.Now())); err != nil {
+			return nil, err
+		}
+		body, retryAfter, err := p.get(ctx, rawURL)
+		if err == nil {
+			return body, nil
+		}
+		if !retryable(err) || attempt >= p.opts.Retries || ctx.Err() != nil {
+			return nil, err
+		}
+		delay := p.backoff(attempt)
+		if retryAfter > delay {
+			delay = min(retryAfter, p.opts.MaxDelay)
+		}
+		slog.Debug("retrying fetch", "url", rawURL, "attempt", attempt+1, "delay", delay, "error", err)
+		if err := p.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// get makes one attempt, returning the Retry-After of a failed response.
+func (p *Pipeline) get(ctx context.Context, rawURL string) ([]byte, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		var retryAfter time.Duration
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			retryAfter = time.Duration(secs) * time.Second
+		}
+		return nil, retryAfter, &StatusError{URL: rawURL, StatusCode: resp.StatusCode}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody+1))
+	if err != nil {
+		return nil, 0, fmt.Errorf("GET %s: %w", rawURL, err)
+	}
+	if len(body) > maxBody {
+		return nil, 0, fmt.Errorf("GET %s: response larger than %d MiB", rawURL, maxBody>>20)
+	}
+	return body, 0, nil
+}
+
+// retryable reports whether a failed attempt may succeed when repeated.
+func retryable(err error) bool {
+	if se, ok := err.(*StatusError); ok {
+		return se.StatusCode == http.StatusTooManyRequests || se.StatusCode >= 500
+	}
+	return true
+}
+
+// backoff is the jittered delay before retry attempt+1: BaseDelay doubled
+// per attempt, capped at MaxDelay, minus up to half.
+func (p *Pipeline) backoff(attempt int) time.Duration {
+	d := p.opts.BaseDelay << min(attempt, 16)
+	if d <= 0 || d > p.opts.MaxDelay {
+		d = p.opts.MaxDelay
+	}
+	return d - time.Duration(rand.Int64N(int64(d/2)+1))
+}
+
+func (p *Pipeline) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	if p.sleep != nil {
+		return p.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// limiter returns the rate limiter of host.
+func (p *Pipeline) limiter(host string) *limiter {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l, ok := p.hosts[host]
+	if !ok {
+		l = &limiter{}
+		if rate := p.hostRate(host); rate > 0 {
+			l.interval = time.Duration(float64(time.Second) / rate)
+		}
+		p.hosts[host] = l
+	}
+	return l
+}
+
+// hostRate returns the rate limit of host, a host[:port].
+func (p *Pipeline) hostRate(host string) float64 {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	rate, best := p.opts.HostRate, ""
+	for h, r := range p.opts.HostRates {
+		if (host == h || strings.HasSuffix(host, "."+h)) && len(h) > len(best) {
+			rate, best = r, h
+		}
+	}
+	return rate
+}
+
+// limiter spaces the requests to one host at least interval apart.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// reserve books the next request slot and returns how long to wait for it.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	if l.interval <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// Map calls fn for each item on the pipeline's workers and returns the
+// results and errors in item order. Once ctx is done, items not yet started
+// fail with its error.
+func Map[T, R any](ctx context.Context, p *Pipeline, items []T, fn func(context.Context, T) (R, error)) ([]R, []error) {
+	results := make([]R, len(items))
+	errs := make([]error, len(items))
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(p.opts.Workers, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i], errs[i] = fn(ctx, items[i])
+			}
+		}()
+	}
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, errs
+}
@@ -0,0 +1,148 @@
+package syncfetch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noSleep records the delays a pipeline would sleep for.
+func noSleep(p *Pipeline) *[]time.Duration {
+	var delays []time.Duration
+	p.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return &delays
+}
+
+func TestGet_Retries(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch n := atomic.AddInt32(&hits, 1); {
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case n == 2:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	p := New(Options{HostRate: -1, BaseDelay: 100 * time.Millisecond})
+	delays := noSleep(p)
+
+	body, err := p.Get(context.Background(), srv.URL+"/file")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+	require.Len(t, *delays, 2)
+	assert.InDelta(t, 75*time.Millisecond, (*delays)[0], float64(25*time.Millisecond))
+	assert.Equal(t, 2*time.Second, (*delays)[1], "Retry-After wins over a shorter backoff")
+
+	// A 404 is final
+	atomic.StoreInt32(&hits, 10)
+	_, err = p.Get(context.Background(), srv.URL+"/missing")
+	var se *StatusError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusNotFound, se.StatusCode)
+	assert.Equal(t, int32(11), atomic.LoadInt32(&hits))
+}
+
+func TestGet_GivesUp(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	p := New(Options{HostRate: -1, Retries: 2})
+	noSleep(p)
+	_, err := p.Get(context.Background(), srv.URL)
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+
+	p = New(Options{HostRate: -1, Retries: -1})
+	atomic.StoreInt32(&hits, 0)
+	_, err = p.Get(context.Background(), srv.URL)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestBackoff(t *testing.T) {
+	p := New(Options{BaseDelay: time.Second, MaxDelay: 5 * time.Second})
+	for attempt, full := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		d := p.backoff(attempt)
+		assert.GreaterOrEqual(t, d, full/2, attempt)
+		assert.LessOrEqual(t, d, full, attempt)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := &limiter{interval: 100 * time.Millisecond}
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 150*time.Millisecond, l.reserve(now.Add(50*time.Millisecond)))
+	// An idle host does not bank slots
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(time.Second)))
+
+	p := New(Options{HostRate: 4})
+	assert.Equal(t, 250*time.Millisecond, p.limiter("example.com").interval)
+	assert.Same(t, p.limiter("example.com"), p.limiter("example.com"))
+	assert.Zero(t, New(Options{HostRate: -1}).limiter("example.com").interval)
+
+	p = New(Options{HostRate: 4, HostRates: map[string]float64{"example.com": 10, "raw.example.com": -1}})
+	assert.Equal(t, 100*time.Millisecond, p.limiter("api.example.com:443").interval)
+	assert.Zero(t, p.limiter("raw.example.com").interval)
+	assert.Equal(t, 250*time.Millisecond, p.limiter("example.org").interval)
+}
+
+func TestMap(t *testing.T) {
+	p := New(Options{Workers: 3})
+	var running, peak int32
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	results, errs := Map(context.Background(), p, items, func(_ context.Context, n int) (int, error) {
+		now := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if now <= old || atomic.CompareAndSwapInt32(&peak, old, now) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if n == 4 {
+			return 0, assert.AnError
+		}
+		return n * n, nil
+	})
+	assert.Equal(t, []int{1, 4, 9, 0, 25, 36, 49, 64, 81, 100}, results)
+	assert.ErrorIs(t, errs[3], assert.AnError)
+	assert.NoError(t, errs[0])
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs = Map(ctx, p, items, func(context.Context, int) (int, error) { return 0, nil })
+	assert.ErrorIs(t, errs[9], context.Canceled)
+}
+
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { current.Store(nil) })
+	assert.Equal(t, DefaultWorkers, Default().Options().Workers)
+	Configure(Options{Workers: 2, Retries: -1})
+	assert.Equal(t, 2, Default().Options().Workers)
+	assert.Equal(t, 0, Default().Options().Retries)
+	assert.Equal(t, float64(DefaultHostRate), Default().Options().HostRate)
+}
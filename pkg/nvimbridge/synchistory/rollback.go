@@ -0,0 +1,70 @@
+package synchistory
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"devopsmaestro/models"
+)
+
+// RollbackResult lists the files Rollback changed, as paths relative to
+// the nvp directory.
+type RollbackResult struct {
+	// Restored files were put back to their content before the sync.
+	Restored []string `json:"restored,omitempty" yaml:"restored,omitempty"`
+	// Removed files were created by the sync and deleted.
+	Removed []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// sortedPaths returns the paths of files in order.
+func sortedPaths(files map[string]models.SyncFile) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Conflicts returns the paths under root that changed since the sync wrote
+// them, which Rollback would overwrite or delete: edited, deleted, or
+// rewritten by a later sync.
+func Conflicts(root string, files map[string]models.SyncFile) []string {
+	var conflicts []string
+	for _, path := range sortedPaths(files) {
+		current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil || !bytes.Equal(current, []byte(files[path].After)) {
+			conflicts = append(conflicts, path)
+		}
+	}
+	return conflicts
+}
+
+// Rollback puts the files under root back to their state before the sync:
+// files the sync changed get their old content and files it created are
+// deleted.
+func Rollback(root string, files map[string]models.SyncFile) (*RollbackResult, error) {
+	result := &RollbackResult{}
+	for _, path := range sortedPaths(files) {
+		full := filepath.Join(root, filepath.FromSlash(path))
+		before := files[path].Before
+		if before == nil {
+			if err := os.Remove(full); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+			result.Removed = append(result.Removed, path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return result, fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+		if err := os.WriteFile(full, []byte(*before), 0644); err != nil {
+			return result, fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+		result.Restored = append(result.Restored, path)
+	}
+	return result, nil
+}
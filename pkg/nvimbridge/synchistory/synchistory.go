@@ -0,0 +1,177 @@
+// Package synchistory builds the sync_history records of nvp source sync.
+// A Snapshot of the plugin store taken before the sync is compared with the
+// files the sync left behind, so each record says which plugins a run
+// created or changed and how, not just what the source reported, and keeps
+// the old content of every file the run wrote so Rollback can undo it.
+package synchistory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/linediff"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+)
+
+// Snapshot is the plugin and package YAML files of an nvp directory, keyed
+// by their slash path relative to it, such as plugins/telescope.yaml.
+type Snapshot map[string][]byte
+
+// Store directories a sync writes to, relative to the nvp directory.
+const (
+	PluginsDir  = "plugins"
+	PackagesDir = "packages"
+)
+
+// Take reads the plugin and package YAML files of the nvp directory root.
+// Missing directories are empty.
+func Take(root string) (Snapshot, error) {
+	snap := Snapshot{}
+	for _, sub := range []string{PluginsDir, PackagesDir} {
+		entries, err := os.ReadDir(filepath.Join(root, sub))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read plugin store: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".yaml") {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(root, sub, e.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read plugin store: %w", err)
+			}
+			snap[sub+"/"+e.Name()] = data
+		}
+	}
+	return snap, nil
+}
+
+// PluginPath is the path of a plugin's file relative to the nvp directory.
+func PluginPath(name string) string {
+	return PluginsDir + "/" + strings.NewReplacer("/", "-", `\`, "-").Replace(name) + ".yaml"
+}
+
+// PackagePath is the path of a package's file relative to the nvp directory.
+func PackagePath(name string) string {
+	return PackagesDir + "/" + name + ".yaml"
+}
+
+// Written returns the paths of the plugin and package files a sync reports
+// writing.
+func Written(result *sync.SyncResult) []string {
+	var paths []string
+	for _, names := range [][]string{result.PluginsCreated, result.PluginsUpdated} {
+		for _, name := range names {
+			paths = append(paths, PluginPath(name))
+		}
+	}
+	for _, names := range [][]string{result.PackagesCreated, result.PackagesUpdated} {
+		for _, name := range names {
+			paths = append(paths, PackagePath(name))
+		}
+	}
+	return paths
+}
+
+// Changes compares the files of the named plugins under root with before
+// and summarizes each as created, updated, or unchanged. Plugins whose file
+// the sync did not leave are omitted.
+func Changes(before Snapshot, root string, names []string) []models.SyncChange {
+	var changes []models.SyncChange
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		path := PluginPath(name)
+		after, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		old, existed := before[path]
+		diff, added, removed := linediff.Unified(string(old), string(after))
+		c := models.SyncChange{Plugin: name, Added: added, Removed: removed}
+		switch {
+		case !existed:
+			c.Action = models.SyncChangeCreated
+		case added+removed == 0:
+			c.Action = models.SyncChangeUnchanged
+		default:
+			c.Action = models.SyncChangeUpdated
+			c.Diff = diff
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// Files pairs the content of each path under root before a sync, from
+// before, with its content after, for rolling the sync back. Paths the sync
+// did not leave a file at are omitted.
+func Files(before Snapshot, root string, paths []string) map[string]models.SyncFile {
+	files := map[string]models.SyncFile{}
+	for _, path := range paths {
+		after, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+		if err != nil {
+			continue
+		}
+		f := models.SyncFile{After: string(after)}
+		if old, ok := before[path]; ok {
+			s := string(old)
+			f.Before = &s
+		}
+		files[path] = f
+	}
+	return files
+}
+
+// Record builds the history record of a sync that started at started.
+// Changes and files are the change summary and rollback snapshot of a real
+// run; a dry run has neither.
+func Record(result *sync.SyncResult, version string, dryRun bool, started time.Time, changes []models.SyncChange, files map[string]models.SyncFile) (*models.SyncHistory, error) {
+	h := &models.SyncHistory{
+		Source:         result.SourceName,
+		DryRun:         dryRun,
+		TotalAvailable: result.TotalAvailable,
+		TotalSynced:    result.TotalSynced,
+		StartedAt:      started.UTC(),
+		DurationMS:     time.Since(started).Milliseconds(),
+	}
+	if version != "" {
+		h.Version.String, h.Version.Valid = version, true
+	}
+	if err := h.SetResult(models.SyncHistoryResult{
+		PluginsCreated:  result.PluginsCreated,
+		PluginsUpdated:  result.PluginsUpdated,
+		PackagesCreated: result.PackagesCreated,
+		PackagesUpdated: result.PackagesUpdated,
+	}); err != nil {
+		return nil, err
+	}
+	if err := h.SetChanges(changes); err != nil {
+		return nil, err
+	}
+	if err := h.SetSnapshot(files); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		// Count what the source reported it would write
+		h.CreatedCount, h.UpdatedCount = len(result.PluginsCreated), len(result.PluginsUpdated)
+	}
+	var errs []string
+	for _, err := range result.Errors {
+		errs = append(errs, err.Error())
+	}
+	if err := h.SetErrors(errs); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
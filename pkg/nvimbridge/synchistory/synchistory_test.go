@@ -0,0 +1,125 @@
+package synchistory
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroNvim/nvimops/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+}
+
+func read(t *testing.T, root, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestChanges(t *testing.T) {
+	root := t.TempDir()
+	write(t, root, "plugins/flash.yaml", "name: flash\nlazy: false\n")
+	write(t, root, "plugins/noice.yaml", "name: noice\n")
+	write(t, root, "plugins/notes.txt", "ignored")
+	write(t, root, "packages/lazyvim.yaml", "name: lazyvim\n")
+
+	before, err := Take(root)
+	require.NoError(t, err)
+	assert.Len(t, before, 3)
+	assert.Contains(t, before, "packages/lazyvim.yaml")
+
+	write(t, root, "plugins/flash.yaml", "name: flash\nlazy: true\n")
+	write(t, root, "plugins/telescope.yaml", "name: telescope\nrepo: nvim-telescope/telescope.nvim\n")
+
+	changes := Changes(before, root, []string{"telescope", "flash", "noice", "flash", "missing"})
+	require.Len(t, changes, 3)
+	assert.Equal(t, models.SyncChange{Plugin: "telescope", Action: models.SyncChangeCreated, Added: 2}, changes[0])
+	assert.Equal(t, models.SyncChangeUpdated, changes[1].Action)
+	assert.Equal(t, 1, changes[1].Added)
+	assert.Equal(t, 1, changes[1].Removed)
+	assert.Equal(t, " name: flash\n-lazy: false\n+lazy: true\n", changes[1].Diff)
+	assert.Equal(t, models.SyncChange{Plugin: "noice", Action: models.SyncChangeUnchanged}, changes[2])
+
+	empty, err := Take(filepath.Join(root, "nope"))
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestRollback(t *testing.T) {
+	root := t.TempDir()
+	write(t, root, "plugins/flash.yaml", "name: flash\nlazy: false\n")
+	write(t, root, "plugins/noice.yaml", "name: noice\n")
+	before, err := Take(root)
+	require.NoError(t, err)
+
+	// The sync changes flash, creates telescope and the lazyvim package
+	write(t, root, "plugins/flash.yaml", "name: flash\nlazy: true\n")
+	write(t, root, "plugins/telescope.yaml", "name: telescope\n")
+	write(t, root, "packages/lazyvim.yaml", "name: lazyvim\n")
+	result := &sync.SyncResult{
+		PluginsCreated:  []string{"telescope", "flash"},
+		PackagesCreated: []string{"lazyvim"},
+	}
+	paths := Written(result)
+	assert.Equal(t, []string{"plugins/telescope.yaml", "plugins/flash.yaml", "packages/lazyvim.yaml"}, paths)
+	files := Files(before, root, append(paths, "plugins/missing.yaml"))
+	require.Len(t, files, 3)
+	assert.Nil(t, files["plugins/telescope.yaml"].Before)
+	assert.Equal(t, "name: flash\nlazy: false\n", *files["plugins/flash.yaml"].Before)
+	assert.Empty(t, Conflicts(root, files))
+
+	// A later edit is a conflict
+	write(t, root, "plugins/flash.yaml", "name: flash\nlazy: true\nevent: VeryLazy\n")
+	assert.Equal(t, []string{"plugins/flash.yaml"}, Conflicts(root, files))
+
+	rolled, err := Rollback(root, files)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plugins/flash.yaml"}, rolled.Restored)
+	assert.Equal(t, []string{"packages/lazyvim.yaml", "plugins/telescope.yaml"}, rolled.Removed)
+
+	after, err := Take(root)
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+	assert.Equal(t, "name: noice\n", read(t, root, "plugins/noice.yaml"))
+}
+
+func TestRecord(t *testing.T) {
+	result := &sync.SyncResult{
+		SourceName:      "lazyvim",
+		PluginsCreated:  []string{"telescope"},
+		PackagesCreated: []string{"lazyvim"},
+		Errors:          []error{errors.New("failed to write plugin noice")},
+		TotalAvailable:  3,
+		TotalSynced:     1,
+	}
+	started := time.Now().Add(-2 * time.Second)
+	h, err := Record(result, "v15.1.0", false, started, []models.SyncChange{
+		{Plugin: "telescope", Action: models.SyncChangeCreated, Added: 2},
+	}, map[string]models.SyncFile{"plugins/telescope.yaml": {After: "name: telescope\n"}})
+	require.NoError(t, err)
+	assert.Equal(t, "lazyvim", h.Source)
+	assert.Equal(t, "v15.1.0", h.Version.String)
+	assert.Equal(t, 1, h.CreatedCount)
+	assert.Equal(t, 1, h.ErrorCount)
+	assert.GreaterOrEqual(t, h.Duration(), 2*time.Second)
+	assert.Equal(t, []string{"lazyvim"}, h.GetResult().PackagesCreated)
+	assert.Equal(t, "name: telescope\n", h.GetSnapshot()["plugins/telescope.yaml"].After)
+
+	dry, err := Record(&sync.SyncResult{SourceName: "lazyvim", PluginsCreated: []string{"a", "b"}}, "", true, started, nil, nil)
+	require.NoError(t, err)
+	assert.False(t, dry.Version.Valid)
+	assert.Equal(t, 2, dry.CreatedCount)
+	assert.Empty(t, dry.GetChanges())
+	assert.Empty(t, dry.GetSnapshot())
+}
@@ -0,0 +1,119 @@
+// Package nvimbridge provides database adapters that bridge MaestroNvim types
+// with dvm's database layer (models, db packages).
+// This file resolves the plugin set of a single dvm workspace from the shared
+// database, so nvp can generate a workspace's plugin specs without a build.
+package nvimbridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/resolver"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/library"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// WorkspacePlugins is the resolved plugin set for one workspace.
+type WorkspacePlugins struct {
+	// Package is the nvim package the set was built from, or empty when the
+	// workspace lists its plugins explicitly.
+	Package string
+
+	// PackageSource names the hierarchy level the package was set at
+	// (workspace, app, domain, ecosystem, global).
+	PackageSource string
+
+	// Associated lists plugins attached to the workspace through
+	// workspace_plugins associations.
+	Associated []string
+
+	// Plugins is the final set, in load order.
+	Plugins []*plugin.Plugin
+
+	// Missing lists referenced plugins found in neither the database nor
+	// the plugin library.
+	Missing []string
+
+	// Disabled lists referenced plugins that are disabled in the database.
+	// They are left out rather than replaced by the library definition.
+	Disabled []string
+}
+
+// ResolveWorkspacePlugins returns the plugins a workspace's Neovim config is
+// generated from. The base set is the workspace's explicit plugin list when
+// set, otherwise the flattened nvim package resolved through the hierarchy
+// (workspace → app → domain → ecosystem → global default). Plugins enabled
+// for the workspace via workspace_plugins associations are appended.
+//
+// Plugin definitions come from the database, then the plugin library. A
+// plugin disabled in the database is skipped, even if the library has it.
+func ResolveWorkspacePlugins(ds db.DataStore, ws *models.Workspace) (*WorkspacePlugins, error) {
+	result := &WorkspacePlugins{}
+
+	var names []string
+	if ws.NvimPlugins.Valid && strings.TrimSpace(ws.NvimPlugins.String) != "" {
+		for _, name := range strings.Split(ws.NvimPlugins.String, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	} else {
+		hierarchy := resolver.NewHierarchyPackageResolver(resolver.NewDataStorePackageAdapter(ds))
+		resolution, err := hierarchy.ResolveNvimPackage(context.Background(), resolver.PackageLevelWorkspace, ws.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve nvim package for workspace '%s': %w", ws.Name, err)
+		}
+		if resolution.PackageName != "" {
+			resolved, err := ResolvePackage(resolution.PackageName, NewPackageLookup(ds))
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve nvim package '%s': %w", resolution.PackageName, err)
+			}
+			result.Package = resolution.PackageName
+			result.PackageSource = resolution.Source.String()
+			names = resolved.Package.Plugins
+		}
+	}
+
+	associated, err := ds.GetWorkspacePlugins(ws.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace plugins: %w", err)
+	}
+
+	store := NewPluginDBStoreAdapter(ds)
+	lib, _ := library.NewLibrary()
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		if p, err := store.Get(name); err == nil {
+			if !p.Enabled {
+				result.Disabled = append(result.Disabled, name)
+				return
+			}
+			result.Plugins = append(result.Plugins, p)
+			return
+		}
+		if lib != nil {
+			if p, ok := lib.Get(name); ok {
+				result.Plugins = append(result.Plugins, p)
+				return
+			}
+		}
+		result.Missing = append(result.Missing, name)
+	}
+
+	for _, name := range names {
+		add(name)
+	}
+	for _, p := range associated {
+		result.Associated = append(result.Associated, p.Name)
+		add(p.Name)
+	}
+	return result, nil
+}
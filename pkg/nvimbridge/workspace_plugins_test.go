@@ -0,0 +1,80 @@
+package nvimbridge
+
+import (
+	"database/sql"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pluginNames(set *WorkspacePlugins) []string {
+	names := make([]string, len(set.Plugins))
+	for i, p := range set.Plugins {
+		names[i] = p.Name
+	}
+	return names
+}
+
+func TestResolveWorkspacePlugins_Package(t *testing.T) {
+	ds := db.NewMockDataStore()
+	require.NoError(t, ds.CreatePlugin(&models.NvimPluginDB{Name: "team-snippets", Repo: "acme/team-snippets", Enabled: true}))
+	personal := &models.NvimPackageDB{Name: "personal", Extends: sql.NullString{String: "core", Valid: true}}
+	require.NoError(t, personal.SetPlugins([]string{"-which-key", "team-snippets"}))
+	require.NoError(t, ds.CreatePackage(personal))
+
+	ws := &models.Workspace{Name: "dev", AppID: 1, NvimPackage: sql.NullString{String: "personal", Valid: true}}
+	require.NoError(t, ds.CreateWorkspace(ws))
+
+	set, err := ResolveWorkspacePlugins(ds, ws)
+	require.NoError(t, err)
+
+	assert.Equal(t, "personal", set.Package)
+	assert.Equal(t, "workspace", set.PackageSource)
+	names := pluginNames(set)
+	assert.Contains(t, names, "telescope")
+	assert.Contains(t, names, "team-snippets")
+	assert.NotContains(t, names, "which-key")
+	assert.Empty(t, set.Missing)
+}
+
+func TestResolveWorkspacePlugins_ExplicitListAndAssociations(t *testing.T) {
+	ds := db.NewMockDataStore()
+	extra := &models.NvimPluginDB{Name: "extra", Repo: "acme/extra", Enabled: true}
+	require.NoError(t, ds.CreatePlugin(extra))
+
+	ws := &models.Workspace{
+		Name: "dev", AppID: 1,
+		NvimPlugins: sql.NullString{String: "telescope, not-a-plugin", Valid: true},
+		NvimPackage: sql.NullString{String: "maestro-go", Valid: true},
+	}
+	require.NoError(t, ds.CreateWorkspace(ws))
+	require.NoError(t, ds.AddPluginToWorkspace(ws.ID, extra.ID))
+
+	set, err := ResolveWorkspacePlugins(ds, ws)
+	require.NoError(t, err)
+
+	assert.Empty(t, set.Package, "explicit plugin list takes precedence over the package")
+	assert.Equal(t, []string{"telescope", "extra"}, pluginNames(set))
+	assert.Equal(t, []string{"extra"}, set.Associated)
+	assert.Equal(t, []string{"not-a-plugin"}, set.Missing)
+}
+
+func TestResolveWorkspacePlugins_DisabledInStore(t *testing.T) {
+	ds := db.NewMockDataStore()
+	// telescope is in the library too; disabling it in the store must win
+	require.NoError(t, ds.CreatePlugin(&models.NvimPluginDB{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Enabled: false}))
+
+	ws := &models.Workspace{Name: "dev", AppID: 1, NvimPlugins: sql.NullString{String: "telescope", Valid: true}}
+	require.NoError(t, ds.CreateWorkspace(ws))
+
+	set, err := ResolveWorkspacePlugins(ds, ws)
+	require.NoError(t, err)
+
+	assert.Empty(t, set.Plugins)
+	assert.Equal(t, []string{"telescope"}, set.Disabled)
+	assert.Empty(t, set.Missing)
+}
@@ -0,0 +1,411 @@
+// Package overlay builds resource manifests from a shared base and thin
+// per-environment overlays, in the style of kustomize. A team keeps one base
+// workspace definition and an overlay for each machine or environment that
+// patches only what differs, such as a work laptop's registry mirror or a
+// desktop's GPU.
+//
+// # Layout
+//
+//	workspaces/
+//	  base/
+//	    workspace.yaml
+//	  overlays/
+//	    work-laptop/
+//	      overlay.yaml        # kind: Overlay
+//	      registry.yaml       # a patch
+//	    home-desktop/
+//	      overlay.yaml
+//
+// # Overlay
+//
+//	apiVersion: devopsmaestro.io/v1
+//	kind: Overlay
+//	metadata:
+//	  name: work-laptop
+//	spec:
+//	  resources:
+//	    - ../../base            # a directory, a file, or another overlay
+//	  labels:
+//	    machine: work-laptop
+//	  patches:
+//	    - path: registry.yaml   # strategic merge, targeting its kind and name
+//	    - target: {kind: Workspace, name: dev}
+//	      type: json
+//	      patch: |
+//	        - op: replace
+//	          path: /spec/resources/memory
+//	          value: 8g
+//
+// Overlays are built locally by dvm into a List document that the apply
+// pipeline understands; nothing about them is stored.
+package overlay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"devopsmaestro/pkg/resource/handlers"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// File is the file name of an overlay in its directory.
+	File = "overlay.yaml"
+	// Kind is the overlay kind.
+	Kind = "Overlay"
+	// APIVersion is the overlay API version.
+	APIVersion = "devopsmaestro.io/v1"
+)
+
+// Overlay is a parsed overlay.yaml.
+type Overlay struct {
+	APIVersion string   `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string   `yaml:"kind" json:"kind"`
+	Metadata   Metadata `yaml:"metadata" json:"metadata"`
+	Spec       Spec     `yaml:"spec" json:"spec"`
+}
+
+// Metadata describes an overlay.
+type Metadata struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Spec lists an overlay's bases and what it changes in them.
+type Spec struct {
+	// Resources are files, directories of YAML files, or directories with
+	// an overlay.yaml, relative to the overlay.
+	Resources []string `yaml:"resources" json:"resources"`
+	// Labels are added to the metadata.labels of every resource.
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// Patches are applied in order after the labels.
+	Patches []Patch `yaml:"patches,omitempty" json:"patches,omitempty"`
+}
+
+// Patch changes the resources its target selects.
+type Patch struct {
+	// Path is a file with the patch, relative to the overlay.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Patch is the patch inline; set Path or Patch.
+	Patch string `yaml:"patch,omitempty" json:"patch,omitempty"`
+	// Type is strategic (the default), merge, or json.
+	Type handlers.PatchType `yaml:"type,omitempty" json:"type,omitempty"`
+	// Target selects the resources to patch. Without one, a merge patch
+	// targets the kind and metadata.name it sets, and a patch that sets
+	// neither targets every resource.
+	Target *Target `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+// Target selects resources by kind and name; an empty field matches any.
+type Target struct {
+	Kind string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+}
+
+func (t Target) matches(doc map[string]any) bool {
+	kind, _ := doc["kind"].(string)
+	meta, _ := doc["metadata"].(map[string]any)
+	name, _ := meta["name"].(string)
+	return (t.Kind == "" || strings.EqualFold(t.Kind, kind)) && (t.Name == "" || t.Name == name)
+}
+
+func (t Target) String() string {
+	kind, name := t.Kind, t.Name
+	if kind == "" {
+		kind = "*"
+	}
+	if name == "" {
+		name = "*"
+	}
+	return kind + "/" + name
+}
+
+// Options control how an overlay is built.
+type Options struct {
+	// Transform, when set, rewrites every file read, such as to substitute
+	// variables, before it is parsed. path is the file's path.
+	Transform func(data []byte, path string) ([]byte, error)
+}
+
+// Parse parses and validates an overlay.yaml.
+func Parse(data []byte) (*Overlay, error) {
+	var o Overlay
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&o); err != nil {
+		return nil, fmt.Errorf("invalid overlay: %w", err)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Validate checks the overlay's kind, resources, and patches.
+func (o *Overlay) Validate() error {
+	if o.Kind != Kind {
+		return fmt.Errorf("expected kind %s, got %q", Kind, o.Kind)
+	}
+	if len(o.Spec.Resources) == 0 {
+		return fmt.Errorf("overlay %q lists no resources", o.Metadata.Name)
+	}
+	for i, p := range o.Spec.Patches {
+		if (p.Path == "") == (p.Patch == "") {
+			return fmt.Errorf("patches[%d]: set one of path and patch", i)
+		}
+		if p.Type != "" {
+			if _, err := handlers.ParsePatchType(string(p.Type)); err != nil {
+				return fmt.Errorf("patches[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Build builds the overlay at path, a directory with an overlay.yaml or the
+// overlay file itself, and returns its resources as a List document.
+func Build(path string, opts Options) ([]byte, error) {
+	b := &builder{opts: opts, building: map[string]bool{}}
+	docs, name, err := b.overlay(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("overlay %q built no resources", name)
+	}
+
+	list := resource.NewResourceList()
+	list.Metadata["overlay"] = name
+	for _, doc := range docs {
+		list.Items = append(list.Items, doc)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(list); err != nil {
+		return nil, fmt.Errorf("failed to encode built resources: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// builder builds nested overlays, detecting cycles.
+type builder struct {
+	opts     Options
+	building map[string]bool // overlay files being built
+}
+
+// overlay builds the overlay at path and returns its resources and name.
+func (b *builder) overlay(path string) ([]map[string]any, string, error) {
+	file := path
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		file = filepath.Join(path, File)
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, "", err
+	}
+	if b.building[abs] {
+		return nil, "", fmt.Errorf("overlay %s includes itself", file)
+	}
+	b.building[abs] = true
+	defer delete(b.building, abs)
+
+	data, err := b.read(file)
+	if err != nil {
+		return nil, "", err
+	}
+	o, err := Parse(data)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", file, err)
+	}
+	dir := filepath.Dir(file)
+
+	var docs []map[string]any
+	for _, r := range o.Spec.Resources {
+		found, err := b.resources(filepath.Join(dir, r))
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: resource %s: %w", file, r, err)
+		}
+		docs = append(docs, found...)
+	}
+
+	if len(o.Spec.Labels) > 0 {
+		for _, doc := range docs {
+			addLabels(doc, o.Spec.Labels)
+		}
+	}
+	for i, p := range o.Spec.Patches {
+		if err := b.patch(docs, p, dir); err != nil {
+			return nil, "", fmt.Errorf("%s: patches[%d]: %w", file, i, err)
+		}
+	}
+	return docs, o.Metadata.Name, nil
+}
+
+// resources loads the resources at path: a nested overlay, a directory of
+// YAML files in name order, or a file.
+func (b *builder) resources(path string) ([]map[string]any, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return b.file(path)
+	}
+	if _, err := os.Stat(filepath.Join(path, File)); err == nil {
+		docs, _, err := b.overlay(path)
+		return docs, err
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	var docs []map[string]any
+	for _, name := range names {
+		found, err := b.file(filepath.Join(path, name))
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, found...)
+	}
+	return docs, nil
+}
+
+// file loads the documents of a YAML file, expanding List documents.
+func (b *builder) file(path string) ([]map[string]any, error) {
+	data, err := b.read(path)
+	if err != nil {
+		return nil, err
+	}
+	var docs []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 0; ; i++ {
+		var doc map[string]any
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: document %d: %w", path, i+1, err)
+		}
+		if len(doc) == 0 {
+			continue
+		}
+		switch kind, _ := doc["kind"].(string); kind {
+		case "":
+			return nil, fmt.Errorf("%s: document %d has no kind", path, i+1)
+		case Kind:
+			return nil, fmt.Errorf("%s: an overlay is included by its directory, not as a file", path)
+		case "List":
+			items, _ := doc["items"].([]any)
+			for _, item := range items {
+				if m, ok := item.(map[string]any); ok {
+					docs = append(docs, m)
+				}
+			}
+		default:
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// patch applies p to the documents it targets.
+func (b *builder) patch(docs []map[string]any, p Patch, dir string) error {
+	body := []byte(p.Patch)
+	if p.Path != "" {
+		data, err := b.read(filepath.Join(dir, p.Path))
+		if err != nil {
+			return err
+		}
+		body = data
+	}
+	pt := p.Type
+	if pt == "" {
+		pt = handlers.StrategicMergePatch
+	}
+
+	target := Target{}
+	if p.Target != nil {
+		target = *p.Target
+	} else if pt != handlers.JSONPatch {
+		var head struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(body, &head); err != nil {
+			return fmt.Errorf("failed to parse patch: %w", err)
+		}
+		target = Target{Kind: head.Kind, Name: head.Metadata.Name}
+	}
+
+	patched := 0
+	for i, doc := range docs {
+		if !target.matches(doc) {
+			continue
+		}
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		out, err := handlers.PatchYAML(data, pt, body)
+		if err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		var result map[string]any
+		if err := yaml.Unmarshal(out, &result); err != nil {
+			return err
+		}
+		docs[i] = result
+		patched++
+	}
+	if patched == 0 {
+		return fmt.Errorf("no resource matches %s", target)
+	}
+	return nil
+}
+
+// read reads a file and applies the Transform option.
+func (b *builder) read(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if b.opts.Transform != nil {
+		return b.opts.Transform(data, path)
+	}
+	return data, nil
+}
+
+// addLabels adds labels to doc's metadata.labels; the overlay's win.
+func addLabels(doc map[string]any, labels map[string]string) {
+	meta, ok := doc["metadata"].(map[string]any)
+	if !ok {
+		meta = map[string]any{}
+		doc["metadata"] = meta
+	}
+	existing, ok := meta["labels"].(map[string]any)
+	if !ok {
+		existing = map[string]any{}
+		meta["labels"] = existing
+	}
+	for k, v := range labels {
+		existing[k] = v
+	}
+}
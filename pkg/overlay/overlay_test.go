@@ -0,0 +1,216 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const baseWorkspace = `apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  image:
+    name: ubuntu:24.04
+  env:
+    LOG_LEVEL: info
+  mounts:
+    - source: ~/.ssh
+      destination: /home/dev/.ssh
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func buildItems(t *testing.T, path string, opts Options) []map[string]any {
+	t.Helper()
+	out, err := Build(path, opts)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	var list struct {
+		Kind     string           `yaml:"kind"`
+		Metadata map[string]any   `yaml:"metadata"`
+		Items    []map[string]any `yaml:"items"`
+	}
+	if err := yaml.Unmarshal(out, &list); err != nil {
+		t.Fatalf("built List does not parse: %v\n%s", err, out)
+	}
+	if list.Kind != "List" {
+		t.Errorf("kind = %q, want List", list.Kind)
+	}
+	return list.Items
+}
+
+func field(doc map[string]any, path string) any {
+	var v any = doc
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/workspace.yaml": baseWorkspace,
+		"overlays/laptop/overlay.yaml": `apiVersion: devopsmaestro.io/v1
+kind: Overlay
+metadata:
+  name: laptop
+spec:
+  resources:
+    - ../../base
+  labels:
+    machine: laptop
+  patches:
+    - path: mirror.yaml
+    - target: {kind: Workspace, name: dev}
+      type: json
+      patch: |
+        - op: replace
+          path: /spec/env/LOG_LEVEL
+          value: debug
+`,
+		"overlays/laptop/mirror.yaml": `kind: Workspace
+metadata:
+  name: dev
+spec:
+  build:
+    args:
+      PIP_INDEX_URL: https://pypi.corp
+  mounts:
+    - destination: /home/dev/.ssh
+      readOnly: true
+`,
+	})
+
+	items := buildItems(t, filepath.Join(dir, "overlays/laptop"), Options{})
+	if len(items) != 1 {
+		t.Fatalf("items = %d, want 1", len(items))
+	}
+	ws := items[0]
+	checks := map[string]any{
+		"metadata.labels.machine":       "laptop",
+		"spec.image.name":               "ubuntu:24.04",
+		"spec.env.LOG_LEVEL":            "debug",
+		"spec.build.args.PIP_INDEX_URL": "https://pypi.corp",
+	}
+	for path, want := range checks {
+		if got := field(ws, path); got != want {
+			t.Errorf("%s = %v, want %v", path, got, want)
+		}
+	}
+	// Strategic merge merges mounts by destination
+	mounts, _ := field(ws, "spec.mounts").([]any)
+	if len(mounts) != 1 {
+		t.Fatalf("mounts = %v, want the one merged mount", mounts)
+	}
+	if m := mounts[0].(map[string]any); m["source"] != "~/.ssh" || m["readOnly"] != true {
+		t.Errorf("mount = %v, want the base source made read-only", m)
+	}
+}
+
+func TestBuild_NestedOverlayAndTransform(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"base/workspace.yaml": baseWorkspace,
+		"base/overlay.yaml": `kind: Overlay
+metadata:
+  name: base
+spec:
+  resources: [workspace.yaml]
+  labels:
+    team: infra
+`,
+		"desktop/overlay.yaml": `kind: Overlay
+metadata:
+  name: desktop
+spec:
+  resources: [../base]
+  patches:
+    - patch: |
+        spec:
+          env:
+            GPU: "@gpu@"
+`,
+	})
+
+	items := buildItems(t, filepath.Join(dir, "desktop", File), Options{
+		Transform: func(data []byte, path string) ([]byte, error) {
+			return []byte(strings.ReplaceAll(string(data), "@gpu@", "all")), nil
+		},
+	})
+	if len(items) != 1 {
+		t.Fatalf("items = %d, want 1", len(items))
+	}
+	if got := field(items[0], "metadata.labels.team"); got != "infra" {
+		t.Errorf("metadata.labels.team = %v, want the base overlay's label", got)
+	}
+	if got := field(items[0], "spec.env.GPU"); got != "all" {
+		t.Errorf("spec.env.GPU = %v, want the transformed patch value", got)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		overlay string
+		wantErr string
+	}{
+		{
+			name:    "no resources",
+			overlay: "kind: Overlay\nmetadata: {name: x}\nspec: {}\n",
+			wantErr: "lists no resources",
+		},
+		{
+			name:    "unknown field",
+			overlay: "kind: Overlay\nmetadata: {name: x}\nspec:\n  resource: [ws.yaml]\n",
+			wantErr: "field resource not found",
+		},
+		{
+			name:    "patch without a match",
+			overlay: "kind: Overlay\nmetadata: {name: x}\nspec:\n  resources: [ws.yaml]\n  patches:\n    - target: {kind: App}\n      patch: 'spec: {language: go}'\n",
+			wantErr: "no resource matches App/*",
+		},
+		{
+			name:    "patch path and inline",
+			overlay: "kind: Overlay\nmetadata: {name: x}\nspec:\n  resources: [ws.yaml]\n  patches:\n    - path: p.yaml\n      patch: 'spec: {}'\n",
+			wantErr: "set one of path and patch",
+		},
+		{
+			name:    "cycle",
+			overlay: "kind: Overlay\nmetadata: {name: x}\nspec:\n  resources: [.]\n",
+			wantErr: "includes itself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{File: tt.overlay, "ws.yaml": baseWorkspace})
+			_, err := Build(dir, Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Build() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
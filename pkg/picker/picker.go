@@ -0,0 +1,426 @@
+// Package picker is a Telescope-style fuzzy finder for the terminal: a
+// query line, a ranked list that supports multi-select, and a preview pane
+// for the highlighted item.
+//
+// The query is split into terms that must all match. "#tag" keeps items
+// with a tag starting with tag, "@category" keeps items whose category
+// starts with category, and any other term is fuzzy-matched against the
+// item's title and detail.
+//
+// # Keys
+//
+//	type            filter
+//	up/down         move (also ctrl-p/ctrl-n, ctrl-k/ctrl-j)
+//	tab             select or unselect the highlighted item, then move down
+//	ctrl-a          select every match, or clear the selection
+//	ctrl-r          cycle the preview (e.g. YAML, README)
+//	ctrl-u          clear the query
+//	enter           accept the selection, or the highlighted item
+//	esc / ctrl-c    cancel
+//
+// Model holds the state and renders it, so it can be driven and inspected
+// without a terminal; Run connects it to one.
+package picker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Item is one pickable entry.
+type Item struct {
+	// Title is matched by the query and shown in the list.
+	Title string
+	// Detail follows the title in the list and is also matched.
+	Detail string
+	// Category is matched by "@" query terms.
+	Category string
+	// Tags are matched by "#" query terms.
+	Tags []string
+}
+
+// Preview renders the preview pane for an item.
+type Preview struct {
+	// Name is shown in the pane's header.
+	Name string
+	// Render returns the preview text of items[i]. It may be slow, such as
+	// a network fetch; results are cached per item.
+	Render func(i int) string
+}
+
+// Options configures a picker.
+type Options struct {
+	// Prompt precedes the query (default "> ").
+	Prompt string
+	// Query is the initial query.
+	Query string
+	// Previews are cycled with ctrl-r. Without any, no pane is shown.
+	Previews []Preview
+}
+
+// Model is the state of a picker.
+type Model struct {
+	items    []Item
+	opts     Options
+	query    []rune
+	matches  []int
+	cursor   int
+	offset   int
+	selected map[int]bool
+	preview  int
+	cache    map[[2]int]string
+
+	done     bool
+	canceled bool
+}
+
+// NewModel creates a picker over items.
+func NewModel(items []Item, opts Options) *Model {
+	if opts.Prompt == "" {
+		opts.Prompt = "> "
+	}
+	m := &Model{
+		items:    items,
+		opts:     opts,
+		query:    []rune(opts.Query),
+		selected: make(map[int]bool),
+		cache:    make(map[[2]int]string),
+	}
+	m.filter()
+	return m
+}
+
+// Key is a decoded key press.
+type Key struct {
+	Code KeyCode
+	Rune rune // for KeyRune
+}
+
+// KeyCode identifies a non-printable key.
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyEnter
+	KeyEscape
+	KeyTab
+	KeyBackspace
+	KeyCtrlA
+	KeyCtrlR
+	KeyCtrlU
+	KeyUnknown
+)
+
+// pageSize is how far PageUp and PageDown move.
+const pageSize = 10
+
+// Update applies a key press. It reports whether the picker is finished,
+// accepted or canceled.
+func (m *Model) Update(k Key) bool {
+	switch k.Code {
+	case KeyRune:
+		m.query = append(m.query, k.Rune)
+		m.filter()
+	case KeyBackspace:
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.filter()
+		}
+	case KeyCtrlU:
+		m.query = nil
+		m.filter()
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPageUp:
+		m.move(-pageSize)
+	case KeyPageDown:
+		m.move(pageSize)
+	case KeyTab:
+		if i, ok := m.Current(); ok {
+			m.selected[i] = !m.selected[i]
+			if !m.selected[i] {
+				delete(m.selected, i)
+			}
+			m.move(1)
+		}
+	case KeyCtrlA:
+		if len(m.selected) > 0 {
+			m.selected = make(map[int]bool)
+		} else {
+			for _, i := range m.matches {
+				m.selected[i] = true
+			}
+		}
+	case KeyCtrlR:
+		if len(m.opts.Previews) > 0 {
+			m.preview = (m.preview + 1) % len(m.opts.Previews)
+		}
+	case KeyEnter:
+		m.done = true
+	case KeyEscape:
+		m.done, m.canceled = true, true
+	}
+	return m.done
+}
+
+// Canceled reports whether the picker was dismissed without accepting.
+func (m *Model) Canceled() bool {
+	return m.canceled
+}
+
+// Query returns the current query.
+func (m *Model) Query() string {
+	return string(m.query)
+}
+
+// Matches returns the indices of the items matching the query, best first.
+func (m *Model) Matches() []int {
+	return m.matches
+}
+
+// Current returns the index of the highlighted item.
+func (m *Model) Current() (int, bool) {
+	if len(m.matches) == 0 {
+		return 0, false
+	}
+	return m.matches[m.cursor], true
+}
+
+// Result returns the accepted items' indices in item order: the selection,
+// or the highlighted item when nothing is selected.
+func (m *Model) Result() []int {
+	if m.canceled {
+		return nil
+	}
+	if len(m.selected) == 0 {
+		if i, ok := m.Current(); ok {
+			return []int{i}
+		}
+		return nil
+	}
+	result := make([]int, 0, len(m.selected))
+	for i := range m.selected {
+		result = append(result, i)
+	}
+	sort.Ints(result)
+	return result
+}
+
+func (m *Model) move(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.matches)-1)
+}
+
+// filter re-ranks the items against the query, keeping the highlighted
+// item when it still matches.
+func (m *Model) filter() {
+	current, hadCurrent := m.Current()
+	terms := strings.Fields(string(m.query))
+
+	type ranked struct{ index, score int }
+	var hits []ranked
+	for i, item := range m.items {
+		if score, ok := matchItem(item, terms); ok {
+			hits = append(hits, ranked{i, score})
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool { return hits[a].score > hits[b].score })
+
+	m.matches = m.matches[:0]
+	m.cursor = 0
+	for n, h := range hits {
+		m.matches = append(m.matches, h.index)
+		if hadCurrent && h.index == current {
+			m.cursor = n
+		}
+	}
+}
+
+func matchItem(item Item, terms []string) (int, bool) {
+	total := 0
+	for _, term := range terms {
+		switch {
+		case strings.HasPrefix(term, "#") && len(term) > 1:
+			if !hasPrefixFold(item.Tags, term[1:]) {
+				return 0, false
+			}
+		case strings.HasPrefix(term, "@") && len(term) > 1:
+			if !hasPrefixFold([]string{item.Category}, term[1:]) {
+				return 0, false
+			}
+		default:
+			score, ok := Score(term, item.Title)
+			if ok {
+				// Title hits outrank detail hits
+				score *= 2
+			} else if score, ok = Score(term, item.Detail); !ok {
+				return 0, false
+			}
+			total += score
+		}
+	}
+	return total, true
+}
+
+func hasPrefixFold(values []string, prefix string) bool {
+	prefix = strings.ToLower(prefix)
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Score fuzzy-matches pattern against text, case-insensitively: every rune
+// of pattern must appear in text in order. Runs of consecutive runes and
+// matches at the start of a word score higher, and shorter texts win ties.
+func Score(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score, pi, prev := 0, 0, -2
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if t[ti] != p[pi] {
+			continue
+		}
+		s := 1
+		if ti == prev+1 {
+			s += 4
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			s += 3
+		}
+		score += s
+		prev = ti
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score*10 - len(t), true
+}
+
+// View renders the picker into a width×height screen, one string per line.
+func (m *Model) View(width, height int) []string {
+	if width < 10 || height < 3 {
+		return nil
+	}
+
+	header := fmt.Sprintf("%s%s", m.opts.Prompt, string(m.query))
+	status := fmt.Sprintf("%d/%d", len(m.matches), len(m.items))
+	if len(m.selected) > 0 {
+		status += fmt.Sprintf(" (%d selected)", len(m.selected))
+	}
+	lines := []string{padBetween(header, status, width)}
+
+	listHeight := height - 1
+	var pane *Preview
+	if len(m.opts.Previews) > 0 && height >= 8 {
+		pane = &m.opts.Previews[m.preview]
+		listHeight = (height - 1) / 2
+	}
+
+	// Keep the cursor in view
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+	for row := 0; row < listHeight; row++ {
+		n := m.offset + row
+		if n >= len(m.matches) {
+			lines = append(lines, "")
+			continue
+		}
+		i := m.matches[n]
+		mark := "  "
+		if m.selected[i] {
+			mark = "● "
+		}
+		title := truncate(mark+m.items[i].Title, width)
+		detail := ""
+		if rest := width - utf8.RuneCountInString(title) - 2; rest > 0 && m.items[i].Detail != "" {
+			detail = "  " + truncate(m.items[i].Detail, rest)
+		}
+		if n == m.cursor {
+			lines = append(lines, reverse(padRight(title+detail, width)))
+		} else {
+			lines = append(lines, title+dim(detail))
+		}
+	}
+
+	if pane == nil {
+		return lines
+	}
+	name := pane.Name
+	if len(m.opts.Previews) > 1 {
+		name += " (ctrl-r: switch)"
+	}
+	lines = append(lines, dim(truncate("── "+name+" "+strings.Repeat("─", width), width)))
+	previewHeight := height - len(lines)
+	if i, ok := m.Current(); ok {
+		for _, l := range strings.Split(m.previewText(i), "\n") {
+			if previewHeight == 0 {
+				break
+			}
+			lines = append(lines, truncate(strings.ReplaceAll(l, "\t", "    "), width))
+			previewHeight--
+		}
+	}
+	for ; previewHeight > 0; previewHeight-- {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func (m *Model) previewText(i int) string {
+	key := [2]int{m.preview, i}
+	if text, ok := m.cache[key]; ok {
+		return text
+	}
+	text := m.opts.Previews[m.preview].Render(i)
+	m.cache[key] = text
+	return text
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:width-1]) + "…"
+}
+
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+func padBetween(left, right string, width int) string {
+	gap := width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if gap < 1 {
+		return truncate(left, width)
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+func dim(s string) string     { return "\x1b[2m" + s + "\x1b[0m" }
+func reverse(s string) string { return "\x1b[7m" + s + "\x1b[0m" }
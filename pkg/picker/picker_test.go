@@ -0,0 +1,139 @@
+package picker
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() []Item {
+	return []Item{
+		{Title: "telescope", Detail: "Fuzzy finder", Category: "navigation", Tags: []string{"finder", "search"}},
+		{Title: "treesitter", Detail: "Syntax highlighting", Category: "syntax", Tags: []string{"syntax"}},
+		{Title: "lspconfig", Detail: "LSP client configs", Category: "lsp", Tags: []string{"lsp"}},
+		{Title: "harpoon", Detail: "Quick file marks", Category: "navigation", Tags: []string{"files"}},
+	}
+}
+
+func typeQuery(m *Model, q string) {
+	for _, r := range q {
+		m.Update(Key{Code: KeyRune, Rune: r})
+	}
+}
+
+func titles(m *Model) []string {
+	var out []string
+	for _, i := range m.Matches() {
+		out = append(out, m.items[i].Title)
+	}
+	return out
+}
+
+func TestScore(t *testing.T) {
+	_, ok := Score("tsc", "telescope")
+	assert.True(t, ok)
+	_, ok = Score("xyz", "telescope")
+	assert.False(t, ok)
+
+	// Consecutive and word-start matches rank higher
+	prefix, _ := Score("tel", "telescope")
+	scattered, _ := Score("tel", "treesitter-textobjects-el")
+	assert.Greater(t, prefix, scattered)
+
+	// Case-insensitive
+	_, ok = Score("LSP", "lspconfig")
+	assert.True(t, ok)
+}
+
+func TestFilter(t *testing.T) {
+	m := NewModel(testItems(), Options{})
+	assert.Len(t, m.Matches(), 4)
+
+	typeQuery(m, "te")
+	assert.Equal(t, "telescope", titles(m)[0])
+
+	m.Update(Key{Code: KeyCtrlU})
+	typeQuery(m, "@nav")
+	assert.ElementsMatch(t, []string{"telescope", "harpoon"}, titles(m))
+
+	m.Update(Key{Code: KeyCtrlU})
+	typeQuery(m, "#syn")
+	assert.Equal(t, []string{"treesitter"}, titles(m))
+
+	// Detail text is matched too
+	m.Update(Key{Code: KeyCtrlU})
+	typeQuery(m, "marks")
+	assert.Equal(t, []string{"harpoon"}, titles(m))
+
+	for range "marks" {
+		m.Update(Key{Code: KeyBackspace})
+	}
+	assert.Len(t, m.Matches(), 4)
+}
+
+func TestSelection(t *testing.T) {
+	m := NewModel(testItems(), Options{})
+
+	// Nothing selected: enter accepts the highlighted item
+	m.Update(Key{Code: KeyDown})
+	assert.True(t, m.Update(Key{Code: KeyEnter}))
+	assert.Equal(t, []int{1}, m.Result())
+
+	m = NewModel(testItems(), Options{})
+	m.Update(Key{Code: KeyTab}) // selects 0, moves to 1
+	m.Update(Key{Code: KeyDown})
+	m.Update(Key{Code: KeyTab}) // selects 2
+	m.Update(Key{Code: KeyEnter})
+	assert.Equal(t, []int{0, 2}, m.Result())
+
+	m = NewModel(testItems(), Options{})
+	m.Update(Key{Code: KeyCtrlA})
+	m.Update(Key{Code: KeyEnter})
+	assert.Equal(t, []int{0, 1, 2, 3}, m.Result())
+
+	m = NewModel(testItems(), Options{})
+	m.Update(Key{Code: KeyTab})
+	assert.True(t, m.Update(Key{Code: KeyEscape}))
+	assert.True(t, m.Canceled())
+	assert.Nil(t, m.Result())
+}
+
+func TestView(t *testing.T) {
+	calls := 0
+	m := NewModel(testItems(), Options{
+		Query: "tele",
+		Previews: []Preview{
+			{Name: "YAML", Render: func(i int) string { calls++; return "name: " + testItems()[i].Title }},
+			{Name: "README", Render: func(i int) string { return "# readme" }},
+		},
+	})
+
+	lines := m.View(60, 12)
+	require.Len(t, lines, 12)
+	assert.True(t, strings.HasPrefix(lines[0], "> tele"))
+	assert.Contains(t, lines[0], "1/4")
+	assert.Contains(t, strings.Join(lines, "\n"), "name: telescope")
+
+	// Previews are cached
+	m.View(60, 12)
+	assert.Equal(t, 1, calls)
+
+	m.Update(Key{Code: KeyCtrlR})
+	assert.Contains(t, strings.Join(m.View(60, 12), "\n"), "# readme")
+}
+
+func TestReadKey(t *testing.T) {
+	in := bufio.NewReader(strings.NewReader("a\x1b[A\x1b[B\t\r\x7f"))
+	var codes []KeyCode
+	for {
+		k, err := ReadKey(in)
+		if err != nil {
+			break
+		}
+		codes = append(codes, k.Code)
+	}
+	assert.Equal(t, []KeyCode{KeyRune, KeyUp, KeyDown, KeyTab, KeyEnter, KeyBackspace}, codes)
+}
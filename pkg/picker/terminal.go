@@ -0,0 +1,122 @@
+package picker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"devopsmaestro/pkg/interactive"
+	"golang.org/x/term"
+)
+
+// Run shows the picker full-screen on the terminal and returns the indices
+// of the accepted items. It returns interactive.ErrNonInteractive when
+// prompting is disabled and interactive.ErrAborted when the user cancels.
+func Run(items []Item, opts Options) ([]int, error) {
+	if !interactive.Enabled() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, interactive.ErrNonInteractive
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enter raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	// Alternate screen, hidden cursor; both undone on exit
+	fmt.Fprint(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[?1049l")
+
+	m := NewModel(items, opts)
+	in := bufio.NewReader(os.Stdin)
+	for {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil || width == 0 || height == 0 {
+			width, height = 80, 24
+		}
+		draw(os.Stdout, m.View(width, height))
+
+		k, err := ReadKey(in)
+		if err != nil {
+			return nil, interactive.ErrAborted
+		}
+		if m.Update(k) {
+			break
+		}
+	}
+	if m.Canceled() {
+		return nil, interactive.ErrAborted
+	}
+	return m.Result(), nil
+}
+
+func draw(w io.Writer, lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+	b.WriteString(strings.Join(lines, "\r\n"))
+	io.WriteString(w, b.String())
+}
+
+// ReadKey decodes one key press from a raw-mode terminal.
+func ReadKey(r *bufio.Reader) (Key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return Key{}, err
+	}
+	switch c {
+	case '\r':
+		return Key{Code: KeyEnter}, nil
+	case '\t':
+		return Key{Code: KeyTab}, nil
+	case 0x7f, 0x08:
+		return Key{Code: KeyBackspace}, nil
+	case 0x03: // ctrl-c
+		return Key{Code: KeyEscape}, nil
+	case 0x01:
+		return Key{Code: KeyCtrlA}, nil
+	case 0x12:
+		return Key{Code: KeyCtrlR}, nil
+	case 0x15:
+		return Key{Code: KeyCtrlU}, nil
+	case 0x10, 0x0b: // ctrl-p, ctrl-k
+		return Key{Code: KeyUp}, nil
+	case 0x0e, 0x0a: // ctrl-n, ctrl-j
+		return Key{Code: KeyDown}, nil
+	case 0x1b:
+		return readEscape(r)
+	}
+	if c < 0x20 {
+		return Key{Code: KeyUnknown}, nil
+	}
+	return Key{Code: KeyRune, Rune: c}, nil
+}
+
+// readEscape decodes the rest of an escape sequence. A lone ESC (nothing
+// buffered after it) cancels.
+func readEscape(r *bufio.Reader) (Key, error) {
+	if r.Buffered() == 0 {
+		return Key{Code: KeyEscape}, nil
+	}
+	b, _ := r.ReadByte()
+	if b != '[' && b != 'O' {
+		return Key{Code: KeyUnknown}, nil
+	}
+	b, _ = r.ReadByte()
+	switch b {
+	case 'A':
+		return Key{Code: KeyUp}, nil
+	case 'B':
+		return Key{Code: KeyDown}, nil
+	case '5', '6':
+		if t, _ := r.ReadByte(); t == '~' {
+			if b == '5' {
+				return Key{Code: KeyPageUp}, nil
+			}
+			return Key{Code: KeyPageDown}, nil
+		}
+	}
+	return Key{Code: KeyUnknown}, nil
+}
@@ -0,0 +1,114 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"devopsmaestro/operators"
+)
+
+// PlatformCheck verifies that a container platform is detected and running
+type PlatformCheck struct {
+	detector operators.PlatformDetector
+}
+
+// NewPlatformCheck creates a new PlatformCheck
+func NewPlatformCheck(detector operators.PlatformDetector) *PlatformCheck {
+	return &PlatformCheck{detector: detector}
+}
+
+// Name returns the check name
+func (pc *PlatformCheck) Name() string {
+	return "Container Platform"
+}
+
+// Run executes the platform check
+func (pc *PlatformCheck) Run(ctx context.Context) CheckResult {
+	platform, err := pc.detector.Detect()
+	if err != nil {
+		return CheckResult{
+			Status:  StatusError,
+			Message: err.Error(),
+		}
+	}
+	details := map[string]interface{}{"socket": platform.SocketPath}
+	if !platform.IsReachable() {
+		details["recommendation"] = platform.GetStartHint()
+		return CheckResult{
+			Status:  StatusError,
+			Message: platform.Name + " is not running",
+			Details: details,
+		}
+	}
+	return CheckResult{
+		Status:  StatusOK,
+		Message: platform.Name + " is running",
+		Details: details,
+	}
+}
+
+// VMStatusProvider reports the state of a container VM, such as
+// *operators.ColimaVM
+type VMStatusProvider interface {
+	Status(ctx context.Context) (*operators.VMStatus, error)
+}
+
+// ColimaVMCheck verifies that the Colima VM is running and has the
+// resources workspaces need
+type ColimaVMCheck struct {
+	vm           VMStatusProvider
+	requirements operators.VMRequirements
+}
+
+// NewColimaVMCheck creates a new ColimaVMCheck
+func NewColimaVMCheck(vm VMStatusProvider, requirements operators.VMRequirements) *ColimaVMCheck {
+	return &ColimaVMCheck{vm: vm, requirements: requirements}
+}
+
+// Name returns the check name
+func (vc *ColimaVMCheck) Name() string {
+	return "Colima VM"
+}
+
+// Run executes the Colima VM check
+func (vc *ColimaVMCheck) Run(ctx context.Context) CheckResult {
+	status, err := vc.vm.Status(ctx)
+	if err != nil {
+		return CheckResult{
+			Status:  StatusError,
+			Message: "Failed to get VM status: " + err.Error(),
+		}
+	}
+	startHint := "colima start --profile " + status.Profile
+	if !status.Exists {
+		return CheckResult{
+			Status:  StatusError,
+			Message: fmt.Sprintf("Profile %s does not exist", status.Profile),
+			Details: map[string]interface{}{"recommendation": "Create it with: " + startHint},
+		}
+	}
+	if !status.Running {
+		return CheckResult{
+			Status:  StatusError,
+			Message: fmt.Sprintf("Profile %s is stopped", status.Profile),
+			Details: map[string]interface{}{"recommendation": "Start it with: dvm start runtime (or " + startHint + ")"},
+		}
+	}
+
+	summary := fmt.Sprintf("Profile %s is running: %d CPUs, %s memory, %s disk",
+		status.Profile, status.CPUs, operators.FormatGiB(status.MemoryBytes), operators.FormatGiB(status.DiskBytes))
+	if shortfalls := status.Shortfalls(vc.requirements); len(shortfalls) > 0 {
+		return CheckResult{
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Profile %s is smaller than required (%s)", status.Profile, strings.Join(shortfalls, ", ")),
+			Details: map[string]interface{}{
+				"recommendation": "Resize it with: colima stop --profile " + status.Profile + " && dvm start runtime",
+			},
+		}
+	}
+	return CheckResult{
+		Status:  StatusOK,
+		Message: summary,
+	}
+}
@@ -0,0 +1,85 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"devopsmaestro/operators"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVM struct {
+	status *operators.VMStatus
+	err    error
+}
+
+func (f *fakeVM) Status(ctx context.Context) (*operators.VMStatus, error) {
+	return f.status, f.err
+}
+
+func TestColimaVMCheck_ImplementsCheckInterface(t *testing.T) {
+	var _ Check = (*ColimaVMCheck)(nil)
+	var _ Check = (*PlatformCheck)(nil)
+	var _ VMStatusProvider = (*operators.ColimaVM)(nil)
+}
+
+func TestColimaVMCheck_Run(t *testing.T) {
+	running := &operators.VMStatus{Profile: "default", Exists: true, Running: true, CPUs: 2, MemoryBytes: 4 << 30, DiskBytes: 60 << 30}
+
+	tests := []struct {
+		name        string
+		vm          *fakeVM
+		req         operators.VMRequirements
+		wantStatus  CheckStatus
+		wantMessage string
+		wantRec     string
+	}{
+		{
+			name:        "status error",
+			vm:          &fakeVM{err: errors.New("colima is not installed")},
+			wantStatus:  StatusError,
+			wantMessage: "Failed to get VM status: colima is not installed",
+		},
+		{
+			name:        "profile missing",
+			vm:          &fakeVM{status: &operators.VMStatus{Profile: "work"}},
+			wantStatus:  StatusError,
+			wantMessage: "Profile work does not exist",
+			wantRec:     "Create it with: colima start --profile work",
+		},
+		{
+			name:        "stopped",
+			vm:          &fakeVM{status: &operators.VMStatus{Profile: "work", Exists: true}},
+			wantStatus:  StatusError,
+			wantMessage: "Profile work is stopped",
+			wantRec:     "Start it with: dvm start runtime (or colima start --profile work)",
+		},
+		{
+			name:        "too small",
+			vm:          &fakeVM{status: running},
+			req:         operators.VMRequirements{MemoryBytes: 8 << 30},
+			wantStatus:  StatusWarning,
+			wantMessage: "Profile default is smaller than required (memory: 4.0 GiB < 8.0 GiB required)",
+			wantRec:     "Resize it with: colima stop --profile default && dvm start runtime",
+		},
+		{
+			name:        "ok",
+			vm:          &fakeVM{status: running},
+			req:         operators.VMRequirements{CPUs: 2, MemoryBytes: 4 << 30},
+			wantStatus:  StatusOK,
+			wantMessage: "Profile default is running: 2 CPUs, 4.0 GiB memory, 60.0 GiB disk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewColimaVMCheck(tt.vm, tt.req).Run(context.Background())
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantMessage, result.Message)
+			rec, _ := result.Details["recommendation"].(string)
+			assert.Equal(t, tt.wantRec, rec)
+		})
+	}
+}
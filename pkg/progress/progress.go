@@ -0,0 +1,285 @@
+// Package progress reports the progress of concurrent tasks such as the
+// workspaces of a parallel build or the resource types of a library import.
+//
+// A Reporter renders its tasks in one of three modes:
+//
+//   - tty: a live block with a spinner (or a bar, when the task has a total)
+//     per running task, redrawn in place; finished tasks scroll above it
+//   - plain: one line per task event, for logs and CI
+//   - json: one Event per line (JSON Lines), for tools that follow progress
+//
+// # Usage
+//
+//	r := progress.New(os.Stdout, progress.ModeAuto)
+//	defer r.Close()
+//	task := r.Start("backend/api/dev")
+//	task.Update("building image")
+//	task.Done()
+//
+// Task methods are safe for concurrent use and are no-ops on a nil *Task,
+// so code paths without a reporter can pass nil.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Mode selects how a Reporter renders progress.
+type Mode string
+
+const (
+	// ModeAuto uses ModeTTY when the output is a terminal and ModePlain
+	// otherwise.
+	ModeAuto Mode = "auto"
+	// ModeTTY redraws a live block of spinners and bars.
+	ModeTTY Mode = "tty"
+	// ModePlain prints one line per task event.
+	ModePlain Mode = "plain"
+	// ModeJSON prints one JSON Event per line.
+	ModeJSON Mode = "json"
+)
+
+// Modes lists the accepted --progress values.
+var Modes = []Mode{ModeAuto, ModeTTY, ModePlain, ModeJSON}
+
+// ParseMode parses a --progress value. The empty string means ModeAuto.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ModeAuto, nil
+	}
+	for _, m := range Modes {
+		if Mode(s) == m {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid progress mode %q (valid: auto, tty, plain, json)", s)
+}
+
+// String implements pflag.Value.
+func (m *Mode) String() string {
+	return string(*m)
+}
+
+// Set implements pflag.Value, rejecting unknown modes.
+func (m *Mode) Set(s string) error {
+	parsed, err := ParseMode(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Type implements pflag.Value.
+func (m *Mode) Type() string {
+	return "mode"
+}
+
+// Event types in the json stream.
+const (
+	EventStart  = "start"
+	EventUpdate = "update"
+	EventDone   = "done"
+	EventFail   = "fail"
+	EventLog    = "log"
+)
+
+// Event is one line of the json stream.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"`
+	Task    string    `json:"task,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Current int       `json:"current,omitempty"`
+	Total   int       `json:"total,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	// ElapsedMS is the task's run time, set on done and fail events.
+	ElapsedMS int64 `json:"elapsedMs,omitempty"`
+}
+
+// redrawInterval is how often the tty block is redrawn.
+const redrawInterval = 100 * time.Millisecond
+
+// Reporter renders the progress of a set of tasks to a writer.
+type Reporter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	mode  Mode
+	width int
+	now   func() time.Time
+
+	// tty state
+	running  []*Task
+	finished []*Task // finished since the last redraw
+	drawn    int     // lines in the live block
+	frame    int
+	stop     chan struct{}
+	stopped  chan struct{}
+	closed   bool
+}
+
+// New returns a reporter writing to w. ModeAuto resolves to ModeTTY when w
+// is a terminal.
+func New(w io.Writer, mode Mode) *Reporter {
+	r := &Reporter{w: w, mode: mode, width: 80, now: time.Now}
+	f, isFile := w.(*os.File)
+	if r.mode == ModeAuto || r.mode == "" {
+		r.mode = ModePlain
+		if isFile && term.IsTerminal(int(f.Fd())) {
+			r.mode = ModeTTY
+		}
+	}
+	if r.mode == ModeTTY {
+		if isFile {
+			if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+				r.width = width
+			}
+		}
+		r.stop = make(chan struct{})
+		r.stopped = make(chan struct{})
+		go r.loop()
+	}
+	return r
+}
+
+// Mode returns the resolved rendering mode.
+func (r *Reporter) Mode() Mode {
+	return r.mode
+}
+
+// Start begins a task and returns it.
+func (r *Reporter) Start(name string) *Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := &Task{r: r, name: name, started: r.now()}
+	switch r.mode {
+	case ModeTTY:
+		r.running = append(r.running, t)
+	case ModeJSON:
+		r.emit(Event{Event: EventStart, Task: name})
+	default:
+		fmt.Fprintf(r.w, "[%s] started\n", name)
+	}
+	return t
+}
+
+// Log prints text that is not tied to a task: above the live block in tty
+// mode, as is in plain mode, and as a log event in json mode.
+func (r *Reporter) Log(text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.mode {
+	case ModeTTY:
+		r.clear()
+		fmt.Fprint(r.w, text)
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			fmt.Fprintln(r.w)
+		}
+		r.draw()
+	case ModeJSON:
+		r.emit(Event{Event: EventLog, Message: strings.TrimRight(text, "\n")})
+	default:
+		fmt.Fprint(r.w, text)
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			fmt.Fprintln(r.w)
+		}
+	}
+}
+
+// Close stops redrawing and leaves the final state of every task on
+// screen. The reporter must not be used afterwards.
+func (r *Reporter) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	r.mu.Unlock()
+	if r.mode != ModeTTY {
+		return
+	}
+	close(r.stop)
+	<-r.stopped
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clear()
+	r.draw()
+}
+
+func (r *Reporter) loop() {
+	defer close(r.stopped)
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			r.frame++
+			r.clear()
+			r.draw()
+			r.mu.Unlock()
+		}
+	}
+}
+
+// clear erases the live block. Callers hold r.mu.
+func (r *Reporter) clear() {
+	if r.drawn > 0 {
+		fmt.Fprintf(r.w, "\x1b[%dA\x1b[J", r.drawn)
+		r.drawn = 0
+	}
+}
+
+// draw prints tasks that finished since the last draw, then the live block
+// of running tasks. Callers hold r.mu after clear.
+func (r *Reporter) draw() {
+	for _, t := range r.finished {
+		fmt.Fprintln(r.w, r.fit(t.line(r.frame, r.now())))
+	}
+	r.finished = nil
+	for _, t := range r.running {
+		fmt.Fprintln(r.w, r.fit(t.line(r.frame, r.now())))
+	}
+	r.drawn = len(r.running)
+}
+
+// fit truncates a line to the terminal width so redraws stay aligned.
+func (r *Reporter) fit(line string) string {
+	runes := []rune(line)
+	if len(runes) < r.width {
+		return line
+	}
+	return string(runes[:r.width-1])
+}
+
+// emit writes a json event. Callers hold r.mu.
+func (r *Reporter) emit(e Event) {
+	e.Time = r.now().UTC()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	r.w.Write(append(data, '\n'))
+}
+
+// finish moves t out of the live block. Callers hold r.mu.
+func (r *Reporter) finish(t *Task) {
+	for i, rt := range r.running {
+		if rt == t {
+			r.running = append(r.running[:i], r.running[i+1:]...)
+			break
+		}
+	}
+	r.finished = append(r.finished, t)
+}
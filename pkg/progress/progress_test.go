@@ -0,0 +1,177 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock advances one second per call (including the timestamp of each
+// json event) so elapsed times are stable.
+func fakeClock() func() time.Time {
+	var mu sync.Mutex
+	t := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		t = t.Add(time.Second)
+		return t
+	}
+}
+
+func newTestReporter(mode Mode) (*Reporter, *bytes.Buffer) {
+	var buf bytes.Buffer
+	r := New(&buf, mode)
+	r.mu.Lock() // the tty redraw loop is already running
+	r.now = fakeClock()
+	r.mu.Unlock()
+	return r, &buf
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"auto", "tty", "plain", "json"} {
+		if m, err := ParseMode(s); err != nil || string(m) != s {
+			t.Errorf("ParseMode(%q) = %q, %v", s, m, err)
+		}
+	}
+	if m, err := ParseMode(""); err != nil || m != ModeAuto {
+		t.Errorf("ParseMode(\"\") = %q, %v, want auto", m, err)
+	}
+	if _, err := ParseMode("fancy"); err == nil {
+		t.Error("ParseMode(fancy) succeeded")
+	}
+}
+
+func TestNew_AutoWithoutTerminalIsPlain(t *testing.T) {
+	r := New(&bytes.Buffer{}, ModeAuto)
+	defer r.Close()
+	if r.Mode() != ModePlain {
+		t.Errorf("Mode() = %q, want plain", r.Mode())
+	}
+}
+
+func TestPlain(t *testing.T) {
+	r, buf := newTestReporter(ModePlain)
+	task := r.Start("api/dev")
+	task.Update("building image")
+	task.Done()
+	task.Update("ignored after done")
+
+	failed := r.Start("web/dev")
+	failed.SetTotal(4)
+	failed.Advance(1, "staging")
+	failed.Fail(errors.New("boom"))
+	r.Log("summary")
+	r.Close()
+
+	want := strings.Join([]string{
+		"[api/dev] started",
+		"[api/dev] building image",
+		"[api/dev] done (1s)",
+		"[web/dev] started",
+		"[web/dev] 1/4 staging",
+		"[web/dev] failed after 1s: boom",
+		"summary",
+		"",
+	}, "\n")
+	if buf.String() != want {
+		t.Errorf("plain output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	r, buf := newTestReporter(ModeJSON)
+	task := r.Start("nvim-plugins")
+	task.SetTotal(2)
+	task.Advance(1, "importing")
+	task.Fail(errors.New("boom"))
+	r.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not a JSON event: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %+v", len(events), events)
+	}
+	if events[0].Event != EventStart || events[0].Task != "nvim-plugins" {
+		t.Errorf("first event = %+v", events[0])
+	}
+	if e := events[1]; e.Event != EventUpdate || e.Current != 1 || e.Total != 2 || e.Message != "importing" {
+		t.Errorf("update event = %+v", e)
+	}
+	if e := events[2]; e.Event != EventFail || e.Error != "boom" || e.ElapsedMS != 3000 {
+		t.Errorf("fail event = %+v", e)
+	}
+}
+
+func TestTTY(t *testing.T) {
+	r, buf := newTestReporter(ModeTTY)
+	a := r.Start("api/dev")
+	b := r.Start("web/dev")
+	b.SetTotal(4)
+	b.Advance(2, "staging")
+	a.Done()
+	b.Fail(errors.New("boom"))
+	r.Close()
+
+	out := buf.String()
+	for _, want := range []string{"✓ api/dev  done", "✗ web/dev  failed: boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("tty output missing %q:\n%s", want, out)
+		}
+	}
+	if r.drawn != 0 {
+		t.Errorf("live block has %d lines after all tasks finished", r.drawn)
+	}
+}
+
+func TestTaskLine_Bar(t *testing.T) {
+	r, _ := newTestReporter(ModePlain)
+	task := r.Start("sync")
+	task.SetTotal(4)
+	task.Advance(2, "nvim-themes")
+	line := task.line(0, task.started.Add(3*time.Second))
+	want := "⠋ sync  [██████████░░░░░░░░░░] 2/4  nvim-themes (3s)"
+	if line != want {
+		t.Errorf("line() = %q, want %q", line, want)
+	}
+}
+
+func TestNilTask(t *testing.T) {
+	var task *Task
+	task.Update("x")
+	task.SetTotal(1)
+	task.Advance(1, "x")
+	task.Done()
+	task.Fail(errors.New("x"))
+}
+
+func TestConcurrentTasks(t *testing.T) {
+	r, buf := newTestReporter(ModeJSON)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task := r.Start(strings.Repeat("w", i+1))
+			task.Update("building")
+			task.Done()
+		}(i)
+	}
+	wg.Wait()
+	r.Close()
+	if lines := strings.Count(buf.String(), "\n"); lines != 24 {
+		t.Errorf("got %d events, want 24", lines)
+	}
+}
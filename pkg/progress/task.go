@@ -0,0 +1,168 @@
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// barWidth is the width of a tty progress bar in cells.
+const barWidth = 20
+
+// Task is one unit of work shown by a Reporter.
+type Task struct {
+	r       *Reporter
+	name    string
+	message string
+	current int
+	total   int
+	started time.Time
+	ended   time.Time
+	err     error
+}
+
+// Update sets the task's status message, typically the current stage.
+func (t *Task) Update(message string) {
+	if t == nil {
+		return
+	}
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	if !t.ended.IsZero() {
+		return
+	}
+	t.message = message
+	t.report(EventUpdate)
+}
+
+// SetTotal gives the task a known amount of work, turning its spinner
+// into a bar.
+func (t *Task) SetTotal(total int) {
+	if t == nil {
+		return
+	}
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	t.total = total
+}
+
+// Advance records n more units of work done and reports message.
+func (t *Task) Advance(n int, message string) {
+	if t == nil {
+		return
+	}
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	if !t.ended.IsZero() {
+		return
+	}
+	t.current += n
+	t.message = message
+	t.report(EventUpdate)
+}
+
+// Done marks the task finished successfully.
+func (t *Task) Done() {
+	if t == nil {
+		return
+	}
+	t.end(nil)
+}
+
+// Fail marks the task failed with err.
+func (t *Task) Fail(err error) {
+	if t == nil {
+		return
+	}
+	t.end(err)
+}
+
+func (t *Task) end(err error) {
+	t.r.mu.Lock()
+	defer t.r.mu.Unlock()
+	if !t.ended.IsZero() {
+		return
+	}
+	t.ended = t.r.now()
+	t.err = err
+	if err != nil {
+		t.report(EventFail)
+	} else {
+		t.report(EventDone)
+	}
+}
+
+// report renders an event for t in plain and json mode, or queues a
+// redraw in tty mode. Callers hold t.r.mu.
+func (t *Task) report(event string) {
+	r := t.r
+	switch r.mode {
+	case ModeTTY:
+		if event == EventDone || event == EventFail {
+			r.finish(t)
+		}
+	case ModeJSON:
+		e := Event{Event: event, Task: t.name, Message: t.message, Current: t.current, Total: t.total}
+		if event == EventDone || event == EventFail {
+			e.Message = ""
+			e.ElapsedMS = t.ended.Sub(t.started).Milliseconds()
+		}
+		if t.err != nil {
+			e.Error = t.err.Error()
+		}
+		r.emit(e)
+	default:
+		fmt.Fprintf(r.w, "[%s] %s\n", t.name, t.plain(event))
+	}
+}
+
+// plain returns the text of a plain-mode line.
+func (t *Task) plain(event string) string {
+	switch event {
+	case EventDone:
+		return fmt.Sprintf("done (%s)", formatElapsed(t.ended.Sub(t.started)))
+	case EventFail:
+		return fmt.Sprintf("failed after %s: %v", formatElapsed(t.ended.Sub(t.started)), t.err)
+	}
+	if t.total > 0 {
+		return fmt.Sprintf("%d/%d %s", t.current, t.total, t.message)
+	}
+	return t.message
+}
+
+// line returns the task's line in the tty block.
+func (t *Task) line(frame int, now time.Time) string {
+	switch {
+	case t.err != nil:
+		return fmt.Sprintf("✗ %s  failed: %v (%s)", t.name, t.err, formatElapsed(t.ended.Sub(t.started)))
+	case !t.ended.IsZero():
+		return fmt.Sprintf("✓ %s  done (%s)", t.name, formatElapsed(t.ended.Sub(t.started)))
+	}
+	var b strings.Builder
+	if t.total > 0 {
+		filled := t.current * barWidth / t.total
+		if filled > barWidth {
+			filled = barWidth
+		}
+		fmt.Fprintf(&b, "%s %s  [%s%s] %d/%d", spinnerFrames[frame%len(spinnerFrames)], t.name,
+			strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), t.current, t.total)
+	} else {
+		fmt.Fprintf(&b, "%s %s", spinnerFrames[frame%len(spinnerFrames)], t.name)
+	}
+	if t.message != "" {
+		fmt.Fprintf(&b, "  %s", t.message)
+	}
+	fmt.Fprintf(&b, " (%s)", formatElapsed(now.Sub(t.started)))
+	return b.String()
+}
+
+// formatElapsed rounds durations for display: tenths of a second under a
+// minute, whole seconds above.
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -2,7 +2,9 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
@@ -65,6 +67,17 @@ func (m *AthensManager) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to ensure binary: %w", err)
 	}
 
+	// Resolve the logins of private upstreams
+	var auths []*UpstreamAuth
+	for _, u := range m.config.Upstreams {
+		if u.Auth != nil {
+			auths = append(auths, u.Auth)
+		}
+	}
+	if err := resolveUpstreamAuths(auths); err != nil {
+		return fmt.Errorf("failed to resolve upstream credentials: %w", err)
+	}
+
 	// Generate Athens config
 	athensConfig, err := GenerateAthensConfig(m.config)
 	if err != nil {
@@ -95,10 +108,23 @@ func (m *AthensManager) Start(ctx context.Context) error {
 	}
 
 	// Start Athens process
-	// Athens uses environment variables for config instead of --config flag
-	args := []string{}
+	args := []string{"-config_file", configPath}
 	if err := m.processManager.Start(ctx, binaryPath, args, procConfig); err != nil {
-		return fmt.Errorf("failed to start proxy: %w", err)
+		// If the process is already running (adopted from stale PID), verify
+		// it's actually serving before returning an error (#385).
+		if errors.Is(err, ErrProcessAlreadyRunning) {
+			if ProbeServiceHealth(m.config.Port, "/healthz", []int{200}) {
+				m.RecordStartLocked()
+				return nil // Adopt the running instance
+			}
+			// PID exists but service not healthy — kill and retry
+			m.processManager.Stop(ctx)
+			if retryErr := m.processManager.Start(ctx, binaryPath, args, procConfig); retryErr != nil {
+				return fmt.Errorf("failed to start proxy after cleanup: %w", retryErr)
+			}
+		} else {
+			return fmt.Errorf("failed to start proxy: %w", err)
+		}
 	}
 
 	// Record start time
@@ -151,14 +177,8 @@ func (m *AthensManager) Status(ctx context.Context) (*GoModuleProxyStatus, error
 	// Get version
 	version, _ := m.binaryManager.GetVersion(ctx)
 
-	// Get module count and disk usage
-	moduleCount := 0
-	var diskUsage int64
-
-	if running {
-		// Count modules in storage directory
-		moduleCount, diskUsage = m.getProxyStats(ctx)
-	}
+	// Count cached modules; the storage is readable while stopped too
+	stats := m.StorageStats(ctx)
 
 	return &GoModuleProxyStatus{
 		State:       state,
@@ -167,8 +187,8 @@ func (m *AthensManager) Status(ctx context.Context) (*GoModuleProxyStatus, error
 		Storage:     m.config.Storage,
 		Version:     version,
 		Uptime:      uptime,
-		ModuleCount: moduleCount,
-		DiskUsage:   diskUsage,
+		ModuleCount: stats.Items,
+		DiskUsage:   stats.DiskUsage,
 	}, nil
 }
 
@@ -210,7 +230,6 @@ func (m *AthensManager) GetGoEnv() map[string]string {
 	return map[string]string{
 		"GOPROXY":   endpoint,
 		"GONOSUMDB": "*", // Disable checksum verification for local development
-		"GOPRIVATE": "*", // Mark all modules as private for local development
 	}
 }
 
@@ -231,27 +250,27 @@ func (m *AthensManager) waitForReady(ctx context.Context) error {
 	return WaitForReady(ctx, endpoint, []int{200}, 10*time.Second)
 }
 
-// getProxyStats counts modules and disk usage.
-func (m *AthensManager) getProxyStats(ctx context.Context) (int, int64) {
-	// Athens stores modules in a directory structure
-	// Count directories in storage path
-	moduleCount := 0
-	var diskUsage int64
+// StorageStats measures the module cache. Athens stores each version as
+// <module>/<version>/ with a go.mod, so a directory with a go.mod is one
+// version and its parent one module. Only version directories count towards
+// disk usage, not the binary, logs, or config kept next to them.
+func (m *AthensManager) StorageStats(ctx context.Context) StorageStats {
+	stats := StorageStats{Kind: "modules"}
+	modules := map[string]bool{}
 
-	// Walk the storage directory
-	filepath.Walk(m.config.Storage, func(path string, info os.FileInfo, err error) error {
+	filepath.WalkDir(m.config.Storage, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
-			return nil // Skip errors
+			return nil // Skip unreadable entries
 		}
-		if !info.IsDir() {
-			diskUsage += info.Size()
-			// Count go.mod files as modules
-			if filepath.Base(path) == "go.mod" {
-				moduleCount++
-			}
+		if d.IsDir() || d.Name() != "go.mod" {
+			return nil
 		}
-		return nil
+		stats.Versions++
+		stats.addDir(filepath.Dir(path))
+		modules[filepath.Dir(filepath.Dir(path))] = true
+		return filepath.SkipDir
 	})
 
-	return moduleCount, diskUsage
+	stats.Items = len(modules)
+	return stats
 }
@@ -2,8 +2,11 @@ package registry
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -409,8 +412,8 @@ func TestAthensManager_GetGoEnv_ReturnsCorrectVariables(t *testing.T) {
 	// Should include GONOSUMDB for local development
 	assert.Contains(t, goEnv, "GONOSUMDB", "Should include GONOSUMDB for local development")
 
-	// Should include GOPRIVATE as alternative
-	assert.Contains(t, goEnv, "GOPRIVATE", "Should include GOPRIVATE as alternative")
+	// GOPRIVATE would make the go command bypass the proxy
+	assert.NotContains(t, goEnv, "GOPRIVATE", "GOPRIVATE would bypass the proxy")
 }
 
 func TestAthensManager_GetGoEnv_DifferentPorts(t *testing.T) {
@@ -636,3 +639,74 @@ func TestAthensManager_IsRunning_NoPIDNoHealth(t *testing.T) {
 	assert.False(t, mgr.IsRunning(ctx),
 		"IsRunning should return false when both PID file and health probe fail")
 }
+
+// =============================================================================
+// Config File and Storage Stats Tests
+// =============================================================================
+
+func TestAthensManager_Start_PassesConfigFile(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	storage := t.TempDir()
+	var gotArgs []string
+	var server *http.Server
+	process := &MockProcessManagerForBase{
+		StartFunc: func(ctx context.Context, binary string, args []string, config ProcessConfig) error {
+			gotArgs = args
+			ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+			if err != nil {
+				return err
+			}
+			server = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+			go server.Serve(ln)
+			return nil
+		},
+	}
+	t.Cleanup(func() {
+		if server != nil {
+			server.Close()
+		}
+	})
+
+	mgr, err := NewAthensManager(GoModuleConfig{Lifecycle: "manual", Port: port, Storage: storage},
+		NewMockBinaryManager(storage, "v0.14.1"), process)
+	require.NoError(t, err)
+	require.NoError(t, mgr.Start(context.Background()))
+
+	configPath := filepath.Join(storage, "config.toml")
+	assert.Equal(t, []string{"-config_file", configPath}, gotArgs)
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), fmt.Sprintf(`Port = "127.0.0.1:%d"`, port))
+}
+
+func TestAthensManager_StorageStats(t *testing.T) {
+	storage := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(storage, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	write("github.com/pkg/errors/v0.9.1/go.mod", "module x")
+	write("github.com/pkg/errors/v0.9.1/source.zip", "12345")
+	write("github.com/pkg/errors/v0.8.0/go.mod", "module x")
+	write("golang.org/x/mod/v0.20.0/go.mod", "module y")
+	write("athens.log", "not a module")
+
+	mgr, err := NewAthensManagerDefault(GoModuleConfig{Port: 3000, Storage: storage})
+	require.NoError(t, err)
+	stats := (&AthensManagerAdapter{manager: mgr}).StorageStats(context.Background())
+
+	assert.Equal(t, "modules", stats.Kind)
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, 3, stats.Versions)
+	assert.Equal(t, int64(8+5+8+8), stats.DiskUsage, "the log is not part of the cache")
+	assert.False(t, stats.LastSync.IsZero())
+
+	status, err := mgr.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, status.ModuleCount, "stats are read from disk while stopped")
+}
@@ -7,13 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
+
+	"devopsmaestro/pkg/fetch"
 )
 
 // AthensBinaryManager implements BinaryManager for Athens.
@@ -147,24 +148,28 @@ func (b *AthensBinaryManager) downloadBinary(ctx context.Context, destPath strin
 		arch,
 	)
 
-	// Download the archive
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	// Download the archive and verify it against the release's checksums
+	archivePath := destPath + ".tar.gz"
+	defer os.Remove(archivePath)
+	fetcher := &fetch.Fetcher{MaxSize: maxBinarySize}
+	err := fetcher.Fetch(ctx, fetch.Artifact{
+		Name: "athens",
+		URL:  url,
+		ResolveChecksum: func(ctx context.Context) (string, error) {
+			return b.fetchChecksum(ctx, fetcher, url)
+		},
+	}, archivePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to download athens %s: %w", b.version, err)
 	}
-
-	resp, err := http.DefaultClient.Do(req)
+	archive, err := os.Open(archivePath)
 	if err != nil {
-		return "", fmt.Errorf("%w: %s", ErrDownloadFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: HTTP %d from %s", ErrDownloadFailed, resp.StatusCode, url)
+		return "", err
 	}
+	defer archive.Close()
 
 	// Extract from tar.gz
-	if err := b.extractTarGz(resp.Body, destPath); err != nil {
+	if err := b.extractTarGz(archive, destPath); err != nil {
 		return "", fmt.Errorf("failed to extract archive: %w", err)
 	}
 
@@ -176,6 +181,24 @@ func (b *AthensBinaryManager) downloadBinary(ctx context.Context, destPath strin
 	return destPath, nil
 }
 
+// fetchChecksum looks up the archive's digest in the release's checksum
+// manifest, trying both the plain checksums.txt name and GoReleaser's
+// default athens_<version>_checksums.txt.
+func (b *AthensBinaryManager) fetchChecksum(ctx context.Context, fetcher *fetch.Fetcher, archiveURL string) (string, error) {
+	lastSlash := strings.LastIndex(archiveURL, "/")
+	baseURL, filename := archiveURL[:lastSlash], archiveURL[lastSlash+1:]
+
+	var errs []error
+	for _, manifest := range []string{"checksums.txt", fmt.Sprintf("athens_%s_checksums.txt", b.version)} {
+		sum, err := fetcher.ManifestChecksum(ctx, baseURL+"/"+manifest, filename)
+		if err == nil {
+			return sum, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", manifest, err))
+	}
+	return "", errors.Join(errs...)
+}
+
 // extractTarGz extracts the athens binary from a tar.gz archive.
 func (b *AthensBinaryManager) extractTarGz(r io.Reader, destPath string) error {
 	// Create gzip reader
@@ -2,20 +2,17 @@ package registry
 
 import (
 	"context"
-	"crypto/sha256"
-	"crypto/subtle"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
+
+	"devopsmaestro/pkg/fetch"
 )
 
 // maxBinarySize is the maximum allowed size for downloaded binaries (500 MB).
@@ -174,68 +171,18 @@ func (b *DefaultBinaryManager) downloadBinary(ctx context.Context, destPath stri
 		arch,
 	)
 
-	// Download the binary
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("%w: %s", ErrDownloadFailed, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: HTTP %d from %s", ErrDownloadFailed, resp.StatusCode, url)
-	}
-
-	// Check Content-Length against maximum allowed size
-	if resp.ContentLength > maxBinarySize {
-		return "", fmt.Errorf("binary size %d exceeds maximum allowed %d", resp.ContentLength, maxBinarySize)
-	}
-
-	// Limit actual read to maxBinarySize
-	limitedBody := io.LimitReader(resp.Body, maxBinarySize)
-
-	// Create temporary file
-	tempPath := destPath + ".tmp"
-	f, err := os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	// Download and verify against the release's checksum manifest
+	fetcher := &fetch.Fetcher{MaxSize: maxBinarySize}
+	err := fetcher.Fetch(ctx, fetch.Artifact{
+		Name: "zot",
+		URL:  url,
+		ResolveChecksum: func(ctx context.Context) (string, error) {
+			return b.fetchChecksum(ctx, url)
+		},
+		Mode: 0755,
+	}, destPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer func() {
-		f.Close()
-		os.Remove(tempPath)
-	}()
-
-	// Download to temp file
-	if _, err := io.Copy(f, limitedBody); err != nil {
-		return "", fmt.Errorf("failed to download binary: %w", err)
-	}
-
-	// Close file before verification and moving
-	if err := f.Close(); err != nil {
-		return "", fmt.Errorf("failed to close temp file: %w", err)
-	}
-
-	// Verify integrity
-	expectedSum, err := b.fetchChecksum(ctx, url)
-	if err != nil {
-		return "", fmt.Errorf("checksum fetch failed: %w", err)
-	}
-	if err := b.verifyChecksum(tempPath, expectedSum); err != nil {
-		return "", fmt.Errorf("integrity verification failed: %w", err)
-	}
-
-	// Move temp file to final location
-	if err := os.Rename(tempPath, destPath); err != nil {
-		return "", fmt.Errorf("failed to move binary to final location: %w", err)
-	}
-
-	// Ensure executable
-	if err := os.Chmod(destPath, 0755); err != nil {
-		return "", fmt.Errorf("failed to set executable permissions: %w", err)
+		return "", fmt.Errorf("failed to download zot %s: %w", b.version, err)
 	}
 
 	return destPath, nil
@@ -258,23 +205,7 @@ func (b *DefaultBinaryManager) verifyExecutable(path string) error {
 
 // verifyChecksum verifies the SHA256 checksum of a file.
 func (b *DefaultBinaryManager) verifyChecksum(path string, expectedSum string) error {
-	f, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	hash := sha256.New()
-	if _, err := io.Copy(hash, f); err != nil {
-		return err
-	}
-
-	actualSum := hex.EncodeToString(hash.Sum(nil))
-	if subtle.ConstantTimeCompare([]byte(actualSum), []byte(expectedSum)) != 1 {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s", expectedSum, actualSum)
-	}
-
-	return nil
+	return fetch.VerifyFile(path, expectedSum)
 }
 
 // fetchChecksum downloads the consolidated checksum manifest for the given
@@ -300,42 +231,8 @@ func (b *DefaultBinaryManager) fetchChecksum(ctx context.Context, binaryURL stri
 	baseURL := binaryURL[:lastSlash]
 	filename := binaryURL[lastSlash+1:]
 
-	checksumURL := baseURL + "/checksums.sha256.txt"
-	req, err := http.NewRequestWithContext(ctx, "GET", checksumURL, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create checksum request: %w", err)
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to download checksum: %w", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("checksum download failed with status %d", resp.StatusCode)
-	}
-
-	// Read the entire manifest (limit to 64KB to prevent abuse).
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
-	if err != nil {
-		return "", fmt.Errorf("failed to read checksum manifest: %w", err)
-	}
-
-	// Parse the manifest line by line.
-	// The sha256sum tool produces two formats:
-	//   text mode:   "<hex-digest>  <filename>"
-	//   binary mode:  "<hex-digest> *<filename>"
-	// The Zot project uses binary mode, so the filename field starts with "*".
-	// We strip the leading "*" before comparing.
-	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
-	for _, line := range lines {
-		parts := strings.Fields(strings.TrimSpace(line))
-		if len(parts) >= 2 {
-			entry := strings.TrimPrefix(parts[1], "*")
-			if entry == filename {
-				return parts[0], nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("checksum not found for binary %q in manifest", filename)
+	// The Zot project uses sha256sum's binary mode, so entries read
+	// "<hex-digest> *<filename>"; LookupChecksum accepts both modes.
+	fetcher := &fetch.Fetcher{}
+	return fetcher.ManifestChecksum(ctx, baseURL+"/checksums.sha256.txt", filename)
 }
@@ -70,6 +70,11 @@ func generateBuildKitTOML(endpoint string, mirrors []MirrorConfig) string {
 		b.WriteString("  http = true\n\n")
 	}
 
+	// Images dvm pushes to the registry itself, such as shared base images,
+	// are pulled from it directly, also over HTTP
+	fmt.Fprintf(&b, "[registry.\"%s\"]\n", endpoint)
+	b.WriteString("  http = true\n")
+
 	return b.String()
 }
 
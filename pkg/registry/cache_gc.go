@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheEntry is one cached module version in a pull-through cache's storage.
+type CacheEntry struct {
+	Path    string // directory holding the version
+	Size    int64
+	ModTime time.Time // newest file in the entry
+}
+
+// StaleAthensEntries returns the module versions in an Athens disk storage
+// directory that have not been written for longer than ttl. Athens stores
+// each version as <module>/<version>/ with a go.mod, so every directory
+// containing a go.mod is one entry. A missing storage directory has no
+// entries.
+func StaleAthensEntries(storage string, ttl time.Duration, now time.Time) ([]CacheEntry, error) {
+	if storage == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(storage); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	cutoff := now.Add(-ttl)
+	var stale []CacheEntry
+	err := filepath.WalkDir(storage, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "go.mod" {
+			return nil
+		}
+		entry, err := measureEntry(filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if entry.ModTime.Before(cutoff) {
+			stale = append(stale, entry)
+		}
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan cache %s: %w", storage, err)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i].Path < stale[j].Path })
+	return stale, nil
+}
+
+// measureEntry sums the files directly in dir and finds the newest one.
+func measureEntry(dir string) (CacheEntry, error) {
+	entry := CacheEntry{Path: dir}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return entry, err
+	}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return entry, err
+		}
+		entry.Size += info.Size()
+		if info.ModTime().After(entry.ModTime) {
+			entry.ModTime = info.ModTime()
+		}
+	}
+	return entry, nil
+}
+
+// RemoveCacheEntries deletes the entries and any module directories under
+// storage they leave empty. It stops at the first error, returning what was
+// removed so far.
+func RemoveCacheEntries(storage string, entries []CacheEntry) (removed int, freed int64, err error) {
+	root := filepath.Clean(storage)
+	for _, e := range entries {
+		rel, relErr := filepath.Rel(root, e.Path)
+		if relErr != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return removed, freed, fmt.Errorf("refusing to remove %s: not inside %s", e.Path, storage)
+		}
+		if err := os.RemoveAll(e.Path); err != nil {
+			return removed, freed, fmt.Errorf("failed to remove %s: %w", e.Path, err)
+		}
+		removed++
+		freed += e.Size
+
+		// Remove now-empty parents (module path segments) up to the root
+		for dir := filepath.Dir(e.Path); dir != root && len(dir) > len(root); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+	return removed, freed, nil
+}
@@ -0,0 +1,66 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeAthensVersion creates <storage>/<module>/<version>/ with Athens' files,
+// all with the given modification time.
+func writeAthensVersion(t *testing.T, storage, module, version string, mtime time.Time) string {
+	t.Helper()
+	dir := filepath.Join(storage, filepath.FromSlash(module), version)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, data := range map[string]string{"go.mod": "module " + module + "\n", "source.zip": "zipdata", version + ".info": "{}"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	return dir
+}
+
+func TestStaleAthensEntries(t *testing.T) {
+	storage := t.TempDir()
+	now := time.Now()
+	old := writeAthensVersion(t, storage, "github.com/acme/lib", "v1.0.0", now.Add(-60*24*time.Hour))
+	writeAthensVersion(t, storage, "github.com/acme/lib", "v1.1.0", now.Add(-time.Hour))
+
+	entries, err := StaleAthensEntries(storage, 30*24*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, old, entries[0].Path)
+	assert.Equal(t, int64(len("module github.com/acme/lib\n")+len("zipdata")+len("{}")), entries[0].Size)
+
+	entries, err = StaleAthensEntries(filepath.Join(storage, "missing"), time.Hour, now)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRemoveCacheEntries(t *testing.T) {
+	storage := t.TempDir()
+	now := time.Now()
+	stale := now.Add(-60 * 24 * time.Hour)
+	writeAthensVersion(t, storage, "github.com/acme/gone", "v0.1.0", stale)
+	writeAthensVersion(t, storage, "github.com/acme/lib", "v1.0.0", stale)
+	kept := writeAthensVersion(t, storage, "github.com/acme/lib", "v1.1.0", now)
+
+	entries, err := StaleAthensEntries(storage, 24*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	removed, freed, err := RemoveCacheEntries(storage, entries)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.Positive(t, freed)
+
+	assert.NoDirExists(t, filepath.Join(storage, "github.com", "acme", "gone"), "empty module dirs are removed")
+	assert.DirExists(t, kept)
+
+	_, _, err = RemoveCacheEntries(storage, []CacheEntry{{Path: filepath.Dir(storage)}})
+	assert.Error(t, err)
+}
@@ -2,6 +2,8 @@ package registry
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
 
 	"github.com/rmkohlman/MaestroSDK/paths"
 )
@@ -58,17 +60,45 @@ func GenerateZotConfig(cfg RegistryConfig) (map[string]interface{}, error) {
 			registries = append(registries, reg)
 		}
 
+		sync := map[string]interface{}{
+			"enable":     true,
+			"registries": registries,
+		}
+		if len(GenerateZotCredentials(mirrors)) > 0 {
+			sync["credentialsFile"] = filepath.Join(cfg.Storage, zotCredentialsFile)
+		}
 		config["extensions"] = map[string]interface{}{
-			"sync": map[string]interface{}{
-				"enable":     true,
-				"registries": registries,
-			},
+			"sync": sync,
 		}
 	}
 
 	return config, nil
 }
 
+// zotCredentialsFile is the file, in the registry storage, that holds the
+// logins of private upstreams.
+const zotCredentialsFile = "credentials.json"
+
+// GenerateZotCredentials returns the contents of Zot's sync credentials
+// file: the login of each mirror with resolved auth, keyed by its host.
+func GenerateZotCredentials(mirrors []MirrorConfig) map[string]map[string]string {
+	creds := map[string]map[string]string{}
+	for _, mirror := range mirrors {
+		if !mirror.Auth.IsSet() {
+			continue
+		}
+		parsed, err := url.Parse(mirror.URL)
+		if err != nil || parsed.Host == "" {
+			continue
+		}
+		creds[parsed.Host] = map[string]string{
+			"username": mirror.Auth.Username,
+			"password": mirror.Auth.Password,
+		}
+	}
+	return creds
+}
+
 // DefaultRegistryConfig returns a RegistryConfig with sensible defaults.
 func DefaultRegistryConfig() RegistryConfig {
 	var storage string
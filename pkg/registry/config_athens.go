@@ -35,6 +35,9 @@ type UpstreamProxyConfig struct {
 
 	// URL is the upstream proxy URL (e.g., "https://proxy.golang.org")
 	URL string `yaml:"url"`
+
+	// Auth is the login of a private upstream, resolved when Athens starts
+	Auth *UpstreamAuth `yaml:"-"`
 }
 
 // Validate checks if the Go module proxy configuration is valid.
@@ -91,7 +94,7 @@ func GenerateAthensConfig(cfg GoModuleConfig) (string, error) {
 		if i > 0 {
 			goproxy += ","
 		}
-		goproxy += upstream.URL
+		goproxy += basicAuthURL(upstream.URL, upstream.Auth)
 	}
 	// Add direct fallback
 	goproxy += ",direct"
@@ -101,20 +104,19 @@ func GenerateAthensConfig(cfg GoModuleConfig) (string, error) {
 	config := fmt.Sprintf(`# Athens Configuration
 # Generated by DevOpsMaestro
 
-# Port to listen on
+# Address to listen on
 Port = "127.0.0.1:%d"
 
-# Storage configuration
-[Storage]
-Type = "disk"
+# Environment of the go command Athens fetches modules with
+GoBinaryEnvVars = [%q]
 
-[Storage.Disk]
-RootPath = "%s"
+# Storage configuration (tables last: later keys would belong to them)
+StorageType = "disk"
 
-# Go environment
-[GoEnv]
-GOPROXY = "%s"
-`, cfg.Port, cfg.Storage, goproxy)
+[Storage]
+  [Storage.Disk]
+    RootPath = %q
+`, cfg.Port, "GOPROXY="+goproxy, cfg.Storage)
 
 	return config, nil
 }
@@ -2,6 +2,7 @@ package registry
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -195,9 +196,38 @@ func TestAthensConfig_UpstreamProxies(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify upstreams are configured
-	// Athens uses GoEnv GOPROXY for upstreams
-	assert.Contains(t, athensConfig, "[GoEnv]", "Config should have GoEnv section")
-	assert.Contains(t, athensConfig, "https://proxy.golang.org", "Config should have upstream URL")
+	// Athens passes GOPROXY to the go command it fetches modules with
+	assert.Contains(t, athensConfig, `GoBinaryEnvVars = ["GOPROXY=https://proxy.golang.org,direct"]`)
+}
+
+func TestAthensConfig_UpstreamAuth(t *testing.T) {
+	goModConfig := GoModuleConfig{
+		Port:    3000,
+		Storage: "/tmp/athens",
+		Upstreams: []UpstreamProxyConfig{
+			{Name: "acme", URL: "https://goproxy.acme.dev", Auth: &UpstreamAuth{Username: "me", Password: "pw"}},
+			{Name: "golang", URL: "https://proxy.golang.org"},
+		},
+	}
+
+	athensConfig, err := GenerateAthensConfig(goModConfig)
+	require.NoError(t, err)
+	assert.Contains(t, athensConfig, `"GOPROXY=https://me:pw@goproxy.acme.dev,https://proxy.golang.org,direct"`)
+}
+
+func TestAthensConfig_TopLevelKeysBeforeTables(t *testing.T) {
+	athensConfig, err := GenerateAthensConfig(GoModuleConfig{Port: 3000, Storage: "/tmp/athens"})
+	require.NoError(t, err)
+
+	// Keys after a [table] header belong to that table, so Athens would
+	// ignore top-level settings placed below [Storage]
+	tables := strings.Index(athensConfig, "[Storage]")
+	for _, key := range []string{"Port =", "StorageType =", "GoBinaryEnvVars ="} {
+		idx := strings.Index(athensConfig, key)
+		require.GreaterOrEqual(t, idx, 0, key)
+		assert.Less(t, idx, tables, key)
+	}
+	assert.NotContains(t, athensConfig, "[GoEnv]", "GoEnv is a string setting, not a table")
 }
 
 func TestAthensConfig_ValidTOML(t *testing.T) {
@@ -39,6 +39,9 @@ type PyPIUpstreamConfig struct {
 
 	// URL is the upstream index URL (e.g., "https://pypi.org/simple")
 	URL string `yaml:"url"`
+
+	// Auth is the login of a private upstream (optional)
+	Auth *UpstreamAuth `yaml:"auth,omitempty"`
 }
 
 // PyPIProxyStatus represents the current state of the PyPI proxy.
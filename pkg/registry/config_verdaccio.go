@@ -1,6 +1,7 @@
 package registry
 
 import (
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 	"time"
@@ -47,6 +48,9 @@ type NpmUpstreamConfig struct {
 
 	// URL is the upstream registry URL (e.g., "https://registry.npmjs.org")
 	URL string `yaml:"url"`
+
+	// Auth is the login of a private upstream (optional)
+	Auth *UpstreamAuth `yaml:"auth,omitempty"`
 }
 
 // NpmAuthConfig configures authentication for the npm proxy.
@@ -210,9 +214,13 @@ func GenerateVerdaccioConfig(cfg NpmProxyConfig) (string, error) {
 	uplinks := config["uplinks"].(map[string]interface{})
 	proxyList := []string{}
 	for _, upstream := range upstreams {
-		uplinks[upstream.Name] = map[string]interface{}{
+		uplink := map[string]interface{}{
 			"url": upstream.URL,
 		}
+		if upstream.Auth.IsSet() {
+			uplink["auth"] = verdaccioUplinkAuth(upstream.Auth)
+		}
+		uplinks[upstream.Name] = uplink
 		proxyList = append(proxyList, upstream.Name)
 	}
 
@@ -232,6 +240,16 @@ func GenerateVerdaccioConfig(cfg NpmProxyConfig) (string, error) {
 	return string(yamlBytes), nil
 }
 
+// verdaccioUplinkAuth returns the auth of a verdaccio uplink: a bearer
+// token, or basic auth when the upstream has a username.
+func verdaccioUplinkAuth(auth *UpstreamAuth) map[string]interface{} {
+	if auth.Username == "" {
+		return map[string]interface{}{"type": "bearer", "token": auth.Password}
+	}
+	token := base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+	return map[string]interface{}{"type": "basic", "token": token}
+}
+
 // DefaultNpmProxyConfig returns an NpmProxyConfig with sensible defaults.
 func DefaultNpmProxyConfig() NpmProxyConfig {
 	var storage string
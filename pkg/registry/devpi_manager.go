@@ -1,9 +1,14 @@
 package registry
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -142,9 +147,78 @@ func (m *DevpiManager) Start(ctx context.Context) error {
 		return fmt.Errorf("proxy failed to become ready: %w", err)
 	}
 
+	// Point root/pypi at the configured upstream
+	if err := m.configureMirror(ctx, fmt.Sprintf("http://localhost:%d", m.config.Port)); err != nil {
+		m.processManager.Stop(ctx)
+		return fmt.Errorf("failed to configure upstream: %w", err)
+	}
+
+	return nil
+}
+
+// configureMirror sets the mirror_url of devpi's root/pypi index to the
+// first upstream, with its resolved login embedded, through devpi's HTTP
+// API. devpi mirrors a single index, so later upstreams are not used. The
+// default pypi.org upstream needs no change.
+func (m *DevpiManager) configureMirror(ctx context.Context, endpoint string) error {
+	if len(m.config.Upstreams) == 0 {
+		return nil
+	}
+	upstream := m.config.Upstreams[0]
+	if upstream.Auth != nil {
+		if err := resolveUpstreamAuths([]*UpstreamAuth{upstream.Auth}); err != nil {
+			return err
+		}
+	}
+	if !upstream.Auth.IsSet() && strings.TrimSuffix(upstream.URL, "/") == strings.TrimSuffix(defaultPyPIUpstreams()[0].URL, "/") {
+		return nil
+	}
+
+	// Log in as root, whose password devpi-init leaves empty
+	body, _ := json.Marshal(map[string]string{"user": "root", "password": ""})
+	var login struct {
+		Result struct {
+			Password string `json:"password"`
+		} `json:"result"`
+	}
+	if err := devpiRequest(ctx, http.MethodPost, endpoint+"/+login", "", body, &login); err != nil {
+		return fmt.Errorf("devpi login failed: %w", err)
+	}
+	token := base64.StdEncoding.EncodeToString([]byte("root:" + login.Result.Password))
+
+	body, _ = json.Marshal([]string{"mirror_url=" + basicAuthURL(upstream.URL, upstream.Auth)})
+	if err := devpiRequest(ctx, http.MethodPatch, endpoint+"/root/pypi", token, body, nil); err != nil {
+		return fmt.Errorf("failed to set mirror_url of root/pypi: %w", err)
+	}
 	return nil
 }
 
+// devpiRequest sends a JSON request to devpi-server and decodes the JSON
+// reply into out when it is not nil.
+func devpiRequest(ctx context.Context, method, url, auth string, body []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if auth != "" {
+		req.Header.Set("X-Devpi-Auth", auth)
+	}
+	resp, err := healthCheckClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: HTTP %d", method, req.URL.Path, resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
 // Stop stops the devpi proxy process gracefully.
 func (m *DevpiManager) Stop(ctx context.Context) error {
 	m.mu.Lock()
@@ -182,7 +256,8 @@ func (m *DevpiManager) Status(ctx context.Context) (*PyPIProxyStatus, error) {
 
 	if running {
 		// Count packages in storage directory
-		packageCount, diskUsage = m.getProxyStats(ctx)
+		stats := m.StorageStats(ctx)
+		packageCount, diskUsage = stats.Items, stats.DiskUsage
 	}
 
 	return &PyPIProxyStatus{
@@ -290,32 +365,49 @@ func (m *DevpiManager) waitForReady(ctx context.Context) error {
 	return WaitForReady(ctx, endpoint, []int{200, 302}, 10*time.Second)
 }
 
-// getProxyStats counts packages and disk usage.
-func (m *DevpiManager) getProxyStats(ctx context.Context) (int, int64) {
-	// Devpi stores packages in a directory structure
-	// Count directories/files in storage path
-	packageCount := 0
-	var diskUsage int64
+// StorageStats returns the packages devpi has cached. devpi's mirror index
+// API doesn't list cached projects, so they are read from the file store:
+// every release file under +files is one version of the project it is named
+// after.
+func (m *DevpiManager) StorageStats(ctx context.Context) StorageStats {
+	stats := StorageStats{Kind: "packages"}
+	projects := map[string]bool{}
 
 	serverDir := m.config.ServerDir
 	if serverDir == "" {
 		serverDir = filepath.Join(m.config.Storage, "server")
 	}
 
-	// Walk the storage directory
-	filepath.Walk(serverDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil // Skip errors
+	filepath.WalkDir(filepath.Join(serverDir, "+files"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil // Skip unreadable entries
 		}
-		if !info.IsDir() {
-			diskUsage += info.Size()
-			// Count .tar.gz or .whl files as packages
-			if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".whl") {
-				packageCount++
-			}
+		project := pythonProjectName(d.Name())
+		if project == "" {
+			return nil
 		}
+		projects[project] = true
+		stats.Versions++
+		stats.addFile(d)
 		return nil
 	})
 
-	return packageCount, diskUsage
+	stats.Items = len(projects)
+	return stats
+}
+
+// pythonProjectName returns the normalized project of a release file name
+// such as "Flask-3.0.0.tar.gz" or "flask-3.0.0-py3-none-any.whl", or "" for
+// other files. The name ends at the first dash followed by a digit.
+func pythonProjectName(file string) string {
+	if !strings.HasSuffix(file, ".tar.gz") && !strings.HasSuffix(file, ".whl") && !strings.HasSuffix(file, ".zip") {
+		return ""
+	}
+	for i := 0; i+1 < len(file); i++ {
+		if file[i] == '-' && file[i+1] >= '0' && file[i+1] <= '9' {
+			name := strings.ToLower(file[:i])
+			return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+		}
+	}
+	return ""
 }
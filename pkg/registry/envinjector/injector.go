@@ -61,7 +61,7 @@ func (ei *EnvironmentInjector) InjectForAttachWithHost(registry *models.Registry
 		url := fmt.Sprintf("http://%s:%d", host, registry.Port)
 		envVars["GOPROXY"] = url
 		envVars["GONOSUMDB"] = "*" // Disable checksum verification for privacy
-		envVars["GOPRIVATE"] = "*" // Treat all modules as private
+		// No GOPRIVATE: it implies GONOPROXY and would bypass the proxy
 
 	case "squid":
 		// HTTP proxy
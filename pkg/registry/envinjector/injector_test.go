@@ -70,9 +70,8 @@ func TestEnvironmentInjector_InjectForAttach_Go(t *testing.T) {
 	assert.Contains(t, envVars, "GONOSUMDB")
 	assert.Equal(t, "*", envVars["GONOSUMDB"])
 
-	// Should inject GOPRIVATE
-	assert.Contains(t, envVars, "GOPRIVATE")
-	assert.Equal(t, "*", envVars["GOPRIVATE"])
+	// Should not inject GOPRIVATE, which bypasses GOPROXY
+	assert.NotContains(t, envVars, "GOPRIVATE")
 }
 
 // TestEnvironmentInjector_InjectForAttach_HTTP tests HTTP proxy env var injection
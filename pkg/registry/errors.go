@@ -1,6 +1,10 @@
 package registry
 
-import "errors"
+import (
+	"errors"
+
+	"devopsmaestro/pkg/fetch"
+)
 
 var (
 	// ErrNotRunning indicates the registry is not running
@@ -21,8 +25,9 @@ var (
 	// includes the install command for the user.
 	ErrBinaryNotInstalled = errors.New("binary not installed")
 
-	// ErrDownloadFailed indicates binary download failed
-	ErrDownloadFailed = errors.New("download failed")
+	// ErrDownloadFailed indicates binary download failed. It is the fetcher's
+	// error, so errors.Is matches failures reported by either package.
+	ErrDownloadFailed = fetch.ErrDownloadFailed
 
 	// ErrInvalidConfig indicates configuration validation failed
 	ErrInvalidConfig = errors.New("invalid configuration")
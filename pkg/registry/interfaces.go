@@ -112,6 +112,9 @@ type MirrorConfig struct {
 
 	// Prefix is the registry prefix (e.g., "docker.io")
 	Prefix string `yaml:"prefix"`
+
+	// Auth is the login of a private upstream (optional)
+	Auth *UpstreamAuth `yaml:"auth,omitempty"`
 }
 
 // RegistryStatus represents the current state of the registry.
@@ -232,7 +235,7 @@ type GoModuleProxy interface {
 	GetEndpoint() string
 
 	// GetGoEnv returns the Go environment variables to use this proxy.
-	// Returns a map with keys like "GOPROXY", "GONOSUMDB", "GOPRIVATE".
+	// Returns a map with keys like "GOPROXY" and "GONOSUMDB".
 	GetGoEnv() map[string]string
 }
 
@@ -30,6 +30,15 @@ type ServiceManager interface {
 	GetEndpoint() string
 }
 
+// StorageReporter is implemented by ServiceManagers that can measure what
+// their cache holds. Every registry type implements it.
+type StorageReporter interface {
+	// StorageStats returns the cache contents, whether or not the service
+	// runs. Counts come from the service's API when it runs and from its
+	// storage otherwise.
+	StorageStats(ctx context.Context) StorageStats
+}
+
 // RegistryStrategy defines the strategy pattern for type-specific behavior.
 // Each registry type (zot, athens, devpi, etc.) implements this interface
 // to provide its own configuration validation, manager creation, and defaults.
@@ -3,6 +3,7 @@ package registry
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -248,6 +249,27 @@ func (m *SquidManager) Status(ctx context.Context) (*HttpProxyStatus, error) {
 	}, nil
 }
 
+// StorageStats returns the objects in squid's cache and the hits and misses
+// recorded in its access log.
+func (m *SquidManager) StorageStats(ctx context.Context) StorageStats {
+	stats := StorageStats{Kind: "objects"}
+
+	// The ufs store keeps one file per object, next to its swap.state index
+	filepath.WalkDir(m.config.CacheDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasPrefix(d.Name(), "swap.state") {
+			return nil // Skip unreadable entries
+		}
+		stats.Items++
+		stats.addFile(d)
+		return nil
+	})
+
+	if counters, err := countSquidRequests(filepath.Join(m.config.LogDir, "access.log")); err == nil {
+		stats.Counters = counters
+	}
+	return stats
+}
+
 // EnsureRunning starts the proxy if it's not running.
 func (m *SquidManager) EnsureRunning(ctx context.Context) error {
 	if m.IsRunning(ctx) {
@@ -505,6 +527,11 @@ func (a *SquidManagerAdapter) GetEndpoint() string {
 	return a.manager.GetEndpoint()
 }
 
+// StorageStats returns the objects cached by squid.
+func (a *SquidManagerAdapter) StorageStats(ctx context.Context) StorageStats {
+	return a.manager.StorageStats(ctx)
+}
+
 // =============================================================================
 // Interface Compliance
 // =============================================================================
@@ -0,0 +1,131 @@
+package registry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/httpclient"
+)
+
+// StorageStats summarizes a registry's cache.
+type StorageStats struct {
+	// Kind names what Items counts: "repositories", "modules", "packages",
+	// or "objects"
+	Kind string
+
+	// Items is the number of distinct repositories, modules, or packages cached
+	Items int
+
+	// Versions is the number of cached versions across all items (0 for
+	// types without versions)
+	Versions int
+
+	// DiskUsage is the size of the cached content (bytes)
+	DiskUsage int64
+
+	// LastSync is when content was last written to the cache (zero if empty)
+	LastSync time.Time
+
+	// Counters holds cache hits and misses, or nil when the type doesn't
+	// record them
+	Counters *CacheCounters
+}
+
+// CacheCounters counts requests served from the cache and from upstream.
+type CacheCounters struct {
+	Hits   int64
+	Misses int64
+}
+
+// addDir adds the files under dir to the disk usage and last sync time.
+func (s *StorageStats) addDir(dir string) {
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil // Skip unreadable entries
+		}
+		s.addFile(d)
+		return nil
+	})
+}
+
+// addFile adds one file to the disk usage and last sync time.
+func (s *StorageStats) addFile(d fs.DirEntry) {
+	info, err := d.Info()
+	if err != nil {
+		return
+	}
+	s.DiskUsage += info.Size()
+	if info.ModTime().After(s.LastSync) {
+		s.LastSync = info.ModTime()
+	}
+}
+
+// statsTimeout bounds the API calls made for StorageStats.
+const statsTimeout = 5 * time.Second
+
+// getStatsJSON decodes the JSON response of a GET on a local registry API.
+func getStatsJSON(ctx context.Context, url string, v interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, statsTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := httpclient.New(0).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// countSquidRequests counts cache hits and misses in a squid access log in
+// the native format, where the fourth field is the result code (e.g.
+// TCP_HIT/200). Tunnelled and denied requests are neither.
+func countSquidRequests(path string) (*CacheCounters, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	counters := &CacheCounters{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		code, _, _ := strings.Cut(fields[3], "/")
+		switch {
+		case strings.Contains(code, "HIT"):
+			counters.Hits++
+		case strings.Contains(code, "MISS"):
+			counters.Misses++
+		}
+	}
+	return counters, scanner.Err()
+}
+
+// Verify every registry type's ServiceManager reports storage stats
+var (
+	_ StorageReporter = (*ZotManager)(nil)
+	_ StorageReporter = (*AthensManagerAdapter)(nil)
+	_ StorageReporter = (*DevpiManagerAdapter)(nil)
+	_ StorageReporter = (*VerdaccioManagerAdapter)(nil)
+	_ StorageReporter = (*SquidManagerAdapter)(nil)
+)
@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeStatsFile writes content to rel under dir, creating parents.
+func writeStatsFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// statsServer serves body at path and returns the port it listens on.
+func statsServer(t *testing.T, path, body string) int {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return port
+}
+
+func TestZotManager_StorageStats(t *testing.T) {
+	storage := t.TempDir()
+	writeStatsFile(t, storage, "library/alpine/index.json", `{"manifests":[{},{}]}`)
+	writeStatsFile(t, storage, "library/alpine/blobs/sha256/abc", "layer")
+	writeStatsFile(t, storage, "acme/app/index.json", `{"manifests":[{}]}`)
+	writeStatsFile(t, storage, "zot.log", "not a repository")
+
+	z := NewZotManagerWithDeps(RegistryConfig{Port: 5001, Storage: storage}, nil, nil)
+	stats := z.storageStats(context.Background(), false)
+	assert.Equal(t, "repositories", stats.Kind)
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, 3, stats.Versions)
+	assert.Equal(t, int64(len(`{"manifests":[{},{}]}`)+len("layer")+len(`{"manifests":[{}]}`)), stats.DiskUsage)
+	assert.Nil(t, stats.Counters)
+
+	// The catalog API wins while zot runs
+	z.config.Port = statsServer(t, "/v2/_catalog", `{"repositories":["a","b","c"]}`)
+	assert.Equal(t, 3, z.storageStats(context.Background(), true).Items)
+}
+
+func TestVerdaccioManager_StorageStats(t *testing.T) {
+	storage := t.TempDir()
+	writeStatsFile(t, storage, "storage/lodash/package.json", "{}")
+	writeStatsFile(t, storage, "storage/lodash/lodash-4.17.21.tgz", "tgz")
+	writeStatsFile(t, storage, "storage/@types/node/package.json", "{}")
+	writeStatsFile(t, storage, "storage/@types/node/node-20.0.0.tgz", "tgz")
+	writeStatsFile(t, storage, "storage/@types/node/node-22.0.0.tgz", "tgz")
+
+	m := &VerdaccioManager{config: NpmProxyConfig{Port: 4873, Storage: storage}}
+	stats := m.storageStats(context.Background(), false)
+	assert.Equal(t, "packages", stats.Kind)
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, 3, stats.Versions)
+
+	m.config.Port = statsServer(t, "/-/verdaccio/data/packages", `[{"name":"lodash"}]`)
+	assert.Equal(t, 1, m.storageStats(context.Background(), true).Items)
+}
+
+func TestDevpiManager_StorageStats(t *testing.T) {
+	storage := t.TempDir()
+	files := filepath.Join("server", "+files", "root", "pypi", "+f")
+	writeStatsFile(t, storage, filepath.Join(files, "a1", "b2", "Flask-3.0.0.tar.gz"), "sdist")
+	writeStatsFile(t, storage, filepath.Join(files, "c3", "d4", "flask-3.0.0-py3-none-any.whl"), "wheel")
+	writeStatsFile(t, storage, filepath.Join(files, "e5", "f6", "zope.interface-6.0-cp312-none-any.whl"), "wheel")
+	writeStatsFile(t, storage, filepath.Join(files, "e5", "f6", "README"), "not a release")
+
+	m := &DevpiManager{config: PyPIProxyConfig{Storage: storage}}
+	stats := m.StorageStats(context.Background())
+	assert.Equal(t, "packages", stats.Kind)
+	assert.Equal(t, 2, stats.Items)
+	assert.Equal(t, 3, stats.Versions)
+	assert.Equal(t, int64(len("sdist")+2*len("wheel")), stats.DiskUsage)
+}
+
+func TestPythonProjectName(t *testing.T) {
+	assert.Equal(t, "my-pkg", pythonProjectName("my_pkg-1.0.tar.gz"))
+	assert.Equal(t, "my-pkg", pythonProjectName("My-Pkg-1.0.zip"))
+	assert.Equal(t, "", pythonProjectName("my_pkg-1.0.tar.gz.asc"))
+	assert.Equal(t, "", pythonProjectName("noversion.whl"))
+}
+
+func TestSquidManager_StorageStats(t *testing.T) {
+	dir := t.TempDir()
+	cfg := HttpProxyConfig{CacheDir: filepath.Join(dir, "cache"), LogDir: filepath.Join(dir, "logs")}
+	writeStatsFile(t, cfg.CacheDir, "00/00/00000000", "object")
+	writeStatsFile(t, cfg.CacheDir, "00/00/00000001", "object")
+	writeStatsFile(t, cfg.CacheDir, "swap.state", "index")
+
+	m := &SquidManager{config: cfg}
+	stats := m.StorageStats(context.Background())
+	assert.Equal(t, "objects", stats.Kind)
+	assert.Equal(t, 2, stats.Items)
+	assert.Nil(t, stats.Counters, "no access log yet")
+
+	writeStatsFile(t, cfg.LogDir, "access.log", `1700000000.000 10 127.0.0.1 TCP_MISS/200 100 GET http://a/ - HIER_DIRECT/1.2.3.4 text/html
+1700000001.000 2 127.0.0.1 TCP_HIT/200 100 GET http://a/ - HIER_NONE/- text/html
+1700000002.000 1 127.0.0.1 TCP_MEM_HIT/200 100 GET http://a/ - HIER_NONE/- text/html
+1700000003.000 50 127.0.0.1 TCP_TUNNEL/200 100 CONNECT b:443 - HIER_DIRECT/5.6.7.8 -
+`)
+	stats = m.StorageStats(context.Background())
+	require.NotNil(t, stats.Counters)
+	assert.Equal(t, CacheCounters{Hits: 2, Misses: 1}, *stats.Counters)
+}
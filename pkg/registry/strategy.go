@@ -94,8 +94,10 @@ func (s *ZotStrategy) ValidateConfig(config json.RawMessage) error {
 		return fmt.Errorf("invalid JSON config: %w", err)
 	}
 
-	// Zot config validation would go here
-	// For now, we accept any valid JSON
+	// Only upstreams are interpreted; other keys are accepted as-is
+	if _, err := parseUpstreamsJSON(config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -125,10 +127,23 @@ func (s *ZotStrategy) CreateManager(reg *models.Registry) (ServiceManager, error
 		config.Port = s.GetDefaultPort()
 	}
 
-	// Parse custom config if provided
-	if reg.Config.Valid && reg.Config.String != "" {
-		// Could merge custom config here
-		// For now, we use defaults
+	// Mirror the configured upstreams instead of the defaults
+	upstreams, err := parseUpstreams(reg)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range upstreams {
+		onDemand := true
+		if u.OnDemand != nil {
+			onDemand = *u.OnDemand
+		}
+		config.Mirrors = append(config.Mirrors, MirrorConfig{
+			Name:     u.Name,
+			URL:      u.URL,
+			OnDemand: onDemand,
+			Prefix:   u.Prefix,
+			Auth:     u.auth(reg.Name),
+		})
 	}
 
 	// Create managers with explicit dependency construction.
@@ -186,7 +201,10 @@ func (s *AthensStrategy) ValidateConfig(config json.RawMessage) error {
 		return fmt.Errorf("invalid JSON config: %w", err)
 	}
 
-	// Athens config validation would go here
+	// Only upstreams are interpreted; other keys are accepted as-is
+	if _, err := parseUpstreamsJSON(config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -211,6 +229,16 @@ func (s *AthensStrategy) CreateManager(reg *models.Registry) (ServiceManager, er
 		IdleTimeout: 30 * time.Minute,
 		Upstreams:   defaultUpstreams(),
 	}
+	upstreams, err := parseUpstreams(reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(upstreams) > 0 {
+		config.Upstreams = nil
+		for _, u := range upstreams {
+			config.Upstreams = append(config.Upstreams, UpstreamProxyConfig{Name: u.Name, URL: u.URL, Auth: u.auth(reg.Name)})
+		}
+	}
 
 	// Apply defaults
 	if config.Port == 0 {
@@ -266,6 +294,11 @@ func (a *AthensManagerAdapter) GetEndpoint() string {
 	return a.manager.GetEndpoint()
 }
 
+// StorageStats returns the modules cached by Athens.
+func (a *AthensManagerAdapter) StorageStats(ctx context.Context) StorageStats {
+	return a.manager.StorageStats(ctx)
+}
+
 // --- Devpi Strategy ---
 
 // DevpiStrategy implements RegistryStrategy for devpi PyPI proxy.
@@ -288,7 +321,10 @@ func (s *DevpiStrategy) ValidateConfig(config json.RawMessage) error {
 		return fmt.Errorf("invalid JSON config: %w", err)
 	}
 
-	// Devpi config validation would go here
+	// Only upstreams are interpreted; other keys are accepted as-is
+	if _, err := parseUpstreamsJSON(config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -313,6 +349,16 @@ func (s *DevpiStrategy) CreateManager(reg *models.Registry) (ServiceManager, err
 		IdleTimeout: 30 * time.Minute,
 		Upstreams:   defaultPyPIUpstreams(),
 	}
+	upstreams, err := parseUpstreams(reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(upstreams) > 0 {
+		config.Upstreams = nil
+		for _, u := range upstreams {
+			config.Upstreams = append(config.Upstreams, PyPIUpstreamConfig{Name: u.Name, URL: u.URL, Auth: u.auth(reg.Name)})
+		}
+	}
 
 	// Apply defaults
 	if config.Port == 0 {
@@ -367,6 +413,11 @@ func (d *DevpiManagerAdapter) GetEndpoint() string {
 	return d.manager.GetEndpoint()
 }
 
+// StorageStats returns the packages cached by devpi.
+func (d *DevpiManagerAdapter) StorageStats(ctx context.Context) StorageStats {
+	return d.manager.StorageStats(ctx)
+}
+
 // --- Verdaccio Strategy ---
 
 // VerdaccioStrategy implements RegistryStrategy for verdaccio npm proxy.
@@ -389,7 +440,10 @@ func (s *VerdaccioStrategy) ValidateConfig(config json.RawMessage) error {
 		return fmt.Errorf("invalid JSON config: %w", err)
 	}
 
-	// Verdaccio config validation would go here
+	// Only upstreams are interpreted; other keys are accepted as-is
+	if _, err := parseUpstreamsJSON(config); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -414,6 +468,16 @@ func (s *VerdaccioStrategy) CreateManager(reg *models.Registry) (ServiceManager,
 		IdleTimeout: 30 * time.Minute,
 		Upstreams:   defaultNpmUpstreams(),
 	}
+	upstreams, err := parseUpstreams(reg)
+	if err != nil {
+		return nil, err
+	}
+	if len(upstreams) > 0 {
+		config.Upstreams = nil
+		for _, u := range upstreams {
+			config.Upstreams = append(config.Upstreams, NpmUpstreamConfig{Name: u.Name, URL: u.URL, Auth: u.auth(reg.Name)})
+		}
+	}
 
 	// Apply defaults
 	if config.Port == 0 {
@@ -473,6 +537,11 @@ func (v *VerdaccioManagerAdapter) GetEndpoint() string {
 	return v.manager.GetEndpoint()
 }
 
+// StorageStats returns the packages cached by verdaccio.
+func (v *VerdaccioManagerAdapter) StorageStats(ctx context.Context) StorageStats {
+	return v.manager.StorageStats(ctx)
+}
+
 // --- Registry Type Constants ---
 
 // RegistryTypeSquid is the registry type for squid HTTP proxy.
@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+
+	"devopsmaestro/config"
+	"devopsmaestro/models"
+)
+
+// UpstreamAuth is the login of a private upstream. The password comes from
+// the global credential named Credential, or else from the secret stored by
+// `dvm registry login`; an upstream with neither is used anonymously.
+type UpstreamAuth struct {
+	// Username is the upstream user (optional for token-only upstreams)
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+
+	// Credential names an entry of the global credentials config
+	Credential string `yaml:"credential,omitempty" json:"credential,omitempty"`
+
+	// Password is the resolved token or password (never serialized)
+	Password string `yaml:"-" json:"-"`
+
+	// LoginSecret is the vault secret written by dvm registry login
+	LoginSecret string `yaml:"-" json:"-"`
+}
+
+// IsSet returns true if the auth has a resolved password.
+func (a *UpstreamAuth) IsSet() bool {
+	return a != nil && a.Password != ""
+}
+
+// upstreamSpec is one entry of a registry's spec.config.upstreams.
+type upstreamSpec struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	Prefix     string `json:"prefix,omitempty"`
+	OnDemand   *bool  `json:"onDemand,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Credential string `json:"credential,omitempty"`
+}
+
+// validUpstreamName matches upstream names usable in vault secret names.
+var validUpstreamName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// LoginSecretName returns the vault secret that dvm registry login stores
+// an upstream's token in.
+func LoginSecretName(registryName, upstreamName string) string {
+	return fmt.Sprintf("dvm-registry-%s-%s", registryName, upstreamName)
+}
+
+// parseUpstreams returns the upstreams in a registry's spec.config, or nil
+// when none are configured.
+func parseUpstreams(reg *models.Registry) ([]upstreamSpec, error) {
+	if !reg.Config.Valid || reg.Config.String == "" {
+		return nil, nil
+	}
+	return parseUpstreamsJSON([]byte(reg.Config.String))
+}
+
+// parseUpstreamsJSON returns the validated upstreams of a registry config.
+func parseUpstreamsJSON(data []byte) ([]upstreamSpec, error) {
+	var cfg struct {
+		Upstreams []upstreamSpec `json:"upstreams"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid upstreams: %w", err)
+	}
+	for _, u := range cfg.Upstreams {
+		if !validUpstreamName.MatchString(u.Name) {
+			return nil, fmt.Errorf("invalid upstream name %q", u.Name)
+		}
+		parsed, err := url.Parse(u.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("upstream %q: url must be an http or https URL", u.Name)
+		}
+		if parsed.User != nil {
+			return nil, fmt.Errorf("upstream %q: put credentials in 'credential' or use dvm registry login, not in the url", u.Name)
+		}
+	}
+	return cfg.Upstreams, nil
+}
+
+// auth returns the unresolved login of an upstream of registry registryName.
+func (u upstreamSpec) auth(registryName string) *UpstreamAuth {
+	return &UpstreamAuth{
+		Username:    u.Username,
+		Credential:  u.Credential,
+		LoginSecret: LoginSecretName(registryName, u.Name),
+	}
+}
+
+// UpstreamNames returns the names of the upstreams configured on a registry.
+func UpstreamNames(reg *models.Registry) ([]string, error) {
+	upstreams, err := parseUpstreams(reg)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(upstreams))
+	for i, u := range upstreams {
+		names[i] = u.Name
+	}
+	return names, nil
+}
+
+// Resolve sets Password (and Username, when the login secret has one).
+// A Credential is looked up in global, with an environment variable of the
+// same name taking priority as for build credentials. Otherwise the login
+// secret is read from backend; a missing secret or backend leaves the
+// upstream anonymous.
+func (a *UpstreamAuth) Resolve(backend config.SecretBackend, global config.Credentials) error {
+	if a == nil {
+		return nil
+	}
+	if a.Credential != "" {
+		if v := os.Getenv(a.Credential); v != "" {
+			a.Password = v
+			return nil
+		}
+		cfg, ok := global[a.Credential]
+		if !ok {
+			return fmt.Errorf("credential %q is not defined in the global credentials", a.Credential)
+		}
+		v, err := config.ResolveCredentialWithBackend(cfg, backend)
+		if err != nil {
+			return fmt.Errorf("credential %q: %w", a.Credential, err)
+		}
+		if v == "" {
+			return fmt.Errorf("credential %q is empty", a.Credential)
+		}
+		a.Password = v
+		return nil
+	}
+
+	if backend == nil || a.LoginSecret == "" {
+		return nil
+	}
+	v, err := backend.Get(a.LoginSecret, "")
+	if err != nil {
+		slog.Debug("no stored upstream login", "secret", a.LoginSecret, "error", err)
+		return nil
+	}
+	a.Password = v
+	if a.Username == "" {
+		if fb, ok := backend.(config.FieldCapableBackend); ok {
+			if user, err := fb.GetField(a.LoginSecret, "", "username"); err == nil {
+				a.Username = user
+			}
+		}
+	}
+	return nil
+}
+
+// resolveUpstreamAuths resolves the logins of a registry's upstreams when
+// it starts. The vault is only opened when one of them needs it.
+var resolveUpstreamAuths = func(auths []*UpstreamAuth) error {
+	if len(auths) == 0 {
+		return nil
+	}
+	var backend config.SecretBackend
+	if vb, err := config.OpenVaultBackend(); err != nil {
+		slog.Debug("vault unavailable for upstream credentials", "error", err)
+	} else {
+		backend = vb
+	}
+	global := config.GetGlobalCredentials()
+	for _, a := range auths {
+		if err := a.Resolve(backend, global); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// basicAuthURL returns rawURL with user and password embedded, for
+// upstreams that only take credentials in the URL.
+func basicAuthURL(rawURL string, auth *UpstreamAuth) string {
+	if !auth.IsSet() {
+		return rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	user := auth.Username
+	if user == "" {
+		user = "token"
+	}
+	parsed.User = url.UserPassword(user, auth.Password)
+	return parsed.String()
+}
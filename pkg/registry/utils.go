@@ -10,23 +10,23 @@ import (
 	"path/filepath"
 	"strconv"
 	"time"
+
+	"devopsmaestro/pkg/httpclient"
 )
 
 // healthCheckClient is the shared HTTP client for health-check requests in
 // waitForReady methods.  It has a short timeout to prevent hung requests from
 // blocking service startup indefinitely.
-var healthCheckClient = &http.Client{Timeout: 2 * time.Second}
+var healthCheckClient = httpclient.New(2 * time.Second)
 
 // ProbeServiceHealth makes a single HTTP GET to http://localhost:{port}{path}
 // and returns true if the response status code matches one of acceptedStatuses.
 // It does NOT follow redirects — the raw status code is checked.
 // Returns false on any error (connection refused, timeout, etc.).
 func ProbeServiceHealth(port int, path string, acceptedStatuses []int) bool {
-	client := &http.Client{
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-		Timeout: 2 * time.Second,
+	client := httpclient.New(2 * time.Second)
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
 	}
 
 	url := fmt.Sprintf("http://localhost:%d%s", port, path)
@@ -2,8 +2,10 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -176,7 +178,8 @@ func (m *VerdaccioManager) Status(ctx context.Context) (*NpmProxyStatus, error)
 
 	if running {
 		// Count packages in storage directory
-		packageCount, diskUsage = m.getProxyStats(ctx)
+		stats := m.storageStats(ctx, false)
+		packageCount, diskUsage = stats.Items, stats.DiskUsage
 	}
 
 	return &NpmProxyStatus{
@@ -251,6 +254,17 @@ func (m *VerdaccioManager) GetYarnConfig() string {
 
 // generateConfig generates the verdaccio config.yaml file.
 func (m *VerdaccioManager) generateConfig() error {
+	// Resolve the logins of private upstreams
+	var auths []*UpstreamAuth
+	for _, upstream := range m.config.Upstreams {
+		if upstream.Auth != nil {
+			auths = append(auths, upstream.Auth)
+		}
+	}
+	if err := resolveUpstreamAuths(auths); err != nil {
+		return fmt.Errorf("failed to resolve upstream credentials: %w", err)
+	}
+
 	// Generate config content
 	configContent, err := GenerateVerdaccioConfig(m.config)
 	if err != nil {
@@ -272,28 +286,49 @@ func (m *VerdaccioManager) waitForReady(ctx context.Context) error {
 	return WaitForReady(ctx, endpoint, []int{200}, 10*time.Second)
 }
 
-// getProxyStats counts packages and disk usage.
-func (m *VerdaccioManager) getProxyStats(ctx context.Context) (int, int64) {
-	// Verdaccio stores packages in storage/packageName
-	packageCount := 0
-	var diskUsage int64
+// StorageStats returns the packages verdaccio has cached.
+func (m *VerdaccioManager) StorageStats(ctx context.Context) StorageStats {
+	return m.storageStats(ctx, m.IsRunning(ctx))
+}
 
-	storageDir := filepath.Join(m.config.Storage, "storage")
+// storageStats measures verdaccio's storage, which keeps every package in a
+// directory with a package.json next to one tarball per cached version.
+// While verdaccio runs, the package count comes from its web API instead.
+func (m *VerdaccioManager) storageStats(ctx context.Context, running bool) StorageStats {
+	stats := StorageStats{Kind: "packages"}
 
-	// Walk the storage directory
-	filepath.Walk(storageDir, func(path string, info os.FileInfo, err error) error {
+	filepath.WalkDir(filepath.Join(m.config.Storage, "storage"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // Skip unreadable entries
+		}
+		files, err := os.ReadDir(path)
 		if err != nil {
-			return nil // Skip errors
+			return nil
 		}
-		if !info.IsDir() {
-			diskUsage += info.Size()
-			// Count .tgz files as packages
-			if strings.HasSuffix(path, ".tgz") {
-				packageCount++
+		isPackage, versions := false, 0
+		for _, f := range files {
+			switch {
+			case f.Name() == "package.json":
+				isPackage = true
+			case strings.HasSuffix(f.Name(), ".tgz"):
+				versions++
 			}
 		}
-		return nil
+		if !isPackage {
+			return nil
+		}
+		stats.Items++
+		stats.Versions += versions
+		stats.addDir(path)
+		return filepath.SkipDir
 	})
 
-	return packageCount, diskUsage
+	if running {
+		var packages []json.RawMessage
+		endpoint := fmt.Sprintf("http://localhost:%d/-/verdaccio/data/packages", m.config.Port)
+		if err := getStatsJSON(ctx, endpoint, &packages); err == nil {
+			stats.Items = len(packages)
+		}
+	}
+	return stats
 }
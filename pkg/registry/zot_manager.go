@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -32,6 +33,20 @@ func (z *ZotManager) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to ensure binary: %w", err)
 	}
 
+	// Resolve the logins of private upstreams and write Zot's credentials file
+	var auths []*UpstreamAuth
+	for _, mirror := range z.config.Mirrors {
+		if mirror.Auth != nil {
+			auths = append(auths, mirror.Auth)
+		}
+	}
+	if err := resolveUpstreamAuths(auths); err != nil {
+		return fmt.Errorf("failed to resolve upstream credentials: %w", err)
+	}
+	if err := z.writeCredentialsFile(); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+
 	// Generate Zot config
 	zotConfig, err := GenerateZotConfig(z.config)
 	if err != nil {
@@ -123,7 +138,8 @@ func (z *ZotManager) Status(ctx context.Context) (*RegistryStatus, error) {
 
 	if running {
 		// Query registry API for stats
-		imageCount, diskUsage = z.getRegistryStats(ctx)
+		stats := z.storageStats(ctx, true)
+		imageCount, diskUsage = stats.Items, stats.DiskUsage
 	}
 
 	return &RegistryStatus{
@@ -195,6 +211,27 @@ func (z *ZotManager) Prune(ctx context.Context, opts PruneOptions) (*PruneResult
 	return result, nil
 }
 
+// writeCredentialsFile writes the logins of private upstreams for Zot's
+// sync extension, or removes a stale file when there are none.
+func (z *ZotManager) writeCredentialsFile() error {
+	path := filepath.Join(z.config.Storage, zotCredentialsFile)
+	creds := GenerateZotCredentials(z.config.Mirrors)
+	if len(creds) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, configFileMode)
+}
+
 // writeConfigFile writes the Zot config to a file.
 func (z *ZotManager) writeConfigFile(path string, config map[string]interface{}) error {
 	// Ensure directory exists
@@ -218,51 +255,45 @@ func (z *ZotManager) waitForReady(ctx context.Context) error {
 	return WaitForReady(ctx, endpoint, []int{http.StatusOK, http.StatusUnauthorized}, 10*time.Second)
 }
 
-// getRegistryStats queries the registry API for image count and disk usage.
-func (z *ZotManager) getRegistryStats(ctx context.Context) (int, int64) {
-	// Apply defensive timeout if caller didn't set a deadline
-	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, 5*time.Minute)
-		defer cancel()
-	}
-
-	// Query Zot's catalog API to get image count
-	endpoint := fmt.Sprintf("http://localhost:%d/v2/_catalog", z.config.Port)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return 0, 0
-	}
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return 0, 0
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 0, 0
-	}
-
-	// Parse catalog response
-	var catalog struct {
-		Repositories []string `json:"repositories"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&catalog); err != nil {
-		return 0, 0
-	}
+// StorageStats returns the repositories stored in zot.
+func (z *ZotManager) StorageStats(ctx context.Context) StorageStats {
+	return z.storageStats(ctx, z.IsRunning(ctx))
+}
 
-	imageCount := len(catalog.Repositories)
+// storageStats measures zot's storage, which keeps every repository in OCI
+// layout: a directory with an index.json listing its manifests. While zot
+// runs, the repository count comes from its catalog API instead.
+func (z *ZotManager) storageStats(ctx context.Context, running bool) StorageStats {
+	stats := StorageStats{Kind: "repositories"}
 
-	// Get disk usage by walking storage directory
-	var diskUsage int64
-	filepath.Walk(z.config.Storage, func(path string, info os.FileInfo, err error) error {
-		if err == nil && !info.IsDir() {
-			diskUsage += info.Size()
+	filepath.WalkDir(z.config.Storage, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil // Skip unreadable entries
 		}
-		return nil
+		data, err := os.ReadFile(filepath.Join(path, "index.json"))
+		if err != nil {
+			return nil
+		}
+		var index struct {
+			Manifests []json.RawMessage `json:"manifests"`
+		}
+		if json.Unmarshal(data, &index) != nil {
+			return nil
+		}
+		stats.Items++
+		stats.Versions += len(index.Manifests)
+		stats.addDir(path)
+		return filepath.SkipDir
 	})
 
-	return imageCount, diskUsage
+	if running {
+		var catalog struct {
+			Repositories []string `json:"repositories"`
+		}
+		endpoint := fmt.Sprintf("http://localhost:%d/v2/_catalog", z.config.Port)
+		if err := getStatsJSON(ctx, endpoint, &catalog); err == nil {
+			stats.Items = len(catalog.Repositories)
+		}
+	}
+	return stats
 }
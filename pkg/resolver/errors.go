@@ -51,6 +51,9 @@ func (e *AmbiguousError) FormatDisambiguation() string {
 	sb.WriteString("  -s <system>     Filter by system\n")
 	sb.WriteString("  -a <app>        Filter by app\n")
 	sb.WriteString("  -w <workspace>  Filter by workspace name\n")
+	if len(e.Matches) > 0 {
+		sb.WriteString("or name the workspace by its path, e.g. " + e.Matches[0].FullPath() + "\n")
+	}
 
 	return sb.String()
 }
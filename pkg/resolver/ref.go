@@ -0,0 +1,84 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/models"
+)
+
+// ParseWorkspaceRef parses a workspace reference into a filter. A reference
+// is a workspace name optionally qualified by its hierarchy, outermost first:
+//
+//	ws
+//	app/ws
+//	domain/app/ws
+//	ecosystem/domain/app/ws
+//	ecosystem/domain/system/app/ws
+//
+// which is the form WorkspaceWithHierarchy.FullPath prints.
+func ParseWorkspaceRef(ref string) (models.WorkspaceFilter, error) {
+	parts := strings.Split(strings.Trim(ref, "/"), "/")
+	for _, p := range parts {
+		if strings.TrimSpace(p) == "" {
+			return models.WorkspaceFilter{}, fmt.Errorf("invalid workspace reference %q: empty path segment", ref)
+		}
+	}
+
+	var f models.WorkspaceFilter
+	switch len(parts) {
+	case 1:
+		f.WorkspaceName = parts[0]
+	case 2:
+		f.AppName, f.WorkspaceName = parts[0], parts[1]
+	case 3:
+		f.DomainName, f.AppName, f.WorkspaceName = parts[0], parts[1], parts[2]
+	case 4:
+		f.EcosystemName, f.DomainName, f.AppName, f.WorkspaceName = parts[0], parts[1], parts[2], parts[3]
+	case 5:
+		f.EcosystemName, f.DomainName, f.SystemName, f.AppName, f.WorkspaceName = parts[0], parts[1], parts[2], parts[3], parts[4]
+	default:
+		return models.WorkspaceFilter{}, fmt.Errorf("invalid workspace reference %q: expected at most ecosystem/domain/system/app/workspace", ref)
+	}
+	return f, nil
+}
+
+// ExpandWorkspaceRef expands a path in filter.WorkspaceName (as given to
+// -w or as a positional argument) into the filter's hierarchy fields.
+// Fields already set must agree with the path.
+func ExpandWorkspaceRef(filter models.WorkspaceFilter) (models.WorkspaceFilter, error) {
+	if !strings.Contains(filter.WorkspaceName, "/") {
+		return filter, nil
+	}
+	ref, err := ParseWorkspaceRef(filter.WorkspaceName)
+	if err != nil {
+		return filter, err
+	}
+	merge := func(flag string, dst *string, value string) error {
+		if value == "" {
+			return nil
+		}
+		if *dst != "" && *dst != value {
+			return fmt.Errorf("workspace path %q names %s %q, but --%s is %q", filter.WorkspaceName, flag, value, flag, *dst)
+		}
+		*dst = value
+		return nil
+	}
+	out := filter
+	out.WorkspaceName = ref.WorkspaceName
+	for _, m := range []struct {
+		flag  string
+		dst   *string
+		value string
+	}{
+		{"ecosystem", &out.EcosystemName, ref.EcosystemName},
+		{"domain", &out.DomainName, ref.DomainName},
+		{"system", &out.SystemName, ref.SystemName},
+		{"app", &out.AppName, ref.AppName},
+	} {
+		if err := merge(m.flag, m.dst, m.value); err != nil {
+			return filter, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package resolver
+
+import (
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+)
+
+func TestParseWorkspaceRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want models.WorkspaceFilter
+	}{
+		{"dev", models.WorkspaceFilter{WorkspaceName: "dev"}},
+		{"portal/dev", models.WorkspaceFilter{AppName: "portal", WorkspaceName: "dev"}},
+		{"billing/portal/dev", models.WorkspaceFilter{DomainName: "billing", AppName: "portal", WorkspaceName: "dev"}},
+		{"healthcare/billing/portal/dev", models.WorkspaceFilter{
+			EcosystemName: "healthcare", DomainName: "billing", AppName: "portal", WorkspaceName: "dev",
+		}},
+		{"healthcare/billing/core/portal/dev", models.WorkspaceFilter{
+			EcosystemName: "healthcare", DomainName: "billing", SystemName: "core", AppName: "portal", WorkspaceName: "dev",
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseWorkspaceRef(tt.ref)
+		if err != nil {
+			t.Errorf("ParseWorkspaceRef(%q) error = %v", tt.ref, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWorkspaceRef(%q) = %+v, want %+v", tt.ref, got, tt.want)
+		}
+	}
+
+	for _, ref := range []string{"portal//dev", "a/b/c/d/e/f"} {
+		if _, err := ParseWorkspaceRef(ref); err == nil {
+			t.Errorf("ParseWorkspaceRef(%q) succeeded, want an error", ref)
+		}
+	}
+}
+
+func TestExpandWorkspaceRef(t *testing.T) {
+	got, err := ExpandWorkspaceRef(models.WorkspaceFilter{AppName: "portal", WorkspaceName: "billing/portal/dev"})
+	if err != nil {
+		t.Fatalf("ExpandWorkspaceRef() error = %v", err)
+	}
+	want := models.WorkspaceFilter{DomainName: "billing", AppName: "portal", WorkspaceName: "dev"}
+	if got != want {
+		t.Errorf("ExpandWorkspaceRef() = %+v, want %+v", got, want)
+	}
+
+	plain := models.WorkspaceFilter{AppName: "portal", WorkspaceName: "dev"}
+	if got, err := ExpandWorkspaceRef(plain); err != nil || got != plain {
+		t.Errorf("ExpandWorkspaceRef(plain name) = %+v, %v, want it unchanged", got, err)
+	}
+
+	_, err = ExpandWorkspaceRef(models.WorkspaceFilter{AppName: "api", WorkspaceName: "portal/dev"})
+	if err == nil || !strings.Contains(err.Error(), "--app") {
+		t.Errorf("ExpandWorkspaceRef() with a conflicting --app error = %v", err)
+	}
+}
+
+func TestResolve_WorkspacePath(t *testing.T) {
+	store := setupTestData()
+	filter, err := ExpandWorkspaceRef(models.WorkspaceFilter{WorkspaceName: "healthcare/billing/portal/dev"})
+	if err != nil {
+		t.Fatalf("ExpandWorkspaceRef() error = %v", err)
+	}
+	wh, err := NewWorkspaceResolver(store).Resolve(filter)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got := wh.FullPath(); got != "healthcare/billing/portal/dev" {
+		t.Errorf("Resolve() = %s", got)
+	}
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/resource"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Admission
+// =============================================================================
+//
+// Admission validators run before a handler's Apply, like Kubernetes
+// validating webhooks. Every registered handler is wrapped by RegisterAll,
+// so `dvm apply`, List documents, and resource.Apply callers all pass
+// through them. Their findings are collected into one AdmissionError that
+// names each offending field, instead of the first opaque DB error.
+
+// AdmissionRequest is a resource document about to be applied.
+type AdmissionRequest struct {
+	Kind     string
+	Metadata AdmissionMetadata
+	Spec     map[string]any
+	Data     []byte // the raw document
+}
+
+// AdmissionMetadata is the metadata shared by resource kinds. Parent
+// references are empty for kinds that don't have them.
+type AdmissionMetadata struct {
+	Name      string            `yaml:"name"`
+	Ecosystem string            `yaml:"ecosystem,omitempty"`
+	Domain    string            `yaml:"domain,omitempty"`
+	System    string            `yaml:"system,omitempty"`
+	App       string            `yaml:"app,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+// FieldError is a problem with one field of a resource, such as
+// metadata.labels.team.
+type FieldError struct {
+	Field   string `json:"field" yaml:"field"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// AdmissionError is returned by Apply when admission rejects a resource.
+// It lists every problem found, not only the first.
+type AdmissionError struct {
+	Kind   string
+	Name   string
+	Errors []FieldError
+}
+
+func (e *AdmissionError) Error() string {
+	var sb strings.Builder
+	if e.Name != "" {
+		fmt.Fprintf(&sb, "%s %q rejected:", e.Kind, e.Name)
+	} else {
+		fmt.Fprintf(&sb, "%s rejected:", e.Kind)
+	}
+	for _, fe := range e.Errors {
+		sb.WriteString("\n  - " + fe.Error())
+	}
+	return sb.String()
+}
+
+// Validator checks a resource before it is applied and returns the problems
+// it finds, or nil to admit it.
+type Validator interface {
+	Validate(ctx resource.Context, req *AdmissionRequest) []FieldError
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(ctx resource.Context, req *AdmissionRequest) []FieldError
+
+// Validate calls f(ctx, req).
+func (f ValidatorFunc) Validate(ctx resource.Context, req *AdmissionRequest) []FieldError {
+	return f(ctx, req)
+}
+
+type registeredValidator struct {
+	validator Validator
+	kinds     []string
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   []registeredValidator
+)
+
+// RegisterValidator adds an admission validator for the given kinds, or
+// for every kind when none are given. Validators run in registration order.
+func RegisterValidator(v Validator, kinds ...string) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	validators = append(validators, registeredValidator{validator: v, kinds: kinds})
+}
+
+// Admit runs the admission validators registered for the document's kind
+// and returns an *AdmissionError listing everything they reject. Documents
+// that don't parse are left to the handler to report.
+func Admit(ctx resource.Context, data []byte) error {
+	var doc struct {
+		Kind     string            `yaml:"kind"`
+		Metadata AdmissionMetadata `yaml:"metadata"`
+		Spec     map[string]any    `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil || doc.Kind == "" {
+		return nil
+	}
+	req := &AdmissionRequest{Kind: doc.Kind, Metadata: doc.Metadata, Spec: doc.Spec, Data: data}
+
+	validatorsMu.RLock()
+	registered := append([]registeredValidator(nil), validators...)
+	validatorsMu.RUnlock()
+
+	var errs []FieldError
+	for _, rv := range registered {
+		if len(rv.kinds) > 0 && !slices.Contains(rv.kinds, req.Kind) {
+			continue
+		}
+		errs = append(errs, rv.validator.Validate(ctx, req)...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &AdmissionError{Kind: req.Kind, Name: req.Metadata.Name, Errors: errs}
+}
+
+// admittedHandler converts a document to its kind's hub apiVersion, rejects
+// unknown fields, runs admission, and checks field managers before
+// delegating Apply to its handler, then records the managers of the fields
+// it wrote and the resource's new revision.
+type admittedHandler struct {
+	resource.Handler
+}
+
+// withAdmission wraps h so its Apply is admitted first.
+func withAdmission(h resource.Handler) resource.Handler {
+	return admittedHandler{Handler: h}
+}
+
+// Validate runs the checks Apply makes before a handler sees data: apiVersion
+// conversion, unknown fields when Strict, and admission. Conversion warnings
+// are not reported; Apply reports them.
+func Validate(ctx resource.Context, data []byte) error {
+	data, _, err := defaultScheme.Convert(data)
+	if err != nil {
+		return err
+	}
+	if Strict() {
+		if err := defaultScheme.CheckStrict(data); err != nil {
+			return err
+		}
+	}
+	return Admit(ctx, data)
+}
+
+func (a admittedHandler) Apply(ctx resource.Context, data []byte) (resource.Resource, error) {
+	data, warnings, err := defaultScheme.Convert(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		warningHandler(w)
+	}
+	if Strict() {
+		if err := defaultScheme.CheckStrict(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := Admit(ctx, data); err != nil {
+		return nil, err
+	}
+	ownership, err := checkFieldManagers(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.Handler.Apply(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := a.Handler.ToYAML(res)
+	if err != nil {
+		warningHandler(fmt.Sprintf("%s %q: field managers and revision not recorded: %v", a.Kind(), res.GetName(), err))
+		return res, nil
+	}
+	ownership.record(applied)
+	recordRevision(ctx, a.Kind(), res.GetName(), applied)
+	return res, nil
+}
+
+// Delete deletes the resource and forgets the managers of its fields and
+// its revisions.
+func (a admittedHandler) Delete(ctx resource.Context, name string) error {
+	if err := a.Handler.Delete(ctx, name); err != nil {
+		return err
+	}
+	forgetFieldManagers(ctx, a.Kind(), name)
+	forgetRevisions(ctx, a.Kind(), name)
+	return nil
+}
+
+// Unwrap returns the wrapped handler, for optional interfaces such as
+// Describer.
+func (a admittedHandler) Unwrap() resource.Handler {
+	return a.Handler
+}
+
+// unwrapHandler returns the handler beneath any admission wrapper.
+func unwrapHandler(h resource.Handler) resource.Handler {
+	if u, ok := h.(interface{ Unwrap() resource.Handler }); ok {
+		return u.Unwrap()
+	}
+	return h
+}
+
+// =============================================================================
+// Built-in validators
+// =============================================================================
+
+// hierarchyKinds are the kinds whose names become workspace slugs, container
+// names, and directory names.
+var hierarchyKinds = []string{KindEcosystem, KindDomain, KindSystem, KindApp, KindWorkspace}
+
+var registerValidatorsOnce sync.Once
+
+// registerDefaultValidators registers the built-in admission checks.
+func registerDefaultValidators() {
+	registerValidatorsOnce.Do(func() {
+		RegisterValidator(ValidatorFunc(validateName))
+		RegisterValidator(ValidatorFunc(validateLabels))
+		RegisterValidator(ValidatorFunc(validateParents), KindDomain, KindSystem, KindApp, KindWorkspace)
+		RegisterValidator(ValidatorFunc(validateAppPath), KindApp)
+	})
+}
+
+// maxNameLength bounds resource names; longer names make unwieldy container
+// and image names.
+const maxNameLength = 63
+
+// validateName requires metadata.name, and for hierarchy kinds a name that
+// is safe to use in paths and container names.
+func validateName(_ resource.Context, req *AdmissionRequest) []FieldError {
+	name := req.Metadata.Name
+	field := "metadata.name"
+	switch {
+	case strings.TrimSpace(name) == "":
+		return []FieldError{{field, "is required"}}
+	case strings.TrimSpace(name) != name:
+		return []FieldError{{field, fmt.Sprintf("%q has leading or trailing whitespace", name)}}
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return []FieldError{{field, fmt.Sprintf("%q contains control characters", name)}}
+	}
+	if !slices.Contains(hierarchyKinds, req.Kind) {
+		return nil
+	}
+	if len(name) > maxNameLength {
+		return []FieldError{{field, fmt.Sprintf("%q is longer than %d characters", name, maxNameLength)}}
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return []FieldError{{field, fmt.Sprintf("%q must not contain / or \\", name)}}
+	}
+	return nil
+}
+
+// labelNameRegex matches a label key's name part and a non-empty label value.
+var labelNameRegex = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?$`)
+
+// labelPrefixRegex matches a DNS subdomain label key prefix, such as
+// devopsmaestro.io.
+var labelPrefixRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// validateLabels checks metadata.labels against the Kubernetes label
+// syntax: keys are an optional DNS prefix and a name of at most 63
+// characters, values are empty or at most 63 characters, alphanumeric at
+// both ends.
+func validateLabels(_ resource.Context, req *AdmissionRequest) []FieldError {
+	var errs []FieldError
+	for _, key := range slices.Sorted(maps.Keys(req.Metadata.Labels)) {
+		field := "metadata.labels." + key
+		prefix, name, hasPrefix := strings.Cut(key, "/")
+		if !hasPrefix {
+			prefix, name = "", key
+		}
+		switch {
+		case hasPrefix && (len(prefix) > 253 || !labelPrefixRegex.MatchString(prefix)):
+			errs = append(errs, FieldError{field, fmt.Sprintf("key prefix %q must be a DNS subdomain", prefix)})
+		case len(name) > maxNameLength || !labelNameRegex.MatchString(name):
+			errs = append(errs, FieldError{field, fmt.Sprintf("key name %q must be at most %d alphanumeric characters, '-', '_' or '.', starting and ending alphanumeric", name, maxNameLength)})
+		}
+		if value := req.Metadata.Labels[key]; value != "" && (len(value) > maxNameLength || !labelNameRegex.MatchString(value)) {
+			errs = append(errs, FieldError{field, fmt.Sprintf("value %q must be at most %d alphanumeric characters, '-', '_' or '.', starting and ending alphanumeric", value, maxNameLength)})
+		}
+	}
+	return errs
+}
+
+// validateParents checks that the ecosystem, domain, system, and app a
+// resource names in its metadata exist. References left empty fall back to
+// the active context in the handler and are not checked here.
+func validateParents(ctx resource.Context, req *AdmissionRequest) []FieldError {
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil
+	}
+	md := req.Metadata
+
+	var eco *models.Ecosystem
+	if md.Ecosystem != "" {
+		eco, err = ds.GetEcosystemByName(md.Ecosystem)
+		if db.IsNotFound(err) {
+			return []FieldError{{"metadata.ecosystem", fmt.Sprintf("ecosystem %q not found", md.Ecosystem)}}
+		}
+		if err != nil {
+			return nil
+		}
+	}
+	if req.Kind == KindDomain || md.Domain == "" {
+		if req.Kind == KindWorkspace && md.App != "" {
+			return checkAppExists(ds, nil, md.App)
+		}
+		return nil
+	}
+
+	var candidates []*models.Domain
+	if eco != nil {
+		candidates, err = ds.ListDomainsByEcosystem(eco.ID)
+	} else {
+		candidates, err = ds.ListAllDomains()
+	}
+	if err != nil {
+		return nil
+	}
+	var domains []*models.Domain
+	for _, d := range candidates {
+		if d.Name == md.Domain {
+			domains = append(domains, d)
+		}
+	}
+	if len(domains) == 0 {
+		msg := fmt.Sprintf("domain %q not found", md.Domain)
+		if eco != nil {
+			msg += fmt.Sprintf(" in ecosystem %q", eco.Name)
+		}
+		return []FieldError{{"metadata.domain", msg}}
+	}
+	if len(domains) > 1 {
+		// Ambiguous across ecosystems; the handler asks for metadata.ecosystem
+		return nil
+	}
+	domain := domains[0]
+	domainID := sql.NullInt64{Int64: int64(domain.ID), Valid: true}
+
+	var errs []FieldError
+	if req.Kind == KindApp && md.System != "" {
+		if _, err := ds.GetSystemByName(domainID, md.System); db.IsNotFound(err) {
+			errs = append(errs, FieldError{"metadata.system", fmt.Sprintf("system %q not found in domain %q", md.System, domain.Name)})
+		}
+	}
+	if req.Kind == KindWorkspace && md.App != "" {
+		errs = append(errs, checkAppExists(ds, domain, md.App)...)
+	}
+	return errs
+}
+
+// checkAppExists reports a missing app, looked up in domain when it is not
+// nil and across all domains otherwise.
+func checkAppExists(ds db.DataStore, domain *models.Domain, name string) []FieldError {
+	if domain != nil {
+		if _, err := ds.GetAppByName(sql.NullInt64{Int64: int64(domain.ID), Valid: true}, name); db.IsNotFound(err) {
+			return []FieldError{{"metadata.app", fmt.Sprintf("app %q not found in domain %q", name, domain.Name)}}
+		}
+		return nil
+	}
+	apps, err := ds.ListAllApps()
+	if err != nil {
+		return nil
+	}
+	for _, a := range apps {
+		if a.Name == name {
+			return nil
+		}
+	}
+	return []FieldError{{"metadata.app", fmt.Sprintf("app %q not found", name)}}
+}
+
+// validateAppPath rejects an App whose spec.path is already another app's
+// path; two apps sharing a checkout would build and mount the same source.
+func validateAppPath(ctx resource.Context, req *AdmissionRequest) []FieldError {
+	path, _ := req.Spec["path"].(string)
+	if path == "" {
+		return nil
+	}
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil
+	}
+	apps, err := ds.ListAllApps()
+	if err != nil {
+		return nil
+	}
+	clean := filepath.Clean(path)
+	for _, a := range apps {
+		if a.Name != req.Metadata.Name && a.Path != "" && filepath.Clean(a.Path) == clean {
+			return []FieldError{{"spec.path", fmt.Sprintf("%s is already the path of app %q", path, a.Name)}}
+		}
+	}
+	return nil
+}
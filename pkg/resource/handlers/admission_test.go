@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+// admissionErrors admits data and returns the rejected fields.
+func admissionErrors(t *testing.T, store *db.MockDataStore, data string) []FieldError {
+	t.Helper()
+	registerDefaultValidators()
+	err := Admit(resource.Context{DataStore: store}, []byte(data))
+	if err == nil {
+		return nil
+	}
+	var ae *AdmissionError
+	if !errors.As(err, &ae) {
+		t.Fatalf("Admit() error = %T %v, want *AdmissionError", err, err)
+	}
+	return ae.Errors
+}
+
+func fieldsOf(errs []FieldError) []string {
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	return fields
+}
+
+func TestAdmit_AggregatesFieldErrors(t *testing.T) {
+	store, _, _, _ := setupWorkspaceTest(t)
+
+	errs := admissionErrors(t, store, `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: team/dev
+  app: missing-app
+  labels:
+    team: platform
+    -bad: ok
+    devopsmaestro.io/tier: "not valid"
+spec:
+  image:
+    name: ubuntu:22.04
+`)
+	got := strings.Join(fieldsOf(errs), ",")
+	want := "metadata.name,metadata.labels.-bad,metadata.labels.devopsmaestro.io/tier,metadata.app"
+	if got != want {
+		t.Errorf("rejected fields = %s, want %s", got, want)
+	}
+
+	err := &AdmissionError{Kind: KindWorkspace, Name: "team/dev", Errors: errs}
+	if !strings.HasPrefix(err.Error(), `Workspace "team/dev" rejected:`+"\n  - metadata.name: ") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
+
+func TestAdmit_Valid(t *testing.T) {
+	store, _, _, _ := setupWorkspaceTest(t)
+
+	errs := admissionErrors(t, store, `
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: ws-app
+  domain: ws-domain
+  ecosystem: ws-eco
+  labels:
+    devopsmaestro.io/team: platform
+    tier: ""
+spec:
+  image:
+    name: ubuntu:22.04
+`)
+	if len(errs) != 0 {
+		t.Errorf("Admit() rejected %v", errs)
+	}
+}
+
+func TestAdmit_Parents(t *testing.T) {
+	store, _, _, _ := setupWorkspaceTest(t)
+
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "missing ecosystem",
+			doc:  "kind: Domain\nmetadata:\n  name: d\n  ecosystem: nope\n",
+			want: `metadata.ecosystem: ecosystem "nope" not found`,
+		},
+		{
+			name: "domain not in ecosystem",
+			doc:  "kind: System\nmetadata:\n  name: s\n  domain: other\n  ecosystem: ws-eco\n",
+			want: `metadata.domain: domain "other" not found in ecosystem "ws-eco"`,
+		},
+		{
+			name: "missing system",
+			doc:  "kind: App\nmetadata:\n  name: a\n  domain: ws-domain\n  system: nope\nspec:\n  path: /src/a\n",
+			want: `metadata.system: system "nope" not found in domain "ws-domain"`,
+		},
+		{
+			name: "missing app in domain",
+			doc:  "kind: Workspace\nmetadata:\n  name: w\n  app: nope\n  domain: ws-domain\n",
+			want: `metadata.app: app "nope" not found in domain "ws-domain"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := admissionErrors(t, store, tt.doc)
+			if len(errs) != 1 || errs[0].Error() != tt.want {
+				t.Errorf("Admit() = %v, want [%s]", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdmit_AppPathUnique(t *testing.T) {
+	store, _, domainID, _ := setupWorkspaceTest(t)
+	if err := store.CreateApp(&models.App{Name: "other", DomainID: sql.NullInt64{Int64: int64(domainID), Valid: true}, Path: "/src/other"}); err != nil {
+		t.Fatalf("CreateApp() error = %v", err)
+	}
+
+	errs := admissionErrors(t, store, "kind: App\nmetadata:\n  name: ws-app\n  domain: ws-domain\nspec:\n  path: /src/other/\n")
+	if len(errs) != 1 || errs[0].Field != "spec.path" || !strings.Contains(errs[0].Message, `app "other"`) {
+		t.Errorf("Admit() = %v, want spec.path taken by app other", errs)
+	}
+
+	// Re-applying an app with its own path is fine
+	if errs := admissionErrors(t, store, "kind: App\nmetadata:\n  name: ws-app\n  domain: ws-domain\nspec:\n  path: /ws/app\n"); len(errs) != 0 {
+		t.Errorf("Admit() rejected re-apply: %v", errs)
+	}
+}
+
+func TestRegisterAll_AdmitsBeforeApply(t *testing.T) {
+	RegisterAll()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	_, err := resource.Apply(ctx, []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata:
+  name: " platform"
+`), "test")
+	var ae *AdmissionError
+	if !errors.As(err, &ae) {
+		t.Fatalf("Apply() error = %v, want *AdmissionError", err)
+	}
+	if ecosystems, _ := store.ListEcosystems(); len(ecosystems) != 0 {
+		t.Errorf("Apply() stored %d ecosystems after admission rejected it", len(ecosystems))
+	}
+
+	res, err := resource.Apply(ctx, []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata:
+  name: platform
+`), "test")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if res.GetName() != "platform" {
+		t.Errorf("Apply() Name = %q, want platform", res.GetName())
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	themeresolver "devopsmaestro/pkg/colors/resolver"
+	"devopsmaestro/pkg/envresolver"
 	"github.com/rmkohlman/MaestroSDK/resource"
 
 	"gopkg.in/yaml.v3"
@@ -32,6 +34,15 @@ func (h *AppHandler) Apply(ctx resource.Context, data []byte) (resource.Resource
 	if err := yaml.Unmarshal(data, &appYAML); err != nil {
 		return nil, fmt.Errorf("failed to parse app YAML: %w", err)
 	}
+	if err := envresolver.Validate(appYAML.Spec.Env); err != nil {
+		return nil, fmt.Errorf("app %s: spec.env: %w", appYAML.Metadata.Name, err)
+	}
+	if err := appYAML.Spec.Build.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("app %s: spec.build.%w", appYAML.Metadata.Name, err)
+	}
+	if err := appYAML.Spec.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("app %s: spec.%w", appYAML.Metadata.Name, err)
+	}
 
 	// Get the datastore
 	ds, err := resource.DataStoreAs[db.DataStore](ctx)
@@ -320,6 +331,56 @@ func (h *AppHandler) Delete(ctx resource.Context, name string) error {
 	return ds.DeleteApp(app.ID)
 }
 
+// Describe summarizes an app: its parents, workspaces, theme resolution, and
+// the last build of each workspace.
+func (h *AppHandler) Describe(ctx resource.Context, res resource.Resource) (*Description, error) {
+	ar, ok := res.(*AppResource)
+	if !ok {
+		return nil, fmt.Errorf("expected AppResource, got %T", res)
+	}
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil, err
+	}
+	app := ar.app
+	eco, dom, sys := appParents(ds, app)
+
+	d := &Description{Kind: KindApp, Name: app.Name}
+	d.Sections = append(d.Sections, DescriptionSection{Title: KindApp, Fields: []DescriptionField{
+		{Key: "Name", Value: app.Name},
+		{Key: "Path", Value: orNone(app.Path)},
+		{Key: "Description", Value: orNone(nullString(app.Description))},
+		{Key: "Git Repo", Value: orNone(gitRepoName(ds, app.GitRepoID))},
+		{Key: "Created", Value: describeTime(app.CreatedAt)},
+		{Key: "Updated", Value: describeTime(app.UpdatedAt)},
+	}})
+	d.Sections = append(d.Sections, describeParents(eco, dom, sys, nil))
+
+	workspaces := DescriptionSection{Title: "Workspaces", Headers: []string{"NAME", "STATUS", "IMAGE"}, None: "<none>"}
+	workspaces.Rows = describeWorkspaceRows(ds, app.ID)
+	d.Sections = append(d.Sections, workspaces)
+
+	d.Sections = append(d.Sections, describeTheme(ds, themeresolver.LevelApp, app.ID))
+
+	builds := DescriptionSection{Title: "Last Builds", Headers: []string{"WORKSPACE", "STATUS", "STARTED", "IMAGE TAG"}, None: "<never built>"}
+	if list, err := ds.ListWorkspacesByApp(app.ID); err == nil {
+		for _, w := range list {
+			bsw, err := ds.GetLatestBuildSessionWorkspace(w.ID)
+			if err != nil || bsw == nil {
+				continue
+			}
+			started := ""
+			if bsw.StartedAt.Valid {
+				started = describeTime(bsw.StartedAt.Time)
+			}
+			builds.Rows = append(builds.Rows, []string{w.Name, bsw.Status, orNone(started), orNone(nullString(bsw.ImageTag))})
+		}
+	}
+	d.Sections = append(d.Sections, builds)
+
+	return d, nil
+}
+
 // ToYAML serializes an app to YAML.
 func (h *AppHandler) ToYAML(res resource.Resource) ([]byte, error) {
 	ar, ok := res.(*AppResource)
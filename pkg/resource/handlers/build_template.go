@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/buildtemplate"
+	"github.com/rmkohlman/MaestroSDK/resource"
+
+	"gopkg.in/yaml.v3"
+)
+
+const KindBuildTemplate = "BuildTemplate"
+
+// BuildTemplateHandler handles BuildTemplate resources.
+type BuildTemplateHandler struct{}
+
+// NewBuildTemplateHandler creates a new BuildTemplate handler.
+func NewBuildTemplateHandler() *BuildTemplateHandler {
+	return &BuildTemplateHandler{}
+}
+
+func (h *BuildTemplateHandler) Kind() string {
+	return KindBuildTemplate
+}
+
+// Apply creates or updates a build template from YAML data. When an existing
+// template's build config changes, every workspace of every app using it is
+// marked as needing a rebuild.
+func (h *BuildTemplateHandler) Apply(ctx resource.Context, data []byte) (resource.Resource, error) {
+	var tmplYAML models.BuildTemplateYAML
+	if err := yaml.Unmarshal(data, &tmplYAML); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	ds, err := resource.DataStoreAs[buildtemplate.Store](ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &models.BuildTemplate{}
+	if err := tmpl.FromYAML(tmplYAML); err != nil {
+		return nil, fmt.Errorf("failed to convert build template: %w", err)
+	}
+	res := &BuildTemplateResource{template: tmpl}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := ds.GetBuildTemplate(tmpl.Name)
+	if err != nil {
+		if err := ds.CreateBuildTemplate(tmpl); err != nil {
+			return nil, fmt.Errorf("failed to create build template: %w", err)
+		}
+		return res, nil
+	}
+
+	tmpl.ID = existing.ID
+	if err := ds.UpdateBuildTemplate(tmpl); err != nil {
+		return nil, fmt.Errorf("failed to update build template: %w", err)
+	}
+	if existing.BuildConfig != tmpl.BuildConfig {
+		res.marked, err = buildtemplate.MarkDependents(ds, tmpl.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Get retrieves a build template by name.
+func (h *BuildTemplateHandler) Get(ctx resource.Context, name string) (resource.Resource, error) {
+	ds, err := resource.DataStoreAs[buildtemplate.Store](ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := ds.GetBuildTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	return &BuildTemplateResource{template: tmpl}, nil
+}
+
+// List retrieves all build templates.
+func (h *BuildTemplateHandler) List(ctx resource.Context) ([]resource.Resource, error) {
+	ds, err := resource.DataStoreAs[buildtemplate.Store](ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := ds.ListBuildTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]resource.Resource, len(templates))
+	for i, tmpl := range templates {
+		resources[i] = &BuildTemplateResource{template: tmpl}
+	}
+	return resources, nil
+}
+
+// Delete removes a build template by name. A template still referenced by
+// apps cannot be deleted.
+func (h *BuildTemplateHandler) Delete(ctx resource.Context, name string) error {
+	ds, err := resource.DataStoreAs[buildtemplate.Store](ctx)
+	if err != nil {
+		return err
+	}
+
+	apps, err := buildtemplate.Dependents(ds, name)
+	if err != nil {
+		return err
+	}
+	if len(apps) > 0 {
+		names := make([]string, len(apps))
+		for i, app := range apps {
+			names[i] = app.Name
+		}
+		sort.Strings(names)
+		return fmt.Errorf("build template %s is used by apps: %s", name, strings.Join(names, ", "))
+	}
+	return ds.DeleteBuildTemplate(name)
+}
+
+// ToYAML converts a build template resource to YAML.
+func (h *BuildTemplateHandler) ToYAML(res resource.Resource) ([]byte, error) {
+	tr, ok := res.(*BuildTemplateResource)
+	if !ok {
+		return nil, fmt.Errorf("resource is not a BuildTemplate")
+	}
+	return yaml.Marshal(tr.template.ToYAML())
+}
+
+// BuildTemplateResource wraps a BuildTemplate model as a resource.Resource.
+type BuildTemplateResource struct {
+	template *models.BuildTemplate
+	marked   []*models.Workspace // workspaces marked for rebuild by Apply
+}
+
+func (r *BuildTemplateResource) GetKind() string {
+	return KindBuildTemplate
+}
+
+func (r *BuildTemplateResource) GetName() string {
+	return r.template.Name
+}
+
+// Validate checks the template name and build config. Templates cannot
+// themselves reference a template.
+func (r *BuildTemplateResource) Validate() error {
+	if r.template.Name == "" {
+		return fmt.Errorf("build template name is required")
+	}
+	cfg := r.template.GetBuildConfig()
+	if cfg.Template != "" {
+		return fmt.Errorf("build template %s: spec.template is not allowed; templates do not inherit from other templates", r.template.Name)
+	}
+	switch cfg.Kind {
+	case "", "auto", "cicd", "language":
+	default:
+		return fmt.Errorf("build template %s: invalid kind %q (want auto, cicd, or language)", r.template.Name, cfg.Kind)
+	}
+	if err := models.ValidateCACerts(cfg.CACerts); err != nil {
+		return fmt.Errorf("build template %s: %w", r.template.Name, err)
+	}
+	if err := cfg.Hooks.Validate(); err != nil {
+		return fmt.Errorf("build template %s: spec.%w", r.template.Name, err)
+	}
+	return nil
+}
+
+// BuildTemplate returns the underlying build template model.
+func (r *BuildTemplateResource) BuildTemplate() *models.BuildTemplate {
+	return r.template
+}
+
+// MarkedForRebuild returns the workspaces Apply marked as needing a rebuild
+// because the template changed.
+func (r *BuildTemplateResource) MarkedForRebuild() []*models.Workspace {
+	return r.marked
+}
+
+// NewBuildTemplateResource creates a new BuildTemplateResource from a model.
+func NewBuildTemplateResource(tmpl *models.BuildTemplate) *BuildTemplateResource {
+	return &BuildTemplateResource{template: tmpl}
+}
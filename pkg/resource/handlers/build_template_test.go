@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+const goServiceTemplateYAML = `apiVersion: devopsmaestro.io/v1
+kind: BuildTemplate
+metadata:
+  name: go-service
+spec:
+  dockerfile: Dockerfile.go
+  args:
+    GO_VERSION: "1.22"
+`
+
+func TestBuildTemplateHandler_Kind(t *testing.T) {
+	h := NewBuildTemplateHandler()
+	if h.Kind() != KindBuildTemplate {
+		t.Errorf("Kind() = %q, want %q", h.Kind(), KindBuildTemplate)
+	}
+}
+
+func TestBuildTemplateHandler_Apply_Create(t *testing.T) {
+	h := NewBuildTemplateHandler()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	res, err := h.Apply(ctx, []byte(goServiceTemplateYAML))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if res.GetName() != "go-service" {
+		t.Errorf("GetName() = %q, want %q", res.GetName(), "go-service")
+	}
+
+	tmpl, err := store.GetBuildTemplate("go-service")
+	if err != nil {
+		t.Fatalf("template not stored: %v", err)
+	}
+	if got := tmpl.GetBuildConfig().Args["GO_VERSION"]; got != "1.22" {
+		t.Errorf("GO_VERSION = %q, want %q", got, "1.22")
+	}
+}
+
+func TestBuildTemplateHandler_Apply_UpdateMarksDependents(t *testing.T) {
+	h := NewBuildTemplateHandler()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	if _, err := h.Apply(ctx, []byte(goServiceTemplateYAML)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	app := &models.App{
+		Name:        "api",
+		BuildConfig: sql.NullString{String: `{"template":"go-service"}`, Valid: true},
+	}
+	if err := store.CreateApp(app); err != nil {
+		t.Fatalf("CreateApp() error = %v", err)
+	}
+	ws := &models.Workspace{Name: "dev", AppID: app.ID}
+	if err := store.CreateWorkspace(ws); err != nil {
+		t.Fatalf("CreateWorkspace() error = %v", err)
+	}
+
+	// Re-applying an unchanged template marks nothing.
+	res, err := h.Apply(ctx, []byte(goServiceTemplateYAML))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if n := len(res.(*BuildTemplateResource).MarkedForRebuild()); n != 0 {
+		t.Errorf("unchanged template marked %d workspaces, want 0", n)
+	}
+
+	changed := strings.Replace(goServiceTemplateYAML, `"1.22"`, `"1.23"`, 1)
+	res, err = h.Apply(ctx, []byte(changed))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	marked := res.(*BuildTemplateResource).MarkedForRebuild()
+	if len(marked) != 1 || marked[0].ID != ws.ID {
+		t.Fatalf("MarkedForRebuild() = %v, want workspace %d", marked, ws.ID)
+	}
+	if store.WorkspaceRebuilds[ws.ID] == "" {
+		t.Error("workspace has no pending rebuild reason")
+	}
+}
+
+func TestBuildTemplateHandler_Apply_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			yaml:    "kind: BuildTemplate\nmetadata: {}\nspec: {}\n",
+			wantErr: "name is required",
+		},
+		{
+			name:    "nested template",
+			yaml:    "kind: BuildTemplate\nmetadata:\n  name: t\nspec:\n  template: other\n",
+			wantErr: "spec.template is not allowed",
+		},
+		{
+			name:    "bad kind",
+			yaml:    "kind: BuildTemplate\nmetadata:\n  name: t\nspec:\n  kind: rust\n",
+			wantErr: "invalid kind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewBuildTemplateHandler()
+			ctx := resource.Context{DataStore: db.NewMockDataStore()}
+			_, err := h.Apply(ctx, []byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Apply() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildTemplateHandler_Delete_InUse(t *testing.T) {
+	h := NewBuildTemplateHandler()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	if _, err := h.Apply(ctx, []byte(goServiceTemplateYAML)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	app := &models.App{
+		Name:        "api",
+		BuildConfig: sql.NullString{String: `{"template":"go-service"}`, Valid: true},
+	}
+	if err := store.CreateApp(app); err != nil {
+		t.Fatalf("CreateApp() error = %v", err)
+	}
+
+	err := h.Delete(ctx, "go-service")
+	if err == nil || !strings.Contains(err.Error(), "used by apps: api") {
+		t.Fatalf("Delete() error = %v, want in-use error", err)
+	}
+
+	app.BuildConfig = sql.NullString{}
+	if err := h.Delete(ctx, "go-service"); err != nil {
+		t.Fatalf("Delete() unused template error = %v", err)
+	}
+	if _, err := store.GetBuildTemplate("go-service"); !db.IsNotFound(err) {
+		t.Errorf("template still exists after delete: %v", err)
+	}
+}
+
+func TestBuildTemplateHandler_ToYAML(t *testing.T) {
+	h := NewBuildTemplateHandler()
+	ctx := resource.Context{DataStore: db.NewMockDataStore()}
+
+	res, err := h.Apply(ctx, []byte(goServiceTemplateYAML))
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	out, err := h.ToYAML(res)
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	for _, want := range []string{"kind: BuildTemplate", "name: go-service", "dockerfile: Dockerfile.go"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("ToYAML() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"devopsmaestro/models"
+	nvimpkg "github.com/rmkohlman/MaestroNvim/nvimops/package"
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	terminalpkg "github.com/rmkohlman/MaestroTerminal/terminalops/package"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	theme "github.com/rmkohlman/MaestroTheme"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// API Version Conversion
+// =============================================================================
+//
+// Each kind has one hub apiVersion, the one its handler parses. Manifests
+// written for another version of the kind are converted to the hub before
+// Apply, and fields a release deprecates are moved to their replacement with
+// a warning, so stored manifests keep applying across releases. Versions a
+// kind doesn't know, such as manifests from a newer dvm, are rejected with
+// the versions it does support.
+
+// HubAPIVersion is the apiVersion the built-in kinds are parsed at.
+const HubAPIVersion = "devopsmaestro.io/v1"
+
+// ConvertFunc rewrites a decoded document of an older or newer apiVersion
+// in place into the hub version's shape. It returns warnings to show the
+// user, such as fields that have no equivalent and were dropped.
+type ConvertFunc func(doc map[string]any) ([]string, error)
+
+// DeprecatedField is a field a kind still accepts at its hub version but
+// that will be removed. Path and Replacement are dotted paths such as
+// spec.nvim.package.
+type DeprecatedField struct {
+	Path        string
+	Replacement string // where the value moves to; empty when it is dropped
+	Message     string // optional extra guidance
+}
+
+type kindScheme struct {
+	hub         string
+	typ         reflect.Type // what the kind's handler parses, for strict decoding
+	conversions map[string]ConvertFunc
+	deprecated  []DeprecatedField
+}
+
+// Scheme holds the apiVersions, conversions, and deprecations of each kind.
+// Kinds without a hub version are passed through untouched.
+type Scheme struct {
+	mu    sync.RWMutex
+	kinds map[string]*kindScheme
+}
+
+// NewScheme creates an empty Scheme.
+func NewScheme() *Scheme {
+	return &Scheme{kinds: map[string]*kindScheme{}}
+}
+
+// defaultScheme is the Scheme applied in front of the registered handlers.
+var defaultScheme = NewScheme()
+
+// DefaultScheme returns the Scheme RegisterAll applies before every handler.
+func DefaultScheme() *Scheme {
+	return defaultScheme
+}
+
+func (s *Scheme) kind(kind string) *kindScheme {
+	ks, ok := s.kinds[kind]
+	if !ok {
+		ks = &kindScheme{conversions: map[string]ConvertFunc{}}
+		s.kinds[kind] = ks
+	}
+	return ks
+}
+
+// AddKind declares the hub apiVersion of kind.
+func (s *Scheme) AddKind(kind, hub string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kind(kind).hub = hub
+}
+
+// AddConversion registers convert for documents of kind at apiVersion from.
+// A nil convert means the version's schema is the same as the hub's.
+func (s *Scheme) AddConversion(kind, from string, convert ConvertFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kind(kind).conversions[from] = convert
+}
+
+// AddDeprecatedField registers a deprecated field of kind.
+func (s *Scheme) AddDeprecatedField(kind string, f DeprecatedField) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ks := s.kind(kind)
+	ks.deprecated = append(ks.deprecated, f)
+}
+
+// APIVersions returns the apiVersions kind accepts, hub first, or nil for a
+// kind the Scheme doesn't know.
+func (s *Scheme) APIVersions(kind string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ks, ok := s.kinds[kind]
+	if !ok || ks.hub == "" {
+		return nil
+	}
+	versions := []string{ks.hub}
+	for v := range ks.conversions {
+		versions = append(versions, v)
+	}
+	slices.Sort(versions[1:])
+	return versions
+}
+
+// Convert returns data converted to its kind's hub apiVersion, with
+// deprecated fields moved to their replacements, and warnings describing
+// what changed. A document without an apiVersion is taken to be at the hub.
+// Data that needs no change is returned as is; documents that don't parse
+// are left to the handler to report.
+func (s *Scheme) Convert(data []byte) ([]byte, []string, error) {
+	var head struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+	}
+	if err := yaml.Unmarshal(data, &head); err != nil || head.Kind == "" {
+		return data, nil, nil
+	}
+
+	s.mu.RLock()
+	ks, ok := s.kinds[head.Kind]
+	var hub string
+	var convert ConvertFunc
+	var known bool
+	var deprecated []DeprecatedField
+	if ok {
+		hub = ks.hub
+		convert, known = ks.conversions[head.APIVersion]
+		deprecated = slices.Clone(ks.deprecated)
+	}
+	s.mu.RUnlock()
+	if hub == "" {
+		return data, nil, nil
+	}
+
+	atHub := head.APIVersion == "" || head.APIVersion == hub
+	if !atHub && !known {
+		return nil, nil, fmt.Errorf("apiVersion %q is not supported for kind %s (supported: %s)",
+			head.APIVersion, head.Kind, strings.Join(s.APIVersions(head.Kind), ", "))
+	}
+	if atHub && !hasAnyField(data, deprecated) {
+		return data, nil, nil
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return data, nil, nil
+	}
+	var warnings []string
+	if !atHub {
+		if convert != nil {
+			w, err := convert(doc)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to convert %s from %s to %s: %w", head.Kind, head.APIVersion, hub, err)
+			}
+			warnings = append(warnings, w...)
+		}
+		doc["apiVersion"] = hub
+	}
+	for _, f := range deprecated {
+		value, ok := getField(doc, f.Path)
+		if !ok {
+			continue
+		}
+		deleteField(doc, f.Path)
+		msg := fmt.Sprintf("%s %s: %s is deprecated", head.Kind, nameOf(doc), f.Path)
+		if f.Replacement != "" {
+			if _, set := getField(doc, f.Replacement); set {
+				msg += fmt.Sprintf(" and ignored because %s is set", f.Replacement)
+			} else {
+				setField(doc, f.Replacement, value)
+				msg += fmt.Sprintf("; use %s", f.Replacement)
+			}
+		} else {
+			msg += " and ignored"
+		}
+		if f.Message != "" {
+			msg += " (" + f.Message + ")"
+		}
+		warnings = append(warnings, msg)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode converted %s: %w", head.Kind, err)
+	}
+	return out, warnings, nil
+}
+
+// hasAnyField reports whether data sets any of the fields, without a full
+// decode for the common case of a manifest that uses none.
+func hasAnyField(data []byte, fields []DeprecatedField) bool {
+	if len(fields) == 0 {
+		return false
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	for _, f := range fields {
+		if _, ok := getField(doc, f.Path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// nameOf returns the quoted metadata.name of doc, or "" when it has none.
+func nameOf(doc map[string]any) string {
+	name, ok := getField(doc, "metadata.name")
+	if !ok {
+		return `""`
+	}
+	return fmt.Sprintf("%q", fmt.Sprint(name))
+}
+
+func getField(doc map[string]any, path string) (any, bool) {
+	parts := strings.Split(path, ".")
+	cur := doc
+	for i, p := range parts {
+		v, ok := cur[p]
+		if !ok {
+			return nil, false
+		}
+		if i == len(parts)-1 {
+			return v, true
+		}
+		if cur, ok = v.(map[string]any); !ok {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func setField(doc map[string]any, path string, value any) {
+	parts := strings.Split(path, ".")
+	cur := doc
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := cur[p].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			cur[p] = next
+		}
+		cur = next
+	}
+	cur[parts[len(parts)-1]] = value
+}
+
+func deleteField(doc map[string]any, path string) {
+	parts := strings.Split(path, ".")
+	cur := doc
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := cur[p].(map[string]any)
+		if !ok {
+			return
+		}
+		cur = next
+	}
+	delete(cur, parts[len(parts)-1])
+}
+
+// warningHandler receives conversion warnings.
+var warningHandler = func(msg string) {
+	slog.Warn(msg)
+}
+
+// SetWarningHandler sets where conversion warnings go; the CLI prints them.
+func SetWarningHandler(fn func(msg string)) {
+	warningHandler = fn
+}
+
+var registerKindsOnce sync.Once
+
+// registerDefaultKinds declares the hub apiVersion of the built-in kinds and
+// the types their handlers parse.
+// Conversions and deprecations are added here as kinds evolve.
+func registerDefaultKinds() {
+	registerKindsOnce.Do(func() {
+		for _, kind := range []string{
+			KindNvimPlugin, KindNvimTheme, KindNvimPackage,
+			KindEcosystem, KindDomain, KindSystem, KindApp, KindWorkspace,
+			KindTerminalPrompt, KindTerminalPackage, KindTerminalPlugin,
+			KindRegistry, KindCredential, KindGitRepo, KindBuildTemplate, KindGlobalDefaults,
+		} {
+			defaultScheme.AddKind(kind, HubAPIVersion)
+		}
+		defaultScheme.AddKind(KindCRD, "devopsmaestro.io/v1alpha1")
+
+		for kind, v := range map[string]any{
+			KindNvimPlugin:      plugin.PluginYAML{},
+			KindNvimTheme:       theme.ThemeYAML{},
+			KindNvimPackage:     nvimpkg.PackageYAML{},
+			KindEcosystem:       models.EcosystemYAML{},
+			KindDomain:          models.DomainYAML{},
+			KindSystem:          models.SystemYAML{},
+			KindApp:             models.AppYAML{},
+			KindWorkspace:       models.WorkspaceYAML{},
+			KindTerminalPrompt:  prompt.PromptYAML{},
+			KindTerminalPackage: terminalpkg.PackageYAML{},
+			KindTerminalPlugin:  models.TerminalPluginYAML{},
+			KindRegistry:        models.RegistryYAML{},
+			KindCredential:      models.CredentialYAML{},
+			KindGitRepo:         models.GitRepoYAML{},
+			KindBuildTemplate:   models.BuildTemplateYAML{},
+			KindGlobalDefaults:  globalDefaultsYAML{},
+			KindCRD:             models.CRDYAML{},
+		} {
+			defaultScheme.AddType(kind, v)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"github.com/rmkohlman/MaestroSDK/resource"
+
+	"gopkg.in/yaml.v3"
+)
+
+func newTestScheme() *Scheme {
+	s := NewScheme()
+	s.AddKind(KindWorkspace, HubAPIVersion)
+	// v1alpha1 kept the image name at spec.imageName
+	s.AddConversion(KindWorkspace, "devopsmaestro.io/v1alpha1", func(doc map[string]any) ([]string, error) {
+		spec, _ := doc["spec"].(map[string]any)
+		if name, ok := spec["imageName"]; ok {
+			delete(spec, "imageName")
+			spec["image"] = map[string]any{"name": name}
+		}
+		return nil, nil
+	})
+	s.AddConversion(KindWorkspace, "devopsmaestro.io/v1beta1", nil)
+	s.AddDeprecatedField(KindWorkspace, DeprecatedField{Path: "spec.nvim.package", Replacement: "spec.nvim.pluginPackage"})
+	s.AddDeprecatedField(KindWorkspace, DeprecatedField{Path: "spec.legacy", Message: "it had no effect"})
+	return s
+}
+
+func TestScheme_Convert_OlderVersion(t *testing.T) {
+	s := newTestScheme()
+
+	out, warnings, err := s.Convert([]byte(`
+apiVersion: devopsmaestro.io/v1alpha1
+kind: Workspace
+metadata:
+  name: dev
+spec:
+  imageName: ubuntu:22.04
+`))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Convert() warnings = %v, want none", warnings)
+	}
+	var doc struct {
+		APIVersion string `yaml:"apiVersion"`
+		Spec       struct {
+			Image struct {
+				Name string `yaml:"name"`
+			} `yaml:"image"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("converted YAML does not parse: %v", err)
+	}
+	if doc.APIVersion != HubAPIVersion {
+		t.Errorf("apiVersion = %q, want %q", doc.APIVersion, HubAPIVersion)
+	}
+	if doc.Spec.Image.Name != "ubuntu:22.04" {
+		t.Errorf("spec.image.name = %q, want ubuntu:22.04", doc.Spec.Image.Name)
+	}
+}
+
+func TestScheme_Convert_Unchanged(t *testing.T) {
+	s := newTestScheme()
+
+	for _, data := range []string{
+		"apiVersion: devopsmaestro.io/v1\nkind: Workspace\nmetadata:\n  name: dev\n",
+		"kind: Workspace\nmetadata:\n  name: dev\n",
+		"apiVersion: other.io/v9\nkind: Unregistered\n",
+	} {
+		out, warnings, err := s.Convert([]byte(data))
+		if err != nil || len(warnings) != 0 || string(out) != data {
+			t.Errorf("Convert(%q) = %q, %v, %v; want it unchanged", data, out, warnings, err)
+		}
+	}
+}
+
+func TestScheme_Convert_UnsupportedVersion(t *testing.T) {
+	s := newTestScheme()
+
+	_, _, err := s.Convert([]byte("apiVersion: devopsmaestro.io/v2\nkind: Workspace\nmetadata:\n  name: dev\n"))
+	want := `apiVersion "devopsmaestro.io/v2" is not supported for kind Workspace (supported: devopsmaestro.io/v1, devopsmaestro.io/v1alpha1, devopsmaestro.io/v1beta1)`
+	if err == nil || err.Error() != want {
+		t.Errorf("Convert() error = %v, want %s", err, want)
+	}
+}
+
+func TestScheme_Convert_DeprecatedFields(t *testing.T) {
+	s := newTestScheme()
+
+	out, warnings, err := s.Convert([]byte(`
+apiVersion: devopsmaestro.io/v1beta1
+kind: Workspace
+metadata:
+  name: dev
+spec:
+  legacy: true
+  nvim:
+    package: core
+`))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := []string{
+		`Workspace "dev": spec.nvim.package is deprecated; use spec.nvim.pluginPackage`,
+		`Workspace "dev": spec.legacy is deprecated and ignored (it had no effect)`,
+	}
+	if strings.Join(warnings, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Convert() warnings = %q, want %q", warnings, want)
+	}
+	if !strings.Contains(string(out), "pluginPackage: core") || strings.Contains(string(out), "legacy") {
+		t.Errorf("converted YAML = %s", out)
+	}
+
+	// A replacement that is already set wins
+	_, warnings, _ = s.Convert([]byte("kind: Workspace\nmetadata:\n  name: dev\nspec:\n  nvim:\n    package: old\n    pluginPackage: new\n"))
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "ignored because spec.nvim.pluginPackage is set") {
+		t.Errorf("Convert() warnings = %q", warnings)
+	}
+}
+
+func TestRegisterAll_RejectsUnsupportedAPIVersion(t *testing.T) {
+	RegisterAll()
+	ctx := resource.Context{DataStore: db.NewMockDataStore()}
+
+	_, err := resource.Apply(ctx, []byte("apiVersion: devopsmaestro.io/v2\nkind: Ecosystem\nmetadata:\n  name: platform\n"), "test")
+	if err == nil || !strings.Contains(err.Error(), "not supported for kind Ecosystem") {
+		t.Errorf("Apply() error = %v, want unsupported apiVersion", err)
+	}
+	var ae *AdmissionError
+	if errors.As(err, &ae) {
+		t.Errorf("Apply() error is an admission error, want a conversion error")
+	}
+	if got := DefaultScheme().APIVersions(KindCRD); len(got) != 1 || got[0] != "devopsmaestro.io/v1alpha1" {
+		t.Errorf("APIVersions(CRD) = %v", got)
+	}
+}
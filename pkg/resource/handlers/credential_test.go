@@ -907,6 +907,7 @@ func createCredentialTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args  TEXT,
 			ca_certs    TEXT,
+			env         TEXT,
 			created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP
 		)`,
@@ -921,6 +922,7 @@ func createCredentialTestSchema(driver db.Driver) error {
 			terminal_package TEXT,
 			build_args   TEXT,
 			ca_certs     TEXT,
+			env          TEXT,
 			created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
 			UNIQUE(ecosystem_id, name)
@@ -939,6 +941,7 @@ func createCredentialTestSchema(driver db.Driver) error {
 			language     TEXT,
 			build_config TEXT,
 			git_repo_id  INTEGER,
+			env          TEXT,
 			created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
 			updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
 			UNIQUE(domain_id, name)
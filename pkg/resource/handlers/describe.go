@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+	themeresolver "devopsmaestro/pkg/colors/resolver"
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+// Describer is implemented by handlers that back 'dvm describe'. Describe
+// summarizes res, which must come from the same handler, together with the
+// resources around it.
+type Describer interface {
+	Describe(ctx resource.Context, res resource.Resource) (*Description, error)
+}
+
+// Description is a human-oriented view of one resource: its own fields
+// followed by sections for parents, children, and related state.
+type Description struct {
+	Kind     string               `json:"kind" yaml:"kind"`
+	Name     string               `json:"name" yaml:"name"`
+	Sections []DescriptionSection `json:"sections" yaml:"sections"`
+}
+
+// DescriptionSection is one titled block of a Description. It holds either
+// key-value Fields or a table (Headers and Rows). An empty table is shown
+// as None.
+type DescriptionSection struct {
+	Title   string             `json:"title" yaml:"title"`
+	Fields  []DescriptionField `json:"fields,omitempty" yaml:"fields,omitempty"`
+	Headers []string           `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Rows    [][]string         `json:"rows,omitempty" yaml:"rows,omitempty"`
+	None    string             `json:"-" yaml:"-"`
+}
+
+// DescriptionField is one key-value line of a section.
+type DescriptionField struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Section returns the section with the given title, or nil.
+func (d *Description) Section(title string) *DescriptionSection {
+	for i := range d.Sections {
+		if d.Sections[i].Title == title {
+			return &d.Sections[i]
+		}
+	}
+	return nil
+}
+
+// Field returns the value of key in the section, or "".
+func (s *DescriptionSection) Field(key string) string {
+	for _, f := range s.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Describe summarizes res using its kind's handler. It fails when the
+// handler does not implement Describer.
+func Describe(ctx resource.Context, res resource.Resource) (*Description, error) {
+	h := resource.GetHandler(res.GetKind())
+	if h == nil {
+		return nil, fmt.Errorf("no handler registered for kind %q", res.GetKind())
+	}
+	d, ok := unwrapHandler(h).(Describer)
+	if !ok {
+		return nil, fmt.Errorf("describe is not supported for %s", res.GetKind())
+	}
+	return d.Describe(ctx, res)
+}
+
+// describeTimeLayout is the timestamp format used in descriptions.
+const describeTimeLayout = "2006-01-02 15:04:05"
+
+// orNone returns s, or "<none>" when s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// nullString returns the string of a NullString, or "".
+func nullString(s sql.NullString) string {
+	if s.Valid {
+		return s.String
+	}
+	return ""
+}
+
+// describeTime formats t, or returns "" for the zero time.
+func describeTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format(describeTimeLayout)
+}
+
+// describeParents returns the Parents section for the given hierarchy
+// levels. Only the levels that exist are listed, outermost first.
+func describeParents(eco *models.Ecosystem, dom *models.Domain, sys *models.System, app *models.App) DescriptionSection {
+	sec := DescriptionSection{Title: "Parents", None: "<none>"}
+	if eco != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "Ecosystem", Value: eco.Name})
+	}
+	if dom != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "Domain", Value: dom.Name})
+	}
+	if sys != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "System", Value: sys.Name})
+	}
+	if app != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "App", Value: app.Name})
+	}
+	return sec
+}
+
+// domainParents loads a domain's ecosystem. Missing parents are nil.
+func domainParents(ds db.DataStore, dom *models.Domain) *models.Ecosystem {
+	if dom == nil || !dom.EcosystemID.Valid {
+		return nil
+	}
+	eco, err := ds.GetEcosystemByID(int(dom.EcosystemID.Int64))
+	if err != nil {
+		return nil
+	}
+	return eco
+}
+
+// appParents loads an app's domain, system, and ecosystem. Missing parents
+// are nil.
+func appParents(ds db.DataStore, app *models.App) (*models.Ecosystem, *models.Domain, *models.System) {
+	var dom *models.Domain
+	var sys *models.System
+	if app.DomainID.Valid {
+		dom, _ = ds.GetDomainByID(int(app.DomainID.Int64))
+	}
+	if app.SystemID.Valid {
+		sys, _ = ds.GetSystemByID(int(app.SystemID.Int64))
+	}
+	return domainParents(ds, dom), dom, sys
+}
+
+// describeTheme returns the Theme section: the effective theme and the
+// hierarchy levels that were consulted.
+func describeTheme(ds db.DataStore, level themeresolver.HierarchyLevel, objectID int) DescriptionSection {
+	sec := DescriptionSection{Title: "Theme"}
+	r, err := themeresolver.NewThemeResolver(ds, nil)
+	if err != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "Effective", Value: fmt.Sprintf("unavailable (%v)", err)})
+		return sec
+	}
+	resolution, err := r.GetResolutionPath(context.Background(), level, objectID)
+	if err != nil {
+		sec.Fields = append(sec.Fields, DescriptionField{Key: "Effective", Value: fmt.Sprintf("unavailable (%v)", err)})
+		return sec
+	}
+
+	if resolution.Source == themeresolver.LevelGlobal {
+		sec.Fields = append(sec.Fields,
+			DescriptionField{Key: "Effective", Value: themeresolver.DefaultTheme + " (default)"},
+			DescriptionField{Key: "Source", Value: "global default"})
+	} else {
+		sec.Fields = append(sec.Fields,
+			DescriptionField{Key: "Effective", Value: resolution.GetEffectiveThemeName()},
+			DescriptionField{Key: "Source", Value: resolution.GetSourceDescription()})
+	}
+	for _, step := range resolution.Path {
+		value := "inherits"
+		if step.Found && step.ThemeName != "" {
+			value = step.ThemeName
+		}
+		sec.Fields = append(sec.Fields, DescriptionField{
+			Key:   fmt.Sprintf("  %s %s", step.Level.String(), step.Name),
+			Value: value,
+		})
+	}
+	return sec
+}
+
+// describeWorkspaceRows returns one table row per workspace of an app.
+func describeWorkspaceRows(ds db.DataStore, appID int) [][]string {
+	workspaces, err := ds.ListWorkspacesByApp(appID)
+	if err != nil {
+		return nil
+	}
+	rows := make([][]string, 0, len(workspaces))
+	for _, w := range workspaces {
+		rows = append(rows, []string{w.Name, w.Status, orNone(w.ImageName)})
+	}
+	return rows
+}
+
+// gitRepoName returns the name of the git repo with the given ID, or "".
+func gitRepoName(ds db.DataStore, id sql.NullInt64) string {
+	if !id.Valid {
+		return ""
+	}
+	repo, err := ds.GetGitRepoByID(id.Int64)
+	if err != nil || repo == nil {
+		return ""
+	}
+	return repo.Name
+}
+
+// describeEvent is one entry of the Events section.
+type describeEvent struct {
+	At    time.Time
+	Event string
+}
+
+// describeEvents returns the Events section, newest first. Events without a
+// time are dropped.
+func describeEvents(events []describeEvent) DescriptionSection {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.After(events[j].At) })
+	sec := DescriptionSection{Title: "Events", Headers: []string{"TIME", "EVENT"}, None: "<none>"}
+	for _, e := range events {
+		if e.At.IsZero() {
+			continue
+		}
+		sec.Rows = append(sec.Rows, []string{describeTime(e.At), e.Event})
+	}
+	return sec
+}
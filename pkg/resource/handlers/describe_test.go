@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"devopsmaestro/models"
+)
+
+func TestWorkspaceHandler_Describe(t *testing.T) {
+	RegisterAll()
+	store, _, _, _, _, _, _, app1, _ := setupMoveStore(t)
+
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	ws := &models.Workspace{
+		Name:      "dev",
+		AppID:     app1.ID,
+		ImageName: "dvm-dev-app1:1",
+		Status:    "running",
+		CreatedAt: created,
+		UpdatedAt: created,
+	}
+	must(t, store.CreateWorkspace(ws))
+	store.WorkspaceLastAttached = map[int]time.Time{ws.ID: created.Add(2 * time.Hour)}
+
+	session := &models.BuildSession{ID: "s1", StartedAt: created.Add(time.Hour), Status: "completed"}
+	must(t, store.CreateBuildSession(session))
+	must(t, store.CreateBuildSessionWorkspace(&models.BuildSessionWorkspace{
+		SessionID:       "s1",
+		WorkspaceID:     ws.ID,
+		Status:          "failed",
+		StartedAt:       sql.NullTime{Time: created.Add(time.Hour), Valid: true},
+		DurationSeconds: sql.NullInt64{Int64: 42, Valid: true},
+		ErrorMessage:    sql.NullString{String: "exit status 1", Valid: true},
+	}))
+
+	desc, err := Describe(newCtx(store), NewWorkspaceResource(ws, app1.Name, "dom1", "", "eco1"))
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+
+	parents := desc.Section("Parents")
+	if parents == nil {
+		t.Fatal("missing Parents section")
+	}
+	for key, want := range map[string]string{"Ecosystem": "eco1", "Domain": "dom1", "System": "sys1", "App": "app1"} {
+		if got := parents.Field(key); got != want {
+			t.Errorf("Parents %s = %q, want %q", key, got, want)
+		}
+	}
+
+	if got := desc.Section("Container").Field("Status"); got != "running" {
+		t.Errorf("Container Status = %q, want running", got)
+	}
+
+	build := desc.Section("Last Build")
+	if build.Field("Status") != "failed" || build.Field("Duration") != "42s" || build.Field("Error") != "exit status 1" {
+		t.Errorf("Last Build = %+v", build.Fields)
+	}
+
+	if got := desc.Section("Theme").Field("Effective"); got == "" {
+		t.Error("Theme section has no effective theme")
+	}
+
+	events := desc.Section("Events")
+	var names []string
+	for _, row := range events.Rows {
+		names = append(names, row[1])
+	}
+	if got := strings.Join(names, ","); got != "Attached,Build started,Created" {
+		t.Errorf("Events = %s, want newest first", got)
+	}
+}
+
+func TestDomainHandler_Describe(t *testing.T) {
+	RegisterAll()
+	store, _, _, dom1, _, _, _, app1, _ := setupMoveStore(t)
+	must(t, store.CreateWorkspace(&models.Workspace{Name: "dev", AppID: app1.ID}))
+
+	desc, err := Describe(newCtx(store), NewDomainResource(dom1, "eco1"))
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	if got := desc.Section("Parents").Field("Ecosystem"); got != "eco1" {
+		t.Errorf("Parents Ecosystem = %q, want eco1", got)
+	}
+	apps := desc.Section("Apps")
+	if len(apps.Rows) != 1 || strings.Join(apps.Rows[0], ",") != "app1,sys1,1" {
+		t.Errorf("Apps rows = %v", apps.Rows)
+	}
+}
+
+func TestEcosystemHandler_Describe(t *testing.T) {
+	RegisterAll()
+	store, eco1, _, _, _, _, _, _, _ := setupMoveStore(t)
+
+	desc, err := Describe(newCtx(store), NewEcosystemResource(eco1))
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	domains := desc.Section("Domains")
+	if len(domains.Rows) != 1 || strings.Join(domains.Rows[0], ",") != "dom1,1,1" {
+		t.Errorf("Domains rows = %v", domains.Rows)
+	}
+}
+
+func TestDescribe_Unsupported(t *testing.T) {
+	RegisterAll()
+	store, _, _, _, _, sys1, _, _, _ := setupMoveStore(t)
+	_, err := Describe(newCtx(store), NewSystemResource(sys1, "dom1", "eco1"))
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("Describe(System) error = %v, want not supported", err)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+func TestAppHandler_Apply_DockerfileHooks(t *testing.T) {
+	h := NewAppHandler()
+	store, _, _ := setupAppTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: App
+metadata:
+  name: hooked-app
+  domain: app-domain
+spec:
+  path: /home/user/hooked
+  build:
+    hooks:
+      preBase: |
+        COPY certs/ /usr/local/share/ca-certificates/
+        RUN update-ca-certificates
+`)
+
+	res, err := h.Apply(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	cfg := res.(*AppResource).App().GetBuildConfig()
+	if cfg == nil || cfg.Hooks == nil || !strings.Contains(cfg.Hooks.PreBase, "update-ca-certificates") {
+		t.Fatalf("GetBuildConfig().Hooks = %+v, want preBase stored", cfg)
+	}
+
+	out, err := h.ToYAML(res)
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(out), "preBase:") {
+		t.Errorf("ToYAML() lost spec.build.hooks:\n%s", out)
+	}
+}
+
+func TestAppHandler_Apply_InvalidDockerfileHook(t *testing.T) {
+	h := NewAppHandler()
+	store, _, _ := setupAppTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: App
+metadata:
+  name: hooked-app
+  domain: app-domain
+spec:
+  path: /home/user/hooked
+  build:
+    hooks:
+      postTools: "FROM alpine"
+`)
+
+	_, err := h.Apply(ctx, yamlData)
+	if err == nil || !strings.Contains(err.Error(), "app hooked-app: spec.build.hooks.postTools: line 1: FROM is not allowed") {
+		t.Fatalf("Apply() error = %v, want invalid hook error", err)
+	}
+}
+
+func TestWorkspaceHandler_Apply_InvalidDockerfileHook(t *testing.T) {
+	h := NewWorkspaceHandler()
+	store, _, _, _ := setupWorkspaceTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: test-app
+spec:
+  build:
+    hooks:
+      postNvim: "HEALTHCHECK CMD true"
+`)
+
+	_, err := h.Apply(ctx, yamlData)
+	if err == nil || !strings.Contains(err.Error(), `spec.build.hooks.postNvim: line 1: unsupported instruction "HEALTHCHECK"`) {
+		t.Fatalf("Apply() error = %v, want invalid hook error", err)
+	}
+}
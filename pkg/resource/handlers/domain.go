@@ -6,6 +6,8 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	themeresolver "devopsmaestro/pkg/colors/resolver"
+	"devopsmaestro/pkg/envresolver"
 	"github.com/rmkohlman/MaestroSDK/resource"
 
 	"gopkg.in/yaml.v3"
@@ -32,6 +34,9 @@ func (h *DomainHandler) Apply(ctx resource.Context, data []byte) (resource.Resou
 	if err := yaml.Unmarshal(data, &domainYAML); err != nil {
 		return nil, fmt.Errorf("failed to parse domain YAML: %w", err)
 	}
+	if err := envresolver.Validate(domainYAML.Spec.Env); err != nil {
+		return nil, fmt.Errorf("domain %s: spec.env: %w", domainYAML.Metadata.Name, err)
+	}
 
 	// Get the datastore
 	ds, err := resource.DataStoreAs[db.DataStore](ctx)
@@ -178,6 +183,55 @@ func (h *DomainHandler) Delete(ctx resource.Context, name string) error {
 	return ds.DeleteDomain(domain.ID)
 }
 
+// Describe summarizes a domain: its ecosystem, systems, apps, and theme
+// resolution.
+func (h *DomainHandler) Describe(ctx resource.Context, res resource.Resource) (*Description, error) {
+	dr, ok := res.(*DomainResource)
+	if !ok {
+		return nil, fmt.Errorf("expected DomainResource, got %T", res)
+	}
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil, err
+	}
+	dom := dr.domain
+
+	d := &Description{Kind: KindDomain, Name: dom.Name}
+	d.Sections = append(d.Sections, DescriptionSection{Title: KindDomain, Fields: []DescriptionField{
+		{Key: "Name", Value: dom.Name},
+		{Key: "Description", Value: orNone(nullString(dom.Description))},
+		{Key: "Created", Value: describeTime(dom.CreatedAt)},
+		{Key: "Updated", Value: describeTime(dom.UpdatedAt)},
+	}})
+	d.Sections = append(d.Sections, describeParents(domainParents(ds, dom), nil, nil, nil))
+
+	systems := DescriptionSection{Title: "Systems", Headers: []string{"NAME", "DESCRIPTION"}, None: "<none>"}
+	systemNames := map[int64]string{}
+	if list, err := ds.ListSystemsByDomain(dom.ID); err == nil {
+		for _, s := range list {
+			systemNames[int64(s.ID)] = s.Name
+			systems.Rows = append(systems.Rows, []string{s.Name, nullString(s.Description)})
+		}
+	}
+	d.Sections = append(d.Sections, systems)
+
+	apps := DescriptionSection{Title: "Apps", Headers: []string{"NAME", "SYSTEM", "WORKSPACES"}, None: "<none>"}
+	if list, err := ds.ListAppsByDomain(dom.ID); err == nil {
+		for _, a := range list {
+			system := ""
+			if a.SystemID.Valid {
+				system = systemNames[a.SystemID.Int64]
+			}
+			apps.Rows = append(apps.Rows, []string{a.Name, orNone(system), fmt.Sprint(len(describeWorkspaceRows(ds, a.ID)))})
+		}
+	}
+	d.Sections = append(d.Sections, apps)
+
+	d.Sections = append(d.Sections, describeTheme(ds, themeresolver.LevelDomain, dom.ID))
+
+	return d, nil
+}
+
 // ToYAML serializes a domain to YAML.
 func (h *DomainHandler) ToYAML(res resource.Resource) ([]byte, error) {
 	dr, ok := res.(*DomainResource)
@@ -6,6 +6,8 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	themeresolver "devopsmaestro/pkg/colors/resolver"
+	"devopsmaestro/pkg/envresolver"
 	"github.com/rmkohlman/MaestroSDK/resource"
 
 	"gopkg.in/yaml.v3"
@@ -32,10 +34,16 @@ func (h *EcosystemHandler) Apply(ctx resource.Context, data []byte) (resource.Re
 	if err := yaml.Unmarshal(data, &ecosystemYAML); err != nil {
 		return nil, fmt.Errorf("failed to parse ecosystem YAML: %w", err)
 	}
+	if err := envresolver.Validate(ecosystemYAML.Spec.Env); err != nil {
+		return nil, fmt.Errorf("ecosystem %s: spec.env: %w", ecosystemYAML.Metadata.Name, err)
+	}
 
 	// Convert to model
 	ecosystem := &models.Ecosystem{}
 	ecosystem.FromYAML(ecosystemYAML)
+	if err := (&EcosystemResource{ecosystem: ecosystem}).Validate(); err != nil {
+		return nil, err
+	}
 
 	// Get the datastore
 	ds, err := resource.DataStoreAs[db.EcosystemStore](ctx)
@@ -44,10 +52,15 @@ func (h *EcosystemHandler) Apply(ctx resource.Context, data []byte) (resource.Re
 	}
 
 	// Check if ecosystem exists
-	existing, _ := ds.GetEcosystemByName(ecosystem.Name)
+	existing, err := ds.GetEcosystemByName(ecosystem.Name)
+	if err != nil && !db.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to look up ecosystem: %w", err)
+	}
 	if existing != nil {
-		// Update existing
+		// Update existing, keeping its ID and creation time so re-applying
+		// the same file is a no-op
 		ecosystem.ID = existing.ID
+		ecosystem.CreatedAt = existing.CreatedAt
 		if err := ds.UpdateEcosystem(ecosystem); err != nil {
 			return nil, fmt.Errorf("failed to update ecosystem: %w", err)
 		}
@@ -56,11 +69,12 @@ func (h *EcosystemHandler) Apply(ctx resource.Context, data []byte) (resource.Re
 		if err := ds.CreateEcosystem(ecosystem); err != nil {
 			return nil, fmt.Errorf("failed to create ecosystem: %w", err)
 		}
-		// Fetch to get the ID
-		ecosystem, err = ds.GetEcosystemByName(ecosystem.Name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to retrieve created ecosystem: %w", err)
-		}
+	}
+
+	// Fetch to return the stored state, with its ID and timestamps
+	ecosystem, err = ds.GetEcosystemByName(ecosystem.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve applied ecosystem: %w", err)
 	}
 
 	return &EcosystemResource{ecosystem: ecosystem}, nil
@@ -117,6 +131,42 @@ func (h *EcosystemHandler) Delete(ctx resource.Context, name string) error {
 	return ds.DeleteEcosystem(name)
 }
 
+// Describe summarizes an ecosystem: its domains with their system and app
+// counts, and its theme resolution.
+func (h *EcosystemHandler) Describe(ctx resource.Context, res resource.Resource) (*Description, error) {
+	er, ok := res.(*EcosystemResource)
+	if !ok {
+		return nil, fmt.Errorf("expected EcosystemResource, got %T", res)
+	}
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil, err
+	}
+	eco := er.ecosystem
+
+	d := &Description{Kind: KindEcosystem, Name: eco.Name}
+	d.Sections = append(d.Sections, DescriptionSection{Title: KindEcosystem, Fields: []DescriptionField{
+		{Key: "Name", Value: eco.Name},
+		{Key: "Description", Value: orNone(nullString(eco.Description))},
+		{Key: "Created", Value: describeTime(eco.CreatedAt)},
+		{Key: "Updated", Value: describeTime(eco.UpdatedAt)},
+	}})
+
+	domains := DescriptionSection{Title: "Domains", Headers: []string{"NAME", "SYSTEMS", "APPS"}, None: "<none>"}
+	if list, err := ds.ListDomainsByEcosystem(eco.ID); err == nil {
+		for _, dom := range list {
+			systems, _ := ds.ListSystemsByDomain(dom.ID)
+			apps, _ := ds.ListAppsByDomain(dom.ID)
+			domains.Rows = append(domains.Rows, []string{dom.Name, fmt.Sprint(len(systems)), fmt.Sprint(len(apps))})
+		}
+	}
+	d.Sections = append(d.Sections, domains)
+
+	d.Sections = append(d.Sections, describeTheme(ds, themeresolver.LevelEcosystem, eco.ID))
+
+	return d, nil
+}
+
 // ToYAML serializes an ecosystem to YAML.
 func (h *EcosystemHandler) ToYAML(res resource.Resource) ([]byte, error) {
 	er, ok := res.(*EcosystemResource)
@@ -98,6 +98,68 @@ spec: {}
 	}
 }
 
+func TestEcosystemHandler_Apply_Idempotent(t *testing.T) {
+	h := NewEcosystemHandler()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata:
+  name: eco-idem
+  annotations:
+    description: Platform team
+spec:
+  theme: catppuccin-mocha
+`)
+
+	first, err := h.Apply(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("Apply() first error = %v", err)
+	}
+	second, err := h.Apply(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("Apply() second error = %v", err)
+	}
+
+	a := first.(*EcosystemResource).Ecosystem()
+	b := second.(*EcosystemResource).Ecosystem()
+	if a.ID != b.ID {
+		t.Errorf("re-apply ID = %d, want %d", b.ID, a.ID)
+	}
+	if b.Theme.String != "catppuccin-mocha" {
+		t.Errorf("re-apply Theme = %q, want %q", b.Theme.String, "catppuccin-mocha")
+	}
+	if b.Description.String != "Platform team" {
+		t.Errorf("re-apply Description = %q, want %q", b.Description.String, "Platform team")
+	}
+	ecosystems, _ := store.ListEcosystems()
+	if len(ecosystems) != 1 {
+		t.Errorf("ListEcosystems() = %d ecosystems, want 1", len(ecosystems))
+	}
+}
+
+func TestEcosystemHandler_Apply_MissingName(t *testing.T) {
+	h := NewEcosystemHandler()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+
+	_, err := h.Apply(ctx, []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata: {}
+spec:
+  theme: tokyonight
+`))
+	if err == nil || !strings.Contains(err.Error(), "name is required") {
+		t.Errorf("Apply() error = %v, want name is required", err)
+	}
+	if ecosystems, _ := store.ListEcosystems(); len(ecosystems) != 0 {
+		t.Errorf("Apply() stored %d ecosystems, want 0", len(ecosystems))
+	}
+}
+
 func TestEcosystemHandler_Apply_InvalidYAML(t *testing.T) {
 	h := NewEcosystemHandler()
 	store := db.NewMockDataStore()
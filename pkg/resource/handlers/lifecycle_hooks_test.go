@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+func TestAppHandler_Apply_LifecycleHooks(t *testing.T) {
+	h := NewAppHandler()
+	store, _, _ := setupAppTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: App
+metadata:
+  name: hooked-app
+  domain: app-domain
+spec:
+  path: /home/user/hooked
+  hooks:
+    postStart:
+      - name: migrate
+        run: make migrate
+        on: container
+        onFailure: warn
+`)
+
+	res, err := h.Apply(ctx, yamlData)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	hooks := res.(*AppResource).App().GetLifecycleHooks()
+	if len(hooks.PostStart) != 1 || hooks.PostStart[0].Run != "make migrate" {
+		t.Fatalf("GetLifecycleHooks() = %+v, want postStart stored", hooks)
+	}
+
+	out, err := h.ToYAML(res)
+	if err != nil {
+		t.Fatalf("ToYAML() error = %v", err)
+	}
+	if !strings.Contains(string(out), "postStart:") || strings.Contains(string(out), "build:") {
+		t.Errorf("ToYAML() = \n%s\nwant spec.hooks and no spec.build", out)
+	}
+}
+
+func TestWorkspaceHandler_Apply_InvalidLifecycleHook(t *testing.T) {
+	h := NewWorkspaceHandler()
+	store, _, _, _ := setupWorkspaceTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: test-app
+spec:
+  hooks:
+    preStart:
+      - run: ./seed.sh
+        on: container
+`)
+
+	_, err := h.Apply(ctx, yamlData)
+	if err == nil || !strings.Contains(err.Error(), "workspace dev: spec.hooks.preStart[0]: preStart hooks run on the host") {
+		t.Fatalf("Apply() error = %v, want invalid hook error", err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	"devopsmaestro/pkg/nvimbridge"
+	pluginlibrary "github.com/rmkohlman/MaestroNvim/nvimops/library"
 	nvimpkg "github.com/rmkohlman/MaestroNvim/nvimops/package"
 	"github.com/rmkohlman/MaestroNvim/nvimops/package/library"
 	"github.com/rmkohlman/MaestroSDK/resource"
@@ -43,6 +45,11 @@ func (h *NvimPackageHandler) Apply(ctx resource.Context, data []byte) (resource.
 		return nil, err
 	}
 
+	// Validate the extends chain and referenced plugins before storing
+	if err := h.validateComposition(ctx, dataStore, pkg); err != nil {
+		return nil, err
+	}
+
 	// Convert to DB model
 	dbPkg, err := h.toDBModel(pkg)
 	if err != nil {
@@ -151,6 +158,42 @@ func (h *NvimPackageHandler) ToYAML(res resource.Resource) ([]byte, error) {
 	return yaml.Marshal(yamlDoc)
 }
 
+// validateComposition checks that the package's extends chain resolves
+// without a cycle and that every plugin it references (including "-name"
+// removals) exists in the plugin library or the plugin store.
+func (h *NvimPackageHandler) validateComposition(ctx resource.Context, dataStore db.NvimPackageStore, pkg *nvimpkg.Package) error {
+	lookup := nvimbridge.NewPackageLookup(dataStore)
+	_, err := nvimbridge.ResolvePackage(pkg.Name, func(name string) (*nvimpkg.Package, error) {
+		if name == pkg.Name {
+			return pkg, nil
+		}
+		return lookup(name)
+	})
+	if err != nil {
+		return fmt.Errorf("invalid pkg '%s': %w", pkg.Name, err)
+	}
+
+	lib, _ := pluginlibrary.NewLibrary()
+	pluginStore, _ := resource.DataStoreAs[db.PluginStore](ctx)
+	missing := nvimbridge.MissingPlugins(pkg.Plugins, func(name string) bool {
+		if lib != nil {
+			if _, ok := lib.Get(name); ok {
+				return true
+			}
+		}
+		if pluginStore != nil {
+			if p, err := pluginStore.GetPluginByName(name); err == nil && p != nil {
+				return true
+			}
+		}
+		return false
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("pkg '%s' references unknown plugin(s): %s (not in library or plugin store)", pkg.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // getDataStore returns the DataStore with pkg operations from context.
 func (h *NvimPackageHandler) getDataStore(ctx resource.Context) (db.NvimPackageStore, error) {
 	return resource.DataStoreAs[db.NvimPackageStore](ctx)
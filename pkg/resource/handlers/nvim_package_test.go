@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"database/sql"
 	"errors"
+	"strings"
 	"testing"
 
 	"devopsmaestro/db"
@@ -122,6 +124,48 @@ spec:
 	}
 }
 
+func TestNvimPackageHandler_Apply_ValidatesComposition(t *testing.T) {
+	h := NewNvimPackageHandler()
+	mockStore := db.NewMockDataStore()
+	mockStore.Plugins["my-plugin"] = &models.NvimPluginDB{ID: 1, Name: "my-plugin", Repo: "me/my-plugin"}
+	ctx := resource.Context{DataStore: mockStore}
+
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{"library and stored plugins", "extends: core\n  plugins: [my-plugin, telescope, -which-key]", ""},
+		{"unknown plugin", "plugins: [telescope, not-a-plugin]", "not-a-plugin"},
+		{"unknown parent", "extends: ghost\n  plugins: [telescope]", "'personal' extends 'ghost'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "apiVersion: devopsmaestro.io/v1\nkind: NvimPackage\nmetadata:\n  name: personal\nspec:\n  " + tt.spec
+			_, err := h.Apply(ctx, []byte(yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Apply() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Apply() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A stored parent that extends the applied package forms a cycle.
+	parent := &models.NvimPackageDB{Name: "team", Extends: sql.NullString{String: "personal", Valid: true}}
+	parent.SetPlugins([]string{"telescope"})
+	mockStore.Packages["team"] = parent
+	yaml := "apiVersion: devopsmaestro.io/v1\nkind: NvimPackage\nmetadata:\n  name: personal\nspec:\n  extends: team\n  plugins: [telescope]"
+	if _, err := h.Apply(ctx, []byte(yaml)); err == nil || !strings.Contains(err.Error(), "personal -> team -> personal") {
+		t.Errorf("Apply() error = %v, want extends cycle", err)
+	}
+}
+
 func TestNvimPackageHandler_Get(t *testing.T) {
 	h := NewNvimPackageHandler()
 	mockStore := db.NewMockDataStore()
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Field Managers
+// =============================================================================
+//
+// A manifest apply replaces a resource, so a field changed with a CLI
+// command (dvm set build-arg, dvm edit, dvm patch) silently reverts when a
+// manifest that doesn't set it is applied again. Like Kubernetes server-side
+// apply, every Apply records which manager last set each field. A manifest
+// apply that would change or remove a field the CLI set is rejected unless
+// forced; fields set by a sync only produce a warning.
+//
+// A declarative manager (manifest or sync) owns every field its documents
+// set. The CLI sends whole documents, so it owns only the fields it changes.
+// Fields no manager set, such as defaults, have no manager.
+
+// Field managers.
+const (
+	ManagerManifest = "manifest" // dvm apply
+	ManagerCLI      = "cli"      // dvm edit, dvm patch, and the set commands
+	ManagerSync     = "sync"     // documents written by a library or source sync
+)
+
+var (
+	fieldManager   atomic.Value // string
+	forceConflicts atomic.Bool
+)
+
+// SetFieldManager sets the manager Apply records as the writer of the fields
+// it sets. The default is ManagerCLI.
+func SetFieldManager(manager string) {
+	fieldManager.Store(manager)
+}
+
+// FieldManager returns the manager Apply records fields for.
+func FieldManager() string {
+	if m, ok := fieldManager.Load().(string); ok && m != "" {
+		return m
+	}
+	return ManagerCLI
+}
+
+// SetForceConflicts sets whether a manifest apply takes over fields another
+// manager set instead of failing with a *ConflictError.
+func SetForceConflicts(force bool) {
+	forceConflicts.Store(force)
+}
+
+// ForceConflicts reports whether a manifest apply takes over conflicting
+// fields.
+func ForceConflicts() bool {
+	return forceConflicts.Load()
+}
+
+// FieldConflict is a field an apply would change or remove that another
+// manager set.
+type FieldConflict struct {
+	Field    string `json:"field" yaml:"field"`
+	Manager  string `json:"manager" yaml:"manager"`
+	Value    string `json:"value" yaml:"value"`                           // the manager's value, as JSON
+	NewValue string `json:"newValue,omitempty" yaml:"newValue,omitempty"` // empty when the apply removes the field
+}
+
+func (c FieldConflict) String() string {
+	if c.NewValue == "" {
+		return fmt.Sprintf("%s: set to %s by %s; the apply removes it", c.Field, c.Value, c.Manager)
+	}
+	return fmt.Sprintf("%s: set to %s by %s; the apply sets %s", c.Field, c.Value, c.Manager, c.NewValue)
+}
+
+// ConflictError is returned by Apply when a manifest would change or remove
+// fields set with the CLI.
+type ConflictError struct {
+	Kind      string
+	Name      string
+	Conflicts []FieldConflict
+}
+
+func (e *ConflictError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %q has fields set by another manager (add them to the manifest, or use --force to overwrite them):", e.Kind, e.Name)
+	for _, c := range e.Conflicts {
+		sb.WriteString("\n  - " + c.String())
+	}
+	return sb.String()
+}
+
+// fieldOwnership is what an Apply knows about the managers of the fields of
+// the resource it writes.
+type fieldOwnership struct {
+	store    db.ManagedFieldStore
+	kind     string
+	name     string
+	manager  string
+	previous map[string]*models.ManagedField // by field; nil for an untracked resource
+	incoming map[string]string               // the fields data sets, as JSON
+}
+
+// checkFieldManagers loads the recorded managers of the resource data
+// describes and checks data against them. It returns nil when the data store
+// doesn't record managers or data has no kind and name.
+func checkFieldManagers(ctx resource.Context, data []byte) (*fieldOwnership, error) {
+	store, ok := ctx.DataStore.(db.ManagedFieldStore)
+	if !ok {
+		return nil, nil
+	}
+	kind, meta, doc := parseManagedDocument(data)
+	if kind == "" || meta.Name == "" {
+		return nil, nil
+	}
+	records, err := store.ListManagedFields(kind, meta.Name)
+	if err != nil {
+		warningHandler(fmt.Sprintf("%s %q: field managers not checked: %v", kind, meta.Name, err))
+		return nil, nil
+	}
+	o := &fieldOwnership{
+		store:    store,
+		kind:     kind,
+		name:     meta.Name,
+		manager:  FieldManager(),
+		previous: matchingFields(records, meta),
+		incoming: flattenFields(doc),
+	}
+	return o, o.check()
+}
+
+// check returns a *ConflictError for the fields a manifest would change or
+// remove that the CLI set, unless forced, and warns about the others a
+// declarative manager takes over.
+func (o *fieldOwnership) check() error {
+	if o.manager == ManagerCLI {
+		return nil
+	}
+	var conflicts []FieldConflict
+	for _, field := range slices.Sorted(maps.Keys(o.previous)) {
+		prev := o.previous[field]
+		if prev.Manager == "" || prev.Manager == o.manager {
+			continue
+		}
+		value, set := o.incoming[field]
+		if set && value == prev.Value {
+			continue
+		}
+		c := FieldConflict{Field: field, Manager: prev.Manager, Value: prev.Value, NewValue: value}
+		if o.manager == ManagerManifest && prev.Manager == ManagerCLI && !ForceConflicts() {
+			conflicts = append(conflicts, c)
+			continue
+		}
+		warningHandler(fmt.Sprintf("%s %q: %s; now managed by %s", o.kind, o.name, c, o.manager))
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Kind: o.kind, Name: o.name, Conflicts: conflicts}
+	}
+	return nil
+}
+
+// record saves the managers of the fields of applied, the YAML of the
+// resource Apply wrote. Failures are warnings: the resource has been applied.
+func (o *fieldOwnership) record(applied []byte) {
+	if o == nil {
+		return
+	}
+	_, meta, doc := parseManagedDocument(applied)
+	live := flattenFields(doc)
+	declarative := o.manager != ManagerCLI
+
+	fields := make([]*models.ManagedField, 0, len(live))
+	for _, field := range slices.Sorted(maps.Keys(live)) {
+		value := live[field]
+		prev := o.previous[field]
+		_, set := o.incoming[field]
+		manager := ""
+		switch {
+		case set && declarative:
+			manager = o.manager
+		case prev != nil && prev.Value == value:
+			manager = prev.Manager
+		case set:
+			manager = o.manager
+		}
+		fields = append(fields, &models.ManagedField{Field: field, Manager: manager, Value: value})
+	}
+	if err := o.store.ReplaceManagedFields(o.kind, o.name, managedScope(meta), fields); err != nil {
+		warningHandler(fmt.Sprintf("%s %q: field managers not recorded: %v", o.kind, o.name, err))
+	}
+}
+
+// forgetFieldManagers removes the recorded managers of the resources of
+// kind named name.
+func forgetFieldManagers(ctx resource.Context, kind, name string) {
+	store, ok := ctx.DataStore.(db.ManagedFieldStore)
+	if !ok {
+		return
+	}
+	if err := store.DeleteManagedFields(kind, name); err != nil {
+		warningHandler(fmt.Sprintf("%s %q: field managers not removed: %v", kind, name, err))
+	}
+}
+
+func parseManagedDocument(data []byte) (string, AdmissionMetadata, map[string]any) {
+	var head struct {
+		Kind     string            `yaml:"kind"`
+		Metadata AdmissionMetadata `yaml:"metadata"`
+	}
+	var doc map[string]any
+	if yaml.Unmarshal(data, &head) != nil || yaml.Unmarshal(data, &doc) != nil {
+		return "", AdmissionMetadata{}, nil
+	}
+	return head.Kind, head.Metadata, doc
+}
+
+// managedScope identifies a resource among those of its kind with the same
+// name by its parents.
+func managedScope(meta AdmissionMetadata) string {
+	parents := []string{meta.Ecosystem, meta.Domain, meta.System, meta.App}
+	if strings.Join(parents, "") == "" {
+		return ""
+	}
+	return strings.Join(parents, "/")
+}
+
+// matchingFields returns the records of the one resource whose parents
+// match those meta names, by field. Parents a document leaves out come from
+// the active context, so when several resources match, none is chosen.
+func matchingFields(records []*models.ManagedField, meta AdmissionMetadata) map[string]*models.ManagedField {
+	byScope := map[string]map[string]*models.ManagedField{}
+	for _, r := range records {
+		if byScope[r.Scope] == nil {
+			byScope[r.Scope] = map[string]*models.ManagedField{}
+		}
+		byScope[r.Scope][r.Field] = r
+	}
+	want := []string{meta.Ecosystem, meta.Domain, meta.System, meta.App}
+	var match map[string]*models.ManagedField
+	for scope, fields := range byScope {
+		parts := make([]string, len(want))
+		if scope != "" {
+			copy(parts, strings.Split(scope, "/"))
+		}
+		matches := true
+		for i, w := range want {
+			if w != "" && w != parts[i] {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = fields
+	}
+	return match
+}
+
+// identityFields are the fields that name a resource rather than describe
+// it; they have no manager.
+var identityFields = []string{"apiVersion", "kind", "status",
+	"metadata.name", "metadata.ecosystem", "metadata.domain", "metadata.system", "metadata.app"}
+
+// flattenFields returns the fields of doc by dotted path, with their values
+// as JSON. Lists are single fields; empty objects and nulls are no field.
+func flattenFields(doc map[string]any) map[string]string {
+	fields := map[string]string{}
+	var walk func(path string, v any)
+	walk = func(path string, v any) {
+		if slices.Contains(identityFields, path) || v == nil {
+			return
+		}
+		if m, ok := v.(map[string]any); ok {
+			for k, e := range m {
+				if path != "" {
+					k = path + "." + k
+				}
+				walk(k, e)
+			}
+			return
+		}
+		if b, err := json.Marshal(v); err == nil {
+			fields[path] = string(b)
+		}
+	}
+	walk("", doc)
+	return fields
+}
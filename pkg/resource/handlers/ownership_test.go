@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+const ownershipTestDoc = `apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata:
+  name: platform
+spec:
+  description: Platform team
+  env:
+    LOG_LEVEL: info
+`
+
+func TestApply_FieldManagers(t *testing.T) {
+	RegisterAll()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+	var warnings []string
+	previous := warningHandler
+	SetWarningHandler(func(msg string) { warnings = append(warnings, msg) })
+	t.Cleanup(func() {
+		SetWarningHandler(previous)
+		SetFieldManager(ManagerCLI)
+		SetForceConflicts(false)
+	})
+
+	apply := func(manager, data string) error {
+		t.Helper()
+		SetFieldManager(manager)
+		_, err := resource.Apply(ctx, []byte(data), "test")
+		return err
+	}
+	owner := func(field string) string {
+		t.Helper()
+		fields, err := store.ListManagedFields(KindEcosystem, "platform")
+		if err != nil {
+			t.Fatalf("ListManagedFields() error = %v", err)
+		}
+		for _, f := range fields {
+			if f.Field == field {
+				return f.Manager
+			}
+		}
+		return "(none)"
+	}
+	withTheme := func(theme string) string {
+		return ownershipTestDoc + "  theme: " + theme + "\n"
+	}
+
+	if err := apply(ManagerManifest, ownershipTestDoc); err != nil {
+		t.Fatalf("manifest Apply() error = %v", err)
+	}
+	if got := owner("spec.description"); got != ManagerManifest {
+		t.Errorf("spec.description manager = %q, want manifest", got)
+	}
+
+	// The CLI sends the whole resource but owns only what it changes
+	if err := apply(ManagerCLI, withTheme("nord")); err != nil {
+		t.Fatalf("cli Apply() error = %v", err)
+	}
+	if got := owner("spec.theme"); got != ManagerCLI {
+		t.Errorf("spec.theme manager = %q, want cli", got)
+	}
+	if got := owner("spec.env.LOG_LEVEL"); got != ManagerManifest {
+		t.Errorf("spec.env.LOG_LEVEL manager = %q, want manifest", got)
+	}
+
+	// Reapplying the manifest would revert the theme
+	err := apply(ManagerManifest, ownershipTestDoc)
+	var ce *ConflictError
+	if !errors.As(err, &ce) {
+		t.Fatalf("manifest Apply() error = %v, want *ConflictError", err)
+	}
+	want := `Ecosystem "platform" has fields set by another manager (add them to the manifest, or use --force to overwrite them):
+  - spec.theme: set to "nord" by cli; the apply removes it`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if eco, _ := store.GetEcosystemByName("platform"); eco.Theme.String != "nord" {
+		t.Errorf("theme = %q after a rejected apply, want nord", eco.Theme.String)
+	}
+
+	// A manifest that agrees takes the field over
+	if err := apply(ManagerManifest, withTheme("nord")); err != nil {
+		t.Fatalf("manifest Apply() with the theme error = %v", err)
+	}
+	if got := owner("spec.theme"); got != ManagerManifest {
+		t.Errorf("spec.theme manager = %q, want manifest", got)
+	}
+
+	// --force overwrites the CLI's change
+	if err := apply(ManagerCLI, withTheme("gruvbox")); err != nil {
+		t.Fatalf("cli Apply() error = %v", err)
+	}
+	SetForceConflicts(true)
+	warnings = nil
+	if err := apply(ManagerManifest, withTheme("nord")); err != nil {
+		t.Fatalf("forced manifest Apply() error = %v", err)
+	}
+	SetForceConflicts(false)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `spec.theme: set to "gruvbox" by cli; the apply sets "nord"`) {
+		t.Errorf("warnings = %q, want the overwritten theme", warnings)
+	}
+
+	// A sync only warns
+	warnings = nil
+	if err := apply(ManagerSync, strings.Replace(withTheme("nord"), "Platform team", "Synced", 1)); err != nil {
+		t.Fatalf("sync Apply() error = %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "spec.description") {
+		t.Errorf("warnings = %q, want the description the sync took over", warnings)
+	}
+
+	if err := resource.Delete(ctx, KindEcosystem, "platform"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if fields, _ := store.ListManagedFields(KindEcosystem, "platform"); len(fields) != 0 {
+		t.Errorf("ListManagedFields() after Delete = %d fields, want none", len(fields))
+	}
+}
+
+func TestFlattenFields(t *testing.T) {
+	_, _, doc := parseManagedDocument([]byte(`apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+  labels:
+    team: infra
+spec:
+  image:
+    name: ubuntu:24.04
+  env: {}
+  shell: null
+  nvim:
+    plugins: [telescope, lualine]
+`))
+	got := flattenFields(doc)
+	want := map[string]string{
+		"metadata.labels.team": `"infra"`,
+		"spec.image.name":      `"ubuntu:24.04"`,
+		"spec.nvim.plugins":    `["telescope","lualine"]`,
+	}
+	if len(got) != len(want) {
+		t.Errorf("flattenFields() = %v, want %v", got, want)
+	}
+	for field, value := range want {
+		if got[field] != value {
+			t.Errorf("flattenFields()[%s] = %q, want %q", field, got[field], value)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Patch
+// =============================================================================
+//
+// A patch changes some fields of a stored resource without a full manifest.
+// Patches are applied to the resource's YAML, and the result goes through
+// Apply like any other document, so it is converted, checked, and admitted.
+// Patches may be written in JSON or YAML.
+
+// PatchType is how a patch document is applied.
+type PatchType string
+
+const (
+	// StrategicMergePatch is a merge patch that merges lists of objects by
+	// their name (or, for mounts, destination) instead of replacing them. A
+	// list item with "$patch: delete" removes the item with its key.
+	StrategicMergePatch PatchType = "strategic"
+	// MergePatch is an RFC 7386 JSON merge patch: objects merge, null
+	// removes a field, and everything else, lists included, is replaced.
+	MergePatch PatchType = "merge"
+	// JSONPatch is an RFC 6902 list of add, remove, replace, move, copy,
+	// and test operations on JSON Pointer paths.
+	JSONPatch PatchType = "json"
+)
+
+// ParsePatchType parses a --type value.
+func ParsePatchType(s string) (PatchType, error) {
+	switch t := PatchType(s); t {
+	case StrategicMergePatch, MergePatch, JSONPatch:
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown patch type %q (valid: strategic, merge, json)", s)
+}
+
+// listMergeKeys are the fields strategic merge matches list items by, in
+// order of preference.
+var listMergeKeys = []string{"name", "destination"}
+
+// PatchYAML applies patch to the YAML document data and returns the patched
+// document.
+func PatchYAML(data []byte, pt PatchType, patch []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	var p any
+	if err := yaml.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("patch is empty")
+	}
+
+	var err error
+	switch pt {
+	case StrategicMergePatch, MergePatch:
+		if _, ok := p.(map[string]any); !ok {
+			return nil, fmt.Errorf("a %s patch must be an object", pt)
+		}
+		doc, err = mergePatch(doc, p, pt == StrategicMergePatch)
+	case JSONPatch:
+		doc, err = applyJSONPatch(doc, p)
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", pt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
+
+// mergePatch merges patch into target. Strategic merges lists of objects
+// that share a merge key item by item.
+func mergePatch(target, patch any, strategic bool) (any, error) {
+	switch p := patch.(type) {
+	case map[string]any:
+		t, ok := target.(map[string]any)
+		if !ok {
+			t = map[string]any{}
+		}
+		for k, v := range p {
+			if v == nil {
+				delete(t, k)
+				continue
+			}
+			merged, err := mergePatch(t[k], v, strategic)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			t[k] = merged
+		}
+		return t, nil
+	case []any:
+		if t, ok := target.([]any); ok && strategic {
+			return mergeList(t, p)
+		}
+	}
+	return patch, nil
+}
+
+// mergeList merges the items of patch into target by their merge key, or
+// returns patch when the lists have no merge key in common.
+func mergeList(target, patch []any) (any, error) {
+	key := mergeKeyOf(target, patch)
+	if key == "" {
+		for _, item := range patch {
+			if m, ok := item.(map[string]any); ok && m["$patch"] != nil {
+				return nil, fmt.Errorf("$patch needs list items with a %s field", strings.Join(listMergeKeys, " or "))
+			}
+		}
+		return patch, nil
+	}
+
+	result := slices.Clone(target)
+	for _, item := range patch {
+		m := item.(map[string]any)
+		directive := m["$patch"]
+		delete(m, "$patch")
+		idx := slices.IndexFunc(result, func(existing any) bool {
+			return reflect.DeepEqual(existing.(map[string]any)[key], m[key])
+		})
+		switch {
+		case directive == "delete":
+			if idx >= 0 {
+				result = slices.Delete(result, idx, idx+1)
+			}
+		case directive != nil:
+			return nil, fmt.Errorf("unsupported $patch directive %v (only delete is supported in lists)", directive)
+		case idx >= 0:
+			merged, err := mergePatch(result[idx], m, true)
+			if err != nil {
+				return nil, fmt.Errorf("[%s=%v]: %w", key, m[key], err)
+			}
+			result[idx] = merged
+		default:
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// mergeKeyOf returns the first merge key every object of both lists has,
+// or "" when an item isn't an object or no key is shared.
+func mergeKeyOf(target, patch []any) string {
+	if len(patch) == 0 {
+		return ""
+	}
+	items := append(slices.Clone(target), patch...)
+	for _, key := range listMergeKeys {
+		shared := true
+		for _, item := range items {
+			m, ok := item.(map[string]any)
+			if !ok || m[key] == nil {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			return key
+		}
+	}
+	return ""
+}
+
+// jsonPatchOp is one RFC 6902 operation.
+type jsonPatchOp struct {
+	Op, Path, From string
+	Value          any
+	hasValue       bool // distinguishes "value: null" from no value
+}
+
+func applyJSONPatch(doc, patch any) (any, error) {
+	list, ok := patch.([]any)
+	if !ok {
+		return nil, fmt.Errorf("a json patch must be a list of operations")
+	}
+	for i, raw := range list {
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("patch operation %d is not an object", i)
+		}
+		var op jsonPatchOp
+		op.Op, _ = m["op"].(string)
+		op.Path, _ = m["path"].(string)
+		op.From, _ = m["from"].(string)
+		op.Value, op.hasValue = m["value"]
+
+		var err error
+		if doc, err = op.apply(doc); err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func (op jsonPatchOp) apply(doc any) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch op.Op {
+	case "add", "replace", "test":
+		if !op.hasValue {
+			return nil, fmt.Errorf("value is required")
+		}
+	case "move", "copy":
+		if _, err := parsePointer(op.From); err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	switch op.Op {
+	case "add":
+		return addAt(doc, path, op.Value)
+	case "remove":
+		return removeAt(doc, path)
+	case "replace":
+		if len(path) == 0 {
+			return op.Value, nil
+		}
+		if doc, err = removeAt(doc, path); err != nil {
+			return nil, err
+		}
+		return addAt(doc, path, op.Value)
+	case "move":
+		from, _ := parsePointer(op.From)
+		if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+			return nil, fmt.Errorf("cannot move %s into itself", op.From)
+		}
+		value, err := getAt(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if doc, err = removeAt(doc, from); err != nil {
+			return nil, err
+		}
+		return addAt(doc, path, value)
+	case "copy":
+		from, _ := parsePointer(op.From)
+		value, err := getAt(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		return addAt(doc, path, deepCopy(value))
+	case "test":
+		value, err := getAt(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, op.Value) {
+			return nil, fmt.Errorf("test failed: value is %v, not %v", value, op.Value)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unknown op %q (valid: add, remove, replace, move, copy, test)", op.Op)
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its reference tokens.
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("path %q must start with /", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses token as an index into a list of length n; "-", the
+// end of the list, is allowed when appending.
+func arrayIndex(token string, n int, appending bool) (int, error) {
+	if token == "-" && appending {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > n || (i == n && !appending) || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("index %q is out of range", token)
+	}
+	return i, nil
+}
+
+func getAt(doc any, path []string) (any, error) {
+	for _, t := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			v, ok := node[t]
+			if !ok {
+				return nil, fmt.Errorf("field %q not found", t)
+			}
+			doc = v
+		case []any:
+			i, err := arrayIndex(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("%q is not an object or list", t)
+		}
+	}
+	return doc, nil
+}
+
+// update replaces the container of path's last token with what fn returns
+// for it and that token.
+func update(doc any, path []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	child, err := getAt(doc, path[:1])
+	if err != nil {
+		return nil, err
+	}
+	child, err = update(child, path[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+	switch node := doc.(type) {
+	case map[string]any:
+		node[path[0]] = child
+	case []any:
+		i, _ := arrayIndex(path[0], len(node), false)
+		node[i] = child
+	}
+	return doc, nil
+}
+
+func addAt(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = value
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(node, i, value), nil
+		}
+		return nil, fmt.Errorf("parent of %q is not an object or list", token)
+	})
+}
+
+func removeAt(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	return update(doc, path, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			if _, ok := node[token]; !ok {
+				return nil, fmt.Errorf("field %q not found", token)
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Delete(node, i, i+1), nil
+		}
+		return nil, fmt.Errorf("parent of %q is not an object or list", token)
+	})
+}
+
+func deepCopy(v any) any {
+	switch node := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(node))
+		for k, e := range node {
+			out[k] = deepCopy(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(node))
+		for i, e := range node {
+			out[i] = deepCopy(e)
+		}
+		return out
+	}
+	return v
+}
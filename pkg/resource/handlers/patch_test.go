@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const patchTestDoc = `apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  labels:
+    team: platform
+    tier: backend
+spec:
+  image:
+    name: ubuntu:22.04
+  env:
+    DEBUG: "1"
+  mounts:
+    - source: ~/.aws
+      destination: /home/dev/.aws
+      readOnly: true
+    - source: cache
+      destination: /cache
+      type: volume
+  nvim:
+    plugins: [telescope, treesitter]
+`
+
+// patched applies patch to patchTestDoc and decodes the result.
+func patched(t *testing.T, pt PatchType, patch string) map[string]any {
+	t.Helper()
+	out, err := PatchYAML([]byte(patchTestDoc), pt, []byte(patch))
+	if err != nil {
+		t.Fatalf("PatchYAML() error = %v", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("patched document does not parse: %v", err)
+	}
+	return doc
+}
+
+func field(doc map[string]any, path string) any {
+	v, _ := getField(doc, path)
+	return v
+}
+
+func TestPatchYAML_StrategicMerge(t *testing.T) {
+	doc := patched(t, StrategicMergePatch, `{
+  "metadata": {"labels": {"tier": null, "owner": "ops"}},
+  "spec": {
+    "image": {"name": "ubuntu:24.04"},
+    "mounts": [
+      {"destination": "/cache", "source": "cache-v2"},
+      {"destination": "/home/dev/.aws", "$patch": "delete"},
+      {"destination": "/data", "source": "/srv/data", "type": "bind"}
+    ],
+    "nvim": {"plugins": ["lualine"]}
+  }
+}`)
+
+	if got := field(doc, "spec.image.name"); got != "ubuntu:24.04" {
+		t.Errorf("spec.image.name = %v", got)
+	}
+	if got := field(doc, "metadata.labels"); !reflect.DeepEqual(got, map[string]any{"team": "platform", "owner": "ops"}) {
+		t.Errorf("metadata.labels = %v", got)
+	}
+	want := []any{
+		map[string]any{"source": "cache-v2", "destination": "/cache", "type": "volume"},
+		map[string]any{"source": "/srv/data", "destination": "/data", "type": "bind"},
+	}
+	if got := field(doc, "spec.mounts"); !reflect.DeepEqual(got, want) {
+		t.Errorf("spec.mounts = %v, want %v", got, want)
+	}
+	// Lists of scalars have no merge key and are replaced
+	if got := field(doc, "spec.nvim.plugins"); !reflect.DeepEqual(got, []any{"lualine"}) {
+		t.Errorf("spec.nvim.plugins = %v", got)
+	}
+	if got := field(doc, "spec.env.DEBUG"); got != "1" {
+		t.Errorf("spec.env.DEBUG = %v, want it untouched", got)
+	}
+}
+
+func TestPatchYAML_MergeReplacesLists(t *testing.T) {
+	doc := patched(t, MergePatch, `
+spec:
+  mounts:
+    - source: cache
+      destination: /cache
+  env: null
+`)
+	if got := field(doc, "spec.mounts"); len(got.([]any)) != 1 {
+		t.Errorf("spec.mounts = %v, want the patch's list", got)
+	}
+	if _, ok := getField(doc, "spec.env"); ok {
+		t.Error("spec.env should be removed by null")
+	}
+}
+
+func TestPatchYAML_JSONPatch(t *testing.T) {
+	doc := patched(t, JSONPatch, `[
+  {"op": "test", "path": "/spec/image/name", "value": "ubuntu:22.04"},
+  {"op": "replace", "path": "/spec/image/name", "value": "debian:12"},
+  {"op": "add", "path": "/spec/nvim/plugins/-", "value": "lualine"},
+  {"op": "add", "path": "/spec/nvim/plugins/0", "value": "mason"},
+  {"op": "remove", "path": "/spec/mounts/0"},
+  {"op": "copy", "from": "/metadata/labels/team", "path": "/metadata/labels/owner"},
+  {"op": "move", "from": "/spec/env/DEBUG", "path": "/spec/env/VERBOSE"},
+  {"op": "add", "path": "/metadata/annotations", "value": {"a~b/c": "x"}},
+  {"op": "remove", "path": "/metadata/annotations/a~0b~1c"}
+]`)
+
+	checks := map[string]any{
+		"spec.image.name":       "debian:12",
+		"spec.nvim.plugins":     []any{"mason", "telescope", "treesitter", "lualine"},
+		"metadata.labels.owner": "platform",
+		"spec.env":              map[string]any{"VERBOSE": "1"},
+		"metadata.annotations":  map[string]any{},
+	}
+	for path, want := range checks {
+		if got := field(doc, path); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s = %v, want %v", path, got, want)
+		}
+	}
+	if mounts := field(doc, "spec.mounts").([]any); len(mounts) != 1 {
+		t.Errorf("spec.mounts = %v, want one mount left", mounts)
+	}
+}
+
+func TestPatchYAML_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		pt    PatchType
+		patch string
+		want  string
+	}{
+		{"merge patch not an object", MergePatch, `["x"]`, "must be an object"},
+		{"json patch not a list", JSONPatch, `{"op": "add"}`, "must be a list"},
+		{"failed test", JSONPatch, `[{"op": "test", "path": "/metadata/name", "value": "prod"}]`, "patch operation 0 (test /metadata/name): test failed"},
+		{"missing field", JSONPatch, `[{"op": "remove", "path": "/spec/missing"}]`, `field "missing" not found`},
+		{"index out of range", JSONPatch, `[{"op": "replace", "path": "/spec/mounts/5", "value": {}}]`, "out of range"},
+		{"relative path", JSONPatch, `[{"op": "add", "path": "spec/x", "value": 1}]`, "must start with /"},
+		{"unknown op", JSONPatch, `[{"op": "merge", "path": "/spec"}]`, "unknown op"},
+		{"missing value", JSONPatch, `[{"op": "add", "path": "/spec/x"}]`, "value is required"},
+		{"delete without merge key", StrategicMergePatch, `{"spec": {"nvim": {"plugins": [{"$patch": "delete"}]}}}`, "$patch needs list items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PatchYAML([]byte(patchTestDoc), tt.pt, []byte(tt.patch))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("PatchYAML() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParsePatchType("apply"); err == nil {
+		t.Error("ParsePatchType(apply) succeeded")
+	}
+}
@@ -39,11 +39,11 @@ func createStackingDS(t *testing.T) *db.SQLDataStore {
 // stackingSchema returns all DDL statements needed for the progressive stacking test.
 func stackingSchema() []string {
 	return []string{
-		`CREATE TABLE IF NOT EXISTS ecosystems (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, build_args TEXT, ca_certs TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP)`,
-		`CREATE TABLE IF NOT EXISTS domains (id INTEGER PRIMARY KEY AUTOINCREMENT, ecosystem_id INTEGER NOT NULL, name TEXT NOT NULL, description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, build_args TEXT, ca_certs TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE CASCADE, UNIQUE(ecosystem_id, name))`,
+		`CREATE TABLE IF NOT EXISTS ecosystems (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, build_args TEXT, ca_certs TEXT, env TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP)`,
+		`CREATE TABLE IF NOT EXISTS domains (id INTEGER PRIMARY KEY AUTOINCREMENT, ecosystem_id INTEGER NOT NULL, name TEXT NOT NULL, description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, build_args TEXT, ca_certs TEXT, env TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE CASCADE, UNIQUE(ecosystem_id, name))`,
 		`CREATE TABLE IF NOT EXISTS git_repos (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, url TEXT NOT NULL, slug TEXT NOT NULL UNIQUE, default_ref TEXT NOT NULL DEFAULT 'main', auth_type TEXT NOT NULL CHECK(auth_type IN ('none','ssh','token')), credential_id INTEGER, auto_sync BOOLEAN NOT NULL DEFAULT 0, sync_interval_minutes INTEGER NOT NULL DEFAULT 0, last_synced_at DATETIME, sync_status TEXT NOT NULL DEFAULT 'pending' CHECK(sync_status IN ('pending','syncing','synced','error')), sync_error TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP)`,
 		`CREATE TABLE IF NOT EXISTS systems (id INTEGER PRIMARY KEY AUTOINCREMENT, ecosystem_id INTEGER, domain_id INTEGER, name TEXT NOT NULL, description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, build_args TEXT, ca_certs TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (ecosystem_id) REFERENCES ecosystems(id) ON DELETE SET NULL, FOREIGN KEY (domain_id) REFERENCES domains(id) ON DELETE SET NULL)`,
-		`CREATE TABLE IF NOT EXISTS apps (id INTEGER PRIMARY KEY AUTOINCREMENT, domain_id INTEGER NOT NULL, system_id INTEGER, name TEXT NOT NULL, path TEXT NOT NULL DEFAULT '', description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, language TEXT, build_config TEXT, git_repo_id INTEGER, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (domain_id) REFERENCES domains(id), FOREIGN KEY (system_id) REFERENCES systems(id), UNIQUE(domain_id, name))`,
+		`CREATE TABLE IF NOT EXISTS apps (id INTEGER PRIMARY KEY AUTOINCREMENT, domain_id INTEGER NOT NULL, system_id INTEGER, name TEXT NOT NULL, path TEXT NOT NULL DEFAULT '', description TEXT, theme TEXT, nvim_package TEXT, terminal_package TEXT, language TEXT, build_config TEXT, git_repo_id INTEGER, env TEXT, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (domain_id) REFERENCES domains(id), FOREIGN KEY (system_id) REFERENCES systems(id), UNIQUE(domain_id, name))`,
 		`CREATE TABLE IF NOT EXISTS workspaces (id INTEGER PRIMARY KEY AUTOINCREMENT, app_id INTEGER NOT NULL, name TEXT NOT NULL, description TEXT, image_name TEXT, container_id TEXT, status TEXT DEFAULT 'stopped', nvim_structure TEXT, nvim_plugins TEXT, theme TEXT, terminal_prompt TEXT, terminal_plugins TEXT, terminal_package TEXT, nvim_package TEXT, slug TEXT, ssh_agent_forwarding INTEGER DEFAULT 0, git_repo_id INTEGER, env TEXT NOT NULL DEFAULT '{}', build_config TEXT, git_credential_mounting BOOLEAN NOT NULL DEFAULT 0, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, FOREIGN KEY (app_id) REFERENCES apps(id), UNIQUE(app_id, name))`,
 		`CREATE TABLE IF NOT EXISTS credentials (id INTEGER PRIMARY KEY AUTOINCREMENT, scope_type TEXT NOT NULL CHECK(scope_type IN ('ecosystem','domain','app','workspace')), scope_id INTEGER, name TEXT NOT NULL, source TEXT NOT NULL CHECK(source IN ('vault','env')), vault_secret TEXT, vault_env TEXT, vault_username_secret TEXT, vault_fields TEXT, env_var TEXT, description TEXT, username_var TEXT, password_var TEXT, expires_at DATETIME, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP, UNIQUE(scope_type, scope_id, name))`,
 		`CREATE TABLE IF NOT EXISTS registries (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, type TEXT NOT NULL, version TEXT NOT NULL DEFAULT '', enabled BOOLEAN NOT NULL DEFAULT 1, lifecycle TEXT NOT NULL DEFAULT 'manual', port INTEGER NOT NULL UNIQUE, storage TEXT NOT NULL DEFAULT '', idle_timeout INTEGER DEFAULT 1800, config TEXT, description TEXT, status TEXT DEFAULT 'stopped', created_at DATETIME DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME DEFAULT CURRENT_TIMESTAMP)`,
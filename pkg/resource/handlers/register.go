@@ -25,41 +25,54 @@ import (
 
 var registerOnce sync.Once
 
-// RegisterAll registers all available resource handlers.
+// RegisterAll registers all available resource handlers, each behind
+// apiVersion conversion (see Scheme) and the admission validators (see Admit).
 // Call this at application startup before using the resource package.
 // This function is idempotent and safe to call multiple times.
 func RegisterAll() {
 	registerOnce.Do(func() {
+		registerDefaultKinds()
+		registerDefaultValidators()
+
 		// Nvim resources
-		resource.Register(NewNvimPluginHandler())
-		resource.Register(NewNvimThemeHandler())
-		resource.Register(NewNvimPackageHandler())
+		register(NewNvimPluginHandler())
+		register(NewNvimThemeHandler())
+		register(NewNvimPackageHandler())
 
 		// Object hierarchy resources (Ecosystem -> Domain -> System -> App -> Workspace)
-		resource.Register(NewEcosystemHandler())
-		resource.Register(NewDomainHandler())
-		resource.Register(NewSystemHandler())
-		resource.Register(NewAppHandler())
-		resource.Register(NewWorkspaceHandler())
+		register(NewEcosystemHandler())
+		register(NewDomainHandler())
+		register(NewSystemHandler())
+		register(NewAppHandler())
+		register(NewWorkspaceHandler())
 
 		// Terminal resources
-		resource.Register(NewTerminalPromptHandler())
-		resource.Register(NewTerminalPackageHandler())
-		resource.Register(NewTerminalPluginHandler())
+		register(NewTerminalPromptHandler())
+		register(NewTerminalPackageHandler())
+		register(NewTerminalPluginHandler())
 
 		// Package registry resources
-		resource.Register(NewRegistryHandler())
+		register(NewRegistryHandler())
 
 		// Credential resources
-		resource.Register(NewCredentialHandler())
+		register(NewCredentialHandler())
 
 		// Git repository resources
-		resource.Register(NewGitRepoHandler())
+		register(NewGitRepoHandler())
 
 		// CRD resources (v0.29.0 Extensibility)
-		resource.Register(NewCRDHandler())
+		register(NewCRDHandler())
+
+		// Build templates shared by apps
+		register(NewBuildTemplateHandler())
 
 		// Global defaults (build-args, CA-certs)
-		resource.Register(NewGlobalDefaultsHandler())
+		register(NewGlobalDefaultsHandler())
 	})
 }
+
+// register adds h to the resource registry behind conversion and admission,
+// so documents are converted and validated before its Apply.
+func register(h resource.Handler) {
+	resource.Register(withAdmission(h))
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"fmt"
+
+	"devopsmaestro/db"
+	"devopsmaestro/models"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+// =============================================================================
+// Revision History
+// =============================================================================
+//
+// Every Apply that changes a resource records its YAML as a new revision,
+// keeping the newest RevisionHistoryLimit. `dvm history` lists them, `dvm
+// diff` compares one with the resource as it is, and `dvm rollback` applies
+// one again through the kind's handler, which records it as a new revision.
+
+// RevisionHistoryLimit is how many revisions are kept for each resource.
+const RevisionHistoryLimit = 10
+
+// recordRevision records applied, the YAML of a resource Apply wrote, as its
+// next revision unless it is unchanged since the last one. Failures are
+// warnings: the resource has been applied.
+func recordRevision(ctx resource.Context, kind, name string, applied []byte) {
+	store, ok := ctx.DataStore.(db.ResourceRevisionStore)
+	if !ok {
+		return
+	}
+	_, meta, _ := parseManagedDocument(applied)
+	scope := managedScope(meta)
+	revisions, err := store.ListResourceRevisions(kind, name, scope)
+	if err == nil && len(revisions) > 0 && revisions[len(revisions)-1].Content == string(applied) {
+		return
+	}
+	rev := &models.ResourceRevision{Kind: kind, Name: name, Scope: scope, Content: string(applied), Manager: FieldManager()}
+	if err == nil {
+		err = store.CreateResourceRevision(rev, RevisionHistoryLimit)
+	}
+	if err != nil {
+		warningHandler(fmt.Sprintf("%s %q: revision not recorded: %v", kind, name, err))
+	}
+}
+
+// forgetRevisions removes the revisions of the resources of kind named name.
+func forgetRevisions(ctx resource.Context, kind, name string) {
+	store, ok := ctx.DataStore.(db.ResourceRevisionStore)
+	if !ok {
+		return
+	}
+	if err := store.DeleteResourceRevisions(kind, name); err != nil {
+		warningHandler(fmt.Sprintf("%s %q: revisions not removed: %v", kind, name, err))
+	}
+}
+
+// Revisions returns the recorded revisions of res, oldest first.
+func Revisions(ctx resource.Context, res resource.Resource) ([]*models.ResourceRevision, error) {
+	store, ok := ctx.DataStore.(db.ResourceRevisionStore)
+	if !ok {
+		return nil, fmt.Errorf("the data store does not record revisions")
+	}
+	data, err := resource.ToYAML(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s '%s' as YAML: %w", res.GetKind(), res.GetName(), err)
+	}
+	_, meta, _ := parseManagedDocument(data)
+	return store.ListResourceRevisions(res.GetKind(), res.GetName(), managedScope(meta))
+}
+
+// FindRevision returns revision n of revisions, or for n == 0 the one
+// before the newest.
+func FindRevision(revisions []*models.ResourceRevision, n int) (*models.ResourceRevision, error) {
+	if n == 0 {
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("no previous revision (%d recorded)", len(revisions))
+		}
+		return revisions[len(revisions)-2], nil
+	}
+	for _, r := range revisions {
+		if r.Revision == n {
+			return r, nil
+		}
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("revision %d not found (no revisions recorded)", n)
+	}
+	return nil, fmt.Errorf("revision %d not found (revisions %d to %d are kept)", n, revisions[0].Revision, revisions[len(revisions)-1].Revision)
+}
+
+// Rollback applies revision n of res again, or for n == 0 the revision
+// before the newest, and returns the applied resource and the revision
+// restored.
+func Rollback(ctx resource.Context, res resource.Resource, n int) (resource.Resource, *models.ResourceRevision, error) {
+	revisions, err := Revisions(ctx, res)
+	if err != nil {
+		return nil, nil, err
+	}
+	rev, err := FindRevision(revisions, n)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s '%s': %w", res.GetKind(), res.GetName(), err)
+	}
+	applied, err := resource.Apply(ctx, []byte(rev.Content), fmt.Sprintf("revision %d", rev.Revision))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to roll back %s '%s' to revision %d: %w", res.GetKind(), res.GetName(), rev.Revision, err)
+	}
+	return applied, rev, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+
+	"github.com/rmkohlman/MaestroSDK/resource"
+)
+
+func TestApply_RecordsRevisions(t *testing.T) {
+	RegisterAll()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+	apply := func(description string) resource.Resource {
+		t.Helper()
+		res, err := resource.Apply(ctx, []byte(strings.Replace(ownershipTestDoc, "Platform team", description, 1)), "test")
+		if err != nil {
+			t.Fatalf("Apply(%s) error = %v", description, err)
+		}
+		return res
+	}
+
+	res := apply("v1")
+	apply("v1") // unchanged, no revision
+	for i := 2; i <= RevisionHistoryLimit+2; i++ {
+		res = apply(fmt.Sprintf("v%d", i))
+	}
+
+	revisions, err := Revisions(ctx, res)
+	if err != nil {
+		t.Fatalf("Revisions() error = %v", err)
+	}
+	if len(revisions) != RevisionHistoryLimit {
+		t.Fatalf("Revisions() = %d revisions, want the newest %d", len(revisions), RevisionHistoryLimit)
+	}
+	if first, last := revisions[0].Revision, revisions[len(revisions)-1].Revision; first != 3 || last != RevisionHistoryLimit+2 {
+		t.Errorf("Revisions() = %d to %d, want 3 to %d", first, last, RevisionHistoryLimit+2)
+	}
+
+	if _, err := FindRevision(revisions, 1); err == nil || !strings.Contains(err.Error(), "revisions 3 to 12 are kept") {
+		t.Errorf("FindRevision(1) error = %v, want the kept range", err)
+	}
+	prev, err := FindRevision(revisions, 0)
+	if err != nil || prev.Revision != RevisionHistoryLimit+1 {
+		t.Errorf("FindRevision(0) = %v, %v, want the revision before the newest", prev, err)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	RegisterAll()
+	store := db.NewMockDataStore()
+	ctx := resource.Context{DataStore: store}
+	for _, description := range []string{"first", "second", "third"} {
+		if _, err := resource.Apply(ctx, []byte(strings.Replace(ownershipTestDoc, "Platform team", description, 1)), "test"); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+	eco, _ := store.GetEcosystemByName("platform")
+
+	applied, rev, err := Rollback(ctx, NewEcosystemResource(eco), 1)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if rev.Revision != 1 || applied.GetName() != "platform" {
+		t.Errorf("Rollback() restored revision %d of %s, want 1 of platform", rev.Revision, applied.GetName())
+	}
+	eco, _ = store.GetEcosystemByName("platform")
+	if eco.Description.String != "first" {
+		t.Errorf("description = %q after rollback, want first", eco.Description.String)
+	}
+
+	// The rollback is itself a revision
+	revisions, _ := Revisions(ctx, NewEcosystemResource(eco))
+	if latest := revisions[len(revisions)-1]; latest.Revision != 4 || latest.Content != revisions[0].Content {
+		t.Errorf("latest revision = %d, want 4 with revision 1's content", latest.Revision)
+	}
+
+	if err := resource.Delete(ctx, KindEcosystem, "platform"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if len(store.ResourceRevisions) != 0 {
+		t.Errorf("%d revisions left after Delete, want none", len(store.ResourceRevisions))
+	}
+}
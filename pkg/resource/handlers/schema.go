@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// =============================================================================
+// JSON Schema
+// =============================================================================
+//
+// The JSON Schema of a kind is generated from the Go type its handler parses
+// (see Scheme.AddType), so it accepts exactly what strict decoding accepts.
+// Editors use it for completion and validation, for example through
+// yaml-language-server's "# yaml-language-server: $schema=<file>" comment.
+// The schemas of the built-in kinds are committed under schemas/ and
+// embedded in the binary; regenerate them after changing a YAML type with
+//
+//	UPDATE_SCHEMAS=1 go test ./pkg/resource/handlers -run TestEmbeddedSchemas
+
+// JSONSchemaDialect is the JSON Schema draft the generated schemas use.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// SchemaFS returns the embedded JSON Schemas of the built-in kinds, one
+// <kind>.json file per kind (see SchemaFileName) plus devopsmaestro.json,
+// which accepts a document of any of them.
+func SchemaFS() fs.FS {
+	sub, _ := fs.Sub(schemaFS, "schemas")
+	return sub
+}
+
+// AllKindsSchemaFile is the schema file that accepts a document of any kind.
+const AllKindsSchemaFile = "devopsmaestro.json"
+
+// SchemaFileName returns the schema file name of kind, its kebab-case form:
+// NvimPlugin is nvim-plugin.json.
+func SchemaFileName(kind string) string {
+	var sb strings.Builder
+	for i, r := range kind {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String() + ".json"
+}
+
+// SchemaKinds returns the kinds with a registered type, sorted.
+func (s *Scheme) SchemaKinds() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var kinds []string
+	for kind, ks := range s.kinds {
+		if ks.typ != nil {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// JSONSchema returns the indented JSON Schema of kind's registered type.
+// kind and apiVersion are pinned to the kind and the apiVersions it accepts.
+func (s *Scheme) JSONSchema(kind string) ([]byte, error) {
+	t := s.Type(kind)
+	if t == nil {
+		return nil, fmt.Errorf("no schema for kind %q", kind)
+	}
+	schema := typeSchema(t, map[reflect.Type]bool{})
+	schema["$schema"] = JSONSchemaDialect
+	schema["title"] = kind
+
+	if props, ok := schema["properties"].(map[string]any); ok {
+		if _, ok := props["kind"]; ok {
+			props["kind"] = map[string]any{"const": kind}
+		}
+		if _, ok := props["apiVersion"]; ok {
+			if versions := s.APIVersions(kind); len(versions) > 0 {
+				props["apiVersion"] = map[string]any{"enum": versions}
+			}
+		}
+		var required []string
+		for _, key := range []string{"apiVersion", "kind", "metadata"} {
+			if _, ok := props[key]; ok {
+				required = append(required, key)
+			}
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		if md, ok := props["metadata"].(map[string]any); ok {
+			if mdProps, ok := md["properties"].(map[string]any); ok {
+				if _, ok := mdProps["name"]; ok {
+					md["required"] = []string{"name"}
+				}
+			}
+		}
+	}
+	return marshalSchema(schema)
+}
+
+// AllKindsSchema returns a JSON Schema that accepts a document of any of
+// kinds, referring to each kind's schema file next to it.
+func AllKindsSchema(kinds []string) ([]byte, error) {
+	var refs []any
+	for _, kind := range kinds {
+		refs = append(refs, map[string]any{"$ref": SchemaFileName(kind)})
+	}
+	return marshalSchema(map[string]any{
+		"$schema": JSONSchemaDialect,
+		"title":   "DevOpsMaestro resource",
+		"oneOf":   refs,
+	})
+}
+
+func marshalSchema(schema map[string]any) ([]byte, error) {
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeSchema returns the schema of the YAML t decodes. Types that parse
+// their own YAML, interfaces, and recursive references accept anything.
+func typeSchema(t reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]any{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		fields, anyKey := yamlStructFields(t)
+		props := make(map[string]any, len(fields))
+		for _, f := range fields {
+			props[f.Name] = typeSchema(f.Type, visiting)
+		}
+		schema := map[string]any{"type": "object", "properties": props}
+		if anyKey != nil {
+			schema["additionalProperties"] = typeSchema(anyKey, visiting)
+		} else {
+			schema["additionalProperties"] = false
+		}
+		return schema
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaFileName(t *testing.T) {
+	for kind, want := range map[string]string{
+		KindWorkspace:       "workspace.json",
+		KindNvimPlugin:      "nvim-plugin.json",
+		KindTerminalPackage: "terminal-package.json",
+		KindCRD:             "custom-resource-definition.json",
+	} {
+		if got := SchemaFileName(kind); got != want {
+			t.Errorf("SchemaFileName(%q) = %q, want %q", kind, got, want)
+		}
+	}
+}
+
+func TestScheme_JSONSchema(t *testing.T) {
+	type spec struct {
+		Image   string            `yaml:"image"`
+		Ports   []int             `yaml:"ports,omitempty"`
+		Env     map[string]string `yaml:"env,omitempty"`
+		Options selfParsed        `yaml:"options"`
+	}
+	type doc struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+		Metadata   struct {
+			Name   string         `yaml:"name"`
+			Extra  map[string]any `yaml:",inline"`
+			Hidden string         `yaml:"-"`
+		} `yaml:"metadata"`
+		Spec spec `yaml:"spec"`
+	}
+	s := NewScheme()
+	s.AddKind("Test", HubAPIVersion)
+	s.AddConversion("Test", "devopsmaestro.io/v1alpha1", nil)
+	s.AddType("Test", doc{})
+
+	out, err := s.JSONSchema("Test")
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("JSONSchema() is not JSON: %v", err)
+	}
+	var want map[string]any
+	if err := json.Unmarshal([]byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Test",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["apiVersion", "kind", "metadata"],
+  "properties": {
+    "apiVersion": {"enum": ["devopsmaestro.io/v1", "devopsmaestro.io/v1alpha1"]},
+    "kind": {"const": "Test"},
+    "metadata": {
+      "type": "object",
+      "required": ["name"],
+      "properties": {"name": {"type": "string"}},
+      "additionalProperties": {}
+    },
+    "spec": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "image": {"type": "string"},
+        "ports": {"type": "array", "items": {"type": "integer"}},
+        "env": {"type": "object", "additionalProperties": {"type": "string"}},
+        "options": {}
+      }
+    }
+  }
+}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSONSchema() = %s", out)
+	}
+
+	if _, err := s.JSONSchema("Other"); err == nil {
+		t.Error("JSONSchema() of an unregistered kind succeeded")
+	}
+}
+
+// TestEmbeddedSchemas checks the committed schemas match the YAML types.
+// Run with UPDATE_SCHEMAS=1 to regenerate them.
+func TestEmbeddedSchemas(t *testing.T) {
+	registerDefaultKinds()
+	kinds := DefaultScheme().SchemaKinds()
+
+	want := map[string][]byte{}
+	for _, kind := range kinds {
+		out, err := DefaultScheme().JSONSchema(kind)
+		if err != nil {
+			t.Fatalf("JSONSchema(%s) error = %v", kind, err)
+		}
+		want[SchemaFileName(kind)] = out
+	}
+	all, err := AllKindsSchema(kinds)
+	if err != nil {
+		t.Fatalf("AllKindsSchema() error = %v", err)
+	}
+	want[AllKindsSchemaFile] = all
+
+	if os.Getenv("UPDATE_SCHEMAS") != "" {
+		old, _ := filepath.Glob(filepath.Join("schemas", "*.json"))
+		for _, path := range old {
+			if err := os.Remove(path); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for name, data := range want {
+			if err := os.WriteFile(filepath.Join("schemas", name), data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	embedded, err := fs.Glob(SchemaFS(), "*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(embedded) != len(want) {
+		t.Errorf("embedded schemas = %v, want %d files; run UPDATE_SCHEMAS=1 go test ./pkg/resource/handlers -run TestEmbeddedSchemas", embedded, len(want))
+	}
+	for name, data := range want {
+		got, err := fs.ReadFile(SchemaFS(), name)
+		if err != nil || string(got) != string(data) {
+			t.Errorf("schemas/%s is out of date; run UPDATE_SCHEMAS=1 go test ./pkg/resource/handlers -run TestEmbeddedSchemas", name)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// =============================================================================
+// Strict Decoding
+// =============================================================================
+//
+// yaml.Unmarshal ignores fields a struct doesn't define, so a typo such as
+// spec.descripton silently does nothing. Before Apply, a document is checked
+// against the Go type its kind's handler parses (see Scheme.AddType) and
+// every unknown field is reported with its line and column.
+
+// UnknownField is a field of a document its kind doesn't define.
+type UnknownField struct {
+	Path       string `json:"path" yaml:"path"`
+	Line       int    `json:"line" yaml:"line"`
+	Column     int    `json:"column" yaml:"column"`
+	Suggestion string `json:"suggestion,omitempty" yaml:"suggestion,omitempty"` // a known field of the same object it may be a typo of
+}
+
+func (f UnknownField) String() string {
+	s := fmt.Sprintf("%s (line %d, column %d): unknown field", f.Path, f.Line, f.Column)
+	if f.Suggestion != "" {
+		s += fmt.Sprintf("; did you mean %q?", f.Suggestion)
+	}
+	return s
+}
+
+// StrictDecodingError is returned by Apply when a document has fields its
+// kind doesn't define.
+type StrictDecodingError struct {
+	Kind   string
+	Name   string
+	Fields []UnknownField
+}
+
+func (e *StrictDecodingError) Error() string {
+	var sb strings.Builder
+	if e.Name != "" {
+		fmt.Fprintf(&sb, "%s %q has unknown fields", e.Kind, e.Name)
+	} else {
+		fmt.Fprintf(&sb, "%s has unknown fields", e.Kind)
+	}
+	sb.WriteString(" (use --validate=false to apply it anyway):")
+	for _, f := range e.Fields {
+		sb.WriteString("\n  - " + f.String())
+	}
+	return sb.String()
+}
+
+// strict is whether Apply rejects unknown fields. It is off by default so
+// internal applies and the plugin, prompt, and package libraries stay
+// lenient; dvm apply and nvp apply turn it on unless --validate=false.
+var strict atomic.Bool
+
+// SetStrict sets whether Apply rejects documents with unknown fields.
+func SetStrict(enabled bool) {
+	strict.Store(enabled)
+}
+
+// Strict reports whether Apply rejects documents with unknown fields.
+func Strict() bool {
+	return strict.Load()
+}
+
+// AddType registers the Go type documents of kind are parsed into, such as
+// models.WorkspaceYAML{}, for strict decoding.
+func (s *Scheme) AddType(kind string, v any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.kind(kind).typ = reflect.TypeOf(v)
+}
+
+// Type returns the Go type registered for kind, or nil.
+func (s *Scheme) Type(kind string) reflect.Type {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if ks, ok := s.kinds[kind]; ok {
+		return ks.typ
+	}
+	return nil
+}
+
+// UnknownFields returns the fields of data its kind's registered type
+// doesn't define, in document order. Kinds without a type, and documents
+// that don't parse, have none.
+func (s *Scheme) UnknownFields(data []byte) []UnknownField {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+	var head struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := root.Decode(&head); err != nil {
+		return nil
+	}
+	t := s.Type(head.Kind)
+	if t == nil {
+		return nil
+	}
+	var fields []UnknownField
+	walkUnknownFields(&root, t, "", &fields)
+	return fields
+}
+
+// CheckStrict returns a *StrictDecodingError for the unknown fields of data,
+// or nil when it has none.
+func (s *Scheme) CheckStrict(data []byte) error {
+	fields := s.UnknownFields(data)
+	if len(fields) == 0 {
+		return nil
+	}
+	var head struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+	}
+	_ = yaml.Unmarshal(data, &head)
+	return &StrictDecodingError{Kind: head.Kind, Name: head.Metadata.Name, Fields: fields}
+}
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// walkUnknownFields records the mapping keys under node that t has no field
+// for. Types with their own UnmarshalYAML, and interface values, accept
+// anything and are not descended into.
+func walkUnknownFields(node *yaml.Node, t reflect.Type, path string, out *[]UnknownField) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			walkUnknownFields(node.Content[0], t, path, out)
+		}
+		return
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			walkUnknownFields(node.Alias, t, path, out)
+		}
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields, anyKey := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				// Merge key: its mapping's keys belong to this object
+				walkUnknownFields(value, t, path, out)
+				continue
+			}
+			ft, ok := fields[key.Value]
+			if !ok {
+				if anyKey == nil {
+					*out = append(*out, UnknownField{
+						Path:       joinPath(path, key.Value),
+						Line:       key.Line,
+						Column:     key.Column,
+						Suggestion: suggestField(key.Value, fields),
+					})
+					continue
+				}
+				ft = anyKey
+			}
+			walkUnknownFields(value, ft, joinPath(path, key.Value), out)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkUnknownFields(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value), out)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			walkUnknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), out)
+		}
+	}
+}
+
+// yamlField is a key of a YAML-decoded struct.
+type yamlField struct {
+	Name      string
+	Type      reflect.Type
+	OmitEmpty bool
+}
+
+// yamlStructFields returns the YAML keys of struct t in declaration order,
+// following yaml.v3's rules: the key is the yaml tag's name or the
+// lowercased field name, and ",inline" structs contribute their own keys.
+// anyKey is the value type of an inline map, which takes every other key.
+func yamlStructFields(t reflect.Type) (fields []yamlField, anyKey reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		opts = "," + opts + ","
+		if strings.Contains(opts, ",inline,") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			switch ft.Kind() {
+			case reflect.Struct:
+				inner, innerAny := yamlStructFields(ft)
+				fields = append(fields, inner...)
+				if innerAny != nil {
+					anyKey = innerAny
+				}
+			case reflect.Map:
+				anyKey = ft.Elem()
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, yamlField{Name: name, Type: f.Type, OmitEmpty: strings.Contains(opts, ",omitempty,")})
+	}
+	return fields, anyKey
+}
+
+// yamlFields is yamlStructFields keyed by name.
+func yamlFields(t reflect.Type) (fields map[string]reflect.Type, anyKey reflect.Type) {
+	list, anyKey := yamlStructFields(t)
+	fields = make(map[string]reflect.Type, len(list))
+	for _, f := range list {
+		fields[f.Name] = f.Type
+	}
+	return fields, anyKey
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// suggestField returns the known field key is most likely a typo of: one
+// that differs only in case, or is at most two edits away.
+func suggestField(key string, fields map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return name
+		}
+		if d := editDistance(strings.ToLower(key), strings.ToLower(name)); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
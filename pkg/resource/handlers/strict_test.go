@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"devopsmaestro/db"
+	"github.com/rmkohlman/MaestroSDK/resource"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestScheme_UnknownFields(t *testing.T) {
+	registerDefaultKinds()
+
+	fields := DefaultScheme().UnknownFields([]byte(`apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: dev
+  app: api
+spec:
+  image:
+    nmae: ubuntu:22.04
+  Env:
+    DEBUG: "1"
+  mounts:
+    - source: ~/.aws
+      destination: /home/dev/.aws
+      readonly: true
+  nvim:
+    structure: lazyvim
+    pluginz: [telescope]
+`))
+	want := []UnknownField{
+		{Path: "spec.image.nmae", Line: 8, Column: 5, Suggestion: "name"},
+		{Path: "spec.Env", Line: 9, Column: 3, Suggestion: "env"},
+		{Path: "spec.mounts[0].readonly", Line: 14, Column: 7, Suggestion: "readOnly"},
+		{Path: "spec.nvim.pluginz", Line: 17, Column: 5, Suggestion: "plugins"},
+	}
+	if len(fields) != len(want) {
+		t.Fatalf("UnknownFields() = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("UnknownFields()[%d] = %+v, want %+v", i, fields[i], want[i])
+		}
+	}
+}
+
+func TestScheme_UnknownFields_InlineAndUnmarshalers(t *testing.T) {
+	type base struct {
+		Name string `yaml:"name"`
+	}
+	type doc struct {
+		Kind   string         `yaml:"kind"`
+		Base   base           `yaml:",inline"`
+		Extra  map[string]int `yaml:",inline"`
+		Opaque selfParsed     `yaml:"opaque"`
+		Any    any            `yaml:"any"`
+		Count  int
+	}
+	s := NewScheme()
+	s.AddType("Test", doc{})
+
+	fields := s.UnknownFields([]byte("kind: Test\nname: x\ncount: 2\nwhatever: 3\nopaque:\n  free: form\nany:\n  also: free\n"))
+	if len(fields) != 0 {
+		t.Errorf("UnknownFields() = %v, want none", fields)
+	}
+	if fields := s.UnknownFields([]byte("kind: Other\nbogus: 1\n")); len(fields) != 0 {
+		t.Errorf("UnknownFields() of an unregistered kind = %v, want none", fields)
+	}
+}
+
+// selfParsed stands in for a type that parses its own YAML.
+type selfParsed struct{}
+
+func (*selfParsed) UnmarshalYAML(*yaml.Node) error { return nil }
+
+func TestApply_Strict(t *testing.T) {
+	RegisterAll()
+	t.Cleanup(func() { SetStrict(false) })
+	ctx := resource.Context{DataStore: db.NewMockDataStore()}
+	data := []byte(`apiVersion: devopsmaestro.io/v1
+kind: Ecosystem
+metadata:
+  name: platform
+spec:
+  descripton: Platform team
+`)
+
+	SetStrict(true)
+	_, err := resource.Apply(ctx, data, "test")
+	var se *StrictDecodingError
+	if !errors.As(err, &se) {
+		t.Fatalf("Apply() error = %v, want *StrictDecodingError", err)
+	}
+	want := `Ecosystem "platform" has unknown fields (use --validate=false to apply it anyway):
+  - spec.descripton (line 6, column 3): unknown field; did you mean "description"?`
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	SetStrict(false)
+	if _, err := resource.Apply(ctx, data, "test"); err != nil {
+		t.Errorf("Apply() with strict off error = %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"descripton", "description", 1},
+		{"tga", "tag", 2},
+		{"same", "same", 0},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !strings.EqualFold(suggestField("IMAGE", map[string]reflect.Type{"image": nil}), "image") {
+		t.Error("suggestField() did not match case-insensitively")
+	}
+}
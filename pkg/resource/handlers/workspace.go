@@ -9,7 +9,10 @@ import (
 
 	"devopsmaestro/db"
 	"devopsmaestro/models"
+	themeresolver "devopsmaestro/pkg/colors/resolver"
+	"devopsmaestro/pkg/envresolver"
 	"devopsmaestro/pkg/mirror"
+	"devopsmaestro/pkg/terminalbridge/fonts"
 	ws "devopsmaestro/pkg/workspace"
 	"github.com/rmkohlman/MaestroSDK/paths"
 	"github.com/rmkohlman/MaestroSDK/resource"
@@ -84,6 +87,44 @@ func (h *WorkspaceHandler) Apply(ctx resource.Context, data []byte) (resource.Re
 	if appName == "" {
 		return nil, fmt.Errorf("workspace YAML must specify metadata.app")
 	}
+	if wsYAML.Metadata.Name == "" {
+		return nil, fmt.Errorf("workspace name is required")
+	}
+	if err := models.ValidateMounts(wsYAML.Spec.Mounts); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := models.ValidatePorts(wsYAML.Spec.Container.Ports); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := models.ValidateWorkspaceRuntime(wsYAML.Spec.Runtime); err != nil {
+		return nil, err
+	}
+	if err := models.ValidateDevices(wsYAML.Spec.Devices, wsYAML.Spec.Runtime); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := models.ValidateGPUs(wsYAML.Spec.GPUs, wsYAML.Spec.Runtime); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := models.ValidateDependsOn(wsYAML.Spec.DependsOn, appName, wsYAML.Metadata.Name); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := models.ValidateSyncConfig(wsYAML.Spec.Sync); err != nil {
+		return nil, err
+	}
+	if err := envresolver.Validate(wsYAML.Spec.Env); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.env: %w", wsYAML.Metadata.Name, err)
+	}
+	if err := wsYAML.Spec.Build.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.build.%w", wsYAML.Metadata.Name, err)
+	}
+	if err := wsYAML.Spec.Hooks.Validate(); err != nil {
+		return nil, fmt.Errorf("workspace %s: spec.%w", wsYAML.Metadata.Name, err)
+	}
+	if font := wsYAML.Spec.Tools.NerdFont; font != "" {
+		if _, err := fonts.Get(font); err != nil {
+			return nil, fmt.Errorf("workspace %s: spec.tools.nerdFont: %w", wsYAML.Metadata.Name, err)
+		}
+	}
 
 	// Resolve domain: try metadata.domain first, then fall back to active context
 	var domainID sql.NullInt64
@@ -444,6 +485,96 @@ func (h *WorkspaceHandler) Delete(ctx resource.Context, name string) error {
 	return ds.DeleteWorkspace(workspace.ID)
 }
 
+// Describe summarizes a workspace: its parents, container state, plugins,
+// theme resolution, last build, and recent events.
+func (h *WorkspaceHandler) Describe(ctx resource.Context, res resource.Resource) (*Description, error) {
+	wr, ok := res.(*WorkspaceResource)
+	if !ok {
+		return nil, fmt.Errorf("expected WorkspaceResource, got %T", res)
+	}
+	ds, err := resource.DataStoreAs[db.DataStore](ctx)
+	if err != nil {
+		return nil, err
+	}
+	w := wr.workspace
+
+	app, err := ds.GetAppByID(w.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app for workspace '%s': %w", w.Name, err)
+	}
+	eco, dom, sys := appParents(ds, app)
+
+	d := &Description{Kind: KindWorkspace, Name: w.Name}
+	d.Sections = append(d.Sections, DescriptionSection{Title: KindWorkspace, Fields: []DescriptionField{
+		{Key: "Name", Value: w.Name},
+		{Key: "Description", Value: orNone(nullString(w.Description))},
+		{Key: "Image", Value: orNone(w.ImageName)},
+		{Key: "Git Repo", Value: orNone(gitRepoName(ds, w.GitRepoID))},
+		{Key: "Created", Value: describeTime(w.CreatedAt)},
+		{Key: "Updated", Value: describeTime(w.UpdatedAt)},
+	}})
+	d.Sections = append(d.Sections, describeParents(eco, dom, sys, app))
+
+	containerID := nullString(w.ContainerID)
+	if len(containerID) > 12 {
+		containerID = containerID[:12]
+	}
+	d.Sections = append(d.Sections, DescriptionSection{Title: "Container", Fields: []DescriptionField{
+		{Key: "Status", Value: orNone(w.Status)},
+		{Key: "Container ID", Value: orNone(containerID)},
+	}})
+
+	plugins := DescriptionSection{Title: "Plugins", Headers: []string{"NAME", "REPO"}, None: "<none>"}
+	if dbPlugins, err := ds.GetWorkspacePlugins(w.ID); err == nil {
+		for _, p := range dbPlugins {
+			plugins.Rows = append(plugins.Rows, []string{p.Name, p.Repo})
+		}
+	}
+	d.Sections = append(d.Sections, plugins)
+
+	d.Sections = append(d.Sections, describeTheme(ds, themeresolver.LevelWorkspace, w.ID))
+
+	events := []describeEvent{
+		{At: w.CreatedAt, Event: "Created"},
+	}
+	if w.UpdatedAt.After(w.CreatedAt) {
+		events = append(events, describeEvent{At: w.UpdatedAt, Event: "Updated"})
+	}
+	if attached, err := ds.ListWorkspaceLastAttached(); err == nil {
+		if at, ok := attached[w.ID]; ok {
+			events = append(events, describeEvent{At: at, Event: "Attached"})
+		}
+	}
+
+	build := DescriptionSection{Title: "Last Build", None: "<never built>"}
+	if bsw, err := ds.GetLatestBuildSessionWorkspace(w.ID); err == nil && bsw != nil {
+		build.Fields = []DescriptionField{
+			{Key: "Session", Value: bsw.SessionID},
+			{Key: "Status", Value: bsw.Status},
+		}
+		if bsw.StartedAt.Valid {
+			build.Fields = append(build.Fields, DescriptionField{Key: "Started", Value: describeTime(bsw.StartedAt.Time)})
+			events = append(events, describeEvent{At: bsw.StartedAt.Time, Event: "Build started"})
+		}
+		if bsw.DurationSeconds.Valid {
+			build.Fields = append(build.Fields, DescriptionField{Key: "Duration", Value: fmt.Sprintf("%ds", bsw.DurationSeconds.Int64)})
+		}
+		if bsw.ImageTag.Valid {
+			build.Fields = append(build.Fields, DescriptionField{Key: "Image Tag", Value: bsw.ImageTag.String})
+		}
+		if bsw.ErrorMessage.Valid && bsw.ErrorMessage.String != "" {
+			build.Fields = append(build.Fields, DescriptionField{Key: "Error", Value: bsw.ErrorMessage.String})
+		}
+		if bsw.CompletedAt.Valid {
+			events = append(events, describeEvent{At: bsw.CompletedAt.Time, Event: "Build " + bsw.Status})
+		}
+	}
+	d.Sections = append(d.Sections, build)
+	d.Sections = append(d.Sections, describeEvents(events))
+
+	return d, nil
+}
+
 // ToYAML serializes a workspace to YAML.
 func (h *WorkspaceHandler) ToYAML(res resource.Resource) ([]byte, error) {
 	wr, ok := res.(*WorkspaceResource)
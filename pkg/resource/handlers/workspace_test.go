@@ -278,6 +278,57 @@ spec:
 	}
 }
 
+func TestWorkspaceHandler_Apply_MissingName(t *testing.T) {
+	h := NewWorkspaceHandler()
+	store, _, _, _ := setupWorkspaceTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  app: ws-app
+spec:
+  image:
+    name: ubuntu:22.04
+`)
+
+	_, err := h.Apply(ctx, yamlData)
+	if err == nil || !strings.Contains(err.Error(), "name is required") {
+		t.Errorf("Apply() error = %v, want name is required", err)
+	}
+}
+
+func TestWorkspaceHandler_Apply_InvalidMounts(t *testing.T) {
+	h := NewWorkspaceHandler()
+	store, _, _, appID := setupWorkspaceTest(t)
+	ctx := resource.Context{DataStore: store}
+
+	yamlData := []byte(`
+apiVersion: devopsmaestro.io/v1
+kind: Workspace
+metadata:
+  name: mount-ws
+  app: ws-app
+spec:
+  image:
+    name: ubuntu:22.04
+  mounts:
+    - source: ~/.aws
+      destination: /home/dev/.aws
+    - source: ~/.kube
+      destination: home/dev/.kube
+`)
+
+	_, err := h.Apply(ctx, yamlData)
+	if err == nil || !strings.Contains(err.Error(), "spec.mounts[1].destination") {
+		t.Errorf("Apply() error = %v, want it to name spec.mounts[1].destination", err)
+	}
+	if ws, _ := store.GetWorkspaceByName(appID, "mount-ws"); ws != nil {
+		t.Error("Apply() stored a workspace with invalid mounts")
+	}
+}
+
 func TestWorkspaceHandler_Apply_NoActiveDomain(t *testing.T) {
 	h := NewWorkspaceHandler()
 	// Store with no active domain
@@ -0,0 +1,219 @@
+package sbom
+
+import (
+	"regexp"
+	"strings"
+)
+
+// githubRelease matches release asset downloads such as the Neovim tarball.
+var githubRelease = regexp.MustCompile(`https://github\.com/([\w.-]+)/([\w.-]+)/releases/download/v?([\w.+-]+)/`)
+
+// githubClone matches shallow clones of a tag, used to build Neovim from source.
+var githubClone = regexp.MustCompile(`--branch\s+v?([\w.+-]+)\s+https://github\.com/([\w.-]+)/([\w.-]+?)(?:\.git)?(?:\s|$)`)
+
+// ParseDockerfile reads the base images and installed packages from a
+// generated Dockerfile. It recognizes FROM lines, apt-get install, apk add,
+// pip install, npm install -g, go install, cargo install, and GitHub release
+// downloads. Base images and distro packages of stages the final image is
+// not built from are marked BuildOnly.
+func ParseDockerfile(content string) ([]BaseImage, []Component) {
+	var (
+		bases      []BaseImage
+		baseStages []string // Stage of each base, named or not
+		components []Component
+		stages     = map[string]string{} // stage → the stage it is built from
+		stage      string
+	)
+	seen := map[Component]bool{}
+	add := func(c Component) {
+		c.Stage = stage
+		if !seen[c] {
+			seen[c] = true
+			components = append(components, c)
+		}
+	}
+
+	for _, line := range logicalLines(content) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "FROM":
+			ref, name := parseFrom(fields[1:])
+			stage = name
+			if stage == "" {
+				stage = ref
+			}
+			parent := ""
+			if _, ok := stages[ref]; ok {
+				parent = ref
+			} else if ref != "" {
+				image, digest, _ := strings.Cut(ref, "@")
+				bases = append(bases, BaseImage{Ref: image, Digest: digest, Stage: name})
+				baseStages = append(baseStages, stage)
+			}
+			stages[stage] = parent
+		case "RUN":
+			for _, c := range parseRun(strings.Join(fields[1:], " ")) {
+				add(c)
+			}
+		}
+	}
+
+	// Stages the last one descends from end up in the image
+	final := map[string]bool{}
+	for s := stage; s != ""; s = stages[s] {
+		if final[s] {
+			break
+		}
+		final[s] = true
+	}
+	for i, s := range baseStages {
+		bases[i].BuildOnly = !final[s]
+	}
+	for i, c := range components {
+		if (c.Type == TypeDeb || c.Type == TypeAPK) && !final[c.Stage] {
+			components[i].BuildOnly = true
+		}
+	}
+	return bases, components
+}
+
+// logicalLines joins continuation lines and drops comments.
+func logicalLines(content string) []string {
+	var lines []string
+	var current strings.Builder
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			current.WriteString(strings.TrimSuffix(line, `\`))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+		if s := strings.TrimSpace(current.String()); s != "" {
+			lines = append(lines, s)
+		}
+		current.Reset()
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		lines = append(lines, s)
+	}
+	return lines
+}
+
+// parseFrom returns the image reference and stage name of a FROM line.
+func parseFrom(args []string) (ref, name string) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return "", ""
+	}
+	ref = args[0]
+	if len(args) >= 3 && strings.EqualFold(args[1], "AS") {
+		name = args[2]
+	}
+	return ref, name
+}
+
+// parseRun finds package installs in the shell commands of a RUN line.
+func parseRun(script string) []Component {
+	var components []Component
+	for _, m := range githubRelease.FindAllStringSubmatch(script, -1) {
+		components = append(components, Component{Type: TypeGitHub, Name: m[1] + "/" + m[2], Version: m[3]})
+	}
+	for _, m := range githubClone.FindAllStringSubmatch(script, -1) {
+		components = append(components, Component{Type: TypeGitHub, Name: m[2] + "/" + m[3], Version: m[1]})
+	}
+
+	commands := strings.FieldsFunc(script, func(r rune) bool {
+		return strings.ContainsRune(";&|()", r)
+	})
+	for _, command := range commands {
+		words := strings.Fields(command)
+		for len(words) > 0 && strings.Contains(words[0], "=") {
+			words = words[1:] // Environment assignments
+		}
+		switch {
+		case hasPrefix(words, "apt-get", "install"), hasPrefix(words, "apt", "install"):
+			components = append(components, packages(TypeDeb, words[2:], "=", "-t", "--target-release", "-o")...)
+		case hasPrefix(words, "apk", "add"):
+			components = append(components, packages(TypeAPK, words[2:], "=", "-X", "--repository", "-t", "--virtual")...)
+		case hasPrefix(words, "uv", "pip", "install"):
+			components = append(components, packages(TypePyPI, words[3:], "==", pipValueFlags...)...)
+		case hasPrefix(words, "pip", "install"), hasPrefix(words, "pip3", "install"):
+			components = append(components, packages(TypePyPI, words[2:], "==", pipValueFlags...)...)
+		case hasPrefix(words, "npm", "install"), hasPrefix(words, "npm", "i"):
+			if contains(words, "-g") || contains(words, "--global") {
+				components = append(components, packages(TypeNPM, words[2:], "@")...)
+			}
+		case hasPrefix(words, "go", "install"):
+			components = append(components, packages(TypeGolang, words[2:], "@")...)
+		case hasPrefix(words, "cargo", "install"):
+			components = append(components, packages(TypeCargo, words[2:], "@", "--version", "--root", "--git")...)
+		}
+	}
+	return components
+}
+
+// pipValueFlags are pip options that take a value.
+var pipValueFlags = []string{"-r", "--requirement", "-c", "--constraint", "-e", "--editable", "-i", "--index-url", "--extra-index-url", "-t", "--target"}
+
+// packages turns install arguments into components, splitting name and
+// version on sep and skipping options, the values of valueFlags, paths, and
+// shell expansions.
+func packages(typ string, args []string, sep string, valueFlags ...string) []Component {
+	var components []Component
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if contains(valueFlags, arg) {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, "/") ||
+			strings.ContainsAny(arg, `$"'<>\`) {
+			continue
+		}
+		if strings.Contains(arg, "/") && typ != TypeGolang && typ != TypeNPM {
+			continue // A file path, not a package name
+		}
+		name, version := arg, ""
+		if i := strings.LastIndex(arg, sep); i > 0 {
+			name, version = arg[:i], arg[i+len(sep):]
+		}
+		if typ == TypePyPI {
+			// Drop extras and other specifiers: "black[jupyter]>=24" → "black"
+			if i := strings.IndexAny(name, "[<>=!~"); i > 0 {
+				name = name[:i]
+			}
+		}
+		components = append(components, Component{Type: typ, Name: name, Version: version})
+	}
+	return components
+}
+
+func hasPrefix(words []string, prefix ...string) bool {
+	if len(words) < len(prefix) {
+		return false
+	}
+	for i, p := range prefix {
+		if words[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDockerfile = `# syntax=docker/dockerfile:1
+FROM python:3.11-slim@sha256:4203 AS base
+
+RUN --mount=type=cache,target=/var/cache/apt \
+    rm -rf /var/lib/apt/lists/* && apt-get update && apt-get install -y --no-install-recommends --fix-broken \
+    build-essential \
+    && true
+
+RUN pip install -r /tmp/requirements.txt
+
+FROM debian:bookworm-slim@sha256:f065 AS neovim-builder
+RUN apt-get update && apt-get install -y --no-install-recommends curl ca-certificates && \
+    curl -fsSL -o "${NVIM_ARCH}.tar.gz" "https://github.com/neovim/neovim/releases/download/v0.11.6/${NVIM_ARCH}.tar.gz"
+
+FROM golang:1.25-alpine AS go-tools-builder
+RUN go install golang.org/x/tools/gopls@latest && \
+    go install github.com/go-delve/delve/cmd/dlv@v1.24.0
+
+FROM base AS dev
+COPY --from=neovim-builder /opt/nvim/ /opt/nvim/
+RUN apt-get install -y --no-install-recommends -t $(. /etc/os-release && echo "$VERSION_CODENAME")-backports git; \
+    apt-get install -y git ripgrep=14.1.0-1
+RUN npm install -g neovim || (unset HTTP_PROXY && npm install -g neovim @biomejs/biome@1.9.4)
+RUN uv pip install python-lsp-server black==24.1.0 \
+    || pip install python-lsp-server "black==24.1.0"
+RUN apk add --no-cache --virtual .deps neovim
+`
+
+func TestParseDockerfile_BaseImages(t *testing.T) {
+	bases, _ := ParseDockerfile(testDockerfile)
+
+	assert.Equal(t, []BaseImage{
+		{Ref: "python:3.11-slim", Digest: "sha256:4203", Stage: "base"},
+		{Ref: "debian:bookworm-slim", Digest: "sha256:f065", Stage: "neovim-builder", BuildOnly: true},
+		{Ref: "golang:1.25-alpine", Stage: "go-tools-builder", BuildOnly: true},
+	}, bases, "FROM <stage> is not a base image")
+}
+
+func TestParseDockerfile_Components(t *testing.T) {
+	_, components := ParseDockerfile(testDockerfile)
+
+	assert.Equal(t, []Component{
+		{Type: TypeDeb, Name: "build-essential", Stage: "base"},
+		{Type: TypeGitHub, Name: "neovim/neovim", Version: "0.11.6", Stage: "neovim-builder"},
+		{Type: TypeDeb, Name: "curl", Stage: "neovim-builder", BuildOnly: true},
+		{Type: TypeDeb, Name: "ca-certificates", Stage: "neovim-builder", BuildOnly: true},
+		{Type: TypeGolang, Name: "golang.org/x/tools/gopls", Version: "latest", Stage: "go-tools-builder"},
+		{Type: TypeGolang, Name: "github.com/go-delve/delve/cmd/dlv", Version: "v1.24.0", Stage: "go-tools-builder"},
+		{Type: TypeDeb, Name: "git", Stage: "dev"},
+		{Type: TypeDeb, Name: "ripgrep", Version: "14.1.0-1", Stage: "dev"},
+		{Type: TypeNPM, Name: "neovim", Stage: "dev"},
+		{Type: TypeNPM, Name: "@biomejs/biome", Version: "1.9.4", Stage: "dev"},
+		{Type: TypePyPI, Name: "python-lsp-server", Stage: "dev"},
+		{Type: TypePyPI, Name: "black", Version: "24.1.0", Stage: "dev"},
+		{Type: TypeAPK, Name: "neovim", Stage: "dev"},
+	}, components)
+}
+
+func TestParseDockerfile_SourceBuild(t *testing.T) {
+	_, components := ParseDockerfile(`FROM alpine:3.20
+RUN git clone --depth 1 --branch v0.11.6 https://github.com/neovim/neovim.git /tmp/nvim-src && make install
+`)
+
+	assert.Equal(t, []Component{
+		{Type: TypeGitHub, Name: "neovim/neovim", Version: "0.11.6", Stage: "alpine:3.20"},
+	}, components)
+}
@@ -0,0 +1,276 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// creator names the tool in generated documents.
+const creator = "dvm"
+
+// documentID derives a stable identifier from the image and build time, so
+// exporting the same build twice yields the same document.
+func (inv *Inventory) documentID() uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(inv.Image+"@"+inv.BuiltAt.Format(time.RFC3339Nano)))
+}
+
+// =============================================================================
+// SPDX 2.3
+// =============================================================================
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+func (inv *Inventory) spdx() spdxDocument {
+	const imageID = "SPDXRef-Image"
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              inv.Image,
+		DocumentNamespace: fmt.Sprintf("https://devopsmaestro.dev/spdx/%s/%s", inv.Workspace, inv.documentID()),
+		CreationInfo: spdxCreationInfo{
+			Created:  inv.BuiltAt.Format(time.RFC3339),
+			Creators: []string{"Tool: " + creator},
+		},
+		Packages: []spdxPackage{{
+			Name:                  inv.Image,
+			SPDXID:                imageID,
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: "CONTAINER",
+		}},
+		Relationships: []spdxRelationship{{
+			SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: imageID,
+		}},
+	}
+
+	for i, b := range inv.BaseImages {
+		id := fmt.Sprintf("SPDXRef-Base-%d", i+1)
+		pkg := spdxPackage{
+			Name:                  b.Ref,
+			SPDXID:                id,
+			VersionInfo:           b.Digest,
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: "CONTAINER",
+			ExternalRefs:          []spdxExternalRef{purlRef(b.PURL())},
+		}
+		if algorithm, value, ok := digestParts(b.Digest); ok {
+			pkg.Checksums = []spdxChecksum{{Algorithm: algorithm, ChecksumValue: value}}
+		}
+		if b.Stage != "" {
+			pkg.Comment = "Build stage: " + b.Stage
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		if b.BuildOnly {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{id, "BUILD_TOOL_OF", imageID})
+		} else {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{imageID, "DESCENDANT_OF", id})
+		}
+	}
+
+	for i, c := range inv.Components {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		pkg := spdxPackage{
+			Name:                  c.Name,
+			SPDXID:                id,
+			VersionInfo:           c.Version,
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: "LIBRARY",
+			ExternalRefs:          []spdxExternalRef{purlRef(c.PURL())},
+		}
+		if c.Type == TypeGitHub {
+			pkg.DownloadLocation = "https://github.com/" + c.Name
+			if !c.Plugin {
+				pkg.PrimaryPackagePurpose = "APPLICATION"
+			}
+		}
+		switch {
+		case c.Plugin:
+			pkg.Comment = "Neovim plugin"
+		case c.Stage != "":
+			pkg.Comment = "Build stage: " + c.Stage
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		if c.BuildOnly {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{id, "BUILD_TOOL_OF", imageID})
+		} else {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{imageID, "CONTAINS", id})
+		}
+	}
+	return doc
+}
+
+func purlRef(purl string) spdxExternalRef {
+	return spdxExternalRef{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purl}
+}
+
+// =============================================================================
+// CycloneDX 1.5
+// =============================================================================
+
+type cdxDocument struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Scope      string        `json:"scope,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Hashes     []cdxHash     `json:"hashes,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+func (inv *Inventory) cycloneDX() cdxDocument {
+	const imageRef = "image"
+	doc := cdxDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + inv.documentID().String(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: inv.BuiltAt.Format(time.RFC3339),
+			Tools:     cdxTools{Components: []cdxComponent{{Type: "application", Name: creator}}},
+			Component: cdxComponent{Type: "container", BOMRef: imageRef, Name: inv.Image},
+		},
+		Components: []cdxComponent{},
+	}
+	dependsOn := []string{}
+
+	for i, b := range inv.BaseImages {
+		ref := fmt.Sprintf("base-%d", i+1)
+		comp := cdxComponent{Type: "container", BOMRef: ref, Name: b.Ref, Version: b.Digest, PURL: b.PURL()}
+		if algorithm, value, ok := digestParts(b.Digest); ok {
+			comp.Hashes = []cdxHash{{Alg: "SHA-" + strings.TrimPrefix(algorithm, "SHA"), Content: value}}
+		}
+		comp.Properties = stageProperties(b.Stage, false)
+		if b.BuildOnly {
+			comp.Scope = "excluded"
+		} else {
+			dependsOn = append(dependsOn, ref)
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	for i, c := range inv.Components {
+		ref := fmt.Sprintf("component-%d", i+1)
+		comp := cdxComponent{Type: "library", BOMRef: ref, Name: c.Name, Version: c.Version, PURL: c.PURL()}
+		if c.Type == TypeGitHub && !c.Plugin {
+			comp.Type = "application"
+		}
+		comp.Properties = stageProperties(c.Stage, c.Plugin)
+		if c.BuildOnly {
+			comp.Scope = "excluded"
+		} else {
+			dependsOn = append(dependsOn, ref)
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	doc.Dependencies = []cdxDependency{{Ref: imageRef, DependsOn: dependsOn}}
+	return doc
+}
+
+func stageProperties(stage string, plugin bool) []cdxProperty {
+	var props []cdxProperty
+	if stage != "" {
+		props = append(props, cdxProperty{Name: "dvm:build-stage", Value: stage})
+	}
+	if plugin {
+		props = append(props, cdxProperty{Name: "dvm:neovim-plugin", Value: "true"})
+	}
+	return props
+}
+
+// digestParts splits an image digest ("sha256:…") into the SPDX algorithm
+// name and the hex value.
+func digestParts(digest string) (algorithm, value string, ok bool) {
+	algorithm, value, _ = strings.Cut(digest, ":")
+	switch {
+	case value == "":
+		return "", "", false
+	case algorithm == "sha256":
+		return "SHA256", value, true
+	case algorithm == "sha512":
+		return "SHA512", value, true
+	}
+	return "", "", false
+}
@@ -0,0 +1,188 @@
+// Package sbom records what went into a workspace image — its base images,
+// distro and language packages, Neovim, and Neovim plugin pins — and encodes
+// that inventory as an SPDX or CycloneDX software bill of materials.
+//
+// The inventory is derived at build time from the generated Dockerfile and
+// the workspace's resolved plugins, and stored as JSON with the build record
+// so a document can be exported later without rebuilding.
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"devopsmaestro/pkg/nvimbridge/pinning"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+)
+
+// Component types, named after their package URL types.
+const (
+	TypeDeb    = "deb"
+	TypeAPK    = "apk"
+	TypePyPI   = "pypi"
+	TypeNPM    = "npm"
+	TypeGolang = "golang"
+	TypeCargo  = "cargo"
+	TypeGitHub = "github"
+)
+
+// Output formats.
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatSPDX, FormatCycloneDX}
+
+// Inventory is everything recorded about one image build.
+type Inventory struct {
+	Workspace  string      `json:"workspace"`
+	Image      string      `json:"image"`
+	BuiltAt    time.Time   `json:"builtAt"`
+	BaseImages []BaseImage `json:"baseImages,omitempty"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// BaseImage is an image a build stage starts FROM.
+type BaseImage struct {
+	Ref    string `json:"ref"`
+	Digest string `json:"digest,omitempty"` // Empty when the image is not pinned
+	Stage  string `json:"stage,omitempty"`
+
+	// BuildOnly marks the bases of builder stages the final image does not
+	// inherit from.
+	BuildOnly bool `json:"buildOnly,omitempty"`
+}
+
+// Component is a package installed into the image, or a plugin Neovim loads.
+type Component struct {
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"` // A commit for pinned plugins
+	Stage   string `json:"stage,omitempty"`   // Build stage that installed it
+	Plugin  bool   `json:"plugin,omitempty"`  // A Neovim plugin
+
+	// BuildOnly marks distro packages installed in a builder stage that the
+	// final image does not inherit.
+	BuildOnly bool `json:"buildOnly,omitempty"`
+}
+
+// New builds the inventory of an image from its generated Dockerfile and the
+// Neovim plugins configured for it. lock holds the plugins' commit pins and
+// may be nil.
+func New(workspace, image string, builtAt time.Time, dockerfile string, plugins []*plugin.Plugin, lock *plugin.LockFile) *Inventory {
+	inv := &Inventory{Workspace: workspace, Image: image, BuiltAt: builtAt.UTC()}
+	inv.BaseImages, inv.Components = ParseDockerfile(dockerfile)
+	inv.Components = append(inv.Components, Plugins(plugins, lock)...)
+	return inv
+}
+
+// Plugins lists Neovim plugins as components. A plugin's version is its
+// pinned commit when lock has one, otherwise the tag or branch it tracks.
+func Plugins(plugins []*plugin.Plugin, lock *plugin.LockFile) []Component {
+	var components []Component
+	for _, p := range plugins {
+		if !strings.Contains(p.Repo, "/") {
+			continue
+		}
+		version := pinning.RefFor(p)
+		if lock != nil {
+			if entry, ok := lock.Entries[pinning.ShortName(p.Repo)]; ok && entry.Commit != "" {
+				version = entry.Commit
+			}
+		}
+		components = append(components, Component{
+			Type:    TypeGitHub,
+			Name:    strings.TrimSuffix(p.Repo, ".git"),
+			Version: version,
+			Plugin:  true,
+		})
+	}
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Name < components[j].Name })
+	return components
+}
+
+// Parse decodes an inventory stored with a build record.
+func Parse(data string) (*Inventory, error) {
+	var inv Inventory
+	if err := json.Unmarshal([]byte(data), &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM: %w", err)
+	}
+	return &inv, nil
+}
+
+// Marshal encodes the inventory for storage with a build record.
+func (inv *Inventory) Marshal() (string, error) {
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Encode renders the inventory as an SBOM document in the given format.
+func (inv *Inventory) Encode(format string) ([]byte, error) {
+	var doc any
+	switch format {
+	case FormatSPDX:
+		doc = inv.spdx()
+	case FormatCycloneDX:
+		doc = inv.cycloneDX()
+	default:
+		return nil, fmt.Errorf("unknown SBOM format %q (supported: %s)", format, strings.Join(Formats, ", "))
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// PURL returns the component's package URL.
+func (c Component) PURL() string {
+	name := c.Name
+	switch c.Type {
+	case TypeDeb:
+		name = "debian/" + name
+	case TypeAPK:
+		name = "alpine/" + name
+	case TypePyPI:
+		name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	case TypeNPM:
+		name = strings.Replace(name, "@", "%40", 1)
+	}
+	purl := "pkg:" + c.Type + "/" + name
+	if c.Version != "" {
+		purl += "@" + escapeVersion(c.Version)
+	}
+	return purl
+}
+
+// PURL returns the base image's package URL.
+func (b BaseImage) PURL() string {
+	name := b.Ref
+	tag := ""
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	purl := "pkg:docker/" + name
+	if b.Digest != "" {
+		purl += "@" + escapeVersion(b.Digest)
+	}
+	if tag != "" {
+		purl += "?tag=" + url.QueryEscape(tag)
+	}
+	return purl
+}
+
+// escapeVersion percent-encodes a package URL version, including the ':' of
+// digests.
+func escapeVersion(v string) string {
+	return strings.ReplaceAll(url.PathEscape(v), ":", "%3A")
+}
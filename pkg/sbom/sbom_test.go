@@ -0,0 +1,130 @@
+package sbom
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/rmkohlman/MaestroNvim/nvimops/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInventory() *Inventory {
+	plugins := []*plugin.Plugin{
+		{Name: "telescope", Repo: "nvim-telescope/telescope.nvim", Branch: "master"},
+		{Name: "lspconfig", Repo: "neovim/nvim-lspconfig", Version: "v1.2.0"},
+		{Name: "local", Repo: "local-plugin"},
+	}
+	lock := plugin.NewLockFile()
+	lock.Entries["telescope.nvim"] = plugin.LockEntry{Branch: "master", Commit: "a0bbec2"}
+
+	built := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	return New("dev", "dvm-dev-api:20261017-093000", built, testDockerfile, plugins, lock)
+}
+
+func TestPlugins(t *testing.T) {
+	inv := testInventory()
+
+	var plugins []Component
+	for _, c := range inv.Components {
+		if c.Plugin {
+			plugins = append(plugins, c)
+		}
+	}
+	assert.Equal(t, []Component{
+		{Type: TypeGitHub, Name: "neovim/nvim-lspconfig", Version: "v1.2.0", Plugin: true},
+		{Type: TypeGitHub, Name: "nvim-telescope/telescope.nvim", Version: "a0bbec2", Plugin: true},
+	}, plugins, "pinned commits win over the tracked ref; plugins without a GitHub repo are skipped")
+}
+
+func TestPURL(t *testing.T) {
+	tests := []struct {
+		component Component
+		want      string
+	}{
+		{Component{Type: TypeDeb, Name: "git"}, "pkg:deb/debian/git"},
+		{Component{Type: TypeAPK, Name: "neovim", Version: "0.10.0-r0"}, "pkg:apk/alpine/neovim@0.10.0-r0"},
+		{Component{Type: TypePyPI, Name: "Python_LSP_Server", Version: "1.0"}, "pkg:pypi/python-lsp-server@1.0"},
+		{Component{Type: TypeNPM, Name: "@biomejs/biome", Version: "1.9.4"}, "pkg:npm/%40biomejs/biome@1.9.4"},
+		{Component{Type: TypeGolang, Name: "golang.org/x/tools/gopls", Version: "latest"}, "pkg:golang/golang.org/x/tools/gopls@latest"},
+		{Component{Type: TypeGitHub, Name: "neovim/neovim", Version: "0.11.6"}, "pkg:github/neovim/neovim@0.11.6"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.component.PURL())
+	}
+
+	base := BaseImage{Ref: "python:3.11-slim", Digest: "sha256:4203"}
+	assert.Equal(t, "pkg:docker/python@sha256%3A4203?tag=3.11-slim", base.PURL())
+	assert.Equal(t, "pkg:docker/localhost:5000/tools", BaseImage{Ref: "localhost:5000/tools"}.PURL())
+}
+
+func TestMarshalParse_RoundTrip(t *testing.T) {
+	inv := testInventory()
+
+	data, err := inv.Marshal()
+	require.NoError(t, err)
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, inv, parsed)
+
+	_, err = Parse("not json")
+	assert.Error(t, err)
+}
+
+func TestEncode_SPDX(t *testing.T) {
+	inv := testInventory()
+
+	data, err := inv.Encode(FormatSPDX)
+	require.NoError(t, err)
+	again, err := inv.Encode(FormatSPDX)
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(again), "documents are reproducible")
+
+	var doc spdxDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Equal(t, "2026-10-17T09:30:00Z", doc.CreationInfo.Created)
+	require.Len(t, doc.Packages, 1+len(inv.BaseImages)+len(inv.Components))
+	assert.Equal(t, "SPDXRef-Image", doc.Packages[0].SPDXID)
+
+	base := doc.Packages[1]
+	assert.Equal(t, "python:3.11-slim", base.Name)
+	assert.Equal(t, []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: "4203"}}, base.Checksums)
+	assert.Contains(t, doc.Relationships, spdxRelationship{"SPDXRef-Image", "DESCENDANT_OF", "SPDXRef-Base-1"})
+	assert.Contains(t, doc.Relationships, spdxRelationship{"SPDXRef-Base-2", "BUILD_TOOL_OF", "SPDXRef-Image"})
+}
+
+func TestEncode_CycloneDX(t *testing.T) {
+	inv := testInventory()
+
+	data, err := inv.Encode(FormatCycloneDX)
+	require.NoError(t, err)
+
+	var doc cdxDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, "1.5", doc.SpecVersion)
+	assert.Equal(t, "dvm-dev-api:20261017-093000", doc.Metadata.Component.Name)
+	require.Len(t, doc.Components, len(inv.BaseImages)+len(inv.Components))
+
+	var curl, telescope cdxComponent
+	for _, c := range doc.Components {
+		switch c.Name {
+		case "curl":
+			curl = c
+		case "nvim-telescope/telescope.nvim":
+			telescope = c
+		}
+	}
+	assert.Equal(t, "excluded", curl.Scope, "builder-stage packages are not in the image")
+	assert.Equal(t, "pkg:github/nvim-telescope/telescope.nvim@a0bbec2", telescope.PURL)
+	require.Len(t, doc.Dependencies, 1)
+	assert.NotContains(t, doc.Dependencies[0].DependsOn, "base-2")
+	assert.Contains(t, doc.Dependencies[0].DependsOn, "base-1")
+}
+
+func TestEncode_UnknownFormat(t *testing.T) {
+	_, err := testInventory().Encode("swid")
+	assert.ErrorContains(t, err, "unknown SBOM format")
+}
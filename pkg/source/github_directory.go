@@ -4,14 +4,12 @@ package source
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
+	"devopsmaestro/pkg/githubapi"
 	"devopsmaestro/pkg/secrets"
 	"devopsmaestro/pkg/secrets/providers"
 )
@@ -97,53 +95,16 @@ func (s *GitHubDirectorySource) ListFiles() ([]Source, error) {
 		return nil, fmt.Errorf("invalid GitHub directory source: missing owner or repo in %q", s.Original)
 	}
 
-	// Build the GitHub API URL
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", s.Owner, s.Repo, s.Path)
+	path := fmt.Sprintf("/repos/%s/%s/contents/%s", s.Owner, s.Repo, s.Path)
 	if s.Branch != "" && s.Branch != "main" {
-		apiURL += "?ref=" + s.Branch
+		path += "?ref=" + url.QueryEscape(s.Branch)
 	}
 
-	slog.Debug("fetching GitHub directory listing", "url", apiURL, "owner", s.Owner, "repo", s.Repo, "path", s.Path)
+	slog.Debug("fetching GitHub directory listing", "path", path, "owner", s.Owner, "repo", s.Repo)
 
-	// Create HTTP request
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add authorization header if GitHub token is available
-	if token := getGitHubToken(); token != "" {
-		req.Header.Set("Authorization", "token "+token)
-	}
-
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "dvm")
-
-	// Make the request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch directory listing: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Handle rate limiting
-	if resp.StatusCode == http.StatusForbidden {
-		remaining := resp.Header.Get("X-RateLimit-Remaining")
-		if remaining == "0" {
-			return nil, fmt.Errorf("GitHub API rate limit exceeded. Set GITHUB_TOKEN env var for higher limits (5000/hour vs 60/hour)")
-		}
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitHub API error %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse the response
 	var files []GitHubFile
-	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
-		return nil, fmt.Errorf("failed to parse GitHub API response: %w", err)
+	if err := githubapi.NewClient(getGitHubToken()).Get(context.Background(), path, &files); err != nil {
+		return nil, fmt.Errorf("failed to fetch directory listing: %w", err)
 	}
 
 	// Filter for YAML files only
@@ -185,6 +146,12 @@ func (s *GitHubSource) Name() string {
 	return s.Original
 }
 
+// GitHubToken returns the GitHub token from the secret provider chain used
+// by GitHub sources, or an empty string if none is configured.
+func GitHubToken() string {
+	return getGitHubToken()
+}
+
 // getGitHubToken retrieves the GitHub token using the secret provider system.
 // It tries providers in this order:
 //  1. MaestroVault - looks for "github-token" secret
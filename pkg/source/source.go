@@ -25,6 +25,8 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"devopsmaestro/pkg/httpclient"
 )
 
 // Source represents a location that can provide resource data.
@@ -184,7 +186,7 @@ func (s *URLSource) Read() ([]byte, string, error) {
 
 	slog.Debug("fetching URL", "url", s.URL)
 
-	client := &http.Client{Timeout: timeout}
+	client := httpclient.New(timeout)
 	resp, err := client.Get(s.URL)
 	if err != nil {
 		slog.Error("HTTP request failed", "url", s.URL, "error", err)
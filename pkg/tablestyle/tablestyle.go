@@ -0,0 +1,266 @@
+// Package tablestyle draws table output in the colors of the resolved theme.
+//
+// The SDK's table renderers only see a context when called through the
+// *WithContext entry points, which commands do not use, so tables were always
+// drawn in the SDK's fixed colors. Register wraps the table-drawing renderers
+// in the render registry with one bound to the command's ColorProvider:
+//
+//   - headers in the theme's accent color, borders in its border color
+//   - the row of the active resource (NAME starting with ActiveMarker) in
+//     the primary color
+//   - STATUS and STATE cells by meaning: running green, drifted or pending
+//     yellow, failed red, stopped muted
+//
+// Colors are written in the richest form the output supports: true color,
+// 256 or 16 colors, or none when the output is not a terminal or --no-color
+// is set.
+package tablestyle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/muesli/termenv"
+	"github.com/rmkohlman/MaestroSDK/colors"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// ActiveMarker prefixes the name of the active resource in a table.
+const ActiveMarker = "● "
+
+// Level is the meaning of a status cell.
+type Level int
+
+const (
+	// LevelNone is a status without a color.
+	LevelNone Level = iota
+	// LevelOK is a healthy or finished status.
+	LevelOK
+	// LevelWarn is a transitional or drifted status.
+	LevelWarn
+	// LevelError is a failed status.
+	LevelError
+	// LevelMuted is an inactive status.
+	LevelMuted
+)
+
+// statusLevels maps the first word of a status cell to its meaning.
+var statusLevels = map[string]Level{
+	"running":   LevelOK,
+	"ready":     LevelOK,
+	"healthy":   LevelOK,
+	"active":    LevelOK,
+	"succeeded": LevelOK,
+	"completed": LevelOK,
+	"success":   LevelOK,
+	"synced":    LevelOK,
+	"enabled":   LevelOK,
+
+	"pending":     LevelWarn,
+	"building":    LevelWarn,
+	"queued":      LevelWarn,
+	"starting":    LevelWarn,
+	"stopping":    LevelWarn,
+	"restarting":  LevelWarn,
+	"partial":     LevelWarn,
+	"interrupted": LevelWarn,
+	"outdated":    LevelWarn,
+	"stale":       LevelWarn,
+	"unknown":     LevelWarn,
+
+	"failed":    LevelError,
+	"error":     LevelError,
+	"unhealthy": LevelError,
+	"missing":   LevelError,
+	"dead":      LevelError,
+
+	"stopped":   LevelMuted,
+	"exited":    LevelMuted,
+	"created":   LevelMuted,
+	"disabled":  LevelMuted,
+	"cancelled": LevelMuted,
+}
+
+// StatusLevel classifies a status cell. A "(drifted)" suffix makes any
+// status a warning.
+func StatusLevel(cell string) Level {
+	cell = strings.ToLower(strings.TrimSpace(cell))
+	if strings.Contains(cell, "(drifted)") {
+		return LevelWarn
+	}
+	word, _, _ := strings.Cut(cell, " ")
+	return statusLevels[word]
+}
+
+// statusColumns are the headers whose cells are colored by StatusLevel.
+var statusColumns = map[string]bool{"STATUS": true, "STATE": true}
+
+// Palette holds the styles a table is drawn with.
+type Palette struct {
+	Header lipgloss.Style
+	Cell   lipgloss.Style
+	Border lipgloss.Style
+	Active lipgloss.Style
+	Levels map[Level]lipgloss.Style
+}
+
+// NewPalette builds table styles from a ColorProvider for the lipgloss
+// renderer r, which decides how colors are downgraded. Empty colors (as
+// from colors.NoColorProvider) leave text uncolored.
+func NewPalette(r *lipgloss.Renderer, p colors.ColorProvider) Palette {
+	fg := func(hex string) lipgloss.Style {
+		s := r.NewStyle()
+		if hex != "" {
+			s = s.Foreground(lipgloss.Color(hex))
+		}
+		return s
+	}
+	return Palette{
+		Header: fg(p.Accent()).Bold(true),
+		Cell:   fg(p.Foreground()),
+		Border: fg(p.Border()),
+		Active: fg(p.Primary()).Bold(true),
+		Levels: map[Level]lipgloss.Style{
+			LevelOK:    fg(p.Success()),
+			LevelWarn:  fg(p.Warning()),
+			LevelError: fg(p.Error()),
+			LevelMuted: fg(p.Muted()),
+		},
+	}
+}
+
+// Renderer draws render.TableData with a Palette and hands everything else
+// to the renderer it wraps.
+type Renderer struct {
+	render.Renderer
+	provider colors.ColorProvider
+	noColor  bool
+}
+
+// Wrap returns inner with its tables drawn in the colors of provider. An
+// already wrapped renderer is rewrapped rather than nested.
+func Wrap(inner render.Renderer, provider colors.ColorProvider, noColor bool) *Renderer {
+	if w, ok := inner.(*Renderer); ok {
+		inner = w.Renderer
+	}
+	return &Renderer{Renderer: inner, provider: provider, noColor: noColor}
+}
+
+// Register wraps the table, colored, and pretty renderers in the render
+// registry so every table is drawn in the colors of provider.
+func Register(provider colors.ColorProvider, noColor bool) {
+	for _, name := range []render.RendererName{render.RendererTable, render.RendererColored, render.RendererPretty} {
+		if r := render.Get(name); r != nil {
+			render.Register(Wrap(r, provider, noColor))
+		}
+	}
+}
+
+// Render implements render.Renderer.
+func (r *Renderer) Render(w io.Writer, data any, opts render.Options) error {
+	return r.RenderWithContext(context.Background(), w, data, opts)
+}
+
+// RenderWithContext implements render.Renderer. A ColorProvider in ctx
+// takes precedence over the one the renderer was registered with.
+func (r *Renderer) RenderWithContext(ctx context.Context, w io.Writer, data any, opts render.Options) error {
+	td, ok := data.(render.TableData)
+	if !ok || opts.Empty || len(td.Rows) == 0 {
+		return r.Renderer.RenderWithContext(ctx, w, data, opts)
+	}
+	provider := r.provider
+	if p, ok := colors.FromContext(ctx); ok {
+		provider = p
+	}
+	if provider == nil {
+		provider = colors.Default()
+	}
+	lr := lipgloss.NewRenderer(w)
+	if r.noColor {
+		lr.SetColorProfile(termenv.Ascii)
+	}
+	_, err := fmt.Fprintln(w, Table(td, NewPalette(lr, provider)))
+	return err
+}
+
+// Table draws td as a bordered table in the styles of p.
+func Table(td render.TableData, p Palette) string {
+	headers, rows := truncate(td)
+
+	statusCols := make(map[int]bool)
+	for i, h := range headers {
+		if statusColumns[strings.ToUpper(strings.TrimSpace(h))] {
+			statusCols[i] = true
+		}
+	}
+	activeRows := make(map[int]bool)
+	for i, row := range rows {
+		for _, cell := range row {
+			if strings.HasPrefix(cell, ActiveMarker) {
+				activeRows[i] = true
+				break
+			}
+		}
+	}
+
+	header := p.Header.Padding(0, 1)
+	cell := p.Cell.Padding(0, 1)
+	active := p.Active.Padding(0, 1)
+	levels := make(map[Level]lipgloss.Style, len(p.Levels))
+	for level, s := range p.Levels {
+		levels[level] = s.Padding(0, 1)
+	}
+
+	t := table.New().
+		Headers(headers...).
+		Rows(rows...).
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(p.Border).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return header
+			}
+			if statusCols[col] && row < len(rows) && col < len(rows[row]) {
+				if s, ok := levels[StatusLevel(rows[row][col])]; ok {
+					return s
+				}
+			}
+			if activeRows[row] {
+				return active
+			}
+			return cell
+		})
+	return t.Render()
+}
+
+// truncate applies td's column constraints to headers and cells.
+func truncate(td render.TableData) ([]string, [][]string) {
+	if len(td.Constraints) == 0 {
+		return td.Headers, td.Rows
+	}
+	fit := func(i int, s string) string {
+		if i < len(td.Constraints) {
+			c := td.Constraints[i]
+			if c.MaxWidth > 0 && len([]rune(s)) > c.MaxWidth {
+				return render.ApplyTruncation(s, c.MaxWidth, c.Truncate)
+			}
+		}
+		return s
+	}
+	headers := make([]string, len(td.Headers))
+	for i, h := range td.Headers {
+		headers[i] = fit(i, h)
+	}
+	rows := make([][]string, len(td.Rows))
+	for ri, row := range td.Rows {
+		rows[ri] = make([]string, len(row))
+		for ci, c := range row {
+			rows[ri][ci] = fit(ci, c)
+		}
+	}
+	return headers, rows
+}
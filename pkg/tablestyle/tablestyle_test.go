@@ -0,0 +1,141 @@
+package tablestyle
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/rmkohlman/MaestroSDK/colors"
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// testProvider returns fixed, distinct colors.
+type testProvider struct{ colors.ColorProvider }
+
+func (testProvider) Primary() string    { return "#0000ff" }
+func (testProvider) Accent() string     { return "#ff00ff" }
+func (testProvider) Success() string    { return "#00ff00" }
+func (testProvider) Warning() string    { return "#ffff00" }
+func (testProvider) Error() string      { return "#ff0000" }
+func (testProvider) Foreground() string { return "#ffffff" }
+func (testProvider) Muted() string      { return "#808080" }
+func (testProvider) Border() string     { return "#404040" }
+
+var testData = render.TableData{
+	Headers: []string{"NAME", "STATUS"},
+	Rows: [][]string{
+		{"● dev", "running"},
+		{"staging", "running (drifted)"},
+		{"old", "stopped"},
+		{"broken", "failed"},
+	},
+}
+
+func renderWith(profile termenv.Profile) string {
+	r := lipgloss.NewRenderer(&bytes.Buffer{})
+	r.SetColorProfile(profile)
+	return Table(testData, NewPalette(r, testProvider{}))
+}
+
+// cellLine returns the line of out containing text.
+func cellLine(t *testing.T, out, text string) string {
+	t.Helper()
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, text) {
+			return line
+		}
+	}
+	t.Fatalf("output has no line containing %q:\n%s", text, out)
+	return ""
+}
+
+func TestStatusLevel(t *testing.T) {
+	tests := map[string]Level{
+		"running":           LevelOK,
+		"Running":           LevelOK,
+		"running (drifted)": LevelWarn,
+		"building":          LevelWarn,
+		"failed":            LevelError,
+		"stopped":           LevelMuted,
+		"-":                 LevelNone,
+		"":                  LevelNone,
+	}
+	for cell, want := range tests {
+		if got := StatusLevel(cell); got != want {
+			t.Errorf("StatusLevel(%q) = %v, want %v", cell, got, want)
+		}
+	}
+}
+
+func TestTable_TrueColor(t *testing.T) {
+	out := renderWith(termenv.TrueColor)
+	for text, seq := range map[string]string{
+		"NAME":              "38;2;255;0;255", // header: accent
+		"● dev":             "38;2;0;0;255",   // active row: primary
+		"running (drifted)": "38;2;255;255;0", // drifted: warning
+		"stopped":           "38;2;128;128;128",
+		"failed":            "38;2;255;0;0",
+	} {
+		if line := cellLine(t, out, text); !strings.Contains(line, seq) {
+			t.Errorf("line for %q has no %s color:\n%q", text, seq, line)
+		}
+	}
+	if line := cellLine(t, out, "● dev"); !strings.Contains(line, "38;2;0;255;0") {
+		t.Errorf("running status is not green:\n%q", line)
+	}
+}
+
+func TestTable_Downgrade(t *testing.T) {
+	out := renderWith(termenv.ANSI256)
+	if strings.Contains(out, "38;2;") {
+		t.Errorf("256-color output contains true-color sequences:\n%q", out)
+	}
+	if !strings.Contains(out, "38;5;") {
+		t.Errorf("256-color output has no 256-color sequences:\n%q", out)
+	}
+
+	out = renderWith(termenv.ANSI)
+	if strings.Contains(out, "38;5;") || strings.Contains(out, "38;2;") {
+		t.Errorf("16-color output contains extended color sequences:\n%q", out)
+	}
+}
+
+func TestRenderer_NoColor(t *testing.T) {
+	inner := render.Get(render.RendererTable)
+	var buf bytes.Buffer
+	r := Wrap(inner, testProvider{}, true)
+	if err := r.Render(&buf, testData, render.Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("--no-color output contains escape sequences:\n%q", buf.String())
+	}
+	for _, text := range []string{"NAME", "● dev", "running (drifted)"} {
+		cellLine(t, buf.String(), text)
+	}
+}
+
+func TestRenderer_DelegatesNonTables(t *testing.T) {
+	var got, want bytes.Buffer
+	inner := render.Get(render.RendererTable)
+	data := render.KeyValueData{Pairs: []render.KeyValue{{Key: "Name", Value: "dev"}}}
+	if err := inner.Render(&want, data, render.Options{}); err != nil {
+		t.Fatalf("inner Render() error = %v", err)
+	}
+	if err := Wrap(inner, testProvider{}, true).Render(&got, data, render.Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got.String() != want.String() {
+		t.Errorf("Render() = %q, want the inner renderer's %q", got.String(), want.String())
+	}
+}
+
+func TestWrap_DoesNotNest(t *testing.T) {
+	inner := render.Get(render.RendererTable)
+	w := Wrap(Wrap(inner, testProvider{}, false), testProvider{}, true)
+	if _, nested := w.Renderer.(*Renderer); nested {
+		t.Error("Wrap() nested a wrapped renderer")
+	}
+}
@@ -0,0 +1,192 @@
+// Package tableview shapes list output before it is rendered: it sorts the
+// rows of a render.TableData by a column, keeps a chosen set of columns, and
+// returns one page of rows.
+//
+// Columns are named by their header ("LAST-ATTACHED") or by the json/yaml
+// field of the header ("lastAttached"); case and punctuation are ignored.
+package tableview
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+// Options selects the part of a list that is shown. The zero value shows
+// everything.
+type Options struct {
+	// Limit is the maximum number of rows; 0 means no limit.
+	Limit int
+	// Offset is the number of rows skipped before the first one shown.
+	Offset int
+	// SortBy is the column rows are sorted by, ascending; a leading "-"
+	// sorts descending. Empty keeps the command's order.
+	SortBy string
+	// Columns are the columns shown, in order. Empty shows all.
+	Columns []string
+}
+
+// IsZero reports whether o leaves a list unchanged.
+func (o Options) IsZero() bool {
+	return o.Limit == 0 && o.Offset == 0 && o.SortBy == "" && len(o.Columns) == 0
+}
+
+// Paged reports whether o selects a page of rows.
+func (o Options) Paged() bool {
+	return o.Limit > 0 || o.Offset > 0
+}
+
+// WithoutPaging returns o without Limit and Offset, for lists that were
+// already paged by the query that loaded them.
+func (o Options) WithoutPaging() Options {
+	o.Limit, o.Offset = 0, 0
+	return o
+}
+
+// Validate rejects negative paging values.
+func (o Options) Validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("--limit must not be negative (got %d)", o.Limit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("--offset must not be negative (got %d)", o.Offset)
+	}
+	return nil
+}
+
+// Apply sorts td, pages its rows, and keeps the selected columns. It fails
+// when SortBy or Columns name a column td does not have.
+func (o Options) Apply(td render.TableData) (render.TableData, error) {
+	if err := o.Validate(); err != nil {
+		return td, err
+	}
+	rows := td.Rows
+	if o.SortBy != "" {
+		key, desc := strings.CutPrefix(o.SortBy, "-")
+		col, err := column(td.Headers, key)
+		if err != nil {
+			return td, fmt.Errorf("--sort-by: %w", err)
+		}
+		rows = sortRows(rows, col, desc)
+	}
+	start, end := o.Page(len(rows))
+	rows = rows[start:end]
+
+	out := render.TableData{Headers: td.Headers, Rows: rows, Constraints: td.Constraints}
+	if len(o.Columns) == 0 {
+		return out, nil
+	}
+	cols := make([]int, len(o.Columns))
+	for i, name := range o.Columns {
+		col, err := column(td.Headers, name)
+		if err != nil {
+			return td, fmt.Errorf("--columns: %w", err)
+		}
+		cols[i] = col
+	}
+	out.Headers = make([]string, len(cols))
+	for i, col := range cols {
+		out.Headers[i] = td.Headers[col]
+	}
+	if len(td.Constraints) > 0 {
+		out.Constraints = make([]render.ColumnConstraint, len(cols))
+		for i, col := range cols {
+			if col < len(td.Constraints) {
+				out.Constraints[i] = td.Constraints[col]
+			}
+		}
+	}
+	out.Rows = make([][]string, len(rows))
+	for ri, row := range rows {
+		out.Rows[ri] = make([]string, len(cols))
+		for i, col := range cols {
+			if col < len(row) {
+				out.Rows[ri][i] = row[col]
+			}
+		}
+	}
+	return out, nil
+}
+
+// Page returns the bounds of the page of a list of n items.
+func (o Options) Page(n int) (start, end int) {
+	start = min(max(o.Offset, 0), n)
+	end = n
+	if o.Limit > 0 {
+		end = min(start+o.Limit, n)
+	}
+	return start, end
+}
+
+// PageSlice returns the page of a slice, or data unchanged when it is not a
+// slice.
+func (o Options) PageSlice(data any) any {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice || !o.Paged() {
+		return data
+	}
+	start, end := o.Page(v.Len())
+	return v.Slice(start, end).Interface()
+}
+
+// column returns the index of the header named name.
+func column(headers []string, name string) (int, error) {
+	want := normalize(name)
+	for i, h := range headers {
+		if normalize(h) == want {
+			return i, nil
+		}
+	}
+	names := make([]string, len(headers))
+	for i, h := range headers {
+		names[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+	return 0, fmt.Errorf("unknown column %q (columns: %s)", name, strings.Join(names, ", "))
+}
+
+// normalize folds "LAST-ATTACHED", "last_attached", and "lastAttached" to
+// the same key.
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// sortRows returns rows stably sorted by column col. Cells that are both
+// numbers compare numerically; others compare case-insensitively.
+func sortRows(rows [][]string, col int, desc bool) [][]string {
+	sorted := make([][]string, len(rows))
+	copy(sorted, rows)
+	cell := func(row []string) string {
+		if col < len(row) {
+			return strings.TrimSpace(row[col])
+		}
+		return ""
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := cell(sorted[i]), cell(sorted[j])
+		if desc {
+			a, b = b, a
+		}
+		return less(a, b)
+	})
+	return sorted
+}
+
+func less(a, b string) bool {
+	na, errA := strconv.ParseFloat(a, 64)
+	nb, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
@@ -0,0 +1,111 @@
+package tableview
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroSDK/render"
+)
+
+var workspaces = render.TableData{
+	Headers: []string{"NAME", "APP", "STATUS", "LAST-ATTACHED"},
+	Rows: [][]string{
+		{"dev", "api", "running", "10"},
+		{"Beta", "web", "stopped", "2"},
+		{"alpha", "api", "failed", "33"},
+	},
+}
+
+func names(td render.TableData) []string {
+	var out []string
+	for _, row := range td.Rows {
+		out = append(out, row[0])
+	}
+	return out
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		wantNames []string
+	}{
+		{name: "zero", opts: Options{}, wantNames: []string{"dev", "Beta", "alpha"}},
+		{name: "sort by name", opts: Options{SortBy: "name"}, wantNames: []string{"alpha", "Beta", "dev"}},
+		{name: "sort descending", opts: Options{SortBy: "-name"}, wantNames: []string{"dev", "Beta", "alpha"}},
+		{name: "sort numeric by field name", opts: Options{SortBy: "lastAttached"}, wantNames: []string{"Beta", "dev", "alpha"}},
+		{name: "limit", opts: Options{Limit: 2}, wantNames: []string{"dev", "Beta"}},
+		{name: "offset", opts: Options{Offset: 1}, wantNames: []string{"Beta", "alpha"}},
+		{name: "sort then page", opts: Options{SortBy: "name", Limit: 1, Offset: 1}, wantNames: []string{"Beta"}},
+		{name: "offset past end", opts: Options{Offset: 10}, wantNames: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.Apply(workspaces)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if !reflect.DeepEqual(names(got), tt.wantNames) {
+				t.Errorf("Apply() rows = %v, want %v", names(got), tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestApply_Columns(t *testing.T) {
+	td := workspaces
+	td.Constraints = []render.ColumnConstraint{{MaxWidth: 10}, {}, {}, {MaxWidth: 4}}
+	got, err := Options{Columns: []string{"last-attached", "NAME"}}.Apply(td)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := []string{"LAST-ATTACHED", "NAME"}; !reflect.DeepEqual(got.Headers, want) {
+		t.Errorf("Headers = %v, want %v", got.Headers, want)
+	}
+	if want := []string{"10", "dev"}; !reflect.DeepEqual(got.Rows[0], want) {
+		t.Errorf("Rows[0] = %v, want %v", got.Rows[0], want)
+	}
+	if got.Constraints[0].MaxWidth != 4 || got.Constraints[1].MaxWidth != 10 {
+		t.Errorf("Constraints = %+v, want them to follow their columns", got.Constraints)
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	for _, opts := range []Options{
+		{SortBy: "size"},
+		{Columns: []string{"name", "size"}},
+		{Limit: -1},
+		{Offset: -1},
+	} {
+		if _, err := opts.Apply(workspaces); err == nil {
+			t.Errorf("Apply(%+v) succeeded", opts)
+		}
+	}
+	_, err := Options{SortBy: "size"}.Apply(workspaces)
+	if err == nil || !strings.Contains(err.Error(), "name, app, status, last-attached") {
+		t.Errorf("unknown column error = %v, want it to list the columns", err)
+	}
+}
+
+func TestApply_DoesNotModifyInput(t *testing.T) {
+	if _, err := (Options{SortBy: "name"}).Apply(workspaces); err != nil {
+		t.Fatal(err)
+	}
+	if workspaces.Rows[0][0] != "dev" {
+		t.Error("Apply() reordered the input rows")
+	}
+}
+
+func TestPageSlice(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	if got := (Options{Limit: 2, Offset: 1}).PageSlice(items); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("PageSlice() = %v", got)
+	}
+	if got := (Options{}).PageSlice(items); !reflect.DeepEqual(got, items) {
+		t.Errorf("PageSlice() without paging = %v", got)
+	}
+	if got := (Options{Limit: 1}).PageSlice("not a slice"); got != "not a slice" {
+		t.Errorf("PageSlice(string) = %v", got)
+	}
+}
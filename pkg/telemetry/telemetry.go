@@ -0,0 +1,123 @@
+// Package telemetry provides optional OpenTelemetry tracing and metrics.
+//
+// Nothing is exported unless DVM_OTEL_ENDPOINT is set. Init then installs
+// global trace and meter providers that send OTLP over HTTP to that
+// endpoint; otherwise the OpenTelemetry no-op providers stay in place and
+// spans and counters cost next to nothing.
+//
+// # Usage
+//
+//	shutdown, err := telemetry.Init(ctx, "dvm", version)
+//	defer shutdown(ctx)
+//
+//	ctx, span := telemetry.Start(ctx, "dvm.build.image")
+//	err := build(ctx)
+//	telemetry.End(span, err)
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EnvEndpoint names the environment variable holding the OTLP/HTTP endpoint
+// URL (e.g., http://localhost:4318). Telemetry is disabled when it is unset.
+const EnvEndpoint = "DVM_OTEL_ENDPOINT"
+
+// instrumentationName identifies dvm's tracer and meter.
+const instrumentationName = "devopsmaestro"
+
+// Enabled reports whether telemetry export is configured.
+func Enabled() bool {
+	return os.Getenv(EnvEndpoint) != ""
+}
+
+// Init installs the global trace and meter providers when DVM_OTEL_ENDPOINT
+// is set. The returned shutdown flushes pending spans and metrics and must be
+// called before the process exits; it is a no-op when telemetry is disabled.
+func Init(ctx context.Context, service, version string) (shutdown func(context.Context) error, err error) {
+	endpoint := os.Getenv(EnvEndpoint)
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build telemetry resource: %w", err)
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
+
+// Start starts a span named name as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it failed with err when err is non-nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// RecordCommand counts one invocation of command, and one error when err is
+// non-nil.
+func RecordCommand(ctx context.Context, command string, err error) {
+	meter := otel.Meter(instrumentationName)
+	attrs := metric.WithAttributes(attribute.String("command", command))
+
+	if invocations, cerr := meter.Int64Counter("dvm.command.invocations",
+		metric.WithDescription("Number of dvm command invocations")); cerr == nil {
+		invocations.Add(ctx, 1, attrs)
+	}
+	if err == nil {
+		return
+	}
+	if errs, cerr := meter.Int64Counter("dvm.command.errors",
+		metric.WithDescription("Number of dvm commands that returned an error")); cerr == nil {
+		errs.Add(ctx, 1, attrs)
+	}
+}
@@ -0,0 +1,114 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInit_DisabledWithoutEndpoint(t *testing.T) {
+	t.Setenv(EnvEndpoint, "")
+
+	shutdown, err := Init(context.Background(), "dvm", "test")
+	require.NoError(t, err)
+	assert.False(t, Enabled())
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestInit_WithEndpoint(t *testing.T) {
+	var mu sync.Mutex
+	received := map[string]int{}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path]++
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	t.Setenv(EnvEndpoint, collector.URL)
+	prevTracer, prevMeter := otel.GetTracerProvider(), otel.GetMeterProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTracer)
+		otel.SetMeterProvider(prevMeter)
+	})
+
+	shutdown, err := Init(context.Background(), "dvm", "test")
+	require.NoError(t, err)
+	assert.True(t, Enabled())
+	_, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+	assert.True(t, ok, "Init should install the SDK tracer provider")
+
+	_, span := Start(context.Background(), "dvm test")
+	End(span, nil)
+	RecordCommand(context.Background(), "dvm test", nil)
+
+	// shutdown flushes the span and the counter to the collector
+	require.NoError(t, shutdown(context.Background()))
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, received["/v1/traces"])
+	assert.Equal(t, 1, received["/v1/metrics"])
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child", attribute.String("dvm.workspace", "dev"))
+	End(child, errors.New("boom"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("dvm.workspace", "dev"))
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestRecordCommand(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	prev := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(prev) })
+
+	ctx := context.Background()
+	RecordCommand(ctx, "dvm build", nil)
+	RecordCommand(ctx, "dvm build", errors.New("failed"))
+	RecordCommand(ctx, "dvm get apps", nil)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	totals := map[string]map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok, "%s should be an int64 sum", m.Name)
+			totals[m.Name] = map[string]int64{}
+			for _, dp := range sum.DataPoints {
+				command, _ := dp.Attributes.Value("command")
+				totals[m.Name][command.AsString()] = dp.Value
+			}
+		}
+	}
+
+	assert.Equal(t, map[string]int64{"dvm build": 2, "dvm get apps": 1}, totals["dvm.command.invocations"])
+	assert.Equal(t, map[string]int64{"dvm build": 1}, totals["dvm.command.errors"])
+}
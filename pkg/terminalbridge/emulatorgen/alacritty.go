@@ -0,0 +1,228 @@
+package emulatorgen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AlacrittyGenerator renders alacritty.toml. The stored config is expected to
+// use Alacritty's own TOML structure (font.normal.family, window.opacity, ...)
+// and is emitted as-is after the font and palette are merged in.
+type AlacrittyGenerator struct{}
+
+// EmulatorType returns "alacritty".
+func (g *AlacrittyGenerator) EmulatorType() string { return "alacritty" }
+
+// FileName returns "alacritty.toml".
+func (g *AlacrittyGenerator) FileName() string { return "alacritty.toml" }
+
+// Generate merges font and palette into the stored config and renders TOML.
+func (g *AlacrittyGenerator) Generate(in Input) (string, error) {
+	cfg := cloneMap(in.Config)
+
+	font := ResolveFont(in.Config, in.Font)
+	fontTable, _ := cfg["font"].(map[string]any)
+	if fontTable == nil {
+		fontTable = map[string]any{}
+	}
+	// "family" is the generic form; Alacritty only understands font.normal.family.
+	delete(fontTable, "family")
+	normal, _ := fontTable["normal"].(map[string]any)
+	if normal == nil {
+		normal = map[string]any{}
+	}
+	normal["family"] = font.Family
+	fontTable["normal"] = normal
+	fontTable["size"] = tomlFloat(font.Size)
+	cfg["font"] = fontTable
+
+	if _, hasColors := cfg["colors"]; !hasColors {
+		if c := colorsFromPalette(in.Palette); c != nil {
+			cfg["colors"] = alacrittyColors(c)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(header("#", in.Name))
+	if err := writeTOMLTable(&b, nil, cfg); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// alacrittyColors maps the neutral color set onto Alacritty's colors table.
+func alacrittyColors(c *terminalColors) map[string]any {
+	normal := map[string]any{}
+	bright := map[string]any{}
+	for i, name := range ansiNames {
+		normal[name] = c.ANSI[i]
+		bright[name] = c.Brights[i]
+	}
+	return map[string]any{
+		"primary":   map[string]any{"background": c.Background, "foreground": c.Foreground},
+		"cursor":    map[string]any{"cursor": c.Cursor, "text": c.CursorText},
+		"selection": map[string]any{"background": c.Selection, "text": c.SelectionText},
+		"normal":    normal,
+		"bright":    bright,
+	}
+}
+
+// tomlFloat marks a value that must always be rendered as a TOML float.
+type tomlFloat float64
+
+// writeTOMLTable writes the scalar keys of table followed by its sub-tables,
+// each in sorted key order so output is deterministic.
+func writeTOMLTable(b *strings.Builder, path []string, table map[string]any) error {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var tables, tableArrays []string
+	for _, k := range keys {
+		switch v := table[k].(type) {
+		case map[string]any:
+			tables = append(tables, k)
+		case []any:
+			if len(v) > 0 && isMapSlice(v) {
+				tableArrays = append(tableArrays, k)
+				continue
+			}
+			s, err := tomlValue(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(append(path, k), "."), err)
+			}
+			fmt.Fprintf(b, "%s = %s\n", tomlKey(k), s)
+		default:
+			s, err := tomlValue(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(append(path, k), "."), err)
+			}
+			fmt.Fprintf(b, "%s = %s\n", tomlKey(k), s)
+		}
+	}
+
+	for _, k := range tables {
+		sub := append(append([]string{}, path...), k)
+		fmt.Fprintf(b, "\n[%s]\n", tomlPath(sub))
+		if err := writeTOMLTable(b, sub, table[k].(map[string]any)); err != nil {
+			return err
+		}
+	}
+	for _, k := range tableArrays {
+		sub := append(append([]string{}, path...), k)
+		for _, item := range table[k].([]any) {
+			fmt.Fprintf(b, "\n[[%s]]\n", tomlPath(sub))
+			if err := writeTOMLTable(b, sub, item.(map[string]any)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// tomlValue renders a scalar or inline array.
+func tomlValue(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return strconv.Quote(x), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case tomlFloat:
+		s := formatNumber(float64(x))
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return s, nil
+	case float64, float32, int, int64:
+		f, _ := toFloat(x)
+		return formatNumber(f), nil
+	case []any:
+		parts := make([]string, 0, len(x))
+		for _, item := range x {
+			s, err := tomlValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, 0, len(x))
+		for _, k := range keys {
+			s, err := tomlValue(x[k])
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, tomlKey(k)+" = "+s)
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	default:
+		return "", fmt.Errorf("unsupported TOML value of type %T", v)
+	}
+}
+
+// tomlKey quotes a key unless it is a valid bare key.
+func tomlKey(k string) string {
+	for _, r := range k {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return strconv.Quote(k)
+		}
+	}
+	if k == "" {
+		return `""`
+	}
+	return k
+}
+
+// tomlPath renders a dotted table header path.
+func tomlPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = tomlKey(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// isMapSlice reports whether every element of s is a table.
+func isMapSlice(s []any) bool {
+	for _, item := range s {
+		if _, ok := item.(map[string]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// cloneMap deep-copies nested maps and slices so generators can merge values
+// without mutating the caller's config.
+func cloneMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+	return out
+}
+
+func cloneValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		return cloneMap(x)
+	case []any:
+		out := make([]any, len(x))
+		for i, item := range x {
+			out[i] = cloneValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
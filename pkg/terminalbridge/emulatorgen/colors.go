@@ -0,0 +1,71 @@
+package emulatorgen
+
+import (
+	"github.com/rmkohlman/MaestroPalette"
+)
+
+// terminalColors is the emulator-neutral color set every generator maps from.
+type terminalColors struct {
+	Foreground    string
+	Background    string
+	Cursor        string
+	CursorText    string
+	Selection     string
+	SelectionText string
+	ANSI          [8]string
+	Brights       [8]string
+}
+
+// colorsFromPalette derives the terminal color set from a theme palette.
+// Returns nil when the palette is nil or has no terminal colors.
+// Fallbacks match the Tokyo Night values used by the WezTerm build path.
+func colorsFromPalette(pal *palette.Palette) *terminalColors {
+	if pal == nil {
+		return nil
+	}
+	term := pal.ToTerminalColors()
+	if len(term) == 0 {
+		return nil
+	}
+
+	get := func(key, fallback string) string {
+		if c, ok := term[key]; ok && c != "" {
+			return c
+		}
+		return fallback
+	}
+
+	fg := get(palette.ColorFg, "#c0caf5")
+	bg := get(palette.ColorBg, "#1a1b26")
+	return &terminalColors{
+		Foreground:    fg,
+		Background:    bg,
+		Cursor:        get(palette.TermCursor, fg),
+		CursorText:    get(palette.TermCursorText, bg),
+		Selection:     get(palette.TermSelection, "#283457"),
+		SelectionText: get(palette.TermSelectionText, fg),
+		ANSI: [8]string{
+			get(palette.TermBlack, "#15161e"),
+			get(palette.TermRed, "#f7768e"),
+			get(palette.TermGreen, "#9ece6a"),
+			get(palette.TermYellow, "#e0af68"),
+			get(palette.TermBlue, "#7aa2f7"),
+			get(palette.TermMagenta, "#bb9af7"),
+			get(palette.TermCyan, "#7dcfff"),
+			get(palette.TermWhite, "#a9b1d6"),
+		},
+		Brights: [8]string{
+			get(palette.TermBrightBlack, "#414868"),
+			get(palette.TermBrightRed, "#f7768e"),
+			get(palette.TermBrightGreen, "#9ece6a"),
+			get(palette.TermBrightYellow, "#e0af68"),
+			get(palette.TermBrightBlue, "#7aa2f7"),
+			get(palette.TermBrightMagenta, "#bb9af7"),
+			get(palette.TermBrightCyan, "#7dcfff"),
+			get(palette.TermBrightWhite, "#c0caf5"),
+		},
+	}
+}
+
+// ansiNames lists the ANSI color names in index order.
+var ansiNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
@@ -0,0 +1,26 @@
+package emulatorgen
+
+import "fmt"
+
+// registry maps emulator types to their generator constructors.
+var registry = map[string]func() ConfigGenerator{
+	"wezterm":   func() ConfigGenerator { return &WezTermGenerator{} },
+	"alacritty": func() ConfigGenerator { return &AlacrittyGenerator{} },
+	"kitty":     func() ConfigGenerator { return &KittyGenerator{} },
+	"ghostty":   func() ConfigGenerator { return &GhosttyGenerator{} },
+}
+
+// NewConfigGenerator returns a generator for the named emulator type.
+// Returns an error if the type is not recognized.
+func NewConfigGenerator(emulatorType string) (ConfigGenerator, error) {
+	ctor, ok := registry[emulatorType]
+	if !ok {
+		return nil, fmt.Errorf("unknown emulator type %q (available: %v)", emulatorType, AvailableEmulators())
+	}
+	return ctor(), nil
+}
+
+// AvailableEmulators returns the supported emulator types in sorted order.
+func AvailableEmulators() []string {
+	return []string{"alacritty", "ghostty", "kitty", "wezterm"}
+}
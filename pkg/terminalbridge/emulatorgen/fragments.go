@@ -0,0 +1,77 @@
+package emulatorgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rmkohlman/MaestroPalette"
+)
+
+// Colors-only fragments hold just a theme's colors, so a theme can be
+// switched without regenerating the rest of the user's emulator config.
+const (
+	// WezTermColorsFile is a Lua module returning a WezTerm colors table,
+	// loaded with: config.colors = require("dvm-colors")
+	WezTermColorsFile = "dvm-colors.lua"
+	// AlacrittyColorsFile is a TOML file with Alacritty's colors table,
+	// loaded through general.import in alacritty.toml.
+	AlacrittyColorsFile = "dvm-colors.toml"
+)
+
+// WezTermColorsLua renders the palette as a Lua module that returns a WezTerm
+// colors table. Returns an error when the palette has no terminal colors.
+func WezTermColorsLua(pal *palette.Palette) (string, error) {
+	c := wezTermColors(colorsFromPalette(pal))
+	if c == nil {
+		return "", fmt.Errorf("palette has no terminal colors")
+	}
+
+	var b strings.Builder
+	b.WriteString(fragmentHeader("--", pal.Name))
+	b.WriteString("return {\n")
+	for _, kv := range [][2]string{
+		{"foreground", c.Foreground},
+		{"background", c.Background},
+		{"cursor_bg", c.CursorBg},
+		{"cursor_fg", c.CursorFg},
+		{"cursor_border", c.CursorBorder},
+		{"selection_bg", c.SelectionBg},
+		{"selection_fg", c.SelectionFg},
+	} {
+		fmt.Fprintf(&b, "  %s = %q,\n", kv[0], kv[1])
+	}
+	fmt.Fprintf(&b, "  ansi = { %s },\n", luaStrings(c.ANSI))
+	fmt.Fprintf(&b, "  brights = { %s },\n", luaStrings(c.Brights))
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// AlacrittyColorsTOML renders the palette as Alacritty's colors table.
+// Returns an error when the palette has no terminal colors.
+func AlacrittyColorsTOML(pal *palette.Palette) (string, error) {
+	c := colorsFromPalette(pal)
+	if c == nil {
+		return "", fmt.Errorf("palette has no terminal colors")
+	}
+
+	var b strings.Builder
+	b.WriteString(fragmentHeader("#", pal.Name))
+	if err := writeTOMLTable(&b, nil, map[string]any{"colors": alacrittyColors(c)}); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// fragmentHeader returns the comment header placed at the top of fragments.
+func fragmentHeader(comment, themeName string) string {
+	return fmt.Sprintf("%s Generated by dvm theme apply from theme %q\n\n", comment, themeName)
+}
+
+// luaStrings renders colors as the items of a Lua list.
+func luaStrings(colors []string) string {
+	quoted := make([]string, len(colors))
+	for i, c := range colors {
+		quoted[i] = fmt.Sprintf("%q", c)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,231 @@
+package emulatorgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroPalette"
+)
+
+// testPalette returns a palette with the standard terminal keys populated.
+func testPalette() *palette.Palette {
+	return &palette.Palette{
+		Name:     "test-theme",
+		Category: palette.CategoryDark,
+		Colors: map[string]string{
+			palette.ColorBg:      "#1a1b26",
+			palette.ColorFg:      "#c0caf5",
+			palette.ColorError:   "#f7768e",
+			palette.ColorSuccess: "#9ece6a",
+			palette.ColorWarning: "#e0af68",
+			palette.ColorInfo:    "#7aa2f7",
+			palette.ColorComment: "#565f89",
+		},
+	}
+}
+
+func TestNewConfigGenerator(t *testing.T) {
+	for _, typ := range AvailableEmulators() {
+		g, err := NewConfigGenerator(typ)
+		if err != nil {
+			t.Fatalf("NewConfigGenerator(%q) error = %v", typ, err)
+		}
+		if g.EmulatorType() != typ {
+			t.Errorf("EmulatorType() = %q, want %q", g.EmulatorType(), typ)
+		}
+		if g.FileName() == "" {
+			t.Errorf("%s: FileName() is empty", typ)
+		}
+	}
+	if _, err := NewConfigGenerator("iterm2"); err == nil {
+		t.Error("expected error for unsupported emulator type")
+	}
+}
+
+func TestResolveFont(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		override Font
+		want     Font
+	}{
+		{"defaults", nil, Font{}, Font{DefaultFontFamily, DefaultFontSize}},
+		{"nested family", map[string]any{"font": map[string]any{"family": "Fira Code", "size": 13.0}}, Font{}, Font{"Fira Code", 13}},
+		{"alacritty normal", map[string]any{"font": map[string]any{"normal": map[string]any{"family": "Hack"}, "size": 12}}, Font{}, Font{"Hack", 12}},
+		{"kitty flat", map[string]any{"font_family": "JetBrains Mono", "font_size": 11.5}, Font{}, Font{"JetBrains Mono", 11.5}},
+		{"override wins", map[string]any{"font_family": "Hack"}, Font{Family: "Iosevka", Size: 16}, Font{"Iosevka", 16}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveFont(tt.config, tt.override); got != tt.want {
+				t.Errorf("ResolveFont() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWezTermGenerator_Generate(t *testing.T) {
+	g := &WezTermGenerator{}
+	out, err := g.Generate(Input{
+		Name:    "dev",
+		Config:  map[string]any{"font": map[string]any{"family": "Fira Code", "size": 13.0}},
+		Palette: testPalette(),
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{"Fira Code", "#1a1b26", "#c0caf5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("wezterm output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAlacrittyGenerator_Generate(t *testing.T) {
+	g := &AlacrittyGenerator{}
+	config := map[string]any{
+		"font":   map[string]any{"normal": map[string]any{"family": "Source Code Pro", "style": "Regular"}, "size": 12},
+		"window": map[string]any{"opacity": 0.9, "dimensions": map[string]any{"columns": 80, "lines": 24}},
+	}
+	out, err := g.Generate(Input{Name: "minimal", Config: config, Palette: testPalette()})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		"[font]\nsize = 12.0",
+		"[font.normal]\nfamily = \"Source Code Pro\"\nstyle = \"Regular\"",
+		"[window]\nopacity = 0.9",
+		"[window.dimensions]\ncolumns = 80",
+		"[colors.primary]\nbackground = \"#1a1b26\"",
+		"[colors.normal]",
+		"red = \"#f7768e\"",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("alacritty output missing %q:\n%s", want, out)
+		}
+	}
+	if _, ok := config["colors"]; ok {
+		t.Error("Generate must not mutate the input config")
+	}
+}
+
+func TestAlacrittyGenerator_StoredColorsWin(t *testing.T) {
+	g := &AlacrittyGenerator{}
+	out, err := g.Generate(Input{
+		Config:  map[string]any{"colors": map[string]any{"primary": map[string]any{"background": "#000000"}}},
+		Palette: testPalette(),
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(out, "#1a1b26") {
+		t.Errorf("palette must not override stored colors:\n%s", out)
+	}
+	if !strings.Contains(out, `background = "#000000"`) {
+		t.Errorf("stored colors missing:\n%s", out)
+	}
+}
+
+func TestKittyGenerator_Generate(t *testing.T) {
+	g := &KittyGenerator{}
+	out, err := g.Generate(Input{
+		Name: "poweruser",
+		Config: map[string]any{
+			"font_family":    "Fira Code",
+			"font_size":      13,
+			"copy_on_select": true,
+			"map":            []any{"ctrl+shift+t new_tab", "ctrl+shift+w close_tab"},
+		},
+		Palette: testPalette(),
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		"font_family Fira Code\n",
+		"font_size 13\n",
+		"background #1a1b26\n",
+		"color1 #f7768e\n",
+		"copy_on_select yes\n",
+		"map ctrl+shift+t new_tab\nmap ctrl+shift+w close_tab\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("kitty output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "font_family") != 1 {
+		t.Errorf("font_family must be written once:\n%s", out)
+	}
+}
+
+func TestGhosttyGenerator_Generate(t *testing.T) {
+	g := &GhosttyGenerator{}
+	out, err := g.Generate(Input{
+		Config: map[string]any{
+			"window-padding-x": 8,
+			"keybind":          map[string]any{"ctrl+t": "new_tab"},
+		},
+		Palette: testPalette(),
+		Font:    Font{Family: "Iosevka", Size: 15},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		"font-family = Iosevka\n",
+		"font-size = 15\n",
+		"background = #1a1b26\n",
+		"palette = 1=#f7768e\n",
+		"window-padding-x = 8\n",
+		"keybind = ctrl+t=new_tab\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("ghostty output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGenerate_NoPaletteOmitsColors(t *testing.T) {
+	for _, typ := range AvailableEmulators() {
+		g, _ := NewConfigGenerator(typ)
+		out, err := g.Generate(Input{})
+		if err != nil {
+			t.Fatalf("%s: Generate() error = %v", typ, err)
+		}
+		if strings.Contains(out, "#1a1b26") {
+			t.Errorf("%s: colors emitted without a palette:\n%s", typ, out)
+		}
+		if !strings.Contains(out, DefaultFontFamily) {
+			t.Errorf("%s: default font missing:\n%s", typ, out)
+		}
+	}
+}
+
+func TestColorFragments(t *testing.T) {
+	lua, err := WezTermColorsLua(testPalette())
+	if err != nil {
+		t.Fatalf("WezTermColorsLua() error = %v", err)
+	}
+	for _, want := range []string{`from theme "test-theme"`, "return {", `background = "#1a1b26",`, `ansi = { "#`} {
+		if !strings.Contains(lua, want) {
+			t.Errorf("WezTermColorsLua() missing %q:\n%s", want, lua)
+		}
+	}
+
+	toml, err := AlacrittyColorsTOML(testPalette())
+	if err != nil {
+		t.Fatalf("AlacrittyColorsTOML() error = %v", err)
+	}
+	for _, want := range []string{"[colors.primary]", `background = "#1a1b26"`, "[colors.bright]"} {
+		if !strings.Contains(toml, want) {
+			t.Errorf("AlacrittyColorsTOML() missing %q:\n%s", want, toml)
+		}
+	}
+	if strings.Contains(toml, "[font") {
+		t.Errorf("AlacrittyColorsTOML() should only hold colors:\n%s", toml)
+	}
+
+	if _, err := WezTermColorsLua(nil); err == nil {
+		t.Error("expected error for a nil palette")
+	}
+}
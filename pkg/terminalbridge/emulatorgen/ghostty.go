@@ -0,0 +1,75 @@
+package emulatorgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GhosttyGenerator renders a Ghostty config file ("key = value" lines).
+// List values repeat the key per item, which is how Ghostty expresses
+// multi-value options such as keybind and palette.
+type GhosttyGenerator struct{}
+
+// EmulatorType returns "ghostty".
+func (g *GhosttyGenerator) EmulatorType() string { return "ghostty" }
+
+// FileName returns "config", the file Ghostty reads from ~/.config/ghostty.
+func (g *GhosttyGenerator) FileName() string { return "config" }
+
+// ghosttyColorKeys are stored config keys that mean the user set colors explicitly.
+var ghosttyColorKeys = []string{"foreground", "background", "palette"}
+
+// Generate renders the Ghostty config from the stored config, font, and palette.
+func (g *GhosttyGenerator) Generate(in Input) (string, error) {
+	font := ResolveFont(in.Config, in.Font)
+
+	var b strings.Builder
+	b.WriteString(header("#", in.Name))
+	fmt.Fprintf(&b, "font-family = %s\n", font.Family)
+	fmt.Fprintf(&b, "font-size = %s\n", formatNumber(font.Size))
+
+	if c := colorsFromPalette(in.Palette); c != nil && !hasAnyKey(in.Config, ghosttyColorKeys) {
+		b.WriteString("\n# Colors\n")
+		fmt.Fprintf(&b, "foreground = %s\n", c.Foreground)
+		fmt.Fprintf(&b, "background = %s\n", c.Background)
+		fmt.Fprintf(&b, "cursor-color = %s\n", c.Cursor)
+		fmt.Fprintf(&b, "cursor-text = %s\n", c.CursorText)
+		fmt.Fprintf(&b, "selection-foreground = %s\n", c.SelectionText)
+		fmt.Fprintf(&b, "selection-background = %s\n", c.Selection)
+		for i := range c.ANSI {
+			fmt.Fprintf(&b, "palette = %d=%s\n", i, c.ANSI[i])
+		}
+		for i := range c.Brights {
+			fmt.Fprintf(&b, "palette = %d=%s\n", i+8, c.Brights[i])
+		}
+	}
+
+	lines, err := flatLines(in.Config, " = ", ghosttyValue, "font", "font-family", "font-size", "font_family", "font_size")
+	if err != nil {
+		return "", err
+	}
+	if len(lines) > 0 {
+		b.WriteString("\n# Options\n")
+		b.WriteString(strings.Join(lines, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// ghosttyValue renders a scalar in Ghostty config syntax.
+func ghosttyValue(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case bool:
+		if x {
+			return "true", nil
+		}
+		return "false", nil
+	default:
+		if f, ok := toFloat(x); ok {
+			return formatNumber(f), nil
+		}
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
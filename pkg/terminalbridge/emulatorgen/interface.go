@@ -0,0 +1,125 @@
+// Package emulatorgen renders real terminal emulator config files (wezterm.lua,
+// alacritty.toml, kitty.conf, ghostty config) from the config blob stored on a
+// terminal_emulators row, the resolved theme palette, and font settings.
+//
+// Precedence follows the workspace build: explicit values in the stored config
+// win, the theme palette fills in colors when the stored config has none, and a
+// font override (e.g. from CLI flags) replaces the stored font.
+package emulatorgen
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rmkohlman/MaestroPalette"
+)
+
+// ConfigGenerator renders the native configuration file for one emulator type.
+type ConfigGenerator interface {
+	// EmulatorType returns the emulator type this generator targets (e.g. "kitty").
+	EmulatorType() string
+
+	// FileName returns the conventional config file name inside the emulator's
+	// config directory (e.g. "kitty.conf").
+	FileName() string
+
+	// Generate renders the config file content.
+	Generate(in Input) (string, error)
+}
+
+// Input is everything a generator needs to render a config file.
+type Input struct {
+	// Name is the emulator config name, used in the generated header.
+	Name string
+	// Config is the emulator-specific config blob stored in the database.
+	Config map[string]any
+	// Palette is the resolved theme palette; nil means no theme colors.
+	Palette *palette.Palette
+	// Font overrides the font found in Config when its fields are set.
+	Font Font
+}
+
+// Font holds the font settings shared by all emulators.
+type Font struct {
+	Family string
+	Size   float64
+}
+
+// Default font used when neither the stored config nor an override sets one.
+// Matches the WezTerm defaults used by the workspace image build.
+const (
+	DefaultFontFamily = "MesloLGS Nerd Font Mono"
+	DefaultFontSize   = 14
+)
+
+// ResolveFont returns the effective font for an input. It understands the
+// nested form (font.family / font.normal.family, font.size) as well as the flat
+// forms used by kitty (font_family, font_size) and ghostty (font-family,
+// font-size). Non-zero fields of override take precedence.
+func ResolveFont(config map[string]any, override Font) Font {
+	font := Font{Family: DefaultFontFamily, Size: DefaultFontSize}
+
+	if fc, ok := config["font"].(map[string]any); ok {
+		if family, ok := fc["family"].(string); ok && family != "" {
+			font.Family = family
+		}
+		if normal, ok := fc["normal"].(map[string]any); ok {
+			if family, ok := normal["family"].(string); ok && family != "" {
+				font.Family = family
+			}
+		}
+		if size, ok := toFloat(fc["size"]); ok {
+			font.Size = size
+		}
+	}
+	for _, key := range []string{"font_family", "font-family"} {
+		if family, ok := config[key].(string); ok && family != "" {
+			font.Family = family
+		}
+	}
+	for _, key := range []string{"font_size", "font-size"} {
+		if size, ok := toFloat(config[key]); ok {
+			font.Size = size
+		}
+	}
+
+	if override.Family != "" {
+		font.Family = override.Family
+	}
+	if override.Size > 0 {
+		font.Size = override.Size
+	}
+	return font
+}
+
+// toFloat converts the numeric types produced by JSON and YAML decoding.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// formatNumber renders a number without a trailing ".0" for whole values.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// header returns the comment header placed at the top of generated files.
+func header(comment, name string) string {
+	if name == "" {
+		return fmt.Sprintf("%s Generated by dvm terminal generate\n\n", comment)
+	}
+	return fmt.Sprintf("%s Generated by dvm terminal generate from emulator %q\n\n", comment, name)
+}
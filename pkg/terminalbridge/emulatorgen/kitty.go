@@ -0,0 +1,146 @@
+package emulatorgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KittyGenerator renders kitty.conf. Kitty options are flat snake_case keys;
+// list values (e.g. "map") are written as one line per item and nested maps as
+// "key subkey value" lines.
+type KittyGenerator struct{}
+
+// EmulatorType returns "kitty".
+func (g *KittyGenerator) EmulatorType() string { return "kitty" }
+
+// FileName returns "kitty.conf".
+func (g *KittyGenerator) FileName() string { return "kitty.conf" }
+
+// kittyColorKeys are stored config keys that mean the user set colors explicitly.
+var kittyColorKeys = []string{"foreground", "background", "color0"}
+
+// Generate renders kitty.conf from the stored config, font, and palette.
+func (g *KittyGenerator) Generate(in Input) (string, error) {
+	font := ResolveFont(in.Config, in.Font)
+
+	var b strings.Builder
+	b.WriteString(header("#", in.Name))
+	fmt.Fprintf(&b, "font_family %s\n", font.Family)
+	fmt.Fprintf(&b, "font_size %s\n", formatNumber(font.Size))
+
+	if c := colorsFromPalette(in.Palette); c != nil && !hasAnyKey(in.Config, kittyColorKeys) {
+		b.WriteString("\n# Colors\n")
+		fmt.Fprintf(&b, "foreground %s\n", c.Foreground)
+		fmt.Fprintf(&b, "background %s\n", c.Background)
+		fmt.Fprintf(&b, "cursor %s\n", c.Cursor)
+		fmt.Fprintf(&b, "cursor_text_color %s\n", c.CursorText)
+		fmt.Fprintf(&b, "selection_foreground %s\n", c.SelectionText)
+		fmt.Fprintf(&b, "selection_background %s\n", c.Selection)
+		for i := range c.ANSI {
+			fmt.Fprintf(&b, "color%d %s\n", i, c.ANSI[i])
+		}
+		for i := range c.Brights {
+			fmt.Fprintf(&b, "color%d %s\n", i+8, c.Brights[i])
+		}
+	}
+
+	lines, err := flatLines(in.Config, " ", kittyValue, "font", "font_family", "font_size")
+	if err != nil {
+		return "", err
+	}
+	if len(lines) > 0 {
+		b.WriteString("\n# Options\n")
+		b.WriteString(strings.Join(lines, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// kittyValue renders a scalar in kitty.conf syntax.
+func kittyValue(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return x, nil
+	case bool:
+		if x {
+			return "yes", nil
+		}
+		return "no", nil
+	default:
+		if f, ok := toFloat(x); ok {
+			return formatNumber(f), nil
+		}
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+// flatLines renders a flat "key<sep>value" config format, skipping the given
+// keys. Lists repeat the key per item; nested maps become "key subkey value".
+func flatLines(config map[string]any, sep string, render func(any) (string, error), skip ...string) ([]string, error) {
+	skipped := make(map[string]bool, len(skip))
+	for _, k := range skip {
+		skipped[k] = true
+	}
+
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		if !skipped[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		switch v := config[k].(type) {
+		case []any:
+			for _, item := range v {
+				s, err := render(item)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+				lines = append(lines, k+sep+s)
+			}
+		case map[string]any:
+			subKeys := make([]string, 0, len(v))
+			for sk := range v {
+				subKeys = append(subKeys, sk)
+			}
+			sort.Strings(subKeys)
+			for _, sk := range subKeys {
+				s, err := render(v[sk])
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", k, sk, err)
+				}
+				lines = append(lines, k+sep+nestedPair(sep, sk, s))
+			}
+		default:
+			s, err := render(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			lines = append(lines, k+sep+s)
+		}
+	}
+	return lines, nil
+}
+
+// nestedPair joins a nested key and value: "sub value" for space-separated
+// formats, "sub=value" for "key = value" formats.
+func nestedPair(sep, key, value string) string {
+	if strings.TrimSpace(sep) == "" {
+		return key + " " + value
+	}
+	return key + "=" + value
+}
+
+// hasAnyKey reports whether config contains any of keys.
+func hasAnyKey(config map[string]any, keys []string) bool {
+	for _, k := range keys {
+		if _, ok := config[k]; ok {
+			return true
+		}
+	}
+	return false
+}
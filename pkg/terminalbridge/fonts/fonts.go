@@ -0,0 +1,193 @@
+// Package fonts manages the Nerd Fonts that themes and prompts assume: a
+// catalog pinned to one Nerd Fonts release, per-platform install locations,
+// and checks that a configured font family is a Nerd Font and is installed.
+// Without one, prompt and statusline glyphs render as boxes.
+package fonts
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NerdFontsVersion is the pinned Nerd Fonts release every catalog font is
+// installed from.
+const NerdFontsVersion = "3.3.0"
+
+// releaseURL is the download base of the pinned release.
+var releaseURL = fmt.Sprintf("https://github.com/ryanoasis/nerd-fonts/releases/download/v%s", NerdFontsVersion)
+
+// ChecksumManifestURL is the release's SHA-256 manifest, which archives are
+// verified against.
+var ChecksumManifestURL = releaseURL + "/SHA-256.txt"
+
+// Font is one Nerd Font of the catalog.
+type Font struct {
+	Name    string `yaml:"name" json:"name"`       // Release archive name, e.g. JetBrainsMono
+	Family  string `yaml:"family" json:"family"`   // Family to configure, e.g. "JetBrainsMono Nerd Font"
+	Summary string `yaml:"summary" json:"summary"` // Upstream font it patches
+}
+
+// Archive returns the file name of the font's release archive.
+func (f Font) Archive() string { return f.Name + ".zip" }
+
+// URL returns the download URL of the font's release archive.
+func (f Font) URL() string { return releaseURL + "/" + f.Archive() }
+
+// catalog lists the supported fonts, MesloLGS (the emulator default) first.
+var catalog = []Font{
+	{Name: "Meslo", Family: "MesloLGS Nerd Font", Summary: "Meslo LG, the default terminal font"},
+	{Name: "JetBrainsMono", Family: "JetBrainsMono Nerd Font", Summary: "JetBrains Mono"},
+	{Name: "FiraCode", Family: "FiraCode Nerd Font", Summary: "Fira Code"},
+	{Name: "Hack", Family: "Hack Nerd Font", Summary: "Hack"},
+	{Name: "CascadiaCode", Family: "CaskaydiaCove Nerd Font", Summary: "Cascadia Code"},
+	{Name: "Iosevka", Family: "Iosevka Nerd Font", Summary: "Iosevka"},
+	{Name: "SourceCodePro", Family: "SauceCodePro Nerd Font", Summary: "Source Code Pro"},
+	{Name: "UbuntuMono", Family: "UbuntuMono Nerd Font", Summary: "Ubuntu Mono"},
+}
+
+// Catalog returns the supported fonts.
+func Catalog() []Font {
+	return append([]Font(nil), catalog...)
+}
+
+// Get returns the catalog font with the given archive name or family,
+// matched case-insensitively.
+func Get(name string) (Font, error) {
+	for _, f := range catalog {
+		if strings.EqualFold(f.Name, name) || normalizeFamily(f.Family) == normalizeFamily(name) {
+			return f, nil
+		}
+	}
+	names := make([]string, len(catalog))
+	for i, f := range catalog {
+		names[i] = f.Name
+	}
+	return Font{}, fmt.Errorf("font %q is not in the catalog (available: %s)", name, strings.Join(names, ", "))
+}
+
+// ForFamily returns the catalog font providing family, including its Mono
+// and Propo variants and the NF/NFM/NFP short names.
+func ForFamily(family string) (Font, bool) {
+	norm := normalizeFamily(family)
+	for _, f := range catalog {
+		if strings.HasPrefix(norm, normalizeFamily(f.Family)) {
+			return f, true
+		}
+	}
+	return Font{}, false
+}
+
+// IsNerdFont reports whether family names a Nerd Font patched family.
+func IsNerdFont(family string) bool {
+	return strings.Contains(normalizeFamily(family), "nerdfont")
+}
+
+// normalizeFamily lowercases family, expands the NF/NFM/NFP suffixes to
+// Nerd Font, Nerd Font Mono, and Nerd Font Propo, and drops spaces, so a
+// family compares equal to the file names of its fonts.
+func normalizeFamily(family string) string {
+	fields := strings.Fields(family)
+	if n := len(fields); n > 0 {
+		switch strings.ToUpper(fields[n-1]) {
+		case "NF":
+			fields = append(fields[:n-1], "Nerd", "Font")
+		case "NFM":
+			fields = append(fields[:n-1], "Nerd", "Font", "Mono")
+		case "NFP":
+			fields = append(fields[:n-1], "Nerd", "Font", "Propo")
+		}
+	}
+	return strings.ToLower(strings.Join(fields, ""))
+}
+
+// Platform holds where fonts are installed on one OS.
+type Platform struct {
+	OS string
+	// UserDir is where Install puts fonts; empty when the OS is unsupported.
+	UserDir string
+	// SearchDirs are scanned for installed fonts, UserDir first.
+	SearchDirs []string
+	// RefreshCache is the font cache rebuild command run after an install,
+	// if it is available.
+	RefreshCache []string
+}
+
+// PlatformFor returns the font locations of goos for the user whose home
+// directory is home. dataHome is $XDG_DATA_HOME on Linux, empty for the
+// default ~/.local/share.
+func PlatformFor(goos, home, dataHome string) Platform {
+	switch goos {
+	case "darwin":
+		user := filepath.Join(home, "Library", "Fonts")
+		return Platform{OS: goos, UserDir: user, SearchDirs: []string{user, "/Library/Fonts", "/System/Library/Fonts"}}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		user := filepath.Join(dataHome, "fonts")
+		return Platform{
+			OS:           goos,
+			UserDir:      user,
+			SearchDirs:   []string{user, filepath.Join(home, ".fonts"), "/usr/local/share/fonts", "/usr/share/fonts"},
+			RefreshCache: []string{"fc-cache", "-f", user},
+		}
+	default:
+		return Platform{OS: goos}
+	}
+}
+
+// Installed returns the font files under the platform's search directories
+// that provide family, sorted. Mono and Propo variants count only when
+// family names them.
+func (p Platform) Installed(family string) []string {
+	prefix := normalizeFamily(family)
+	var found []string
+	for _, dir := range p.SearchDirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !isFontFile(d.Name()) {
+				return nil
+			}
+			stem := strings.ToLower(strings.TrimSuffix(d.Name(), filepath.Ext(d.Name())))
+			style, ok := strings.CutPrefix(stem, prefix)
+			if ok && (style == "" || style[0] == '-') {
+				found = append(found, path)
+			}
+			return nil
+		})
+	}
+	sort.Strings(found)
+	return found
+}
+
+// Verification is the result of checking one configured font family.
+type Verification struct {
+	Family    string   `yaml:"family" json:"family"`
+	NerdFont  bool     `yaml:"nerdFont" json:"nerdFont"`
+	Installed []string `yaml:"installed,omitempty" json:"installed,omitempty"`
+	// Catalog is the archive name of the catalog font providing Family, if any.
+	Catalog string `yaml:"catalog,omitempty" json:"catalog,omitempty"`
+}
+
+// OK reports whether the family is an installed Nerd Font.
+func (v Verification) OK() bool { return v.NerdFont && len(v.Installed) > 0 }
+
+// Verify checks that family is a Nerd Font and is installed on the platform.
+func (p Platform) Verify(family string) Verification {
+	v := Verification{Family: family, NerdFont: IsNerdFont(family), Installed: p.Installed(family)}
+	if f, ok := ForFamily(family); ok {
+		v.Catalog = f.Name
+	}
+	return v
+}
+
+// isFontFile reports whether name is a TrueType or OpenType font file.
+func isFontFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".ttf", ".otf":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+package fonts
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"devopsmaestro/pkg/fetch"
+)
+
+func TestGet(t *testing.T) {
+	for _, name := range []string{"JetBrainsMono", "jetbrainsmono", "JetBrainsMono Nerd Font"} {
+		if f, err := Get(name); err != nil || f.Name != "JetBrainsMono" {
+			t.Errorf("Get(%q) = %v, %v", name, f.Name, err)
+		}
+	}
+	if _, err := Get("Comic Sans"); err == nil {
+		t.Error("expected error for a font outside the catalog")
+	}
+}
+
+func TestForFamily(t *testing.T) {
+	tests := []struct {
+		family, want string
+		nerd         bool
+	}{
+		{"MesloLGS Nerd Font Mono", "Meslo", true},
+		{"JetBrainsMono NFM", "JetBrainsMono", true},
+		{"jetbrainsmono nerd font propo", "JetBrainsMono", true},
+		{"CaskaydiaCove Nerd Font", "CascadiaCode", true},
+		{"Agave Nerd Font", "", true},
+		{"JetBrains Mono", "", false},
+	}
+	for _, tt := range tests {
+		f, _ := ForFamily(tt.family)
+		if f.Name != tt.want {
+			t.Errorf("ForFamily(%q) = %q, want %q", tt.family, f.Name, tt.want)
+		}
+		if got := IsNerdFont(tt.family); got != tt.nerd {
+			t.Errorf("IsNerdFont(%q) = %v, want %v", tt.family, got, tt.nerd)
+		}
+	}
+}
+
+func TestPlatformFor(t *testing.T) {
+	if got := PlatformFor("darwin", "/Users/me", "").UserDir; got != "/Users/me/Library/Fonts" {
+		t.Errorf("darwin UserDir = %q", got)
+	}
+	if got := PlatformFor("linux", "/home/me", "").UserDir; got != "/home/me/.local/share/fonts" {
+		t.Errorf("linux UserDir = %q", got)
+	}
+	if got := PlatformFor("linux", "/home/me", "/data").UserDir; got != "/data/fonts" {
+		t.Errorf("linux UserDir with XDG_DATA_HOME = %q", got)
+	}
+	if p := PlatformFor("windows", `C:\Users\me`, ""); p.UserDir != "" {
+		t.Errorf("windows should be unsupported, got %q", p.UserDir)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"MesloLGSNerdFontMono-Regular.ttf", "MesloLGSNerdFont-Bold.ttf", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := Platform{OS: "linux", UserDir: dir, SearchDirs: []string{dir}}
+
+	v := p.Verify("MesloLGS Nerd Font Mono")
+	if !v.OK() || v.Catalog != "Meslo" || !reflect.DeepEqual(v.Installed, []string{filepath.Join(dir, "MesloLGSNerdFontMono-Regular.ttf")}) {
+		t.Errorf("Verify(mono) = %+v", v)
+	}
+	if v := p.Verify("MesloLGS Nerd Font"); len(v.Installed) != 1 {
+		t.Errorf("Verify() should not count the Mono variant: %+v", v)
+	}
+	if v := p.Verify("Hack Nerd Font"); v.OK() || !v.NerdFont {
+		t.Errorf("Verify(missing) = %+v", v)
+	}
+	if v := p.Verify("Menlo"); v.NerdFont {
+		t.Errorf("Verify(Menlo) = %+v", v)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, name := range []string{"HackNerdFont-Regular.ttf", "LICENSE.md"} {
+		w, _ := zw.Create(name)
+		w.Write([]byte(name))
+	}
+	zw.Close()
+	sum := sha256.Sum256(archive.Bytes())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Hack.zip":
+			w.Write(archive.Bytes())
+		case "/SHA-256.txt":
+			w.Write([]byte(hex.EncodeToString(sum[:]) + "  Hack.zip\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	oldRelease, oldManifest := releaseURL, ChecksumManifestURL
+	releaseURL, ChecksumManifestURL = server.URL, server.URL+"/SHA-256.txt"
+	defer func() { releaseURL, ChecksumManifestURL = oldRelease, oldManifest }()
+
+	dir := t.TempDir()
+	p := Platform{OS: "linux", UserDir: dir, SearchDirs: []string{dir}}
+	font, _ := Get("Hack")
+	files, err := p.Install(context.Background(), &fetch.Fetcher{}, font)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	want := []string{filepath.Join(dir, "Hack", "HackNerdFont-Regular.ttf")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("Install() = %v, want %v", files, want)
+	}
+	if !p.Verify("Hack Nerd Font").OK() {
+		t.Error("installed font does not verify")
+	}
+
+	if _, err := (Platform{OS: "windows"}).Install(context.Background(), &fetch.Fetcher{}, font); err == nil {
+		t.Error("expected error installing on an unsupported OS")
+	}
+}
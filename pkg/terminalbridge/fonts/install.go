@@ -0,0 +1,112 @@
+package fonts
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"devopsmaestro/pkg/fetch"
+)
+
+// maxArchiveSize caps a font archive download; the largest (Iosevka) is
+// well under this.
+const maxArchiveSize = 512 << 20
+
+// Artifact returns the verified download of the font's release archive. Its
+// digest is looked up in the pinned release's SHA-256 manifest.
+func (f Font) Artifact(fetcher *fetch.Fetcher) fetch.Artifact {
+	return fetch.Artifact{
+		Name: f.Archive(),
+		URL:  f.URL(),
+		ResolveChecksum: func(ctx context.Context) (string, error) {
+			return fetcher.ManifestChecksum(ctx, ChecksumManifestURL, f.Archive())
+		},
+	}
+}
+
+// Install downloads and verifies the font's archive and extracts its font
+// files into <UserDir>/<Name>, then refreshes the font cache where the
+// platform has one. It returns the installed files.
+func (p Platform) Install(ctx context.Context, fetcher *fetch.Fetcher, f Font) ([]string, error) {
+	if p.UserDir == "" {
+		return nil, fmt.Errorf("installing fonts is not supported on %s; download %s and install it manually", p.OS, f.URL())
+	}
+	if fetcher.MaxSize == 0 {
+		fetcher.MaxSize = maxArchiveSize
+	}
+
+	tmp, err := os.MkdirTemp("", "dvt-font-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	archive := filepath.Join(tmp, f.Archive())
+	if err := fetcher.Fetch(ctx, f.Artifact(fetcher), archive); err != nil {
+		return nil, err
+	}
+
+	files, err := extractFonts(archive, filepath.Join(p.UserDir, f.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.RefreshCache) > 0 {
+		if path, err := exec.LookPath(p.RefreshCache[0]); err == nil {
+			_ = exec.CommandContext(ctx, path, p.RefreshCache[1:]...).Run()
+		}
+	}
+	return files, nil
+}
+
+// extractFonts writes the .ttf and .otf files of a zip archive into dir,
+// flattening any directories, and returns their paths.
+func extractFonts(archive, dir string) ([]string, error) {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open font archive: %w", err)
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create font directory: %w", err)
+	}
+	var files []string
+	for _, zf := range r.File {
+		name := filepath.Base(zf.Name)
+		if zf.FileInfo().IsDir() || !isFontFile(name) {
+			continue
+		}
+		dest := filepath.Join(dir, name)
+		if err := extractFile(zf, dest); err != nil {
+			return nil, err
+		}
+		files = append(files, dest)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("font archive %s holds no font files", filepath.Base(archive))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func extractFile(zf *zip.File, dest string) error {
+	src, err := zf.Open()
+	if err != nil {
+		return fmt.Errorf("failed to read %s from archive: %w", zf.Name, err)
+	}
+	defer src.Close()
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	return out.Close()
+}
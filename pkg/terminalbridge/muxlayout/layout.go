@@ -0,0 +1,214 @@
+// Package muxlayout describes terminal multiplexer (tmux or zellij) session
+// layouts for workspaces and renders the shell script that creates or
+// re-attaches a workspace's session inside its container.
+//
+// A layout is stored per app and looks like:
+//
+//	multiplexer: tmux
+//	windows:
+//	  - name: editor
+//	    command: nvim .
+//	  - name: shell
+//	  - name: logs
+//	    command: tail -F /tmp/app.log
+//	    dir: /workspace
+//
+// Re-attaching to an existing session leaves its windows untouched, so the
+// working layout survives detach/attach cycles as long as the container runs.
+package muxlayout
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported multiplexers.
+const (
+	Tmux   = "tmux"
+	Zellij = "zellij"
+)
+
+// Window is one window (tmux) or tab (zellij) of a session.
+type Window struct {
+	Name    string `yaml:"name" json:"name"`
+	Command string `yaml:"command,omitempty" json:"command,omitempty"` // typed into the window's shell
+	Dir     string `yaml:"dir,omitempty" json:"dir,omitempty"`         // defaults to the container's working directory
+}
+
+// Layout is the set of windows a workspace session starts with.
+type Layout struct {
+	Multiplexer string   `yaml:"multiplexer,omitempty" json:"multiplexer,omitempty"` // tmux (default) or zellij
+	Windows     []Window `yaml:"windows" json:"windows"`
+}
+
+// DefaultLayout returns the editor/shell/logs layout used when an app has
+// none stored.
+func DefaultLayout() *Layout {
+	return &Layout{
+		Multiplexer: Tmux,
+		Windows: []Window{
+			{Name: "editor", Command: "nvim ."},
+			{Name: "shell"},
+			{Name: "logs"},
+		},
+	}
+}
+
+// Parse parses and validates a YAML layout spec.
+func Parse(data []byte) (*Layout, error) {
+	var l Layout
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&l); err != nil {
+		return nil, fmt.Errorf("failed to parse layout: %w", err)
+	}
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ToYAML marshals the layout to YAML.
+func (l *Layout) ToYAML() ([]byte, error) {
+	return yaml.Marshal(l)
+}
+
+// GetMultiplexer returns the multiplexer, defaulting to tmux.
+func (l *Layout) GetMultiplexer() string {
+	if l.Multiplexer == "" {
+		return Tmux
+	}
+	return l.Multiplexer
+}
+
+// Validate checks the multiplexer and window names.
+func (l *Layout) Validate() error {
+	switch l.GetMultiplexer() {
+	case Tmux, Zellij:
+	default:
+		return fmt.Errorf("unsupported multiplexer %q (supported: %s, %s)", l.Multiplexer, Tmux, Zellij)
+	}
+	if len(l.Windows) == 0 {
+		return fmt.Errorf("layout must define at least one window")
+	}
+	seen := make(map[string]bool, len(l.Windows))
+	for i, w := range l.Windows {
+		if strings.TrimSpace(w.Name) == "" {
+			return fmt.Errorf("window %d: name is required", i+1)
+		}
+		if strings.ContainsAny(w.Name, ":.\n") {
+			return fmt.Errorf("window %q: name must not contain ':', '.' or newlines", w.Name)
+		}
+		if seen[w.Name] {
+			return fmt.Errorf("window %q is defined more than once", w.Name)
+		}
+		seen[w.Name] = true
+	}
+	return nil
+}
+
+var unsafeSessionChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// SessionName returns the multiplexer session name for a workspace. tmux
+// treats '.' and ':' as target separators, so anything outside
+// [A-Za-z0-9_-] is replaced.
+func SessionName(app, workspace string) string {
+	return strings.Trim(unsafeSessionChars.ReplaceAllString(app+"-"+workspace, "-"), "-")
+}
+
+// Command returns the argv that creates or re-attaches the session inside
+// the container. shell is started instead when the multiplexer is not
+// installed in the image.
+func (l *Layout) Command(session, shell string) []string {
+	return []string{"/bin/sh", "-c", l.Script(session, shell)}
+}
+
+// Script returns the POSIX shell script run by Command.
+func (l *Layout) Script(session, shell string) string {
+	mux := l.GetMultiplexer()
+	var b strings.Builder
+	fmt.Fprintf(&b, "export SHELL=%s\n", quote(shell))
+	fmt.Fprintf(&b, "if ! command -v %s >/dev/null 2>&1; then\n", mux)
+	fmt.Fprintf(&b, "  echo %s >&2\n", quote("dvm: "+mux+" is not installed in this workspace; starting a plain shell"))
+	b.WriteString("  exec \"$SHELL\" -l\nfi\n")
+	if mux == Zellij {
+		l.writeZellij(&b, session)
+	} else {
+		l.writeTmux(&b, session)
+	}
+	return b.String()
+}
+
+func (l *Layout) writeTmux(b *strings.Builder, session string) {
+	target := quote("=" + session)
+	fmt.Fprintf(b, "if ! tmux has-session -t %s 2>/dev/null; then\n", target)
+	for i, w := range l.Windows {
+		dir := ""
+		if w.Dir != "" {
+			dir = " -c " + quote(w.Dir)
+		}
+		if i == 0 {
+			fmt.Fprintf(b, "  first=$(tmux new-session -d -P -F '#{window_id}' -s %s -n %s%s)\n", quote(session), quote(w.Name), dir)
+			b.WriteString("  w=$first\n")
+		} else {
+			fmt.Fprintf(b, "  w=$(tmux new-window -P -F '#{window_id}' -t %s -n %s%s)\n", target, quote(w.Name), dir)
+		}
+		if w.Command != "" {
+			fmt.Fprintf(b, "  tmux send-keys -t \"$w\" %s C-m\n", quote(w.Command))
+		}
+	}
+	b.WriteString("  tmux select-window -t \"$first\"\nfi\n")
+	fmt.Fprintf(b, "exec tmux attach-session -t %s\n", target)
+}
+
+func (l *Layout) writeZellij(b *strings.Builder, session string) {
+	fmt.Fprintf(b, "if zellij list-sessions --short 2>/dev/null | grep -qx %s; then\n", quote(session))
+	fmt.Fprintf(b, "  exec zellij attach %s\nfi\n", quote(session))
+	b.WriteString("layout=$(mktemp \"${TMPDIR:-/tmp}/dvm-layout.XXXXXX\")\n")
+	b.WriteString("cat > \"$layout\" <<'DVM_LAYOUT'\n")
+	b.WriteString(l.ZellijKDL())
+	b.WriteString("DVM_LAYOUT\n")
+	fmt.Fprintf(b, "exec zellij --session %s --layout \"$layout\"\n", quote(session))
+}
+
+// ZellijKDL renders the layout as a zellij KDL layout with one tab per
+// window. Commands run through the shell so the pane stays usable after
+// they exit.
+func (l *Layout) ZellijKDL() string {
+	var b strings.Builder
+	b.WriteString("layout {\n")
+	for i, w := range l.Windows {
+		fmt.Fprintf(&b, "    tab name=%s", kdlString(w.Name))
+		if w.Dir != "" {
+			fmt.Fprintf(&b, " cwd=%s", kdlString(w.Dir))
+		}
+		if i == 0 {
+			b.WriteString(" focus=true")
+		}
+		b.WriteString(" {\n")
+		if w.Command != "" {
+			fmt.Fprintf(&b, "        pane command=\"sh\" {\n            args \"-c\" %s\n        }\n",
+				kdlString(w.Command+"; exec \"$SHELL\""))
+		} else {
+			b.WriteString("        pane\n")
+		}
+		b.WriteString("    }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// quote single-quotes s for POSIX sh.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// kdlString returns s as a KDL string literal.
+func kdlString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,118 @@
+package muxlayout
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	l, err := Parse([]byte(`
+multiplexer: zellij
+windows:
+  - name: editor
+    command: nvim .
+  - name: logs
+    dir: /var/log
+`))
+	require.NoError(t, err)
+	assert.Equal(t, Zellij, l.GetMultiplexer())
+	assert.Equal(t, []Window{{Name: "editor", Command: "nvim ."}, {Name: "logs", Dir: "/var/log"}}, l.Windows)
+
+	data, err := l.ToYAML()
+	require.NoError(t, err)
+	roundTrip, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, l, roundTrip)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown multiplexer": "multiplexer: screen\nwindows: [{name: a}]",
+		"no windows":          "multiplexer: tmux\nwindows: []",
+		"missing name":        "windows: [{command: ls}]",
+		"duplicate name":      "windows: [{name: a}, {name: a}]",
+		"name with colon":     "windows: [{name: 'a:b'}]",
+		"unknown field":       "windows: [{name: a, cmd: ls}]",
+	}
+	for name, spec := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(spec))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDefaultLayout(t *testing.T) {
+	l := DefaultLayout()
+	require.NoError(t, l.Validate())
+	var names []string
+	for _, w := range l.Windows {
+		names = append(names, w.Name)
+	}
+	assert.Equal(t, []string{"editor", "shell", "logs"}, names)
+}
+
+func TestSessionName(t *testing.T) {
+	assert.Equal(t, "api-dev", SessionName("api", "dev"))
+	assert.Equal(t, "my-app-feature-x", SessionName("my.app", "feature:x"))
+	assert.Equal(t, "web-v2", SessionName("web", "v2."))
+}
+
+func TestScript_Syntax(t *testing.T) {
+	l := DefaultLayout()
+	l.Windows[2].Command = `echo "it's here"`
+	for _, mux := range []string{Tmux, Zellij} {
+		l.Multiplexer = mux
+		out, err := exec.Command("sh", "-n", "-c", l.Script("api-dev", "/bin/zsh")).CombinedOutput()
+		assert.NoError(t, err, "%s: %s", mux, out)
+	}
+}
+
+func TestZellijKDL(t *testing.T) {
+	l := &Layout{Multiplexer: Zellij, Windows: []Window{
+		{Name: "editor", Command: `nvim "main.go"`, Dir: "/workspace"},
+		{Name: "shell"},
+	}}
+	kdl := l.ZellijKDL()
+	assert.Contains(t, kdl, `tab name="editor" cwd="/workspace" focus=true {`)
+	assert.Contains(t, kdl, `args "-c" "nvim \"main.go\"; exec \"$SHELL\""`)
+	assert.Contains(t, kdl, "tab name=\"shell\" {\n        pane\n")
+}
+
+// TestScript_Tmux runs the tmux script against a private tmux server. The
+// final attach fails without a terminal, but the session is already built.
+func TestScript_Tmux(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Setenv("TMUX", "")
+	defer exec.Command("tmux", "kill-server").Run()
+
+	l := &Layout{Windows: []Window{
+		{Name: "editor", Command: "echo editing"},
+		{Name: "shell", Dir: os.TempDir()},
+		{Name: "logs"},
+	}}
+	run := func() {
+		_ = exec.Command("sh", "-c", l.Script("api-dev", "/bin/sh")).Run()
+	}
+	run()
+
+	windows := func() []string {
+		out, err := exec.Command("tmux", "list-windows", "-t", "=api-dev", "-F", "#{window_name}").Output()
+		require.NoError(t, err)
+		return strings.Fields(string(out))
+	}
+	assert.Equal(t, []string{"editor", "shell", "logs"}, windows())
+
+	// Re-running attaches to the existing session instead of adding windows.
+	require.NoError(t, exec.Command("tmux", "kill-window", "-t", "=api-dev:logs").Run())
+	run()
+	assert.Equal(t, []string{"editor", "shell"}, windows())
+}
@@ -0,0 +1,122 @@
+// Package terminalbridge provides database adapters that bridge MaestroTerminal types
+// with dvm's database layer (models, db packages).
+// This file implements terminal package inheritance: resolving a package's
+// extends chain into a single flattened package.
+package terminalbridge
+
+import (
+	"fmt"
+	"strings"
+
+	"devopsmaestro/pkg/internal/listmerge"
+
+	terminalpkg "github.com/rmkohlman/MaestroTerminal/terminalops/package"
+)
+
+// PackageLookup returns the named terminal package, or an error if it does
+// not exist. Callers typically check the database first and fall back to the
+// embedded package library.
+type PackageLookup func(name string) (*terminalpkg.Package, error)
+
+// ResolvedPackage is a terminal package with its extends chain flattened.
+type ResolvedPackage struct {
+	// Package is the merged result. Its Extends field is cleared since
+	// inherited content has already been applied.
+	Package *terminalpkg.Package
+
+	// Chain lists the packages that contributed, starting with the requested
+	// package and ending at the root of the extends chain.
+	Chain []string
+}
+
+// Inherited returns the ancestors of the resolved package, nearest first.
+func (r *ResolvedPackage) Inherited() []string {
+	if len(r.Chain) <= 1 {
+		return nil
+	}
+	return r.Chain[1:]
+}
+
+// ResolvePackage walks the extends chain of the named package and merges it
+// into a single package, applying ancestors first so descendants override them.
+//
+// Merge semantics:
+//   - Plugins, Prompts, Profiles, PromptExtensions and Tags are concatenated
+//     parent-first with duplicates dropped. An entry prefixed with "-" removes
+//     that item inherited from an ancestor (e.g. "-zsh-autosuggestions").
+//   - Description, Category, PromptStyle and Theme are overridden by the
+//     nearest package that sets them; WezTerm settings override field by field.
+//   - Name and Enabled always come from the requested package.
+//
+// A cycle in the chain is an error naming the packages involved.
+func ResolvePackage(name string, lookup PackageLookup) (*ResolvedPackage, error) {
+	var chain []*terminalpkg.Package
+	var names []string
+	seen := make(map[string]bool)
+
+	for current := name; current != ""; {
+		if seen[current] {
+			return nil, fmt.Errorf("terminal package extends cycle: %s -> %s", strings.Join(names, " -> "), current)
+		}
+		seen[current] = true
+
+		p, err := lookup(current)
+		if err != nil {
+			if len(names) > 0 {
+				return nil, fmt.Errorf("terminal package '%s' extends '%s': %w", names[len(names)-1], current, err)
+			}
+			return nil, err
+		}
+		chain = append(chain, p)
+		names = append(names, current)
+		current = p.Extends
+	}
+
+	merged := &terminalpkg.Package{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		mergePackage(merged, chain[i])
+	}
+	merged.Name = chain[0].Name
+	merged.Enabled = chain[0].Enabled
+	merged.CreatedAt = chain[0].CreatedAt
+	merged.UpdatedAt = chain[0].UpdatedAt
+	merged.Extends = ""
+
+	return &ResolvedPackage{Package: merged, Chain: names}, nil
+}
+
+// mergePackage layers child on top of dst.
+func mergePackage(dst, child *terminalpkg.Package) {
+	if child.Description != "" {
+		dst.Description = child.Description
+	}
+	if child.Category != "" {
+		dst.Category = child.Category
+	}
+	if child.PromptStyle != "" {
+		dst.PromptStyle = child.PromptStyle
+	}
+	if child.Theme != "" {
+		dst.Theme = child.Theme
+	}
+	if child.WezTerm != nil {
+		if dst.WezTerm == nil {
+			dst.WezTerm = &terminalpkg.WezTermConfig{}
+		}
+		if child.WezTerm.FontSize != 0 {
+			dst.WezTerm.FontSize = child.WezTerm.FontSize
+		}
+		if child.WezTerm.ColorScheme != "" {
+			dst.WezTerm.ColorScheme = child.WezTerm.ColorScheme
+		}
+		if child.WezTerm.FontFamily != "" {
+			dst.WezTerm.FontFamily = child.WezTerm.FontFamily
+		}
+	}
+
+	dst.Plugins = listmerge.Merge(dst.Plugins, child.Plugins)
+	dst.Prompts = listmerge.Merge(dst.Prompts, child.Prompts)
+	dst.Profiles = listmerge.Merge(dst.Profiles, child.Profiles)
+	dst.PromptExtensions = listmerge.Merge(dst.PromptExtensions, child.PromptExtensions)
+	dst.Tags = listmerge.Merge(dst.Tags, child.Tags)
+}
@@ -0,0 +1,115 @@
+package terminalbridge
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	terminalpkg "github.com/rmkohlman/MaestroTerminal/terminalops/package"
+)
+
+func lookupFrom(pkgs ...*terminalpkg.Package) PackageLookup {
+	byName := make(map[string]*terminalpkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = p
+	}
+	return func(name string) (*terminalpkg.Package, error) {
+		if p, ok := byName[name]; ok {
+			return p, nil
+		}
+		return nil, fmt.Errorf("terminal package '%s' not found", name)
+	}
+}
+
+func TestResolvePackage(t *testing.T) {
+	lookup := lookupFrom(
+		&terminalpkg.Package{
+			Name: "core", Description: "Core", Category: "base",
+			Plugins:     []string{"zsh-autosuggestions", "zsh-syntax-highlighting"},
+			Prompts:     []string{"starship-minimal"},
+			PromptStyle: "minimal", Theme: "tokyonight",
+			WezTerm: &terminalpkg.WezTermConfig{FontSize: 13, FontFamily: "JetBrains Mono"},
+		},
+		&terminalpkg.Package{
+			Name: "dev", Extends: "core",
+			Plugins:  []string{"fzf", "zsh-autosuggestions"},
+			Profiles: []string{"developer"},
+		},
+		&terminalpkg.Package{
+			Name: "team", Extends: "dev", Description: "Team setup", Enabled: true,
+			Plugins: []string{"-zsh-syntax-highlighting", "zoxide"},
+			Theme:   "coolnight-ocean",
+			WezTerm: &terminalpkg.WezTermConfig{FontSize: 15},
+		},
+	)
+
+	res, err := ResolvePackage("team", lookup)
+	if err != nil {
+		t.Fatalf("ResolvePackage() error = %v", err)
+	}
+
+	p := res.Package
+	if want := []string{"team", "dev", "core"}; !reflect.DeepEqual(res.Chain, want) {
+		t.Errorf("Chain = %v, want %v", res.Chain, want)
+	}
+	if want := []string{"dev", "core"}; !reflect.DeepEqual(res.Inherited(), want) {
+		t.Errorf("Inherited() = %v, want %v", res.Inherited(), want)
+	}
+	if want := []string{"zsh-autosuggestions", "fzf", "zoxide"}; !reflect.DeepEqual(p.Plugins, want) {
+		t.Errorf("Plugins = %v, want %v", p.Plugins, want)
+	}
+	if !reflect.DeepEqual(p.Prompts, []string{"starship-minimal"}) || !reflect.DeepEqual(p.Profiles, []string{"developer"}) {
+		t.Errorf("Prompts = %v, Profiles = %v", p.Prompts, p.Profiles)
+	}
+	if p.Name != "team" || p.Description != "Team setup" || p.Category != "base" || !p.Enabled {
+		t.Errorf("unexpected identity fields: %+v", p)
+	}
+	if p.PromptStyle != "minimal" || p.Theme != "coolnight-ocean" {
+		t.Errorf("PromptStyle = %q, Theme = %q", p.PromptStyle, p.Theme)
+	}
+	if p.WezTerm == nil || p.WezTerm.FontSize != 15 || p.WezTerm.FontFamily != "JetBrains Mono" {
+		t.Errorf("WezTerm = %+v", p.WezTerm)
+	}
+	if p.Extends != "" {
+		t.Errorf("Extends = %q, want empty after flattening", p.Extends)
+	}
+}
+
+func TestResolvePackage_NoExtends(t *testing.T) {
+	res, err := ResolvePackage("solo", lookupFrom(&terminalpkg.Package{Name: "solo", Plugins: []string{"a", "a", "b"}}))
+	if err != nil {
+		t.Fatalf("ResolvePackage() error = %v", err)
+	}
+	if !reflect.DeepEqual(res.Package.Plugins, []string{"a", "b"}) {
+		t.Errorf("Plugins = %v", res.Package.Plugins)
+	}
+	if res.Inherited() != nil {
+		t.Errorf("Inherited() = %v, want nil", res.Inherited())
+	}
+}
+
+func TestResolvePackage_Errors(t *testing.T) {
+	cycle := lookupFrom(
+		&terminalpkg.Package{Name: "a", Extends: "b"},
+		&terminalpkg.Package{Name: "b", Extends: "c"},
+		&terminalpkg.Package{Name: "c", Extends: "a"},
+	)
+	if _, err := ResolvePackage("a", cycle); err == nil || !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+
+	self := lookupFrom(&terminalpkg.Package{Name: "a", Extends: "a"})
+	if _, err := ResolvePackage("a", self); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected self-extends cycle error, got %v", err)
+	}
+
+	missing := lookupFrom(&terminalpkg.Package{Name: "a", Extends: "ghost"})
+	if _, err := ResolvePackage("a", missing); err == nil || !strings.Contains(err.Error(), "'a' extends 'ghost'") {
+		t.Errorf("expected missing parent error, got %v", err)
+	}
+
+	if _, err := ResolvePackage("nope", missing); err == nil {
+		t.Error("expected error for unknown package")
+	}
+}
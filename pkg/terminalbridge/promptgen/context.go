@@ -0,0 +1,81 @@
+package promptgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+)
+
+// ContextModuleName is the Starship custom module that shows the active dvm
+// context. Formats reference it as ${custom.dvm}; $all includes it.
+const ContextModuleName = "custom.dvm"
+
+// ContextCommand prints the context for the module. It prints nothing when
+// no context is active, which hides the module.
+const ContextCommand = "dvm context current --format starship"
+
+// contextModuleRef is how a Starship format references the module.
+const contextModuleRef = "${" + ContextModuleName + "}"
+
+// ContextModule returns the Starship custom module configuration for the
+// dvm context segment.
+func ContextModule() prompt.ModuleConfig {
+	return prompt.ModuleConfig{
+		Format: "[$symbol$output]($style) ",
+		Style:  "bold ${theme.magenta}",
+		Symbol: "◆ ",
+		Options: map[string]any{
+			"command":     ContextCommand,
+			"when":        true,
+			"description": "Active dvm context",
+		},
+	}
+}
+
+// WithContextModule returns a copy of p with the dvm context module added.
+// An existing custom.dvm module is kept as is. When p has a format that
+// references neither the module nor $all, the module is placed before
+// $character (or at the end).
+func WithContextModule(p *prompt.Prompt) *prompt.Prompt {
+	out := *p
+	out.Modules = make(map[string]prompt.ModuleConfig, len(p.Modules)+1)
+	for name, m := range p.Modules {
+		out.Modules[name] = m
+	}
+	if _, ok := out.Modules[ContextModuleName]; !ok {
+		out.Modules[ContextModuleName] = ContextModule()
+	}
+
+	if out.Format == "" || strings.Contains(out.Format, contextModuleRef) ||
+		strings.Contains(out.Format, "$all") || strings.Contains(out.Format, "${custom}") {
+		return &out
+	}
+	if i := strings.Index(out.Format, "$character"); i >= 0 {
+		out.Format = out.Format[:i] + contextModuleRef + out.Format[i:]
+	} else {
+		out.Format += contextModuleRef
+	}
+	return &out
+}
+
+// ContextModuleSnippet renders the [custom.dvm] section to paste into an
+// existing starship.toml, with its style color taken from pal (nil keeps the
+// ANSI color name).
+func ContextModuleSnippet(pal *palette.Palette) string {
+	m := ContextModule()
+	fg, _ := parseStyle(m.Style)
+	style := strings.Replace(m.Style, fg, resolveHex(pal, fg), 1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Active dvm context. Add %s to your format unless it uses $all.\n", contextModuleRef)
+	fmt.Fprintf(&b, "[%s]\n", ContextModuleName)
+	fmt.Fprintf(&b, "command = %q\n", m.Options["command"])
+	fmt.Fprintf(&b, "when = %v\n", m.Options["when"])
+	fmt.Fprintf(&b, "description = %q\n", m.Options["description"])
+	fmt.Fprintf(&b, "format = %q\n", m.Format)
+	fmt.Fprintf(&b, "style = %q\n", style)
+	fmt.Fprintf(&b, "symbol = %q\n", m.Symbol)
+	return b.String()
+}
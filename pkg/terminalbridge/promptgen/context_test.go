@@ -0,0 +1,69 @@
+package promptgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+)
+
+func TestWithContextModule(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", ""},
+		{"$all", "$all"},
+		{"$directory$character", "$directory${custom.dvm}$character"},
+		{"$directory", "$directory${custom.dvm}"},
+		{"${custom.dvm}$directory", "${custom.dvm}$directory"},
+	}
+	for _, tt := range tests {
+		p := testPrompt(prompt.PromptTypeStarship)
+		p.Format = tt.format
+		got := WithContextModule(p)
+		if got.Format != tt.want {
+			t.Errorf("WithContextModule(%q).Format = %q, want %q", tt.format, got.Format, tt.want)
+		}
+		if _, ok := got.Modules[ContextModuleName]; !ok {
+			t.Errorf("WithContextModule(%q) did not add %s", tt.format, ContextModuleName)
+		}
+		if _, ok := p.Modules[ContextModuleName]; ok {
+			t.Error("WithContextModule() modified the input prompt")
+		}
+	}
+
+	// An existing module is kept
+	p := testPrompt(prompt.PromptTypeStarship)
+	p.Modules[ContextModuleName] = prompt.ModuleConfig{Symbol: "ctx "}
+	if got := WithContextModule(p).Modules[ContextModuleName].Symbol; got != "ctx " {
+		t.Errorf("existing module symbol = %q, want %q", got, "ctx ")
+	}
+}
+
+func TestWithContextModule_StarshipRender(t *testing.T) {
+	out, err := New().GenerateWithPalette(WithContextModule(testPrompt(prompt.PromptTypeStarship)), testPalette())
+	if err != nil {
+		t.Fatalf("GenerateWithPalette() error = %v", err)
+	}
+	for _, want := range []string{"[custom.dvm]", `command = "` + ContextCommand + `"`, "when = true", "${custom.dvm}$character"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered config missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestContextModuleSnippet(t *testing.T) {
+	pal := testPalette()
+	pal.Colors["magenta"] = "#bb9af7"
+	snippet := ContextModuleSnippet(pal)
+	for _, want := range []string{"[custom.dvm]\n", `command = "dvm context current --format starship"`, `style = "bold #bb9af7"`} {
+		if !strings.Contains(snippet, want) {
+			t.Errorf("snippet missing %q:\n%s", want, snippet)
+		}
+	}
+
+	if snippet := ContextModuleSnippet(nil); !strings.Contains(snippet, `style = "bold magenta"`) {
+		t.Errorf("snippet without palette should keep the color name:\n%s", snippet)
+	}
+}
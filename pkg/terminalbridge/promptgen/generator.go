@@ -1,6 +1,6 @@
 // Package promptgen extracts prompt configuration generation logic from the CLI
 // layer into a reusable package. It handles the pipeline: prompt → YAML → renderer
-// → generated config (e.g., starship.toml content).
+// → generated config (starship.toml, an oh-my-posh JSON config, or .p10k.zsh).
 //
 // This keeps the CLI layer thin — it only orchestrates, while this package
 // owns the generation logic.
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/rmkohlman/MaestroPalette"
 	"github.com/rmkohlman/MaestroSDK/colors"
 	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
 )
@@ -37,6 +38,21 @@ func NewWithRenderer(r prompt.PromptRenderer) *Generator {
 	}
 }
 
+// NewForType creates a Generator with the renderer for a prompt type:
+// starship, oh-my-posh, or powerlevel10k. An empty type means starship.
+func NewForType(t prompt.PromptType) (*Generator, error) {
+	switch t {
+	case "", prompt.PromptTypeStarship:
+		return New(), nil
+	case prompt.PromptTypeOhMyPosh:
+		return NewWithRenderer(NewOhMyPoshRenderer()), nil
+	case prompt.PromptTypePowerlevel10k:
+		return NewWithRenderer(NewP10kRenderer()), nil
+	default:
+		return nil, fmt.Errorf("unsupported prompt type %q (supported: starship, oh-my-posh, powerlevel10k)", t)
+	}
+}
+
 // Generate renders a prompt's configuration using the color palette from ctx.
 // Returns the generated config string (e.g., starship.toml content).
 func (g *Generator) Generate(ctx context.Context, p *prompt.Prompt) (string, error) {
@@ -75,6 +91,24 @@ func (g *Generator) GenerateWithProvider(p *prompt.Prompt, provider colors.Color
 	return config, nil
 }
 
+// GenerateWithPalette renders using an explicit palette, e.g. one resolved
+// from the prompt's palette_ref.
+func (g *Generator) GenerateWithPalette(p *prompt.Prompt, pal *palette.Palette) (string, error) {
+	if p == nil {
+		return "", fmt.Errorf("prompt cannot be nil")
+	}
+	if pal == nil {
+		return "", fmt.Errorf("palette cannot be nil")
+	}
+
+	config, err := g.renderer.Render(p.ToYAML(), pal)
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt %q: %w", p.Name, err)
+	}
+
+	return config, nil
+}
+
 // Validate checks whether a prompt can be successfully rendered.
 // Returns nil if the prompt would generate valid config.
 func (g *Generator) Validate(ctx context.Context, p *prompt.Prompt) error {
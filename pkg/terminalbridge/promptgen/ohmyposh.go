@@ -0,0 +1,254 @@
+package promptgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt/composer"
+)
+
+// ohMyPoshSchema is the JSON schema URL oh-my-posh configs declare.
+const ohMyPoshSchema = "https://raw.githubusercontent.com/JanDeDobbeleer/oh-my-posh/main/themes/schema.json"
+
+// OhMyPoshRenderer converts stored prompts into oh-my-posh JSON configs.
+// Modules become segments (see segmentSpecs), theme colors are emitted as
+// the config's palette and referenced as "p:<name>", and the character
+// module becomes a final text segment that turns red on a non-zero exit.
+type OhMyPoshRenderer struct{}
+
+// NewOhMyPoshRenderer creates an OhMyPoshRenderer.
+func NewOhMyPoshRenderer() *OhMyPoshRenderer {
+	return &OhMyPoshRenderer{}
+}
+
+// ompConfig mirrors the subset of the oh-my-posh schema we generate.
+type ompConfig struct {
+	Schema     string            `json:"$schema"`
+	Version    int               `json:"version"`
+	FinalSpace bool              `json:"final_space"`
+	Palette    map[string]string `json:"palette,omitempty"`
+	Blocks     []ompBlock        `json:"blocks"`
+}
+
+type ompBlock struct {
+	Type      string       `json:"type"`
+	Alignment string       `json:"alignment"`
+	Newline   bool         `json:"newline,omitempty"`
+	Segments  []ompSegment `json:"segments"`
+}
+
+type ompSegment struct {
+	Type                string         `json:"type"`
+	Style               string         `json:"style"`
+	Foreground          string         `json:"foreground,omitempty"`
+	ForegroundTemplates []string       `json:"foreground_templates,omitempty"`
+	Background          string         `json:"background,omitempty"`
+	Template            string         `json:"template,omitempty"`
+	Properties          map[string]any `json:"properties,omitempty"`
+}
+
+// Render generates oh-my-posh JSON from a PromptYAML. A non-empty rawConfig
+// is returned verbatim.
+func (r *OhMyPoshRenderer) Render(py *prompt.PromptYAML, pal *palette.Palette) (string, error) {
+	if py == nil {
+		return "", fmt.Errorf("prompt is nil")
+	}
+	if pal == nil {
+		return "", fmt.Errorf("palette is nil")
+	}
+	p := py.ToPrompt()
+	if p.RawConfig != "" {
+		return p.RawConfig, nil
+	}
+	pal = withColorOverrides(pal, p.Colors)
+
+	cfg := ompConfig{
+		Schema:     ohMyPoshSchema,
+		Version:    2,
+		FinalSpace: true,
+		Palette:    ompPalette(pal),
+	}
+
+	var left, right []ompSegment
+	usedGit := false
+	for _, name := range orderedModules(p) {
+		spec, ok := segmentSpecs[name]
+		if !ok || spec.ompType == "" {
+			continue
+		}
+		// git_branch and git_status both map to the single git segment.
+		if spec.ompType == "git" {
+			if usedGit {
+				continue
+			}
+			usedGit = true
+		}
+		seg := r.segment(spec, p.Modules[name], pal)
+		if spec.right {
+			right = append(right, seg)
+		} else {
+			left = append(left, seg)
+		}
+	}
+	left = append(left, r.characterSegment(p.Character, pal))
+
+	cfg.Blocks = append(cfg.Blocks, ompBlock{Type: "prompt", Alignment: "left", Newline: p.AddNewline, Segments: left})
+	if len(right) > 0 {
+		cfg.Blocks = append(cfg.Blocks, ompBlock{Type: "prompt", Alignment: "right", Segments: right})
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode oh-my-posh config: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// RenderToFile renders and writes to a file path.
+func (r *OhMyPoshRenderer) RenderToFile(py *prompt.PromptYAML, pal *palette.Palette, path string) error {
+	return renderToFile(r, py, pal, path)
+}
+
+// RenderComposed renders a ComposedPrompt by converting it to a PromptYAML.
+func (r *OhMyPoshRenderer) RenderComposed(composed *composer.ComposedPrompt, name string, pal *palette.Palette) (string, error) {
+	py, err := composedToYAML(composed, name, prompt.PromptTypeOhMyPosh)
+	if err != nil {
+		return "", err
+	}
+	return r.Render(py, pal)
+}
+
+// RenderComposedToFile renders a ComposedPrompt and writes to a file path.
+func (r *OhMyPoshRenderer) RenderComposedToFile(composed *composer.ComposedPrompt, name string, pal *palette.Palette, path string) error {
+	content, err := r.RenderComposed(composed, name, pal)
+	if err != nil {
+		return err
+	}
+	return writeConfig(path, content)
+}
+
+// segment builds one oh-my-posh segment from a module.
+func (r *OhMyPoshRenderer) segment(spec segmentSpec, m prompt.ModuleConfig, pal *palette.Palette) ompSegment {
+	seg := ompSegment{
+		Type:     spec.ompType,
+		Style:    "plain",
+		Template: spec.ompTemplate,
+	}
+	if m.Symbol != "" {
+		seg.Template = " " + m.Symbol + strings.TrimPrefix(seg.Template, " ")
+	}
+	fg, bg := parseStyle(m.Style)
+	seg.Foreground = ompColor(pal, fg)
+	seg.Background = ompColor(pal, bg)
+	if seg.Background != "" {
+		seg.Style = "powerline"
+	}
+	if len(m.Options) > 0 {
+		seg.Properties = make(map[string]any, len(m.Options))
+		for k, v := range m.Options {
+			seg.Properties[k] = v
+		}
+	}
+	return seg
+}
+
+// characterSegment renders the prompt character as a text segment.
+func (r *OhMyPoshRenderer) characterSegment(c *prompt.CharacterConfig, pal *palette.Palette) ompSegment {
+	symbol, okStyle := "❯", "green"
+	errStyle := "red"
+	if c != nil {
+		if c.SuccessSymbol != "" {
+			symbol, okStyle = parseStyledText(c.SuccessSymbol)
+		}
+		if c.ErrorSymbol != "" {
+			_, errStyle = parseStyledText(c.ErrorSymbol)
+		}
+	}
+	okFg, _ := parseStyle(okStyle)
+	errFg, _ := parseStyle(errStyle)
+
+	seg := ompSegment{
+		Type:       "text",
+		Style:      "plain",
+		Foreground: ompColor(pal, okFg),
+		Template:   symbol,
+	}
+	if fg := ompColor(pal, errFg); fg != "" {
+		seg.ForegroundTemplates = []string{"{{ if gt .Code 0 }}" + fg + "{{ end }}"}
+	}
+	return seg
+}
+
+// ompColor converts a style color token to oh-my-posh syntax: palette names
+// become "p:<name>", hex values and unknown names pass through.
+func ompColor(pal *palette.Palette, token string) string {
+	name := colorName(token)
+	if name == "" || strings.HasPrefix(name, "#") {
+		return name
+	}
+	if lookupColor(pal, name) != "" {
+		return "p:" + name
+	}
+	return name
+}
+
+// ompPalette flattens the theme into oh-my-posh palette entries: ANSI colors
+// by plain name ("red"), semantic colors, then prompt color overrides.
+func ompPalette(pal *palette.Palette) map[string]string {
+	out := make(map[string]string)
+	for k, v := range pal.ToTerminalColors() {
+		out[strings.TrimPrefix(k, "ansi_")] = v
+	}
+	for k, v := range pal.Colors {
+		if _, exists := out[k]; !exists && v != "" {
+			out[k] = v
+		}
+	}
+	for k, v := range pal.PromptColors {
+		if v != "" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// composedToYAML converts a ComposedPrompt into the PromptYAML the renderers accept.
+func composedToYAML(composed *composer.ComposedPrompt, name string, t prompt.PromptType) (*prompt.PromptYAML, error) {
+	if composed == nil {
+		return nil, fmt.Errorf("composed prompt is nil")
+	}
+	py := prompt.NewPromptYAML(name, t)
+	py.Spec.AddNewline = true
+	py.Spec.Format = composed.Format
+	py.Spec.Modules = make(map[string]prompt.ModuleConfig, len(composed.Modules))
+	for k, m := range composed.Modules {
+		py.Spec.Modules[k] = prompt.ModuleConfig{
+			Disabled: m.Disabled,
+			Format:   m.Format,
+			Style:    m.Style,
+			Symbol:   m.Symbol,
+			Options:  m.Options,
+		}
+	}
+	return py, nil
+}
+
+// renderToFile renders with r and writes the result to path.
+func renderToFile(r prompt.PromptRenderer, py *prompt.PromptYAML, pal *palette.Palette, path string) error {
+	content, err := r.Render(py, pal)
+	if err != nil {
+		return fmt.Errorf("failed to render: %w", err)
+	}
+	return writeConfig(path, content)
+}
+
+func writeConfig(path, content string) error {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
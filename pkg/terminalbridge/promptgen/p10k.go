@@ -0,0 +1,195 @@
+package promptgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt/composer"
+)
+
+// P10kRenderer converts stored prompts into a Powerlevel10k config script
+// (.p10k.zsh). Modules become prompt elements (see segmentSpecs), styles are
+// resolved to hex colors from the palette, and module options are written as
+// POWERLEVEL9K_<ELEMENT>_<OPTION> parameters.
+type P10kRenderer struct{}
+
+// NewP10kRenderer creates a P10kRenderer.
+func NewP10kRenderer() *P10kRenderer {
+	return &P10kRenderer{}
+}
+
+// Render generates .p10k.zsh content from a PromptYAML. A non-empty rawConfig
+// is returned verbatim.
+func (r *P10kRenderer) Render(py *prompt.PromptYAML, pal *palette.Palette) (string, error) {
+	if py == nil {
+		return "", fmt.Errorf("prompt is nil")
+	}
+	if pal == nil {
+		return "", fmt.Errorf("palette is nil")
+	}
+	p := py.ToPrompt()
+	if p.RawConfig != "" {
+		return p.RawConfig, nil
+	}
+	pal = withColorOverrides(pal, p.Colors)
+
+	var left, right []string
+	var settings []string
+	seen := map[string]bool{}
+	for _, name := range orderedModules(p) {
+		spec, ok := segmentSpecs[name]
+		if !ok || spec.p10k == "" || seen[spec.p10k] {
+			continue
+		}
+		seen[spec.p10k] = true
+		if spec.right {
+			right = append(right, spec.p10k)
+		} else {
+			left = append(left, spec.p10k)
+		}
+		settings = append(settings, r.elementSettings(spec.p10k, p.Modules[name], pal)...)
+	}
+	left = append(left, "prompt_char")
+	settings = append(settings, r.promptCharSettings(p.Character, pal)...)
+
+	var b strings.Builder
+	b.WriteString("# Generated by dvm - do not edit\n")
+	fmt.Fprintf(&b, "# Prompt: %s\n", p.Name)
+	if p.Description != "" {
+		fmt.Fprintf(&b, "# Description: %s\n", p.Description)
+	}
+	fmt.Fprintf(&b, "# Theme: %s\n", pal.Name)
+	b.WriteString("#\n# Source from ~/.zshrc after loading powerlevel10k:\n#   [[ -f ~/.p10k.zsh ]] && source ~/.p10k.zsh\n\n")
+
+	b.WriteString("'builtin' 'local' '-a' 'p10k_config_opts'\n")
+	b.WriteString("[[ ! -o 'aliases'         ]] || p10k_config_opts+=('aliases')\n")
+	b.WriteString("[[ ! -o 'sh_glob'         ]] || p10k_config_opts+=('sh_glob')\n")
+	b.WriteString("[[ ! -o 'no_brace_expand' ]] || p10k_config_opts+=('no_brace_expand')\n")
+	b.WriteString("'builtin' 'setopt' 'no_aliases' 'no_sh_glob' 'brace_expand'\n\n")
+
+	b.WriteString("() {\n")
+	b.WriteString("  emulate -L zsh -o extended_glob\n")
+	b.WriteString("  unset -m '(POWERLEVEL9K_*|DEFAULT_USER)~POWERLEVEL9K_GITSTATUS_DIR'\n\n")
+	fmt.Fprintf(&b, "  typeset -g POWERLEVEL9K_LEFT_PROMPT_ELEMENTS=(%s)\n", strings.Join(left, " "))
+	fmt.Fprintf(&b, "  typeset -g POWERLEVEL9K_RIGHT_PROMPT_ELEMENTS=(%s)\n", strings.Join(right, " "))
+	fmt.Fprintf(&b, "  typeset -g POWERLEVEL9K_PROMPT_ADD_NEWLINE=%t\n", p.AddNewline)
+	b.WriteString("  typeset -g POWERLEVEL9K_MODE=nerdfont-complete\n")
+	b.WriteString("  typeset -g POWERLEVEL9K_BACKGROUND=\n")
+	b.WriteString("  typeset -g POWERLEVEL9K_{LEFT,RIGHT}_{LEFT,RIGHT}_WHITESPACE=\n")
+	b.WriteString("  typeset -g POWERLEVEL9K_{LEFT,RIGHT}_SUBSEGMENT_SEPARATOR=' '\n")
+	b.WriteString("  typeset -g POWERLEVEL9K_{LEFT,RIGHT}_SEGMENT_SEPARATOR=\n")
+	if len(settings) > 0 {
+		b.WriteString("\n")
+		for _, s := range settings {
+			b.WriteString("  " + s + "\n")
+		}
+	}
+	b.WriteString("\n  (( ! $+functions[p10k] )) || p10k reload\n")
+	b.WriteString("}\n\n")
+	b.WriteString("(( ${#p10k_config_opts} )) && setopt ${p10k_config_opts[@]}\n")
+	b.WriteString("'builtin' 'unset' 'p10k_config_opts'\n")
+	return b.String(), nil
+}
+
+// RenderToFile renders and writes to a file path.
+func (r *P10kRenderer) RenderToFile(py *prompt.PromptYAML, pal *palette.Palette, path string) error {
+	return renderToFile(r, py, pal, path)
+}
+
+// RenderComposed renders a ComposedPrompt by converting it to a PromptYAML.
+func (r *P10kRenderer) RenderComposed(composed *composer.ComposedPrompt, name string, pal *palette.Palette) (string, error) {
+	py, err := composedToYAML(composed, name, prompt.PromptTypePowerlevel10k)
+	if err != nil {
+		return "", err
+	}
+	return r.Render(py, pal)
+}
+
+// RenderComposedToFile renders a ComposedPrompt and writes to a file path.
+func (r *P10kRenderer) RenderComposedToFile(composed *composer.ComposedPrompt, name string, pal *palette.Palette, path string) error {
+	content, err := r.RenderComposed(composed, name, pal)
+	if err != nil {
+		return err
+	}
+	return writeConfig(path, content)
+}
+
+// elementSettings returns the typeset lines for one prompt element.
+func (r *P10kRenderer) elementSettings(element string, m prompt.ModuleConfig, pal *palette.Palette) []string {
+	prefix := "POWERLEVEL9K_" + strings.ToUpper(element)
+	var lines []string
+	fg, bg := parseStyle(m.Style)
+	if fg != "" {
+		lines = append(lines, fmt.Sprintf("typeset -g %s_FOREGROUND=%s", prefix, zshQuote(resolveHex(pal, fg))))
+	}
+	if bg != "" {
+		lines = append(lines, fmt.Sprintf("typeset -g %s_BACKGROUND=%s", prefix, zshQuote(resolveHex(pal, bg))))
+	}
+	if m.Symbol != "" {
+		lines = append(lines, fmt.Sprintf("typeset -g %s_VISUAL_IDENTIFIER_EXPANSION=%s", prefix, zshQuote(strings.TrimSpace(m.Symbol))))
+	}
+
+	keys := make([]string, 0, len(m.Options))
+	for k := range m.Options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("typeset -g %s_%s=%s", prefix, strings.ToUpper(k), zshValue(m.Options[k])))
+	}
+	return lines
+}
+
+// promptCharSettings styles prompt_char from the character config.
+func (r *P10kRenderer) promptCharSettings(c *prompt.CharacterConfig, pal *palette.Palette) []string {
+	okSymbol, okStyle := "❯", "green"
+	errSymbol, errStyle := "❯", "red"
+	viSymbol := "❮"
+	if c != nil {
+		if c.SuccessSymbol != "" {
+			okSymbol, okStyle = parseStyledText(c.SuccessSymbol)
+		}
+		if c.ErrorSymbol != "" {
+			errSymbol, errStyle = parseStyledText(c.ErrorSymbol)
+		}
+		if c.ViCmdSymbol != "" {
+			viSymbol, _ = parseStyledText(c.ViCmdSymbol)
+		}
+	}
+	okFg, _ := parseStyle(okStyle)
+	errFg, _ := parseStyle(errStyle)
+	return []string{
+		fmt.Sprintf("typeset -g POWERLEVEL9K_PROMPT_CHAR_OK_{VIINS,VICMD,VIVIS,VIOWR}_FOREGROUND=%s", zshQuote(resolveHex(pal, okFg))),
+		fmt.Sprintf("typeset -g POWERLEVEL9K_PROMPT_CHAR_ERROR_{VIINS,VICMD,VIVIS,VIOWR}_FOREGROUND=%s", zshQuote(resolveHex(pal, errFg))),
+		fmt.Sprintf("typeset -g POWERLEVEL9K_PROMPT_CHAR_OK_VIINS_CONTENT_EXPANSION=%s", zshQuote(okSymbol)),
+		fmt.Sprintf("typeset -g POWERLEVEL9K_PROMPT_CHAR_ERROR_VIINS_CONTENT_EXPANSION=%s", zshQuote(errSymbol)),
+		fmt.Sprintf("typeset -g POWERLEVEL9K_PROMPT_CHAR_{OK,ERROR}_VICMD_CONTENT_EXPANSION=%s", zshQuote(viSymbol)),
+	}
+}
+
+// zshQuote single-quotes s for zsh.
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshValue renders an option value: bools and numbers bare, lists as zsh
+// arrays, everything else quoted.
+func zshValue(v any) string {
+	switch x := v.(type) {
+	case bool:
+		return fmt.Sprintf("%t", x)
+	case int, int64, float64:
+		return fmt.Sprintf("%v", x)
+	case []any:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			parts[i] = zshQuote(fmt.Sprint(item))
+		}
+		return "(" + strings.Join(parts, " ") + ")"
+	default:
+		return zshQuote(fmt.Sprint(x))
+	}
+}
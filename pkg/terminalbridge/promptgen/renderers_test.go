@@ -0,0 +1,151 @@
+package promptgen
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+)
+
+func testPalette() *palette.Palette {
+	return &palette.Palette{
+		Name: "test-theme",
+		Colors: map[string]string{
+			palette.ColorBg:    "#1a1b26",
+			palette.ColorFg:    "#c0caf5",
+			palette.ColorRed:   "#f7768e",
+			palette.ColorGreen: "#9ece6a",
+			palette.ColorBlue:  "#7aa2f7",
+		},
+	}
+}
+
+func testPrompt(t prompt.PromptType) *prompt.Prompt {
+	return &prompt.Prompt{
+		Name:       "dev",
+		Type:       t,
+		AddNewline: true,
+		Format:     "$directory$git_branch$nodejs$time$character",
+		Modules: map[string]prompt.ModuleConfig{
+			"directory":  {Style: "bold fg:${theme.blue}", Symbol: " "},
+			"git_branch": {Style: "bg:#000000 fg:green"},
+			"nodejs":     {Style: "yellow"},
+			"time":       {Options: map[string]any{"time_format": "%R"}},
+			"python":     {Disabled: true},
+			"unknown":    {},
+		},
+		Character: &prompt.CharacterConfig{SuccessSymbol: "[➜](bold green)", ErrorSymbol: "[➜](bold red)"},
+		Enabled:   true,
+	}
+}
+
+func TestNewForType(t *testing.T) {
+	for _, typ := range []prompt.PromptType{"", prompt.PromptTypeStarship, prompt.PromptTypeOhMyPosh, prompt.PromptTypePowerlevel10k} {
+		if _, err := NewForType(typ); err != nil {
+			t.Errorf("NewForType(%q) error = %v", typ, err)
+		}
+	}
+	if _, err := NewForType("pure"); err == nil {
+		t.Error("expected error for unsupported prompt type")
+	}
+}
+
+func TestOrderedModules(t *testing.T) {
+	got := orderedModules(testPrompt(prompt.PromptTypeStarship))
+	want := []string{"directory", "git_branch", "nodejs", "time", "unknown"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("orderedModules() = %v, want %v", got, want)
+	}
+}
+
+func TestOhMyPoshRenderer(t *testing.T) {
+	g, _ := NewForType(prompt.PromptTypeOhMyPosh)
+	out, err := g.GenerateWithPalette(testPrompt(prompt.PromptTypeOhMyPosh), testPalette())
+	if err != nil {
+		t.Fatalf("GenerateWithPalette() error = %v", err)
+	}
+
+	var cfg ompConfig
+	if err := json.Unmarshal([]byte(out), &cfg); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	if cfg.Palette["blue"] != "#7aa2f7" {
+		t.Errorf("palette blue = %q", cfg.Palette["blue"])
+	}
+	if len(cfg.Blocks) != 2 || !cfg.Blocks[0].Newline {
+		t.Fatalf("blocks = %+v", cfg.Blocks)
+	}
+
+	left := cfg.Blocks[0].Segments
+	var types []string
+	for _, s := range left {
+		types = append(types, s.Type)
+	}
+	if got := strings.Join(types, ","); got != "path,git,node,text" {
+		t.Errorf("left segments = %s", got)
+	}
+	if left[0].Foreground != "p:blue" || !strings.Contains(left[0].Template, " ") {
+		t.Errorf("path segment = %+v", left[0])
+	}
+	if left[1].Background != "#000000" || left[1].Style != "powerline" {
+		t.Errorf("git segment = %+v", left[1])
+	}
+	if left[3].Template != "➜" || len(left[3].ForegroundTemplates) != 1 {
+		t.Errorf("character segment = %+v", left[3])
+	}
+	if right := cfg.Blocks[1].Segments; right[0].Type != "time" || right[0].Properties["time_format"] != "%R" {
+		t.Errorf("right segments = %+v", right)
+	}
+}
+
+func TestP10kRenderer(t *testing.T) {
+	g, _ := NewForType(prompt.PromptTypePowerlevel10k)
+	out, err := g.GenerateWithPalette(testPrompt(prompt.PromptTypePowerlevel10k), testPalette())
+	if err != nil {
+		t.Fatalf("GenerateWithPalette() error = %v", err)
+	}
+	for _, want := range []string{
+		"typeset -g POWERLEVEL9K_LEFT_PROMPT_ELEMENTS=(dir vcs node_version prompt_char)",
+		"typeset -g POWERLEVEL9K_RIGHT_PROMPT_ELEMENTS=(time)",
+		"typeset -g POWERLEVEL9K_PROMPT_ADD_NEWLINE=true",
+		"typeset -g POWERLEVEL9K_DIR_FOREGROUND='#7aa2f7'",
+		"typeset -g POWERLEVEL9K_VCS_BACKGROUND='#000000'",
+		"typeset -g POWERLEVEL9K_TIME_TIME_FORMAT='%R'",
+		"POWERLEVEL9K_PROMPT_CHAR_ERROR_{VIINS,VICMD,VIVIS,VIOWR}_FOREGROUND='#f7768e'",
+		"POWERLEVEL9K_PROMPT_CHAR_OK_VIINS_CONTENT_EXPANSION='➜'",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("p10k output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderers_RawConfigAndColorOverrides(t *testing.T) {
+	p := testPrompt(prompt.PromptTypePowerlevel10k)
+	p.Colors = map[string]string{"blue": "#123456"}
+	g, _ := NewForType(prompt.PromptTypePowerlevel10k)
+	out, _ := g.GenerateWithPalette(p, testPalette())
+	if !strings.Contains(out, "POWERLEVEL9K_DIR_FOREGROUND='#123456'") {
+		t.Errorf("prompt color override not applied:\n%s", out)
+	}
+
+	p.RawConfig = "# hand written\n"
+	if out, _ := g.GenerateWithPalette(p, testPalette()); out != p.RawConfig {
+		t.Errorf("raw config not returned verbatim: %q", out)
+	}
+}
+
+func TestInitSnippet(t *testing.T) {
+	if _, err := InitSnippet(prompt.PromptTypePowerlevel10k, "bash", "/x"); err == nil {
+		t.Error("expected p10k to be zsh-only")
+	}
+	s, err := InitSnippet(prompt.PromptTypeOhMyPosh, "zsh", "/cfg/dev.omp.json")
+	if err != nil || s != `eval "$(oh-my-posh init zsh --config "/cfg/dev.omp.json")"` {
+		t.Errorf("InitSnippet() = %q, %v", s, err)
+	}
+	if s, _ := InitSnippet("", "fish", "/cfg/starship.toml"); !strings.Contains(s, "starship init fish | source") {
+		t.Errorf("starship fish snippet = %q", s)
+	}
+}
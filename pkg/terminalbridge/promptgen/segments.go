@@ -0,0 +1,176 @@
+package promptgen
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+)
+
+// segmentSpec maps a stored (Starship-style) module name to its equivalent in
+// oh-my-posh and Powerlevel10k. Modules without an entry are skipped by those
+// renderers, since they have no counterpart.
+type segmentSpec struct {
+	ompType     string // oh-my-posh segment type
+	ompTemplate string // oh-my-posh segment template (symbol is prepended)
+	p10k        string // Powerlevel10k prompt element
+	right       bool   // belongs on the right-hand side by default
+}
+
+var segmentSpecs = map[string]segmentSpec{
+	"os":             {"os", " {{ .Icon }} ", "os_icon", false},
+	"username":       {"session", " {{ .UserName }} ", "context", false},
+	"hostname":       {"session", " {{ .HostName }} ", "context", false},
+	"directory":      {"path", " {{ .Path }} ", "dir", false},
+	"git_branch":     {"git", " {{ .HEAD }} ", "vcs", false},
+	"git_status":     {"git", " {{ .HEAD }}{{ if .Working.Changed }} *{{ end }} ", "vcs", false},
+	"nodejs":         {"node", " {{ .Full }} ", "node_version", false},
+	"python":         {"python", " {{ .Full }} ", "virtualenv", false},
+	"golang":         {"go", " {{ .Full }} ", "go_version", false},
+	"rust":           {"rust", " {{ .Full }} ", "rust_version", false},
+	"java":           {"java", " {{ .Full }} ", "java_version", false},
+	"ruby":           {"ruby", " {{ .Full }} ", "rbenv", false},
+	"docker_context": {"docker", " {{ .Context }} ", "", false},
+	"kubernetes":     {"kubectl", " {{ .Context }} ", "kubecontext", true},
+	"aws":            {"aws", " {{ .Profile }} ", "aws", true},
+	"cmd_duration":   {"executiontime", " {{ .FormattedMs }} ", "command_execution_time", true},
+	"status":         {"status", " {{ .Code }} ", "status", true},
+	"time":           {"time", " {{ .CurrentDate | date .Format }} ", "time", true},
+	"jobs":           {"", "", "background_jobs", true},
+}
+
+// moduleRefRegex matches $module and ${custom.module} references in a
+// Starship format string.
+var moduleRefRegex = regexp.MustCompile(`\$(?:\{([a-zA-Z0-9_.]+)\}|([a-zA-Z][a-zA-Z0-9_]*))`)
+
+// orderedModules returns the enabled module names in display order: the order
+// they appear in the prompt format when one is set, then any remaining
+// modules alphabetically. The character module is excluded; renderers emit
+// it last.
+func orderedModules(p *prompt.Prompt) []string {
+	seen := map[string]bool{"character": true}
+	var names []string
+	add := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		if m, ok := p.Modules[name]; ok && m.Disabled {
+			return
+		}
+		names = append(names, name)
+	}
+
+	for _, m := range moduleRefRegex.FindAllStringSubmatch(p.Format, -1) {
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		if name == "all" || strings.HasPrefix(name, "theme.") {
+			continue
+		}
+		add(name)
+	}
+
+	rest := make([]string, 0, len(p.Modules))
+	for name := range p.Modules {
+		rest = append(rest, name)
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		add(name)
+	}
+	return names
+}
+
+// styleModifiers are Starship style words that are not colors.
+var styleModifiers = map[string]bool{
+	"bold": true, "italic": true, "underline": true, "dimmed": true,
+	"inverted": true, "blink": true, "hidden": true, "strikethrough": true, "none": true,
+}
+
+// parseStyle splits a Starship style string ("bold fg:blue bg:#1a1b26") into
+// foreground and background color tokens. A bare color is a foreground.
+func parseStyle(style string) (fg, bg string) {
+	for _, tok := range strings.Fields(style) {
+		switch {
+		case strings.HasPrefix(tok, "fg:"):
+			fg = strings.TrimPrefix(tok, "fg:")
+		case strings.HasPrefix(tok, "bg:"):
+			bg = strings.TrimPrefix(tok, "bg:")
+		case !styleModifiers[strings.ToLower(tok)]:
+			fg = tok
+		}
+	}
+	return fg, bg
+}
+
+// styledTextRegex matches Starship "[text](style)" markup.
+var styledTextRegex = regexp.MustCompile(`^\[(.*)\]\((.*)\)$`)
+
+// parseStyledText splits "[❯](bold green)" into its text and style. Plain text
+// is returned unchanged with an empty style.
+func parseStyledText(s string) (text, style string) {
+	if m := styledTextRegex.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		return m[1], m[2]
+	}
+	return s, ""
+}
+
+// themeRefRegex matches a ${theme.X} color reference.
+var themeRefRegex = regexp.MustCompile(`^\$\{theme\.([a-zA-Z_][a-zA-Z0-9_]*)\}$`)
+
+// colorName strips ${theme.X} to X; other tokens are returned unchanged.
+func colorName(token string) string {
+	if m := themeRefRegex.FindStringSubmatch(token); m != nil {
+		return m[1]
+	}
+	return token
+}
+
+// lookupColor resolves a color name against the palette. Prompt color
+// overrides win, then the ANSI terminal colors ("red" → ansi_red), then the
+// palette's semantic colors. Returns "" when the palette has no such color.
+func lookupColor(pal *palette.Palette, name string) string {
+	if pal == nil || name == "" {
+		return ""
+	}
+	if c := pal.GetPromptColor(name); c != "" {
+		return c
+	}
+	if c := pal.ToTerminalColors()["ansi_"+name]; c != "" {
+		return c
+	}
+	return pal.Get(name)
+}
+
+// resolveHex resolves a style color token to a hex value, leaving hex values
+// and unknown names as they are.
+func resolveHex(pal *palette.Palette, token string) string {
+	name := colorName(token)
+	if strings.HasPrefix(name, "#") {
+		return name
+	}
+	if c := lookupColor(pal, name); c != "" {
+		return c
+	}
+	return name
+}
+
+// withColorOverrides returns a copy of pal with the prompt's custom colors
+// applied as prompt color overrides. The input palette is not modified.
+func withColorOverrides(pal *palette.Palette, overrides map[string]string) *palette.Palette {
+	if pal == nil || len(overrides) == 0 {
+		return pal
+	}
+	out := pal.Clone()
+	if out.PromptColors == nil {
+		out.PromptColors = make(map[string]string, len(overrides))
+	}
+	for k, v := range overrides {
+		out.PromptColors[k] = v
+	}
+	return out
+}
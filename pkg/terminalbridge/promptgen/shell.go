@@ -0,0 +1,61 @@
+package promptgen
+
+import (
+	"fmt"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+)
+
+// supportedShells lists the shells each prompt type can be initialized in.
+var supportedShells = map[prompt.PromptType][]string{
+	prompt.PromptTypeStarship:      {"zsh", "bash", "fish"},
+	prompt.PromptTypeOhMyPosh:      {"zsh", "bash", "fish"},
+	prompt.PromptTypePowerlevel10k: {"zsh"},
+}
+
+// ConfigFileName returns the conventional file name for a prompt's config.
+func ConfigFileName(t prompt.PromptType, name string) string {
+	switch t {
+	case prompt.PromptTypeOhMyPosh:
+		return name + ".omp.json"
+	case prompt.PromptTypePowerlevel10k:
+		return ".p10k.zsh"
+	default:
+		return "starship.toml"
+	}
+}
+
+// InitSnippet returns the shell rc line that activates a generated prompt
+// config at configPath. Powerlevel10k is zsh-only.
+func InitSnippet(t prompt.PromptType, shell, configPath string) (string, error) {
+	if t == "" {
+		t = prompt.PromptTypeStarship
+	}
+	if !shellSupported(t, shell) {
+		return "", fmt.Errorf("%s prompts are not supported in %s (supported: %v)", t, shell, supportedShells[t])
+	}
+
+	switch t {
+	case prompt.PromptTypeOhMyPosh:
+		if shell == "fish" {
+			return fmt.Sprintf("oh-my-posh init fish --config %q | source", configPath), nil
+		}
+		return fmt.Sprintf("eval \"$(oh-my-posh init %s --config %q)\"", shell, configPath), nil
+	case prompt.PromptTypePowerlevel10k:
+		return fmt.Sprintf("[[ -f %q ]] && source %q", configPath, configPath), nil
+	default:
+		if shell == "fish" {
+			return fmt.Sprintf("set -gx STARSHIP_CONFIG %q; starship init fish | source", configPath), nil
+		}
+		return fmt.Sprintf("export STARSHIP_CONFIG=%q; eval \"$(starship init %s)\"", configPath, shell), nil
+	}
+}
+
+func shellSupported(t prompt.PromptType, shell string) bool {
+	for _, s := range supportedShells[t] {
+		if s == shell {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package shellops
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+// BashGenerator emits a bash bootstrap: environment variables,
+// bash-completion, then each plugin cloned and sourced in dependency order.
+// oh-my-zsh and zinit plugins are zsh-only and are skipped with a comment.
+type BashGenerator struct{}
+
+// Compile-time interface check.
+var _ Generator = (*BashGenerator)(nil)
+
+// ShellName returns "bash".
+func (g *BashGenerator) ShellName() string {
+	return "bash"
+}
+
+// Generate produces the bash bootstrap script.
+func (g *BashGenerator) Generate(plugins []*plugin.Plugin, opts Options) (string, error) {
+	ordered, err := Order(plugins)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	writeHeader(&sb, "bash", opts)
+
+	env := mergedEnv(opts.Env, ordered)
+	if err := checkEnvNames(env); err != nil {
+		return "", err
+	}
+	if len(env) > 0 {
+		sb.WriteString("# === Environment ===\n")
+		for _, k := range slices.Sorted(maps.Keys(env)) {
+			sb.WriteString(fmt.Sprintf("export %s=%s\n", k, quote("bash", env[k])))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("# === Completions ===\n")
+	sb.WriteString("if ! shopt -oq posix; then\n")
+	sb.WriteString("  for f in /usr/share/bash-completion/bash_completion /etc/bash_completion \"${HOMEBREW_PREFIX:-/opt/homebrew}/etc/profile.d/bash_completion.sh\"; do\n")
+	sb.WriteString("    [[ -r \"$f\" ]] && source \"$f\" && break\n")
+	sb.WriteString("  done\n")
+	sb.WriteString("fi\n\n")
+
+	var manual []*plugin.Plugin
+	for _, p := range ordered {
+		if isManual(p) {
+			manual = append(manual, p)
+		} else {
+			sb.WriteString(fmt.Sprintf("# Skipped %s: %s plugins are zsh-only\n", comment(p.Name), p.Manager))
+		}
+	}
+	if len(manual) < len(ordered) {
+		sb.WriteString("\n")
+	}
+
+	if len(manual) > 0 {
+		sb.WriteString("# === Plugins ===\n")
+		sb.WriteString(fmt.Sprintf("PLUGIN_DIR=%s\n", quotePath("bash", defaultPluginDir("bash", opts.PluginDir))))
+		sb.WriteString("mkdir -p \"$PLUGIN_DIR\"\n\n")
+		for _, p := range manual {
+			dir := pluginPath(p)
+			sb.WriteString(fmt.Sprintf("# %s\n", comment(p.Name)))
+			if clone := cloneCommand("bash", p); clone != "" {
+				sb.WriteString(fmt.Sprintf("[[ -d %s ]] || %s\n", quotePath("bash", dir), clone))
+			}
+			if len(p.SourceFiles) > 0 {
+				for _, f := range p.SourceFiles {
+					file := quotePath("bash", dir+"/"+f)
+					sb.WriteString(fmt.Sprintf("[[ -f %s ]] && source %s\n", file, file))
+				}
+			} else {
+				qdir := quotePath("bash", dir)
+				sb.WriteString(fmt.Sprintf("for f in %s/*.plugin.bash %s/*.bash %s/*.sh; do\n", qdir, qdir, qdir))
+				sb.WriteString("  [[ -f \"$f\" ]] && source \"$f\" && break\n")
+				sb.WriteString("done\n")
+			}
+			if cfg := pluginConfig(p); cfg != "" {
+				sb.WriteString(cfg + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("# End of DevOpsMaestro shell bootstrap\n")
+	return sb.String(), nil
+}
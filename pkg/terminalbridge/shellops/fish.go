@@ -0,0 +1,97 @@
+package shellops
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+// FishGenerator emits a fish bootstrap: environment variables, then each
+// plugin cloned and loaded in dependency order. Plugin functions/ and
+// completions/ directories are added to fish's search paths (fish loads
+// completions lazily) and conf.d/*.fish snippets are sourced. oh-my-zsh and
+// zinit plugins are zsh-only and are skipped with a comment.
+type FishGenerator struct{}
+
+// Compile-time interface check.
+var _ Generator = (*FishGenerator)(nil)
+
+// ShellName returns "fish".
+func (g *FishGenerator) ShellName() string {
+	return "fish"
+}
+
+// Generate produces the fish bootstrap script.
+func (g *FishGenerator) Generate(plugins []*plugin.Plugin, opts Options) (string, error) {
+	ordered, err := Order(plugins)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	writeHeader(&sb, "fish", opts)
+
+	env := mergedEnv(opts.Env, ordered)
+	if err := checkEnvNames(env); err != nil {
+		return "", err
+	}
+	if len(env) > 0 {
+		sb.WriteString("# === Environment ===\n")
+		for _, k := range slices.Sorted(maps.Keys(env)) {
+			sb.WriteString(fmt.Sprintf("set -gx %s %s\n", k, quote("fish", env[k])))
+		}
+		sb.WriteString("\n")
+	}
+
+	var manual []*plugin.Plugin
+	for _, p := range ordered {
+		if isManual(p) {
+			manual = append(manual, p)
+		} else {
+			sb.WriteString(fmt.Sprintf("# Skipped %s: %s plugins are zsh-only\n", comment(p.Name), p.Manager))
+		}
+	}
+	if len(manual) < len(ordered) {
+		sb.WriteString("\n")
+	}
+
+	if len(manual) > 0 {
+		pluginDir := opts.PluginDir
+		if pluginDir == "" {
+			pluginDir = "$HOME/.local/share/fish/plugins"
+		}
+		sb.WriteString("# === Plugins ===\n")
+		sb.WriteString(fmt.Sprintf("set -g PLUGIN_DIR %s\n", quotePath("fish", pluginDir)))
+		sb.WriteString("mkdir -p $PLUGIN_DIR\n\n")
+		for _, p := range manual {
+			dir := pluginPath(p)
+			sb.WriteString(fmt.Sprintf("# %s\n", comment(p.Name)))
+			if clone := cloneCommand("fish", p); clone != "" {
+				sb.WriteString(fmt.Sprintf("test -d %s; or %s\n", quotePath("fish", dir), clone))
+			}
+			functions, completions := quotePath("fish", dir+"/functions"), quotePath("fish", dir+"/completions")
+			sb.WriteString(fmt.Sprintf("test -d %s; and set -p fish_function_path %s\n", functions, functions))
+			sb.WriteString(fmt.Sprintf("test -d %s; and set -p fish_complete_path %s\n", completions, completions))
+			if len(p.SourceFiles) > 0 {
+				for _, f := range p.SourceFiles {
+					file := quotePath("fish", dir+"/"+f)
+					sb.WriteString(fmt.Sprintf("test -f %s; and source %s\n", file, file))
+				}
+			} else {
+				sb.WriteString(fmt.Sprintf("for f in %s/conf.d/*.fish\n", quotePath("fish", dir)))
+				sb.WriteString("    source $f\n")
+				sb.WriteString("end\n")
+			}
+			if cfg := pluginConfig(p); cfg != "" {
+				sb.WriteString(cfg + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("# End of DevOpsMaestro shell bootstrap\n")
+	return sb.String(), nil
+}
@@ -0,0 +1,150 @@
+package shellops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+// Options configures a bootstrap script.
+type Options struct {
+	// PackageName is the terminal package the plugins came from (header only).
+	PackageName string
+
+	// PluginDir overrides where manually managed plugins are cloned.
+	// Defaults to ${XDG_DATA_HOME:-$HOME/.local/share}/<shell>/plugins.
+	PluginDir string
+
+	// Env holds extra environment variables, exported before any plugin
+	// loads. Plugin env vars with the same name take precedence.
+	Env map[string]string
+}
+
+// Generator produces a shell bootstrap script from terminal plugins.
+type Generator interface {
+	// Generate returns the init script for the given plugins. Disabled
+	// plugins are skipped; the rest load in Order.
+	Generate(plugins []*plugin.Plugin, opts Options) (string, error)
+
+	// ShellName returns the target shell ("zsh", "bash", "fish").
+	ShellName() string
+}
+
+// NewGenerator returns the Generator for the given shell.
+// Supported values: "zsh", "bash", "fish".
+func NewGenerator(shell string) (Generator, error) {
+	switch shell {
+	case "zsh":
+		return &ZshGenerator{}, nil
+	case "bash":
+		return &BashGenerator{}, nil
+	case "fish":
+		return &FishGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported shell: %q (supported: zsh, bash, fish)", shell)
+	}
+}
+
+// ScriptFileName is the conventional file name for a shell's bootstrap script.
+func ScriptFileName(shell string) string {
+	return "init." + shell
+}
+
+// SourceLine returns the line that loads the script at path from the shell's
+// rc file.
+func SourceLine(shell, path string) string {
+	q := quotePath(shell, path)
+	if shell == "fish" {
+		return fmt.Sprintf("test -f %s; and source %s", q, q)
+	}
+	return fmt.Sprintf("[[ -f %s ]] && source %s", q, q)
+}
+
+// writeHeader writes the shebang and generated-file banner.
+func writeHeader(sb *strings.Builder, shell string, opts Options) {
+	sb.WriteString(fmt.Sprintf("#!/usr/bin/env %s\n", shell))
+	sb.WriteString("# Shell bootstrap generated by DevOpsMaestro\n")
+	if opts.PackageName != "" {
+		sb.WriteString(fmt.Sprintf("# Terminal package: %s\n", opts.PackageName))
+	}
+	sb.WriteString(fmt.Sprintf("# Do not edit manually — regenerate with: dvm shell generate --shell %s", shell))
+	if opts.PackageName != "" {
+		sb.WriteString(" --package " + opts.PackageName)
+	}
+	sb.WriteString("\n\n")
+}
+
+// mergedEnv returns the extra env vars overlaid with each plugin's env vars,
+// later plugins winning.
+func mergedEnv(extra map[string]string, plugins []*plugin.Plugin) map[string]string {
+	env := make(map[string]string, len(extra))
+	for k, v := range extra {
+		env[k] = v
+	}
+	for _, p := range plugins {
+		for k, v := range p.Env {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+// checkEnvNames rejects env var names that are not shell identifiers.
+func checkEnvNames(env map[string]string) error {
+	for k := range env {
+		if !envNameRegex.MatchString(k) {
+			return fmt.Errorf("invalid environment variable name %q", k)
+		}
+	}
+	return nil
+}
+
+// pluginConfig returns the post-load configuration for a plugin. The
+// "plugins+=<name>" load command stored for oh-my-zsh built-ins is not
+// shell code and is dropped.
+func pluginConfig(p *plugin.Plugin) string {
+	if strings.HasPrefix(p.Config, "plugins+=") {
+		return ""
+	}
+	return strings.TrimRight(p.Config, "\n")
+}
+
+// isLocalPath reports whether a plugin source is a path on disk.
+func isLocalPath(s string) bool {
+	return strings.HasPrefix(s, "/") || strings.HasPrefix(s, "~") || strings.HasPrefix(s, "$")
+}
+
+// pluginPath returns the directory a plugin lives in: its local source, or
+// its clone under $PLUGIN_DIR.
+func pluginPath(p *plugin.Plugin) string {
+	if isLocalPath(p.Source) {
+		return p.Source
+	}
+	return "$PLUGIN_DIR/" + p.Name
+}
+
+// cloneCommand returns the git clone command for a plugin, quoted for the
+// shell, or "" when it has no remote source.
+func cloneCommand(shell string, p *plugin.Plugin) string {
+	url := p.GetSourceURL()
+	if url == "" || isLocalPath(p.Source) {
+		return ""
+	}
+	ref := p.Branch
+	if ref == "" {
+		ref = p.Tag
+	}
+	cmd := "git clone --depth=1 --quiet"
+	if ref != "" {
+		cmd += " -b " + quoteWord(shell, ref)
+	}
+	return fmt.Sprintf("%s -- %s %s", cmd, quoteWord(shell, url), quotePath(shell, pluginPath(p)))
+}
+
+func defaultPluginDir(shell, override string) string {
+	if override != "" {
+		return override
+	}
+	return "${XDG_DATA_HOME:-$HOME/.local/share}/" + shell + "/plugins"
+}
@@ -0,0 +1,109 @@
+// Package shellops generates standalone shell bootstrap scripts from terminal
+// plugins. Unlike shellgen, which composites a workspace rc file inside a
+// container, shellops emits a self-contained init script for the host: it
+// installs the plugin frameworks it needs (zinit, oh-my-zsh), loads plugins
+// in dependency order, exports plugin environment variables, and initializes
+// completions. Supports Zsh, Bash, and Fish.
+package shellops
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+// Order returns the enabled plugins sorted so that every plugin comes after
+// its dependencies. Among plugins whose dependencies are satisfied, oh-my-zsh
+// plugins come first, then zinit, then everything else, so each framework
+// loads in one block where possible; ties keep priority (lower first) and
+// then input order.
+//
+// A dependency on a plugin that is not in the set, or a dependency cycle,
+// is an error.
+func Order(plugins []*plugin.Plugin) ([]*plugin.Plugin, error) {
+	var enabled []*plugin.Plugin
+	index := make(map[string]int)
+	for _, p := range plugins {
+		if p == nil || !p.Enabled {
+			continue
+		}
+		if _, dup := index[p.Name]; dup {
+			continue
+		}
+		index[p.Name] = len(enabled)
+		enabled = append(enabled, p)
+	}
+
+	indegree := make([]int, len(enabled))
+	dependents := make([][]int, len(enabled))
+	for i, p := range enabled {
+		for _, dep := range p.Dependencies {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("plugin %q depends on %q, which is not in the plugin set", p.Name, dep)
+			}
+			if j == i {
+				return nil, fmt.Errorf("plugin %q depends on itself", p.Name)
+			}
+			indegree[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	less := func(a, b int) bool {
+		pa, pb := enabled[a], enabled[b]
+		if ra, rb := managerRank(pa.Manager), managerRank(pb.Manager); ra != rb {
+			return ra < rb
+		}
+		if pa.Priority != pb.Priority {
+			return pa.Priority < pb.Priority
+		}
+		return a < b
+	}
+
+	var ready []int
+	for i := range enabled {
+		if indegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]*plugin.Plugin, 0, len(enabled))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(x, y int) bool { return less(ready[x], ready[y]) })
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, enabled[next])
+		for _, d := range dependents[next] {
+			indegree[d]--
+			if indegree[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(ordered) != len(enabled) {
+		var cycle []string
+		for i, p := range enabled {
+			if indegree[i] > 0 {
+				cycle = append(cycle, p.Name)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle between plugins: %s", strings.Join(cycle, ", "))
+	}
+	return ordered, nil
+}
+
+// managerRank groups framework-managed plugins ahead of manually loaded ones.
+func managerRank(m plugin.PluginManager) int {
+	switch m {
+	case plugin.PluginManagerOhMyZsh:
+		return 0
+	case plugin.PluginManagerZinit:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,96 @@
+package shellops
+
+import (
+	"regexp"
+	"strings"
+)
+
+// safeWordRegex matches words that need no quoting in any supported shell.
+var safeWordRegex = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// envNameRegex matches valid environment variable names.
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// quote returns s as a single-quoted literal for the shell. Nothing inside
+// is expanded: not $, backticks, double quotes, or backslashes.
+func quote(shell, s string) string {
+	if shell == "fish" {
+		// fish honors \\ and \' inside single quotes
+		s = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+		return "'" + s + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteWord returns s unchanged when it is a plain word, otherwise quoted.
+func quoteWord(shell, s string) string {
+	if safeWordRegex.MatchString(s) {
+		return s
+	}
+	return quote(shell, s)
+}
+
+// quotePath quotes a path for the shell. A leading ~, $VAR, or ${...}
+// is left to the shell to expand, so paths like $PLUGIN_DIR/name and
+// ~/src/plugin work; everything after it is taken literally.
+func quotePath(shell, path string) string {
+	prefix, rest := splitExpansion(path)
+	switch {
+	case prefix == "":
+		return quoteWord(shell, rest)
+	case prefix == "~":
+		if rest == "" || safeWordRegex.MatchString(rest) {
+			return prefix + rest
+		}
+		return prefix + quote(shell, rest)
+	case rest == "" || safeWordRegex.MatchString(rest):
+		return `"` + prefix + rest + `"`
+	default:
+		return `"` + prefix + `"` + quote(shell, rest)
+	}
+}
+
+// splitExpansion splits a leading ~, $NAME, or ${...} from path. Braced
+// expressions containing anything that could run a command are not split.
+func splitExpansion(path string) (prefix, rest string) {
+	switch {
+	case path == "~" || strings.HasPrefix(path, "~/"):
+		return "~", path[1:]
+	case strings.HasPrefix(path, "${"):
+		depth := 0
+		for i := 1; i < len(path); i++ {
+			switch path[i] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					if strings.ContainsAny(path[:i+1], "`\"'\\();|&<>\n") {
+						return "", path
+					}
+					return path[:i+1], path[i+1:]
+				}
+			}
+		}
+		return "", path
+	case strings.HasPrefix(path, "$"):
+		end := 1
+		for end < len(path) && (path[end] == '_' || isAlnum(path[end])) {
+			end++
+		}
+		if end == 1 {
+			return "", path
+		}
+		return path[:end], path[end:]
+	}
+	return "", path
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// comment flattens s onto one line so it cannot escape a # comment.
+func comment(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,201 @@
+package shellops
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+func names(plugins []*plugin.Plugin) string {
+	out := make([]string, len(plugins))
+	for i, p := range plugins {
+		out[i] = p.Name
+	}
+	return strings.Join(out, ",")
+}
+
+func testPlugins() []*plugin.Plugin {
+	return []*plugin.Plugin{
+		{Name: "fzf-tab", Repo: "Aloxaf/fzf-tab", Manager: plugin.PluginManagerManual, Enabled: true, Dependencies: []string{"fzf"}},
+		{Name: "fzf", Repo: "junegunn/fzf", Manager: plugin.PluginManagerManual, Enabled: true, SourceFiles: []string{"shell/key-bindings.zsh"}, Env: map[string]string{"FZF_DEFAULT_OPTS": "--height 40%"}},
+		{Name: "autosuggestions", Repo: "zsh-users/zsh-autosuggestions", Manager: plugin.PluginManagerZinit, LoadMode: plugin.LoadModeDeferred, Enabled: true},
+		{Name: "git", OhMyZshPlugin: "git", Manager: plugin.PluginManagerOhMyZsh, Enabled: true, Config: "plugins+=git"},
+		{Name: "disabled", Repo: "x/y", Enabled: false},
+	}
+}
+
+func TestOrder(t *testing.T) {
+	ordered, err := Order(testPlugins())
+	if err != nil {
+		t.Fatalf("Order() error = %v", err)
+	}
+	if got := names(ordered); got != "git,autosuggestions,fzf,fzf-tab" {
+		t.Errorf("Order() = %s", got)
+	}
+}
+
+func TestOrder_Errors(t *testing.T) {
+	missing := []*plugin.Plugin{{Name: "a", Enabled: true, Dependencies: []string{"b"}}}
+	if _, err := Order(missing); err == nil || !strings.Contains(err.Error(), `"b"`) {
+		t.Errorf("expected missing dependency error, got %v", err)
+	}
+
+	cycle := []*plugin.Plugin{
+		{Name: "a", Enabled: true, Dependencies: []string{"b"}},
+		{Name: "b", Enabled: true, Dependencies: []string{"a"}},
+		{Name: "c", Enabled: true},
+	}
+	if _, err := Order(cycle); err == nil || !strings.Contains(err.Error(), "cycle between plugins: a, b") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+}
+
+func TestZshGenerator(t *testing.T) {
+	g, _ := NewGenerator("zsh")
+	out, err := g.Generate(testPlugins(), Options{PackageName: "dev", Env: map[string]string{"EDITOR": "nvim"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	inOrder := []string{
+		"# Terminal package: dev",
+		`export EDITOR='nvim'`,
+		`export FZF_DEFAULT_OPTS='--height 40%'`,
+		`[[ -d "$PLUGIN_DIR/fzf" ]] || git clone --depth=1 --quiet -- https://github.com/junegunn/fzf "$PLUGIN_DIR/fzf"`,
+		`fpath=("$PLUGIN_DIR/fzf-tab" $fpath)`,
+		"source \"${ZINIT_HOME:-",
+		"plugins=(git)",
+		`source "$ZSH/oh-my-zsh.sh"`,
+		`zinit ice wait"0" lucid`,
+		"zinit light zsh-users/zsh-autosuggestions",
+		`source "$PLUGIN_DIR/fzf/shell/key-bindings.zsh"`,
+		`"$PLUGIN_DIR/fzf-tab"/*.plugin.zsh(N)`,
+		"zinit cdreplay -q",
+	}
+	pos := 0
+	for _, want := range inOrder {
+		i := strings.Index(out[pos:], want)
+		if i < 0 {
+			t.Fatalf("zsh output missing %q after offset %d:\n%s", want, pos, out)
+		}
+		pos += i + len(want)
+	}
+	if strings.Contains(out, "plugins+=git") || strings.Contains(out, "compinit\n") {
+		t.Errorf("unexpected oh-my-zsh load command or duplicate compinit:\n%s", out)
+	}
+	if strings.Contains(out, "x/y") {
+		t.Error("disabled plugin should be skipped")
+	}
+}
+
+func TestZshGenerator_NoFrameworks(t *testing.T) {
+	g, _ := NewGenerator("zsh")
+	out, _ := g.Generate([]*plugin.Plugin{{Name: "local", Source: "$HOME/src/local", Enabled: true}}, Options{})
+	if !strings.Contains(out, "autoload -Uz compinit && compinit") {
+		t.Errorf("expected compinit without oh-my-zsh:\n%s", out)
+	}
+	if strings.Contains(out, "git clone") || strings.Contains(out, "zinit") {
+		t.Errorf("local plugin should not be cloned:\n%s", out)
+	}
+}
+
+func TestBashAndFishGenerators(t *testing.T) {
+	bash, _ := NewGenerator("bash")
+	out, err := bash.Generate(testPlugins(), Options{PluginDir: "/opt/plugins"})
+	if err != nil {
+		t.Fatalf("bash Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		"#!/usr/bin/env bash",
+		"bash_completion",
+		`PLUGIN_DIR=/opt/plugins`,
+		"# Skipped git: oh-my-zsh plugins are zsh-only",
+		"# Skipped autosuggestions: zinit plugins are zsh-only",
+		`"$PLUGIN_DIR/fzf-tab"/*.plugin.bash`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("bash output missing %q:\n%s", want, out)
+		}
+	}
+
+	fish, _ := NewGenerator("fish")
+	out, err = fish.Generate(testPlugins(), Options{})
+	if err != nil {
+		t.Fatalf("fish Generate() error = %v", err)
+	}
+	for _, want := range []string{
+		`set -gx FZF_DEFAULT_OPTS '--height 40%'`,
+		`set -g PLUGIN_DIR "$HOME/.local/share/fish/plugins"`,
+		`test -d "$PLUGIN_DIR/fzf"; or git clone`,
+		`set -p fish_complete_path "$PLUGIN_DIR/fzf-tab/completions"`,
+		`for f in "$PLUGIN_DIR/fzf-tab"/conf.d/*.fish`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("fish output missing %q:\n%s", want, out)
+		}
+	}
+
+	if _, err := NewGenerator("tcsh"); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+}
+
+func TestSourceLine(t *testing.T) {
+	if got := SourceLine("zsh", "/h/init.zsh"); got != `[[ -f /h/init.zsh ]] && source /h/init.zsh` {
+		t.Errorf("zsh SourceLine = %s", got)
+	}
+	if got := SourceLine("fish", "/h/init.fish"); got != `test -f /h/init.fish; and source /h/init.fish` {
+		t.Errorf("fish SourceLine = %s", got)
+	}
+	if got := SourceLine("bash", "/h/my dir/$x.bash"); got != `[[ -f '/h/my dir/$x.bash' ]] && source '/h/my dir/$x.bash'` {
+		t.Errorf("bash SourceLine = %s", got)
+	}
+}
+
+func TestQuotePath(t *testing.T) {
+	tests := []struct{ shell, in, want string }{
+		{"zsh", "/opt/plugins", "/opt/plugins"},
+		{"zsh", "$PLUGIN_DIR/fzf", `"$PLUGIN_DIR/fzf"`},
+		{"zsh", "$PLUGIN_DIR/a b", `"$PLUGIN_DIR"'/a b'`},
+		{"zsh", "~/src/x", "~/src/x"},
+		{"bash", "~/it's", `~'/it'\''s'`},
+		{"bash", "${XDG_DATA_HOME:-$HOME/.local/share}/bash/plugins", `"${XDG_DATA_HOME:-$HOME/.local/share}/bash/plugins"`},
+		{"bash", "${x`id`}/p", "'${x`id`}/p'"},
+		{"bash", "$(id)/p", "'$(id)/p'"},
+		{"fish", `/tmp/a'b\c`, `'/tmp/a\'b\\c'`},
+	}
+	for _, tt := range tests {
+		if got := quotePath(tt.shell, tt.in); got != tt.want {
+			t.Errorf("quotePath(%s, %q) = %s, want %s", tt.shell, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGenerators_EscapeValues(t *testing.T) {
+	hostile := "$(touch /tmp/pwned) `id` \"q\" \\ it's"
+	plugins := []*plugin.Plugin{{Name: "p", Source: "/src/my plugin", Enabled: true, Env: map[string]string{"HOSTILE": hostile}}}
+
+	for shell, want := range map[string][]string{
+		"zsh":  {`export HOSTILE='$(touch /tmp/pwned) ` + "`id`" + ` "q" \ it'\''s'`, `for f in '/src/my plugin'/*.plugin.zsh(N)`},
+		"bash": {`export HOSTILE='$(touch /tmp/pwned) ` + "`id`" + ` "q" \ it'\''s'`, `for f in '/src/my plugin'/*.plugin.bash`},
+		"fish": {`set -gx HOSTILE '$(touch /tmp/pwned) ` + "`id`" + ` "q" \\ it\'s'`, `for f in '/src/my plugin'/conf.d/*.fish`},
+	} {
+		g, _ := NewGenerator(shell)
+		out, err := g.Generate(plugins, Options{})
+		if err != nil {
+			t.Fatalf("%s Generate() error = %v", shell, err)
+		}
+		for _, w := range want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s output missing %s:\n%s", shell, w, out)
+			}
+		}
+	}
+
+	g, _ := NewGenerator("bash")
+	bad := []*plugin.Plugin{{Name: "p", Enabled: true, Env: map[string]string{"A=1; id #": "x"}}}
+	if _, err := g.Generate(bad, Options{}); err == nil {
+		t.Error("expected error for invalid env var name")
+	}
+}
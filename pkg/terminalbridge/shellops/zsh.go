@@ -0,0 +1,242 @@
+package shellops
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rmkohlman/MaestroTerminal/terminalops/plugin"
+)
+
+const zinitHome = "${ZINIT_HOME:-${XDG_DATA_HOME:-$HOME/.local/share}/zinit/zinit.git}"
+
+// ZshGenerator emits a zsh bootstrap. Layout:
+//
+//  1. environment variables
+//  2. manual plugin clones and fpath entries
+//  3. zinit bootstrap, when any plugin uses zinit
+//  4. oh-my-zsh with its plugins array (oh-my-zsh runs compinit), or a
+//     plain compinit
+//  5. remaining plugins in dependency order, each followed by its config
+//  6. zinit completion replay
+//
+// Antigen and Sheldon plugins are loaded like manual plugins so the script
+// does not depend on those managers.
+type ZshGenerator struct{}
+
+// Compile-time interface check.
+var _ Generator = (*ZshGenerator)(nil)
+
+// ShellName returns "zsh".
+func (g *ZshGenerator) ShellName() string {
+	return "zsh"
+}
+
+// Generate produces the zsh bootstrap script.
+func (g *ZshGenerator) Generate(plugins []*plugin.Plugin, opts Options) (string, error) {
+	ordered, err := Order(plugins)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	writeHeader(&sb, "zsh", opts)
+
+	env := mergedEnv(opts.Env, ordered)
+	if err := checkEnvNames(env); err != nil {
+		return "", err
+	}
+	if len(env) > 0 {
+		sb.WriteString("# === Environment ===\n")
+		for _, k := range slices.Sorted(maps.Keys(env)) {
+			sb.WriteString(fmt.Sprintf("export %s=%s\n", k, quote("zsh", env[k])))
+		}
+		sb.WriteString("\n")
+	}
+
+	// oh-my-zsh plugins at the head of the order load together when
+	// oh-my-zsh.sh is sourced; any that dependencies push later are sourced
+	// directly from $ZSH.
+	omzCount := 0
+	for omzCount < len(ordered) && ordered[omzCount].Manager == plugin.PluginManagerOhMyZsh {
+		omzCount++
+	}
+	head, rest := ordered[:omzCount], ordered[omzCount:]
+
+	g.writeManualSetup(&sb, rest, opts)
+	usesZinit := false
+	for _, p := range rest {
+		if p.Manager == plugin.PluginManagerZinit {
+			usesZinit = true
+			break
+		}
+	}
+	if usesZinit {
+		g.writeZinitBootstrap(&sb)
+	}
+
+	sb.WriteString("# === Completions ===\n")
+	if len(head) > 0 {
+		g.writeOhMyZsh(&sb, head)
+	} else {
+		for _, p := range rest {
+			if p.Manager == plugin.PluginManagerOhMyZsh {
+				sb.WriteString("export ZSH=\"${ZSH:-$HOME/.oh-my-zsh}\"\n")
+				sb.WriteString("export ZSH_CUSTOM=\"${ZSH_CUSTOM:-$ZSH/custom}\"\n")
+				break
+			}
+		}
+		sb.WriteString("autoload -Uz compinit && compinit\n\n")
+	}
+
+	if len(rest) > 0 {
+		sb.WriteString("# === Plugins ===\n")
+		for _, p := range rest {
+			sb.WriteString(fmt.Sprintf("# %s\n", comment(p.Name)))
+			switch p.Manager {
+			case plugin.PluginManagerZinit:
+				g.writeZinitPlugin(&sb, p)
+			case plugin.PluginManagerOhMyZsh:
+				g.writeOhMyZshPlugin(&sb, p)
+			default:
+				g.writeManualPlugin(&sb, p)
+			}
+			if cfg := pluginConfig(p); cfg != "" {
+				sb.WriteString(cfg + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if usesZinit {
+		sb.WriteString("zinit cdreplay -q\n\n")
+	}
+	sb.WriteString("# End of DevOpsMaestro shell bootstrap\n")
+	return sb.String(), nil
+}
+
+// writeManualSetup clones missing manual plugins and adds their directories
+// to fpath before compinit runs.
+func (g *ZshGenerator) writeManualSetup(sb *strings.Builder, plugins []*plugin.Plugin, opts Options) {
+	var manual []*plugin.Plugin
+	for _, p := range plugins {
+		if isManual(p) {
+			manual = append(manual, p)
+		}
+	}
+	if len(manual) == 0 {
+		return
+	}
+
+	sb.WriteString("# === Plugin installation ===\n")
+	sb.WriteString(fmt.Sprintf("PLUGIN_DIR=%s\n", quotePath("zsh", defaultPluginDir("zsh", opts.PluginDir))))
+	sb.WriteString("mkdir -p \"$PLUGIN_DIR\"\n")
+	for _, p := range manual {
+		dir := quotePath("zsh", pluginPath(p))
+		if clone := cloneCommand("zsh", p); clone != "" {
+			sb.WriteString(fmt.Sprintf("[[ -d %s ]] || %s\n", dir, clone))
+		}
+		sb.WriteString(fmt.Sprintf("fpath=(%s $fpath)\n", dir))
+	}
+	sb.WriteString("\n")
+}
+
+func (g *ZshGenerator) writeZinitBootstrap(sb *strings.Builder) {
+	sb.WriteString("# === Zinit ===\n")
+	sb.WriteString(fmt.Sprintf("if [[ ! -f \"%s/zinit.zsh\" ]]; then\n", zinitHome))
+	sb.WriteString(fmt.Sprintf("  command mkdir -p \"%s\"\n", zinitHome))
+	sb.WriteString(fmt.Sprintf("  command git clone --depth=1 --quiet https://github.com/zdharma-continuum/zinit \"%s\"\n", zinitHome))
+	sb.WriteString("fi\n")
+	sb.WriteString(fmt.Sprintf("source \"%s/zinit.zsh\"\n\n", zinitHome))
+}
+
+// writeOhMyZsh installs oh-my-zsh if needed, clones custom plugins, sets the
+// plugins array, and sources oh-my-zsh.sh.
+func (g *ZshGenerator) writeOhMyZsh(sb *strings.Builder, plugins []*plugin.Plugin) {
+	sb.WriteString("export ZSH=\"${ZSH:-$HOME/.oh-my-zsh}\"\n")
+	sb.WriteString("export ZSH_CUSTOM=\"${ZSH_CUSTOM:-$ZSH/custom}\"\n")
+	sb.WriteString("[[ -d \"$ZSH\" ]] || git clone --depth=1 --quiet https://github.com/ohmyzsh/ohmyzsh \"$ZSH\"\n")
+
+	names := make([]string, 0, len(plugins))
+	for _, p := range plugins {
+		if p.OhMyZshPlugin != "" {
+			names = append(names, p.OhMyZshPlugin)
+			continue
+		}
+		if url := p.GetSourceURL(); url != "" {
+			dir := quotePath("zsh", "$ZSH_CUSTOM/plugins/"+p.Name)
+			sb.WriteString(fmt.Sprintf("[[ -d %s ]] || git clone --depth=1 --quiet %s %s\n", dir, quoteWord("zsh", url), dir))
+		}
+		names = append(names, p.Name)
+	}
+	for i, name := range names {
+		names[i] = quoteWord("zsh", name)
+	}
+	sb.WriteString(fmt.Sprintf("plugins=(%s)\n", strings.Join(names, " ")))
+	sb.WriteString("source \"$ZSH/oh-my-zsh.sh\"\n")
+	for _, p := range plugins {
+		if cfg := pluginConfig(p); cfg != "" {
+			sb.WriteString(fmt.Sprintf("# %s\n%s\n", comment(p.Name), cfg))
+		}
+	}
+	sb.WriteString("\n")
+}
+
+// writeOhMyZshPlugin sources an oh-my-zsh plugin after oh-my-zsh.sh has run.
+func (g *ZshGenerator) writeOhMyZshPlugin(sb *strings.Builder, p *plugin.Plugin) {
+	name := p.OhMyZshPlugin
+	if name == "" {
+		name = p.Name
+	}
+	file := "/plugins/" + name + "/" + name + ".plugin.zsh"
+	sb.WriteString(fmt.Sprintf("for f in %s %s; do\n", quotePath("zsh", "$ZSH_CUSTOM"+file), quotePath("zsh", "$ZSH"+file)))
+	sb.WriteString("  [[ -f \"$f\" ]] && source \"$f\" && break\n")
+	sb.WriteString("done\n")
+}
+
+func (g *ZshGenerator) writeZinitPlugin(sb *strings.Builder, p *plugin.Plugin) {
+	var ice []string
+	switch p.LoadMode {
+	case plugin.LoadModeDeferred:
+		ice = append(ice, `wait"0"`, "lucid")
+	case plugin.LoadModeLazy:
+		ice = append(ice, `wait"1"`, "lucid")
+	}
+	if p.Branch != "" {
+		ice = append(ice, "ver"+quote("zsh", p.Branch))
+	}
+	if len(p.SourceFiles) > 0 {
+		ice = append(ice, "pick"+quote("zsh", p.SourceFiles[0]))
+	}
+	if len(ice) > 0 {
+		sb.WriteString(fmt.Sprintf("zinit ice %s\n", strings.Join(ice, " ")))
+	}
+	target := p.Repo
+	if target == "" {
+		target = p.GetSourceURL()
+	}
+	if target != "" {
+		sb.WriteString(fmt.Sprintf("zinit light %s\n", quoteWord("zsh", target)))
+	}
+}
+
+func (g *ZshGenerator) writeManualPlugin(sb *strings.Builder, p *plugin.Plugin) {
+	dir := pluginPath(p)
+	if len(p.SourceFiles) > 0 {
+		for _, f := range p.SourceFiles {
+			sb.WriteString(fmt.Sprintf("source %s\n", quotePath("zsh", dir+"/"+f)))
+		}
+		return
+	}
+	qdir := quotePath("zsh", dir)
+	sb.WriteString(fmt.Sprintf("for f in %s/*.plugin.zsh(N) %s/*.zsh(N); do\n", qdir, qdir))
+	sb.WriteString("  source \"$f\" && break\n")
+	sb.WriteString("done\n")
+}
+
+// isManual reports whether a plugin is cloned and sourced by the script
+// itself rather than a plugin framework.
+func isManual(p *plugin.Plugin) bool {
+	return p.Manager != plugin.PluginManagerZinit && p.Manager != plugin.PluginManagerOhMyZsh
+}
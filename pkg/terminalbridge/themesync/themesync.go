@@ -0,0 +1,311 @@
+// Package themesync regenerates the theme-dependent parts of host
+// application configs from one resolved theme, so nvim, the terminal
+// emulator, the shell prompt, and the multiplexer switch together.
+//
+// Each target writes only colors: a file the user's own config loads
+// (dvm-colors.lua, dvm-theme.conf, ...) or, for starship, the palette
+// entries of starship.toml. A target whose config is not present on the
+// host is out of scope and left alone.
+package themesync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"devopsmaestro/pkg/terminalbridge/emulatorgen"
+
+	"github.com/rmkohlman/MaestroPalette"
+	"github.com/rmkohlman/MaestroTerminal/terminalops/prompt"
+	theme "github.com/rmkohlman/MaestroTheme"
+)
+
+// Supported targets.
+const (
+	TargetNvim      = "nvim"
+	TargetWezTerm   = "wezterm"
+	TargetAlacritty = "alacritty"
+	TargetStarship  = "starship"
+	TargetTmux      = "tmux"
+)
+
+// Targets returns every supported target in the order they are applied.
+func Targets() []string {
+	return []string{TargetNvim, TargetWezTerm, TargetAlacritty, TargetStarship, TargetTmux}
+}
+
+// TmuxThemeFile is the tmux config fragment holding the statusline colors.
+const TmuxThemeFile = "dvm-theme.conf"
+
+// Locations are the host directories the targets' configs live in.
+type Locations struct {
+	ConfigDir string // $XDG_CONFIG_HOME, usually ~/.config
+	Home      string
+}
+
+// File is one generated file.
+type File struct {
+	Path    string
+	Content string
+}
+
+// Plan is what applying a theme to one target writes.
+type Plan struct {
+	Target string
+	// Files is empty when the target is out of scope.
+	Files []File
+	// Note explains why the target is out of scope, or how the user's config
+	// loads the generated file.
+	Note string
+}
+
+// InScope reports whether the target's config is present on the host.
+func (p *Plan) InScope() bool { return len(p.Files) > 0 }
+
+// Write writes the plan's files, creating their directories.
+func (p *Plan) Write() error {
+	for _, f := range p.Files {
+		if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(f.Path), err)
+		}
+		if err := os.WriteFile(f.Path, []byte(f.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// Syncer plans a theme's targets from its palette, resolved once.
+type Syncer struct {
+	theme   *theme.Theme
+	palette *palette.Palette
+	loc     Locations
+}
+
+// New returns a Syncer applying t to the configs under loc.
+func New(t *theme.Theme, loc Locations) *Syncer {
+	return &Syncer{theme: t, palette: t.ToPalette(), loc: loc}
+}
+
+// Plan returns what applying the theme to target writes.
+func (s *Syncer) Plan(target string) (*Plan, error) {
+	switch target {
+	case TargetNvim:
+		return s.planNvim()
+	case TargetWezTerm:
+		return planFragment(target, filepath.Join(s.loc.ConfigDir, "wezterm"), emulatorgen.WezTermColorsFile,
+			`load it with: config.colors = require("dvm-colors")`,
+			func() (string, error) { return emulatorgen.WezTermColorsLua(s.palette) })
+	case TargetAlacritty:
+		dir := filepath.Join(s.loc.ConfigDir, "alacritty")
+		return planFragment(target, dir, emulatorgen.AlacrittyColorsFile,
+			fmt.Sprintf(`load it with: [general] import = [%q]`, filepath.Join(dir, emulatorgen.AlacrittyColorsFile)),
+			func() (string, error) { return emulatorgen.AlacrittyColorsTOML(s.palette) })
+	case TargetStarship:
+		return s.planStarship()
+	case TargetTmux:
+		return s.planTmux()
+	default:
+		return nil, fmt.Errorf("unknown target %q (supported: %s)", target, strings.Join(Targets(), ", "))
+	}
+}
+
+// planNvim regenerates the theme module of a dvm- or nvp-generated nvim
+// config. Other nvim configs have no lua/theme module to load it from.
+func (s *Syncer) planNvim() (*Plan, error) {
+	dir := filepath.Join(s.loc.ConfigDir, "nvim", "lua", "theme")
+	if !isDir(dir) {
+		return &Plan{Target: TargetNvim, Note: fmt.Sprintf("no theme module at %s", dir)}, nil
+	}
+	generated, err := theme.NewGenerator().Generate(s.theme)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nvim theme: %w", err)
+	}
+	files := []File{
+		{Path: filepath.Join(dir, "palette.lua"), Content: generated.PaletteLua},
+		{Path: filepath.Join(dir, "init.lua"), Content: generated.InitLua},
+	}
+	if generated.ColorschemeLua != "" {
+		files = append(files, File{Path: filepath.Join(dir, "colorscheme.lua"), Content: generated.ColorschemeLua})
+	}
+	return &Plan{Target: TargetNvim, Files: files}, nil
+}
+
+// planFragment writes a colors-only file into an emulator's config directory.
+func planFragment(target, dir, fileName, note string, render func() (string, error)) (*Plan, error) {
+	if !isDir(dir) {
+		return &Plan{Target: target, Note: fmt.Sprintf("no config directory %s", dir)}, nil
+	}
+	content, err := render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s colors: %w", target, err)
+	}
+	return &Plan{Target: target, Files: []File{{Path: filepath.Join(dir, fileName), Content: content}}, Note: note}, nil
+}
+
+// planStarship points starship.toml at the theme's palette and replaces that
+// palette's table. Starship has no includes, so the file is edited in place.
+func (s *Syncer) planStarship() (*Plan, error) {
+	path := filepath.Join(s.loc.ConfigDir, "starship.toml")
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Plan{Target: TargetStarship, Note: fmt.Sprintf("no %s", path)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	section, err := StarshipPalette(s.palette)
+	if err != nil {
+		return nil, err
+	}
+	content := UpdateStarshipConfig(string(data), s.palette.Name, section)
+	return &Plan{Target: TargetStarship, Files: []File{{Path: path, Content: content}}}, nil
+}
+
+// planTmux writes the statusline colors next to the tmux config, preferring
+// ~/.config/tmux over ~/.tmux.conf.
+func (s *Syncer) planTmux() (*Plan, error) {
+	dir := filepath.Join(s.loc.ConfigDir, "tmux")
+	if !isDir(dir) {
+		if _, err := os.Stat(filepath.Join(s.loc.Home, ".tmux.conf")); err != nil {
+			return &Plan{Target: TargetTmux, Note: fmt.Sprintf("no %s or ~/.tmux.conf", dir)}, nil
+		}
+		dir = filepath.Join(s.loc.Home, ".tmux")
+	}
+	content, err := TmuxStatusline(s.palette)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, TmuxThemeFile)
+	return &Plan{
+		Target: TargetTmux,
+		Files:  []File{{Path: path, Content: content}},
+		Note:   fmt.Sprintf("load it with: source-file %s", path),
+	}, nil
+}
+
+// StarshipPalette renders the [palettes.<name>] table starship prompts
+// generated by dvm reference, with the same keys dvm prompt generate writes.
+func StarshipPalette(pal *palette.Palette) (string, error) {
+	rendered, err := prompt.NewStarshipRenderer().Render(&prompt.PromptYAML{}, pal)
+	if err != nil {
+		return "", fmt.Errorf("failed to render starship palette: %w", err)
+	}
+	header := fmt.Sprintf("[palettes.%s]", pal.Name)
+	lines := strings.Split(rendered, "\n")
+	start, end, ok := tomlTable(lines, header)
+	if !ok {
+		return "", fmt.Errorf("starship renderer wrote no %s table", header)
+	}
+	return strings.Join(lines[start:end], "\n") + "\n", nil
+}
+
+// UpdateStarshipConfig sets the top-level palette of a starship.toml to name
+// and replaces the [palettes.<name>] table with section, appending it when
+// missing. Everything else in the file is kept as is.
+func UpdateStarshipConfig(config, name, section string) string {
+	lines := strings.Split(strings.TrimRight(config, "\n"), "\n")
+	if start, end, ok := tomlTable(lines, fmt.Sprintf("[palettes.%s]", name)); ok {
+		for end < len(lines) && strings.TrimSpace(lines[end]) == "" {
+			end++
+		}
+		lines = append(lines[:start:start], lines[end:]...)
+	}
+
+	paletteLine := fmt.Sprintf("palette = '%s'", name)
+	firstTable := len(lines)
+	set := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			firstTable = i
+			break
+		}
+		if key, _, found := strings.Cut(trimmed, "="); found && strings.TrimSpace(key) == "palette" {
+			lines[i] = paletteLine
+			set = true
+		}
+	}
+	if !set {
+		lines = append(lines[:firstTable:firstTable], append([]string{paletteLine, ""}, lines[firstTable:]...)...)
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n") + "\n\n" + section
+}
+
+// tomlTable returns the line range of the table with the given header: the
+// header line up to the next table header, without trailing blank lines.
+func tomlTable(lines []string, header string) (start, end int, ok bool) {
+	start = -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == header {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, 0, false
+	}
+	end = len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "[") {
+			end = i
+			break
+		}
+	}
+	for end > start+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return start, end, true
+}
+
+// TmuxStatusline renders tmux options coloring the status line, window
+// list, pane borders, and messages from the palette.
+func TmuxStatusline(pal *palette.Palette) (string, error) {
+	bg, fg := pal.Get(palette.ColorBg), pal.Get(palette.ColorFg)
+	if bg == "" || fg == "" {
+		return "", fmt.Errorf("theme '%s' has no bg or fg color", pal.Name)
+	}
+	statusBg := firstColor(pal, bg, palette.ColorBgStatusline, palette.ColorBgDark)
+	highlight := firstColor(pal, statusBg, palette.ColorBgHighlight)
+	accent := firstColor(pal, fg, palette.ColorBlue, palette.ColorPrimary)
+	muted := firstColor(pal, fg, palette.ColorComment, palette.ColorFgDark)
+	border := firstColor(pal, muted, palette.ColorBorder, palette.ColorFgGutter)
+	visual := firstColor(pal, highlight, palette.ColorBgVisual)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by dvm theme apply from theme %q\n\n", pal.Name)
+	for _, opt := range [][2]string{
+		{"status-style", fmt.Sprintf("bg=%s,fg=%s", statusBg, fg)},
+		{"status-left-style", fmt.Sprintf("bg=%s,fg=%s,bold", accent, statusBg)},
+		{"status-right-style", fmt.Sprintf("bg=%s,fg=%s", highlight, fg)},
+		{"window-status-style", fmt.Sprintf("bg=%s,fg=%s", statusBg, muted)},
+		{"window-status-current-style", fmt.Sprintf("bg=%s,fg=%s,bold", highlight, accent)},
+		{"pane-border-style", fmt.Sprintf("fg=%s", border)},
+		{"pane-active-border-style", fmt.Sprintf("fg=%s", accent)},
+		{"message-style", fmt.Sprintf("bg=%s,fg=%s", highlight, fg)},
+		{"mode-style", fmt.Sprintf("bg=%s,fg=%s", visual, fg)},
+	} {
+		fmt.Fprintf(&b, "set -g %s %q\n", opt[0], opt[1])
+	}
+	return b.String(), nil
+}
+
+// firstColor returns the first of keys set in the palette, or fallback.
+func firstColor(pal *palette.Palette, fallback string, keys ...string) string {
+	for _, key := range keys {
+		if c := pal.Get(key); c != "" {
+			return c
+		}
+	}
+	return fallback
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
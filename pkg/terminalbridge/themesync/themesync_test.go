@@ -0,0 +1,155 @@
+package themesync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	theme "github.com/rmkohlman/MaestroTheme"
+)
+
+func testTheme() *theme.Theme {
+	return &theme.Theme{
+		Name:     "test-theme",
+		Plugin:   theme.ThemePlugin{Repo: "folke/tokyonight.nvim"},
+		Style:    "storm",
+		Category: theme.CategoryDark,
+		Colors: map[string]string{
+			"bg":      "#24283b",
+			"fg":      "#c0caf5",
+			"bg_dark": "#1f2335",
+			"blue":    "#7aa2f7",
+			"comment": "#565f89",
+		},
+	}
+}
+
+func TestSyncer_Plan(t *testing.T) {
+	config := t.TempDir()
+	for _, dir := range []string{"nvim/lua/theme", "wezterm", "tmux"} {
+		if err := os.MkdirAll(filepath.Join(config, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := New(testTheme(), Locations{ConfigDir: config, Home: t.TempDir()})
+
+	tests := []struct {
+		target  string
+		inScope bool
+		file    string
+	}{
+		{TargetNvim, true, "nvim/lua/theme/palette.lua"},
+		{TargetWezTerm, true, "wezterm/dvm-colors.lua"},
+		{TargetAlacritty, false, ""},
+		{TargetStarship, false, ""},
+		{TargetTmux, true, "tmux/dvm-theme.conf"},
+	}
+	for _, tt := range tests {
+		plan, err := s.Plan(tt.target)
+		if err != nil {
+			t.Fatalf("Plan(%q) error = %v", tt.target, err)
+		}
+		if plan.InScope() != tt.inScope {
+			t.Errorf("Plan(%q).InScope() = %v, want %v (note %q)", tt.target, plan.InScope(), tt.inScope, plan.Note)
+			continue
+		}
+		if !tt.inScope {
+			if plan.Note == "" {
+				t.Errorf("Plan(%q) has no note explaining why it is out of scope", tt.target)
+			}
+			continue
+		}
+		if got := plan.Files[0].Path; got != filepath.Join(config, tt.file) {
+			t.Errorf("Plan(%q) path = %q, want %q", tt.target, got, tt.file)
+		}
+		if err := plan.Write(); err != nil {
+			t.Errorf("Write() error = %v", err)
+		}
+	}
+
+	if _, err := s.Plan("kitty"); err == nil {
+		t.Error("expected error for an unknown target")
+	}
+}
+
+func TestSyncer_TmuxConfInHome(t *testing.T) {
+	home := t.TempDir()
+	if err := os.WriteFile(filepath.Join(home, ".tmux.conf"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	plan, err := New(testTheme(), Locations{ConfigDir: t.TempDir(), Home: home}).Plan(TargetTmux)
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if want := filepath.Join(home, ".tmux", TmuxThemeFile); !plan.InScope() || plan.Files[0].Path != want {
+		t.Errorf("Plan() = %+v, want a file at %s", plan, want)
+	}
+}
+
+func TestUpdateStarshipConfig(t *testing.T) {
+	config := `# my prompt
+palette = 'old'
+add_newline = true
+
+[palettes.test-theme]
+bg = "#000000"
+
+[directory]
+style = "bold fg:blue"
+`
+	got := UpdateStarshipConfig(config, "test-theme", "[palettes.test-theme]\nbg = \"#24283b\"\n")
+	want := `# my prompt
+palette = 'test-theme'
+add_newline = true
+
+[directory]
+style = "bold fg:blue"
+
+[palettes.test-theme]
+bg = "#24283b"
+`
+	if got != want {
+		t.Errorf("UpdateStarshipConfig() =\n%s\nwant\n%s", got, want)
+	}
+
+	got = UpdateStarshipConfig("[character]\nsymbol = '>'\n", "test-theme", "[palettes.test-theme]\n")
+	if !strings.HasPrefix(got, "palette = 'test-theme'\n\n[character]") {
+		t.Errorf("palette key not inserted before the first table:\n%s", got)
+	}
+}
+
+func TestStarshipPalette(t *testing.T) {
+	section, err := StarshipPalette(testTheme().ToPalette())
+	if err != nil {
+		t.Fatalf("StarshipPalette() error = %v", err)
+	}
+	if !strings.HasPrefix(section, "[palettes.test-theme]\n") || !strings.Contains(section, `"#24283b"`) {
+		t.Errorf("StarshipPalette() =\n%s", section)
+	}
+	if strings.Count(section, "[") != 1 {
+		t.Errorf("StarshipPalette() holds more than the palette table:\n%s", section)
+	}
+}
+
+func TestTmuxStatusline(t *testing.T) {
+	conf, err := TmuxStatusline(testTheme().ToPalette())
+	if err != nil {
+		t.Fatalf("TmuxStatusline() error = %v", err)
+	}
+	for _, want := range []string{
+		`set -g status-style "bg=#1f2335,fg=#c0caf5"`,
+		`set -g window-status-current-style "bg=#1f2335,fg=#7aa2f7,bold"`,
+		`set -g pane-border-style "fg=#565f89"`,
+	} {
+		if !strings.Contains(conf, want) {
+			t.Errorf("TmuxStatusline() missing %q:\n%s", want, conf)
+		}
+	}
+
+	noColors := testTheme()
+	noColors.Colors = map[string]string{"blue": "#7aa2f7"}
+	if _, err := TmuxStatusline(noColors.ToPalette()); err == nil {
+		t.Error("expected error for a theme without bg and fg")
+	}
+}
@@ -0,0 +1,80 @@
+package themeextract
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Script is the Lua run by headless nvim. It loads the colorscheme named by
+// $NVP_EXTRACT_COLORSCHEME, reads the groups listed in $NVP_EXTRACT_GROUPS
+// and the terminal colors, and writes a Snapshot as JSON to
+// $NVP_EXTRACT_OUT.
+const Script = `
+local result = { highlights = vim.empty_dict(), terminal = vim.empty_dict() }
+local ok, err = pcall(vim.cmd.colorscheme, os.getenv("NVP_EXTRACT_COLORSCHEME"))
+if not ok then
+  result.error = tostring(err)
+else
+  result.background = vim.o.background
+  for _, name in ipairs(vim.split(os.getenv("NVP_EXTRACT_GROUPS"), ",")) do
+    local hl = vim.api.nvim_get_hl(0, { name = name, link = false })
+    local entry = vim.empty_dict()
+    if hl.fg then entry.fg = string.format("#%06x", hl.fg) end
+    if hl.bg then entry.bg = string.format("#%06x", hl.bg) end
+    result.highlights[name] = entry
+  end
+  for i = 0, 15 do
+    local c = vim.g["terminal_color_" .. i]
+    if c then result.terminal[tostring(i)] = c end
+  end
+end
+vim.fn.writefile({ vim.json.encode(result) }, os.getenv("NVP_EXTRACT_OUT"))
+`
+
+// RunHeadless loads colorscheme in headless nvim, with the user's config so
+// the colorscheme's plugin is available, and returns what it reports.
+// nvimPath "" looks nvim up in PATH.
+func RunHeadless(ctx context.Context, nvimPath, colorscheme string) (*Snapshot, error) {
+	if nvimPath == "" {
+		path, err := exec.LookPath("nvim")
+		if err != nil {
+			return nil, fmt.Errorf("nvim not found in PATH: %w", err)
+		}
+		nvimPath = path
+	}
+
+	dir, err := os.MkdirTemp("", "nvp-theme-extract-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	scriptPath := filepath.Join(dir, "extract.lua")
+	outPath := filepath.Join(dir, "snapshot.json")
+	if err := os.WriteFile(scriptPath, []byte(Script), 0o600); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, nvimPath, "--headless", "-c", "luafile "+scriptPath, "-c", "qa!")
+	cmd.Env = append(os.Environ(),
+		"NVP_EXTRACT_COLORSCHEME="+colorscheme,
+		"NVP_EXTRACT_GROUPS="+strings.Join(HighlightGroups(), ","),
+		"NVP_EXTRACT_OUT="+outPath,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("nvim failed: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("nvim did not report any colors: %s", strings.TrimSpace(stderr.String()))
+	}
+	return ParseSnapshot(data)
+}
@@ -0,0 +1,279 @@
+// Package themeextract builds an nvp theme from a Neovim colorscheme that is
+// not in the theme library. Colors come either from running headless nvim
+// with the colorscheme loaded and reading its highlight groups, or from a
+// Lua palette file of the colorscheme's plugin.
+package themeextract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	theme "github.com/rmkohlman/MaestroTheme"
+)
+
+// Highlight holds the resolved colors of one highlight group.
+type Highlight struct {
+	Fg string `json:"fg,omitempty"`
+	Bg string `json:"bg,omitempty"`
+}
+
+// Snapshot is what headless nvim reports after loading a colorscheme.
+type Snapshot struct {
+	// Background is the value of 'background': "dark" or "light".
+	Background string `json:"background,omitempty"`
+	// Highlights maps the groups in HighlightGroups to their colors.
+	Highlights map[string]Highlight `json:"highlights"`
+	// Terminal maps the index of g:terminal_color_N ("0" to "15") to its color.
+	Terminal map[string]string `json:"terminal"`
+	// Error is set when the colorscheme could not be loaded.
+	Error string `json:"error,omitempty"`
+}
+
+// highlightColor reads one theme color from a highlight group.
+type highlightColor struct {
+	key   string
+	group string
+	bg    bool
+}
+
+// highlightColors lists where each theme color is read from, in order of
+// preference: a later entry fills a key only when earlier ones left it
+// empty.
+var highlightColors = []highlightColor{
+	{theme.ColorBg, "Normal", true},
+	{theme.ColorFg, "Normal", false},
+	{theme.ColorBgDark, "NormalFloat", true},
+	{theme.ColorBgFloat, "NormalFloat", true},
+	{theme.ColorBgPopup, "Pmenu", true},
+	{theme.ColorBgStatusline, "StatusLine", true},
+	{theme.ColorBgHighlight, "CursorLine", true},
+	{theme.ColorBgSearch, "Search", true},
+	{theme.ColorBgVisual, "Visual", true},
+	{theme.ColorFgDark, "StatusLine", false},
+	{theme.ColorFgGutter, "LineNr", false},
+	{theme.ColorBorder, "FloatBorder", false},
+	{theme.ColorBorder, "WinSeparator", false},
+	{theme.ColorComment, "Comment", false},
+	{theme.ColorError, "DiagnosticError", false},
+	{theme.ColorWarning, "DiagnosticWarn", false},
+	{theme.ColorInfo, "DiagnosticInfo", false},
+	{theme.ColorHint, "DiagnosticHint", false},
+	{"red", "DiagnosticError", false},
+	{"yellow", "DiagnosticWarn", false},
+	{"green", "String", false},
+	{"blue", "Function", false},
+	{"purple", "Keyword", false},
+	{"magenta", "Keyword", false},
+	{"orange", "Constant", false},
+	{"cyan", "Special", false},
+	{"teal", "DiagnosticHint", false},
+}
+
+// terminalColors names the 16 terminal colors. The six hues also fill the
+// theme's plain hue keys, which they describe better than syntax groups do.
+var terminalColors = []struct {
+	ansi, hue string
+}{
+	{"ansi_black", ""}, {"ansi_red", "red"}, {"ansi_green", "green"}, {"ansi_yellow", "yellow"},
+	{"ansi_blue", "blue"}, {"ansi_magenta", "magenta"}, {"ansi_cyan", "cyan"}, {"ansi_white", ""},
+	{"ansi_bright_black", ""}, {"ansi_bright_red", ""}, {"ansi_bright_green", ""}, {"ansi_bright_yellow", ""},
+	{"ansi_bright_blue", ""}, {"ansi_bright_magenta", ""}, {"ansi_bright_cyan", ""}, {"ansi_bright_white", ""},
+}
+
+// HighlightGroups returns the highlight groups a Snapshot must include.
+func HighlightGroups() []string {
+	seen := make(map[string]bool)
+	var groups []string
+	for _, hc := range highlightColors {
+		if !seen[hc.group] {
+			seen[hc.group] = true
+			groups = append(groups, hc.group)
+		}
+	}
+	return groups
+}
+
+// ParseSnapshot decodes the JSON written by Script.
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse nvim output: %w", err)
+	}
+	if s.Error != "" {
+		return nil, fmt.Errorf("nvim could not load the colorscheme: %s", s.Error)
+	}
+	return &s, nil
+}
+
+// Colors maps a snapshot to theme colors. Terminal colors fill the ansi_*
+// keys and the plain hues; highlight groups fill the rest.
+func (s *Snapshot) Colors() map[string]string {
+	colors := make(map[string]string)
+	for i, tc := range terminalColors {
+		c := normalizeHex(s.Terminal[strconv.Itoa(i)])
+		if c == "" {
+			continue
+		}
+		colors[tc.ansi] = c
+		if tc.hue != "" {
+			colors[tc.hue] = c
+		}
+	}
+	for _, hc := range highlightColors {
+		if colors[hc.key] != "" {
+			continue
+		}
+		hl := s.Highlights[hc.group]
+		c := hl.Fg
+		if hc.bg {
+			c = hl.Bg
+		}
+		if c = normalizeHex(c); c != "" {
+			colors[hc.key] = c
+		}
+	}
+	return colors
+}
+
+// paletteEntry matches `name = "#rrggbb"` and `["name"] = "#rrggbb"` in Lua.
+var paletteEntry = regexp.MustCompile(`(?m)(?:^|[{,;])\s*\[?["']?([A-Za-z_][A-Za-z0-9_]*)["']?\]?\s*=\s*["'](#[0-9A-Fa-f]{6})["']`)
+
+// paletteAliases maps palette names used by popular colorschemes to theme
+// colors. A name that already is a theme color is kept as is.
+var paletteAliases = map[string]string{
+	"background": theme.ColorBg,
+	"foreground": theme.ColorFg,
+	"base":       theme.ColorBg,
+	"bg0":        theme.ColorBg,
+	"text":       theme.ColorFg,
+	"fg0":        theme.ColorFg,
+	"mantle":     theme.ColorBgDark,
+	"surface0":   theme.ColorBgHighlight,
+	"bg1":        theme.ColorBgHighlight,
+	"overlay0":   theme.ColorComment,
+	"mauve":      "purple",
+	"peach":      "orange",
+}
+
+// themeColorKeys are the palette names kept without an alias.
+var themeColorKeys = map[string]bool{
+	theme.ColorBg: true, theme.ColorBgDark: true, theme.ColorBgHighlight: true,
+	theme.ColorBgSearch: true, theme.ColorBgVisual: true, theme.ColorBgFloat: true,
+	theme.ColorBgPopup: true, theme.ColorBgSidebar: true, theme.ColorBgStatusline: true,
+	theme.ColorFg: true, theme.ColorFgDark: true, theme.ColorFgGutter: true,
+	theme.ColorFgSidebar: true, theme.ColorBorder: true, theme.ColorComment: true,
+	theme.ColorError: true, theme.ColorWarning: true, theme.ColorInfo: true, theme.ColorHint: true,
+	"red": true, "green": true, "yellow": true, "blue": true, "magenta": true,
+	"cyan": true, "orange": true, "purple": true, "teal": true, "pink": true,
+}
+
+// ParseLuaPalette reads the `name = "#rrggbb"` entries of a colorscheme's
+// Lua palette file and returns those that name theme colors. The first
+// entry for a color wins, so base palettes at the top of a file outrank
+// variants defined below them.
+func ParseLuaPalette(data []byte) map[string]string {
+	colors := make(map[string]string)
+	aliased := make(map[string]string)
+	for _, m := range paletteEntry.FindAllSubmatch(data, -1) {
+		name, hex := string(m[1]), normalizeHex(string(m[2]))
+		if themeColorKeys[name] {
+			if _, ok := colors[name]; !ok {
+				colors[name] = hex
+			}
+		} else if key, ok := paletteAliases[name]; ok {
+			if _, ok := aliased[key]; !ok {
+				aliased[key] = hex
+			}
+		}
+	}
+	for key, hex := range aliased {
+		if _, ok := colors[key]; !ok {
+			colors[key] = hex
+		}
+	}
+	return colors
+}
+
+// NewTheme returns a theme named name for colorscheme. A colorscheme of a
+// supported plugin (tokyonight-storm) gets that plugin and style (storm);
+// any other becomes a standalone theme generated from its colors. An empty
+// background is guessed from the lightness of the bg color.
+func NewTheme(name, colorscheme string, colors map[string]string, background string) *theme.Theme {
+	t := &theme.Theme{
+		Name:        name,
+		Description: fmt.Sprintf("Extracted from the %s colorscheme", colorscheme),
+		Colors:      colors,
+	}
+	if repo, style := PluginFor(colorscheme); repo != "" {
+		t.Plugin = theme.ThemePlugin{Repo: repo}
+		t.Style = style
+	}
+	if background == "" {
+		background = guessBackground(colors[theme.ColorBg])
+	}
+	switch background {
+	case "dark":
+		t.Category = theme.CategoryDark
+	case "light":
+		t.Category = theme.CategoryLight
+	}
+	return t
+}
+
+// PluginFor returns the supported plugin repo that provides colorscheme
+// and the style the colorscheme selects, or "" when no plugin does. The
+// longest matching setup name wins.
+func PluginFor(colorscheme string) (repo, style string) {
+	repos := make([]string, 0, len(theme.SupportedThemePlugins))
+	for r := range theme.SupportedThemePlugins {
+		repos = append(repos, r)
+	}
+	sort.Strings(repos)
+
+	best := ""
+	for _, r := range repos {
+		setup := theme.SupportedThemePlugins[r]
+		if colorscheme != setup && !strings.HasPrefix(colorscheme, setup+"-") {
+			continue
+		}
+		if len(setup) > len(theme.SupportedThemePlugins[best]) {
+			best = r
+		}
+	}
+	if best == "" {
+		return "", ""
+	}
+	return best, strings.TrimPrefix(strings.TrimPrefix(colorscheme, theme.SupportedThemePlugins[best]), "-")
+}
+
+// guessBackground returns "light" for a light bg color and "dark" for a
+// dark one, or "" when bg is not a color.
+func guessBackground(bg string) string {
+	bg = normalizeHex(bg)
+	if bg == "" {
+		return ""
+	}
+	v, _ := strconv.ParseUint(bg[1:], 16, 32)
+	r, g, b := float64(v>>16&0xff), float64(v>>8&0xff), float64(v&0xff)
+	if 0.299*r+0.587*g+0.114*b > 127 {
+		return "light"
+	}
+	return "dark"
+}
+
+// normalizeHex returns c as a lowercase #rrggbb color, or "" when it is not
+// one.
+func normalizeHex(c string) string {
+	c = strings.ToLower(strings.TrimSpace(c))
+	if len(c) != 7 || c[0] != '#' {
+		return ""
+	}
+	if _, err := strconv.ParseUint(c[1:], 16, 32); err != nil {
+		return ""
+	}
+	return c
+}
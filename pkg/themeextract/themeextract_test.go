@@ -0,0 +1,115 @@
+package themeextract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotColors(t *testing.T) {
+	s, err := ParseSnapshot([]byte(`{
+		"background": "dark",
+		"highlights": {
+			"Normal": {"fg": "#C0CAF5", "bg": "#24283b"},
+			"NormalFloat": {"fg": "#c0caf5", "bg": "#1f2335"},
+			"Comment": {"fg": "#565f89"},
+			"DiagnosticError": {"fg": "#db4b4b"},
+			"String": {"fg": "#9ece6a"}
+		},
+		"terminal": {"1": "#f7768e", "4": "#7aa2f7"}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseSnapshot() error = %v", err)
+	}
+	colors := s.Colors()
+	want := map[string]string{
+		"bg":         "#24283b",
+		"fg":         "#c0caf5",
+		"bg_dark":    "#1f2335",
+		"bg_float":   "#1f2335",
+		"comment":    "#565f89",
+		"error":      "#db4b4b",
+		"red":        "#f7768e", // the terminal color outranks DiagnosticError
+		"green":      "#9ece6a",
+		"blue":       "#7aa2f7",
+		"ansi_red":   "#f7768e",
+		"ansi_blue":  "#7aa2f7",
+		"teal":       "",
+		"bg_visual":  "",
+		"fg_gutter":  "",
+		"ansi_black": "",
+	}
+	for key, w := range want {
+		if colors[key] != w {
+			t.Errorf("Colors()[%q] = %q, want %q", key, colors[key], w)
+		}
+	}
+}
+
+func TestParseSnapshot_Error(t *testing.T) {
+	if _, err := ParseSnapshot([]byte(`{"highlights": {}, "terminal": {}, "error": "E185: Cannot find color scheme 'nope'"}`)); err == nil {
+		t.Fatal("ParseSnapshot() succeeded for a colorscheme nvim could not load")
+	}
+}
+
+func TestParseLuaPalette(t *testing.T) {
+	data := []byte(`
+local M = {}
+M.default = {
+  bg = "#24283b",
+  ["fg"] = '#C0CAF5',
+  comment = "#565f89",
+  git = { add = "#449dab" },
+  base = "#000000",
+  mauve = "#bb9af7",
+}
+M.night = { bg = "#1a1b26", cyan = "#7dcfff" }
+return M
+`)
+	got := ParseLuaPalette(data)
+	want := map[string]string{
+		"bg":      "#24283b",
+		"fg":      "#c0caf5",
+		"comment": "#565f89",
+		"purple":  "#bb9af7",
+		"cyan":    "#7dcfff",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseLuaPalette() = %v, want %v", got, want)
+	}
+}
+
+func TestPluginFor(t *testing.T) {
+	tests := []struct {
+		colorscheme, repo, style string
+	}{
+		{"tokyonight-storm", "folke/tokyonight.nvim", "storm"},
+		{"tokyonight", "folke/tokyonight.nvim", ""},
+		{"catppuccin-mocha", "catppuccin/nvim", "mocha"},
+		{"rose-pine", "rose-pine/neovim", ""},
+		{"my-scheme", "", ""},
+	}
+	for _, tt := range tests {
+		repo, style := PluginFor(tt.colorscheme)
+		if repo != tt.repo || style != tt.style {
+			t.Errorf("PluginFor(%q) = %q, %q, want %q, %q", tt.colorscheme, repo, style, tt.repo, tt.style)
+		}
+	}
+}
+
+func TestNewTheme(t *testing.T) {
+	th := NewTheme("storm", "tokyonight-storm", map[string]string{"bg": "#24283b"}, "")
+	if th.Plugin.Repo != "folke/tokyonight.nvim" || th.Style != "storm" {
+		t.Errorf("plugin = %q style %q", th.Plugin.Repo, th.Style)
+	}
+	if th.Category != "dark" {
+		t.Errorf("Category = %q, want dark from the bg color", th.Category)
+	}
+	if err := th.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	light := NewTheme("paper", "my-scheme", map[string]string{"bg": "#f5f5f5"}, "")
+	if light.Plugin.Repo != "" || light.Category != "light" {
+		t.Errorf("standalone theme = %+v", light)
+	}
+}
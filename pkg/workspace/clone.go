@@ -0,0 +1,83 @@
+package workspace
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// dataDirs are the directories of a workspace that hold user data: the git
+// checkout and the persistent volume. The generated configs in .dvm/ are not
+// data; they are regenerated for each workspace.
+var dataDirs = []string{"repo", "volume"}
+
+// CopyWorkspaceData copies the repo/ and volume/ directories of the
+// workspace at srcPath into the workspace at dstPath, keeping file modes and
+// symlinks. A directory the source doesn't have is skipped. Existing files
+// in the destination are never overwritten; finding one is an error.
+func CopyWorkspaceData(srcPath, dstPath string) error {
+	for _, dir := range dataDirs {
+		src := filepath.Join(srcPath, dir)
+		if _, err := os.Lstat(src); os.IsNotExist(err) {
+			continue
+		}
+		if err := copyTree(src, filepath.Join(dstPath, dir)); err != nil {
+			return fmt.Errorf("failed to copy workspace %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// copyTree copies the directory tree at src to dst. Sockets, devices, and
+// other special files are skipped.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch mode := info.Mode(); {
+		case mode.IsDir():
+			return os.MkdirAll(target, mode.Perm())
+		case mode&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case mode.IsRegular():
+			return copyFile(path, target, mode.Perm())
+		}
+		return nil
+	})
+}
+
+// copyFile copies the regular file src to a new file dst with mode perm.
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCopyWorkspaceData verifies repo/ and volume/ are copied with modes and
+// symlinks, and generated configs are left behind
+func TestCopyWorkspaceData(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	dst := filepath.Join(t.TempDir(), "dst")
+
+	files := map[string]os.FileMode{
+		"repo/main.go":                     0644,
+		"repo/.git/HEAD":                   0644,
+		"volume/nvim-data/mason/bin/gopls": 0755,
+		"volume/nvim-state/undo/%file":     0600,
+		".dvm/nvim/init.lua":               0644,
+	}
+	for rel, mode := range files {
+		path := filepath.Join(src, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(rel), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("mason/bin/gopls", filepath.Join(src, "volume/nvim-data/gopls")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CopyWorkspaceData(src, dst); err != nil {
+		t.Fatalf("CopyWorkspaceData() error = %v", err)
+	}
+
+	for rel, mode := range files {
+		info, err := os.Stat(filepath.Join(dst, rel))
+		if rel == ".dvm/nvim/init.lua" {
+			if !os.IsNotExist(err) {
+				t.Errorf("generated config %s should not be copied", rel)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s not copied: %v", rel, err)
+			continue
+		}
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s mode = %v, want %v", rel, info.Mode().Perm(), mode)
+		}
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "volume/nvim-data/gopls")); err != nil || link != "mason/bin/gopls" {
+		t.Errorf("symlink = %q, %v; want mason/bin/gopls", link, err)
+	}
+
+	// Copying again would overwrite the copied files
+	if err := CopyWorkspaceData(src, dst); err == nil {
+		t.Error("CopyWorkspaceData() should not overwrite existing files")
+	}
+}
+
+// TestCopyWorkspaceData_MissingDirs verifies a workspace without data copies nothing
+func TestCopyWorkspaceData_MissingDirs(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "dst")
+	if err := CopyWorkspaceData(t.TempDir(), dst); err != nil {
+		t.Fatalf("CopyWorkspaceData() error = %v", err)
+	}
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Error("nothing should be created for a workspace without data")
+	}
+}
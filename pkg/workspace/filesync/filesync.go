@@ -0,0 +1,386 @@
+// Package filesync keeps a workspace container's /workspace in sync with the
+// app's files on the host (spec.sync), as an alternative to bind mounts,
+// which are slow on macOS VMs.
+//
+// Each pass lists both sides, compares them with the state recorded after
+// the previous pass, and copies the changed files as tar streams through the
+// container runtime's exec. Files changed on both sides are reported as
+// conflicts and never overwritten. Only regular files are synced; the app's
+// .gitignore and spec.sync.ignore decide what is left out.
+package filesync
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultContainerDir is where the app's files live in the container.
+const DefaultContainerDir = "/workspace"
+
+// StateFile is the name of the sync state file in the workspace's
+// generated config directory.
+const StateFile = "sync-state.json"
+
+// ExecFunc runs a command in the workspace container with the given stdin
+// (nil for none), writing its stdout to stdout (nil to discard it).
+type ExecFunc func(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error
+
+// Session syncs one workspace.
+type Session struct {
+	Mode         string   // models.SyncModeOneWay or models.SyncModeTwoWay
+	HostDir      string   // App files on the host
+	ContainerDir string   // App files in the container (default: /workspace)
+	ContainerID  string   // The recorded state is discarded when the container changes
+	StatePath    string   // Where the state of the last pass is kept
+	Ignore       *Matcher // Paths left out of the sync
+	Exec         ExecFunc
+}
+
+// State is what a session records after each pass.
+type State struct {
+	ContainerID string    `json:"containerId"`
+	SyncedAt    time.Time `json:"syncedAt"`
+	Files       Manifest  `json:"files"`
+}
+
+// Status plans a pass without applying it.
+func (s *Session) Status(ctx context.Context) (*Plan, error) {
+	state, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+	host, err := ScanHost(s.HostDir, s.Ignore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", s.HostDir, err)
+	}
+	container, err := s.scanContainer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list container files: %w", err)
+	}
+	return computePlan(s.Mode, state.Files, host, container), nil
+}
+
+// Sync runs one pass and returns what it did. The state is only recorded
+// when every change was applied, so a failed pass is retried in full.
+func (s *Session) Sync(ctx context.Context) (*Plan, error) {
+	plan, err := s.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Push) > 0 {
+		if err := s.push(ctx, plan.Push); err != nil {
+			return nil, fmt.Errorf("failed to copy files into the container: %w", err)
+		}
+	}
+	if len(plan.DeleteInContainer) > 0 {
+		if err := s.deleteInContainer(ctx, plan.DeleteInContainer); err != nil {
+			return nil, fmt.Errorf("failed to delete files in the container: %w", err)
+		}
+	}
+	if len(plan.Pull) > 0 {
+		if err := s.pull(ctx, plan.Pull); err != nil {
+			return nil, fmt.Errorf("failed to copy files from the container: %w", err)
+		}
+	}
+	for _, p := range plan.DeleteOnHost {
+		if err := os.Remove(s.hostPath(p)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	state := State{ContainerID: s.ContainerID, SyncedAt: time.Now().UTC(), Files: plan.base}
+	if err := s.saveState(state); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Watch runs a pass every interval until ctx is done, calling report after
+// each pass that changed something, found conflicts, or failed.
+func (s *Session) Watch(ctx context.Context, interval time.Duration, report func(*Plan, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		plan, err := s.Sync(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil || !plan.Empty() {
+			report(plan, err)
+		}
+	}
+}
+
+// ContainerDirMounted reports whether the container directory is a mount
+// point, as in a container created to bind mount the app's files. Syncing
+// into such a container would copy the files onto themselves.
+func (s *Session) ContainerDirMounted(ctx context.Context) (bool, error) {
+	var out strings.Builder
+	script := `if cut -d' ' -f5 /proc/self/mountinfo | grep -qxF -- "$1"; then echo mounted; fi`
+	if err := s.Exec(ctx, []string{"sh", "-c", script, "sh", s.containerDir()}, nil, &out); err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out.String()) == "mounted", nil
+}
+
+// ScanHost lists the regular files under dir that are not ignored.
+func ScanHost(dir string, ignore *Matcher) (Manifest, error) {
+	files := Manifest{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if ignore.Match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files[rel] = Entry{Size: info.Size(), ModTime: info.ModTime().Unix()}
+		return nil
+	})
+	return files, err
+}
+
+// listScript prints "<size> <mtime> ./<path>" for each file in $1.
+const listScript = `[ -d "$1" ] || exit 0; cd "$1" && find . -type f -exec stat -c '%s %Y %n' {} +`
+
+func (s *Session) scanContainer(ctx context.Context) (Manifest, error) {
+	var out strings.Builder
+	if err := s.Exec(ctx, []string{"sh", "-c", listScript, "sh", s.containerDir()}, nil, &out); err != nil {
+		return nil, err
+	}
+	return parseListing(out.String(), s.Ignore)
+}
+
+// parseListing parses the output of listScript.
+func parseListing(out string, ignore *Matcher) (Manifest, error) {
+	files := Manifest{}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected file listing line %q", line)
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected file listing line %q", line)
+		}
+		mtime, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected file listing line %q", line)
+		}
+		rel := strings.TrimPrefix(fields[2], "./")
+		if ignore.Ignored(rel) {
+			continue
+		}
+		files[rel] = Entry{Size: size, ModTime: mtime}
+	}
+	return files, nil
+}
+
+// push copies host files into the container as a tar stream.
+func (s *Session) push(ctx context.Context, paths []string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(s.writeTar(pw, paths))
+	}()
+	err := s.Exec(ctx, []string{"sh", "-c", `mkdir -p "$1" && tar -xf - -C "$1"`, "sh", s.containerDir()}, pr, nil)
+	pr.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+func (s *Session) writeTar(w io.Writer, paths []string) error {
+	tw := tar.NewWriter(w)
+	for _, p := range paths {
+		if err := addFile(tw, s.hostPath(p), p); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func addFile(tw *tar.Writer, file, name string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	// Whole seconds, so both sides record the same modification time
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime().Truncate(time.Second),
+	}); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, f, info.Size())
+	return err
+}
+
+// pull copies container files to the host as a tar stream.
+func (s *Session) pull(ctx context.Context, paths []string) error {
+	pr, pw := io.Pipe()
+	execErr := make(chan error, 1)
+	go func() {
+		err := s.Exec(ctx, []string{"sh", "-c", `cd "$1" && tar -cf - -T -`, "sh", s.containerDir()},
+			strings.NewReader(strings.Join(paths, "\n")+"\n"), pw)
+		pw.CloseWithError(err)
+		execErr <- err
+	}()
+	err := s.extractTar(pr)
+	if err == nil {
+		// tar pads the archive past its end marker
+		_, err = io.Copy(io.Discard, pr)
+	}
+	pr.CloseWithError(io.ErrClosedPipe) // unblocks the exec if extraction stopped early
+	if execErr := <-execErr; execErr != nil {
+		return execErr
+	}
+	return err
+}
+
+func (s *Session) extractTar(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("refusing to write %q outside %s", hdr.Name, s.HostDir)
+		}
+		if err := writeFile(s.hostPath(name), tr, hdr); err != nil {
+			return err
+		}
+	}
+}
+
+func writeFile(file string, r io.Reader, hdr *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(file, hdr.ModTime, hdr.ModTime)
+}
+
+// deleteBatch bounds the number of paths passed to one rm.
+const deleteBatch = 500
+
+func (s *Session) deleteInContainer(ctx context.Context, paths []string) error {
+	for len(paths) > 0 {
+		n := min(len(paths), deleteBatch)
+		command := append([]string{"sh", "-c", `cd "$1" && shift && rm -f -- "$@"`, "sh", s.containerDir()}, paths[:n]...)
+		if err := s.Exec(ctx, command, nil, nil); err != nil {
+			return err
+		}
+		paths = paths[n:]
+	}
+	return nil
+}
+
+func (s *Session) containerDir() string {
+	if s.ContainerDir == "" {
+		return DefaultContainerDir
+	}
+	return s.ContainerDir
+}
+
+func (s *Session) hostPath(rel string) string {
+	return filepath.Join(s.HostDir, filepath.FromSlash(rel))
+}
+
+// loadState reads the recorded state. A missing file, or one recorded for
+// another container, starts from an empty state: every host file is pushed.
+func (s *Session) loadState() (State, error) {
+	state := State{Files: Manifest{}}
+	data, err := os.ReadFile(s.StatePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	var recorded State
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return state, fmt.Errorf("failed to parse %s: %w", s.StatePath, err)
+	}
+	if recorded.ContainerID != s.ContainerID || recorded.Files == nil {
+		return state, nil
+	}
+	return recorded, nil
+}
+
+func (s *Session) saveState(state State) error {
+	if err := os.MkdirAll(filepath.Dir(s.StatePath), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := s.StatePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.StatePath)
+}
+
+// LastSynced returns when the current container was last synced, or the
+// zero time when it has not been.
+func (s *Session) LastSynced() (time.Time, error) {
+	state, err := s.loadState()
+	return state.SyncedAt, err
+}
@@ -0,0 +1,137 @@
+package filesync
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// localExec runs the container commands on this machine, so a temporary
+// directory stands in for the container's /workspace.
+func localExec(ctx context.Context, command []string, stdin io.Reader, stdout io.Writer) error {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	return cmd.Run()
+}
+
+func newTestSession(t *testing.T, mode string) *Session {
+	if err := exec.Command("stat", "-c", "%s", "/").Run(); err != nil {
+		t.Skip("requires GNU or busybox stat")
+	}
+	root := t.TempDir()
+	s := &Session{
+		Mode:         mode,
+		HostDir:      filepath.Join(root, "host"),
+		ContainerDir: filepath.Join(root, "container"),
+		ContainerID:  "abc123",
+		StatePath:    filepath.Join(root, "state", StateFile),
+		Ignore:       NewMatcher([]string{"tmp/"}),
+		Exec:         localExec,
+	}
+	require.NoError(t, os.MkdirAll(s.HostDir, 0755))
+	return s
+}
+
+func writeAt(t *testing.T, file, content string, mtime time.Time) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	require.NoError(t, os.Chtimes(file, mtime, mtime))
+}
+
+func TestSession_TwoWay(t *testing.T) {
+	s := newTestSession(t, models.SyncModeTwoWay)
+	ctx := context.Background()
+	t0 := time.Unix(1700000000, 0)
+	writeAt(t, filepath.Join(s.HostDir, "main.go"), "package main\n", t0)
+	writeAt(t, filepath.Join(s.HostDir, "pkg", "util.go"), "package pkg\n", t0)
+	writeAt(t, filepath.Join(s.HostDir, "tmp", "cache"), "ignored", t0)
+
+	// First pass copies the host files into the empty container
+	plan, err := s.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go", "pkg/util.go"}, plan.Push)
+	data, err := os.ReadFile(filepath.Join(s.ContainerDir, "pkg", "util.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg\n", string(data))
+	assert.NoFileExists(t, filepath.Join(s.ContainerDir, "tmp", "cache"))
+
+	plan, err = s.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "nothing changed since the last pass")
+
+	// Container edits come back; host deletions go in
+	t1 := t0.Add(time.Minute)
+	writeAt(t, filepath.Join(s.ContainerDir, "main.go"), "package main // edited\n", t1)
+	writeAt(t, filepath.Join(s.ContainerDir, "gen.go"), "package main\n", t1)
+	require.NoError(t, os.Remove(filepath.Join(s.HostDir, "pkg", "util.go")))
+
+	plan, err = s.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gen.go", "main.go"}, plan.Pull)
+	assert.Equal(t, []string{"pkg/util.go"}, plan.DeleteInContainer)
+	data, err = os.ReadFile(filepath.Join(s.HostDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main // edited\n", string(data))
+	assert.NoFileExists(t, filepath.Join(s.ContainerDir, "pkg", "util.go"))
+
+	// Edits on both sides are conflicts and left alone
+	t2 := t1.Add(time.Minute)
+	writeAt(t, filepath.Join(s.HostDir, "gen.go"), "host", t2)
+	writeAt(t, filepath.Join(s.ContainerDir, "gen.go"), "container", t2)
+	plan, err = s.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Conflict{{Path: "gen.go", Reason: "changed on both sides"}}, plan.Conflicts)
+	data, err = os.ReadFile(filepath.Join(s.HostDir, "gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "host", string(data))
+
+	synced, err := s.LastSynced()
+	require.NoError(t, err)
+	assert.False(t, synced.IsZero())
+
+	// A new container starts over
+	s.ContainerID = "def456"
+	synced, err = s.LastSynced()
+	require.NoError(t, err)
+	assert.True(t, synced.IsZero())
+}
+
+func TestSession_ContainerDirMounted(t *testing.T) {
+	s := newTestSession(t, models.SyncModeTwoWay)
+	mounted, err := s.ContainerDirMounted(context.Background())
+	require.NoError(t, err)
+	assert.False(t, mounted)
+
+	s.ContainerDir = "/"
+	mounted, err = s.ContainerDirMounted(context.Background())
+	require.NoError(t, err)
+	assert.True(t, mounted)
+}
+
+func TestSession_OneWay(t *testing.T) {
+	s := newTestSession(t, models.SyncModeOneWay)
+	ctx := context.Background()
+	t0 := time.Unix(1700000000, 0)
+	writeAt(t, filepath.Join(s.HostDir, "main.go"), "package main\n", t0)
+
+	_, err := s.Sync(ctx)
+	require.NoError(t, err)
+
+	writeAt(t, filepath.Join(s.ContainerDir, "main.go"), "container edit\n", t0.Add(time.Minute))
+	plan, err := s.Sync(ctx)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty(), "container changes stay in the container")
+	data, err := os.ReadFile(filepath.Join(s.HostDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+}
@@ -0,0 +1,141 @@
+package filesync
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Matcher decides which paths are left out of the sync, using .gitignore
+// pattern syntax: '#' comments, '!' negation, a trailing '/' for directories
+// only, a leading or inner '/' to anchor the pattern at the root, and '**'
+// for any number of directories. The last matching pattern wins.
+type Matcher struct {
+	rules []rule
+}
+
+type rule struct {
+	segments []string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// NewMatcher compiles .gitignore-style patterns.
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{}
+	for _, p := range patterns {
+		p = strings.TrimRight(p, " \t\r")
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+		var r rule
+		if strings.HasPrefix(p, "!") {
+			r.negate = true
+			p = p[1:]
+		}
+		p = strings.TrimPrefix(p, `\`)
+		if strings.HasSuffix(p, "/") {
+			r.dirOnly = true
+			p = strings.TrimRight(p, "/")
+		}
+		if strings.Contains(p, "/") {
+			r.anchored = true
+			p = strings.TrimPrefix(p, "/")
+		}
+		if p == "" {
+			continue
+		}
+		r.segments = strings.Split(p, "/")
+		m.rules = append(m.rules, r)
+	}
+	return m
+}
+
+// LoadIgnore builds the matcher for a directory from its .gitignore (when
+// present) followed by the extra patterns, so the extras take precedence.
+func LoadIgnore(dir string, extra []string) (*Matcher, error) {
+	var patterns []string
+	f, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			patterns = append(patterns, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return NewMatcher(append(patterns, extra...)), nil
+}
+
+// Match reports whether a slash-separated path, relative to the sync root,
+// is ignored by itself (without looking at its parent directories).
+func (m *Matcher) Match(rel string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	ignored := false
+	for _, r := range m.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if r.matches(parts) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Ignored reports whether a file is ignored, by itself or because one of its
+// parent directories is. As in git, files in an ignored directory cannot be
+// re-included.
+func (m *Matcher) Ignored(rel string) bool {
+	for i := 0; i < len(rel); i++ {
+		if rel[i] == '/' && m.Match(rel[:i], true) {
+			return true
+		}
+	}
+	return m.Match(rel, false)
+}
+
+func (r rule) matches(parts []string) bool {
+	if !r.anchored {
+		// A pattern without a slash matches a name at any depth
+		ok, _ := path.Match(r.segments[0], parts[len(parts)-1])
+		return ok
+	}
+	return matchSegments(r.segments, parts)
+}
+
+// matchSegments matches path segments against pattern segments, where '**'
+// matches zero or more segments.
+func matchSegments(pattern, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(pattern[1:], parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(parts) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+			return false
+		}
+		pattern, parts = pattern[1:], parts[1:]
+	}
+	return len(parts) == 0
+}
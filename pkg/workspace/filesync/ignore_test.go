@@ -0,0 +1,60 @@
+package filesync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]string{
+		"# build output",
+		"node_modules/",
+		"*.log",
+		"!keep.log",
+		"/dist",
+		"docs/**/*.tmp",
+		"",
+	})
+
+	tests := []struct {
+		path    string
+		ignored bool
+	}{
+		{"node_modules/react/index.js", true},
+		{"web/node_modules/react/index.js", true},
+		{"app.log", true},
+		{"logs/server.log", true},
+		{"keep.log", false},
+		{"dist/app.js", true},
+		{"web/dist/app.js", false},
+		{"docs/a/b/draft.tmp", true},
+		{"docs/draft.tmp", true},
+		{"main.go", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.ignored, m.Ignored(tt.path), tt.path)
+	}
+
+	// Directory-only patterns do not match files of the same name
+	assert.False(t, m.Match("node_modules", false))
+	assert.True(t, m.Match("node_modules", true))
+}
+
+func TestLoadIgnore(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("vendor/\n*.out\n"), 0644))
+
+	m, err := LoadIgnore(dir, []string{"!main.out"})
+	require.NoError(t, err)
+	assert.True(t, m.Ignored("vendor/x.go"))
+	assert.True(t, m.Ignored("test.out"))
+	assert.False(t, m.Ignored("main.out"), "spec.sync.ignore patterns come after .gitignore")
+
+	m, err = LoadIgnore(t.TempDir(), nil)
+	require.NoError(t, err)
+	assert.False(t, m.Ignored("anything"))
+}
@@ -0,0 +1,117 @@
+package filesync
+
+import (
+	"sort"
+
+	"devopsmaestro/models"
+)
+
+// Entry is what the sync knows about a file: its size and modification
+// time in whole seconds, which both sides keep when a file is copied.
+type Entry struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"mtime"`
+}
+
+// Manifest maps slash-separated paths, relative to the sync root, to files.
+type Manifest map[string]Entry
+
+// Conflict is a file changed on both sides since the last sync. Conflicts
+// are reported and left alone until one side is changed to match the other.
+type Conflict struct {
+	Path   string `json:"path" yaml:"path"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// Plan is the work of one sync pass.
+type Plan struct {
+	Push              []string   `json:"push,omitempty" yaml:"push,omitempty"`                           // Copy from the host into the container
+	Pull              []string   `json:"pull,omitempty" yaml:"pull,omitempty"`                           // Copy from the container to the host
+	DeleteInContainer []string   `json:"deleteInContainer,omitempty" yaml:"deleteInContainer,omitempty"` // Deleted on the host
+	DeleteOnHost      []string   `json:"deleteOnHost,omitempty" yaml:"deleteOnHost,omitempty"`           // Deleted in the container
+	Conflicts         []Conflict `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+
+	// base is the state to record once the plan is applied.
+	base Manifest
+}
+
+// Pending returns the number of changes the plan applies.
+func (p *Plan) Pending() int {
+	return len(p.Push) + len(p.Pull) + len(p.DeleteInContainer) + len(p.DeleteOnHost)
+}
+
+// Empty reports whether there is nothing to apply or report.
+func (p *Plan) Empty() bool {
+	return p.Pending() == 0 && len(p.Conflicts) == 0
+}
+
+// computePlan compares both sides with the state of the last sync (base).
+//
+// A side changed a file when its entry differs from the base. Changes on the
+// host are pushed; changes in the container are pulled in two-way mode and
+// left in the container in one-way mode. A file changed on both sides is a
+// conflict unless both sides now agree.
+func computePlan(mode string, base, host, container Manifest) *Plan {
+	plan := &Plan{base: make(Manifest, len(base))}
+	for p, e := range base {
+		plan.base[p] = e
+	}
+
+	paths := make(map[string]bool, len(host))
+	for _, m := range []Manifest{base, host, container} {
+		for p := range m {
+			paths[p] = true
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	for _, p := range sorted {
+		b, inBase := base[p]
+		h, onHost := host[p]
+		c, inContainer := container[p]
+		hostChanged := onHost != inBase || h != b
+		containerChanged := inContainer != inBase || c != b
+
+		switch {
+		case !hostChanged && !containerChanged:
+		case onHost == inContainer && h == c:
+			// Both sides made the same change
+			if onHost {
+				plan.base[p] = h
+			} else {
+				delete(plan.base, p)
+			}
+		case hostChanged && containerChanged:
+			plan.Conflicts = append(plan.Conflicts, Conflict{Path: p, Reason: conflictReason(onHost, inContainer)})
+		case hostChanged && onHost:
+			plan.Push = append(plan.Push, p)
+			plan.base[p] = h
+		case hostChanged:
+			plan.DeleteInContainer = append(plan.DeleteInContainer, p)
+			delete(plan.base, p)
+		case mode != models.SyncModeTwoWay:
+			// One-way: container changes stay in the container
+		case inContainer:
+			plan.Pull = append(plan.Pull, p)
+			plan.base[p] = c
+		default:
+			plan.DeleteOnHost = append(plan.DeleteOnHost, p)
+			delete(plan.base, p)
+		}
+	}
+	return plan
+}
+
+func conflictReason(onHost, inContainer bool) string {
+	switch {
+	case !onHost:
+		return "deleted on host, changed in container"
+	case !inContainer:
+		return "changed on host, deleted in container"
+	}
+	return "changed on both sides"
+}
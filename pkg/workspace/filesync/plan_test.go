@@ -0,0 +1,92 @@
+package filesync
+
+import (
+	"testing"
+
+	"devopsmaestro/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePlan(t *testing.T) {
+	v1, v2, v3 := Entry{Size: 1, ModTime: 100}, Entry{Size: 2, ModTime: 200}, Entry{Size: 3, ModTime: 300}
+	base := Manifest{
+		"same.go":          v1,
+		"host-edit.go":     v1,
+		"box-edit.go":      v1,
+		"both-edit.go":     v1,
+		"host-deleted.go":  v1,
+		"box-deleted.go":   v1,
+		"converged.go":     v1,
+		"deleted-edit.go":  v1,
+		"edited-delete.go": v1,
+	}
+	host := Manifest{
+		"same.go":          v1,
+		"host-edit.go":     v2,
+		"box-edit.go":      v1,
+		"both-edit.go":     v2,
+		"box-deleted.go":   v1,
+		"converged.go":     v2,
+		"edited-delete.go": v2,
+		"host-new.go":      v1,
+	}
+	container := Manifest{
+		"same.go":         v1,
+		"host-edit.go":    v1,
+		"box-edit.go":     v2,
+		"both-edit.go":    v3,
+		"host-deleted.go": v1,
+		"converged.go":    v2,
+		"deleted-edit.go": v2,
+		"box-new.go":      v1,
+	}
+
+	plan := computePlan(models.SyncModeTwoWay, base, host, container)
+	assert.Equal(t, []string{"host-edit.go", "host-new.go"}, plan.Push)
+	assert.Equal(t, []string{"box-edit.go", "box-new.go"}, plan.Pull)
+	assert.Equal(t, []string{"host-deleted.go"}, plan.DeleteInContainer)
+	assert.Equal(t, []string{"box-deleted.go"}, plan.DeleteOnHost)
+	assert.Equal(t, []Conflict{
+		{Path: "both-edit.go", Reason: "changed on both sides"},
+		{Path: "deleted-edit.go", Reason: "deleted on host, changed in container"},
+		{Path: "edited-delete.go", Reason: "changed on host, deleted in container"},
+	}, plan.Conflicts)
+	assert.Equal(t, 6, plan.Pending())
+
+	// The new base records applied changes and keeps conflicts as they were
+	assert.Equal(t, v2, plan.base["host-edit.go"])
+	assert.Equal(t, v2, plan.base["box-edit.go"])
+	assert.Equal(t, v2, plan.base["converged.go"])
+	assert.Equal(t, v1, plan.base["both-edit.go"])
+	assert.NotContains(t, plan.base, "host-deleted.go")
+	assert.NotContains(t, plan.base, "box-deleted.go")
+
+	// One-way leaves container changes in the container
+	plan = computePlan(models.SyncModeOneWay, base, host, container)
+	assert.Equal(t, []string{"host-edit.go", "host-new.go"}, plan.Push)
+	assert.Empty(t, plan.Pull)
+	assert.Empty(t, plan.DeleteOnHost)
+	assert.Len(t, plan.Conflicts, 3)
+	assert.Equal(t, v1, plan.base["box-edit.go"])
+}
+
+func TestComputePlan_FirstSync(t *testing.T) {
+	host := Manifest{"main.go": {Size: 10, ModTime: 100}}
+	plan := computePlan(models.SyncModeTwoWay, Manifest{}, host, Manifest{})
+	assert.Equal(t, []string{"main.go"}, plan.Push)
+	assert.True(t, computePlan(models.SyncModeTwoWay, host, host, host).Empty())
+}
+
+func TestParseListing(t *testing.T) {
+	files, err := parseListing("12 1700000000 ./main.go\n3 1700000001 ./my notes.txt\n5 1700000002 ./node_modules/x.js\n",
+		NewMatcher([]string{"node_modules/"}))
+	assert.NoError(t, err)
+	assert.Equal(t, Manifest{
+		"main.go":      {Size: 12, ModTime: 1700000000},
+		"my notes.txt": {Size: 3, ModTime: 1700000001},
+	}, files)
+
+	_, err = parseListing("garbage\n", nil)
+	assert.Error(t, err)
+}
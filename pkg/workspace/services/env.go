@@ -0,0 +1,110 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// kind describes a well-known service image: its default port and the URL
+// variable apps conventionally read its address from.
+type kind struct {
+	port   int
+	urlVar string
+	url    func(svc Service, host string) string
+}
+
+// kinds maps image repository names to well-known service kinds.
+var kinds = map[string]kind{
+	"postgres": {5432, "DATABASE_URL", func(svc Service, host string) string {
+		user := envOr(svc.Env, "POSTGRES_USER", "postgres")
+		return fmt.Sprintf("postgres://%s:%s@%s:%d/%s", user, svc.Env["POSTGRES_PASSWORD"], host, svc.Port,
+			envOr(svc.Env, "POSTGRES_DB", user))
+	}},
+	"mysql":   {3306, "DATABASE_URL", mysqlURL},
+	"mariadb": {3306, "DATABASE_URL", mysqlURL},
+	"redis": {6379, "REDIS_URL", func(svc Service, host string) string {
+		return fmt.Sprintf("redis://%s:%d", host, svc.Port)
+	}},
+	"valkey": {6379, "REDIS_URL", func(svc Service, host string) string {
+		return fmt.Sprintf("redis://%s:%d", host, svc.Port)
+	}},
+	"mongo": {27017, "MONGODB_URL", func(svc Service, host string) string {
+		return fmt.Sprintf("mongodb://%s:%d", host, svc.Port)
+	}},
+	"rabbitmq": {5672, "AMQP_URL", func(svc Service, host string) string {
+		return fmt.Sprintf("amqp://%s:%s@%s:%d", envOr(svc.Env, "RABBITMQ_DEFAULT_USER", "guest"),
+			envOr(svc.Env, "RABBITMQ_DEFAULT_PASS", "guest"), host, svc.Port)
+	}},
+	"nats": {4222, "NATS_URL", func(svc Service, host string) string {
+		return fmt.Sprintf("nats://%s:%d", host, svc.Port)
+	}},
+}
+
+func mysqlURL(svc Service, host string) string {
+	user, password := envOr(svc.Env, "MYSQL_USER", "root"), svc.Env["MYSQL_PASSWORD"]
+	if user == "root" {
+		password = svc.Env["MYSQL_ROOT_PASSWORD"]
+	}
+	return fmt.Sprintf("mysql://%s:%s@%s:%d/%s", user, password, host, svc.Port, svc.Env["MYSQL_DATABASE"])
+}
+
+func envOr(env map[string]string, key, fallback string) string {
+	if v := env[key]; v != "" {
+		return v
+	}
+	return fallback
+}
+
+// kindOf returns the well-known kind of an image, if any.
+func kindOf(image string) (kind, bool) {
+	repo := image
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	k, ok := kinds[path.Base(repo)]
+	return k, ok
+}
+
+// defaultPort returns the default port of a well-known image, or 0.
+func defaultPort(image string) int {
+	k, _ := kindOf(image)
+	return k.port
+}
+
+// Env returns the connection variables for the stack's services:
+// <NAME>_HOST (see Host) and <NAME>_PORT for every service, where NAME is
+// the service name in upper case, and the conventional URL variable (DATABASE_URL,
+// REDIS_URL, ...) for the first service of each well-known kind.
+func (s *Stack) Env() map[string]string {
+	env := map[string]string{}
+	for _, svc := range s.Services {
+		prefix := envName(svc.Name)
+		host := s.Host(svc)
+		env[prefix+"_HOST"] = host
+		if svc.Port == 0 {
+			continue
+		}
+		env[prefix+"_PORT"] = strconv.Itoa(svc.Port)
+		if k, ok := kindOf(svc.Image); ok {
+			if _, set := env[k.urlVar]; !set {
+				env[k.urlVar] = k.url(svc, host)
+			}
+		}
+	}
+	return env
+}
+
+// envName converts a service name to an environment variable prefix.
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+}
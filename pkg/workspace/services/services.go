@@ -0,0 +1,323 @@
+// Package services plans the backing services of a workspace (spec.services):
+// the Compose project that runs them next to the workspace container, and the
+// connection environment variables injected into the workspace.
+//
+// Services from the workspace's Compose file and inline definitions run as one
+// Compose project per workspace. Compose puts them on the project's default
+// network, which the workspace container joins, so each service is reachable
+// by its service name. When the workspace is on its app's network, the
+// services join that network instead, and with an alias domain are also
+// reachable as <service>.<domain>.
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"devopsmaestro/models"
+	"devopsmaestro/operators"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GeneratedComposeFile is the file inline services are written to, in the
+// workspace's generated config directory.
+const GeneratedComposeFile = "services-compose.yaml"
+
+// Service is a backing service the workspace connects to.
+type Service struct {
+	Name  string
+	Image string
+	Port  int               // Container port; 0 when unknown
+	Env   map[string]string // Service environment (used for credentials)
+}
+
+// Stack is the Compose project for a workspace's services.
+type Stack struct {
+	// Project is the Compose project name.
+	Project string
+
+	// ComposeFiles are the Compose files of the project, as absolute paths.
+	// The generated file for inline services is added by WriteInline.
+	ComposeFiles []string
+
+	// Only lists the services to start; empty starts all of them.
+	Only []string
+
+	// Services are the services the workspace connects to.
+	Services []Service
+
+	// Labels are put on the services' containers and the project's network,
+	// next to the managed label. Set them before WriteInline.
+	Labels map[string]string
+
+	// AppNetwork is the existing network the services join instead of a
+	// network of the project's own. Set it before WriteInline.
+	AppNetwork string
+
+	// AliasDomain qualifies the services' names on AppNetwork, which other
+	// workspaces share: each is also reachable as <service>.<AliasDomain>,
+	// and Env addresses it so. Set it before WriteInline.
+	AliasDomain string
+
+	inline []models.AppServiceConfig
+}
+
+// ProjectName returns the Compose project name for a workspace slug.
+func ProjectName(slug string) string {
+	return "dvm-" + strings.ToLower(slug)
+}
+
+// Network returns the name of the network the services are on: AppNetwork,
+// or the one Compose creates for the project. The workspace container joins
+// it to reach the services.
+func (s *Stack) Network() string {
+	if s.AppNetwork != "" {
+		return s.AppNetwork
+	}
+	return s.Project + "_default"
+}
+
+// Host returns the host name the workspace reaches svc by.
+func (s *Stack) Host(svc Service) string {
+	if s.AppNetwork != "" && s.AliasDomain != "" {
+		return svc.Name + "." + s.AliasDomain
+	}
+	return svc.Name
+}
+
+// Plan resolves a workspace's services config. The Compose file is read to
+// learn its services' images and ports; it is resolved relative to appPath.
+// Plan returns nil when no services are configured.
+func Plan(cfg models.ServicesConfig, appPath, slug string) (*Stack, error) {
+	if cfg.IsZero() {
+		return nil, nil
+	}
+	stack := &Stack{Project: ProjectName(slug), Only: append([]string(nil), cfg.Only...), inline: cfg.Inline}
+
+	if cfg.ComposeFile != "" {
+		path := cfg.ComposeFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(appPath, path)
+		}
+		composeServices, err := readComposeServices(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range cfg.Only {
+			if _, ok := composeServices[name]; !ok {
+				return nil, fmt.Errorf("service %q is not defined in %s", name, cfg.ComposeFile)
+			}
+		}
+		stack.ComposeFiles = append(stack.ComposeFiles, path)
+
+		names := cfg.Only
+		if len(names) == 0 {
+			for name := range composeServices {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+		}
+		for _, name := range names {
+			stack.Services = append(stack.Services, composeServices[name])
+		}
+	}
+
+	for _, svc := range cfg.Inline {
+		if svc.Name == "" {
+			return nil, fmt.Errorf("inline service without a name")
+		}
+		image := inlineImage(svc)
+		port := svc.Port
+		if port == 0 {
+			port = defaultPort(image)
+		}
+		stack.Services = append(stack.Services, Service{Name: svc.Name, Image: image, Port: port, Env: svc.Env})
+		if len(cfg.Only) > 0 {
+			stack.Only = append(stack.Only, svc.Name)
+		}
+	}
+	return stack, nil
+}
+
+// inlineImage returns the image of an inline service: Image, or the official
+// image named after the service at Version (default: latest).
+func inlineImage(svc models.AppServiceConfig) string {
+	if svc.Image != "" {
+		return svc.Image
+	}
+	version := svc.Version
+	if version == "" {
+		version = "latest"
+	}
+	return svc.Name + ":" + version
+}
+
+// InlineCompose returns the generated Compose file: the inline services,
+// the labels of every service and the project's network, and the app network
+// and service aliases when set. It returns nil when there is nothing to add.
+func (s *Stack) InlineCompose() ([]byte, error) {
+	if len(s.inline) == 0 && len(s.Labels) == 0 && s.AppNetwork == "" {
+		return nil, nil
+	}
+	labels := map[string]string{operators.LabelManaged: "true"}
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	type serviceNetwork struct {
+		Aliases []string `yaml:"aliases,omitempty"`
+	}
+	type composeService struct {
+		Image       string                    `yaml:"image,omitempty"`
+		Environment map[string]string         `yaml:"environment,omitempty"`
+		Labels      map[string]string         `yaml:"labels"`
+		Networks    map[string]serviceNetwork `yaml:"networks,omitempty"`
+	}
+	type composeNetwork struct {
+		Name     string            `yaml:"name,omitempty"`
+		External bool              `yaml:"external,omitempty"`
+		Labels   map[string]string `yaml:"labels,omitempty"`
+	}
+	file := struct {
+		Services map[string]composeService `yaml:"services"`
+		Networks map[string]composeNetwork `yaml:"networks"`
+	}{
+		Services: map[string]composeService{},
+		Networks: map[string]composeNetwork{"default": {Labels: labels}},
+	}
+	// The app network belongs to the app, not the project, so dvm labels it
+	// when creating it and Compose leaves it in place on down
+	if s.AppNetwork != "" {
+		file.Networks["default"] = composeNetwork{Name: s.AppNetwork, External: true}
+	}
+	networks := func(name string) map[string]serviceNetwork {
+		if s.AppNetwork == "" || s.AliasDomain == "" {
+			return nil
+		}
+		return map[string]serviceNetwork{"default": {Aliases: []string{name + "." + s.AliasDomain}}}
+	}
+	// Services of the Compose files only get the labels and aliases, which
+	// Compose merges into their definitions
+	for _, svc := range s.Services {
+		file.Services[svc.Name] = composeService{Labels: labels, Networks: networks(svc.Name)}
+	}
+	for _, svc := range s.inline {
+		file.Services[svc.Name] = composeService{
+			Image:       inlineImage(svc),
+			Environment: svc.Env,
+			Labels:      labels,
+			Networks:    networks(svc.Name),
+		}
+	}
+	return yaml.Marshal(file)
+}
+
+// WriteInline writes the generated Compose file (see InlineCompose) into dir
+// and adds it to ComposeFiles. It does nothing when there is none.
+func (s *Stack) WriteInline(dir string) error {
+	data, err := s.InlineCompose()
+	if err != nil || data == nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, GeneratedComposeFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	s.ComposeFiles = append(s.ComposeFiles, path)
+	return nil
+}
+
+// composeFile is the part of a Compose file Plan reads.
+type composeFile struct {
+	Services map[string]struct {
+		Image       string `yaml:"image"`
+		Ports       []any  `yaml:"ports"`
+		Expose      []any  `yaml:"expose"`
+		Environment any    `yaml:"environment"`
+	} `yaml:"services"`
+}
+
+// readComposeServices returns the services defined in a Compose file.
+func readComposeServices(path string) (map[string]Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %w", err)
+	}
+	var file composeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid compose file %s: %w", path, err)
+	}
+	if len(file.Services) == 0 {
+		return nil, fmt.Errorf("compose file %s defines no services", path)
+	}
+
+	services := make(map[string]Service, len(file.Services))
+	for name, def := range file.Services {
+		port := firstPort(def.Expose)
+		if port == 0 {
+			port = firstPort(def.Ports)
+		}
+		if port == 0 {
+			port = defaultPort(def.Image)
+		}
+		services[name] = Service{Name: name, Image: def.Image, Port: port, Env: composeEnv(def.Environment)}
+	}
+	return services, nil
+}
+
+// firstPort returns the container port of the first entry of a Compose
+// ports or expose list: "5432", "5432/tcp", "15432:5432",
+// "127.0.0.1:15432:5432", or the long form {target: 5432}.
+func firstPort(entries []any) int {
+	for _, entry := range entries {
+		var spec string
+		switch v := entry.(type) {
+		case int:
+			return v
+		case string:
+			spec = v
+		case map[string]any:
+			if target, ok := v["target"].(int); ok {
+				return target
+			}
+			continue
+		default:
+			continue
+		}
+		spec, _, _ = strings.Cut(spec, "/")
+		spec = spec[strings.LastIndex(spec, ":")+1:]
+		spec, _, _ = strings.Cut(spec, "-") // port ranges use their first port
+		if port, err := strconv.Atoi(spec); err == nil {
+			return port
+		}
+	}
+	return 0
+}
+
+// composeEnv converts a Compose environment (a map or a KEY=VALUE list) to a
+// map.
+func composeEnv(env any) map[string]string {
+	out := map[string]string{}
+	switch v := env.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if val != nil {
+				out[k] = fmt.Sprint(val)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if k, val, ok := strings.Cut(fmt.Sprint(item), "="); ok {
+				out[k] = val
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+package wstemplate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"devopsmaestro/pkg/source"
+)
+
+const (
+	// SourceBuiltin marks templates shipped with dvm.
+	SourceBuiltin = "builtin"
+	// SourceLocal marks templates added to the local catalog.
+	SourceLocal = "local"
+)
+
+// ErrNotFound is returned when the catalog has no template with a name.
+var ErrNotFound = errors.New("workspace template not found")
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// Catalog is the set of workspace templates available by name: the
+// built-in templates plus the YAML files in Dir. A local template with the
+// same name as a built-in one replaces it.
+type Catalog struct {
+	Dir string
+}
+
+// NewCatalog returns a catalog backed by dir.
+func NewCatalog(dir string) *Catalog {
+	return &Catalog{Dir: dir}
+}
+
+// Builtins returns the templates shipped with dvm, sorted by name.
+func Builtins() ([]*Template, error) {
+	entries, err := fs.Glob(builtinFS, "builtin/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*Template, 0, len(entries))
+	for _, name := range entries {
+		data, err := builtinFS.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		t, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("built-in %s: %w", name, err)
+		}
+		t.Source = SourceBuiltin
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Metadata.Name < out[j].Metadata.Name })
+	return out, nil
+}
+
+// List returns every template in the catalog, sorted by name.
+func (c *Catalog) List() ([]*Template, error) {
+	builtins, err := Builtins()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*Template, len(builtins))
+	for _, t := range builtins {
+		byName[t.Metadata.Name] = t
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.Dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		t, err := c.readLocal(file)
+		if err != nil {
+			return nil, err
+		}
+		byName[t.Metadata.Name] = t
+	}
+
+	out := make([]*Template, 0, len(byName))
+	for _, t := range byName {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Metadata.Name < out[j].Metadata.Name })
+	return out, nil
+}
+
+// Get returns the named template, preferring the local catalog.
+func (c *Catalog) Get(name string) (*Template, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	t, err := c.readLocal(c.path(name))
+	if err == nil {
+		return t, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	builtins, err := Builtins()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range builtins {
+		if t.Metadata.Name == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Load returns the template ref names: a catalog name, or a file path,
+// http(s) URL, or github:user/repo/path reference read directly.
+func (c *Catalog) Load(ref string) (*Template, error) {
+	if ValidName(ref) {
+		return c.Get(ref)
+	}
+	data, display, err := source.Resolve(ref).Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace template %s: %w", ref, err)
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", display, err)
+	}
+	t.Source = ref
+	return t, nil
+}
+
+// Add validates template YAML and stores it in the catalog directory as
+// <name>.yaml. An existing local template is only replaced when force is
+// set.
+func (c *Catalog) Add(data []byte, force bool) (*Template, error) {
+	t, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	path := c.path(t.Metadata.Name)
+	if _, err := os.Stat(path); err == nil && !force {
+		return nil, fmt.Errorf("workspace template %q already exists in %s", t.Metadata.Name, c.Dir)
+	}
+	if err := os.MkdirAll(c.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	t.Source = SourceLocal
+	return t, nil
+}
+
+// Remove deletes a template from the catalog directory. Built-in templates
+// cannot be removed.
+func (c *Catalog) Remove(name string) error {
+	if !ValidName(name) {
+		return fmt.Errorf("invalid template name %q", name)
+	}
+	err := os.Remove(c.path(name))
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	builtins, berr := Builtins()
+	if berr != nil {
+		return berr
+	}
+	for _, t := range builtins {
+		if t.Metadata.Name == name {
+			return fmt.Errorf("workspace template %q is built in and cannot be removed", name)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+func (c *Catalog) path(name string) string {
+	return filepath.Join(c.Dir, name+".yaml")
+}
+
+// readLocal parses a catalog file, which must be named after its template.
+func (c *Catalog) readLocal(file string) (*Template, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	t, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if want := t.Metadata.Name + ".yaml"; filepath.Base(file) != want {
+		return nil, fmt.Errorf("%s: template %q must be stored as %s", file, t.Metadata.Name, want)
+	}
+	t.Source = SourceLocal
+	return t, nil
+}
@@ -0,0 +1,177 @@
+// Package wstemplate implements workspace templates: single YAML files that
+// bundle a workspace setup (nvim and terminal packages, build config,
+// mounts, env) with ${param:name} placeholders. 'dvm create workspace
+// --template' instantiates one onto a new workspace.
+//
+//	apiVersion: devopsmaestro.io/v1
+//	kind: WorkspaceTemplate
+//	metadata:
+//	  name: go-grpc
+//	spec:
+//	  parameters:
+//	    - name: theme
+//	      default: tokyonight-night
+//	  workspace:             # any workspace spec fields
+//	    nvim:
+//	      pluginPackage: maestro-go
+//	      theme: ${param:theme}
+//
+// Parameters and placeholders work exactly as in environment templates
+// (see package envtemplate).
+package wstemplate
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"devopsmaestro/models"
+	"devopsmaestro/pkg/envtemplate"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Kind is the template kind.
+	Kind = "WorkspaceTemplate"
+	// APIVersion is the template API version.
+	APIVersion = envtemplate.APIVersion
+)
+
+// nameRegex restricts template names to values that are safe file names.
+var nameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// Template is a parsed workspace template.
+type Template struct {
+	APIVersion string               `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string               `yaml:"kind" json:"kind"`
+	Metadata   envtemplate.Metadata `yaml:"metadata" json:"metadata"`
+	Spec       Spec                 `yaml:"spec" json:"spec"`
+
+	// Source is where the template was loaded from: SourceBuiltin,
+	// SourceLocal, or the reference it was read from.
+	Source string `yaml:"-" json:"-"`
+	// Data is the raw template YAML.
+	Data []byte `yaml:"-" json:"-"`
+}
+
+// Spec holds the template's parameters and workspace spec.
+type Spec struct {
+	Parameters []envtemplate.Parameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	// Workspace is a workspace spec (spec of kind Workspace) that may
+	// contain placeholders. It stays a YAML node until instantiation so
+	// placeholders can stand in for non-string values.
+	Workspace yaml.Node `yaml:"workspace" json:"-"`
+}
+
+// ValidName reports whether name can be used as a template name.
+func ValidName(name string) bool {
+	return nameRegex.MatchString(name)
+}
+
+// Parse parses and validates workspace template YAML.
+func Parse(data []byte) (*Template, error) {
+	var t Template
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace template: %w", err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	t.Data = data
+	return &t, nil
+}
+
+// Validate checks the template structure and that every placeholder names
+// a declared parameter.
+func (t *Template) Validate() error {
+	if t.Kind != Kind {
+		return fmt.Errorf("expected kind %s, got %q", Kind, t.Kind)
+	}
+	if t.APIVersion != APIVersion {
+		return fmt.Errorf("expected apiVersion %s, got %q", APIVersion, t.APIVersion)
+	}
+	if !ValidName(t.Metadata.Name) {
+		return fmt.Errorf("invalid template name %q (lowercase letters, digits, and dashes)", t.Metadata.Name)
+	}
+	if err := envtemplate.ValidateParameters(t.Spec.Parameters); err != nil {
+		return fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	if t.Spec.Workspace.Kind != yaml.MappingNode {
+		return fmt.Errorf("template %s: spec.workspace must be a mapping", t.Metadata.Name)
+	}
+	placeholders := make(map[string]string, len(t.Spec.Parameters))
+	for _, p := range t.Spec.Parameters {
+		placeholders[p.Name] = ""
+	}
+	if _, err := t.render(placeholders); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Missing returns the required parameters that have neither a value in
+// values nor a default, in declaration order.
+func (t *Template) Missing(values map[string]string) []envtemplate.Parameter {
+	return envtemplate.MissingParameters(t.Spec.Parameters, values)
+}
+
+// Instantiate resolves values against the template's parameters and
+// overlays the rendered workspace spec onto spec. Fields the template sets
+// replace those in spec; env entries are merged. spec is not modified.
+func (t *Template) Instantiate(spec models.WorkspaceSpec, values map[string]string) (models.WorkspaceSpec, error) {
+	resolved, err := envtemplate.ResolveParameters(t.Metadata.Name, t.Spec.Parameters, values)
+	if err != nil {
+		return spec, err
+	}
+	data, err := t.render(resolved)
+	if err != nil {
+		return spec, err
+	}
+
+	out := spec
+	out.Env = make(map[string]string, len(spec.Env))
+	for k, v := range spec.Env {
+		out.Env[k] = v
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&out); err != nil {
+		return spec, fmt.Errorf("template %s: spec.workspace: %w", t.Metadata.Name, err)
+	}
+	if err := out.Build.Hooks.Validate(); err != nil {
+		return spec, fmt.Errorf("template %s: spec.workspace.build.%w", t.Metadata.Name, err)
+	}
+	if err := models.ValidateWorkspaceRuntime(out.Runtime); err != nil {
+		return spec, fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	for i, m := range out.Mounts {
+		if m.Destination == "" {
+			return spec, fmt.Errorf("template %s: spec.workspace.mounts[%d]: destination is required", t.Metadata.Name, i)
+		}
+	}
+	return out, nil
+}
+
+// render returns the workspace spec YAML with values substituted. The
+// template's node is copied first so it can be rendered again.
+func (t *Template) render(values map[string]string) ([]byte, error) {
+	data, err := yaml.Marshal(&t.Spec.Workspace)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	if err := envtemplate.Substitute(&doc, values); err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", t.Metadata.Name, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,224 @@
+package wstemplate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"devopsmaestro/models"
+)
+
+const testTemplate = `apiVersion: devopsmaestro.io/v1
+kind: WorkspaceTemplate
+metadata:
+  name: go-api
+  description: Go API
+spec:
+  parameters:
+    - name: package
+      default: maestro-go
+    - name: autostart
+      default: "true"
+      enum: ["true", "false"]
+    - name: owner
+      required: true
+  workspace:
+    nvim:
+      pluginPackage: ${param:package}
+    terminal:
+      package: developer
+      autostart: ${param:autostart}
+    build:
+      devStage:
+        packages: [protobuf-compiler]
+    mounts:
+      - type: bind
+        source: ~/.aws
+        destination: /home/dev/.aws
+        readOnly: true
+    env:
+      OWNER: ${param:owner}
+`
+
+func mustParse(t *testing.T, data string) *Template {
+	t.Helper()
+	tmpl, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return tmpl
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"kind", strings.Replace(testTemplate, "WorkspaceTemplate", "Workspace", 1), "expected kind WorkspaceTemplate"},
+		{"name", strings.Replace(testTemplate, "name: go-api", "name: Go_API", 1), "invalid template name"},
+		{"undeclared placeholder", strings.Replace(testTemplate, "${param:owner}", "${param:team}", 1), "undeclared parameter(s): team"},
+		{"unknown field", strings.Replace(testTemplate, "  workspace:", "  workspaces: {}\n  workspace:", 1), "field workspaces not found"},
+		{"workspace not a mapping", strings.Split(testTemplate, "  workspace:")[0] + "  workspace: [nvim]\n", "spec.workspace must be a mapping"},
+		{"bad default", strings.Replace(testTemplate, `default: "true"`, `default: "yes"`, 1), `default for parameter "autostart"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplate_Instantiate(t *testing.T) {
+	tmpl := mustParse(t, testTemplate)
+	base := models.WorkspaceSpec{
+		Image: models.ImageConfig{Name: "dvm-api:pending"},
+		Nvim:  models.NvimConfig{Structure: "lazyvim", Theme: "gruvbox"},
+		Env:   map[string]string{"KEEP": "1", "OWNER": "old"},
+	}
+
+	got, err := tmpl.Instantiate(base, map[string]string{"owner": "platform", "autostart": "false"})
+	if err != nil {
+		t.Fatalf("Instantiate() error = %v", err)
+	}
+
+	if got.Nvim.PluginPackage != "maestro-go" || got.Nvim.Structure != "lazyvim" || got.Nvim.Theme != "gruvbox" {
+		t.Errorf("Nvim = %+v, want template package over the base structure and theme", got.Nvim)
+	}
+	if got.Terminal.Package != "developer" || got.Terminal.Autostart {
+		t.Errorf("Terminal = %+v, want package developer with autostart false", got.Terminal)
+	}
+	if got.Image.Name != "dvm-api:pending" {
+		t.Errorf("Image.Name = %q, want the base image kept", got.Image.Name)
+	}
+	if len(got.Build.DevStage.Packages) != 1 || len(got.Mounts) != 1 || !got.Mounts[0].ReadOnly {
+		t.Errorf("Build = %+v, Mounts = %+v", got.Build, got.Mounts)
+	}
+	if got.Env["KEEP"] != "1" || got.Env["OWNER"] != "platform" {
+		t.Errorf("Env = %v, want merged env with the template's OWNER", got.Env)
+	}
+	if base.Env["OWNER"] != "old" {
+		t.Error("Instantiate() modified the base spec's env")
+	}
+}
+
+func TestTemplate_Instantiate_Errors(t *testing.T) {
+	tmpl := mustParse(t, testTemplate)
+
+	if _, err := tmpl.Instantiate(models.WorkspaceSpec{}, nil); err == nil || !strings.Contains(err.Error(), `parameter "owner" is required`) {
+		t.Errorf("Instantiate() error = %v, want required parameter error", err)
+	}
+	if _, err := tmpl.Instantiate(models.WorkspaceSpec{}, map[string]string{"owner": "x", "typo": "y"}); err == nil || !strings.Contains(err.Error(), `unknown parameter "typo"`) {
+		t.Errorf("Instantiate() error = %v, want unknown parameter error", err)
+	}
+	if missing := tmpl.Missing(nil); len(missing) != 1 || missing[0].Name != "owner" {
+		t.Errorf("Missing() = %v, want [owner]", missing)
+	}
+
+	bad := mustParse(t, strings.Replace(testTemplate, "    nvim:", "    nvimm:\n      x: 1\n    nvim:", 1))
+	if _, err := bad.Instantiate(models.WorkspaceSpec{}, map[string]string{"owner": "x"}); err == nil || !strings.Contains(err.Error(), "field nvimm not found") {
+		t.Errorf("Instantiate() error = %v, want unknown workspace field error", err)
+	}
+	hooks := mustParse(t, strings.Replace(testTemplate, "    build:\n", "    build:\n      hooks:\n        preBase: FROM alpine\n", 1))
+	if _, err := hooks.Instantiate(models.WorkspaceSpec{}, map[string]string{"owner": "x"}); err == nil || !strings.Contains(err.Error(), "spec.workspace.build.hooks.preBase") {
+		t.Errorf("Instantiate() error = %v, want invalid hook error", err)
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	builtins, err := Builtins()
+	if err != nil {
+		t.Fatalf("Builtins() error = %v", err)
+	}
+	if len(builtins) == 0 {
+		t.Fatal("Builtins() returned no templates")
+	}
+	for _, tmpl := range builtins {
+		if tmpl.Source != SourceBuiltin {
+			t.Errorf("%s: Source = %q", tmpl.Metadata.Name, tmpl.Source)
+		}
+		if _, err := tmpl.Instantiate(models.WorkspaceSpec{}, nil); err != nil {
+			t.Errorf("%s: Instantiate() with defaults error = %v", tmpl.Metadata.Name, err)
+		}
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	c := NewCatalog(filepath.Join(t.TempDir(), "workspaces"))
+
+	if _, err := c.Get("go-api"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() error = %v, want ErrNotFound", err)
+	}
+	if _, err := c.Add([]byte(testTemplate), false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := c.Add([]byte(testTemplate), false); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("Add() again error = %v, want already exists", err)
+	}
+	if _, err := c.Add([]byte(testTemplate), true); err != nil {
+		t.Fatalf("Add(force) error = %v", err)
+	}
+
+	got, err := c.Load("go-api")
+	if err != nil || got.Source != SourceLocal {
+		t.Fatalf("Load() = %v, %v, want the local template", got, err)
+	}
+	list, err := c.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	builtins, _ := Builtins()
+	if len(list) != len(builtins)+1 {
+		t.Errorf("List() returned %d templates, want %d", len(list), len(builtins)+1)
+	}
+
+	if err := c.Remove("go-grpc"); err == nil || !strings.Contains(err.Error(), "built in") {
+		t.Errorf("Remove(builtin) error = %v", err)
+	}
+	if err := c.Remove("go-api"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if err := c.Remove("go-api"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Remove() again error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCatalog_LocalOverridesBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCatalog(dir)
+	override := strings.Replace(testTemplate, "name: go-api", "name: go-grpc", 1)
+	if _, err := c.Add([]byte(override), false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	got, err := c.Get("go-grpc")
+	if err != nil || got.Source != SourceLocal || got.Metadata.Description != "Go API" {
+		t.Fatalf("Get() = %+v, %v, want the local override", got, err)
+	}
+
+	// A catalog file must be named after its template
+	if err := os.Rename(filepath.Join(dir, "go-grpc.yaml"), filepath.Join(dir, "other.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.List(); err == nil || !strings.Contains(err.Error(), "must be stored as go-grpc.yaml") {
+		t.Errorf("List() error = %v, want file name mismatch", err)
+	}
+}
+
+func TestCatalog_LoadFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "go-api.yaml")
+	if err := os.WriteFile(file, []byte(testTemplate), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewCatalog(t.TempDir()).Load(file)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.Metadata.Name != "go-api" || got.Source != file {
+		t.Errorf("Load() = %s from %s", got.Metadata.Name, got.Source)
+	}
+}